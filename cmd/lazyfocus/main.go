@@ -4,41 +4,28 @@ package main
 import (
 	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/pwojciechowski/lazyfocus/internal/cli"
-	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
 )
 
 func main() {
 	rootCmd := cli.NewRootCommand()
 
-	// Add commands
-	rootCmd.AddCommand(cli.NewTasksCommand())
-	rootCmd.AddCommand(cli.NewProjectsCommand())
-	rootCmd.AddCommand(cli.NewTagsCommand())
-	rootCmd.AddCommand(cli.NewShowCommand())
-	rootCmd.AddCommand(cli.NewPerspectiveCommand())
-	rootCmd.AddCommand(cli.NewVersionCommand())
-	rootCmd.AddCommand(cli.NewCompletionCommand())
+	cli.AddCommands(rootCmd)
 
-	// Write operation commands
-	rootCmd.AddCommand(cli.NewAddCommand())
-	rootCmd.AddCommand(cli.NewCompleteCommand())
-	rootCmd.AddCommand(cli.NewDeleteCommand())
-	rootCmd.AddCommand(cli.NewModifyCommand())
+	// Ctrl+C cancels the command's context, which stops a running OmniFocus
+	// script; a second Ctrl+C exits at once
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
 
-	// TUI command
-	rootCmd.AddCommand(cli.NewTUICommand())
-
-	if err := rootCmd.ExecuteContext(context.Background()); err != nil {
-		// Determine exit code based on error type
-		exitCode := output.ExitGeneralError
-
-		// Check for specific error types
-		if itemNotFoundErr, ok := err.(*cli.ItemNotFoundError); ok {
-			exitCode = itemNotFoundErr.ExitCode()
-		}
-
-		os.Exit(exitCode)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		os.Exit(cli.ReportError(rootCmd, err))
 	}
 }
@@ -0,0 +1,287 @@
+// Package api serves a small JSON HTTP API over the OmniFocus service for
+// `lazyfocus serve --listen`. Every request needs a bearer token, and each
+// token only gets the calls its scope permits.
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// maxBodyBytes limits the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// Token grants a scope to whoever presents its secret
+type Token struct {
+	Name   string
+	Secret string
+	Scope  service.Scope
+}
+
+// Server routes API requests to the service, scoped by the caller's token
+type Server struct {
+	svc    service.OmniFocusService
+	tokens []Token
+	mux    *http.ServeMux
+}
+
+// handlerFunc handles a request with the service scoped to the caller's token
+type handlerFunc func(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error
+
+// NewServer creates an API server backed by svc
+func NewServer(svc service.OmniFocusService, tokens []Token) *Server {
+	s := &Server{svc: svc, tokens: tokens, mux: http.NewServeMux()}
+
+	s.handle("GET /v1/inbox", handleInbox)
+	s.handle("GET /v1/tasks", handleTasks)
+	s.handle("POST /v1/tasks", handleCreateTask)
+	s.handle("GET /v1/tasks/{id}", handleTask)
+	s.handle("PATCH /v1/tasks/{id}", handleModifyTask)
+	s.handle("DELETE /v1/tasks/{id}", handleDeleteTask)
+	s.handle("POST /v1/tasks/{id}/complete", handleCompleteTask)
+	s.handle("GET /v1/projects", handleProjects)
+	s.handle("POST /v1/projects", handleCreateProject)
+	s.handle("GET /v1/tags", handleTags)
+	s.handle("POST /v1/tags", handleCreateTag)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle registers a route that requires a valid token
+func (s *Server) handle(pattern string, h handlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		token, ok := s.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="lazyfocus"`)
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+			return
+		}
+		if err := h(w, r, service.NewScopedOmniFocusService(s.svc, token.Scope)); err != nil {
+			writeError(w, statusFor(err), err)
+		}
+	})
+}
+
+// authenticate returns the token presented in the Authorization header
+func (s *Server) authenticate(r *http.Request) (Token, bool) {
+	secret, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || secret == "" {
+		return Token{}, false
+	}
+	for _, token := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(token.Secret)) == 1 {
+			return token, true
+		}
+	}
+	return Token{}, false
+}
+
+// badRequestError marks errors caused by the request itself
+type badRequestError struct {
+	err error
+}
+
+func (e *badRequestError) Error() string { return e.err.Error() }
+func (e *badRequestError) Unwrap() error { return e.err }
+
+// notFoundError is returned when the requested item does not exist
+type notFoundError struct {
+	what string
+	id   string
+}
+
+func (e *notFoundError) Error() string { return fmt.Sprintf("%s not found: %s", e.what, e.id) }
+
+// statusFor returns the HTTP status for an error returned by a handler
+func statusFor(err error) int {
+	var permErr *service.PermissionError
+	var badRequest *badRequestError
+	var notFound *notFoundError
+	switch {
+	case errors.As(err, &permErr):
+		return http.StatusForbidden
+	case errors.As(err, &badRequest):
+		return http.StatusBadRequest
+	case errors.As(err, &notFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeJSON writes v as the JSON response body
+func writeJSON(w http.ResponseWriter, status int, v any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response
+func writeError(w http.ResponseWriter, status int, err error) {
+	_ = writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// decode reads a JSON request body into v
+func decode(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return &badRequestError{fmt.Errorf("invalid request body: %w", err)}
+	}
+	return nil
+}
+
+func handleInbox(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	tasks, err := svc.GetInboxTasks(r.Context())
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, tasks)
+}
+
+func handleTasks(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	query := r.URL.Query()
+	filters := service.TaskFilters{
+		ProjectID: query.Get("project"),
+		TagID:     query.Get("tag"),
+		Flagged:   query.Get("flagged") == "true",
+	}
+	tasks, err := svc.GetAllTasks(r.Context(), filters)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, tasks)
+}
+
+func handleTask(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	id := r.PathValue("id")
+	task, err := svc.GetTaskByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return &notFoundError{what: "task", id: id}
+	}
+	return writeJSON(w, http.StatusOK, task)
+}
+
+func handleCreateTask(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	var input domain.TaskInput
+	if err := decode(r, &input); err != nil {
+		return err
+	}
+	if err := input.Validate(); err != nil {
+		return &badRequestError{err}
+	}
+	if input.ProjectID == "" && input.ProjectName != "" {
+		id, err := svc.ResolveProjectName(r.Context(), input.ProjectName)
+		if err != nil {
+			return &badRequestError{err}
+		}
+		input.ProjectID = id
+	}
+	task, err := svc.CreateTask(r.Context(), input)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusCreated, task)
+}
+
+func handleModifyTask(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	var mod domain.TaskModification
+	if err := decode(r, &mod); err != nil {
+		return err
+	}
+	if mod.IsEmpty() {
+		return &badRequestError{errors.New("no modifications given")}
+	}
+	task, err := svc.ModifyTask(r.Context(), r.PathValue("id"), mod)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, task)
+}
+
+func handleCompleteTask(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	result, err := svc.CompleteTask(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, result)
+}
+
+func handleDeleteTask(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	result, err := svc.DeleteTask(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, result)
+}
+
+func handleProjects(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = "active"
+	}
+	projects, err := svc.GetProjects(r.Context(), status)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, projects)
+}
+
+func handleCreateProject(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	var input domain.ProjectInput
+	if err := decode(r, &input); err != nil {
+		return err
+	}
+	if err := input.Validate(); err != nil {
+		return &badRequestError{err}
+	}
+	project, err := svc.CreateProject(r.Context(), input)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusCreated, project)
+}
+
+func handleTags(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	tags, err := svc.GetTags(r.Context())
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, tags)
+}
+
+// tagInput is the body of POST /v1/tags
+type tagInput struct {
+	Name     string `json:"name"`
+	ParentID string `json:"parentId"`
+}
+
+func handleCreateTag(w http.ResponseWriter, r *http.Request, svc service.OmniFocusService) error {
+	var input tagInput
+	if err := decode(r, &input); err != nil {
+		return err
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		return &badRequestError{errors.New("tag name is required")}
+	}
+	tag, err := svc.CreateTag(r.Context(), input.Name, input.ParentID)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusCreated, tag)
+}
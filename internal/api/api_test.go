@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+var testTokens = []Token{
+	{Name: "widget", Secret: "read-secret", Scope: service.ScopeReadOnly},
+	{Name: "capture", Secret: "create-secret", Scope: service.ScopeCreateOnly},
+	{Name: "admin", Secret: "full-secret", Scope: service.ScopeFull},
+}
+
+func newTestServer() (*Server, *service.MockOmniFocusService) {
+	mock := &service.MockOmniFocusService{
+		InboxTasks:   []domain.Task{{ID: "task1", Name: "Buy milk"}},
+		Task:         &domain.Task{ID: "task1", Name: "Buy milk"},
+		CreatedTask:  &domain.Task{ID: "task2", Name: "Call bank"},
+		DeleteResult: &domain.OperationResult{Success: true, ID: "task1"},
+	}
+	return NewServer(mock, testTokens), mock
+}
+
+func do(s *Server, method, path, secret, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if secret != "" {
+		req.Header.Set("Authorization", "Bearer "+secret)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	s, _ := newTestServer()
+
+	for _, secret := range []string{"", "wrong"} {
+		rec := do(s, http.MethodGet, "/v1/inbox", secret, "")
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("GET /v1/inbox with %q = %d, want %d", secret, rec.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestServer_Scopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   map[string]int // Status by token secret
+	}{
+		{
+			name: "list inbox", method: http.MethodGet, path: "/v1/inbox",
+			want: map[string]int{"read-secret": http.StatusOK, "create-secret": http.StatusForbidden, "full-secret": http.StatusOK},
+		},
+		{
+			name: "create task", method: http.MethodPost, path: "/v1/tasks", body: `{"name": "Call bank"}`,
+			want: map[string]int{"read-secret": http.StatusForbidden, "create-secret": http.StatusCreated, "full-secret": http.StatusCreated},
+		},
+		{
+			name: "delete task", method: http.MethodDelete, path: "/v1/tasks/task1",
+			want: map[string]int{"read-secret": http.StatusForbidden, "create-secret": http.StatusForbidden, "full-secret": http.StatusOK},
+		},
+	}
+
+	for _, tt := range tests {
+		for secret, want := range tt.want {
+			t.Run(tt.name+"/"+secret, func(t *testing.T) {
+				s, _ := newTestServer()
+				rec := do(s, tt.method, tt.path, secret, tt.body)
+				if rec.Code != want {
+					t.Errorf("%s %s = %d, want %d (body %s)", tt.method, tt.path, rec.Code, want, rec.Body.String())
+				}
+			})
+		}
+	}
+}
+
+func TestServer_DeniedWriteDoesNotReachService(t *testing.T) {
+	s, mock := newTestServer()
+
+	rec := do(s, http.MethodPost, "/v1/tasks", "read-secret", `{"name": "Call bank"}`)
+
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if !strings.Contains(body["error"], "permission denied") {
+		t.Errorf("error = %q, want permission denied", body["error"])
+	}
+	if mock.CreateInput != nil {
+		t.Errorf("CreateTask() reached the service with %+v", mock.CreateInput)
+	}
+}
+
+func TestServer_CreateTask(t *testing.T) {
+	s, mock := newTestServer()
+
+	rec := do(s, http.MethodPost, "/v1/tasks", "create-secret", `{"name": "Call bank", "tagNames": ["phone"]}`)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /v1/tasks = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if mock.CreateInput == nil || mock.CreateInput.Name != "Call bank" || len(mock.CreateInput.TagNames) != 1 {
+		t.Errorf("CreateTask() input = %+v, want Call bank tagged phone", mock.CreateInput)
+	}
+	var task domain.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &task); err != nil || task.ID != "task2" {
+		t.Errorf("response = %s, want created task2", rec.Body.String())
+	}
+}
+
+func TestServer_BadRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "missing name", body: `{}`},
+		{name: "unknown field", body: `{"name": "x", "priority": 1}`},
+		{name: "not json", body: `name=x`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestServer()
+			rec := do(s, http.MethodPost, "/v1/tasks", "full-secret", tt.body)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("POST /v1/tasks = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestServer_TaskNotFound(t *testing.T) {
+	s, mock := newTestServer()
+	mock.Task = nil
+
+	rec := do(s, http.MethodGet, "/v1/tasks/missing", "read-secret", "")
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET /v1/tasks/missing = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
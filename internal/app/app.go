@@ -2,28 +2,43 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/health"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
-	"github.com/pwojciechowski/lazyfocus/internal/tui/components/commandinput"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/confirm"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/datepicker"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/filterpicker"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/nextpanel"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/palette"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/projectpicker"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/quickadd"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/searchinput"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/searchresults"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/statusbar"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/taskdetail"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/taskedit"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/overlay"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/views/forecast"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/views/inbox"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/views/projects"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/views/review"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/views/stats"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/views/tags"
 )
 
@@ -31,6 +46,7 @@ import (
 type DeleteContext struct {
 	TaskID   string
 	TaskName string
+	Task     *domain.Task // Snapshot used to recreate the task on undo
 }
 
 // Model represents the main TUI application state
@@ -41,20 +57,29 @@ type Model struct {
 	tagsView     tags.Model
 	forecastView forecast.Model
 	reviewView   review.Model
+	statsView    stats.Model
 	currentView  int // tui.ViewInbox, tui.ViewProjects, etc from messages.go
 
 	// Overlays
-	quickAdd     quickadd.Model
-	taskDetail   taskdetail.Model
-	taskEdit     taskedit.Model
-	confirmModal confirm.Model
-	searchInput  searchinput.Model
-	commandInput commandinput.Model
-	showHelp     bool
-	compositor   *overlay.Compositor
+	quickAdd      quickadd.Model
+	taskDetail    taskdetail.Model
+	taskEdit      taskedit.Model
+	confirmModal  confirm.Model
+	searchInput   searchinput.Model
+	palette       palette.Model
+	filterPicker  filterpicker.Model
+	projectPicker projectpicker.Model
+	datePicker    datepicker.Model
+	nextPanel     nextpanel.Model
+	searchResults searchresults.Model
+	toasts        toast.Model
+	statusBar     statusbar.Model
+	showHelp      bool
+	compositor    *overlay.Compositor
 
 	// State
 	filterState filter.State
+	undoStack   []undoEntry
 	service     service.OmniFocusService
 	styles      *tui.Styles
 	keys        tui.KeyMap
@@ -62,6 +87,33 @@ type Model struct {
 	height      int
 	err         error
 	ready       bool // true after first WindowSizeMsg
+
+	savedFilters string                 // Path of the saved filters file applied by :filter
+	debugLog     string                 // Path of the debug log toggled by :debug
+	pinned       map[string]bool        // Task IDs pinned to the top of their view
+	sorts        map[int]tui.SortMode   // Sort mode of each view not in added order
+	local        map[string]localChange // Changes shown before OmniFocus has them, by task ID
+	conflicts    map[string]conflict    // Tasks whose local change OmniFocus did not take
+	device       string                 // Device ID of this machine, named in conflicts
+
+	showCompleted bool // List recently completed tasks in the Inbox, Projects and Tags views
+
+	permissionCheck   func() error // Run after the first failed script; nil skips it
+	permissionChecked bool
+	healthCheck       func() health.Snapshot // Run at startup; nil skips it
+	banner            string                 // What does not work, shown above the status bar until dismissed
+	macros            macroState
+	goPending         bool                   // goKey was pressed; the next key completes the sequence
+	chord             pendingChord           // Dangerous action waiting for the second press of its key
+	confirmPolicy     ConfirmPolicy          // How dangerous actions are confirmed
+	pickingForEdit    bool                   // The project picker fills the task edit overlay instead of moving tasks
+	datingForEdit     bool                   // The date picker fills the task edit overlay instead of rescheduling tasks
+	backgroundWrites  []service.PendingWrite // Writes handed to a background flush on quit
+	stepProgress      string                 // Step of the running multi-step operation, e.g. "2/4: tagging…"
+	titleEnabled      bool                   // Keep the terminal title showing the current view
+	showPath          bool                   // Task rows and details show the folders and project containing each task
+	nextOptions       next.Options           // Scoring of the suggestions shown by :next
+	title             string                 // Terminal title last set
 }
 
 // NewApp creates a new TUI application instance
@@ -76,17 +128,25 @@ func NewApp(svc service.OmniFocusService) Model {
 		tagsView:     tags.New(styles, keys, svc),
 		forecastView: forecast.New(styles, keys, svc),
 		reviewView:   review.New(styles, keys, svc),
+		statsView:    stats.New(styles, keys, svc),
 		currentView:  tui.ViewInbox,
 
 		// Overlays
-		quickAdd:     quickadd.New(styles, svc),
-		taskDetail:   taskdetail.New(styles, keys),
-		taskEdit:     taskedit.New(styles),
-		confirmModal: confirm.New(styles),
-		searchInput:  searchinput.New(styles),
-		commandInput: commandinput.New(styles),
-		showHelp:     false,
-		compositor:   overlay.New(styles.UI.OverlayBackdrop),
+		quickAdd:      quickadd.New(styles, svc),
+		taskDetail:    taskdetail.New(styles, keys),
+		taskEdit:      taskedit.New(styles),
+		confirmModal:  confirm.New(styles),
+		searchInput:   searchinput.New(styles),
+		palette:       palette.New(styles),
+		filterPicker:  filterpicker.New(styles),
+		projectPicker: projectpicker.New(styles),
+		datePicker:    datepicker.New(styles),
+		nextPanel:     nextpanel.New(styles),
+		searchResults: searchresults.New(styles),
+		toasts:        toast.New(styles),
+		statusBar:     statusbar.New(styles, tabLabels()),
+		showHelp:      false,
+		compositor:    overlay.New(styles.UI.OverlayBackdrop),
 
 		// State
 		filterState: filter.State{},
@@ -94,17 +154,52 @@ func NewApp(svc service.OmniFocusService) Model {
 		styles:      styles,
 		keys:        keys,
 		ready:       false,
+
+		savedFilters: config.SavedFiltersPath(),
+		debugLog:     config.DebugLogPath(),
+		nextOptions:  next.Options{Weights: next.DefaultWeights},
+		confirmPolicy: ConfirmPolicy{
+			Delete:       ConfirmModal,
+			Complete:     ConfirmModal,
+			ChordTimeout: DefaultChordTimeout,
+		},
 	}
 }
 
+// SetForecastLabels sets the Forecast's group names, group order and task
+// count text, as configured under tui.forecast
+func (m Model) SetForecastLabels(labels forecast.Labels) Model {
+	m.forecastView = m.forecastView.SetLabels(labels)
+	return m
+}
+
+// LimitWarningMsg reports a change that took a tag or project past its WIP
+// limit. It is sent by the service when the change is made, outside the
+// command that made it.
+type LimitWarningMsg struct {
+	Usage limits.Usage
+}
+
+// SetLimits sets the WIP limits shown next to tag and project task counts
+func (m Model) SetLimits(limitList []limits.Limit) Model {
+	m.tagsView = m.tagsView.SetLimits(limitList)
+	m.projectsView = m.projectsView.SetLimits(limitList)
+	return m
+}
+
 // Init initializes the application
 func (m Model) Init() tea.Cmd {
-	return m.initCurrentView()
+	return tea.Batch(m.initCurrentView(), startPrefetch(), m.runHealthCheck())
 }
 
 // initCurrentView initializes the current view
 func (m Model) initCurrentView() tea.Cmd {
-	switch m.currentView {
+	return m.initView(m.currentView)
+}
+
+// initView initializes a view, usually by loading its data
+func (m Model) initView(view int) tea.Cmd {
+	switch view {
 	case tui.ViewInbox:
 		return m.inboxView.Init()
 	case tui.ViewProjects:
@@ -115,17 +210,43 @@ func (m Model) initCurrentView() tea.Cmd {
 		return m.forecastView.Init()
 	case tui.ViewReview:
 		return m.reviewView.Init()
+	case tui.ViewStats:
+		return m.statsView.Init()
 	default:
 		return nil
 	}
 }
 
-// Update handles messages and updates the application state
+// Update handles messages and updates the application state, then the
+// terminal title
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.update(msg)
+	return updated.(Model).syncWindowTitle(cmd)
+}
+
+// update handles messages and updates the application state
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Record and replay macros before keys reach any other handler
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		var cmd tea.Cmd
+		var handled bool
+		if m, cmd, handled = m.handleMacroKey(keyMsg); handled {
+			return m, cmd
+		}
+	}
+
+	// Send keys to an inline tag name input so typed letters are not taken as shortcuts
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() != "ctrl+c" &&
+		m.currentView == tui.ViewTags && m.tagsView.Editing() {
+		var cmd tea.Cmd
+		m.tagsView, cmd = m.tagsView.Update(keyMsg)
+		return m, cmd
+	}
+
 	// Handle quit immediately
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		if key.Matches(keyMsg, m.keys.Quit) {
-			return m, tea.Quit
+			return m.quit()
 		}
 	}
 
@@ -134,20 +255,77 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleWindowResize(msg)
 	}
 
+	// Note loaded data for the status bar; the views still handle the message
+	m = m.noteLoaded(msg)
+
+	// Load the other views in the background and hand them their data
+	if _, ok := msg.(prefetchMsg); ok {
+		return m.prefetch()
+	}
+	if msg, ok := msg.(viewLoadedMsg); ok {
+		return m.handleViewLoaded(msg)
+	}
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		var cmd tea.Cmd
+		m.statusBar, cmd = m.statusBar.Update(msg)
+		return m, cmd
+	}
+
+	// Handle mouse clicks and scrolling
+	if msg, ok := msg.(tea.MouseMsg); ok {
+		return m.handleMouse(msg)
+	}
+
 	// Handle TaskCreatedMsg - hide quick add and refresh view
 	// Must come before quick add delegation since quick add emits this message
 	if msg, ok := msg.(tui.TaskCreatedMsg); ok {
-		_ = msg // Task created successfully
 		m.quickAdd = m.quickAdd.Hide()
+		var toastCmd tea.Cmd
+		m, toastCmd = m.pushToast(toast.Success, taskToastText("Added", msg.Task.Name))
 		// Refresh the current view
-		return m, m.inboxView.Refresh()
+		return m, tea.Batch(m.inboxView.Refresh(), toastCmd)
+	}
+
+	// Describe what does not work after the startup health check
+	if msg, ok := msg.(healthCheckedMsg); ok {
+		return m.handleHealthChecked(msg)
 	}
 
 	// Handle ErrorMsg
 	if msg, ok := msg.(tui.ErrorMsg); ok {
 		m.err = msg.Err
+		var checkCmd, toastCmd tea.Cmd
+		m, checkCmd = m.checkPermissionOnce()
+		m, toastCmd = m.pushToast(toast.Error, msg.Err.Error())
+		return m, tea.Batch(toastCmd, checkCmd)
+	}
+
+	// Show the steps of multi-step operations as they run
+	if msg, ok := msg.(stepProgressMsg); ok {
+		return m.handleStepProgress(msg)
+	}
+	if msg, ok := msg.(stepsDoneMsg); ok {
+		return m.handleStepsDone(msg)
+	}
+
+	// Guide the user when the first failure came from a missing permission
+	if msg, ok := msg.(permissionCheckedMsg); ok {
+		return m.handlePermissionChecked(msg)
+	}
+
+	// Dismiss expired toasts regardless of which overlay is open
+	if msg, ok := msg.(toast.DismissMsg); ok {
+		m.toasts, _ = m.toasts.Update(msg)
 		return m, nil
 	}
+	if msg, ok := msg.(chordExpiredMsg); ok {
+		return m.handleChordExpired(msg), nil
+	}
+
+	// Warn about changes past a WIP limit, whichever overlay made them
+	if msg, ok := msg.(LimitWarningMsg); ok {
+		return m.pushToast(toast.Warning, "Over WIP limit: "+msg.Usage.String())
+	}
 
 	// Handle task detail action messages before overlay delegation
 	// These are emitted by taskdetail component and must be handled at app level
@@ -160,6 +338,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return newModel, cmd
 	}
 
+	// Picker messages may be meant for the task edit overlay below them
+	if newModel, cmd, handled := m.handleProjectPickerMessages(msg); handled {
+		return newModel, cmd
+	}
+	if newModel, cmd, handled := m.handleDatePickerMessages(msg); handled {
+		return newModel, cmd
+	}
+
 	// Handle overlays in priority order (highest to lowest)
 	if newModel, cmd, handled := m.handleOverlays(msg); handled {
 		return newModel, cmd
@@ -175,6 +361,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKeyMsg(keyMsg)
 	}
 
+	// Check loaded tasks against changes shown before OmniFocus had them
+	if loaded, ok := msg.(tui.TasksLoadedMsg); ok {
+		newModel, cmd := m.delegateToCurrentView(msg)
+		return newModel.(Model).reconcileLoaded(loaded.Tasks), cmd
+	}
+
 	// Delegate to current view
 	return m.delegateToCurrentView(msg)
 }
@@ -194,9 +386,17 @@ func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.taskEdit = m.taskEdit.SetSize(msg.Width, msg.Height)
 	m.confirmModal = m.confirmModal.SetSize(msg.Width, msg.Height)
 	m.searchInput = m.searchInput.SetWidth(msg.Width)
-	m.commandInput = m.commandInput.SetWidth(msg.Width)
-
-	// Pass resize to all views
+	m.palette = m.palette.SetSize(msg.Width, msg.Height)
+	m.filterPicker = m.filterPicker.SetSize(msg.Width, msg.Height)
+	m.projectPicker = m.projectPicker.SetSize(msg.Width, msg.Height)
+	m.datePicker = m.datePicker.SetSize(msg.Width, msg.Height)
+	m.nextPanel = m.nextPanel.SetSize(msg.Width, msg.Height)
+	m.searchResults = m.searchResults.SetSize(msg.Width, msg.Height)
+	m.toasts = m.toasts.SetWidth(msg.Width)
+	m.statusBar = m.statusBar.SetWidth(msg.Width)
+
+	// Pass resize to all views, which render above the status bar
+	msg.Height = max(msg.Height-m.bottomHeight(), 0)
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 	m.inboxView, cmd = m.inboxView.Update(msg)
@@ -209,9 +409,29 @@ func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	cmds = append(cmds, cmd)
 	m.reviewView, cmd = m.reviewView.Update(msg)
 	cmds = append(cmds, cmd)
+	m.statsView, cmd = m.statsView.Update(msg)
+	cmds = append(cmds, cmd)
 	return m, tea.Batch(cmds...)
 }
 
+// overlayOpen reports whether an overlay or text input is open, so keys go to
+// it rather than to macros and mouse events are ignored. It lists every
+// overlay handleOverlays delegates to.
+func (m Model) overlayOpen() bool {
+	return m.confirmModal.IsVisible() ||
+		m.projectPicker.IsVisible() ||
+		m.datePicker.IsVisible() ||
+		m.taskEdit.IsVisible() ||
+		m.taskDetail.IsVisible() ||
+		m.quickAdd.IsVisible() ||
+		m.searchInput.IsVisible() ||
+		m.palette.IsVisible() ||
+		m.filterPicker.IsVisible() ||
+		m.nextPanel.IsVisible() ||
+		m.searchResults.IsVisible() ||
+		(m.currentView == tui.ViewTags && m.tagsView.Editing())
+}
+
 // handleOverlays delegates messages to visible overlays
 // Returns the updated model, command, and true if an overlay handled the message
 func (m Model) handleOverlays(msg tea.Msg) (Model, tea.Cmd, bool) {
@@ -222,41 +442,82 @@ func (m Model) handleOverlays(msg tea.Msg) (Model, tea.Cmd, bool) {
 		return m, cmd, true
 	}
 
-	// 2. Task edit overlay
+	// 2. Project picker, which opens over task edit
+	if m.projectPicker.IsVisible() {
+		var cmd tea.Cmd
+		m.projectPicker, cmd = m.projectPicker.Update(msg)
+		return m, cmd, true
+	}
+
+	// 3. Date picker, which opens over task edit
+	if m.datePicker.IsVisible() {
+		var cmd tea.Cmd
+		m.datePicker, cmd = m.datePicker.Update(msg)
+		return m, cmd, true
+	}
+
+	// 4. Task edit overlay
 	if m.taskEdit.IsVisible() {
 		var cmd tea.Cmd
 		m.taskEdit, cmd = m.taskEdit.Update(msg)
 		return m, cmd, true
 	}
 
-	// 3. Task detail overlay
+	// 5. Task detail overlay
 	if m.taskDetail.IsVisible() {
 		var cmd tea.Cmd
 		m.taskDetail, cmd = m.taskDetail.Update(msg)
 		return m, cmd, true
 	}
 
-	// 4. Quick add overlay
+	// 6. Quick add overlay
 	if m.quickAdd.IsVisible() {
 		var cmd tea.Cmd
 		m.quickAdd, cmd = m.quickAdd.Update(msg)
 		return m, cmd, true
 	}
 
-	// 5. Search input
+	// 7. Search input
 	if m.searchInput.IsVisible() {
 		var cmd tea.Cmd
 		m.searchInput, cmd = m.searchInput.Update(msg)
 		return m, cmd, true
 	}
 
-	// 6. Command input
-	if m.commandInput.IsVisible() {
+	// 8. Command palette
+	if m.palette.IsVisible() {
 		var cmd tea.Cmd
-		m.commandInput, cmd = m.commandInput.Update(msg)
+		m.palette, cmd = m.palette.Update(msg)
 		return m, cmd, true
 	}
 
+	// 9. Saved filter picker
+	if m.filterPicker.IsVisible() {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.filterPicker, cmd = m.filterPicker.Update(msg)
+			return m, cmd, true
+		}
+	}
+
+	// 10. Next panel
+	if m.nextPanel.IsVisible() {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.nextPanel, cmd = m.nextPanel.Update(msg)
+			return m, cmd, true
+		}
+	}
+
+	// 11. Search results
+	if m.searchResults.IsVisible() {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.searchResults, cmd = m.searchResults.Update(msg)
+			return m, cmd, true
+		}
+	}
+
 	return m, nil, false
 }
 
@@ -268,8 +529,8 @@ func (m Model) handleCustomMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 		return newModel, cmd, true
 	}
 
-	// Handle command input messages
-	if newModel, cmd, handled := m.handleCommandInputMessages(msg); handled {
+	// Handle command palette messages
+	if newModel, cmd, handled := m.handlePaletteMessages(msg); handled {
 		return newModel, cmd, true
 	}
 
@@ -278,6 +539,21 @@ func (m Model) handleCustomMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 		return newModel, cmd, true
 	}
 
+	// Handle saved filter picker messages
+	if newModel, cmd, handled := m.handleFilterPickerMessages(msg); handled {
+		return newModel, cmd, true
+	}
+
+	// Handle next panel messages
+	if newModel, cmd, handled := m.handleNextMessages(msg); handled {
+		return newModel, cmd, true
+	}
+
+	// Handle search results messages
+	if newModel, cmd, handled := m.handleSearchResultsMessages(msg); handled {
+		return newModel, cmd, true
+	}
+
 	// Handle task operation messages
 	if newModel, cmd, handled := m.handleTaskOperationMessages(msg); handled {
 		return newModel, cmd, true
@@ -300,13 +576,19 @@ func (m Model) handleTaskDetailMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 	}
 
 	if completeMsg, ok := msg.(taskdetail.CompleteRequestedMsg); ok {
+		taskName := ""
+		if task := m.taskDetail.Task(); task != nil {
+			taskName = task.Name
+		}
 		m.taskDetail = m.taskDetail.Hide()
-		return m, m.completeTask(completeMsg.TaskID), true
+		newModel, cmd := m.completeTask(completeMsg.TaskID, taskName)
+		return newModel, cmd, true
 	}
 
 	if deleteMsg, ok := msg.(taskdetail.DeleteRequestedMsg); ok {
+		task := m.taskDetail.Task()
 		m.taskDetail = m.taskDetail.Hide()
-		ctx := DeleteContext{TaskID: deleteMsg.TaskID, TaskName: deleteMsg.TaskName}
+		ctx := DeleteContext{TaskID: deleteMsg.TaskID, TaskName: deleteMsg.TaskName, Task: task}
 		m.confirmModal = m.confirmModal.ShowWithContext(
 			"Delete Task",
 			fmt.Sprintf("Delete \"%s\"?", deleteMsg.TaskName),
@@ -315,11 +597,17 @@ func (m Model) handleTaskDetailMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 		return m, nil, true
 	}
 
+	// Links open outside the TUI, so the detail view stays open
+	if openMsg, ok := msg.(taskdetail.OpenURLRequestedMsg); ok {
+		return m, openURL(openMsg.URL), true
+	}
+
 	if _, ok := msg.(taskdetail.FlagRequestedMsg); ok {
 		task := m.taskDetail.Task()
 		m.taskDetail = m.taskDetail.Hide()
 		if task != nil {
-			return m, m.toggleTaskFlag(task), true
+			newModel, cmd := m.toggleTaskFlag(task)
+			return newModel, cmd, true
 		}
 		return m, nil, true
 	}
@@ -330,8 +618,9 @@ func (m Model) handleTaskDetailMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 // handleTaskEditMessages handles task edit related messages
 func (m Model) handleTaskEditMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 	if saveMsg, ok := msg.(taskedit.SaveMsg); ok {
+		previous := m.taskEdit.Task()
 		m.taskEdit = m.taskEdit.Hide()
-		return m, m.modifyTask(saveMsg.TaskID, saveMsg.Modification), true
+		return m, m.modifyTask(saveMsg.TaskID, saveMsg.Modification, previous), true
 	}
 
 	if _, ok := msg.(taskedit.CancelMsg); ok {
@@ -339,13 +628,22 @@ func (m Model) handleTaskEditMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 		return m, nil, true
 	}
 
+	if pickMsg, ok := msg.(taskedit.PickProjectMsg); ok {
+		newModel, cmd := m.showProjectPicker(true, pickMsg.ProjectID)
+		return newModel, cmd, true
+	}
+
+	if pickMsg, ok := msg.(taskedit.PickDateMsg); ok {
+		return m.showDatePickerForEdit(pickMsg), nil, true
+	}
+
 	return m, nil, false
 }
 
 // handleSearchInputMessages handles search input related messages
 func (m Model) handleSearchInputMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 	if searchMsg, ok := msg.(searchinput.SearchChangedMsg); ok {
-		m.filterState = m.filterState.WithSearchText(searchMsg.Text)
+		m.filterState = m.filterState.WithSearchText(searchMsg.Text).WithNamesOnly(searchMsg.NamesOnly)
 		m = m.applyFilterToCurrentView()
 		return m, nil, true
 	}
@@ -357,7 +655,7 @@ func (m Model) handleSearchInputMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 	}
 
 	if searchMsg, ok := msg.(searchinput.SearchConfirmedMsg); ok {
-		m.filterState = m.filterState.WithSearchText(searchMsg.Text)
+		m.filterState = m.filterState.WithSearchText(searchMsg.Text).WithNamesOnly(searchMsg.NamesOnly)
 		m = m.applyFilterToCurrentView()
 		return m, nil, true
 	}
@@ -365,18 +663,23 @@ func (m Model) handleSearchInputMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 	return m, nil, false
 }
 
-// handleCommandInputMessages handles command input related messages
-func (m Model) handleCommandInputMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
-	if cmdMsg, ok := msg.(commandinput.CommandExecutedMsg); ok {
+// handlePaletteMessages handles command palette related messages
+func (m Model) handlePaletteMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
+	if cmdMsg, ok := msg.(palette.ExecutedMsg); ok {
 		newModel, cmd := m.executeCommand(cmdMsg.Command)
 		return newModel, cmd, true
 	}
 
-	if _, ok := msg.(commandinput.CommandCancelledMsg); ok {
+	if _, ok := msg.(palette.CancelledMsg); ok {
 		return m, nil, true
 	}
 
-	if errMsg, ok := msg.(commandinput.CommandErrorMsg); ok {
+	// Projects and tags loaded after the palette was closed
+	if _, ok := msg.(palette.ItemsLoadedMsg); ok {
+		return m, nil, true
+	}
+
+	if errMsg, ok := msg.(palette.ErrorMsg); ok {
 		m.err = fmt.Errorf("%s", errMsg.Error)
 		return m, nil, true
 	}
@@ -384,11 +687,42 @@ func (m Model) handleCommandInputMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 	return m, nil, false
 }
 
+// loadPaletteItems loads the projects and tags the command palette offers
+func (m Model) loadPaletteItems() tea.Cmd {
+	svc := m.service
+	return func() tea.Msg {
+		// The palette still offers commands if either list fails to load
+		projects, _ := svc.GetProjects(context.Background(), "")
+		tags, _ := svc.GetTags(context.Background())
+		return palette.ItemsLoadedMsg{Projects: projects, Tags: tags}
+	}
+}
+
 // handleConfirmMessages handles confirmation modal messages
 func (m Model) handleConfirmMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 	if msg, ok := msg.(confirm.ConfirmedMsg); ok {
 		if ctx, ok := msg.Context.(DeleteContext); ok {
-			return m, m.deleteTask(ctx.TaskID), true
+			return m, m.deleteTask(ctx), true
+		}
+		if ctx, ok := msg.Context.(BatchContext); ok {
+			return m, m.batchModify(ctx), true
+		}
+		if ctx, ok := msg.Context.(DropContext); ok {
+			return m, m.drop(ctx), true
+		}
+		if ctx, ok := msg.Context.(MoveContext); ok {
+			return m, m.moveWithTags(ctx), true
+		}
+		if _, ok := msg.Context.(PermissionContext); ok {
+			return m, m.runPermissionCheck(true), true
+		}
+		return m, nil, true
+	}
+
+	if msg, ok := msg.(confirm.ChoiceMsg); ok {
+		if _, ok := msg.Context.(QuitContext); ok {
+			newModel, cmd := m.handleQuitChoice(msg.Key)
+			return newModel, cmd, true
 		}
 		return m, nil, true
 	}
@@ -398,16 +732,77 @@ func (m Model) handleConfirmMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 
 // handleTaskOperationMessages handles task operation result messages
 func (m Model) handleTaskOperationMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
-	if _, ok := msg.(tui.TaskCompletedMsg); ok {
-		return m, m.refreshCurrentView(), true
+	if completedMsg, ok := msg.(tui.TaskCompletedMsg); ok {
+		newModel, cmd := m.confirmChange(completedMsg.TaskID).recordCompleted(completedMsg).refreshWithToast(toast.Success, taskToastText("Completed", completedMsg.TaskName))
+		return newModel, cmd, true
+	}
+
+	if uncompletedMsg, ok := msg.(tui.TaskUncompletedMsg); ok {
+		newModel, cmd := m.confirmChange(uncompletedMsg.TaskID).refreshWithToast(toast.Success, taskToastText("Reopened", uncompletedMsg.TaskName))
+		return newModel, cmd, true
+	}
+
+	if failedMsg, ok := msg.(changeFailedMsg); ok {
+		newModel, cmd := m.handleChangeFailed(failedMsg)
+		return newModel, cmd, true
+	}
+
+	if clickedMsg, ok := msg.(tui.CompleteClickedMsg); ok {
+		newModel, cmd := m.completeTask(clickedMsg.Task.ID, clickedMsg.Task.Name)
+		return newModel, cmd, true
+	}
+
+	if deletedMsg, ok := msg.(tui.TaskDeletedMsg); ok {
+		newModel, cmd := m.recordDeleted(deletedMsg).refreshWithToast(toast.Success, taskToastText("Deleted", deletedMsg.TaskName))
+		return newModel, cmd, true
+	}
+
+	if droppedMsg, ok := msg.(tui.TaskDroppedMsg); ok {
+		newModel, cmd := m.refreshWithToast(toast.Success, taskToastText("Dropped", droppedMsg.TaskName))
+		return newModel, cmd, true
+	}
+
+	if droppedMsg, ok := msg.(tui.ProjectDroppedMsg); ok {
+		newModel, cmd := m.refreshWithToast(toast.Success, fmt.Sprintf("Dropped project \"%s\"", droppedMsg.ProjectName))
+		return newModel, cmd, true
+	}
+
+	if modifiedMsg, ok := msg.(tui.TaskModifiedMsg); ok {
+		m = m.confirmChange(modifiedMsg.Task.ID).recordModified(modifiedMsg)
+		newModel, toastCmd := m.pushToast(toast.Success, taskToastText("Updated", modifiedMsg.Task.Name))
+		return newModel, tea.Batch(newModel.refreshModified(modifiedMsg), toastCmd), true
+	}
+
+	if refreshedMsg, ok := msg.(taskRefreshedMsg); ok {
+		newModel, cmd := m.handleTaskRefreshed(refreshedMsg)
+		return newModel, cmd, true
+	}
+
+	if batchMsg, ok := msg.(tui.BatchCompletedMsg); ok {
+		m = m.recordBatch(batchMsg)
+		m = m.clearMarksInCurrentView()
+		if failed := batchMsg.Result.Failed(); failed > 0 {
+			m.err = fmt.Errorf("%d of %d tasks failed", failed, len(batchMsg.Result.Results))
+			newModel, cmd := m.refreshWithToast(toast.Error, m.err.Error())
+			return newModel, cmd, true
+		}
+		text := fmt.Sprintf("Applied %s to %d tasks", batchMsg.Operation.Action, batchMsg.Result.Succeeded())
+		newModel, cmd := m.refreshWithToast(toast.Success, text)
+		return newModel, cmd, true
 	}
 
-	if _, ok := msg.(tui.TaskDeletedMsg); ok {
-		return m, m.refreshCurrentView(), true
+	if undoneMsg, ok := msg.(undoCompletedMsg); ok {
+		newModel, cmd := m.handleUndoCompleted(undoneMsg)
+		return newModel, cmd, true
 	}
 
-	if _, ok := msg.(tui.TaskModifiedMsg); ok {
-		return m, m.refreshCurrentView(), true
+	if savedMsg, ok := msg.(tui.TagSavedMsg); ok {
+		verb := "Renamed"
+		if savedMsg.Created {
+			verb = "Created"
+		}
+		newModel, cmd := m.refreshWithToast(toast.Success, fmt.Sprintf("%s tag \"%s\"", verb, savedMsg.Tag.Name))
+		return newModel, cmd, true
 	}
 
 	return m, nil, false
@@ -415,6 +810,17 @@ func (m Model) handleTaskOperationMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
 
 // handleKeyMsg handles global key messages
 func (m Model) handleKeyMsg(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// g / searches every task; dd and cc confirm actions set to chords
+	m, cmd, handled := m.handleKeySequence(keyMsg)
+	if handled {
+		return m, cmd
+	}
+
+	// The first Esc dismisses the health banner before reaching the view
+	if keyMsg.String() == "esc" && m.banner != "" {
+		return m.dismissBanner()
+	}
+
 	// Toggle help
 	if key.Matches(keyMsg, m.keys.Help) {
 		m.showHelp = !m.showHelp
@@ -446,11 +852,34 @@ func (m Model) handleKeyMsg(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Complete task(s) - marked tasks need confirmation
+	if key.Matches(keyMsg, m.keys.Complete) {
+		return m.executeCompleteCommand("")
+	}
+
+	// Ask for a closing note in the palette, then complete the selected task
+	if key.Matches(keyMsg, m.keys.Resolve) {
+		if m.getSelectedTask() == nil {
+			return m, nil
+		}
+		m.palette = m.palette.ShowWith("complete ")
+		return m, m.loadPaletteItems()
+	}
+
+	// Open the selected task in OmniFocus
+	if key.Matches(keyMsg, m.keys.Open) {
+		return m.executeOpenCommand()
+	}
+
 	// Delete task - show confirmation
 	if key.Matches(keyMsg, m.keys.Delete) {
+		if marked := m.getMarkedTasks(); len(marked) > 0 {
+			op := domain.BatchOperation{Action: domain.BatchDelete}
+			return m.confirmBatch("Delete Tasks", "Delete", op, marked), nil
+		}
 		task := m.getSelectedTask()
 		if task != nil {
-			ctx := DeleteContext{TaskID: task.ID, TaskName: task.Name}
+			ctx := DeleteContext{TaskID: task.ID, TaskName: task.Name, Task: task}
 			m.confirmModal = m.confirmModal.ShowWithContext(
 				"Delete Task",
 				fmt.Sprintf("Delete \"%s\"?", task.Name),
@@ -460,69 +889,120 @@ func (m Model) handleKeyMsg(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Toggle flag - immediate action (no confirmation)
+	// Drop task(s), or the selected project - show confirmation
+	if key.Matches(keyMsg, m.keys.Drop) {
+		return m.executeDropCommand()
+	}
+
+	// Toggle flag - immediate action for a single task, confirmed for marked tasks
 	if key.Matches(keyMsg, m.keys.Flag) {
+		if marked := m.getMarkedTasks(); len(marked) > 0 {
+			return m.confirmBatchFlag(marked), nil
+		}
 		task := m.getSelectedTask()
 		if task != nil {
-			return m, m.toggleTaskFlag(task)
+			return m.toggleTaskFlag(task)
 		}
 		return m, nil
 	}
 
+	// Move the selected or marked tasks to a project picked from a list
+	if key.Matches(keyMsg, m.keys.Move) {
+		return m.executeMoveKey()
+	}
+
+	// Reschedule the selected or marked tasks with the date picker
+	if key.Matches(keyMsg, m.keys.Due) {
+		return m.executeDateKey(datepicker.FieldDue), nil
+	}
+	if key.Matches(keyMsg, m.keys.Defer) {
+		return m.executeDateKey(datepicker.FieldDefer), nil
+	}
+	if key.Matches(keyMsg, m.keys.Bump) {
+		return m.bumpSelected(domain.Bump{Amount: 1, Unit: "d"})
+	}
+	if key.Matches(keyMsg, m.keys.Unbump) {
+		return m.bumpSelected(domain.Bump{Amount: -1, Unit: "d"})
+	}
+
+	// Undo the last complete, delete or modify operation
+	if key.Matches(keyMsg, m.keys.Undo) {
+		return m.undo()
+	}
+
+	// Show saved filters
+	if key.Matches(keyMsg, m.keys.Filters) {
+		return m.showFilterPicker()
+	}
+
+	// Pin or unpin the selected task
+	if key.Matches(keyMsg, m.keys.Pin) {
+		return m.togglePin()
+	}
+
+	// Sort the current view by the next sort mode
+	if key.Matches(keyMsg, m.keys.Sort) {
+		return m.cycleSort()
+	}
+
+	// Show or hide recently completed tasks
+	if key.Matches(keyMsg, m.keys.Completed) {
+		return m.toggleCompleted()
+	}
+
+	// Retry the change OmniFocus did not take on the selected task
+	if key.Matches(keyMsg, m.keys.Reconcile) {
+		return m.reconcile(false)
+	}
+
 	// Show search input
 	if keyMsg.String() == "/" {
 		m.searchInput = m.searchInput.Show()
 		return m, nil
 	}
 
-	// Show command input
+	// Show command palette
 	if keyMsg.String() == ":" {
-		m.commandInput = m.commandInput.Show()
-		return m, nil
+		m.palette = m.palette.Show()
+		return m, m.loadPaletteItems()
 	}
 
 	// Handle view switching
 	return m.handleViewSwitching(keyMsg)
 }
 
-// handleViewSwitching handles view switching key presses
+// handleViewSwitching handles view switching key presses and delegates other keys to the current view
 func (m Model) handleViewSwitching(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if key.Matches(keyMsg, m.keys.View1) {
-		if m.currentView != tui.ViewInbox {
-			m.currentView = tui.ViewInbox
-			return m, m.inboxView.Init()
-		}
-		return m, nil
-	}
-	if key.Matches(keyMsg, m.keys.View2) {
-		if m.currentView != tui.ViewProjects {
-			m.currentView = tui.ViewProjects
-			return m, m.projectsView.Init()
-		}
-		return m, nil
-	}
-	if key.Matches(keyMsg, m.keys.View3) {
-		if m.currentView != tui.ViewTags {
-			m.currentView = tui.ViewTags
-			return m, m.tagsView.Init()
-		}
-		return m, nil
-	}
-	if key.Matches(keyMsg, m.keys.View4) {
-		if m.currentView != tui.ViewForecast {
-			m.currentView = tui.ViewForecast
-			return m, m.forecastView.Init()
-		}
+	switch {
+	case key.Matches(keyMsg, m.keys.View1):
+		return m.switchView(tui.ViewInbox)
+	case key.Matches(keyMsg, m.keys.View2):
+		return m.switchView(tui.ViewProjects)
+	case key.Matches(keyMsg, m.keys.View3):
+		return m.switchView(tui.ViewTags)
+	case key.Matches(keyMsg, m.keys.View4):
+		return m.switchView(tui.ViewForecast)
+	case key.Matches(keyMsg, m.keys.View5):
+		return m.switchView(tui.ViewReview)
+	case key.Matches(keyMsg, m.keys.View6):
+		return m.switchView(tui.ViewStats)
+	}
+
+	// Any other key (navigation, marking) goes to the current view
+	return m.delegateToCurrentView(keyMsg)
+}
+
+// switchView shows the given view, loading it unless it is already current
+func (m Model) switchView(view int) (tea.Model, tea.Cmd) {
+	if m.currentView == view {
 		return m, nil
 	}
-	if key.Matches(keyMsg, m.keys.View5) {
-		if m.currentView != tui.ViewReview {
-			m.currentView = tui.ViewReview
-			return m, m.reviewView.Init()
-		}
+	m.currentView = view
+	// A view still being prefetched gets its data when the load finishes
+	if m.statusBar.TabLoading(slices.Index(tabViews, view)) {
 		return m, nil
 	}
-	return m, nil
+	return m, m.initCurrentView()
 }
 
 // delegateToCurrentView delegates messages to the current view
@@ -539,6 +1019,8 @@ func (m Model) delegateToCurrentView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.forecastView, cmd = m.forecastView.Update(msg)
 	case tui.ViewReview:
 		m.reviewView, cmd = m.reviewView.Update(msg)
+	case tui.ViewStats:
+		m.statsView, cmd = m.statsView.Update(msg)
 	}
 	return m, cmd
 }
@@ -562,19 +1044,23 @@ func (m Model) View() string {
 		view = m.forecastView.View()
 	case tui.ViewReview:
 		view = m.reviewView.View()
+	case tui.ViewStats:
+		view = m.statsView.View()
 	default:
 		view = "View not implemented"
 	}
 
-	// Layer overlays from lowest to highest priority
-	// Bottom bar overlays (search, command)
+	// The search input takes the place of the status bar while it is open
+	bottom := m.renderStatusBar()
 	if m.searchInput.IsVisible() {
-		view = m.renderWithBottomBar(view, m.searchInput.View())
+		bottom = m.searchInput.View()
 	}
-
-	if m.commandInput.IsVisible() {
-		view = m.renderWithBottomBar(view, m.commandInput.View())
+	if m.banner != "" {
+		bottom = m.renderBanner() + "\n" + bottom
 	}
+	view = fitHeight(view, m.height-m.bottomHeight()) + "\n" + bottom
+
+	// Layer overlays from lowest to highest priority
 
 	// Center overlays
 	if m.quickAdd.IsVisible() {
@@ -589,6 +1075,30 @@ func (m Model) View() string {
 		view = m.layerOverlay(view, m.taskEdit.View())
 	}
 
+	if m.projectPicker.IsVisible() {
+		view = m.layerOverlay(view, m.projectPicker.View())
+	}
+
+	if m.datePicker.IsVisible() {
+		view = m.layerOverlay(view, m.datePicker.View())
+	}
+
+	if m.palette.IsVisible() {
+		view = m.layerOverlay(view, m.palette.View())
+	}
+
+	if m.filterPicker.IsVisible() {
+		view = m.layerOverlay(view, m.filterPicker.View())
+	}
+
+	if m.nextPanel.IsVisible() {
+		view = m.layerOverlay(view, m.nextPanel.View())
+	}
+
+	if m.searchResults.IsVisible() {
+		view = m.layerOverlay(view, m.searchResults.View())
+	}
+
 	// Top priority overlays
 	if m.confirmModal.IsVisible() {
 		view = m.layerOverlay(view, m.confirmModal.View())
@@ -598,12 +1108,26 @@ func (m Model) View() string {
 		view = m.layerOverlay(view, m.renderHelp())
 	}
 
+	if m.stepProgress != "" {
+		view = m.compositor.Compose(view, m.renderStepProgress(), false)
+	}
+
+	// Notifications stay visible above everything else
+	if m.toasts.IsVisible() {
+		view = m.compositor.ComposeTopRight(view, m.toasts.View())
+	}
+
 	return view
 }
 
 // CurrentViewName returns the name of the current view
 func (m Model) CurrentViewName() string {
-	switch m.currentView {
+	return viewName(m.currentView)
+}
+
+// viewName returns the display name of a view
+func viewName(view int) string {
+	switch view {
 	case tui.ViewInbox:
 		return "Inbox"
 	case tui.ViewProjects:
@@ -614,6 +1138,8 @@ func (m Model) CurrentViewName() string {
 		return "Forecast"
 	case tui.ViewReview:
 		return "Review"
+	case tui.ViewStats:
+		return "Stats"
 	default:
 		return "Unknown"
 	}
@@ -644,7 +1170,11 @@ func (m Model) renderHelp() string {
 	content.WriteString("\n")
 	content.WriteString(m.formatHelpLine(m.keys.Up.Help().Key, m.keys.Up.Help().Desc))
 	content.WriteString("\n")
-	content.WriteString(m.formatHelpLine("1-5", "switch views"))
+	content.WriteString(m.formatHelpLine("tab", "expand/collapse subtasks"))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine("1-6", "switch views"))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine("g /", "search all tasks"))
 	content.WriteString("\n\n")
 
 	// Actions section
@@ -656,9 +1186,48 @@ func (m Model) renderHelp() string {
 	content.WriteString("\n")
 	content.WriteString(m.formatHelpLine(m.keys.Complete.Help().Key, m.keys.Complete.Help().Desc))
 	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Resolve.Help().Key, m.keys.Resolve.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Open.Help().Key, m.keys.Open.Help().Desc))
+	content.WriteString("\n")
 	content.WriteString(m.formatHelpLine(m.keys.Delete.Help().Key, m.keys.Delete.Help().Desc))
 	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Drop.Help().Key, m.keys.Drop.Help().Desc))
+	content.WriteString("\n")
 	content.WriteString(m.formatHelpLine(m.keys.Flag.Help().Key, m.keys.Flag.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Move.Help().Key, m.keys.Move.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Due.Help().Key, m.keys.Due.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Defer.Help().Key, m.keys.Defer.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Bump.Help().Key+"/"+m.keys.Unbump.Help().Key, "bump due or defer date a day"))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Select.Help().Key, m.keys.Select.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Undo.Help().Key, m.keys.Undo.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Filters.Help().Key, m.keys.Filters.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Pin.Help().Key, m.keys.Pin.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Sort.Help().Key, m.keys.Sort.Help().Desc))
+	content.WriteString(m.formatHelpLine(m.keys.Completed.Help().Key, m.keys.Completed.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine(m.keys.Reconcile.Help().Key, m.keys.Reconcile.Help().Desc))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine("esc", "dismiss banner, clear marks"))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine("J/K", "move task down/up (project tasks)"))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine("A", "next actions only (project tasks)"))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine("n/r", "new/rename tag (tags view)"))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine("←/→", "select forecast day"))
+	content.WriteString("\n")
+	content.WriteString(m.formatHelpLine("Q<a-z>/@<a-z>", "record/replay macro (q stops)"))
 	content.WriteString("\n\n")
 
 	// General section
@@ -696,20 +1265,26 @@ func (m Model) layerOverlay(base, overlay string) string {
 	return m.compositor.Compose(base, overlay, true)
 }
 
-// renderWithBottomBar renders a bottom bar overlay (search, command)
-func (m Model) renderWithBottomBar(base, bottomBar string) string {
-	// Split base into lines
-	baseLines := strings.Split(base, "\n")
-	if len(baseLines) == 0 {
-		return bottomBar
-	}
+// pushToast shows a transient notification
+func (m Model) pushToast(level toast.Level, text string) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.toasts, cmd = m.toasts.Push(level, text)
+	return m, cmd
+}
 
-	// Replace last line with bottom bar
-	if len(baseLines) > 0 {
-		baseLines[len(baseLines)-1] = bottomBar
-	}
+// refreshWithToast refreshes the current view and shows a notification
+func (m Model) refreshWithToast(level toast.Level, text string) (Model, tea.Cmd) {
+	m, toastCmd := m.pushToast(level, text)
+	return m, tea.Batch(m.refreshCurrentView(), toastCmd)
+}
 
-	return strings.Join(baseLines, "\n")
+// taskToastText describes an operation on a task, falling back to a generic
+// message when the task name is unknown
+func taskToastText(verb, taskName string) string {
+	if taskName == "" {
+		return verb + " task"
+	}
+	return fmt.Sprintf("%s \"%s\"", verb, taskName)
 }
 
 // getSelectedTask returns the currently selected task from the current view
@@ -725,62 +1300,56 @@ func (m Model) getSelectedTask() *domain.Task {
 		return m.forecastView.SelectedTask()
 	case tui.ViewReview:
 		return m.reviewView.SelectedTask()
+	case tui.ViewStats:
+		return m.statsView.SelectedTask()
 	default:
 		return nil
 	}
 }
 
-// deleteTask creates a command to delete a task
-func (m Model) deleteTask(taskID string) tea.Cmd {
+// deleteTask creates a command to delete the task described by the confirmation context
+func (m Model) deleteTask(ctx DeleteContext) tea.Cmd {
 	return func() tea.Msg {
-		result, err := m.service.DeleteTask(taskID)
+		result, err := m.service.DeleteTask(context.Background(), ctx.TaskID)
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
 		return tui.TaskDeletedMsg{
 			TaskID:   result.ID,
-			TaskName: result.Message, // Message contains task name
+			TaskName: ctx.TaskName,
+			Snapshot: ctx.Task,
 		}
 	}
 }
 
-// toggleTaskFlag creates a command to toggle a task's flag status
-func (m Model) toggleTaskFlag(task *domain.Task) tea.Cmd {
-	return func() tea.Msg {
-		newFlagged := !task.Flagged
-		mod := domain.TaskModification{
-			Flagged: &newFlagged,
-		}
-		result, err := m.service.ModifyTask(task.ID, mod)
-		if err != nil {
-			return tui.ErrorMsg{Err: err}
-		}
-		return tui.TaskModifiedMsg{Task: *result}
-	}
+// toggleTaskFlag flags or unflags a task at once and saves the change in the
+// background; a failure shows the old flag again, with a conflict
+func (m Model) toggleTaskFlag(task *domain.Task) (Model, tea.Cmd) {
+	return m.flagTask(*task, !task.Flagged)
 }
 
-// completeTask creates a command to complete a task
-func (m Model) completeTask(taskID string) tea.Cmd {
-	return func() tea.Msg {
-		result, err := m.service.CompleteTask(taskID)
+// flagTask shows task flagged or unflagged at once and saves it in the background
+func (m Model) flagTask(task domain.Task, flagged bool) (Model, tea.Cmd) {
+	svc := m.service
+	mod := domain.TaskModification{Flagged: &flagged}
+	return m.applyOptimistically(task.ID, localChange{TaskName: task.Name, Flagged: &flagged}, func() (tea.Msg, error) {
+		result, err := svc.ModifyTask(context.Background(), task.ID, mod)
 		if err != nil {
-			return tui.ErrorMsg{Err: err}
+			return nil, err
 		}
-		return tui.TaskCompletedMsg{
-			TaskID:   result.ID,
-			TaskName: result.Message,
-		}
-	}
+		return tui.TaskModifiedMsg{Task: *result, Previous: &task, Modification: mod}, nil
+	})
 }
 
-// modifyTask creates a command to modify a task
-func (m Model) modifyTask(taskID string, mod domain.TaskModification) tea.Cmd {
+// modifyTask creates a command to modify a task; previous is the task before
+// the change and may be nil when it is unknown
+func (m Model) modifyTask(taskID string, mod domain.TaskModification, previous *domain.Task) tea.Cmd {
 	return func() tea.Msg {
-		result, err := m.service.ModifyTask(taskID, mod)
+		result, err := m.service.ModifyTask(context.Background(), taskID, mod)
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
-		return tui.TaskModifiedMsg{Task: *result}
+		return tui.TaskModifiedMsg{Task: *result, Previous: previous, Modification: mod}
 	}
 }
 
@@ -797,6 +1366,8 @@ func (m Model) refreshCurrentView() tea.Cmd {
 		return m.forecastView.Refresh()
 	case tui.ViewReview:
 		return m.reviewView.Refresh()
+	case tui.ViewStats:
+		return m.statsView.Refresh()
 	default:
 		return nil
 	}
@@ -810,15 +1381,27 @@ func (m Model) executeCommand(cmd *command.Command) (Model, tea.Cmd) {
 
 	switch cmd.Name {
 	case "quit":
-		return m, tea.Quit
+		return m.quit()
 	case "refresh":
+		// Bust any cached service data so the refresh hits OmniFocus
+		if inv, ok := m.service.(service.Invalidator); ok {
+			inv.Invalidate()
+		}
 		return m, m.refreshCurrentView()
 	case "add":
 		return m.executeAddCommand(cmd)
 	case "complete":
-		return m.executeCompleteCommand()
+		return m.executeCompleteCommand(strings.Join(cmd.Args, " "))
 	case "delete":
 		return m.executeDeleteCommand()
+	case "drop":
+		return m.executeDropCommand()
+	case "open":
+		return m.executeOpenCommand()
+	case "reconcile":
+		return m.reconcile(len(cmd.Args) > 0 && cmd.Args[0] == "discard")
+	case "move":
+		return m.executeMoveCommand(cmd)
 	case "project":
 		return m.executeProjectCommand(cmd)
 	case "tag":
@@ -827,8 +1410,30 @@ func (m Model) executeCommand(cmd *command.Command) (Model, tea.Cmd) {
 		return m.executeDueCommand(cmd)
 	case "flagged":
 		return m.executeFlaggedCommand()
+	case "available":
+		return m.executeAvailableCommand()
+	case "time":
+		return m.executeTimeCommand(cmd)
+	case "low-energy":
+		return m.executeLowEnergyCommand()
+	case "next":
+		return m.executeNextCommand(cmd)
+	case "search-all":
+		return m.executeSearchAllCommand(cmd)
+	case "sort":
+		return m.executeSortCommand(cmd)
+	case "filter":
+		return m.executeFilterCommand(cmd)
+	case "save-filter":
+		return m.executeSaveFilterCommand(cmd)
+	case "replay":
+		return m.executeReplayCommand(cmd)
 	case "clear":
 		return m.executeClearCommand()
+	case "path":
+		return m.executePathCommand()
+	case "debug":
+		return m.executeDebugCommand()
 	case "help":
 		m.showHelp = !m.showHelp
 		return m, nil
@@ -839,29 +1444,47 @@ func (m Model) executeCommand(cmd *command.Command) (Model, tea.Cmd) {
 
 // executeAddCommand handles the "add" command
 func (m Model) executeAddCommand(cmd *command.Command) (Model, tea.Cmd) {
-	// Open quick add with args if provided
+	// Open quick add, pre-filled with args if provided
 	if len(cmd.Args) > 0 {
-		_ = strings.Join(cmd.Args, " ") // taskText for future pre-fill feature
-		m.quickAdd = m.quickAdd.Show()
-		// TODO: Pre-fill quick add with taskText
-		// This would require adding a method to quickadd component
+		m.quickAdd = m.quickAdd.ShowWithText(strings.Join(cmd.Args, " "))
 	} else {
 		m.quickAdd = m.quickAdd.Show()
 	}
 	return m, nil
 }
 
-// executeCompleteCommand handles the "complete" command
-func (m Model) executeCompleteCommand() (Model, tea.Cmd) {
+// executeCompleteCommand handles the "complete" command; a non-empty note is
+// added to the selected task as a closing "resolution: …" line
+func (m Model) executeCompleteCommand(note string) (Model, tea.Cmd) {
+	if marked := m.getMarkedTasks(); len(marked) > 0 {
+		if note != "" {
+			return m.pushToast(toast.Error, "A closing note can only be added to one task at a time")
+		}
+		op := domain.BatchOperation{Action: domain.BatchComplete}
+		return m.confirmBatch("Complete Tasks", "Complete", op, marked), nil
+	}
 	task := m.getSelectedTask()
-	if task != nil {
-		return m, m.completeTask(task.ID)
+	if task == nil {
+		return m, nil
 	}
-	return m, nil
+	if task.Completed {
+		if note != "" {
+			return m.pushToast(toast.Error, taskToastText("Already completed", task.Name))
+		}
+		return m.uncompleteTask(task.ID, task.Name)
+	}
+	if note != "" {
+		return m.completeTaskWithNote(task.ID, task.Name, note)
+	}
+	return m.completeTask(task.ID, task.Name)
 }
 
 // executeDeleteCommand handles the "delete" command
 func (m Model) executeDeleteCommand() (Model, tea.Cmd) {
+	if marked := m.getMarkedTasks(); len(marked) > 0 {
+		op := domain.BatchOperation{Action: domain.BatchDelete}
+		return m.confirmBatch("Delete Tasks", "Delete", op, marked), nil
+	}
 	task := m.getSelectedTask()
 	if task != nil {
 		ctx := DeleteContext{TaskID: task.ID, TaskName: task.Name}
@@ -874,44 +1497,82 @@ func (m Model) executeDeleteCommand() (Model, tea.Cmd) {
 	return m, nil
 }
 
-// executeProjectCommand handles the "project" command
-func (m Model) executeProjectCommand(cmd *command.Command) (Model, tea.Cmd) {
-	if len(cmd.Args) > 0 {
-		projectName := strings.Join(cmd.Args, " ")
-		// Resolve project name to ID
-		projects, err := m.service.GetProjects("")
-		if err != nil {
-			m.err = fmt.Errorf("failed to get projects: %w", err)
+// executeMoveCommand handles the "move" command for marked tasks or the selected task
+func (m Model) executeMoveCommand(cmd *command.Command) (Model, tea.Cmd) {
+	if len(cmd.Args) == 0 {
+		return m, nil
+	}
+
+	tasks := m.getMarkedTasks()
+	if len(tasks) == 0 {
+		task := m.getSelectedTask()
+		if task == nil {
 			return m, nil
 		}
+		tasks = []domain.Task{*task}
+	}
 
-		// Find project by name (case-insensitive)
-		var projectID string
-		projectNameLower := strings.ToLower(projectName)
-		for _, proj := range projects {
-			if strings.ToLower(proj.Name) == projectNameLower {
-				projectID = proj.ID
-				break
-			}
+	name, tags := splitMoveArgs(cmd.Args)
+	var project *domain.Project
+	if name != "" || len(tags) == 0 {
+		var err error
+		if project, err = m.findProjectByName(name); err != nil {
+			m.err = err
+			return m, nil
 		}
+	}
+
+	// Tagging takes a script per tag, so it runs step by step with progress
+	if len(tags) > 0 {
+		return m.confirmMoveWithTags(project, tags, tasks), nil
+	}
+
+	op := domain.BatchOperation{
+		Action:       domain.BatchModify,
+		Modification: domain.TaskModification{ProjectID: &project.ID},
+	}
+	verb := fmt.Sprintf("Move to \"%s\":", project.Name)
+	return m.confirmBatch("Move Tasks", verb, op, tasks), nil
+}
 
-		if projectID == "" {
-			m.err = fmt.Errorf("project not found: %s", projectName)
+// executeProjectCommand handles the "project" command
+func (m Model) executeProjectCommand(cmd *command.Command) (Model, tea.Cmd) {
+	if len(cmd.Args) > 0 {
+		project, err := m.findProjectByName(strings.Join(cmd.Args, " "))
+		if err != nil {
+			m.err = err
 			return m, nil
 		}
 
-		m.filterState = m.filterState.WithProject(projectID)
+		m.filterState = m.filterState.WithProject(project.ID)
 		m = m.applyFilterToCurrentView()
 	}
 	return m, nil
 }
 
+// findProjectByName resolves a project by name (case-insensitive)
+func (m Model) findProjectByName(name string) (*domain.Project, error) {
+	projects, err := m.service.GetProjects(context.Background(), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	nameLower := strings.ToLower(name)
+	for i := range projects {
+		if strings.ToLower(projects[i].Name) == nameLower {
+			return &projects[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("project not found: %s", name)
+}
+
 // executeTagCommand handles the "tag" command
 func (m Model) executeTagCommand(cmd *command.Command) (Model, tea.Cmd) {
 	if len(cmd.Args) > 0 {
 		tagName := strings.Join(cmd.Args, " ")
 		// Resolve tag name to ID
-		tags, err := m.service.GetTags()
+		tags, err := m.service.GetTags(context.Background())
 		if err != nil {
 			m.err = fmt.Errorf("failed to get tags: %w", err)
 			return m, nil
@@ -968,6 +1629,67 @@ func (m Model) executeFlaggedCommand() (Model, tea.Cmd) {
 	return m, nil
 }
 
+// executeAvailableCommand handles the "available" command
+func (m Model) executeAvailableCommand() (Model, tea.Cmd) {
+	m.filterState = m.filterState.WithAvailableOnly(true)
+	m = m.applyFilterToCurrentView()
+	return m, nil
+}
+
+// executeLowEnergyCommand handles the "low-energy" command
+func (m Model) executeLowEnergyCommand() (Model, tea.Cmd) {
+	m.filterState = m.filterState.WithLowEnergy(true)
+	m = m.applyFilterToCurrentView()
+	return m, nil
+}
+
+// executeTimeCommand handles the "time" command. A leading "<" or "<=" is
+// accepted, so ":time <30m" and ":time 30m" both show tasks that fit in 30
+// minutes; "off" shows tasks of any length again.
+func (m Model) executeTimeCommand(cmd *command.Command) (Model, tea.Cmd) {
+	if len(cmd.Args) == 0 {
+		return m, nil
+	}
+
+	arg := strings.Join(cmd.Args, "")
+	minutes := 0
+	if !strings.EqualFold(arg, "off") {
+		var err error
+		minutes, err = domain.ParseEstimate(strings.TrimLeft(arg, "<="))
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+	}
+
+	m.filterState = m.filterState.WithMaxMinutes(minutes)
+	m = m.applyFilterToCurrentView()
+	return m, nil
+}
+
+// executeFilterCommand handles the "filter" command
+func (m Model) executeFilterCommand(cmd *command.Command) (Model, tea.Cmd) {
+	if len(cmd.Args) == 0 {
+		return m.showFilterPicker()
+	}
+
+	name := strings.Join(cmd.Args, " ")
+	saved, err := filter.LoadSaved(m.savedFilters)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	state, ok := saved.Find(name)
+	if !ok {
+		m.err = fmt.Errorf("saved filter not found: %s", name)
+		return m, nil
+	}
+
+	m.filterState = state
+	m = m.applyFilterToCurrentView()
+	return m, nil
+}
+
 // executeClearCommand handles the "clear" command
 func (m Model) executeClearCommand() (Model, tea.Cmd) {
 	m.filterState = m.filterState.Clear()
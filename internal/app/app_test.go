@@ -1,19 +1,25 @@
 package app
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
-	"github.com/pwojciechowski/lazyfocus/internal/tui/components/commandinput"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/confirm"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/palette"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/searchinput"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/taskdetail"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/taskedit"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/views/tags"
 )
 
 func TestNewApp(t *testing.T) {
@@ -186,6 +192,7 @@ func TestAppViewSwitching(t *testing.T) {
 		{"Switch to Tags", '3', tui.ViewTags},
 		{"Switch to Forecast", '4', tui.ViewForecast},
 		{"Switch to Review", '5', tui.ViewReview},
+		{"Switch to Stats", '6', tui.ViewStats},
 	}
 
 	for _, tt := range tests {
@@ -346,6 +353,7 @@ func TestAppCurrentViewName(t *testing.T) {
 		{"Tags view", tui.ViewTags, "Tags"},
 		{"Forecast view", tui.ViewForecast, "Forecast"},
 		{"Review view", tui.ViewReview, "Review"},
+		{"Stats view", tui.ViewStats, "Stats"},
 		{"Unknown view", 99, "Unknown"},
 	}
 
@@ -578,6 +586,39 @@ func TestTaskDeletedMsg_RefreshesView(t *testing.T) {
 	}
 }
 
+func TestTagNameInput_ReceivesShortcutKeys(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{}
+	app := NewApp(mockSvc)
+	app.currentView = tui.ViewTags
+
+	newModel, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	app = newModel.(Model)
+	if !app.tagsView.Editing() {
+		t.Fatal("expected n to start tag input in the tags view")
+	}
+
+	newModel, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	app = newModel.(Model)
+	if cmd != nil {
+		if _, ok := cmd().(tea.QuitMsg); ok {
+			t.Fatal("expected q to be typed into the tag input, not quit")
+		}
+	}
+	if !app.tagsView.Editing() {
+		t.Error("expected tag input to stay open")
+	}
+}
+
+func TestTagSavedMsg_RefreshesView(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{})
+	app.currentView = tui.ViewTags
+
+	_, cmd := app.Update(tui.TagSavedMsg{Tag: domain.Tag{ID: "tag1", Name: "Errands"}, Created: true})
+	if cmd == nil {
+		t.Error("expected refresh command after saving a tag")
+	}
+}
+
 // Tests for flag toggle functionality (Stage 4)
 
 func TestFlagKey_TogglesFlag(t *testing.T) {
@@ -940,9 +981,9 @@ func TestHandleSearchInputMessages_SearchConfirmedMsg(t *testing.T) {
 	}
 }
 
-// Tests for handleCommandInputMessages (Stage 3)
+// Tests for handlePaletteMessages (Stage 3)
 
-func TestHandleCommandInputMessages_CommandExecutedMsg(t *testing.T) {
+func TestHandlePaletteMessages_CommandExecutedMsg(t *testing.T) {
 	// Arrange
 	mockSvc := &service.MockOmniFocusService{}
 	app := NewApp(mockSvc)
@@ -950,7 +991,7 @@ func TestHandleCommandInputMessages_CommandExecutedMsg(t *testing.T) {
 
 	// Act - send CommandExecutedMsg with quit command
 	quitCmd := &command.Command{Name: "quit", Args: []string{}}
-	_, cmd := newModel.(Model).Update(commandinput.CommandExecutedMsg{Command: quitCmd})
+	_, cmd := newModel.(Model).Update(palette.ExecutedMsg{Command: quitCmd})
 
 	// Assert - quit command should return tea.Quit
 	if cmd == nil {
@@ -958,7 +999,7 @@ func TestHandleCommandInputMessages_CommandExecutedMsg(t *testing.T) {
 	}
 }
 
-func TestHandleCommandInputMessages_CommandCancelledMsg(t *testing.T) {
+func TestHandlePaletteMessages_CommandCancelledMsg(t *testing.T) {
 	// Arrange
 	mockSvc := &service.MockOmniFocusService{}
 	app := NewApp(mockSvc)
@@ -966,7 +1007,7 @@ func TestHandleCommandInputMessages_CommandCancelledMsg(t *testing.T) {
 	app = newModel.(Model)
 
 	// Act - send CommandCancelledMsg
-	newModel, _ = app.Update(commandinput.CommandCancelledMsg{})
+	newModel, _ = app.Update(palette.CancelledMsg{})
 	app = newModel.(Model)
 
 	// Assert - no error should be set
@@ -975,7 +1016,7 @@ func TestHandleCommandInputMessages_CommandCancelledMsg(t *testing.T) {
 	}
 }
 
-func TestHandleCommandInputMessages_CommandErrorMsg(t *testing.T) {
+func TestHandlePaletteMessages_CommandErrorMsg(t *testing.T) {
 	// Arrange
 	mockSvc := &service.MockOmniFocusService{}
 	app := NewApp(mockSvc)
@@ -983,7 +1024,7 @@ func TestHandleCommandInputMessages_CommandErrorMsg(t *testing.T) {
 	app = newModel.(Model)
 
 	// Act - send CommandErrorMsg
-	newModel, _ = app.Update(commandinput.CommandErrorMsg{Error: "command error"})
+	newModel, _ = app.Update(palette.ErrorMsg{Error: "command error"})
 	app = newModel.(Model)
 
 	// Assert - error should be set
@@ -1009,10 +1050,13 @@ func TestExecuteCommand_Add(t *testing.T) {
 	newModel, _ = app.executeCommand(cmd)
 	app = newModel.(Model)
 
-	// Assert - quick add should be visible
+	// Assert - quick add should be visible and pre-filled with the args
 	if !app.quickAdd.IsVisible() {
 		t.Error("expected quick add to be visible after add command")
 	}
+	if preview := app.quickAdd.Preview(); !strings.Contains(preview, "test task") {
+		t.Errorf("quick add preview = %q, want it pre-filled with %q", preview, "test task")
+	}
 }
 
 func TestExecuteCommand_AddWithoutArgs(t *testing.T) {
@@ -1053,6 +1097,37 @@ func TestExecuteCommand_Complete(t *testing.T) {
 	}
 }
 
+func TestExecuteCommand_CompleteWithNote(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks:     []domain.Task{{ID: "task1", Name: "Test Task"}},
+		Task:           &domain.Task{ID: "task1", Name: "Test Task"},
+		CompleteResult: &domain.OperationResult{Success: true, ID: "task1"},
+	}
+	app := NewApp(mockSvc)
+	newModel, _ := app.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	app = newModel.(Model)
+	newModel, _ = app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+
+	// Shift+C asks for the note in the palette
+	newModel, _ = newModel.(Model).Update(runeKey('C'))
+	app = newModel.(Model)
+	if !app.palette.IsVisible() {
+		t.Fatal("expected the palette to open for the closing note")
+	}
+
+	cmd := &command.Command{Name: "complete", Args: []string{"Sent", "the", "invoice"}}
+	_, completeCmd := app.executeCommand(cmd)
+	if completeCmd == nil {
+		t.Fatal("expected complete command to be returned")
+	}
+	if msg, ok := completeCmd().(tui.TaskCompletedMsg); !ok || msg.TaskID != "task1" {
+		t.Errorf("completeCmd() = %v, want TaskCompletedMsg for task1", msg)
+	}
+	if note := mockSvc.Modifications["task1"].Note; note == nil || *note != "resolution: Sent the invoice" {
+		t.Errorf("note = %v, want the closing note", note)
+	}
+}
+
 func TestExecuteCommand_CompleteNoTask(t *testing.T) {
 	// Arrange
 	mockSvc := &service.MockOmniFocusService{
@@ -1132,6 +1207,35 @@ func TestExecuteCommand_Refresh(t *testing.T) {
 	}
 }
 
+func TestExecuteCommand_RefreshInvalidatesCache(t *testing.T) {
+	// Arrange - cached service that has already memoized inbox tasks
+	inner := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "task1", Name: "Old"}},
+	}
+	cached := service.NewCachedOmniFocusService(inner, time.Hour)
+	_, _ = cached.GetInboxTasks(context.Background())
+	inner.InboxTasks = []domain.Task{{ID: "task2", Name: "New"}}
+
+	app := NewApp(cached)
+	newModel, _ := app.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	// Act
+	cmd := &command.Command{Name: "refresh", Args: []string{}}
+	_, refreshCmd := newModel.(Model).executeCommand(cmd)
+
+	// Assert - the refresh must see the new data, not the cached copy
+	if refreshCmd == nil {
+		t.Fatal("expected refresh command to be returned")
+	}
+	msg, ok := refreshCmd().(tui.TasksLoadedMsg)
+	if !ok {
+		t.Fatalf("expected TasksLoadedMsg, got %T", refreshCmd())
+	}
+	if len(msg.Tasks) != 1 || msg.Tasks[0].ID != "task2" {
+		t.Errorf("expected refreshed tasks to contain task2, got %+v", msg.Tasks)
+	}
+}
+
 func TestExecuteCommand_Help(t *testing.T) {
 	// Arrange
 	mockSvc := &service.MockOmniFocusService{}
@@ -1301,9 +1405,9 @@ func TestKeyHandling_CommandKey(t *testing.T) {
 	newModel, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
 	app = newModel.(Model)
 
-	// Assert - command input should be visible
-	if !app.commandInput.IsVisible() {
-		t.Error("expected command input to be visible after ':' key")
+	// Assert - command palette should be visible
+	if !app.palette.IsVisible() {
+		t.Error("expected command palette to be visible after ':' key")
 	}
 }
 
@@ -1325,3 +1429,78 @@ func TestTaskCompletedMsg_RefreshesView(t *testing.T) {
 		t.Error("expected refresh command after TaskCompletedMsg")
 	}
 }
+
+func TestTaskOperationMessages_ShowToasts(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  tea.Msg
+		want string
+	}{
+		{"completed", tui.TaskCompletedMsg{TaskID: "task1", TaskName: "Write report"}, "Completed \"Write report\""},
+		{"deleted", tui.TaskDeletedMsg{TaskID: "task1", TaskName: "Write report"}, "Deleted \"Write report\""},
+		{"modified", tui.TaskModifiedMsg{Task: domain.Task{ID: "task1", Name: "Write report"}}, "Updated \"Write report\""},
+		{"error", tui.ErrorMsg{Err: errors.New("OmniFocus is not running")}, "OmniFocus is not running"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := NewApp(&service.MockOmniFocusService{})
+			newModel, _ := app.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+			newModel, cmd := newModel.(Model).Update(tt.msg)
+			app = newModel.(Model)
+
+			if cmd == nil {
+				t.Error("expected command to dismiss the toast")
+			}
+			if toasts := app.toasts.Messages(); len(toasts) != 1 || toasts[0] != tt.want {
+				t.Errorf("toasts = %v, want [%s]", toasts, tt.want)
+			}
+			if !strings.Contains(app.View(), tt.want) {
+				t.Errorf("View() missing toast %q", tt.want)
+			}
+		})
+	}
+}
+
+func TestToastDismissMsg_RemovesToast(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{})
+	newModel, _ := app.Update(tui.TaskCompletedMsg{TaskID: "task1", TaskName: "Write report"})
+	app = newModel.(Model)
+
+	// Dismissal must get through even while an overlay is open
+	app.showHelp = true
+	newModel, _ = app.Update(toast.DismissMsg{ID: 1})
+	app = newModel.(Model)
+
+	if app.toasts.IsVisible() {
+		t.Errorf("toasts = %v, want none after dismissal", app.toasts.Messages())
+	}
+}
+
+func TestLimitWarningMsg_ShowsToast(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{})
+
+	usage := limits.Usage{Limit: limits.Limit{Tag: "doing", Max: 5}, Count: 6}
+	model, cmd := app.Update(LimitWarningMsg{Usage: usage})
+	app = model.(Model)
+	if cmd == nil {
+		t.Error("the warning toast should be dismissed later")
+	}
+	if toasts := strings.Join(app.toasts.Messages(), "\n"); !strings.Contains(toasts, `6 open tasks with tag "doing" (limit 5)`) {
+		t.Errorf("toasts = %q, want the WIP limit warning", toasts)
+	}
+}
+
+func TestSetLimits_ShowsBadges(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{})
+	app = app.SetLimits([]limits.Limit{{Tag: "doing", Max: 1}})
+
+	app.tagsView, _ = app.tagsView.Update(tags.LoadedWithCountsMsg{
+		Tags:   []domain.Tag{{ID: "t1", Name: "doing"}},
+		Counts: map[string]int{"t1": 2},
+	})
+	if view := app.tagsView.View(); !strings.Contains(view, limits.WarnIcon+" (2/1)") {
+		t.Errorf("tags view should flag doing over its limit, got:\n%s", view)
+	}
+}
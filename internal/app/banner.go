@@ -0,0 +1,68 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pwojciechowski/lazyfocus/internal/health"
+)
+
+// healthCheckedMsg carries the health check run at startup
+type healthCheckedMsg struct {
+	Snapshot health.Snapshot
+}
+
+// SetHealthCheck sets the health check run at startup, whose failures are
+// described in a banner above the status bar until dismissed. Without one,
+// no banner is shown.
+func (m Model) SetHealthCheck(check func() health.Snapshot) Model {
+	m.healthCheck = check
+	return m
+}
+
+// runHealthCheck creates a command running the health check, or nil without one
+func (m Model) runHealthCheck() tea.Cmd {
+	check := m.healthCheck
+	if check == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return healthCheckedMsg{Snapshot: check()}
+	}
+}
+
+// handleHealthChecked shows the banner for a failed health check
+func (m Model) handleHealthChecked(msg healthCheckedMsg) (tea.Model, tea.Cmd) {
+	return m.setBanner(msg.Snapshot.Banner())
+}
+
+// dismissBanner hides the health banner
+func (m Model) dismissBanner() (tea.Model, tea.Cmd) {
+	return m.setBanner("")
+}
+
+// setBanner shows text above the status bar, or hides the banner when text
+// is empty, giving the views the lines left
+func (m Model) setBanner(text string) (tea.Model, tea.Cmd) {
+	m.banner = text
+	if !m.ready {
+		return m, nil
+	}
+	return m.handleWindowResize(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+}
+
+// bottomHeight is the number of lines below the current view: the status bar
+// and the banner while one is shown
+func (m Model) bottomHeight() int {
+	if m.banner != "" {
+		return statusBarHeight + 1
+	}
+	return statusBarHeight
+}
+
+// renderBanner renders the banner on one line, cutting its text short so
+// how to dismiss it stays in view
+func (m Model) renderBanner() string {
+	const hint = "  (esc: dismiss)"
+	text := ansi.Truncate("⚠ "+m.banner, max(m.width-ansi.StringWidth(hint), 0), "…")
+	return m.styles.UI.Banner.Render(ansi.Truncate(text+hint, m.width, ""))
+}
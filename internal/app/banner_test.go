@@ -0,0 +1,59 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/health"
+)
+
+func TestHealthCheck_ShowsBannerUntilEsc(t *testing.T) {
+	notRunning := health.Snapshot{
+		Checks: []health.Check{{Name: "OmniFocus", Detail: "not running", Degraded: "OmniFocus is not running"}},
+		At:     time.Date(2024, 1, 15, 9, 12, 0, 0, time.Local),
+	}
+	app := startApp(t, NewApp(&service.MockOmniFocusService{}).SetHealthCheck(func() health.Snapshot { return notRunning }))
+
+	lines := strings.Split(app.View(), "\n")
+	if len(lines) != app.height {
+		t.Fatalf("view has %d lines, want %d", len(lines), app.height)
+	}
+	banner := lines[len(lines)-2]
+	if !strings.Contains(banner, "OmniFocus is not running (checked 09:12") || !strings.Contains(banner, "esc: dismiss") {
+		t.Errorf("line above the status bar = %q, want the banner", banner)
+	}
+
+	model, _ := app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app = model.(Model)
+	if strings.Contains(app.View(), "OmniFocus is not running") {
+		t.Error("Esc should dismiss the banner")
+	}
+	if lines := strings.Split(app.View(), "\n"); len(lines) != app.height {
+		t.Errorf("view has %d lines after dismissing, want %d", len(lines), app.height)
+	}
+}
+
+func TestHealthCheck_NoBannerWhenHealthy(t *testing.T) {
+	healthy := health.Snapshot{Checks: []health.Check{{Name: "OmniFocus", OK: true, Detail: "running"}}}
+	app := startApp(t, NewApp(&service.MockOmniFocusService{}).SetHealthCheck(func() health.Snapshot { return healthy }))
+
+	if app.banner != "" {
+		t.Errorf("banner = %q, want none", app.banner)
+	}
+}
+
+func TestMouse_ClickOnBannerDismissesIt(t *testing.T) {
+	app := newMacroTestApp()
+	model, _ := app.Update(healthCheckedMsg{Snapshot: health.Snapshot{
+		Checks: []health.Check{{Name: "osascript", Detail: "not found", Degraded: "osascript not found"}},
+	}})
+	app = model.(Model)
+
+	model, _ = app.Update(click(0, app.height-2))
+	if banner := model.(Model).banner; banner != "" {
+		t.Errorf("banner = %q after clicking it, want none", banner)
+	}
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// maxBatchSummaryNames limits how many task names the bulk confirmation lists
+const maxBatchSummaryNames = 5
+
+// BatchContext stores context for bulk-action confirmation
+type BatchContext struct {
+	Operation domain.BatchOperation
+	Tasks     []domain.Task
+}
+
+// getMarkedTasks returns the tasks marked for bulk actions in the current view
+func (m Model) getMarkedTasks() []domain.Task {
+	switch m.currentView {
+	case tui.ViewInbox:
+		return m.inboxView.MarkedTasks()
+	case tui.ViewProjects:
+		return m.projectsView.MarkedTasks()
+	case tui.ViewTags:
+		return m.tagsView.MarkedTasks()
+	case tui.ViewForecast:
+		return m.forecastView.MarkedTasks()
+	case tui.ViewReview:
+		return m.reviewView.MarkedTasks()
+	default:
+		return nil
+	}
+}
+
+// clearMarksInCurrentView removes bulk-action marks from the current view
+func (m Model) clearMarksInCurrentView() Model {
+	switch m.currentView {
+	case tui.ViewInbox:
+		m.inboxView = m.inboxView.ClearMarks()
+	case tui.ViewProjects:
+		m.projectsView = m.projectsView.ClearMarks()
+	case tui.ViewTags:
+		m.tagsView = m.tagsView.ClearMarks()
+	case tui.ViewForecast:
+		m.forecastView = m.forecastView.ClearMarks()
+	case tui.ViewReview:
+		m.reviewView = m.reviewView.ClearMarks()
+	}
+	return m
+}
+
+// confirmBatch shows a single confirmation modal summarizing a bulk action
+func (m Model) confirmBatch(title, verb string, op domain.BatchOperation, tasks []domain.Task) Model {
+	ctx := BatchContext{Operation: op, Tasks: tasks}
+	m.confirmModal = m.confirmModal.ShowWithContext(title, batchSummary(verb, tasks), ctx)
+	return m
+}
+
+// confirmBatchFlag confirms flagging the marked tasks, or unflagging them if all are flagged
+func (m Model) confirmBatchFlag(tasks []domain.Task) Model {
+	flagged := false
+	for _, task := range tasks {
+		if !task.Flagged {
+			flagged = true
+			break
+		}
+	}
+
+	title, verb := "Unflag Tasks", "Unflag"
+	if flagged {
+		title, verb = "Flag Tasks", "Flag"
+	}
+
+	op := domain.BatchOperation{
+		Action:       domain.BatchModify,
+		Modification: domain.TaskModification{Flagged: &flagged},
+	}
+	return m.confirmBatch(title, verb, op, tasks)
+}
+
+// batchSummary builds the confirmation message listing the affected tasks
+func batchSummary(verb string, tasks []domain.Task) string {
+	var b strings.Builder
+
+	noun := "tasks"
+	if len(tasks) == 1 {
+		noun = "task"
+	}
+	fmt.Fprintf(&b, "%s %d %s?\n", verb, len(tasks), noun)
+
+	for i, task := range tasks {
+		if i == maxBatchSummaryNames {
+			fmt.Fprintf(&b, "\n…and %d more", len(tasks)-maxBatchSummaryNames)
+			break
+		}
+		fmt.Fprintf(&b, "\n• %s", task.Name)
+	}
+
+	return b.String()
+}
+
+// batchModify creates a command to apply a bulk operation via the service
+func (m Model) batchModify(ctx BatchContext) tea.Cmd {
+	ids := make([]string, len(ctx.Tasks))
+	for i, task := range ctx.Tasks {
+		ids[i] = task.ID
+	}
+
+	return func() tea.Msg {
+		result, err := m.service.BatchModify(context.Background(), ids, ctx.Operation)
+		if err != nil {
+			return tui.ErrorMsg{Err: err}
+		}
+		return tui.BatchCompletedMsg{Result: *result, Operation: ctx.Operation, Tasks: ctx.Tasks}
+	}
+}
@@ -0,0 +1,213 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/confirm"
+)
+
+var spaceKey = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+// newAppWithMarkedTasks creates an app showing the given inbox tasks with the first two marked
+func newAppWithMarkedTasks(t *testing.T, mockSvc *service.MockOmniFocusService) Model {
+	t.Helper()
+
+	app := NewApp(mockSvc)
+	newModel, _ := app.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	app = newModel.(Model)
+	newModel, _ = app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	app = newModel.(Model)
+
+	// Space marks the current task and advances the cursor
+	newModel, _ = app.Update(spaceKey)
+	app = newModel.(Model)
+	newModel, _ = app.Update(spaceKey)
+	app = newModel.(Model)
+
+	if got := len(app.getMarkedTasks()); got != 2 {
+		t.Fatalf("expected 2 marked tasks, got %d", got)
+	}
+	return app
+}
+
+func TestBulkDelete_ShowsSingleConfirmationSummary(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "First"},
+			{ID: "task2", Name: "Second"},
+			{ID: "task3", Name: "Third"},
+		},
+		BatchResult: &domain.BatchResult{},
+	}
+	app := newAppWithMarkedTasks(t, mockSvc)
+
+	newModel, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	app = newModel.(Model)
+
+	if !app.confirmModal.IsVisible() {
+		t.Fatal("confirm modal should be visible for bulk delete")
+	}
+	view := app.confirmModal.View()
+	if !strings.Contains(view, "Delete 2 tasks?") {
+		t.Errorf("expected summary of affected tasks, got: %s", view)
+	}
+
+	// Confirm and run the resulting batch command
+	newModel, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = newModel.(Model)
+	confirmed, ok := cmd().(confirm.ConfirmedMsg)
+	if !ok {
+		t.Fatal("expected ConfirmedMsg")
+	}
+	_, batchCmd := app.Update(confirmed)
+	if batchCmd == nil {
+		t.Fatal("expected batch command after confirmation")
+	}
+	if _, ok := batchCmd().(tui.BatchCompletedMsg); !ok {
+		t.Fatal("expected BatchCompletedMsg from batch command")
+	}
+
+	if mockSvc.BatchOperation == nil || mockSvc.BatchOperation.Action != domain.BatchDelete {
+		t.Errorf("expected BatchDelete operation, got %+v", mockSvc.BatchOperation)
+	}
+	if len(mockSvc.BatchIDs) != 2 || mockSvc.BatchIDs[0] != "task1" || mockSvc.BatchIDs[1] != "task2" {
+		t.Errorf("expected IDs [task1 task2], got %v", mockSvc.BatchIDs)
+	}
+}
+
+func TestBulkFlag_FlagsWhenAnyUnflagged(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "First", Flagged: true},
+			{ID: "task2", Name: "Second", Flagged: false},
+		},
+	}
+	app := newAppWithMarkedTasks(t, mockSvc)
+
+	newModel, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	app = newModel.(Model)
+
+	if !app.confirmModal.IsVisible() {
+		t.Fatal("confirm modal should be visible for bulk flag")
+	}
+	if !strings.Contains(app.confirmModal.View(), "Flag 2 tasks?") {
+		t.Errorf("expected flag summary, got: %s", app.confirmModal.View())
+	}
+}
+
+func TestBulkComplete_UsesCompleteAction(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "First"},
+			{ID: "task2", Name: "Second"},
+		},
+	}
+	app := newAppWithMarkedTasks(t, mockSvc)
+
+	newModel, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	app = newModel.(Model)
+
+	if !app.confirmModal.IsVisible() {
+		t.Fatal("confirm modal should be visible for bulk complete")
+	}
+	if !strings.Contains(app.confirmModal.View(), "Complete 2 tasks?") {
+		t.Errorf("expected complete summary, got: %s", app.confirmModal.View())
+	}
+}
+
+func TestMoveCommand_MovesMarkedTasksToProject(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "First"},
+			{ID: "task2", Name: "Second"},
+		},
+		Projects: []domain.Project{{ID: "proj1", Name: "Work"}},
+	}
+	app := newAppWithMarkedTasks(t, mockSvc)
+
+	app, _ = app.executeCommand(&command.Command{Name: "move", Args: []string{"work"}})
+
+	if !app.confirmModal.IsVisible() {
+		t.Fatal("confirm modal should be visible for move")
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	confirmed := cmd().(confirm.ConfirmedMsg)
+	ctx, ok := confirmed.Context.(BatchContext)
+	if !ok {
+		t.Fatalf("expected BatchContext, got %T", confirmed.Context)
+	}
+	if ctx.Operation.Modification.ProjectID == nil || *ctx.Operation.Modification.ProjectID != "proj1" {
+		t.Errorf("expected move to proj1, got %+v", ctx.Operation.Modification)
+	}
+	if len(ctx.Tasks) != 2 {
+		t.Errorf("expected 2 tasks in context, got %d", len(ctx.Tasks))
+	}
+}
+
+func TestMoveCommand_UnknownProjectSetsError(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "task1", Name: "First"}},
+	}
+	app := NewApp(mockSvc)
+	newModel, _ := app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	app = newModel.(Model)
+
+	app, _ = app.executeCommand(&command.Command{Name: "move", Args: []string{"Nowhere"}})
+
+	if app.err == nil {
+		t.Error("expected error for unknown project")
+	}
+	if app.confirmModal.IsVisible() {
+		t.Error("confirm modal should not be visible on error")
+	}
+}
+
+func TestBatchCompletedMsg_ClearsMarksAndReportsFailures(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "First"},
+			{ID: "task2", Name: "Second"},
+		},
+	}
+	app := newAppWithMarkedTasks(t, mockSvc)
+
+	result := domain.BatchResult{Results: []domain.OperationResult{
+		{Success: true, ID: "task1"},
+		{Success: false, ID: "task2", Message: "not found"},
+	}}
+	newModel, cmd := app.Update(tui.BatchCompletedMsg{Result: result})
+	app = newModel.(Model)
+
+	if cmd == nil {
+		t.Error("expected refresh command after batch")
+	}
+	if len(app.getMarkedTasks()) != 0 {
+		t.Error("expected marks cleared after batch")
+	}
+	if app.err == nil || !strings.Contains(app.err.Error(), "1 of 2") {
+		t.Errorf("expected failure summary error, got %v", app.err)
+	}
+}
+
+func TestBatchSummary_TruncatesLongLists(t *testing.T) {
+	var tasks []domain.Task
+	for i := 0; i < 8; i++ {
+		tasks = append(tasks, domain.Task{ID: "t", Name: "Task"})
+	}
+
+	summary := batchSummary("Delete", tasks)
+
+	if !strings.Contains(summary, "Delete 8 tasks?") {
+		t.Errorf("expected count in summary, got: %s", summary)
+	}
+	if !strings.Contains(summary, "…and 3 more") {
+		t.Errorf("expected truncation note in summary, got: %s", summary)
+	}
+}
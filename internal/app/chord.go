@@ -0,0 +1,174 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// ConfirmStyle is how a dangerous action is confirmed
+type ConfirmStyle string
+
+// Confirmation styles
+const (
+	ConfirmModal ConfirmStyle = "modal" // Ask in a confirmation modal
+	ConfirmChord ConfirmStyle = "chord" // Press the action's key twice, like vim's dd
+)
+
+// DefaultChordTimeout is how long a chord waits for its second press
+const DefaultChordTimeout = time.Second
+
+// ConfirmPolicy picks how each dangerous action is confirmed
+type ConfirmPolicy struct {
+	Delete       ConfirmStyle  // Deleting the selected or marked tasks
+	Complete     ConfirmStyle  // Completing marked tasks; one task is completed at once
+	ChordTimeout time.Duration // Longest wait for the second press of a chord
+}
+
+// NewConfirmPolicy builds a policy from configured style names; empty names
+// keep the modal
+func NewConfirmPolicy(deleteStyle, completeStyle string, timeout time.Duration) (ConfirmPolicy, error) {
+	p := ConfirmPolicy{ChordTimeout: timeout}
+	if p.ChordTimeout <= 0 {
+		p.ChordTimeout = DefaultChordTimeout
+	}
+	var err error
+	if p.Delete, err = parseConfirmStyle(deleteStyle); err != nil {
+		return ConfirmPolicy{}, fmt.Errorf("delete: %w", err)
+	}
+	if p.Complete, err = parseConfirmStyle(completeStyle); err != nil {
+		return ConfirmPolicy{}, fmt.Errorf("complete: %w", err)
+	}
+	return p, nil
+}
+
+// parseConfirmStyle checks a configured style name
+func parseConfirmStyle(name string) (ConfirmStyle, error) {
+	switch ConfirmStyle(name) {
+	case "", ConfirmModal:
+		return ConfirmModal, nil
+	case ConfirmChord:
+		return ConfirmChord, nil
+	}
+	return "", fmt.Errorf("unknown confirmation %q (must be %s or %s)", name, ConfirmModal, ConfirmChord)
+}
+
+// SetConfirmPolicy sets how dangerous actions are confirmed
+func (m Model) SetConfirmPolicy(p ConfirmPolicy) Model {
+	m.confirmPolicy = p
+	return m
+}
+
+// Chord actions
+const (
+	chordDelete   = "delete"
+	chordComplete = "complete"
+)
+
+// pendingChord is a dangerous action whose key was pressed once
+type pendingChord struct {
+	action string // chordDelete or chordComplete; empty when no chord is pending
+	taskID string // Task selected at the first press
+	id     int    // Tells this chord's timeout from a later chord's
+}
+
+// chordExpiredMsg ends a chord whose second press did not come in time
+type chordExpiredMsg struct {
+	id int
+}
+
+// handleKeySequence dispatches the keys of two-key sequences: goKey
+// prefixes, and chords confirming dangerous actions. Returns true if the key
+// was consumed.
+func (m Model) handleKeySequence(keyMsg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	m, cmd, handled := m.handleGoKey(keyMsg)
+	if handled {
+		return m, cmd, true
+	}
+	return m.handleChordKey(keyMsg)
+}
+
+// handleChordKey starts a chord on the first press of a dangerous action's
+// key and runs the action on the second. Any other key drops the chord and
+// is handled as usual.
+func (m Model) handleChordKey(keyMsg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	pending := m.chord
+	m.chord.action = ""
+
+	action := m.chordAction(keyMsg)
+	if action == "" {
+		return m, nil, false
+	}
+	taskID := ""
+	if task := m.getSelectedTask(); task != nil {
+		taskID = task.ID
+	}
+	if pending.action == action && pending.taskID == taskID {
+		newModel, cmd := m.runChord(action)
+		return newModel, cmd, true
+	}
+
+	target := m.chordTarget(action)
+	if target == "" {
+		return m, nil, false
+	}
+	m.chord = pendingChord{action: action, taskID: taskID, id: pending.id + 1}
+	id := m.chord.id
+	expire := tea.Tick(m.confirmPolicy.ChordTimeout, func(time.Time) tea.Msg {
+		return chordExpiredMsg{id: id}
+	})
+	newModel, toastCmd := m.pushToast(toast.Info, fmt.Sprintf("Press %s again to %s %s", keyMsg.String(), action, target))
+	return newModel, tea.Batch(toastCmd, expire), true
+}
+
+// chordAction returns the action whose key was pressed when its policy is
+// a chord, or an empty string
+func (m Model) chordAction(keyMsg tea.KeyMsg) string {
+	switch {
+	case m.confirmPolicy.Delete == ConfirmChord && key.Matches(keyMsg, m.keys.Delete):
+		return chordDelete
+	case m.confirmPolicy.Complete == ConfirmChord && key.Matches(keyMsg, m.keys.Complete) && len(m.getMarkedTasks()) > 0:
+		return chordComplete
+	}
+	return ""
+}
+
+// chordTarget describes the tasks a chord acts on, or returns an empty
+// string when there are none
+func (m Model) chordTarget(action string) string {
+	if marked := m.getMarkedTasks(); len(marked) > 0 {
+		return countLabel(len(marked), "task")
+	}
+	if task := m.getSelectedTask(); task != nil && action == chordDelete {
+		return fmt.Sprintf("%q", task.Name)
+	}
+	return ""
+}
+
+// runChord runs a confirmed chord's action without asking again
+func (m Model) runChord(action string) (Model, tea.Cmd) {
+	if marked := m.getMarkedTasks(); len(marked) > 0 {
+		op := domain.BatchOperation{Action: domain.BatchDelete}
+		if action == chordComplete {
+			op.Action = domain.BatchComplete
+		}
+		return m, m.batchModify(BatchContext{Operation: op, Tasks: marked})
+	}
+	task := m.getSelectedTask()
+	if task == nil || action != chordDelete {
+		return m, nil
+	}
+	return m, m.deleteTask(DeleteContext{TaskID: task.ID, TaskName: task.Name, Task: task})
+}
+
+// handleChordExpired drops the chord the message belongs to, if still pending
+func (m Model) handleChordExpired(msg chordExpiredMsg) Model {
+	if m.chord.id == msg.id {
+		m.chord.action = ""
+	}
+	return m
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// newChordTestApp creates an app with deleting and completing set to chords
+func newChordTestApp() (Model, *service.MockOmniFocusService) {
+	app := newCompleteTestApp(nil, "")
+	mockSvc := app.service.(*service.MockOmniFocusService)
+	mockSvc.DeleteResult = &domain.OperationResult{ID: "1", Success: true}
+	policy, _ := NewConfirmPolicy("chord", "chord", time.Second)
+	return app.SetConfirmPolicy(policy), mockSvc
+}
+
+func TestChord_DoublePressDeletesWithoutModal(t *testing.T) {
+	app, _ := newChordTestApp()
+
+	model, cmd := app.Update(runeKey('d'))
+	app = model.(Model)
+	if app.confirmModal.IsVisible() {
+		t.Fatal("d with a chord policy opened the confirmation modal")
+	}
+	if cmd == nil {
+		t.Fatal("the first d should show a toast and start the timeout")
+	}
+	if toasts := strings.Join(app.toasts.Messages(), "\n"); !strings.Contains(toasts, `Press d again to delete "One"`) {
+		t.Errorf("toasts = %q, want the chord hint", toasts)
+	}
+
+	model, cmd = app.Update(runeKey('d'))
+	app = model.(Model)
+	if cmd == nil {
+		t.Fatal("the second d should delete the task")
+	}
+	if msg, ok := cmd().(tui.TaskDeletedMsg); !ok || msg.TaskID != "1" || msg.Snapshot == nil {
+		t.Errorf("cmd() = %v, want TaskDeletedMsg for task 1 with its snapshot", msg)
+	}
+}
+
+func TestChord_OtherKeyCancels(t *testing.T) {
+	app, _ := newChordTestApp()
+
+	app = update(app, runeKey('d'))
+	app = update(app, runeKey('j'))
+	if app.chord.action != "" {
+		t.Fatalf("chord = %+v after another key, want none pending", app.chord)
+	}
+	if task := app.getSelectedTask(); task == nil || task.ID != "2" {
+		t.Errorf("selected task = %v, want j handled as usual", task)
+	}
+
+	model, _ := app.Update(runeKey('d'))
+	if model.(Model).chord.action != chordDelete {
+		t.Error("d after a cancelled chord should start a new one")
+	}
+}
+
+func TestChord_Expires(t *testing.T) {
+	app, _ := newChordTestApp()
+
+	model, _ := app.Update(runeKey('d'))
+	app = model.(Model)
+	stale := app.chord.id
+
+	model, _ = app.Update(chordExpiredMsg{id: stale})
+	app = model.(Model)
+	if app.chord.action != "" {
+		t.Fatalf("chord = %+v after the timeout, want none pending", app.chord)
+	}
+
+	// A second d after the timeout starts over instead of deleting
+	model, cmd := app.Update(runeKey('d'))
+	app = model.(Model)
+	if app.chord.action != chordDelete {
+		t.Errorf("chord = %+v, want a new delete chord", app.chord)
+	}
+	if _, ok := cmd().(tui.TaskDeletedMsg); ok {
+		t.Error("d after the timeout deleted the task")
+	}
+
+	// The old chord's timeout does not end the new chord
+	model, _ = app.Update(chordExpiredMsg{id: stale})
+	if model.(Model).chord.action != chordDelete {
+		t.Error("an earlier chord's timeout ended the new chord")
+	}
+}
+
+func TestChord_CompletesMarkedTasks(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks:  []domain.Task{{ID: "1", Name: "One"}, {ID: "2", Name: "Two"}, {ID: "3", Name: "Three"}},
+		BatchResult: &domain.BatchResult{},
+	}
+	app := newAppWithMarkedTasks(t, mockSvc)
+	policy, _ := NewConfirmPolicy("", "chord", 0)
+	app = app.SetConfirmPolicy(policy)
+
+	app = update(app, runeKey('c'))
+	if toasts := strings.Join(app.toasts.Messages(), "\n"); !strings.Contains(toasts, "Press c again to complete 2 tasks") {
+		t.Errorf("toasts = %q, want the chord hint", toasts)
+	}
+	app = update(app, runeKey('c'))
+	if app.confirmModal.IsVisible() {
+		t.Error("cc opened the confirmation modal")
+	}
+	if mockSvc.BatchOperation == nil || mockSvc.BatchOperation.Action != domain.BatchComplete {
+		t.Errorf("BatchOperation = %+v, want the marked tasks completed", mockSvc.BatchOperation)
+	}
+}
+
+func TestChord_ModalPolicyKeepsModal(t *testing.T) {
+	app := newCompleteTestApp(nil, "")
+
+	app = update(app, runeKey('d'))
+	if !app.confirmModal.IsVisible() {
+		t.Error("d with the default policy should open the confirmation modal")
+	}
+}
+
+func TestNewConfirmPolicy(t *testing.T) {
+	p, err := NewConfirmPolicy("chord", "", 0)
+	if err != nil {
+		t.Fatalf("NewConfirmPolicy() error = %v", err)
+	}
+	want := ConfirmPolicy{Delete: ConfirmChord, Complete: ConfirmModal, ChordTimeout: DefaultChordTimeout}
+	if p != want {
+		t.Errorf("NewConfirmPolicy() = %+v, want %+v", p, want)
+	}
+
+	if _, err := NewConfirmPolicy("double", "", 0); err == nil || !strings.Contains(err.Error(), "delete") {
+		t.Errorf("NewConfirmPolicy(double) error = %v, want an error naming delete", err)
+	}
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// completeTask shows a task as completed at once and completes it in the
+// background; a failure marks it not completed again, with a conflict
+func (m Model) completeTask(taskID, taskName string) (Model, tea.Cmd) {
+	svc := m.service
+	return m.completeOptimistically(taskID, taskName, func() (*domain.OperationResult, error) {
+		return svc.CompleteTask(context.Background(), taskID)
+	})
+}
+
+// completeTaskWithNote is completeTask with a closing note added to the task first
+func (m Model) completeTaskWithNote(taskID, taskName, note string) (Model, tea.Cmd) {
+	svc := m.service
+	return m.completeOptimistically(taskID, taskName, func() (*domain.OperationResult, error) {
+		return service.CompleteTaskWithNote(context.Background(), svc, taskID, note)
+	})
+}
+
+// completeOptimistically marks a task completed in every view and runs
+// complete in the background
+func (m Model) completeOptimistically(taskID, taskName string, complete func() (*domain.OperationResult, error)) (Model, tea.Cmd) {
+	completed := true
+	return m.applyOptimistically(taskID, localChange{TaskName: taskName, Completed: &completed}, func() (tea.Msg, error) {
+		result, err := complete()
+		if err != nil {
+			return nil, err
+		}
+		return tui.TaskCompletedMsg{
+			TaskID:   result.ID,
+			TaskName: taskName,
+		}, nil
+	})
+}
+
+// uncompleteTask shows a completed task as open at once and reopens it in
+// the background; a failure marks it completed again, with a conflict
+func (m Model) uncompleteTask(taskID, taskName string) (Model, tea.Cmd) {
+	svc := m.service
+	completed := false
+	return m.applyOptimistically(taskID, localChange{TaskName: taskName, Completed: &completed}, func() (tea.Msg, error) {
+		if _, err := svc.UncompleteTask(context.Background(), taskID); err != nil {
+			return nil, err
+		}
+		return tui.TaskUncompletedMsg{TaskID: taskID, TaskName: taskName}, nil
+	})
+}
+
+// toggleCompleted shows or hides the tasks completed in the last
+// tui.CompletedDays days in the Inbox, Projects and Tags views
+func (m Model) toggleCompleted() (Model, tea.Cmd) {
+	m.showCompleted = !m.showCompleted
+	m.inboxView = m.inboxView.SetShowCompleted(m.showCompleted)
+	m.projectsView = m.projectsView.SetShowCompleted(m.showCompleted)
+	m.tagsView = m.tagsView.SetShowCompleted(m.showCompleted)
+
+	text := "Hiding completed tasks"
+	if m.showCompleted {
+		text = fmt.Sprintf("Showing tasks completed in the last %d days", tui.CompletedDays)
+	}
+	return m.refreshWithToast(toast.Info, text)
+}
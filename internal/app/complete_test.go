@@ -0,0 +1,126 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func newCompleteTestApp(completeErr error, completedID string) Model {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks:      []domain.Task{{ID: "1", Name: "One"}, {ID: "2", Name: "Two"}},
+		CompleteResult:  &domain.OperationResult{ID: completedID, Success: true},
+		CompleteTaskErr: completeErr,
+	}
+	app := NewApp(mockSvc)
+	app.width = 80
+	app.height = 24
+	app.ready = true
+	model, _ := app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	return model.(Model)
+}
+
+func TestComplete_ShowsTaskCompletedBeforeOmniFocusConfirms(t *testing.T) {
+	app := newCompleteTestApp(nil, "1")
+
+	model, cmd := app.Update(runeKey('c'))
+	app = model.(Model)
+	if task := app.getSelectedTask(); task == nil || !task.Completed {
+		t.Fatalf("selected task = %v, want it shown completed at once", task)
+	}
+	if cmd == nil {
+		t.Fatal("c should return a command completing the task")
+	}
+	if msg, ok := cmd().(tui.TaskCompletedMsg); !ok || msg.TaskID != "1" {
+		t.Errorf("cmd() = %v, want TaskCompletedMsg for task 1", msg)
+	}
+}
+
+func TestComplete_RollsBackWhenCompleteFails(t *testing.T) {
+	app := newCompleteTestApp(errors.New("OmniFocus is not running"), "")
+
+	model, cmd := app.Update(runeKey('c'))
+	app = model.(Model)
+	msg := cmd()
+	if _, ok := msg.(changeFailedMsg); !ok {
+		t.Fatalf("cmd() = %T, want changeFailedMsg", msg)
+	}
+
+	model, _ = app.Update(msg)
+	app = model.(Model)
+	if task := app.getSelectedTask(); task == nil || task.Completed {
+		t.Errorf("selected task = %v, want it not completed after the failure", task)
+	}
+	toasts := strings.Join(app.toasts.Messages(), "\n")
+	if !strings.Contains(toasts, `Failed to complete "One": OmniFocus is not running`) {
+		t.Errorf("toasts = %q, want the failure", toasts)
+	}
+}
+
+func TestCompleteClickedMsg_CompletesTask(t *testing.T) {
+	app := newCompleteTestApp(nil, "2")
+
+	model, cmd := app.Update(tui.CompleteClickedMsg{Task: domain.Task{ID: "2", Name: "Two"}})
+	app = model.(Model)
+	app.inboxView = app.inboxView.SelectTask("2")
+	if task := app.getSelectedTask(); task == nil || !task.Completed {
+		t.Errorf("task 2 = %v, want it shown completed", task)
+	}
+	if msg, ok := cmd().(tui.TaskCompletedMsg); !ok || msg.TaskID != "2" {
+		t.Errorf("cmd() = %v, want TaskCompletedMsg for task 2", msg)
+	}
+}
+
+func TestComplete_ReopensCompletedTask(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks:       []domain.Task{{ID: "1", Name: "One", Completed: true}},
+		UncompleteResult: &domain.OperationResult{ID: "1", Success: true},
+	}
+	app := NewApp(mockSvc)
+	model, _ := app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	app = model.(Model)
+
+	model, cmd := app.Update(runeKey('c'))
+	app = model.(Model)
+	if task := app.getSelectedTask(); task == nil || task.Completed {
+		t.Fatalf("selected task = %v, want it shown open at once", task)
+	}
+	if msg, ok := cmd().(tui.TaskUncompletedMsg); !ok || msg.TaskID != "1" {
+		t.Fatalf("cmd() = %v, want TaskUncompletedMsg for task 1", msg)
+	}
+
+	model, _ = app.Update(tui.TaskUncompletedMsg{TaskID: "1", TaskName: "One"})
+	app = model.(Model)
+	if toasts := strings.Join(app.toasts.Messages(), "\n"); !strings.Contains(toasts, `Reopened "One"`) {
+		t.Errorf("toasts = %q, want the task reported reopened", toasts)
+	}
+}
+
+func TestToggleCompleted(t *testing.T) {
+	done := time.Now()
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks:     []domain.Task{{ID: "1", Name: "One"}},
+		CompletedTasks: []domain.Task{{ID: "2", Name: "Two", Completed: true, CompletedDate: &done}},
+	}
+	app := NewApp(mockSvc)
+
+	model, _ := app.Update(runeKey('H'))
+	app = model.(Model)
+	if !app.showCompleted {
+		t.Fatal("H should show completed tasks")
+	}
+	app = update(app, app.inboxView.Refresh()())
+	if app.inboxView.TaskCount() != 2 {
+		t.Errorf("inbox lists %d tasks, want the completed task too", app.inboxView.TaskCount())
+	}
+
+	model, _ = app.Update(runeKey('H'))
+	if model.(Model).showCompleted {
+		t.Error("a second H should hide completed tasks")
+	}
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/log"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// executeDebugCommand toggles the debug log for the rest of the session
+func (m Model) executeDebugCommand() (Model, tea.Cmd) {
+	if log.Enabled() {
+		if err := log.Disable(); err != nil {
+			return m.pushToast(toast.Error, err.Error())
+		}
+		return m.pushToast(toast.Info, "Debug logging off")
+	}
+
+	if err := log.Enable(m.debugLog); err != nil {
+		m.err = err
+		return m.pushToast(toast.Error, err.Error())
+	}
+	log.Logger().Debug("debug logging enabled from the TUI")
+	return m.pushToast(toast.Info, "Debug logging to "+m.debugLog)
+}
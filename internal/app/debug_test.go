@@ -0,0 +1,27 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/log"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+)
+
+func TestDebugCommand_TogglesLog(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{})
+	app.debugLog = filepath.Join(t.TempDir(), "debug.log")
+	t.Cleanup(func() { _ = log.Disable() })
+	cmd, _ := command.NewParser().Parse("debug")
+
+	app, _ = app.executeCommand(cmd)
+	if !log.Enabled() || log.Path() != app.debugLog {
+		t.Fatalf("after :debug, enabled = %v at %q, want logging to %q", log.Enabled(), log.Path(), app.debugLog)
+	}
+
+	_, _ = app.executeCommand(cmd)
+	if log.Enabled() {
+		t.Error("a second :debug should turn logging off")
+	}
+}
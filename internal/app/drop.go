@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// DropContext stores context for drop confirmation
+type DropContext struct {
+	ID      string
+	Name    string
+	Project bool // Drop the project with ID rather than a task
+}
+
+// executeDropCommand confirms dropping the marked tasks, the selected task
+// or, in the projects list, the selected project
+func (m Model) executeDropCommand() (Model, tea.Cmd) {
+	if marked := m.getMarkedTasks(); len(marked) > 0 {
+		op := domain.BatchOperation{Action: domain.BatchDrop}
+		return m.confirmBatch("Drop Tasks", "Drop", op, marked), nil
+	}
+	if task := m.getSelectedTask(); task != nil {
+		m.confirmModal = m.confirmModal.ShowWithContext(
+			"Drop Task",
+			fmt.Sprintf("Drop \"%s\"?", task.Name),
+			DropContext{ID: task.ID, Name: task.Name},
+		)
+		return m, nil
+	}
+	if m.currentView != tui.ViewProjects {
+		return m, nil
+	}
+	if project := m.projectsView.SelectedProject(); project != nil {
+		m.confirmModal = m.confirmModal.ShowWithContext(
+			"Drop Project",
+			fmt.Sprintf("Drop \"%s\" and its remaining tasks?", project.Name),
+			DropContext{ID: project.ID, Name: project.Name, Project: true},
+		)
+	}
+	return m, nil
+}
+
+// drop creates a command to drop the task or project described by the
+// confirmation context
+func (m Model) drop(ctx DropContext) tea.Cmd {
+	svc := m.service
+	return func() tea.Msg {
+		if ctx.Project {
+			if _, err := svc.DropProject(context.Background(), ctx.ID); err != nil {
+				return tui.ErrorMsg{Err: err}
+			}
+			return tui.ProjectDroppedMsg{ProjectID: ctx.ID, ProjectName: ctx.Name}
+		}
+		if _, err := svc.DropTask(context.Background(), ctx.ID); err != nil {
+			return tui.ErrorMsg{Err: err}
+		}
+		return tui.TaskDroppedMsg{TaskID: ctx.ID, TaskName: ctx.Name}
+	}
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/confirm"
+)
+
+// confirmAndRun accepts the visible confirmation and returns the app and the
+// message of the command it starts
+func confirmAndRun(t *testing.T, app Model) (Model, tea.Msg) {
+	t.Helper()
+	model, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = model.(Model)
+	if cmd == nil {
+		t.Fatal("expected a command from the confirmation")
+	}
+	confirmed, ok := cmd().(confirm.ConfirmedMsg)
+	if !ok {
+		t.Fatal("expected ConfirmedMsg")
+	}
+	model, cmd = app.Update(confirmed)
+	if cmd == nil {
+		t.Fatal("expected a command after confirmation")
+	}
+	return model.(Model), cmd()
+}
+
+func TestDrop_ConfirmsAndDropsSelectedTask(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "task1", Name: "Old idea"}},
+		DropResult: &domain.OperationResult{ID: "task1", Success: true},
+	}
+	app := NewApp(mockSvc)
+	app = update(app, tea.WindowSizeMsg{Width: 80, Height: 24})
+	app = update(app, tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+
+	model, _ := app.Update(runeKey('x'))
+	app = model.(Model)
+	if !app.confirmModal.IsVisible() || !strings.Contains(app.confirmModal.View(), `Drop "Old idea"?`) {
+		t.Fatalf("x should ask to drop the task, got: %s", app.confirmModal.View())
+	}
+
+	app, msg := confirmAndRun(t, app)
+	if dropped, ok := msg.(tui.TaskDroppedMsg); !ok || dropped.TaskID != "task1" {
+		t.Fatalf("msg = %#v, want TaskDroppedMsg for task1", msg)
+	}
+	if len(mockSvc.DroppedIDs) != 1 || mockSvc.DroppedIDs[0] != "task1" {
+		t.Errorf("DropTask() called with %v, want [task1]", mockSvc.DroppedIDs)
+	}
+
+	app = update(app, msg)
+	if toasts := strings.Join(app.toasts.Messages(), "\n"); !strings.Contains(toasts, `Dropped "Old idea"`) {
+		t.Error("expected a toast naming the dropped task")
+	}
+}
+
+func TestDrop_DropsSelectedProjectInProjectsList(t *testing.T) {
+	projects := []domain.Project{{ID: "proj1", Name: "Someday", Status: "active"}}
+	mockSvc := &service.MockOmniFocusService{
+		Projects:          projects,
+		DropProjectResult: &domain.OperationResult{ID: "proj1", Success: true},
+	}
+	app := NewApp(mockSvc)
+	app = update(app, tea.WindowSizeMsg{Width: 80, Height: 24})
+	model, _ := app.Update(runeKey('2'))
+	app = model.(Model)
+	app = update(app, tui.ProjectsLoadedMsg{Projects: projects})
+
+	model, _ = app.Update(runeKey('x'))
+	app = model.(Model)
+	if !app.confirmModal.IsVisible() || !strings.Contains(app.confirmModal.View(), "Drop Project") {
+		t.Fatalf("x should ask to drop the project, got: %s", app.confirmModal.View())
+	}
+
+	_, msg := confirmAndRun(t, app)
+	if dropped, ok := msg.(tui.ProjectDroppedMsg); !ok || dropped.ProjectName != "Someday" {
+		t.Fatalf("msg = %#v, want ProjectDroppedMsg for Someday", msg)
+	}
+	if len(mockSvc.DroppedProjectIDs) != 1 || mockSvc.DroppedProjectIDs[0] != "proj1" {
+		t.Errorf("DropProject() called with %v, want [proj1]", mockSvc.DroppedProjectIDs)
+	}
+}
+
+func TestBulkDrop_UsesDropAction(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "First"},
+			{ID: "task2", Name: "Second"},
+		},
+		BatchResult: &domain.BatchResult{},
+	}
+	app := newAppWithMarkedTasks(t, mockSvc)
+
+	model, _ := app.Update(runeKey('x'))
+	app = model.(Model)
+	if !strings.Contains(app.confirmModal.View(), "Drop 2 tasks?") {
+		t.Fatalf("expected drop summary, got: %s", app.confirmModal.View())
+	}
+
+	_, msg := confirmAndRun(t, app)
+	if _, ok := msg.(tui.BatchCompletedMsg); !ok {
+		t.Fatal("expected BatchCompletedMsg from batch command")
+	}
+	if mockSvc.BatchOperation == nil || mockSvc.BatchOperation.Action != domain.BatchDrop {
+		t.Errorf("expected BatchDrop operation, got %+v", mockSvc.BatchOperation)
+	}
+}
@@ -1,12 +1,15 @@
 package app
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/searchinput"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
 )
@@ -46,6 +49,41 @@ func TestFilterIntegration_SearchText(t *testing.T) {
 	}
 }
 
+// TestFilterIntegration_RegexNamesOnly tests that a regex search can leave
+// out task notes
+func TestFilterIntegration_RegexNamesOnly(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "1", Name: "Call mom"},
+			{ID: "2", Name: "Plan trip", Note: "call the hotel"},
+			{ID: "3", Name: "Recall order"},
+		},
+	}
+
+	app := NewApp(mockSvc)
+	app.width = 80
+	app.height = 24
+	app.ready = true
+
+	model, _ := app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	app = model.(Model)
+
+	model, _ = app.Update(searchinput.SearchChangedMsg{Text: "re:^call"})
+	app = model.(Model)
+	if app.inboxView.TaskCount() != 2 {
+		t.Errorf("Expected 2 tasks starting with call in name or note, got %d", app.inboxView.TaskCount())
+	}
+
+	model, _ = app.Update(searchinput.SearchChangedMsg{Text: "re:^call", NamesOnly: true})
+	app = model.(Model)
+	if !app.filterState.NamesOnly {
+		t.Error("Expected filter state to search names only")
+	}
+	if app.inboxView.TaskCount() != 1 {
+		t.Errorf("Expected 1 task starting with call in its name, got %d", app.inboxView.TaskCount())
+	}
+}
+
 // TestFilterIntegration_ClearFilter tests that clearing filters works
 func TestFilterIntegration_ClearFilter(t *testing.T) {
 	mockSvc := &service.MockOmniFocusService{
@@ -147,6 +185,143 @@ func TestFilterIntegration_FlaggedFilter(t *testing.T) {
 	}
 }
 
+// TestFilterIntegration_AvailableCommand tests that :available hides deferred and blocked tasks
+func TestFilterIntegration_AvailableCommand(t *testing.T) {
+	future := time.Now().Add(48 * time.Hour)
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "1", Name: "Available task"},
+			{ID: "2", Name: "Deferred task", DeferDate: &future},
+			{ID: "3", Name: "Group", Children: []domain.Task{{ID: "3a", Name: "Step", ParentID: "3"}}},
+		},
+	}
+
+	app := NewApp(mockSvc)
+	app.width = 80
+	app.height = 24
+	app.ready = true
+	model, _ := app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	app = model.(Model)
+
+	cmd, err := command.NewParser().Parse("available")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	app, _ = app.executeCommand(cmd)
+
+	// The group waits on its step, so only the plain task and the step remain
+	if app.inboxView.TaskCount() != 2 {
+		t.Errorf("Expected 2 available tasks, got %d", app.inboxView.TaskCount())
+	}
+}
+
+// TestFilterIntegration_LowEnergyCommand tests that :low-energy shows only low effort tasks
+func TestFilterIntegration_LowEnergyCommand(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "1", Name: "Water plants", Note: "effort: low"},
+			{ID: "2", Name: "Write report", Note: "effort: high"},
+			{ID: "3", Name: "No effort"},
+		},
+	}
+
+	app := NewApp(mockSvc)
+	app.width = 80
+	app.height = 24
+	app.ready = true
+	model, _ := app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	app = model.(Model)
+
+	cmd, err := command.NewParser().Parse("low")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	app, _ = app.executeCommand(cmd)
+
+	if app.inboxView.TaskCount() != 1 {
+		t.Errorf("Expected 1 low effort task, got %d", app.inboxView.TaskCount())
+	}
+}
+
+// TestFilterIntegration_TimeCommand tests that :time shows tasks that fit the slot
+func TestFilterIntegration_TimeCommand(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "1", Name: "Reply to email", EstimatedMinutes: 10},
+			{ID: "2", Name: "Write report", EstimatedMinutes: 120},
+			{ID: "3", Name: "Not estimated"},
+		},
+	}
+
+	app := NewApp(mockSvc)
+	app.width = 80
+	app.height = 24
+	app.ready = true
+	model, _ := app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	app = model.(Model)
+
+	cmd, err := command.NewParser().Parse("time <30m")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	app, _ = app.executeCommand(cmd)
+
+	if app.filterState.MaxMinutes != 30 {
+		t.Errorf("MaxMinutes = %d, want 30", app.filterState.MaxMinutes)
+	}
+	if app.inboxView.TaskCount() != 1 {
+		t.Errorf("Expected 1 task that fits in 30m, got %d", app.inboxView.TaskCount())
+	}
+
+	cmd, _ = command.NewParser().Parse("time off")
+	app, _ = app.executeCommand(cmd)
+	if app.inboxView.TaskCount() != 3 {
+		t.Errorf("Expected all 3 tasks after :time off, got %d", app.inboxView.TaskCount())
+	}
+
+	cmd, _ = command.NewParser().Parse("time later")
+	app, _ = app.executeCommand(cmd)
+	if app.err == nil {
+		t.Error("Expected an error for an invalid time")
+	}
+}
+
+func TestFilterIntegration_SavedFilterCommand(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "1", Name: "Buy milk", Tags: []string{"Errands"}},
+			{ID: "2", Name: "Write report", Tags: []string{"Work"}},
+		},
+	}
+
+	app := NewApp(mockSvc)
+	app.width = 80
+	app.height = 24
+	app.ready = true
+	app.savedFilters = filepath.Join(t.TempDir(), "filters.json")
+	if err := (filter.Saved{"Errands": {TagID: "Errands"}}).Save(app.savedFilters); err != nil {
+		t.Fatal(err)
+	}
+	model, _ := app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	app = model.(Model)
+
+	cmd, err := command.NewParser().Parse("filter errands")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	app, _ = app.executeCommand(cmd)
+
+	if app.inboxView.TaskCount() != 1 {
+		t.Errorf("Expected 1 task after applying saved filter, got %d", app.inboxView.TaskCount())
+	}
+
+	cmd, _ = command.NewParser().Parse("filter missing")
+	app, _ = app.executeCommand(cmd)
+	if app.err == nil || !strings.Contains(app.err.Error(), "saved filter not found") {
+		t.Errorf("Expected saved filter not found error, got %v", app.err)
+	}
+}
+
 // TestFilterIntegration_ProjectFilter tests that project filters work
 func TestFilterIntegration_ProjectFilter(t *testing.T) {
 	mockSvc := &service.MockOmniFocusService{
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/filterpicker"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
+)
+
+// showFilterPicker opens the saved filter picker
+func (m Model) showFilterPicker() (Model, tea.Cmd) {
+	saved, err := filter.LoadSaved(m.savedFilters)
+	if err != nil {
+		m.err = err
+		return m.pushToast(toast.Error, err.Error())
+	}
+	m.filterPicker = m.filterPicker.Show(saved)
+	return m, nil
+}
+
+// executeSaveFilterCommand handles the "save-filter" command
+func (m Model) executeSaveFilterCommand(cmd *command.Command) (Model, tea.Cmd) {
+	name := strings.Join(cmd.Args, " ")
+	if name == "" {
+		return m.pushToast(toast.Error, "save-filter needs a name")
+	}
+	if !m.filterState.IsActive() {
+		return m.pushToast(toast.Error, "No filter to save")
+	}
+
+	err := m.updateSavedFilters(func(saved filter.Saved) {
+		// Replace a filter saved under the same name in another case
+		for savedName := range saved {
+			if strings.EqualFold(savedName, name) {
+				delete(saved, savedName)
+			}
+		}
+		saved[name] = m.filterState
+	})
+	if err != nil {
+		m.err = err
+		return m.pushToast(toast.Error, err.Error())
+	}
+	return m.pushToast(toast.Success, fmt.Sprintf("Saved filter %q", name))
+}
+
+// handleFilterPickerMessages handles messages from the saved filter picker
+func (m Model) handleFilterPickerMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case filterpicker.AppliedMsg:
+		m.filterState = msg.State
+		m = m.applyFilterToCurrentView()
+		return m.withToast(toast.Info, fmt.Sprintf("Filter %q applied", msg.Name))
+
+	case filterpicker.DeletedMsg:
+		err := m.updateSavedFilters(func(saved filter.Saved) {
+			delete(saved, msg.Name)
+		})
+		if err != nil {
+			m.err = err
+			return m.withToast(toast.Error, err.Error())
+		}
+		return m.withToast(toast.Success, fmt.Sprintf("Deleted filter %q", msg.Name))
+
+	case filterpicker.CancelledMsg:
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// updateSavedFilters changes the saved filters file
+func (m Model) updateSavedFilters(change func(filter.Saved)) error {
+	saved, err := filter.LoadSaved(m.savedFilters)
+	if err != nil {
+		return err
+	}
+	change(saved)
+	return saved.Save(m.savedFilters)
+}
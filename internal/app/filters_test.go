@@ -0,0 +1,110 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
+)
+
+func newFiltersTestApp(t *testing.T) Model {
+	t.Helper()
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "1", Name: "Buy milk", Tags: []string{"Errands"}, Flagged: true},
+			{ID: "2", Name: "Write report", Tags: []string{"Work"}},
+		},
+	}
+	app := NewApp(mockSvc)
+	app.savedFilters = filepath.Join(t.TempDir(), "filters.json")
+	model, _ := app.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model, _ = model.(Model).Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	return model.(Model)
+}
+
+// update feeds msg to the app and then every message its commands produce
+func update(app Model, msg tea.Msg) Model {
+	model, cmd := app.Update(msg)
+	app = model.(Model)
+	if cmd != nil {
+		if next := cmd(); next != nil {
+			if _, batch := next.(tea.BatchMsg); !batch {
+				model, _ = app.Update(next)
+				app = model.(Model)
+			}
+		}
+	}
+	return app
+}
+
+func TestSaveFilterCommand(t *testing.T) {
+	app := newFiltersTestApp(t)
+	app.filterState = filter.State{FlaggedOnly: true}
+
+	cmd, _ := command.NewParser().Parse("save-filter work today")
+	app, _ = app.executeCommand(cmd)
+
+	saved, err := filter.LoadSaved(app.savedFilters)
+	if err != nil {
+		t.Fatalf("LoadSaved() error = %v", err)
+	}
+	if state, ok := saved.Find("work today"); !ok || !state.FlaggedOnly {
+		t.Errorf("saved filters = %+v, want flagged-only filter named work today", saved)
+	}
+}
+
+func TestSaveFilterCommand_NothingToSave(t *testing.T) {
+	app := newFiltersTestApp(t)
+
+	cmd, _ := command.NewParser().Parse("save-filter empty")
+	app, _ = app.executeCommand(cmd)
+
+	saved, _ := filter.LoadSaved(app.savedFilters)
+	if len(saved) != 0 {
+		t.Errorf("saved filters = %+v, want none saved for an inactive filter", saved)
+	}
+}
+
+func TestFilterPicker_AppliesAndDeletes(t *testing.T) {
+	app := newFiltersTestApp(t)
+	saved := filter.Saved{"Errands": {TagID: "Errands"}, "Work": {TagID: "Work"}}
+	if err := saved.Save(app.savedFilters); err != nil {
+		t.Fatal(err)
+	}
+
+	app = update(app, runeKey('F'))
+	if !app.filterPicker.IsVisible() {
+		t.Fatal("F should open the saved filter picker")
+	}
+
+	// Delete "Errands", then apply "Work"
+	app = update(app, runeKey('d'))
+	app = update(app, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if app.filterPicker.IsVisible() {
+		t.Error("picker should close after applying a filter")
+	}
+	if app.filterState.TagID != "Work" || app.inboxView.TaskCount() != 1 {
+		t.Errorf("filterState = %+v with %d tasks, want Work filter with 1 task", app.filterState, app.inboxView.TaskCount())
+	}
+	remaining, _ := filter.LoadSaved(app.savedFilters)
+	if _, ok := remaining["Errands"]; ok || len(remaining) != 1 {
+		t.Errorf("saved filters = %+v, want only Work", remaining)
+	}
+}
+
+func TestFilterCommand_NoArgsOpensPicker(t *testing.T) {
+	app := newFiltersTestApp(t)
+
+	cmd, _ := command.NewParser().Parse("filter")
+	app, _ = app.executeCommand(cmd)
+
+	if !app.filterPicker.IsVisible() {
+		t.Error(":filter without a name should open the saved filter picker")
+	}
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// Keys that start recording and playback; both are followed by a register (a-z).
+// Recording uses Q rather than vim's q, which quits.
+const (
+	macroRecordKey = "Q"
+	macroPlayKey   = "@"
+	macroStopKey   = "q"
+)
+
+// macroDepthLimit stops macros that replay each other endlessly
+const macroDepthLimit = 10
+
+// macroState records key presses into registers and replays them
+type macroState struct {
+	registers map[string][]tea.KeyMsg
+	pending   string       // macroRecordKey or macroPlayKey while waiting for a register
+	recording string       // Register being recorded, empty when idle
+	keys      []tea.KeyMsg // Keys recorded so far
+	last      string       // Last replayed register, replayed again by @@
+	depth     int          // Nesting of replays in progress
+}
+
+// isMacroRegister reports whether s names a macro register
+func isMacroRegister(s string) bool {
+	return len(s) == 1 && s[0] >= 'a' && s[0] <= 'z'
+}
+
+// handleMacroKey starts, stops and replays macros, and records keys while a
+// macro is being recorded. Returns true if the key was consumed.
+func (m Model) handleMacroKey(keyMsg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	k := keyMsg.String()
+
+	if prefix := m.macros.pending; prefix != "" {
+		m.macros.pending = ""
+		if prefix == macroPlayKey {
+			m = m.recordMacroKey(keyMsg)
+			if k == macroPlayKey {
+				k = m.macros.last
+			}
+		}
+		if !isMacroRegister(k) {
+			// Any other key cancels
+			return m, nil, true
+		}
+		if prefix == macroRecordKey {
+			m.macros.recording = k
+			m.macros.keys = nil
+			return m.withToast(toast.Info, fmt.Sprintf("Recording macro @%s (q to stop)", k))
+		}
+		var cmd tea.Cmd
+		m, cmd = m.playMacro(k, 1)
+		return m, cmd, true
+	}
+
+	// Keys typed into overlays and inputs are recorded but never start or stop macros
+	if !m.overlayOpen() {
+		switch {
+		case m.macros.recording != "" && (k == macroStopKey || k == macroRecordKey):
+			return m.stopMacro()
+		case m.macros.recording == "" && k == macroRecordKey:
+			m.macros.pending = k
+			return m, nil, true
+		case k == macroPlayKey:
+			m = m.recordMacroKey(keyMsg)
+			m.macros.pending = k
+			return m, nil, true
+		}
+	}
+
+	return m.recordMacroKey(keyMsg), nil, false
+}
+
+// recordMacroKey appends a key to the macro being recorded. Keys replayed
+// from another macro are not recorded; the @ keys that replayed them are.
+func (m Model) recordMacroKey(keyMsg tea.KeyMsg) Model {
+	if m.macros.recording != "" && m.macros.depth == 0 {
+		m.macros.keys = append(append([]tea.KeyMsg{}, m.macros.keys...), keyMsg)
+	}
+	return m
+}
+
+// stopMacro stores the recorded keys in the register being recorded
+func (m Model) stopMacro() (Model, tea.Cmd, bool) {
+	reg := m.macros.recording
+	registers := make(map[string][]tea.KeyMsg, len(m.macros.registers)+1)
+	for name, keys := range m.macros.registers {
+		registers[name] = keys
+	}
+	registers[reg] = m.macros.keys
+	m.macros.registers = registers
+	m.macros.recording = ""
+	m.macros.keys = nil
+
+	return m.withToast(toast.Success, fmt.Sprintf("Recorded macro @%s (%d keys)", reg, len(registers[reg])))
+}
+
+// playMacro feeds the keys of a register through Update count times. The
+// commands the keys produce run in order once the replay has finished, so a
+// key never sees the result of an OmniFocus call an earlier key started: a j
+// after completing a task moves within the list as it was before the refresh.
+// Waiting for each key's commands instead would also wait for their timers,
+// expiring a chord between its keys and holding every step for its toast.
+func (m Model) playMacro(reg string, count int) (Model, tea.Cmd) {
+	keys, ok := m.macros.registers[reg]
+	if !ok || len(keys) == 0 {
+		return m.pushToast(toast.Info, fmt.Sprintf("Macro @%s is empty", reg))
+	}
+	if reg == m.macros.recording {
+		return m.pushToast(toast.Error, fmt.Sprintf("Cannot replay macro @%s while recording it", reg))
+	}
+	if m.macros.depth >= macroDepthLimit {
+		return m.pushToast(toast.Error, fmt.Sprintf("Macro @%s nests too deeply", reg))
+	}
+
+	m.macros.last = reg
+	m.macros.depth++
+	var cmds []tea.Cmd
+	for i := 0; i < count; i++ {
+		for _, keyMsg := range keys {
+			next, cmd := m.Update(keyMsg)
+			m = next.(Model)
+			cmds = append(cmds, cmd)
+		}
+	}
+	m.macros.depth--
+
+	return m, tea.Sequence(cmds...)
+}
+
+// executeReplayCommand handles the "replay" command
+func (m Model) executeReplayCommand(cmd *command.Command) (Model, tea.Cmd) {
+	if len(cmd.Args) == 0 {
+		return m, nil
+	}
+
+	reg := strings.TrimPrefix(cmd.Args[0], macroPlayKey)
+	if !isMacroRegister(reg) {
+		m.err = fmt.Errorf("invalid macro register: %s", cmd.Args[0])
+		return m, nil
+	}
+
+	count := 1
+	if len(cmd.Args) > 1 {
+		n, err := strconv.Atoi(cmd.Args[1])
+		if err != nil || n < 1 {
+			m.err = fmt.Errorf("invalid replay count: %s", cmd.Args[1])
+			return m, nil
+		}
+		count = n
+	}
+
+	return m.playMacro(reg, count)
+}
+
+// withToast shows a notification from a handler that reports whether it consumed a message
+func (m Model) withToast(level toast.Level, text string) (Model, tea.Cmd, bool) {
+	m, cmd := m.pushToast(level, text)
+	return m, cmd, true
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/projectpicker"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func newMacroTestApp() Model {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "1", Name: "One"}, {ID: "2", Name: "Two"}, {ID: "3", Name: "Three"}, {ID: "4", Name: "Four"},
+		},
+	}
+	app := NewApp(mockSvc)
+	app.width = 80
+	app.height = 24
+	app.ready = true
+	model, _ := app.Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	return model.(Model)
+}
+
+func pressKeys(t *testing.T, app Model, keys string) Model {
+	t.Helper()
+	for _, r := range keys {
+		model, _ := app.Update(runeKey(r))
+		app = model.(Model)
+	}
+	return app
+}
+
+func TestMacro_RecordAndReplay(t *testing.T) {
+	app := newMacroTestApp()
+
+	app = pressKeys(t, app, "Qa")
+	if app.macros.recording != "a" {
+		t.Fatalf("recording = %q, want a", app.macros.recording)
+	}
+	app = pressKeys(t, app, "jq")
+	if app.macros.recording != "" || len(app.macros.registers["a"]) != 1 {
+		t.Fatalf("registers = %v, want one key in a after q", app.macros.registers)
+	}
+	if got := app.inboxView.SelectedTask().ID; got != "2" {
+		t.Errorf("SelectedTask() = %s after recording, want 2", got)
+	}
+
+	app = pressKeys(t, app, "@a")
+	if got := app.inboxView.SelectedTask().ID; got != "3" {
+		t.Errorf("SelectedTask() = %s after @a, want 3", got)
+	}
+
+	app = pressKeys(t, app, "@@")
+	if got := app.inboxView.SelectedTask().ID; got != "4" {
+		t.Errorf("SelectedTask() = %s after @@, want 4", got)
+	}
+}
+
+func TestMacro_ReplayCommandWithCount(t *testing.T) {
+	app := pressKeys(t, newMacroTestApp(), "Qbjq")
+
+	cmd, err := command.NewParser().Parse("replay b 2")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	app, _ = app.executeCommand(cmd)
+
+	if got := app.inboxView.SelectedTask().ID; got != "4" {
+		t.Errorf("SelectedTask() = %s after replaying twice, want 4", got)
+	}
+
+	cmd, _ = command.NewParser().Parse("replay b zero")
+	app, _ = app.executeCommand(cmd)
+	if app.err == nil || !strings.Contains(app.err.Error(), "invalid replay count") {
+		t.Errorf("Expected invalid count error, got %v", app.err)
+	}
+}
+
+func TestMacro_SelfReplayStops(t *testing.T) {
+	app := pressKeys(t, newMacroTestApp(), "Qcj@cq")
+
+	if keys := app.macros.registers["c"]; len(keys) != 3 {
+		t.Fatalf("register c has %d keys, want j, @ and c", len(keys))
+	}
+
+	app = pressKeys(t, app, "@c")
+	if app.macros.depth != 0 {
+		t.Errorf("depth = %d after replay, want 0", app.macros.depth)
+	}
+	if got := app.inboxView.SelectedTask().ID; got != "4" {
+		t.Errorf("SelectedTask() = %s, want last task", got)
+	}
+}
+
+func TestMacro_ReplayRunsCommandsAfterAllKeys(t *testing.T) {
+	app := newMacroTestApp()
+	mockSvc := app.service.(*service.MockOmniFocusService)
+	app.macros.registers = map[string][]tea.KeyMsg{"a": {runeKey('c'), runeKey('j')}}
+
+	app, cmd := app.playMacro("a", 1)
+
+	// Every key has been replayed before the completion reaches OmniFocus, so
+	// j moved within the list as it was
+	if len(mockSvc.CompletedIDs) != 0 {
+		t.Errorf("CompletedIDs = %v during replay, want none yet", mockSvc.CompletedIDs)
+	}
+	if got := app.inboxView.SelectedTask().ID; got != "2" {
+		t.Errorf("SelectedTask() = %s after replay, want 2", got)
+	}
+	if cmd == nil {
+		t.Fatal("playMacro() should return the commands of the replayed keys")
+	}
+}
+
+func TestMacro_KeysInInputsDoNotStopRecording(t *testing.T) {
+	app := pressKeys(t, newMacroTestApp(), "Qd")
+
+	app = pressKeys(t, app, "/")
+	if !app.searchInput.IsVisible() {
+		t.Fatal("expected search input to open")
+	}
+	app = pressKeys(t, app, "Q")
+	if app.macros.recording != "d" {
+		t.Errorf("recording = %q, want Q typed into search to keep recording", app.macros.recording)
+	}
+}
+
+func TestMacro_KeysTypedIntoProjectPickerAreNotMacroKeys(t *testing.T) {
+	app, mockSvc := newMoveTestApp()
+	mockSvc.Projects = append(mockSvc.Projects, domain.Project{ID: "p3", Name: "Q4 Planning", Status: "active"})
+
+	app = update(app, runeKey('m'))
+	app = update(app, projectpicker.ProjectsLoadedMsg{Projects: mockSvc.Projects})
+	if !app.projectPicker.IsVisible() {
+		t.Fatal("m should open the project picker")
+	}
+	app = typeText(app, "Q4")
+
+	if app.macros.pending != "" || app.macros.recording != "" {
+		t.Errorf("macros = %+v, want Q typed into the picker left alone", app.macros)
+	}
+	matches := app.projectPicker.Matches()
+	if len(matches) != 1 || matches[0].Name != "Q4 Planning" {
+		t.Errorf("picker matches = %+v, want only Q4 Planning", matches)
+	}
+}
+
+func TestMacro_EmptyRegister(t *testing.T) {
+	app := pressKeys(t, newMacroTestApp(), "@z")
+
+	if app.inboxView.SelectedTask().ID != "1" || app.macros.pending != "" {
+		t.Error("expected replaying an empty register to do nothing")
+	}
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// handleMouse switches views on status bar tab clicks, dismisses the health
+// banner when it is clicked and passes other mouse events to the current view. Overlays take no mouse input, so mouse events
+// are ignored while one is open.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.showHelp || m.overlayOpen() {
+		return m, nil
+	}
+
+	if m.banner != "" && msg.Y == m.height-m.bottomHeight() {
+		if tui.IsClick(msg) {
+			return m.dismissBanner()
+		}
+		return m, nil
+	}
+
+	if msg.Y >= m.height-statusBarHeight {
+		if !tui.IsClick(msg) {
+			return m, nil
+		}
+		if index, ok := m.statusBar.TabAt(msg.X); ok {
+			return m.switchView(tabViews[index])
+		}
+		return m, nil
+	}
+
+	return m.delegateToCurrentView(msg)
+}
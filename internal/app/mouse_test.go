@@ -0,0 +1,59 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func click(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+}
+
+func TestMouse_ClickOnTabSwitchesView(t *testing.T) {
+	app := newMacroTestApp()
+	bottom := app.height - 1
+	forecastX := 0
+	for _, label := range tabLabels()[:3] {
+		forecastX += lipgloss.Width(app.styles.UI.Tab.Render(label)) + 1
+	}
+
+	model, cmd := app.Update(click(forecastX, bottom))
+	app = model.(Model)
+	if app.currentView != tui.ViewForecast {
+		t.Errorf("currentView = %d, want %d", app.currentView, tui.ViewForecast)
+	}
+	if cmd == nil {
+		t.Error("switching views should load the new view")
+	}
+
+	// Clicking the status past the last tab does nothing
+	model, _ = app.Update(click(app.width-1, bottom))
+	if model.(Model).currentView != tui.ViewForecast {
+		t.Error("a click past the tabs should not switch views")
+	}
+}
+
+func TestMouse_ClickSelectsTask(t *testing.T) {
+	app := newMacroTestApp()
+
+	// The inbox header with its border, then the task rows
+	model, _ := app.Update(click(10, 2+1))
+	app = model.(Model)
+	if task := app.getSelectedTask(); task == nil || task.ID != "2" {
+		t.Errorf("getSelectedTask() = %v, want task 2", task)
+	}
+}
+
+func TestMouse_IgnoredWhileOverlayVisible(t *testing.T) {
+	app := newMacroTestApp()
+	app.quickAdd = app.quickAdd.Show()
+
+	model, _ := app.Update(click(10, 2+1))
+	app = model.(Model)
+	if task := app.getSelectedTask(); task == nil || task.ID != "1" {
+		t.Errorf("getSelectedTask() = %v, want task 1 while an overlay is open", task)
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/projectpicker"
+)
+
+// executeMoveKey opens the project picker to move the marked tasks, or the
+// selected task
+func (m Model) executeMoveKey() (Model, tea.Cmd) {
+	if len(m.getMarkedTasks()) > 0 {
+		return m.showProjectPicker(false, "")
+	}
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+	return m.showProjectPicker(false, task.ProjectID)
+}
+
+// showProjectPicker opens the project picker and loads the projects, for the
+// task edit overlay when forEdit is set or to move tasks otherwise
+func (m Model) showProjectPicker(forEdit bool, currentID string) (Model, tea.Cmd) {
+	title := "Move to Project"
+	if forEdit {
+		title = "Pick Project"
+	}
+	m.pickingForEdit = forEdit
+	m.projectPicker = m.projectPicker.Show(title, currentID)
+
+	svc := m.service
+	return m, func() tea.Msg {
+		projects, err := svc.GetProjects(context.Background(), "")
+		if err != nil {
+			err = fmt.Errorf("failed to get projects: %w", err)
+		}
+		return projectpicker.ProjectsLoadedMsg{Projects: projects, Err: err}
+	}
+}
+
+// handleProjectPickerMessages handles messages from the project picker
+func (m Model) handleProjectPickerMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case projectpicker.ProjectsLoadedMsg:
+		// Projects loaded after the picker was closed are dropped
+		var cmd tea.Cmd
+		m.projectPicker, cmd = m.projectPicker.Update(msg)
+		return m, cmd, true
+
+	case projectpicker.PickedMsg:
+		if m.pickingForEdit {
+			m.taskEdit = m.taskEdit.SetProject(msg.Project)
+			return m, nil, true
+		}
+		newModel, cmd := m.moveToProject(msg.Project)
+		return newModel, cmd, true
+
+	case projectpicker.CancelledMsg:
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// moveToProject moves the marked tasks, after confirmation, or the selected
+// task to project; a project without an ID is the inbox
+func (m Model) moveToProject(project domain.Project) (Model, tea.Cmd) {
+	mod := domain.TaskModification{ProjectID: &project.ID}
+
+	if marked := m.getMarkedTasks(); len(marked) > 0 {
+		op := domain.BatchOperation{Action: domain.BatchModify, Modification: mod}
+		verb := fmt.Sprintf("Move to %q:", project.Name)
+		return m.confirmBatch("Move Tasks", verb, op, marked), nil
+	}
+
+	task := m.getSelectedTask()
+	if task == nil || task.ProjectID == project.ID {
+		return m, nil
+	}
+	return m, m.modifyTask(task.ID, mod, task)
+}
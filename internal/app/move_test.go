@@ -0,0 +1,104 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/projectpicker"
+)
+
+func newMoveTestApp() (Model, *service.MockOmniFocusService) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "1", Name: "Buy paint"}},
+		Projects: []domain.Project{
+			{ID: "p1", Name: "Home Renovation", Status: "active"},
+			{ID: "p2", Name: "Work", Status: "active"},
+		},
+		ModifiedTask: &domain.Task{ID: "1", Name: "Buy paint", ProjectID: "p1"},
+	}
+	app := NewApp(mockSvc)
+	model, _ := app.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model, _ = model.(Model).Update(tui.TasksLoadedMsg{Tasks: mockSvc.InboxTasks})
+	return model.(Model), mockSvc
+}
+
+// typeText feeds each rune of text to the app as a key press
+func typeText(app Model, text string) Model {
+	for _, r := range text {
+		app = update(app, runeKey(r))
+	}
+	return app
+}
+
+func TestMoveKey_MovesSelectedTask(t *testing.T) {
+	app, mockSvc := newMoveTestApp()
+
+	app = update(app, runeKey('m'))
+	if !app.projectPicker.IsVisible() {
+		t.Fatal("m should open the project picker")
+	}
+	if got := len(app.projectPicker.Matches()); got != 3 {
+		t.Fatalf("picker lists %d entries, want the inbox and 2 projects", got)
+	}
+
+	app = typeText(app, "home")
+	model, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = update(model.(Model), cmd())
+	if app.projectPicker.IsVisible() {
+		t.Error("picking a project should close the picker")
+	}
+	if mod, ok := mockSvc.Modifications["1"]; !ok || mod.ProjectID == nil || *mod.ProjectID != "p1" {
+		t.Errorf("ModifyTask() modifications = %+v, want the task moved to p1", mockSvc.Modifications)
+	}
+}
+
+func TestMoveKey_NoTaskSelected(t *testing.T) {
+	app, _ := newMoveTestApp()
+	app = update(app, tui.TasksLoadedMsg{Tasks: []domain.Task{}})
+
+	app = update(app, runeKey('m'))
+	if app.projectPicker.IsVisible() {
+		t.Error("m should do nothing without a selected task")
+	}
+}
+
+func TestTaskEdit_PicksProject(t *testing.T) {
+	app, mockSvc := newMoveTestApp()
+
+	app = update(app, runeKey('e'))
+	app = update(app, tea.KeyMsg{Type: tea.KeyTab})
+	app = update(app, tea.KeyMsg{Type: tea.KeyTab})
+	app = update(app, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if !app.projectPicker.IsVisible() || !app.taskEdit.IsVisible() {
+		t.Fatal("Ctrl+P on the project field should open the picker over the edit form")
+	}
+	app = update(app, projectpicker.ProjectsLoadedMsg{Projects: mockSvc.Projects})
+
+	app = typeText(app, "work")
+	model, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = update(model.(Model), cmd())
+	if app.projectPicker.IsVisible() || !app.taskEdit.IsVisible() {
+		t.Fatal("picking a project should return to the edit form")
+	}
+	if len(mockSvc.Modifications) != 0 {
+		t.Error("picking a project for the form should not save yet")
+	}
+
+	model, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = update(model.(Model), cmd())
+	if mod := mockSvc.Modifications["1"]; mod.ProjectID == nil || *mod.ProjectID != "p2" {
+		t.Errorf("saved modification = %+v, want the picked project's ID", mod)
+	}
+}
+
+func TestProjectPicker_LoadedAfterClose(t *testing.T) {
+	app, _ := newMoveTestApp()
+
+	app = update(app, projectpicker.ProjectsLoadedMsg{Projects: []domain.Project{{ID: "p1", Name: "Home"}}})
+	if app.projectPicker.IsVisible() {
+		t.Error("projects loaded after closing should not reopen the picker")
+	}
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/nextpanel"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// nextPanelLimit is the number of suggestions the next panel shows
+const nextPanelLimit = 10
+
+// nextLoadedMsg carries the suggestions for the next panel
+type nextLoadedMsg struct {
+	Suggestions []next.Suggestion
+	Err         error
+}
+
+// SetNextOptions sets how :next weighs each factor and which tags fit the
+// current context
+func (m Model) SetNextOptions(weights next.Weights, context []string) Model {
+	m.nextOptions.Weights = weights
+	m.nextOptions.Context = context
+	return m
+}
+
+// executeNextCommand handles the "next" command, ranking every available task
+// in the background. Arguments such as "30m" and "low" are the time and
+// energy available.
+func (m Model) executeNextCommand(cmd *command.Command) (Model, tea.Cmd) {
+	opts := m.nextOptions
+	for _, arg := range cmd.Args {
+		if effort, err := domain.ParseEffort(arg); err == nil {
+			opts.Energy = effort
+			continue
+		}
+		minutes, err := domain.ParseEstimate(arg)
+		if err != nil {
+			err = fmt.Errorf("invalid next argument %q: use a time such as 30m or an energy such as low", arg)
+			m.err = err
+			return m.pushToast(toast.Error, err.Error())
+		}
+		opts.Available = minutes
+	}
+
+	svc := m.service
+	return m, func() tea.Msg {
+		// A truncated list still has tasks worth suggesting
+		tasks, err := svc.GetAllTasks(context.Background(), service.TaskFilters{})
+		var truncated *service.TruncatedError
+		if err != nil && !errors.As(err, &truncated) {
+			return nextLoadedMsg{Err: err}
+		}
+		opts.Now = time.Now()
+		suggestions := next.Suggest(tasks, opts)
+		if len(suggestions) > nextPanelLimit {
+			suggestions = suggestions[:nextPanelLimit]
+		}
+		return nextLoadedMsg{Suggestions: suggestions}
+	}
+}
+
+// handleNextMessages handles the suggestions loaded for the next panel and
+// messages from the panel
+func (m Model) handleNextMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case nextLoadedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m.withToast(toast.Error, fmt.Sprintf("Failed to suggest tasks: %v", msg.Err))
+		}
+		m.nextPanel = m.nextPanel.Show(msg.Suggestions)
+		return m, nil, true
+
+	case nextpanel.SelectedMsg:
+		task := msg.Task
+		m.taskDetail = m.taskDetail.Show(&task)
+		return m, nil, true
+
+	case nextpanel.CancelledMsg:
+		return m, nil, true
+	}
+	return m, nil, false
+}
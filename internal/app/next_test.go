@@ -0,0 +1,125 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+)
+
+func TestNextCommand_ShowsPanelAndOpensTask(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "t1", Name: "Water plants"},
+			{ID: "t2", Name: "Fix shelf", Tags: []string{"home"}},
+			{ID: "t3", Name: "Call bank", Completed: true},
+		},
+	}
+	app := NewApp(mockSvc).SetNextOptions(next.Weights{Context: 1}, []string{"Home"})
+	newModel, _ := app.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	app = newModel.(Model)
+
+	app, cmd := app.executeCommand(&command.Command{Name: "next"})
+	newModel, _ = app.Update(cmd())
+	app = newModel.(Model)
+
+	if !app.nextPanel.IsVisible() {
+		t.Fatal("next panel should be visible after :next")
+	}
+	view := app.View()
+	for _, want := range []string{"What's Next", "Fix shelf", "tagged home", "Water plants"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+	if strings.Contains(view, "Call bank") {
+		t.Error("View() should leave out completed tasks")
+	}
+
+	newModel, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = newModel.(Model)
+	newModel, _ = app.Update(cmd())
+	app = newModel.(Model)
+
+	if app.nextPanel.IsVisible() {
+		t.Error("next panel should close after Enter")
+	}
+	if task := app.taskDetail.Task(); !app.taskDetail.IsVisible() || task == nil || task.ID != "t2" {
+		t.Errorf("task detail = %+v, want the top suggestion t2", task)
+	}
+}
+
+func TestNextCommand_AvailableTime(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "t1", Name: "Write report", EstimatedMinutes: 120},
+			{ID: "t2", Name: "Reply to email", EstimatedMinutes: 10},
+		},
+	}
+	app := NewApp(mockSvc)
+
+	_, cmd := app.executeCommand(&command.Command{Name: "next", Args: []string{"30m"}})
+	msg := cmd().(nextLoadedMsg)
+
+	if len(msg.Suggestions) != 2 || msg.Suggestions[0].Task.ID != "t2" {
+		t.Fatalf("Suggestions = %+v, want t2 first", msg.Suggestions)
+	}
+	if got := msg.Suggestions[1].Explain(); got != "takes 2h, more than 30m" {
+		t.Errorf("Explain() = %q, want %q", got, "takes 2h, more than 30m")
+	}
+}
+
+func TestNextCommand_Energy(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "t1", Name: "Write report", Note: "effort: high"},
+			{ID: "t2", Name: "Water plants", Note: "effort: low"},
+		},
+	}
+	app := NewApp(mockSvc)
+
+	_, cmd := app.executeCommand(&command.Command{Name: "next", Args: []string{"low", "30m"}})
+	msg := cmd().(nextLoadedMsg)
+
+	if len(msg.Suggestions) != 2 || msg.Suggestions[0].Task.ID != "t2" {
+		t.Fatalf("Suggestions = %+v, want t2 first", msg.Suggestions)
+	}
+	if got := msg.Suggestions[1].Explain(); got != "high effort, more than your low energy" {
+		t.Errorf("Explain() = %q, want the effort to count against", got)
+	}
+}
+
+func TestNextCommand_InvalidTime(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{})
+
+	app, cmd := app.executeCommand(&command.Command{Name: "next", Args: []string{"soon"}})
+
+	if app.err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+	if cmd != nil {
+		if _, ok := cmd().(nextLoadedMsg); ok {
+			t.Error("should not load suggestions for an invalid time")
+		}
+	}
+}
+
+func TestNextCommand_LoadError(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{AllTasksErr: errors.New("OmniFocus is not running")})
+
+	app, cmd := app.executeCommand(&command.Command{Name: "next"})
+	newModel, _ := app.Update(cmd())
+	app = newModel.(Model)
+
+	if app.nextPanel.IsVisible() {
+		t.Error("next panel should stay closed when loading fails")
+	}
+	if app.err == nil {
+		t.Error("expected the load error to be recorded")
+	}
+}
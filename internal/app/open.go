@@ -0,0 +1,37 @@
+package app
+
+import (
+	"fmt"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// openCommand opens a URL in its default application; tests replace it
+var openCommand = defaultOpenCommand
+
+// defaultOpenCommand opens url with the macOS open command
+func defaultOpenCommand(url string) error {
+	return exec.Command("open", url).Run()
+}
+
+// openURL creates a command that opens url outside the TUI
+func openURL(url string) tea.Cmd {
+	return func() tea.Msg {
+		if err := openCommand(url); err != nil {
+			return tui.ErrorMsg{Err: fmt.Errorf("failed to open %s: %w", url, err)}
+		}
+		return nil
+	}
+}
+
+// executeOpenCommand opens the selected task in OmniFocus
+func (m Model) executeOpenCommand() (Model, tea.Cmd) {
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+	return m, openURL(domain.TaskURL(task.ID))
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/taskdetail"
+)
+
+func TestTaskDetail_OpenURLRunsOpen(t *testing.T) {
+	var opened string
+	openCommand = func(url string) error {
+		opened = url
+		return nil
+	}
+	t.Cleanup(func() { openCommand = defaultOpenCommand })
+
+	app := newMacroTestApp()
+	_, cmd := app.Update(taskdetail.OpenURLRequestedMsg{URL: "https://example.com"})
+	if cmd == nil {
+		t.Fatal("opening a link should return a command")
+	}
+	if msg := cmd(); msg != nil {
+		t.Errorf("cmd() = %v, want nil on success", msg)
+	}
+	if opened != "https://example.com" {
+		t.Errorf("opened %q, want https://example.com", opened)
+	}
+
+	openCommand = func(string) error { return errors.New("no open command") }
+	_, cmd = app.Update(taskdetail.OpenURLRequestedMsg{URL: "https://example.com"})
+	if _, ok := cmd().(tui.ErrorMsg); !ok {
+		t.Error("a failed open should report an error")
+	}
+}
+
+func TestOpenKey_OpensSelectedTaskInOmniFocus(t *testing.T) {
+	var opened string
+	openCommand = func(url string) error {
+		opened = url
+		return nil
+	}
+	t.Cleanup(func() { openCommand = defaultOpenCommand })
+
+	app := pressKeys(t, newMacroTestApp(), "j")
+	_, cmd := app.Update(runeKey('o'))
+	if cmd == nil {
+		t.Fatal("o should return a command")
+	}
+	cmd()
+	if opened != "omnifocus:///task/2" {
+		t.Errorf("opened %q, want omnifocus:///task/2", opened)
+	}
+}
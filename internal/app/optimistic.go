@@ -0,0 +1,266 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// localChange is a change shown on a task before OmniFocus is known to have
+// it, kept until a load shows the task as OmniFocus has it
+type localChange struct {
+	TaskName  string
+	Completed *bool // Set when the change completes the task
+	Flagged   *bool // Set when the change flags or unflags the task
+	Confirmed bool  // OmniFocus reported the change made
+}
+
+// apply shows the change on task
+func (c localChange) apply(task *domain.Task) {
+	if c.Completed != nil {
+		task.Completed = *c.Completed
+	}
+	if c.Flagged != nil {
+		task.Flagged = *c.Flagged
+	}
+}
+
+// revert shows task as it was before the change
+func (c localChange) revert(task *domain.Task) {
+	if c.Completed != nil {
+		task.Completed = !*c.Completed
+	}
+	if c.Flagged != nil {
+		task.Flagged = !*c.Flagged
+	}
+}
+
+// shownBy reports whether task already has the change
+func (c localChange) shownBy(task domain.Task) bool {
+	return (c.Completed == nil || task.Completed == *c.Completed) &&
+		(c.Flagged == nil || task.Flagged == *c.Flagged)
+}
+
+// verb names the change for toasts, e.g. "complete"
+func (c localChange) verb() string {
+	switch {
+	case c.Completed != nil && *c.Completed:
+		return "complete"
+	case c.Completed != nil:
+		return "reopen"
+	case c.Flagged != nil && *c.Flagged:
+		return "flag"
+	default:
+		return "unflag"
+	}
+}
+
+// conflict is a task whose local change OmniFocus did not take
+type conflict struct {
+	Change localChange
+	Reason string
+	Device string // Device ID of the machine that made the change
+}
+
+// SetDevice sets the device ID of this machine, recorded with conflicts and
+// named in their messages so a database shared by several machines shows
+// which of them made the change OmniFocus did not take
+func (m Model) SetDevice(id string) Model {
+	m.device = id
+	return m
+}
+
+// onDevice appends the device ID, if set, to a conflict message
+func (m Model) onDevice(text string) string {
+	if m.device == "" {
+		return text
+	}
+	return fmt.Sprintf("%s on %s", text, m.device)
+}
+
+// changeFailedMsg reports that OmniFocus rejected a change already shown
+type changeFailedMsg struct {
+	TaskID string
+	Change localChange
+	Err    error
+}
+
+// applyOptimistically shows change on a task in every view at once and runs
+// save in the background. A failure marks the task with a conflict.
+func (m Model) applyOptimistically(taskID string, change localChange, save func() (tea.Msg, error)) (Model, tea.Cmd) {
+	m.local = withEntry(m.local, taskID, change)
+	m = m.clearConflict(taskID)
+	m = m.patchTask(taskID, change.apply)
+	return m, func() tea.Msg {
+		msg, err := save()
+		if err != nil {
+			return changeFailedMsg{TaskID: taskID, Change: change, Err: err}
+		}
+		return msg
+	}
+}
+
+// handleChangeFailed shows a task as OmniFocus has it again after a rejected
+// change, and marks it with a conflict until it is reconciled
+func (m Model) handleChangeFailed(msg changeFailedMsg) (Model, tea.Cmd) {
+	m.local = withoutEntry(m.local, msg.TaskID)
+	m = m.patchTask(msg.TaskID, msg.Change.revert)
+	m = m.setConflict(msg.TaskID, conflict{Change: msg.Change, Reason: msg.Err.Error(), Device: m.device})
+	m.err = msg.Err
+	m, checkCmd := m.checkPermissionOnce()
+	m, toastCmd := m.pushToast(toast.Error, fmt.Sprintf("%s: %v", m.onDevice(taskToastText("Failed to "+msg.Change.verb(), msg.Change.TaskName)), msg.Err))
+	return m, tea.Batch(toastCmd, checkCmd)
+}
+
+// confirmChange records that OmniFocus reported the local change of a task made
+func (m Model) confirmChange(taskID string) Model {
+	change, ok := m.local[taskID]
+	if !ok {
+		return m
+	}
+	change.Confirmed = true
+	m.local = withEntry(m.local, taskID, change)
+	return m
+}
+
+// reconcileLoaded checks loaded tasks against the local changes. Changes the
+// load shows are done with, changes still being saved are shown again over
+// the loaded state, and saved changes the load contradicts become conflicts.
+func (m Model) reconcileLoaded(tasks []domain.Task) Model {
+	if len(m.local) == 0 && len(m.conflicts) == 0 {
+		return m
+	}
+
+	loaded := make(map[string]domain.Task)
+	for _, task := range domain.FlattenTasks(tasks) {
+		loaded[task.ID] = task
+	}
+
+	for id, change := range m.local {
+		task, ok := loaded[id]
+		if !ok {
+			if change.Confirmed {
+				m.local = withoutEntry(m.local, id)
+			}
+			continue
+		}
+		m = m.reconcileChange(id, change, task)
+	}
+
+	for id, c := range m.conflicts {
+		if task, ok := loaded[id]; ok && c.Change.shownBy(task) {
+			m = m.clearConflict(id)
+		}
+	}
+	return m
+}
+
+// reconcileTask checks one task reloaded on its own against its local change
+// and conflict, like reconcileLoaded
+func (m Model) reconcileTask(task domain.Task) Model {
+	if change, ok := m.local[task.ID]; ok {
+		m = m.reconcileChange(task.ID, change, task)
+	}
+	if c, ok := m.conflicts[task.ID]; ok && c.Change.shownBy(task) {
+		m = m.clearConflict(task.ID)
+	}
+	return m
+}
+
+// reconcileChange checks the local change of a task against the task as
+// loaded from OmniFocus
+func (m Model) reconcileChange(id string, change localChange, task domain.Task) Model {
+	switch {
+	case change.shownBy(task):
+		m.local = withoutEntry(m.local, id)
+	case !change.Confirmed:
+		m = m.patchTask(id, change.apply)
+	default:
+		m.local = withoutEntry(m.local, id)
+		m = m.setConflict(id, conflict{Change: change, Reason: "OmniFocus shows the task unchanged", Device: m.device})
+	}
+	return m
+}
+
+// reconcile resolves the conflict on the selected task by retrying its
+// change, or with discard by dropping the change and reloading the task as
+// OmniFocus has it
+func (m Model) reconcile(discard bool) (Model, tea.Cmd) {
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+	c, ok := m.conflicts[task.ID]
+	if !ok {
+		return m.pushToast(toast.Info, taskToastText("No conflict on", task.Name))
+	}
+
+	m = m.clearConflict(task.ID)
+	if discard {
+		return m.refreshWithToast(toast.Info, taskToastText("Kept OmniFocus state of", task.Name))
+	}
+	if c.Change.Completed != nil {
+		return m.completeTask(task.ID, task.Name)
+	}
+	return m.flagTask(*task, *c.Change.Flagged)
+}
+
+// setConflict marks a task with a conflict in every view
+func (m Model) setConflict(taskID string, c conflict) Model {
+	m.conflicts = withEntry(m.conflicts, taskID, c)
+	return m.applyConflicts()
+}
+
+// clearConflict removes the conflict mark from a task
+func (m Model) clearConflict(taskID string) Model {
+	if _, ok := m.conflicts[taskID]; !ok {
+		return m
+	}
+	m.conflicts = withoutEntry(m.conflicts, taskID)
+	return m.applyConflicts()
+}
+
+// applyConflicts shows the conflicted tasks in every view
+func (m Model) applyConflicts() Model {
+	ids := make(map[string]bool, len(m.conflicts))
+	for id := range m.conflicts {
+		ids[id] = true
+	}
+	m.inboxView = m.inboxView.SetConflicts(ids)
+	m.projectsView = m.projectsView.SetConflicts(ids)
+	m.tagsView = m.tagsView.SetConflicts(ids)
+	m.forecastView = m.forecastView.SetConflicts(ids)
+	m.reviewView = m.reviewView.SetConflicts(ids)
+	return m
+}
+
+// patchTask changes a task in every view that shows it
+func (m Model) patchTask(taskID string, patch func(*domain.Task)) Model {
+	m.inboxView = m.inboxView.PatchTask(taskID, patch)
+	m.projectsView = m.projectsView.PatchTask(taskID, patch)
+	m.tagsView = m.tagsView.PatchTask(taskID, patch)
+	m.forecastView = m.forecastView.PatchTask(taskID, patch)
+	m.reviewView = m.reviewView.PatchTask(taskID, patch)
+	return m
+}
+
+// withEntry returns a copy of entries with key set to value, leaving the
+// original untouched since Model is passed by value
+func withEntry[V any](entries map[string]V, key string, value V) map[string]V {
+	out := maps.Clone(entries)
+	if out == nil {
+		out = make(map[string]V)
+	}
+	out[key] = value
+	return out
+}
+
+// withoutEntry returns a copy of entries without key
+func withoutEntry[V any](entries map[string]V, key string) map[string]V {
+	out := maps.Clone(entries)
+	delete(out, key)
+	return out
+}
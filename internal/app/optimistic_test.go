@@ -0,0 +1,130 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+)
+
+// failComplete completes the selected task with OmniFocus rejecting it
+func failComplete(t *testing.T, app Model) Model {
+	t.Helper()
+	model, cmd := app.Update(runeKey('c'))
+	model, _ = model.Update(cmd())
+	return model.(Model)
+}
+
+func TestChangeFailed_MarksTaskWithConflict(t *testing.T) {
+	app := failComplete(t, newCompleteTestApp(errors.New("OmniFocus is not running"), ""))
+
+	c, ok := app.conflicts["1"]
+	if !ok || c.Reason != "OmniFocus is not running" {
+		t.Fatalf("conflicts[1] = %+v, %v, want the failure", c, ok)
+	}
+	if _, ok := app.local["1"]; ok {
+		t.Error("local[1] is still set after the failure, want it dropped")
+	}
+}
+
+func TestChangeFailed_NamesDevice(t *testing.T) {
+	app := newCompleteTestApp(errors.New("OmniFocus is not running"), "").SetDevice("studio-mac")
+	app = failComplete(t, app)
+
+	if c := app.conflicts["1"]; c.Device != "studio-mac" {
+		t.Errorf("conflicts[1].Device = %q, want studio-mac", c.Device)
+	}
+	if toasts := app.toasts.Messages(); len(toasts) == 0 || !strings.Contains(toasts[len(toasts)-1], "on studio-mac: OmniFocus is not running") {
+		t.Errorf("toasts = %v, want the failure named with the device", toasts)
+	}
+}
+
+func TestReconcile_RetriesChange(t *testing.T) {
+	app := failComplete(t, newCompleteTestApp(errors.New("OmniFocus is not running"), "1"))
+	app.service.(*service.MockOmniFocusService).CompleteTaskErr = nil
+
+	model, cmd := app.Update(runeKey('R'))
+	app = model.(Model)
+	if _, ok := app.conflicts["1"]; ok {
+		t.Error("conflicts[1] is still set after R, want it cleared")
+	}
+	if task := app.getSelectedTask(); task == nil || !task.Completed {
+		t.Errorf("selected task = %v, want it shown completed again", task)
+	}
+	if msg, ok := cmd().(tui.TaskCompletedMsg); !ok || msg.TaskID != "1" {
+		t.Errorf("cmd() = %v, want TaskCompletedMsg for task 1", msg)
+	}
+}
+
+func TestReconcile_DiscardKeepsOmniFocusState(t *testing.T) {
+	app := failComplete(t, newCompleteTestApp(errors.New("OmniFocus is not running"), ""))
+
+	app, cmd := app.executeCommand(&command.Command{Name: "reconcile", Args: []string{"discard"}})
+	if _, ok := app.conflicts["1"]; ok {
+		t.Error("conflicts[1] is still set after :reconcile discard, want it cleared")
+	}
+	if cmd == nil {
+		t.Error(":reconcile discard should reload the view")
+	}
+	if task := app.getSelectedTask(); task == nil || task.Completed {
+		t.Errorf("selected task = %v, want it left not completed", task)
+	}
+}
+
+func TestReconcile_WithoutConflict(t *testing.T) {
+	app := newCompleteTestApp(nil, "1")
+
+	model, cmd := app.Update(runeKey('R'))
+	app = model.(Model)
+	if cmd == nil {
+		t.Fatal("R without a conflict should show a toast")
+	}
+	if toasts := strings.Join(app.toasts.Messages(), "\n"); !strings.Contains(toasts, `No conflict on "One"`) {
+		t.Errorf("toasts = %q, want the no-conflict notice", toasts)
+	}
+}
+
+func TestReconcileLoaded_PendingChangeSurvivesReload(t *testing.T) {
+	app := newCompleteTestApp(nil, "1")
+	model, _ := app.Update(runeKey('f'))
+
+	model, _ = model.Update(tui.TasksLoadedMsg{Tasks: []domain.Task{{ID: "1", Name: "One"}, {ID: "2", Name: "Two"}}})
+	app = model.(Model)
+
+	if task := app.getSelectedTask(); task == nil || !task.Flagged {
+		t.Errorf("selected task = %v, want the pending flag kept over the reload", task)
+	}
+}
+
+func TestReconcileLoaded_ContradictedChangeBecomesConflict(t *testing.T) {
+	app := newCompleteTestApp(nil, "1")
+	app.service.(*service.MockOmniFocusService).ModifiedTask = &domain.Task{ID: "1", Name: "One", Flagged: true}
+	model, cmd := app.Update(runeKey('f'))
+	model, _ = model.Update(cmd())
+
+	model, _ = model.Update(tui.TasksLoadedMsg{Tasks: []domain.Task{{ID: "1", Name: "One"}, {ID: "2", Name: "Two"}}})
+	app = model.(Model)
+
+	if _, ok := app.conflicts["1"]; !ok {
+		t.Error("conflicts[1] is not set, want a conflict for the flag the reload did not show")
+	}
+	if task := app.getSelectedTask(); task == nil || task.Flagged {
+		t.Errorf("selected task = %v, want it shown as OmniFocus has it", task)
+	}
+}
+
+func TestReconcileLoaded_AgreeingLoadClearsChange(t *testing.T) {
+	app := newCompleteTestApp(nil, "1")
+	model, _ := app.Update(runeKey('f'))
+
+	model, _ = model.Update(tui.TasksLoadedMsg{Tasks: []domain.Task{{ID: "1", Name: "One", Flagged: true}, {ID: "2", Name: "Two"}}})
+	app = model.(Model)
+
+	if len(app.local) != 0 || len(app.conflicts) != 0 {
+		t.Errorf("local = %v, conflicts = %v, want both empty", app.local, app.conflicts)
+	}
+}
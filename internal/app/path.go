@@ -0,0 +1,28 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// SetShowPath sets whether task rows and the task detail show the folders and
+// project containing each task, e.g. "Work ▸ Clients ▸ Acme"
+func (m Model) SetShowPath(show bool) Model {
+	m.showPath = show
+	m.inboxView = m.inboxView.SetShowPath(show)
+	m.projectsView = m.projectsView.SetShowPath(show)
+	m.tagsView = m.tagsView.SetShowPath(show)
+	m.forecastView = m.forecastView.SetShowPath(show)
+	m.reviewView = m.reviewView.SetShowPath(show)
+	m.taskDetail = m.taskDetail.SetShowPath(show)
+	return m
+}
+
+// executePathCommand shows or hides task paths
+func (m Model) executePathCommand() (Model, tea.Cmd) {
+	m = m.SetShowPath(!m.showPath)
+	if m.showPath {
+		return m.pushToast(toast.Info, "Showing task paths")
+	}
+	return m.pushToast(toast.Info, "Hiding task paths")
+}
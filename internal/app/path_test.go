@@ -0,0 +1,34 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+)
+
+func TestPathCommand_TogglesTaskPaths(t *testing.T) {
+	svc := &service.MockOmniFocusService{InboxTasks: []domain.Task{
+		{ID: "t1", Name: "Send invoice", ProjectName: "Acme", FolderPath: []string{"Work"}},
+	}}
+	app := startApp(t, NewApp(svc))
+	if strings.Contains(app.View(), "Work ▸ Acme") {
+		t.Fatal("task paths should be hidden by default")
+	}
+	cmd, _ := command.NewParser().Parse("path")
+
+	app, _ = app.executeCommand(cmd)
+	if !strings.Contains(app.View(), "Send invoice  Work ▸ Acme") {
+		t.Errorf("after :path the inbox should show task paths, got:\n%s", app.View())
+	}
+	if messages := app.toasts.Messages(); len(messages) == 0 || messages[len(messages)-1] != "Showing task paths" {
+		t.Errorf("toasts = %v, want Showing task paths", messages)
+	}
+
+	app, _ = app.executeCommand(cmd)
+	if strings.Contains(app.View(), "Work ▸ Acme") {
+		t.Error("a second :path should hide task paths")
+	}
+}
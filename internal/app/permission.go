@@ -0,0 +1,62 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// permissionGuide is shown when macOS does not allow the terminal to control OmniFocus
+const permissionGuide = "Allow your terminal to control OmniFocus in System Settings → Privacy & Security → Automation, then check again. Run lazyfocus doctor for details."
+
+// PermissionContext stores context for the Automation permission guide,
+// whose confirmation checks the permission again
+type PermissionContext struct{}
+
+// permissionCheckedMsg carries the result of the Automation permission check
+type permissionCheckedMsg struct {
+	Err     error
+	Recheck bool // Asked for from the permission guide
+}
+
+// SetPermissionCheck sets the check run after the first failed OmniFocus
+// script, which guides the user when the Automation permission is missing.
+// Without one, failures only show their error.
+func (m Model) SetPermissionCheck(check func() error) Model {
+	m.permissionCheck = check
+	return m
+}
+
+// checkPermissionOnce runs the permission check after the first failure
+func (m Model) checkPermissionOnce() (Model, tea.Cmd) {
+	if m.permissionCheck == nil || m.permissionChecked {
+		return m, nil
+	}
+	m.permissionChecked = true
+	return m, m.runPermissionCheck(false)
+}
+
+// runPermissionCheck creates a command running the permission check
+func (m Model) runPermissionCheck(recheck bool) tea.Cmd {
+	check := m.permissionCheck
+	return func() tea.Msg {
+		return permissionCheckedMsg{Err: check(), Recheck: recheck}
+	}
+}
+
+// handlePermissionChecked shows the permission guide when the permission is
+// missing, and the outcome of a check the user asked for
+func (m Model) handlePermissionChecked(msg permissionCheckedMsg) (Model, tea.Cmd) {
+	if bridge.IsPermissionDenied(msg.Err) {
+		m.confirmModal = m.confirmModal.ShowWithContext("OmniFocus Automation Not Allowed", permissionGuide, PermissionContext{})
+		return m, nil
+	}
+	if !msg.Recheck {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.err = msg.Err
+		return m.pushToast(toast.Error, msg.Err.Error())
+	}
+	return m.refreshWithToast(toast.Success, "OmniFocus Automation allowed")
+}
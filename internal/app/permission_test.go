@@ -0,0 +1,77 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/confirm"
+)
+
+// newPermissionTestApp returns an app whose permission check reports err and
+// counts its runs
+func newPermissionTestApp(err error) (Model, *int) {
+	runs := 0
+	app := newCompleteTestApp(nil, "1").SetPermissionCheck(func() error {
+		runs++
+		return err
+	})
+	return app, &runs
+}
+
+func TestPermissionCheck_RunsOnceAfterFirstFailure(t *testing.T) {
+	app, _ := newPermissionTestApp(nil)
+
+	model, _ := app.Update(tui.ErrorMsg{Err: errors.New("osascript execution failed")})
+	app = model.(Model)
+	if !app.permissionChecked {
+		t.Fatal("the first failure should run the permission check")
+	}
+	if _, cmd := app.checkPermissionOnce(); cmd != nil {
+		t.Error("checkPermissionOnce() after the first failure returned a command, want nil")
+	}
+}
+
+func TestPermissionCheck_DeniedShowsGuide(t *testing.T) {
+	denied := fmt.Errorf("%w: (-1743)", bridge.ErrAutomationNotPermitted)
+	app, runs := newPermissionTestApp(denied)
+
+	app, cmd := app.checkPermissionOnce()
+	model, _ := app.Update(cmd())
+	if !model.(Model).confirmModal.IsVisible() {
+		t.Error("a denied permission should show the permission guide")
+	}
+	if *runs != 1 {
+		t.Errorf("permission check ran %d times, want once", *runs)
+	}
+}
+
+func TestPermissionCheck_GrantedShowsNothing(t *testing.T) {
+	app, _ := newPermissionTestApp(nil)
+
+	app, cmd := app.checkPermissionOnce()
+	model, _ := app.Update(cmd())
+	if model.(Model).confirmModal.IsVisible() {
+		t.Error("a granted permission should not show the permission guide")
+	}
+}
+
+func TestPermissionCheck_SkippedWithoutCheck(t *testing.T) {
+	app := newCompleteTestApp(nil, "1")
+
+	if _, cmd := app.checkPermissionOnce(); cmd != nil {
+		t.Error("checkPermissionOnce() without a check returned a command, want nil")
+	}
+}
+
+func TestPermissionGuide_ConfirmChecksAgain(t *testing.T) {
+	app, runs := newPermissionTestApp(nil)
+
+	_, cmd := app.Update(confirm.ConfirmedMsg{Context: PermissionContext{}})
+	checked, ok := cmd().(permissionCheckedMsg)
+	if !ok || !checked.Recheck || *runs != 1 {
+		t.Errorf("confirming the guide = %+v after %d runs, want one recheck", checked, *runs)
+	}
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// togglePin pins or unpins the selected task. Pins are local to the TUI and
+// kept in the session state, so they never change the task in OmniFocus.
+func (m Model) togglePin() (Model, tea.Cmd) {
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+
+	pinned := make(map[string]bool, len(m.pinned)+1)
+	for id := range m.pinned {
+		pinned[id] = true
+	}
+	verb := "Pinned"
+	if pinned[task.ID] {
+		delete(pinned, task.ID)
+		verb = "Unpinned"
+	} else {
+		pinned[task.ID] = true
+	}
+
+	m = m.setPinned(pinned)
+	return m.pushToast(toast.Info, taskToastText(verb, task.Name))
+}
+
+// setPinned sets the pinned tasks and applies them to every view
+func (m Model) setPinned(pinned map[string]bool) Model {
+	m.pinned = pinned
+	m.inboxView = m.inboxView.SetPinned(pinned)
+	m.projectsView = m.projectsView.SetPinned(pinned)
+	m.tagsView = m.tagsView.SetPinned(pinned)
+	m.forecastView = m.forecastView.SetPinned(pinned)
+	m.reviewView = m.reviewView.SetPinned(pinned)
+	return m
+}
+
+// pinnedIDs returns the IDs of the pinned tasks in a stable order
+func (m Model) pinnedIDs() []string {
+	if len(m.pinned) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(m.pinned))
+	for id := range m.pinned {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/session"
+)
+
+func TestPin_TogglesSelectedTask(t *testing.T) {
+	app := newMacroTestApp()
+	app = pressKeys(t, app, "jj!")
+
+	if !app.pinned["3"] {
+		t.Fatalf("pinned = %v, want task 3 pinned", app.pinned)
+	}
+	if task := app.inboxView.SelectedTask(); task == nil || task.ID != "3" {
+		t.Errorf("SelectedTask() = %v, want the pinned task to stay selected", task)
+	}
+	if !app.toasts.IsVisible() {
+		t.Error("pinning should show a toast")
+	}
+
+	// The pinned task moves to the top of the inbox, ahead of task 1
+	app = pressKeys(t, app, "j")
+	if task := app.inboxView.SelectedTask(); task == nil || task.ID != "1" {
+		t.Errorf("SelectedTask() after moving down = %v, want task 1 below the pinned task", task)
+	}
+
+	app = pressKeys(t, app, "k!")
+	if len(app.pinned) != 0 {
+		t.Errorf("pinned = %v, want none after unpinning", app.pinned)
+	}
+}
+
+func TestSession_RestoresPins(t *testing.T) {
+	svc := &service.MockOmniFocusService{InboxTasks: []domain.Task{
+		{ID: "t1", Name: "Buy milk"},
+		{ID: "t2", Name: "Call bank"},
+	}}
+	state := session.State{View: "inbox", SelectedTask: "t2", Pinned: []string{"t2"}}
+
+	app := startApp(t, NewApp(svc).RestoreSession(state))
+
+	if !app.pinned["t2"] {
+		t.Errorf("pinned = %v, want t2", app.pinned)
+	}
+	if got := app.Session(); !reflect.DeepEqual(got, state) {
+		t.Errorf("Session() = %+v, want %+v", got, state)
+	}
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// prefetchViews lists the views whose data is loaded at startup, so
+// switching to them shows data at once
+var prefetchViews = []int{
+	tui.ViewInbox,
+	tui.ViewProjects,
+	tui.ViewTags,
+	tui.ViewForecast,
+}
+
+// prefetchMsg starts loading the views other than the current one
+type prefetchMsg struct{}
+
+// viewLoadedMsg carries a message produced by loading a view in the
+// background, to be handled by that view whichever view is shown
+type viewLoadedMsg struct {
+	View int
+	Msg  tea.Msg
+}
+
+// startPrefetch creates a command that starts loading the other views once
+// the program runs
+func startPrefetch() tea.Cmd {
+	return func() tea.Msg { return prefetchMsg{} }
+}
+
+// prefetch loads the views other than the current one concurrently, showing
+// a spinner on each tab until its data arrives
+func (m Model) prefetch() (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, view := range prefetchViews {
+		if view == m.currentView {
+			continue
+		}
+		cmd := m.initView(view)
+		if cmd == nil {
+			continue
+		}
+		m.statusBar = m.statusBar.SetLoading(slices.Index(tabViews, view), true)
+		cmds = append(cmds, forView(view, cmd))
+	}
+	if len(cmds) == 0 {
+		return m, nil
+	}
+	return m, tea.Batch(append(cmds, m.statusBar.Init())...)
+}
+
+// forView wraps cmd so its result goes to view
+func forView(view int, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return viewLoadedMsg{View: view, Msg: cmd()}
+	}
+}
+
+// handleViewLoaded hands a background load result to its view. Batched
+// commands are run with their results wrapped in turn.
+func (m Model) handleViewLoaded(msg viewLoadedMsg) (Model, tea.Cmd) {
+	if batch, ok := msg.Msg.(tea.BatchMsg); ok {
+		cmds := make([]tea.Cmd, 0, len(batch))
+		for _, cmd := range batch {
+			if cmd != nil {
+				cmds = append(cmds, forView(msg.View, cmd))
+			}
+		}
+		return m, tea.Batch(cmds...)
+	}
+
+	m.statusBar = m.statusBar.SetLoading(slices.Index(tabViews, msg.View), false)
+	if msg.View == m.currentView {
+		m = m.noteLoaded(msg.Msg)
+	}
+
+	var cmd tea.Cmd
+	switch msg.View {
+	case tui.ViewInbox:
+		m.inboxView, cmd = m.inboxView.Update(msg.Msg)
+	case tui.ViewProjects:
+		m.projectsView, cmd = m.projectsView.Update(msg.Msg)
+	case tui.ViewTags:
+		m.tagsView, cmd = m.tagsView.Update(msg.Msg)
+	case tui.ViewForecast:
+		m.forecastView, cmd = m.forecastView.Update(msg.Msg)
+	}
+	if loaded, ok := msg.Msg.(tui.TasksLoadedMsg); ok {
+		m = m.reconcileLoaded(loaded.Tasks)
+	}
+	if _, ok := msg.Msg.(tui.ErrorMsg); ok {
+		var checkCmd tea.Cmd
+		m, checkCmd = m.checkPermissionOnce()
+		cmd = tea.Batch(cmd, checkCmd)
+	}
+	return m, cmd
+}
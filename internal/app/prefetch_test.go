@@ -0,0 +1,86 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func newPrefetchTestService() *service.MockOmniFocusService {
+	return &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "t1", Name: "Buy milk"}},
+		Projects:   []domain.Project{{ID: "p1", Name: "Garden", Status: "active"}},
+		Tags:       []domain.Tag{{ID: "g1", Name: "errands"}},
+	}
+}
+
+func TestPrefetch_LoadsOtherViews(t *testing.T) {
+	app := startApp(t, NewApp(newPrefetchTestService()))
+
+	if got := app.inboxView.TaskCount(); got != 1 {
+		t.Errorf("inbox TaskCount() = %d, want 1", got)
+	}
+	if view := app.projectsView.View(); !strings.Contains(view, "Garden") {
+		t.Errorf("projectsView.View() = %q, want it to contain the prefetched project", view)
+	}
+	if view := app.tagsView.View(); !strings.Contains(view, "errands") {
+		t.Errorf("tagsView.View() = %q, want it to contain the prefetched tag", view)
+	}
+	if app.statusBar.Loading() {
+		t.Error("statusBar.Loading() = true after all views loaded, want false")
+	}
+}
+
+func TestPrefetch_MarksTabsLoading(t *testing.T) {
+	app := NewApp(newPrefetchTestService())
+
+	app, cmd := app.prefetch()
+
+	if cmd == nil {
+		t.Fatal("prefetch() returned no command")
+	}
+	if app.statusBar.TabLoading(slices.Index(tabViews, tui.ViewInbox)) {
+		t.Error("inbox tab loading, want the current view left to its own load")
+	}
+	for _, view := range []int{tui.ViewProjects, tui.ViewTags, tui.ViewForecast} {
+		if !app.statusBar.TabLoading(slices.Index(tabViews, view)) {
+			t.Errorf("tab of view %d not loading, want a spinner until its data arrives", view)
+		}
+	}
+}
+
+func TestPrefetch_SwitchToLoadingViewSkipsReload(t *testing.T) {
+	app := NewApp(newPrefetchTestService())
+	app, _ = app.prefetch()
+
+	newModel, cmd := app.switchView(tui.ViewProjects)
+	app = newModel.(Model)
+
+	if app.currentView != tui.ViewProjects {
+		t.Errorf("currentView = %d, want projects", app.currentView)
+	}
+	if cmd != nil {
+		t.Error("switchView() returned a command, want the background load reused")
+	}
+}
+
+func TestHandleViewLoaded_ClearsLoading(t *testing.T) {
+	app := NewApp(newPrefetchTestService())
+	app, _ = app.prefetch()
+
+	app, _ = app.handleViewLoaded(viewLoadedMsg{
+		View: tui.ViewTags,
+		Msg:  tui.TagsLoadedMsg{Tags: []domain.Tag{{ID: "g1", Name: "errands"}}},
+	})
+
+	if app.statusBar.TabLoading(slices.Index(tabViews, tui.ViewTags)) {
+		t.Error("tags tab still loading after its data arrived")
+	}
+	if !app.statusBar.TabLoading(slices.Index(tabViews, tui.ViewProjects)) {
+		t.Error("projects tab stopped loading, want only the loaded view cleared")
+	}
+}
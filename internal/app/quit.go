@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/confirm"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// Answers offered when quitting with writes in flight
+const (
+	quitWait       = "w"
+	quitDiscard    = "d"
+	quitBackground = "b"
+)
+
+// QuitContext marks the confirm modal asking what to do with pending writes
+type QuitContext struct{}
+
+// quit exits the app, first asking what to do with writes still in flight.
+// Quitting again while the question is shown exits without waiting.
+func (m Model) quit() (Model, tea.Cmd) {
+	tracker, ok := m.service.(service.PendingTracker)
+	if !ok || m.askingQuit() {
+		return m, tea.Quit
+	}
+
+	n := len(tracker.PendingWrites())
+	if n == 0 {
+		return m, tea.Quit
+	}
+
+	noun := "operations"
+	if n == 1 {
+		noun = "operation"
+	}
+	m.confirmModal = m.confirmModal.ShowWithChoices(
+		"Quit",
+		fmt.Sprintf("%d %s pending — wait, discard, or background?", n, noun),
+		[]confirm.Choice{
+			{Key: quitWait, Label: "Wait"},
+			{Key: quitDiscard, Label: "Discard"},
+			{Key: quitBackground, Label: "Background"},
+		},
+		QuitContext{},
+	)
+	return m, nil
+}
+
+// askingQuit reports whether the pending writes question is shown
+func (m Model) askingQuit() bool {
+	_, ok := m.confirmModal.Context().(QuitContext)
+	return ok && m.confirmModal.IsVisible()
+}
+
+// handleQuitChoice carries out the answer to the pending writes question
+func (m Model) handleQuitChoice(choice string) (Model, tea.Cmd) {
+	tracker, ok := m.service.(service.PendingTracker)
+	if !ok {
+		return m, tea.Quit
+	}
+
+	switch choice {
+	case quitWait:
+		m, toastCmd := m.pushToast(toast.Info, "Waiting for pending operations to finish…")
+		return m, tea.Batch(toastCmd, func() tea.Msg {
+			tracker.WaitIdle()
+			return tea.Quit()
+		})
+	case quitBackground:
+		// Writes failing from now on are kept for the background flush
+		m.backgroundWrites = tracker.Detach()
+		return m, tea.Quit
+	default:
+		return m, tea.Quit
+	}
+}
+
+// BackgroundWrites returns the writes the user chose to finish in the
+// background when quitting
+func (m Model) BackgroundWrites() []service.PendingWrite {
+	return m.backgroundWrites
+}
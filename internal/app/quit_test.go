@@ -0,0 +1,132 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/confirm"
+)
+
+// pendingService reports a fixed set of writes in flight
+type pendingService struct {
+	*service.MockOmniFocusService
+	writes []service.PendingWrite
+}
+
+func (p *pendingService) PendingWrites() []service.PendingWrite {
+	return p.writes
+}
+
+func (p *pendingService) WaitIdle() {}
+
+func (p *pendingService) Detach() []service.PendingWrite {
+	return p.writes
+}
+
+func newQuitTestApp(writes ...service.PendingWrite) (Model, *pendingService) {
+	svc := &pendingService{MockOmniFocusService: &service.MockOmniFocusService{}, writes: writes}
+	app, _ := NewApp(svc).Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return app.(Model), svc
+}
+
+func isQuit(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+func TestQuit_NoPendingWritesQuitsImmediately(t *testing.T) {
+	app, _ := newQuitTestApp()
+
+	newModel, cmd := app.Update(runeKey('q'))
+
+	if !isQuit(cmd) {
+		t.Error("Update(q) should quit when nothing is pending")
+	}
+	if newModel.(Model).confirmModal.IsVisible() {
+		t.Error("Update(q) should not ask when nothing is pending")
+	}
+}
+
+func TestQuit_PendingWritesAsk(t *testing.T) {
+	app, _ := newQuitTestApp(service.PendingWrite{Method: "CompleteTask", ID: "t1"}, service.PendingWrite{Method: "DeleteTask", ID: "t2"})
+
+	newModel, cmd := app.Update(runeKey('q'))
+	app = newModel.(Model)
+
+	if cmd != nil {
+		t.Error("Update(q) should not quit while writes are pending")
+	}
+	if !app.confirmModal.IsVisible() {
+		t.Fatal("Update(q) should show the pending operations question")
+	}
+	if view := app.confirmModal.View(); !strings.Contains(view, "2 operations pending") || !strings.Contains(view, "[b] Background") {
+		t.Errorf("confirm view = %q, want pending count and choices", view)
+	}
+
+	// Quitting again exits without waiting
+	_, cmd = app.Update(runeKey('q'))
+	if !isQuit(cmd) {
+		t.Error("second Update(q) should quit")
+	}
+}
+
+func TestQuit_OtherModalStillAsks(t *testing.T) {
+	app, _ := newQuitTestApp(service.PendingWrite{Method: "CompleteTask", ID: "t1"})
+	app.confirmModal = app.confirmModal.ShowWithContext("Delete Task", "Delete?", DeleteContext{})
+
+	newModel, cmd := app.Update(runeKey('q'))
+	app = newModel.(Model)
+
+	if isQuit(cmd) {
+		t.Error("Update(q) over another modal should ask about pending writes, not quit")
+	}
+	if !app.askingQuit() {
+		t.Error("Update(q) over another modal should show the pending operations question")
+	}
+}
+
+func TestQuit_Choices(t *testing.T) {
+	writes := []service.PendingWrite{{Method: "CompleteTask", ID: "t1"}}
+
+	tests := []struct {
+		choice         string
+		wantBackground int
+	}{
+		{choice: quitWait},
+		{choice: quitDiscard},
+		{choice: quitBackground, wantBackground: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.choice, func(t *testing.T) {
+			app, _ := newQuitTestApp(writes...)
+			newModel, _ := app.Update(runeKey('q'))
+			newModel, cmd := newModel.(Model).Update(runeKey(rune(tt.choice[0])))
+			if cmd == nil {
+				t.Fatal("choice key should return a command")
+			}
+			choice, ok := cmd().(confirm.ChoiceMsg)
+			if !ok {
+				t.Fatalf("choice key should send ChoiceMsg, got %T", cmd())
+			}
+
+			newModel, cmd = newModel.(Model).Update(choice)
+			app = newModel.(Model)
+
+			if cmd == nil {
+				t.Fatal("choice should return a command")
+			}
+			if tt.choice != quitWait && !isQuit(cmd) {
+				t.Errorf("choice %s should quit", tt.choice)
+			}
+			if got := len(app.BackgroundWrites()); got != tt.wantBackground {
+				t.Errorf("BackgroundWrites() = %d writes, want %d", got, tt.wantBackground)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// taskRefreshedMsg carries a task reloaded on its own after a change; a nil
+// task means it could not be loaded and the current view reloads instead
+type taskRefreshedMsg struct {
+	Task *domain.Task
+}
+
+// refreshModified reloads just the modified task so its rows can be patched
+// in place, or reloads the whole current view when the change may move the
+// task into or out of it
+func (m Model) refreshModified(msg tui.TaskModifiedMsg) tea.Cmd {
+	if m.changesMembership(msg.Modification) {
+		return m.refreshCurrentView()
+	}
+	svc := m.service
+	id := msg.Task.ID
+	return func() tea.Msg {
+		task, err := svc.GetTaskByID(context.Background(), id)
+		if err != nil || task == nil {
+			return taskRefreshedMsg{}
+		}
+		return taskRefreshedMsg{Task: task}
+	}
+}
+
+// changesMembership reports whether mod may change which tasks the current
+// view lists. Sorting and grouping are redone when a row is patched.
+func (m Model) changesMembership(mod domain.TaskModification) bool {
+	if mod.ProjectID != nil || mod.HasTagChanges() || m.filterState.IsActive() {
+		return true
+	}
+	switch m.currentView {
+	case tui.ViewForecast:
+		return mod.DueDate != nil || mod.ClearDue || mod.DeferDate != nil || mod.ClearDefer
+	case tui.ViewReview:
+		return mod.Flagged != nil
+	case tui.ViewStats:
+		return true
+	}
+	return false
+}
+
+// handleTaskRefreshed patches a reloaded task into every view, keeping the
+// subtasks the views already have
+func (m Model) handleTaskRefreshed(msg taskRefreshedMsg) (Model, tea.Cmd) {
+	if msg.Task == nil {
+		return m, m.refreshCurrentView()
+	}
+	fresh := *msg.Task
+	m = m.patchTask(fresh.ID, func(task *domain.Task) {
+		children := task.Children
+		*task = fresh
+		task.Children = children
+	})
+	return m.reconcileTask(fresh), nil
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func TestRefreshModified_PatchesTaskInPlace(t *testing.T) {
+	app := newCompleteTestApp(nil, "")
+	mockSvc := app.service.(*service.MockOmniFocusService)
+	mockSvc.Task = &domain.Task{ID: "1", Name: "One renamed"}
+
+	name := "One renamed"
+	cmd := app.refreshModified(tui.TaskModifiedMsg{Task: domain.Task{ID: "1"}, Modification: domain.TaskModification{Name: &name}})
+	msg, ok := cmd().(taskRefreshedMsg)
+	if !ok || msg.Task == nil {
+		t.Fatalf("cmd() = %v, want taskRefreshedMsg with the reloaded task", msg)
+	}
+
+	model, cmd := app.Update(msg)
+	app = model.(Model)
+	if cmd != nil {
+		t.Errorf("patching the task returned a command, want no reload")
+	}
+	if task := app.getSelectedTask(); task == nil || task.Name != "One renamed" {
+		t.Errorf("selected task = %v, want it renamed in place", task)
+	}
+	if got := app.inboxView.TaskCount(); got != 2 {
+		t.Errorf("TaskCount() = %d, want 2", got)
+	}
+}
+
+func TestRefreshModified_ReloadsWhenTaskMayLeaveView(t *testing.T) {
+	projectID := "p1"
+	name := "Renamed"
+	flagged := false
+	tests := []struct {
+		name string
+		view int
+		mod  domain.TaskModification
+	}{
+		{"moved to a project", tui.ViewInbox, domain.TaskModification{ProjectID: &projectID}},
+		{"tagged", tui.ViewInbox, domain.TaskModification{AddTags: []string{"Errands"}}},
+		{"unflagged in review", tui.ViewReview, domain.TaskModification{Flagged: &flagged}},
+		{"due date cleared in forecast", tui.ViewForecast, domain.TaskModification{ClearDue: true}},
+		{"renamed in stats", tui.ViewStats, domain.TaskModification{Name: &name}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newCompleteTestApp(nil, "")
+			app.currentView = tt.view
+			if !app.changesMembership(tt.mod) {
+				t.Errorf("changesMembership() = false, want a full reload")
+			}
+		})
+	}
+}
+
+func TestRefreshModified_FallsBackWhenTaskCannotBeLoaded(t *testing.T) {
+	app := newCompleteTestApp(nil, "")
+	app.service.(*service.MockOmniFocusService).TaskErr = errors.New("OmniFocus is not running")
+
+	name := "One renamed"
+	cmd := app.refreshModified(tui.TaskModifiedMsg{Task: domain.Task{ID: "1"}, Modification: domain.TaskModification{Name: &name}})
+	msg, ok := cmd().(taskRefreshedMsg)
+	if !ok || msg.Task != nil {
+		t.Fatalf("cmd() = %v, want an empty taskRefreshedMsg", msg)
+	}
+
+	_, cmd = app.Update(msg)
+	if cmd == nil {
+		t.Fatal("a failed reload of the task should reload the view")
+	}
+	if _, ok := cmd().(tui.TasksLoadedMsg); !ok {
+		t.Errorf("cmd() is not TasksLoadedMsg, want the inbox reloaded")
+	}
+}
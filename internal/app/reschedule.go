@@ -0,0 +1,127 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/datepicker"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/taskedit"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// executeDateKey opens the date picker to reschedule the marked tasks, or the
+// selected task
+func (m Model) executeDateKey(field datepicker.Field) Model {
+	title := "Due Date"
+	if field == datepicker.FieldDefer {
+		title = "Defer Date"
+	}
+
+	if marked := m.getMarkedTasks(); len(marked) > 0 {
+		m.datingForEdit = false
+		title = fmt.Sprintf("%s of %d Tasks", title, len(marked))
+		m.datePicker = m.datePicker.Show(title, field, nil, time.Now())
+		return m
+	}
+
+	task := m.getSelectedTask()
+	if task == nil {
+		return m
+	}
+	m.datingForEdit = false
+	m.datePicker = m.datePicker.Show(title, field, taskDate(*task, field), time.Now())
+	return m
+}
+
+// showDatePickerForEdit opens the date picker for a date field of the task
+// edit overlay
+func (m Model) showDatePickerForEdit(msg taskedit.PickDateMsg) Model {
+	field, title := datepicker.FieldDue, "Pick Due Date"
+	if msg.Field == taskedit.FieldDeferDate {
+		field, title = datepicker.FieldDefer, "Pick Defer Date"
+	}
+	m.datingForEdit = true
+	m.datePicker = m.datePicker.Show(title, field, msg.Date, time.Now())
+	return m
+}
+
+// handleDatePickerMessages handles messages from the date picker
+func (m Model) handleDatePickerMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case datepicker.PickedMsg:
+		if m.datingForEdit {
+			field := taskedit.FieldDueDate
+			if msg.Field == datepicker.FieldDefer {
+				field = taskedit.FieldDeferDate
+			}
+			m.taskEdit = m.taskEdit.SetDate(field, msg.Date)
+			return m, nil, true
+		}
+		newModel, cmd := m.reschedule(msg.Field, msg.Date)
+		return newModel, cmd, true
+
+	case datepicker.CancelledMsg:
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// reschedule sets the due or defer date of the marked tasks, after
+// confirmation, or of the selected task; a nil date clears it
+func (m Model) reschedule(field datepicker.Field, date *time.Time) (Model, tea.Cmd) {
+	var mod domain.TaskModification
+	switch {
+	case field == datepicker.FieldDue && date != nil:
+		mod.DueDate = date
+	case field == datepicker.FieldDue:
+		mod.ClearDue = true
+	case date != nil:
+		mod.DeferDate = date
+	default:
+		mod.ClearDefer = true
+	}
+
+	if marked := m.getMarkedTasks(); len(marked) > 0 {
+		op := domain.BatchOperation{Action: domain.BatchModify, Modification: mod}
+		verb := fmt.Sprintf("Clear the %s date of", field)
+		if date != nil {
+			verb = fmt.Sprintf("Set the %s date to %s:", field, date.Format("Mon Jan 2"))
+		}
+		return m.confirmBatch("Reschedule Tasks", verb, op, marked), nil
+	}
+
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+	if current := taskDate(*task, field); (current == nil && date == nil) || (current != nil && date != nil && current.Equal(*date)) {
+		return m, nil
+	}
+	return m, m.modifyTask(task.ID, mod, task)
+}
+
+// bumpSelected shifts the due date of the selected task, or its defer date
+// when it has no due date
+func (m Model) bumpSelected(bump domain.Bump) (Model, tea.Cmd) {
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+	mod, err := bump.Modification(*task, dateparse.RollForward)
+	if errors.Is(err, domain.ErrNothingToBump) {
+		return m.pushToast(toast.Info, taskToastText("No due or defer date to bump on", task.Name))
+	}
+	return m, m.modifyTask(task.ID, mod, task)
+}
+
+// taskDate returns the task's due or defer date
+func taskDate(task domain.Task, field datepicker.Field) *time.Time {
+	if field == datepicker.FieldDefer {
+		return task.DeferDate
+	}
+	return task.DueDate
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/confirm"
+)
+
+func TestDueKey_ReschedulesSelectedTask(t *testing.T) {
+	app, mockSvc := newMoveTestApp()
+
+	app = update(app, runeKey('D'))
+	if !app.datePicker.IsVisible() {
+		t.Fatal("D should open the date picker")
+	}
+
+	model, cmd := app.Update(runeKey('m'))
+	app = update(model.(Model), cmd())
+	if app.datePicker.IsVisible() {
+		t.Error("picking a date should close the picker")
+	}
+	mod, ok := mockSvc.Modifications["1"]
+	if !ok || mod.DueDate == nil {
+		t.Fatalf("ModifyTask() modifications = %+v, want a due date", mockSvc.Modifications)
+	}
+	if tomorrow := time.Now().AddDate(0, 0, 1); mod.DueDate.Day() != tomorrow.Day() {
+		t.Errorf("DueDate = %v, want tomorrow", mod.DueDate)
+	}
+}
+
+func TestDeferKey_ClearsMarkedTasksAfterConfirmation(t *testing.T) {
+	app, _ := newMoveTestApp()
+	app = update(app, tui.TasksLoadedMsg{Tasks: []domain.Task{{ID: "1", Name: "Buy paint"}, {ID: "2", Name: "Call plumber"}}})
+	app = update(app, runeKey(' '))
+
+	app = update(app, tea.KeyMsg{Type: tea.KeyCtrlD})
+	if !app.datePicker.IsVisible() {
+		t.Fatal("Ctrl+D should open the date picker")
+	}
+	model, cmd := app.Update(runeKey('x'))
+	app = update(model.(Model), cmd())
+
+	if !app.confirmModal.IsVisible() {
+		t.Fatal("rescheduling marked tasks should ask for confirmation")
+	}
+	if !strings.Contains(app.confirmModal.View(), "Clear the defer date of 1 task?") {
+		t.Errorf("expected a reschedule summary, got: %s", app.confirmModal.View())
+	}
+
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	confirmed, ok := cmd().(confirm.ConfirmedMsg)
+	if !ok {
+		t.Fatal("expected ConfirmedMsg")
+	}
+	if ctx, ok := confirmed.Context.(BatchContext); !ok || !ctx.Operation.Modification.ClearDefer {
+		t.Errorf("confirmed context = %+v, want clearing the defer date", confirmed.Context)
+	}
+}
+
+func TestTaskEdit_PicksDueDate(t *testing.T) {
+	app, mockSvc := newMoveTestApp()
+
+	app = update(app, runeKey('e'))
+	for i := 0; i < 4; i++ {
+		app = update(app, tea.KeyMsg{Type: tea.KeyTab})
+	}
+	app = update(app, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if !app.datePicker.IsVisible() || !app.taskEdit.IsVisible() {
+		t.Fatal("Ctrl+P on the due field should open the date picker over the edit form")
+	}
+
+	model, cmd := app.Update(runeKey('t'))
+	app = update(model.(Model), cmd())
+	if app.datePicker.IsVisible() || !app.taskEdit.IsVisible() {
+		t.Fatal("picking a date should return to the edit form")
+	}
+	if len(mockSvc.Modifications) != 0 {
+		t.Error("picking a date for the form should not save yet")
+	}
+
+	model, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = update(model.(Model), cmd())
+	if mod := mockSvc.Modifications["1"]; mod.DueDate == nil || mod.DueDate.Day() != time.Now().Day() {
+		t.Errorf("saved modification = %+v, want today's due date", mod)
+	}
+}
+
+func TestBumpKeys_ShiftSelectedTaskDate(t *testing.T) {
+	app, mockSvc := newMoveTestApp()
+	due := time.Date(2026, 3, 2, 17, 0, 0, 0, time.Local)
+	deferDate := time.Date(2026, 3, 1, 8, 0, 0, 0, time.Local)
+	app = update(app, tui.TasksLoadedMsg{Tasks: []domain.Task{
+		{ID: "1", Name: "Buy paint", DueDate: &due},
+		{ID: "2", Name: "Call plumber", DeferDate: &deferDate},
+	}})
+
+	_, cmd := app.Update(runeKey('+'))
+	if cmd == nil {
+		t.Fatal("+ should modify the selected task")
+	}
+	cmd()
+	if mod := mockSvc.Modifications["1"]; mod.DueDate == nil || !mod.DueDate.Equal(due.AddDate(0, 0, 1)) {
+		t.Errorf("DueDate = %v, want a day later", mod.DueDate)
+	}
+
+	app = update(app, runeKey('j'))
+	_, cmd = app.Update(runeKey('-'))
+	if cmd == nil {
+		t.Fatal("- should modify the selected task")
+	}
+	cmd()
+	if mod := mockSvc.Modifications["2"]; mod.DueDate != nil || mod.DeferDate == nil || !mod.DeferDate.Equal(deferDate.AddDate(0, 0, -1)) {
+		t.Errorf("modification = %+v, want the defer date a day earlier", mod)
+	}
+}
+
+func TestBumpKey_WithoutDates(t *testing.T) {
+	app, mockSvc := newMoveTestApp()
+
+	model, _ := app.Update(runeKey('+'))
+	app = model.(Model)
+	if len(mockSvc.Modifications) != 0 {
+		t.Errorf("ModifyTask() called with %+v, want no change", mockSvc.Modifications)
+	}
+	if toasts := strings.Join(app.toasts.Messages(), "\n"); !strings.Contains(toasts, `No due or defer date to bump on "Buy paint"`) {
+		t.Errorf("toasts = %q, want the no-date notice", toasts)
+	}
+}
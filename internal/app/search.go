@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/searchresults"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// goKey starts a two-key sequence; goKey then "/" searches every task
+const goKey = "g"
+
+// searchLoadedMsg carries the tasks a search across the database found
+type searchLoadedMsg struct {
+	Query string
+	Tasks []domain.Task
+	Err   error
+}
+
+// handleGoKey handles goKey and the key after it. Returns true if the key
+// was consumed; a key goKey does not prefix is handled as usual.
+func (m Model) handleGoKey(keyMsg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.goPending {
+		m.goPending = false
+		if keyMsg.String() == "/" {
+			m.palette = m.palette.ShowWith("search-all ")
+			return m, m.loadPaletteItems(), true
+		}
+		return m, nil, false
+	}
+	if keyMsg.String() == goKey {
+		m.goPending = true
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// executeSearchAllCommand handles the "search-all" command, searching task
+// names and notes across the whole database in the background
+func (m Model) executeSearchAllCommand(cmd *command.Command) (Model, tea.Cmd) {
+	query := strings.TrimSpace(strings.Join(cmd.Args, " "))
+	if query == "" {
+		return m.pushToast(toast.Error, "Usage: :search-all <text>")
+	}
+
+	svc := m.service
+	return m, func() tea.Msg {
+		tasks, err := svc.SearchTasks(context.Background(), query)
+		return searchLoadedMsg{Query: query, Tasks: tasks, Err: err}
+	}
+}
+
+// handleSearchResultsMessages handles the tasks found by :search-all and
+// messages from the results overlay
+func (m Model) handleSearchResultsMessages(msg tea.Msg) (Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case searchLoadedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m.withToast(toast.Error, fmt.Sprintf("Failed to search tasks: %v", msg.Err))
+		}
+		m.searchResults = m.searchResults.Show(msg.Query, msg.Tasks)
+		return m, nil, true
+
+	case searchresults.SelectedMsg:
+		task := msg.Task
+		m.taskDetail = m.taskDetail.Show(&task)
+		return m, nil, true
+
+	case searchresults.ProjectMsg:
+		m.currentView = tui.ViewProjects
+		var cmd tea.Cmd
+		m.projectsView, cmd = m.projectsView.OpenProject(msg.Task.ProjectID, msg.Task.ProjectName, msg.Task.ID)
+		return m, cmd, true
+
+	case searchresults.CancelledMsg:
+		return m, nil, true
+	}
+	return m, nil, false
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+)
+
+func searchApp(t *testing.T, mockSvc *service.MockOmniFocusService) Model {
+	t.Helper()
+	app := NewApp(mockSvc)
+	newModel, _ := app.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	app = newModel.(Model)
+
+	app, cmd := app.executeCommand(&command.Command{Name: "search-all", Args: []string{"passport", "photos"}})
+	newModel, _ = app.Update(cmd())
+	return newModel.(Model)
+}
+
+func TestSearchAllCommand_ShowsResultsAndOpensTask(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		SearchResults: []domain.Task{
+			{ID: "t1", Name: "Book passport photos", ProjectID: "p1", ProjectName: "Travel"},
+		},
+	}
+	app := searchApp(t, mockSvc)
+
+	if mockSvc.SearchQuery != "passport photos" {
+		t.Errorf("SearchTasks() query = %q, want %q", mockSvc.SearchQuery, "passport photos")
+	}
+	if !app.searchResults.IsVisible() {
+		t.Fatal("search results should be visible after :search-all")
+	}
+	view := app.View()
+	for _, want := range []string{"Search: passport photos (1)", "Book passport photos", "Travel"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+
+	newModel, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = newModel.(Model)
+	newModel, _ = app.Update(cmd())
+	app = newModel.(Model)
+
+	if task := app.taskDetail.Task(); !app.taskDetail.IsVisible() || task == nil || task.ID != "t1" {
+		t.Errorf("task detail = %+v, want t1", task)
+	}
+}
+
+func TestSearchAllCommand_JumpsToProject(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		SearchResults: []domain.Task{{ID: "t2", Name: "Book passport photos", ProjectID: "p1", ProjectName: "Travel"}},
+		ProjectTasks:  []domain.Task{{ID: "t1", Name: "Pack"}, {ID: "t2", Name: "Book passport photos"}},
+	}
+	app := searchApp(t, mockSvc)
+
+	newModel, cmd := app.Update(runeKey('p'))
+	app = newModel.(Model)
+	newModel, cmd = app.Update(cmd())
+	app = newModel.(Model)
+	newModel, _ = app.Update(cmd())
+	app = newModel.(Model)
+
+	if app.currentView != tui.ViewProjects {
+		t.Fatalf("currentView = %d, want the projects view", app.currentView)
+	}
+	if task := app.getSelectedTask(); task == nil || task.ID != "t2" {
+		t.Errorf("selected task = %+v, want t2 in its project", task)
+	}
+}
+
+func TestSearchAllCommand_Errors(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{})
+	if _, cmd := app.executeCommand(&command.Command{Name: "search-all"}); cmd == nil {
+		t.Error("expected a usage toast without search text")
+	}
+
+	app = searchApp(t, &service.MockOmniFocusService{SearchErr: errors.New("OmniFocus is not running")})
+	if app.searchResults.IsVisible() {
+		t.Error("search results should stay closed when the search fails")
+	}
+	if app.err == nil {
+		t.Error("expected the search error to be recorded")
+	}
+}
+
+func TestGoKey_OpensSearchAll(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{})
+	newModel, _ := app.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	newModel, _ = newModel.(Model).Update(runeKey('g'))
+	newModel, _ = newModel.(Model).Update(runeKey('/'))
+	app = newModel.(Model)
+
+	if !app.palette.IsVisible() || app.searchInput.IsVisible() {
+		t.Fatal("g / should open the palette for :search-all, not the view search")
+	}
+	if !strings.Contains(app.palette.View(), "search-all") {
+		t.Errorf("palette = %q, want it prefilled with search-all", app.palette.View())
+	}
+
+	// g followed by another key leaves that key to do its usual job
+	newModel, _ = NewApp(&service.MockOmniFocusService{}).Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	newModel, _ = newModel.(Model).Update(runeKey('g'))
+	newModel, _ = newModel.(Model).Update(runeKey('?'))
+	app = newModel.(Model)
+	if !app.showHelp || app.goPending {
+		t.Error("g ? should still toggle help and end the sequence")
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/session"
+)
+
+// viewNames are the names of views in saved session state
+var viewNames = map[int]string{
+	tui.ViewInbox:    "inbox",
+	tui.ViewProjects: "projects",
+	tui.ViewTags:     "tags",
+	tui.ViewForecast: "forecast",
+	tui.ViewReview:   "review",
+	tui.ViewStats:    "stats",
+}
+
+// Session returns the state to restore on the next start
+func (m Model) Session() session.State {
+	state := session.State{
+		View:              viewNames[m.currentView],
+		ForecastCollapsed: m.forecastView.CollapsedGroups(),
+		Filter:            m.filterState,
+		Pinned:            m.pinnedIDs(),
+		Sort:              m.sessionSorts(),
+	}
+
+	var selected *domain.Task
+	switch m.currentView {
+	case tui.ViewInbox:
+		selected = m.inboxView.SelectedTask()
+	case tui.ViewForecast:
+		selected = m.forecastView.SelectedTask()
+	}
+	if selected != nil {
+		state.SelectedTask = selected.ID
+	}
+	return state
+}
+
+// RestoreSession returns the model with a saved session applied. Call it
+// before the program starts so Init loads the restored view.
+func (m Model) RestoreSession(state session.State) Model {
+	for view, name := range viewNames {
+		if name == state.View {
+			m.currentView = view
+		}
+	}
+
+	m.forecastView = m.forecastView.SetCollapsedGroups(state.ForecastCollapsed)
+	m.filterState = state.Filter
+	m = m.applyFilterToCurrentView()
+
+	pinned := make(map[string]bool, len(state.Pinned))
+	for _, id := range state.Pinned {
+		pinned[id] = true
+	}
+	m = m.setPinned(pinned)
+	m = m.restoreSorts(state.Sort)
+
+	if state.SelectedTask != "" {
+		switch m.currentView {
+		case tui.ViewInbox:
+			m.inboxView = m.inboxView.SelectTask(state.SelectedTask)
+		case tui.ViewForecast:
+			m.forecastView = m.forecastView.SelectTask(state.SelectedTask)
+		}
+	}
+	return m
+}
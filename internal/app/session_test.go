@@ -0,0 +1,107 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/session"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/views/forecast"
+)
+
+// startApp runs Init and feeds its results back, as the program does on
+// start, including the background loads of the other views
+func startApp(t *testing.T, app Model) Model {
+	t.Helper()
+	newModel, _ := app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app = newModel.(Model)
+
+	cmds := []tea.Cmd{app.Init()}
+	for len(cmds) > 0 {
+		cmd := cmds[0]
+		cmds = cmds[1:]
+		if cmd == nil {
+			continue
+		}
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			cmds = append(cmds, msg...)
+		case spinner.TickMsg:
+			// Leave the spinner still rather than wait for its frames
+		default:
+			var next tea.Cmd
+			newModel, next = app.Update(msg)
+			app = newModel.(Model)
+			if _, ok := msg.(prefetchMsg); ok {
+				cmds = append(cmds, next)
+			}
+		}
+	}
+	return app
+}
+
+func TestSession_RestoresInbox(t *testing.T) {
+	svc := &service.MockOmniFocusService{InboxTasks: []domain.Task{
+		{ID: "t1", Name: "Buy milk", Flagged: true},
+		{ID: "t2", Name: "Call bank", Flagged: true},
+		{ID: "t3", Name: "Read book"},
+	}}
+	state := session.State{View: "inbox", SelectedTask: "t2", Filter: filter.State{FlaggedOnly: true}}
+
+	app := startApp(t, NewApp(svc).RestoreSession(state))
+
+	if app.currentView != tui.ViewInbox {
+		t.Errorf("currentView = %d, want inbox", app.currentView)
+	}
+	if got := app.inboxView.TaskCount(); got != 2 {
+		t.Errorf("inbox TaskCount() = %d, want 2 flagged tasks", got)
+	}
+	if got := app.Session(); !reflect.DeepEqual(got, state) {
+		t.Errorf("Session() = %+v, want %+v", got, state)
+	}
+}
+
+func TestSession_RestoresForecast(t *testing.T) {
+	due := time.Now().Add(time.Hour)
+	past := time.Now().AddDate(0, 0, -2)
+	svc := &service.MockOmniFocusService{AllTasks: []domain.Task{
+		{ID: "t1", Name: "Late report", DueDate: &past},
+		{ID: "t2", Name: "Pay rent", DueDate: &due},
+		{ID: "t3", Name: "Call bank", DueDate: &due},
+	}}
+	state := session.State{
+		View:              "forecast",
+		SelectedTask:      "t3",
+		ForecastCollapsed: []forecast.DueGroup{forecast.GroupOverdue},
+	}
+
+	app := startApp(t, NewApp(svc).RestoreSession(state))
+
+	if app.currentView != tui.ViewForecast {
+		t.Fatalf("currentView = %d, want forecast", app.currentView)
+	}
+	if task := app.forecastView.SelectedTask(); task == nil || task.ID != "t3" {
+		t.Errorf("forecast SelectedTask() = %+v, want t3", task)
+	}
+	if got := app.Session(); !reflect.DeepEqual(got, state) {
+		t.Errorf("Session() = %+v, want %+v", got, state)
+	}
+}
+
+func TestSession_UnknownViewKeepsDefault(t *testing.T) {
+	app := NewApp(&service.MockOmniFocusService{}).RestoreSession(session.State{View: "calendar", SelectedTask: "gone"})
+
+	if app.currentView != tui.ViewInbox {
+		t.Errorf("currentView = %d, want inbox", app.currentView)
+	}
+	app = startApp(t, app)
+	if got := app.Session().SelectedTask; got != "" {
+		t.Errorf("Session().SelectedTask = %q, want none for a task that no longer exists", got)
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// cycleSort sorts the current view by the next sort mode
+func (m Model) cycleSort() (Model, tea.Cmd) {
+	return m.sortCurrentView(m.sorts[m.currentView].Next())
+}
+
+// executeSortCommand handles the "sort" command; without a mode it cycles
+// like the sort key
+func (m Model) executeSortCommand(cmd *command.Command) (Model, tea.Cmd) {
+	if len(cmd.Args) == 0 {
+		return m.cycleSort()
+	}
+	mode, err := tui.ParseSortMode(strings.Join(cmd.Args, " "))
+	if err != nil {
+		return m.pushToast(toast.Error, err.Error())
+	}
+	return m.sortCurrentView(mode)
+}
+
+// sortCurrentView sorts the current view by mode. The stats view lists no
+// tasks and keeps no sort.
+func (m Model) sortCurrentView(mode tui.SortMode) (Model, tea.Cmd) {
+	if m.currentView == tui.ViewStats {
+		return m, nil
+	}
+	m = m.setSort(m.currentView, mode)
+	return m.pushToast(toast.Info, "Sorted by "+mode.Label())
+}
+
+// setSort sets the sort mode of view. Sorting is local to the TUI and kept
+// in the session state per view.
+func (m Model) setSort(view int, mode tui.SortMode) Model {
+	sorts := make(map[int]tui.SortMode, len(m.sorts)+1)
+	for v, s := range m.sorts {
+		sorts[v] = s
+	}
+	if mode.IsDefault() {
+		delete(sorts, view)
+	} else {
+		sorts[view] = mode
+	}
+	m.sorts = sorts
+
+	switch view {
+	case tui.ViewInbox:
+		m.inboxView = m.inboxView.SetSort(mode)
+	case tui.ViewProjects:
+		m.projectsView = m.projectsView.SetSort(mode)
+	case tui.ViewTags:
+		m.tagsView = m.tagsView.SetSort(mode)
+	case tui.ViewForecast:
+		m.forecastView = m.forecastView.SetSort(mode)
+	case tui.ViewReview:
+		m.reviewView = m.reviewView.SetSort(mode)
+	}
+	return m
+}
+
+// sessionSorts returns the sort modes by view name for the session state
+func (m Model) sessionSorts() map[string]tui.SortMode {
+	if len(m.sorts) == 0 {
+		return nil
+	}
+	sorts := make(map[string]tui.SortMode, len(m.sorts))
+	for view, mode := range m.sorts {
+		sorts[viewNames[view]] = mode
+	}
+	return sorts
+}
+
+// restoreSorts applies the sort modes saved by view name, ignoring unknown
+// views and modes
+func (m Model) restoreSorts(sorts map[string]tui.SortMode) Model {
+	for view, name := range viewNames {
+		mode, err := tui.ParseSortMode(string(sorts[name]))
+		if err == nil && view != tui.ViewStats {
+			m = m.setSort(view, mode)
+		}
+	}
+	return m
+}
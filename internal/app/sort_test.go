@@ -0,0 +1,84 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/session"
+)
+
+func TestSort_KeyCyclesCurrentView(t *testing.T) {
+	app := newMacroTestApp()
+
+	app = pressKeys(t, app, "s")
+	if app.sorts[tui.ViewInbox] != tui.SortDue {
+		t.Fatalf("sorts = %v, want the inbox sorted by due date", app.sorts)
+	}
+	if !app.toasts.IsVisible() {
+		t.Error("sorting should show a toast")
+	}
+
+	app = pressKeys(t, app, "ss")
+	if task := app.inboxView.SelectedTask(); task == nil || task.ID != "1" {
+		t.Errorf("SelectedTask() = %v, want the selected task kept", task)
+	}
+	if !strings.Contains(app.inboxView.View(), "sorted by name") {
+		t.Errorf("inbox header should show the sort, got:\n%s", app.inboxView.View())
+	}
+
+	app = pressKeys(t, app, "sss")
+	if _, ok := app.sorts[tui.ViewInbox]; ok {
+		t.Errorf("sorts = %v, want the inbox back in added order after a full cycle", app.sorts)
+	}
+}
+
+func TestSort_Command(t *testing.T) {
+	app := newMacroTestApp()
+	parser := command.NewParser()
+
+	cmd, err := parser.Parse("sort name")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	app, _ = app.executeCommand(cmd)
+	// One stays selected, with Four above it
+	app = pressKeys(t, app, "k")
+	if task := app.inboxView.SelectedTask(); task == nil || task.Name != "Four" {
+		t.Errorf("task above One by name = %v, want Four", task)
+	}
+
+	cmd, _ = parser.Parse("sort priority")
+	app, _ = app.executeCommand(cmd)
+	if app.sorts[tui.ViewInbox] != tui.SortName {
+		t.Errorf("sorts = %v, want an unknown mode to keep the sort", app.sorts)
+	}
+}
+
+func TestSession_RestoresSorts(t *testing.T) {
+	svc := &service.MockOmniFocusService{InboxTasks: []domain.Task{
+		{ID: "t1", Name: "Call bank"},
+		{ID: "t2", Name: "Buy milk"},
+	}}
+	state := session.State{View: "inbox", SelectedTask: "t2", Sort: map[string]tui.SortMode{"inbox": tui.SortName, "forecast": tui.SortFlagged}}
+
+	app := startApp(t, NewApp(svc).RestoreSession(state))
+
+	if task := app.inboxView.SelectedTask(); task == nil || task.ID != "t2" {
+		t.Errorf("SelectedTask() = %v, want t2", task)
+	}
+	if app.sorts[tui.ViewForecast] != tui.SortFlagged {
+		t.Errorf("sorts = %v, want the forecast sort restored", app.sorts)
+	}
+	// Buy milk is listed first by name
+	if next := pressKeys(t, app, "j").inboxView.SelectedTask(); next == nil || next.ID != "t1" {
+		t.Errorf("task below Buy milk = %v, want Call bank", next)
+	}
+	if got := app.Session(); !reflect.DeepEqual(got, state) {
+		t.Errorf("Session() = %+v, want %+v", got, state)
+	}
+}
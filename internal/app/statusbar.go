@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// statusBarHeight is the number of lines the status bar takes below the current view
+const statusBarHeight = 1
+
+// tabViews lists the views in status bar tab order
+var tabViews = []int{
+	tui.ViewInbox,
+	tui.ViewProjects,
+	tui.ViewTags,
+	tui.ViewForecast,
+	tui.ViewReview,
+	tui.ViewStats,
+}
+
+// tabLabels returns the status bar label of each view, with its number key
+func tabLabels() []string {
+	labels := make([]string, 0, len(tabViews))
+	for i, view := range tabViews {
+		labels = append(labels, fmt.Sprintf("%d %s", i+1, viewName(view)))
+	}
+	return labels
+}
+
+// noteLoaded records the size and time of data loaded for a view, so the
+// status bar can show them
+func (m Model) noteLoaded(msg tea.Msg) Model {
+	var count string
+	switch msg := msg.(type) {
+	case tui.TasksLoadedMsg:
+		count = countLabel(len(msg.Tasks), "task")
+	case tui.ProjectsLoadedMsg:
+		count = countLabel(len(msg.Projects), "project")
+	case tui.TagsLoadedMsg:
+		count = countLabel(len(msg.Tags), "tag")
+	case tui.CompletedTasksLoadedMsg:
+		count = fmt.Sprintf("%d completed", len(msg.Tasks))
+	default:
+		return m
+	}
+	m.statusBar = m.statusBar.SetCount(count).SetRefreshed(time.Now())
+	return m
+}
+
+// countLabel formats n items of the given kind, e.g. "1 task" or "3 tasks"
+func countLabel(n int, kind string) string {
+	if n == 1 {
+		return "1 " + kind
+	}
+	return fmt.Sprintf("%d %ss", n, kind)
+}
+
+// renderStatusBar renders the status bar for the current view and filters
+func (m Model) renderStatusBar() string {
+	return m.statusBar.
+		SetActive(slices.Index(tabViews, m.currentView)).
+		SetFilters(m.filterState.Describe()).
+		SetRecording(m.macros.recording).
+		View()
+}
+
+// fitHeight pads or cuts view to exactly height lines, so the status bar
+// stays on the bottom line of the screen
+func fitHeight(view string, height int) string {
+	if height <= 0 {
+		return ""
+	}
+	lines := strings.Split(view, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
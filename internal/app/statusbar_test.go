@@ -0,0 +1,64 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func TestView_StatusBarOnBottomLine(t *testing.T) {
+	app := newMacroTestApp()
+	app.filterState = app.filterState.WithSearchText("milk")
+
+	lines := strings.Split(app.View(), "\n")
+	if len(lines) != app.height {
+		t.Fatalf("View() has %d lines, want %d", len(lines), app.height)
+	}
+	bottom := lines[len(lines)-1]
+	for _, want := range []string{"1 Inbox", "6 Stats", "4 tasks", `search: "milk"`, "refreshed"} {
+		if !strings.Contains(bottom, want) {
+			t.Errorf("status bar %q should contain %q", bottom, want)
+		}
+	}
+}
+
+func TestView_SearchInputReplacesStatusBar(t *testing.T) {
+	app := newMacroTestApp()
+	app.searchInput = app.searchInput.Show()
+
+	lines := strings.Split(app.View(), "\n")
+	if bottom := lines[len(lines)-1]; strings.Contains(bottom, "1 Inbox") {
+		t.Errorf("bottom line %q should be the search input", bottom)
+	}
+}
+
+func TestNoteLoaded_CountsItems(t *testing.T) {
+	app := newMacroTestApp()
+
+	model, _ := app.Update(tui.ProjectsLoadedMsg{})
+	app = model.(Model)
+	if got := app.renderStatusBar(); !strings.Contains(got, "0 projects") {
+		t.Errorf("status bar %q should count projects", got)
+	}
+	if app.statusBar.Refreshed().IsZero() {
+		t.Error("loading data should record the refresh time")
+	}
+}
+
+func TestFitHeight(t *testing.T) {
+	tests := []struct {
+		view   string
+		height int
+		want   string
+	}{
+		{view: "a\nb", height: 3, want: "a\nb\n"},
+		{view: "a\nb\nc", height: 2, want: "a\nb"},
+		{view: "a", height: 0, want: ""},
+	}
+	for _, tt := range tests {
+		if got := fitHeight(tt.view, tt.height); got != tt.want {
+			t.Errorf("fitHeight(%q, %d) = %q, want %q", tt.view, tt.height, got, tt.want)
+		}
+	}
+}
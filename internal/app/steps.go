@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// MoveContext stores context for confirming a move that also tags the
+// tasks, which takes several scripts per task
+type MoveContext struct {
+	ProjectID   string // Empty to only tag
+	ProjectName string
+	Tags        []string
+	Tasks       []domain.Task
+}
+
+// stepProgressMsg reports the step of a multi-step operation now running
+type stepProgressMsg struct {
+	Progress service.StepProgress
+	next     <-chan tea.Msg // Delivers the following step or the outcome
+}
+
+// stepsDoneMsg reports that a multi-step operation finished or stopped
+type stepsDoneMsg struct {
+	Success string // Toast shown when every step succeeded
+	Err     error
+}
+
+// runSteps creates a command running steps in the background, reporting each
+// step as a stepProgressMsg and the outcome as a stepsDoneMsg
+func runSteps(success string, steps []service.Step) tea.Cmd {
+	ch := make(chan tea.Msg, len(steps)+1)
+	return func() tea.Msg {
+		go func() {
+			err := service.RunSteps(context.Background(), steps, func(p service.StepProgress) {
+				ch <- stepProgressMsg{Progress: p, next: ch}
+			})
+			ch <- stepsDoneMsg{Success: success, Err: err}
+		}()
+		return <-ch
+	}
+}
+
+// waitForStep creates a command receiving the next message of a multi-step operation
+func waitForStep(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// confirmMoveWithTags confirms moving tasks to project, when not nil, and
+// adding tags to them
+func (m Model) confirmMoveWithTags(project *domain.Project, tags []string, tasks []domain.Task) Model {
+	ctx := MoveContext{Tags: tags, Tasks: tasks}
+	verb := "Tag with #" + strings.Join(tags, " #") + ":"
+	title := "Tag Tasks"
+	if project != nil {
+		ctx.ProjectID, ctx.ProjectName = project.ID, project.Name
+		verb = fmt.Sprintf("Move to %q and tag with #%s:", project.Name, strings.Join(tags, " #"))
+		title = "Move Tasks"
+	}
+	m.confirmModal = m.confirmModal.ShowWithContext(title, batchSummary(verb, tasks), ctx)
+	return m
+}
+
+// moveWithTags creates a command moving the tasks of ctx and tagging them,
+// step by step
+func (m Model) moveWithTags(ctx MoveContext) tea.Cmd {
+	var steps []service.Step
+	for _, task := range ctx.Tasks {
+		steps = append(steps, service.MoveTaskSteps(m.service, task, ctx.ProjectID, ctx.Tags)...)
+	}
+
+	noun := "tasks"
+	if len(ctx.Tasks) == 1 {
+		noun = "task"
+	}
+	success := fmt.Sprintf("Tagged %d %s", len(ctx.Tasks), noun)
+	if ctx.ProjectID != "" {
+		success = fmt.Sprintf("Moved %d %s to %s", len(ctx.Tasks), noun, ctx.ProjectName)
+	}
+	return runSteps(success, steps)
+}
+
+// handleStepProgress shows the running step and waits for the next
+func (m Model) handleStepProgress(msg stepProgressMsg) (Model, tea.Cmd) {
+	m.stepProgress = msg.Progress.String()
+	return m, waitForStep(msg.next)
+}
+
+// handleStepsDone hides the step indicator, reports the outcome and reloads
+// the view, since even a failed operation may have changed tasks
+func (m Model) handleStepsDone(msg stepsDoneMsg) (Model, tea.Cmd) {
+	m.stepProgress = ""
+	if msg.Err != nil {
+		m.err = msg.Err
+		var checkCmd tea.Cmd
+		m, checkCmd = m.checkPermissionOnce()
+		m, toastCmd := m.pushToast(toast.Error, msg.Err.Error())
+		return m, tea.Batch(toastCmd, checkCmd, m.refreshCurrentView())
+	}
+	m = m.clearMarksInCurrentView()
+	return m.refreshWithToast(toast.Success, msg.Success)
+}
+
+// renderStepProgress renders the step indicator overlay
+func (m Model) renderStepProgress() string {
+	return m.styles.UI.Overlay.Render(lipgloss.NewStyle().Bold(true).Render(m.stepProgress))
+}
+
+// splitMoveArgs splits :move arguments into the project name and the tags
+// written as #tag
+func splitMoveArgs(args []string) (string, []string) {
+	var name, tags []string
+	for _, arg := range args {
+		if tag, ok := strings.CutPrefix(arg, "#"); ok && tag != "" {
+			tags = append(tags, tag)
+		} else {
+			name = append(name, arg)
+		}
+	}
+	return strings.Join(name, " "), tags
+}
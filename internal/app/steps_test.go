@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/confirm"
+)
+
+// failingModifyService fails the ModifyTask call numbered failAt, counting from 1
+type failingModifyService struct {
+	*service.MockOmniFocusService
+	calls  int
+	failAt int
+}
+
+func (s *failingModifyService) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
+	s.calls++
+	if s.calls == s.failAt {
+		return nil, errors.New("script timed out")
+	}
+	return s.MockOmniFocusService.ModifyTask(ctx, id, mod)
+}
+
+// runStepsToEnd feeds the messages of a multi-step operation through Update,
+// returning the model and the step indicators shown along the way
+func runStepsToEnd(t *testing.T, app Model, cmd tea.Cmd) (Model, []string) {
+	t.Helper()
+	var shown []string
+	for i := 0; i < 50; i++ {
+		msg := cmd()
+		newModel, next := app.Update(msg)
+		app = newModel.(Model)
+		if _, done := msg.(stepsDoneMsg); done {
+			return app, shown
+		}
+		shown = append(shown, app.stepProgress)
+		if !strings.Contains(app.View(), app.stepProgress) {
+			t.Errorf("View() does not show step %q", app.stepProgress)
+		}
+		cmd = next
+	}
+	t.Fatal("multi-step operation did not finish")
+	return app, nil
+}
+
+func TestMoveCommand_WithTagsConfirmsMoveContext(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "First"},
+			{ID: "task2", Name: "Second"},
+		},
+		Projects: []domain.Project{{ID: "proj1", Name: "Work"}},
+	}
+	app := newAppWithMarkedTasks(t, mockSvc)
+
+	app, _ = app.executeCommand(&command.Command{Name: "move", Args: []string{"work", "#urgent", "#home"}})
+
+	if !app.confirmModal.IsVisible() {
+		t.Fatal("confirm modal should be visible for move with tags")
+	}
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	confirmed := cmd().(confirm.ConfirmedMsg)
+	ctx, ok := confirmed.Context.(MoveContext)
+	if !ok {
+		t.Fatalf("expected MoveContext, got %T", confirmed.Context)
+	}
+	if ctx.ProjectID != "proj1" || !reflect.DeepEqual(ctx.Tags, []string{"urgent", "home"}) || len(ctx.Tasks) != 2 {
+		t.Errorf("MoveContext = %+v, want proj1, [urgent home] and 2 tasks", ctx)
+	}
+}
+
+func TestMoveWithTags_ShowsEachStep(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "First"},
+			{ID: "task2", Name: "Second"},
+		},
+	}
+	app := newAppWithMarkedTasks(t, mockSvc)
+	ctx := MoveContext{ProjectID: "proj1", ProjectName: "Work", Tags: []string{"urgent"}, Tasks: mockSvc.InboxTasks}
+
+	app, shown := runStepsToEnd(t, app, app.moveWithTags(ctx))
+
+	want := []string{
+		`1/4: moving "First"…`,
+		`2/4: tagging "First" #urgent…`,
+		`3/4: moving "Second"…`,
+		`4/4: tagging "Second" #urgent…`,
+	}
+	if !reflect.DeepEqual(shown, want) {
+		t.Errorf("steps shown = %v, want %v", shown, want)
+	}
+	if app.stepProgress != "" {
+		t.Errorf("stepProgress = %q, want it cleared when done", app.stepProgress)
+	}
+	if toasts := strings.Join(app.toasts.Messages(), "\n"); !strings.Contains(toasts, "Moved 2 tasks to Work") {
+		t.Errorf("toasts = %q, want the move reported", toasts)
+	}
+	if len(app.getMarkedTasks()) != 0 {
+		t.Error("marks should be cleared after the move")
+	}
+}
+
+func TestMoveWithTags_ReportsPartialFailure(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "task1", Name: "First"}},
+	}
+	svc := &failingModifyService{MockOmniFocusService: mockSvc, failAt: 2}
+	app := NewApp(svc)
+	newModel, _ := app.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	app = newModel.(Model)
+	ctx := MoveContext{ProjectID: "proj1", ProjectName: "Work", Tags: []string{"urgent"}, Tasks: mockSvc.InboxTasks}
+
+	app, _ = runStepsToEnd(t, app, app.moveWithTags(ctx))
+
+	var partial *service.PartialFailureError
+	if !errors.As(app.err, &partial) {
+		t.Fatalf("err = %v, want *service.PartialFailureError", app.err)
+	}
+	toasts := strings.Join(app.toasts.Messages(), "\n")
+	if !strings.Contains(toasts, `tagging "First" #urgent failed after 1 of 2 steps`) {
+		t.Errorf("toasts = %q, want the partial failure reported", toasts)
+	}
+	if app.stepProgress != "" {
+		t.Errorf("stepProgress = %q, want it cleared after a failure", app.stepProgress)
+	}
+}
+
+func TestSplitMoveArgs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantName string
+		wantTags []string
+	}{
+		{[]string{"Home", "Repairs"}, "Home Repairs", nil},
+		{[]string{"Work", "#urgent", "#calls"}, "Work", []string{"urgent", "calls"}},
+		{[]string{"#urgent"}, "", []string{"urgent"}},
+		{[]string{"Work", "#"}, "Work #", nil},
+	}
+
+	for _, tt := range tests {
+		name, tags := splitMoveArgs(tt.args)
+		if name != tt.wantName || !reflect.DeepEqual(tags, tt.wantTags) {
+			t.Errorf("splitMoveArgs(%v) = %q, %v, want %q, %v", tt.args, name, tags, tt.wantName, tt.wantTags)
+		}
+	}
+}
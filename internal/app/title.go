@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// WindowTitleReset is the OSC sequence clearing the terminal title, written
+// after the TUI exits so the terminal shows its own title again
+const WindowTitleReset = "\x1b]2;\x07"
+
+// SetWindowTitle sets whether the TUI keeps the terminal window or tab title
+// showing the current view, e.g. "lazyfocus — Forecast (3 due)"
+func (m Model) SetWindowTitle(enabled bool) Model {
+	m.titleEnabled = enabled
+	return m
+}
+
+// windowTitle describes the current view, with a count where the view has
+// one and a note when a filter narrows it
+func (m Model) windowTitle() string {
+	var count string
+	switch m.currentView {
+	case tui.ViewInbox:
+		count = countLabel(m.inboxView.TaskCount(), "task")
+	case tui.ViewForecast:
+		count = fmt.Sprintf("%d due", m.forecastView.DueCount())
+	case tui.ViewReview:
+		count = countLabel(m.reviewView.TaskCount(), "task")
+	}
+	if m.filterState.IsActive() {
+		if count != "" {
+			count += ", "
+		}
+		count += "filtered"
+	}
+
+	title := "lazyfocus — " + viewName(m.currentView)
+	if count != "" {
+		title += " (" + count + ")"
+	}
+	return title
+}
+
+// syncWindowTitle adds setting the terminal title to cmd when the title
+// changed since it was last set
+func (m Model) syncWindowTitle(cmd tea.Cmd) (Model, tea.Cmd) {
+	if !m.titleEnabled {
+		return m, cmd
+	}
+	title := m.windowTitle()
+	if title == m.title {
+		return m, cmd
+	}
+	m.title = title
+	return m, tea.Batch(cmd, tea.SetWindowTitle(title))
+}
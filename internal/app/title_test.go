@@ -0,0 +1,61 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func TestWindowTitle_FollowsViewAndFilter(t *testing.T) {
+	app := newMacroTestApp().SetWindowTitle(true)
+
+	model, cmd := app.Update(tea.FocusMsg{})
+	app = model.(Model)
+	if app.title != "lazyfocus — Inbox (4 tasks)" {
+		t.Errorf("title = %q, want %q", app.title, "lazyfocus — Inbox (4 tasks)")
+	}
+	if cmd == nil {
+		t.Error("Update() should set the terminal title when it changes")
+	}
+
+	app = pressKeys(t, app, "4")
+	if app.title != "lazyfocus — Forecast (0 due)" {
+		t.Errorf("title = %q, want %q", app.title, "lazyfocus — Forecast (0 due)")
+	}
+
+	app.filterState = app.filterState.WithFlaggedOnly(true)
+	model, _ = app.Update(tea.FocusMsg{})
+	app = model.(Model)
+	if app.title != "lazyfocus — Forecast (0 due, filtered)" {
+		t.Errorf("title = %q, want %q", app.title, "lazyfocus — Forecast (0 due, filtered)")
+	}
+}
+
+func TestWindowTitle_UnchangedTitleNotSetAgain(t *testing.T) {
+	app := newMacroTestApp().SetWindowTitle(true)
+	model, _ := app.Update(tea.FocusMsg{})
+	app = model.(Model)
+
+	if _, cmd := app.syncWindowTitle(nil); cmd != nil {
+		t.Error("syncWindowTitle() should not set an unchanged title again")
+	}
+}
+
+func TestWindowTitle_Disabled(t *testing.T) {
+	app := newMacroTestApp()
+
+	model, _ := app.Update(tea.FocusMsg{})
+	if got := model.(Model).title; got != "" {
+		t.Errorf("title = %q, want none when disabled", got)
+	}
+}
+
+func TestWindowTitle_ViewsWithoutCount(t *testing.T) {
+	app := newMacroTestApp()
+	app.currentView = tui.ViewProjects
+
+	if got := app.windowTitle(); got != "lazyfocus — Projects" {
+		t.Errorf("windowTitle() = %q, want %q", got, "lazyfocus — Projects")
+	}
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/toast"
+)
+
+// undoLimit caps how many operations are remembered for undo
+const undoLimit = 20
+
+// undoAction identifies how a single task operation is reversed
+type undoAction int
+
+const (
+	undoUncomplete undoAction = iota // Reopen a completed task
+	undoRecreate                     // Recreate a deleted task from its snapshot
+	undoModify                       // Apply the inverse modification
+)
+
+// undoStep reverses one operation on one task
+type undoStep struct {
+	action       undoAction
+	taskID       string
+	snapshot     domain.Task
+	modification domain.TaskModification
+}
+
+// undoEntry reverses one user action, which may span several tasks
+type undoEntry struct {
+	description string
+	steps       []undoStep
+}
+
+// undoCompletedMsg reports the outcome of reverting an undo entry
+type undoCompletedMsg struct {
+	description string
+	recreated   map[string]string // Old task ID -> ID of the recreated task
+	err         error
+}
+
+// pushUndo records an undo entry, dropping the oldest once the limit is reached
+func (m Model) pushUndo(entry undoEntry) Model {
+	if len(entry.steps) == 0 {
+		return m
+	}
+	stack := append([]undoEntry{}, m.undoStack...)
+	stack = append(stack, entry)
+	if len(stack) > undoLimit {
+		stack = stack[len(stack)-undoLimit:]
+	}
+	m.undoStack = stack
+	return m
+}
+
+// recordCompleted records how to reopen a completed task
+func (m Model) recordCompleted(msg tui.TaskCompletedMsg) Model {
+	return m.pushUndo(undoEntry{
+		description: fmt.Sprintf("complete \"%s\"", msg.TaskName),
+		steps:       []undoStep{{action: undoUncomplete, taskID: msg.TaskID}},
+	})
+}
+
+// recordDeleted records how to recreate a deleted task, if a snapshot is available
+func (m Model) recordDeleted(msg tui.TaskDeletedMsg) Model {
+	if msg.Snapshot == nil {
+		return m
+	}
+	return m.pushUndo(undoEntry{
+		description: fmt.Sprintf("delete \"%s\"", msg.Snapshot.Name),
+		steps:       []undoStep{{action: undoRecreate, taskID: msg.TaskID, snapshot: *msg.Snapshot}},
+	})
+}
+
+// recordModified records how to revert a modification, if the previous state is known
+func (m Model) recordModified(msg tui.TaskModifiedMsg) Model {
+	if msg.Previous == nil {
+		return m
+	}
+	inverse := msg.Modification.Inverse(*msg.Previous)
+	if inverse.IsEmpty() {
+		return m
+	}
+	return m.pushUndo(undoEntry{
+		description: fmt.Sprintf("edit \"%s\"", msg.Previous.Name),
+		steps:       []undoStep{{action: undoModify, taskID: msg.Task.ID, modification: inverse}},
+	})
+}
+
+// recordBatch records how to reverse a bulk operation for the tasks it succeeded on
+func (m Model) recordBatch(msg tui.BatchCompletedMsg) Model {
+	tasks := make(map[string]domain.Task, len(msg.Tasks))
+	for _, task := range msg.Tasks {
+		tasks[task.ID] = task
+	}
+
+	var steps []undoStep
+	for _, res := range msg.Result.Results {
+		task, ok := tasks[res.ID]
+		if !res.Success || !ok {
+			continue
+		}
+		switch msg.Operation.Action {
+		case domain.BatchComplete:
+			steps = append(steps, undoStep{action: undoUncomplete, taskID: task.ID})
+		case domain.BatchDelete:
+			steps = append(steps, undoStep{action: undoRecreate, taskID: task.ID, snapshot: task})
+		case domain.BatchModify:
+			inverse := msg.Operation.Modification.Inverse(task)
+			if !inverse.IsEmpty() {
+				steps = append(steps, undoStep{action: undoModify, taskID: task.ID, modification: inverse})
+			}
+		}
+	}
+
+	noun := "tasks"
+	if len(steps) == 1 {
+		noun = "task"
+	}
+	return m.pushUndo(undoEntry{
+		description: fmt.Sprintf("%s %d %s", msg.Operation.Action, len(steps), noun),
+		steps:       steps,
+	})
+}
+
+// undo pops the most recent entry and reverts it
+func (m Model) undo() (Model, tea.Cmd) {
+	if len(m.undoStack) == 0 {
+		return m.pushToast(toast.Info, "Nothing to undo")
+	}
+
+	entry := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+
+	svc := m.service
+	return m, func() tea.Msg {
+		msg := undoCompletedMsg{description: entry.description, recreated: map[string]string{}}
+		for _, step := range entry.steps {
+			var err error
+			switch step.action {
+			case undoUncomplete:
+				_, err = svc.UncompleteTask(context.Background(), step.taskID)
+			case undoRecreate:
+				var task *domain.Task
+				task, err = svc.CreateTask(context.Background(), domain.TaskInputFromTask(step.snapshot))
+				if err == nil {
+					msg.recreated[step.taskID] = task.ID
+				}
+			case undoModify:
+				_, err = svc.ModifyTask(context.Background(), step.taskID, step.modification)
+			}
+			if err != nil && msg.err == nil {
+				msg.err = err
+			}
+		}
+		return msg
+	}
+}
+
+// handleUndoCompleted remaps recreated task IDs, reports the outcome and refreshes the view
+func (m Model) handleUndoCompleted(msg undoCompletedMsg) (Model, tea.Cmd) {
+	if len(msg.recreated) > 0 {
+		stack := make([]undoEntry, len(m.undoStack))
+		for i, entry := range m.undoStack {
+			steps := make([]undoStep, len(entry.steps))
+			for j, step := range entry.steps {
+				if newID, ok := msg.recreated[step.taskID]; ok {
+					step.taskID = newID
+					step.snapshot.ID = newID
+				}
+				steps[j] = step
+			}
+			stack[i] = undoEntry{description: entry.description, steps: steps}
+		}
+		m.undoStack = stack
+	}
+
+	if msg.err != nil {
+		m.err = msg.err
+		return m.refreshWithToast(toast.Error, fmt.Sprintf("Undo %s failed: %v", msg.description, msg.err))
+	}
+	return m.refreshWithToast(toast.Info, fmt.Sprintf("Undid %s", msg.description))
+}
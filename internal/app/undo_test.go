@@ -0,0 +1,195 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+var undoKey = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}}
+
+// recordingService wraps MockOmniFocusService and records the write calls used by undo
+type recordingService struct {
+	*service.MockOmniFocusService
+	uncompleted []string
+	created     []domain.TaskInput
+	modified    map[string]domain.TaskModification
+}
+
+func newRecordingService() *recordingService {
+	return &recordingService{
+		MockOmniFocusService: &service.MockOmniFocusService{
+			CreatedTask:      &domain.Task{ID: "new1"},
+			ModifiedTask:     &domain.Task{ID: "task1"},
+			UncompleteResult: &domain.OperationResult{Success: true},
+		},
+		modified: map[string]domain.TaskModification{},
+	}
+}
+
+func (r *recordingService) UncompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	r.uncompleted = append(r.uncompleted, id)
+	return r.MockOmniFocusService.UncompleteTask(ctx, id)
+}
+
+func (r *recordingService) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	r.created = append(r.created, input)
+	return r.MockOmniFocusService.CreateTask(ctx, input)
+}
+
+func (r *recordingService) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
+	r.modified[id] = mod
+	return r.MockOmniFocusService.ModifyTask(ctx, id, mod)
+}
+
+// pressUndo sends the undo key and runs the resulting command, returning its message
+func pressUndo(t *testing.T, app Model) (Model, tea.Msg) {
+	t.Helper()
+
+	newModel, cmd := app.Update(undoKey)
+	app = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("expected command from undo")
+	}
+	return app, cmd()
+}
+
+func TestUndo_CompletedTaskIsReopened(t *testing.T) {
+	svc := newRecordingService()
+	app := NewApp(svc)
+
+	newModel, _ := app.Update(tui.TaskCompletedMsg{TaskID: "task1", TaskName: "Write report"})
+	app = newModel.(Model)
+
+	app, msg := pressUndo(t, app)
+	if len(svc.uncompleted) != 1 || svc.uncompleted[0] != "task1" {
+		t.Errorf("UncompleteTask() calls = %v, want [task1]", svc.uncompleted)
+	}
+
+	newModel, _ = app.Update(msg)
+	app = newModel.(Model)
+	if toasts := strings.Join(app.toasts.Messages(), "\n"); !strings.Contains(toasts, "Undid complete \"Write report\"") {
+		t.Errorf("toasts = %q, want undo confirmation", toasts)
+	}
+	if len(app.undoStack) != 0 {
+		t.Errorf("undo stack has %d entries, want 0", len(app.undoStack))
+	}
+}
+
+func TestUndo_DeletedTaskIsRecreatedFromSnapshot(t *testing.T) {
+	svc := newRecordingService()
+	app := NewApp(svc)
+	snapshot := domain.Task{ID: "task1", Name: "Call mom", ProjectID: "proj1", Tags: []string{"phone"}}
+
+	newModel, _ := app.Update(tui.TaskDeletedMsg{TaskID: "task1", TaskName: "Call mom", Snapshot: &snapshot})
+	app = newModel.(Model)
+
+	_, _ = pressUndo(t, app)
+	if len(svc.created) != 1 {
+		t.Fatalf("CreateTask() called %d times, want 1", len(svc.created))
+	}
+	input := svc.created[0]
+	if input.Name != "Call mom" || input.ProjectID != "proj1" || len(input.TagNames) != 1 {
+		t.Errorf("CreateTask() input = %+v, want snapshot fields", input)
+	}
+}
+
+func TestUndo_ModifiedTaskIsReverted(t *testing.T) {
+	svc := newRecordingService()
+	app := NewApp(svc)
+	previous := domain.Task{ID: "task1", Name: "Old name"}
+	newName := "New name"
+
+	newModel, _ := app.Update(tui.TaskModifiedMsg{
+		Task:         domain.Task{ID: "task1", Name: newName},
+		Previous:     &previous,
+		Modification: domain.TaskModification{Name: &newName},
+	})
+	app = newModel.(Model)
+
+	_, _ = pressUndo(t, app)
+	mod, ok := svc.modified["task1"]
+	if !ok {
+		t.Fatal("expected ModifyTask() to be called for task1")
+	}
+	if mod.Name == nil || *mod.Name != "Old name" {
+		t.Errorf("ModifyTask() name = %v, want %q", mod.Name, "Old name")
+	}
+}
+
+func TestUndo_BatchRevertsOnlySucceededTasks(t *testing.T) {
+	svc := newRecordingService()
+	app := NewApp(svc)
+
+	newModel, _ := app.Update(tui.BatchCompletedMsg{
+		Operation: domain.BatchOperation{Action: domain.BatchComplete},
+		Tasks:     []domain.Task{{ID: "task1"}, {ID: "task2"}},
+		Result: domain.BatchResult{Results: []domain.OperationResult{
+			{Success: true, ID: "task1"},
+			{Success: false, ID: "task2"},
+		}},
+	})
+	app = newModel.(Model)
+
+	_, _ = pressUndo(t, app)
+	if len(svc.uncompleted) != 1 || svc.uncompleted[0] != "task1" {
+		t.Errorf("UncompleteTask() calls = %v, want [task1]", svc.uncompleted)
+	}
+}
+
+func TestUndo_RecreatedIDIsRemappedInOlderEntries(t *testing.T) {
+	svc := newRecordingService()
+	app := NewApp(svc)
+	previous := domain.Task{ID: "task1", Flagged: false}
+	flagged := true
+
+	newModel, _ := app.Update(tui.TaskModifiedMsg{
+		Task:         domain.Task{ID: "task1", Flagged: true},
+		Previous:     &previous,
+		Modification: domain.TaskModification{Flagged: &flagged},
+	})
+	app = newModel.(Model)
+	newModel, _ = app.Update(tui.TaskDeletedMsg{TaskID: "task1", Snapshot: &domain.Task{ID: "task1", Flagged: true}})
+	app = newModel.(Model)
+
+	app, msg := pressUndo(t, app)
+	newModel, _ = app.Update(msg)
+	app = newModel.(Model)
+
+	_, _ = pressUndo(t, app)
+	if _, ok := svc.modified["new1"]; !ok {
+		t.Errorf("ModifyTask() calls = %v, want call for recreated task new1", svc.modified)
+	}
+}
+
+func TestUndo_EmptyStackShowsToast(t *testing.T) {
+	app := NewApp(newRecordingService())
+
+	newModel, cmd := app.Update(undoKey)
+	app = newModel.(Model)
+
+	if toasts := app.toasts.Messages(); len(toasts) != 1 || toasts[0] != "Nothing to undo" {
+		t.Errorf("toasts = %v, want [Nothing to undo]", toasts)
+	}
+	if cmd == nil {
+		t.Error("expected command to dismiss the toast")
+	}
+}
+
+func TestUndo_StackIsCapped(t *testing.T) {
+	app := NewApp(newRecordingService())
+
+	for i := 0; i < undoLimit+5; i++ {
+		newModel, _ := app.Update(tui.TaskCompletedMsg{TaskID: "task1"})
+		app = newModel.(Model)
+	}
+
+	if len(app.undoStack) != undoLimit {
+		t.Errorf("undo stack has %d entries, want %d", len(app.undoStack), undoLimit)
+	}
+}
@@ -0,0 +1,43 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AccessibilityPermissionFix tells the user how to let LazyFocus use the
+// OmniFocus menus, which backups are started from
+const AccessibilityPermissionFix = `macOS has not allowed your terminal to use the OmniFocus menus.
+  1. Open System Settings → Privacy & Security → Accessibility
+  2. Turn on your terminal app (Terminal, iTerm, …)
+  3. Restart the terminal`
+
+// IsAccessibilityDenied reports whether err is macOS denying LazyFocus the
+// Accessibility permission System Events needs to click menu items
+func IsAccessibilityDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "assistive access") || strings.Contains(msg, "(-1719)")
+}
+
+// RequestBackup asks OmniFocus to back up its database now. OmniFocus writes
+// the backup in the background, so RequestBackup returns before it is done.
+func RequestBackup(ctx context.Context, e Executor) error {
+	script, err := GetScript("backup_database")
+	if err != nil {
+		return fmt.Errorf("failed to load backup script: %w", err)
+	}
+
+	output, err := e.Execute(ctx, script)
+	if err != nil {
+		return fmt.Errorf("failed to execute backup script: %w", err)
+	}
+
+	if _, err := ParseOperationResult(output); err != nil {
+		return fmt.Errorf("failed to start backup: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRequestBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		execErr error
+		wantErr bool
+	}{
+		{name: "started", output: `{"success":true,"message":"Back Up Database Now"}`},
+		{name: "not running", output: `{"error":"OmniFocus is not running"}`, wantErr: true},
+		{name: "script error", execErr: errors.New("osascript failed"), wantErr: true},
+		{name: "malformed output", output: "oops", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran string
+			e := &mockExecutor{executeFunc: func(script string) (string, error) {
+				ran = script
+				return tt.output, tt.execErr
+			}}
+
+			err := RequestBackup(context.Background(), e)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RequestBackup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(ran, "Back Up Database") {
+				t.Error("RequestBackup() should run the backup script")
+			}
+		})
+	}
+}
+
+func TestIsAccessibilityDenied(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "assistive access", err: errors.New("failed to start backup: osascript is not allowed assistive access. (-1719)"), want: true},
+		{name: "other", err: errors.New("OmniFocus is not running"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAccessibilityDenied(tt.err); got != tt.want {
+				t.Errorf("IsAccessibilityDenied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
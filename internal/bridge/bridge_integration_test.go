@@ -3,6 +3,7 @@
 package bridge_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -20,7 +21,7 @@ func TestGetInboxTasks_Integration(t *testing.T) {
 	}
 
 	// Execute the script
-	output, err := executor.Execute(script)
+	output, err := executor.Execute(context.Background(), script)
 
 	// We expect either success or OmniFocus not running error
 	if err != nil {
@@ -72,7 +73,7 @@ func TestGetProjects_Integration(t *testing.T) {
 	}
 
 	// Execute the script
-	output, err := executor.Execute(script)
+	output, err := executor.Execute(context.Background(), script)
 
 	// We expect either success or OmniFocus not running error
 	if err != nil {
@@ -136,7 +137,7 @@ func TestEndToEnd_GetInboxTasksFlow(t *testing.T) {
 	}
 
 	// Step 3: Execute script
-	output, err := executor.Execute(script)
+	output, err := executor.Execute(context.Background(), script)
 	if err != nil {
 		if errors.Is(err, bridge.ErrOmniFocusNotRunning) {
 			t.Skip("OmniFocus is not running - skipping integration test")
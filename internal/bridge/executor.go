@@ -10,60 +10,91 @@ import (
 	"fmt"
 	"os/exec"
 	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/log"
 )
 
 // Error types for executor operations
 var (
 	ErrOSAScriptNotFound   = errors.New("osascript not found")
 	ErrExecutionTimeout    = errors.New("script execution timed out")
+	ErrExecutionCanceled   = errors.New("script execution canceled")
 	ErrOmniFocusNotRunning = errors.New("OmniFocus is not running")
+	ErrPayloadTooLarge     = errors.New("script output exceeds maximum payload size")
 )
 
-// Executor defines the interface for executing Omni Automation scripts
+// DefaultMaxPayloadBytes is the default upper bound on script output size
+const DefaultMaxPayloadBytes int64 = 32 << 20
+
+// Executor defines the interface for executing Omni Automation scripts.
+// A script stops when ctx is canceled.
 type Executor interface {
-	Execute(script string) (string, error)
-	ExecuteWithTimeout(script string, timeout time.Duration) (string, error)
+	Execute(ctx context.Context, script string) (string, error)
+	ExecuteWithTimeout(ctx context.Context, script string, timeout time.Duration) (string, error)
 }
 
 // OSAScriptExecutor executes JavaScript via osascript command
 type OSAScriptExecutor struct {
-	timeout time.Duration
+	timeout    time.Duration
+	maxPayload int64
 }
 
 // NewOSAScriptExecutor creates a new executor with default 30s timeout
 func NewOSAScriptExecutor() *OSAScriptExecutor {
 	return &OSAScriptExecutor{
-		timeout: 30 * time.Second,
+		timeout:    30 * time.Second,
+		maxPayload: DefaultMaxPayloadBytes,
 	}
 }
 
 // NewOSAScriptExecutorWithTimeout creates a new executor with custom timeout
 func NewOSAScriptExecutorWithTimeout(timeout time.Duration) *OSAScriptExecutor {
 	return &OSAScriptExecutor{
-		timeout: timeout,
+		timeout:    timeout,
+		maxPayload: DefaultMaxPayloadBytes,
 	}
 }
 
+// SetMaxPayloadBytes sets the maximum accepted script output size.
+// A value of zero or less disables the limit.
+func (e *OSAScriptExecutor) SetMaxPayloadBytes(n int64) {
+	e.maxPayload = n
+}
+
 // Execute runs a JavaScript script via osascript using the default timeout
-func (e *OSAScriptExecutor) Execute(script string) (string, error) {
-	return e.ExecuteWithTimeout(script, e.timeout)
+func (e *OSAScriptExecutor) Execute(ctx context.Context, script string) (string, error) {
+	return e.ExecuteWithTimeout(ctx, script, e.timeout)
 }
 
-// ExecuteWithTimeout runs a JavaScript script via osascript with a custom timeout
-func (e *OSAScriptExecutor) ExecuteWithTimeout(script string, timeout time.Duration) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+// ExecuteWithTimeout runs a JavaScript script via osascript with a custom
+// timeout. The osascript process is killed when the timeout passes or ctx is
+// canceled, whichever comes first.
+func (e *OSAScriptExecutor) ExecuteWithTimeout(parent context.Context, script string, timeout time.Duration) (string, error) {
+	if err := parent.Err(); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrExecutionCanceled, err)
+	}
+
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "osascript", "-l", "JavaScript", "-e", script)
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
+	stdout := newLimitedBuffer(e.maxPayload)
+	var stderr bytes.Buffer
+	cmd.Stdout = stdout
 	cmd.Stderr = &stderr
 
 	err := cmd.Run()
 
+	// Check if the caller gave up, e.g. on Ctrl+C or its own deadline
+	if err := parent.Err(); err != nil {
+		log.Logger().Debug("osascript canceled", "reason", err)
+		return "", fmt.Errorf("%w: %w", ErrExecutionCanceled, err)
+	}
+
 	// Check if context was cancelled (timeout occurred)
 	if ctx.Err() == context.DeadlineExceeded {
+		log.Logger().Debug("osascript timed out", "timeout", timeout)
 		return "", ErrExecutionTimeout
 	}
 
@@ -71,6 +102,7 @@ func (e *OSAScriptExecutor) ExecuteWithTimeout(script string, timeout time.Durat
 	if err != nil {
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
+			log.Logger().Debug("osascript failed", "exit", exitErr.ExitCode(), "stderr", log.Truncate(stderr.String()))
 			// Non-zero exit code, include stderr in error
 			return "", fmt.Errorf("osascript execution failed: %w: %s", err, stderr.String())
 		}
@@ -84,5 +116,9 @@ func (e *OSAScriptExecutor) ExecuteWithTimeout(script string, timeout time.Durat
 		return "", fmt.Errorf("failed to execute osascript: %w", err)
 	}
 
+	if stdout.Overflowed() {
+		return "", fmt.Errorf("%w (limit %d bytes)", ErrPayloadTooLarge, e.maxPayload)
+	}
+
 	return stdout.String(), nil
 }
@@ -3,6 +3,7 @@
 package bridge
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -16,7 +17,7 @@ func TestExecute_SuccessfulExecution(t *testing.T) {
 	// Simple JavaScript that returns a value
 	script := `(() => { return "hello"; })()`
 
-	result, err := executor.Execute(script)
+	result, err := executor.Execute(context.Background(), script)
 
 	if err != nil {
 		t.Errorf("expected no error, got: %v", err)
@@ -36,7 +37,7 @@ func TestExecute_NonZeroExitCode(t *testing.T) {
 	// Invalid JavaScript that will cause osascript to fail
 	script := `(() => { throw new Error("test error"); })()`
 
-	_, err := executor.Execute(script)
+	_, err := executor.Execute(context.Background(), script)
 
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -55,7 +56,7 @@ func TestExecuteWithTimeout_Success(t *testing.T) {
 	script := `(() => { return "timeout test"; })()`
 	timeout := 5 * time.Second
 
-	result, err := executor.ExecuteWithTimeout(script, timeout)
+	result, err := executor.ExecuteWithTimeout(context.Background(), script, timeout)
 
 	if err != nil {
 		t.Errorf("expected no error, got: %v", err)
@@ -80,7 +81,7 @@ func TestExecuteWithTimeout_TimeoutOccurs(t *testing.T) {
 
 	timeout := 100 * time.Millisecond
 
-	_, err := executor.ExecuteWithTimeout(script, timeout)
+	_, err := executor.ExecuteWithTimeout(context.Background(), script, timeout)
 
 	if err == nil {
 		t.Fatal("expected timeout error, got nil")
@@ -103,7 +104,7 @@ func TestExecute_UsesDefaultTimeout(t *testing.T) {
 		return "slow";
 	})()`
 
-	_, err := executor.Execute(script)
+	_, err := executor.Execute(context.Background(), script)
 
 	if err == nil {
 		t.Fatal("expected timeout error, got nil")
@@ -118,7 +119,7 @@ func TestExecute_UsesDefaultTimeout(t *testing.T) {
 func TestExecute_EmptyScript(t *testing.T) {
 	executor := NewOSAScriptExecutor()
 
-	result, err := executor.Execute("")
+	result, err := executor.Execute(context.Background(), "")
 
 	// osascript with empty script returns empty output
 	if err != nil {
@@ -144,7 +145,7 @@ func TestExecute_ContextCancellation(t *testing.T) {
 	// Very short timeout to trigger cancellation
 	timeout := 10 * time.Millisecond
 
-	_, err := executor.ExecuteWithTimeout(script, timeout)
+	_, err := executor.ExecuteWithTimeout(context.Background(), script, timeout)
 
 	if err == nil {
 		t.Fatal("expected error due to context cancellation")
@@ -155,3 +156,29 @@ func TestExecute_ContextCancellation(t *testing.T) {
 		t.Errorf("expected ErrExecutionTimeout, got: %v", err)
 	}
 }
+
+// TestExecute_ParentCancellation tests that canceling the caller's context
+// kills a running script
+func TestExecute_ParentCancellation(t *testing.T) {
+	executor := NewOSAScriptExecutor()
+
+	// Long-running script
+	script := `(() => {
+		const start = Date.now();
+		while (Date.now() - start < 5000) {}
+		return "completed";
+	})()`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := executor.ExecuteWithTimeout(ctx, script, 10*time.Second)
+
+	if !errors.Is(err, ErrExecutionCanceled) {
+		t.Errorf("expected ErrExecutionCanceled, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("script ran for %v after the context was canceled", elapsed)
+	}
+}
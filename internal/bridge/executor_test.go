@@ -1,6 +1,8 @@
 package bridge
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -28,3 +30,30 @@ func TestNewOSAScriptExecutorWithTimeout_CustomTimeout(t *testing.T) {
 func TestExecutor_Interface(t *testing.T) {
 	var _ Executor = (*OSAScriptExecutor)(nil)
 }
+
+// TestNewOSAScriptExecutor_DefaultMaxPayload tests default payload limit is set
+func TestNewOSAScriptExecutor_DefaultMaxPayload(t *testing.T) {
+	executor := NewOSAScriptExecutor()
+
+	if executor.maxPayload != DefaultMaxPayloadBytes {
+		t.Errorf("expected default max payload of %d, got: %d", DefaultMaxPayloadBytes, executor.maxPayload)
+	}
+
+	executor.SetMaxPayloadBytes(1024)
+	if executor.maxPayload != 1024 {
+		t.Errorf("expected max payload of 1024, got: %d", executor.maxPayload)
+	}
+}
+
+// TestExecuteWithTimeout_CanceledContext tests that nothing runs once the
+// caller has given up
+func TestExecuteWithTimeout_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewOSAScriptExecutor().ExecuteWithTimeout(ctx, "1 + 1", time.Second)
+
+	if !errors.Is(err, ErrExecutionCanceled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected ErrExecutionCanceled, got: %v", err)
+	}
+}
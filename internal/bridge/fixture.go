@@ -0,0 +1,84 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// placeholderPattern matches the {{.Param}} placeholders of a script template
+var placeholderPattern = regexp.MustCompile(`\{\{\.[A-Za-z]+\}\}`)
+
+// FixtureExecutor answers scripts with output recorded from a real OmniFocus
+// database instead of running them, so lazyfocus can be tried and tested
+// without OmniFocus. The output of each script is read from <name>.json in
+// its directory whatever the parameters; a script without a fixture, such as
+// any write, fails.
+type FixtureExecutor struct {
+	dir string
+
+	once     sync.Once
+	patterns map[string]*regexp.Regexp // Embedded scripts by name, placeholders matching any value
+}
+
+// NewFixtureExecutor creates an executor answering from the fixtures in dir
+func NewFixtureExecutor(dir string) *FixtureExecutor {
+	return &FixtureExecutor{dir: dir}
+}
+
+// Execute returns the fixture of the script
+func (e *FixtureExecutor) Execute(ctx context.Context, script string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrExecutionCanceled, err)
+	}
+
+	name, ok := e.ScriptName(script)
+	if !ok {
+		return "", fmt.Errorf("fixture mode: script is not one of lazyfocus's scripts")
+	}
+
+	data, err := os.ReadFile(filepath.Join(e.dir, name+".json"))
+	if err != nil {
+		return "", fmt.Errorf("fixture mode: no fixture for %s in %s", name, e.dir)
+	}
+	return string(data), nil
+}
+
+// ExecuteWithTimeout returns the fixture of the script; fixtures never time out
+func (e *FixtureExecutor) ExecuteWithTimeout(ctx context.Context, script string, timeout time.Duration) (string, error) {
+	return e.Execute(ctx, script)
+}
+
+// ScriptName returns the name of the embedded script the rendered script was
+// made from
+func (e *FixtureExecutor) ScriptName(script string) (string, bool) {
+	e.once.Do(e.compilePatterns)
+	for name, pattern := range e.patterns {
+		if pattern.MatchString(script) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// compilePatterns turns each embedded script into a pattern matching it with
+// any parameter values
+func (e *FixtureExecutor) compilePatterns() {
+	e.patterns = make(map[string]*regexp.Regexp)
+	for _, name := range ListScripts() {
+		script, err := GetScript(name)
+		if err != nil {
+			continue
+		}
+		parts := placeholderPattern.Split(script, -1)
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		e.patterns[name] = regexp.MustCompile(`(?s)^` + strings.Join(parts, `.*?`) + `$`)
+	}
+}
@@ -0,0 +1,51 @@
+package bridge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFixtureExecutor_AnswersWithRecordedOutput(t *testing.T) {
+	dir := t.TempDir()
+	recorded := `{"tasks": [{"id": "a1", "name": "Task 1"}]}`
+	if err := os.WriteFile(filepath.Join(dir, "get_tasks_by_project.json"), []byte(recorded), 0644); err != nil {
+		t.Fatal(err)
+	}
+	executor := NewFixtureExecutor(dir)
+
+	script, err := GetScriptWithParams("get_tasks_by_project", map[string]string{"ProjectID": "p1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := executor.Execute(context.Background(), script)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output != recorded {
+		t.Errorf("Execute() = %q, want the fixture", output)
+	}
+
+	// A script without a fixture fails rather than reaching OmniFocus
+	script, err = GetScriptWithParams("complete_task", map[string]string{"TaskID": "a1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executor.Execute(context.Background(), script); err == nil {
+		t.Error("Execute() without a fixture should fail")
+	}
+}
+
+func TestFixtureExecutor_ScriptName(t *testing.T) {
+	executor := NewFixtureExecutor(t.TempDir())
+	for _, name := range ListScripts() {
+		script, err := GetScript(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := executor.ScriptName(script); !ok || got != name {
+			t.Errorf("ScriptName(%s) = %q, %v", name, got, ok)
+		}
+	}
+}
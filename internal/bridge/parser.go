@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 )
@@ -11,6 +12,7 @@ import (
 // TasksResponse represents the JSON response from get_inbox_tasks.js
 type TasksResponse struct {
 	Tasks []domain.Task `json:"tasks"`
+	Total int           `json:"total,omitempty"`
 	Error string        `json:"error,omitempty"`
 }
 
@@ -44,12 +46,30 @@ type TagsResponse struct {
 	Error string       `json:"error,omitempty"`
 }
 
+// FoldersResponse represents the JSON response from get_folders.js
+type FoldersResponse struct {
+	Folders []domain.Folder `json:"folders"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// AttachmentsResponse represents the JSON response from get_attachments.js
+type AttachmentsResponse struct {
+	Attachments []domain.Attachment `json:"attachments"`
+	Error       string              `json:"error,omitempty"`
+}
+
 // TagCountsResponse represents tag counts response
 type TagCountsResponse struct {
 	Counts map[string]int `json:"counts"`
 	Error  string         `json:"error,omitempty"`
 }
 
+// PerspectiveRulesResponse represents the response from get_perspective_rules.js
+type PerspectiveRulesResponse struct {
+	Perspective *domain.PerspectiveRules `json:"perspective,omitempty"`
+	Error       string                   `json:"error,omitempty"`
+}
+
 // OperationResultResponse represents the response from write operations
 type OperationResultResponse struct {
 	Success bool   `json:"success"`
@@ -58,8 +78,33 @@ type OperationResultResponse struct {
 	Error   string `json:"error,omitempty"`
 }
 
+// ErrNotFound matches errors about a task, project, tag or other item that
+// does not exist
+var ErrNotFound = errors.New("not found")
+
+// NotFoundError reports an item that does not exist; it matches ErrNotFound
+type NotFoundError struct {
+	Message string
+}
+
+// NewNotFoundError returns an error for the item of the given type, such as
+// "task not found: abc123"
+func NewNotFoundError(itemType, id string) *NotFoundError {
+	return &NotFoundError{Message: fmt.Sprintf("%s not found: %s", itemType, id)}
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is ErrNotFound
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
 // checkResponseError checks if a response contains an error field
 // Returns ErrOmniFocusNotRunning if the error is "OmniFocus is not running"
+// Returns a NotFoundError for errors such as "Task not found: abc123"
 // Returns error for any other error message
 func checkResponseError(errorMsg string) error {
 	if errorMsg == "" {
@@ -70,9 +115,25 @@ func checkResponseError(errorMsg string) error {
 		return ErrOmniFocusNotRunning
 	}
 
+	if strings.Contains(errorMsg, " not found: ") {
+		return &NotFoundError{Message: errorMsg}
+	}
+
 	return errors.New(errorMsg)
 }
 
+// ResponseError returns the error a script reported in its JSON output, if
+// any, as the Parse functions would
+func ResponseError(output string) error {
+	var response struct {
+		Error string `json:"error,omitempty"`
+	}
+	if err := json.Unmarshal([]byte(output), &response); err != nil {
+		return fmt.Errorf("failed to parse script output: %w", err)
+	}
+	return checkResponseError(response.Error)
+}
+
 // ParseTasks parses JSON output into a slice of Tasks
 // Returns ErrOmniFocusNotRunning if the JSON contains an error about OmniFocus not running
 // Returns parsing error for malformed JSON
@@ -97,6 +158,27 @@ func ParseTasks(jsonStr string) ([]domain.Task, error) {
 	return response.Tasks, nil
 }
 
+// ParseTasksPage parses a paginated tasks response, returning the page of tasks
+// and the total number of matching tasks reported by the script
+func ParseTasksPage(jsonStr string) ([]domain.Task, int, error) {
+	var response TasksResponse
+
+	err := json.Unmarshal([]byte(jsonStr), &response)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse tasks JSON: %w", err)
+	}
+
+	if err := checkResponseError(response.Error); err != nil {
+		return nil, 0, err
+	}
+
+	if response.Tasks == nil {
+		return []domain.Task{}, response.Total, nil
+	}
+
+	return response.Tasks, response.Total, nil
+}
+
 // ParseProjects parses JSON output into a slice of Projects
 func ParseProjects(jsonStr string) ([]domain.Project, error) {
 	var response ProjectsResponse
@@ -116,6 +198,9 @@ func ParseProjects(jsonStr string) ([]domain.Project, error) {
 		return []domain.Project{}, nil
 	}
 
+	for i := range response.Projects {
+		resolveProjectType(&response.Projects[i])
+	}
 	return response.Projects, nil
 }
 
@@ -154,9 +239,25 @@ func ParseProject(jsonStr string) (*domain.Project, error) {
 		return nil, err
 	}
 
+	if response.Project != nil {
+		resolveProjectType(response.Project)
+	}
 	return response.Project, nil
 }
 
+// resolveProjectType fills in a project's type from its sequential and
+// single-actions settings, or the settings from its type, for output giving
+// only one of them
+func resolveProjectType(project *domain.Project) {
+	switch {
+	case project.Type == "":
+		project.Type = domain.ProjectType(project.Sequential, project.ContainsSingletonActions)
+	case !project.Sequential && !project.ContainsSingletonActions:
+		project.Sequential = project.Type == domain.ProjectSequential
+		project.ContainsSingletonActions = project.Type == domain.ProjectSingleActions
+	}
+}
+
 // ParseTag parses JSON output into a single Tag
 // Returns ErrOmniFocusNotRunning if the JSON contains an error about OmniFocus not running
 // Returns parsing error for malformed JSON
@@ -200,6 +301,55 @@ func ParseTags(jsonStr string) ([]domain.Tag, error) {
 	return response.Tags, nil
 }
 
+// ParseFolders parses JSON output into the folder tree
+// Returns ErrOmniFocusNotRunning if the JSON contains an error about OmniFocus not running
+// Returns parsing error for malformed JSON
+func ParseFolders(jsonStr string) ([]domain.Folder, error) {
+	var response FoldersResponse
+
+	err := json.Unmarshal([]byte(jsonStr), &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse folders JSON: %w", err)
+	}
+
+	// Check if response contains an error
+	if err := checkResponseError(response.Error); err != nil {
+		return nil, err
+	}
+
+	// Return empty slice if no folders (not nil)
+	if response.Folders == nil {
+		return []domain.Folder{}, nil
+	}
+
+	return response.Folders, nil
+}
+
+// ParseAttachments parses JSON output into a slice of Attachments, decoding
+// their base64 contents
+// Returns ErrOmniFocusNotRunning if the JSON contains an error about OmniFocus not running
+// Returns parsing error for malformed JSON
+func ParseAttachments(jsonStr string) ([]domain.Attachment, error) {
+	var response AttachmentsResponse
+
+	err := json.Unmarshal([]byte(jsonStr), &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse attachments JSON: %w", err)
+	}
+
+	// Check if response contains an error
+	if err := checkResponseError(response.Error); err != nil {
+		return nil, err
+	}
+
+	// Return empty slice if no attachments (not nil)
+	if response.Attachments == nil {
+		return []domain.Attachment{}, nil
+	}
+
+	return response.Attachments, nil
+}
+
 // ParseTagCounts parses JSON output into a map of tag names to counts
 // Returns ErrOmniFocusNotRunning if the JSON contains an error about OmniFocus not running
 // Returns parsing error for malformed JSON
@@ -224,6 +374,25 @@ func ParseTagCounts(jsonStr string) (map[string]int, error) {
 	return response.Counts, nil
 }
 
+// ParsePerspectiveRules parses JSON output into a perspective's filter rules
+// Returns ErrOmniFocusNotRunning if the JSON contains an error about OmniFocus not running
+// Returns parsing error for malformed JSON
+func ParsePerspectiveRules(jsonStr string) (*domain.PerspectiveRules, error) {
+	var response PerspectiveRulesResponse
+
+	err := json.Unmarshal([]byte(jsonStr), &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse perspective rules JSON: %w", err)
+	}
+
+	// Check if response contains an error
+	if err := checkResponseError(response.Error); err != nil {
+		return nil, err
+	}
+
+	return response.Perspective, nil
+}
+
 // ParseOperationResult parses JSON output into an OperationResult
 // Returns ErrOmniFocusNotRunning if the JSON contains an error about OmniFocus not running
 // Returns parsing error for malformed JSON or operation failure
@@ -1,7 +1,11 @@
 package bridge
 
 import (
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
 )
 
 func TestParseTasks_ValidJSON(t *testing.T) {
@@ -206,6 +210,147 @@ func TestParseTasks_CompletedTask(t *testing.T) {
 	}
 }
 
+func TestParseTasks_RepetitionRule(t *testing.T) {
+	jsonStr := `{
+		"tasks": [
+			{
+				"id": "repeat123",
+				"name": "Water plants",
+				"tags": [],
+				"flagged": false,
+				"completed": false,
+				"repetitionRule": {"recurrence": "FREQ=DAILY;INTERVAL=3", "method": "start-after-completion"},
+				"estimatedMinutes": 15
+			},
+			{
+				"id": "once123",
+				"name": "File taxes",
+				"tags": [],
+				"flagged": false,
+				"completed": false,
+				"repetitionRule": null,
+				"estimatedMinutes": null
+			}
+		]
+	}`
+
+	tasks, err := ParseTasks(jsonStr)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	rule := tasks[0].Repetition
+	if rule == nil {
+		t.Fatal("expected repetitionRule to be set")
+	}
+	if rule.Recurrence != "FREQ=DAILY;INTERVAL=3" {
+		t.Errorf("expected recurrence FREQ=DAILY;INTERVAL=3, got %q", rule.Recurrence)
+	}
+	if rule.Method != domain.RepeatStartAfterCompletion {
+		t.Errorf("expected method %q, got %q", domain.RepeatStartAfterCompletion, rule.Method)
+	}
+	if tasks[1].Repetition != nil {
+		t.Errorf("expected no repetitionRule, got %+v", tasks[1].Repetition)
+	}
+	if tasks[0].EstimatedMinutes != 15 || tasks[1].EstimatedMinutes != 0 {
+		t.Errorf("expected estimatedMinutes 15 and 0, got %d and %d", tasks[0].EstimatedMinutes, tasks[1].EstimatedMinutes)
+	}
+}
+
+func TestParseTasks_AvailabilityFields(t *testing.T) {
+	jsonStr := `{
+		"tasks": [
+			{
+				"id": "group1",
+				"name": "Plan trip",
+				"dueDate": null,
+				"deferDate": null,
+				"effectiveDueDate": "2025-03-01T17:00:00.000Z",
+				"effectiveDeferDate": "2025-02-20T08:00:00.000Z",
+				"sequential": true,
+				"dropped": false,
+				"flagged": false,
+				"completed": false
+			},
+			{
+				"id": "old1",
+				"name": "Old idea",
+				"effectiveDueDate": null,
+				"sequential": false,
+				"dropped": true,
+				"flagged": false,
+				"completed": false
+			}
+		]
+	}`
+
+	tasks, err := ParseTasks(jsonStr)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	group := tasks[0]
+	if group.DueDate != nil {
+		t.Errorf("expected no own due date, got %v", group.DueDate)
+	}
+	wantDue := time.Date(2025, 3, 1, 17, 0, 0, 0, time.UTC)
+	if group.EffectiveDueDate == nil || !group.EffectiveDueDate.Equal(wantDue) {
+		t.Errorf("expected effective due date %v, got %v", wantDue, group.EffectiveDueDate)
+	}
+	wantDefer := time.Date(2025, 2, 20, 8, 0, 0, 0, time.UTC)
+	if group.EffectiveDeferDate == nil || !group.EffectiveDeferDate.Equal(wantDefer) {
+		t.Errorf("expected effective defer date %v, got %v", wantDefer, group.EffectiveDeferDate)
+	}
+	if !group.Sequential || group.Dropped {
+		t.Errorf("expected a sequential, undropped group, got sequential %v, dropped %v", group.Sequential, group.Dropped)
+	}
+
+	if old := tasks[1]; !old.Dropped || old.Sequential || old.EffectiveDueDate != nil {
+		t.Errorf("expected a dropped task without dates, got %+v", old)
+	}
+}
+
+func TestParseProjects_ResolvesType(t *testing.T) {
+	tests := []struct {
+		name             string
+		project          string
+		wantType         string
+		wantSequential   bool
+		wantSingleAction bool
+	}{
+		{"type and settings", `{"type": "sequential", "sequential": true}`, domain.ProjectSequential, true, false},
+		{"settings only", `{"sequential": true, "containsSingletonActions": true}`, domain.ProjectSingleActions, true, true},
+		{"type only", `{"type": "single-actions"}`, domain.ProjectSingleActions, false, true},
+		{"neither", `{}`, domain.ProjectParallel, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects, err := ParseProjects(`{"projects": [` + tt.project + `]}`)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			project, err := ParseProject(`{"project": ` + tt.project + `}`)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			for _, got := range []domain.Project{projects[0], *project} {
+				if got.Type != tt.wantType || got.Sequential != tt.wantSequential || got.ContainsSingletonActions != tt.wantSingleAction {
+					t.Errorf("got type %q, sequential %v, single actions %v; want %q, %v, %v",
+						got.Type, got.Sequential, got.ContainsSingletonActions, tt.wantType, tt.wantSequential, tt.wantSingleAction)
+				}
+			}
+		})
+	}
+}
+
 func TestParseProjects_ValidJSON(t *testing.T) {
 	jsonStr := `{
 		"projects": [
@@ -213,6 +358,7 @@ func TestParseProjects_ValidJSON(t *testing.T) {
 				"id": "xyz789",
 				"name": "Home Renovation",
 				"status": "active",
+				"type": "sequential",
 				"note": "Kitchen project"
 			},
 			{
@@ -248,6 +394,9 @@ func TestParseProjects_ValidJSON(t *testing.T) {
 	if proj1.Note != "Kitchen project" {
 		t.Errorf("expected note 'Kitchen project', got '%s'", proj1.Note)
 	}
+	if !proj1.IsSequential() {
+		t.Errorf("expected type 'sequential', got '%s'", proj1.Type)
+	}
 
 	// Verify second project
 	proj2 := projects[1]
@@ -683,6 +832,41 @@ func TestParseTags_ValidJSON(t *testing.T) {
 	}
 }
 
+func TestParseAttachments_DecodesData(t *testing.T) {
+	attachments, err := ParseAttachments(`{"attachments": [
+		{"taskId": "t1", "taskName": "Quote", "name": "a.txt", "size": 5, "data": "aGVsbG8="},
+		{"taskId": "t1", "taskName": "Quote", "name": "big.mov", "size": 900, "skipped": "too-large"}
+	]}`)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(attachments) != 2 || string(attachments[0].Data) != "hello" {
+		t.Fatalf("expected decoded data, got %+v", attachments)
+	}
+	if attachments[1].Data != nil || attachments[1].Skipped != "too-large" {
+		t.Errorf("expected the skipped file without data, got %+v", attachments[1])
+	}
+}
+
+func TestParseFolders(t *testing.T) {
+	folders, err := ParseFolders(`{"folders": [{"id": "f1", "name": "Work", "projectIds": ["p1", "p2"]}]}`)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(folders) != 1 || folders[0].Name != "Work" || len(folders[0].ProjectIDs) != 2 {
+		t.Errorf("expected Work with 2 projects, got %+v", folders)
+	}
+
+	folders, err = ParseFolders(`{"folders": null}`)
+	if err != nil || folders == nil {
+		t.Errorf("expected an empty slice, got %v, %v", folders, err)
+	}
+
+	if _, err := ParseFolders(`{"error": "OmniFocus is not running"}`); err == nil {
+		t.Error("expected an error when OmniFocus is not running")
+	}
+}
+
 func TestParseTags_EmptyArray(t *testing.T) {
 	jsonStr := `{"tags": []}`
 
@@ -925,3 +1109,35 @@ func TestParseOperationResult_OmniFocusNotRunning(t *testing.T) {
 		t.Errorf("expected ErrOmniFocusNotRunning, got %v", err)
 	}
 }
+
+func TestParseTasksPage_ReturnsTasksAndTotal(t *testing.T) {
+	jsonStr := `{"tasks": [{"id": "task1", "name": "Task 1"}], "total": 42}`
+
+	tasks, total, err := ParseTasksPage(jsonStr)
+	if err != nil {
+		t.Fatalf("ParseTasksPage() error = %v, want nil", err)
+	}
+	if len(tasks) != 1 {
+		t.Errorf("ParseTasksPage() returned %d tasks, want 1", len(tasks))
+	}
+	if total != 42 {
+		t.Errorf("ParseTasksPage() total = %d, want 42", total)
+	}
+}
+
+func TestParseTasksPage_OmniFocusNotRunning(t *testing.T) {
+	_, _, err := ParseTasksPage(`{"error": "OmniFocus is not running"}`)
+	if err != ErrOmniFocusNotRunning {
+		t.Errorf("ParseTasksPage() error = %v, want ErrOmniFocusNotRunning", err)
+	}
+}
+
+func TestParseTask_NotFoundError(t *testing.T) {
+	_, err := ParseTask(`{"error": "Task not found: abc123"}`)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ParseTask() error = %v, want ErrNotFound", err)
+	}
+	if err.Error() != "Task not found: abc123" {
+		t.Errorf("error = %q, want the script's message", err.Error())
+	}
+}
@@ -0,0 +1,48 @@
+package bridge
+
+import "bytes"
+
+// limitedBuffer is an io.Writer that keeps at most limit bytes.
+// Writes past the limit are accepted but discarded so the child process
+// never blocks on a full pipe; Overflowed reports whether data was dropped.
+type limitedBuffer struct {
+	buf        bytes.Buffer
+	limit      int64
+	overflowed bool
+}
+
+// newLimitedBuffer creates a limitedBuffer; a limit of zero or less means unlimited
+func newLimitedBuffer(limit int64) *limitedBuffer {
+	return &limitedBuffer{limit: limit}
+}
+
+// Write implements io.Writer
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit <= 0 {
+		return b.buf.Write(p)
+	}
+
+	remaining := b.limit - int64(b.buf.Len())
+	if remaining <= 0 {
+		b.overflowed = true
+		return len(p), nil
+	}
+
+	if int64(len(p)) > remaining {
+		b.buf.Write(p[:remaining])
+		b.overflowed = true
+		return len(p), nil
+	}
+
+	return b.buf.Write(p)
+}
+
+// Overflowed reports whether any output was discarded
+func (b *limitedBuffer) Overflowed() bool {
+	return b.overflowed
+}
+
+// String returns the retained output
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,55 @@
+package bridge
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLimitedBuffer_WithinLimit(t *testing.T) {
+	buf := newLimitedBuffer(10)
+
+	n, err := buf.Write([]byte("hello"))
+	if err != nil || n != 5 {
+		t.Fatalf("Write() = %d, %v, want 5, nil", n, err)
+	}
+
+	if buf.Overflowed() {
+		t.Error("Overflowed() = true, want false")
+	}
+	if buf.String() != "hello" {
+		t.Errorf("String() = %q, want %q", buf.String(), "hello")
+	}
+}
+
+func TestLimitedBuffer_ExceedsLimit(t *testing.T) {
+	buf := newLimitedBuffer(8)
+
+	// Writes past the limit must still report full length to avoid blocking the writer
+	for _, chunk := range []string{"hello", "world", "again"} {
+		n, err := buf.Write([]byte(chunk))
+		if err != nil || n != len(chunk) {
+			t.Fatalf("Write(%q) = %d, %v, want %d, nil", chunk, n, err, len(chunk))
+		}
+	}
+
+	if !buf.Overflowed() {
+		t.Error("Overflowed() = false, want true")
+	}
+	if buf.String() != "hellowor" {
+		t.Errorf("String() = %q, want %q", buf.String(), "hellowor")
+	}
+}
+
+func TestLimitedBuffer_Unlimited(t *testing.T) {
+	buf := newLimitedBuffer(0)
+
+	large := strings.Repeat("x", 1<<16)
+	_, _ = buf.Write([]byte(large))
+
+	if buf.Overflowed() {
+		t.Error("Overflowed() = true, want false")
+	}
+	if len(buf.String()) != len(large) {
+		t.Errorf("len(String()) = %d, want %d", len(buf.String()), len(large))
+	}
+}
@@ -0,0 +1,72 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrAutomationNotPermitted is returned when macOS does not allow LazyFocus
+// to send Apple events to OmniFocus
+var ErrAutomationNotPermitted = errors.New("not permitted to control OmniFocus")
+
+// AutomationPermissionFix tells the user how to grant the Automation permission
+const AutomationPermissionFix = `macOS has not allowed your terminal to control OmniFocus.
+  1. Open System Settings → Privacy & Security → Automation
+  2. Under your terminal app (Terminal, iTerm, …), turn on OmniFocus
+  3. If your terminal is not listed, run: tccutil reset AppleEvents
+     and run lazyfocus again to get the permission prompt
+  4. Restart the terminal`
+
+// permissionDeniedCode is the AppleScript error number osascript reports
+// when macOS denies an Apple event to another app
+const permissionDeniedCode = "(-1743)"
+
+// permissionCheckScript sends OmniFocus the smallest Apple event that needs
+// the Automation permission, without launching it
+const permissionCheckScript = `(() => {
+  const app = Application("OmniFocus");
+  if (!app.running()) {
+    return "not running";
+  }
+  app.name();
+  return "ok";
+})()`
+
+// IsPermissionDenied reports whether err is macOS denying LazyFocus the
+// Automation permission for OmniFocus
+func IsPermissionDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrAutomationNotPermitted) {
+		return true
+	}
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && strings.Contains(err.Error(), permissionDeniedCode)
+}
+
+// CheckAutomationPermission checks that macOS allows LazyFocus to control
+// OmniFocus. It returns ErrAutomationNotPermitted when the permission is
+// missing, ErrOmniFocusNotRunning when OmniFocus cannot be asked, and the
+// osascript failure for anything else.
+func CheckAutomationPermission(ctx context.Context) error {
+	return checkAutomationPermission(ctx, NewOSAScriptExecutor())
+}
+
+// checkAutomationPermission is CheckAutomationPermission running its script
+// through e
+func checkAutomationPermission(ctx context.Context, e Executor) error {
+	out, err := e.Execute(ctx, permissionCheckScript)
+	switch {
+	case IsPermissionDenied(err):
+		return fmt.Errorf("%w: %w", ErrAutomationNotPermitted, err)
+	case err != nil:
+		return err
+	case strings.TrimSpace(out) == "not running":
+		return ErrOmniFocusNotRunning
+	}
+	return nil
+}
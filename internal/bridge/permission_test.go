@@ -0,0 +1,63 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+)
+
+func TestIsPermissionDenied(t *testing.T) {
+	denied := fmt.Errorf("osascript execution failed: %w: %s", &exec.ExitError{}, "execution error: Not authorized to send Apple events to OmniFocus. (-1743)")
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "osascript denied", err: denied, want: true},
+		{name: "denied after retries", err: &RetryError{Attempts: 3, Err: denied}, want: true},
+		{name: "wrapped sentinel", err: fmt.Errorf("check: %w", ErrAutomationNotPermitted), want: true},
+		{name: "other osascript failure", err: fmt.Errorf("osascript execution failed: %w: %s", &exec.ExitError{}, "(-1712)"), want: false},
+		{name: "code in a plain error", err: errors.New("task named (-1743)"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPermissionDenied(tt.err); got != tt.want {
+				t.Errorf("IsPermissionDenied(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAutomationPermission(t *testing.T) {
+	denied := fmt.Errorf("osascript execution failed: %w: %s", &exec.ExitError{}, "Not authorized to send Apple events to OmniFocus. (-1743)")
+	failed := errors.New("osascript not found")
+
+	tests := []struct {
+		name    string
+		out     string
+		err     error
+		wantErr error
+	}{
+		{name: "allowed", out: "ok\n"},
+		{name: "not running", out: "not running\n", wantErr: ErrOmniFocusNotRunning},
+		{name: "denied", err: denied, wantErr: ErrAutomationNotPermitted},
+		{name: "other failure", err: failed, wantErr: failed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &mockExecutor{executeFunc: func(string) (string, error) { return tt.out, tt.err }}
+
+			err := checkAutomationPermission(context.Background(), executor)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("checkAutomationPermission() = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkAutomationPermission() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,10 +1,104 @@
 package bridge
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
 	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/log"
 )
 
+// transientErrorCodes are the AppleScript error numbers osascript reports for
+// failures that tend to clear up on their own: an Apple event timing out
+// while OmniFocus is busy (-1712), a dropped connection to it (-609), and
+// automation access still waiting on the permission dialog (-1743)
+var transientErrorCodes = []string{"(-1712)", "(-609)", "(-1743)"}
+
+// RetryError is returned when an operation still fails after being retried,
+// and records how many attempts were made
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+// Error describes the last failure and the number of attempts
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%v (after %d attempts)", e.Err, e.Attempts)
+}
+
+// Unwrap returns the last failure
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAttempts returns the number of attempts made
+func (e *RetryError) RetryAttempts() int {
+	return e.Attempts
+}
+
+// IsRetryable reports whether err is a transient failure worth retrying:
+// a script timeout, or an osascript failure with a transient error code.
+// Anything else, such as a missing osascript, an oversized payload or a
+// script error, is fatal.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrExecutionTimeout) {
+		return true
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	message := err.Error()
+	for _, code := range transientErrorCodes {
+		if strings.Contains(message, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// ambiguousErrorCodes are the transient error numbers that can come back
+// after OmniFocus received the Apple event: it timing out (-1712) or the
+// connection dropping (-609) says nothing about whether the script ran
+var ambiguousErrorCodes = []string{"(-1712)", "(-609)"}
+
+// MayHaveApplied reports whether a script that failed with err may still
+// have changed OmniFocus: it timed out or was canceled while running, or the
+// Apple event timed out. Any other failure means OmniFocus answered with an
+// error or was never reached.
+func MayHaveApplied(err error) bool {
+	if errors.Is(err, ErrExecutionTimeout) || errors.Is(err, ErrExecutionCanceled) {
+		return true
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	message := err.Error()
+	for _, code := range ambiguousErrorCodes {
+		if strings.Contains(message, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// noRetryKey marks a context whose scripts must run at most once
+type noRetryKey struct{}
+
+// WithoutRetry returns a copy of ctx under which a RetryableExecutor runs
+// scripts only once. Scripts changing OmniFocus use it: a timed out write
+// may still have been applied, and running it again would apply it twice.
+func WithoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
 // RetryConfig holds retry configuration
 type RetryConfig struct {
 	MaxAttempts int
@@ -26,7 +120,7 @@ func DefaultRetryConfig() RetryConfig {
 
 // RetryableExecutor wraps an executor with retry logic.
 // It automatically retries failed operations with exponential backoff,
-// but only for timeout errors. Other errors fail immediately.
+// but only for errors IsRetryable accepts. Other errors fail immediately.
 type RetryableExecutor struct {
 	executor Executor
 	config   RetryConfig
@@ -40,35 +134,57 @@ func NewRetryableExecutor(executor Executor, config RetryConfig) *RetryableExecu
 	}
 }
 
-// Execute runs the script with retry logic using the default 30-second timeout.
-func (r *RetryableExecutor) Execute(script string) (string, error) {
-	return r.ExecuteWithTimeout(script, 30*time.Second)
+// Execute runs the script with retry logic, each attempt limited by the
+// wrapped executor's own timeout.
+func (r *RetryableExecutor) Execute(ctx context.Context, script string) (string, error) {
+	return r.retry(ctx, func() (string, error) {
+		return r.executor.Execute(ctx, script)
+	})
 }
 
 // ExecuteWithTimeout runs the script with retry logic and a custom timeout.
-// Only timeout errors (ErrExecutionTimeout) are retried.
-// Other errors are returned immediately without retry.
-// Implements exponential backoff with a configurable maximum wait time.
-func (r *RetryableExecutor) ExecuteWithTimeout(script string, timeout time.Duration) (string, error) {
+// Only transient errors (see IsRetryable) are retried; other errors are
+// returned immediately. Implements exponential backoff with a configurable
+// maximum wait time. When retries run out, the last error is returned in a
+// RetryError. Once ctx is canceled no further attempt is made, and a ctx
+// from WithoutRetry runs the script only once.
+func (r *RetryableExecutor) ExecuteWithTimeout(ctx context.Context, script string, timeout time.Duration) (string, error) {
+	return r.retry(ctx, func() (string, error) {
+		return r.executor.ExecuteWithTimeout(ctx, script, timeout)
+	})
+}
+
+// retry calls run until it succeeds, fails with an error IsRetryable
+// rejects, or the attempts run out
+func (r *RetryableExecutor) retry(ctx context.Context, run func() (string, error)) (string, error) {
+	if noRetry, _ := ctx.Value(noRetryKey{}).(bool); noRetry {
+		return run()
+	}
+
 	var lastErr error
 	wait := r.config.InitialWait
+	attempts := max(r.config.MaxAttempts, 1)
 
-	for attempt := 1; attempt <= r.config.MaxAttempts; attempt++ {
-		result, err := r.executor.ExecuteWithTimeout(script, timeout)
+	for attempt := 1; attempt <= attempts; attempt++ {
+		result, err := run()
 		if err == nil {
 			return result, nil
 		}
 
-		// Only retry on timeout errors
-		if !errors.Is(err, ErrExecutionTimeout) {
+		if !IsRetryable(err) {
 			return "", err
 		}
 
 		lastErr = err
 
 		// Don't wait after last attempt
-		if attempt < r.config.MaxAttempts {
-			time.Sleep(wait)
+		if attempt < attempts {
+			log.Logger().Debug("retrying script after transient error", "attempt", attempt, "wait", wait, "error", log.Truncate(err.Error()))
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return "", fmt.Errorf("%w: %w", ErrExecutionCanceled, ctx.Err())
+			}
 			// Exponential backoff
 			wait *= 2
 			if wait > r.config.MaxWait {
@@ -77,5 +193,8 @@ func (r *RetryableExecutor) ExecuteWithTimeout(script string, timeout time.Durat
 		}
 	}
 
+	if attempts > 1 {
+		return "", &RetryError{Attempts: attempts, Err: lastErr}
+	}
 	return "", lastErr
 }
@@ -1,7 +1,10 @@
 package bridge
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"os/exec"
 	"testing"
 	"time"
 )
@@ -14,14 +17,14 @@ type mockExecutor struct {
 	executeWithTimeoutFunc func(script string, timeout time.Duration) (string, error)
 }
 
-func (m *mockExecutor) Execute(script string) (string, error) {
+func (m *mockExecutor) Execute(ctx context.Context, script string) (string, error) {
 	if m.executeFunc != nil {
 		return m.executeFunc(script)
 	}
 	return "", errors.New("not implemented")
 }
 
-func (m *mockExecutor) ExecuteWithTimeout(script string, timeout time.Duration) (string, error) {
+func (m *mockExecutor) ExecuteWithTimeout(ctx context.Context, script string, timeout time.Duration) (string, error) {
 	if m.executeWithTimeoutFunc != nil {
 		return m.executeWithTimeoutFunc(script, timeout)
 	}
@@ -59,7 +62,7 @@ func TestRetryableExecutor_SuccessOnFirstAttempt(t *testing.T) {
 
 	retryExecutor := NewRetryableExecutor(mock, config)
 
-	result, err := retryExecutor.ExecuteWithTimeout("test script", 5*time.Second)
+	result, err := retryExecutor.ExecuteWithTimeout(context.Background(), "test script", 5*time.Second)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -91,7 +94,7 @@ func TestRetryableExecutor_SuccessAfterRetries(t *testing.T) {
 	retryExecutor := NewRetryableExecutor(mock, config)
 
 	start := time.Now()
-	result, err := retryExecutor.ExecuteWithTimeout("test script", 5*time.Second)
+	result, err := retryExecutor.ExecuteWithTimeout(context.Background(), "test script", 5*time.Second)
 	elapsed := time.Since(start)
 
 	if err != nil {
@@ -130,9 +133,9 @@ func TestRetryableExecutor_FailureAfterMaxAttempts(t *testing.T) {
 
 	retryExecutor := NewRetryableExecutor(mock, config)
 
-	result, err := retryExecutor.ExecuteWithTimeout("test script", 5*time.Second)
+	result, err := retryExecutor.ExecuteWithTimeout(context.Background(), "test script", 5*time.Second)
 
-	if err != ErrExecutionTimeout {
+	if !errors.Is(err, ErrExecutionTimeout) {
 		t.Errorf("Expected ErrExecutionTimeout, got %v", err)
 	}
 
@@ -143,6 +146,11 @@ func TestRetryableExecutor_FailureAfterMaxAttempts(t *testing.T) {
 	if attemptCount != 3 {
 		t.Errorf("Expected 3 attempts, got %d", attemptCount)
 	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 {
+		t.Errorf("Expected a RetryError with 3 attempts, got %v", err)
+	}
 }
 
 func TestRetryableExecutor_NoRetryOnNonTimeoutError(t *testing.T) {
@@ -164,7 +172,7 @@ func TestRetryableExecutor_NoRetryOnNonTimeoutError(t *testing.T) {
 
 	retryExecutor := NewRetryableExecutor(mock, config)
 
-	result, err := retryExecutor.ExecuteWithTimeout("test script", 5*time.Second)
+	result, err := retryExecutor.ExecuteWithTimeout(context.Background(), "test script", 5*time.Second)
 
 	if err != otherError {
 		t.Errorf("Expected otherError, got %v", err)
@@ -200,10 +208,10 @@ func TestRetryableExecutor_ExponentialBackoff(t *testing.T) {
 
 	// Single execution should make 4 attempts with exponential backoff
 	start := time.Now()
-	_, err := retryExecutor.ExecuteWithTimeout("test script", 1*time.Second)
+	_, err := retryExecutor.ExecuteWithTimeout(context.Background(), "test script", 1*time.Second)
 	elapsed := time.Since(start)
 
-	if err != ErrExecutionTimeout {
+	if !errors.Is(err, ErrExecutionTimeout) {
 		t.Errorf("Expected ErrExecutionTimeout, got %v", err)
 	}
 
@@ -220,20 +228,26 @@ func TestRetryableExecutor_ExponentialBackoff(t *testing.T) {
 }
 
 func TestRetryableExecutor_Execute(t *testing.T) {
-	// Test that Execute method uses ExecuteWithTimeout with default timeout
+	// Test that Execute leaves the timeout to the wrapped executor
+	attemptCount := 0
 	mock := &mockExecutor{
-		executeWithTimeoutFunc: func(script string, timeout time.Duration) (string, error) {
-			if timeout != 30*time.Second {
-				t.Errorf("Expected default timeout of 30s, got %v", timeout)
+		executeFunc: func(script string) (string, error) {
+			attemptCount++
+			if attemptCount < 2 {
+				return "", ErrExecutionTimeout
 			}
 			return "success", nil
 		},
+		executeWithTimeoutFunc: func(script string, timeout time.Duration) (string, error) {
+			t.Errorf("Execute() should not pick a timeout, got %v", timeout)
+			return "", nil
+		},
 	}
 
-	config := DefaultRetryConfig()
+	config := RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond}
 	retryExecutor := NewRetryableExecutor(mock, config)
 
-	result, err := retryExecutor.Execute("test script")
+	result, err := retryExecutor.Execute(context.Background(), "test script")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -242,6 +256,35 @@ func TestRetryableExecutor_Execute(t *testing.T) {
 	if result != "success" {
 		t.Errorf("Expected result 'success', got %q", result)
 	}
+
+	if attemptCount != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attemptCount)
+	}
+}
+
+func TestRetryableExecutor_WithoutRetryRunsOnce(t *testing.T) {
+	attemptCount := 0
+	mock := &mockExecutor{
+		executeWithTimeoutFunc: func(script string, timeout time.Duration) (string, error) {
+			attemptCount++
+			return "", ErrExecutionTimeout
+		},
+	}
+
+	retryExecutor := NewRetryableExecutor(mock, RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond})
+
+	_, err := retryExecutor.ExecuteWithTimeout(WithoutRetry(context.Background()), "test script", time.Second)
+
+	if !errors.Is(err, ErrExecutionTimeout) {
+		t.Errorf("ExecuteWithTimeout() error = %v, want ErrExecutionTimeout", err)
+	}
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		t.Errorf("ExecuteWithTimeout() error = %v, want no RetryError", err)
+	}
+	if attemptCount != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attemptCount)
+	}
 }
 
 func TestRetryableExecutor_MaxWaitCap(t *testing.T) {
@@ -263,10 +306,10 @@ func TestRetryableExecutor_MaxWaitCap(t *testing.T) {
 	retryExecutor := NewRetryableExecutor(mock, config)
 
 	start := time.Now()
-	_, err := retryExecutor.ExecuteWithTimeout("test script", 5*time.Second)
+	_, err := retryExecutor.ExecuteWithTimeout(context.Background(), "test script", 5*time.Second)
 	elapsed := time.Since(start)
 
-	if err != ErrExecutionTimeout {
+	if !errors.Is(err, ErrExecutionTimeout) {
 		t.Errorf("Expected ErrExecutionTimeout, got %v", err)
 	}
 
@@ -306,7 +349,7 @@ func TestRetryableExecutor_WrappedTimeoutError(t *testing.T) {
 
 	retryExecutor := NewRetryableExecutor(mock, config)
 
-	result, err := retryExecutor.ExecuteWithTimeout("test script", 5*time.Second)
+	result, err := retryExecutor.ExecuteWithTimeout(context.Background(), "test script", 5*time.Second)
 
 	if err != nil {
 		t.Errorf("Expected no error after retry, got %v", err)
@@ -320,3 +363,112 @@ func TestRetryableExecutor_WrappedTimeoutError(t *testing.T) {
 		t.Errorf("Expected 2 attempts (retry on wrapped timeout), got %d", attemptCount)
 	}
 }
+
+func TestIsRetryable(t *testing.T) {
+	osascriptErr := func(stderr string) error {
+		return fmt.Errorf("osascript execution failed: %w: %s", &exec.ExitError{}, stderr)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "timeout", err: ErrExecutionTimeout, want: true},
+		{name: "wrapped timeout", err: fmt.Errorf("failed to get inbox tasks: %w", ErrExecutionTimeout), want: true},
+		{name: "apple event timed out", err: osascriptErr("execution error: OmniFocus got an error: AppleEvent timed out. (-1712)"), want: true},
+		{name: "connection invalid", err: osascriptErr("execution error: Connection is invalid. (-609)"), want: true},
+		{name: "awaiting permission", err: osascriptErr("execution error: Not authorized to send Apple events to OmniFocus. (-1743)"), want: true},
+		{name: "script error", err: osascriptErr("execution error: Error: TypeError: undefined is not an object (-2700)"), want: false},
+		{name: "osascript missing", err: ErrOSAScriptNotFound, want: false},
+		{name: "payload too large", err: ErrPayloadTooLarge, want: false},
+		{name: "code in a plain error", err: errors.New("task named (-1712)"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryableExecutor_RetriesTransientOSAScriptError(t *testing.T) {
+	attemptCount := 0
+	mock := &mockExecutor{
+		executeWithTimeoutFunc: func(script string, timeout time.Duration) (string, error) {
+			attemptCount++
+			if attemptCount == 1 {
+				return "", fmt.Errorf("osascript execution failed: %w: %s", &exec.ExitError{}, "AppleEvent timed out. (-1712)")
+			}
+			return "ok", nil
+		},
+	}
+
+	retryExecutor := NewRetryableExecutor(mock, RetryConfig{MaxAttempts: 3, InitialWait: time.Millisecond, MaxWait: time.Millisecond})
+
+	result, err := retryExecutor.ExecuteWithTimeout(context.Background(), "test script", time.Second)
+
+	if err != nil || result != "ok" {
+		t.Errorf("ExecuteWithTimeout() = %q, %v, want \"ok\", nil", result, err)
+	}
+	if attemptCount != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attemptCount)
+	}
+}
+
+func TestRetryError(t *testing.T) {
+	err := &RetryError{Attempts: 3, Err: ErrExecutionTimeout}
+
+	if got, want := err.Error(), "script execution timed out (after 3 attempts)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrExecutionTimeout) {
+		t.Error("errors.Is(RetryError, ErrExecutionTimeout) = false, want true")
+	}
+}
+
+func TestRetryableExecutor_StopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attemptCount := 0
+	mock := &mockExecutor{
+		executeWithTimeoutFunc: func(script string, timeout time.Duration) (string, error) {
+			attemptCount++
+			cancel()
+			return "", ErrExecutionTimeout
+		},
+	}
+
+	retryExecutor := NewRetryableExecutor(mock, RetryConfig{MaxAttempts: 3, InitialWait: time.Minute, MaxWait: time.Minute})
+
+	_, err := retryExecutor.ExecuteWithTimeout(ctx, "test script", time.Second)
+
+	if !errors.Is(err, ErrExecutionCanceled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("ExecuteWithTimeout() error = %v, want ErrExecutionCanceled", err)
+	}
+	if attemptCount != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attemptCount)
+	}
+}
+
+func TestMayHaveApplied(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"timeout", ErrExecutionTimeout, true},
+		{"canceled", fmt.Errorf("%w: %w", ErrExecutionCanceled, context.Canceled), true},
+		{"apple event timed out", fmt.Errorf("osascript execution failed: %w: %s", &exec.ExitError{}, "AppleEvent timed out. (-1712)"), true},
+		{"script error", fmt.Errorf("osascript execution failed: %w: %s", &exec.ExitError{}, "Error: Task not found"), false},
+		{"osascript missing", ErrOSAScriptNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MayHaveApplied(tt.err); got != tt.want {
+				t.Errorf("MayHaveApplied(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
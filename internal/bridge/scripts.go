@@ -125,8 +125,8 @@ func GetScriptWithParams(name string, params map[string]string) (string, error)
 		}
 	}
 
-	// Parse script as template
-	tmpl, err := template.New(name).Parse(script)
+	// Parse script as template; placeholders without a param render as ""
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(script)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse script template: %w", err)
 	}
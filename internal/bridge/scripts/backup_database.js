@@ -0,0 +1,26 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    // OmniFocus has no scripting command for backups, so choose its
+    // File > Back Up Database menu item through System Events
+    const process = Application("System Events").processes.byName("OmniFocus");
+    const fileMenu = process.menuBars[0].menuBarItems.byName("File").menus[0];
+    const items = fileMenu.menuItems.whose({ name: { _beginsWith: "Back Up Database" } })();
+
+    if (items.length === 0) {
+      return JSON.stringify({ error: "OmniFocus has no Back Up Database menu item" });
+    }
+
+    items[0].click();
+    return JSON.stringify({ success: true, message: items[0].name() });
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
@@ -0,0 +1,66 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    const doc = app.defaultDocument;
+
+    // Template parameters (filled by Go)
+    const projectName = "{{.Name}}";
+    const projectNote = "{{.Note}}";
+    const dueDateStr = "{{.DueDate}}";
+    const deferDateStr = "{{.DeferDate}}";
+
+    if (!projectName) {
+      return JSON.stringify({ error: "Project name is required" });
+    }
+
+    // Create project properties object
+    const projectProps = {
+      name: projectName
+    };
+
+    if (projectNote) {
+      projectProps.note = projectNote;
+    }
+
+    // Parse and set due date
+    if (dueDateStr) {
+      const dueDate = new Date(dueDateStr);
+      if (isNaN(dueDate.getTime())) {
+        return JSON.stringify({ error: `Invalid due date format: ${dueDateStr}` });
+      }
+      projectProps.dueDate = dueDate;
+    }
+
+    // Parse and set defer date
+    if (deferDateStr) {
+      const deferDate = new Date(deferDateStr);
+      if (isNaN(deferDate.getTime())) {
+        return JSON.stringify({ error: `Invalid defer date format: ${deferDateStr}` });
+      }
+      projectProps.deferDate = deferDate;
+    }
+
+    // Create the project at the top level of the library
+    const newProject = app.Project(projectProps);
+    doc.projects.push(newProject);
+
+    const project = {
+      id: newProject.id(),
+      name: newProject.name(),
+      status: "active",
+      note: newProject.note() || ""
+    };
+
+    return JSON.stringify({ project: project }, null, 2);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
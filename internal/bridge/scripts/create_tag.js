@@ -0,0 +1,57 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    const doc = app.defaultDocument;
+
+    // Template parameters (filled by Go)
+    const tagName = "{{.Name}}";
+    const parentTagID = "{{.ParentTagID}}";
+
+    if (!tagName) {
+      return JSON.stringify({ error: "Tag name is required" });
+    }
+
+    // Without a parent tag the tag is created at the top level
+    let container = doc;
+    let parentID = "";
+    if (parentTagID) {
+      const allTags = doc.flattenedTags;
+      let parentTag = null;
+
+      for (let i = 0; i < allTags.length; i++) {
+        if (allTags[i].id() === parentTagID) {
+          parentTag = allTags[i];
+          break;
+        }
+      }
+
+      if (!parentTag) {
+        return JSON.stringify({ error: `Parent tag not found: ${parentTagID}` });
+      }
+
+      container = parentTag;
+      parentID = parentTagID;
+    }
+
+    const newTag = app.Tag({ name: tagName });
+    container.tags.push(newTag);
+
+    const tag = {
+      id: newTag.id(),
+      name: newTag.name(),
+      parentID: parentID
+    };
+
+    return JSON.stringify({ tag: tag }, null, 2);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
@@ -14,6 +14,7 @@
     const taskName = "{{.Name}}";
     const taskNote = "{{.Note}}";
     const projectID = "{{.ProjectID}}";
+    const parentID = "{{.ParentID}}";
     const tagsJSON = "{{.Tags}}";
     const dueDateStr = "{{.DueDate}}";
     const deferDateStr = "{{.DeferDate}}";
@@ -59,8 +60,25 @@
     // Create the task
     const newTask = app.Task(taskProps);
 
-    // Add task to project or inbox
-    if (projectID) {
+    // Add task under its parent task, to project, or to inbox
+    if (parentID) {
+      // Find parent task by ID
+      const allTasks = doc.flattenedTasks;
+      let parentTask = null;
+
+      for (let i = 0; i < allTasks.length; i++) {
+        if (allTasks[i].id() === parentID) {
+          parentTask = allTasks[i];
+          break;
+        }
+      }
+
+      if (!parentTask) {
+        return JSON.stringify({ error: `Parent task not found: ${parentID}` });
+      }
+
+      parentTask.tasks.push(newTask);
+    } else if (projectID) {
       // Find project by ID
       const allProjects = doc.flattenedProjects;
       let targetProject = null;
@@ -0,0 +1,49 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    const doc = app.defaultDocument;
+
+    // Template parameters (filled by Go)
+    const tagID = "{{.TagID}}";
+
+    if (!tagID) {
+      return JSON.stringify({ error: "Tag ID is required" });
+    }
+
+    // Find the tag by ID
+    const allTags = doc.flattenedTags;
+    let targetTag = null;
+
+    for (let i = 0; i < allTags.length; i++) {
+      if (allTags[i].id() === tagID) {
+        targetTag = allTags[i];
+        break;
+      }
+    }
+
+    if (!targetTag) {
+      return JSON.stringify({ error: `Tag not found: ${tagID}` });
+    }
+
+    // Deleting a tag also deletes its child tags; tasks keep their other tags
+    app.delete(targetTag);
+
+    const result = {
+      success: true,
+      id: tagID,
+      message: "Tag deleted"
+    };
+
+    return JSON.stringify(result, null, 2);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
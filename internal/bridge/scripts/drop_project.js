@@ -0,0 +1,49 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    const doc = app.defaultDocument;
+
+    // Template parameters (filled by Go)
+    const projectID = "{{.ProjectID}}";
+
+    if (!projectID) {
+      return JSON.stringify({ error: "Project ID is required" });
+    }
+
+    // Find the project by ID
+    const allProjects = doc.flattenedProjects;
+    let targetProject = null;
+
+    for (let i = 0; i < allProjects.length; i++) {
+      if (allProjects[i].id() === projectID) {
+        targetProject = allProjects[i];
+        break;
+      }
+    }
+
+    if (!targetProject) {
+      return JSON.stringify({ error: `Project not found: ${projectID}` });
+    }
+
+    // Drop the project along with its remaining tasks
+    targetProject.markDropped();
+
+    const result = {
+      success: true,
+      id: projectID,
+      message: "Project dropped"
+    };
+
+    return JSON.stringify(result, null, 2);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
@@ -10,9 +10,35 @@
 
     const doc = app.defaultDocument;
 
+    // Folder names containing a project, outermost first, looked up once per project
+    const folderPaths = {};
+    function folderPath(project) {
+      if (!project) return [];
+      const id = project.id();
+      if (!folderPaths[id]) {
+        const path = [];
+        try {
+          let folder = project.folder();
+          while (folder) {
+            path.unshift(folder.name());
+            const container = folder.container();
+            folder = container && container.class() === "folder" ? container : null;
+          }
+        } catch (e) {
+          // Keep the folders found so far
+        }
+        folderPaths[id] = path;
+      }
+      return folderPaths[id];
+    }
+
     // Template parameters (filled by Go)
     const showCompleted = "{{.ShowCompleted}}" === "true";
     const flaggedOnly = "{{.FlaggedOnly}}" === "true";
+    // Pagination (0 limit = return everything)
+    const offset = parseInt("{{.Offset}}", 10) || 0;
+    const limit = parseInt("{{.Limit}}", 10) || 0;
+    let matched = 0;
 
     let allTasks = doc.flattenedTasks;
     const tasks = [];
@@ -20,12 +46,19 @@
     for (let i = 0; i < allTasks.length; i++) {
       const task = allTasks[i];
 
+      // Dropped tasks are hidden, like deleted ones
+      if (task.dropped()) continue;
+
       // Skip completed unless requested
       if (!showCompleted && task.completed()) continue;
 
       // Skip if filtering for flagged only
       if (flaggedOnly && !task.flagged()) continue;
 
+      // Count every match, but only serialize tasks inside the requested page
+      matched++;
+      if (limit > 0 && (matched <= offset || matched > offset + limit)) continue;
+
       // Extract tag names from task tags
       const taskTags = task.tags;
       const tags = [];
@@ -41,6 +74,9 @@
       // Convert dates to ISO 8601 format or null
       const dueDate = task.dueDate();
       const deferDate = task.deferDate();
+      const effectiveDueDate = task.effectiveDueDate();
+      const effectiveDeferDate = task.effectiveDeferDate();
+      const repetition = task.repetitionRule();
       const completedDate = task.completionDate();
 
       tasks.push({
@@ -49,15 +85,28 @@
         note: task.note() || "",
         projectID: projectID,
         projectName: projectName,
+        folderPath: folderPath(containingProject),
         tags: tags,
         dueDate: dueDate ? dueDate.toISOString() : null,
         deferDate: deferDate ? deferDate.toISOString() : null,
         flagged: task.flagged(),
+        repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+        estimatedMinutes: task.estimatedMinutes(),
+        modifiedDate: task.modificationDate() ? task.modificationDate().toISOString() : null,
+        blocked: task.blocked(),
+        sequential: task.sequential(),
+        dropped: task.dropped(),
+        effectiveDueDate: effectiveDueDate ? effectiveDueDate.toISOString() : null,
+        effectiveDeferDate: effectiveDeferDate ? effectiveDeferDate.toISOString() : null,
         completed: task.completed(),
         completedDate: completedDate ? completedDate.toISOString() : null
       });
     }
 
+    if (limit > 0) {
+      return JSON.stringify({ tasks: tasks, total: matched });
+    }
+
     return JSON.stringify({ tasks: tasks }, null, 2);
 
   } catch (e) {
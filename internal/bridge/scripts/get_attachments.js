@@ -0,0 +1,71 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    // Template parameters (filled by Go)
+    const projectID = "{{.ProjectID}}";
+    const offset = parseInt("{{.Offset}}", 10) || 0;
+    const maxFileBytes = parseInt("{{.MaxFileBytes}}", 10) || 0;
+    const maxTotalBytes = parseInt("{{.MaxTotalBytes}}", 10) || 0;
+
+    // Attachment contents are only exposed to Omni Automation, so read them
+    // from inside OmniFocus and pass them back base64-encoded. Files past the
+    // size limits come without contents so the output stays under the
+    // payload limit.
+    const omniScript = `(() => {
+      const project = Project.byIdentifier(${JSON.stringify(projectID)});
+      if (!project) {
+        return JSON.stringify({ error: "Project not found: " + ${JSON.stringify(projectID)} });
+      }
+      const offset = ${offset};
+      const maxFileBytes = ${maxFileBytes};
+      const maxTotalBytes = ${maxTotalBytes};
+
+      const attachments = [];
+      let total = 0;
+      let overBudget = false;
+      project.flattenedTasks.forEach(task => {
+        task.attachments.forEach(wrapper => {
+          const index = attachments.length;
+          const item = {
+            taskId: task.id.primaryKey,
+            taskName: task.name,
+            name: wrapper.preferredFilename || wrapper.filename || "attachment",
+            size: 0
+          };
+          attachments.push(item);
+
+          if (wrapper.type !== FileWrapper.Type.File) {
+            item.skipped = "not-file";
+            return;
+          }
+          const data = wrapper.contents;
+          item.size = data.length;
+          if (index < offset) {
+            item.skipped = "before";
+          } else if (maxFileBytes > 0 && item.size > maxFileBytes) {
+            item.skipped = "too-large";
+          } else if (overBudget || (maxTotalBytes > 0 && total + item.size > maxTotalBytes && total > 0)) {
+            overBudget = true;
+            item.skipped = "over-budget";
+          } else {
+            total += item.size;
+            item.data = data.toBase64();
+          }
+        });
+      });
+      return JSON.stringify({ attachments: attachments });
+    })()`;
+
+    return app.evaluateJavascript(omniScript);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
@@ -0,0 +1,93 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    // Template parameter: earliest completion date to include (YYYY-MM-DD, local time)
+    const sinceParts = "{{.Since}}".split("-");
+    const since = new Date(parseInt(sinceParts[0], 10), parseInt(sinceParts[1], 10) - 1, parseInt(sinceParts[2], 10));
+    if (isNaN(since.getTime())) {
+      return JSON.stringify({ error: "Invalid since date" });
+    }
+
+    const doc = app.defaultDocument;
+
+    // Folder names containing a project, outermost first, looked up once per project
+    const folderPaths = {};
+    function folderPath(project) {
+      if (!project) return [];
+      const id = project.id();
+      if (!folderPaths[id]) {
+        const path = [];
+        try {
+          let folder = project.folder();
+          while (folder) {
+            path.unshift(folder.name());
+            const container = folder.container();
+            folder = container && container.class() === "folder" ? container : null;
+          }
+        } catch (e) {
+          // Keep the folders found so far
+        }
+        folderPaths[id] = path;
+      }
+      return folderPaths[id];
+    }
+    const allTasks = doc.flattenedTasks;
+    const tasks = [];
+
+    for (let i = 0; i < allTasks.length; i++) {
+      const task = allTasks[i];
+
+      // Only include tasks completed on or after the since date
+      if (!task.completed()) continue;
+      const completedDate = task.completionDate();
+      if (!completedDate || completedDate < since) continue;
+
+      // Extract tag names from task tags
+      const taskTags = task.tags;
+      const tags = [];
+      for (let j = 0; j < taskTags.length; j++) {
+        tags.push(taskTags[j].name());
+      }
+
+      // Get project info if task belongs to a project
+      const containingProject = task.containingProject();
+      const projectID = containingProject ? containingProject.id() : "";
+      const projectName = containingProject ? containingProject.name() : "";
+
+      // Convert dates to ISO 8601 format or null
+      const dueDate = task.dueDate();
+      const deferDate = task.deferDate();
+      const repetition = task.repetitionRule();
+
+      tasks.push({
+        id: task.id(),
+        name: task.name(),
+        note: task.note() || "",
+        projectID: projectID,
+        projectName: projectName,
+        folderPath: folderPath(containingProject),
+        tags: tags,
+        dueDate: dueDate ? dueDate.toISOString() : null,
+        deferDate: deferDate ? deferDate.toISOString() : null,
+        flagged: task.flagged(),
+        repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+        estimatedMinutes: task.estimatedMinutes(),
+        modifiedDate: task.modificationDate() ? task.modificationDate().toISOString() : null,
+        completed: true,
+        completedDate: completedDate.toISOString()
+      });
+    }
+
+    return JSON.stringify({ tasks: tasks }, null, 2);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
@@ -10,6 +10,28 @@
 
     const doc = app.defaultDocument;
 
+    // Folder names containing a project, outermost first, looked up once per project
+    const folderPaths = {};
+    function folderPath(project) {
+      if (!project) return [];
+      const id = project.id();
+      if (!folderPaths[id]) {
+        const path = [];
+        try {
+          let folder = project.folder();
+          while (folder) {
+            path.unshift(folder.name());
+            const container = folder.container();
+            folder = container && container.class() === "folder" ? container : null;
+          }
+        } catch (e) {
+          // Keep the folders found so far
+        }
+        folderPaths[id] = path;
+      }
+      return folderPaths[id];
+    }
+
     // Template parameters (filled by Go)
     // Dates should be passed as RFC3339/ISO 8601 strings with timezone info
     // e.g., "2024-01-28T23:59:59+01:00" or "2024-01-28T22:59:59Z"
@@ -36,6 +58,9 @@
     for (let i = 0; i < allTasks.length; i++) {
       const task = allTasks[i];
 
+      // Dropped tasks are hidden, like deleted ones
+      if (task.dropped()) continue;
+
       // Skip completed tasks
       if (task.completed()) continue;
 
@@ -61,6 +86,9 @@
 
       // Convert dates to ISO 8601 format or null
       const deferDate = task.deferDate();
+      const effectiveDueDate = task.effectiveDueDate();
+      const effectiveDeferDate = task.effectiveDeferDate();
+      const repetition = task.repetitionRule();
       const completedDate = task.completionDate();
 
       tasks.push({
@@ -69,10 +97,19 @@
         note: task.note() || "",
         projectID: projectID,
         projectName: projectName,
+        folderPath: folderPath(containingProject),
         tags: tags,
         dueDate: dueDate.toISOString(),
         deferDate: deferDate ? deferDate.toISOString() : null,
         flagged: task.flagged(),
+        repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+        estimatedMinutes: task.estimatedMinutes(),
+        modifiedDate: task.modificationDate() ? task.modificationDate().toISOString() : null,
+        blocked: task.blocked(),
+        sequential: task.sequential(),
+        dropped: task.dropped(),
+        effectiveDueDate: effectiveDueDate ? effectiveDueDate.toISOString() : null,
+        effectiveDeferDate: effectiveDeferDate ? effectiveDeferDate.toISOString() : null,
         completed: task.completed(),
         completedDate: completedDate ? completedDate.toISOString() : null
       });
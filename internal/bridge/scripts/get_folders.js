@@ -0,0 +1,54 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    const doc = app.defaultDocument;
+    const topLevelFolders = doc.folders;
+    const folders = [];
+
+    for (let i = 0; i < topLevelFolders.length; i++) {
+      folders.push(buildFolderTree(topLevelFolders[i], ""));
+    }
+
+    return JSON.stringify({ folders: folders }, null, 2);
+
+    // Helper function to build the folder tree recursively, with the IDs of
+    // the projects directly in each folder
+    function buildFolderTree(folder, parentID) {
+      const result = {
+        id: folder.id(),
+        name: folder.name(),
+        parentId: parentID
+      };
+
+      const projects = folder.projects;
+      const projectIds = [];
+      for (let j = 0; j < projects.length; j++) {
+        projectIds.push(projects[j].id());
+      }
+      if (projectIds.length > 0) {
+        result.projectIds = projectIds;
+      }
+
+      const childFolders = folder.folders;
+      const children = [];
+      for (let j = 0; j < childFolders.length; j++) {
+        children.push(buildFolderTree(childFolders[j], folder.id()));
+      }
+      if (children.length > 0) {
+        result.children = children;
+      }
+
+      return result;
+    }
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
@@ -16,6 +16,9 @@
     for (let i = 0; i < inboxTasks.length; i++) {
       const task = inboxTasks[i];
 
+      // Dropped tasks are hidden, like deleted ones
+      if (task.dropped()) continue;
+
       // Extract tag names from task tags
       const taskTags = task.tags;
       const tags = [];
@@ -26,6 +29,9 @@
       // Convert dates to ISO 8601 format or null
       const dueDate = task.dueDate();
       const deferDate = task.deferDate();
+      const effectiveDueDate = task.effectiveDueDate();
+      const effectiveDeferDate = task.effectiveDeferDate();
+      const repetition = task.repetitionRule();
       const completedDate = task.completionDate();
 
       tasks.push({
@@ -36,6 +42,14 @@
         dueDate: dueDate ? dueDate.toISOString() : null,
         deferDate: deferDate ? deferDate.toISOString() : null,
         flagged: task.flagged(),
+        repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+        estimatedMinutes: task.estimatedMinutes(),
+        modifiedDate: task.modificationDate() ? task.modificationDate().toISOString() : null,
+        blocked: task.blocked(),
+        sequential: task.sequential(),
+        dropped: task.dropped(),
+        effectiveDueDate: effectiveDueDate ? effectiveDueDate.toISOString() : null,
+        effectiveDeferDate: effectiveDeferDate ? effectiveDeferDate.toISOString() : null,
         completed: task.completed(),
         completedDate: completedDate ? completedDate.toISOString() : null
       });
@@ -0,0 +1,39 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    // Template parameter (filled by Go)
+    const perspectiveName = "{{.PerspectiveName}}";
+
+    if (!perspectiveName || perspectiveName === "") {
+      return JSON.stringify({ error: "Perspective name is required" });
+    }
+
+    // Filter rules are only exposed to Omni Automation, so read them from
+    // inside OmniFocus and pass the result back as JSON
+    const omniScript = `(() => {
+      const perspective = Perspective.Custom.byName(${JSON.stringify(perspectiveName)});
+      if (!perspective) {
+        return JSON.stringify({ error: "Perspective not found: " + ${JSON.stringify(perspectiveName)} });
+      }
+      return JSON.stringify({
+        perspective: {
+          name: perspective.name,
+          aggregation: perspective.archivedTopLevelFilterAggregation || "all",
+          rules: perspective.archivedFilterRules || []
+        }
+      });
+    })()`;
+
+    return app.evaluateJavascript(omniScript);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
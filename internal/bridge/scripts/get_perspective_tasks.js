@@ -1,4 +1,7 @@
 (() => {
+  // Folder paths by project ID, filled by folderPath
+  const folderPaths = {};
+
   try {
     const app = Application("OmniFocus");
     app.includeStandardAdditions = true;
@@ -105,6 +108,27 @@
     }
   }
 
+  // Folder names containing a project, outermost first, looked up once per project
+  function folderPath(project) {
+    if (!project) return [];
+    const id = project.id();
+    if (!folderPaths[id]) {
+      const path = [];
+      try {
+        let folder = project.folder();
+        while (folder) {
+          path.unshift(folder.name());
+          const container = folder.container();
+          folder = container && container.class() === "folder" ? container : null;
+        }
+      } catch (e) {
+        // Keep the folders found so far
+      }
+      folderPaths[id] = path;
+    }
+    return folderPaths[id];
+  }
+
   // Helper function to format and add a task to the array
   function addTaskToArray(task, tasks) {
     // Extract tag names from task tags
@@ -122,6 +146,9 @@
     // Convert dates to ISO 8601 format or null
     const dueDate = task.dueDate();
     const deferDate = task.deferDate();
+    const effectiveDueDate = task.effectiveDueDate();
+    const effectiveDeferDate = task.effectiveDeferDate();
+    const repetition = task.repetitionRule();
     const completedDate = task.completionDate();
 
     tasks.push({
@@ -130,10 +157,19 @@
       note: task.note() || "",
       projectID: projectID,
       projectName: projectName,
+      folderPath: folderPath(containingProject),
       tags: tags,
       dueDate: dueDate ? dueDate.toISOString() : null,
       deferDate: deferDate ? deferDate.toISOString() : null,
       flagged: task.flagged(),
+      repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+      estimatedMinutes: task.estimatedMinutes(),
+      modifiedDate: task.modificationDate() ? task.modificationDate().toISOString() : null,
+      blocked: task.blocked(),
+      sequential: task.sequential(),
+      dropped: task.dropped(),
+      effectiveDueDate: effectiveDueDate ? effectiveDueDate.toISOString() : null,
+      effectiveDeferDate: effectiveDeferDate ? effectiveDeferDate.toISOString() : null,
       completed: task.completed(),
       completedDate: completedDate ? completedDate.toISOString() : null
     });
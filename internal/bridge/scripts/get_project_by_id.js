@@ -36,10 +36,23 @@
       projectStatus = "on-hold";
     }
 
+    // Determine how the project's tasks become available
+    const sequential = targetProject.sequential();
+    const containsSingletonActions = targetProject.singletonActionHolder();
+    let projectType = "parallel";
+    if (containsSingletonActions) {
+      projectType = "single-actions";
+    } else if (sequential) {
+      projectType = "sequential";
+    }
+
     const project = {
       id: targetProject.id(),
       name: targetProject.name(),
       status: projectStatus,
+      type: projectType,
+      sequential: sequential,
+      containsSingletonActions: containsSingletonActions,
       note: targetProject.note() || ""
     };
 
@@ -9,6 +9,28 @@
     }
 
     const doc = app.defaultDocument;
+
+    // Folder names containing a project, outermost first, looked up once per project
+    const folderPaths = {};
+    function folderPath(project) {
+      if (!project) return [];
+      const id = project.id();
+      if (!folderPaths[id]) {
+        const path = [];
+        try {
+          let folder = project.folder();
+          while (folder) {
+            path.unshift(folder.name());
+            const container = folder.container();
+            folder = container && container.class() === "folder" ? container : null;
+          }
+        } catch (e) {
+          // Keep the folders found so far
+        }
+        folderPaths[id] = path;
+      }
+      return folderPaths[id];
+    }
     const projectID = "{{.ProjectID}}";
 
     // Find the project by ID
@@ -36,6 +58,16 @@
       projectStatus = "on-hold";
     }
 
+    // Determine how the project's tasks become available
+    const sequential = targetProject.sequential();
+    const containsSingletonActions = targetProject.singletonActionHolder();
+    let projectType = "parallel";
+    if (containsSingletonActions) {
+      projectType = "single-actions";
+    } else if (sequential) {
+      projectType = "sequential";
+    }
+
     // Get all tasks in the project
     const projectTasks = targetProject.flattenedTasks;
     const tasks = [];
@@ -43,6 +75,9 @@
     for (let i = 0; i < projectTasks.length; i++) {
       const task = projectTasks[i];
 
+      // Dropped tasks are hidden, like deleted ones
+      if (task.dropped()) continue;
+
       // Extract tag names from task tags
       const taskTags = task.tags;
       const tags = [];
@@ -53,6 +88,9 @@
       // Convert dates to ISO 8601 format or null
       const dueDate = task.dueDate();
       const deferDate = task.deferDate();
+      const effectiveDueDate = task.effectiveDueDate();
+      const effectiveDeferDate = task.effectiveDeferDate();
+      const repetition = task.repetitionRule();
       const completedDate = task.completionDate();
 
       tasks.push({
@@ -61,10 +99,19 @@
         note: task.note() || "",
         projectID: targetProject.id(),
         projectName: targetProject.name(),
+        folderPath: folderPath(targetProject),
         tags: tags,
         dueDate: dueDate ? dueDate.toISOString() : null,
         deferDate: deferDate ? deferDate.toISOString() : null,
         flagged: task.flagged(),
+        repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+        estimatedMinutes: task.estimatedMinutes(),
+        modifiedDate: task.modificationDate() ? task.modificationDate().toISOString() : null,
+        blocked: task.blocked(),
+        sequential: task.sequential(),
+        dropped: task.dropped(),
+        effectiveDueDate: effectiveDueDate ? effectiveDueDate.toISOString() : null,
+        effectiveDeferDate: effectiveDeferDate ? effectiveDeferDate.toISOString() : null,
         completed: task.completed(),
         completedDate: completedDate ? completedDate.toISOString() : null
       });
@@ -74,6 +121,9 @@
       id: targetProject.id(),
       name: targetProject.name(),
       status: projectStatus,
+      type: projectType,
+      sequential: sequential,
+      containsSingletonActions: containsSingletonActions,
       note: targetProject.note() || "",
       tasks: tasks
     };
@@ -16,6 +16,10 @@
 
     const projects = [];
 
+    // Completion-rate window used for forecasting (keep in sync with stats.ForecastWindowDays)
+    const windowStart = new Date();
+    windowStart.setDate(windowStart.getDate() - 28);
+
     for (let i = 0; i < allProjects.length; i++) {
       const project = allProjects[i];
 
@@ -29,6 +33,16 @@
         projectStatus = "on-hold";
       }
 
+      // Determine how the project's tasks become available
+      const sequential = project.sequential();
+      const containsSingletonActions = project.singletonActionHolder();
+      let projectType = "parallel";
+      if (containsSingletonActions) {
+        projectType = "single-actions";
+      } else if (sequential) {
+        projectType = "sequential";
+      }
+
       // Apply status filter
       if (statusFilter !== "all" && statusFilter !== "" && statusFilter !== projectStatus) {
         continue;
@@ -37,9 +51,15 @@
       // Count tasks in the project
       const tasks = project.flattenedTasks;
       let taskCount = 0;
+      let completedRecently = 0;
       for (let j = 0; j < tasks.length; j++) {
         if (!tasks[j].completed()) {
           taskCount++;
+          continue;
+        }
+        const completionDate = tasks[j].completionDate();
+        if (completionDate && completionDate >= windowStart) {
+          completedRecently++;
         }
       }
 
@@ -47,8 +67,12 @@
         id: project.id(),
         name: project.name(),
         status: projectStatus,
+        type: projectType,
+        sequential: sequential,
+        containsSingletonActions: containsSingletonActions,
         note: project.note() || "",
-        taskCount: taskCount
+        taskCount: taskCount,
+        completedRecently: completedRecently
       });
     }
 
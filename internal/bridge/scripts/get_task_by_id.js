@@ -9,6 +9,28 @@
     }
 
     const doc = app.defaultDocument;
+
+    // Folder names containing a project, outermost first, looked up once per project
+    const folderPaths = {};
+    function folderPath(project) {
+      if (!project) return [];
+      const id = project.id();
+      if (!folderPaths[id]) {
+        const path = [];
+        try {
+          let folder = project.folder();
+          while (folder) {
+            path.unshift(folder.name());
+            const container = folder.container();
+            folder = container && container.class() === "folder" ? container : null;
+          }
+        } catch (e) {
+          // Keep the folders found so far
+        }
+        folderPaths[id] = path;
+      }
+      return folderPaths[id];
+    }
     const taskID = "{{.TaskID}}";
 
     // Find the task by ID
@@ -41,6 +63,9 @@
     // Convert dates to ISO 8601 format or null
     const dueDate = targetTask.dueDate();
     const deferDate = targetTask.deferDate();
+    const effectiveDueDate = targetTask.effectiveDueDate();
+    const effectiveDeferDate = targetTask.effectiveDeferDate();
+    const repetition = targetTask.repetitionRule();
     const completedDate = targetTask.completionDate();
 
     const task = {
@@ -49,10 +74,19 @@
       note: targetTask.note() || "",
       projectID: projectID,
       projectName: projectName,
+      folderPath: folderPath(containingProject),
       tags: tags,
       dueDate: dueDate ? dueDate.toISOString() : null,
       deferDate: deferDate ? deferDate.toISOString() : null,
       flagged: targetTask.flagged(),
+      repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+      estimatedMinutes: targetTask.estimatedMinutes(),
+      modifiedDate: targetTask.modificationDate() ? targetTask.modificationDate().toISOString() : null,
+      blocked: targetTask.blocked(),
+      sequential: targetTask.sequential(),
+      dropped: targetTask.dropped(),
+      effectiveDueDate: effectiveDueDate ? effectiveDueDate.toISOString() : null,
+      effectiveDeferDate: effectiveDeferDate ? effectiveDeferDate.toISOString() : null,
       completed: targetTask.completed(),
       completedDate: completedDate ? completedDate.toISOString() : null
     };
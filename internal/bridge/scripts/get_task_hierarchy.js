@@ -0,0 +1,143 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    const doc = app.defaultDocument;
+
+    // Folder names containing a project, outermost first, looked up once per project
+    const folderPaths = {};
+    function folderPath(project) {
+      if (!project) return [];
+      const id = project.id();
+      if (!folderPaths[id]) {
+        const path = [];
+        try {
+          let folder = project.folder();
+          while (folder) {
+            path.unshift(folder.name());
+            const container = folder.container();
+            folder = container && container.class() === "folder" ? container : null;
+          }
+        } catch (e) {
+          // Keep the folders found so far
+        }
+        folderPaths[id] = path;
+      }
+      return folderPaths[id];
+    }
+
+    // Without a ProjectID parameter the placeholder is left as is and the inbox is used
+    const projectID = "{{.ProjectID}}";
+    const fromInbox = projectID.charAt(0) === "{";
+
+    let topLevelTasks;
+    let projectName = "";
+    let projectFolderPath = [];
+
+    if (fromInbox) {
+      // Inbox tasks include subtasks, keep only those without a parent task
+      topLevelTasks = [];
+      const inboxTasks = doc.inboxTasks;
+      for (let i = 0; i < inboxTasks.length; i++) {
+        if (!inboxTasks[i].parentTask()) {
+          topLevelTasks.push(inboxTasks[i]);
+        }
+      }
+    } else {
+      // Find the project by ID
+      const allProjects = doc.flattenedProjects;
+      let targetProject = null;
+
+      for (let i = 0; i < allProjects.length; i++) {
+        if (allProjects[i].id() === projectID) {
+          targetProject = allProjects[i];
+          break;
+        }
+      }
+
+      if (!targetProject) {
+        return JSON.stringify({ error: `Project not found: ${projectID}` });
+      }
+
+      topLevelTasks = targetProject.tasks;
+      projectName = targetProject.name();
+      projectFolderPath = folderPath(targetProject);
+    }
+
+    // Helper function to build task tree recursively
+    function buildTaskTree(task, parentID) {
+      // Extract tag names from task tags
+      const taskTags = task.tags;
+      const tags = [];
+      for (let j = 0; j < taskTags.length; j++) {
+        tags.push(taskTags[j].name());
+      }
+
+      // Convert dates to ISO 8601 format or null
+      const dueDate = task.dueDate();
+      const deferDate = task.deferDate();
+      const effectiveDueDate = task.effectiveDueDate();
+      const effectiveDeferDate = task.effectiveDeferDate();
+      const repetition = task.repetitionRule();
+      const completedDate = task.completionDate();
+
+      const result = {
+        id: task.id(),
+        name: task.name(),
+        note: task.note() || "",
+        tags: tags,
+        dueDate: dueDate ? dueDate.toISOString() : null,
+        deferDate: deferDate ? deferDate.toISOString() : null,
+        flagged: task.flagged(),
+        repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+        estimatedMinutes: task.estimatedMinutes(),
+        modifiedDate: task.modificationDate() ? task.modificationDate().toISOString() : null,
+        blocked: task.blocked(),
+        sequential: task.sequential(),
+        dropped: task.dropped(),
+        effectiveDueDate: effectiveDueDate ? effectiveDueDate.toISOString() : null,
+        effectiveDeferDate: effectiveDeferDate ? effectiveDeferDate.toISOString() : null,
+        completed: task.completed(),
+        completedDate: completedDate ? completedDate.toISOString() : null,
+        parentId: parentID
+      };
+
+      if (!fromInbox) {
+        result.projectID = projectID;
+        result.projectName = projectName;
+        result.folderPath = projectFolderPath;
+      }
+
+      const childTasks = task.tasks;
+      const children = [];
+      for (let j = 0; j < childTasks.length; j++) {
+        if (childTasks[j].dropped()) continue;
+        children.push(buildTaskTree(childTasks[j], result.id));
+      }
+
+      if (children.length > 0) {
+        result.children = children;
+      }
+
+      return result;
+    }
+
+    const tasks = [];
+    for (let i = 0; i < topLevelTasks.length; i++) {
+      // Dropped tasks are hidden, like deleted ones
+      if (topLevelTasks[i].dropped()) continue;
+      tasks.push(buildTaskTree(topLevelTasks[i], ""));
+    }
+
+    return JSON.stringify({ tasks: tasks }, null, 2);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
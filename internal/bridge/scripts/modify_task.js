@@ -20,6 +20,10 @@
     const dueDateStr = "{{.DueDate}}";
     const deferDateStr = "{{.DeferDate}}";
     const flaggedStr = "{{.Flagged}}";
+    const repeatFrequency = "{{.RepeatFrequency}}";
+    const repeatInterval = "{{.RepeatInterval}}";
+    const repeatMethod = "{{.RepeatMethod}}";
+    const estimatedMinutesStr = "{{.EstimatedMinutes}}";
 
     if (!taskID) {
       return JSON.stringify({ error: "Task ID is required" });
@@ -108,6 +112,41 @@
       }
     }
 
+    // Update repetition if provided
+    if (repeatFrequency) {
+      if (repeatFrequency === "CLEAR") {
+        targetTask.repetitionRule = null;
+      } else {
+        const frequencies = { daily: "DAILY", weekly: "WEEKLY", monthly: "MONTHLY", yearly: "YEARLY" };
+        const methods = {
+          "fixed": "fixed repetition",
+          "due-after-completion": "due after completion",
+          "start-after-completion": "start after completion"
+        };
+        const interval = parseInt(repeatInterval || "1", 10);
+        if (!frequencies[repeatFrequency] || !methods[repeatMethod || "fixed"] || !(interval > 0)) {
+          return JSON.stringify({ error: `Invalid repeat: ${repeatFrequency} ${repeatInterval} ${repeatMethod}` });
+        }
+        targetTask.repetitionRule = {
+          recurrence: `FREQ=${frequencies[repeatFrequency]}` + (interval > 1 ? `;INTERVAL=${interval}` : ""),
+          repetitionMethod: methods[repeatMethod || "fixed"]
+        };
+      }
+    }
+
+    // Update estimated duration if provided
+    if (estimatedMinutesStr) {
+      if (estimatedMinutesStr === "CLEAR") {
+        targetTask.estimatedMinutes = null;
+      } else {
+        const minutes = parseInt(estimatedMinutesStr, 10);
+        if (!(minutes > 0)) {
+          return JSON.stringify({ error: `Invalid estimated minutes: ${estimatedMinutesStr}` });
+        }
+        targetTask.estimatedMinutes = minutes;
+      }
+    }
+
     // Add tags if specified
     // Note: Due to JXA/OmniFocus limitations, we can only set the primary tag
     // The tag must already exist in OmniFocus
@@ -157,6 +196,7 @@
 
     const dueDate = targetTask.dueDate();
     const deferDate = targetTask.deferDate();
+    const repetition = targetTask.repetitionRule();
     const completedDate = targetTask.completionDate();
 
     const result = {
@@ -169,6 +209,9 @@
       dueDate: dueDate ? dueDate.toISOString() : null,
       deferDate: deferDate ? deferDate.toISOString() : null,
       flagged: targetTask.flagged(),
+      repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+      estimatedMinutes: targetTask.estimatedMinutes(),
+      modifiedDate: targetTask.modificationDate() ? targetTask.modificationDate().toISOString() : null,
       completed: targetTask.completed(),
       completedDate: completedDate ? completedDate.toISOString() : null
     };
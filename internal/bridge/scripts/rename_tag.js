@@ -0,0 +1,51 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    const doc = app.defaultDocument;
+
+    // Template parameters (filled by Go)
+    const tagID = "{{.TagID}}";
+    const tagName = "{{.Name}}";
+
+    if (!tagID) {
+      return JSON.stringify({ error: "Tag ID is required" });
+    }
+    if (!tagName) {
+      return JSON.stringify({ error: "Tag name is required" });
+    }
+
+    // Find the tag by ID
+    const allTags = doc.flattenedTags;
+    let targetTag = null;
+
+    for (let i = 0; i < allTags.length; i++) {
+      if (allTags[i].id() === tagID) {
+        targetTag = allTags[i];
+        break;
+      }
+    }
+
+    if (!targetTag) {
+      return JSON.stringify({ error: `Tag not found: ${tagID}` });
+    }
+
+    targetTag.name = tagName;
+
+    const tag = {
+      id: targetTag.id(),
+      name: targetTag.name()
+    };
+
+    return JSON.stringify({ tag: tag }, null, 2);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
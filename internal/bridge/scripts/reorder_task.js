@@ -0,0 +1,72 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    const doc = app.defaultDocument;
+
+    // Template parameters (filled by Go)
+    const taskID = "{{.TaskID}}";
+    const siblingID = "{{.SiblingID}}";
+    const position = "{{.Position}}";
+
+    if (!taskID) {
+      return JSON.stringify({ error: "Task ID is required" });
+    }
+    if (!siblingID) {
+      return JSON.stringify({ error: "Sibling task ID is required" });
+    }
+    if (position !== "before" && position !== "after") {
+      return JSON.stringify({ error: `Invalid position: ${position}` });
+    }
+
+    // Find the task and its sibling by ID
+    const allTasks = doc.flattenedTasks;
+    let targetTask = null;
+    let siblingTask = null;
+
+    for (let i = 0; i < allTasks.length && (!targetTask || !siblingTask); i++) {
+      const id = allTasks[i].id();
+      if (id === taskID) {
+        targetTask = allTasks[i];
+      } else if (id === siblingID) {
+        siblingTask = allTasks[i];
+      }
+    }
+
+    if (!targetTask) {
+      return JSON.stringify({ error: `Task not found: ${taskID}` });
+    }
+    if (!siblingTask) {
+      return JSON.stringify({ error: `Task not found: ${siblingID}` });
+    }
+
+    // Only reorder within the same parent, so the task never changes project
+    const parentID = (task) => {
+      const parent = task.parentTask();
+      return parent ? parent.id() : "";
+    };
+    if (parentID(targetTask) !== parentID(siblingTask)) {
+      return JSON.stringify({ error: "Tasks do not share a parent" });
+    }
+
+    const location = position === "after" ? siblingTask.after : siblingTask.before;
+    app.move(targetTask, { to: location });
+
+    const result = {
+      success: true,
+      id: taskID,
+      message: `Task moved ${position} ${siblingID}`
+    };
+
+    return JSON.stringify(result, null, 2);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
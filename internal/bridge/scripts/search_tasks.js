@@ -0,0 +1,106 @@
+(() => {
+  try {
+    const app = Application("OmniFocus");
+    app.includeStandardAdditions = true;
+
+    // Check if OmniFocus is running
+    if (!app.running()) {
+      return JSON.stringify({ error: "OmniFocus is not running" });
+    }
+
+    const doc = app.defaultDocument;
+
+    // Folder names containing a project, outermost first, looked up once per project
+    const folderPaths = {};
+    function folderPath(project) {
+      if (!project) return [];
+      const id = project.id();
+      if (!folderPaths[id]) {
+        const path = [];
+        try {
+          let folder = project.folder();
+          while (folder) {
+            path.unshift(folder.name());
+            const container = folder.container();
+            folder = container && container.class() === "folder" ? container : null;
+          }
+        } catch (e) {
+          // Keep the folders found so far
+        }
+        folderPaths[id] = path;
+      }
+      return folderPaths[id];
+    }
+
+    // Template parameter (filled by Go)
+    const query = "{{.Query}}".toLowerCase();
+
+    if (!query) {
+      return JSON.stringify({ error: "Search text is required" });
+    }
+
+    const allTasks = doc.flattenedTasks;
+    const tasks = [];
+
+    for (let i = 0; i < allTasks.length; i++) {
+      const task = allTasks[i];
+
+      // Dropped tasks are hidden, like deleted ones
+      if (task.dropped()) continue;
+
+      // Only include remaining tasks whose name or note contains the query
+      if (task.completed()) continue;
+      const name = task.name();
+      const note = task.note() || "";
+      if (name.toLowerCase().indexOf(query) < 0 && note.toLowerCase().indexOf(query) < 0) continue;
+
+      // Extract tag names from task tags
+      const taskTags = task.tags;
+      const tags = [];
+      for (let j = 0; j < taskTags.length; j++) {
+        tags.push(taskTags[j].name());
+      }
+
+      // Get project info if task belongs to a project
+      const containingProject = task.containingProject();
+      const projectID = containingProject ? containingProject.id() : "";
+      const projectName = containingProject ? containingProject.name() : "";
+
+      // Convert dates to ISO 8601 format or null
+      const dueDate = task.dueDate();
+      const deferDate = task.deferDate();
+      const effectiveDueDate = task.effectiveDueDate();
+      const effectiveDeferDate = task.effectiveDeferDate();
+      const repetition = task.repetitionRule();
+      const completedDate = task.completionDate();
+
+      tasks.push({
+        id: task.id(),
+        name: name,
+        note: note,
+        projectID: projectID,
+        projectName: projectName,
+        folderPath: folderPath(containingProject),
+        tags: tags,
+        dueDate: dueDate ? dueDate.toISOString() : null,
+        deferDate: deferDate ? deferDate.toISOString() : null,
+        flagged: task.flagged(),
+        repetitionRule: repetition ? { recurrence: repetition.recurrence, method: String(repetition.repetitionMethod).replace(/ repetition$/, "").replace(/ /g, "-") } : null,
+        estimatedMinutes: task.estimatedMinutes(),
+        modifiedDate: task.modificationDate() ? task.modificationDate().toISOString() : null,
+        blocked: task.blocked(),
+        sequential: task.sequential(),
+        dropped: task.dropped(),
+        effectiveDueDate: effectiveDueDate ? effectiveDueDate.toISOString() : null,
+        effectiveDeferDate: effectiveDeferDate ? effectiveDeferDate.toISOString() : null,
+        completed: task.completed(),
+        completedDate: completedDate ? completedDate.toISOString() : null
+      });
+    }
+
+    return JSON.stringify({ tasks: tasks }, null, 2);
+
+  } catch (e) {
+    return JSON.stringify({ error: e.message });
+  }
+})();
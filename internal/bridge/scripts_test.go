@@ -436,3 +436,18 @@ func TestGetScriptWithParams_ValidatesParameters(t *testing.T) {
 		})
 	}
 }
+
+// TestGetScriptWithParams_MissingParamRendersEmpty tests that optional placeholders without a param are left empty
+func TestGetScriptWithParams_MissingParamRendersEmpty(t *testing.T) {
+	script, err := GetScriptWithParams("create_tag", map[string]string{"Name": "Errands"})
+	if err != nil {
+		t.Fatalf("GetScriptWithParams() error = %v", err)
+	}
+
+	if !strings.Contains(script, `const parentTagID = "";`) {
+		t.Error("GetScriptWithParams() should render a missing param as an empty string")
+	}
+	if strings.Contains(script, "<no value>") {
+		t.Error("GetScriptWithParams() should not render <no value> for a missing param")
+	}
+}
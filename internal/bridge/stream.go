@@ -0,0 +1,103 @@
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// StreamTasks parses a tasks response from r like ParseTasks, but hands each
+// task to yield as soon as it is decoded instead of collecting them, so a
+// large result is never held in full. An error from yield stops parsing and
+// is returned as is.
+func StreamTasks(r io.Reader, yield func(domain.Task) error) error {
+	_, err := StreamTasksPage(r, yield)
+	return err
+}
+
+// StreamTasksPage streams a paginated tasks response like StreamTasks,
+// returning the total number of matching tasks reported by the script
+func StreamTasksPage(r io.Reader, yield func(domain.Task) error) (int, error) {
+	decoder := json.NewDecoder(r)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return 0, fmt.Errorf("failed to parse tasks JSON: %w", err)
+	}
+
+	var total int
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return total, fmt.Errorf("failed to parse tasks JSON: %w", err)
+		}
+
+		switch token {
+		case "tasks":
+			if err := streamArray(decoder, yield); err != nil {
+				return total, err
+			}
+		case "total":
+			if err := decoder.Decode(&total); err != nil {
+				return total, fmt.Errorf("failed to parse tasks JSON: %w", err)
+			}
+		case "error":
+			var message string
+			if err := decoder.Decode(&message); err != nil {
+				return total, fmt.Errorf("failed to parse tasks JSON: %w", err)
+			}
+			if err := checkResponseError(message); err != nil {
+				return total, err
+			}
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return total, fmt.Errorf("failed to parse tasks JSON: %w", err)
+			}
+		}
+	}
+	return total, nil
+}
+
+// streamArray decodes the array of tasks the decoder is at, or null, handing
+// each task to yield
+func streamArray(decoder *json.Decoder, yield func(domain.Task) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("failed to parse tasks JSON: %w", err)
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("failed to parse tasks JSON: tasks is %v, not an array", token)
+	}
+
+	for decoder.More() {
+		var task domain.Task
+		if err := decoder.Decode(&task); err != nil {
+			return fmt.Errorf("failed to parse tasks JSON: %w", err)
+		}
+		if err := yield(task); err != nil {
+			return err
+		}
+	}
+
+	// The closing bracket
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("failed to parse tasks JSON: %w", err)
+	}
+	return nil
+}
+
+// expectDelim reads the next token, failing unless it is delim
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, found %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package bridge
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestStreamTasksPage(t *testing.T) {
+	tests := []struct {
+		name      string
+		json      string
+		wantIDs   []string
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name:    "tasks in order",
+			json:    `{"tasks": [{"id": "a", "name": "One", "flagged": true}, {"id": "b", "name": "Two"}]}`,
+			wantIDs: []string{"a", "b"},
+		},
+		{
+			name:      "page with total after the tasks",
+			json:      `{"tasks": [{"id": "a"}], "total": 3}`,
+			wantIDs:   []string{"a"},
+			wantTotal: 3,
+		},
+		{
+			name: "null tasks",
+			json: `{"tasks": null, "extra": {"ignored": [1, 2]}}`,
+		},
+		{
+			name:    "OmniFocus not running",
+			json:    `{"error": "OmniFocus is not running"}`,
+			wantErr: ErrOmniFocusNotRunning,
+		},
+		{
+			name:    "not found",
+			json:    `{"error": "Project not found: p1"}`,
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			total, err := StreamTasksPage(strings.NewReader(tt.json), func(task domain.Task) error {
+				ids = append(ids, task.ID)
+				return nil
+			})
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("tasks = %v, want %v", ids, tt.wantIDs)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestStreamTasks_MalformedJSON(t *testing.T) {
+	for _, input := range []string{``, `[]`, `{"tasks": {}}`, `{"tasks": [{"id": "a"}, {"id": `} {
+		var yielded int
+		err := StreamTasks(strings.NewReader(input), func(domain.Task) error {
+			yielded++
+			return nil
+		})
+		if err == nil {
+			t.Errorf("StreamTasks(%q) error = nil, want a parse error", input)
+		}
+		if input == `{"tasks": [{"id": "a"}, {"id": ` && yielded != 1 {
+			t.Errorf("StreamTasks(%q) yielded %d tasks before failing, want 1", input, yielded)
+		}
+	}
+}
+
+func TestStreamTasks_StopsOnYieldError(t *testing.T) {
+	stop := errors.New("stop")
+	var yielded int
+	err := StreamTasks(strings.NewReader(`{"tasks": [{"id": "a"}, {"id": "b"}]}`), func(domain.Task) error {
+		yielded++
+		return stop
+	})
+	if err != stop {
+		t.Errorf("error = %v, want the yield error unwrapped", err)
+	}
+	if yielded != 1 {
+		t.Errorf("yielded %d tasks, want 1", yielded)
+	}
+}
@@ -0,0 +1,406 @@
+// Package bulkedit writes tasks as a tab-separated table for editing in a
+// text editor, and turns the edited table back into task changes.
+package bulkedit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// DateLayout is how due dates are written in the table, in local time
+const DateLayout = "2006-01-02 15:04"
+
+// doneMark marks a completed task in the done column
+const doneMark = "x"
+
+// columns names the table's columns, in order
+var columns = []string{"id", "done", "name", "due", "tags"}
+
+// header explains the table to the person editing it
+const header = `# Edit the name, due and tags columns, or put x in done to complete a task.
+# Due dates are YYYY-MM-DD HH:MM or anything lazyfocus add understands, such
+# as "friday"; tags are separated by commas. Clear a cell to clear the field.
+# Columns are separated by tabs. Keep the ids; removed rows and lines starting
+# with # are ignored.
+`
+
+// Query selects the tasks to edit. Its terms are all required to match.
+type Query struct {
+	Project string // Project name or ID
+	Tag     string // Tag name
+	Inbox   bool   // Only tasks without a project
+	Flagged bool
+	Due     *dateparse.Range
+	Words   []string // Must all appear in the task name, ignoring case
+}
+
+// ParseQuery parses a query such as `project:Work tag:"On hold" flagged
+// due:friday report`. Values with spaces are quoted; other words search task
+// names.
+func ParseQuery(s string) (Query, error) {
+	terms, err := splitTerms(s)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	for _, term := range terms {
+		key, value, hasValue := strings.Cut(term, ":")
+		switch {
+		case hasValue && strings.EqualFold(key, "project"):
+			q.Project = value
+		case hasValue && strings.EqualFold(key, "tag"):
+			q.Tag = value
+		case hasValue && strings.EqualFold(key, "due"):
+			r, err := dateparse.ParseRange(value)
+			if err != nil {
+				return Query{}, fmt.Errorf("invalid due in query: %w", err)
+			}
+			q.Due = &r
+		case strings.EqualFold(term, "flagged"):
+			q.Flagged = true
+		case strings.EqualFold(term, "inbox"):
+			q.Inbox = true
+		default:
+			q.Words = append(q.Words, strings.ToLower(term))
+		}
+	}
+	if q.Inbox && q.Project != "" {
+		return Query{}, fmt.Errorf("query cannot combine inbox and project:")
+	}
+	return q, nil
+}
+
+// splitTerms splits s at spaces outside double quotes, dropping the quotes
+func splitTerms(s string) ([]string, error) {
+	var terms []string
+	var term strings.Builder
+	quoted, started := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case r == ' ' && !quoted:
+			if started {
+				terms = append(terms, term.String())
+				term.Reset()
+				started = false
+			}
+		default:
+			term.WriteRune(r)
+			started = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in query: %s", s)
+	}
+	if started {
+		terms = append(terms, term.String())
+	}
+	return terms, nil
+}
+
+// Matches reports whether a remaining task matches every term of the query
+func (q Query) Matches(task domain.Task) bool {
+	if task.Completed {
+		return false
+	}
+	if q.Project != "" && task.ProjectID != q.Project && !strings.EqualFold(task.ProjectName, q.Project) {
+		return false
+	}
+	if q.Inbox && task.ProjectID != "" {
+		return false
+	}
+	if q.Tag != "" && !hasTag(task.Tags, q.Tag) {
+		return false
+	}
+	if q.Flagged && !task.Flagged {
+		return false
+	}
+	if q.Due != nil && (task.DueDate == nil || !q.Due.Contains(*task.DueDate)) {
+		return false
+	}
+	name := strings.ToLower(task.Name)
+	for _, word := range q.Words {
+		if !strings.Contains(name, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasTag reports whether tags contains tag, ignoring case
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Row is one edited line of the table
+type Row struct {
+	Line int // 1-based line number, for error messages
+	ID   string
+	Done string
+	Name string
+	Due  string
+	Tags string
+}
+
+// Format writes tasks as the editable table
+func Format(tasks []domain.Task) string {
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString("# " + strings.Join(columns, "\t") + "\n")
+	for _, task := range tasks {
+		b.WriteString(strings.Join(cells(task), "\t"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// cells returns a task's cells as Format writes them
+func cells(task domain.Task) []string {
+	done := ""
+	if task.Completed {
+		done = doneMark
+	}
+	due := ""
+	if task.DueDate != nil {
+		due = task.DueDate.Local().Format(DateLayout)
+	}
+	return []string{task.ID, done, cell(task.Name), due, cell(strings.Join(task.Tags, ", "))}
+}
+
+// cell keeps a value on its line and in its column, trimmed as Parse trims it
+func cell(s string) string {
+	return strings.TrimSpace(strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s))
+}
+
+// Parse reads the edited table, skipping comments and blank lines
+func Parse(text string) ([]Row, error) {
+	var rows []Row
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != len(columns) {
+			return nil, fmt.Errorf("line %d: found %d columns, want %d (%s) separated by tabs", i+1, len(fields), len(columns), strings.Join(columns, ", "))
+		}
+		rows = append(rows, Row{
+			Line: i + 1,
+			ID:   strings.TrimSpace(fields[0]),
+			Done: strings.TrimSpace(fields[1]),
+			Name: strings.TrimSpace(fields[2]),
+			Due:  strings.TrimSpace(fields[3]),
+			Tags: strings.TrimSpace(fields[4]),
+		})
+	}
+	return rows, nil
+}
+
+// Change is what an edited row changes about a task
+type Change struct {
+	Task         domain.Task
+	Modification domain.TaskModification
+	Complete     bool
+}
+
+// Describe lists the change for the preview, e.g. `"Buy milk": due 2024-01-20 17:00, +tag errands`
+func (c Change) Describe() string {
+	mod := c.Modification
+	var parts []string
+	if mod.Name != nil {
+		parts = append(parts, fmt.Sprintf("rename to %q", *mod.Name))
+	}
+	if mod.ClearDue {
+		parts = append(parts, "clear due")
+	} else if mod.DueDate != nil {
+		parts = append(parts, "due "+mod.DueDate.Local().Format(DateLayout))
+	}
+	for _, tag := range mod.AddTags {
+		parts = append(parts, "+tag "+tag)
+	}
+	for _, tag := range mod.RemoveTags {
+		parts = append(parts, "-tag "+tag)
+	}
+	if c.Complete {
+		parts = append(parts, "complete")
+	}
+	return fmt.Sprintf("%q: %s", c.Task.Name, strings.Join(parts, ", "))
+}
+
+// Diff compares the edited rows with the tasks the table was made from and
+// returns the changes, in row order. Rows left as they were are skipped;
+// rows naming tasks that were not in the table are errors.
+func Diff(tasks []domain.Task, rows []Row, now time.Time) ([]Change, error) {
+	byID := make(map[string]domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	seen := make(map[string]bool, len(rows))
+	var changes []Change
+	for _, row := range rows {
+		task, ok := byID[row.ID]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown task ID %q", row.Line, row.ID)
+		}
+		if seen[row.ID] {
+			return nil, fmt.Errorf("line %d: task %s is listed twice", row.Line, row.ID)
+		}
+		seen[row.ID] = true
+
+		change, err := diffRow(task, row, now)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", row.Line, err)
+		}
+		if change.Complete || !change.Modification.IsEmpty() {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// diffRow compares one row with the cells Format wrote for its task
+func diffRow(task domain.Task, row Row, now time.Time) (Change, error) {
+	was := cells(task)
+	change := Change{Task: task}
+	mod := &change.Modification
+
+	switch {
+	case row.Done == was[1]:
+	case strings.EqualFold(row.Done, doneMark):
+		change.Complete = true
+	case row.Done == "":
+		return Change{}, fmt.Errorf("reopening tasks is not supported; use lazyfocus uncomplete")
+	default:
+		return Change{}, fmt.Errorf("done must be empty or %s, got %q", doneMark, row.Done)
+	}
+
+	if row.Name != was[2] {
+		if row.Name == "" {
+			return Change{}, fmt.Errorf("name cannot be empty")
+		}
+		mod.Name = &row.Name
+	}
+
+	if row.Due != was[3] {
+		if row.Due == "" {
+			mod.ClearDue = true
+		} else {
+			due, err := parseDue(row.Due, now)
+			if err != nil {
+				return Change{}, err
+			}
+			mod.DueDate = &due
+		}
+	}
+
+	if row.Tags != was[4] {
+		mod.AddTags, mod.RemoveTags = diffTags(task.Tags, splitTags(row.Tags))
+	}
+	return change, nil
+}
+
+// parseDue reads a due date in DateLayout or any form dateparse understands
+func parseDue(value string, now time.Time) (time.Time, error) {
+	if due, err := time.ParseInLocation(DateLayout, value, time.Local); err == nil {
+		return due, nil
+	}
+	due, err := dateparse.ParseWithReference(value, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due date: %w", err)
+	}
+	return due, nil
+}
+
+// splitTags splits a comma-separated tags cell
+func splitTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// diffTags returns the tags to add and remove to turn before into after,
+// ignoring case
+func diffTags(before, after []string) (add, remove []string) {
+	for _, tag := range after {
+		if !hasTag(before, tag) && !hasTag(add, tag) {
+			add = append(add, tag)
+		}
+	}
+	for _, tag := range before {
+		if !hasTag(after, tag) {
+			remove = append(remove, tag)
+		}
+	}
+	return add, remove
+}
+
+// Batch is one operation applied to several tasks at once
+type Batch struct {
+	Operation domain.BatchOperation
+	Tasks     []domain.Task
+}
+
+// IDs returns the IDs of the batch's tasks
+func (b Batch) IDs() []string {
+	ids := make([]string, len(b.Tasks))
+	for i, task := range b.Tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+// Batches groups changes into as few batch operations as possible: tasks
+// getting the same modification share a batch, and completions come last so
+// a task is renamed or rescheduled before it is completed.
+func Batches(changes []Change) []Batch {
+	var batches []Batch
+	byKey := make(map[string]int)
+	var complete []domain.Task
+	for _, change := range changes {
+		if change.Complete {
+			complete = append(complete, change.Task)
+		}
+		if change.Modification.IsEmpty() {
+			continue
+		}
+		key := modificationKey(change.Modification)
+		if i, ok := byKey[key]; ok {
+			batches[i].Tasks = append(batches[i].Tasks, change.Task)
+			continue
+		}
+		byKey[key] = len(batches)
+		batches = append(batches, Batch{
+			Operation: domain.BatchOperation{Action: domain.BatchModify, Modification: change.Modification},
+			Tasks:     []domain.Task{change.Task},
+		})
+	}
+	if len(complete) > 0 {
+		batches = append(batches, Batch{Operation: domain.BatchOperation{Action: domain.BatchComplete}, Tasks: complete})
+	}
+	return batches
+}
+
+// modificationKey identifies equal modifications by their values rather
+// than their pointers
+func modificationKey(mod domain.TaskModification) string {
+	data, _ := json.Marshal(mod)
+	return string(data)
+}
@@ -0,0 +1,182 @@
+package bulkedit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery(`project:"Home Office" tag:errands flagged Buy MILK`)
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+	want := Query{Project: "Home Office", Tag: "errands", Flagged: true, Words: []string{"buy", "milk"}}
+	if !reflect.DeepEqual(q, want) {
+		t.Errorf("ParseQuery() = %+v, want %+v", q, want)
+	}
+
+	for _, input := range []string{`project:"Work`, "due:someday", "inbox project:Work"} {
+		if _, err := ParseQuery(input); err == nil {
+			t.Errorf("ParseQuery(%q) error = nil, want an error", input)
+		}
+	}
+}
+
+func TestQuery_Matches(t *testing.T) {
+	task := domain.Task{ID: "t1", Name: "Buy milk", ProjectID: "p1", ProjectName: "Home", Tags: []string{"Errands"}}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"project:home", true},
+		{"project:p1 tag:errands milk", true},
+		{"project:Work", false},
+		{"inbox", false},
+		{"flagged", false},
+		{"bread", false},
+		{"", true},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error = %v", tt.query, err)
+		}
+		if got := q.Matches(task); got != tt.want {
+			t.Errorf("%q matches = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	done := task
+	done.Completed = true
+	if (Query{}).Matches(done) {
+		t.Error("completed tasks should never match")
+	}
+}
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	due := time.Date(2024, 1, 20, 17, 0, 0, 0, time.Local)
+	tasks := []domain.Task{
+		{ID: "t1", Name: "Buy milk", DueDate: &due, Tags: []string{"errands", "home"}},
+		{ID: "t2", Name: "Call\tBob"},
+	}
+
+	rows, err := Parse(Format(tasks))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	changes, err := Diff(tasks, rows, due)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("an unedited table should change nothing, got %+v", changes)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.Local)
+	due := time.Date(2024, 1, 20, 17, 0, 0, 0, time.Local)
+	tasks := []domain.Task{
+		{ID: "t1", Name: "Buy milk", DueDate: &due, Tags: []string{"errands", "home"}},
+		{ID: "t2", Name: "Call Bob"},
+		{ID: "t3", Name: "Pay rent", DueDate: &due},
+	}
+	edited := strings.Join([]string{
+		"# comment",
+		"t1\t\tBuy oat milk\t2024-01-22 09:30\terrands, shop",
+		"t2\tx\tCall Bob\t\t",
+		"t3\t\tPay rent\t\t",
+	}, "\n")
+
+	rows, err := Parse(edited)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	changes, err := Diff(tasks, rows, now)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("Diff() = %d changes, want 3", len(changes))
+	}
+
+	mod := changes[0].Modification
+	if mod.Name == nil || *mod.Name != "Buy oat milk" {
+		t.Errorf("rename = %v, want Buy oat milk", mod.Name)
+	}
+	if want := time.Date(2024, 1, 22, 9, 30, 0, 0, time.Local); mod.DueDate == nil || !mod.DueDate.Equal(want) {
+		t.Errorf("due = %v, want %v", mod.DueDate, want)
+	}
+	if !reflect.DeepEqual(mod.AddTags, []string{"shop"}) || !reflect.DeepEqual(mod.RemoveTags, []string{"home"}) {
+		t.Errorf("tags = +%v -%v, want +[shop] -[home]", mod.AddTags, mod.RemoveTags)
+	}
+	if !changes[1].Complete || !changes[1].Modification.IsEmpty() {
+		t.Errorf("second change = %+v, want only a completion", changes[1])
+	}
+	if !changes[2].Modification.ClearDue {
+		t.Errorf("third change = %+v, want the due date cleared", changes[2])
+	}
+	if got := changes[0].Describe(); got != `"Buy milk": rename to "Buy oat milk", due 2024-01-22 09:30, +tag shop, -tag home` {
+		t.Errorf("Describe() = %q", got)
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	tasks := []domain.Task{{ID: "t1", Name: "Buy milk"}}
+	tests := []struct {
+		name  string
+		table string
+		want  string
+	}{
+		{"unknown ID", "t9\t\tBuy milk\t\t", "unknown task ID"},
+		{"listed twice", "t1\t\tBuy milk\t\t\nt1\t\tBuy milk\t\t", "listed twice"},
+		{"empty name", "t1\t\t\t\t", "name cannot be empty"},
+		{"bad due", "t1\t\tBuy milk\tsomeday\t", "invalid due date"},
+		{"bad done", "t1\tyes\tBuy milk\t\t", "done must be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(tt.table)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if _, err := Diff(tasks, rows, time.Now()); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Diff() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Parse("t1\tBuy milk"); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Parse() with missing columns error = %v, want the line number", err)
+	}
+}
+
+func TestBatches(t *testing.T) {
+	due := time.Date(2024, 1, 22, 17, 0, 0, 0, time.Local)
+	sameDue := due
+	name := "Renamed"
+	changes := []Change{
+		{Task: domain.Task{ID: "t1"}, Modification: domain.TaskModification{DueDate: &due}},
+		{Task: domain.Task{ID: "t2"}, Modification: domain.TaskModification{Name: &name}, Complete: true},
+		{Task: domain.Task{ID: "t3"}, Modification: domain.TaskModification{DueDate: &sameDue}},
+		{Task: domain.Task{ID: "t4"}, Complete: true},
+	}
+
+	batches := Batches(changes)
+	if len(batches) != 3 {
+		t.Fatalf("Batches() = %d batches, want 3", len(batches))
+	}
+	if got := batches[0].IDs(); !reflect.DeepEqual(got, []string{"t1", "t3"}) {
+		t.Errorf("first batch = %v, want the tasks sharing a due date", got)
+	}
+	if got := batches[1].IDs(); !reflect.DeepEqual(got, []string{"t2"}) || batches[1].Operation.Action != domain.BatchModify {
+		t.Errorf("second batch = %v %s, want the rename", got, batches[1].Operation.Action)
+	}
+	if got := batches[2].IDs(); !reflect.DeepEqual(got, []string{"t2", "t4"}) || batches[2].Operation.Action != domain.BatchComplete {
+		t.Errorf("last batch = %v %s, want the completions", got, batches[2].Operation.Action)
+	}
+}
@@ -2,7 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/taskparse"
@@ -10,6 +13,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// historyNumberPattern matches an optional "Label:" prefix followed by the
+// event number that `history 1` prints before the command. History pads the
+// number with two spaces, or "* " for an edited entry, so a title such as
+// "Fix: 2 tests failing" is left alone.
+var historyNumberPattern = regexp.MustCompile(`^([^:]*:)?\s*\d+(?:\* |  )\s*(.+)$`)
+
 // NewAddCommand creates the add command
 func NewAddCommand() *cobra.Command {
 	var (
@@ -35,15 +44,19 @@ Natural syntax in description:
 
 Command-line flags override natural syntax when both are present.
 
+With --from-exit the description is taken literally (no natural syntax) as a
+follow-up for a failed shell command: the history number printed by
+"history 1" is removed and the command, exit code, working directory and time
+are recorded in the note.
+
 Note: Due to OmniFocus automation limitations, only the first tag specified
 will be applied to the task (as the primary tag). Multiple tags can be
-specified but only the first will be used.
-
-Examples:
-  lazyfocus add "Buy milk #groceries"
+specified but only the first will be used.`,
+		Example: `  lazyfocus add "Buy milk #groceries"
   lazyfocus add "Call dentist" --due tomorrow
   lazyfocus add "Review PR @Work due:friday !"
-  lazyfocus add "Meeting prep" --project Work --flagged --note "Prepare slides"`,
+  lazyfocus add "Meeting prep" --project Work --flagged --note "Prepare slides"
+  make build || lazyfocus add --from-exit --exit-code $? "Fix: $(history 1)"`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runAdd(cmd, args, projectFlag, tagFlags, dueFlag, deferFlag, flaggedFlag, noteFlag)
@@ -56,21 +69,43 @@ Examples:
 	cmd.Flags().StringVar(&deferFlag, "defer", "", "Defer date")
 	cmd.Flags().BoolVarP(&flaggedFlag, "flagged", "f", false, "Mark flagged")
 	cmd.Flags().StringVarP(&noteFlag, "note", "n", "", "Task note")
+	cmd.Flags().Bool("from-exit", false, "Create a follow-up for a failed shell command")
+	cmd.Flags().Int("exit-code", 0, "Exit code of the failed command (with --from-exit)")
 
 	return cmd
 }
 
 func runAdd(cmd *cobra.Command, args []string, projectFlag string, tagFlags []string, dueFlag, deferFlag string, flaggedFlag bool, noteFlag string) error {
+	ctx := cmd.Context()
+
 	// Combine all args into a single task description
 	taskDescription := strings.Join(args, " ")
 
-	// Parse the task description with natural syntax
-	taskInput, err := taskparse.Parse(taskDescription)
-	if err != nil {
-		return handleError(cmd, fmt.Errorf("failed to parse task: %w", err))
+	var taskInput domain.TaskInput
+	fromExit, _ := cmd.Flags().GetBool("from-exit")
+	if fromExit {
+		// Shell commands often contain #, @ or !, so skip natural syntax
+		name, command := splitHistoryLine(taskDescription)
+		taskInput.Name = name
+		exitCode := -1
+		if cmd.Flags().Changed("exit-code") {
+			exitCode, _ = cmd.Flags().GetInt("exit-code")
+		}
+		cwd, _ := os.Getwd()
+		taskInput.Note = exitNote(command, exitCode, cwd, time.Now())
+	} else {
+		// Parse the task description with natural syntax
+		var err error
+		taskInput, err = taskparse.Parse(taskDescription)
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to parse task: %w", err))
+		}
 	}
 
 	// Apply command-line flags (flags take precedence over natural syntax)
+	if fromExit && noteFlag != "" {
+		noteFlag += "\n\n" + taskInput.Note
+	}
 	if err := applyAddFlags(cmd, &taskInput, projectFlag, tagFlags, dueFlag, deferFlag, flaggedFlag, noteFlag); err != nil {
 		return handleError(cmd, err)
 	}
@@ -83,7 +118,7 @@ func runAdd(cmd *cobra.Command, args []string, projectFlag string, tagFlags []st
 
 	// Resolve project name to ID if needed
 	if taskInput.ProjectName != "" && taskInput.ProjectID == "" {
-		projectID, err := svc.ResolveProjectName(taskInput.ProjectName)
+		projectID, err := svc.ResolveProjectName(ctx, taskInput.ProjectName)
 		if err != nil {
 			return handleError(cmd, fmt.Errorf("failed to resolve project: %w", err))
 		}
@@ -91,7 +126,7 @@ func runAdd(cmd *cobra.Command, args []string, projectFlag string, tagFlags []st
 	}
 
 	// Create the task
-	task, err := svc.CreateTask(taskInput)
+	task, err := svc.CreateTask(ctx, taskInput)
 	if err != nil {
 		return handleError(cmd, fmt.Errorf("failed to create task: %w", err))
 	}
@@ -102,10 +137,7 @@ func runAdd(cmd *cobra.Command, args []string, projectFlag string, tagFlags []st
 	}
 
 	formatter := getFormatter()
-	outputStr := formatter.FormatCreatedTask(*task)
-	cmd.Print(outputStr)
-
-	return nil
+	return formatter.FormatCreatedTask(ctx, cmd.OutOrStdout(), *task)
 }
 
 // applyAddFlags applies command-line flags to TaskInput, overriding natural syntax values.
@@ -126,7 +158,7 @@ func applyAddFlags(cmd *cobra.Command, taskInput *domain.TaskInput, projectFlag
 	if dueFlag != "" {
 		dueDate, err := dateparse.Parse(dueFlag)
 		if err != nil {
-			return fmt.Errorf("invalid due date: %w", err)
+			return invalidInput("invalid due date: %w", err)
 		}
 		taskInput.DueDate = &dueDate
 	}
@@ -134,7 +166,7 @@ func applyAddFlags(cmd *cobra.Command, taskInput *domain.TaskInput, projectFlag
 	if deferFlag != "" {
 		deferDate, err := dateparse.Parse(deferFlag)
 		if err != nil {
-			return fmt.Errorf("invalid defer date: %w", err)
+			return invalidInput("invalid defer date: %w", err)
 		}
 		taskInput.DeferDate = &deferDate
 	}
@@ -146,3 +178,35 @@ func applyAddFlags(cmd *cobra.Command, taskInput *domain.TaskInput, projectFlag
 
 	return nil
 }
+
+// splitHistoryLine removes the history event number from a task description,
+// returning the cleaned name and the command it refers to
+func splitHistoryLine(description string) (name, command string) {
+	description = strings.TrimSpace(description)
+	m := historyNumberPattern.FindStringSubmatch(description)
+	if m == nil {
+		_, command, found := strings.Cut(description, ":")
+		if !found {
+			command = description
+		}
+		return description, strings.TrimSpace(command)
+	}
+	command = strings.TrimSpace(m[2])
+	if m[1] == "" {
+		return command, command
+	}
+	return m[1] + " " + command, command
+}
+
+// exitNote describes a failed command; a negative exit code is omitted
+func exitNote(command string, exitCode int, dir string, now time.Time) string {
+	lines := []string{"Command: " + command}
+	if exitCode >= 0 {
+		lines = append(lines, fmt.Sprintf("Exit code: %d", exitCode))
+	}
+	if dir != "" {
+		lines = append(lines, "Directory: "+dir)
+	}
+	lines = append(lines, "Captured: "+now.Format("2006-01-02 15:04"))
+	return strings.Join(lines, "\n")
+}
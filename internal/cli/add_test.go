@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
 )
 
 func TestAddCommand_BasicTask(t *testing.T) {
@@ -393,6 +396,55 @@ func TestAddCommand_InvalidDeferDate(t *testing.T) {
 	}
 }
 
+func TestAddCommand_FromExit(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		CreatedTask: &domain.Task{ID: "task1", Name: "Fix: make build #ci"},
+	}
+
+	_, _, err := executeAddCommand(mockService, []string{"--from-exit", "--exit-code", "2", "--note", "Broke after rebase", "Fix:  512  make build #ci"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	input := mockService.CreateInput
+	if input == nil {
+		t.Fatal("Expected CreateTask to be called")
+	}
+	if input.Name != "Fix: make build #ci" {
+		t.Errorf("Name = %q, want history number removed and #ci kept literally", input.Name)
+	}
+	if len(input.TagNames) != 0 {
+		t.Errorf("TagNames = %v, want none (natural syntax skipped)", input.TagNames)
+	}
+	for _, want := range []string{"Broke after rebase\n\n", "Command: make build #ci", "Exit code: 2", "Directory: ", "Captured: "} {
+		if !strings.Contains(input.Note, want) {
+			t.Errorf("Note = %q, want it to contain %q", input.Note, want)
+		}
+	}
+}
+
+func TestSplitHistoryLine(t *testing.T) {
+	tests := []struct {
+		description string
+		wantName    string
+		wantCommand string
+	}{
+		{"Fix:   512  go test ./...", "Fix: go test ./...", "go test ./..."},
+		{"  88* make", "make", "make"},
+		{"Fix: make build", "Fix: make build", "make build"},
+		{"make build", "make build", "make build"},
+		{"Fix: 2 tests failing", "Fix: 2 tests failing", "2 tests failing"},
+		{"3 retries left", "3 retries left", "3 retries left"},
+	}
+
+	for _, tt := range tests {
+		name, command := splitHistoryLine(tt.description)
+		if name != tt.wantName || command != tt.wantCommand {
+			t.Errorf("splitHistoryLine(%q) = %q, %q, want %q, %q", tt.description, name, command, tt.wantName, tt.wantCommand)
+		}
+	}
+}
+
 // Helper function to execute add command and capture output
 func executeAddCommand(mockService service.OmniFocusService, args []string) (string, int, error) {
 	// Create a new root command for each test to avoid flag pollution
@@ -422,3 +474,35 @@ func executeAddCommand(mockService service.OmniFocusService, args []string) (str
 
 	return output, exitCode, err
 }
+
+func TestAddCommand_RuleTagsCountTowardsLimits(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configContent := `rules:
+  - name: Calls
+    match:
+      name: call
+    actions:
+      add_tags: [phone]
+limits:
+  - tag: phone
+    max: 1
+    block: true
+`
+	if err := os.WriteFile(filepath.Join(home, ".lazyfocus.yaml"), []byte(configContent), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	mockService := &service.MockOmniFocusService{
+		AllTasks:    []domain.Task{{ID: "t1", Name: "Call the bank", Tags: []string{"phone"}}},
+		CreatedTask: &domain.Task{ID: "new", Name: "Call mom"},
+	}
+
+	_, _, err := executeAddCommand(mockService, []string{"Call mom"})
+
+	if !errors.Is(err, limits.ErrLimitReached) {
+		t.Errorf("Expected the tag added by the rule to hit the blocking limit, got: %v", err)
+	}
+	if mockService.CreateInput != nil {
+		t.Errorf("Expected no task to be created, got %+v", mockService.CreateInput)
+	}
+}
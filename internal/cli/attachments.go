@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// attachmentBudgetBytes is how much file data one script call returns; base64
+// makes it about 21 MB of output, under the default 32 MB payload limit
+const attachmentBudgetBytes = 16 << 20
+
+// attachmentOverheadBytes is the room left in a script's output for the
+// JSON describing the attachments around the file data
+const attachmentOverheadBytes = 1 << 20
+
+// defaultAttachmentMaxSizeMB is the default --max-size; a file that large
+// still fits one script call under the default payload limit
+const defaultAttachmentMaxSizeMB = 20
+
+// manifestFile is the name of the manifest written next to exported files
+const manifestFile = "manifest.json"
+
+// maxFileNameBytes keeps exported file names under the 255-byte limit of
+// common file systems, with room for a " (2)" suffix
+const maxFileNameBytes = 200
+
+// NewAttachmentsCommand creates the attachments command
+func NewAttachmentsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attachments",
+		Short: "Work with files attached to tasks",
+		Long: `Work with the files attached to OmniFocus tasks.
+
+Attachments are read through Omni Automation, so OmniFocus must be running.`,
+	}
+
+	cmd.AddCommand(newAttachmentsExportCommand())
+
+	return cmd
+}
+
+// newAttachmentsExportCommand creates the attachments export subcommand
+func newAttachmentsExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Save the attachments of a project's tasks to a folder",
+		Long: `Save the files attached to the tasks of a project to a folder, with a
+manifest.json listing each file, the task it came from and any file left out.
+
+File names are made safe for the file system and numbered when two tasks
+attach files with the same name. Files larger than --max-size are listed in
+the manifest but not saved; --max-size can be at most what one OmniFocus
+script returns (23 MB with the default max_payload_mb). Existing files of an
+earlier export are replaced.`,
+		Example: `  lazyfocus attachments export --project "Home Renovation" --dir ./out
+  lazyfocus attachments export --project Taxes --dir ~/taxes --max-size 10`,
+		Args: cobra.NoArgs,
+		RunE: runAttachmentsExport,
+	}
+
+	cmd.Flags().String("project", "", "Project whose task attachments are exported (required)")
+	cmd.Flags().String("dir", "", "Folder to write the files and manifest to (required)")
+	cmd.Flags().Int64("max-size", defaultAttachmentMaxSizeMB, "Largest file to export, in MB")
+
+	return cmd
+}
+
+// attachmentManifest is the JSON shape of manifest.json and of the command's
+// JSON output
+type attachmentManifest struct {
+	Project    string                `json:"project"`
+	Dir        string                `json:"dir"`
+	ExportedAt time.Time             `json:"exportedAt"`
+	Files      []attachmentFileEntry `json:"files"`
+}
+
+// attachmentFileEntry describes one attachment in the manifest
+type attachmentFileEntry struct {
+	TaskID   string `json:"taskId"`
+	TaskName string `json:"taskName"`
+	Name     string `json:"name"`
+	File     string `json:"file,omitempty"` // Name written in the folder; empty when skipped
+	Size     int64  `json:"size"`
+	Skipped  string `json:"skipped,omitempty"`
+}
+
+func runAttachmentsExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	projectName, _ := cmd.Flags().GetString("project")
+	dir, _ := cmd.Flags().GetString("dir")
+	maxSizeMB, _ := cmd.Flags().GetInt64("max-size")
+	if projectName == "" || dir == "" {
+		return handleError(cmd, errors.New("--project and --dir are required"))
+	}
+	if maxSizeMB <= 0 {
+		return handleError(cmd, invalidInput("invalid --max-size %d: must be at least 1", maxSizeMB))
+	}
+	limit := attachmentLimitBytes(cmd)
+	if maxSizeMB<<20 > limit {
+		return handleError(cmd, invalidInput("invalid --max-size %d: one script call returns files of at most %d MB", maxSizeMB, limit>>20))
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	projectID, err := svc.ResolveProjectName(ctx, projectName)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	attachments, err := fetchAttachments(ctx, svc, projectID, maxSizeMB<<20, min(attachmentBudgetBytes, limit))
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return handleError(cmd, fmt.Errorf("failed to create export folder: %w", err))
+	}
+	manifest := attachmentManifest{Project: projectName, Dir: dir, ExportedAt: time.Now(), Files: []attachmentFileEntry{}}
+	// An attachment named like the manifest gets numbered instead
+	used := map[string]bool{manifestFile: true}
+	for _, a := range attachments {
+		entry := attachmentFileEntry{TaskID: a.TaskID, TaskName: a.TaskName, Name: a.Name, Size: a.Size, Skipped: a.Skipped}
+		if a.Skipped == "" {
+			entry.File = uniqueFileName(sanitizeFileName(a.Name), used)
+			if err := os.WriteFile(filepath.Join(dir, entry.File), a.Data, 0o644); err != nil {
+				return handleError(cmd, fmt.Errorf("failed to write %s: %w", entry.File, err))
+			}
+		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to encode manifest: %w", err))
+	}
+	if err := os.WriteFile(filepath.Join(dir, manifestFile), append(data, '\n'), 0o644); err != nil {
+		return handleError(cmd, fmt.Errorf("failed to write manifest: %w", err))
+	}
+
+	if GetQuietFlag() {
+		return nil
+	}
+	if GetJSONFlag() {
+		cmd.Println(string(data))
+		return nil
+	}
+
+	saved, skipped := 0, 0
+	var bytes int64
+	for _, entry := range manifest.Files {
+		if entry.Skipped != "" {
+			skipped++
+			continue
+		}
+		saved++
+		bytes += entry.Size
+	}
+	cmd.Printf("✓ Exported %d attachments (%s) from %s to %s\n", saved, formatBytes(bytes), projectName, dir)
+	for _, entry := range manifest.Files {
+		if entry.Skipped != "" {
+			cmd.Printf("  skipped %s from %q: %s\n", entry.Name, entry.TaskName, skipReason(entry.Skipped))
+		}
+	}
+	if skipped > 0 {
+		cmd.Printf("%d attachments skipped; see %s\n", skipped, filepath.Join(dir, manifestFile))
+	}
+	return nil
+}
+
+// attachmentLimitBytes returns the largest file one script call can return:
+// the configured payload limit less the growth of base64 and the JSON around
+// the data. Each call returns at least one file, however large.
+func attachmentLimitBytes(cmd *cobra.Command) int64 {
+	payload := bridge.DefaultMaxPayloadBytes
+	if cfg, err := config.FromContext(cmd.Context()); err == nil && cfg.MaxPayloadMB > 0 {
+		payload = cfg.MaxPayloadBytes()
+	}
+	return payload*3/4 - attachmentOverheadBytes
+}
+
+// fetchAttachments loads every attachment of a project, in as many script
+// calls as budgetBytes of file data needs; files past maxFileBytes or not
+// files at all come without data
+func fetchAttachments(ctx context.Context, svc service.OmniFocusService, projectID string, maxFileBytes, budgetBytes int64) ([]domain.Attachment, error) {
+	var all []domain.Attachment
+	offset := 0
+	for {
+		batch, err := svc.GetAttachments(ctx, projectID, service.AttachmentOptions{
+			Offset:        offset,
+			MaxFileBytes:  maxFileBytes,
+			MaxTotalBytes: budgetBytes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if all == nil {
+			all = batch
+		}
+
+		next := -1
+		for i := offset; i < len(batch) && i < len(all); i++ {
+			if batch[i].Skipped == domain.AttachmentOverBudget {
+				if next < 0 {
+					next = i
+				}
+				continue
+			}
+			all[i] = batch[i]
+		}
+		// Stop once nothing is over budget, or if a call made no progress
+		if next <= offset {
+			return all, nil
+		}
+		offset = next
+	}
+}
+
+// sanitizeFileName makes an attachment name safe to write: path separators,
+// control characters and characters Windows and macOS reject become "_",
+// leading dots and trailing spaces or dots are dropped, and long names are
+// shortened keeping their extension
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimRight(strings.TrimLeft(strings.TrimSpace(name), "."), " .")
+	if name == "" {
+		name = "attachment"
+	}
+
+	if len(name) > maxFileNameBytes {
+		ext := filepath.Ext(name)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		base := strings.TrimSuffix(name, ext)
+		cut := maxFileNameBytes - len(ext)
+		// Cut on a rune boundary
+		for cut > 0 && !isRuneStart(base[cut]) {
+			cut--
+		}
+		name = base[:cut] + ext
+	}
+	return name
+}
+
+// isRuneStart reports whether b starts a UTF-8 encoded rune
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// uniqueFileName returns name, or name numbered " (2)", " (3)", … before its
+// extension when already used, and records it as used. Names are compared
+// ignoring case, as macOS file systems do.
+func uniqueFileName(name string, used map[string]bool) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := name
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		candidate = fmt.Sprintf("%s (%d)%s", base, n, ext)
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// skipReason describes why an attachment was not exported
+func skipReason(skipped string) string {
+	switch skipped {
+	case domain.AttachmentTooLarge:
+		return "larger than --max-size"
+	case domain.AttachmentNotFile:
+		return "a folder or package, not a file"
+	default:
+		return skipped
+	}
+}
+
+// formatBytes returns a size such as "512 B", "1.5 KB" or "2.0 MB"
+func formatBytes(n int64) string {
+	switch {
+	case n < 1<<10:
+		return fmt.Sprintf("%d B", n)
+	case n < 1<<20:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	}
+}
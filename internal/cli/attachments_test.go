@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func executeAttachmentsCommand(mockService service.OmniFocusService, args []string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewAttachmentsCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(append([]string{"attachments", "export"}, args...))
+
+	err := rootCmd.ExecuteContext(ContextWithService(context.Background(), mockService))
+	return buf.String(), err
+}
+
+func TestAttachmentsExport_WritesFilesAndManifest(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	mockService := &service.MockOmniFocusService{
+		ResolvedProjectID: "proj1",
+		Attachments: []domain.Attachment{
+			{TaskID: "t1", TaskName: "Quote", Name: "scan.pdf", Size: 3, Data: []byte("pdf")},
+			{TaskID: "t2", TaskName: "Invoice", Name: "SCAN.pdf", Size: 3, Data: []byte("two")},
+			{TaskID: "t2", TaskName: "Invoice", Name: "../etc/passwd", Size: 4, Data: []byte("evil")},
+			{TaskID: "t3", TaskName: "Photos", Name: "album", Skipped: domain.AttachmentNotFile},
+			{TaskID: "t3", TaskName: "Photos", Name: "Manifest.json", Size: 2, Data: []byte("{}")},
+		},
+	}
+
+	output, err := executeAttachmentsCommand(mockService, []string{"--project", "Home", "--dir", dir})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, "Exported 4 attachments") || !strings.Contains(output, "skipped album") {
+		t.Errorf("Expected a summary with the skipped folder, got: %s", output)
+	}
+
+	for name, want := range map[string]string{"scan.pdf": "pdf", "SCAN (2).pdf": "two", "_etc_passwd": "evil", "Manifest (2).json": "{}"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(data) != want {
+			t.Errorf("file %q = %q, %v, want %q", name, data, err, want)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		t.Fatalf("manifest not written: %v", err)
+	}
+	var manifest attachmentManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if len(manifest.Files) != 5 || manifest.Files[1].File != "SCAN (2).pdf" || manifest.Files[3].Skipped != domain.AttachmentNotFile {
+		t.Errorf("manifest files = %+v", manifest.Files)
+	}
+}
+
+func TestAttachmentsExport_SizeGuards(t *testing.T) {
+	big := int64(attachmentBudgetBytes - 10)
+	mockService := &service.MockOmniFocusService{
+		ResolvedProjectID: "proj1",
+		Attachments: []domain.Attachment{
+			{TaskID: "t1", TaskName: "A", Name: "a.bin", Size: big, Data: []byte("a")},
+			{TaskID: "t1", TaskName: "A", Name: "b.bin", Size: big, Data: []byte("b")},
+			{TaskID: "t2", TaskName: "B", Name: "huge.mov", Size: 100 << 20, Data: []byte("h")},
+		},
+	}
+	dir := t.TempDir()
+
+	output, err := executeAttachmentsCommand(mockService, []string{"--project", "Home", "--dir", dir, "--max-size", "20"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// The second file is over the budget of the first call and fetched by a second
+	if len(mockService.AttachmentCalls) != 2 || mockService.AttachmentCalls[1].Offset != 1 {
+		t.Errorf("GetAttachments calls = %+v, want a second call from offset 1", mockService.AttachmentCalls)
+	}
+	if got := mockService.AttachmentCalls[0].MaxFileBytes; got != 20<<20 {
+		t.Errorf("MaxFileBytes = %d, want 20 MB", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b.bin")); err != nil {
+		t.Errorf("b.bin should be exported by the second call: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "huge.mov")); err == nil {
+		t.Error("huge.mov is over --max-size and should not be written")
+	}
+	if !strings.Contains(output, "larger than --max-size") {
+		t.Errorf("Expected the oversized file to be reported, got: %s", output)
+	}
+}
+
+func TestAttachmentsExport_RejectsMaxSizeOverPayloadLimit(t *testing.T) {
+	mockService := &service.MockOmniFocusService{ResolvedProjectID: "proj1"}
+
+	_, err := executeAttachmentsCommand(mockService, []string{"--project", "Home", "--dir", t.TempDir(), "--max-size", "24"})
+	if err == nil || !strings.Contains(err.Error(), "at most 23 MB") {
+		t.Errorf("Expected --max-size over the payload limit to be rejected, got: %v", err)
+	}
+	if len(mockService.AttachmentCalls) != 0 {
+		t.Errorf("GetAttachments should not run, got %d calls", len(mockService.AttachmentCalls))
+	}
+}
+
+func TestAttachmentsExport_RequiresProjectAndDir(t *testing.T) {
+	_, err := executeAttachmentsCommand(&service.MockOmniFocusService{}, []string{"--project", "Home"})
+	if err == nil || !strings.Contains(err.Error(), "required") {
+		t.Errorf("Expected a required flag error, got: %v", err)
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"a/b\\c:d.txt", "a_b_c_d.txt"},
+		{"..hidden", "hidden"},
+		{"  trailing. ", "trailing"},
+		{"", "attachment"},
+		{"line\nbreak", "line_break"},
+	}
+	for _, tt := range tests {
+		if got := sanitizeFileName(tt.name); got != tt.want {
+			t.Errorf("sanitizeFileName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	long := strings.Repeat("é", 150) + ".jpeg"
+	got := sanitizeFileName(long)
+	if len(got) > maxFileNameBytes || !strings.HasSuffix(got, ".jpeg") || !strings.HasPrefix(got, "é") {
+		t.Errorf("sanitizeFileName(long) = %q (%d bytes), want at most %d bytes ending in .jpeg", got, len(got), maxFileNameBytes)
+	}
+}
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// requestBackup asks OmniFocus to back up its database; tests replace it
+var requestBackup = func(ctx context.Context, cfg *config.Config) error {
+	return bridge.RequestBackup(ctx, newExecutor(cfg))
+}
+
+// backupPollInterval is how often the backups folder is checked for the new
+// backup; tests shorten it
+var backupPollInterval = 500 * time.Millisecond
+
+// backupDirs are where OmniFocus 4 and 3 keep their backups, under the home
+// folder
+var backupDirs = []string{
+	"Library/Containers/com.omnigroup.OmniFocus4/Data/Library/Application Support/OmniFocus/Backups",
+	"Library/Containers/com.omnigroup.OmniFocus3/Data/Library/Application Support/OmniFocus/Backups",
+}
+
+// NewBackupCommand creates the backup command
+func NewBackupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up the OmniFocus database",
+		Long: `Ask OmniFocus to back up its database now, through File > Back Up Database,
+and wait until the backup appears in its backups folder. With --copy-to, or
+backup.destination in the config file, the new backup is also copied there.
+
+OmniFocus must be running, and your terminal needs the Accessibility
+permission to use the OmniFocus menus. Run it before bulk changes to have a
+backup to restore from.`,
+		Example: `  lazyfocus backup
+  lazyfocus backup --copy-to ~/Dropbox/OmniFocus
+  lazyfocus backup --wait 5m --json`,
+		Args: cobra.NoArgs,
+		RunE: runBackup,
+	}
+
+	cmd.Flags().String("copy-to", "", "Folder to copy the new backup to (default: backup.destination)")
+	cmd.Flags().Duration("wait", time.Minute, "How long to wait for OmniFocus to write the backup")
+
+	return cmd
+}
+
+// backupResult is the JSON shape of backup output
+type backupResult struct {
+	Backup string `json:"backup"`
+	Copy   string `json:"copy,omitempty"`
+}
+
+func runBackup(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.FromContext(ctx)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	copyTo, _ := cmd.Flags().GetString("copy-to")
+	if copyTo == "" {
+		copyTo = cfg.Backup.Destination
+	}
+	wait, _ := cmd.Flags().GetDuration("wait")
+
+	result, err := backupNow(ctx, cfg, copyTo, wait)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	switch {
+	case GetJSONFlag():
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to encode result: %w", err))
+		}
+		cmd.Println(string(data))
+	case !GetQuietFlag():
+		cmd.Printf("✓ Backed up OmniFocus to %s\n", result.Backup)
+		if result.Copy != "" {
+			cmd.Printf("✓ Copied backup to %s\n", result.Copy)
+		}
+	}
+	return nil
+}
+
+// backupNow has OmniFocus back up its database, waits up to wait for the
+// backup to be written and copies it into copyTo unless that is empty.
+// Commands that change many tasks at once can call it first.
+func backupNow(ctx context.Context, cfg *config.Config, copyTo string, wait time.Duration) (backupResult, error) {
+	dir, err := backupDir(cfg.Backup.Dir)
+	if err != nil {
+		return backupResult{}, err
+	}
+
+	// File times can be coarser than the clock, so allow for rounding
+	since := time.Now().Add(-time.Second)
+	if err := requestBackup(ctx, cfg); err != nil {
+		if bridge.IsAccessibilityDenied(err) {
+			return backupResult{}, fmt.Errorf("%w\n%s", err, bridge.AccessibilityPermissionFix)
+		}
+		return backupResult{}, err
+	}
+
+	backup, err := waitForBackup(dir, since, wait)
+	if err != nil {
+		return backupResult{}, err
+	}
+	result := backupResult{Backup: backup}
+
+	if copyTo != "" {
+		copied, err := copyBackup(backup, expandHome(copyTo))
+		if err != nil {
+			return backupResult{}, err
+		}
+		result.Copy = copied
+	}
+	return result, nil
+}
+
+// backupDir returns the configured backups folder, or the first folder of
+// backupDirs that exists
+func backupDir(configured string) (string, error) {
+	if configured != "" {
+		return expandHome(configured), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home folder: %w", err)
+	}
+	for _, dir := range backupDirs {
+		path := filepath.Join(home, dir)
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", errors.New("OmniFocus backups folder not found: set backup.dir in the config file")
+}
+
+// latestBackup returns the entry of dir modified last, with its time. OmniFocus
+// writes backups as .ofocus-backup packages, which are folders.
+func latestBackup(dir string) (string, time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read backups folder: %w", err)
+	}
+
+	var latest string
+	var latestTime time.Time
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(latestTime) {
+			latest = filepath.Join(dir, entry.Name())
+			latestTime = info.ModTime()
+		}
+	}
+	return latest, latestTime, nil
+}
+
+// waitForBackup polls dir until a backup modified after since appears, for up
+// to wait
+func waitForBackup(dir string, since time.Time, wait time.Duration) (string, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		backup, modified, err := latestBackup(dir)
+		if err != nil {
+			return "", err
+		}
+		if backup != "" && modified.After(since) {
+			return backup, nil
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("no new backup in %s after %s", dir, wait)
+		}
+		time.Sleep(backupPollInterval)
+	}
+}
+
+// copyBackup copies the backup at src, a file or a package folder, into
+// destDir and returns the path of the copy. An existing copy is not replaced.
+func copyBackup(src, destDir string) (string, error) {
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", destDir, err)
+	}
+	target := filepath.Join(destDir, filepath.Base(src))
+	if _, err := os.Stat(target); err == nil {
+		return "", fmt.Errorf("failed to copy backup: %s already exists", target)
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy backup: %w", err)
+	}
+	if info.IsDir() {
+		if err := os.CopyFS(target, os.DirFS(src)); err != nil {
+			return "", fmt.Errorf("failed to copy backup: %w", err)
+		}
+		return target, nil
+	}
+
+	if err := copyFile(src, target); err != nil {
+		return "", fmt.Errorf("failed to copy backup: %w", err)
+	}
+	return target, nil
+}
+
+// copyFile copies the file at src to a new file at dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+)
+
+func executeBackupCommand(cfg *config.Config, args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewBackupCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs(append([]string{"backup"}, args...))
+
+	ctx := config.ContextWithConfig(context.Background(), cfg)
+	err := rootCmd.ExecuteContext(ctx)
+	return buf.String(), err
+}
+
+// stubRequestBackup makes backups write a package folder named name into
+// dir, or fail with err
+func stubRequestBackup(t *testing.T, dir, name string, err error) *int {
+	t.Helper()
+	var calls int
+	originalRequest, originalInterval := requestBackup, backupPollInterval
+	requestBackup = func(ctx context.Context, cfg *config.Config) error {
+		calls++
+		if err != nil {
+			return err
+		}
+		pkg := filepath.Join(dir, name)
+		if err := os.MkdirAll(pkg, 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(pkg, "contents.xml"), []byte("<omnifocus/>"), 0644)
+	}
+	backupPollInterval = time.Millisecond
+	t.Cleanup(func() { requestBackup, backupPollInterval = originalRequest, originalInterval })
+	return &calls
+}
+
+func TestBackupCommand(t *testing.T) {
+	dir := t.TempDir()
+	calls := stubRequestBackup(t, dir, "OmniFocus 2026-10-16 093000.ofocus-backup", nil)
+
+	output, err := executeBackupCommand(&config.Config{Backup: config.BackupConfig{Dir: dir}})
+	if err != nil {
+		t.Fatalf("backup error = %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("requested %d backups, want 1", *calls)
+	}
+	want := filepath.Join(dir, "OmniFocus 2026-10-16 093000.ofocus-backup")
+	if !strings.Contains(output, "Backed up OmniFocus to "+want) {
+		t.Errorf("Expected the backup path, got: %s", output)
+	}
+	if strings.Contains(output, "Copied") {
+		t.Errorf("Expected no copy without a destination, got: %s", output)
+	}
+}
+
+func TestBackupCommand_CopiesToDestination(t *testing.T) {
+	dir := t.TempDir()
+	dest := filepath.Join(t.TempDir(), "backups")
+	stubRequestBackup(t, dir, "latest.ofocus-backup", nil)
+
+	cfg := &config.Config{Backup: config.BackupConfig{Dir: dir, Destination: dest}}
+	output, err := executeBackupCommand(cfg, "--json")
+	if err != nil {
+		t.Fatalf("backup error = %v", err)
+	}
+
+	copied := filepath.Join(dest, "latest.ofocus-backup")
+	data, err := os.ReadFile(filepath.Join(copied, "contents.xml"))
+	if err != nil || string(data) != "<omnifocus/>" {
+		t.Errorf("copied contents = %q, %v; want the backup's", data, err)
+	}
+	if !strings.Contains(output, `"copy": "`+copied+`"`) {
+		t.Errorf("Expected JSON with the copy, got: %s", output)
+	}
+}
+
+func TestBackupCommand_CopyToFlagWins(t *testing.T) {
+	dir := t.TempDir()
+	flagDest := t.TempDir()
+	stubRequestBackup(t, dir, "latest.ofocus-backup", nil)
+
+	cfg := &config.Config{Backup: config.BackupConfig{Dir: dir, Destination: filepath.Join(t.TempDir(), "unused")}}
+	if _, err := executeBackupCommand(cfg, "--copy-to", flagDest); err != nil {
+		t.Fatalf("backup error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(flagDest, "latest.ofocus-backup")); err != nil {
+		t.Errorf("backup not copied to --copy-to: %v", err)
+	}
+	if _, err := os.Stat(cfg.Backup.Destination); err == nil {
+		t.Error("backup.destination should be ignored when --copy-to is given")
+	}
+}
+
+func TestBackupCommand_NoNewBackup(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "old.ofocus-backup")
+	if err := os.Mkdir(old, 0755); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(old, past, past); err != nil {
+		t.Fatal(err)
+	}
+	originalRequest, originalInterval := requestBackup, backupPollInterval
+	requestBackup = func(ctx context.Context, cfg *config.Config) error { return nil }
+	backupPollInterval = time.Millisecond
+	t.Cleanup(func() { requestBackup, backupPollInterval = originalRequest, originalInterval })
+
+	_, err := executeBackupCommand(&config.Config{Backup: config.BackupConfig{Dir: dir}}, "--wait", "20ms")
+	if err == nil || !strings.Contains(err.Error(), "no new backup") {
+		t.Errorf("backup error = %v, want no new backup", err)
+	}
+}
+
+func TestBackupCommand_AccessibilityDenied(t *testing.T) {
+	stubRequestBackup(t, t.TempDir(), "", errors.New("failed to start backup: osascript is not allowed assistive access. (-1719)"))
+
+	_, err := executeBackupCommand(&config.Config{Backup: config.BackupConfig{Dir: t.TempDir()}})
+	if err == nil || !strings.Contains(err.Error(), "Accessibility") {
+		t.Errorf("backup error = %v, want the Accessibility fix", err)
+	}
+}
+
+func TestCopyBackup_ExistingTarget(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "backup.ofocus-backup")
+	if err := os.WriteFile(src, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dest := t.TempDir()
+
+	copied, err := copyBackup(src, dest)
+	if err != nil {
+		t.Fatalf("copyBackup() error = %v", err)
+	}
+	if data, _ := os.ReadFile(copied); string(data) != "data" {
+		t.Errorf("copied file = %q, want %q", data, "data")
+	}
+	if _, err := copyBackup(src, dest); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("copyBackup() again error = %v, want already exists", err)
+	}
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+)
+
+// setupCalendar makes relative dates skip the weekends and holidays in cfg
+func setupCalendar(cfg config.CalendarConfig) error {
+	calendar, err := dateparse.NewCalendar(cfg.SkipWeekends, cfg.Holidays)
+	if err != nil {
+		return fmt.Errorf("invalid calendar: %w", err)
+	}
+
+	if cfg.HolidaysICS != "" {
+		f, err := os.Open(expandHome(cfg.HolidaysICS))
+		if err != nil {
+			return fmt.Errorf("failed to open holidays calendar: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		if calendar, err = calendar.AddICS(f); err != nil {
+			return fmt.Errorf("invalid holidays calendar %s: %w", cfg.HolidaysICS, err)
+		}
+	}
+
+	dateparse.SetCalendar(calendar)
+	return nil
+}
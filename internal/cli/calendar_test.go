@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+)
+
+func TestSetupCalendar(t *testing.T) {
+	ics := filepath.Join(t.TempDir(), "holidays.ics")
+	content := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20240116\nEND:VEVENT\nEND:VCALENDAR\n"
+	if err := os.WriteFile(ics, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write calendar: %v", err)
+	}
+	t.Cleanup(func() { dateparse.SetCalendar(dateparse.Calendar{}) })
+
+	err := setupCalendar(config.CalendarConfig{
+		SkipWeekends: true,
+		Holidays:     []string{"2024-01-15"},
+		HolidaysICS:  ics,
+	})
+	if err != nil {
+		t.Fatalf("setupCalendar() error = %v", err)
+	}
+
+	// Friday, January 12, 2024: the weekend, Monday and Tuesday are skipped
+	ref := time.Date(2024, 1, 12, 10, 0, 0, 0, time.Local)
+	got, err := dateparse.ParseWithReference("tomorrow", ref)
+	if err != nil {
+		t.Fatalf("ParseWithReference() error = %v", err)
+	}
+	if want := time.Date(2024, 1, 17, 17, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("ParseWithReference(tomorrow) = %v, want %v", got, want)
+	}
+}
+
+func TestSetupCalendar_Errors(t *testing.T) {
+	t.Cleanup(func() { dateparse.SetCalendar(dateparse.Calendar{}) })
+
+	if err := setupCalendar(config.CalendarConfig{Holidays: []string{"tomorrow"}}); err == nil {
+		t.Error("setupCalendar() error = nil, want error for invalid holiday")
+	}
+	missing := filepath.Join(t.TempDir(), "missing.ics")
+	if err := setupCalendar(config.CalendarConfig{HolidaysICS: missing}); err == nil {
+		t.Error("setupCalendar() error = nil, want error for missing calendar")
+	}
+}
@@ -0,0 +1,49 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+// AddCommands registers every lazyfocus command on root
+func AddCommands(root *cobra.Command) {
+	// Read commands
+	root.AddCommand(NewTasksCommand())
+	root.AddCommand(NewProjectsCommand())
+	root.AddCommand(NewTagsCommand())
+	root.AddCommand(NewShowCommand())
+	root.AddCommand(NewOpenCommand())
+	root.AddCommand(NewPerspectiveCommand())
+	root.AddCommand(NewReportCommand())
+	root.AddCommand(NewNextCommand())
+	root.AddCommand(NewExportCommand())
+	root.AddCommand(NewAttachmentsCommand())
+	root.AddCommand(NewVersionCommand())
+	root.AddCommand(NewCompletionCommand())
+	root.AddCommand(NewDoctorCommand())
+	root.AddCommand(NewShortcutsCommand())
+
+	// Write operation commands
+	root.AddCommand(NewAddCommand())
+	root.AddCommand(NewCompleteCommand())
+	root.AddCommand(NewUncompleteCommand())
+	root.AddCommand(NewDoneCommand())
+	root.AddCommand(NewDeleteCommand())
+	root.AddCommand(NewDropCommand())
+	root.AddCommand(NewModifyCommand())
+	root.AddCommand(NewEditCommand())
+	root.AddCommand(NewRulesCommand())
+	root.AddCommand(NewTemplateCommand())
+	root.AddCommand(NewImportCommand())
+	root.AddCommand(NewConfigCommand())
+	root.AddCommand(NewBackupCommand())
+
+	// Background commands
+	root.AddCommand(NewServeCommand())
+	root.AddCommand(NewFlushCommand())
+	root.AddCommand(NewDigestCommand())
+
+	// TUI command
+	root.AddCommand(NewTUICommand())
+
+	// Release and development tooling
+	root.AddCommand(NewGenDocsCommand())
+	root.AddCommand(NewDevCommand())
+}
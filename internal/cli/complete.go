@@ -3,50 +3,86 @@ package cli
 import (
 	"fmt"
 
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/spf13/cobra"
 )
 
 // NewCompleteCommand creates the complete command
 func NewCompleteCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "complete <task-id> [task-id...]",
+		Use:   "complete [task-id...]",
 		Short: "Mark tasks as complete in OmniFocus",
 		Long: `Mark one or more tasks as complete in OmniFocus.
 
-Accepts one or more task IDs as arguments. The command will attempt to
-complete all specified tasks, continuing even if some fail.
+Accepts one or more task IDs, or the numbers the last tasks listing printed,
+as arguments. The command will attempt to complete all specified tasks,
+continuing even if some fail.
 
-Examples:
-  lazyfocus complete abc123
+With --note, a closing "resolution: <note>" line is appended to each task's
+note before it is completed, so the task records how it was resolved.
+
+With --interactive and no IDs, pick the task from a list of incomplete tasks,
+typing to fuzzy-search their names.`,
+		Example: `  lazyfocus complete abc123
   lazyfocus complete abc123 def456
-  lazyfocus complete task1 task2 task3 --json`,
-		Args: cobra.MinimumNArgs(1),
+  lazyfocus complete 3          # Task 3 of the last lazyfocus tasks
+  lazyfocus complete abc123 --note "Fixed in release 2.3"
+  lazyfocus complete task1 task2 task3 --json
+  lazyfocus complete --interactive`,
+		Args: taskIDArgs(1, true),
 		RunE: runComplete,
 	}
 
+	cmd.Flags().String("note", "", "Append a closing \"resolution: <note>\" line to each task's note first")
+	addInteractiveFlag(cmd)
+
 	return cmd
 }
 
 func runComplete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	note, _ := cmd.Flags().GetString("note")
+
 	// Get service
 	svc, err := getServiceFromCmd(cmd)
 	if err != nil {
 		return handleError(cmd, err)
 	}
 
+	if len(args) == 0 {
+		task, ok, err := pickTask(cmd, svc, "Complete which task?")
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		if !ok {
+			cmd.Println("Cancelled")
+			return nil
+		}
+		args = []string{task.ID}
+	}
+	args, err = resolveTaskIDs(args)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
 	// Track if any errors occurred
 	var lastError error
 	successCount := 0
 
+	reporter := newProgressReporter(cmd, len(args))
+	reporter.Start("Completing tasks", len(args))
+	defer reporter.Finish()
+
 	// Attempt to complete each task
 	for _, taskID := range args {
-		result, err := svc.CompleteTask(taskID)
+		result, err := service.CompleteTaskWithNote(ctx, svc, taskID, note)
+		reporter.Increment()
 		if err != nil {
 			lastError = err
 			// In non-quiet mode, show the error
 			if !GetQuietFlag() {
-				formatter := getFormatter()
-				cmd.Print(formatter.FormatError(fmt.Errorf("failed to complete %s: %w", taskID, err)))
+				reporter.Clear()
+				printError(cmd, fmt.Errorf("failed to complete %s: %w", taskID, err))
 			}
 			continue
 		}
@@ -55,15 +91,17 @@ func runComplete(cmd *cobra.Command, args []string) error {
 
 		// Format and output result
 		if !GetQuietFlag() {
+			reporter.Clear()
 			formatter := getFormatter()
-			outputStr := formatter.FormatCompletedTask(*result)
-			cmd.Print(outputStr)
+			if err := formatter.FormatCompletedTask(ctx, cmd.OutOrStdout(), *result); err != nil {
+				return err
+			}
 		}
 	}
 
 	// If all tasks failed, return the last error
 	if successCount == 0 && lastError != nil {
-		return lastError
+		return printed(lastError)
 	}
 
 	return nil
@@ -42,6 +42,23 @@ func TestCompleteCommand_SingleTask(t *testing.T) {
 	}
 }
 
+func TestCompleteCommand_WithNote(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		Task:           &domain.Task{ID: "task123", Note: "Ticket 42"},
+		CompleteResult: &domain.OperationResult{Success: true, ID: "task123", Message: "Task completed"},
+	}
+
+	_, _, err := executeCompleteCommand(mockService, []string{"task123", "--note", "Fixed upstream"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	note := mockService.Modifications["task123"].Note
+	if note == nil || *note != "Ticket 42\n\nresolution: Fixed upstream" {
+		t.Errorf("Expected resolution appended to note, got: %v", note)
+	}
+}
+
 func TestCompleteCommand_MultipleTasks(t *testing.T) {
 	// Test completing multiple tasks
 	result := &domain.OperationResult{
@@ -71,6 +88,42 @@ func TestCompleteCommand_MultipleTasks(t *testing.T) {
 	}
 }
 
+func TestCompleteCommand_MultipleTasksReportsProgress(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		CompleteResult: &domain.OperationResult{Success: true, Message: "Task completed"},
+	}
+
+	output, _, err := executeCompleteCommand(mockService, []string{"task1", "task2", "task3"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, "Completing tasks: done (3/3)") {
+		t.Errorf("Expected progress summary, got: %s", output)
+	}
+
+	output, _, err = executeCompleteCommand(mockService, []string{"--json", "task1", "task2"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, `"type":"done"`) {
+		t.Errorf("Expected JSON progress events, got: %s", output)
+	}
+}
+
+func TestCompleteCommand_SingleTaskNoProgress(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		CompleteResult: &domain.OperationResult{Success: true, Message: "Task completed"},
+	}
+
+	output, _, _ := executeCompleteCommand(mockService, []string{"task1"})
+
+	if strings.Contains(output, "Completing tasks") {
+		t.Errorf("Expected no progress output for a single task, got: %s", output)
+	}
+}
+
 func TestCompleteCommand_JSONOutput(t *testing.T) {
 	// Test JSON output format
 	result := &domain.OperationResult{
@@ -219,3 +272,44 @@ func executeCompleteCommand(mockService service.OmniFocusService, args []string)
 
 	return output, exitCode, err
 }
+
+func TestCompleteCommand_Interactive(t *testing.T) {
+	stubPicker(t, "Call dentist")
+	mockService := &service.MockOmniFocusService{
+		AllTasks:       pickerTasks(),
+		CompleteResult: &domain.OperationResult{Success: true, ID: "t2", Message: "Task completed"},
+	}
+
+	_, _, err := executeCompleteCommand(mockService, []string{"--interactive"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.CompletedIDs) != 1 || mockService.CompletedIDs[0] != "t2" {
+		t.Errorf("CompletedIDs = %v, want [t2]", mockService.CompletedIDs)
+	}
+}
+
+func TestCompleteCommand_InteractiveCancelled(t *testing.T) {
+	stubPicker(t, "")
+	mockService := &service.MockOmniFocusService{AllTasks: pickerTasks()}
+
+	output, _, err := executeCompleteCommand(mockService, []string{"-i"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.CompletedIDs) != 0 {
+		t.Errorf("CompletedIDs = %v, want none", mockService.CompletedIDs)
+	}
+	if !strings.Contains(output, "Cancelled") {
+		t.Errorf("output = %q, want Cancelled", output)
+	}
+}
+
+func TestCompleteCommand_NoIDWithoutInteractive(t *testing.T) {
+	_, _, err := executeCompleteCommand(&service.MockOmniFocusService{}, []string{})
+	if err == nil {
+		t.Error("Expected error without task IDs or --interactive")
+	}
+}
@@ -43,6 +43,8 @@ PowerShell:
   PS> lazyfocus completion powershell > lazyfocus.ps1
   # and source this file from your PowerShell profile.
 `,
+		Example: `  lazyfocus completion zsh > "${fpath[1]}/_lazyfocus"
+  lazyfocus completion fish | source`,
 		DisableFlagsInUseLine: true,
 		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
 		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCommand creates the config command
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Move lazyfocus configuration between machines",
+		Long: `Export lazyfocus configuration to a bundle, or import one.
+
+A bundle is a .tar.gz holding ~/.lazyfocus.yaml (theme, colors, templates,
+note templates, rules and schedule) and the saved TUI filters. API tokens are
+left out unless export is given --include-tokens. Caches and session state
+are not included.`,
+	}
+
+	cmd.AddCommand(newConfigExportCommand())
+	cmd.AddCommand(newConfigImportCommand())
+
+	return cmd
+}
+
+// newConfigExportCommand creates the config export subcommand
+func newConfigExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <bundle.tar.gz>",
+		Short: "Package configuration into a bundle",
+		Long: `Package the config file and saved filters into a .tar.gz bundle.
+
+Bundles are meant to be shared, so the bearer tokens under api.tokens, which
+grant access to "lazyfocus serve", are left out of the config file. Pass
+--include-tokens to keep them, e.g. for a bundle only you will use.`,
+		Example: `  lazyfocus config export lazyfocus-setup.tar.gz
+  lazyfocus config export --include-tokens my-machines.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			"skipServiceSetup": "true",
+		},
+		RunE: runConfigExport,
+	}
+
+	cmd.Flags().Bool("include-tokens", false, "Keep the API tokens of the config file in the bundle")
+
+	return cmd
+}
+
+// newConfigImportCommand creates the config import subcommand
+func newConfigImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <bundle.tar.gz>",
+		Short: "Install configuration from a bundle",
+		Long: `Install the config file and saved filters from a bundle written by
+"lazyfocus config export". Existing files are left alone unless --force is
+given; nothing is written if any file in the bundle is invalid.`,
+		Example: `  lazyfocus config import lazyfocus-setup.tar.gz
+  lazyfocus config import --force lazyfocus-setup.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			"skipServiceSetup": "true",
+		},
+		RunE: runConfigImport,
+	}
+
+	cmd.Flags().Bool("force", false, "Replace existing configuration files")
+
+	return cmd
+}
+
+// configBundleSummary is the JSON shape of config export and import output
+type configBundleSummary struct {
+	Bundle string   `json:"bundle"`
+	Files  []string `json:"files"`
+}
+
+func runConfigExport(cmd *cobra.Command, args []string) error {
+	includeTokens, _ := cmd.Flags().GetBool("include-tokens")
+
+	f, err := os.Create(args[0])
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to create bundle: %w", err))
+	}
+
+	files, err := config.ExportBundle(f, config.BundleFiles(), includeTokens)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write bundle: %w", closeErr)
+	}
+	if err == nil && len(files) == 0 {
+		err = fmt.Errorf("no configuration to export (looked for %s and %s)", config.FilePath(), config.SavedFiltersPath())
+	}
+	if err != nil {
+		_ = os.Remove(args[0])
+		return handleError(cmd, err)
+	}
+
+	return printConfigBundle(cmd, configBundleSummary{Bundle: args[0], Files: files}, "Exported")
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to open bundle: %w", err))
+	}
+	defer func() { _ = f.Close() }()
+
+	files, err := config.ImportBundle(f, config.BundleFiles(), force)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	return printConfigBundle(cmd, configBundleSummary{Bundle: args[0], Files: files}, "Imported")
+}
+
+// printConfigBundle reports the files exported to or imported from a bundle
+func printConfigBundle(cmd *cobra.Command, summary configBundleSummary, verb string) error {
+	if GetQuietFlag() {
+		return nil
+	}
+	if GetJSONFlag() {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to encode summary: %w", err))
+		}
+		cmd.Println(string(data))
+		return nil
+	}
+
+	cmd.Printf("✓ %s %d files (%s)\n", verb, len(summary.Files), summary.Bundle)
+	for _, file := range summary.Files {
+		cmd.Printf("  %s\n", file)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func executeConfigCommand(args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewConfigCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs(append([]string{"config"}, args...))
+
+	err := rootCmd.ExecuteContext(context.Background())
+	return buf.String(), err
+}
+
+func TestConfigCommand_ExportImport(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configFile := filepath.Join(home, ".lazyfocus.yaml")
+	if err := os.WriteFile(configFile, []byte("tui:\n  theme: dark\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bundle := filepath.Join(t.TempDir(), "setup.tar.gz")
+
+	output, err := executeConfigCommand("export", bundle)
+	if err != nil {
+		t.Fatalf("export error = %v", err)
+	}
+	if !strings.Contains(output, "Exported 1 files") || !strings.Contains(output, "lazyfocus.yaml") {
+		t.Errorf("Expected export summary, got: %s", output)
+	}
+
+	// Importing over the existing config needs --force
+	if _, err := executeConfigCommand("import", bundle); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Expected import to refuse to overwrite, got: %v", err)
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	output, err = executeConfigCommand("import", "--json", bundle)
+	if err != nil {
+		t.Fatalf("import error = %v", err)
+	}
+	if !strings.Contains(output, `"lazyfocus.yaml"`) {
+		t.Errorf("Expected JSON summary, got: %s", output)
+	}
+	home, _ = os.UserHomeDir()
+	if got, _ := os.ReadFile(filepath.Join(home, ".lazyfocus.yaml")); string(got) != "tui:\n  theme: dark\n" {
+		t.Errorf("imported config = %q, want exported config", got)
+	}
+}
+
+func TestConfigCommand_ExportNothing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	bundle := filepath.Join(t.TempDir(), "setup.tar.gz")
+
+	_, err := executeConfigCommand("export", bundle)
+
+	if err == nil || !strings.Contains(err.Error(), "no configuration to export") {
+		t.Errorf("Expected no configuration error, got: %v", err)
+	}
+	if _, statErr := os.Stat(bundle); statErr == nil {
+		t.Error("Expected no bundle to be left behind")
+	}
+}
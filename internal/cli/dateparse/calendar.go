@@ -0,0 +1,146 @@
+package dateparse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dayFormat keys holidays by calendar day
+const dayFormat = "2006-01-02"
+
+// Calendar decides which days relative dates such as "tomorrow" may land on
+type Calendar struct {
+	SkipWeekends bool
+	Holidays     map[string]bool // Days to skip, keyed by YYYY-MM-DD
+}
+
+var (
+	calendarMu sync.RWMutex
+	calendar   Calendar
+)
+
+// SetCalendar sets the calendar used when parsing relative dates; the zero Calendar skips nothing
+func SetCalendar(c Calendar) {
+	calendarMu.Lock()
+	defer calendarMu.Unlock()
+	calendar = c
+}
+
+// currentCalendar returns the calendar set by SetCalendar
+func currentCalendar() Calendar {
+	calendarMu.RLock()
+	defer calendarMu.RUnlock()
+	return calendar
+}
+
+// RollForward returns t, or the first workday after it in the calendar set by
+// SetCalendar
+func RollForward(t time.Time) time.Time {
+	return currentCalendar().RollForward(t)
+}
+
+// NewCalendar creates a calendar from holiday dates written as YYYY-MM-DD
+func NewCalendar(skipWeekends bool, holidays []string) (Calendar, error) {
+	c := Calendar{SkipWeekends: skipWeekends, Holidays: make(map[string]bool, len(holidays))}
+	for _, day := range holidays {
+		if _, err := time.Parse(dayFormat, day); err != nil {
+			return Calendar{}, fmt.Errorf("invalid holiday %q: want YYYY-MM-DD", day)
+		}
+		c.Holidays[day] = true
+	}
+	return c, nil
+}
+
+// AddICS adds the days of every event in an iCalendar (.ics) file as holidays
+func (c Calendar) AddICS(r io.Reader) (Calendar, error) {
+	days, err := parseICS(r)
+	if err != nil {
+		return c, err
+	}
+	holidays := make(map[string]bool, len(c.Holidays)+len(days))
+	for day := range c.Holidays {
+		holidays[day] = true
+	}
+	for _, day := range days {
+		holidays[day] = true
+	}
+	c.Holidays = holidays
+	return c, nil
+}
+
+// IsWorkday reports whether t is neither a skipped weekend day nor a holiday
+func (c Calendar) IsWorkday(t time.Time) bool {
+	if c.SkipWeekends && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return false
+	}
+	return !c.Holidays[t.Format(dayFormat)]
+}
+
+// RollForward returns t, or the first workday after it when t is skipped
+func (c Calendar) RollForward(t time.Time) time.Time {
+	// A year of skipped days means the calendar is unusable; give up on it
+	for i := 0; i < 366 && !c.IsWorkday(t); i++ {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// parseICS returns the days covered by the events of an iCalendar file. All-day
+// events end the day before DTEND; timed events cover the day they start.
+func parseICS(r io.Reader) ([]string, error) {
+	var days []string
+	var start, end time.Time
+	allDay := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		property, params, _ := strings.Cut(name, ";")
+
+		switch strings.ToUpper(property) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				start, end, allDay = time.Time{}, time.Time{}, false
+			}
+		case "DTSTART":
+			start = parseICSDate(value)
+			allDay = strings.Contains(strings.ToUpper(params), "VALUE=DATE") || len(value) == 8
+		case "DTEND":
+			end = parseICSDate(value)
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || start.IsZero() {
+				continue
+			}
+			days = append(days, start.Format(dayFormat))
+			if allDay {
+				for day := start.AddDate(0, 0, 1); day.Before(end); day = day.AddDate(0, 0, 1) {
+					days = append(days, day.Format(dayFormat))
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return days, nil
+}
+
+// parseICSDate returns the day of an iCalendar DATE or DATE-TIME value
+func parseICSDate(value string) time.Time {
+	if len(value) < 8 {
+		return time.Time{}
+	}
+	day, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}
+	}
+	return day
+}
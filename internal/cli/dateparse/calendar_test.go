@@ -0,0 +1,106 @@
+package dateparse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse_Calendar(t *testing.T) {
+	// Friday, January 12, 2024, 10:00 AM; Monday the 15th is a holiday
+	ref := time.Date(2024, 1, 12, 10, 0, 0, 0, time.Local)
+
+	calendar, err := NewCalendar(true, []string{"2024-01-15"})
+	if err != nil {
+		t.Fatalf("NewCalendar() error = %v", err)
+	}
+	SetCalendar(calendar)
+	t.Cleanup(func() { SetCalendar(Calendar{}) })
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"tomorrow", time.Date(2024, 1, 16, 17, 0, 0, 0, time.Local)},
+		{"in 1 day", time.Date(2024, 1, 16, 17, 0, 0, 0, time.Local)},
+		{"in 2 days", time.Date(2024, 1, 16, 17, 0, 0, 0, time.Local)},
+		{"in 5 days", time.Date(2024, 1, 17, 17, 0, 0, 0, time.Local)},
+		{"next week", time.Date(2024, 1, 19, 17, 0, 0, 0, time.Local)},
+		{"next weekday", time.Date(2024, 1, 16, 17, 0, 0, 0, time.Local)},
+		{"today", time.Date(2024, 1, 12, 17, 0, 0, 0, time.Local)},
+		{"next saturday", time.Date(2024, 1, 13, 17, 0, 0, 0, time.Local)},
+		{"2024-01-15", time.Date(2024, 1, 15, 17, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseWithReference(tt.input, ref)
+			if err != nil {
+				t.Fatalf("ParseWithReference(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseWithReference(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_HolidaysWithoutWeekends(t *testing.T) {
+	// Friday, January 12, 2024
+	ref := time.Date(2024, 1, 12, 10, 0, 0, 0, time.Local)
+
+	calendar, err := NewCalendar(false, []string{"2024-01-13"})
+	if err != nil {
+		t.Fatalf("NewCalendar() error = %v", err)
+	}
+	SetCalendar(calendar)
+	t.Cleanup(func() { SetCalendar(Calendar{}) })
+
+	got, err := ParseWithReference("tomorrow", ref)
+	if err != nil {
+		t.Fatalf("ParseWithReference() error = %v", err)
+	}
+	if want := time.Date(2024, 1, 14, 17, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("ParseWithReference(tomorrow) = %v, want %v", got, want)
+	}
+}
+
+func TestNewCalendar_InvalidHoliday(t *testing.T) {
+	if _, err := NewCalendar(false, []string{"12/25/2024"}); err == nil {
+		t.Error("NewCalendar() error = nil, want error for invalid date")
+	}
+}
+
+func TestCalendar_AddICS(t *testing.T) {
+	ics := `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+SUMMARY:Christmas
+DTSTART;VALUE=DATE:20241225
+DTEND;VALUE=DATE:20241227
+END:VEVENT
+BEGIN:VEVENT
+SUMMARY:Offsite
+DTSTART:20241230T090000Z
+DTEND:20241230T170000Z
+END:VEVENT
+END:VCALENDAR
+`
+	calendar, err := NewCalendar(false, []string{"2024-01-01"})
+	if err != nil {
+		t.Fatalf("NewCalendar() error = %v", err)
+	}
+	calendar, err = calendar.AddICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("AddICS() error = %v", err)
+	}
+
+	for _, day := range []string{"2024-01-01", "2024-12-25", "2024-12-26", "2024-12-30"} {
+		if !calendar.Holidays[day] {
+			t.Errorf("Holidays[%s] = false, want true", day)
+		}
+	}
+	if calendar.Holidays["2024-12-27"] {
+		t.Error("Holidays[2024-12-27] = true, want false (DTEND is exclusive)")
+	}
+}
@@ -68,6 +68,7 @@ func ParseWithReference(input string, ref time.Time) (time.Time, error) {
 	parsers := []func(string, time.Time) (time.Time, bool){
 		parseRelativeDay,
 		parseNextWeekday,
+		parseWeekday,
 		parseInDaysWeeks,
 		parseNextWeek,
 		parseISO,
@@ -83,21 +84,21 @@ func ParseWithReference(input string, ref time.Time) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unrecognized date format: %s", input)
 }
 
-// parseRelativeDay handles "today", "tomorrow", "yesterday"
+// parseRelativeDay handles "today", "tomorrow", "yesterday". Tomorrow moves
+// past days the calendar skips.
 func parseRelativeDay(input string, ref time.Time) (time.Time, bool) {
-	var days int
+	var result time.Time
 	switch input {
 	case "today":
-		days = 0
+		result = ref
 	case "tomorrow":
-		days = 1
+		result = currentCalendar().RollForward(ref.AddDate(0, 0, 1))
 	case "yesterday":
-		days = -1
+		result = ref.AddDate(0, 0, -1)
 	default:
 		return time.Time{}, false
 	}
 
-	result := ref.AddDate(0, 0, days)
 	return setTo5PM(result), true
 }
 
@@ -107,17 +108,21 @@ func parseNextWeek(input string, ref time.Time) (time.Time, bool) {
 		return time.Time{}, false
 	}
 
-	result := ref.AddDate(0, 0, 7)
+	result := currentCalendar().RollForward(ref.AddDate(0, 0, 7))
 	return setTo5PM(result), true
 }
 
-// parseNextWeekday handles "next monday", "next tuesday", etc.
+// parseNextWeekday handles "next monday", "next tuesday", etc. and "next weekday"
 func parseNextWeekday(input string, ref time.Time) (time.Time, bool) {
 	if !strings.HasPrefix(input, "next ") {
 		return time.Time{}, false
 	}
 
 	weekdayStr := strings.TrimPrefix(input, "next ")
+	if weekdayStr == "weekday" {
+		return setTo5PM(nextWorkday(ref)), true
+	}
+
 	targetWeekday, ok := weekdays[weekdayStr]
 	if !ok {
 		return time.Time{}, false
@@ -134,6 +139,26 @@ func parseNextWeekday(input string, ref time.Time) (time.Time, bool) {
 	return setTo5PM(result), true
 }
 
+// parseWeekday handles "monday", "tuesday", etc., the coming occurrence of
+// that day, which is today when today is that day
+func parseWeekday(input string, ref time.Time) (time.Time, bool) {
+	targetWeekday, ok := weekdays[input]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	daysUntil := (int(targetWeekday) - int(ref.Weekday()) + 7) % 7
+	return setTo5PM(ref.AddDate(0, 0, daysUntil)), true
+}
+
+// nextWorkday returns the first Monday-to-Friday day after ref that is not
+// a holiday
+func nextWorkday(ref time.Time) time.Time {
+	c := currentCalendar()
+	c.SkipWeekends = true
+	return c.RollForward(ref.AddDate(0, 0, 1))
+}
+
 // parseInDaysWeeks handles "in N days" and "in N weeks"
 func parseInDaysWeeks(input string, ref time.Time) (time.Time, bool) {
 	// Pattern: "in N day(s)" or "in N week(s)"
@@ -154,7 +179,7 @@ func parseInDaysWeeks(input string, ref time.Time) (time.Time, bool) {
 		days = n * 7
 	}
 
-	result := ref.AddDate(0, 0, days)
+	result := currentCalendar().RollForward(ref.AddDate(0, 0, days))
 	return setTo5PM(result), true
 }
 
@@ -181,6 +206,9 @@ func parseISO(input string, ref time.Time) (time.Time, bool) {
 	}
 
 	result := time.Date(year, time.Month(month), day, 17, 0, 0, 0, time.Local)
+	if result.Month() != time.Month(month) || result.Day() != day {
+		return time.Time{}, false // Out of range, e.g. 2024-13-01
+	}
 	return result, true
 }
 
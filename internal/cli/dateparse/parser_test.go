@@ -35,6 +35,24 @@ func TestParse(t *testing.T) {
 			ref:   ref,
 			want:  time.Date(2024, 1, 14, 17, 0, 0, 0, time.Local),
 		},
+		{
+			name:  "weekday name",
+			input: "wednesday",
+			ref:   ref,
+			want:  time.Date(2024, 1, 17, 17, 0, 0, 0, time.Local),
+		},
+		{
+			name:  "weekday name is today",
+			input: "Monday",
+			ref:   ref,
+			want:  time.Date(2024, 1, 15, 17, 0, 0, 0, time.Local),
+		},
+		{
+			name:    "out of range ISO date",
+			input:   "2024-02-30",
+			ref:     ref,
+			wantErr: true,
+		},
 		{
 			name:  "next week",
 			input: "next week",
@@ -83,6 +101,18 @@ func TestParse(t *testing.T) {
 			ref:   ref,
 			want:  time.Date(2024, 1, 21, 17, 0, 0, 0, time.Local),
 		},
+		{
+			name:  "next weekday",
+			input: "next weekday",
+			ref:   ref,
+			want:  time.Date(2024, 1, 16, 17, 0, 0, 0, time.Local),
+		},
+		{
+			name:  "next weekday from friday skips weekend",
+			input: "next weekday",
+			ref:   time.Date(2024, 1, 19, 10, 0, 0, 0, time.Local),
+			want:  time.Date(2024, 1, 22, 17, 0, 0, 0, time.Local),
+		},
 		{
 			name:  "in 3 days",
 			input: "in 3 days",
@@ -0,0 +1,91 @@
+package dateparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Range is the time from Start up to but not including End. A zero bound
+// leaves that side of the range open.
+type Range struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether t falls within the range
+func (r Range) Contains(t time.Time) bool {
+	return (r.Start.IsZero() || !t.Before(r.Start)) && (r.End.IsZero() || t.Before(r.End))
+}
+
+// ParseRange parses a date range. It accepts:
+//   - "a..b", the days from a through b, where either side may be left out
+//     for an open range and both are dates Parse understands
+//   - "overdue", everything before now
+//   - "week" or "this week", today and the six days after it
+//   - "this month" and "next month", the rest of this month and all of the next
+//   - any single date Parse understands, such as "friday" or "2025-06-15",
+//     which is open at the start and ends with that day
+func ParseRange(input string) (Range, error) {
+	return ParseRangeWithReference(input, time.Now())
+}
+
+// ParseRangeWithReference parses a range relative to a reference time (useful for testing).
+func ParseRangeWithReference(input string, ref time.Time) (Range, error) {
+	normalized := strings.ToLower(strings.TrimSpace(input))
+	today := startOfDay(ref)
+
+	if from, to, ok := strings.Cut(normalized, ".."); ok {
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if from == "" && to == "" {
+			return Range{}, fmt.Errorf("empty date range: %s", input)
+		}
+		var r Range
+		if from != "" {
+			start, err := ParseWithReference(from, ref)
+			if err != nil {
+				return Range{}, err
+			}
+			r.Start = startOfDay(start)
+		}
+		if to != "" {
+			end, err := ParseWithReference(to, ref)
+			if err != nil {
+				return Range{}, err
+			}
+			r.End = startOfDay(end).AddDate(0, 0, 1)
+		}
+		if !r.Start.IsZero() && !r.End.IsZero() && !r.Start.Before(r.End) {
+			return Range{}, fmt.Errorf("date range ends before it starts: %s", input)
+		}
+		return r, nil
+	}
+
+	switch normalized {
+	case "overdue":
+		return Range{End: ref}, nil
+	case "week", "this week":
+		return Range{Start: today, End: today.AddDate(0, 0, 7)}, nil
+	case "this month":
+		return Range{Start: today, End: firstOfMonth(today).AddDate(0, 1, 0)}, nil
+	case "next month":
+		next := firstOfMonth(today).AddDate(0, 1, 0)
+		return Range{Start: next, End: next.AddDate(0, 1, 0)}, nil
+	}
+
+	day, err := ParseWithReference(input, ref)
+	if err != nil {
+		return Range{}, err
+	}
+	return Range{End: startOfDay(day).AddDate(0, 0, 1)}, nil
+}
+
+// startOfDay returns midnight local time on the day of t
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// firstOfMonth returns midnight local time on the first day of the month of t
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,68 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	// Reference time: Monday, January 15, 2024, 10:00 AM
+	ref := time.Date(2024, 1, 15, 10, 0, 0, 0, time.Local)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		input string
+		want  Range
+	}{
+		{"today", Range{End: day(time.January, 16)}},
+		{"friday", Range{End: day(time.January, 20)}},
+		{"monday", Range{End: day(time.January, 16)}},
+		{"2024-01-20..2024-01-25", Range{Start: day(time.January, 20), End: day(time.January, 26)}},
+		{"today..friday", Range{Start: day(time.January, 15), End: day(time.January, 20)}},
+		{"..jan 31", Range{End: day(time.February, 1)}},
+		{"tomorrow..", Range{Start: day(time.January, 16)}},
+		{"overdue", Range{End: ref}},
+		{"week", Range{Start: day(time.January, 15), End: day(time.January, 22)}},
+		{"this month", Range{Start: day(time.January, 15), End: day(time.February, 1)}},
+		{"Next Month", Range{Start: day(time.February, 1), End: day(time.March, 1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRangeWithReference(tt.input, ref)
+			if err != nil {
+				t.Fatalf("ParseRangeWithReference(%q) error = %v", tt.input, err)
+			}
+			if !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+				t.Errorf("ParseRangeWithReference(%q) = %v..%v, want %v..%v", tt.input, got.Start, got.End, tt.want.Start, tt.want.End)
+			}
+		})
+	}
+}
+
+func TestParseRange_Invalid(t *testing.T) {
+	ref := time.Date(2024, 1, 15, 10, 0, 0, 0, time.Local)
+	for _, input := range []string{"", "..", "someday", "2024-01-25..2024-01-20", "today..whenever"} {
+		if _, err := ParseRangeWithReference(input, ref); err == nil {
+			t.Errorf("ParseRangeWithReference(%q) error = nil, want an error", input)
+		}
+	}
+}
+
+func TestRange_Contains(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 1, 16, 0, 0, 0, 0, time.Local)
+	r := Range{Start: start, End: end}
+
+	if !r.Contains(start) {
+		t.Error("Contains(start) = false, want true")
+	}
+	if r.Contains(end) {
+		t.Error("Contains(end) = true, want false")
+	}
+	if !(Range{End: end}).Contains(start.AddDate(-1, 0, 0)) {
+		t.Error("open start range should contain earlier times")
+	}
+}
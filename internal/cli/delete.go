@@ -11,34 +11,46 @@ func NewDeleteCommand() *cobra.Command {
 	var forceFlag bool
 
 	cmd := &cobra.Command{
-		Use:   "delete <task-id> [task-id...] [flags]",
+		Use:   "delete [task-id...] [flags]",
 		Short: "Delete tasks from OmniFocus",
 		Long: `Delete one or more tasks from OmniFocus.
 
-Accepts one or more task IDs as arguments. By default, prompts for confirmation
+Accepts one or more task IDs, or the numbers the last tasks listing printed,
+as arguments. By default, prompts for confirmation
 before deleting. Use --force to skip confirmation.
 
 In JSON mode, confirmation is automatically skipped.
 
-Examples:
-  lazyfocus delete abc123 --force
+With --interactive and no IDs, pick the task from a list of incomplete tasks,
+typing to fuzzy-search their names. The picked task is confirmed by name
+instead of requiring --force.`,
+		Example: `  lazyfocus delete abc123 --force
   lazyfocus delete task1 task2 task3 --force
-  lazyfocus delete abc123 --json`,
-		Args: cobra.MinimumNArgs(1),
+  lazyfocus delete 2 --force
+  lazyfocus delete abc123 --json
+  lazyfocus delete --interactive`,
+		Args: taskIDArgs(1, true),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runDelete(cmd, args, forceFlag)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation")
+	addInteractiveFlag(cmd)
 
 	return cmd
 }
 
 func runDelete(cmd *cobra.Command, args []string, forceFlag bool) error {
+	ctx := cmd.Context()
+
 	// Skip confirmation in JSON mode or quiet mode
 	skipConfirmation := forceFlag || GetJSONFlag() || GetQuietFlag()
 
+	if len(args) == 0 {
+		return runDeleteInteractive(cmd, skipConfirmation)
+	}
+
 	// If not skipping, we would prompt here
 	// For now, we require --force for non-interactive mode
 	// In a real implementation, we'd use a prompt library
@@ -48,6 +60,11 @@ func runDelete(cmd *cobra.Command, args []string, forceFlag bool) error {
 		return fmt.Errorf("confirmation required: use --force to delete without confirmation")
 	}
 
+	args, err := resolveTaskIDs(args)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
 	// Get service
 	svc, err := getServiceFromCmd(cmd)
 	if err != nil {
@@ -58,15 +75,20 @@ func runDelete(cmd *cobra.Command, args []string, forceFlag bool) error {
 	var lastError error
 	successCount := 0
 
+	reporter := newProgressReporter(cmd, len(args))
+	reporter.Start("Deleting tasks", len(args))
+	defer reporter.Finish()
+
 	// Attempt to delete each task
 	for _, taskID := range args {
-		result, err := svc.DeleteTask(taskID)
+		result, err := svc.DeleteTask(ctx, taskID)
+		reporter.Increment()
 		if err != nil {
 			lastError = err
 			// In non-quiet mode, show the error
 			if !GetQuietFlag() {
-				formatter := getFormatter()
-				cmd.Print(formatter.FormatError(fmt.Errorf("failed to delete %s: %w", taskID, err)))
+				reporter.Clear()
+				printError(cmd, fmt.Errorf("failed to delete %s: %w", taskID, err))
 			}
 			continue
 		}
@@ -75,16 +97,38 @@ func runDelete(cmd *cobra.Command, args []string, forceFlag bool) error {
 
 		// Format and output result
 		if !GetQuietFlag() {
+			reporter.Clear()
 			formatter := getFormatter()
-			outputStr := formatter.FormatDeletedTask(*result)
-			cmd.Print(outputStr)
+			if err := formatter.FormatDeletedTask(ctx, cmd.OutOrStdout(), *result); err != nil {
+				return err
+			}
 		}
 	}
 
 	// If all tasks failed, return the last error
 	if successCount == 0 && lastError != nil {
-		return lastError
+		return printed(lastError)
 	}
 
 	return nil
 }
+
+// runDeleteInteractive deletes a task picked from the list, confirming it by
+// name unless skipConfirmation
+func runDeleteInteractive(cmd *cobra.Command, skipConfirmation bool) error {
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	task, ok, err := pickTask(cmd, svc, "Delete which task?")
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	if !ok || (!skipConfirmation && !confirmTask(cmd, "Delete", task)) {
+		cmd.Println("Cancelled")
+		return nil
+	}
+
+	return runDelete(cmd, []string{task.ID}, true)
+}
@@ -247,3 +247,20 @@ func executeDeleteCommand(mockService service.OmniFocusService, args []string) (
 
 	return output, exitCode, err
 }
+
+func TestDeleteCommand_Interactive(t *testing.T) {
+	stubPicker(t, "Pay rent")
+	mockService := &service.MockOmniFocusService{
+		AllTasks:     pickerTasks(),
+		DeleteResult: &domain.OperationResult{Success: true, ID: "t1", Message: "Task deleted"},
+	}
+
+	_, _, err := executeDeleteCommand(mockService, []string{"--interactive", "--force"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.DeletedIDs) != 1 || mockService.DeletedIDs[0] != "t1" {
+		t.Errorf("DeletedIDs = %v, want [t1]", mockService.DeletedIDs)
+	}
+}
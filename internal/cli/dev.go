@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/fixtures"
+	"github.com/spf13/cobra"
+)
+
+// defaultFixturesDir is where record-fixtures writes by default
+const defaultFixturesDir = "testdata/fixtures"
+
+// recordExecutor creates the executor fixtures are recorded through; tests
+// replace it
+var recordExecutor = newOSAScriptExecutor
+
+// NewDevCommand creates the hidden dev command, which groups tools for
+// working on lazyfocus itself
+func NewDevCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "dev",
+		Short:  "Tools for developing lazyfocus",
+		Args:   cobra.NoArgs,
+		Hidden: true,
+	}
+
+	cmd.AddCommand(newRecordFixturesCommand())
+
+	return cmd
+}
+
+// newRecordFixturesCommand creates the dev record-fixtures command
+func newRecordFixturesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record-fixtures",
+		Short: "Record anonymized script output from OmniFocus as test fixtures",
+		Long: `Run each read script against your OmniFocus database and write its output to
+<script>.json in --dir, with task, project, tag and folder names replaced by
+"Task 1", "Project 1", … and the words of notes replaced by filler. IDs,
+dates, flags and statuses are kept, and a name gets the same replacement in
+every fixture.
+
+Scripts looking up one item are recorded for the first task, project and tag
+listed. Set LAZYFOCUS_FIXTURES to the directory to run lazyfocus against the
+fixtures instead of OmniFocus. Review the fixtures before committing them.`,
+		Example: `  lazyfocus dev record-fixtures
+  lazyfocus dev record-fixtures --dir /tmp/fixtures
+  LAZYFOCUS_FIXTURES=testdata/fixtures lazyfocus tasks --all`,
+		Args: cobra.NoArgs,
+		RunE: runRecordFixtures,
+	}
+
+	cmd.Flags().String("dir", defaultFixturesDir, "Directory to write the fixtures to")
+
+	return cmd
+}
+
+// fixtureRecorder runs scripts and writes their anonymized output
+type fixtureRecorder struct {
+	cmd        *cobra.Command
+	executor   bridge.Executor
+	anonymizer *fixtures.Anonymizer
+	dir        string
+	recorded   int
+}
+
+// fixtureScript is a read script to record and the parameters to run it with
+type fixtureScript struct {
+	name   string
+	params map[string]string
+}
+
+func runRecordFixtures(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	dir, _ := cmd.Flags().GetString("dir")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return handleError(cmd, fmt.Errorf("failed to create fixtures directory: %w", err))
+	}
+
+	cfg, _ := config.FromContext(ctx)
+	r := &fixtureRecorder{
+		cmd:        cmd,
+		executor:   recordExecutor(cfg),
+		anonymizer: fixtures.NewAnonymizer(),
+		dir:        dir,
+	}
+
+	// Listings first; their first task, project and tag are looked up next
+	since := time.Now().AddDate(0, 0, -14).Format("2006-01-02")
+	outputs := make(map[string]string)
+	for _, script := range []fixtureScript{
+		{name: "get_inbox_tasks"},
+		{name: "get_all_tasks"},
+		{name: "get_flagged_tasks"},
+		{name: "get_completed_tasks", params: map[string]string{"Since": since}},
+		{name: "get_perspective_tasks", params: map[string]string{"PerspectiveName": "Flagged"}},
+		{name: "get_projects"},
+		{name: "get_folders"},
+		{name: "get_tags"},
+		{name: "get_tag_counts"},
+	} {
+		output, err := r.record(ctx, script)
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		outputs[script.name] = output
+	}
+
+	var lookups []fixtureScript
+	if tasks, err := bridge.ParseTasks(outputs["get_all_tasks"]); err == nil && len(tasks) > 0 {
+		params := map[string]string{"TaskID": tasks[0].ID}
+		lookups = append(lookups, fixtureScript{name: "get_task_by_id", params: params})
+	}
+	if projects, err := bridge.ParseProjects(outputs["get_projects"]); err == nil && len(projects) > 0 {
+		params := map[string]string{"ProjectID": projects[0].ID}
+		lookups = append(lookups,
+			fixtureScript{name: "get_project_by_id", params: params},
+			fixtureScript{name: "get_project_with_tasks", params: params},
+			fixtureScript{name: "get_tasks_by_project", params: params},
+			fixtureScript{name: "get_task_hierarchy", params: params})
+	}
+	if tags, err := bridge.ParseTags(outputs["get_tags"]); err == nil && len(tags) > 0 {
+		params := map[string]string{"TagID": tags[0].ID}
+		lookups = append(lookups,
+			fixtureScript{name: "get_tag_by_id", params: params},
+			fixtureScript{name: "get_tasks_by_tag", params: params})
+	}
+	for _, script := range lookups {
+		if _, err := r.record(ctx, script); err != nil {
+			return handleError(cmd, err)
+		}
+	}
+
+	if !GetQuietFlag() {
+		cmd.Printf("✓ Recorded %d fixtures in %s; review them before committing\n", r.recorded, dir)
+	}
+	return nil
+}
+
+// record runs a script and writes its anonymized output, returning the
+// output as OmniFocus gave it
+func (r *fixtureRecorder) record(ctx context.Context, script fixtureScript) (string, error) {
+	source, err := bridge.GetScriptWithParams(script.name, script.params)
+	if err != nil {
+		return "", fmt.Errorf("failed to load %s: %w", script.name, err)
+	}
+	output, err := r.executor.ExecuteWithTimeout(ctx, source, GetTimeoutFlag())
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s: %w", script.name, err)
+	}
+	if err := bridge.ResponseError(output); err != nil {
+		return "", fmt.Errorf("%s failed: %w", script.name, err)
+	}
+
+	data, err := r.anonymizer.Anonymize([]byte(output))
+	if err != nil {
+		return "", fmt.Errorf("failed to anonymize %s: %w", script.name, err)
+	}
+	path := filepath.Join(r.dir, script.name+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write fixture: %w", err)
+	}
+	r.recorded++
+
+	if !GetQuietFlag() {
+		r.cmd.Printf("  %s\n", path)
+	}
+	return output, nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestDevRecordFixtures_AnonymizesAndReplays(t *testing.T) {
+	// The "real" database, answered from fixtures of its own
+	real := t.TempDir()
+	outputs := map[string]string{
+		"get_inbox_tasks":       `{"tasks": []}`,
+		"get_all_tasks":         `{"tasks": [{"id": "t1", "name": "Call the dentist", "note": "Ask about Friday", "projectName": "Health", "flagged": true}]}`,
+		"get_flagged_tasks":     `{"tasks": []}`,
+		"get_completed_tasks":   `{"tasks": []}`,
+		"get_perspective_tasks": `{"tasks": []}`,
+		"get_projects":          `{"projects": []}`,
+		"get_folders":           `{"folders": []}`,
+		"get_tags":              `{"tags": []}`,
+		"get_tag_counts":        `{"tags": []}`,
+		"get_task_by_id":        `{"task": {"id": "t1", "name": "Call the dentist"}}`,
+	}
+	for name, output := range outputs {
+		if err := os.WriteFile(filepath.Join(real, name+".json"), []byte(output), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	original := recordExecutor
+	recordExecutor = func(*config.Config) bridge.Executor { return bridge.NewFixtureExecutor(real) }
+	t.Cleanup(func() { recordExecutor = original })
+
+	dir := filepath.Join(t.TempDir(), "fixtures")
+	output, err := executeWithoutService(NewDevCommand(), "dev", "record-fixtures", "--dir", dir)
+	if err != nil {
+		t.Fatalf("record-fixtures error = %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Recorded 10 fixtures") {
+		t.Errorf("output = %q, want 10 fixtures recorded", output)
+	}
+
+	recorded, err := os.ReadFile(filepath.Join(dir, "get_all_tasks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, private := range []string{"dentist", "Friday", "Health"} {
+		if strings.Contains(string(recorded), private) {
+			t.Errorf("fixture still contains %q:\n%s", private, recorded)
+		}
+	}
+
+	// The fixtures answer in place of OmniFocus
+	t.Setenv(fixturesEnv, dir)
+	output, err = executeWithoutService(NewTasksCommand(), "tasks", "--all")
+	if err != nil {
+		t.Fatalf("tasks error = %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Task 1") {
+		t.Errorf("tasks output = %q, want the recorded task", output)
+	}
+}
+
+// executeWithoutService runs args on a root command with cmd added and no
+// service in the context, so the root creates one as it would for users
+func executeWithoutService(cmd *cobra.Command, args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(cmd)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(args)
+
+	ctx := config.ContextWithConfig(context.Background(), &config.Config{})
+	err := rootCmd.ExecuteContext(ctx)
+	return buf.String(), err
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/digest"
+	"github.com/spf13/cobra"
+)
+
+// sendMail delivers a message through the SMTP server; replaced in tests
+var sendMail = digest.Send
+
+// lookupPassword reads the SMTP password from the keychain; replaced in tests
+var lookupPassword = digest.KeychainPassword
+
+// digestResult is the JSON output of the digest command
+type digestResult struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+}
+
+// NewDigestCommand creates the digest command
+func NewDigestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Email the weekly digest",
+		Long: `Build the weekly digest and send it by email.
+
+The digest is a Markdown report of the tasks completed in the last 7 days,
+overdue tasks, tasks due in the next 7 days, flagged tasks and projected
+project completion dates. Without a recipient it is printed instead.
+
+Mail is sent through the server under digest.smtp in the config file. The
+password is read from the macOS keychain item named by digest.smtp.keychain
+(default "lazyfocus-smtp") for the account digest.smtp.username:
+
+  security add-generic-password -s lazyfocus-smtp -a me@example.com -w
+
+To send the digest every week, schedule the "digest" action for
+'lazyfocus serve' or run this command from a launchd agent.`,
+		Example: `  lazyfocus digest
+  lazyfocus digest --email me@example.com`,
+		RunE: runDigest,
+	}
+
+	cmd.Flags().String("email", "", "Recipient of the digest (default: digest.to from the config)")
+
+	return cmd
+}
+
+func runDigest(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.FromContext(ctx)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	to, _ := cmd.Flags().GetString("email")
+	if to == "" {
+		to = cfg.Digest.To
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	report, err := weeklyDigest(ctx, svc, time.Now())
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	if to == "" {
+		if !GetQuietFlag() {
+			cmd.Print(report.Markdown())
+		}
+		return nil
+	}
+
+	if err := emailDigest(ctx, cfg.Digest.SMTP, to, report); err != nil {
+		return handleError(cmd, err)
+	}
+
+	switch {
+	case GetJSONFlag():
+		data, err := json.MarshalIndent(digestResult{To: to, Subject: report.Subject()}, "", "  ")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to encode result: %w", err))
+		}
+		cmd.Println(string(data))
+	case !GetQuietFlag():
+		cmd.Printf("✓ Sent the weekly digest to %s\n", to)
+	}
+	return nil
+}
+
+// weeklyDigest builds the digest of the week ending at now
+func weeklyDigest(ctx context.Context, svc service.OmniFocusService, now time.Time) (digest.Report, error) {
+	completed, err := svc.GetCompletedTasks(ctx, now.AddDate(0, 0, -digest.Days))
+	if err != nil {
+		return digest.Report{}, fmt.Errorf("failed to get completed tasks: %w", err)
+	}
+	remaining, err := svc.GetAllTasks(ctx, service.TaskFilters{})
+	if err != nil {
+		return digest.Report{}, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	projects, err := svc.GetProjects(ctx, "active")
+	if err != nil {
+		return digest.Report{}, fmt.Errorf("failed to get projects: %w", err)
+	}
+	return digest.NewReport(completed, remaining, projects, now), nil
+}
+
+// emailDigest sends the digest to the recipient through the SMTP server
+func emailDigest(ctx context.Context, smtpCfg config.SMTPConfig, to string, report digest.Report) error {
+	if smtpCfg.Host == "" {
+		return errors.New("no SMTP server configured: set digest.smtp.host in the config file")
+	}
+
+	from := smtpCfg.From
+	if from == "" {
+		from = smtpCfg.Username
+	}
+	if from == "" {
+		return errors.New("no sender configured: set digest.smtp.from or digest.smtp.username in the config file")
+	}
+
+	server := digest.SMTP{Host: smtpCfg.Host, Port: smtpCfg.Port, Username: smtpCfg.Username}
+	if server.Username != "" {
+		password, err := lookupPassword(smtpCfg.Keychain, server.Username)
+		if err != nil {
+			return err
+		}
+		server.Password = password
+	}
+
+	msg, err := digest.Message(from, to, report.Subject(), report.Markdown(), report.End)
+	if err != nil {
+		return err
+	}
+	return sendMail(ctx, server, from, []string{to}, msg)
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/digest"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func executeDigestCommand(cfg *config.Config, mockService service.OmniFocusService, args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewDigestCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(append([]string{"digest"}, args...))
+
+	ctx := config.ContextWithConfig(context.Background(), cfg)
+	err := rootCmd.ExecuteContext(ContextWithService(ctx, mockService))
+	return buf.String(), err
+}
+
+// sentMail is a message captured by stubMail
+type sentMail struct {
+	server digest.SMTP
+	from   string
+	to     []string
+	msg    string
+}
+
+// stubMail captures sent mail and answers keychain lookups with "s3cret"
+func stubMail(t *testing.T) *[]sentMail {
+	t.Helper()
+	var sent []sentMail
+	originalSend, originalLookup := sendMail, lookupPassword
+	sendMail = func(ctx context.Context, server digest.SMTP, from string, to []string, msg []byte) error {
+		sent = append(sent, sentMail{server: server, from: from, to: to, msg: string(msg)})
+		return nil
+	}
+	lookupPassword = func(service, account string) (string, error) {
+		return "s3cret", nil
+	}
+	t.Cleanup(func() { sendMail, lookupPassword = originalSend, originalLookup })
+	return &sent
+}
+
+func digestTestConfig() *config.Config {
+	return &config.Config{Digest: config.DigestConfig{SMTP: config.SMTPConfig{
+		Host: "smtp.example.com", Port: 587, Username: "me@example.com", Keychain: "lazyfocus-smtp",
+	}}}
+}
+
+func TestDigestCommand_SendsEmail(t *testing.T) {
+	sent := stubMail(t)
+	mockService := &service.MockOmniFocusService{
+		CompletedTasks: []domain.Task{{ID: "t1", Name: "Ship release"}},
+		Projects:       []domain.Project{{ID: "p1", Name: "Website", Status: "active"}},
+	}
+
+	output, err := executeDigestCommand(digestTestConfig(), mockService, "--email", "boss@example.com")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, "Sent the weekly digest to boss@example.com") {
+		t.Errorf("Expected a confirmation, got: %s", output)
+	}
+
+	if len(*sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(*sent))
+	}
+	mail := (*sent)[0]
+	if mail.from != "me@example.com" || mail.to[0] != "boss@example.com" || mail.server.Password != "s3cret" {
+		t.Errorf("sent mail = %+v, want from the username to --email with the keychain password", mail)
+	}
+	if !strings.Contains(mail.msg, "Ship release") || !strings.Contains(mail.msg, "Website") {
+		t.Errorf("message should contain the weekly report, got:\n%s", mail.msg)
+	}
+}
+
+func TestDigestCommand_PrintsWithoutRecipient(t *testing.T) {
+	sent := stubMail(t)
+	mockService := &service.MockOmniFocusService{
+		CompletedTasks: []domain.Task{{ID: "t1", Name: "Ship release"}},
+	}
+
+	output, err := executeDigestCommand(&config.Config{}, mockService)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.HasPrefix(output, "# Weekly digest") || !strings.Contains(output, "- Ship release") {
+		t.Errorf("Expected the Markdown digest, got: %s", output)
+	}
+	if len(*sent) != 0 {
+		t.Errorf("sent %d messages, want none", len(*sent))
+	}
+}
+
+func TestDigestCommand_RequiresSMTPHost(t *testing.T) {
+	stubMail(t)
+	cfg := &config.Config{Digest: config.DigestConfig{To: "me@example.com"}}
+
+	_, err := executeDigestCommand(cfg, &service.MockOmniFocusService{})
+	if err == nil || !strings.Contains(err.Error(), "digest.smtp.host") {
+		t.Errorf("Expected a missing SMTP server error, got: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/docgen"
+	"github.com/spf13/cobra"
+)
+
+// NewGenDocsCommand creates the hidden gen-docs command used at release time
+func NewGenDocsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen-docs",
+		Short: "Generate man pages for every command",
+		Long: `Generate a section 1 man page for lazyfocus and each of its commands from
+the same metadata that drives --help: descriptions, flags, examples, exit codes
+and environment variables.`,
+		Example: `  lazyfocus gen-docs --dir manpages
+  man ./manpages/lazyfocus-tasks.1`,
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		Annotations: map[string]string{
+			"skipServiceSetup": "true",
+		},
+		RunE: runGenDocs,
+	}
+
+	cmd.Flags().String("dir", "manpages", "Directory to write the man pages to")
+
+	return cmd
+}
+
+func runGenDocs(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+
+	pages, err := docgen.GenerateMan(cmd.Root(), dir, docsMetadata())
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	if !GetQuietFlag() {
+		cmd.Printf("✓ Wrote %d man pages to %s\n", len(pages), dir)
+	}
+	return nil
+}
+
+// docsMetadata returns the exit codes and environment variables documented
+// by --help and the man pages
+func docsMetadata() docgen.Metadata {
+	meta := docgen.Metadata{Version: Version, Date: time.Now()}
+	if date, err := time.Parse(time.RFC3339, BuildDate); err == nil {
+		meta.Date = date
+	}
+	for _, exit := range output.ExitCodes {
+		meta.ExitCodes = append(meta.ExitCodes, docgen.Entry{Name: fmt.Sprint(exit.Code), Description: exit.Description})
+	}
+	for _, env := range config.EnvVars() {
+		meta.Environment = append(meta.Environment, docgen.Entry{Name: env.Name, Description: env.Description})
+	}
+	return meta
+}
+
+// helpSections renders the exit codes and environment variables appended to
+// every command's --help
+func helpSections() string {
+	meta := docsMetadata()
+	var b strings.Builder
+	writeSection := func(title string, entries []docgen.Entry) {
+		width := 0
+		for _, entry := range entries {
+			width = max(width, len(entry.Name))
+		}
+		b.WriteString("\n" + title + ":\n")
+		for _, entry := range entries {
+			fmt.Fprintf(&b, "  %-*s  %s\n", width, entry.Name, entry.Description)
+		}
+	}
+	writeSection("Exit Codes", meta.ExitCodes)
+	writeSection("Environment", meta.Environment)
+	return b.String()
+}
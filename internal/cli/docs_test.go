@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/docgen"
+	"github.com/spf13/cobra"
+)
+
+// TestCommands_HelpMetadata keeps new commands from shipping without the
+// metadata their --help and man page are generated from
+func TestCommands_HelpMetadata(t *testing.T) {
+	root := NewRootCommand()
+	AddCommands(root)
+
+	var check func(cmd *cobra.Command)
+	check = func(cmd *cobra.Command) {
+		if !docgen.Documented(cmd) || cmd.Name() == "help" {
+			return
+		}
+		if cmd.Short == "" || cmd.Long == "" {
+			t.Errorf("%s: missing Short or Long description", cmd.CommandPath())
+		}
+		if cmd.Runnable() && cmd.HasParent() && cmd.Example == "" {
+			t.Errorf("%s: missing Example", cmd.CommandPath())
+		}
+		for _, child := range cmd.Commands() {
+			check(child)
+		}
+	}
+	check(root)
+}
+
+func TestHelp_ExitCodesAndEnvironment(t *testing.T) {
+	root := NewRootCommand()
+	AddCommands(root)
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetArgs([]string{"tasks", "--help"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"Examples:", "Exit Codes:", "3    Requested item not found", "130  Interrupted", "Environment:", "LAZYFOCUS_TIMEOUT"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("tasks --help missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenDocsCommand(t *testing.T) {
+	root := NewRootCommand()
+	AddCommands(root)
+	dir := t.TempDir()
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetArgs([]string{"gen-docs", "--dir", dir})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "man pages to "+dir) {
+		t.Errorf("Expected summary, got: %s", buf.String())
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "lazyfocus-tags-add.1"))
+	if err != nil {
+		t.Fatalf("Expected a page for tags add: %v", err)
+	}
+	for _, want := range []string{".SH EXAMPLES", "lazyfocus tags add Errands", ".SH EXIT STATUS", "LAZYFOCUS_TIMEOUT"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("lazyfocus-tags-add.1 missing %q", want)
+		}
+	}
+	for _, hidden := range []string{"lazyfocus-gen-docs.1", "lazyfocus-flush.1"} {
+		if _, err := os.Stat(filepath.Join(dir, hidden)); err == nil {
+			t.Errorf("Expected no page for hidden command %s", hidden)
+		}
+	}
+}
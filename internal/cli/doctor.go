@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/health"
+	"github.com/spf13/cobra"
+)
+
+// Checks run by doctor; tests replace them
+var (
+	lookPath        = exec.LookPath
+	checkAutomation = bridge.CheckAutomationPermission
+	findJournals    = health.NewChecker().Journals
+)
+
+// NewDoctorCommand creates the doctor command
+func NewDoctorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that lazyfocus can talk to OmniFocus",
+		Long: `Check that osascript is available, that OmniFocus is running, and that macOS
+allows your terminal to control OmniFocus (the Automation permission). Also
+reports changes an earlier TUI session queued but never saved. Each failed
+check prints how to fix it, and the command exits non-zero.`,
+		Example: `  lazyfocus doctor
+  lazyfocus doctor --json`,
+		Args: cobra.NoArgs,
+		Annotations: map[string]string{
+			"skipServiceSetup": "true",
+		},
+		RunE: runDoctor,
+	}
+
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	checks := health.Checker{
+		LookPath:        lookPath,
+		CheckAutomation: checkAutomation,
+		Journals:        findJournals,
+	}.Run(cmd.Context()).Checks
+
+	failed := 0
+	for _, check := range checks {
+		if !check.OK {
+			failed++
+		}
+	}
+
+	switch {
+	case GetJSONFlag():
+		data, err := json.MarshalIndent(map[string][]health.Check{"checks": checks}, "", "  ")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to encode checks: %w", err))
+		}
+		cmd.Println(string(data))
+	case !GetQuietFlag():
+		for _, check := range checks {
+			mark := "✓"
+			if !check.OK {
+				mark = "✗"
+			}
+			cmd.Printf("%s %s: %s\n", mark, check.Name, check.Detail)
+			if check.Fix != "" {
+				cmd.Printf("\n%s\n\n", check.Fix)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+)
+
+func executeDoctorCommand(args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewDoctorCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs(append([]string{"doctor"}, args...))
+
+	err := rootCmd.ExecuteContext(context.Background())
+	return buf.String(), err
+}
+
+// stubDoctorChecks makes doctor find osascript unless pathErr is set, and
+// report automationErr from the permission check, with no pending writes
+// left behind
+func stubDoctorChecks(t *testing.T, pathErr, automationErr error) {
+	t.Helper()
+	originalLookPath, originalCheck, originalJournals := lookPath, checkAutomation, findJournals
+	lookPath = func(string) (string, error) { return "/usr/bin/osascript", pathErr }
+	checkAutomation = func(context.Context) error { return automationErr }
+	findJournals = func() ([]string, error) { return nil, nil }
+	t.Cleanup(func() {
+		lookPath, checkAutomation, findJournals = originalLookPath, originalCheck, originalJournals
+	})
+}
+
+func TestDoctorCommand_AllChecksPass(t *testing.T) {
+	stubDoctorChecks(t, nil, nil)
+
+	output, err := executeDoctorCommand()
+	if err != nil {
+		t.Fatalf("doctor error = %v", err)
+	}
+	for _, want := range []string{"✓ osascript: /usr/bin/osascript", "✓ OmniFocus: running", "✓ Automation permission: granted"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output = %q, want %q", output, want)
+		}
+	}
+}
+
+func TestDoctorCommand_PermissionDenied(t *testing.T) {
+	denied := fmt.Errorf("%w: %s", bridge.ErrAutomationNotPermitted, "(-1743)")
+	stubDoctorChecks(t, nil, denied)
+
+	output, err := executeDoctorCommand()
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 checks failed") {
+		t.Errorf("doctor error = %v, want 1 of 3 checks failed", err)
+	}
+	if !strings.Contains(output, "✗ Automation permission: denied") || !strings.Contains(output, "Privacy & Security → Automation") {
+		t.Errorf("output = %q, want the denied check and its fix", output)
+	}
+}
+
+func TestDoctorCommand_OmniFocusNotRunning(t *testing.T) {
+	stubDoctorChecks(t, nil, bridge.ErrOmniFocusNotRunning)
+
+	output, err := executeDoctorCommand("--json")
+	if err == nil {
+		t.Error("doctor error = nil, want a failed check")
+	}
+	if !strings.Contains(output, `"detail": "not running"`) || strings.Contains(output, `"name": "Automation permission"`) {
+		t.Errorf("output = %q, want OmniFocus not running and no permission check", output)
+	}
+}
+
+func TestDoctorCommand_OSAScriptMissing(t *testing.T) {
+	stubDoctorChecks(t, exec.ErrNotFound, errors.New("should not run"))
+
+	output, err := executeDoctorCommand()
+	if err == nil || !strings.Contains(err.Error(), "1 of 1 checks failed") {
+		t.Errorf("doctor error = %v, want 1 of 1 checks failed", err)
+	}
+	if !strings.Contains(output, "✗ osascript: not found") {
+		t.Errorf("output = %q, want osascript not found", output)
+	}
+}
+
+func TestDoctorCommand_PendingWritesLeftBehind(t *testing.T) {
+	stubDoctorChecks(t, nil, nil)
+	findJournals = func() ([]string, error) { return []string{"/tmp/lazyfocus-pending-1.json"}, nil }
+
+	output, err := executeDoctorCommand()
+	if err == nil || !strings.Contains(err.Error(), "1 of 4 checks failed") {
+		t.Errorf("doctor error = %v, want 1 of 4 checks failed", err)
+	}
+	if !strings.Contains(output, "✗ Pending writes:") || !strings.Contains(output, "lazyfocus flush /tmp/lazyfocus-pending-1.json") {
+		t.Errorf("output = %q, want the pending writes and how to flush them", output)
+	}
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/spf13/cobra"
+)
+
+// NewDoneCommand creates the done command
+func NewDoneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "done <name>",
+		Short: "Complete the task best matching a name",
+		Long: `Complete the incomplete task whose name best matches <name>, fuzzily and
+ignoring case, so "pay rent" finds "Pay rent for March".
+
+The matched task is shown and must be confirmed; --yes completes it without
+asking, as do --json and --quiet. Without a match the command exits non-zero.`,
+		Example: `  lazyfocus done "pay rent"
+  lazyfocus done call dentist --yes
+  lazyfocus done "renew passport" --note "Picked up at the office"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runDone,
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "Complete the matched task without confirmation")
+	cmd.Flags().String("note", "", "Append a closing \"resolution: <note>\" line to the task's note first")
+
+	return cmd
+}
+
+func runDone(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	yes, _ := cmd.Flags().GetBool("yes")
+	note, _ := cmd.Flags().GetString("note")
+	query := strings.Join(args, " ")
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	// A truncated list may still hold the task; search what came back
+	tasks, err := svc.GetAllTasks(ctx, service.TaskFilters{})
+	var truncated *service.TruncatedError
+	if err != nil && !errors.As(err, &truncated) {
+		return handleError(cmd, err)
+	}
+
+	task, ok := bestTaskMatch(tasks, query)
+	if !ok {
+		return handleError(cmd, fmt.Errorf("no incomplete task matches %q", query))
+	}
+
+	if !yes && !GetJSONFlag() && !GetQuietFlag() {
+		if !confirmTask(cmd, "Complete", task) {
+			cmd.Println("Cancelled")
+			return nil
+		}
+	}
+
+	result, err := service.CompleteTaskWithNote(ctx, svc, task.ID, note)
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to complete %q: %w", task.Name, err))
+	}
+
+	if !GetQuietFlag() {
+		if err := getFormatter().FormatCompletedTask(ctx, cmd.OutOrStdout(), *result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bestTaskMatch returns the incomplete task whose name best matches query,
+// the first one listed on a tie
+func bestTaskMatch(tasks []domain.Task, query string) (domain.Task, bool) {
+	var best domain.Task
+	bestScore, found := 0, false
+	for _, task := range domain.FlattenTasks(tasks) {
+		if task.Completed {
+			continue
+		}
+		score, ok := command.FuzzyScore(query, task.Name)
+		if ok && (!found || score > bestScore) {
+			best, bestScore, found = task, score, true
+		}
+	}
+	return best, found
+}
+
+// confirmTask asks on stderr whether to do verb to task, e.g. "Complete",
+// reading the answer from stdin; anything but yes, including end of input,
+// declines
+func confirmTask(cmd *cobra.Command, verb string, task domain.Task) bool {
+	prompt := fmt.Sprintf("%s %q", verb, task.Name)
+	if task.ProjectName != "" {
+		prompt += fmt.Sprintf(" (%s)", task.ProjectName)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s? [y/N]: ", prompt)
+
+	line, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func doneTestService() *service.MockOmniFocusService {
+	return &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "task1", Name: "Call the bank"},
+			{ID: "task2", Name: "Pay rent for March", ProjectName: "Home"},
+			{ID: "task3", Name: "Pay rent", Completed: true},
+		},
+		CompleteResult: &domain.OperationResult{Success: true, ID: "task2", Message: "Task completed"},
+	}
+}
+
+func TestDoneCommand_CompletesBestMatch(t *testing.T) {
+	mockService := doneTestService()
+
+	output, exitCode, err := executeDoneCommand(mockService, "", []string{"pay", "rent", "--yes"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got: %d", exitCode)
+	}
+
+	if len(mockService.CompletedIDs) != 1 || mockService.CompletedIDs[0] != "task2" {
+		t.Errorf("Expected task2 to be completed, got: %v", mockService.CompletedIDs)
+	}
+
+	if !strings.Contains(output, "Completed") {
+		t.Errorf("Expected output to contain 'Completed', got: %s", output)
+	}
+}
+
+func TestDoneCommand_Confirmed(t *testing.T) {
+	mockService := doneTestService()
+
+	output, _, err := executeDoneCommand(mockService, "y\n", []string{"pay rent"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, `Complete "Pay rent for March" (Home)? [y/N]`) {
+		t.Errorf("Expected a confirmation prompt, got: %s", output)
+	}
+
+	if len(mockService.CompletedIDs) != 1 {
+		t.Errorf("Expected the task to be completed, got: %v", mockService.CompletedIDs)
+	}
+}
+
+func TestDoneCommand_Declined(t *testing.T) {
+	for _, answer := range []string{"n\n", "\n", ""} {
+		mockService := doneTestService()
+
+		output, exitCode, err := executeDoneCommand(mockService, answer, []string{"pay rent"})
+
+		if err != nil {
+			t.Fatalf("Expected no error for answer %q, got: %v", answer, err)
+		}
+
+		if exitCode != 0 {
+			t.Errorf("Expected exit code 0 for answer %q, got: %d", answer, exitCode)
+		}
+
+		if len(mockService.CompletedIDs) != 0 {
+			t.Errorf("Expected nothing completed for answer %q, got: %v", answer, mockService.CompletedIDs)
+		}
+
+		if !strings.Contains(output, "Cancelled") {
+			t.Errorf("Expected output to contain 'Cancelled', got: %s", output)
+		}
+	}
+}
+
+func TestDoneCommand_NoMatch(t *testing.T) {
+	mockService := doneTestService()
+
+	_, exitCode, err := executeDoneCommand(mockService, "", []string{"water plants", "--yes"})
+
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if exitCode == 0 {
+		t.Errorf("Expected non-zero exit code, got: %d", exitCode)
+	}
+
+	if !strings.Contains(err.Error(), `no incomplete task matches "water plants"`) {
+		t.Errorf("Expected error about no match, got: %v", err)
+	}
+}
+
+func TestBestTaskMatch(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "a", Name: "Review budget"},
+		{ID: "b", Name: "Review"},
+		{ID: "c", Name: "Parent", Children: []domain.Task{{ID: "d", Name: "Renew passport"}}},
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"review", "b"},
+		{"rev budg", "a"},
+		{"passport", "d"},
+	}
+	for _, tt := range tests {
+		got, ok := bestTaskMatch(tasks, tt.query)
+		if !ok || got.ID != tt.want {
+			t.Errorf("bestTaskMatch(%q) = %q, %v, want %q", tt.query, got.ID, ok, tt.want)
+		}
+	}
+}
+
+// executeDoneCommand runs the done command with input as stdin
+func executeDoneCommand(mockService service.OmniFocusService, input string, args []string) (string, int, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewDoneCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetIn(strings.NewReader(input))
+	rootCmd.SetArgs(append([]string{"done"}, args...))
+
+	ctx := ContextWithService(context.Background(), mockService)
+	err := rootCmd.ExecuteContext(ctx)
+
+	exitCode := 0
+	if err != nil {
+		exitCode = 1
+	}
+	return buf.String(), exitCode, err
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewDropCommand creates the drop command
+func NewDropCommand() *cobra.Command {
+	var projectFlag string
+
+	cmd := &cobra.Command{
+		Use:   "drop [task-id...] [flags]",
+		Short: "Drop tasks or a project in OmniFocus",
+		Long: `Mark one or more tasks, or a project, as dropped in OmniFocus.
+
+Accepts one or more task IDs, or the numbers the last tasks listing printed,
+as arguments. Unlike delete, dropped items stay in the OmniFocus database and
+can be found again there. The command will attempt to drop all specified
+tasks, continuing even if some fail.
+
+With --project, drops the named project along with its remaining tasks
+instead.`,
+		Example: `  lazyfocus drop abc123
+  lazyfocus drop 2 3        # Tasks 2 and 3 of the last lazyfocus tasks
+  lazyfocus drop --project "Old Ideas"
+  lazyfocus drop abc123 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if projectFlag != "" {
+				if len(args) > 0 {
+					return handleError(cmd, invalidInput("--project cannot be combined with task IDs"))
+				}
+				return runDropProject(cmd, projectFlag)
+			}
+			if len(args) == 0 {
+				return handleError(cmd, invalidInput("requires at least 1 task ID, or --project"))
+			}
+			return runDrop(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Drop the project with this name instead of tasks")
+
+	return cmd
+}
+
+func runDrop(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	args, err = resolveTaskIDs(args)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	var lastError error
+	successCount := 0
+	formatter := getFormatter()
+
+	reporter := newProgressReporter(cmd, len(args))
+	reporter.Start("Dropping tasks", len(args))
+	defer reporter.Finish()
+
+	for _, taskID := range args {
+		result, err := svc.DropTask(ctx, taskID)
+		reporter.Increment()
+		if err != nil {
+			lastError = err
+			if !GetQuietFlag() {
+				reporter.Clear()
+				printError(cmd, fmt.Errorf("failed to drop %s: %w", taskID, err))
+			}
+			continue
+		}
+
+		successCount++
+		if !GetQuietFlag() {
+			reporter.Clear()
+			if err := formatter.FormatDropped(ctx, cmd.OutOrStdout(), *result); err != nil {
+				return err
+			}
+		}
+	}
+
+	// If all tasks failed, return the last error
+	if successCount == 0 && lastError != nil {
+		return printed(lastError)
+	}
+
+	return nil
+}
+
+// runDropProject drops the project with the given name
+func runDropProject(cmd *cobra.Command, name string) error {
+	ctx := cmd.Context()
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	projectID, err := svc.ResolveProjectName(ctx, name)
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to resolve project: %w", err))
+	}
+
+	result, err := svc.DropProject(ctx, projectID)
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to drop project %s: %w", name, err))
+	}
+
+	if !GetQuietFlag() {
+		if err := getFormatter().FormatDropped(ctx, cmd.OutOrStdout(), *result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
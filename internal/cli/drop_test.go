@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestDropCommand(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		DropResult: &domain.OperationResult{Success: true, ID: "task123", Message: "Task dropped"},
+	}
+
+	output, err := executeDropCommand(mockService, "drop", "task123", "task456")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if want := []string{"task123", "task456"}; !reflect.DeepEqual(mockService.DroppedIDs, want) {
+		t.Errorf("DropTask() called with %v, want %v", mockService.DroppedIDs, want)
+	}
+	if !strings.Contains(output, "Dropped: task123") {
+		t.Errorf("Expected output to report the dropped task, got: %s", output)
+	}
+}
+
+func TestDropCommand_Project(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		ResolvedProjectID: "proj1",
+		DropProjectResult: &domain.OperationResult{Success: true, ID: "proj1", Message: "Project dropped"},
+	}
+
+	output, err := executeDropCommand(mockService, "drop", "--project", "Old Ideas")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if want := []string{"proj1"}; !reflect.DeepEqual(mockService.DroppedProjectIDs, want) {
+		t.Errorf("DropProject() called with %v, want %v", mockService.DroppedProjectIDs, want)
+	}
+	if len(mockService.DroppedIDs) != 0 {
+		t.Errorf("DropTask() called with %v, want no calls", mockService.DroppedIDs)
+	}
+	if !strings.Contains(output, "Project dropped") {
+		t.Errorf("Expected output to report the dropped project, got: %s", output)
+	}
+}
+
+func TestDropCommand_InvalidArgs(t *testing.T) {
+	for _, args := range [][]string{
+		{"drop"},
+		{"drop", "task123", "--project", "Old Ideas"},
+	} {
+		mockService := &service.MockOmniFocusService{}
+		_, err := executeDropCommand(mockService, args...)
+		if err == nil {
+			t.Errorf("%v: expected an error", args)
+		} else if code := output.ExitCodeFor(err); code != output.ExitValidationError {
+			t.Errorf("%v: exit code = %d, want %d", args, code, output.ExitValidationError)
+		}
+		if len(mockService.DroppedIDs)+len(mockService.DroppedProjectIDs) != 0 {
+			t.Errorf("%v: expected nothing to be dropped", args)
+		}
+	}
+}
+
+func executeDropCommand(mockService service.OmniFocusService, args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewDropCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(args)
+
+	err := rootCmd.ExecuteContext(ContextWithService(context.Background(), mockService))
+	return buf.String(), err
+}
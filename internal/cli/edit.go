@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bulkedit"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// runEditor opens a file in $VISUAL or $EDITOR (vi by default) and waits for
+// it to close; tests replace it
+var runEditor = func(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// Editors such as "code --wait" come with arguments
+	args := append(strings.Fields(editor), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+// NewEditCommand creates the edit command
+func NewEditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit --query <query>",
+		Short: "Bulk-edit tasks as a table in your editor",
+		Long: `Open the remaining tasks matching a query as a tab-separated table in $VISUAL
+or $EDITOR (vi by default). Edit names, due dates and tags, or put x in the
+done column to complete a task, then save and close the editor. The changes
+are previewed and, once confirmed, applied as batch modifications: tasks
+getting the same change share one batch.
+
+Query terms, all of which must match:
+  project:<name>   Tasks in the project with this name or ID
+  tag:<name>       Tasks with this tag
+  due:<range>      Tasks due within a range, as for tasks --due
+  flagged          Flagged tasks only
+  inbox            Inbox tasks only
+  <word>           Tasks whose name contains the word
+
+Quote values with spaces: project:"Home Office". --yes, --json and --quiet
+apply the changes without asking.`,
+		Example: `  lazyfocus edit --query 'project:Work'
+  lazyfocus edit --query 'tag:errands due:week'
+  lazyfocus edit --query 'project:"Home Office" flagged' --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: runEdit,
+	}
+
+	cmd.Flags().String("query", "", "Tasks to edit, e.g. 'project:Work tag:errands' (required)")
+	cmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	cmd.Flags().BoolP("yes", "y", false, "Apply the changes without confirmation")
+	_ = cmd.MarkFlagRequired("query")
+
+	return cmd
+}
+
+// editSummary is the JSON shape of edit output
+type editSummary struct {
+	Query   string       `json:"query"`
+	Tasks   int          `json:"tasks"`
+	DryRun  bool         `json:"dryRun"`
+	Changes []editChange `json:"changes"`
+	Applied int          `json:"applied"`
+	Failed  int          `json:"failed"`
+}
+
+// editChange is one previewed change in edit's JSON output
+type editChange struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	queryFlag, _ := cmd.Flags().GetString("query")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	query, err := bulkedit.ParseQuery(queryFlag)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	all, err := svc.GetAllTasks(ctx, service.TaskFilters{})
+	var truncated *service.TruncatedError
+	if errors.As(err, &truncated) {
+		if !GetQuietFlag() {
+			cmd.PrintErrf("Warning: %s\n", truncated)
+		}
+		err = nil
+	}
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	var tasks []domain.Task
+	for _, task := range all {
+		if query.Matches(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	if len(tasks) == 0 {
+		if !GetQuietFlag() && !GetJSONFlag() {
+			cmd.Println("No tasks match the query")
+		}
+		return printEditSummary(cmd, editSummary{Query: queryFlag, DryRun: dryRun, Changes: []editChange{}})
+	}
+
+	edited, err := editInEditor(bulkedit.Format(tasks))
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	rows, err := bulkedit.Parse(edited)
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to read edited table: %w", err))
+	}
+	changes, err := bulkedit.Diff(tasks, rows, time.Now())
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to read edited table: %w", err))
+	}
+
+	summary := editSummary{Query: queryFlag, Tasks: len(tasks), DryRun: dryRun, Changes: make([]editChange, len(changes))}
+	for i, change := range changes {
+		summary.Changes[i] = editChange{ID: change.Task.ID, Name: change.Task.Name, Description: change.Describe()}
+	}
+
+	if len(changes) == 0 {
+		if !GetQuietFlag() && !GetJSONFlag() {
+			cmd.Println("No changes")
+		}
+		return printEditSummary(cmd, summary)
+	}
+
+	if !GetQuietFlag() && !GetJSONFlag() {
+		cmd.Printf("%d %s:\n", len(changes), changesNoun(len(changes)))
+		for _, change := range changes {
+			cmd.Printf("  %s\n", change.Describe())
+		}
+	}
+	if dryRun {
+		return printEditSummary(cmd, summary)
+	}
+	if !yes && !GetJSONFlag() && !GetQuietFlag() && !confirmEdit(cmd, len(changes)) {
+		cmd.Println("Cancelled")
+		return nil
+	}
+
+	failedIDs := make(map[string]bool)
+	var lastError error
+	for _, batch := range bulkedit.Batches(changes) {
+		result, err := svc.BatchModify(ctx, batch.IDs(), batch.Operation)
+		if err != nil {
+			lastError = err
+			for _, task := range batch.Tasks {
+				failedIDs[task.ID] = true
+			}
+			if !GetQuietFlag() {
+				printError(cmd, fmt.Errorf("failed to %s %d tasks: %w", batch.Operation.Action, len(batch.Tasks), err))
+			}
+			continue
+		}
+		for _, res := range result.Results {
+			if res.Success {
+				continue
+			}
+			failedIDs[res.ID] = true
+			lastError = errors.New(res.Message)
+			if !GetQuietFlag() {
+				printError(cmd, fmt.Errorf("failed to %s %s: %s", batch.Operation.Action, res.ID, res.Message))
+			}
+		}
+	}
+	summary.Failed = len(failedIDs)
+	summary.Applied = len(changes) - summary.Failed
+
+	if !GetQuietFlag() && !GetJSONFlag() {
+		cmd.Printf("✓ Applied %d of %d changes\n", summary.Applied, len(changes))
+	}
+	if err := printEditSummary(cmd, summary); err != nil {
+		return err
+	}
+	// If all changes failed, return the last error
+	if summary.Applied == 0 && lastError != nil {
+		return printed(lastError)
+	}
+	return nil
+}
+
+// editInEditor writes table to a temporary file, opens it in the editor and
+// returns what was saved
+func editInEditor(table string) (string, error) {
+	file, err := os.CreateTemp("", "lazyfocus-edit-*.tsv")
+	if err != nil {
+		return "", fmt.Errorf("failed to create edit file: %w", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	if _, err := file.WriteString(table); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write edit file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write edit file: %w", err)
+	}
+
+	if err := runEditor(path); err != nil {
+		return "", fmt.Errorf("editor failed: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edit file: %w", err)
+	}
+	return string(data), nil
+}
+
+// confirmEdit asks on stderr whether to apply the previewed changes; anything
+// but yes, including end of input, declines
+func confirmEdit(cmd *cobra.Command, count int) bool {
+	fmt.Fprintf(cmd.ErrOrStderr(), "Apply %d %s? [y/N]: ", count, changesNoun(count))
+
+	line, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// changesNoun returns "change" or "changes" to follow count
+func changesNoun(count int) string {
+	if count == 1 {
+		return "change"
+	}
+	return "changes"
+}
+
+// printEditSummary writes the summary in JSON mode
+func printEditSummary(cmd *cobra.Command, summary editSummary) error {
+	if !GetJSONFlag() || GetQuietFlag() {
+		return nil
+	}
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to encode edit summary: %w", err))
+	}
+	cmd.Println(string(data))
+	return nil
+}
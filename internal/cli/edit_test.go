@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// batchRecorder records every BatchModify call, in order
+type batchRecorder struct {
+	*service.MockOmniFocusService
+	ids [][]string
+	ops []domain.BatchOperation
+}
+
+func (r *batchRecorder) BatchModify(ctx context.Context, ids []string, op domain.BatchOperation) (*domain.BatchResult, error) {
+	r.ids = append(r.ids, ids)
+	r.ops = append(r.ops, op)
+	return &domain.BatchResult{}, nil
+}
+
+// stubEditor replaces the editor with one rewriting the table with edit
+func stubEditor(t *testing.T, edit func(table string) string) {
+	t.Helper()
+	original := runEditor
+	runEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(edit(string(data))), 0o600)
+	}
+	t.Cleanup(func() { runEditor = original })
+}
+
+func newEditTestService() *batchRecorder {
+	return &batchRecorder{MockOmniFocusService: &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "t1", Name: "Write report", ProjectID: "p1", ProjectName: "Work", Tags: []string{"office"}},
+			{ID: "t2", Name: "Review PR", ProjectID: "p1", ProjectName: "Work"},
+			{ID: "t3", Name: "Buy milk", Tags: []string{"office"}},
+		},
+	}}
+}
+
+func TestEditCommand_AppliesEditedTable(t *testing.T) {
+	var opened string
+	stubEditor(t, func(table string) string {
+		opened = table
+		table = strings.Replace(table, "Write report", "Write Q3 report", 1)
+		return strings.Replace(table, "t2\t\t", "t2\tx\t", 1)
+	})
+	svc := newEditTestService()
+
+	output, err := executeEditCommand(svc, "", "edit", "--query", "project:work", "--yes")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if strings.Contains(opened, "Buy milk") || !strings.Contains(opened, "Review PR") {
+		t.Errorf("editor should get only the matching tasks, got:\n%s", opened)
+	}
+	if !strings.Contains(output, `"Write report": rename to "Write Q3 report"`) || !strings.Contains(output, `"Review PR": complete`) {
+		t.Errorf("Expected a preview of both changes, got: %s", output)
+	}
+
+	if len(svc.ops) != 2 {
+		t.Fatalf("BatchModify() called %d times, want 2", len(svc.ops))
+	}
+	if name := svc.ops[0].Modification.Name; svc.ops[0].Action != domain.BatchModify || name == nil || *name != "Write Q3 report" || !reflect.DeepEqual(svc.ids[0], []string{"t1"}) {
+		t.Errorf("first batch = %v %+v, want the rename of t1", svc.ids[0], svc.ops[0])
+	}
+	if svc.ops[1].Action != domain.BatchComplete || !reflect.DeepEqual(svc.ids[1], []string{"t2"}) {
+		t.Errorf("second batch = %v %+v, want the completion of t2", svc.ids[1], svc.ops[1])
+	}
+}
+
+func TestEditCommand_DeclinedOrDryRunAppliesNothing(t *testing.T) {
+	stubEditor(t, func(table string) string {
+		return strings.Replace(table, "office", "home", -1)
+	})
+
+	for _, args := range [][]string{
+		{"edit", "--query", "tag:office"},
+		{"edit", "--query", "tag:office", "--dry-run"},
+	} {
+		svc := newEditTestService()
+		output, err := executeEditCommand(svc, "n\n", args...)
+		if err != nil {
+			t.Fatalf("%v: expected no error, got: %v", args, err)
+		}
+		if !strings.Contains(output, "2 changes:") {
+			t.Errorf("%v: expected a preview, got: %s", args, output)
+		}
+		if len(svc.ops) != 0 {
+			t.Errorf("%v: BatchModify() called %d times, want none", args, len(svc.ops))
+		}
+	}
+}
+
+func TestEditCommand_InvalidTable(t *testing.T) {
+	stubEditor(t, func(table string) string {
+		return table + "t9\t\tNew task\t\t\n"
+	})
+	svc := newEditTestService()
+
+	if _, err := executeEditCommand(svc, "", "edit", "--query", "project:Work", "--yes"); err == nil || !strings.Contains(err.Error(), "unknown task ID") {
+		t.Errorf("Expected an unknown task ID error, got: %v", err)
+	}
+	if len(svc.ops) != 0 {
+		t.Errorf("BatchModify() called %d times, want none", len(svc.ops))
+	}
+}
+
+func executeEditCommand(mockService service.OmniFocusService, input string, args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewEditCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetIn(strings.NewReader(input))
+	rootCmd.SetArgs(args)
+
+	err := rootCmd.ExecuteContext(ContextWithService(context.Background(), mockService))
+	return buf.String(), err
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
+	"github.com/spf13/cobra"
+)
+
+// printedErr is the last error a command printed itself, which ReportError
+// does not print again
+var printedErr error
+
+// printed records that err has been printed and returns it
+func printed(err error) error {
+	printedErr = err
+	return err
+}
+
+// invalidInput returns a validation error for the arguments or flags given
+// to a command, which exits with output.ExitValidationError
+func invalidInput(format string, args ...any) error {
+	return &output.ValidationError{Err: fmt.Errorf(format, args...)}
+}
+
+// ReportError prints an error returned by a command in the output format,
+// unless the command printed it already or --quiet is set, and returns the
+// exit code for it
+func ReportError(cmd *cobra.Command, err error) int {
+	if err != printedErr && !GetQuietFlag() {
+		printError(cmd, err)
+	}
+	return output.ExitCodeFor(err)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestReportError_PrintsUnprintedErrorAsJSON(t *testing.T) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewAddCommand())
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"add", "Buy milk", "--due", "someday maybe", "--output", "json"})
+
+	err := rootCmd.ExecuteContext(ContextWithService(context.Background(), &service.MockOmniFocusService{}))
+	if err == nil {
+		t.Fatal("expected an invalid date error")
+	}
+	if code := ReportError(rootCmd, err); code != output.ExitValidationError {
+		t.Errorf("exit code = %d, want %d", code, output.ExitValidationError)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("output %q is not one JSON error: %v", buf.String(), err)
+	}
+	if parsed["kind"] != output.KindValidation || parsed["code"] != float64(output.ExitValidationError) {
+		t.Errorf("error JSON = %v, want kind %q and code %d", parsed, output.KindValidation, output.ExitValidationError)
+	}
+}
+
+func TestReportError_DoesNotPrintHandledErrorTwice(t *testing.T) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewShowCommand())
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"show", "missing", "--type", "task"})
+
+	err := rootCmd.ExecuteContext(ContextWithService(context.Background(), &service.MockOmniFocusService{}))
+	if err == nil {
+		t.Fatal("expected a not found error")
+	}
+	if code := ReportError(rootCmd, err); code != output.ExitItemNotFound {
+		t.Errorf("exit code = %d, want %d", code, output.ExitItemNotFound)
+	}
+	if count := strings.Count(buf.String(), "Error:"); count != 1 {
+		t.Errorf("error printed %d times, want once:\n%s", count, buf.String())
+	}
+}
+
+func TestCommands_WriteResultsToStdoutAndErrorsToStderr(t *testing.T) {
+	mockService := &service.MockOmniFocusService{InboxTasks: []domain.Task{{ID: "t1", Name: "Buy milk"}}}
+
+	run := func(args ...string) (stdout, stderr string) {
+		rootCmd := newTestRootCommand()
+		rootCmd.AddCommand(NewTasksCommand(), NewShowCommand())
+		var out, errOut bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&errOut)
+		rootCmd.SetArgs(args)
+		_ = rootCmd.ExecuteContext(ContextWithService(context.Background(), mockService))
+		return out.String(), errOut.String()
+	}
+
+	stdout, stderr := run("tasks")
+	if !strings.Contains(stdout, "Buy milk") || stderr != "" {
+		t.Errorf("tasks wrote stdout %q, stderr %q; want the tasks on stdout only", stdout, stderr)
+	}
+
+	stdout, stderr = run("show", "missing", "--type", "task")
+	if stdout != "" || !strings.Contains(stderr, "Error:") {
+		t.Errorf("show wrote stdout %q, stderr %q; want the error on stderr only", stdout, stderr)
+	}
+}
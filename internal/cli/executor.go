@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+)
+
+// fixturesEnv names the directory of recorded script output answering
+// scripts instead of OmniFocus, for trying and testing without it
+const fixturesEnv = "LAZYFOCUS_FIXTURES"
+
+// newExecutor creates the executor OmniFocus scripts run through: the
+// fixtures in $LAZYFOCUS_FIXTURES when set, else osascript
+func newExecutor(cfg *config.Config) bridge.Executor {
+	if dir := os.Getenv(fixturesEnv); dir != "" {
+		return bridge.NewFixtureExecutor(dir)
+	}
+	return newOSAScriptExecutor(cfg)
+}
+
+// newOSAScriptExecutor creates an executor running scripts with osascript,
+// limited to the configured payload size and retrying transient failures as
+// configured. Without a config the bridge defaults are used.
+func newOSAScriptExecutor(cfg *config.Config) bridge.Executor {
+	executor := bridge.NewOSAScriptExecutor()
+	if cfg == nil {
+		return bridge.NewRetryableExecutor(executor, bridge.DefaultRetryConfig())
+	}
+
+	if cfg.MaxPayloadMB > 0 {
+		executor.SetMaxPayloadBytes(cfg.MaxPayloadBytes())
+	}
+	return bridge.NewRetryableExecutor(executor, retryConfig(cfg.Retry))
+}
+
+// retryConfig converts the retry settings of the config file to the bridge's
+func retryConfig(cfg config.RetryConfig) bridge.RetryConfig {
+	return bridge.RetryConfig{
+		MaxAttempts: cfg.Attempts,
+		InitialWait: cfg.InitialWait,
+		MaxWait:     cfg.MaxWait,
+	}
+}
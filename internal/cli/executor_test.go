@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+)
+
+func TestRetryConfig(t *testing.T) {
+	got := retryConfig(config.RetryConfig{Attempts: 5, InitialWait: 50 * time.Millisecond, MaxWait: time.Second})
+
+	want := bridge.RetryConfig{MaxAttempts: 5, InitialWait: 50 * time.Millisecond, MaxWait: time.Second}
+	if got != want {
+		t.Errorf("retryConfig() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewExecutor_Retries(t *testing.T) {
+	for _, cfg := range []*config.Config{nil, {Retry: config.RetryConfig{Attempts: 2}}} {
+		if _, ok := newExecutor(cfg).(*bridge.RetryableExecutor); !ok {
+			t.Errorf("newExecutor(%v) = %T, want a *bridge.RetryableExecutor", cfg, newExecutor(cfg))
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/export"
+	"github.com/spf13/cobra"
+)
+
+// NewExportCommand creates the export command
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all projects, tasks and tags",
+		Long: `Export the whole OmniFocus database: every project (including completed and
+dropped ones) with its tasks and subtasks, the inbox, and all tags.
+
+Formats:
+  json       Structured dump for backups and scripts (default)
+  taskpaper  TaskPaper outline, with dates, flags and tags as @attributes
+  llm        Compact summary of open tasks to paste into an AI assistant,
+             limited to about --budget tokens, most urgent tasks first
+
+The export is written to stdout unless --file is given. Progress is shown on
+stderr while projects are read.`,
+		Example: `  lazyfocus export --file backup.json
+  lazyfocus export --format taskpaper > omnifocus.taskpaper
+  lazyfocus export --format llm --budget 2000 | pbcopy`,
+		Args: cobra.NoArgs,
+		RunE: runExport,
+	}
+
+	cmd.Flags().String("format", export.FormatJSON, "Export format (json, taskpaper, llm)")
+	cmd.Flags().Int("budget", 0, "Approximate token limit of the llm format, keeping the most urgent tasks (0: no limit)")
+	cmd.Flags().String("file", "", "Write the export to a file instead of stdout")
+
+	return cmd
+}
+
+// exportSummary is the JSON shape of `export --file` output
+type exportSummary struct {
+	File     string `json:"file"`
+	Format   string `json:"format"`
+	Projects int    `json:"projects"`
+	Tasks    int    `json:"tasks"`
+	Tags     int    `json:"tags"`
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	file, _ := cmd.Flags().GetString("file")
+	budget, _ := cmd.Flags().GetInt("budget")
+
+	// Reject an unknown format before reading the whole database
+	if err := export.Write(io.Discard, &export.Database{}, format); err != nil {
+		return handleError(cmd, err)
+	}
+	llm := strings.EqualFold(format, export.FormatLLM)
+	if budget < 0 || (budget > 0 && !llm) {
+		return handleError(cmd, invalidInput("invalid --budget %d: must be positive and used with --format %s", budget, export.FormatLLM))
+	}
+	write := func(w io.Writer, db *export.Database) error {
+		if llm {
+			return export.WriteLLM(w, db, budget)
+		}
+		return export.Write(w, db, format)
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	// The number of projects is only known once the export has started
+	db, err := export.Collect(cmd.Context(), svc, newProgressReporter(cmd, progressThreshold), time.Now())
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to export: %w", err))
+	}
+
+	if file == "" {
+		return write(cmd.OutOrStdout(), db)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to create export file: %w", err))
+	}
+	writeErr := write(f, db)
+	if closeErr := f.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		return handleError(cmd, writeErr)
+	}
+
+	if GetQuietFlag() {
+		return nil
+	}
+
+	summary := exportSummary{File: file, Format: format, Projects: len(db.Projects), Tasks: db.TaskCount(), Tags: len(db.Tags)}
+	if GetJSONFlag() {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to encode export summary: %w", err))
+		}
+		cmd.Println(string(data))
+		return nil
+	}
+
+	cmd.Printf("✓ Exported %d projects, %d tasks and %d tags to %s\n", summary.Projects, summary.Tasks, summary.Tags, file)
+	return nil
+}
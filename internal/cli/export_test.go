@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func newExportMockService() *service.MockOmniFocusService {
+	return &service.MockOmniFocusService{
+		Projects:     []domain.Project{{ID: "p1", Name: "Home", Status: "active"}},
+		ProjectTasks: []domain.Task{{ID: "t1", Name: "Paint fence"}},
+		InboxTasks:   []domain.Task{{ID: "i1", Name: "Call bank"}},
+		Tags:         []domain.Tag{{ID: "tag1", Name: "Errands"}},
+	}
+}
+
+func TestExportCommand_Stdout(t *testing.T) {
+	output, err := executeExportCommand(newExportMockService(), []string{"--format", "taskpaper"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, "Home:\n\t- Paint fence\n") || !strings.Contains(output, "Inbox:\n\t- Call bank\n") {
+		t.Errorf("Expected TaskPaper outline, got: %s", output)
+	}
+}
+
+func TestExportCommand_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.json")
+
+	output, err := executeExportCommand(newExportMockService(), []string{"--file", path})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, "Exported 1 projects, 2 tasks and 1 tags") {
+		t.Errorf("Expected export summary, got: %s", output)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected export file, got: %v", err)
+	}
+	if !strings.Contains(string(data), `"Paint fence"`) || !strings.Contains(string(data), `"Errands"`) {
+		t.Errorf("Expected JSON dump with tasks and tags, got: %s", data)
+	}
+}
+
+func TestExportCommand_LLM(t *testing.T) {
+	output, err := executeExportCommand(newExportMockService(), []string{"--format", "llm", "--budget", "500"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, "## Home [p1]\n- t1 Paint fence\n") || !strings.Contains(output, "## Inbox\n- i1 Call bank\n") {
+		t.Errorf("Expected LLM summary, got: %s", output)
+	}
+}
+
+func TestExportCommand_BudgetNeedsLLM(t *testing.T) {
+	_, err := executeExportCommand(newExportMockService(), []string{"--format", "json", "--budget", "500"})
+
+	if err == nil || !strings.Contains(err.Error(), "invalid --budget") {
+		t.Errorf("Expected invalid budget error, got: %v", err)
+	}
+}
+
+func TestExportCommand_UnknownFormat(t *testing.T) {
+	_, err := executeExportCommand(newExportMockService(), []string{"--format", "xml"})
+
+	if err == nil || !strings.Contains(err.Error(), "unknown export format") {
+		t.Errorf("Expected unknown format error, got: %v", err)
+	}
+}
+
+// Helper function to execute export command
+func executeExportCommand(mockService service.OmniFocusService, args []string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewExportCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs(append([]string{"export"}, args...))
+
+	err := rootCmd.ExecuteContext(ContextWithService(context.Background(), mockService))
+	return buf.String(), err
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/spf13/cobra"
+)
+
+// NewFlushCommand creates the hidden flush command, which finishes writes the
+// TUI handed off when it quit with operations pending
+func NewFlushCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flush <journal>",
+		Short: "Finish pending writes saved by the TUI",
+		Long: `Replay the writes in a journal saved by the TUI when it quit with operations
+still pending. Writes whose effect is already visible in OmniFocus are skipped.
+The journal is removed once every write succeeds; otherwise it keeps the
+writes that failed so the command can be run again.
+
+With --wait, the command first waits for writes the TUI left running to land,
+so their effect is visible before it looks for it.`,
+		Args:   cobra.ExactArgs(1),
+		Hidden: true,
+		RunE:   runFlush,
+	}
+
+	cmd.Flags().Duration("wait", 0, "Wait this long before replaying")
+
+	return cmd
+}
+
+func runFlush(cmd *cobra.Command, args []string) error {
+	path := args[0]
+
+	writes, err := service.ReadJournal(path)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	wait, _ := cmd.Flags().GetDuration("wait")
+	if wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-cmd.Context().Done():
+			return handleError(cmd, cmd.Context().Err())
+		}
+	}
+
+	var failed []service.PendingWrite
+	var firstErr error
+	for _, w := range writes {
+		if err := w.Replay(cmd.Context(), svc); err != nil {
+			failed = append(failed, w)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", w.Describe(), err)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		if err := service.WriteJournal(path, failed); err != nil {
+			return handleError(cmd, err)
+		}
+		return handleError(cmd, fmt.Errorf("failed to flush %d of %d pending operations (kept in %s): %w", len(failed), len(writes), path, firstErr))
+	}
+
+	if err := os.Remove(path); err != nil {
+		return handleError(cmd, fmt.Errorf("failed to remove journal: %w", err))
+	}
+
+	if !GetQuietFlag() {
+		cmd.Printf("✓ Flushed %d pending operations\n", len(writes))
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package cli
+
+import "syscall"
+
+// detachedProcAttr returns nil where sessions are not available
+func detachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
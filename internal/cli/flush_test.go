@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func writeTestJournal(t *testing.T, writes ...service.PendingWrite) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pending.json")
+	if err := service.WriteJournal(path, writes); err != nil {
+		t.Fatalf("WriteJournal() error = %v", err)
+	}
+	return path
+}
+
+func TestFlushCommand_ReplaysAndRemovesJournal(t *testing.T) {
+	mockService := &service.MockOmniFocusService{CreatedTask: &domain.Task{ID: "t1", Name: "Call Bob"}}
+	path := writeTestJournal(t, service.PendingWrite{Method: "CreateTask", Task: &domain.TaskInput{Name: "Call Bob"}})
+
+	output, err := executeFlushCommand(mockService, []string{path})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.CreatedInputs) != 1 || mockService.CreatedInputs[0].Name != "Call Bob" {
+		t.Errorf("CreateTask() inputs = %+v, want Call Bob", mockService.CreatedInputs)
+	}
+	if !strings.Contains(output, "Flushed 1 pending operations") {
+		t.Errorf("Expected flush summary, got: %s", output)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected journal to be removed, got: %v", err)
+	}
+}
+
+func TestFlushCommand_KeepsFailedWrites(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		CreatedTask:   &domain.Task{ID: "t1"},
+		Task:          &domain.Task{ID: "t2"},
+		DeleteTaskErr: errors.New("OmniFocus is not running"),
+	}
+	path := writeTestJournal(t,
+		service.PendingWrite{Method: "CreateTask", Task: &domain.TaskInput{Name: "Call Bob"}},
+		service.PendingWrite{Method: "DeleteTask", ID: "t2"},
+	)
+
+	_, err := executeFlushCommand(mockService, []string{path})
+	if err == nil || !strings.Contains(err.Error(), "failed to flush 1 of 2 pending operations") {
+		t.Fatalf("Expected flush error, got: %v", err)
+	}
+
+	remaining, err := service.ReadJournal(path)
+	if err != nil {
+		t.Fatalf("ReadJournal() error = %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Method != "DeleteTask" {
+		t.Errorf("Journal = %+v, want only the failed DeleteTask", remaining)
+	}
+}
+
+func TestFlushCommand_WaitsBeforeReplaying(t *testing.T) {
+	mockService := &service.MockOmniFocusService{CreatedTask: &domain.Task{ID: "t1", Name: "Call Bob"}}
+	path := writeTestJournal(t, service.PendingWrite{Method: "CreateTask", Task: &domain.TaskInput{Name: "Call Bob"}})
+
+	start := time.Now()
+	if _, err := executeFlushCommand(mockService, []string{"--wait", "50ms", path}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("flush returned after %v, want at least the 50ms wait", elapsed)
+	}
+	if len(mockService.CreatedInputs) != 1 {
+		t.Errorf("CreateTask() inputs = %+v, want Call Bob", mockService.CreatedInputs)
+	}
+}
+
+func TestFlushCommand_MissingJournal(t *testing.T) {
+	_, err := executeFlushCommand(&service.MockOmniFocusService{}, []string{filepath.Join(t.TempDir(), "missing.json")})
+
+	if err == nil || !strings.Contains(err.Error(), "failed to read pending writes") {
+		t.Errorf("Expected read error, got: %v", err)
+	}
+}
+
+// Helper function to execute flush command
+func executeFlushCommand(mockService service.OmniFocusService, args []string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewFlushCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(append([]string{"flush"}, args...))
+
+	err := rootCmd.ExecuteContext(ContextWithService(context.Background(), mockService))
+	return buf.String(), err
+}
@@ -0,0 +1,11 @@
+//go:build unix
+
+package cli
+
+import "syscall"
+
+// detachedProcAttr starts the background flush in its own session so it
+// outlives the terminal the TUI ran in
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/export"
+	"github.com/pwojciechowski/lazyfocus/internal/importer"
+	"github.com/spf13/cobra"
+)
+
+// NewImportCommand creates the import command
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Create projects and tasks from TaskPaper or Markdown",
+		Long: `Create projects and tasks in OmniFocus from a TaskPaper outline or a Markdown
+checklist, read from a file or stdin (when no file or "-" is given).
+
+Formats:
+  taskpaper  "Project:" lines with tab-indented "- task" items; @flagged,
+             @due(date), @defer(date), @tags(a, b), @done and other @tags are
+             read as in ` + "`lazyfocus export --format taskpaper`" + `
+  markdown   "- [ ] task" items under "# Project" headings; indented items
+             become subtasks
+
+The format is detected from the file extension or content unless --format is
+given. Tasks before the first project, or under "Inbox", go to the inbox;
+other lines become notes. Completed tasks and projects that are not active are
+skipped. Use --dry-run to preview the import without writing to OmniFocus.
+
+A task whose name matches a remaining task in OmniFocus, ignoring case and
+punctuation, is a possible duplicate. With --on-duplicate ask (the default) a
+merge view shows the incoming task, the existing one and the result side by
+side: pick either side for each field and merge into the existing task, create
+the task anyway or skip it. Asking needs a terminal, so imports from stdin or
+scripts create such tasks as before; use skip to leave them out instead.`,
+		Example: `  lazyfocus import plan.taskpaper
+  lazyfocus import --dry-run checklist.md
+  lazyfocus import --on-duplicate skip plan.taskpaper
+  pbpaste | lazyfocus import --format markdown`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runImport,
+	}
+
+	cmd.Flags().String("format", "", "Import format (taskpaper, markdown); detected when omitted")
+	cmd.Flags().Bool("dry-run", false, "Show what would be created without writing")
+	cmd.Flags().String("on-duplicate", duplicateAsk, "What to do with tasks matching existing ones (ask, create, skip)")
+
+	return cmd
+}
+
+// importSummary is the JSON shape of import output
+type importSummary struct {
+	File       string      `json:"file"`
+	Format     string      `json:"format"`
+	DryRun     bool        `json:"dryRun"`
+	Projects   int         `json:"projects"`
+	Tasks      int         `json:"tasks"`
+	Skipped    int         `json:"skipped"`
+	Merged     int         `json:"merged"`
+	Duplicates int         `json:"duplicates"`
+	Plan       *importPlan `json:"plan,omitempty"` // Parsed outline, on dry runs
+}
+
+// importPlan is the parsed outline shown by `import --dry-run --json`
+type importPlan struct {
+	Inbox    []domain.Task    `json:"inbox"`
+	Projects []domain.Project `json:"projects"`
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	onDuplicate, _ := cmd.Flags().GetString("on-duplicate")
+	if !slices.Contains([]string{duplicateAsk, duplicateCreate, duplicateSkip}, onDuplicate) {
+		return handleError(cmd, invalidInput("invalid --on-duplicate %q: use ask, create or skip", onDuplicate))
+	}
+
+	file := "-"
+	if len(args) > 0 {
+		file = args[0]
+	}
+
+	var content []byte
+	var err error
+	if file == "-" {
+		content, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		content, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to read import: %w", err))
+	}
+
+	if format == "" {
+		format = importer.DetectFormat(file, content)
+	}
+	db, err := importer.Parse(bytes.NewReader(content), format, time.Now())
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	var target importer.Target = dryRunTarget{}
+	var dups importer.Duplicates
+	progressItems := 0
+	if !dryRun {
+		svc, err := getServiceFromCmd(cmd)
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		target = svc
+		// Stdin holding the import cannot also answer the merge view
+		if file == "-" && onDuplicate == duplicateAsk {
+			onDuplicate = duplicateCreate
+		}
+		if dups, err = importDuplicates(cmd, svc, onDuplicate); err != nil {
+			return handleError(cmd, err)
+		}
+		// The number of tasks is only known once the import has started, and
+		// progress would draw over the merge view
+		if onDuplicate != duplicateAsk || dups.Resolve == nil {
+			progressItems = progressThreshold
+		}
+	}
+
+	result, err := importer.Apply(cmd.Context(), db, target, newProgressReporter(cmd, progressItems), dups)
+	if err != nil {
+		if result.Tasks > 0 || result.Projects > 0 {
+			err = fmt.Errorf("%w (created %d projects and %d tasks before failing)", err, result.Projects, result.Tasks)
+		}
+		return handleError(cmd, fmt.Errorf("failed to import: %w", err))
+	}
+
+	if GetQuietFlag() {
+		return nil
+	}
+
+	summary := importSummary{
+		File: file, Format: format, DryRun: dryRun,
+		Projects: result.Projects, Tasks: result.Tasks, Skipped: result.Skipped,
+		Merged: result.Merged, Duplicates: result.Duplicates,
+	}
+	if GetJSONFlag() {
+		if dryRun {
+			summary.Plan = &importPlan{Inbox: db.Inbox, Projects: db.Projects}
+		}
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to encode import summary: %w", err))
+		}
+		cmd.Println(string(data))
+		return nil
+	}
+
+	if dryRun {
+		cmd.Printf("Would import %d projects and %d tasks", result.Projects, result.Tasks)
+	} else {
+		cmd.Printf("✓ Imported %d projects and %d tasks", result.Projects, result.Tasks)
+	}
+	if result.Skipped > 0 {
+		cmd.Printf(" (skipped %d completed or inactive)", result.Skipped)
+	}
+	if result.Merged > 0 {
+		cmd.Printf(", merged %d into existing tasks", result.Merged)
+	}
+	if result.Duplicates > 0 {
+		cmd.Printf(", skipped %d duplicates", result.Duplicates)
+	}
+	cmd.Println()
+
+	if dryRun {
+		return export.WriteTaskPaper(cmd.OutOrStdout(), db)
+	}
+	return nil
+}
+
+// dryRunTarget stands in for OmniFocus on dry runs, creating nothing
+type dryRunTarget struct{}
+
+// CreateProject returns the project that would be created
+func (dryRunTarget) CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error) {
+	return &domain.Project{Name: input.Name, Status: "active"}, nil
+}
+
+// CreateTask returns the task that would be created
+func (dryRunTarget) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	return &domain.Task{Name: input.Name}, nil
+}
+
+// ModifyTask returns the task that would be modified
+func (dryRunTarget) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
+	return &domain.Task{ID: id}, nil
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+const testChecklist = "# Home\n- [ ] Paint fence\n  - [ ] Buy paint\n- [x] Mow lawn\n"
+
+func newImportMockService() *service.MockOmniFocusService {
+	return &service.MockOmniFocusService{
+		CreatedProject: &domain.Project{ID: "proj1", Name: "Home", Status: "active"},
+		CreatedTask:    &domain.Task{ID: "task1", Name: "Paint fence"},
+	}
+}
+
+func TestImportCommand_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.md")
+	if err := os.WriteFile(path, []byte(testChecklist), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mockService := newImportMockService()
+
+	output, err := executeImportCommand(mockService, "", []string{path})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.CreatedInputs) != 2 {
+		t.Fatalf("CreateTask() called %d times, want 2", len(mockService.CreatedInputs))
+	}
+	if sub := mockService.CreatedInputs[1]; sub.Name != "Buy paint" || sub.ParentID != "task1" || sub.ProjectID != "proj1" {
+		t.Errorf("CreateTask() subtask input = %+v, want Buy paint under task1 in proj1", sub)
+	}
+	if !strings.Contains(output, "Imported 1 projects and 2 tasks (skipped 1 completed or inactive)") {
+		t.Errorf("Expected import summary, got: %s", output)
+	}
+}
+
+func TestImportCommand_DryRunFromStdin(t *testing.T) {
+	mockService := newImportMockService()
+
+	output, err := executeImportCommand(mockService, "Home:\n\t- Paint fence @flagged\n", []string{"--dry-run"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.CreatedInputs) != 0 {
+		t.Errorf("CreateTask() called %d times, want none on dry run", len(mockService.CreatedInputs))
+	}
+	if !strings.Contains(output, "Would import 1 projects and 1 tasks") || !strings.Contains(output, "\t- Paint fence @flagged") {
+		t.Errorf("Expected dry run summary and outline, got: %s", output)
+	}
+}
+
+func TestImportCommand_DryRunJSON(t *testing.T) {
+	output, err := executeImportCommand(newImportMockService(), testChecklist, []string{"--dry-run", "--json"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{`"format": "markdown"`, `"dryRun": true`, `"tasks": 2`, `"name": "Buy paint"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %s, got: %s", want, output)
+		}
+	}
+}
+
+func TestImportCommand_UnknownFormat(t *testing.T) {
+	_, err := executeImportCommand(newImportMockService(), "", []string{"--format", "opml"})
+
+	if err == nil || !strings.Contains(err.Error(), "unknown import format") {
+		t.Errorf("Expected unknown format error, got: %v", err)
+	}
+}
+
+// Helper function to execute import command with stdin
+func executeImportCommand(mockService service.OmniFocusService, stdin string, args []string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewImportCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetIn(strings.NewReader(stdin))
+	rootCmd.SetArgs(append([]string{"import"}, args...))
+
+	err := rootCmd.ExecuteContext(ContextWithService(context.Background(), mockService))
+	return buf.String(), err
+}
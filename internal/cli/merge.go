@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/importer"
+	"github.com/spf13/cobra"
+)
+
+// What import does with tasks matching existing ones (--on-duplicate)
+const (
+	duplicateAsk    = "ask"
+	duplicateCreate = "create"
+	duplicateSkip   = "skip"
+)
+
+// errImportCancelled is returned when the merge view is closed with esc
+var errImportCancelled = errors.New("import cancelled")
+
+// runMergeView shows the merge view for a collision and returns what to do
+// with it; tests replace it
+var runMergeView = func(cmd *cobra.Command, c importer.Collision) (importer.Resolution, error) {
+	p := tea.NewProgram(newMergeView(c), tea.WithInput(cmd.InOrStdin()), tea.WithOutput(cmd.ErrOrStderr()))
+	final, err := p.Run()
+	if err != nil {
+		return importer.Resolution{}, fmt.Errorf("failed to run merge view: %w", err)
+	}
+	view := final.(mergeView)
+	if view.resolution == nil {
+		return importer.Resolution{}, errImportCancelled
+	}
+	return *view.resolution, nil
+}
+
+// stdinIsTerminal reports whether the command can ask the user; tests
+// replace it
+var stdinIsTerminal = func(cmd *cobra.Command) bool {
+	f, ok := cmd.InOrStdin().(*os.File)
+	return ok && term.IsTerminal(f.Fd())
+}
+
+// importDuplicates returns how an import handles tasks matching tasks already
+// in OmniFocus: created anyway, skipped, or resolved in the merge view. Asking
+// needs a terminal, so without one matching tasks are created as before.
+func importDuplicates(cmd *cobra.Command, svc service.OmniFocusService, mode string) (importer.Duplicates, error) {
+	var resolve importer.Resolver
+	switch mode {
+	case duplicateSkip:
+		resolve = func(importer.Collision) (importer.Resolution, error) {
+			return importer.Resolution{Action: importer.ActionSkip}, nil
+		}
+	case duplicateAsk:
+		if !stdinIsTerminal(cmd) {
+			return importer.Duplicates{}, nil
+		}
+		resolve = func(c importer.Collision) (importer.Resolution, error) {
+			return runMergeView(cmd, c)
+		}
+	default: // duplicateCreate
+		return importer.Duplicates{}, nil
+	}
+
+	// A truncated list still has tasks worth matching
+	tasks, err := svc.GetAllTasks(cmd.Context(), service.TaskFilters{})
+	var truncated *service.TruncatedError
+	if err != nil && !errors.As(err, &truncated) {
+		return importer.Duplicates{}, fmt.Errorf("failed to load existing tasks: %w", err)
+	}
+	return importer.Duplicates{Existing: tasks, Resolve: resolve}, nil
+}
+
+// mergeView is a Bubble Tea model showing an incoming task, the existing task
+// it matches and the result of merging them, with a pick of either side for
+// each field
+type mergeView struct {
+	collision  importer.Collision
+	picks      map[importer.Field]bool // Fields taken from the incoming task
+	cursor     int
+	width      int
+	resolution *importer.Resolution
+}
+
+// newMergeView creates a merge view for c, taking from the incoming task the
+// fields the existing one has no value for
+func newMergeView(c importer.Collision) mergeView {
+	return mergeView{collision: c, picks: importer.DefaultPicks(c), width: 100}
+}
+
+// Init does nothing
+func (m mergeView) Init() tea.Cmd {
+	return nil
+}
+
+// Update picks a side for the field under the cursor, and quits with a
+// resolution on enter, c or s, or without one on esc
+func (m mergeView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case tea.KeyMsg:
+		field := importer.Fields[m.cursor]
+		switch msg.String() {
+		case "esc", "q", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(importer.Fields)-1 {
+				m.cursor++
+			}
+		case "left", "h":
+			m.picks[field] = true
+		case "right", "l":
+			delete(m.picks, field)
+		case " ", "tab":
+			if m.picks[field] {
+				delete(m.picks, field)
+			} else {
+				m.picks[field] = true
+			}
+		case "enter":
+			m.resolution = &importer.Resolution{Action: importer.ActionMerge, Merged: importer.Merge(m.collision, m.picks)}
+			return m, tea.Quit
+		case "c":
+			m.resolution = &importer.Resolution{Action: importer.ActionCreate}
+			return m, tea.Quit
+		case "s":
+			m.resolution = &importer.Resolution{Action: importer.ActionSkip}
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// View renders the incoming, existing and merged task side by side, one
+// field per row
+func (m mergeView) View() string {
+	if m.resolution != nil {
+		return ""
+	}
+
+	const labelWidth = 9
+	colWidth := max(16, (m.width-labelWidth-8)/3)
+	bold := lipgloss.NewStyle().Bold(true)
+	dim := lipgloss.NewStyle().Faint(true)
+	cell := func(s string, style lipgloss.Style) string {
+		return style.Width(colWidth).Render(ansi.Truncate(s, colWidth, "…"))
+	}
+
+	merged := importer.Merge(m.collision, m.picks)
+	var b strings.Builder
+	b.WriteString(bold.Render("Possible duplicate: "+m.collision.Incoming.Name) + "\n")
+	project := m.collision.Existing.ProjectName
+	if project == "" {
+		project = "Inbox"
+	}
+	b.WriteString(dim.Render("An existing task in "+project+" has a matching name. Pick each field of the result.") + "\n\n")
+
+	b.WriteString(strings.Repeat(" ", labelWidth+2) + cell("Incoming", bold) + "  " + cell("Existing", bold) + "  " + cell("Result", bold) + "\n")
+	for i, field := range importer.Fields {
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		incoming, existing := dim, dim
+		if m.picks[field] {
+			incoming = bold
+		} else {
+			existing = bold
+		}
+		b.WriteString(marker + lipgloss.NewStyle().Width(labelWidth).Render(field.String()) +
+			cell(mergeFieldValue(m.collision.Incoming, field), incoming) + "  " +
+			cell(mergeFieldValue(m.collision.Existing, field), existing) + "  " +
+			cell(mergeFieldValue(merged, field), lipgloss.NewStyle()) + "\n")
+	}
+
+	b.WriteString("\n" + dim.Render("↑/↓ field · ← incoming · → existing · enter merge · c create new · s skip · esc cancel import") + "\n")
+	return b.String()
+}
+
+// mergeFieldValue returns field of task as shown in the merge view
+func mergeFieldValue(task domain.Task, field importer.Field) string {
+	if importer.IsEmpty(task, field) && field != importer.FieldFlagged {
+		return "—"
+	}
+	switch field {
+	case importer.FieldName:
+		return task.Name
+	case importer.FieldNote:
+		first, rest, more := strings.Cut(task.Note, "\n")
+		if more && strings.TrimSpace(rest) != "" {
+			return first + " …"
+		}
+		return first
+	case importer.FieldDue:
+		return task.DueDate.Local().Format("2006-01-02 15:04")
+	case importer.FieldDefer:
+		return task.DeferDate.Local().Format("2006-01-02 15:04")
+	case importer.FieldFlagged:
+		if task.Flagged {
+			return "yes"
+		}
+		return "no"
+	case importer.FieldTags:
+		return strings.Join(task.Tags, ", ")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/importer"
+	"github.com/spf13/cobra"
+)
+
+// stubMergeView makes import ask through resolve instead of the merge view
+func stubMergeView(t *testing.T, resolve func(importer.Collision) (importer.Resolution, error)) {
+	t.Helper()
+	originalRun, originalTerminal := runMergeView, stdinIsTerminal
+	runMergeView = func(cmd *cobra.Command, c importer.Collision) (importer.Resolution, error) {
+		return resolve(c)
+	}
+	stdinIsTerminal = func(*cobra.Command) bool { return true }
+	t.Cleanup(func() { runMergeView, stdinIsTerminal = originalRun, originalTerminal })
+}
+
+func writeImportFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "todo.taskpaper")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportCommand_MergesDuplicate(t *testing.T) {
+	mockService := newImportMockService()
+	mockService.AllTasks = []domain.Task{{ID: "existing1", Name: "Call bank", Note: "About the loan"}}
+	var asked []importer.Collision
+	stubMergeView(t, func(c importer.Collision) (importer.Resolution, error) {
+		asked = append(asked, c)
+		return importer.Resolution{Action: importer.ActionMerge, Merged: importer.Merge(c, importer.DefaultPicks(c))}, nil
+	})
+
+	output, err := executeImportCommand(mockService, "", []string{writeImportFile(t, "- call bank @flagged\n- Buy milk\n")})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(asked) != 1 || asked[0].Existing.ID != "existing1" {
+		t.Fatalf("merge view shown for %+v, want the existing Call bank", asked)
+	}
+	if mod, ok := mockService.Modifications["existing1"]; !ok || mod.Flagged == nil || !*mod.Flagged || mod.Note != nil {
+		t.Errorf("ModifyTask(existing1) = %+v, want it flagged with its note kept", mockService.Modifications)
+	}
+	if len(mockService.CreatedInputs) != 1 || mockService.CreatedInputs[0].Name != "Buy milk" {
+		t.Errorf("CreateTask() inputs = %+v, want only Buy milk", mockService.CreatedInputs)
+	}
+	if !strings.Contains(output, "merged 1 into existing tasks") {
+		t.Errorf("Expected the merge in the summary, got: %s", output)
+	}
+}
+
+func TestImportCommand_SkipsDuplicates(t *testing.T) {
+	mockService := newImportMockService()
+	mockService.AllTasks = []domain.Task{{ID: "existing1", Name: "Call bank"}}
+
+	output, err := executeImportCommand(mockService, "", []string{"--on-duplicate", "skip", writeImportFile(t, "- Call bank\n- Buy milk\n")})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.CreatedInputs) != 1 || len(mockService.Modifications) != 0 {
+		t.Errorf("created %+v and modified %+v, want only Buy milk created", mockService.CreatedInputs, mockService.Modifications)
+	}
+	if !strings.Contains(output, "skipped 1 duplicates") {
+		t.Errorf("Expected the skipped duplicate in the summary, got: %s", output)
+	}
+}
+
+func TestImportCommand_AskWithoutTerminalCreates(t *testing.T) {
+	mockService := newImportMockService()
+	mockService.AllTasks = []domain.Task{{ID: "existing1", Name: "Call bank"}}
+
+	if _, err := executeImportCommand(mockService, "", []string{writeImportFile(t, "- Call bank\n")}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(mockService.CreatedInputs) != 1 {
+		t.Errorf("CreateTask() called %d times, want the task created as before", len(mockService.CreatedInputs))
+	}
+}
+
+func TestImportCommand_CancelledMergeStops(t *testing.T) {
+	mockService := newImportMockService()
+	mockService.AllTasks = []domain.Task{{ID: "existing1", Name: "Call bank"}}
+	stubMergeView(t, func(importer.Collision) (importer.Resolution, error) {
+		return importer.Resolution{}, errImportCancelled
+	})
+
+	_, err := executeImportCommand(mockService, "", []string{writeImportFile(t, "- Call bank\n- Buy milk\n")})
+	if err == nil || !strings.Contains(err.Error(), "import cancelled") {
+		t.Errorf("Expected the import to be cancelled, got: %v", err)
+	}
+	if len(mockService.CreatedInputs) != 0 {
+		t.Errorf("CreateTask() called %d times, want none", len(mockService.CreatedInputs))
+	}
+}
+
+func TestImportCommand_InvalidOnDuplicate(t *testing.T) {
+	_, err := executeImportCommand(newImportMockService(), "", []string{"--on-duplicate", "merge", "--dry-run", writeImportFile(t, "- Call bank\n")})
+	if err == nil || !strings.Contains(err.Error(), "invalid --on-duplicate") {
+		t.Errorf("Expected an invalid --on-duplicate error, got: %v", err)
+	}
+}
+
+func TestMergeView_PicksFields(t *testing.T) {
+	c := importer.Collision{
+		Incoming: domain.Task{Name: "Call bank", Note: "Ask about fees", Flagged: true},
+		Existing: domain.Task{ID: "existing1", Name: "call bank", Note: "About the loan", ProjectName: "Finance"},
+	}
+	var model tea.Model = newMergeView(c)
+
+	view := model.View()
+	for _, want := range []string{"Possible duplicate: Call bank", "Finance", "Incoming", "Existing", "Result", "About the loan"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+
+	// Take the incoming note: down to Note, then left
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if cmd == nil {
+		t.Fatal("Enter should quit the merge view")
+	}
+	resolution := model.(mergeView).resolution
+	if resolution == nil || resolution.Action != importer.ActionMerge {
+		t.Fatalf("resolution = %+v, want a merge", resolution)
+	}
+	merged := resolution.Merged
+	if merged.Name != "call bank" || merged.Note != "Ask about fees" || !merged.Flagged {
+		t.Errorf("Merged = %+v, want the existing name, incoming note and the flag", merged)
+	}
+}
+
+func TestMergeView_CreateSkipAndCancel(t *testing.T) {
+	c := importer.Collision{Incoming: domain.Task{Name: "Call bank"}, Existing: domain.Task{ID: "existing1", Name: "Call bank"}}
+
+	for key, want := range map[string]*importer.Action{"c": ptr(importer.ActionCreate), "s": ptr(importer.ActionSkip), "esc": nil} {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if key == "esc" {
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		model, _ := tea.Model(newMergeView(c)).Update(msg)
+		got := model.(mergeView).resolution
+		if (got == nil) != (want == nil) || (got != nil && got.Action != *want) {
+			t.Errorf("key %q resolution = %+v, want %v", key, got, want)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -20,16 +20,20 @@ func NewModifyCommand() *cobra.Command {
 		dueFlag        string
 		deferFlag      string
 		flaggedFlag    string
+		repeatFlag     string
+		effortFlag     string
+		bumpFlag       string
 		clearDueFlag   bool
 		clearDeferFlag bool
 	)
 
 	cmd := &cobra.Command{
-		Use:   "modify <task-id> [flags]",
+		Use:   "modify [task-id] [flags]",
 		Short: "Modify an existing task in OmniFocus",
 		Long: `Modify an existing task in OmniFocus.
 
-Requires exactly one task ID as argument. Use flags to specify which
+Requires exactly one task ID, or a number the last tasks listing printed, as
+argument. Use flags to specify which
 fields to modify. At least one modification flag is required.
 
 Note: Due to OmniFocus automation limitations, only the first tag specified
@@ -37,16 +41,33 @@ with --add-tag will be applied to the task (as the primary tag). Multiple
 tags can be specified but only the first will be used. Using --remove-tag
 will only remove the primary tag if it matches.
 
-Examples:
-  lazyfocus modify task123 --name "New name"
+--effort records how much energy the task takes on an "effort: …" line of
+its note, which "lazyfocus next --energy" and the TUI's :low-energy filter
+read.
+
+--bump shifts the due date by a relative amount, or the defer date when the
+task has no due date: h (hours), d (days), w (weeks), mo (months) or y
+(years), negative to move it earlier.
+
+With --interactive and no ID, pick the task from a list of incomplete tasks,
+typing to fuzzy-search their names.`,
+		Example: `  lazyfocus modify task123 --name "New name"
   lazyfocus modify task123 --due tomorrow --flagged true
   lazyfocus modify task123 --add-tag urgent --remove-tag low
   lazyfocus modify task123 --clear-due
-  lazyfocus modify task123 --project Work --note "Updated note"`,
-		Args: cobra.ExactArgs(1),
+  lazyfocus modify 4 --flagged true
+  lazyfocus modify task123 --repeat weekly
+  lazyfocus modify task123 --repeat "every 2 months, after completion"
+  lazyfocus modify task123 --repeat none
+  lazyfocus modify task123 --effort low
+  lazyfocus modify task123 --bump 1d
+  lazyfocus modify task123 --bump -2w
+  lazyfocus modify task123 --project Work --note "Updated note"
+  lazyfocus modify --interactive --flagged true`,
+		Args: taskIDArgs(1, false),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runModify(cmd, args, nameFlag, noteFlag, projectFlag, addTagFlags, removeTagFlag,
-				dueFlag, deferFlag, flaggedFlag, clearDueFlag, clearDeferFlag)
+				dueFlag, deferFlag, flaggedFlag, repeatFlag, effortFlag, bumpFlag, clearDueFlag, clearDeferFlag)
 		},
 	}
 
@@ -58,27 +79,49 @@ Examples:
 	cmd.Flags().StringVar(&dueFlag, "due", "", "Set due date")
 	cmd.Flags().StringVar(&deferFlag, "defer", "", "Set defer date")
 	cmd.Flags().StringVar(&flaggedFlag, "flagged", "", "Set flagged (true/false)")
+	cmd.Flags().StringVar(&repeatFlag, "repeat", "", `Set repeat (daily, weekly, monthly, yearly, "every N weeks"; add "after completion" or "defer after completion"; none stops repeating)`)
+	cmd.Flags().StringVar(&effortFlag, "effort", "", "Set effort (low, medium, high; none removes it)")
+	cmd.Flags().StringVar(&bumpFlag, "bump", "", "Shift the due date, or else the defer date (e.g. 1d, -2w, 1mo)")
 	cmd.Flags().BoolVar(&clearDueFlag, "clear-due", false, "Clear due date")
 	cmd.Flags().BoolVar(&clearDeferFlag, "clear-defer", false, "Clear defer date")
+	addInteractiveFlag(cmd)
 
 	return cmd
 }
 
 func runModify(cmd *cobra.Command, args []string, nameFlag, noteFlag, projectFlag string,
-	addTagFlags, removeTagFlags []string, dueFlag, deferFlag, flaggedFlag string,
+	addTagFlags, removeTagFlags []string, dueFlag, deferFlag, flaggedFlag, repeatFlag, effortFlag, bumpFlag string,
 	clearDueFlag, clearDeferFlag bool) error {
-
-	taskID := args[0]
+	ctx := cmd.Context()
 
 	// Build TaskModification from flags
 	mod, err := buildModificationFromFlags(nameFlag, noteFlag, projectFlag, addTagFlags, removeTagFlags,
-		dueFlag, deferFlag, flaggedFlag, clearDueFlag, clearDeferFlag)
+		dueFlag, deferFlag, flaggedFlag, repeatFlag, clearDueFlag, clearDeferFlag)
 	if err != nil {
 		return handleError(cmd, err)
 	}
 
+	var effort domain.Effort
+	if effortFlag != "" {
+		effort, err = domain.ParseEffort(effortFlag)
+		if err != nil {
+			return handleError(cmd, err)
+		}
+	}
+
+	var bump domain.Bump
+	if bumpFlag != "" {
+		bump, err = domain.ParseBump(bumpFlag)
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		if mod.DueDate != nil || mod.DeferDate != nil || mod.ClearDue || mod.ClearDefer {
+			return handleError(cmd, invalidInput("--bump cannot be combined with --due, --defer, --clear-due or --clear-defer"))
+		}
+	}
+
 	// Check that at least one modification is specified
-	if mod.IsEmpty() {
+	if mod.IsEmpty() && effortFlag == "" && bumpFlag == "" {
 		return handleError(cmd, fmt.Errorf("no modifications specified"))
 	}
 
@@ -88,17 +131,62 @@ func runModify(cmd *cobra.Command, args []string, nameFlag, noteFlag, projectFla
 		return handleError(cmd, err)
 	}
 
+	var taskID string
+	if len(args) > 0 {
+		taskID, err = resolveTaskID(args[0])
+		if err != nil {
+			return handleError(cmd, err)
+		}
+	} else {
+		task, ok, err := pickTask(cmd, svc, "Modify which task?")
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		if !ok {
+			cmd.Println("Cancelled")
+			return nil
+		}
+		taskID = task.ID
+	}
+
 	// Resolve project name to ID if needed
 	if mod.ProjectID != nil && *mod.ProjectID != "" {
-		projectID, err := svc.ResolveProjectName(*mod.ProjectID)
+		projectID, err := svc.ResolveProjectName(ctx, *mod.ProjectID)
 		if err != nil {
 			return handleError(cmd, fmt.Errorf("failed to resolve project: %w", err))
 		}
 		mod.ProjectID = &projectID
 	}
 
+	// The effort and the bump change what the task has now
+	var current *domain.Task
+	if bumpFlag != "" || (effortFlag != "" && mod.Note == nil) {
+		current, err = svc.GetTaskByID(ctx, taskID)
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to get task: %w", err))
+		}
+	}
+
+	// The effort lives in the note, so it is set on the new note or the current one
+	if effortFlag != "" {
+		note := noteFlag
+		if mod.Note == nil {
+			note = current.Note
+		}
+		note = domain.SetNoteEffort(note, effort)
+		mod.Note = &note
+	}
+
+	if bumpFlag != "" {
+		bumped, err := bump.Modification(*current, dateparse.RollForward)
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		mod.DueDate, mod.DeferDate = bumped.DueDate, bumped.DeferDate
+	}
+
 	// Modify the task
-	task, err := svc.ModifyTask(taskID, mod)
+	task, err := svc.ModifyTask(ctx, taskID, mod)
 	if err != nil {
 		return handleError(cmd, fmt.Errorf("failed to modify task: %w", err))
 	}
@@ -109,15 +197,12 @@ func runModify(cmd *cobra.Command, args []string, nameFlag, noteFlag, projectFla
 	}
 
 	formatter := getFormatter()
-	outputStr := formatter.FormatModifiedTask(*task)
-	cmd.Print(outputStr)
-
-	return nil
+	return formatter.FormatModifiedTask(ctx, cmd.OutOrStdout(), *task)
 }
 
 // buildModificationFromFlags constructs a TaskModification from command-line flags.
 func buildModificationFromFlags(nameFlag, noteFlag, projectFlag string,
-	addTagFlags, removeTagFlags []string, dueFlag, deferFlag, flaggedFlag string,
+	addTagFlags, removeTagFlags []string, dueFlag, deferFlag, flaggedFlag, repeatFlag string,
 	clearDueFlag, clearDeferFlag bool) (domain.TaskModification, error) {
 
 	mod := domain.TaskModification{
@@ -142,7 +227,7 @@ func buildModificationFromFlags(nameFlag, noteFlag, projectFlag string,
 	if dueFlag != "" {
 		dueDate, err := dateparse.Parse(dueFlag)
 		if err != nil {
-			return domain.TaskModification{}, fmt.Errorf("invalid due date: %w", err)
+			return domain.TaskModification{}, invalidInput("invalid due date: %w", err)
 		}
 		mod.DueDate = &dueDate
 	}
@@ -150,7 +235,7 @@ func buildModificationFromFlags(nameFlag, noteFlag, projectFlag string,
 	if deferFlag != "" {
 		deferDate, err := dateparse.Parse(deferFlag)
 		if err != nil {
-			return domain.TaskModification{}, fmt.Errorf("invalid defer date: %w", err)
+			return domain.TaskModification{}, invalidInput("invalid defer date: %w", err)
 		}
 		mod.DeferDate = &deferDate
 	}
@@ -158,10 +243,19 @@ func buildModificationFromFlags(nameFlag, noteFlag, projectFlag string,
 	if flaggedFlag != "" {
 		flaggedBool, err := strconv.ParseBool(flaggedFlag)
 		if err != nil {
-			return domain.TaskModification{}, fmt.Errorf("invalid flagged value (use true/false): %w", err)
+			return domain.TaskModification{}, invalidInput("invalid flagged value (use true/false): %w", err)
 		}
 		mod.Flagged = &flaggedBool
 	}
 
+	if repeatFlag != "" {
+		repeat, err := domain.ParseRepeat(repeatFlag)
+		if err != nil {
+			return domain.TaskModification{}, err
+		}
+		mod.Repeat = repeat
+		mod.ClearRepeat = repeat == nil
+	}
+
 	return mod, nil
 }
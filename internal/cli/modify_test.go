@@ -550,6 +550,168 @@ func TestModifyCommand_InvalidFlaggedValue(t *testing.T) {
 	}
 }
 
+func TestModifyCommand_Repeat(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		ModifiedTask: &domain.Task{
+			ID:         "task123",
+			Name:       "Task",
+			Repetition: &domain.RepetitionRule{Recurrence: "FREQ=WEEKLY", Method: domain.RepeatFixed},
+		},
+	}
+
+	output, exitCode, err := executeModifyCommand(mockService, []string{"task123", "--repeat", "weekly"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got: %d", exitCode)
+	}
+
+	repeat := mockService.Modifications["task123"].Repeat
+	if repeat == nil || repeat.Frequency != domain.FrequencyWeekly || repeat.Method != domain.RepeatFixed {
+		t.Errorf("Expected a weekly fixed repeat, got: %+v", repeat)
+	}
+
+	if !strings.Contains(output, "Repeats: weekly") {
+		t.Errorf("Expected output to show the repeat, got: %s", output)
+	}
+}
+
+func TestModifyCommand_RepeatNone(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		ModifiedTask: &domain.Task{ID: "task123", Name: "Task"},
+	}
+
+	_, exitCode, err := executeModifyCommand(mockService, []string{"task123", "--repeat", "none"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got: %d", exitCode)
+	}
+
+	if mod := mockService.Modifications["task123"]; !mod.ClearRepeat || mod.Repeat != nil {
+		t.Errorf("Expected the repeat to be cleared, got: %+v", mod)
+	}
+}
+
+func TestModifyCommand_InvalidRepeat(t *testing.T) {
+	mockService := &service.MockOmniFocusService{}
+	_, exitCode, err := executeModifyCommand(mockService, []string{"task123", "--repeat", "fortnightly"})
+
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if exitCode == 0 {
+		t.Errorf("Expected non-zero exit code, got: %d", exitCode)
+	}
+
+	if !strings.Contains(err.Error(), "invalid repeat") {
+		t.Errorf("Expected error about invalid repeat, got: %v", err)
+	}
+}
+
+func TestModifyCommand_Effort(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		Task:         &domain.Task{ID: "task123", Name: "Fix shelf", Note: "Bring the drill"},
+		ModifiedTask: &domain.Task{ID: "task123", Name: "Fix shelf"},
+	}
+	_, _, err := executeModifyCommand(mockService, []string{"task123", "--effort", "low"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	mod := mockService.Modifications["task123"]
+	if mod.Note == nil || *mod.Note != "Bring the drill\n\neffort: low" {
+		t.Errorf("Expected the effort line added to the note, got: %v", mod.Note)
+	}
+}
+
+func TestModifyCommand_EffortWithNewNote(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		ModifiedTask: &domain.Task{ID: "task123", Name: "Fix shelf"},
+	}
+	_, _, err := executeModifyCommand(mockService, []string{"task123", "--note", "Borrow a drill", "--effort", "high"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	mod := mockService.Modifications["task123"]
+	if mod.Note == nil || *mod.Note != "Borrow a drill\n\neffort: high" {
+		t.Errorf("Expected the effort line added to the new note, got: %v", mod.Note)
+	}
+}
+
+func TestModifyCommand_InvalidEffort(t *testing.T) {
+	mockService := &service.MockOmniFocusService{}
+	_, _, err := executeModifyCommand(mockService, []string{"task123", "--effort", "tiny"})
+
+	if err == nil || !strings.Contains(err.Error(), "invalid effort") {
+		t.Errorf("Expected error about invalid effort, got: %v", err)
+	}
+}
+
+func TestModifyCommand_Bump(t *testing.T) {
+	due := time.Date(2026, 3, 2, 17, 0, 0, 0, time.Local)
+	mockService := &service.MockOmniFocusService{
+		Task:         &domain.Task{ID: "task123", Name: "File taxes", DueDate: &due},
+		ModifiedTask: &domain.Task{ID: "task123", Name: "File taxes"},
+	}
+	_, _, err := executeModifyCommand(mockService, []string{"task123", "--bump", "-1w"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	mod := mockService.Modifications["task123"]
+	if mod.DueDate == nil || !mod.DueDate.Equal(due.AddDate(0, 0, -7)) {
+		t.Errorf("Expected the due date moved a week earlier, got: %v", mod.DueDate)
+	}
+}
+
+func TestModifyCommand_BumpDeferDate(t *testing.T) {
+	deferDate := time.Date(2026, 3, 2, 8, 0, 0, 0, time.Local)
+	mockService := &service.MockOmniFocusService{
+		Task:         &domain.Task{ID: "task123", Name: "Water plants", DeferDate: &deferDate},
+		ModifiedTask: &domain.Task{ID: "task123", Name: "Water plants"},
+	}
+	_, _, err := executeModifyCommand(mockService, []string{"task123", "--bump", "2d"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	mod := mockService.Modifications["task123"]
+	if mod.DueDate != nil || mod.DeferDate == nil || !mod.DeferDate.Equal(deferDate.AddDate(0, 0, 2)) {
+		t.Errorf("Expected only the defer date moved, got due %v, defer %v", mod.DueDate, mod.DeferDate)
+	}
+}
+
+func TestModifyCommand_BumpErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		task *domain.Task
+		args []string
+		want string
+	}{
+		{"invalid", &domain.Task{ID: "task123"}, []string{"--bump", "soon"}, "invalid bump"},
+		{"with due", &domain.Task{ID: "task123"}, []string{"--bump", "1d", "--due", "tomorrow"}, "cannot be combined"},
+		{"no dates", &domain.Task{ID: "task123"}, []string{"--bump", "1d"}, "no due or defer date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &service.MockOmniFocusService{Task: tt.task}
+			_, _, err := executeModifyCommand(mockService, append([]string{"task123"}, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
 // Helper function to execute modify command and capture output
 func executeModifyCommand(mockService service.OmniFocusService, args []string) (string, int, error) {
 	// Create a new root command for each test to avoid flag pollution
@@ -579,3 +741,20 @@ func executeModifyCommand(mockService service.OmniFocusService, args []string) (
 
 	return output, exitCode, err
 }
+
+func TestModifyCommand_Interactive(t *testing.T) {
+	stubPicker(t, "Call dentist")
+	mockService := &service.MockOmniFocusService{
+		AllTasks:     pickerTasks(),
+		ModifiedTask: &domain.Task{ID: "t2", Name: "Call dentist", Flagged: true},
+	}
+
+	_, _, err := executeModifyCommand(mockService, []string{"--interactive", "--flagged", "true"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if _, ok := mockService.Modifications["t2"]; !ok {
+		t.Errorf("Modifications = %v, want t2 modified", mockService.Modifications)
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"errors"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/spf13/cobra"
+)
+
+// NewNextCommand creates the next command
+func NewNextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "next",
+		Short: "Suggest the tasks to work on next",
+		Long: `Suggest the tasks to work on next, best first, with the reasons for each.
+
+Available tasks are scored on how soon they are due, whether they are
+flagged, whether their estimate fits the time you have, whether they carry
+a tag for where you are, how long they have gone unchanged, and whether
+their effort suits your energy. The weight
+of each factor is set under next.weights in the config file; a weight of 0
+ignores the factor. Tags under next.context count as your context unless
+--context is given.`,
+		Example: `  lazyfocus next
+  lazyfocus next --available 30m --context home,calls
+  lazyfocus next --energy low
+  lazyfocus next --limit 1 --json`,
+		Args: cobra.NoArgs,
+		RunE: runNext,
+	}
+
+	cmd.Flags().String("available", "", "Time you have now (e.g. 30m, 1h30m); tasks that fit rank higher")
+	cmd.Flags().StringSlice("context", nil, "Tags that fit where you are (e.g. home,calls)")
+	cmd.Flags().String("energy", "", "Energy you have now (low, medium, high); tasks whose effort suits it rank higher")
+	cmd.Flags().Int("limit", 5, "Number of suggestions to show; 0 shows every available task")
+
+	return cmd
+}
+
+func runNext(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	opts, limit, err := nextOptions(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	// A truncated list still has tasks worth suggesting
+	tasks, err := svc.GetAllTasks(ctx, service.TaskFilters{})
+	var truncated *service.TruncatedError
+	if err != nil && !errors.As(err, &truncated) {
+		return handleError(cmd, err)
+	}
+
+	if GetQuietFlag() {
+		return nil
+	}
+
+	suggestions := next.Suggest(tasks, opts)
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	formatOptions := output.TaskFormatOptions{ShowProject: true}
+	if format := GetOutputFlag(); format == OutputHuman || format == OutputTable {
+		suggested := make([]domain.Task, len(suggestions))
+		for i, s := range suggestions {
+			suggested[i] = s.Task
+		}
+		numbers, err := saveTaskIndex(suggested)
+		if err != nil {
+			cmd.PrintErrf("Warning: %v; tasks are not numbered\n", err)
+		}
+		formatOptions.Numbers = numbers
+	}
+
+	return getFormatter().FormatSuggestions(ctx, cmd.OutOrStdout(), suggestions, formatOptions)
+}
+
+// nextOptions reads the scoring options from the config and flags, and the
+// number of suggestions to show
+func nextOptions(cmd *cobra.Command) (next.Options, int, error) {
+	opts := next.Options{Weights: next.DefaultWeights, Now: time.Now()}
+	if cfg, err := config.FromContext(cmd.Context()); err == nil {
+		opts.Weights = nextWeights(cfg.Next.Weights)
+		opts.Context = cfg.Next.Context
+	}
+
+	if cmd.Flags().Changed("context") {
+		opts.Context, _ = cmd.Flags().GetStringSlice("context")
+	}
+
+	if available, _ := cmd.Flags().GetString("available"); available != "" {
+		minutes, err := domain.ParseEstimate(available)
+		if err != nil {
+			return next.Options{}, 0, invalidInput("invalid --available: %w", err)
+		}
+		opts.Available = minutes
+	}
+
+	if energy, _ := cmd.Flags().GetString("energy"); energy != "" {
+		effort, err := domain.ParseEffort(energy)
+		if err != nil {
+			return next.Options{}, 0, invalidInput("invalid --energy: %w", err)
+		}
+		opts.Energy = effort
+	}
+
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return next.Options{}, 0, invalidInput("invalid --limit value %d: must not be negative", limit)
+	}
+	return opts, limit, nil
+}
+
+// nextWeights converts configured weights to scoring weights
+func nextWeights(w config.NextWeightsConfig) next.Weights {
+	return next.Weights{Due: w.Due, Flagged: w.Flagged, Estimate: w.Estimate, Context: w.Context, Stale: w.Stale, Effort: w.Effort}
+}
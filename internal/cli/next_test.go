@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// nextTestTasks returns an overdue task, a flagged task, a long task and a
+// task at home
+func nextTestTasks() []domain.Task {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	return []domain.Task{
+		{ID: "plain", Name: "Water plants"},
+		{ID: "long", Name: "Write report", EstimatedMinutes: 120},
+		{ID: "home", Name: "Fix shelf", Tags: []string{"Home"}},
+		{ID: "flagged", Name: "Call bank", Flagged: true},
+		{ID: "overdue", Name: "Pay rent", DueDate: &yesterday},
+		{ID: "done", Name: "Already done", Completed: true, Flagged: true},
+	}
+}
+
+func TestNextCommand_RanksWithReasons(t *testing.T) {
+	mockService := &service.MockOmniFocusService{AllTasks: nextTestTasks()}
+
+	output, err := executeNextCommand(context.Background(), mockService, []string{"--context", "home"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{"NEXT (5 suggestions)", "Why: overdue by 1 day", "Why: flagged", "Why: tagged Home"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+	if strings.Contains(output, "Already done") {
+		t.Errorf("Expected completed task to be left out, got: %s", output)
+	}
+	overdue, flagged, home := strings.Index(output, "Pay rent"), strings.Index(output, "Call bank"), strings.Index(output, "Fix shelf")
+	if !(overdue < flagged && flagged < home) {
+		t.Errorf("Expected overdue, then flagged, then home task, got: %s", output)
+	}
+}
+
+func TestNextCommand_Limit(t *testing.T) {
+	mockService := &service.MockOmniFocusService{AllTasks: nextTestTasks()}
+
+	output, err := executeNextCommand(context.Background(), mockService, []string{"--limit", "1", "--json"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var parsed struct {
+		Suggestions []struct {
+			Task    domain.Task `json:"task"`
+			Score   float64     `json:"score"`
+			Reasons []struct {
+				Factor string `json:"factor"`
+				Text   string `json:"text"`
+			} `json:"reasons"`
+		} `json:"suggestions"`
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Expected valid JSON, got error: %v\nOutput: %s", err, output)
+	}
+	if parsed.Count != 1 || len(parsed.Suggestions) != 1 {
+		t.Fatalf("Expected 1 suggestion, got count=%d len=%d", parsed.Count, len(parsed.Suggestions))
+	}
+	if got := parsed.Suggestions[0]; got.Task.ID != "overdue" || len(got.Reasons) == 0 || got.Reasons[0].Factor != "due" {
+		t.Errorf("Expected overdue task with a due reason, got: %+v", got)
+	}
+}
+
+func TestNextCommand_Available(t *testing.T) {
+	mockService := &service.MockOmniFocusService{AllTasks: nextTestTasks()}
+
+	output, err := executeNextCommand(context.Background(), mockService, []string{"--available", "30m", "--limit", "0"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, "takes 2h, more than 30m") {
+		t.Errorf("Expected the long task to count against, got: %s", output)
+	}
+	if last := strings.LastIndex(output, "["); !strings.Contains(output[last:], "Write report") {
+		t.Errorf("Expected the long task last, got: %s", output)
+	}
+}
+
+func TestNextCommand_Energy(t *testing.T) {
+	mockService := &service.MockOmniFocusService{AllTasks: []domain.Task{
+		{ID: "hard", Name: "Write report", Note: "effort: high"},
+		{ID: "easy", Name: "Water plants", Note: "effort: low"},
+	}}
+
+	output, err := executeNextCommand(context.Background(), mockService, []string{"--energy", "low", "--shortcut-output"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := "Water plants, because low effort suits your energy\nWrite report, because high effort, more than your low energy\n"
+	if output != want {
+		t.Errorf("Expected %q, got: %q", want, output)
+	}
+}
+
+func TestNextCommand_InvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"available", []string{"--available", "soon"}, "invalid --available"},
+		{"limit", []string{"--limit", "-1"}, "invalid --limit"},
+		{"energy", []string{"--energy", "sleepy"}, "invalid --energy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &service.MockOmniFocusService{AllTasks: nextTestTasks()}
+
+			_, err := executeNextCommand(context.Background(), mockService, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNextCommand_UsesConfig(t *testing.T) {
+	mockService := &service.MockOmniFocusService{AllTasks: nextTestTasks()}
+	cfg := &config.Config{Next: config.NextConfig{
+		Weights: config.NextWeightsConfig{Context: 10},
+		Context: []string{"home"},
+	}}
+
+	output, err := executeNextCommand(config.ContextWithConfig(context.Background(), cfg), mockService, []string{"--limit", "1", "--shortcut-output"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if want := "Fix shelf, because tagged Home\n"; output != want {
+		t.Errorf("Expected %q, got: %q", want, output)
+	}
+}
+
+func TestNextCommand_ServiceError(t *testing.T) {
+	mockService := &service.MockOmniFocusService{AllTasksErr: errors.New("OmniFocus is not running")}
+
+	_, err := executeNextCommand(context.Background(), mockService, []string{})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+// executeNextCommand runs the next command with ctx carrying the service
+func executeNextCommand(ctx context.Context, mockService service.OmniFocusService, args []string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewNextCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+
+	rootCmd.SetArgs(append([]string{"next"}, args...))
+
+	err := rootCmd.ExecuteContext(ContextWithService(ctx, mockService))
+	return buf.String(), err
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// openURL opens a URL in its default application; tests replace it
+var openURL = func(url string) error {
+	return exec.Command("open", url).Run()
+}
+
+// NewOpenCommand creates the open command
+func NewOpenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "open <id>",
+		Short: "Open a task in OmniFocus",
+		Long: `Open a task in the OmniFocus app through its omnifocus:///task/<id> link,
+for editing that lazyfocus does not support. The ID is not checked; OmniFocus
+reports tasks it cannot find. A number such as 3 opens that task of the last
+tasks listing.`,
+		Example: `  lazyfocus open abc123
+  lazyfocus open abc123 --print  # Print the link instead of opening it`,
+		Args: cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			"skipServiceSetup": "true",
+		},
+		RunE: runOpen,
+	}
+
+	cmd.Flags().Bool("print", false, "Print the link without opening it")
+
+	return cmd
+}
+
+// openResult is the JSON shape of open output
+type openResult struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	printOnly, _ := cmd.Flags().GetBool("print")
+	id, err := resolveTaskID(args[0])
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	result := openResult{ID: id, URL: domain.TaskURL(id)}
+
+	if !printOnly {
+		if err := openURL(result.URL); err != nil {
+			return handleError(cmd, fmt.Errorf("failed to open %s: %w", result.URL, err))
+		}
+	}
+
+	switch {
+	case GetJSONFlag():
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to encode result: %w", err))
+		}
+		cmd.Println(string(data))
+	case printOnly:
+		cmd.Println(result.URL)
+	case !GetQuietFlag():
+		cmd.Printf("✓ Opened %s\n", result.URL)
+	}
+	return nil
+}
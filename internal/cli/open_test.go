@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func executeOpenCommand(args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewOpenCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs(append([]string{"open"}, args...))
+
+	err := rootCmd.ExecuteContext(context.Background())
+	return buf.String(), err
+}
+
+func stubOpenURL(t *testing.T, err error) *[]string {
+	t.Helper()
+	var opened []string
+	original := openURL
+	openURL = func(url string) error {
+		opened = append(opened, url)
+		return err
+	}
+	t.Cleanup(func() { openURL = original })
+	return &opened
+}
+
+func TestOpenCommand(t *testing.T) {
+	opened := stubOpenURL(t, nil)
+
+	output, err := executeOpenCommand("abc123")
+	if err != nil {
+		t.Fatalf("open error = %v", err)
+	}
+	if len(*opened) != 1 || (*opened)[0] != "omnifocus:///task/abc123" {
+		t.Errorf("opened %v, want [omnifocus:///task/abc123]", *opened)
+	}
+	if !strings.Contains(output, "Opened omnifocus:///task/abc123") {
+		t.Errorf("Expected confirmation, got: %s", output)
+	}
+}
+
+func TestOpenCommand_PrintJSON(t *testing.T) {
+	opened := stubOpenURL(t, nil)
+
+	output, err := executeOpenCommand("abc123", "--print", "--json")
+	if err != nil {
+		t.Fatalf("open error = %v", err)
+	}
+	if len(*opened) != 0 {
+		t.Errorf("--print opened %v, want nothing", *opened)
+	}
+	if !strings.Contains(output, `"url": "omnifocus:///task/abc123"`) {
+		t.Errorf("Expected JSON with the link, got: %s", output)
+	}
+}
+
+func TestOpenCommand_Error(t *testing.T) {
+	stubOpenURL(t, errors.New("exit status 1"))
+
+	if _, err := executeOpenCommand("abc123"); err == nil || !strings.Contains(err.Error(), "failed to open") {
+		t.Errorf("open error = %v, want failure to open", err)
+	}
+}
@@ -0,0 +1,304 @@
+package output
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+)
+
+// column describes a named field extracted from a value of type T
+type column[T any] struct {
+	name  string
+	value func(T) string
+}
+
+// taskColumns lists every column available for tasks
+var taskColumns = []column[domain.Task]{
+	{"id", func(t domain.Task) string { return t.ID }},
+	{"name", func(t domain.Task) string { return t.Name }},
+	{"note", func(t domain.Task) string { return t.Note }},
+	{"project", func(t domain.Task) string { return t.ProjectName }},
+	{"projectId", func(t domain.Task) string { return t.ProjectID }},
+	{"tags", func(t domain.Task) string { return strings.Join(t.Tags, ",") }},
+	{"due", func(t domain.Task) string { return formatCSVTime(t.DueDate) }},
+	{"defer", func(t domain.Task) string { return formatCSVTime(t.DeferDate) }},
+	{"flagged", func(t domain.Task) string { return strconv.FormatBool(t.Flagged) }},
+	{"completed", func(t domain.Task) string { return strconv.FormatBool(t.Completed) }},
+	{"completedDate", func(t domain.Task) string { return formatCSVTime(t.CompletedDate) }},
+}
+
+// projectColumns lists every column available for projects
+var projectColumns = []column[domain.Project]{
+	{"id", func(p domain.Project) string { return p.ID }},
+	{"name", func(p domain.Project) string { return p.Name }},
+	{"status", func(p domain.Project) string { return p.Status }},
+	{"note", func(p domain.Project) string { return p.Note }},
+	{"taskCount", func(p domain.Project) string { return strconv.Itoa(p.TaskCount) }},
+}
+
+// tagColumns lists every column available for tags
+var tagColumns = []column[domain.Tag]{
+	{"id", func(t domain.Tag) string { return t.ID }},
+	{"name", func(t domain.Tag) string { return t.Name }},
+	{"parent", func(t domain.Tag) string { return t.ParentID }},
+}
+
+// Default columns used when --columns is not given
+var (
+	defaultTaskColumns    = []string{"id", "name", "project", "tags", "due", "flagged"}
+	defaultProjectColumns = []string{"id", "name", "status", "taskCount"}
+	defaultTagColumns     = []string{"id", "name", "parent"}
+)
+
+// ValidateColumns returns an error if any column is unknown to every entity type
+func ValidateColumns(columns []string) error {
+	for _, name := range columns {
+		if !hasColumn(taskColumns, name) && !hasColumn(projectColumns, name) && !hasColumn(tagColumns, name) {
+			return fmt.Errorf("unknown column %q", name)
+		}
+	}
+	return nil
+}
+
+// CSVFormatter implements Formatter interface for delimiter-separated output
+type CSVFormatter struct {
+	delimiter rune
+	columns   []string
+}
+
+// NewCSVFormatter creates a comma-separated formatter restricted to the given columns
+func NewCSVFormatter(columns []string) *CSVFormatter {
+	return &CSVFormatter{delimiter: ',', columns: columns}
+}
+
+// NewTSVFormatter creates a tab-separated formatter restricted to the given columns
+func NewTSVFormatter(columns []string) *CSVFormatter {
+	return &CSVFormatter{delimiter: '\t', columns: columns}
+}
+
+// FormatTasks formats tasks as one row per task
+func (f *CSVFormatter) FormatTasks(ctx context.Context, w io.Writer, tasks []domain.Task, options TaskFormatOptions) error {
+	return formatRows(ctx, w, f, selectColumns(taskColumns, defaultTaskColumns, f.columns), tasks)
+}
+
+// FormatProjects formats projects as one row per project
+func (f *CSVFormatter) FormatProjects(ctx context.Context, w io.Writer, projects []domain.Project, options ProjectFormatOptions) error {
+	return formatRows(ctx, w, f, selectColumns(projectColumns, defaultProjectColumns, f.columns), projects)
+}
+
+// FormatTags formats tags as one row per tag, flattening the hierarchy
+func (f *CSVFormatter) FormatTags(ctx context.Context, w io.Writer, tags []domain.Tag, options TagFormatOptions) error {
+	return formatRows(ctx, w, f, selectColumns(tagColumns, defaultTagColumns, f.columns), flattenTags(tags))
+}
+
+// FormatTask formats a single task as a one-row table
+func (f *CSVFormatter) FormatTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return f.FormatTasks(ctx, w, []domain.Task{task}, TaskFormatOptions{})
+}
+
+// FormatProject formats a single project as a one-row table
+func (f *CSVFormatter) FormatProject(ctx context.Context, w io.Writer, project domain.Project) error {
+	return f.FormatProjects(ctx, w, []domain.Project{project}, ProjectFormatOptions{})
+}
+
+// FormatTag formats a single tag as a one-row table
+func (f *CSVFormatter) FormatTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return formatRows(ctx, w, f, selectColumns(tagColumns, defaultTagColumns, f.columns), []domain.Tag{tag})
+}
+
+// FormatError formats an error as a single-column table
+func (f *CSVFormatter) FormatError(ctx context.Context, w io.Writer, err error) error {
+	return f.formatTable(ctx, w, []string{"error"}, [][]string{{err.Error()}})
+}
+
+// FormatCreatedTask formats a newly created task as a one-row table
+func (f *CSVFormatter) FormatCreatedTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return f.FormatTask(ctx, w, task)
+}
+
+// FormatModifiedTask formats a modified task as a one-row table
+func (f *CSVFormatter) FormatModifiedTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return f.FormatTask(ctx, w, task)
+}
+
+// FormatCompletedTask formats a completed task operation result as a one-row table
+func (f *CSVFormatter) FormatCompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.formatOperationResult(ctx, w, result)
+}
+
+// FormatUncompletedTask formats a reopened task operation result as a one-row table
+func (f *CSVFormatter) FormatUncompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.formatOperationResult(ctx, w, result)
+}
+
+// FormatDropped formats a dropped task or project operation result as a one-row table
+func (f *CSVFormatter) FormatDropped(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.formatOperationResult(ctx, w, result)
+}
+
+// FormatDeletedTask formats a deleted task operation result as a one-row table
+func (f *CSVFormatter) FormatDeletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.formatOperationResult(ctx, w, result)
+}
+
+// FormatCreatedTag formats a newly created tag as a one-row table
+func (f *CSVFormatter) FormatCreatedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return f.FormatTag(ctx, w, tag)
+}
+
+// FormatRenamedTag formats a renamed tag as a one-row table
+func (f *CSVFormatter) FormatRenamedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return f.FormatTag(ctx, w, tag)
+}
+
+// FormatDeletedTag formats a deleted tag operation result as a one-row table
+func (f *CSVFormatter) FormatDeletedTag(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.formatOperationResult(ctx, w, result)
+}
+
+// FormatForecasts formats projected project completion dates as one row per project
+func (f *CSVFormatter) FormatForecasts(ctx context.Context, w io.Writer, forecasts []stats.ProjectForecast) error {
+	rows := make([][]string, 0, len(forecasts))
+	for _, forecast := range forecasts {
+		rows = append(rows, []string{
+			forecast.ProjectID,
+			forecast.ProjectName,
+			strconv.Itoa(forecast.Remaining),
+			strconv.FormatFloat(forecast.RatePerWeek, 'f', -1, 64),
+			formatCSVTime(forecast.Estimate),
+		})
+	}
+	return f.formatTable(ctx, w, []string{"projectId", "projectName", "remaining", "ratePerWeek", "estimate"}, rows)
+}
+
+// FormatHeatmap formats daily completion counts as one row per day
+func (f *CSVFormatter) FormatHeatmap(ctx context.Context, w io.Writer, heatmap stats.Heatmap) error {
+	days := heatmap.Days()
+	rows := make([][]string, 0, len(days))
+	for _, day := range days {
+		rows = append(rows, []string{day.Date.Format("2006-01-02"), strconv.Itoa(day.Count)})
+	}
+	return f.formatTable(ctx, w, []string{"date", "count"}, rows)
+}
+
+// FormatSuggestions formats suggested tasks as one row per task, best first
+func (f *CSVFormatter) FormatSuggestions(ctx context.Context, w io.Writer, suggestions []next.Suggestion, options TaskFormatOptions) error {
+	rows := make([][]string, 0, len(suggestions))
+	for _, s := range suggestions {
+		rows = append(rows, []string{
+			s.Task.ID,
+			s.Task.Name,
+			strconv.FormatFloat(s.Score, 'f', -1, 64),
+			s.Explain(),
+		})
+	}
+	return f.formatTable(ctx, w, []string{"id", "name", "score", "reasons"}, rows)
+}
+
+// formatOperationResult formats an operation result as a one-row table
+func (f *CSVFormatter) formatOperationResult(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.formatTable(ctx, w,
+		[]string{"id", "success", "message"},
+		[][]string{{result.ID, strconv.FormatBool(result.Success), result.Message}},
+	)
+}
+
+// formatTable writes a header and rows to w using the formatter's delimiter
+func (f *CSVFormatter) formatTable(ctx context.Context, w io.Writer, header []string, rows [][]string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma = f.delimiter
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatRows writes items to w using the given columns
+func formatRows[T any](ctx context.Context, w io.Writer, f *CSVFormatter, columns []column[T], items []T) error {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = col.value(item)
+		}
+		rows = append(rows, row)
+	}
+
+	return f.formatTable(ctx, w, header, rows)
+}
+
+// selectColumns picks the requested columns that apply to this entity type,
+// falling back to the defaults when none are requested or none apply
+func selectColumns[T any](all []column[T], defaults, requested []string) []column[T] {
+	pick := func(names []string) []column[T] {
+		var selected []column[T]
+		for _, name := range names {
+			for _, col := range all {
+				if col.name == name {
+					selected = append(selected, col)
+					break
+				}
+			}
+		}
+		return selected
+	}
+
+	if selected := pick(requested); len(selected) > 0 {
+		return selected
+	}
+	return pick(defaults)
+}
+
+// hasColumn reports whether a column with the given name exists
+func hasColumn[T any](columns []column[T], name string) bool {
+	for _, col := range columns {
+		if col.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenTags returns tags and their descendants in depth-first order
+func flattenTags(tags []domain.Tag) []domain.Tag {
+	var flat []domain.Tag
+	for _, tag := range tags {
+		flat = append(flat, tag)
+		flat = append(flat, flattenTags(tag.Children)...)
+	}
+	return flat
+}
+
+// formatCSVTime formats an optional time as RFC 3339, or empty when nil
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,154 @@
+package output
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+)
+
+func parseCSV(t *testing.T, output string, delimiter rune) [][]string {
+	t.Helper()
+	r := csv.NewReader(strings.NewReader(output))
+	r.Comma = delimiter
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid delimited data: %v\nGot: %s", err, output)
+	}
+	return records
+}
+
+func TestCSVFormatter_FormatTasks_DefaultColumns(t *testing.T) {
+	due := time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)
+	tasks := []domain.Task{
+		{ID: "t1", Name: "Buy milk", ProjectName: "Errands", Tags: []string{"home", "quick"}, DueDate: &due, Flagged: true},
+		{ID: "t2", Name: "Write report"},
+	}
+
+	records := parseCSV(t, rendered(t, NewCSVFormatter(nil)).FormatTasks(tasks, TaskFormatOptions{}), ',')
+
+	want := [][]string{
+		{"id", "name", "project", "tags", "due", "flagged"},
+		{"t1", "Buy milk", "Errands", "home,quick", "2024-01-15T17:00:00Z", "true"},
+		{"t2", "Write report", "", "", "", "false"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("FormatTasks() returned %d records, want %d", len(records), len(want))
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("FormatTasks() record %d = %v, want %v", i, records[i], want[i])
+		}
+	}
+}
+
+func TestCSVFormatter_FormatTasks_QuotesSpecialCharacters(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "t1", Name: `Say "hi", then leave`, Note: "line one\nline two"},
+	}
+
+	output := rendered(t, NewCSVFormatter([]string{"name", "note"})).FormatTasks(tasks, TaskFormatOptions{})
+
+	if !strings.Contains(output, `"Say ""hi"", then leave"`) {
+		t.Errorf("FormatTasks() did not quote name correctly\nGot: %s", output)
+	}
+
+	records := parseCSV(t, output, ',')
+	if records[1][0] != `Say "hi", then leave` || records[1][1] != "line one\nline two" {
+		t.Errorf("FormatTasks() round-trip = %v", records[1])
+	}
+}
+
+func TestCSVFormatter_SelectedColumns(t *testing.T) {
+	formatter := NewCSVFormatter([]string{"name", "due", "id"})
+
+	taskRecords := parseCSV(t, rendered(t, formatter).FormatTasks([]domain.Task{{ID: "t1", Name: "Task"}}, TaskFormatOptions{}), ',')
+	if got := strings.Join(taskRecords[0], ","); got != "name,due,id" {
+		t.Errorf("FormatTasks() header = %q, want %q", got, "name,due,id")
+	}
+
+	// "due" does not apply to projects and is skipped
+	projectRecords := parseCSV(t, rendered(t, formatter).FormatProjects([]domain.Project{{ID: "p1", Name: "Work"}}, ProjectFormatOptions{}), ',')
+	if got := strings.Join(projectRecords[0], ","); got != "name,id" {
+		t.Errorf("FormatProjects() header = %q, want %q", got, "name,id")
+	}
+}
+
+func TestCSVFormatter_SelectedColumns_FallsBackToDefaults(t *testing.T) {
+	formatter := NewCSVFormatter([]string{"due"})
+
+	records := parseCSV(t, rendered(t, formatter).FormatTags([]domain.Tag{{ID: "g1", Name: "Work"}}, TagFormatOptions{}), ',')
+	if got := strings.Join(records[0], ","); got != "id,name,parent" {
+		t.Errorf("FormatTags() header = %q, want %q", got, "id,name,parent")
+	}
+}
+
+func TestCSVFormatter_FormatTags_FlattensHierarchy(t *testing.T) {
+	tags := []domain.Tag{
+		{ID: "g1", Name: "Work", Children: []domain.Tag{
+			{ID: "g2", Name: "Meetings", ParentID: "g1"},
+		}},
+		{ID: "g3", Name: "Home"},
+	}
+
+	records := parseCSV(t, rendered(t, NewCSVFormatter(nil)).FormatTags(tags, TagFormatOptions{}), ',')
+
+	if len(records) != 4 {
+		t.Fatalf("FormatTags() returned %d records, want 4", len(records))
+	}
+	if records[2][0] != "g2" || records[2][2] != "g1" {
+		t.Errorf("FormatTags() child record = %v, want id g2 with parent g1", records[2])
+	}
+}
+
+func TestTSVFormatter_UsesTabs(t *testing.T) {
+	output := rendered(t, NewTSVFormatter([]string{"id", "name"})).FormatProjects(
+		[]domain.Project{{ID: "p1", Name: "Home, Garden"}}, ProjectFormatOptions{})
+
+	want := "id\tname\np1\tHome, Garden\n"
+	if output != want {
+		t.Errorf("FormatProjects() = %q, want %q", output, want)
+	}
+}
+
+func TestCSVFormatter_FormatError(t *testing.T) {
+	records := parseCSV(t, rendered(t, NewCSVFormatter(nil)).FormatError(errors.New("boom")), ',')
+
+	if len(records) != 2 || records[0][0] != "error" || records[1][0] != "boom" {
+		t.Errorf("FormatError() = %v, want [[error] [boom]]", records)
+	}
+}
+
+func TestCSVFormatter_FormatCompletedTask(t *testing.T) {
+	records := parseCSV(t, rendered(t, NewCSVFormatter(nil)).FormatCompletedTask(domain.NewSuccessResult("t1", "Task completed")), ',')
+
+	if strings.Join(records[1], ",") != "t1,true,Task completed" {
+		t.Errorf("FormatCompletedTask() row = %v", records[1])
+	}
+}
+
+func TestCSVFormatter_FormatHeatmap(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	heatmap := stats.Heatmap{
+		Weeks: [][]stats.HeatmapDay{{{Date: monday, Count: 2}}},
+	}
+
+	output := rendered(t, NewCSVFormatter(nil)).FormatHeatmap(heatmap)
+
+	if output != "date,count\n2024-01-15,2\n" {
+		t.Errorf("FormatHeatmap() = %q", output)
+	}
+}
+
+func TestValidateColumns(t *testing.T) {
+	if err := ValidateColumns([]string{"id", "name", "due", "project", "status", "parent"}); err != nil {
+		t.Errorf("ValidateColumns() error = %v, want nil", err)
+	}
+	if err := ValidateColumns([]string{"id", "bogus"}); err == nil {
+		t.Error("ValidateColumns() error = nil, want error for unknown column")
+	}
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+)
+
+// Kinds of error, reported as "kind" in JSON errors
+const (
+	KindGeneral             = "error"
+	KindOmniFocusNotRunning = "omnifocus_not_running"
+	KindNotFound            = "not_found"
+	KindValidation          = "validation"
+	KindPermissionDenied    = "permission_denied"
+	KindTimeout             = "timeout"
+	KindInterrupted         = "interrupted"
+)
+
+// ValidationError marks an error in the arguments or flags given to a
+// command, as opposed to a failure talking to OmniFocus
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the invalid input error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code for validation errors
+func (e *ValidationError) ExitCode() int {
+	return ExitValidationError
+}
+
+// ExitCodeFor returns the exit code the CLI exits with for err: the code of
+// an error that reports its own, else the code for a known OmniFocus failure
+// anywhere in its chain, else ExitGeneralError. A nil err is ExitSuccess.
+func ExitCodeFor(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+
+	var coded interface{ ExitCode() int }
+	switch {
+	case errors.As(err, &coded):
+		return coded.ExitCode()
+	case errors.Is(err, bridge.ErrOmniFocusNotRunning):
+		return ExitOmniFocusNotRunning
+	case bridge.IsPermissionDenied(err):
+		return ExitPermissionDenied
+	case errors.Is(err, bridge.ErrNotFound):
+		return ExitItemNotFound
+	case errors.Is(err, bridge.ErrExecutionTimeout), errors.Is(err, context.DeadlineExceeded):
+		return ExitTimeout
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	default:
+		return ExitGeneralError
+	}
+}
+
+// ErrorKind returns the kind of err, matching its exit code
+func ErrorKind(err error) string {
+	code := ExitCodeFor(err)
+	for _, exit := range ExitCodes {
+		if exit.Code == code && exit.Kind != "" {
+			return exit.Kind
+		}
+	}
+	return KindGeneral
+}
+
+// errorSuggestion returns how to resolve err: the suggestion of an error
+// that carries one, else a hint for its kind
+func errorSuggestion(err error) string {
+	var suggested interface{ Suggestion() string }
+	if errors.As(err, &suggested) {
+		return suggested.Suggestion()
+	}
+
+	switch ExitCodeFor(err) {
+	case ExitOmniFocusNotRunning:
+		return "Start OmniFocus and try again"
+	case ExitPermissionDenied:
+		return "Run lazyfocus doctor for how to allow your terminal to control OmniFocus"
+	case ExitTimeout:
+		return "OmniFocus may be busy; try again"
+	default:
+		return ""
+	}
+}
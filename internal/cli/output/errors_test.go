@@ -0,0 +1,42 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantKind string
+	}{
+		{"nil", nil, ExitSuccess, KindGeneral},
+		{"plain", errors.New("boom"), ExitGeneralError, KindGeneral},
+		{"not running", fmt.Errorf("failed to execute task script: %w", bridge.ErrOmniFocusNotRunning), ExitOmniFocusNotRunning, KindOmniFocusNotRunning},
+		{"not found", fmt.Errorf("failed to get task: %w", bridge.NewNotFoundError("task", "abc")), ExitItemNotFound, KindNotFound},
+		{"validation", &ValidationError{Err: errors.New("invalid --limit")}, ExitValidationError, KindValidation},
+		{"permission denied", fmt.Errorf("%w: (-1743)", bridge.ErrAutomationNotPermitted), ExitPermissionDenied, KindPermissionDenied},
+		{"timeout", fmt.Errorf("failed to execute: %w", bridge.ErrExecutionTimeout), ExitTimeout, KindTimeout},
+		{"deadline", context.DeadlineExceeded, ExitTimeout, KindTimeout},
+		{"interrupted", fmt.Errorf("failed to get tasks: %w: %w", bridge.ErrExecutionCanceled, context.Canceled), ExitInterrupted, KindInterrupted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCodeFor(tt.err); got != tt.wantCode {
+				t.Errorf("ExitCodeFor() = %d, want %d", got, tt.wantCode)
+			}
+			if tt.err == nil {
+				return
+			}
+			if got := ErrorKind(tt.err); got != tt.wantKind {
+				t.Errorf("ErrorKind() = %q, want %q", got, tt.wantKind)
+			}
+		})
+	}
+}
@@ -2,57 +2,163 @@
 // It supports both human-readable and JSON output formats.
 package output
 
-import "github.com/pwojciechowski/lazyfocus/internal/domain"
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+)
 
-// Exit codes used by LazyFocus CLI
+// Exit codes used by LazyFocus CLI; see ExitCodeFor
 const (
-	ExitSuccess             = 0 // Successful execution
-	ExitGeneralError        = 1 // General error
-	ExitOmniFocusNotRunning = 2 // OmniFocus is not running
-	ExitItemNotFound        = 3 // Requested item not found
+	ExitSuccess             = 0   // Successful execution
+	ExitGeneralError        = 1   // General error
+	ExitOmniFocusNotRunning = 2   // OmniFocus is not running
+	ExitItemNotFound        = 3   // Requested item not found
+	ExitValidationError     = 4   // Invalid arguments or flag values
+	ExitPermissionDenied    = 5   // Automation permission denied
+	ExitTimeout             = 6   // OmniFocus did not answer in time
+	ExitInterrupted         = 130 // Interrupted, e.g. with Ctrl+C
 )
 
-// Formatter defines the interface for formatting LazyFocus output
+// ExitCode documents an exit code for help output and man pages
+type ExitCode struct {
+	Code        int
+	Kind        string // Kind of error in JSON output
+	Description string
+}
+
+// ExitCodes lists the exit codes used by the CLI
+var ExitCodes = []ExitCode{
+	{Code: ExitSuccess, Description: "Success"},
+	{Code: ExitGeneralError, Kind: KindGeneral, Description: "General error, including unknown commands or flags and missing arguments"},
+	{Code: ExitOmniFocusNotRunning, Kind: KindOmniFocusNotRunning, Description: "OmniFocus is not running"},
+	{Code: ExitItemNotFound, Kind: KindNotFound, Description: "Requested item not found"},
+	{Code: ExitValidationError, Kind: KindValidation, Description: "Invalid arguments or flag values, such as an unrecognized date"},
+	{Code: ExitPermissionDenied, Kind: KindPermissionDenied, Description: "macOS does not allow the terminal to control OmniFocus"},
+	{Code: ExitTimeout, Kind: KindTimeout, Description: "OmniFocus did not answer in time"},
+	{Code: ExitInterrupted, Kind: KindInterrupted, Description: "Interrupted with Ctrl+C; the running script was stopped"},
+}
+
+// Formatter defines the interface for formatting LazyFocus output. Each
+// method writes to w and returns the first error writing, or ctx's error
+// once it is done, so a canceled command stops writing.
 type Formatter interface {
 	// FormatTasks formats a list of tasks with the given options
-	FormatTasks(tasks []domain.Task, options TaskFormatOptions) string
+	FormatTasks(ctx context.Context, w io.Writer, tasks []domain.Task, options TaskFormatOptions) error
 
 	// FormatProjects formats a list of projects with the given options
-	FormatProjects(projects []domain.Project, options ProjectFormatOptions) string
+	FormatProjects(ctx context.Context, w io.Writer, projects []domain.Project, options ProjectFormatOptions) error
 
 	// FormatTags formats a list of tags with the given options
-	FormatTags(tags []domain.Tag, options TagFormatOptions) string
+	FormatTags(ctx context.Context, w io.Writer, tags []domain.Tag, options TagFormatOptions) error
 
 	// FormatTask formats a single task
-	FormatTask(task domain.Task) string
+	FormatTask(ctx context.Context, w io.Writer, task domain.Task) error
 
 	// FormatProject formats a single project
-	FormatProject(project domain.Project) string
+	FormatProject(ctx context.Context, w io.Writer, project domain.Project) error
 
 	// FormatTag formats a single tag
-	FormatTag(tag domain.Tag) string
+	FormatTag(ctx context.Context, w io.Writer, tag domain.Tag) error
 
 	// FormatError formats an error message
-	FormatError(err error) string
+	FormatError(ctx context.Context, w io.Writer, err error) error
 
 	// FormatCreatedTask formats a newly created task
-	FormatCreatedTask(task domain.Task) string
+	FormatCreatedTask(ctx context.Context, w io.Writer, task domain.Task) error
 
 	// FormatModifiedTask formats a modified task
-	FormatModifiedTask(task domain.Task) string
+	FormatModifiedTask(ctx context.Context, w io.Writer, task domain.Task) error
 
 	// FormatCompletedTask formats a completed task operation result
-	FormatCompletedTask(result domain.OperationResult) string
+	FormatCompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error
+
+	// FormatUncompletedTask formats a reopened task operation result
+	FormatUncompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error
+
+	// FormatDropped formats a dropped task or project operation result
+	FormatDropped(ctx context.Context, w io.Writer, result domain.OperationResult) error
 
 	// FormatDeletedTask formats a deleted task operation result
-	FormatDeletedTask(result domain.OperationResult) string
+	FormatDeletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error
+
+	// FormatCreatedTag formats a newly created tag
+	FormatCreatedTag(ctx context.Context, w io.Writer, tag domain.Tag) error
+
+	// FormatRenamedTag formats a renamed tag
+	FormatRenamedTag(ctx context.Context, w io.Writer, tag domain.Tag) error
+
+	// FormatDeletedTag formats a deleted tag operation result
+	FormatDeletedTag(ctx context.Context, w io.Writer, result domain.OperationResult) error
+
+	// FormatForecasts formats projected project completion dates
+	FormatForecasts(ctx context.Context, w io.Writer, forecasts []stats.ProjectForecast) error
+
+	// FormatHeatmap formats daily completion counts
+	FormatHeatmap(ctx context.Context, w io.Writer, heatmap stats.Heatmap) error
+
+	// FormatSuggestions formats tasks suggested to do next, best first, with
+	// the reasons for each
+	FormatSuggestions(ctx context.Context, w io.Writer, suggestions []next.Suggestion, options TaskFormatOptions) error
+}
+
+// TaskWriter writes tasks one at a time as they arrive
+type TaskWriter interface {
+	WriteTask(task domain.Task) error
+}
+
+// StreamingFormatter is a Formatter that can also write tasks to w as they
+// arrive, so a large listing is never held in full
+type StreamingFormatter interface {
+	Formatter
+	TaskWriter(ctx context.Context, w io.Writer) TaskWriter
+}
+
+// printer writes formatted output to w, keeping the first error, or the
+// context's error once it is done, so a run of writes is checked once
+type printer struct {
+	ctx context.Context
+	w   io.Writer
+	err error
+}
+
+// newPrinter creates a printer writing to w until ctx is done
+func newPrinter(ctx context.Context, w io.Writer) *printer {
+	return &printer{ctx: ctx, w: w}
+}
+
+// print writes s unless an earlier write failed or the context is done
+func (p *printer) print(s string) {
+	if p.err == nil {
+		p.err = p.ctx.Err()
+	}
+	if p.err == nil {
+		_, p.err = io.WriteString(p.w, s)
+	}
+}
+
+// printf formats and writes like fmt.Fprintf
+func (p *printer) printf(format string, args ...any) {
+	p.print(fmt.Sprintf(format, args...))
+}
+
+// write writes s to w unless ctx is done
+func write(ctx context.Context, w io.Writer, s string) error {
+	p := newPrinter(ctx, w)
+	p.print(s)
+	return p.err
 }
 
 // TaskFormatOptions contains options for formatting tasks
 type TaskFormatOptions struct {
-	ShowCompleted bool // Include completed tasks in output
-	ShowProject   bool // Show project name for each task
-	ShowTags      bool // Show tags for each task
+	ShowCompleted bool           // Include completed tasks in output
+	ShowProject   bool           // Show project name for each task
+	ShowTags      bool           // Show tags for each task
+	Numbers       map[string]int // Short number shown before each task, by ID
 }
 
 // ProjectFormatOptions contains options for formatting projects
@@ -0,0 +1,152 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+)
+
+// stringFormatter returns what a Formatter writes as a string, failing the
+// test if writing fails
+type stringFormatter struct {
+	t *testing.T
+	f Formatter
+}
+
+// rendered wraps f to return its output as strings
+func rendered(t *testing.T, f Formatter) stringFormatter {
+	t.Helper()
+	return stringFormatter{t: t, f: f}
+}
+
+// render runs format with a buffer and returns what it wrote
+func (s stringFormatter) render(format func(ctx context.Context, w io.Writer) error) string {
+	s.t.Helper()
+	var buf bytes.Buffer
+	if err := format(context.Background(), &buf); err != nil {
+		s.t.Fatalf("formatting failed: %v", err)
+	}
+	return buf.String()
+}
+
+func (s stringFormatter) FormatTasks(tasks []domain.Task, options TaskFormatOptions) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatTasks(ctx, w, tasks, options) })
+}
+
+func (s stringFormatter) FormatProjects(projects []domain.Project, options ProjectFormatOptions) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatProjects(ctx, w, projects, options) })
+}
+
+func (s stringFormatter) FormatTags(tags []domain.Tag, options TagFormatOptions) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatTags(ctx, w, tags, options) })
+}
+
+func (s stringFormatter) FormatTask(task domain.Task) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatTask(ctx, w, task) })
+}
+
+func (s stringFormatter) FormatProject(project domain.Project) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatProject(ctx, w, project) })
+}
+
+func (s stringFormatter) FormatTag(tag domain.Tag) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatTag(ctx, w, tag) })
+}
+
+func (s stringFormatter) FormatError(err error) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatError(ctx, w, err) })
+}
+
+func (s stringFormatter) FormatCreatedTask(task domain.Task) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatCreatedTask(ctx, w, task) })
+}
+
+func (s stringFormatter) FormatModifiedTask(task domain.Task) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatModifiedTask(ctx, w, task) })
+}
+
+func (s stringFormatter) FormatCompletedTask(result domain.OperationResult) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatCompletedTask(ctx, w, result) })
+}
+
+func (s stringFormatter) FormatUncompletedTask(result domain.OperationResult) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatUncompletedTask(ctx, w, result) })
+}
+
+func (s stringFormatter) FormatDropped(result domain.OperationResult) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatDropped(ctx, w, result) })
+}
+
+func (s stringFormatter) FormatDeletedTask(result domain.OperationResult) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatDeletedTask(ctx, w, result) })
+}
+
+func (s stringFormatter) FormatCreatedTag(tag domain.Tag) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatCreatedTag(ctx, w, tag) })
+}
+
+func (s stringFormatter) FormatRenamedTag(tag domain.Tag) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatRenamedTag(ctx, w, tag) })
+}
+
+func (s stringFormatter) FormatDeletedTag(result domain.OperationResult) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatDeletedTag(ctx, w, result) })
+}
+
+func (s stringFormatter) FormatForecasts(forecasts []stats.ProjectForecast) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatForecasts(ctx, w, forecasts) })
+}
+
+func (s stringFormatter) FormatHeatmap(heatmap stats.Heatmap) string {
+	return s.render(func(ctx context.Context, w io.Writer) error { return s.f.FormatHeatmap(ctx, w, heatmap) })
+}
+
+func (s stringFormatter) FormatSuggestions(suggestions []next.Suggestion, options TaskFormatOptions) string {
+	return s.render(func(ctx context.Context, w io.Writer) error {
+		return s.f.FormatSuggestions(ctx, w, suggestions, options)
+	})
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestFormatters_ReturnWriteErrors(t *testing.T) {
+	tasks := []domain.Task{{ID: "t1", Name: "Buy milk"}, {ID: "t2", Name: "Call Bob"}}
+	for name, formatter := range goldenFormatters() {
+		t.Run(name, func(t *testing.T) {
+			err := formatter.FormatTasks(context.Background(), failingWriter{}, tasks, TaskFormatOptions{})
+			if err == nil || err.Error() != "disk full" {
+				t.Errorf("FormatTasks() error = %v, want the write error", err)
+			}
+		})
+	}
+}
+
+func TestFormatters_StopWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tasks := []domain.Task{{ID: "t1", Name: "Buy milk"}}
+	for name, formatter := range goldenFormatters() {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := formatter.FormatTasks(ctx, &buf, tasks, TaskFormatOptions{})
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("FormatTasks() error = %v, want context.Canceled", err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("FormatTasks() wrote %q after the context was canceled", buf.String())
+			}
+		})
+	}
+}
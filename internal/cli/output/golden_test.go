@@ -0,0 +1,206 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+)
+
+// Run "go test ./internal/cli/output -update" to rewrite the golden files
+// after changing a formatter on purpose, then review the diff
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// goldenFormatters returns every formatter by its --output name, set up as
+// on a terminal without colors
+func goldenFormatters() map[string]Formatter {
+	return map[string]Formatter{
+		"human":    NewHumanFormatter(),
+		"json":     NewJSONFormatter(),
+		"jsonl":    NewJSONLinesFormatter(),
+		"csv":      NewCSVFormatter(nil),
+		"tsv":      NewTSVFormatter(nil),
+		"table":    NewTableFormatter(plainRenderer(), 0),
+		"shortcut": NewShortcutFormatter(),
+	}
+}
+
+// goldenDate returns noon on a day in 2020, far enough back that no
+// formatter names it relative to today
+func goldenDate(month time.Month, day int) *time.Time {
+	date := time.Date(2020, month, day, 12, 0, 0, 0, time.UTC)
+	return &date
+}
+
+// goldenTasks covers a plain, a flagged and completed, and a subtask with
+// an inherited due date
+func goldenTasks() []domain.Task {
+	return []domain.Task{
+		{
+			ID:          "t1",
+			Name:        "Buy milk",
+			Note:        "Semi-skimmed",
+			ProjectID:   "p1",
+			ProjectName: "Errands",
+			Tags:        []string{"shop", "quick"},
+			DueDate:     goldenDate(time.March, 10),
+			DeferDate:   goldenDate(time.March, 9),
+		},
+		{
+			ID:            "t2",
+			Name:          "Call Bob",
+			Flagged:       true,
+			Completed:     true,
+			CompletedDate: goldenDate(time.March, 8),
+		},
+		{
+			ID:               "t3",
+			Name:             "Pack boxes",
+			ProjectID:        "p2",
+			ProjectName:      "Move house",
+			EffectiveDueDate: goldenDate(time.April, 1),
+			EstimatedMinutes: 45,
+		},
+	}
+}
+
+// goldenCommands lists what each command formats, by command name
+var goldenCommands = []struct {
+	name   string
+	format func(f Formatter, ctx context.Context, w io.Writer) error
+}{
+	{"tasks", func(f Formatter, ctx context.Context, w io.Writer) error {
+		options := TaskFormatOptions{ShowProject: true, ShowTags: true, Numbers: map[string]int{"t1": 1, "t2": 2, "t3": 3}}
+		return f.FormatTasks(ctx, w, goldenTasks(), options)
+	}},
+	{"tasks-empty", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatTasks(ctx, w, nil, TaskFormatOptions{})
+	}},
+	{"projects", func(f Formatter, ctx context.Context, w io.Writer) error {
+		projects := []domain.Project{
+			{ID: "p1", Name: "Errands", Status: "active", TaskCount: 1, Note: "Weekly"},
+			{ID: "p2", Name: "Move house", Status: "on-hold", TaskCount: 12, Tasks: goldenTasks()[2:]},
+		}
+		return f.FormatProjects(ctx, w, projects, ProjectFormatOptions{ShowTasks: true, ShowNotes: true})
+	}},
+	{"tags", func(f Formatter, ctx context.Context, w io.Writer) error {
+		tags := []domain.Tag{
+			{ID: "g1", Name: "Places", Children: []domain.Tag{{ID: "g2", Name: "Shop", ParentID: "g1"}}},
+			{ID: "g3", Name: "quick"},
+		}
+		return f.FormatTags(ctx, w, tags, TagFormatOptions{})
+	}},
+	{"show-task", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatTask(ctx, w, goldenTasks()[0])
+	}},
+	{"show-project", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatProject(ctx, w, domain.Project{ID: "p1", Name: "Errands", Status: "active", Note: "Weekly", TaskCount: 1})
+	}},
+	{"show-tag", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatTag(ctx, w, domain.Tag{ID: "g3", Name: "quick"})
+	}},
+	{"error", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatError(ctx, w, errors.New("task not found: t9"))
+	}},
+	{"add", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatCreatedTask(ctx, w, goldenTasks()[0])
+	}},
+	{"modify", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatModifiedTask(ctx, w, goldenTasks()[2])
+	}},
+	{"complete", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatCompletedTask(ctx, w, domain.NewSuccessResult("t1", "Task completed"))
+	}},
+	{"uncomplete", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatUncompletedTask(ctx, w, domain.NewSuccessResult("t2", "Task reopened"))
+	}},
+	{"drop", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatDropped(ctx, w, domain.NewSuccessResult("p2", "Project dropped"))
+	}},
+	{"delete", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatDeletedTask(ctx, w, domain.NewSuccessResult("t3", "Task deleted"))
+	}},
+	{"tags-create", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatCreatedTag(ctx, w, domain.Tag{ID: "g4", Name: "waiting"})
+	}},
+	{"tags-rename", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatRenamedTag(ctx, w, domain.Tag{ID: "g4", Name: "waiting-for"})
+	}},
+	{"tags-delete", func(f Formatter, ctx context.Context, w io.Writer) error {
+		return f.FormatDeletedTag(ctx, w, domain.NewSuccessResult("g4", "Tag deleted"))
+	}},
+	{"report-forecast", func(f Formatter, ctx context.Context, w io.Writer) error {
+		forecasts := []stats.ProjectForecast{
+			{ProjectID: "p1", ProjectName: "Errands", Remaining: 4, RatePerWeek: 2, Estimate: goldenDate(time.March, 24)},
+			{ProjectID: "p2", ProjectName: "Move house", Remaining: 12},
+		}
+		return f.FormatForecasts(ctx, w, forecasts)
+	}},
+	{"report-heatmap", func(f Formatter, ctx context.Context, w io.Writer) error {
+		monday := time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC)
+		heatmap := stats.Heatmap{Max: 4, Total: 9}
+		counts := []int{0, 1, 4, 0, 2, 0, 0, 1, 0, 1}
+		for i, count := range counts {
+			if i%7 == 0 {
+				heatmap.Weeks = append(heatmap.Weeks, nil)
+			}
+			day := stats.HeatmapDay{Date: monday.AddDate(0, 0, i), Count: count}
+			heatmap.Weeks[i/7] = append(heatmap.Weeks[i/7], day)
+		}
+		return f.FormatHeatmap(ctx, w, heatmap)
+	}},
+	{"next", func(f Formatter, ctx context.Context, w io.Writer) error {
+		tasks := goldenTasks()
+		suggestions := []next.Suggestion{
+			{Task: tasks[0], Score: 3.5, Reasons: []next.Reason{
+				{Factor: "due", Score: 2.5, Text: "overdue"},
+				{Factor: "estimate", Score: 1, Text: "quick: 10m"},
+			}},
+			{Task: tasks[2], Score: 0},
+		}
+		return f.FormatSuggestions(ctx, w, suggestions, TaskFormatOptions{Numbers: map[string]int{"t1": 1, "t3": 3}})
+	}},
+}
+
+// TestGolden compares the output of every formatter for every command with
+// testdata/golden/<format>/<command>.golden
+func TestGolden(t *testing.T) {
+	for format, formatter := range goldenFormatters() {
+		for _, command := range goldenCommands {
+			t.Run(format+"/"+command.name, func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := command.format(formatter, context.Background(), &buf); err != nil {
+					t.Fatalf("formatting failed: %v", err)
+				}
+
+				path := filepath.Join("testdata", "golden", format, command.name+".golden")
+				if *update {
+					if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+
+				want, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("reading golden file: %v (run with -update to create it)", err)
+				}
+				if got := buf.String(); got != string(want) {
+					t.Errorf("output differs from %s:\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+				}
+			})
+		}
+	}
+}
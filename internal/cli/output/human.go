@@ -1,11 +1,15 @@
 package output
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
 )
 
 // HumanFormatter implements Formatter interface for human-readable output
@@ -17,8 +21,8 @@ func NewHumanFormatter() *HumanFormatter {
 }
 
 // FormatTasks formats tasks in a human-readable format
-func (f *HumanFormatter) FormatTasks(tasks []domain.Task, options TaskFormatOptions) string {
-	var b strings.Builder
+func (f *HumanFormatter) FormatTasks(ctx context.Context, w io.Writer, tasks []domain.Task, options TaskFormatOptions) error {
+	p := newPrinter(ctx, w)
 
 	// Header
 	taskCount := len(tasks)
@@ -26,28 +30,28 @@ func (f *HumanFormatter) FormatTasks(tasks []domain.Task, options TaskFormatOpti
 	if taskCount != 1 {
 		taskWord = "tasks"
 	}
-	b.WriteString(fmt.Sprintf("TASKS (%d %s)\n", taskCount, taskWord))
-	b.WriteString(strings.Repeat("─", 50) + "\n")
+	p.printf("TASKS (%d %s)\n", taskCount, taskWord)
+	p.print(strings.Repeat("─", 50) + "\n")
 
 	if taskCount == 0 {
-		b.WriteString("No tasks found\n")
-		return b.String()
+		p.print("No tasks found\n")
+		return p.err
 	}
 
 	// Tasks
 	for i, task := range tasks {
 		if i > 0 {
-			b.WriteString("\n")
+			p.print("\n")
 		}
-		b.WriteString(f.formatTaskLine(task, options))
+		p.print(f.formatTaskLine(task, options))
 	}
 
-	return b.String()
+	return p.err
 }
 
 // FormatProjects formats projects in a human-readable format
-func (f *HumanFormatter) FormatProjects(projects []domain.Project, options ProjectFormatOptions) string {
-	var b strings.Builder
+func (f *HumanFormatter) FormatProjects(ctx context.Context, w io.Writer, projects []domain.Project, options ProjectFormatOptions) error {
+	p := newPrinter(ctx, w)
 
 	// Header
 	projectCount := len(projects)
@@ -55,28 +59,28 @@ func (f *HumanFormatter) FormatProjects(projects []domain.Project, options Proje
 	if projectCount != 1 {
 		projectWord = "projects"
 	}
-	b.WriteString(fmt.Sprintf("PROJECTS (%d %s)\n", projectCount, projectWord))
-	b.WriteString(strings.Repeat("─", 50) + "\n")
+	p.printf("PROJECTS (%d %s)\n", projectCount, projectWord)
+	p.print(strings.Repeat("─", 50) + "\n")
 
 	if projectCount == 0 {
-		b.WriteString("No projects found\n")
-		return b.String()
+		p.print("No projects found\n")
+		return p.err
 	}
 
 	// Projects
 	for i, project := range projects {
 		if i > 0 {
-			b.WriteString("\n")
+			p.print("\n")
 		}
-		b.WriteString(f.formatProjectSection(project, options))
+		p.print(f.formatProjectSection(project, options))
 	}
 
-	return b.String()
+	return p.err
 }
 
 // FormatTags formats tags in a human-readable format
-func (f *HumanFormatter) FormatTags(tags []domain.Tag, options TagFormatOptions) string {
-	var b strings.Builder
+func (f *HumanFormatter) FormatTags(ctx context.Context, w io.Writer, tags []domain.Tag, options TagFormatOptions) error {
+	p := newPrinter(ctx, w)
 
 	// Header
 	tagCount := len(tags)
@@ -84,83 +88,74 @@ func (f *HumanFormatter) FormatTags(tags []domain.Tag, options TagFormatOptions)
 	if tagCount != 1 {
 		tagWord = "tags"
 	}
-	b.WriteString(fmt.Sprintf("TAGS (%d %s)\n", tagCount, tagWord))
-	b.WriteString(strings.Repeat("─", 50) + "\n")
+	p.printf("TAGS (%d %s)\n", tagCount, tagWord)
+	p.print(strings.Repeat("─", 50) + "\n")
 
 	if tagCount == 0 {
-		b.WriteString("No tags found\n")
-		return b.String()
+		p.print("No tags found\n")
+		return p.err
 	}
 
 	// Tags
 	for i, tag := range tags {
 		if i > 0 {
-			b.WriteString("\n")
+			p.print("\n")
 		}
 		if options.Flat {
-			b.WriteString(f.formatTagFlat(tag))
+			p.print(f.formatTagFlat(tag))
 		} else {
-			b.WriteString(f.formatTagHierarchical(tag, 0))
+			p.print(f.formatTagHierarchical(tag, 0))
 		}
 	}
 
-	return b.String()
+	return p.err
 }
 
 // FormatTask formats a single task
-func (f *HumanFormatter) FormatTask(task domain.Task) string {
-	return f.formatTaskLine(task, TaskFormatOptions{
+func (f *HumanFormatter) FormatTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return write(ctx, w, f.formatTaskLine(task, TaskFormatOptions{
 		ShowProject: true,
 		ShowTags:    true,
-	})
+	}))
 }
 
 // FormatProject formats a single project
-func (f *HumanFormatter) FormatProject(project domain.Project) string {
-	return f.formatProjectSection(project, ProjectFormatOptions{
+func (f *HumanFormatter) FormatProject(ctx context.Context, w io.Writer, project domain.Project) error {
+	return write(ctx, w, f.formatProjectSection(project, ProjectFormatOptions{
 		ShowNotes: true,
 		ShowTasks: false,
-	})
+	}))
 }
 
 // FormatTag formats a single tag
-func (f *HumanFormatter) FormatTag(tag domain.Tag) string {
-	return f.formatTagFlat(tag)
+func (f *HumanFormatter) FormatTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return write(ctx, w, f.formatTagFlat(tag))
 }
 
 // FormatError formats an error message with suggestion if available
-func (f *HumanFormatter) FormatError(err error) string {
-	var b strings.Builder
+func (f *HumanFormatter) FormatError(ctx context.Context, w io.Writer, err error) error {
+	p := newPrinter(ctx, w)
 
-	b.WriteString(fmt.Sprintf("Error: %s\n", err.Error()))
+	p.printf("Error: %s\n", err.Error())
 
-	// Check if it's a LazyFocusError with a suggestion
-	type lazyFocusError interface {
-		error
-		ExitCode() int
-		Suggestion() string
+	if suggestion := errorSuggestion(err); suggestion != "" {
+		p.printf("Suggestion: %s\n", suggestion)
 	}
 
-	if lfErr, ok := err.(lazyFocusError); ok {
-		if suggestion := lfErr.Suggestion(); suggestion != "" {
-			b.WriteString(fmt.Sprintf("Suggestion: %s\n", suggestion))
-		}
-	}
-
-	return b.String()
+	return p.err
 }
 
 // FormatCreatedTask formats a newly created task
-func (f *HumanFormatter) FormatCreatedTask(task domain.Task) string {
-	var b strings.Builder
+func (f *HumanFormatter) FormatCreatedTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	p := newPrinter(ctx, w)
 
 	// Success header
-	b.WriteString(fmt.Sprintf("✓ Created task: %s\n", task.ID))
-	b.WriteString(fmt.Sprintf("  %s\n", task.Name))
+	p.printf("✓ Created task: %s\n", task.ID)
+	p.printf("  %s\n", task.Name)
 
-	// Due date (if present)
-	if task.DueDate != nil {
-		b.WriteString(fmt.Sprintf("  Due: %s\n", formatDate(*task.DueDate)))
+	// Due date (if present, or inherited)
+	if task.EffectiveDue() != nil {
+		p.printf("  Due: %s\n", formatDue(task))
 	}
 
 	// Tags (if present)
@@ -169,62 +164,214 @@ func (f *HumanFormatter) FormatCreatedTask(task domain.Task) string {
 		for i, tag := range task.Tags {
 			tagStr[i] = "#" + tag
 		}
-		b.WriteString(fmt.Sprintf("  Tags: %s\n", strings.Join(tagStr, ", ")))
+		p.printf("  Tags: %s\n", strings.Join(tagStr, ", "))
 	}
 
 	// Project (if present)
 	if task.ProjectName != "" {
-		b.WriteString(fmt.Sprintf("  Project: %s\n", task.ProjectName))
+		p.printf("  Project: %s\n", task.ProjectName)
 	}
 
-	return b.String()
+	return p.err
 }
 
 // FormatModifiedTask formats a modified task
-func (f *HumanFormatter) FormatModifiedTask(task domain.Task) string {
-	var b strings.Builder
+func (f *HumanFormatter) FormatModifiedTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	p := newPrinter(ctx, w)
 
 	// Success header
-	b.WriteString(fmt.Sprintf("✓ Modified task: %s\n", task.ID))
-	b.WriteString(fmt.Sprintf("  %s\n", task.Name))
+	p.printf("✓ Modified task: %s\n", task.ID)
+	p.printf("  %s\n", task.Name)
 
-	// Due date (if present)
-	if task.DueDate != nil {
-		b.WriteString(fmt.Sprintf("  Due: %s\n", formatDate(*task.DueDate)))
+	// Due date (if present, or inherited)
+	if task.EffectiveDue() != nil {
+		p.printf("  Due: %s\n", formatDue(task))
 	}
 
 	// Flagged status (only show if flagged)
 	if task.Flagged {
-		b.WriteString("  Flagged: Yes\n")
+		p.print("  Flagged: Yes\n")
 	}
 
-	return b.String()
+	// Repeat (if present)
+	if task.Repetition != nil {
+		p.printf("  Repeats: %s\n", task.Repetition)
+	}
+
+	// Estimate (if present)
+	if task.EstimatedMinutes != 0 {
+		p.printf("  Estimate: %s\n", domain.FormatEstimate(task.EstimatedMinutes))
+	}
+
+	return p.err
 }
 
 // FormatCompletedTask formats a completed task operation result
-func (f *HumanFormatter) FormatCompletedTask(result domain.OperationResult) string {
-	var b strings.Builder
+func (f *HumanFormatter) FormatCompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	p := newPrinter(ctx, w)
 
-	b.WriteString(fmt.Sprintf("✓ Completed: %s\n", result.ID))
-	b.WriteString("  Task marked as complete\n")
+	p.printf("✓ Completed: %s\n", result.ID)
+	p.print("  Task marked as complete\n")
 
-	return b.String()
+	return p.err
+}
+
+// FormatUncompletedTask formats a reopened task operation result
+func (f *HumanFormatter) FormatUncompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	p := newPrinter(ctx, w)
+
+	p.printf("✓ Reopened: %s\n", result.ID)
+	p.print("  Task marked as incomplete\n")
+
+	return p.err
+}
+
+// FormatDropped formats a dropped task or project operation result
+func (f *HumanFormatter) FormatDropped(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	p := newPrinter(ctx, w)
+
+	p.printf("✓ Dropped: %s\n", result.ID)
+	if result.Message != "" {
+		p.printf("  %s\n", result.Message)
+	}
+
+	return p.err
 }
 
 // FormatDeletedTask formats a deleted task operation result
-func (f *HumanFormatter) FormatDeletedTask(result domain.OperationResult) string {
-	var b strings.Builder
+func (f *HumanFormatter) FormatDeletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	p := newPrinter(ctx, w)
 
-	b.WriteString(fmt.Sprintf("✓ Deleted: %s\n", result.ID))
-	b.WriteString("  Task moved to trash\n")
+	p.printf("✓ Deleted: %s\n", result.ID)
+	p.print("  Task moved to trash\n")
 
-	return b.String()
+	return p.err
+}
+
+// FormatCreatedTag formats a newly created tag
+func (f *HumanFormatter) FormatCreatedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return write(ctx, w, fmt.Sprintf("✓ Created tag: %s\n  %s\n", tag.ID, tag.Name))
+}
+
+// FormatRenamedTag formats a renamed tag
+func (f *HumanFormatter) FormatRenamedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return write(ctx, w, fmt.Sprintf("✓ Renamed tag: %s\n  %s\n", tag.ID, tag.Name))
+}
+
+// FormatDeletedTag formats a deleted tag operation result
+func (f *HumanFormatter) FormatDeletedTag(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return write(ctx, w, fmt.Sprintf("✓ Deleted tag: %s\n", result.ID))
+}
+
+// FormatForecasts formats projected project completion dates in a human-readable format
+func (f *HumanFormatter) FormatForecasts(ctx context.Context, w io.Writer, forecasts []stats.ProjectForecast) error {
+	p := newPrinter(ctx, w)
+
+	projectWord := "project"
+	if len(forecasts) != 1 {
+		projectWord = "projects"
+	}
+	p.printf("COMPLETION FORECAST (%d %s)\n", len(forecasts), projectWord)
+	p.print(strings.Repeat("─", 50) + "\n")
+
+	if len(forecasts) == 0 {
+		p.print("No projects found\n")
+		return p.err
+	}
+
+	for _, forecast := range forecasts {
+		p.printf("📁 %s\n", forecast.ProjectName)
+		switch {
+		case forecast.Remaining == 0:
+			p.print("  No remaining tasks\n")
+		case !forecast.HasEstimate():
+			p.printf("  %d remaining · no progress in the last %d days\n", forecast.Remaining, stats.ForecastWindowDays)
+		default:
+			p.printf("  %d remaining · %.1f/week · finish by %s\n",
+				forecast.Remaining, forecast.RatePerWeek, forecast.Estimate.Format("Jan 2, 2006"))
+		}
+	}
+
+	return p.err
+}
+
+// FormatSuggestions formats suggested tasks like a task list, with the
+// reasons under each
+func (f *HumanFormatter) FormatSuggestions(ctx context.Context, w io.Writer, suggestions []next.Suggestion, options TaskFormatOptions) error {
+	p := newPrinter(ctx, w)
+
+	word := "suggestion"
+	if len(suggestions) != 1 {
+		word = "suggestions"
+	}
+	p.printf("NEXT (%d %s)\n", len(suggestions), word)
+	p.print(strings.Repeat("─", 50) + "\n")
+
+	if len(suggestions) == 0 {
+		p.print("No available tasks\n")
+		return p.err
+	}
+
+	for i, s := range suggestions {
+		if i > 0 {
+			p.print("\n")
+		}
+		p.print(f.formatTaskLine(s.Task, options))
+		p.printf("  Why: %s (score %.1f)\n", s.Explain(), s.Score)
+	}
+
+	return p.err
+}
+
+// heatmapShades maps heatmap intensity levels to plain-text glyphs
+var heatmapShades = [stats.HeatmapLevels]string{"·", "░", "▒", "▓", "█"}
+
+// FormatHeatmap formats daily completion counts as a weekday-by-week grid
+func (f *HumanFormatter) FormatHeatmap(ctx context.Context, w io.Writer, heatmap stats.Heatmap) error {
+	p := newPrinter(ctx, w)
+
+	weekWord := "week"
+	if len(heatmap.Weeks) != 1 {
+		weekWord = "weeks"
+	}
+	taskWord := "task"
+	if heatmap.Total != 1 {
+		taskWord = "tasks"
+	}
+	p.printf("COMPLETIONS (last %d %s, %d %s)\n", len(heatmap.Weeks), weekWord, heatmap.Total, taskWord)
+	p.print(strings.Repeat("─", 50) + "\n")
+
+	weekdays := [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	for day := 0; day < 7; day++ {
+		p.print(weekdays[day] + " ")
+		for _, week := range heatmap.Weeks {
+			if day < len(week) {
+				p.print(heatmapShades[heatmap.Level(week[day].Count)])
+			} else {
+				p.print(" ")
+			}
+		}
+		p.print("\n")
+	}
+
+	p.print("\nLess " + strings.Join(heatmapShades[:], "") + " More")
+	if heatmap.Max > 0 {
+		p.printf("  (busiest day: %d)", heatmap.Max)
+	}
+	p.print("\n")
+
+	return p.err
 }
 
 // formatTaskLine formats a single task line with icons and details
 func (f *HumanFormatter) formatTaskLine(task domain.Task, options TaskFormatOptions) string {
 	var b strings.Builder
 
+	// Short number (if listed)
+	if n, ok := options.Numbers[task.ID]; ok {
+		b.WriteString(fmt.Sprintf("[%d] ", n))
+	}
+
 	// Status icon
 	if task.Completed {
 		b.WriteString("☑ ")
@@ -240,9 +387,9 @@ func (f *HumanFormatter) formatTaskLine(task domain.Task, options TaskFormatOpti
 		b.WriteString(" 🚩")
 	}
 
-	// Due date
-	if task.DueDate != nil {
-		b.WriteString(fmt.Sprintf("   📅 %s", formatDate(*task.DueDate)))
+	// Due date, its own or inherited
+	if task.EffectiveDue() != nil {
+		b.WriteString(fmt.Sprintf("   📅 %s", formatDue(task)))
 	}
 
 	b.WriteString("\n")
@@ -257,6 +404,16 @@ func (f *HumanFormatter) formatTaskLine(task domain.Task, options TaskFormatOpti
 		b.WriteString(fmt.Sprintf("  Project: %s\n", task.ProjectName))
 	}
 
+	// Repeat (if present)
+	if task.Repetition != nil {
+		b.WriteString(fmt.Sprintf("  Repeats: %s\n", task.Repetition))
+	}
+
+	// Estimate (if present)
+	if task.EstimatedMinutes != 0 {
+		b.WriteString(fmt.Sprintf("  Estimate: %s\n", domain.FormatEstimate(task.EstimatedMinutes)))
+	}
+
 	// Tags (if enabled)
 	if options.ShowTags && len(task.Tags) > 0 {
 		tagStr := make([]string, len(task.Tags))
@@ -326,6 +483,17 @@ func (f *HumanFormatter) formatTagFlat(tag domain.Tag) string {
 	return b.String()
 }
 
+// formatDue formats when task is due, followed by domain.InheritedMarker
+// when the date is inherited from its project or parent task. The task must
+// have an effective due date.
+func formatDue(task domain.Task) string {
+	text := formatDate(*task.EffectiveDue())
+	if task.DueInherited() {
+		text += " " + domain.InheritedMarker
+	}
+	return text
+}
+
 // formatDate formats a time.Time into a human-readable string
 func formatDate(t time.Time) string {
 	now := time.Now()
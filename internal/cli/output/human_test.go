@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
 )
 
 func TestHumanFormatter_FormatTasks(t *testing.T) {
@@ -73,11 +75,20 @@ func TestHumanFormatter_FormatTasks(t *testing.T) {
 			options: TaskFormatOptions{ShowProject: true},
 			want:    []string{"Project task", "Work"},
 		},
+		{
+			name: "numbered tasks",
+			tasks: []domain.Task{
+				{ID: "task1", Name: "First"},
+				{ID: "task2", Name: "Second"},
+			},
+			options: TaskFormatOptions{Numbers: map[string]int{"task1": 1, "task2": 2}},
+			want:    []string{"[1] ☐ First", "[2] ☐ Second"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatTasks(tt.tasks, tt.options)
+			result := rendered(t, formatter).FormatTasks(tt.tasks, tt.options)
 
 			for _, want := range tt.want {
 				if !strings.Contains(result, want) {
@@ -122,7 +133,7 @@ func TestHumanFormatter_FormatTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatTask(tt.task)
+			result := rendered(t, formatter).FormatTask(tt.task)
 
 			for _, want := range tt.want {
 				if !strings.Contains(result, want) {
@@ -184,7 +195,7 @@ func TestHumanFormatter_FormatProjects(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatProjects(tt.projects, tt.options)
+			result := rendered(t, formatter).FormatProjects(tt.projects, tt.options)
 
 			for _, want := range tt.want {
 				if !strings.Contains(result, want) {
@@ -217,7 +228,7 @@ func TestHumanFormatter_FormatProject(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatProject(tt.project)
+			result := rendered(t, formatter).FormatProject(tt.project)
 
 			for _, want := range tt.want {
 				if !strings.Contains(result, want) {
@@ -283,7 +294,7 @@ func TestHumanFormatter_FormatTags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatTags(tt.tags, tt.options)
+			result := rendered(t, formatter).FormatTags(tt.tags, tt.options)
 
 			for _, want := range tt.want {
 				if !strings.Contains(result, want) {
@@ -311,7 +322,7 @@ func TestHumanFormatter_FormatTag(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatTag(tt.tag)
+			result := rendered(t, formatter).FormatTag(tt.tag)
 
 			for _, want := range tt.want {
 				if !strings.Contains(result, want) {
@@ -376,7 +387,7 @@ func TestHumanFormatter_FormatError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatError(tt.err)
+			result := rendered(t, formatter).FormatError(tt.err)
 
 			for _, want := range tt.want {
 				if !strings.Contains(result, want) {
@@ -501,7 +512,7 @@ func TestHumanFormatter_FormatCreatedTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatCreatedTask(tt.task)
+			result := rendered(t, formatter).FormatCreatedTask(tt.task)
 
 			for _, want := range tt.want {
 				if !strings.Contains(result, want) {
@@ -558,7 +569,7 @@ func TestHumanFormatter_FormatModifiedTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatModifiedTask(tt.task)
+			result := rendered(t, formatter).FormatModifiedTask(tt.task)
 
 			for _, want := range tt.want {
 				if !strings.Contains(result, want) {
@@ -586,7 +597,7 @@ func TestHumanFormatter_FormatCompletedTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output := formatter.FormatCompletedTask(tt.result)
+			output := rendered(t, formatter).FormatCompletedTask(tt.result)
 
 			for _, want := range tt.want {
 				if !strings.Contains(output, want) {
@@ -614,7 +625,7 @@ func TestHumanFormatter_FormatDeletedTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output := formatter.FormatDeletedTask(tt.result)
+			output := rendered(t, formatter).FormatDeletedTask(tt.result)
 
 			for _, want := range tt.want {
 				if !strings.Contains(output, want) {
@@ -625,6 +636,127 @@ func TestHumanFormatter_FormatDeletedTask(t *testing.T) {
 	}
 }
 
+func TestHumanFormatter_FormatUncompletedTask(t *testing.T) {
+	output := rendered(t, NewHumanFormatter()).FormatUncompletedTask(domain.NewSuccessResult("abc123", "Task reopened"))
+	for _, want := range []string{"✓", "Reopened:", "abc123", "Task marked as incomplete"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatUncompletedTask() output missing %q\nGot: %s", want, output)
+		}
+	}
+}
+
+func TestHumanFormatter_FormatDropped(t *testing.T) {
+	got := rendered(t, NewHumanFormatter()).FormatDropped(domain.NewSuccessResult("proj1", "Project dropped"))
+	if want := "✓ Dropped: proj1\n  Project dropped\n"; got != want {
+		t.Errorf("FormatDropped() = %q, want %q", got, want)
+	}
+}
+
+func TestHumanFormatter_FormatTagChanges(t *testing.T) {
+	formatter := NewHumanFormatter()
+	tag := domain.Tag{ID: "tag1", Name: "Errands"}
+
+	if got, want := rendered(t, formatter).FormatCreatedTag(tag), "✓ Created tag: tag1\n  Errands\n"; got != want {
+		t.Errorf("FormatCreatedTag() = %q, want %q", got, want)
+	}
+	if got, want := rendered(t, formatter).FormatRenamedTag(tag), "✓ Renamed tag: tag1\n  Errands\n"; got != want {
+		t.Errorf("FormatRenamedTag() = %q, want %q", got, want)
+	}
+	if got, want := rendered(t, formatter).FormatDeletedTag(domain.NewSuccessResult("tag1", "Tag deleted")), "✓ Deleted tag: tag1\n"; got != want {
+		t.Errorf("FormatDeletedTag() = %q, want %q", got, want)
+	}
+}
+
+func TestHumanFormatter_FormatForecasts(t *testing.T) {
+	formatter := NewHumanFormatter()
+	estimate := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		forecasts []stats.ProjectForecast
+		want      []string
+	}{
+		{
+			name:      "empty",
+			forecasts: []stats.ProjectForecast{},
+			want:      []string{"COMPLETION FORECAST (0 projects)", "No projects found"},
+		},
+		{
+			name: "with estimate",
+			forecasts: []stats.ProjectForecast{
+				{ProjectName: "Website", Remaining: 6, RatePerWeek: 1.5, Estimate: &estimate},
+			},
+			want: []string{"COMPLETION FORECAST (1 project)", "Website", "6 remaining", "1.5/week", "Mar 4, 2024"},
+		},
+		{
+			name: "without recent progress",
+			forecasts: []stats.ProjectForecast{
+				{ProjectName: "Garage", Remaining: 3},
+			},
+			want: []string{"Garage", "3 remaining", "no progress in the last 28 days"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := rendered(t, formatter).FormatForecasts(tt.forecasts)
+
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("FormatForecasts() output missing %q\nGot: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestHumanFormatter_FormatSuggestions(t *testing.T) {
+	formatter := NewHumanFormatter()
+	suggestions := []next.Suggestion{
+		{
+			Task:  domain.Task{ID: "t1", Name: "Pay rent", Flagged: true},
+			Score: 4.5,
+			Reasons: []next.Reason{
+				{Factor: next.FactorDue, Score: 2.5, Text: "due tomorrow"},
+				{Factor: next.FactorFlagged, Score: 2, Text: "flagged"},
+			},
+		},
+		{Task: domain.Task{ID: "t2", Name: "Call mom"}},
+	}
+
+	output := rendered(t, formatter).FormatSuggestions(suggestions, TaskFormatOptions{Numbers: map[string]int{"t1": 1, "t2": 2}})
+
+	for _, want := range []string{"NEXT (2 suggestions)", "[1] ☐ Pay rent", "Why: due tomorrow · flagged (score 4.5)", "Why: nothing stands out (score 0.0)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatSuggestions() output missing %q\nGot: %s", want, output)
+		}
+	}
+
+	if got := rendered(t, formatter).FormatSuggestions(nil, TaskFormatOptions{}); !strings.Contains(got, "No available tasks") {
+		t.Errorf("FormatSuggestions(nil) = %q, want it to contain %q", got, "No available tasks")
+	}
+}
+
+func TestHumanFormatter_FormatHeatmap(t *testing.T) {
+	formatter := NewHumanFormatter()
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	heatmap := stats.Heatmap{
+		Weeks: [][]stats.HeatmapDay{
+			{{Date: monday, Count: 4}, {Date: monday.AddDate(0, 0, 1), Count: 0}},
+		},
+		Max:   4,
+		Total: 4,
+	}
+
+	output := rendered(t, formatter).FormatHeatmap(heatmap)
+
+	for _, want := range []string{"COMPLETIONS (last 1 week, 4 tasks)", "Mon █", "Tue ·", "Less ·░▒▓█ More", "busiest day: 4"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatHeatmap() output missing %q\nGot: %s", want, output)
+		}
+	}
+}
+
 // testError is a simple error implementation for testing
 type testError struct {
 	msg string
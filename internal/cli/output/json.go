@@ -1,13 +1,20 @@
 package output
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
 )
 
 // JSONFormatter implements Formatter interface for JSON output
-type JSONFormatter struct{}
+type JSONFormatter struct {
+	compact bool // One line per result instead of indented
+}
 
 // NewJSONFormatter creates a new JSON formatter
 func NewJSONFormatter() *JSONFormatter {
@@ -15,124 +22,208 @@ func NewJSONFormatter() *JSONFormatter {
 }
 
 // FormatTasks formats tasks as JSON
-func (f *JSONFormatter) FormatTasks(tasks []domain.Task, options TaskFormatOptions) string {
+func (f *JSONFormatter) FormatTasks(ctx context.Context, w io.Writer, tasks []domain.Task, options TaskFormatOptions) error {
 	output := map[string]interface{}{
 		"tasks": tasks,
 		"count": len(tasks),
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatProjects formats projects as JSON
-func (f *JSONFormatter) FormatProjects(projects []domain.Project, options ProjectFormatOptions) string {
+func (f *JSONFormatter) FormatProjects(ctx context.Context, w io.Writer, projects []domain.Project, options ProjectFormatOptions) error {
 	output := map[string]interface{}{
 		"projects": projects,
 		"count":    len(projects),
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatTags formats tags as JSON
-func (f *JSONFormatter) FormatTags(tags []domain.Tag, options TagFormatOptions) string {
+func (f *JSONFormatter) FormatTags(ctx context.Context, w io.Writer, tags []domain.Tag, options TagFormatOptions) error {
 	output := map[string]interface{}{
 		"tags":  tags,
 		"count": len(tags),
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatTask formats a single task as JSON
-func (f *JSONFormatter) FormatTask(task domain.Task) string {
+func (f *JSONFormatter) FormatTask(ctx context.Context, w io.Writer, task domain.Task) error {
 	output := map[string]interface{}{
 		"task": task,
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatProject formats a single project as JSON
-func (f *JSONFormatter) FormatProject(project domain.Project) string {
+func (f *JSONFormatter) FormatProject(ctx context.Context, w io.Writer, project domain.Project) error {
 	output := map[string]interface{}{
 		"project": project,
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatTag formats a single tag as JSON
-func (f *JSONFormatter) FormatTag(tag domain.Tag) string {
+func (f *JSONFormatter) FormatTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
 	output := map[string]interface{}{
 		"tag": tag,
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatError formats an error as JSON
-func (f *JSONFormatter) FormatError(err error) string {
+func (f *JSONFormatter) FormatError(ctx context.Context, w io.Writer, err error) error {
 	output := map[string]interface{}{
 		"error": err.Error(),
+		"code":  ExitCodeFor(err),
+		"kind":  ErrorKind(err),
 	}
-
-	// Check if it's a LazyFocusError using a local interface so we rely on
-	// structural typing and avoid importing the internal errors package.
-	type lazyFocusError interface {
-		error
-		ExitCode() int
-		Suggestion() string
-	}
-
-	if lfErr, ok := err.(lazyFocusError); ok {
-		output["code"] = lfErr.ExitCode()
-		if suggestion := lfErr.Suggestion(); suggestion != "" {
-			output["suggestion"] = suggestion
-		}
+	if suggestion := errorSuggestion(err); suggestion != "" {
+		output["suggestion"] = suggestion
 	}
 
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatCreatedTask formats a newly created task as JSON
-func (f *JSONFormatter) FormatCreatedTask(task domain.Task) string {
+func (f *JSONFormatter) FormatCreatedTask(ctx context.Context, w io.Writer, task domain.Task) error {
 	output := map[string]interface{}{
 		"success": true,
 		"task":    task,
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatModifiedTask formats a modified task as JSON
-func (f *JSONFormatter) FormatModifiedTask(task domain.Task) string {
+func (f *JSONFormatter) FormatModifiedTask(ctx context.Context, w io.Writer, task domain.Task) error {
 	output := map[string]interface{}{
 		"success": true,
 		"task":    task,
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatCompletedTask formats a completed task operation result as JSON
-func (f *JSONFormatter) FormatCompletedTask(result domain.OperationResult) string {
+func (f *JSONFormatter) FormatCompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	output := map[string]interface{}{
+		"success": result.Success,
+		"id":      result.ID,
+		"message": result.Message,
+	}
+	return f.encode(ctx, w, output)
+}
+
+// FormatUncompletedTask formats a reopened task operation result as JSON
+func (f *JSONFormatter) FormatUncompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	output := map[string]interface{}{
+		"success": result.Success,
+		"id":      result.ID,
+		"message": result.Message,
+	}
+	return f.encode(ctx, w, output)
+}
+
+// FormatDropped formats a dropped task or project operation result as JSON
+func (f *JSONFormatter) FormatDropped(ctx context.Context, w io.Writer, result domain.OperationResult) error {
 	output := map[string]interface{}{
 		"success": result.Success,
 		"id":      result.ID,
 		"message": result.Message,
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
 }
 
 // FormatDeletedTask formats a deleted task operation result as JSON
-func (f *JSONFormatter) FormatDeletedTask(result domain.OperationResult) string {
+func (f *JSONFormatter) FormatDeletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	output := map[string]interface{}{
+		"success": result.Success,
+		"id":      result.ID,
+		"message": result.Message,
+	}
+	return f.encode(ctx, w, output)
+}
+
+// FormatCreatedTag formats a newly created tag as JSON
+func (f *JSONFormatter) FormatCreatedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	output := map[string]interface{}{
+		"success": true,
+		"tag":     tag,
+	}
+	return f.encode(ctx, w, output)
+}
+
+// FormatRenamedTag formats a renamed tag as JSON
+func (f *JSONFormatter) FormatRenamedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	output := map[string]interface{}{
+		"success": true,
+		"tag":     tag,
+	}
+	return f.encode(ctx, w, output)
+}
+
+// FormatDeletedTag formats a deleted tag operation result as JSON
+func (f *JSONFormatter) FormatDeletedTag(ctx context.Context, w io.Writer, result domain.OperationResult) error {
 	output := map[string]interface{}{
 		"success": result.Success,
 		"id":      result.ID,
 		"message": result.Message,
 	}
-	return f.marshal(output)
+	return f.encode(ctx, w, output)
+}
+
+// FormatForecasts formats projected project completion dates as JSON
+func (f *JSONFormatter) FormatForecasts(ctx context.Context, w io.Writer, forecasts []stats.ProjectForecast) error {
+	output := map[string]interface{}{
+		"forecasts":  forecasts,
+		"count":      len(forecasts),
+		"windowDays": stats.ForecastWindowDays,
+	}
+	return f.encode(ctx, w, output)
 }
 
-// marshal converts data to indented JSON string
-func (f *JSONFormatter) marshal(data interface{}) string {
-	bytes, err := json.MarshalIndent(data, "", "  ")
+// FormatSuggestions formats suggested tasks as JSON, best first
+func (f *JSONFormatter) FormatSuggestions(ctx context.Context, w io.Writer, suggestions []next.Suggestion, options TaskFormatOptions) error {
+	if suggestions == nil {
+		suggestions = []next.Suggestion{}
+	}
+	output := map[string]interface{}{
+		"suggestions": suggestions,
+		"count":       len(suggestions),
+	}
+	return f.encode(ctx, w, output)
+}
+
+// FormatHeatmap formats daily completion counts as JSON
+func (f *JSONFormatter) FormatHeatmap(ctx context.Context, w io.Writer, heatmap stats.Heatmap) error {
+	output := map[string]interface{}{
+		"days":  heatmap.Days(),
+		"weeks": len(heatmap.Weeks),
+		"total": heatmap.Total,
+		"max":   heatmap.Max,
+	}
+	return f.encode(ctx, w, output)
+}
+
+// encode writes data to w as indented JSON, or as one line ending in a
+// newline when compact
+func (f *JSONFormatter) encode(ctx context.Context, w io.Writer, data interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var bytes []byte
+	var err error
+	if f.compact {
+		bytes, err = json.Marshal(data)
+		bytes = append(bytes, '\n')
+	} else {
+		bytes, err = json.MarshalIndent(data, "", "  ")
+	}
 	if err != nil {
-		// Fallback to error JSON if marshaling fails
-		return `{"error": "failed to marshal JSON"}`
+		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	return string(bytes)
+
+	_, err = w.Write(bytes)
+	return err
 }
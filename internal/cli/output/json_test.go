@@ -3,10 +3,13 @@ package output
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
 )
 
 func TestJSONFormatter_FormatTasks(t *testing.T) {
@@ -59,7 +62,7 @@ func TestJSONFormatter_FormatTasks(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatTasks(tt.tasks, tt.options)
+			result := rendered(t, formatter).FormatTasks(tt.tasks, tt.options)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -112,7 +115,7 @@ func TestJSONFormatter_FormatTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatTask(tt.task)
+			result := rendered(t, formatter).FormatTask(tt.task)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -176,7 +179,7 @@ func TestJSONFormatter_FormatProjects(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatProjects(tt.projects, tt.options)
+			result := rendered(t, formatter).FormatProjects(tt.projects, tt.options)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -223,7 +226,7 @@ func TestJSONFormatter_FormatProject(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatProject(tt.project)
+			result := rendered(t, formatter).FormatProject(tt.project)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -286,7 +289,7 @@ func TestJSONFormatter_FormatTags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatTags(tt.tags, tt.options)
+			result := rendered(t, formatter).FormatTags(tt.tags, tt.options)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -328,7 +331,7 @@ func TestJSONFormatter_FormatTag(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatTag(tt.tag)
+			result := rendered(t, formatter).FormatTag(tt.tag)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -385,16 +388,26 @@ func TestJSONFormatter_FormatError(t *testing.T) {
 		expectedSuggestion string
 	}{
 		{
-			name:             "simple error without code or suggestion",
+			name:             "simple error has the general code and no suggestion",
 			err:              errors.New("something went wrong"),
-			expectCode:       false,
+			expectCode:       true,
 			expectSuggestion: false,
+			expectedCode:     1,
 		},
 		{
 			name:             "formatted error",
 			err:              errors.New("OmniFocus is not running"),
-			expectCode:       false,
+			expectCode:       true,
 			expectSuggestion: false,
+			expectedCode:     1,
+		},
+		{
+			name:               "OmniFocus not running has its code and a suggestion",
+			err:                fmt.Errorf("failed to execute task script: %w", bridge.ErrOmniFocusNotRunning),
+			expectCode:         true,
+			expectSuggestion:   true,
+			expectedCode:       2,
+			expectedSuggestion: "Start OmniFocus and try again",
 		},
 		{
 			name: "LazyFocusError with code and suggestion",
@@ -423,7 +436,7 @@ func TestJSONFormatter_FormatError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatError(tt.err)
+			result := rendered(t, formatter).FormatError(tt.err)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -457,6 +470,9 @@ func TestJSONFormatter_FormatError(t *testing.T) {
 					t.Error("FormatError() should not include 'code' field for non-LazyFocusError")
 				}
 			}
+			if _, ok := parsed["kind"].(string); !ok {
+				t.Error("FormatError() missing 'kind' field")
+			}
 
 			// Check for suggestion field
 			if tt.expectSuggestion {
@@ -505,7 +521,7 @@ func TestJSONFormatter_FormatCreatedTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatCreatedTask(tt.task)
+			result := rendered(t, formatter).FormatCreatedTask(tt.task)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -564,7 +580,7 @@ func TestJSONFormatter_FormatModifiedTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatter.FormatModifiedTask(tt.task)
+			result := rendered(t, formatter).FormatModifiedTask(tt.task)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -608,7 +624,7 @@ func TestJSONFormatter_FormatCompletedTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output := formatter.FormatCompletedTask(tt.result)
+			output := rendered(t, formatter).FormatCompletedTask(tt.result)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -653,7 +669,7 @@ func TestJSONFormatter_FormatDeletedTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output := formatter.FormatDeletedTask(tt.result)
+			output := rendered(t, formatter).FormatDeletedTask(tt.result)
 
 			// Verify it's valid JSON
 			var parsed map[string]interface{}
@@ -682,3 +698,84 @@ func TestJSONFormatter_FormatDeletedTask(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONFormatter_FormatCreatedTag(t *testing.T) {
+	formatter := NewJSONFormatter()
+
+	output := rendered(t, formatter).FormatCreatedTag(domain.Tag{ID: "tag1", Name: "Errands", ParentID: "tag0"})
+
+	var parsed struct {
+		Success bool       `json:"success"`
+		Tag     domain.Tag `json:"tag"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("FormatCreatedTag() returned invalid JSON: %v", err)
+	}
+	if !parsed.Success || parsed.Tag.ID != "tag1" || parsed.Tag.ParentID != "tag0" {
+		t.Errorf("FormatCreatedTag() = %+v, want success with tag1 under tag0", parsed)
+	}
+}
+
+func TestJSONFormatter_FormatForecasts(t *testing.T) {
+	formatter := NewJSONFormatter()
+	estimate := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	forecasts := []stats.ProjectForecast{
+		{ProjectID: "p1", ProjectName: "Website", Remaining: 6, RatePerWeek: 1.5, Estimate: &estimate},
+		{ProjectID: "p2", ProjectName: "Garage", Remaining: 3},
+	}
+
+	output := rendered(t, formatter).FormatForecasts(forecasts)
+
+	var parsed struct {
+		Forecasts  []map[string]interface{} `json:"forecasts"`
+		Count      int                      `json:"count"`
+		WindowDays int                      `json:"windowDays"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("FormatForecasts() returned invalid JSON: %v", err)
+	}
+
+	if parsed.Count != 2 {
+		t.Errorf("FormatForecasts() count = %d, want 2", parsed.Count)
+	}
+	if parsed.WindowDays != stats.ForecastWindowDays {
+		t.Errorf("FormatForecasts() windowDays = %d, want %d", parsed.WindowDays, stats.ForecastWindowDays)
+	}
+	if _, ok := parsed.Forecasts[0]["estimate"]; !ok {
+		t.Error("FormatForecasts() first forecast missing 'estimate' field")
+	}
+	if _, ok := parsed.Forecasts[1]["estimate"]; ok {
+		t.Error("FormatForecasts() second forecast should omit 'estimate' field")
+	}
+}
+
+func TestJSONFormatter_FormatHeatmap(t *testing.T) {
+	formatter := NewJSONFormatter()
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	heatmap := stats.Heatmap{
+		Weeks: [][]stats.HeatmapDay{
+			{{Date: monday, Count: 3}, {Date: monday.AddDate(0, 0, 1), Count: 1}},
+		},
+		Max:   3,
+		Total: 4,
+	}
+
+	output := rendered(t, formatter).FormatHeatmap(heatmap)
+
+	var parsed struct {
+		Days  []stats.HeatmapDay `json:"days"`
+		Weeks int                `json:"weeks"`
+		Total int                `json:"total"`
+		Max   int                `json:"max"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("FormatHeatmap() returned invalid JSON: %v", err)
+	}
+
+	if len(parsed.Days) != 2 || parsed.Days[0].Count != 3 {
+		t.Errorf("FormatHeatmap() days = %v, want 2 days starting with count 3", parsed.Days)
+	}
+	if parsed.Weeks != 1 || parsed.Total != 4 || parsed.Max != 3 {
+		t.Errorf("FormatHeatmap() weeks=%d total=%d max=%d, want 1, 4, 3", parsed.Weeks, parsed.Total, parsed.Max)
+	}
+}
@@ -0,0 +1,159 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+)
+
+// JSONLinesFormatter implements Formatter for JSON Lines output: one compact
+// JSON object per line. Lists are written one item per line, without the
+// count; anything else is the object the JSON formatter writes, on one line.
+type JSONLinesFormatter struct {
+	json *JSONFormatter
+}
+
+// NewJSONLinesFormatter creates a new JSON Lines formatter
+func NewJSONLinesFormatter() *JSONLinesFormatter {
+	return &JSONLinesFormatter{json: &JSONFormatter{compact: true}}
+}
+
+// TaskWriter returns a writer of tasks to w, one line each, until ctx is done
+func (f *JSONLinesFormatter) TaskWriter(ctx context.Context, w io.Writer) TaskWriter {
+	return NewJSONLinesWriter(ctx, w)
+}
+
+// FormatTasks formats tasks one per line
+func (f *JSONLinesFormatter) FormatTasks(ctx context.Context, w io.Writer, tasks []domain.Task, options TaskFormatOptions) error {
+	return formatLines(ctx, w, tasks)
+}
+
+// FormatProjects formats projects one per line
+func (f *JSONLinesFormatter) FormatProjects(ctx context.Context, w io.Writer, projects []domain.Project, options ProjectFormatOptions) error {
+	return formatLines(ctx, w, projects)
+}
+
+// FormatTags formats top-level tags one per line, with their children nested
+func (f *JSONLinesFormatter) FormatTags(ctx context.Context, w io.Writer, tags []domain.Tag, options TagFormatOptions) error {
+	return formatLines(ctx, w, tags)
+}
+
+// FormatTask formats a single task as one line
+func (f *JSONLinesFormatter) FormatTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return f.json.FormatTask(ctx, w, task)
+}
+
+// FormatProject formats a single project as one line
+func (f *JSONLinesFormatter) FormatProject(ctx context.Context, w io.Writer, project domain.Project) error {
+	return f.json.FormatProject(ctx, w, project)
+}
+
+// FormatTag formats a single tag as one line
+func (f *JSONLinesFormatter) FormatTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return f.json.FormatTag(ctx, w, tag)
+}
+
+// FormatError formats an error as one line, with its code and kind
+func (f *JSONLinesFormatter) FormatError(ctx context.Context, w io.Writer, err error) error {
+	return f.json.FormatError(ctx, w, err)
+}
+
+// FormatCreatedTask formats a newly created task as one line
+func (f *JSONLinesFormatter) FormatCreatedTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return f.json.FormatCreatedTask(ctx, w, task)
+}
+
+// FormatModifiedTask formats a modified task as one line
+func (f *JSONLinesFormatter) FormatModifiedTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return f.json.FormatModifiedTask(ctx, w, task)
+}
+
+// FormatCompletedTask formats a completed task operation result as one line
+func (f *JSONLinesFormatter) FormatCompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.json.FormatCompletedTask(ctx, w, result)
+}
+
+// FormatUncompletedTask formats a reopened task operation result as one line
+func (f *JSONLinesFormatter) FormatUncompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.json.FormatUncompletedTask(ctx, w, result)
+}
+
+// FormatDropped formats a dropped task or project operation result as one line
+func (f *JSONLinesFormatter) FormatDropped(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.json.FormatDropped(ctx, w, result)
+}
+
+// FormatDeletedTask formats a deleted task operation result as one line
+func (f *JSONLinesFormatter) FormatDeletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.json.FormatDeletedTask(ctx, w, result)
+}
+
+// FormatCreatedTag formats a newly created tag as one line
+func (f *JSONLinesFormatter) FormatCreatedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return f.json.FormatCreatedTag(ctx, w, tag)
+}
+
+// FormatRenamedTag formats a renamed tag as one line
+func (f *JSONLinesFormatter) FormatRenamedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return f.json.FormatRenamedTag(ctx, w, tag)
+}
+
+// FormatDeletedTag formats a deleted tag operation result as one line
+func (f *JSONLinesFormatter) FormatDeletedTag(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return f.json.FormatDeletedTag(ctx, w, result)
+}
+
+// FormatForecasts formats projected project completion dates one project per line
+func (f *JSONLinesFormatter) FormatForecasts(ctx context.Context, w io.Writer, forecasts []stats.ProjectForecast) error {
+	return formatLines(ctx, w, forecasts)
+}
+
+// FormatHeatmap formats daily completion counts one day per line
+func (f *JSONLinesFormatter) FormatHeatmap(ctx context.Context, w io.Writer, heatmap stats.Heatmap) error {
+	return formatLines(ctx, w, heatmap.Days())
+}
+
+// FormatSuggestions formats suggested tasks one per line, best first
+func (f *JSONLinesFormatter) FormatSuggestions(ctx context.Context, w io.Writer, suggestions []next.Suggestion, options TaskFormatOptions) error {
+	return formatLines(ctx, w, suggestions)
+}
+
+// JSONLinesWriter writes values to an io.Writer as JSON Lines as they are
+// given, so a list need not be complete before it is written
+type JSONLinesWriter struct {
+	ctx     context.Context
+	encoder *json.Encoder
+}
+
+// NewJSONLinesWriter creates a writer of JSON Lines to w until ctx is done
+func NewJSONLinesWriter(ctx context.Context, w io.Writer) *JSONLinesWriter {
+	return &JSONLinesWriter{ctx: ctx, encoder: json.NewEncoder(w)}
+}
+
+// Write writes v as one line, unless the writer's context is done
+func (w *JSONLinesWriter) Write(v any) error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	return w.encoder.Encode(v)
+}
+
+// WriteTask writes a task as one line
+func (w *JSONLinesWriter) WriteTask(task domain.Task) error {
+	return w.Write(task)
+}
+
+// formatLines writes items to w one per line until ctx is done
+func formatLines[T any](ctx context.Context, w io.Writer, items []T) error {
+	writer := NewJSONLinesWriter(ctx, w)
+	for _, item := range items {
+		if err := writer.Write(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
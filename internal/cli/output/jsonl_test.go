@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestJSONLinesFormatter_FormatTasks(t *testing.T) {
+	formatter := NewJSONLinesFormatter()
+
+	got := rendered(t, formatter).FormatTasks([]domain.Task{
+		{ID: "t1", Name: "Buy milk", Tags: []string{"errands"}},
+		{ID: "t2", Name: "Call Bob", Flagged: true},
+	}, TaskFormatOptions{})
+
+	want := `{"id":"t1","name":"Buy milk","tags":["errands"],"flagged":false,"completed":false}` + "\n" +
+		`{"id":"t2","name":"Call Bob","flagged":true,"completed":false}` + "\n"
+	if got != want {
+		t.Errorf("FormatTasks() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := rendered(t, formatter).FormatTasks(nil, TaskFormatOptions{}); got != "" {
+		t.Errorf("FormatTasks(nil) = %q, want no lines", got)
+	}
+}
+
+func TestJSONLinesFormatter_SingleResultsOnOneLine(t *testing.T) {
+	formatter := NewJSONLinesFormatter()
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "task",
+			got:  rendered(t, formatter).FormatTask(domain.Task{ID: "t1", Name: "Buy milk"}),
+			want: `{"task":{"id":"t1","name":"Buy milk","flagged":false,"completed":false}}`,
+		},
+		{
+			name: "completed task",
+			got:  rendered(t, formatter).FormatCompletedTask(domain.OperationResult{Success: true, ID: "t1", Message: "Completed"}),
+			want: `{"id":"t1","message":"Completed","success":true}`,
+		},
+		{
+			name: "error",
+			got:  rendered(t, formatter).FormatError(errors.New("boom")),
+			want: `{"code":1,"error":"boom","kind":"error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want+"\n" {
+				t.Errorf("got %q, want %q", tt.got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestJSONLinesFormatter_TaskWriter(t *testing.T) {
+	var formatter StreamingFormatter = NewJSONLinesFormatter()
+	var buf bytes.Buffer
+	writer := formatter.TaskWriter(context.Background(), &buf)
+
+	for _, task := range []domain.Task{{ID: "t1"}, {ID: "t2"}} {
+		if err := writer.WriteTask(task); err != nil {
+			t.Fatalf("WriteTask() error = %v", err)
+		}
+		// Each task is written as it is given
+		if lines := strings.Count(buf.String(), "\n"); lines == 0 {
+			t.Fatalf("WriteTask(%s) wrote nothing", task.ID)
+		}
+	}
+
+	if got := buf.String(); got != rendered(t, formatter).FormatTasks([]domain.Task{{ID: "t1"}, {ID: "t2"}}, TaskFormatOptions{}) {
+		t.Errorf("TaskWriter wrote %q, want the same lines as FormatTasks", got)
+	}
+}
@@ -0,0 +1,222 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+)
+
+// ShortcutFormatter implements Formatter interface for Shortcuts.app: plain
+// sentences, one item per line, without headers, symbols or colors, so a
+// shortcut can show, speak or split the text
+type ShortcutFormatter struct{}
+
+// NewShortcutFormatter creates a new Shortcuts formatter
+func NewShortcutFormatter() *ShortcutFormatter {
+	return &ShortcutFormatter{}
+}
+
+// FormatTasks formats tasks one per line
+func (f *ShortcutFormatter) FormatTasks(ctx context.Context, w io.Writer, tasks []domain.Task, options TaskFormatOptions) error {
+	p := newPrinter(ctx, w)
+	shown := 0
+	for _, task := range tasks {
+		if task.Completed && !options.ShowCompleted {
+			continue
+		}
+		p.print(shortcutTaskLine(task, options.ShowProject) + "\n")
+		shown++
+	}
+	if shown == 0 {
+		p.print("No tasks\n")
+	}
+	return p.err
+}
+
+// FormatProjects formats project names one per line
+func (f *ShortcutFormatter) FormatProjects(ctx context.Context, w io.Writer, projects []domain.Project, options ProjectFormatOptions) error {
+	if len(projects) == 0 {
+		return write(ctx, w, "No projects\n")
+	}
+	p := newPrinter(ctx, w)
+	for _, project := range projects {
+		p.print(project.Name + "\n")
+	}
+	return p.err
+}
+
+// FormatTags formats tag names one per line, flattening the hierarchy
+func (f *ShortcutFormatter) FormatTags(ctx context.Context, w io.Writer, tags []domain.Tag, options TagFormatOptions) error {
+	flat := flattenTags(tags)
+	if len(flat) == 0 {
+		return write(ctx, w, "No tags\n")
+	}
+	p := newPrinter(ctx, w)
+	for _, tag := range flat {
+		p.print(tag.Name + "\n")
+	}
+	return p.err
+}
+
+// FormatTask formats a single task as one line
+func (f *ShortcutFormatter) FormatTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return write(ctx, w, shortcutTaskLine(task, true)+"\n")
+}
+
+// FormatProject formats a single project name
+func (f *ShortcutFormatter) FormatProject(ctx context.Context, w io.Writer, project domain.Project) error {
+	return write(ctx, w, project.Name+"\n")
+}
+
+// FormatTag formats a single tag name
+func (f *ShortcutFormatter) FormatTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return write(ctx, w, tag.Name+"\n")
+}
+
+// FormatError formats an error as a sentence
+func (f *ShortcutFormatter) FormatError(ctx context.Context, w io.Writer, err error) error {
+	return write(ctx, w, fmt.Sprintf("Error: %v\n", err))
+}
+
+// FormatCreatedTask formats a newly created task
+func (f *ShortcutFormatter) FormatCreatedTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return write(ctx, w, "Added "+shortcutTaskLine(task, true)+"\n")
+}
+
+// FormatModifiedTask formats a modified task
+func (f *ShortcutFormatter) FormatModifiedTask(ctx context.Context, w io.Writer, task domain.Task) error {
+	return write(ctx, w, "Updated "+shortcutTaskLine(task, true)+"\n")
+}
+
+// FormatCompletedTask formats a completed task operation result
+func (f *ShortcutFormatter) FormatCompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return write(ctx, w, "Completed "+result.ID+"\n")
+}
+
+// FormatUncompletedTask formats a reopened task operation result
+func (f *ShortcutFormatter) FormatUncompletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return write(ctx, w, "Reopened "+result.ID+"\n")
+}
+
+// FormatDropped formats a dropped task or project operation result
+func (f *ShortcutFormatter) FormatDropped(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return write(ctx, w, "Dropped "+result.ID+"\n")
+}
+
+// FormatDeletedTask formats a deleted task operation result
+func (f *ShortcutFormatter) FormatDeletedTask(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return write(ctx, w, "Deleted "+result.ID+"\n")
+}
+
+// FormatCreatedTag formats a newly created tag
+func (f *ShortcutFormatter) FormatCreatedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return write(ctx, w, "Added tag "+tag.Name+"\n")
+}
+
+// FormatRenamedTag formats a renamed tag
+func (f *ShortcutFormatter) FormatRenamedTag(ctx context.Context, w io.Writer, tag domain.Tag) error {
+	return write(ctx, w, "Renamed tag to "+tag.Name+"\n")
+}
+
+// FormatDeletedTag formats a deleted tag operation result
+func (f *ShortcutFormatter) FormatDeletedTag(ctx context.Context, w io.Writer, result domain.OperationResult) error {
+	return write(ctx, w, "Deleted tag "+result.ID+"\n")
+}
+
+// FormatForecasts formats projected completion dates one project per line
+func (f *ShortcutFormatter) FormatForecasts(ctx context.Context, w io.Writer, forecasts []stats.ProjectForecast) error {
+	if len(forecasts) == 0 {
+		return write(ctx, w, "No projects\n")
+	}
+	p := newPrinter(ctx, w)
+	for _, forecast := range forecasts {
+		if forecast.HasEstimate() {
+			p.printf("%s, done around %s\n", forecast.ProjectName, forecast.Estimate.Format("January 2"))
+		} else {
+			p.printf("%s, no recent progress\n", forecast.ProjectName)
+		}
+	}
+	return p.err
+}
+
+// FormatHeatmap formats the completion total as a sentence
+func (f *ShortcutFormatter) FormatHeatmap(ctx context.Context, w io.Writer, heatmap stats.Heatmap) error {
+	return write(ctx, w, fmt.Sprintf("Completed %d tasks in the last %d weeks\n", heatmap.Total, len(heatmap.Weeks)))
+}
+
+// FormatSuggestions formats suggested tasks one per line with the reasons,
+// e.g. "Pay rent, because due tomorrow and flagged"
+func (f *ShortcutFormatter) FormatSuggestions(ctx context.Context, w io.Writer, suggestions []next.Suggestion, options TaskFormatOptions) error {
+	if len(suggestions) == 0 {
+		return write(ctx, w, "Nothing to do next\n")
+	}
+	p := newPrinter(ctx, w)
+	for _, s := range suggestions {
+		p.print(s.Task.Name)
+		if len(s.Reasons) > 0 {
+			reasons := make([]string, len(s.Reasons))
+			for i, r := range s.Reasons {
+				reasons[i] = r.Text
+			}
+			p.print(", because " + shortcutList(reasons))
+		}
+		p.print("\n")
+	}
+	return p.err
+}
+
+// shortcutList joins items the way a sentence lists them, e.g. "a, b and c"
+func shortcutList(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// shortcutTaskLine describes a task in one line, e.g.
+// "Pay rent (Home), due today, flagged", or "due today with its project"
+// when the due date is inherited
+func shortcutTaskLine(task domain.Task, showProject bool) string {
+	line := task.Name
+	if showProject && task.ProjectName != "" {
+		line += " (" + task.ProjectName + ")"
+	}
+	if due := task.EffectiveDue(); due != nil {
+		line += ", due " + shortcutDate(*due, time.Now())
+		if task.DueInherited() {
+			line += " with its project"
+		}
+	}
+	if task.Flagged {
+		line += ", flagged"
+	}
+	return line
+}
+
+// shortcutDate names a date relative to now the way Siri would read it
+func shortcutDate(date, now time.Time) string {
+	date = date.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	switch days := int(math.Round(day.Sub(today).Hours() / 24)); {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days == -1:
+		return "yesterday"
+	case days > 1 && days < 7:
+		return date.Format("Monday")
+	case date.Year() == now.Year():
+		return date.Format("January 2")
+	default:
+		return date.Format("January 2, 2006")
+	}
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+)
+
+func TestShortcutFormatter_FormatTasks(t *testing.T) {
+	due := time.Now()
+	tasks := []domain.Task{
+		{ID: "t1", Name: "Pay rent", ProjectName: "Home", DueDate: &due, Flagged: true},
+		{ID: "t2", Name: "Call mom"},
+		{ID: "t3", Name: "Done already", Completed: true},
+	}
+
+	got := rendered(t, NewShortcutFormatter()).FormatTasks(tasks, TaskFormatOptions{ShowProject: true})
+
+	want := "Pay rent (Home), due today, flagged\nCall mom\n"
+	if got != want {
+		t.Errorf("FormatTasks() = %q, want %q", got, want)
+	}
+}
+
+func TestShortcutFormatter_FormatTasks_Empty(t *testing.T) {
+	got := rendered(t, NewShortcutFormatter()).FormatTasks(nil, TaskFormatOptions{})
+
+	if got != "No tasks\n" {
+		t.Errorf("FormatTasks() = %q, want %q", got, "No tasks\n")
+	}
+}
+
+func TestShortcutFormatter_FormatSuggestions(t *testing.T) {
+	suggestions := []next.Suggestion{
+		{Task: domain.Task{Name: "Pay rent"}, Reasons: []next.Reason{{Text: "overdue by 2 days"}, {Text: "flagged"}, {Text: "tagged home"}}},
+		{Task: domain.Task{Name: "Call mom"}},
+	}
+
+	got := rendered(t, NewShortcutFormatter()).FormatSuggestions(suggestions, TaskFormatOptions{})
+
+	want := "Pay rent, because overdue by 2 days, flagged and tagged home\nCall mom\n"
+	if got != want {
+		t.Errorf("FormatSuggestions() = %q, want %q", got, want)
+	}
+	if got := rendered(t, NewShortcutFormatter()).FormatSuggestions(nil, TaskFormatOptions{}); got != "Nothing to do next\n" {
+		t.Errorf("FormatSuggestions(nil) = %q, want %q", got, "Nothing to do next\n")
+	}
+}
+
+func TestShortcutFormatter_FormatCreatedTask(t *testing.T) {
+	got := rendered(t, NewShortcutFormatter()).FormatCreatedTask(domain.Task{ID: "t1", Name: "Buy milk", ProjectName: "Errands"})
+
+	if got != "Added Buy milk (Errands)\n" {
+		t.Errorf("FormatCreatedTask() = %q, want %q", got, "Added Buy milk (Errands)\n")
+	}
+}
+
+func TestShortcutDate(t *testing.T) {
+	now := time.Date(2024, 3, 6, 15, 0, 0, 0, time.Local) // Wednesday
+
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{time.Date(2024, 3, 6, 9, 0, 0, 0, time.Local), "today"},
+		{time.Date(2024, 3, 7, 23, 0, 0, 0, time.Local), "tomorrow"},
+		{time.Date(2024, 3, 5, 17, 0, 0, 0, time.Local), "yesterday"},
+		{time.Date(2024, 3, 9, 17, 0, 0, 0, time.Local), "Saturday"},
+		{time.Date(2024, 4, 20, 17, 0, 0, 0, time.Local), "April 20"},
+		{time.Date(2025, 1, 2, 17, 0, 0, 0, time.Local), "January 2, 2025"},
+	}
+
+	for _, tt := range tests {
+		if got := shortcutDate(tt.date, now); got != tt.want {
+			t.Errorf("shortcutDate(%v) = %q, want %q", tt.date, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// Glyphs shared with the TUI task and project lists
+const (
+	tableCheckboxEmpty   = "☐"
+	tableCheckboxChecked = "☑"
+	tableFlag            = "🚩"
+	tableProjectDone     = "✓"
+	tableProjectOnHold   = "⏸"
+	tableProjectDropped  = "✗"
+)
+
+// tableGap separates table columns
+const tableGap = "  "
+
+// minShrunkWidth is the narrowest a column is truncated to when fitting rows
+// to the terminal
+const minShrunkWidth = 8
+
+// TableFormatter implements Formatter interface for aligned columns, colored
+// like the TUI and truncated to fit the terminal. Single items and operation
+// results are formatted as by HumanFormatter.
+type TableFormatter struct {
+	*HumanFormatter
+	width  int // Terminal width rows are fitted to; 0 leaves them whole
+	styles tableStyles
+}
+
+// tableStyles are the styles of table cells
+type tableStyles struct {
+	plain     lipgloss.Style
+	header    lipgloss.Style
+	dim       lipgloss.Style
+	flagged   lipgloss.Style
+	completed lipgloss.Style
+	overdue   lipgloss.Style
+	today     lipgloss.Style
+	active    lipgloss.Style
+}
+
+// NewTableFormatter creates a table formatter rendering colors for renderer's
+// terminal and fitting rows in width columns, or leaving them whole when
+// width is 0
+func NewTableFormatter(renderer *lipgloss.Renderer, width int) *TableFormatter {
+	colors := tui.DefaultStyles().Colors
+	return &TableFormatter{
+		HumanFormatter: NewHumanFormatter(),
+		width:          width,
+		styles: tableStyles{
+			plain:     renderer.NewStyle(),
+			header:    renderer.NewStyle().Bold(true).Foreground(colors.Primary),
+			dim:       renderer.NewStyle().Foreground(colors.Secondary),
+			flagged:   renderer.NewStyle().Bold(true).Foreground(colors.Flagged),
+			completed: renderer.NewStyle().Foreground(colors.Secondary).Faint(true).Strikethrough(true),
+			overdue:   renderer.NewStyle().Bold(true).Foreground(colors.Error),
+			today:     renderer.NewStyle().Bold(true).Foreground(colors.Warning),
+			active:    renderer.NewStyle().Foreground(colors.Primary),
+		},
+	}
+}
+
+// tableCell is the text of a cell and the style it is shown in
+type tableCell struct {
+	text  string
+	style lipgloss.Style
+}
+
+// tableColumn is a column of a table. Shrinkable columns are truncated, widest
+// first, when the rows do not fit the terminal.
+type tableColumn struct {
+	header     string
+	shrinkable bool
+	cells      []tableCell
+}
+
+// FormatTasks formats tasks as a table with their status, name, project, tags
+// and due date
+func (f *TableFormatter) FormatTasks(ctx context.Context, w io.Writer, tasks []domain.Task, options TaskFormatOptions) error {
+	if len(tasks) == 0 {
+		return write(ctx, w, "No tasks found\n")
+	}
+
+	number := tableColumn{header: "#"}
+	status := tableColumn{}
+	flag := tableColumn{}
+	name := tableColumn{header: "Name", shrinkable: true}
+	project := tableColumn{header: "Project", shrinkable: true}
+	tags := tableColumn{header: "Tags", shrinkable: true}
+	due := tableColumn{header: "Due"}
+
+	for _, task := range tasks {
+		n := ""
+		if i, ok := options.Numbers[task.ID]; ok {
+			n = strconv.Itoa(i)
+		}
+		number.cells = append(number.cells, tableCell{n, f.styles.dim})
+
+		glyph, nameStyle := tableCheckboxEmpty, f.styles.plain
+		switch {
+		case task.Completed:
+			glyph, nameStyle = tableCheckboxChecked, f.styles.completed
+		case task.Flagged:
+			nameStyle = f.styles.flagged
+		}
+		status.cells = append(status.cells, tableCell{glyph, f.styles.plain})
+		name.cells = append(name.cells, tableCell{task.Name, nameStyle})
+
+		flagGlyph := ""
+		if task.Flagged {
+			flagGlyph = tableFlag
+		}
+		flag.cells = append(flag.cells, tableCell{flagGlyph, f.styles.plain})
+
+		project.cells = append(project.cells, tableCell{task.ProjectName, f.styles.dim})
+		tags.cells = append(tags.cells, tableCell{strings.Join(task.Tags, ", "), f.styles.dim})
+		due.cells = append(due.cells, f.dueCell(task))
+	}
+
+	var columns []tableColumn
+	if len(options.Numbers) > 0 {
+		columns = append(columns, number)
+	}
+	columns = append(columns, status, name, flag)
+	if options.ShowProject {
+		columns = append(columns, project)
+	}
+	if options.ShowTags {
+		columns = append(columns, tags)
+	}
+	columns = append(columns, due)
+	return f.render(ctx, w, columns)
+}
+
+// FormatProjects formats projects as a table with their status and task count
+func (f *TableFormatter) FormatProjects(ctx context.Context, w io.Writer, projects []domain.Project, options ProjectFormatOptions) error {
+	if len(projects) == 0 {
+		return write(ctx, w, "No projects found\n")
+	}
+
+	name := tableColumn{header: "Name", shrinkable: true}
+	status := tableColumn{header: "Status"}
+	count := tableColumn{header: "Tasks"}
+	for _, project := range projects {
+		nameStyle, statusText := f.styles.active, project.Status
+		switch project.Status {
+		case "done", "completed":
+			nameStyle, statusText = f.styles.completed, tableProjectDone+" "+project.Status
+		case "dropped":
+			nameStyle, statusText = f.styles.completed, tableProjectDropped+" "+project.Status
+		case "on hold", "on-hold":
+			nameStyle, statusText = f.styles.dim, tableProjectOnHold+" "+project.Status
+		}
+		name.cells = append(name.cells, tableCell{project.Name, nameStyle})
+		status.cells = append(status.cells, tableCell{statusText, f.styles.dim})
+		count.cells = append(count.cells, tableCell{strconv.Itoa(project.TaskCount), f.styles.plain})
+	}
+	return f.render(ctx, w, []tableColumn{name, status, count})
+}
+
+// FormatTags formats tags as a table of names and IDs, indenting child tags
+// under their parents unless the list is flat
+func (f *TableFormatter) FormatTags(ctx context.Context, w io.Writer, tags []domain.Tag, options TagFormatOptions) error {
+	if len(tags) == 0 {
+		return write(ctx, w, "No tags found\n")
+	}
+
+	name := tableColumn{header: "Name", shrinkable: true}
+	id := tableColumn{header: "ID"}
+	var add func(tags []domain.Tag, depth int)
+	add = func(tags []domain.Tag, depth int) {
+		for _, tag := range tags {
+			indent := strings.Repeat("  ", depth)
+			if options.Flat {
+				indent = ""
+			}
+			name.cells = append(name.cells, tableCell{indent + tag.Name, f.styles.plain})
+			id.cells = append(id.cells, tableCell{tag.ID, f.styles.dim})
+			add(tag.Children, depth+1)
+		}
+	}
+	add(tags, 0)
+	return f.render(ctx, w, []tableColumn{name, id})
+}
+
+// FormatForecasts formats projected project completion dates as a table
+func (f *TableFormatter) FormatForecasts(ctx context.Context, w io.Writer, forecasts []stats.ProjectForecast) error {
+	if len(forecasts) == 0 {
+		return write(ctx, w, "No projects found\n")
+	}
+
+	name := tableColumn{header: "Project", shrinkable: true}
+	remaining := tableColumn{header: "Remaining"}
+	rate := tableColumn{header: "Per week"}
+	estimate := tableColumn{header: "Finish by"}
+	for _, forecast := range forecasts {
+		name.cells = append(name.cells, tableCell{forecast.ProjectName, f.styles.active})
+		remaining.cells = append(remaining.cells, tableCell{strconv.Itoa(forecast.Remaining), f.styles.plain})
+		rate.cells = append(rate.cells, tableCell{fmt.Sprintf("%.1f", forecast.RatePerWeek), f.styles.dim})
+		finish := tableCell{"-", f.styles.dim}
+		if forecast.HasEstimate() {
+			finish = tableCell{forecast.Estimate.Format("Jan 2, 2006"), f.styles.plain}
+		}
+		estimate.cells = append(estimate.cells, finish)
+	}
+	return f.render(ctx, w, []tableColumn{name, remaining, rate, estimate})
+}
+
+// FormatSuggestions formats suggested tasks as a table with their score and
+// the reasons for it, best first
+func (f *TableFormatter) FormatSuggestions(ctx context.Context, w io.Writer, suggestions []next.Suggestion, options TaskFormatOptions) error {
+	if len(suggestions) == 0 {
+		return write(ctx, w, "No available tasks\n")
+	}
+
+	number := tableColumn{header: "#"}
+	name := tableColumn{header: "Name", shrinkable: true}
+	due := tableColumn{header: "Due"}
+	score := tableColumn{header: "Score"}
+	why := tableColumn{header: "Why", shrinkable: true}
+	for _, s := range suggestions {
+		n := ""
+		if i, ok := options.Numbers[s.Task.ID]; ok {
+			n = strconv.Itoa(i)
+		}
+		number.cells = append(number.cells, tableCell{n, f.styles.dim})
+		nameStyle := f.styles.plain
+		if s.Task.Flagged {
+			nameStyle = f.styles.flagged
+		}
+		name.cells = append(name.cells, tableCell{s.Task.Name, nameStyle})
+		due.cells = append(due.cells, f.dueCell(s.Task))
+		score.cells = append(score.cells, tableCell{fmt.Sprintf("%.1f", s.Score), f.styles.plain})
+		why.cells = append(why.cells, tableCell{s.Explain(), f.styles.dim})
+	}
+	return f.render(ctx, w, []tableColumn{number, name, due, score, why})
+}
+
+// dueCell shows the due date of task, its own or marked as inherited, red
+// when overdue and yellow when due today, as in the TUI
+func (f *TableFormatter) dueCell(task domain.Task) tableCell {
+	due := task.EffectiveDue()
+	if due == nil {
+		return tableCell{"", f.styles.plain}
+	}
+	text := formatDue(task)
+	if task.Completed {
+		return tableCell{text, f.styles.dim}
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case due.Before(today):
+		return tableCell{text, f.styles.overdue}
+	case due.Before(today.AddDate(0, 0, 1)):
+		return tableCell{text, f.styles.today}
+	}
+	return tableCell{text, f.styles.plain}
+}
+
+// render writes columns to w with a header row, dropping columns with no
+// cells to show and truncating shrinkable columns to fit the terminal width
+func (f *TableFormatter) render(ctx context.Context, w io.Writer, columns []tableColumn) error {
+	var shown []tableColumn
+	for _, col := range columns {
+		for _, cell := range col.cells {
+			if cell.text != "" {
+				shown = append(shown, col)
+				break
+			}
+		}
+	}
+
+	widths := make([]int, len(shown))
+	for i, col := range shown {
+		widths[i] = lipgloss.Width(col.header)
+		for _, cell := range col.cells {
+			widths[i] = max(widths[i], lipgloss.Width(cell.text))
+		}
+	}
+	f.fit(shown, widths)
+
+	p := newPrinter(ctx, w)
+	header := make([]tableCell, len(shown))
+	for i, col := range shown {
+		header[i] = tableCell{col.header, f.styles.header}
+	}
+	writeTableRow(p, header, widths)
+	for row := range shown[0].cells {
+		cells := make([]tableCell, len(shown))
+		for i, col := range shown {
+			cells[i] = col.cells[row]
+		}
+		writeTableRow(p, cells, widths)
+	}
+	return p.err
+}
+
+// fit narrows the widest shrinkable column, one cell at a time, until the
+// rows fit the terminal or every shrinkable column is at its minimum
+func (f *TableFormatter) fit(columns []tableColumn, widths []int) {
+	if f.width <= 0 {
+		return
+	}
+	total := lipgloss.Width(tableGap) * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > f.width {
+		widest := -1
+		for i, col := range columns {
+			if col.shrinkable && widths[i] > minShrunkWidth && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// writeTableRow writes cells padded to widths, truncating text that does not
+// fit with an ellipsis
+func writeTableRow(p *printer, cells []tableCell, widths []int) {
+	var line strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			line.WriteString(tableGap)
+		}
+		text := ansi.Truncate(cell.text, widths[i], "…")
+		if text != "" {
+			line.WriteString(cell.style.Render(text))
+		}
+		if i < len(cells)-1 {
+			line.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(text)))
+		}
+	}
+	p.print(strings.TrimRight(line.String(), " ") + "\n")
+}
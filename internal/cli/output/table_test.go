@@ -0,0 +1,188 @@
+package output
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+)
+
+// plainRenderer renders no colors, as when output is piped
+func plainRenderer() *lipgloss.Renderer {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.Ascii)
+	return r
+}
+
+// colorRenderer renders true colors on a dark background
+func colorRenderer() *lipgloss.Renderer {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	r.SetHasDarkBackground(true)
+	return r
+}
+
+func TestTableFormatter_FormatTasks(t *testing.T) {
+	formatter := NewTableFormatter(plainRenderer(), 0)
+	tasks := []domain.Task{
+		{ID: "t1", Name: "Buy milk", ProjectName: "Errands", Tags: []string{"shop"}},
+		{ID: "t2", Name: "Call the bank", Flagged: true, Completed: true},
+	}
+
+	got := rendered(t, formatter).FormatTasks(tasks, TaskFormatOptions{ShowProject: true, ShowTags: true, Numbers: map[string]int{"t1": 1, "t2": 2}})
+
+	want := "" +
+		"#     Name               Project  Tags\n" +
+		"1  ☐  Buy milk           Errands  shop\n" +
+		"2  ☑  Call the bank  🚩\n"
+	if got != want {
+		t.Errorf("FormatTasks() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTableFormatter_FormatTasksEmpty(t *testing.T) {
+	got := rendered(t, NewTableFormatter(plainRenderer(), 0)).FormatTasks(nil, TaskFormatOptions{})
+
+	if got != "No tasks found\n" {
+		t.Errorf("FormatTasks() = %q, want No tasks found", got)
+	}
+}
+
+func TestTableFormatter_TruncatesToWidth(t *testing.T) {
+	formatter := NewTableFormatter(plainRenderer(), 30)
+	tasks := []domain.Task{
+		{ID: "t1", Name: "Write the quarterly report for the board", ProjectName: "Work"},
+	}
+
+	got := rendered(t, formatter).FormatTasks(tasks, TaskFormatOptions{ShowProject: true})
+
+	for _, line := range strings.Split(strings.TrimRight(got, "\n"), "\n") {
+		if w := lipgloss.Width(line); w > 30 {
+			t.Errorf("line %q is %d wide, want at most 30", line, w)
+		}
+	}
+	if !strings.Contains(got, "Write the quarter…") || !strings.Contains(got, "Work") {
+		t.Errorf("FormatTasks() = %q, want the name truncated and the project kept", got)
+	}
+}
+
+func TestTableFormatter_ColorsDueDates(t *testing.T) {
+	formatter := NewTableFormatter(colorRenderer(), 0)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 23, 0, 0, 0, now.Location())
+	lastWeek := today.AddDate(0, 0, -7)
+	nextMonth := today.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name  string
+		due   time.Time
+		color string // Foreground escape of the date, empty for none
+	}{
+		{"overdue", lastWeek, "38;2;255;107;107"},
+		{"today", today, "38;2;255;214;102"},
+		{"later", nextMonth, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rendered(t, formatter).FormatTasks([]domain.Task{{ID: "t1", Name: "Pay rent", DueDate: &tt.due}}, TaskFormatOptions{})
+			rows := strings.Split(got, "\n")
+			if len(rows) < 2 {
+				t.Fatalf("FormatTasks() = %q, want a task row", got)
+			}
+			row := rows[1]
+			if !strings.Contains(ansi.Strip(row), formatDate(tt.due)) {
+				t.Errorf("row %q does not show the due date", row)
+			}
+			if tt.color != "" && !strings.Contains(row, tt.color) {
+				t.Errorf("row %q, want due date colored %s", row, tt.color)
+			}
+			if tt.color == "" && strings.Contains(row, "38;2;255") {
+				t.Errorf("row %q, want due date uncolored", row)
+			}
+		})
+	}
+}
+
+func TestTableFormatter_MarksInheritedDueDate(t *testing.T) {
+	formatter := NewTableFormatter(colorRenderer(), 0)
+	lastWeek := time.Now().AddDate(0, 0, -7)
+
+	got := rendered(t, formatter).FormatTasks([]domain.Task{{ID: "t1", Name: "Pay rent", EffectiveDueDate: &lastWeek}}, TaskFormatOptions{})
+
+	if !strings.Contains(ansi.Strip(got), formatDate(lastWeek)+" "+domain.InheritedMarker) {
+		t.Errorf("FormatTasks() = %q, want the inherited due date marked", got)
+	}
+	if !strings.Contains(got, "38;2;255;107;107") {
+		t.Errorf("FormatTasks() = %q, want the inherited due date colored overdue", got)
+	}
+}
+
+func TestTableFormatter_FormatProjects(t *testing.T) {
+	formatter := NewTableFormatter(plainRenderer(), 0)
+	projects := []domain.Project{
+		{ID: "p1", Name: "Home", Status: "active", TaskCount: 3},
+		{ID: "p2", Name: "Garden", Status: "on-hold", TaskCount: 12},
+	}
+
+	got := rendered(t, formatter).FormatProjects(projects, ProjectFormatOptions{})
+
+	want := "" +
+		"Name    Status     Tasks\n" +
+		"Home    active     3\n" +
+		"Garden  ⏸ on-hold  12\n"
+	if got != want {
+		t.Errorf("FormatProjects() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTableFormatter_FormatSuggestions(t *testing.T) {
+	formatter := NewTableFormatter(plainRenderer(), 0)
+	suggestions := []next.Suggestion{
+		{Task: domain.Task{ID: "t1", Name: "Pay rent"}, Score: 2, Reasons: []next.Reason{{Text: "flagged"}}},
+		{Task: domain.Task{ID: "t2", Name: "Call mom"}},
+	}
+
+	got := rendered(t, formatter).FormatSuggestions(suggestions, TaskFormatOptions{Numbers: map[string]int{"t1": 1, "t2": 2}})
+
+	want := "" +
+		"#  Name      Score  Why\n" +
+		"1  Pay rent  2.0    flagged\n" +
+		"2  Call mom  0.0    nothing stands out\n"
+	if got != want {
+		t.Errorf("FormatSuggestions() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTableFormatter_FormatTags(t *testing.T) {
+	formatter := NewTableFormatter(plainRenderer(), 0)
+	tags := []domain.Tag{
+		{ID: "g1", Name: "Places", Children: []domain.Tag{{ID: "g2", Name: "Home", ParentID: "g1"}}},
+	}
+
+	got := rendered(t, formatter).FormatTags(tags, TagFormatOptions{})
+
+	want := "" +
+		"Name    ID\n" +
+		"Places  g1\n" +
+		"  Home  g2\n"
+	if got != want {
+		t.Errorf("FormatTags() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTableFormatter_SingleTaskIsHuman(t *testing.T) {
+	task := domain.Task{ID: "t1", Name: "Buy milk"}
+
+	got := rendered(t, NewTableFormatter(plainRenderer(), 0)).FormatTask(task)
+
+	if want := rendered(t, NewHumanFormatter()).FormatTask(task); got != want {
+		t.Errorf("FormatTask() = %q, want the human format %q", got, want)
+	}
+}
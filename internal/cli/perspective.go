@@ -1,7 +1,14 @@
 package cli
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
 	"github.com/spf13/cobra"
 )
 
@@ -13,14 +20,44 @@ func NewPerspectiveCommand() *cobra.Command {
 		Long: `Show tasks from a named OmniFocus perspective.
 
 Note: Custom perspectives require OmniFocus Pro.`,
+		Example: `  lazyfocus perspective Forecast
+  lazyfocus perspective "Next Actions" --json`,
 		Args: cobra.ExactArgs(1),
 		RunE: runPerspective,
 	}
 
+	cmd.AddCommand(newPerspectiveImportCommand())
+
+	return cmd
+}
+
+// newPerspectiveImportCommand creates the perspective import subcommand
+func newPerspectiveImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <name>",
+		Short: "Save a custom perspective as a TUI filter",
+		Long: `Read the rules of a custom perspective and save the closest equivalent as a
+named filter, applied in the TUI with :filter <name>.
+
+The conversion is best effort. Rules for availability, flagged and due status,
+a single tag and a single project are converted when combined with "all";
+other rules are skipped and listed. Saved filters are stored in
+~/.lazyfocus-filters.json.`,
+		Example: `  lazyfocus perspective import Errands
+  lazyfocus perspective import "Next Actions" --as next
+  lazyfocus perspective import Errands --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPerspectiveImport,
+	}
+
+	cmd.Flags().String("as", "", "Name of the saved filter (default: the perspective name)")
+	cmd.Flags().Bool("dry-run", false, "Show the filter without saving it")
+
 	return cmd
 }
 
 func runPerspective(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	perspectiveName := args[0]
 
 	svc, err := getServiceFromCmd(cmd)
@@ -28,7 +65,7 @@ func runPerspective(cmd *cobra.Command, args []string) error {
 		return handleError(cmd, err)
 	}
 
-	tasks, getErr := svc.GetPerspectiveTasks(perspectiveName)
+	tasks, getErr := svc.GetPerspectiveTasks(ctx, perspectiveName)
 	if getErr != nil {
 		return handleError(cmd, getErr)
 	}
@@ -43,6 +80,90 @@ func runPerspective(cmd *cobra.Command, args []string) error {
 		ShowTags:    true,
 	}
 
-	cmd.Print(formatter.FormatTasks(tasks, options))
+	return formatter.FormatTasks(ctx, cmd.OutOrStdout(), tasks, options)
+}
+
+// perspectiveImport is the JSON shape of `perspective import`
+type perspectiveImport struct {
+	Name        string       `json:"name"`
+	Perspective string       `json:"perspective"`
+	Filter      filter.State `json:"filter"`
+	Skipped     []string     `json:"skipped"`
+	Saved       bool         `json:"saved"`
+}
+
+func runPerspectiveImport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	name, _ := cmd.Flags().GetString("as")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if strings.TrimSpace(name) == "" {
+		name = args[0]
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	rules, err := svc.GetPerspectiveRules(ctx, args[0])
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to read perspective: %w", err))
+	}
+
+	tagName := func(id string) (string, error) {
+		tag, err := svc.GetTagByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		if tag == nil {
+			return "", bridge.NewNotFoundError("tag", id)
+		}
+		return tag.Name, nil
+	}
+	state, skipped, err := filter.FromPerspective(*rules, tagName)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	if !dryRun {
+		path := config.SavedFiltersPath()
+		saved, err := filter.LoadSaved(path)
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		saved[name] = state
+		if err := saved.Save(path); err != nil {
+			return handleError(cmd, err)
+		}
+	}
+
+	if GetQuietFlag() {
+		return nil
+	}
+
+	if GetJSONFlag() {
+		result := perspectiveImport{Name: name, Perspective: rules.Name, Filter: state, Skipped: skipped, Saved: !dryRun}
+		if result.Skipped == nil {
+			result.Skipped = []string{}
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to encode filter: %w", err))
+		}
+		cmd.Println(string(data))
+		return nil
+	}
+
+	if dryRun {
+		cmd.Printf("Filter %q from perspective %s:\n", name, rules.Name)
+	} else {
+		cmd.Printf("✓ Saved filter %q from perspective %s\n", name, rules.Name)
+	}
+	for _, line := range state.Describe() {
+		cmd.Printf("  %s\n", line)
+	}
+	for _, reason := range skipped {
+		cmd.Printf("  skipped: %s\n", reason)
+	}
 	return nil
 }
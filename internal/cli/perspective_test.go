@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
 )
 
 func TestPerspectiveCommand_ShowTasks(t *testing.T) {
@@ -260,6 +263,77 @@ func TestPerspectiveCommand_TooManyArguments(t *testing.T) {
 	}
 }
 
+func newPerspectiveImportMockService() *service.MockOmniFocusService {
+	return &service.MockOmniFocusService{
+		PerspectiveRules: &domain.PerspectiveRules{
+			Name:        "Errands",
+			Aggregation: "all",
+			Rules: []domain.PerspectiveRule{
+				{"actionAvailability": "available"},
+				{"actionHasAnyOfTags": []interface{}{"tag1"}},
+				{"actionIsLeaf": true},
+			},
+		},
+		Tag: &domain.Tag{ID: "tag1", Name: "Errands"},
+	}
+}
+
+func TestPerspectiveImportCommand_SavesFilter(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	output, _, err := executePerspectiveCommand(newPerspectiveImportMockService(), []string{"import", "Errands", "--as", "errands"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{`Saved filter "errands"`, "tag: Errands", "available only", "skipped: rule actionIsLeaf"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+
+	saved, err := filter.LoadSaved(filepath.Join(home, ".lazyfocus-filters.json"))
+	if err != nil {
+		t.Fatalf("LoadSaved() error = %v", err)
+	}
+	want := filter.State{TagID: "Errands", AvailableOnly: true}
+	if saved["errands"] != want {
+		t.Errorf("saved filter = %+v, want %+v", saved["errands"], want)
+	}
+}
+
+func TestPerspectiveImportCommand_DryRunJSON(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	output, _, err := executePerspectiveCommand(newPerspectiveImportMockService(), []string{"--json", "import", "Errands", "--dry-run"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, `"tag": "Errands"`) || !strings.Contains(output, `"saved": false`) {
+		t.Errorf("Expected JSON filter preview, got: %s", output)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".lazyfocus-filters.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected no saved filters file on dry run, got: %v", err)
+	}
+}
+
+func TestPerspectiveImportCommand_Unconvertible(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mockService := &service.MockOmniFocusService{
+		PerspectiveRules: &domain.PerspectiveRules{Name: "Any", Aggregation: "any", Rules: []domain.PerspectiveRule{
+			{"actionStatus": "flagged"}, {"actionAvailability": "available"},
+		}},
+	}
+
+	_, _, err := executePerspectiveCommand(mockService, []string{"import", "Any"})
+	if err == nil || !strings.Contains(err.Error(), "matches any of its rules") {
+		t.Errorf("Expected aggregation error, got: %v", err)
+	}
+}
+
 // Helper function to execute perspective command and capture output
 func executePerspectiveCommand(mockService service.OmniFocusService, args []string) (string, int, error) {
 	// Create a new root command for each test to avoid flag pollution
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+	"github.com/spf13/cobra"
+)
+
+// pickerRows is how many matching tasks the picker lists at once
+const pickerRows = 10
+
+// runPicker shows the picker and returns the chosen task, or false when the
+// user cancelled; tests replace it
+var runPicker = func(cmd *cobra.Command, title string, tasks []domain.Task) (domain.Task, bool, error) {
+	p := tea.NewProgram(newTaskPicker(title, tasks), tea.WithInput(cmd.InOrStdin()), tea.WithOutput(cmd.ErrOrStderr()))
+	final, err := p.Run()
+	if err != nil {
+		return domain.Task{}, false, fmt.Errorf("failed to run task picker: %w", err)
+	}
+	picker := final.(taskPicker)
+	if picker.chosen == nil {
+		return domain.Task{}, false, nil
+	}
+	return *picker.chosen, true, nil
+}
+
+// addInteractiveFlag adds --interactive to a command taking task IDs
+func addInteractiveFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP("interactive", "i", false, "Pick the task from a fuzzy-searchable list when no ID is given")
+}
+
+// taskIDArgs returns an argument check requiring n task IDs (at least n with
+// variadic), or none when --interactive picks the task
+func taskIDArgs(n int, variadic bool) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if interactive, _ := cmd.Flags().GetBool("interactive"); interactive && len(args) == 0 {
+			return nil
+		}
+		if variadic {
+			return cobra.MinimumNArgs(n)(cmd, args)
+		}
+		return cobra.ExactArgs(n)(cmd, args)
+	}
+}
+
+// pickTask lists the incomplete tasks in the picker, titled with what the
+// pick is for, and returns the one chosen or false when the user cancelled
+func pickTask(cmd *cobra.Command, svc service.OmniFocusService, title string) (domain.Task, bool, error) {
+	// A truncated list still has tasks worth picking from
+	tasks, err := svc.GetAllTasks(cmd.Context(), service.TaskFilters{})
+	var truncated *service.TruncatedError
+	if err != nil && !errors.As(err, &truncated) {
+		return domain.Task{}, false, err
+	}
+
+	var candidates []domain.Task
+	for _, task := range domain.FlattenTasks(tasks) {
+		if !task.Completed {
+			candidates = append(candidates, task)
+		}
+	}
+	if len(candidates) == 0 {
+		return domain.Task{}, false, fmt.Errorf("no incomplete tasks to pick from")
+	}
+
+	return runPicker(cmd, title, candidates)
+}
+
+// rankTasks returns the tasks whose names fuzzily match query, best first,
+// keeping the listed order on a tie
+func rankTasks(tasks []domain.Task, query string) []domain.Task {
+	type ranked struct {
+		task  domain.Task
+		score int
+	}
+	var matches []ranked
+	for _, task := range tasks {
+		if score, ok := command.FuzzyScore(query, task.Name); ok {
+			matches = append(matches, ranked{task, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]domain.Task, len(matches))
+	for i, match := range matches {
+		result[i] = match.task
+	}
+	return result
+}
+
+// taskPicker is a minimal Bubble Tea model listing tasks under a search input
+type taskPicker struct {
+	title   string
+	tasks   []domain.Task
+	matches []domain.Task
+	input   textinput.Model
+	cursor  int
+	chosen  *domain.Task
+}
+
+// newTaskPicker creates a picker over tasks
+func newTaskPicker(title string, tasks []domain.Task) taskPicker {
+	input := textinput.New()
+	input.Placeholder = "type to search"
+	input.Prompt = "> "
+	input.Focus()
+	return taskPicker{title: title, tasks: tasks, matches: tasks, input: input}
+}
+
+// Init starts the cursor blinking
+func (m taskPicker) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update moves through the matches, picks one with enter and cancels with
+// esc; other keys edit the search
+func (m taskPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			return m, tea.Quit
+		case "enter":
+			if len(m.matches) > 0 {
+				chosen := m.matches[m.cursor]
+				m.chosen = &chosen
+			}
+			return m, tea.Quit
+		case "up", "ctrl+p", "ctrl+k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n", "ctrl+j":
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	query := m.input.Value()
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != query {
+		m.matches = rankTasks(m.tasks, m.input.Value())
+		m.cursor = 0
+	}
+	return m, cmd
+}
+
+// View renders the title, search input and the matches around the cursor
+func (m taskPicker) View() string {
+	if m.chosen != nil {
+		return ""
+	}
+
+	dim := lipgloss.NewStyle().Faint(true)
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(m.title) + "\n")
+	b.WriteString(m.input.View() + "\n")
+
+	if len(m.matches) == 0 {
+		b.WriteString(dim.Render("  No matching tasks") + "\n")
+	}
+	start := 0
+	if m.cursor >= pickerRows {
+		start = m.cursor - pickerRows + 1
+	}
+	end := min(start+pickerRows, len(m.matches))
+	for i := start; i < end; i++ {
+		task := m.matches[i]
+		line := task.Name
+		if task.ProjectName != "" {
+			line += dim.Render(" (" + task.ProjectName + ")")
+		}
+		if i == m.cursor {
+			b.WriteString(lipgloss.NewStyle().Bold(true).Render("▸ "+task.Name) + strings.TrimPrefix(line, task.Name) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	b.WriteString(dim.Render(fmt.Sprintf("%d of %d · ↑/↓ move · enter pick · esc cancel", len(m.matches), len(m.tasks))) + "\n")
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// stubPicker makes the picker choose the task named choice, or cancel when
+// choice is empty, and records the tasks it was offered
+func stubPicker(t *testing.T, choice string) *[]domain.Task {
+	t.Helper()
+	var offered []domain.Task
+	original := runPicker
+	runPicker = func(cmd *cobra.Command, title string, tasks []domain.Task) (domain.Task, bool, error) {
+		offered = tasks
+		for _, task := range tasks {
+			if task.Name == choice {
+				return task, true, nil
+			}
+		}
+		return domain.Task{}, false, nil
+	}
+	t.Cleanup(func() { runPicker = original })
+	return &offered
+}
+
+func pickerTasks() []domain.Task {
+	return []domain.Task{
+		{ID: "t1", Name: "Pay rent", ProjectName: "Home"},
+		{ID: "t2", Name: "Call dentist"},
+		{ID: "t3", Name: "Renew passport", Completed: true},
+		{ID: "t4", Name: "Plan trip", Children: []domain.Task{{ID: "t5", Name: "Book hotel"}}},
+	}
+}
+
+func TestPickTask_OffersIncompleteTasks(t *testing.T) {
+	offered := stubPicker(t, "Book hotel")
+	svc := &service.MockOmniFocusService{AllTasks: pickerTasks()}
+
+	task, ok, err := pickTask(&cobra.Command{}, svc, "Pick")
+	if err != nil || !ok {
+		t.Fatalf("pickTask() = %v, %v, want a task", ok, err)
+	}
+	if task.ID != "t5" {
+		t.Errorf("pickTask() = %s, want t5", task.ID)
+	}
+	var ids []string
+	for _, task := range *offered {
+		ids = append(ids, task.ID)
+	}
+	if len(ids) != 4 || ids[2] != "t4" || ids[3] != "t5" {
+		t.Errorf("offered %v, want [t1 t2 t4 t5]", ids)
+	}
+}
+
+func TestPickTask_NoTasks(t *testing.T) {
+	stubPicker(t, "")
+	svc := &service.MockOmniFocusService{}
+
+	if _, _, err := pickTask(&cobra.Command{}, svc, "Pick"); err == nil {
+		t.Error("pickTask() error = nil, want no incomplete tasks")
+	}
+}
+
+func TestRankTasks(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "a", Name: "Prepare yearly taxes"},
+		{ID: "b", Name: "Pay rent"},
+		{ID: "c", Name: "Call dentist"},
+	}
+
+	got := rankTasks(tasks, "pay")
+
+	if len(got) != 2 || got[0].ID != "b" || got[1].ID != "a" {
+		t.Errorf("rankTasks(pay) = %v, want [b a]", got)
+	}
+	if all := rankTasks(tasks, ""); len(all) != 3 || all[0].ID != "a" {
+		t.Errorf("rankTasks(\"\") = %v, want all tasks in order", all)
+	}
+}
+
+func TestTaskPicker_SearchAndPick(t *testing.T) {
+	m := newTaskPicker("Pick", pickerTasks()[:2])
+
+	for _, r := range "dent" {
+		m = updatePicker(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(m.matches) != 1 || m.matches[0].ID != "t2" {
+		t.Fatalf("matches = %v, want [t2]", m.matches)
+	}
+
+	m = updatePicker(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.chosen == nil || m.chosen.ID != "t2" {
+		t.Errorf("chosen = %v, want t2", m.chosen)
+	}
+}
+
+func TestTaskPicker_MoveAndCancel(t *testing.T) {
+	m := newTaskPicker("Pick", pickerTasks()[:2])
+
+	m = updatePicker(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = updatePicker(m, tea.KeyMsg{Type: tea.KeyDown})
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, want 1 (stops at the last match)", m.cursor)
+	}
+
+	m = updatePicker(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.chosen != nil {
+		t.Errorf("chosen = %v after esc, want nil", m.chosen)
+	}
+}
+
+func updatePicker(m taskPicker, msg tea.Msg) taskPicker {
+	updated, _ := m.Update(msg)
+	return updated.(taskPicker)
+}
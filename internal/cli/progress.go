@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"github.com/pwojciechowski/lazyfocus/internal/cli/progress"
+	"github.com/spf13/cobra"
+)
+
+// progressThreshold is the minimum number of items before progress is reported
+const progressThreshold = 2
+
+// newProgressReporter returns a progress reporter for the command, honouring
+// --quiet and --json. Progress is written to stderr so stdout stays parseable.
+func newProgressReporter(cmd *cobra.Command, items int) progress.Reporter {
+	mode := progress.ModeAuto
+	switch {
+	case GetQuietFlag() || items < progressThreshold:
+		mode = progress.ModeQuiet
+	case GetJSONFlag():
+		mode = progress.ModeJSON
+	}
+	return progress.New(cmd.ErrOrStderr(), mode)
+}
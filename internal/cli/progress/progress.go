@@ -0,0 +1,219 @@
+// Package progress provides progress reporting for long-running CLI operations.
+// Output adapts to the destination: a redrawn bar on a terminal, periodic log
+// lines when piped, or JSON events when machine-readable output is requested.
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mode selects how progress is rendered
+type Mode int
+
+// Reporter modes
+const (
+	ModeAuto  Mode = iota // Bar on a TTY, periodic lines otherwise
+	ModeJSON              // One JSON event per line
+	ModeQuiet             // No output
+)
+
+// DefaultLogInterval is the minimum time between periodic log lines
+const DefaultLogInterval = 2 * time.Second
+
+// barWidth is the number of cells in the TTY progress bar
+const barWidth = 30
+
+// Reporter reports progress of an operation over a known number of items
+type Reporter interface {
+	Start(label string, total int)
+	Increment()
+	// Clear removes a bar drawn in place so other output can be written
+	// below it; the next Increment or Finish draws it again
+	Clear()
+	Finish()
+}
+
+// New creates a Reporter writing to w in the given mode
+func New(w io.Writer, mode Mode) Reporter {
+	switch mode {
+	case ModeQuiet:
+		return noopReporter{}
+	case ModeJSON:
+		return &jsonReporter{w: w}
+	default:
+		if isTerminal(w) {
+			return &barReporter{w: w}
+		}
+		return &lineReporter{w: w, interval: DefaultLogInterval, now: time.Now}
+	}
+}
+
+// isTerminal reports whether w is a character device (an interactive terminal)
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// counter holds the state shared by all reporters
+type counter struct {
+	mu      sync.Mutex
+	label   string
+	total   int
+	current int
+}
+
+func (c *counter) start(label string, total int) {
+	c.label = label
+	c.total = total
+	c.current = 0
+}
+
+// noopReporter discards all progress
+type noopReporter struct{}
+
+func (noopReporter) Start(string, int) {}
+func (noopReporter) Increment()        {}
+func (noopReporter) Clear()            {}
+func (noopReporter) Finish()           {}
+
+// barReporter redraws a single-line bar using carriage returns
+type barReporter struct {
+	counter
+	w       io.Writer
+	cleared bool
+}
+
+func (r *barReporter) Start(label string, total int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.start(label, total)
+	r.draw()
+}
+
+func (r *barReporter) Increment() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current++
+	r.draw()
+}
+
+// Clear erases the bar's line, leaving the cursor at its start
+func (r *barReporter) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.cleared {
+		r.cleared = true
+		fmt.Fprint(r.w, "\r\033[K")
+	}
+}
+
+func (r *barReporter) Finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cleared {
+		r.draw()
+	}
+	fmt.Fprintln(r.w)
+}
+
+func (r *barReporter) draw() {
+	filled := 0
+	if r.total > 0 {
+		filled = min(barWidth, r.current*barWidth/r.total)
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	r.cleared = false
+	fmt.Fprintf(r.w, "\r%s [%s] %d/%d", r.label, bar, r.current, r.total)
+}
+
+// lineReporter writes a log line at most once per interval, plus a final summary
+type lineReporter struct {
+	counter
+	w        io.Writer
+	interval time.Duration
+	now      func() time.Time
+	lastLog  time.Time
+}
+
+func (r *lineReporter) Start(label string, total int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.start(label, total)
+	r.lastLog = r.now()
+	fmt.Fprintf(r.w, "%s: starting (%d items)\n", r.label, r.total)
+}
+
+func (r *lineReporter) Increment() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current++
+	if now := r.now(); now.Sub(r.lastLog) >= r.interval {
+		r.lastLog = now
+		fmt.Fprintf(r.w, "%s: %d/%d\n", r.label, r.current, r.total)
+	}
+}
+
+// Clear does nothing: log lines never need erasing
+func (r *lineReporter) Clear() {}
+
+func (r *lineReporter) Finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Fprintf(r.w, "%s: done (%d/%d)\n", r.label, r.current, r.total)
+}
+
+// Event is a single JSON progress event
+type Event struct {
+	Type    string `json:"type"` // "start", "progress" or "done"
+	Label   string `json:"label"`
+	Current int    `json:"current"`
+	Total   int    `json:"total"`
+}
+
+// jsonReporter emits one JSON event per line
+type jsonReporter struct {
+	counter
+	w io.Writer
+}
+
+func (r *jsonReporter) Start(label string, total int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.start(label, total)
+	r.emit("start")
+}
+
+func (r *jsonReporter) Increment() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current++
+	r.emit("progress")
+}
+
+// Clear does nothing: events never need erasing
+func (r *jsonReporter) Clear() {}
+
+func (r *jsonReporter) Finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.emit("done")
+}
+
+func (r *jsonReporter) emit(eventType string) {
+	// json.Marshal cannot fail for Event, which has only string and int fields
+	data, _ := json.Marshal(Event{Type: eventType, Label: r.label, Current: r.current, Total: r.total})
+	fmt.Fprintln(r.w, string(data))
+}
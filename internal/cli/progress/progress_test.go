@@ -0,0 +1,124 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew_SelectsReporterByMode(t *testing.T) {
+	var buf bytes.Buffer
+
+	if _, ok := New(&buf, ModeQuiet).(noopReporter); !ok {
+		t.Error("New(ModeQuiet) should return noopReporter")
+	}
+	if _, ok := New(&buf, ModeJSON).(*jsonReporter); !ok {
+		t.Error("New(ModeJSON) should return *jsonReporter")
+	}
+	// A bytes.Buffer is never a terminal
+	if _, ok := New(&buf, ModeAuto).(*lineReporter); !ok {
+		t.Error("New(ModeAuto) on non-TTY should return *lineReporter")
+	}
+}
+
+func TestQuietReporter_WritesNothing(t *testing.T) {
+	var buf bytes.Buffer
+	r := New(&buf, ModeQuiet)
+
+	r.Start("Working", 3)
+	r.Increment()
+	r.Finish()
+
+	if buf.Len() != 0 {
+		t.Errorf("quiet reporter wrote %q, want nothing", buf.String())
+	}
+}
+
+func TestJSONReporter_EmitsEvents(t *testing.T) {
+	var buf bytes.Buffer
+	r := New(&buf, ModeJSON)
+
+	r.Start("Completing tasks", 2)
+	r.Increment()
+	r.Increment()
+	r.Finish()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d events, want 4", len(lines))
+	}
+
+	var last Event
+	if err := json.Unmarshal([]byte(lines[3]), &last); err != nil {
+		t.Fatalf("invalid JSON event %q: %v", lines[3], err)
+	}
+	want := Event{Type: "done", Label: "Completing tasks", Current: 2, Total: 2}
+	if last != want {
+		t.Errorf("last event = %+v, want %+v", last, want)
+	}
+}
+
+func TestLineReporter_LogsPeriodically(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	r := &lineReporter{w: &buf, interval: time.Second, now: func() time.Time { return now }}
+
+	r.Start("Deleting tasks", 3)
+	r.Increment() // within interval: no line
+	now = now.Add(2 * time.Second)
+	r.Increment() // interval elapsed: logs
+	r.Increment()
+	r.Finish()
+
+	want := "Deleting tasks: starting (3 items)\n" +
+		"Deleting tasks: 2/3\n" +
+		"Deleting tasks: done (3/3)\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestBarReporter_RedrawsInPlace(t *testing.T) {
+	var buf bytes.Buffer
+	r := &barReporter{w: &buf}
+
+	r.Start("Working", 2)
+	r.Increment()
+	r.Increment()
+	r.Finish()
+
+	out := buf.String()
+	if strings.Count(out, "\r") != 3 {
+		t.Errorf("expected 3 redraws, got output %q", out)
+	}
+	if !strings.Contains(out, "2/2") || !strings.HasSuffix(out, "\n") {
+		t.Errorf("expected final 2/2 and trailing newline, got %q", out)
+	}
+}
+
+func TestBarReporter_ClearErasesBarUntilNextDraw(t *testing.T) {
+	var buf bytes.Buffer
+	r := &barReporter{w: &buf}
+	r.Start("Working", 2)
+	r.Increment()
+	r.Clear()
+	r.Clear() // already cleared: writes nothing
+	buf.WriteString("item 1\n")
+	r.Increment()
+	r.Clear()
+	buf.WriteString("item 2\n")
+	r.Finish()
+
+	out := buf.String()
+	if strings.Count(out, "\r\033[K") != 2 {
+		t.Errorf("expected 2 clears, got output %q", out)
+	}
+	if !strings.Contains(out, "\r\033[Kitem 1\n\rWorking") {
+		t.Errorf("expected item output on a cleared line and the bar redrawn below it, got %q", out)
+	}
+	if !strings.HasSuffix(out, "item 2\n\rWorking ["+strings.Repeat("█", barWidth)+"] 2/2\n") {
+		t.Errorf("expected Finish to redraw the cleared bar, got %q", out)
+	}
+}
@@ -1,7 +1,13 @@
 package cli
 
 import (
+	"context"
+	"slices"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -12,20 +18,29 @@ func NewProjectsCommand() *cobra.Command {
 		Short: "List projects from OmniFocus",
 		Long: `List projects from OmniFocus with filtering options.
 
-By default, shows active projects. Use --status flag to filter by status.`,
+By default, shows active projects. Use --status flag to filter by status
+and --folder to list only the projects in a folder and its subfolders.`,
+		Example: `  lazyfocus projects
+  lazyfocus projects --status on-hold
+  lazyfocus projects --folder Work
+  lazyfocus projects --with-tasks --json`,
 		RunE: runProjects,
 	}
 
 	cmd.Flags().String("status", "active", "Filter by status (active, on-hold, completed, dropped, all)")
 	cmd.Flags().Bool("with-tasks", false, "Include nested tasks")
+	cmd.Flags().String("folder", "", "Only list projects in this folder or its subfolders")
 
 	return cmd
 }
 
 func runProjects(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Get flag values
 	statusFlag, _ := cmd.Flags().GetString("status")
 	withTasksFlag, _ := cmd.Flags().GetBool("with-tasks")
+	folderFlag, _ := cmd.Flags().GetString("folder")
 
 	// Get service
 	svc, err := getServiceFromCmd(cmd)
@@ -34,11 +49,17 @@ func runProjects(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get projects from service
-	projects, getErr := svc.GetProjects(statusFlag)
+	projects, getErr := svc.GetProjects(ctx, statusFlag)
 	if getErr != nil {
 		return handleError(cmd, getErr)
 	}
 
+	if folderFlag != "" {
+		if projects, err = projectsInFolder(ctx, svc, projects, folderFlag); err != nil {
+			return handleError(cmd, err)
+		}
+	}
+
 	// Format and output results
 	if GetQuietFlag() {
 		// Quiet mode: no output, just exit code
@@ -51,8 +72,22 @@ func runProjects(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter()
-	outputStr := formatter.FormatProjects(projects, formatOptions)
-	cmd.Print(outputStr)
+	return formatter.FormatProjects(ctx, cmd.OutOrStdout(), projects, formatOptions)
+}
 
-	return nil
+// projectsInFolder returns the projects in the folder named name or in its
+// subfolders, keeping their order
+func projectsInFolder(ctx context.Context, svc service.OmniFocusService, projects []domain.Project, name string) ([]domain.Project, error) {
+	folders, err := svc.GetFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	folder := domain.FindFolder(folders, name)
+	if folder == nil {
+		return nil, bridge.NewNotFoundError("folder", name)
+	}
+	ids := folder.AllProjectIDs()
+	return slices.DeleteFunc(slices.Clone(projects), func(p domain.Project) bool {
+		return !slices.Contains(ids, p.ID)
+	}), nil
 }
@@ -357,6 +357,66 @@ func TestProjectsCommand_WithTasksAndStatus(t *testing.T) {
 }
 
 // Helper function to execute projects command and capture output
+func TestProjectsCommand_CSVOutput(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		Projects: []domain.Project{
+			{ID: "proj1", Name: "Work Project", Status: "active", TaskCount: 3},
+		},
+	}
+
+	output, _, err := executeProjectsCommand(mockService, []string{"--output", "csv"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := "id,name,status,taskCount\nproj1,Work Project,active,3\n"
+	if output != want {
+		t.Errorf("Expected output %q, got: %q", want, output)
+	}
+}
+
+func TestProjectsCommand_Folder(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		Projects: []domain.Project{
+			{ID: "proj1", Name: "Website", Status: "active"},
+			{ID: "proj2", Name: "Garden", Status: "active"},
+			{ID: "proj3", Name: "Acme rollout", Status: "active"},
+		},
+		Folders: []domain.Folder{
+			{ID: "f1", Name: "Work", ProjectIDs: []string{"proj1"}, Children: []domain.Folder{
+				{ID: "f2", Name: "Clients", ParentID: "f1", ProjectIDs: []string{"proj3"}},
+			}},
+			{ID: "f3", Name: "Home", ProjectIDs: []string{"proj2"}},
+		},
+	}
+
+	output, _, err := executeProjectsCommand(mockService, []string{"--folder", "work"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, "Website") || !strings.Contains(output, "Acme rollout") {
+		t.Errorf("Expected projects in Work and its subfolders, got: %s", output)
+	}
+	if strings.Contains(output, "Garden") {
+		t.Errorf("Expected no projects outside Work, got: %s", output)
+	}
+}
+
+func TestProjectsCommand_FolderNotFound(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		Projects: []domain.Project{{ID: "proj1", Name: "Website", Status: "active"}},
+		Folders:  []domain.Folder{{ID: "f1", Name: "Work", ProjectIDs: []string{"proj1"}}},
+	}
+
+	_, _, err := executeProjectsCommand(mockService, []string{"--folder", "Garden"})
+
+	if err == nil || !strings.Contains(err.Error(), "folder not found: Garden") {
+		t.Errorf("Expected a folder not found error, got: %v", err)
+	}
+}
+
 func executeProjectsCommand(mockService service.OmniFocusService, args []string) (string, int, error) {
 	// Create a new root command for each test to avoid flag pollution
 	rootCmd := newTestRootCommand()
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+// NewReportCommand creates the report command
+func NewReportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Show projected completion dates for projects",
+		Long: `Show projected completion dates for projects.
+
+Each project's completion rate over the last 28 days is used to estimate
+when its remaining tasks will be finished. Projects without recent progress
+are listed without an estimate.`,
+		Example: `  lazyfocus report
+  lazyfocus report --status all --json`,
+		RunE: runReport,
+	}
+
+	cmd.Flags().String("status", "active", "Filter by status (active, on-hold, completed, dropped, all)")
+
+	cmd.AddCommand(newReportHeatmapCommand())
+
+	return cmd
+}
+
+// newReportHeatmapCommand creates the report heatmap subcommand
+func newReportHeatmapCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "heatmap",
+		Short: "Show a heatmap of tasks completed per day",
+		Long: `Show a contribution-style heatmap of tasks completed per day.
+
+Rows are weekdays and columns are weeks, ending with the current week.
+Darker cells mean more tasks were completed that day.`,
+		Example: `  lazyfocus report heatmap
+  lazyfocus report heatmap --weeks 12`,
+		RunE: runReportHeatmap,
+	}
+
+	cmd.Flags().Int("weeks", stats.HeatmapWeeks, "Number of weeks to show")
+
+	return cmd
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	statusFlag, _ := cmd.Flags().GetString("status")
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	projects, getErr := svc.GetProjects(ctx, statusFlag)
+	if getErr != nil {
+		return handleError(cmd, getErr)
+	}
+
+	if GetQuietFlag() {
+		return nil
+	}
+
+	forecasts := stats.ForecastProjects(projects, time.Now())
+
+	formatter := getFormatter()
+	return formatter.FormatForecasts(ctx, cmd.OutOrStdout(), forecasts)
+}
+
+func runReportHeatmap(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	weeks, _ := cmd.Flags().GetInt("weeks")
+	if weeks < 1 {
+		return handleError(cmd, invalidInput("invalid --weeks value %d: must be at least 1", weeks))
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	now := time.Now()
+	tasks, getErr := svc.GetCompletedTasks(ctx, stats.HeatmapStart(now, weeks))
+	if getErr != nil {
+		return handleError(cmd, getErr)
+	}
+
+	if GetQuietFlag() {
+		return nil
+	}
+
+	heatmap := stats.BuildHeatmap(tasks, now, weeks)
+
+	formatter := getFormatter()
+	return formatter.FormatHeatmap(ctx, cmd.OutOrStdout(), heatmap)
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestReportCommand_HumanOutput(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		Projects: []domain.Project{
+			{ID: "proj1", Name: "Website", Status: "active", TaskCount: 4, CompletedRecently: 8},
+			{ID: "proj2", Name: "Garage", Status: "active", TaskCount: 3},
+		},
+	}
+
+	output, err := executeReportCommand(mockService, []string{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{"COMPLETION FORECAST (2 projects)", "Website", "finish by", "Garage", "no progress"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+}
+
+func TestReportCommand_JSONOutput(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		Projects: []domain.Project{
+			{ID: "proj1", Name: "Website", Status: "active", TaskCount: 4, CompletedRecently: 8},
+		},
+	}
+
+	output, err := executeReportCommand(mockService, []string{"--json"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var parsed struct {
+		Forecasts []struct {
+			ProjectID string  `json:"projectId"`
+			Remaining int     `json:"remaining"`
+			Rate      float64 `json:"ratePerWeek"`
+			Estimate  *string `json:"estimate"`
+		} `json:"forecasts"`
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Expected valid JSON, got error: %v\nOutput: %s", err, output)
+	}
+
+	if parsed.Count != 1 || len(parsed.Forecasts) != 1 {
+		t.Fatalf("Expected 1 forecast, got count=%d len=%d", parsed.Count, len(parsed.Forecasts))
+	}
+	if parsed.Forecasts[0].ProjectID != "proj1" {
+		t.Errorf("Expected projectId 'proj1', got: %s", parsed.Forecasts[0].ProjectID)
+	}
+	if parsed.Forecasts[0].Rate != 2 {
+		t.Errorf("Expected ratePerWeek 2, got: %v", parsed.Forecasts[0].Rate)
+	}
+	if parsed.Forecasts[0].Estimate == nil {
+		t.Error("Expected estimate to be set")
+	}
+}
+
+func TestReportCommand_ServiceError(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		ProjectsErr: errors.New("OmniFocus is not running"),
+	}
+
+	_, err := executeReportCommand(mockService, []string{})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestReportHeatmapCommand_HumanOutput(t *testing.T) {
+	completed := time.Now().Add(-time.Minute)
+	mockService := &service.MockOmniFocusService{
+		CompletedTasks: []domain.Task{
+			{ID: "task1", Name: "Done", Completed: true, CompletedDate: &completed},
+		},
+	}
+
+	output, err := executeReportCommand(mockService, []string{"heatmap", "--weeks", "4"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{"COMPLETIONS (last 4 weeks, 1 task)", "Mon", "Sun", "█", "busiest day: 1"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+
+	if mockService.CompletedSince.After(time.Now().AddDate(0, 0, -21)) {
+		t.Errorf("Expected history to start at least 3 weeks ago, got: %v", mockService.CompletedSince)
+	}
+}
+
+func TestReportHeatmapCommand_JSONOutput(t *testing.T) {
+	completed := time.Now().Add(-time.Minute)
+	mockService := &service.MockOmniFocusService{
+		CompletedTasks: []domain.Task{
+			{ID: "task1", Completed: true, CompletedDate: &completed},
+			{ID: "task2", Completed: true, CompletedDate: &completed},
+		},
+	}
+
+	output, err := executeReportCommand(mockService, []string{"heatmap", "--json"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var parsed struct {
+		Days []struct {
+			Count int `json:"count"`
+		} `json:"days"`
+		Weeks int `json:"weeks"`
+		Total int `json:"total"`
+		Max   int `json:"max"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Expected valid JSON, got error: %v\nOutput: %s", err, output)
+	}
+
+	if parsed.Weeks != 12 {
+		t.Errorf("Expected 12 weeks, got: %d", parsed.Weeks)
+	}
+	if parsed.Total != 2 || parsed.Max != 2 {
+		t.Errorf("Expected total=2 max=2, got total=%d max=%d", parsed.Total, parsed.Max)
+	}
+	if last := parsed.Days[len(parsed.Days)-1]; last.Count != 2 {
+		t.Errorf("Expected today's count to be 2, got: %d", last.Count)
+	}
+}
+
+func TestReportHeatmapCommand_InvalidWeeks(t *testing.T) {
+	_, err := executeReportCommand(&service.MockOmniFocusService{}, []string{"heatmap", "--weeks", "0"})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func executeReportCommand(mockService service.OmniFocusService, args []string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewReportCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+
+	rootCmd.SetArgs(append([]string{"report"}, args...))
+
+	ctx := ContextWithService(context.Background(), mockService)
+	err := rootCmd.ExecuteContext(ctx)
+
+	return buf.String(), err
+}
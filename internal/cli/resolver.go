@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// taskIndex maps the short numbers printed by the last tasks listing to
+// task IDs; number n is IDs[n-1]
+type taskIndex struct {
+	IDs []string `json:"ids"`
+}
+
+// saveTaskIndex numbers tasks 1, 2, 3… in the order listed and saves the
+// numbers so later commands accept them in place of IDs. It returns each
+// task's number by ID.
+func saveTaskIndex(tasks []domain.Task) (map[string]int, error) {
+	index := taskIndex{IDs: make([]string, len(tasks))}
+	numbers := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		index.IDs[i] = task.ID
+		numbers[task.ID] = i + 1
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task index: %w", err)
+	}
+	path := config.TaskIndexPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create task index directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write task index: %w", err)
+	}
+	return numbers, nil
+}
+
+// loadTaskIndex reads the numbers saved by the last tasks listing, returning
+// an empty index when there was none
+func loadTaskIndex() (taskIndex, error) {
+	data, err := os.ReadFile(config.TaskIndexPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return taskIndex{}, nil
+	}
+	if err != nil {
+		return taskIndex{}, fmt.Errorf("failed to read task index: %w", err)
+	}
+
+	var index taskIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return taskIndex{}, fmt.Errorf("failed to parse task index: %w", err)
+	}
+	return index, nil
+}
+
+// resolveTaskIDs turns each argument into a task ID: a number such as 3 or
+// #3 is looked up in the last tasks listing, anything else is an ID already
+func resolveTaskIDs(args []string) ([]string, error) {
+	var index *taskIndex
+	ids := make([]string, len(args))
+	for i, arg := range args {
+		n, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil || n < 1 {
+			ids[i] = arg
+			continue
+		}
+
+		if index == nil {
+			loaded, err := loadTaskIndex()
+			if err != nil {
+				return nil, err
+			}
+			index = &loaded
+		}
+		if n > len(index.IDs) {
+			return nil, fmt.Errorf("no task %d in the last listing; run lazyfocus tasks to number tasks", n)
+		}
+		ids[i] = index.IDs[n-1]
+	}
+	return ids, nil
+}
+
+// resolveTaskID is resolveTaskIDs for a single argument
+func resolveTaskID(arg string) (string, error) {
+	ids, err := resolveTaskIDs([]string{arg})
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
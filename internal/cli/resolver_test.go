@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// TestMain keeps the task index that tasks listings save out of the real
+// state directory
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "lazyfocus-cli-state-")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_STATE_HOME", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestResolveTaskIDs(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	if _, err := saveTaskIndex([]domain.Task{{ID: "kXu3B1Vn2aF"}, {ID: "pQ7sLm0Zt4e"}}); err != nil {
+		t.Fatalf("saveTaskIndex() error = %v", err)
+	}
+
+	got, err := resolveTaskIDs([]string{"2", "#1", "abc123"})
+	if err != nil {
+		t.Fatalf("resolveTaskIDs() error = %v", err)
+	}
+
+	want := []string{"pQ7sLm0Zt4e", "kXu3B1Vn2aF", "abc123"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("resolveTaskIDs()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestResolveTaskIDs_NumberNotListed(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	if _, err := saveTaskIndex([]domain.Task{{ID: "kXu3B1Vn2aF"}}); err != nil {
+		t.Fatalf("saveTaskIndex() error = %v", err)
+	}
+
+	_, err := resolveTaskIDs([]string{"3"})
+	if err == nil || !strings.Contains(err.Error(), "no task 3 in the last listing") {
+		t.Errorf("resolveTaskIDs() error = %v, want no task 3", err)
+	}
+}
+
+func TestResolveTaskIDs_NoListing(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	if _, err := resolveTaskIDs([]string{"1"}); err == nil {
+		t.Error("resolveTaskIDs() error = nil without a listing, want an error")
+	}
+	if got, err := resolveTaskIDs([]string{"abc123"}); err != nil || got[0] != "abc123" {
+		t.Errorf("resolveTaskIDs(abc123) = %v, %v, want the ID unchanged", got, err)
+	}
+}
+
+func TestTasksThenComplete_ByNumber(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	mockService := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "kXu3B1Vn2aF", Name: "Buy groceries"},
+			{ID: "pQ7sLm0Zt4e", Name: "Call dentist"},
+		},
+		CompleteResult: &domain.OperationResult{Success: true, ID: "pQ7sLm0Zt4e", Message: "Task completed"},
+	}
+
+	output, _, err := executeTasksCommand(mockService, []string{})
+	if err != nil {
+		t.Fatalf("tasks error = %v", err)
+	}
+	if !strings.Contains(output, "[1] ☐ Buy groceries") || !strings.Contains(output, "[2] ☐ Call dentist") {
+		t.Errorf("tasks output = %q, want numbered tasks", output)
+	}
+
+	if _, _, err := executeCompleteCommand(mockService, []string{"2"}); err != nil {
+		t.Fatalf("complete error = %v", err)
+	}
+	if len(mockService.CompletedIDs) != 1 || mockService.CompletedIDs[0] != "pQ7sLm0Zt4e" {
+		t.Errorf("CompletedIDs = %v, want [pQ7sLm0Zt4e]", mockService.CompletedIDs)
+	}
+}
+
+func TestTasksCommand_JSONDoesNotNumber(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	mockService := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "kXu3B1Vn2aF", Name: "Buy groceries"}},
+	}
+
+	if _, _, err := executeTasksCommand(mockService, []string{"--json"}); err != nil {
+		t.Fatalf("tasks error = %v", err)
+	}
+
+	if _, err := resolveTaskIDs([]string{"1"}); err == nil {
+		t.Error("resolveTaskIDs(1) after a JSON listing succeeded, want no numbers saved")
+	}
+}
@@ -3,18 +3,45 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
-	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
+	"github.com/pwojciechowski/lazyfocus/internal/log"
+	"github.com/pwojciechowski/lazyfocus/internal/notetemplates"
+	"github.com/pwojciechowski/lazyfocus/internal/rules"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
 	"github.com/spf13/cobra"
 )
 
+// Output formats accepted by the --output flag
+const (
+	OutputHuman = "human"
+	OutputJSON  = "json"
+	OutputJSONL = "jsonl"
+	OutputCSV   = "csv"
+	OutputTSV   = "tsv"
+	OutputTable = "table"
+
+	// OutputShortcut is set by --shortcut-output rather than --output
+	OutputShortcut = "shortcut"
+)
+
 var (
-	jsonOutput bool
-	quietMode  bool
-	timeout    time.Duration
+	jsonOutput   bool
+	quietMode    bool
+	timeout      time.Duration
+	outputFormat string
+	columns      []string
+	debugMode    bool
+	shortcutMode bool
+	noColorMode  bool
 )
 
 // NewRootCommand creates the root cobra command for lazyfocus
@@ -29,12 +56,21 @@ scripting and AI agent integration.`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := setupDebugLog(); err != nil {
+				return err
+			}
+			setupColor()
+
 			// Skip service setup for commands that have skipServiceSetup annotation
 			// or for the built-in help command (which cannot be annotated)
 			if cmd.Annotations["skipServiceSetup"] == "true" || cmd.Name() == "help" {
 				return nil
 			}
 
+			if err := validateOutputFlags(); err != nil {
+				return err
+			}
+
 			// Get current context, use background if nil
 			ctx := cmd.Context()
 			if ctx == nil {
@@ -56,15 +92,55 @@ scripting and AI agent integration.`,
 				cmd.SetContext(ctx)
 			}
 
-			// Check if service is already in context (e.g., from tests)
-			if _, err := ServiceFromContext(ctx); err == nil {
-				// Service already exists, skip setup
-				return nil
+			// Make relative dates skip weekends and holidays, pick the TUI
+			// theme and name this machine in the debug log if configured
+			if cfg, err := config.FromContext(ctx); err == nil {
+				log.SetDevice(deviceID(cfg))
+				if err := setupCalendar(cfg.Calendar); err != nil {
+					return err
+				}
+				if err := setupTheme(cfg.TUI); err != nil {
+					return err
+				}
+			}
+
+			// Use the service already in context (e.g., from tests) or create one
+			svc, err := ServiceFromContext(ctx)
+			if err != nil {
+				cfg, _ := config.FromContext(ctx)
+				svc = service.NewOmniFocusService(newExecutor(cfg), GetTimeoutFlag())
 			}
 
-			// Create executor and service
-			executor := bridge.NewOSAScriptExecutor()
-			svc := service.NewOmniFocusService(executor, GetTimeoutFlag())
+			// Fill in default notes of created tasks, after rules have added their tags
+			if cfg, err := config.FromContext(ctx); err == nil && len(cfg.NoteTemplates) > 0 {
+				templates, err := notetemplates.New(cfg.NoteTemplates)
+				if err != nil {
+					return fmt.Errorf("invalid note templates: %w", err)
+				}
+				svc = service.NewNoteTemplateOmniFocusService(svc, templates, notetemplates.SourceCLI)
+			}
+
+			// Check WIP limits on changes, including the tags rules add, warning on stderr
+			if cfg, err := config.FromContext(ctx); err == nil && len(cfg.Limits) > 0 {
+				limitList, err := limits.New(cfg.Limits)
+				if err != nil {
+					return fmt.Errorf("invalid limits: %w", err)
+				}
+				svc = service.NewLimitsOmniFocusService(svc, limitList, func(usage limits.Usage) {
+					if !GetQuietFlag() {
+						cmd.PrintErrf("Warning: over WIP limit: %s\n", usage)
+					}
+				})
+			}
+
+			// Apply automatic rules to created tasks
+			if cfg, err := config.FromContext(ctx); err == nil && len(cfg.Rules) > 0 {
+				engine, err := rules.New(cfg.Rules)
+				if err != nil {
+					return fmt.Errorf("invalid rules: %w", err)
+				}
+				svc = service.NewRulesOmniFocusService(svc, engine)
+			}
 
 			// Inject service into context
 			ctx = ContextWithService(ctx, svc)
@@ -77,14 +153,49 @@ scripting and AI agent integration.`,
 	// Global flags
 	cmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	cmd.PersistentFlags().BoolVar(&quietMode, "quiet", false, "Suppress output, exit codes only")
-	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "Timeout for OmniFocus operations")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "Timeout for each OmniFocus script")
+	cmd.PersistentFlags().StringVar(&outputFormat, "output", "", "Output format (human, json, jsonl, csv, tsv, table)")
+	cmd.PersistentFlags().StringSliceVar(&columns, "columns", nil, "Columns to include in csv/tsv output (e.g. id,name,due,project)")
+	cmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Log OmniFocus script calls to the debug log")
+	cmd.PersistentFlags().BoolVar(&shortcutMode, "shortcut-output", false, "Output plain sentences for Shortcuts.app, one item per line")
+	cmd.PersistentFlags().BoolVar(&noColorMode, "no-color", false, "Disable colors and text styling (also set by NO_COLOR)")
+	// Data and errors are written in one format, so only one flag may pick it
+	cmd.MarkFlagsMutuallyExclusive("json", "output")
+
+	// Every command's help ends with the exit codes and environment variables
+	cmd.SetUsageTemplate(cmd.UsageTemplate() + helpSections())
 
 	return cmd
 }
 
-// GetJSONFlag returns the value of the --json flag
+// GetJSONFlag reports whether JSON output was requested via --json,
+// --output json or --output jsonl
 func GetJSONFlag() bool {
-	return jsonOutput
+	return jsonOutput || outputFormat == OutputJSON || outputFormat == OutputJSONL
+}
+
+// GetOutputFlag returns the effective output format
+func GetOutputFlag() string {
+	if shortcutMode {
+		return OutputShortcut
+	}
+	if outputFormat != "" {
+		return outputFormat
+	}
+	if jsonOutput {
+		return OutputJSON
+	}
+	return OutputHuman
+}
+
+// GetNoColorFlag returns the value of the --no-color flag
+func GetNoColorFlag() bool {
+	return noColorMode
+}
+
+// GetColumnsFlag returns the value of the --columns flag
+func GetColumnsFlag() []string {
+	return columns
 }
 
 // GetQuietFlag returns the value of the --quiet flag
@@ -97,6 +208,48 @@ func GetTimeoutFlag() time.Duration {
 	return timeout
 }
 
+// setupDebugLog turns on the debug log when --debug or LAZYFOCUS_DEBUG is set
+func setupDebugLog() error {
+	if !debugMode && !isTruthy(os.Getenv("LAZYFOCUS_DEBUG")) {
+		return nil
+	}
+	if err := log.Enable(config.DebugLogPath()); err != nil {
+		return err
+	}
+	log.Logger().Debug("lazyfocus started", "version", Version, "args", os.Args[1:])
+	return nil
+}
+
+// deviceID returns the name of this machine in the debug log: device_id
+// from the config, or the host name without its domain
+func deviceID(cfg *config.Config) string {
+	if cfg.DeviceID != "" {
+		return cfg.DeviceID
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	host, _, _ = strings.Cut(host, ".")
+	return host
+}
+
+// setupColor makes lipgloss, and so table output and the TUI, render colors
+// only when they are wanted on stdout
+func setupColor() {
+	lipgloss.SetDefaultRenderer(tui.NewRenderer(os.Stdout, tui.ColorEnabled(os.Stdout, GetNoColorFlag())))
+}
+
+// isTruthy reports whether an environment variable value means "on"
+func isTruthy(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
 // applyConfigToFlags applies configuration values to flags if flags were not explicitly set
 func applyConfigToFlags(cmd *cobra.Command, cfg *config.Config) {
 	// Only apply config if flag was not explicitly set by user
@@ -104,7 +257,26 @@ func applyConfigToFlags(cmd *cobra.Command, cfg *config.Config) {
 		_ = cmd.Flags().Set("json", "true")
 	}
 
+	if !cmd.Flags().Changed("output") && !cmd.Flags().Changed("json") &&
+		(cfg.Output.Format == OutputJSONL || cfg.Output.Format == OutputCSV || cfg.Output.Format == OutputTSV || cfg.Output.Format == OutputTable) {
+		_ = cmd.Flags().Set("output", cfg.Output.Format)
+	}
+
 	if !cmd.Flags().Changed("timeout") {
 		_ = cmd.Flags().Set("timeout", cfg.Timeout.String())
 	}
 }
+
+// validateOutputFlags checks the --output and --columns flags
+func validateOutputFlags() error {
+	switch outputFormat {
+	case "", OutputHuman, OutputJSON, OutputJSONL, OutputCSV, OutputTSV, OutputTable:
+	default:
+		return invalidInput("invalid output format %q: must be one of human, json, jsonl, csv, tsv, table", outputFormat)
+	}
+
+	if err := output.ValidateColumns(columns); err != nil {
+		return invalidInput("invalid --columns: %w", err)
+	}
+	return nil
+}
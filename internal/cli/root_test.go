@@ -5,12 +5,14 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/config"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/log"
 	"github.com/spf13/cobra"
 )
 
@@ -37,6 +39,11 @@ func TestRootCommand_Flags(t *testing.T) {
 			flagName: "timeout",
 			flagType: "duration",
 		},
+		{
+			name:     "no-color flag exists",
+			flagName: "no-color",
+			flagType: "bool",
+		},
 	}
 
 	for _, tt := range tests {
@@ -125,6 +132,27 @@ func TestGetJSONFlag(t *testing.T) {
 	}
 }
 
+func TestRootCommand_JSONAndOutputAreExclusive(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(&cobra.Command{
+		Use:         "testexclusive",
+		Annotations: map[string]string{"skipServiceSetup": "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t.Error("command should not run with both --json and --output")
+			return nil
+		},
+	})
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	rootCmd.SetArgs([]string{"testexclusive", "--json", "--output", "csv"})
+	err := rootCmd.Execute()
+
+	if err == nil || !strings.Contains(err.Error(), "[json output]") {
+		t.Errorf("Expected --json and --output to be rejected together, got: %v", err)
+	}
+}
+
 func TestGetQuietFlag(t *testing.T) {
 	cmd := NewRootCommand()
 
@@ -442,3 +470,104 @@ timeout: 30s
 		t.Errorf("Expected --timeout flag to be 90s, got %v", timeoutFlagValue)
 	}
 }
+
+func TestRootCommand_PersistentPreRunE_AppliesTableFormatFromConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		env    string
+	}{
+		{name: "config file", config: "output:\n  format: table\n"},
+		{name: "environment", env: OutputTable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			t.Setenv("HOME", tmpDir)
+			t.Setenv("LAZYFOCUS_OUTPUT_FORMAT", tt.env)
+			if tt.config != "" {
+				configPath := filepath.Join(tmpDir, ".lazyfocus.yaml")
+				if err := os.WriteFile(configPath, []byte(tt.config), 0644); err != nil {
+					t.Fatalf("Failed to write config file: %v", err)
+				}
+			}
+
+			rootCmd := NewRootCommand()
+			rootCmd.AddCommand(NewTasksCommand())
+
+			var format string
+			tasksCmd, _, _ := rootCmd.Find([]string{"tasks"})
+			originalRunE := tasksCmd.RunE
+			tasksCmd.RunE = func(cmd *cobra.Command, args []string) error {
+				format = GetOutputFlag()
+				return originalRunE(cmd, args)
+			}
+
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+
+			mockService := &service.MockOmniFocusService{
+				InboxTasks: []domain.Task{{ID: "task1", Name: "Test task"}},
+			}
+			ctx := ContextWithService(context.Background(), mockService)
+			rootCmd.SetArgs([]string{"tasks"})
+			if err := rootCmd.ExecuteContext(ctx); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if format != OutputTable {
+				t.Errorf("Expected output format %q from %s, got %q", OutputTable, tt.name, format)
+			}
+		})
+	}
+}
+
+func TestRootCommand_DebugFlagEnablesLog(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  string
+	}{
+		{name: "flag", args: []string{"version", "--debug"}},
+		{name: "environment", args: []string{"version"}, env: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := t.TempDir()
+			t.Setenv("XDG_STATE_HOME", state)
+			t.Setenv("LAZYFOCUS_DEBUG", tt.env)
+			t.Cleanup(func() { _ = log.Disable() })
+
+			rootCmd := NewRootCommand()
+			rootCmd.AddCommand(NewVersionCommand())
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetArgs(tt.args)
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			want := filepath.Join(state, "lazyfocus", "debug.log")
+			if !log.Enabled() || log.Path() != want {
+				t.Errorf("debug log enabled = %v at %q, want %q", log.Enabled(), log.Path(), want)
+			}
+		})
+	}
+}
+
+func TestDeviceID(t *testing.T) {
+	if got := deviceID(&config.Config{DeviceID: "studio-mac"}); got != "studio-mac" {
+		t.Errorf("deviceID() = %q, want the configured %q", got, "studio-mac")
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skipf("no host name: %v", err)
+	}
+	want, _, _ := strings.Cut(host, ".")
+	if got := deviceID(&config.Config{}); got != want {
+		t.Errorf("deviceID() = %q, want the host name %q", got, want)
+	}
+}
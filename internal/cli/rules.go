@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+// NewRulesCommand creates the rules command
+func NewRulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Manage automatic tagging rules",
+		Long: `Manage the automatic tagging rules defined under "rules" in the config file.
+
+Rules are applied to every task created with lazyfocus. Use "rules apply" to
+run them against existing tasks.`,
+	}
+
+	cmd.AddCommand(newRulesApplyCommand())
+
+	return cmd
+}
+
+// newRulesApplyCommand creates the rules apply subcommand
+func newRulesApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply rules to existing tasks",
+		Long: `Apply the configured rules to existing incomplete tasks.
+
+Only changes a task is still missing are made: tags already present and dates
+already set are left alone.`,
+		Example: `  lazyfocus rules apply --dry-run
+  lazyfocus rules apply --inbox`,
+		Args: cobra.NoArgs,
+		RunE: runRulesApply,
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Show the tasks that would change without modifying them")
+	cmd.Flags().Bool("inbox", false, "Only apply rules to inbox tasks")
+
+	return cmd
+}
+
+func runRulesApply(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	inboxOnly, _ := cmd.Flags().GetBool("inbox")
+
+	cfg, err := config.FromContext(ctx)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	if len(cfg.Rules) == 0 {
+		return handleError(cmd, errors.New("no rules configured: add a \"rules\" section to the config file"))
+	}
+	engine, err := rules.New(cfg.Rules)
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("invalid rules: %w", err))
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	var tasks []domain.Task
+	if inboxOnly {
+		tasks, err = svc.GetInboxTasks(ctx)
+	} else {
+		tasks, err = svc.GetAllTasks(ctx, service.TaskFilters{})
+	}
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	pending, mods := pendingRuleChanges(engine, tasks)
+
+	// Dry runs and runs with nothing to change just list the affected tasks
+	if dryRun || len(pending) == 0 {
+		if !GetQuietFlag() {
+			formatter := getFormatter()
+			if err := formatter.FormatTasks(ctx, cmd.OutOrStdout(), pending, output.TaskFormatOptions{ShowProject: true, ShowTags: true}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var lastError error
+	successCount := 0
+
+	reporter := newProgressReporter(cmd, len(pending))
+	reporter.Start("Applying rules", len(pending))
+	defer reporter.Finish()
+
+	for _, task := range pending {
+		modified, err := svc.ModifyTask(ctx, task.ID, mods[task.ID])
+		reporter.Increment()
+		if err != nil {
+			lastError = err
+			if !GetQuietFlag() {
+				reporter.Clear()
+				printError(cmd, fmt.Errorf("failed to apply rules to %s: %w", task.ID, err))
+			}
+			continue
+		}
+
+		successCount++
+
+		if !GetQuietFlag() {
+			reporter.Clear()
+			formatter := getFormatter()
+			if err := formatter.FormatModifiedTask(ctx, cmd.OutOrStdout(), *modified); err != nil {
+				return err
+			}
+		}
+	}
+
+	// If every modification failed, return the last error
+	if successCount == 0 && lastError != nil {
+		return printed(lastError)
+	}
+
+	return nil
+}
+
+// pendingRuleChanges returns the incomplete tasks the rules would change, with
+// the modification for each task keyed by ID
+func pendingRuleChanges(engine *rules.Engine, tasks []domain.Task) ([]domain.Task, map[string]domain.TaskModification) {
+	var pending []domain.Task
+	mods := make(map[string]domain.TaskModification)
+	for _, task := range tasks {
+		if task.Completed {
+			continue
+		}
+		mod, _ := engine.ModificationFor(task)
+		if mod.IsEmpty() {
+			continue
+		}
+		pending = append(pending, task)
+		mods[task.ID] = mod
+	}
+	return pending, mods
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+var testRules = []config.RuleConfig{{
+	Name:    "Phone calls",
+	Match:   config.RuleMatchConfig{Name: "call"},
+	Actions: config.RuleActionsConfig{AddTags: []string{"phone"}},
+}}
+
+func TestRulesApplyCommand_ModifiesMatchingTasks(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "task1", Name: "Call mom"},
+			{ID: "task2", Name: "Call bank", Tags: []string{"phone"}},
+			{ID: "task3", Name: "Buy milk"},
+		},
+		ModifiedTask: &domain.Task{ID: "task1", Name: "Call mom", Tags: []string{"phone"}},
+	}
+
+	output, err := executeRulesCommand(mockService, testRules, []string{"apply"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.Modifications) != 1 {
+		t.Fatalf("ModifyTask() calls = %v, want only task1", mockService.Modifications)
+	}
+	if mod := mockService.Modifications["task1"]; strings.Join(mod.AddTags, ",") != "phone" {
+		t.Errorf("ModifyTask() AddTags = %v, want [phone]", mod.AddTags)
+	}
+	if !strings.Contains(output, "Call mom") {
+		t.Errorf("Expected output to contain modified task, got: %s", output)
+	}
+}
+
+func TestRulesApplyCommand_DryRun(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{{ID: "task1", Name: "Call mom"}},
+	}
+
+	output, err := executeRulesCommand(mockService, testRules, []string{"apply", "--dry-run"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.Modifications) != 0 {
+		t.Errorf("ModifyTask() calls = %v, want none on dry run", mockService.Modifications)
+	}
+	if !strings.Contains(output, "Call mom") {
+		t.Errorf("Expected output to list task that would change, got: %s", output)
+	}
+}
+
+func TestRulesApplyCommand_NoRules(t *testing.T) {
+	_, err := executeRulesCommand(&service.MockOmniFocusService{}, nil, []string{"apply"})
+
+	if err == nil || !strings.Contains(err.Error(), "no rules configured") {
+		t.Errorf("Expected no rules error, got: %v", err)
+	}
+}
+
+func TestRulesApplyCommand_InvalidRules(t *testing.T) {
+	invalid := []config.RuleConfig{{Match: config.RuleMatchConfig{Name: "("}, Actions: config.RuleActionsConfig{Flag: true}}}
+
+	_, err := executeRulesCommand(&service.MockOmniFocusService{}, invalid, []string{"apply"})
+
+	if err == nil || !strings.Contains(err.Error(), "invalid rules") {
+		t.Errorf("Expected invalid rules error, got: %v", err)
+	}
+}
+
+func TestAddCommand_AppliesRules(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		CreatedTask:  &domain.Task{ID: "task1", Name: "Call mom"},
+		ModifiedTask: &domain.Task{ID: "task1", Name: "Call mom", Tags: []string{"phone"}},
+	}
+
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewAddCommand())
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"add", "Call mom"})
+
+	ctx := config.ContextWithConfig(context.Background(), &config.Config{Rules: testRules})
+	ctx = ContextWithService(ctx, mockService)
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if mockService.CreateInput == nil || strings.Join(mockService.CreateInput.TagNames, ",") != "phone" {
+		t.Errorf("CreateTask() input = %+v, want phone tag from rule", mockService.CreateInput)
+	}
+}
+
+func TestAddCommand_AppliesNoteTemplateAfterRules(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		CreatedTask:  &domain.Task{ID: "task1", Name: "Call mom"},
+		ModifiedTask: &domain.Task{ID: "task1", Name: "Call mom", Tags: []string{"phone"}},
+	}
+
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewAddCommand())
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"add", "Call mom"})
+
+	cfg := &config.Config{
+		Rules:         testRules,
+		NoteTemplates: []config.NoteTemplateConfig{{Tag: "phone", Note: "Number:\nAdded via {{.Source}}"}},
+	}
+	ctx := config.ContextWithConfig(context.Background(), cfg)
+	ctx = ContextWithService(ctx, mockService)
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if mockService.CreateInput == nil || mockService.CreateInput.Note != "Number:\nAdded via cli" {
+		t.Errorf("CreateTask() input = %+v, want note from the phone template", mockService.CreateInput)
+	}
+}
+
+// Helper function to execute rules command with the given rules configured
+func executeRulesCommand(mockService service.OmniFocusService, ruleConfigs []config.RuleConfig, args []string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewRulesCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(append([]string{"rules"}, args...))
+
+	ctx := config.ContextWithConfig(context.Background(), &config.Config{Rules: ruleConfigs})
+	ctx = ContextWithService(ctx, mockService)
+	err := rootCmd.ExecuteContext(ctx)
+
+	return buf.String(), err
+}
@@ -0,0 +1,340 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/api"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/rules"
+	"github.com/pwojciechowski/lazyfocus/internal/scheduler"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+// Actions that can be scheduled under "schedule" in the config file
+const (
+	ActionRules  = "rules"
+	ActionReport = "report"
+	ActionDigest = "digest"
+)
+
+// NewServeCommand creates the serve command
+func NewServeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run scheduled actions and the HTTP API in the foreground",
+		Long: `Run lazyfocus in the foreground, running the actions listed under
+"schedule" in the config file whenever their cron expression matches.
+
+Supported actions:
+  rules    Apply the configured rules to existing tasks
+  report   Write the project completion report to the job's output file
+
+With --listen, also serve a JSON HTTP API. Every request must send one of the
+tokens under "api.tokens" in the config file as "Authorization: Bearer
+<token>", and each token is limited to its scope:
+  read-only    List and show tasks, projects and tags
+  create-only  Create tasks, projects and tags, nothing else
+  full         Everything, including modifying, completing and deleting
+
+Stop with Ctrl+C.`,
+		Example: `  lazyfocus serve
+  lazyfocus serve --listen 127.0.0.1:7878`,
+		Args: cobra.NoArgs,
+		RunE: runServe,
+	}
+
+	cmd.Flags().String("listen", "", "Serve the HTTP API on this address (e.g. 127.0.0.1:7878)")
+
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	cfg, err := config.FromContext(cmd.Context())
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	listen, _ := cmd.Flags().GetString("listen")
+	if len(cfg.Schedule) == 0 && listen == "" {
+		return handleError(cmd, errors.New("no scheduled actions configured: add a \"schedule\" section to the config file or use --listen to serve the API"))
+	}
+
+	var tokens []api.Token
+	if listen != "" {
+		if tokens, err = apiTokens(cfg); err != nil {
+			return handleError(cmd, err)
+		}
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	jobs, err := buildScheduledJobs(cfg, svc)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var serveErr <-chan error
+	if listen != "" {
+		var addr net.Addr
+		if addr, serveErr, err = startAPI(ctx, listen, api.NewServer(svc, tokens)); err != nil {
+			return handleError(cmd, err)
+		}
+		if !GetQuietFlag() {
+			cmd.Printf("Serving API on http://%s with %d tokens\n", addr, len(tokens))
+		}
+	}
+
+	if len(jobs) == 0 {
+		if err := waitForAPI(ctx, serveErr); err != nil {
+			return handleError(cmd, err)
+		}
+		return nil
+	}
+
+	s := scheduler.New(jobs, func(res scheduler.Result) {
+		if GetQuietFlag() {
+			return
+		}
+		if res.Err != nil {
+			cmd.PrintErrf("[%s] %s failed: %v\n", res.At.Format("2006-01-02 15:04"), res.Job, res.Err)
+			return
+		}
+		cmd.Printf("[%s] %s: ok\n", res.At.Format("2006-01-02 15:04"), res.Job)
+	})
+
+	if !GetQuietFlag() {
+		for _, job := range jobs {
+			if next, ok := s.NextRun(job.Name); ok {
+				cmd.Printf("Scheduled %s (%s), next run %s\n", job.Name, job.Schedule, next.Format("2006-01-02 15:04"))
+			}
+		}
+	}
+
+	if serveErr != nil {
+		// Stop the scheduler if the API fails
+		runCtx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		go func() {
+			if err := <-serveErr; err != nil {
+				cancel(err)
+			}
+		}()
+		ctx = runCtx
+	}
+
+	if err := s.Run(ctx); err != nil {
+		return handleError(cmd, err)
+	}
+	if cause := context.Cause(ctx); cause != nil && !errors.Is(cause, context.Canceled) {
+		return handleError(cmd, fmt.Errorf("API server failed: %w", cause))
+	}
+	return nil
+}
+
+// apiTokens returns the tokens configured under api.tokens
+func apiTokens(cfg *config.Config) ([]api.Token, error) {
+	if len(cfg.API.Tokens) == 0 {
+		return nil, errors.New("no API tokens configured: add tokens under \"api.tokens\" in the config file")
+	}
+
+	tokens := make([]api.Token, 0, len(cfg.API.Tokens))
+	for i, tokenCfg := range cfg.API.Tokens {
+		name := tokenCfg.Name
+		if name == "" {
+			name = fmt.Sprintf("token %d", i+1)
+		}
+		if tokenCfg.Token == "" {
+			return nil, fmt.Errorf("%s: token is required", name)
+		}
+		scope, err := service.ParseScope(tokenCfg.Scope)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		tokens = append(tokens, api.Token{Name: name, Secret: tokenCfg.Token, Scope: scope})
+	}
+	return tokens, nil
+}
+
+// startAPI serves handler on addr until ctx is done and returns the address
+// it listens on. The returned channel receives the error that stopped the
+// server early, or nil after a shutdown.
+func startAPI(ctx context.Context, addr string, handler http.Handler) (net.Addr, <-chan error, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	done := make(chan error, 1)
+	go func() {
+		err := srv.Serve(ln)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		done <- err
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	return ln.Addr(), done, nil
+}
+
+// waitForAPI blocks until ctx is done or the API server fails
+func waitForAPI(ctx context.Context, serveErr <-chan error) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-serveErr:
+		if err != nil {
+			return fmt.Errorf("API server failed: %w", err)
+		}
+		return nil
+	}
+}
+
+// buildScheduledJobs turns the configured schedule into scheduler jobs
+func buildScheduledJobs(cfg *config.Config, svc service.OmniFocusService) ([]scheduler.Job, error) {
+	var engine *rules.Engine
+
+	jobs := make([]scheduler.Job, 0, len(cfg.Schedule))
+	for i, jobCfg := range cfg.Schedule {
+		name := jobCfg.Name
+		if name == "" {
+			name = fmt.Sprintf("job %d", i+1)
+		}
+
+		schedule, err := scheduler.Parse(jobCfg.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		job := scheduler.Job{Name: name, Schedule: schedule}
+		switch jobCfg.Action {
+		case ActionRules:
+			if engine == nil {
+				if len(cfg.Rules) == 0 {
+					return nil, fmt.Errorf("%s: no rules configured", name)
+				}
+				if engine, err = rules.New(cfg.Rules); err != nil {
+					return nil, fmt.Errorf("invalid rules: %w", err)
+				}
+			}
+			job.Run = scheduledRules(svc, engine)
+		case ActionReport:
+			if jobCfg.Output == "" {
+				return nil, fmt.Errorf("%s: report action requires an output file", name)
+			}
+			job.Run = scheduledReport(svc, expandHome(jobCfg.Output))
+		case ActionDigest:
+			to := jobCfg.Email
+			if to == "" {
+				to = cfg.Digest.To
+			}
+			if to == "" {
+				return nil, fmt.Errorf("%s: digest action requires an email or digest.to", name)
+			}
+			job.Run = scheduledDigest(svc, cfg.Digest.SMTP, to)
+		default:
+			return nil, fmt.Errorf("%s: unknown action %q (must be %s, %s or %s)", name, jobCfg.Action, ActionRules, ActionReport, ActionDigest)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// scheduledRules applies the rules to every incomplete task
+func scheduledRules(svc service.OmniFocusService, engine *rules.Engine) func(context.Context) error {
+	return func(ctx context.Context) error {
+		tasks, err := svc.GetAllTasks(ctx, service.TaskFilters{})
+		if err != nil {
+			return fmt.Errorf("failed to get tasks: %w", err)
+		}
+
+		pending, mods := pendingRuleChanges(engine, tasks)
+		var failed int
+		var lastError error
+		for _, task := range pending {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if _, err := svc.ModifyTask(ctx, task.ID, mods[task.ID]); err != nil {
+				failed++
+				lastError = err
+			}
+		}
+
+		if lastError != nil {
+			return fmt.Errorf("failed to apply rules to %d of %d tasks: %w", failed, len(pending), lastError)
+		}
+		return nil
+	}
+}
+
+// scheduledReport writes the project completion report to path
+func scheduledReport(svc service.OmniFocusService, path string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		projects, err := svc.GetProjects(ctx, "active")
+		if err != nil {
+			return fmt.Errorf("failed to get projects: %w", err)
+		}
+
+		forecasts := stats.ForecastProjects(projects, time.Now())
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		if err := getFormatter().FormatForecasts(ctx, file, forecasts); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		return nil
+	}
+}
+
+// scheduledDigest emails the weekly digest to the recipient
+func scheduledDigest(svc service.OmniFocusService, smtpCfg config.SMTPConfig, to string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		report, err := weeklyDigest(ctx, svc, time.Now())
+		if err != nil {
+			return err
+		}
+		return emailDigest(ctx, smtpCfg, to, report)
+	}
+}
+
+// expandHome replaces a leading ~/ with the user's home directory
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
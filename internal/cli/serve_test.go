@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/api"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestServeCommand_NoSchedule(t *testing.T) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewServeCommand())
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"serve"})
+
+	ctx := config.ContextWithConfig(context.Background(), &config.Config{})
+	ctx = ContextWithService(ctx, &service.MockOmniFocusService{})
+	err := rootCmd.ExecuteContext(ctx)
+
+	if err == nil || !strings.Contains(err.Error(), "no scheduled actions configured") {
+		t.Errorf("Expected no schedule error, got: %v", err)
+	}
+}
+
+func TestBuildScheduledJobs_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Config
+		wantErr string
+	}{
+		{"bad cron", config.Config{Schedule: []config.JobConfig{{Cron: "nope", Action: ActionReport, Output: "r.txt"}}}, "invalid cron expression"},
+		{"unknown action", config.Config{Schedule: []config.JobConfig{{Cron: "@daily", Action: "backup"}}}, "unknown action"},
+		{"report without output", config.Config{Schedule: []config.JobConfig{{Cron: "@daily", Action: ActionReport}}}, "requires an output file"},
+		{"rules without rules", config.Config{Schedule: []config.JobConfig{{Cron: "@daily", Action: ActionRules}}}, "no rules configured"},
+		{"digest without recipient", config.Config{Schedule: []config.JobConfig{{Cron: "@weekly", Action: ActionDigest}}}, "requires an email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildScheduledJobs(&tt.cfg, &service.MockOmniFocusService{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("buildScheduledJobs() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "job 1") {
+				t.Errorf("buildScheduledJobs() error = %v, want unnamed job referenced by position", err)
+			}
+		})
+	}
+}
+
+func TestScheduledJobs_Run(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.txt")
+	mockService := &service.MockOmniFocusService{
+		AllTasks:     []domain.Task{{ID: "task1", Name: "Call mom"}},
+		ModifiedTask: &domain.Task{ID: "task1", Name: "Call mom", Tags: []string{"phone"}},
+		Projects:     []domain.Project{{ID: "proj1", Name: "Website", Status: "active"}},
+	}
+	cfg := &config.Config{
+		Rules: testRules,
+		Schedule: []config.JobConfig{
+			{Name: "Nightly rules", Cron: "0 2 * * *", Action: ActionRules},
+			{Name: "Morning report", Cron: "0 7 * * 1-5", Action: ActionReport, Output: reportPath},
+		},
+	}
+
+	jobs, err := buildScheduledJobs(cfg, mockService)
+	if err != nil {
+		t.Fatalf("buildScheduledJobs() error = %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Name != "Nightly rules" {
+		t.Fatalf("buildScheduledJobs() = %+v, want 2 named jobs", jobs)
+	}
+
+	for _, job := range jobs {
+		if err := job.Run(context.Background()); err != nil {
+			t.Errorf("%s.Run() error = %v", job.Name, err)
+		}
+	}
+
+	if _, ok := mockService.Modifications["task1"]; !ok {
+		t.Error("expected rules job to modify task1")
+	}
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if !strings.Contains(string(report), "Website") {
+		t.Errorf("report = %q, want it to contain project name", report)
+	}
+}
+
+func TestScheduledJobs_Digest(t *testing.T) {
+	sent := stubMail(t)
+	cfg := digestTestConfig()
+	cfg.Digest.To = "me@example.com"
+	cfg.Schedule = []config.JobConfig{
+		{Cron: "0 8 * * 1", Action: ActionDigest},
+		{Cron: "0 8 * * 5", Action: ActionDigest, Email: "team@example.com"},
+	}
+
+	jobs, err := buildScheduledJobs(cfg, &service.MockOmniFocusService{})
+	if err != nil {
+		t.Fatalf("buildScheduledJobs() error = %v", err)
+	}
+	for _, job := range jobs {
+		if err := job.Run(context.Background()); err != nil {
+			t.Errorf("%s.Run() error = %v", job.Name, err)
+		}
+	}
+
+	if len(*sent) != 2 || (*sent)[0].to[0] != "me@example.com" || (*sent)[1].to[0] != "team@example.com" {
+		t.Errorf("sent = %+v, want digests to digest.to and the job's email", *sent)
+	}
+}
+
+func TestServeCommand_ListenWithoutTokens(t *testing.T) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewServeCommand())
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"serve", "--listen", "127.0.0.1:0"})
+
+	ctx := config.ContextWithConfig(context.Background(), &config.Config{})
+	ctx = ContextWithService(ctx, &service.MockOmniFocusService{})
+	err := rootCmd.ExecuteContext(ctx)
+
+	if err == nil || !strings.Contains(err.Error(), "no API tokens configured") {
+		t.Errorf("Expected no API tokens error, got: %v", err)
+	}
+}
+
+func TestAPITokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		tokens  []config.APITokenConfig
+		wantErr string
+	}{
+		{"missing secret", []config.APITokenConfig{{Name: "widget", Scope: "read-only"}}, "widget: token is required"},
+		{"unknown scope", []config.APITokenConfig{{Token: "s3cret", Scope: "admin"}}, "token 1: unknown scope"},
+		{"missing scope", []config.APITokenConfig{{Token: "s3cret"}}, "unknown scope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := apiTokens(&config.Config{API: config.APIConfig{Tokens: tt.tokens}})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("apiTokens() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	tokens, err := apiTokens(&config.Config{API: config.APIConfig{Tokens: []config.APITokenConfig{
+		{Name: "widget", Token: "s3cret", Scope: "read-only"},
+	}}})
+	if err != nil || len(tokens) != 1 || tokens[0].Scope != service.ScopeReadOnly {
+		t.Errorf("apiTokens() = %+v, %v, want read-only widget token", tokens, err)
+	}
+}
+
+func TestStartAPI(t *testing.T) {
+	mockService := &service.MockOmniFocusService{InboxTasks: []domain.Task{{ID: "task1", Name: "Buy milk"}}}
+	handler := api.NewServer(mockService, []api.Token{{Name: "widget", Secret: "s3cret", Scope: service.ScopeReadOnly}})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	addr, done, err := startAPI(ctx, "127.0.0.1:0", handler)
+	if err != nil {
+		cancel()
+		t.Fatalf("startAPI() error = %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, "http://"+addr.String()+"/v1/tasks/task1", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatalf("DELETE /v1/tasks/task1 error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("DELETE /v1/tasks/task1 with read-only token = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("server stopped with error = %v, want nil after shutdown", err)
+	}
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// DefaultCacheTTL is the default lifetime of cached read results
+const DefaultCacheTTL = 60 * time.Second
+
+// Invalidator is implemented by services that hold cached data which can be
+// discarded on demand (e.g. by the TUI :refresh command)
+type Invalidator interface {
+	Invalidate()
+}
+
+// cacheEntry holds a cached value together with its expiry time
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// CachedOmniFocusService decorates an OmniFocusService and memoizes the most
+// frequently repeated read operations (inbox tasks, task hierarchies, projects,
+// tags, all remaining tasks) for a fixed TTL. Any write operation invalidates
+// the whole cache so that views never show stale data after a change made
+// through this service.
+type CachedOmniFocusService struct {
+	OmniFocusService
+
+	ttl time.Duration
+	now func() time.Time
+
+	mu         sync.Mutex
+	inboxTasks *cacheEntry[[]domain.Task]
+	hierarchy  map[string]cacheEntry[[]domain.Task]
+	projects   map[string]cacheEntry[[]domain.Project]
+	tags       *cacheEntry[[]domain.Tag]
+	allTasks   *cacheEntry[[]domain.Task]
+}
+
+// NewCachedOmniFocusService wraps the given service with a read cache using the given TTL
+func NewCachedOmniFocusService(svc OmniFocusService, ttl time.Duration) *CachedOmniFocusService {
+	return &CachedOmniFocusService{
+		OmniFocusService: svc,
+		ttl:              ttl,
+		now:              time.Now,
+		hierarchy:        make(map[string]cacheEntry[[]domain.Task]),
+		projects:         make(map[string]cacheEntry[[]domain.Project]),
+	}
+}
+
+// Invalidate discards all cached results
+func (c *CachedOmniFocusService) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.inboxTasks = nil
+	c.hierarchy = make(map[string]cacheEntry[[]domain.Task])
+	c.projects = make(map[string]cacheEntry[[]domain.Project])
+	c.tags = nil
+	c.allTasks = nil
+}
+
+// GetInboxTasks returns cached inbox tasks, fetching them when missing or expired
+func (c *CachedOmniFocusService) GetInboxTasks(ctx context.Context) ([]domain.Task, error) {
+	c.mu.Lock()
+	if c.inboxTasks != nil && c.now().Before(c.inboxTasks.expires) {
+		tasks := c.inboxTasks.value
+		c.mu.Unlock()
+		return tasks, nil
+	}
+	c.mu.Unlock()
+
+	tasks, err := c.OmniFocusService.GetInboxTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.inboxTasks = &cacheEntry[[]domain.Task]{value: tasks, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return tasks, nil
+}
+
+// GetTaskHierarchy returns the cached task tree for a project (or the inbox), fetching it when missing or expired
+func (c *CachedOmniFocusService) GetTaskHierarchy(ctx context.Context, projectID string) ([]domain.Task, error) {
+	c.mu.Lock()
+	if entry, ok := c.hierarchy[projectID]; ok && c.now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.value, nil
+	}
+	c.mu.Unlock()
+
+	tasks, err := c.OmniFocusService.GetTaskHierarchy(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.hierarchy[projectID] = cacheEntry[[]domain.Task]{value: tasks, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return tasks, nil
+}
+
+// GetProjects returns cached projects for the given status, fetching them when missing or expired
+func (c *CachedOmniFocusService) GetProjects(ctx context.Context, status string) ([]domain.Project, error) {
+	c.mu.Lock()
+	if entry, ok := c.projects[status]; ok && c.now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.value, nil
+	}
+	c.mu.Unlock()
+
+	projects, err := c.OmniFocusService.GetProjects(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.projects[status] = cacheEntry[[]domain.Project]{value: projects, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return projects, nil
+}
+
+// GetTags returns cached tags, fetching them when missing or expired
+func (c *CachedOmniFocusService) GetTags(ctx context.Context) ([]domain.Tag, error) {
+	c.mu.Lock()
+	if c.tags != nil && c.now().Before(c.tags.expires) {
+		tags := c.tags.value
+		c.mu.Unlock()
+		return tags, nil
+	}
+	c.mu.Unlock()
+
+	tags, err := c.OmniFocusService.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.tags = &cacheEntry[[]domain.Tag]{value: tags, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return tags, nil
+}
+
+// CreateTask creates a task and invalidates the cache
+func (c *CachedOmniFocusService) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.CreateTask(ctx, input)
+}
+
+// ModifyTask modifies a task and invalidates the cache
+func (c *CachedOmniFocusService) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.ModifyTask(ctx, id, mod)
+}
+
+// ReorderTask moves a task among its siblings and invalidates the cache
+func (c *CachedOmniFocusService) ReorderTask(ctx context.Context, id string, pos domain.TaskPosition) (*domain.OperationResult, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.ReorderTask(ctx, id, pos)
+}
+
+// CompleteTask completes a task and invalidates the cache
+func (c *CachedOmniFocusService) CompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.CompleteTask(ctx, id)
+}
+
+// UncompleteTask reopens a task and invalidates the cache
+func (c *CachedOmniFocusService) UncompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.UncompleteTask(ctx, id)
+}
+
+// DeleteTask deletes a task and invalidates the cache
+func (c *CachedOmniFocusService) DeleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.DeleteTask(ctx, id)
+}
+
+// DropTask drops a task and invalidates the cache
+func (c *CachedOmniFocusService) DropTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.DropTask(ctx, id)
+}
+
+// CreateProject creates a project and invalidates the cache
+func (c *CachedOmniFocusService) CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.CreateProject(ctx, input)
+}
+
+// DropProject drops a project and invalidates the cache
+func (c *CachedOmniFocusService) DropProject(ctx context.Context, id string) (*domain.OperationResult, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.DropProject(ctx, id)
+}
+
+// CreateTag creates a tag and invalidates the cache
+func (c *CachedOmniFocusService) CreateTag(ctx context.Context, name, parentID string) (*domain.Tag, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.CreateTag(ctx, name, parentID)
+}
+
+// RenameTag renames a tag and invalidates the cache
+func (c *CachedOmniFocusService) RenameTag(ctx context.Context, id, name string) (*domain.Tag, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.RenameTag(ctx, id, name)
+}
+
+// DeleteTag deletes a tag and invalidates the cache
+func (c *CachedOmniFocusService) DeleteTag(ctx context.Context, id string) (*domain.OperationResult, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.DeleteTag(ctx, id)
+}
+
+// BatchModify applies a batch operation and invalidates the cache
+func (c *CachedOmniFocusService) BatchModify(ctx context.Context, ids []string, op domain.BatchOperation) (*domain.BatchResult, error) {
+	defer c.Invalidate()
+	return c.OmniFocusService.BatchModify(ctx, ids, op)
+}
+
+// GetAllTasks returns the cached unfiltered task list, fetching it when
+// missing or expired. Filtered and truncated lists are not cached.
+func (c *CachedOmniFocusService) GetAllTasks(ctx context.Context, filters TaskFilters) ([]domain.Task, error) {
+	if filters != (TaskFilters{}) {
+		return c.OmniFocusService.GetAllTasks(ctx, filters)
+	}
+
+	c.mu.Lock()
+	if c.allTasks != nil && c.now().Before(c.allTasks.expires) {
+		tasks := c.allTasks.value
+		c.mu.Unlock()
+		return tasks, nil
+	}
+	c.mu.Unlock()
+
+	tasks, err := c.OmniFocusService.GetAllTasks(ctx, filters)
+	if err != nil {
+		return tasks, err
+	}
+
+	c.mu.Lock()
+	c.allTasks = &cacheEntry[[]domain.Task]{value: tasks, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return tasks, nil
+}
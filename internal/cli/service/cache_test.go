@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Compile-time check that CachedOmniFocusService implements OmniFocusService
+var _ OmniFocusService = (*CachedOmniFocusService)(nil)
+
+// countingService wraps MockOmniFocusService and counts read calls
+type countingService struct {
+	MockOmniFocusService
+	inboxCalls     int
+	hierarchyCalls int
+	projectsCalls  int
+	tagsCalls      int
+	allTasksCalls  int
+}
+
+func (c *countingService) GetInboxTasks(ctx context.Context) ([]domain.Task, error) {
+	c.inboxCalls++
+	return c.MockOmniFocusService.GetInboxTasks(ctx)
+}
+
+func (c *countingService) GetTaskHierarchy(ctx context.Context, projectID string) ([]domain.Task, error) {
+	c.hierarchyCalls++
+	return c.MockOmniFocusService.GetTaskHierarchy(ctx, projectID)
+}
+
+func (c *countingService) GetProjects(ctx context.Context, status string) ([]domain.Project, error) {
+	c.projectsCalls++
+	return c.MockOmniFocusService.GetProjects(ctx, status)
+}
+
+func (c *countingService) GetTags(ctx context.Context) ([]domain.Tag, error) {
+	c.tagsCalls++
+	return c.MockOmniFocusService.GetTags(ctx)
+}
+
+func (c *countingService) GetAllTasks(ctx context.Context, filters TaskFilters) ([]domain.Task, error) {
+	c.allTasksCalls++
+	return c.MockOmniFocusService.GetAllTasks(ctx, filters)
+}
+
+func newTestCache(inner OmniFocusService, now *time.Time) *CachedOmniFocusService {
+	cache := NewCachedOmniFocusService(inner, time.Minute)
+	cache.now = func() time.Time { return *now }
+	return cache
+}
+
+func TestCachedService_GetInboxTasks_MemoizesWithinTTL(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	inner := &countingService{MockOmniFocusService: MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "task1", Name: "Task 1"}},
+	}}
+	cache := newTestCache(inner, &now)
+
+	for i := 0; i < 3; i++ {
+		tasks, err := cache.GetInboxTasks(context.Background())
+		if err != nil {
+			t.Fatalf("GetInboxTasks() error = %v, want nil", err)
+		}
+		if len(tasks) != 1 {
+			t.Fatalf("GetInboxTasks() returned %d tasks, want 1", len(tasks))
+		}
+	}
+
+	if inner.inboxCalls != 1 {
+		t.Errorf("inner GetInboxTasks() called %d times, want 1", inner.inboxCalls)
+	}
+}
+
+func TestCachedService_GetInboxTasks_RefetchesAfterTTL(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	inner := &countingService{}
+	cache := newTestCache(inner, &now)
+
+	_, _ = cache.GetInboxTasks(context.Background())
+	now = now.Add(2 * time.Minute)
+	_, _ = cache.GetInboxTasks(context.Background())
+
+	if inner.inboxCalls != 2 {
+		t.Errorf("inner GetInboxTasks() called %d times, want 2", inner.inboxCalls)
+	}
+}
+
+func TestCachedService_GetProjects_CachesPerStatus(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	inner := &countingService{}
+	cache := newTestCache(inner, &now)
+
+	_, _ = cache.GetProjects(context.Background(), "active")
+	_, _ = cache.GetProjects(context.Background(), "active")
+	_, _ = cache.GetProjects(context.Background(), "")
+
+	if inner.projectsCalls != 2 {
+		t.Errorf("inner GetProjects() called %d times, want 2", inner.projectsCalls)
+	}
+}
+
+func TestCachedService_GetTaskHierarchy_CachesPerProject(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	inner := &countingService{}
+	cache := newTestCache(inner, &now)
+
+	_, _ = cache.GetTaskHierarchy(context.Background(), "")
+	_, _ = cache.GetTaskHierarchy(context.Background(), "")
+	_, _ = cache.GetTaskHierarchy(context.Background(), "proj1")
+	cache.Invalidate()
+	_, _ = cache.GetTaskHierarchy(context.Background(), "")
+
+	if inner.hierarchyCalls != 3 {
+		t.Errorf("inner GetTaskHierarchy() called %d times, want 3", inner.hierarchyCalls)
+	}
+}
+
+func TestCachedService_GetAllTasks_CachesOnlyUnfiltered(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	inner := &countingService{MockOmniFocusService: MockOmniFocusService{
+		AllTasks: []domain.Task{{ID: "task1", Name: "Task 1"}},
+	}}
+	cache := newTestCache(inner, &now)
+
+	_, _ = cache.GetAllTasks(context.Background(), TaskFilters{})
+	tasks, _ := cache.GetAllTasks(context.Background(), TaskFilters{})
+	_, _ = cache.GetAllTasks(context.Background(), TaskFilters{Flagged: true})
+	_, _ = cache.GetAllTasks(context.Background(), TaskFilters{Flagged: true})
+
+	if len(tasks) != 1 || tasks[0].ID != "task1" {
+		t.Errorf("GetAllTasks() = %+v, want task1", tasks)
+	}
+	if inner.allTasksCalls != 3 {
+		t.Errorf("inner GetAllTasks() called %d times, want 3", inner.allTasksCalls)
+	}
+}
+
+func TestCachedService_GetTags_DoesNotCacheErrors(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	inner := &countingService{MockOmniFocusService: MockOmniFocusService{
+		TagsErr: errors.New("boom"),
+	}}
+	cache := newTestCache(inner, &now)
+
+	if _, err := cache.GetTags(context.Background()); err == nil {
+		t.Fatal("GetTags() error = nil, want error")
+	}
+	inner.TagsErr = nil
+	if _, err := cache.GetTags(context.Background()); err != nil {
+		t.Fatalf("GetTags() error = %v, want nil", err)
+	}
+
+	if inner.tagsCalls != 2 {
+		t.Errorf("inner GetTags() called %d times, want 2", inner.tagsCalls)
+	}
+}
+
+func TestCachedService_WriteOperations_InvalidateCache(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(c *CachedOmniFocusService)
+	}{
+		{"CreateTask", func(c *CachedOmniFocusService) {
+			_, _ = c.CreateTask(context.Background(), domain.TaskInput{Name: "New"})
+		}},
+		{"ModifyTask", func(c *CachedOmniFocusService) {
+			flagged := true
+			_, _ = c.ModifyTask(context.Background(), "task1", domain.TaskModification{Flagged: &flagged})
+		}},
+		{"CompleteTask", func(c *CachedOmniFocusService) { _, _ = c.CompleteTask(context.Background(), "task1") }},
+		{"UncompleteTask", func(c *CachedOmniFocusService) { _, _ = c.UncompleteTask(context.Background(), "task1") }},
+		{"DeleteTask", func(c *CachedOmniFocusService) { _, _ = c.DeleteTask(context.Background(), "task1") }},
+		{"DropTask", func(c *CachedOmniFocusService) { _, _ = c.DropTask(context.Background(), "task1") }},
+		{"DropProject", func(c *CachedOmniFocusService) { _, _ = c.DropProject(context.Background(), "proj1") }},
+		{"ReorderTask", func(c *CachedOmniFocusService) {
+			_, _ = c.ReorderTask(context.Background(), "task1", domain.TaskPosition{SiblingID: "task2"})
+		}},
+		{"BatchModify", func(c *CachedOmniFocusService) {
+			_, _ = c.BatchModify(context.Background(), []string{"task1"}, domain.BatchOperation{Action: domain.BatchComplete})
+		}},
+		{"CreateTag", func(c *CachedOmniFocusService) { _, _ = c.CreateTag(context.Background(), "Errands", "") }},
+		{"RenameTag", func(c *CachedOmniFocusService) { _, _ = c.RenameTag(context.Background(), "tag1", "Shopping") }},
+		{"DeleteTag", func(c *CachedOmniFocusService) { _, _ = c.DeleteTag(context.Background(), "tag1") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+			inner := &countingService{}
+			cache := newTestCache(inner, &now)
+
+			_, _ = cache.GetInboxTasks(context.Background())
+			_, _ = cache.GetTags(context.Background())
+			tt.write(cache)
+			_, _ = cache.GetInboxTasks(context.Background())
+			_, _ = cache.GetTags(context.Background())
+
+			if inner.inboxCalls != 2 {
+				t.Errorf("inner GetInboxTasks() called %d times, want 2", inner.inboxCalls)
+			}
+			if inner.tagsCalls != 2 {
+				t.Errorf("inner GetTags() called %d times, want 2", inner.tagsCalls)
+			}
+		})
+	}
+}
+
+func TestCachedService_Invalidate_ForcesRefetch(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	inner := &countingService{}
+	cache := newTestCache(inner, &now)
+
+	_, _ = cache.GetProjects(context.Background(), "")
+	cache.Invalidate()
+	_, _ = cache.GetProjects(context.Background(), "")
+
+	if inner.projectsCalls != 2 {
+		t.Errorf("inner GetProjects() called %d times, want 2", inner.projectsCalls)
+	}
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// CompleteTaskWithNote appends a closing "resolution: …" line to the task's
+// note, then completes it. A blank note completes the task unchanged.
+func CompleteTaskWithNote(ctx context.Context, svc OmniFocusService, id, note string) (*domain.OperationResult, error) {
+	if strings.TrimSpace(note) != "" {
+		task, err := svc.GetTaskByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+		text := domain.AppendResolution(task.Note, note)
+		if _, err := svc.ModifyTask(ctx, id, domain.TaskModification{Note: &text}); err != nil {
+			return nil, fmt.Errorf("failed to add closing note: %w", err)
+		}
+	}
+	return svc.CompleteTask(ctx, id)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestCompleteTaskWithNote_AppendsResolution(t *testing.T) {
+	mock := &MockOmniFocusService{
+		Task:           &domain.Task{ID: "task1", Note: "Reported by Sam"},
+		CompleteResult: &domain.OperationResult{Success: true, ID: "task1"},
+	}
+
+	result, err := CompleteTaskWithNote(context.Background(), mock, "task1", "Fixed in 2.3")
+	if err != nil {
+		t.Fatalf("CompleteTaskWithNote() error = %v", err)
+	}
+	if result.ID != "task1" {
+		t.Errorf("CompleteTaskWithNote() ID = %s, want task1", result.ID)
+	}
+	note := mock.Modifications["task1"].Note
+	if note == nil || *note != "Reported by Sam\n\nresolution: Fixed in 2.3" {
+		t.Errorf("note = %v, want the resolution appended", note)
+	}
+}
+
+func TestCompleteTaskWithNote_BlankNoteOnlyCompletes(t *testing.T) {
+	mock := &MockOmniFocusService{CompleteResult: &domain.OperationResult{Success: true, ID: "task1"}}
+
+	if _, err := CompleteTaskWithNote(context.Background(), mock, "task1", "  "); err != nil {
+		t.Fatalf("CompleteTaskWithNote() error = %v", err)
+	}
+	if len(mock.Modifications) != 0 {
+		t.Errorf("Modifications = %v, want none for a blank note", mock.Modifications)
+	}
+}
+
+func TestCompleteTaskWithNote_NoteFailureSkipsCompletion(t *testing.T) {
+	mock := &MockOmniFocusService{
+		Task:            &domain.Task{ID: "task1"},
+		ModifyTaskErr:   errors.New("boom"),
+		CompleteTaskErr: errors.New("should not complete"),
+	}
+
+	if _, err := CompleteTaskWithNote(context.Background(), mock, "task1", "Done"); err == nil || err.Error() != "failed to add closing note: boom" {
+		t.Errorf("CompleteTaskWithNote() error = %v, want the note error", err)
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
+)
+
+// LimitsOmniFocusService decorates an OmniFocusService and checks the
+// work-in-progress limits whenever a change adds tasks to a tag or project.
+// Changes past a blocking limit are refused with limits.ErrLimitReached;
+// other limits are reported to Warn once the change has been made.
+type LimitsOmniFocusService struct {
+	OmniFocusService
+
+	limits []limits.Limit
+
+	// Warn is called for each non-blocking limit a change took past its maximum
+	Warn func(limits.Usage)
+}
+
+// NewLimitsOmniFocusService wraps the given service so that changes are checked against the limits
+func NewLimitsOmniFocusService(svc OmniFocusService, limitList []limits.Limit, warn func(limits.Usage)) *LimitsOmniFocusService {
+	return &LimitsOmniFocusService{
+		OmniFocusService: svc,
+		limits:           limitList,
+		Warn:             warn,
+	}
+}
+
+// CreateTask checks the limits the new task counts towards before creating it
+func (l *LimitsOmniFocusService) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	if len(input.TagNames) == 0 && input.ProjectID == "" && input.ProjectName == "" {
+		return l.OmniFocusService.CreateTask(ctx, input)
+	}
+
+	newTask := domain.Task{ProjectID: input.ProjectID, ProjectName: input.ProjectName, Tags: input.TagNames}
+	warnings, err := l.check(ctx, func(tasks []domain.Task) []domain.Task {
+		return append(tasks, newTask)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := l.OmniFocusService.CreateTask(ctx, input)
+	if err == nil {
+		l.warn(warnings)
+	}
+	return task, err
+}
+
+// ModifyTask checks the limits before adding tags or moving the task to another project
+func (l *LimitsOmniFocusService) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
+	warnings, err := l.checkModification(ctx, []string{id}, mod)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := l.OmniFocusService.ModifyTask(ctx, id, mod)
+	if err == nil {
+		l.warn(warnings)
+	}
+	return task, err
+}
+
+// BatchModify checks the limits for the whole batch, refusing all of it when
+// a blocking limit would be exceeded
+func (l *LimitsOmniFocusService) BatchModify(ctx context.Context, ids []string, op domain.BatchOperation) (*domain.BatchResult, error) {
+	if op.Action != domain.BatchModify {
+		return l.OmniFocusService.BatchModify(ctx, ids, op)
+	}
+
+	warnings, err := l.checkModification(ctx, ids, op.Modification)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := l.OmniFocusService.BatchModify(ctx, ids, op)
+	if err == nil && result.Succeeded() > 0 {
+		l.warn(warnings)
+	}
+	return result, err
+}
+
+// checkModification checks the limits for applying mod to the given tasks
+func (l *LimitsOmniFocusService) checkModification(ctx context.Context, ids []string, mod domain.TaskModification) ([]limits.Usage, error) {
+	if len(mod.AddTags) == 0 && (mod.ProjectID == nil || *mod.ProjectID == "") {
+		return nil, nil
+	}
+
+	var projectName string
+	if mod.ProjectID != nil && *mod.ProjectID != "" {
+		// Limits may name the project rather than give its ID
+		if project, err := l.OmniFocusService.GetProjectByID(ctx, *mod.ProjectID); err == nil && project != nil {
+			projectName = project.Name
+		}
+	}
+
+	selected := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+	return l.check(ctx, func(tasks []domain.Task) []domain.Task {
+		after := make([]domain.Task, len(tasks))
+		for i, task := range tasks {
+			if selected[task.ID] {
+				task = applyLimitedFields(task, mod, projectName)
+			}
+			after[i] = task
+		}
+		return after
+	})
+}
+
+// applyLimitedFields applies the parts of mod that limits count: tags and project
+func applyLimitedFields(task domain.Task, mod domain.TaskModification, projectName string) domain.Task {
+	tags := make([]string, 0, len(task.Tags)+len(mod.AddTags))
+	for _, tag := range task.Tags {
+		if !containsTagFold(mod.RemoveTags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	for _, tag := range mod.AddTags {
+		if !containsTagFold(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	task.Tags = tags
+
+	if mod.ProjectID != nil {
+		task.ProjectID = *mod.ProjectID
+		task.ProjectName = projectName
+	}
+	return task
+}
+
+// check counts the open tasks before and after the change and returns the
+// non-blocking limits it exceeds, or an error for the first blocking one
+func (l *LimitsOmniFocusService) check(ctx context.Context, change func([]domain.Task) []domain.Task) ([]limits.Usage, error) {
+	tasks, err := l.OmniFocusService.GetAllTasks(ctx, TaskFilters{})
+	if err != nil {
+		if l.blocks() {
+			return nil, fmt.Errorf("failed to check WIP limits: %w", err)
+		}
+		// Warnings are best effort; don't fail the change over them
+		return nil, nil
+	}
+
+	before := append([]domain.Task(nil), tasks...)
+	var warnings []limits.Usage
+	for _, usage := range limits.Exceeded(l.limits, before, change(tasks)) {
+		if usage.Limit.Block {
+			return nil, fmt.Errorf("%w: %s", limits.ErrLimitReached, usage)
+		}
+		warnings = append(warnings, usage)
+	}
+	return warnings, nil
+}
+
+// blocks reports whether any limit refuses changes
+func (l *LimitsOmniFocusService) blocks() bool {
+	for _, limit := range l.limits {
+		if limit.Block {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *LimitsOmniFocusService) warn(warnings []limits.Usage) {
+	if l.Warn == nil {
+		return
+	}
+	for _, usage := range warnings {
+		l.Warn(usage)
+	}
+}
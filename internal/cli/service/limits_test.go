@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
+)
+
+// Compile-time check that LimitsOmniFocusService implements OmniFocusService
+var _ OmniFocusService = (*LimitsOmniFocusService)(nil)
+
+func newTestLimitsService(inner OmniFocusService, block bool) (*LimitsOmniFocusService, *[]limits.Usage) {
+	var warnings []limits.Usage
+	svc := NewLimitsOmniFocusService(inner, []limits.Limit{{Tag: "doing", Max: 2, Block: block}}, func(usage limits.Usage) {
+		warnings = append(warnings, usage)
+	})
+	return svc, &warnings
+}
+
+func doingTasks() []domain.Task {
+	return []domain.Task{
+		{ID: "t1", Tags: []string{"doing"}},
+		{ID: "t2", Tags: []string{"Doing"}},
+		{ID: "t3"},
+	}
+}
+
+func TestLimitsService_CreateTask_Warns(t *testing.T) {
+	inner := &MockOmniFocusService{AllTasks: doingTasks(), CreatedTask: &domain.Task{ID: "new"}}
+	svc, warnings := newTestLimitsService(inner, false)
+
+	if _, err := svc.CreateTask(context.Background(), domain.TaskInput{Name: "Write report", TagNames: []string{"doing"}}); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	if inner.CreateInput == nil {
+		t.Error("CreateTask() should create the task past a warning limit")
+	}
+	if len(*warnings) != 1 || (*warnings)[0].Count != 3 {
+		t.Errorf("warnings = %+v, want one with 3 tasks", *warnings)
+	}
+}
+
+func TestLimitsService_CreateTask_Blocks(t *testing.T) {
+	inner := &MockOmniFocusService{AllTasks: doingTasks(), CreatedTask: &domain.Task{ID: "new"}}
+	svc, warnings := newTestLimitsService(inner, true)
+
+	_, err := svc.CreateTask(context.Background(), domain.TaskInput{Name: "Write report", TagNames: []string{"doing"}})
+	if !errors.Is(err, limits.ErrLimitReached) {
+		t.Fatalf("CreateTask() error = %v, want ErrLimitReached", err)
+	}
+	if inner.CreateInput != nil {
+		t.Error("CreateTask() should not create the task past a blocking limit")
+	}
+	if len(*warnings) != 0 {
+		t.Errorf("warnings = %+v, want none when blocked", *warnings)
+	}
+}
+
+func TestLimitsService_ModifyTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		mod     domain.TaskModification
+		blocked bool
+	}{
+		{"adding the tag", "t3", domain.TaskModification{AddTags: []string{"DOING"}}, true},
+		{"tag already present", "t1", domain.TaskModification{AddTags: []string{"doing"}}, false},
+		{"other changes", "t3", domain.TaskModification{AddTags: []string{"phone"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &MockOmniFocusService{AllTasks: doingTasks(), ModifiedTask: &domain.Task{ID: tt.id}}
+			svc, _ := newTestLimitsService(inner, true)
+
+			_, err := svc.ModifyTask(context.Background(), tt.id, tt.mod)
+			if got := errors.Is(err, limits.ErrLimitReached); got != tt.blocked {
+				t.Errorf("ModifyTask() error = %v, want blocked = %v", err, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestLimitsService_ModifyTask_ProjectByName(t *testing.T) {
+	inner := &MockOmniFocusService{
+		AllTasks:     []domain.Task{{ID: "t1", ProjectID: "p1", ProjectName: "Work"}, {ID: "t2"}},
+		Project:      &domain.Project{ID: "p1", Name: "Work"},
+		ModifiedTask: &domain.Task{ID: "t2"},
+	}
+	var warnings []limits.Usage
+	svc := NewLimitsOmniFocusService(inner, []limits.Limit{{Project: "work", Max: 1}}, func(usage limits.Usage) {
+		warnings = append(warnings, usage)
+	})
+
+	projectID := "p1"
+	if _, err := svc.ModifyTask(context.Background(), "t2", domain.TaskModification{ProjectID: &projectID}); err != nil {
+		t.Fatalf("ModifyTask() error = %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Count != 2 {
+		t.Errorf("warnings = %+v, want one with 2 tasks in Work", warnings)
+	}
+}
+
+func TestLimitsService_BatchModify_Blocks(t *testing.T) {
+	inner := &MockOmniFocusService{AllTasks: doingTasks()}
+	svc, _ := newTestLimitsService(inner, true)
+
+	op := domain.BatchOperation{Action: domain.BatchModify, Modification: domain.TaskModification{AddTags: []string{"doing"}}}
+	if _, err := svc.BatchModify(context.Background(), []string{"t3"}, op); !errors.Is(err, limits.ErrLimitReached) {
+		t.Errorf("BatchModify() error = %v, want ErrLimitReached", err)
+	}
+	if inner.BatchOperation != nil {
+		t.Error("BatchModify() should not run a batch past a blocking limit")
+	}
+}
+
+func TestLimitsService_CheckFails(t *testing.T) {
+	inner := &MockOmniFocusService{AllTasksErr: errors.New("script failed"), CreatedTask: &domain.Task{ID: "new"}}
+	input := domain.TaskInput{Name: "Write report", TagNames: []string{"doing"}}
+
+	warnSvc, _ := newTestLimitsService(inner, false)
+	if _, err := warnSvc.CreateTask(context.Background(), input); err != nil {
+		t.Errorf("CreateTask() error = %v, want warnings to be best effort", err)
+	}
+
+	blockSvc, _ := newTestLimitsService(inner, true)
+	if _, err := blockSvc.CreateTask(context.Background(), input); err == nil {
+		t.Error("CreateTask() error = nil, want an error when a blocking limit cannot be checked")
+	}
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"context"
+	"time"
+
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 )
 
@@ -17,18 +20,44 @@ type MockOmniFocusService struct {
 	TagTasksErr     error
 	FlaggedTasks    []domain.Task
 	FlaggedTasksErr error
+	SearchResults   []domain.Task
+	SearchErr       error
+	SearchQuery     string // Records the query passed to SearchTasks
 	Task            *domain.Task
 	TaskErr         error
 
+	CompletedTasks    []domain.Task
+	CompletedTasksErr error
+	CompletedSince    time.Time // Records the since argument passed to GetCompletedTasks
+
 	// Tasks - Write Operations
-	CreatedTask     *domain.Task
-	CreateTaskErr   error
-	ModifiedTask    *domain.Task
-	ModifyTaskErr   error
-	CompleteResult  *domain.OperationResult
-	CompleteTaskErr error
-	DeleteResult    *domain.OperationResult
-	DeleteTaskErr   error
+	CreatedTask       *domain.Task
+	CreateTaskErr     error
+	ModifiedTask      *domain.Task
+	ModifyTaskErr     error
+	CompleteResult    *domain.OperationResult
+	CompleteTaskErr   error
+	CompletedIDs      []string // Records IDs passed to CompleteTask
+	DeletedIDs        []string // Records IDs passed to DeleteTask
+	UncompleteResult  *domain.OperationResult
+	UncompleteTaskErr error
+	DeleteResult      *domain.OperationResult
+	DeleteTaskErr     error
+	DropResult        *domain.OperationResult
+	DropTaskErr       error
+	DroppedIDs        []string // Records IDs passed to DropTask
+	ReorderResult     *domain.OperationResult
+	ReorderTaskErr    error
+	ReorderID         string               // Records the ID passed to ReorderTask
+	ReorderPosition   *domain.TaskPosition // Records the position passed to ReorderTask
+	BatchResult       *domain.BatchResult
+	BatchModifyErr    error
+	BatchIDs          []string               // Records IDs passed to BatchModify
+	BatchOperation    *domain.BatchOperation // Records operation passed to BatchModify
+
+	CreateInput   *domain.TaskInput                  // Records input passed to CreateTask
+	Modifications map[string]domain.TaskModification // Records modifications passed to ModifyTask, by task ID
+	CreatedInputs []domain.TaskInput                 // Records every input passed to CreateTask, in order
 
 	// Projects
 	Projects            []domain.Project
@@ -37,6 +66,16 @@ type MockOmniFocusService struct {
 	ProjectErr          error
 	ProjectWithTasks    *domain.Project
 	ProjectWithTasksErr error
+	CreatedProject      *domain.Project
+	CreateProjectErr    error
+	DropProjectResult   *domain.OperationResult
+	DropProjectErr      error
+	DroppedProjectIDs   []string // Records IDs passed to DropProject
+	Folders             []domain.Folder
+	FoldersErr          error
+	Attachments         []domain.Attachment
+	AttachmentsErr      error
+	AttachmentCalls     []AttachmentOptions // Records the options passed to GetAttachments
 
 	// Tags
 	Tags         []domain.Tag
@@ -46,9 +85,19 @@ type MockOmniFocusService struct {
 	TagCounts    map[string]int
 	TagCountsErr error
 
+	CreatedTag      *domain.Tag
+	CreateTagErr    error
+	RenamedTag      *domain.Tag
+	RenameTagErr    error
+	DeleteTagResult *domain.OperationResult
+	DeleteTagErr    error
+	TagNameInput    string // Records the name passed to CreateTag or RenameTag
+
 	// Perspectives
 	PerspectiveTasks    []domain.Task
 	PerspectiveTasksErr error
+	PerspectiveRules    *domain.PerspectiveRules
+	PerspectiveRulesErr error
 
 	// Helper Methods
 	ResolvedProjectID string
@@ -56,31 +105,52 @@ type MockOmniFocusService struct {
 }
 
 // GetInboxTasks returns configured inbox tasks or error
-func (m *MockOmniFocusService) GetInboxTasks() ([]domain.Task, error) {
+func (m *MockOmniFocusService) GetInboxTasks(ctx context.Context) ([]domain.Task, error) {
 	if m.InboxTasksErr != nil {
 		return nil, m.InboxTasksErr
 	}
 	return m.InboxTasks, nil
 }
 
-// GetAllTasks returns configured tasks or error
-func (m *MockOmniFocusService) GetAllTasks(filters TaskFilters) ([]domain.Task, error) {
+// GetAllTasks returns configured tasks or error.
+// Tasks are returned alongside the error so partial results can be simulated.
+func (m *MockOmniFocusService) GetAllTasks(ctx context.Context, filters TaskFilters) ([]domain.Task, error) {
 	if m.AllTasksErr != nil {
-		return nil, m.AllTasksErr
+		return m.AllTasks, m.AllTasksErr
 	}
 	return m.AllTasks, nil
 }
 
+// StreamAllTasks yields the configured tasks, then returns the configured
+// error, if any
+func (m *MockOmniFocusService) StreamAllTasks(ctx context.Context, filters TaskFilters, yield func(domain.Task) error) error {
+	for _, task := range m.AllTasks {
+		if err := yield(task); err != nil {
+			return err
+		}
+	}
+	return m.AllTasksErr
+}
+
 // GetTasksByProject returns configured project tasks or error
-func (m *MockOmniFocusService) GetTasksByProject(projectID string) ([]domain.Task, error) {
+func (m *MockOmniFocusService) GetTasksByProject(ctx context.Context, projectID string) ([]domain.Task, error) {
 	if m.ProjectTasksErr != nil {
 		return nil, m.ProjectTasksErr
 	}
 	return m.ProjectTasks, nil
 }
 
+// GetTaskHierarchy returns the configured inbox tasks, or project tasks when
+// projectID is set, or error
+func (m *MockOmniFocusService) GetTaskHierarchy(ctx context.Context, projectID string) ([]domain.Task, error) {
+	if projectID == "" {
+		return m.GetInboxTasks(ctx)
+	}
+	return m.GetTasksByProject(ctx, projectID)
+}
+
 // GetTasksByTag returns configured tag tasks or error
-func (m *MockOmniFocusService) GetTasksByTag(tagID string) ([]domain.Task, error) {
+func (m *MockOmniFocusService) GetTasksByTag(ctx context.Context, tagID string) ([]domain.Task, error) {
 	if m.TagTasksErr != nil {
 		return nil, m.TagTasksErr
 	}
@@ -88,15 +158,33 @@ func (m *MockOmniFocusService) GetTasksByTag(tagID string) ([]domain.Task, error
 }
 
 // GetFlaggedTasks returns configured flagged tasks or error
-func (m *MockOmniFocusService) GetFlaggedTasks() ([]domain.Task, error) {
+func (m *MockOmniFocusService) GetFlaggedTasks(ctx context.Context) ([]domain.Task, error) {
 	if m.FlaggedTasksErr != nil {
 		return nil, m.FlaggedTasksErr
 	}
 	return m.FlaggedTasks, nil
 }
 
+// SearchTasks records the query and returns configured search results or error
+func (m *MockOmniFocusService) SearchTasks(ctx context.Context, query string) ([]domain.Task, error) {
+	m.SearchQuery = query
+	if m.SearchErr != nil {
+		return nil, m.SearchErr
+	}
+	return m.SearchResults, nil
+}
+
+// GetCompletedTasks returns configured completed tasks or error
+func (m *MockOmniFocusService) GetCompletedTasks(ctx context.Context, since time.Time) ([]domain.Task, error) {
+	m.CompletedSince = since
+	if m.CompletedTasksErr != nil {
+		return nil, m.CompletedTasksErr
+	}
+	return m.CompletedTasks, nil
+}
+
 // GetTaskByID returns configured task or error
-func (m *MockOmniFocusService) GetTaskByID(id string) (*domain.Task, error) {
+func (m *MockOmniFocusService) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
 	if m.TaskErr != nil {
 		return nil, m.TaskErr
 	}
@@ -104,7 +192,7 @@ func (m *MockOmniFocusService) GetTaskByID(id string) (*domain.Task, error) {
 }
 
 // GetProjects returns configured projects or error
-func (m *MockOmniFocusService) GetProjects(status string) ([]domain.Project, error) {
+func (m *MockOmniFocusService) GetProjects(ctx context.Context, status string) ([]domain.Project, error) {
 	if m.ProjectsErr != nil {
 		return nil, m.ProjectsErr
 	}
@@ -112,7 +200,7 @@ func (m *MockOmniFocusService) GetProjects(status string) ([]domain.Project, err
 }
 
 // GetProjectByID returns configured project or error
-func (m *MockOmniFocusService) GetProjectByID(id string) (*domain.Project, error) {
+func (m *MockOmniFocusService) GetProjectByID(ctx context.Context, id string) (*domain.Project, error) {
 	if m.ProjectErr != nil {
 		return nil, m.ProjectErr
 	}
@@ -120,15 +208,69 @@ func (m *MockOmniFocusService) GetProjectByID(id string) (*domain.Project, error
 }
 
 // GetProjectWithTasks returns configured project with tasks or error
-func (m *MockOmniFocusService) GetProjectWithTasks(id string) (*domain.Project, error) {
+func (m *MockOmniFocusService) GetProjectWithTasks(ctx context.Context, id string) (*domain.Project, error) {
 	if m.ProjectWithTasksErr != nil {
 		return nil, m.ProjectWithTasksErr
 	}
 	return m.ProjectWithTasks, nil
 }
 
+// CreateProject returns configured created project or error
+func (m *MockOmniFocusService) CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error) {
+	if m.CreateProjectErr != nil {
+		return nil, m.CreateProjectErr
+	}
+	return m.CreatedProject, nil
+}
+
+// DropProject records the ID and returns configured drop result or error
+func (m *MockOmniFocusService) DropProject(ctx context.Context, id string) (*domain.OperationResult, error) {
+	m.DroppedProjectIDs = append(m.DroppedProjectIDs, id)
+	if m.DropProjectErr != nil {
+		return nil, m.DropProjectErr
+	}
+	return m.DropProjectResult, nil
+}
+
+// GetFolders returns configured folders or error
+func (m *MockOmniFocusService) GetFolders(ctx context.Context) ([]domain.Folder, error) {
+	if m.FoldersErr != nil {
+		return nil, m.FoldersErr
+	}
+	return m.Folders, nil
+}
+
+// GetAttachments records the options and returns configured attachments or
+// error, honoring the offset and limits the way the script does
+func (m *MockOmniFocusService) GetAttachments(ctx context.Context, projectID string, opts AttachmentOptions) ([]domain.Attachment, error) {
+	m.AttachmentCalls = append(m.AttachmentCalls, opts)
+	if m.AttachmentsErr != nil {
+		return nil, m.AttachmentsErr
+	}
+	var total int64
+	overBudget := false
+	attachments := make([]domain.Attachment, len(m.Attachments))
+	for i, a := range m.Attachments {
+		if a.Skipped == "" {
+			switch {
+			case i < opts.Offset:
+				a.Data, a.Skipped = nil, domain.AttachmentBefore
+			case opts.MaxFileBytes > 0 && a.Size > opts.MaxFileBytes:
+				a.Data, a.Skipped = nil, domain.AttachmentTooLarge
+			case overBudget || (opts.MaxTotalBytes > 0 && total > 0 && total+a.Size > opts.MaxTotalBytes):
+				a.Data, a.Skipped = nil, domain.AttachmentOverBudget
+				overBudget = true
+			default:
+				total += a.Size
+			}
+		}
+		attachments[i] = a
+	}
+	return attachments, nil
+}
+
 // GetTags returns configured tags or error
-func (m *MockOmniFocusService) GetTags() ([]domain.Tag, error) {
+func (m *MockOmniFocusService) GetTags(ctx context.Context) ([]domain.Tag, error) {
 	if m.TagsErr != nil {
 		return nil, m.TagsErr
 	}
@@ -136,7 +278,7 @@ func (m *MockOmniFocusService) GetTags() ([]domain.Tag, error) {
 }
 
 // GetTagByID returns configured tag or error
-func (m *MockOmniFocusService) GetTagByID(id string) (*domain.Tag, error) {
+func (m *MockOmniFocusService) GetTagByID(ctx context.Context, id string) (*domain.Tag, error) {
 	if m.TagErr != nil {
 		return nil, m.TagErr
 	}
@@ -144,23 +286,59 @@ func (m *MockOmniFocusService) GetTagByID(id string) (*domain.Tag, error) {
 }
 
 // GetTagCounts returns configured tag counts or error
-func (m *MockOmniFocusService) GetTagCounts() (map[string]int, error) {
+func (m *MockOmniFocusService) GetTagCounts(ctx context.Context) (map[string]int, error) {
 	if m.TagCountsErr != nil {
 		return nil, m.TagCountsErr
 	}
 	return m.TagCounts, nil
 }
 
+// CreateTag records the name and returns the configured tag or error
+func (m *MockOmniFocusService) CreateTag(ctx context.Context, name, parentID string) (*domain.Tag, error) {
+	m.TagNameInput = name
+	if m.CreateTagErr != nil {
+		return nil, m.CreateTagErr
+	}
+	return m.CreatedTag, nil
+}
+
+// RenameTag records the name and returns the configured tag or error
+func (m *MockOmniFocusService) RenameTag(ctx context.Context, id, name string) (*domain.Tag, error) {
+	m.TagNameInput = name
+	if m.RenameTagErr != nil {
+		return nil, m.RenameTagErr
+	}
+	return m.RenamedTag, nil
+}
+
+// DeleteTag returns the configured result or error
+func (m *MockOmniFocusService) DeleteTag(ctx context.Context, id string) (*domain.OperationResult, error) {
+	if m.DeleteTagErr != nil {
+		return nil, m.DeleteTagErr
+	}
+	return m.DeleteTagResult, nil
+}
+
 // GetPerspectiveTasks returns configured perspective tasks or error
-func (m *MockOmniFocusService) GetPerspectiveTasks(name string) ([]domain.Task, error) {
+func (m *MockOmniFocusService) GetPerspectiveTasks(ctx context.Context, name string) ([]domain.Task, error) {
 	if m.PerspectiveTasksErr != nil {
 		return nil, m.PerspectiveTasksErr
 	}
 	return m.PerspectiveTasks, nil
 }
 
+// GetPerspectiveRules returns configured perspective rules or error
+func (m *MockOmniFocusService) GetPerspectiveRules(ctx context.Context, name string) (*domain.PerspectiveRules, error) {
+	if m.PerspectiveRulesErr != nil {
+		return nil, m.PerspectiveRulesErr
+	}
+	return m.PerspectiveRules, nil
+}
+
 // CreateTask returns configured created task or error
-func (m *MockOmniFocusService) CreateTask(input domain.TaskInput) (*domain.Task, error) {
+func (m *MockOmniFocusService) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	m.CreateInput = &input
+	m.CreatedInputs = append(m.CreatedInputs, input)
 	if m.CreateTaskErr != nil {
 		return nil, m.CreateTaskErr
 	}
@@ -168,7 +346,11 @@ func (m *MockOmniFocusService) CreateTask(input domain.TaskInput) (*domain.Task,
 }
 
 // ModifyTask returns configured modified task or error
-func (m *MockOmniFocusService) ModifyTask(id string, mod domain.TaskModification) (*domain.Task, error) {
+func (m *MockOmniFocusService) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
+	if m.Modifications == nil {
+		m.Modifications = make(map[string]domain.TaskModification)
+	}
+	m.Modifications[id] = mod
 	if m.ModifyTaskErr != nil {
 		return nil, m.ModifyTaskErr
 	}
@@ -176,23 +358,62 @@ func (m *MockOmniFocusService) ModifyTask(id string, mod domain.TaskModification
 }
 
 // CompleteTask returns configured completion result or error
-func (m *MockOmniFocusService) CompleteTask(id string) (*domain.OperationResult, error) {
+func (m *MockOmniFocusService) CompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	m.CompletedIDs = append(m.CompletedIDs, id)
 	if m.CompleteTaskErr != nil {
 		return nil, m.CompleteTaskErr
 	}
 	return m.CompleteResult, nil
 }
 
+// UncompleteTask returns configured uncomplete result or error
+func (m *MockOmniFocusService) UncompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	if m.UncompleteTaskErr != nil {
+		return nil, m.UncompleteTaskErr
+	}
+	return m.UncompleteResult, nil
+}
+
 // DeleteTask returns configured deletion result or error
-func (m *MockOmniFocusService) DeleteTask(id string) (*domain.OperationResult, error) {
+func (m *MockOmniFocusService) DeleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	m.DeletedIDs = append(m.DeletedIDs, id)
 	if m.DeleteTaskErr != nil {
 		return nil, m.DeleteTaskErr
 	}
 	return m.DeleteResult, nil
 }
 
+// DropTask records the ID and returns configured drop result or error
+func (m *MockOmniFocusService) DropTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	m.DroppedIDs = append(m.DroppedIDs, id)
+	if m.DropTaskErr != nil {
+		return nil, m.DropTaskErr
+	}
+	return m.DropResult, nil
+}
+
+// ReorderTask records its arguments and returns configured result or error
+func (m *MockOmniFocusService) ReorderTask(ctx context.Context, id string, pos domain.TaskPosition) (*domain.OperationResult, error) {
+	m.ReorderID = id
+	m.ReorderPosition = &pos
+	if m.ReorderTaskErr != nil {
+		return nil, m.ReorderTaskErr
+	}
+	return m.ReorderResult, nil
+}
+
+// BatchModify records its arguments and returns configured batch result or error
+func (m *MockOmniFocusService) BatchModify(ctx context.Context, ids []string, op domain.BatchOperation) (*domain.BatchResult, error) {
+	m.BatchIDs = ids
+	m.BatchOperation = &op
+	if m.BatchModifyErr != nil {
+		return nil, m.BatchModifyErr
+	}
+	return m.BatchResult, nil
+}
+
 // ResolveProjectName returns configured project ID or error
-func (m *MockOmniFocusService) ResolveProjectName(name string) (string, error) {
+func (m *MockOmniFocusService) ResolveProjectName(ctx context.Context, name string) (string, error) {
 	if m.ResolveProjectErr != nil {
 		return "", m.ResolveProjectErr
 	}
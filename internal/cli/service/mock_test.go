@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -19,7 +20,7 @@ func TestMockOmniFocusService_GetInboxTasks_ReturnsConfiguredValues(t *testing.T
 		InboxTasks: expectedTasks,
 	}
 
-	tasks, err := mock.GetInboxTasks()
+	tasks, err := mock.GetInboxTasks(context.Background())
 	if err != nil {
 		t.Fatalf("GetInboxTasks() error = %v, want nil", err)
 	}
@@ -35,7 +36,7 @@ func TestMockOmniFocusService_GetInboxTasks_ReturnsConfiguredError(t *testing.T)
 		InboxTasksErr: expectedErr,
 	}
 
-	_, err := mock.GetInboxTasks()
+	_, err := mock.GetInboxTasks(context.Background())
 	if err != expectedErr {
 		t.Errorf("GetInboxTasks() error = %v, want %v", err, expectedErr)
 	}
@@ -50,7 +51,7 @@ func TestMockOmniFocusService_GetProjects_ReturnsConfiguredValues(t *testing.T)
 		Projects: expectedProjects,
 	}
 
-	projects, err := mock.GetProjects("active")
+	projects, err := mock.GetProjects(context.Background(), "active")
 	if err != nil {
 		t.Fatalf("GetProjects() error = %v, want nil", err)
 	}
@@ -70,7 +71,7 @@ func TestMockOmniFocusService_GetTaskByID_ReturnsConfiguredTask(t *testing.T) {
 		Task: expectedTask,
 	}
 
-	task, err := mock.GetTaskByID("task123")
+	task, err := mock.GetTaskByID(context.Background(), "task123")
 	if err != nil {
 		t.Fatalf("GetTaskByID() error = %v, want nil", err)
 	}
@@ -90,7 +91,7 @@ func TestMockOmniFocusService_GetTagCounts_ReturnsConfiguredCounts(t *testing.T)
 		TagCounts: expectedCounts,
 	}
 
-	counts, err := mock.GetTagCounts()
+	counts, err := mock.GetTagCounts(context.Background())
 	if err != nil {
 		t.Fatalf("GetTagCounts() error = %v, want nil", err)
 	}
@@ -115,7 +116,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetInboxTasks",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.InboxTasksErr = testErr
-				_, err := m.GetInboxTasks()
+				_, err := m.GetInboxTasks(context.Background())
 				return err
 			},
 		},
@@ -123,7 +124,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetAllTasks",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.AllTasksErr = testErr
-				_, err := m.GetAllTasks(TaskFilters{})
+				_, err := m.GetAllTasks(context.Background(), TaskFilters{})
 				return err
 			},
 		},
@@ -131,7 +132,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetTasksByProject",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.ProjectTasksErr = testErr
-				_, err := m.GetTasksByProject("proj1")
+				_, err := m.GetTasksByProject(context.Background(), "proj1")
 				return err
 			},
 		},
@@ -139,7 +140,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetTasksByTag",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.TagTasksErr = testErr
-				_, err := m.GetTasksByTag("tag1")
+				_, err := m.GetTasksByTag(context.Background(), "tag1")
 				return err
 			},
 		},
@@ -147,7 +148,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetFlaggedTasks",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.FlaggedTasksErr = testErr
-				_, err := m.GetFlaggedTasks()
+				_, err := m.GetFlaggedTasks(context.Background())
 				return err
 			},
 		},
@@ -155,7 +156,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetTaskByID",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.TaskErr = testErr
-				_, err := m.GetTaskByID("task1")
+				_, err := m.GetTaskByID(context.Background(), "task1")
 				return err
 			},
 		},
@@ -163,7 +164,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetProjects",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.ProjectsErr = testErr
-				_, err := m.GetProjects("active")
+				_, err := m.GetProjects(context.Background(), "active")
 				return err
 			},
 		},
@@ -171,7 +172,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetProjectByID",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.ProjectErr = testErr
-				_, err := m.GetProjectByID("proj1")
+				_, err := m.GetProjectByID(context.Background(), "proj1")
 				return err
 			},
 		},
@@ -179,7 +180,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetProjectWithTasks",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.ProjectWithTasksErr = testErr
-				_, err := m.GetProjectWithTasks("proj1")
+				_, err := m.GetProjectWithTasks(context.Background(), "proj1")
 				return err
 			},
 		},
@@ -187,7 +188,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetTags",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.TagsErr = testErr
-				_, err := m.GetTags()
+				_, err := m.GetTags(context.Background())
 				return err
 			},
 		},
@@ -195,7 +196,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetTagByID",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.TagErr = testErr
-				_, err := m.GetTagByID("tag1")
+				_, err := m.GetTagByID(context.Background(), "tag1")
 				return err
 			},
 		},
@@ -203,7 +204,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetTagCounts",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.TagCountsErr = testErr
-				_, err := m.GetTagCounts()
+				_, err := m.GetTagCounts(context.Background())
 				return err
 			},
 		},
@@ -211,7 +212,7 @@ func TestMockOmniFocusService_AllMethods_CanReturnErrors(t *testing.T) {
 			name: "GetPerspectiveTasks",
 			mockFunc: func(m *MockOmniFocusService) error {
 				m.PerspectiveTasksErr = testErr
-				_, err := m.GetPerspectiveTasks("Today")
+				_, err := m.GetPerspectiveTasks(context.Background(), "Today")
 				return err
 			},
 		},
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/notetemplates"
+)
+
+// NoteTemplateOmniFocusService decorates an OmniFocusService and fills in
+// the configured default note of every task it creates without one.
+type NoteTemplateOmniFocusService struct {
+	OmniFocusService
+
+	templates *notetemplates.Set
+	source    string
+}
+
+// NewNoteTemplateOmniFocusService wraps the given service so that created tasks get
+// their default note; source is exposed to the templates as {{.Source}}
+func NewNoteTemplateOmniFocusService(svc OmniFocusService, templates *notetemplates.Set, source string) *NoteTemplateOmniFocusService {
+	return &NoteTemplateOmniFocusService{
+		OmniFocusService: svc,
+		templates:        templates,
+		source:           source,
+	}
+}
+
+// CreateTask applies the first matching note template before creating the task
+func (n *NoteTemplateOmniFocusService) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	input, err := n.templates.Apply(input, n.source)
+	if err != nil {
+		return nil, err
+	}
+	return n.OmniFocusService.CreateTask(ctx, input)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/notetemplates"
+)
+
+// Compile-time check that NoteTemplateOmniFocusService implements OmniFocusService
+var _ OmniFocusService = (*NoteTemplateOmniFocusService)(nil)
+
+func TestNoteTemplateService_CreateTask_AppliesNote(t *testing.T) {
+	templates, err := notetemplates.New([]config.NoteTemplateConfig{{Tag: "bug", Note: "Steps:\nSource: {{.Source}}"}})
+	if err != nil {
+		t.Fatalf("notetemplates.New() error = %v", err)
+	}
+	inner := &MockOmniFocusService{CreatedTask: &domain.Task{ID: "task1"}}
+	svc := NewNoteTemplateOmniFocusService(inner, templates, notetemplates.SourceTUI)
+
+	if _, err := svc.CreateTask(context.Background(), domain.TaskInput{Name: "Crash", TagNames: []string{"bug"}}); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	if inner.CreateInput == nil || !strings.HasSuffix(inner.CreateInput.Note, "Source: tui") {
+		t.Errorf("CreateTask() input = %+v, want note from template", inner.CreateInput)
+	}
+}
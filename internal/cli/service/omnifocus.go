@@ -4,63 +4,133 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/bridge"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/log"
 )
 
 // TaskFilters defines filtering criteria for task queries
 type TaskFilters struct {
-	Inbox     bool
-	ProjectID string
-	TagID     string
-	Flagged   bool
-	DueStart  *time.Time
-	DueEnd    *time.Time
-	Completed bool
+	Inbox      bool
+	ProjectID  string
+	TagID      string
+	Flagged    bool
+	DueStart   *time.Time // Due at or after
+	DueEnd     *time.Time // Due before
+	DeferStart *time.Time // Deferred until at or after
+	DeferEnd   *time.Time // Deferred until before
+	Completed  bool
 }
 
-// OmniFocusService defines the interface for interacting with OmniFocus
+// MatchesDates reports whether a task's due and defer dates, its own or
+// inherited from its project or parent, fall within the date bounds of the
+// filters. A task without a date never matches a bound on it.
+func (f TaskFilters) MatchesDates(task domain.Task) bool {
+	return withinBounds(task.EffectiveDue(), f.DueStart, f.DueEnd) &&
+		withinBounds(task.EffectiveDefer(), f.DeferStart, f.DeferEnd)
+}
+
+// withinBounds reports whether date is in [start, end), where nil bounds are open
+func withinBounds(date, start, end *time.Time) bool {
+	if start == nil && end == nil {
+		return true
+	}
+	if date == nil {
+		return false
+	}
+	return (start == nil || !date.Before(*start)) && (end == nil || date.Before(*end))
+}
+
+// OmniFocusService defines the interface for interacting with OmniFocus.
+// Each call stops the script it is waiting on once ctx is canceled.
 type OmniFocusService interface {
 	// Tasks - Read Operations
-	GetInboxTasks() ([]domain.Task, error)
-	GetAllTasks(filters TaskFilters) ([]domain.Task, error)
-	GetTasksByProject(projectID string) ([]domain.Task, error)
-	GetTasksByTag(tagID string) ([]domain.Task, error)
-	GetFlaggedTasks() ([]domain.Task, error)
-	GetTaskByID(id string) (*domain.Task, error)
+	GetInboxTasks(ctx context.Context) ([]domain.Task, error)
+	GetAllTasks(ctx context.Context, filters TaskFilters) ([]domain.Task, error)
+	StreamAllTasks(ctx context.Context, filters TaskFilters, yield func(domain.Task) error) error
+	GetTasksByProject(ctx context.Context, projectID string) ([]domain.Task, error)
+	GetTasksByTag(ctx context.Context, tagID string) ([]domain.Task, error)
+	GetFlaggedTasks(ctx context.Context) ([]domain.Task, error)
+	GetCompletedTasks(ctx context.Context, since time.Time) ([]domain.Task, error)
+	GetTaskByID(ctx context.Context, id string) (*domain.Task, error)
+	GetTaskHierarchy(ctx context.Context, projectID string) ([]domain.Task, error)
+	SearchTasks(ctx context.Context, query string) ([]domain.Task, error)
 
 	// Tasks - Write Operations
-	CreateTask(input domain.TaskInput) (*domain.Task, error)
-	ModifyTask(id string, mod domain.TaskModification) (*domain.Task, error)
-	CompleteTask(id string) (*domain.OperationResult, error)
-	DeleteTask(id string) (*domain.OperationResult, error)
+	CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error)
+	ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error)
+	CompleteTask(ctx context.Context, id string) (*domain.OperationResult, error)
+	UncompleteTask(ctx context.Context, id string) (*domain.OperationResult, error)
+	DeleteTask(ctx context.Context, id string) (*domain.OperationResult, error)
+	DropTask(ctx context.Context, id string) (*domain.OperationResult, error)
+	ReorderTask(ctx context.Context, id string, pos domain.TaskPosition) (*domain.OperationResult, error)
+	BatchModify(ctx context.Context, ids []string, op domain.BatchOperation) (*domain.BatchResult, error)
 
 	// Projects
-	GetProjects(status string) ([]domain.Project, error)
-	GetProjectByID(id string) (*domain.Project, error)
-	GetProjectWithTasks(id string) (*domain.Project, error)
+	GetProjects(ctx context.Context, status string) ([]domain.Project, error)
+	GetProjectByID(ctx context.Context, id string) (*domain.Project, error)
+	GetProjectWithTasks(ctx context.Context, id string) (*domain.Project, error)
+	CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error)
+	DropProject(ctx context.Context, id string) (*domain.OperationResult, error)
+	GetFolders(ctx context.Context) ([]domain.Folder, error)
+	GetAttachments(ctx context.Context, projectID string, opts AttachmentOptions) ([]domain.Attachment, error)
 
 	// Tags
-	GetTags() ([]domain.Tag, error)
-	GetTagByID(id string) (*domain.Tag, error)
-	GetTagCounts() (map[string]int, error)
+	GetTags(ctx context.Context) ([]domain.Tag, error)
+	GetTagByID(ctx context.Context, id string) (*domain.Tag, error)
+	GetTagCounts(ctx context.Context) (map[string]int, error)
+	CreateTag(ctx context.Context, name, parentID string) (*domain.Tag, error)
+	RenameTag(ctx context.Context, id, name string) (*domain.Tag, error)
+	DeleteTag(ctx context.Context, id string) (*domain.OperationResult, error)
 
 	// Perspectives
-	GetPerspectiveTasks(name string) ([]domain.Task, error)
+	GetPerspectiveTasks(ctx context.Context, name string) ([]domain.Task, error)
+	GetPerspectiveRules(ctx context.Context, name string) (*domain.PerspectiveRules, error)
 
 	// Helper Methods
-	ResolveProjectName(name string) (string, error)
+	ResolveProjectName(ctx context.Context, name string) (string, error)
+}
+
+// AttachmentOptions bound how much file data one GetAttachments call returns;
+// files left out are listed with the reason in Attachment.Skipped
+type AttachmentOptions struct {
+	Offset        int   // Attachments before this index come without data
+	MaxFileBytes  int64 // Larger files come without data; zero for no limit
+	MaxTotalBytes int64 // Data stops once this much is returned; zero for no limit
+}
+
+// Pagination settings used when a full task fetch exceeds the executor payload limit
+const (
+	DefaultTaskPageSize = 500
+	DefaultMaxTasks     = 10000
+)
+
+// TruncatedError is returned alongside partial results when a task list was
+// too large to load in full. Callers can detect it with errors.As and still
+// use the returned tasks.
+type TruncatedError struct {
+	Returned int
+	Total    int
+}
+
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("result truncated: showing %d of %d tasks", e.Returned, e.Total)
 }
 
 // DefaultOmniFocusService implements OmniFocusService using the bridge layer
 type DefaultOmniFocusService struct {
 	executor bridge.Executor
 	timeout  time.Duration
+	pageSize int
+	maxTasks int
 }
 
 // NewOmniFocusService creates a new OmniFocusService instance
@@ -68,17 +138,49 @@ func NewOmniFocusService(executor bridge.Executor, timeout time.Duration) *Defau
 	return &DefaultOmniFocusService{
 		executor: executor,
 		timeout:  timeout,
+		pageSize: DefaultTaskPageSize,
+		maxTasks: DefaultMaxTasks,
 	}
 }
 
+// execute runs a script loaded from the named template with params, recording
+// the call in the debug log. Only scripts reading OmniFocus are retried after
+// a transient failure; one changing it may already have been applied.
+func (s *DefaultOmniFocusService) execute(ctx context.Context, name string, params map[string]string, script string) (string, error) {
+	logger := log.Logger()
+	logger.Debug("running script", "script", name, "params", params)
+
+	if !isReadScript(name) {
+		ctx = bridge.WithoutRetry(ctx)
+	}
+
+	start := time.Now()
+	output, err := s.executor.ExecuteWithTimeout(ctx, script, s.timeout)
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.Debug("script failed", "script", name, "duration", duration, "error", err)
+		return output, err
+	}
+	if log.Enabled() {
+		logger.Debug("script finished", "script", name, "duration", duration, "bytes", len(output), "output", log.Truncate(output))
+	}
+	return output, nil
+}
+
+// isReadScript reports whether the named script only reads OmniFocus
+func isReadScript(name string) bool {
+	return strings.HasPrefix(name, "get_") || strings.HasPrefix(name, "search_")
+}
+
 // GetInboxTasks retrieves all tasks from the OmniFocus inbox
-func (s *DefaultOmniFocusService) GetInboxTasks() ([]domain.Task, error) {
+func (s *DefaultOmniFocusService) GetInboxTasks(ctx context.Context) ([]domain.Task, error) {
 	script, err := bridge.GetScript("get_inbox_tasks")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load inbox tasks script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_inbox_tasks", nil, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute inbox tasks script: %w", err)
 	}
@@ -91,28 +193,108 @@ func (s *DefaultOmniFocusService) GetInboxTasks() ([]domain.Task, error) {
 	return tasks, nil
 }
 
-// GetAllTasks retrieves all tasks matching the provided filters
-func (s *DefaultOmniFocusService) GetAllTasks(filters TaskFilters) ([]domain.Task, error) {
+// GetAllTasks retrieves all tasks matching the provided filters.
+// If the full result exceeds the executor payload limit, tasks are fetched in
+// pages instead; when even that hits the task cap, the partial list is returned
+// together with a *TruncatedError.
+func (s *DefaultOmniFocusService) GetAllTasks(ctx context.Context, filters TaskFilters) ([]domain.Task, error) {
+	tasks := []domain.Task{}
+	err := s.StreamAllTasks(ctx, filters, func(task domain.Task) error {
+		tasks = append(tasks, task)
+		return nil
+	})
+	var truncated *TruncatedError
+	if err != nil && !errors.As(err, &truncated) {
+		return nil, err
+	}
+	return tasks, err
+}
+
+// StreamAllTasks hands each task GetAllTasks would return to yield as it is
+// decoded from the script output, without building the whole list. Each
+// script's output is still read in full first. An error from yield stops the
+// stream and is returned; a *TruncatedError follows the tasks yielded when
+// the task cap was hit.
+func (s *DefaultOmniFocusService) StreamAllTasks(ctx context.Context, filters TaskFilters, yield func(domain.Task) error) error {
 	script, err := bridge.GetScript("get_all_tasks")
 	if err != nil {
-		return nil, fmt.Errorf("failed to load tasks script: %w", err)
+		return fmt.Errorf("failed to load tasks script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_all_tasks", nil, script)
+	if errors.Is(err, bridge.ErrPayloadTooLarge) {
+		return s.streamAllTasksPaginated(ctx, yield)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute tasks script: %w", err)
+		return fmt.Errorf("failed to execute tasks script: %w", err)
 	}
 
-	tasks, err := bridge.ParseTasks(output)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse tasks: %w", err)
+	_, err = streamTasks(output, yield)
+	return err
+}
+
+// streamAllTasksPaginated fetches all tasks page by page, stopping at maxTasks
+func (s *DefaultOmniFocusService) streamAllTasksPaginated(ctx context.Context, yield func(domain.Task) error) error {
+	var streamed int
+
+	for offset := 0; offset < s.maxTasks; offset += s.pageSize {
+		limit := min(s.pageSize, s.maxTasks-offset)
+		params := map[string]string{
+			"Offset": strconv.Itoa(offset),
+			"Limit":  strconv.Itoa(limit),
+		}
+
+		script, err := bridge.GetScriptWithParams("get_all_tasks", params)
+		if err != nil {
+			return fmt.Errorf("failed to load tasks script: %w", err)
+		}
+
+		output, err := s.execute(ctx, "get_all_tasks", params, script)
+		if err != nil {
+			return fmt.Errorf("failed to execute paginated tasks script: %w", err)
+		}
+
+		page := 0
+		total, err := streamTasks(output, func(task domain.Task) error {
+			page++
+			return yield(task)
+		})
+		if err != nil {
+			return err
+		}
+		streamed += page
+
+		if page == 0 || streamed >= total {
+			return nil
+		}
+		if streamed >= s.maxTasks {
+			return &TruncatedError{Returned: streamed, Total: total}
+		}
 	}
 
-	return tasks, nil
+	return nil
+}
+
+// streamTasks streams the tasks of a script's output to yield, returning the
+// total a paginated script reported. Errors from yield are returned as they
+// are; parse errors are wrapped.
+func streamTasks(output string, yield func(domain.Task) error) (int, error) {
+	var yieldErr error
+	total, err := bridge.StreamTasksPage(strings.NewReader(output), func(task domain.Task) error {
+		yieldErr = yield(task)
+		return yieldErr
+	})
+	if yieldErr != nil {
+		return total, yieldErr
+	}
+	if err != nil {
+		return total, fmt.Errorf("failed to parse tasks: %w", err)
+	}
+	return total, nil
 }
 
 // GetTasksByProject retrieves all tasks for a specific project
-func (s *DefaultOmniFocusService) GetTasksByProject(projectID string) ([]domain.Task, error) {
+func (s *DefaultOmniFocusService) GetTasksByProject(ctx context.Context, projectID string) ([]domain.Task, error) {
 	params := map[string]string{
 		"ProjectID": projectID,
 	}
@@ -122,7 +304,7 @@ func (s *DefaultOmniFocusService) GetTasksByProject(projectID string) ([]domain.
 		return nil, fmt.Errorf("failed to load project tasks script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_tasks_by_project", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute project tasks script: %w", err)
 	}
@@ -135,8 +317,37 @@ func (s *DefaultOmniFocusService) GetTasksByProject(projectID string) ([]domain.
 	return tasks, nil
 }
 
+// GetTaskHierarchy retrieves the top-level tasks of a project, or of the inbox
+// when projectID is empty, with their subtasks nested under Children
+func (s *DefaultOmniFocusService) GetTaskHierarchy(ctx context.Context, projectID string) ([]domain.Task, error) {
+	var script string
+	var params map[string]string
+	var err error
+	if projectID == "" {
+		script, err = bridge.GetScript("get_task_hierarchy")
+	} else {
+		params = map[string]string{"ProjectID": projectID}
+		script, err = bridge.GetScriptWithParams("get_task_hierarchy", params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task hierarchy script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "get_task_hierarchy", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute task hierarchy script: %w", err)
+	}
+
+	tasks, err := bridge.ParseTasks(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse task hierarchy: %w", err)
+	}
+
+	return tasks, nil
+}
+
 // GetTasksByTag retrieves all tasks with a specific tag
-func (s *DefaultOmniFocusService) GetTasksByTag(tagID string) ([]domain.Task, error) {
+func (s *DefaultOmniFocusService) GetTasksByTag(ctx context.Context, tagID string) ([]domain.Task, error) {
 	params := map[string]string{
 		"TagID": tagID,
 	}
@@ -146,7 +357,7 @@ func (s *DefaultOmniFocusService) GetTasksByTag(tagID string) ([]domain.Task, er
 		return nil, fmt.Errorf("failed to load tag tasks script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_tasks_by_tag", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute tag tasks script: %w", err)
 	}
@@ -160,13 +371,13 @@ func (s *DefaultOmniFocusService) GetTasksByTag(tagID string) ([]domain.Task, er
 }
 
 // GetFlaggedTasks retrieves all flagged tasks
-func (s *DefaultOmniFocusService) GetFlaggedTasks() ([]domain.Task, error) {
+func (s *DefaultOmniFocusService) GetFlaggedTasks(ctx context.Context) ([]domain.Task, error) {
 	script, err := bridge.GetScript("get_flagged_tasks")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load flagged tasks script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_flagged_tasks", nil, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute flagged tasks script: %w", err)
 	}
@@ -179,8 +390,57 @@ func (s *DefaultOmniFocusService) GetFlaggedTasks() ([]domain.Task, error) {
 	return tasks, nil
 }
 
+// SearchTasks retrieves the remaining tasks anywhere in the database whose
+// name or note contains query, ignoring case
+func (s *DefaultOmniFocusService) SearchTasks(ctx context.Context, query string) ([]domain.Task, error) {
+	params := map[string]string{
+		"Query": query,
+	}
+
+	script, err := bridge.GetScriptWithParams("search_tasks", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load search tasks script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "search_tasks", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute search tasks script: %w", err)
+	}
+
+	tasks, err := bridge.ParseTasks(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse search results: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// GetCompletedTasks retrieves tasks completed on or after the given day
+func (s *DefaultOmniFocusService) GetCompletedTasks(ctx context.Context, since time.Time) ([]domain.Task, error) {
+	params := map[string]string{
+		"Since": since.Format("2006-01-02"),
+	}
+
+	script, err := bridge.GetScriptWithParams("get_completed_tasks", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load completed tasks script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "get_completed_tasks", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute completed tasks script: %w", err)
+	}
+
+	tasks, err := bridge.ParseTasks(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse completed tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
 // GetTaskByID retrieves a single task by its ID
-func (s *DefaultOmniFocusService) GetTaskByID(id string) (*domain.Task, error) {
+func (s *DefaultOmniFocusService) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
 	params := map[string]string{
 		"TaskID": id,
 	}
@@ -190,7 +450,7 @@ func (s *DefaultOmniFocusService) GetTaskByID(id string) (*domain.Task, error) {
 		return nil, fmt.Errorf("failed to load task script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_task_by_id", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute task script: %w", err)
 	}
@@ -201,20 +461,20 @@ func (s *DefaultOmniFocusService) GetTaskByID(id string) (*domain.Task, error) {
 	}
 
 	if task == nil {
-		return nil, fmt.Errorf("task not found: %s", id)
+		return nil, bridge.NewNotFoundError("task", id)
 	}
 
 	return task, nil
 }
 
 // GetProjects retrieves projects filtered by status
-func (s *DefaultOmniFocusService) GetProjects(status string) ([]domain.Project, error) {
+func (s *DefaultOmniFocusService) GetProjects(ctx context.Context, status string) ([]domain.Project, error) {
 	script, err := bridge.GetScript("get_projects")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load projects script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_projects", nil, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute projects script: %w", err)
 	}
@@ -228,7 +488,7 @@ func (s *DefaultOmniFocusService) GetProjects(status string) ([]domain.Project,
 }
 
 // GetProjectByID retrieves a single project by its ID
-func (s *DefaultOmniFocusService) GetProjectByID(id string) (*domain.Project, error) {
+func (s *DefaultOmniFocusService) GetProjectByID(ctx context.Context, id string) (*domain.Project, error) {
 	params := map[string]string{
 		"ProjectID": id,
 	}
@@ -238,7 +498,7 @@ func (s *DefaultOmniFocusService) GetProjectByID(id string) (*domain.Project, er
 		return nil, fmt.Errorf("failed to load project script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_project_by_id", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute project script: %w", err)
 	}
@@ -249,14 +509,14 @@ func (s *DefaultOmniFocusService) GetProjectByID(id string) (*domain.Project, er
 	}
 
 	if project == nil {
-		return nil, fmt.Errorf("project not found: %s", id)
+		return nil, bridge.NewNotFoundError("project", id)
 	}
 
 	return project, nil
 }
 
 // GetProjectWithTasks retrieves a project with all its tasks
-func (s *DefaultOmniFocusService) GetProjectWithTasks(id string) (*domain.Project, error) {
+func (s *DefaultOmniFocusService) GetProjectWithTasks(ctx context.Context, id string) (*domain.Project, error) {
 	params := map[string]string{
 		"ProjectID": id,
 	}
@@ -266,7 +526,7 @@ func (s *DefaultOmniFocusService) GetProjectWithTasks(id string) (*domain.Projec
 		return nil, fmt.Errorf("failed to load project script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_project_with_tasks", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute project script: %w", err)
 	}
@@ -277,20 +537,68 @@ func (s *DefaultOmniFocusService) GetProjectWithTasks(id string) (*domain.Projec
 	}
 
 	if project == nil {
-		return nil, fmt.Errorf("project not found: %s", id)
+		return nil, bridge.NewNotFoundError("project", id)
 	}
 
 	return project, nil
 }
 
+// GetFolders retrieves the folder tree from OmniFocus
+func (s *DefaultOmniFocusService) GetFolders(ctx context.Context) ([]domain.Folder, error) {
+	script, err := bridge.GetScript("get_folders")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load folders script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "get_folders", nil, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute folders script: %w", err)
+	}
+
+	folders, err := bridge.ParseFolders(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse folders: %w", err)
+	}
+
+	return folders, nil
+}
+
+// GetAttachments retrieves the files attached to the tasks of a project,
+// within the size limits of opts
+func (s *DefaultOmniFocusService) GetAttachments(ctx context.Context, projectID string, opts AttachmentOptions) ([]domain.Attachment, error) {
+	params := map[string]string{
+		"ProjectID":     projectID,
+		"Offset":        strconv.Itoa(opts.Offset),
+		"MaxFileBytes":  strconv.FormatInt(opts.MaxFileBytes, 10),
+		"MaxTotalBytes": strconv.FormatInt(opts.MaxTotalBytes, 10),
+	}
+
+	script, err := bridge.GetScriptWithParams("get_attachments", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attachments script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "get_attachments", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute attachments script: %w", err)
+	}
+
+	attachments, err := bridge.ParseAttachments(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
 // GetTags retrieves all tags from OmniFocus
-func (s *DefaultOmniFocusService) GetTags() ([]domain.Tag, error) {
+func (s *DefaultOmniFocusService) GetTags(ctx context.Context) ([]domain.Tag, error) {
 	script, err := bridge.GetScript("get_tags")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tags script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_tags", nil, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute tags script: %w", err)
 	}
@@ -304,7 +612,7 @@ func (s *DefaultOmniFocusService) GetTags() ([]domain.Tag, error) {
 }
 
 // GetTagByID retrieves a single tag by its ID
-func (s *DefaultOmniFocusService) GetTagByID(id string) (*domain.Tag, error) {
+func (s *DefaultOmniFocusService) GetTagByID(ctx context.Context, id string) (*domain.Tag, error) {
 	params := map[string]string{
 		"TagID": id,
 	}
@@ -314,7 +622,7 @@ func (s *DefaultOmniFocusService) GetTagByID(id string) (*domain.Tag, error) {
 		return nil, fmt.Errorf("failed to load tag script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_tag_by_id", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute tag script: %w", err)
 	}
@@ -328,13 +636,13 @@ func (s *DefaultOmniFocusService) GetTagByID(id string) (*domain.Tag, error) {
 }
 
 // GetTagCounts retrieves the count of tasks for each tag
-func (s *DefaultOmniFocusService) GetTagCounts() (map[string]int, error) {
+func (s *DefaultOmniFocusService) GetTagCounts(ctx context.Context) (map[string]int, error) {
 	script, err := bridge.GetScript("get_tag_counts")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tag counts script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_tag_counts", nil, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute tag counts script: %w", err)
 	}
@@ -347,8 +655,100 @@ func (s *DefaultOmniFocusService) GetTagCounts() (map[string]int, error) {
 	return counts, nil
 }
 
+// CreateTag creates a tag, nested under parentID when it is not empty
+func (s *DefaultOmniFocusService) CreateTag(ctx context.Context, name, parentID string) (*domain.Tag, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("tag name is required")
+	}
+
+	params := map[string]string{
+		"Name": name,
+	}
+	if parentID != "" {
+		params["ParentTagID"] = parentID
+	}
+
+	script, err := bridge.GetScriptWithParams("create_tag", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load create tag script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "create_tag", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create tag script: %w", err)
+	}
+
+	tag, err := bridge.ParseTag(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse created tag: %w", err)
+	}
+
+	if tag == nil {
+		return nil, fmt.Errorf("failed to create tag")
+	}
+
+	return tag, nil
+}
+
+// RenameTag changes the name of a tag
+func (s *DefaultOmniFocusService) RenameTag(ctx context.Context, id, name string) (*domain.Tag, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("tag name is required")
+	}
+
+	params := map[string]string{
+		"TagID": id,
+		"Name":  name,
+	}
+
+	script, err := bridge.GetScriptWithParams("rename_tag", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rename tag script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "rename_tag", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute rename tag script: %w", err)
+	}
+
+	tag, err := bridge.ParseTag(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse renamed tag: %w", err)
+	}
+
+	if tag == nil {
+		return nil, fmt.Errorf("failed to rename tag")
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes a tag together with its child tags
+func (s *DefaultOmniFocusService) DeleteTag(ctx context.Context, id string) (*domain.OperationResult, error) {
+	params := map[string]string{
+		"TagID": id,
+	}
+
+	script, err := bridge.GetScriptWithParams("delete_tag", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load delete tag script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "delete_tag", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute delete tag script: %w", err)
+	}
+
+	result, err := bridge.ParseOperationResult(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tag deletion result: %w", err)
+	}
+
+	return result, nil
+}
+
 // GetPerspectiveTasks retrieves tasks from a named perspective
-func (s *DefaultOmniFocusService) GetPerspectiveTasks(name string) ([]domain.Task, error) {
+func (s *DefaultOmniFocusService) GetPerspectiveTasks(ctx context.Context, name string) ([]domain.Task, error) {
 	params := map[string]string{
 		"PerspectiveName": name,
 	}
@@ -358,7 +758,7 @@ func (s *DefaultOmniFocusService) GetPerspectiveTasks(name string) ([]domain.Tas
 		return nil, fmt.Errorf("failed to load perspective tasks script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "get_perspective_tasks", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute perspective tasks script: %w", err)
 	}
@@ -371,8 +771,36 @@ func (s *DefaultOmniFocusService) GetPerspectiveTasks(name string) ([]domain.Tas
 	return tasks, nil
 }
 
+// GetPerspectiveRules retrieves the filter rules of a custom perspective
+func (s *DefaultOmniFocusService) GetPerspectiveRules(ctx context.Context, name string) (*domain.PerspectiveRules, error) {
+	params := map[string]string{
+		"PerspectiveName": name,
+	}
+
+	script, err := bridge.GetScriptWithParams("get_perspective_rules", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load perspective rules script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "get_perspective_rules", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute perspective rules script: %w", err)
+	}
+
+	rules, err := bridge.ParsePerspectiveRules(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse perspective rules: %w", err)
+	}
+
+	if rules == nil {
+		return nil, bridge.NewNotFoundError("perspective", name)
+	}
+
+	return rules, nil
+}
+
 // CreateTask creates a new task in OmniFocus
-func (s *DefaultOmniFocusService) CreateTask(input domain.TaskInput) (*domain.Task, error) {
+func (s *DefaultOmniFocusService) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid task input: %w", err)
 	}
@@ -384,7 +812,7 @@ func (s *DefaultOmniFocusService) CreateTask(input domain.TaskInput) (*domain.Ta
 		return nil, fmt.Errorf("failed to load create task script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "create_task", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute create task script: %w", err)
 	}
@@ -401,8 +829,38 @@ func (s *DefaultOmniFocusService) CreateTask(input domain.TaskInput) (*domain.Ta
 	return task, nil
 }
 
+// CreateProject creates a new top-level project in OmniFocus
+func (s *DefaultOmniFocusService) CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error) {
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid project input: %w", err)
+	}
+
+	params := buildCreateProjectParams(input)
+
+	script, err := bridge.GetScriptWithParams("create_project", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load create project script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "create_project", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create project script: %w", err)
+	}
+
+	project, err := bridge.ParseProject(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse created project: %w", err)
+	}
+
+	if project == nil {
+		return nil, fmt.Errorf("failed to create project")
+	}
+
+	return project, nil
+}
+
 // ModifyTask modifies an existing task in OmniFocus
-func (s *DefaultOmniFocusService) ModifyTask(id string, mod domain.TaskModification) (*domain.Task, error) {
+func (s *DefaultOmniFocusService) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
 	if mod.IsEmpty() {
 		return nil, fmt.Errorf("no modifications specified")
 	}
@@ -414,7 +872,7 @@ func (s *DefaultOmniFocusService) ModifyTask(id string, mod domain.TaskModificat
 		return nil, fmt.Errorf("failed to load modify task script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "modify_task", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute modify task script: %w", err)
 	}
@@ -425,14 +883,14 @@ func (s *DefaultOmniFocusService) ModifyTask(id string, mod domain.TaskModificat
 	}
 
 	if task == nil {
-		return nil, fmt.Errorf("task not found: %s", id)
+		return nil, bridge.NewNotFoundError("task", id)
 	}
 
 	return task, nil
 }
 
 // CompleteTask marks a task as complete in OmniFocus
-func (s *DefaultOmniFocusService) CompleteTask(id string) (*domain.OperationResult, error) {
+func (s *DefaultOmniFocusService) CompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
 	params := map[string]string{
 		"TaskID": id,
 	}
@@ -442,7 +900,7 @@ func (s *DefaultOmniFocusService) CompleteTask(id string) (*domain.OperationResu
 		return nil, fmt.Errorf("failed to load complete task script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "complete_task", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute complete task script: %w", err)
 	}
@@ -455,8 +913,62 @@ func (s *DefaultOmniFocusService) CompleteTask(id string) (*domain.OperationResu
 	return result, nil
 }
 
+// UncompleteTask reopens a completed task
+func (s *DefaultOmniFocusService) UncompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	params := map[string]string{
+		"TaskID": id,
+	}
+
+	script, err := bridge.GetScriptWithParams("uncomplete_task", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load uncomplete task script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "uncomplete_task", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute uncomplete task script: %w", err)
+	}
+
+	result, err := bridge.ParseOperationResult(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse uncomplete result: %w", err)
+	}
+
+	return result, nil
+}
+
+// ReorderTask moves a task directly before or after one of its siblings
+func (s *DefaultOmniFocusService) ReorderTask(ctx context.Context, id string, pos domain.TaskPosition) (*domain.OperationResult, error) {
+	position := "before"
+	if pos.After {
+		position = "after"
+	}
+	params := map[string]string{
+		"TaskID":    id,
+		"SiblingID": pos.SiblingID,
+		"Position":  position,
+	}
+
+	script, err := bridge.GetScriptWithParams("reorder_task", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reorder task script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "reorder_task", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute reorder task script: %w", err)
+	}
+
+	result, err := bridge.ParseOperationResult(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reorder result: %w", err)
+	}
+
+	return result, nil
+}
+
 // DeleteTask deletes a task from OmniFocus
-func (s *DefaultOmniFocusService) DeleteTask(id string) (*domain.OperationResult, error) {
+func (s *DefaultOmniFocusService) DeleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
 	params := map[string]string{
 		"TaskID": id,
 	}
@@ -466,7 +978,7 @@ func (s *DefaultOmniFocusService) DeleteTask(id string) (*domain.OperationResult
 		return nil, fmt.Errorf("failed to load delete task script: %w", err)
 	}
 
-	output, err := s.executor.ExecuteWithTimeout(script, s.timeout)
+	output, err := s.execute(ctx, "delete_task", params, script)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute delete task script: %w", err)
 	}
@@ -479,9 +991,94 @@ func (s *DefaultOmniFocusService) DeleteTask(id string) (*domain.OperationResult
 	return result, nil
 }
 
+// DropTask marks a task as dropped; unlike DeleteTask it stays in the database
+func (s *DefaultOmniFocusService) DropTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	params := map[string]string{
+		"TaskID": id,
+	}
+
+	script, err := bridge.GetScriptWithParams("drop_task", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load drop task script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "drop_task", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute drop task script: %w", err)
+	}
+
+	result, err := bridge.ParseOperationResult(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse drop result: %w", err)
+	}
+
+	return result, nil
+}
+
+// DropProject marks a project, and with it its remaining tasks, as dropped
+func (s *DefaultOmniFocusService) DropProject(ctx context.Context, id string) (*domain.OperationResult, error) {
+	params := map[string]string{
+		"ProjectID": id,
+	}
+
+	script, err := bridge.GetScriptWithParams("drop_project", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load drop project script: %w", err)
+	}
+
+	output, err := s.execute(ctx, "drop_project", params, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute drop project script: %w", err)
+	}
+
+	result, err := bridge.ParseOperationResult(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse drop project result: %w", err)
+	}
+
+	return result, nil
+}
+
+// BatchModify applies the same operation to each of the given tasks.
+// Per-task failures are recorded in the result rather than aborting the batch;
+// an error is only returned when the operation itself is invalid.
+func (s *DefaultOmniFocusService) BatchModify(ctx context.Context, ids []string, op domain.BatchOperation) (*domain.BatchResult, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no tasks specified")
+	}
+
+	if err := op.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid batch operation: %w", err)
+	}
+
+	result := &domain.BatchResult{Results: make([]domain.OperationResult, 0, len(ids))}
+
+	for _, id := range ids {
+		var err error
+		switch op.Action {
+		case domain.BatchComplete:
+			_, err = s.CompleteTask(ctx, id)
+		case domain.BatchDelete:
+			_, err = s.DeleteTask(ctx, id)
+		case domain.BatchDrop:
+			_, err = s.DropTask(ctx, id)
+		case domain.BatchModify:
+			_, err = s.ModifyTask(ctx, id, op.Modification)
+		}
+
+		if err != nil {
+			result.Results = append(result.Results, domain.OperationResult{ID: id, Message: err.Error()})
+			continue
+		}
+		result.Results = append(result.Results, domain.NewSuccessResult(id, string(op.Action)))
+	}
+
+	return result, nil
+}
+
 // ResolveProjectName finds a project ID by name (case-insensitive)
-func (s *DefaultOmniFocusService) ResolveProjectName(name string) (string, error) {
-	projects, err := s.GetProjects("")
+func (s *DefaultOmniFocusService) ResolveProjectName(ctx context.Context, name string) (string, error) {
+	projects, err := s.GetProjects(ctx, "")
 	if err != nil {
 		return "", fmt.Errorf("failed to get projects: %w", err)
 	}
@@ -493,11 +1090,32 @@ func (s *DefaultOmniFocusService) ResolveProjectName(name string) (string, error
 		}
 	}
 
-	return "", fmt.Errorf("project not found: %s", name)
+	return "", bridge.NewNotFoundError("project", name)
 }
 
 // Helper functions for building script parameters
 
+// buildCreateProjectParams builds parameters for create_project script, filtering out empty values
+func buildCreateProjectParams(input domain.ProjectInput) map[string]string {
+	params := map[string]string{
+		"Name": input.Name,
+	}
+
+	if input.Note != "" {
+		params["Note"] = input.Note
+	}
+
+	if input.DueDate != nil {
+		params["DueDate"] = input.DueDate.Format("2006 01 02 15 04 05")
+	}
+
+	if input.DeferDate != nil {
+		params["DeferDate"] = input.DeferDate.Format("2006 01 02 15 04 05")
+	}
+
+	return params
+}
+
 // buildCreateTaskParams builds parameters for create_task script, filtering out empty values
 func buildCreateTaskParams(input domain.TaskInput) map[string]string {
 	params := map[string]string{
@@ -512,6 +1130,10 @@ func buildCreateTaskParams(input domain.TaskInput) map[string]string {
 		params["ProjectID"] = input.ProjectID
 	}
 
+	if input.ParentID != "" {
+		params["ParentID"] = input.ParentID
+	}
+
 	// TODO: Tags cannot be passed during task creation due to parameter validation
 	// constraints that disallow JSON syntax characters (brackets, quotes). This requires
 	// either enhancing the validation layer or implementing an alternative encoding scheme.
@@ -597,5 +1219,19 @@ func buildModifyTaskParams(id string, mod domain.TaskModification) map[string]st
 		}
 	}
 
+	if mod.ClearRepeat {
+		params["RepeatFrequency"] = "CLEAR"
+	} else if mod.Repeat != nil {
+		params["RepeatFrequency"] = mod.Repeat.Frequency
+		params["RepeatInterval"] = strconv.Itoa(max(mod.Repeat.Interval, 1))
+		params["RepeatMethod"] = string(mod.Repeat.Method)
+	}
+
+	if mod.ClearEstimate {
+		params["EstimatedMinutes"] = "CLEAR"
+	} else if mod.EstimatedMinutes != nil {
+		params["EstimatedMinutes"] = strconv.Itoa(*mod.EstimatedMinutes)
+	}
+
 	return params
 }
@@ -1,27 +1,38 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/bridge"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/log"
 )
 
 // mockExecutor implements bridge.Executor for testing
 type mockExecutor struct {
 	executeFunc func(script string) (string, error)
+	ctx         context.Context // Records the context the last script ran with
 }
 
-func (m *mockExecutor) Execute(script string) (string, error) {
+func (m *mockExecutor) Execute(ctx context.Context, script string) (string, error) {
+	m.ctx = ctx
 	if m.executeFunc != nil {
 		return m.executeFunc(script)
 	}
 	return "", nil
 }
 
-func (m *mockExecutor) ExecuteWithTimeout(script string, timeout time.Duration) (string, error) {
+func (m *mockExecutor) ExecuteWithTimeout(ctx context.Context, script string, timeout time.Duration) (string, error) {
+	m.ctx = ctx
 	if m.executeFunc != nil {
 		return m.executeFunc(script)
 	}
@@ -47,6 +58,51 @@ func TestNewOmniFocusService_CreatesServiceWithExecutor(t *testing.T) {
 	}
 }
 
+func TestGetInboxTasks_PassesContextToExecutor(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "caller")
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			return `{"tasks": []}`, nil
+		},
+	}
+
+	if _, err := NewOmniFocusService(executor, 30*time.Second).GetInboxTasks(ctx); err != nil {
+		t.Fatalf("GetInboxTasks() error = %v", err)
+	}
+
+	if executor.ctx == nil || executor.ctx.Value(key{}) != "caller" {
+		t.Error("GetInboxTasks() did not run the script with the caller's context")
+	}
+}
+
+func TestExecute_RetriesOnlyReadScripts(t *testing.T) {
+	calls := 0
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			calls++
+			return "", bridge.ErrExecutionTimeout
+		},
+	}
+	retrying := bridge.NewRetryableExecutor(executor, bridge.RetryConfig{MaxAttempts: 3})
+	service := NewOmniFocusService(retrying, 30*time.Second)
+
+	if _, err := service.GetInboxTasks(context.Background()); err == nil {
+		t.Fatal("GetInboxTasks() should fail")
+	}
+	if calls != 3 {
+		t.Errorf("GetInboxTasks() ran the script %d times, want 3", calls)
+	}
+
+	calls = 0
+	if _, err := service.CompleteTask(context.Background(), "task1"); err == nil {
+		t.Fatal("CompleteTask() should fail")
+	}
+	if calls != 1 {
+		t.Errorf("CompleteTask() ran the script %d times, want 1", calls)
+	}
+}
+
 func TestGetInboxTasks_Success_ReturnsInboxTasks(t *testing.T) {
 	expectedJSON := `{"tasks": [
 		{"id": "task1", "name": "Task 1", "flagged": false, "completed": false},
@@ -60,7 +116,7 @@ func TestGetInboxTasks_Success_ReturnsInboxTasks(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tasks, err := service.GetInboxTasks()
+	tasks, err := service.GetInboxTasks(context.Background())
 
 	if err != nil {
 		t.Fatalf("GetInboxTasks() error = %v, want nil", err)
@@ -83,7 +139,7 @@ func TestGetInboxTasks_OmniFocusNotRunning_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	_, err := service.GetInboxTasks()
+	_, err := service.GetInboxTasks(context.Background())
 
 	if !errors.Is(err, bridge.ErrOmniFocusNotRunning) {
 		t.Errorf("GetInboxTasks() error = %v, want ErrOmniFocusNotRunning", err)
@@ -99,7 +155,7 @@ func TestGetInboxTasks_ExecutorError_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	_, err := service.GetInboxTasks()
+	_, err := service.GetInboxTasks(context.Background())
 
 	if err == nil {
 		t.Fatal("GetInboxTasks() error = nil, want error")
@@ -114,7 +170,7 @@ func TestGetInboxTasks_EmptyResponse_ReturnsEmptySlice(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tasks, err := service.GetInboxTasks()
+	tasks, err := service.GetInboxTasks(context.Background())
 
 	if err != nil {
 		t.Fatalf("GetInboxTasks() error = %v, want nil", err)
@@ -138,7 +194,7 @@ func TestGetProjects_Success_ReturnsProjects(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	projects, err := service.GetProjects("active")
+	projects, err := service.GetProjects(context.Background(), "active")
 
 	if err != nil {
 		t.Fatalf("GetProjects() error = %v, want nil", err)
@@ -161,7 +217,7 @@ func TestGetFlaggedTasks_Success_ReturnsFlaggedTasks(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tasks, err := service.GetFlaggedTasks()
+	tasks, err := service.GetFlaggedTasks(context.Background())
 
 	if err != nil {
 		t.Fatalf("GetFlaggedTasks() error = %v, want nil", err)
@@ -176,6 +232,35 @@ func TestGetFlaggedTasks_Success_ReturnsFlaggedTasks(t *testing.T) {
 	}
 }
 
+func TestGetCompletedTasks_PassesSinceDateToScript(t *testing.T) {
+	expectedJSON := `{"tasks": [
+		{"id": "task1", "name": "Done Task", "completed": true, "completedDate": "2024-01-16T10:00:00Z"}
+	]}`
+
+	var capturedScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			capturedScript = script
+			return expectedJSON, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+	tasks, err := service.GetCompletedTasks(context.Background(), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))
+
+	if err != nil {
+		t.Fatalf("GetCompletedTasks() error = %v, want nil", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].CompletedDate == nil {
+		t.Fatalf("GetCompletedTasks() = %v, want 1 task with a completion date", tasks)
+	}
+
+	if !strings.Contains(capturedScript, `"2024-01-08"`) {
+		t.Error("GetCompletedTasks() script does not contain the since date")
+	}
+}
+
 func TestGetTasksByProject_Success_ReturnsProjectTasks(t *testing.T) {
 	projectID := "project-123"
 	expectedJSON := `{"tasks": [
@@ -189,7 +274,7 @@ func TestGetTasksByProject_Success_ReturnsProjectTasks(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tasks, err := service.GetTasksByProject(projectID)
+	tasks, err := service.GetTasksByProject(context.Background(), projectID)
 
 	if err != nil {
 		t.Fatalf("GetTasksByProject() error = %v, want nil", err)
@@ -204,6 +289,47 @@ func TestGetTasksByProject_Success_ReturnsProjectTasks(t *testing.T) {
 	}
 }
 
+func TestGetTaskHierarchy_Success_ReturnsNestedTasks(t *testing.T) {
+	expectedJSON := `{"tasks": [
+		{"id": "task1", "name": "Plan trip", "completed": false, "children": [
+			{"id": "task2", "name": "Book flights", "parentId": "task1", "completed": false}
+		]}
+	]}`
+
+	var executedScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			executedScript = script
+			return expectedJSON, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+	tasks, err := service.GetTaskHierarchy(context.Background(), "project-123")
+
+	if err != nil {
+		t.Fatalf("GetTaskHierarchy() error = %v, want nil", err)
+	}
+	if len(tasks) != 1 || len(tasks[0].Children) != 1 {
+		t.Fatalf("GetTaskHierarchy() = %+v, want one task with one subtask", tasks)
+	}
+	if tasks[0].Children[0].ParentID != "task1" {
+		t.Errorf("GetTaskHierarchy() subtask parentID = %s, want task1", tasks[0].Children[0].ParentID)
+	}
+	if !strings.Contains(executedScript, `"project-123"`) {
+		t.Error("GetTaskHierarchy() script does not reference the project ID")
+	}
+}
+
+func TestGetTaskHierarchy_InvalidProjectID_ReturnsError(t *testing.T) {
+	service := NewOmniFocusService(&mockExecutor{}, 30*time.Second)
+
+	_, err := service.GetTaskHierarchy(context.Background(), "bad\"; id")
+	if err == nil {
+		t.Error("GetTaskHierarchy() error = nil, want validation error")
+	}
+}
+
 func TestGetTasksByTag_Success_ReturnsTaggedTasks(t *testing.T) {
 	tagID := "tag-456"
 	expectedJSON := `{"tasks": [
@@ -217,7 +343,7 @@ func TestGetTasksByTag_Success_ReturnsTaggedTasks(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tasks, err := service.GetTasksByTag(tagID)
+	tasks, err := service.GetTasksByTag(context.Background(), tagID)
 
 	if err != nil {
 		t.Fatalf("GetTasksByTag() error = %v, want nil", err)
@@ -228,6 +354,43 @@ func TestGetTasksByTag_Success_ReturnsTaggedTasks(t *testing.T) {
 	}
 }
 
+func TestSearchTasks_Success_ReturnsMatchingTasks(t *testing.T) {
+	var gotScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			gotScript = script
+			return `{"tasks": [{"id": "task1", "name": "Renew passport", "projectName": "Travel"}]}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+	tasks, err := service.SearchTasks(context.Background(), "passport")
+
+	if err != nil {
+		t.Fatalf("SearchTasks() error = %v, want nil", err)
+	}
+	if len(tasks) != 1 || tasks[0].ProjectName != "Travel" {
+		t.Errorf("SearchTasks() = %+v, want the Travel task", tasks)
+	}
+	if !strings.Contains(gotScript, `"passport".toLowerCase()`) {
+		t.Error("SearchTasks() script does not contain the query")
+	}
+}
+
+func TestSearchTasks_UnsafeQuery_ReturnsError(t *testing.T) {
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			t.Error("script should not run for an unsafe query")
+			return "", nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+	if _, err := service.SearchTasks(context.Background(), `"); app.quit(); ("`); err == nil {
+		t.Error("SearchTasks() error = nil, want validation error")
+	}
+}
+
 func TestGetAllTasks_WithFilters_ReturnsFilteredTasks(t *testing.T) {
 	dueDate := time.Now()
 	filters := TaskFilters{
@@ -246,7 +409,7 @@ func TestGetAllTasks_WithFilters_ReturnsFilteredTasks(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tasks, err := service.GetAllTasks(filters)
+	tasks, err := service.GetAllTasks(context.Background(), filters)
 
 	if err != nil {
 		t.Fatalf("GetAllTasks() error = %v, want nil", err)
@@ -257,6 +420,108 @@ func TestGetAllTasks_WithFilters_ReturnsFilteredTasks(t *testing.T) {
 	}
 }
 
+// pagedTasksExecutor rejects unpaginated task fetches as too large and serves
+// paginated requests from a synthetic list of total tasks
+func pagedTasksExecutor(total int, calls *int) *mockExecutor {
+	offsetPattern := regexp.MustCompile(`const offset = parseInt\("(\d+)"`)
+	limitPattern := regexp.MustCompile(`const limit = parseInt\("(\d+)"`)
+
+	return &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			*calls++
+			if strings.Contains(script, "{{.Offset}}") {
+				return "", bridge.ErrPayloadTooLarge
+			}
+
+			offset, _ := strconv.Atoi(offsetPattern.FindStringSubmatch(script)[1])
+			limit, _ := strconv.Atoi(limitPattern.FindStringSubmatch(script)[1])
+
+			var items []string
+			for i := offset; i < offset+limit && i < total; i++ {
+				items = append(items, fmt.Sprintf(`{"id": "task%d", "name": "Task %d"}`, i, i))
+			}
+			return fmt.Sprintf(`{"tasks": [%s], "total": %d}`, strings.Join(items, ","), total), nil
+		},
+	}
+}
+
+func TestGetAllTasks_PayloadTooLarge_FallsBackToPagination(t *testing.T) {
+	calls := 0
+	service := NewOmniFocusService(pagedTasksExecutor(25, &calls), 30*time.Second)
+	service.pageSize = 10
+
+	tasks, err := service.GetAllTasks(context.Background(), TaskFilters{})
+
+	if err != nil {
+		t.Fatalf("GetAllTasks() error = %v, want nil", err)
+	}
+	if len(tasks) != 25 {
+		t.Errorf("GetAllTasks() returned %d tasks, want 25", len(tasks))
+	}
+	// One rejected full fetch plus three pages
+	if calls != 4 {
+		t.Errorf("executor called %d times, want 4", calls)
+	}
+}
+
+func TestGetAllTasks_PaginationCap_ReturnsTruncatedError(t *testing.T) {
+	calls := 0
+	service := NewOmniFocusService(pagedTasksExecutor(100, &calls), 30*time.Second)
+	service.pageSize = 10
+	service.maxTasks = 30
+
+	tasks, err := service.GetAllTasks(context.Background(), TaskFilters{})
+
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) {
+		t.Fatalf("GetAllTasks() error = %v, want *TruncatedError", err)
+	}
+	if truncated.Returned != 30 || truncated.Total != 100 {
+		t.Errorf("TruncatedError = %+v, want Returned 30, Total 100", truncated)
+	}
+	if len(tasks) != 30 {
+		t.Errorf("GetAllTasks() returned %d tasks, want 30", len(tasks))
+	}
+}
+
+func TestStreamAllTasks_YieldsTasksAcrossPages(t *testing.T) {
+	calls := 0
+	service := NewOmniFocusService(pagedTasksExecutor(25, &calls), 30*time.Second)
+	service.pageSize = 10
+
+	var streamed int
+	err := service.StreamAllTasks(context.Background(), TaskFilters{}, func(task domain.Task) error {
+		streamed++
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("StreamAllTasks() error = %v, want nil", err)
+	}
+	if streamed != 25 {
+		t.Errorf("StreamAllTasks() yielded %d tasks, want 25", streamed)
+	}
+}
+
+func TestStreamAllTasks_YieldErrorStopsStream(t *testing.T) {
+	calls := 0
+	service := NewOmniFocusService(pagedTasksExecutor(25, &calls), 30*time.Second)
+	service.pageSize = 10
+
+	stop := errors.New("write failed")
+	err := service.StreamAllTasks(context.Background(), TaskFilters{}, func(task domain.Task) error {
+		return stop
+	})
+
+	if err != stop {
+		t.Errorf("StreamAllTasks() error = %v, want the yield error", err)
+	}
+	// One call hits the payload limit, one fetches the first page
+	if calls != 2 {
+		t.Errorf("StreamAllTasks() ran %d scripts, want 2", calls)
+	}
+}
+
 func TestGetTaskByID_Success_ReturnsSingleTask(t *testing.T) {
 	taskID := "task-789"
 	expectedJSON := `{"task": {"id": "task-789", "name": "Specific Task", "completed": false}}`
@@ -268,7 +533,7 @@ func TestGetTaskByID_Success_ReturnsSingleTask(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	task, err := service.GetTaskByID(taskID)
+	task, err := service.GetTaskByID(context.Background(), taskID)
 
 	if err != nil {
 		t.Fatalf("GetTaskByID() error = %v, want nil", err)
@@ -291,7 +556,7 @@ func TestGetTaskByID_NotFound_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	task, err := service.GetTaskByID("nonexistent")
+	task, err := service.GetTaskByID(context.Background(), "nonexistent")
 
 	if err == nil {
 		t.Fatal("GetTaskByID() error = nil, want error for non-existent task")
@@ -313,7 +578,7 @@ func TestGetProjectByID_Success_ReturnsSingleProject(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	project, err := service.GetProjectByID(projectID)
+	project, err := service.GetProjectByID(context.Background(), projectID)
 
 	if err != nil {
 		t.Fatalf("GetProjectByID() error = %v, want nil", err)
@@ -346,7 +611,7 @@ func TestGetProjectWithTasks_Success_ReturnsProjectWithTasks(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	project, err := service.GetProjectWithTasks(projectID)
+	project, err := service.GetProjectWithTasks(context.Background(), projectID)
 
 	if err != nil {
 		t.Fatalf("GetProjectWithTasks() error = %v, want nil", err)
@@ -369,7 +634,7 @@ func TestGetProjectByID_NotFound_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	project, err := service.GetProjectByID("nonexistent")
+	project, err := service.GetProjectByID(context.Background(), "nonexistent")
 
 	if err == nil {
 		t.Fatal("GetProjectByID() error = nil, want error for non-existent project")
@@ -388,7 +653,7 @@ func TestGetProjectWithTasks_NotFound_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	project, err := service.GetProjectWithTasks("nonexistent")
+	project, err := service.GetProjectWithTasks(context.Background(), "nonexistent")
 
 	if err == nil {
 		t.Fatal("GetProjectWithTasks() error = nil, want error for non-existent project")
@@ -399,6 +664,31 @@ func TestGetProjectWithTasks_NotFound_ReturnsError(t *testing.T) {
 	}
 }
 
+func TestGetFolders_Success_ReturnsTree(t *testing.T) {
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			return `{"folders": [
+				{"id": "f1", "name": "Work", "projectIds": ["p1"], "children": [
+					{"id": "f2", "name": "Clients", "parentId": "f1", "projectIds": ["p2"]}
+				]}
+			]}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+	folders, err := service.GetFolders(context.Background())
+
+	if err != nil {
+		t.Fatalf("GetFolders() error = %v, want nil", err)
+	}
+	if len(folders) != 1 || len(folders[0].Children) != 1 {
+		t.Fatalf("GetFolders() = %+v, want one folder with one subfolder", folders)
+	}
+	if got := folders[0].Children[0]; got.Name != "Clients" || got.ParentID != "f1" || len(got.ProjectIDs) != 1 {
+		t.Errorf("GetFolders() subfolder = %+v, want Clients in f1 with one project", got)
+	}
+}
+
 func TestGetTags_Success_ReturnsTags(t *testing.T) {
 	expectedJSON := `{"tags": [
 		{"id": "tag1", "name": "Work"},
@@ -412,7 +702,7 @@ func TestGetTags_Success_ReturnsTags(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tags, err := service.GetTags()
+	tags, err := service.GetTags(context.Background())
 
 	if err != nil {
 		t.Fatalf("GetTags() error = %v, want nil", err)
@@ -440,7 +730,7 @@ func TestGetTags_ExecutorError_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	_, err := service.GetTags()
+	_, err := service.GetTags(context.Background())
 
 	if err == nil {
 		t.Fatal("GetTags() error = nil, want error")
@@ -455,7 +745,7 @@ func TestGetTags_ParseError_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	_, err := service.GetTags()
+	_, err := service.GetTags(context.Background())
 
 	if err == nil {
 		t.Fatal("GetTags() error = nil, want error for invalid JSON")
@@ -473,7 +763,7 @@ func TestGetTagByID_Success_ReturnsTag(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tag, err := service.GetTagByID(tagID)
+	tag, err := service.GetTagByID(context.Background(), tagID)
 
 	if err != nil {
 		t.Fatalf("GetTagByID() error = %v, want nil", err)
@@ -501,7 +791,7 @@ func TestGetTagByID_ExecutorError_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	_, err := service.GetTagByID("tag1")
+	_, err := service.GetTagByID(context.Background(), "tag1")
 
 	if err == nil {
 		t.Fatal("GetTagByID() error = nil, want error")
@@ -518,7 +808,7 @@ func TestGetTagCounts_Success_ReturnsCounts(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	counts, err := service.GetTagCounts()
+	counts, err := service.GetTagCounts(context.Background())
 
 	if err != nil {
 		t.Fatalf("GetTagCounts() error = %v, want nil", err)
@@ -546,7 +836,7 @@ func TestGetTagCounts_ExecutorError_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	_, err := service.GetTagCounts()
+	_, err := service.GetTagCounts(context.Background())
 
 	if err == nil {
 		t.Fatal("GetTagCounts() error = nil, want error")
@@ -567,7 +857,7 @@ func TestGetPerspectiveTasks_Success_ReturnsTasks(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tasks, err := service.GetPerspectiveTasks(perspectiveName)
+	tasks, err := service.GetPerspectiveTasks(context.Background(), perspectiveName)
 
 	if err != nil {
 		t.Fatalf("GetPerspectiveTasks() error = %v, want nil", err)
@@ -590,7 +880,7 @@ func TestGetPerspectiveTasks_Empty_ReturnsEmptySlice(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	tasks, err := service.GetPerspectiveTasks("EmptyPerspective")
+	tasks, err := service.GetPerspectiveTasks(context.Background(), "EmptyPerspective")
 
 	if err != nil {
 		t.Fatalf("GetPerspectiveTasks() error = %v, want nil", err)
@@ -610,7 +900,7 @@ func TestGetPerspectiveTasks_ExecutorError_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	_, err := service.GetPerspectiveTasks("Review")
+	_, err := service.GetPerspectiveTasks(context.Background(), "Review")
 
 	if err == nil {
 		t.Fatal("GetPerspectiveTasks() error = nil, want error")
@@ -630,7 +920,7 @@ func TestCreateTask_Success_ReturnsCreatedTask(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 	input := domain.TaskInput{Name: "New Task"}
-	task, err := service.CreateTask(input)
+	task, err := service.CreateTask(context.Background(), input)
 
 	if err != nil {
 		t.Fatalf("CreateTask() error = %v, want nil", err)
@@ -667,7 +957,7 @@ func TestCreateTask_ExecutorError_ReturnsError(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 	input := domain.TaskInput{Name: "New Task"}
-	task, err := service.CreateTask(input)
+	task, err := service.CreateTask(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("CreateTask() error = nil, want error")
@@ -687,7 +977,7 @@ func TestCreateTask_InvalidInput_ReturnsError(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 	input := domain.TaskInput{Name: ""} // Empty name should fail validation
-	task, err := service.CreateTask(input)
+	task, err := service.CreateTask(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("CreateTask() error = nil, want validation error")
@@ -709,7 +999,7 @@ func TestCreateTask_TaskNotCreated_ReturnsError(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 	input := domain.TaskInput{Name: "New Task"}
-	task, err := service.CreateTask(input)
+	task, err := service.CreateTask(context.Background(), input)
 
 	if err == nil {
 		t.Fatal("CreateTask() error = nil, want error when task is null")
@@ -734,7 +1024,7 @@ func TestModifyTask_Success_ReturnsModifiedTask(t *testing.T) {
 	service := NewOmniFocusService(executor, 30*time.Second)
 	name := "Modified Task"
 	mod := domain.TaskModification{Name: &name}
-	task, err := service.ModifyTask("task123", mod)
+	task, err := service.ModifyTask(context.Background(), "task123", mod)
 
 	if err != nil {
 		t.Fatalf("ModifyTask() error = %v, want nil", err)
@@ -764,7 +1054,7 @@ func TestModifyTask_ExecutorError_ReturnsError(t *testing.T) {
 	service := NewOmniFocusService(executor, 30*time.Second)
 	name := "Modified Task"
 	mod := domain.TaskModification{Name: &name}
-	task, err := service.ModifyTask("task123", mod)
+	task, err := service.ModifyTask(context.Background(), "task123", mod)
 
 	if err == nil {
 		t.Fatal("ModifyTask() error = nil, want error")
@@ -784,7 +1074,7 @@ func TestModifyTask_EmptyModification_ReturnsError(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 	mod := domain.TaskModification{} // Empty modification
-	task, err := service.ModifyTask("task123", mod)
+	task, err := service.ModifyTask(context.Background(), "task123", mod)
 
 	if err == nil {
 		t.Fatal("ModifyTask() error = nil, want error for empty modification")
@@ -807,7 +1097,7 @@ func TestModifyTask_TaskNotFound_ReturnsError(t *testing.T) {
 	service := NewOmniFocusService(executor, 30*time.Second)
 	name := "Modified Task"
 	mod := domain.TaskModification{Name: &name}
-	task, err := service.ModifyTask("nonexistent", mod)
+	task, err := service.ModifyTask(context.Background(), "nonexistent", mod)
 
 	if err == nil {
 		t.Fatal("ModifyTask() error = nil, want error when task not found")
@@ -830,7 +1120,7 @@ func TestCompleteTask_Success_ReturnsResult(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	result, err := service.CompleteTask("task123")
+	result, err := service.CompleteTask(context.Background(), "task123")
 
 	if err != nil {
 		t.Fatalf("CompleteTask() error = %v, want nil", err)
@@ -862,7 +1152,7 @@ func TestCompleteTask_ExecutorError_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	result, err := service.CompleteTask("task123")
+	result, err := service.CompleteTask(context.Background(), "task123")
 
 	if err == nil {
 		t.Fatal("CompleteTask() error = nil, want error")
@@ -885,7 +1175,7 @@ func TestDeleteTask_Success_ReturnsResult(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	result, err := service.DeleteTask("task123")
+	result, err := service.DeleteTask(context.Background(), "task123")
 
 	if err != nil {
 		t.Fatalf("DeleteTask() error = %v, want nil", err)
@@ -917,7 +1207,7 @@ func TestDeleteTask_ExecutorError_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	result, err := service.DeleteTask("task123")
+	result, err := service.DeleteTask(context.Background(), "task123")
 
 	if err == nil {
 		t.Fatal("DeleteTask() error = nil, want error")
@@ -943,7 +1233,7 @@ func TestResolveProjectName_Success_ReturnsProjectID(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	projectID, err := service.ResolveProjectName("Work")
+	projectID, err := service.ResolveProjectName(context.Background(), "Work")
 
 	if err != nil {
 		t.Fatalf("ResolveProjectName() error = %v, want nil", err)
@@ -967,7 +1257,7 @@ func TestResolveProjectName_CaseInsensitive_ReturnsProjectID(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	projectID, err := service.ResolveProjectName("work")
+	projectID, err := service.ResolveProjectName(context.Background(), "work")
 
 	if err != nil {
 		t.Fatalf("ResolveProjectName() error = %v, want nil", err)
@@ -991,7 +1281,7 @@ func TestResolveProjectName_NotFound_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	projectID, err := service.ResolveProjectName("NonExistent")
+	projectID, err := service.ResolveProjectName(context.Background(), "NonExistent")
 
 	if err == nil {
 		t.Fatal("ResolveProjectName() error = nil, want error for non-existent project")
@@ -1011,7 +1301,7 @@ func TestResolveProjectName_GetProjectsError_ReturnsError(t *testing.T) {
 	}
 
 	service := NewOmniFocusService(executor, 30*time.Second)
-	projectID, err := service.ResolveProjectName("Work")
+	projectID, err := service.ResolveProjectName(context.Background(), "Work")
 
 	if err == nil {
 		t.Fatal("ResolveProjectName() error = nil, want error")
@@ -1021,3 +1311,66 @@ func TestResolveProjectName_GetProjectsError_ReturnsError(t *testing.T) {
 		t.Errorf("ResolveProjectName() projectID = %s, want empty string on error", projectID)
 	}
 }
+
+func TestGetPerspectiveRules_Success_ReturnsRules(t *testing.T) {
+	var gotScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			gotScript = script
+			return `{"perspective": {"name": "Errands", "aggregation": "all", "rules": [{"actionStatus": "flagged"}]}}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+	rules, err := service.GetPerspectiveRules(context.Background(), "Errands")
+
+	if err != nil {
+		t.Fatalf("GetPerspectiveRules() error = %v, want nil", err)
+	}
+	if rules.Name != "Errands" || len(rules.Rules) != 1 || rules.Rules[0]["actionStatus"] != "flagged" {
+		t.Errorf("GetPerspectiveRules() = %+v, want Errands with flagged rule", rules)
+	}
+	if !strings.Contains(gotScript, `"Errands"`) {
+		t.Error("Expected script to contain perspective name")
+	}
+}
+
+func TestGetPerspectiveRules_NotFound_ReturnsError(t *testing.T) {
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			return `{"error": "Perspective not found: Nope"}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+	_, err := service.GetPerspectiveRules(context.Background(), "Nope")
+
+	if err == nil || !strings.Contains(err.Error(), "Perspective not found") {
+		t.Errorf("GetPerspectiveRules() error = %v, want not found", err)
+	}
+}
+
+func TestExecute_LogsScriptCalls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+	if err := log.Enable(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = log.Disable() })
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			return `{"tasks": []}`, nil
+		},
+	}
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	if _, err := service.GetTasksByTag(context.Background(), "tag1"); err != nil {
+		t.Fatalf("GetTasksByTag() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{`msg="running script" script=get_tasks_by_tag params=map[TagID:tag1]`, "script finished", "duration=", `output="{\"tasks\": []}"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("debug log missing %q:\n%s", want, data)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -38,7 +39,7 @@ func TestCreateTask_Success(t *testing.T) {
 		Note: "Test note",
 	}
 
-	task, err := service.CreateTask(input)
+	task, err := service.CreateTask(context.Background(), input)
 	if err != nil {
 		t.Fatalf("CreateTask failed: %v", err)
 	}
@@ -95,7 +96,7 @@ func TestCreateTask_WithAllFields(t *testing.T) {
 		Flagged:   &flagged,
 	}
 
-	task, err := service.CreateTask(input)
+	task, err := service.CreateTask(context.Background(), input)
 	if err != nil {
 		t.Fatalf("CreateTask failed: %v", err)
 	}
@@ -109,6 +110,25 @@ func TestCreateTask_WithAllFields(t *testing.T) {
 	}
 }
 
+func TestCreateTask_UnderParent(t *testing.T) {
+	var script string
+	executor := &mockExecutor{
+		executeFunc: func(s string) (string, error) {
+			script = s
+			return `{"task": {"id": "task789", "name": "Subtask", "flagged": false, "completed": false}}`, nil
+		},
+	}
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	if _, err := service.CreateTask(context.Background(), domain.TaskInput{Name: "Subtask", ParentID: "task456"}); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	if !strings.Contains(script, `const parentID = "task456";`) {
+		t.Errorf("Expected script to target parent task456, got: %s", script)
+	}
+}
+
 func TestCreateTask_ValidationError(t *testing.T) {
 	executor := &mockExecutor{
 		executeFunc: func(script string) (string, error) {
@@ -121,7 +141,7 @@ func TestCreateTask_ValidationError(t *testing.T) {
 		Name: "", // Empty name should fail validation
 	}
 
-	_, err := service.CreateTask(input)
+	_, err := service.CreateTask(context.Background(), input)
 	if err == nil {
 		t.Fatal("Expected error when task input is invalid")
 	}
@@ -144,7 +164,7 @@ func TestCreateTask_ExecutionError(t *testing.T) {
 		Name: "Test Task",
 	}
 
-	_, err := service.CreateTask(input)
+	_, err := service.CreateTask(context.Background(), input)
 	if err == nil {
 		t.Fatal("Expected error when execution fails")
 	}
@@ -188,7 +208,7 @@ func TestModifyTask_Success(t *testing.T) {
 		Flagged: &flagged,
 	}
 
-	task, err := service.ModifyTask("task123", mod)
+	task, err := service.ModifyTask(context.Background(), "task123", mod)
 	if err != nil {
 		t.Fatalf("ModifyTask failed: %v", err)
 	}
@@ -231,7 +251,7 @@ func TestModifyTask_ClearDates(t *testing.T) {
 		ClearDefer: true,
 	}
 
-	task, err := service.ModifyTask("task123", mod)
+	task, err := service.ModifyTask(context.Background(), "task123", mod)
 	if err != nil {
 		t.Fatalf("ModifyTask failed: %v", err)
 	}
@@ -245,6 +265,94 @@ func TestModifyTask_ClearDates(t *testing.T) {
 	}
 }
 
+func TestModifyTask_Repeat(t *testing.T) {
+	var gotScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			gotScript = script
+			return `{"task": {"id": "task123", "name": "Task", "tags": [], "flagged": false, "completed": false,
+				"repetitionRule": {"recurrence": "FREQ=WEEKLY;INTERVAL=2", "method": "due-after-completion"}}}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	mod := domain.TaskModification{
+		Repeat: &domain.Repeat{Frequency: domain.FrequencyWeekly, Interval: 2, Method: domain.RepeatDueAfterCompletion},
+	}
+
+	task, err := service.ModifyTask(context.Background(), "task123", mod)
+	if err != nil {
+		t.Fatalf("ModifyTask failed: %v", err)
+	}
+
+	for _, want := range []string{`repeatFrequency = "weekly"`, `repeatInterval = "2"`, `repeatMethod = "due-after-completion"`} {
+		if !strings.Contains(gotScript, want) {
+			t.Errorf("Expected script to contain %s", want)
+		}
+	}
+	if task.Repetition == nil || task.Repetition.String() != "every 2 weeks, after completion" {
+		t.Errorf("Expected task to repeat every 2 weeks after completion, got %+v", task.Repetition)
+	}
+}
+
+func TestModifyTask_ClearRepeat(t *testing.T) {
+	var gotScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			gotScript = script
+			return `{"task": {"id": "task123", "name": "Task", "tags": [], "flagged": false, "completed": false}}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	task, err := service.ModifyTask(context.Background(), "task123", domain.TaskModification{ClearRepeat: true})
+	if err != nil {
+		t.Fatalf("ModifyTask failed: %v", err)
+	}
+
+	if !strings.Contains(gotScript, `repeatFrequency = "CLEAR"`) {
+		t.Error("Expected script to clear the repeat")
+	}
+	if task.Repetition != nil {
+		t.Errorf("Expected no repetition, got %+v", task.Repetition)
+	}
+}
+
+func TestModifyTask_Estimate(t *testing.T) {
+	fortyFive := 45
+	tests := []struct {
+		name string
+		mod  domain.TaskModification
+		want string
+	}{
+		{"set", domain.TaskModification{EstimatedMinutes: &fortyFive}, `estimatedMinutesStr = "45"`},
+		{"clear", domain.TaskModification{ClearEstimate: true}, `estimatedMinutesStr = "CLEAR"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotScript string
+			executor := &mockExecutor{
+				executeFunc: func(script string) (string, error) {
+					gotScript = script
+					return `{"task": {"id": "task123", "name": "Task", "tags": [], "flagged": false, "completed": false}}`, nil
+				},
+			}
+
+			service := NewOmniFocusService(executor, 30*time.Second)
+			if _, err := service.ModifyTask(context.Background(), "task123", tt.mod); err != nil {
+				t.Fatalf("ModifyTask failed: %v", err)
+			}
+
+			if !strings.Contains(gotScript, tt.want) {
+				t.Errorf("Expected script to contain %s", tt.want)
+			}
+		})
+	}
+}
+
 func TestModifyTask_AddRemoveTags(t *testing.T) {
 	// Skip this test for now - tag modification requires parameter validation enhancement
 	// TODO: Re-enable when parameter validation supports JSON arrays
@@ -278,7 +386,7 @@ func TestModifyTask_AddRemoveTags(t *testing.T) {
 		RemoveTags: []string{"old-tag"},
 	}
 
-	task, err := service.ModifyTask("task123", mod)
+	task, err := service.ModifyTask(context.Background(), "task123", mod)
 	if err != nil {
 		t.Fatalf("ModifyTask failed: %v", err)
 	}
@@ -303,7 +411,7 @@ func TestCompleteTask_Success(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 
-	result, err := service.CompleteTask("task123")
+	result, err := service.CompleteTask(context.Background(), "task123")
 	if err != nil {
 		t.Fatalf("CompleteTask failed: %v", err)
 	}
@@ -321,6 +429,115 @@ func TestCompleteTask_Success(t *testing.T) {
 	}
 }
 
+func TestUncompleteTask_Success(t *testing.T) {
+	expectedJSON := `{
+		"success": true,
+		"id": "task123",
+		"message": "Task reopened"
+	}`
+
+	var capturedScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			capturedScript = script
+			return expectedJSON, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	result, err := service.UncompleteTask(context.Background(), "task123")
+	if err != nil {
+		t.Fatalf("UncompleteTask failed: %v", err)
+	}
+
+	if !result.Success || result.ID != "task123" {
+		t.Errorf("Expected successful result for 'task123', got %+v", result)
+	}
+
+	if !strings.Contains(capturedScript, "markIncomplete") {
+		t.Error("Expected uncomplete_task script to be executed")
+	}
+}
+
+func TestDropTask_Success(t *testing.T) {
+	var capturedScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			capturedScript = script
+			return `{"success": true, "id": "task123", "message": "Task dropped"}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	result, err := service.DropTask(context.Background(), "task123")
+	if err != nil {
+		t.Fatalf("DropTask failed: %v", err)
+	}
+
+	if !result.Success || result.ID != "task123" {
+		t.Errorf("Expected successful result for 'task123', got %+v", result)
+	}
+
+	if !strings.Contains(capturedScript, "markDropped") || !strings.Contains(capturedScript, `"task123"`) {
+		t.Error("Expected drop_task script to be executed for task123")
+	}
+}
+
+func TestDropProject_Success(t *testing.T) {
+	var capturedScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			capturedScript = script
+			return `{"success": true, "id": "proj1", "message": "Project dropped"}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	result, err := service.DropProject(context.Background(), "proj1")
+	if err != nil {
+		t.Fatalf("DropProject failed: %v", err)
+	}
+
+	if !result.Success || result.ID != "proj1" {
+		t.Errorf("Expected successful result for 'proj1', got %+v", result)
+	}
+
+	if !strings.Contains(capturedScript, "flattenedProjects") || !strings.Contains(capturedScript, `"proj1"`) {
+		t.Error("Expected drop_project script to be executed for proj1")
+	}
+}
+
+func TestDropProject_NotFound(t *testing.T) {
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			return `{"error": "Project not found: proj1"}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	if _, err := service.DropProject(context.Background(), "proj1"); err == nil {
+		t.Fatal("DropProject() error = nil, want an error")
+	}
+}
+
+func TestUncompleteTask_NotCompleted(t *testing.T) {
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			return `{"error": "Task is not completed: task123"}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	if _, err := service.UncompleteTask(context.Background(), "task123"); err == nil {
+		t.Fatal("Expected error when task is not completed")
+	}
+}
+
 func TestCompleteTask_TaskNotFound(t *testing.T) {
 	expectedJSON := `{
 		"error": "Task not found: invalid-id"
@@ -334,7 +551,7 @@ func TestCompleteTask_TaskNotFound(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 
-	_, err := service.CompleteTask("invalid-id")
+	_, err := service.CompleteTask(context.Background(), "invalid-id")
 	if err == nil {
 		t.Fatal("Expected error when task not found")
 	}
@@ -359,7 +576,7 @@ func TestDeleteTask_Success(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 
-	result, err := service.DeleteTask("task123")
+	result, err := service.DeleteTask(context.Background(), "task123")
 	if err != nil {
 		t.Fatalf("DeleteTask failed: %v", err)
 	}
@@ -373,6 +590,100 @@ func TestDeleteTask_Success(t *testing.T) {
 	}
 }
 
+func TestReorderTask_PassesPosition(t *testing.T) {
+	var got string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			got = script
+			return `{"success": true, "id": "task1", "message": "Task moved"}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	result, err := service.ReorderTask(context.Background(), "task1", domain.TaskPosition{SiblingID: "task2", After: true})
+	if err != nil {
+		t.Fatalf("ReorderTask failed: %v", err)
+	}
+	if !result.Success || result.ID != "task1" {
+		t.Errorf("ReorderTask() = %+v, want success for task1", result)
+	}
+	for _, want := range []string{`"task1"`, `"task2"`, `"after"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("script missing %s", want)
+		}
+	}
+}
+
+func TestBatchModify_CompleteRecordsPerTaskResults(t *testing.T) {
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			if strings.Contains(script, "missing") {
+				return `{"error": "Task not found: missing"}`, nil
+			}
+			return `{"success": true, "id": "task1", "message": "Task completed"}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	result, err := service.BatchModify(context.Background(), []string{"task1", "missing", "task3"}, domain.BatchOperation{Action: domain.BatchComplete})
+	if err != nil {
+		t.Fatalf("BatchModify failed: %v", err)
+	}
+
+	if len(result.Results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(result.Results))
+	}
+
+	if result.Succeeded() != 2 || result.Failed() != 1 {
+		t.Errorf("Expected 2 succeeded and 1 failed, got %d and %d", result.Succeeded(), result.Failed())
+	}
+
+	if result.Results[1].ID != "missing" || result.Results[1].Success {
+		t.Errorf("Expected failure recorded for 'missing', got %+v", result.Results[1])
+	}
+}
+
+func TestBatchModify_ModifyAppliesToEachTask(t *testing.T) {
+	var scripts []string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			scripts = append(scripts, script)
+			return `{"task": {"id": "task1", "name": "Task", "flagged": true}}`, nil
+		},
+	}
+
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	flagged := true
+	op := domain.BatchOperation{Action: domain.BatchModify, Modification: domain.TaskModification{Flagged: &flagged}}
+	result, err := service.BatchModify(context.Background(), []string{"task1", "task2"}, op)
+	if err != nil {
+		t.Fatalf("BatchModify failed: %v", err)
+	}
+
+	if result.Succeeded() != 2 {
+		t.Errorf("Expected 2 succeeded, got %d", result.Succeeded())
+	}
+
+	if len(scripts) != 2 || !strings.Contains(scripts[1], "task2") {
+		t.Errorf("Expected one modify script per task, got %d scripts", len(scripts))
+	}
+}
+
+func TestBatchModify_InvalidInput(t *testing.T) {
+	service := NewOmniFocusService(&mockExecutor{}, 30*time.Second)
+
+	if _, err := service.BatchModify(context.Background(), nil, domain.BatchOperation{Action: domain.BatchComplete}); err == nil {
+		t.Error("Expected error for empty ID list")
+	}
+
+	if _, err := service.BatchModify(context.Background(), []string{"task1"}, domain.BatchOperation{Action: domain.BatchModify}); err == nil {
+		t.Error("Expected error for empty modification")
+	}
+}
+
 func TestResolveProjectName_Success(t *testing.T) {
 	expectedJSON := `{
 		"projects": [
@@ -401,7 +712,7 @@ func TestResolveProjectName_Success(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 
-	projectID, err := service.ResolveProjectName("My Project")
+	projectID, err := service.ResolveProjectName(context.Background(), "My Project")
 	if err != nil {
 		t.Fatalf("ResolveProjectName failed: %v", err)
 	}
@@ -432,7 +743,7 @@ func TestResolveProjectName_CaseInsensitive(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 
-	projectID, err := service.ResolveProjectName("my project")
+	projectID, err := service.ResolveProjectName(context.Background(), "my project")
 	if err != nil {
 		t.Fatalf("ResolveProjectName failed: %v", err)
 	}
@@ -463,7 +774,7 @@ func TestResolveProjectName_NotFound(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 
-	_, err := service.ResolveProjectName("Nonexistent Project")
+	_, err := service.ResolveProjectName(context.Background(), "Nonexistent Project")
 	if err == nil {
 		t.Fatal("Expected error when project not found")
 	}
@@ -482,8 +793,110 @@ func TestResolveProjectName_GetProjectsError(t *testing.T) {
 
 	service := NewOmniFocusService(executor, 30*time.Second)
 
-	_, err := service.ResolveProjectName("Any Project")
+	_, err := service.ResolveProjectName(context.Background(), "Any Project")
 	if err == nil {
 		t.Fatal("Expected error when GetProjects fails")
 	}
 }
+
+func TestCreateProject_Success(t *testing.T) {
+	var gotScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			gotScript = script
+			return `{"project": {"id": "proj123", "name": "Release 1 2", "status": "active", "note": ""}}`, nil
+		},
+	}
+	service := NewOmniFocusService(executor, 30*time.Second)
+	due := time.Date(2024, 2, 1, 17, 0, 0, 0, time.Local)
+
+	project, err := service.CreateProject(context.Background(), domain.ProjectInput{Name: "Release 1 2", DueDate: &due})
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+
+	if project.ID != "proj123" || project.Status != "active" {
+		t.Errorf("CreateProject() = %+v, want active project proj123", project)
+	}
+	if !strings.Contains(gotScript, `"Release 1 2"`) || !strings.Contains(gotScript, "2024 02 01 17 00 00") {
+		t.Error("Expected script to contain project name and due date")
+	}
+}
+
+func TestCreateProject_ValidationError(t *testing.T) {
+	service := NewOmniFocusService(&mockExecutor{}, 30*time.Second)
+
+	_, err := service.CreateProject(context.Background(), domain.ProjectInput{Name: " "})
+	if err == nil || !strings.Contains(err.Error(), "invalid project input") {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+}
+
+func TestCreateTag_Success(t *testing.T) {
+	var gotScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			gotScript = script
+			return `{"tag": {"id": "tag123", "name": "Errands", "parentID": "tag1"}}`, nil
+		},
+	}
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	tag, err := service.CreateTag(context.Background(), "Errands", "tag1")
+	if err != nil {
+		t.Fatalf("CreateTag failed: %v", err)
+	}
+
+	if tag.ID != "tag123" || tag.ParentID != "tag1" {
+		t.Errorf("CreateTag() = %+v, want tag123 under tag1", tag)
+	}
+	if !strings.Contains(gotScript, `"Errands"`) || !strings.Contains(gotScript, `"tag1"`) {
+		t.Error("Expected script to contain tag name and parent ID")
+	}
+}
+
+func TestCreateTag_EmptyName(t *testing.T) {
+	service := NewOmniFocusService(&mockExecutor{}, 30*time.Second)
+
+	_, err := service.CreateTag(context.Background(), " ", "")
+	if err == nil || !strings.Contains(err.Error(), "tag name is required") {
+		t.Errorf("Expected tag name error, got: %v", err)
+	}
+}
+
+func TestRenameTag_Success(t *testing.T) {
+	var gotScript string
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			gotScript = script
+			return `{"tag": {"id": "tag123", "name": "Shopping"}}`, nil
+		},
+	}
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	tag, err := service.RenameTag(context.Background(), "tag123", "Shopping")
+	if err != nil {
+		t.Fatalf("RenameTag failed: %v", err)
+	}
+
+	if tag.Name != "Shopping" {
+		t.Errorf("RenameTag() name = %s, want Shopping", tag.Name)
+	}
+	if !strings.Contains(gotScript, `"tag123"`) || !strings.Contains(gotScript, `"Shopping"`) {
+		t.Error("Expected script to contain tag ID and new name")
+	}
+}
+
+func TestDeleteTag_Error(t *testing.T) {
+	executor := &mockExecutor{
+		executeFunc: func(script string) (string, error) {
+			return `{"error": "Tag not found: tag999"}`, nil
+		},
+	}
+	service := NewOmniFocusService(executor, 30*time.Second)
+
+	_, err := service.DeleteTag(context.Background(), "tag999")
+	if err == nil || !strings.Contains(err.Error(), "Tag not found") {
+		t.Errorf("Expected tag not found error, got: %v", err)
+	}
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// PendingWrite describes a write call that has started but not returned, with
+// the arguments needed to perform it again from another process
+type PendingWrite struct {
+	Method       string                   `json:"method"`
+	ID           string                   `json:"id,omitempty"`
+	IDs          []string                 `json:"ids,omitempty"`
+	Name         string                   `json:"name,omitempty"`
+	ParentID     string                   `json:"parentId,omitempty"`
+	Task         *domain.TaskInput        `json:"task,omitempty"`
+	Project      *domain.ProjectInput     `json:"project,omitempty"`
+	Modification *domain.TaskModification `json:"modification,omitempty"`
+	Batch        *domain.BatchOperation   `json:"batch,omitempty"`
+	Position     *domain.TaskPosition     `json:"position,omitempty"`
+
+	// Device is the device ID of the machine that started the write, so a
+	// journal replayed on a shared database shows where it came from
+	Device string `json:"device,omitempty"`
+
+	// Rejected is set when OmniFocus answered the original call with an
+	// error, so it certainly changed nothing and Replay need not look for
+	// its effect (another task of the same name, say)
+	Rejected bool `json:"rejected,omitempty"`
+}
+
+// PendingTracker is implemented by services that know which writes are in
+// progress (e.g. so the TUI can warn before quitting)
+type PendingTracker interface {
+	PendingWrites() []PendingWrite
+	WaitIdle()
+	Detach() []PendingWrite
+}
+
+// PendingOmniFocusService decorates an OmniFocusService and tracks the write
+// calls that are in progress.
+type PendingOmniFocusService struct {
+	OmniFocusService
+
+	device   string // Device ID recorded with each write
+	mu       sync.Mutex
+	idle     *sync.Cond
+	next     int
+	pending  map[int]PendingWrite
+	detached bool           // Set by Detach; failures are kept from then on
+	failed   []PendingWrite // Writes that failed once detached
+}
+
+// NewPendingOmniFocusService wraps the given service to track its writes
+func NewPendingOmniFocusService(svc OmniFocusService) *PendingOmniFocusService {
+	p := &PendingOmniFocusService{
+		OmniFocusService: svc,
+		pending:          make(map[int]PendingWrite),
+	}
+	p.idle = sync.NewCond(&p.mu)
+	return p
+}
+
+// SetDevice records id as the device of the writes started from now on
+func (p *PendingOmniFocusService) SetDevice(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.device = id
+}
+
+// begin records a write as in progress and returns the function that ends
+// it with the error of the call
+func (p *PendingOmniFocusService) begin(w PendingWrite) func(error) {
+	p.mu.Lock()
+	w.Device = p.device
+	id := p.next
+	p.next++
+	p.pending[id] = w
+	p.mu.Unlock()
+
+	return func(err error) {
+		p.mu.Lock()
+		delete(p.pending, id)
+		if err != nil && p.detached {
+			w.Rejected = !bridge.MayHaveApplied(err)
+			p.failed = append(p.failed, w)
+		}
+		if len(p.pending) == 0 {
+			p.idle.Broadcast()
+		}
+		p.mu.Unlock()
+	}
+}
+
+// PendingWrites returns the writes in progress in the order they started
+func (p *PendingOmniFocusService) PendingWrites() []PendingWrite {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.running()
+}
+
+// running returns the writes in progress in the order they started; p.mu
+// must be held
+func (p *PendingOmniFocusService) running() []PendingWrite {
+	ids := make([]int, 0, len(p.pending))
+	for id := range p.pending {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	writes := make([]PendingWrite, 0, len(ids))
+	for _, id := range ids {
+		writes = append(writes, p.pending[id])
+	}
+	return writes
+}
+
+// WaitIdle blocks until no writes are in progress
+func (p *PendingOmniFocusService) WaitIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(p.pending) > 0 {
+		p.idle.Wait()
+	}
+}
+
+// Detach stops waiting for the writes in progress and returns them, along
+// with the writes that failed since the first call to Detach, for another
+// process to finish. It does not block: a write still running may yet reach
+// OmniFocus, so whoever takes them over must allow for that before replaying.
+func (p *PendingOmniFocusService) Detach() []PendingWrite {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.detached = true
+	return append(append([]PendingWrite(nil), p.failed...), p.running()...)
+}
+
+// Invalidate discards cached data of the wrapped service, if it has any
+func (p *PendingOmniFocusService) Invalidate() {
+	if inv, ok := p.OmniFocusService.(Invalidator); ok {
+		inv.Invalidate()
+	}
+}
+
+// CreateTask tracks the wrapped CreateTask
+func (p *PendingOmniFocusService) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	end := p.begin(PendingWrite{Method: "CreateTask", Task: &input})
+	result, err := p.OmniFocusService.CreateTask(ctx, input)
+	end(err)
+	return result, err
+}
+
+// ModifyTask tracks the wrapped ModifyTask
+func (p *PendingOmniFocusService) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
+	end := p.begin(PendingWrite{Method: "ModifyTask", ID: id, Modification: &mod})
+	result, err := p.OmniFocusService.ModifyTask(ctx, id, mod)
+	end(err)
+	return result, err
+}
+
+// ReorderTask tracks the wrapped ReorderTask
+func (p *PendingOmniFocusService) ReorderTask(ctx context.Context, id string, pos domain.TaskPosition) (*domain.OperationResult, error) {
+	end := p.begin(PendingWrite{Method: "ReorderTask", ID: id, Position: &pos})
+	result, err := p.OmniFocusService.ReorderTask(ctx, id, pos)
+	end(err)
+	return result, err
+}
+
+// CompleteTask tracks the wrapped CompleteTask
+func (p *PendingOmniFocusService) CompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	end := p.begin(PendingWrite{Method: "CompleteTask", ID: id})
+	result, err := p.OmniFocusService.CompleteTask(ctx, id)
+	end(err)
+	return result, err
+}
+
+// UncompleteTask tracks the wrapped UncompleteTask
+func (p *PendingOmniFocusService) UncompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	end := p.begin(PendingWrite{Method: "UncompleteTask", ID: id})
+	result, err := p.OmniFocusService.UncompleteTask(ctx, id)
+	end(err)
+	return result, err
+}
+
+// DeleteTask tracks the wrapped DeleteTask
+func (p *PendingOmniFocusService) DeleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	end := p.begin(PendingWrite{Method: "DeleteTask", ID: id})
+	result, err := p.OmniFocusService.DeleteTask(ctx, id)
+	end(err)
+	return result, err
+}
+
+// BatchModify tracks the wrapped BatchModify
+func (p *PendingOmniFocusService) BatchModify(ctx context.Context, ids []string, op domain.BatchOperation) (*domain.BatchResult, error) {
+	end := p.begin(PendingWrite{Method: "BatchModify", IDs: ids, Batch: &op})
+	result, err := p.OmniFocusService.BatchModify(ctx, ids, op)
+	end(err)
+	return result, err
+}
+
+// DropTask tracks the wrapped DropTask
+func (p *PendingOmniFocusService) DropTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	end := p.begin(PendingWrite{Method: "DropTask", ID: id})
+	result, err := p.OmniFocusService.DropTask(ctx, id)
+	end(err)
+	return result, err
+}
+
+// CreateProject tracks the wrapped CreateProject
+func (p *PendingOmniFocusService) CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error) {
+	end := p.begin(PendingWrite{Method: "CreateProject", Project: &input})
+	result, err := p.OmniFocusService.CreateProject(ctx, input)
+	end(err)
+	return result, err
+}
+
+// DropProject tracks the wrapped DropProject
+func (p *PendingOmniFocusService) DropProject(ctx context.Context, id string) (*domain.OperationResult, error) {
+	end := p.begin(PendingWrite{Method: "DropProject", ID: id})
+	result, err := p.OmniFocusService.DropProject(ctx, id)
+	end(err)
+	return result, err
+}
+
+// CreateTag tracks the wrapped CreateTag
+func (p *PendingOmniFocusService) CreateTag(ctx context.Context, name, parentID string) (*domain.Tag, error) {
+	end := p.begin(PendingWrite{Method: "CreateTag", Name: name, ParentID: parentID})
+	result, err := p.OmniFocusService.CreateTag(ctx, name, parentID)
+	end(err)
+	return result, err
+}
+
+// RenameTag tracks the wrapped RenameTag
+func (p *PendingOmniFocusService) RenameTag(ctx context.Context, id, name string) (*domain.Tag, error) {
+	end := p.begin(PendingWrite{Method: "RenameTag", ID: id, Name: name})
+	result, err := p.OmniFocusService.RenameTag(ctx, id, name)
+	end(err)
+	return result, err
+}
+
+// DeleteTag tracks the wrapped DeleteTag
+func (p *PendingOmniFocusService) DeleteTag(ctx context.Context, id string) (*domain.OperationResult, error) {
+	end := p.begin(PendingWrite{Method: "DeleteTag", ID: id})
+	result, err := p.OmniFocusService.DeleteTag(ctx, id)
+	end(err)
+	return result, err
+}
+
+// Describe returns a short human-readable description of the write, naming
+// the device that started it if known
+func (w PendingWrite) Describe() string {
+	if w.Device == "" {
+		return w.describe()
+	}
+	return fmt.Sprintf("%s (from %s)", w.describe(), w.Device)
+}
+
+// describe returns what the write does
+func (w PendingWrite) describe() string {
+	switch w.Method {
+	case "CreateTask":
+		return fmt.Sprintf("create task %q", w.Task.Name)
+	case "CreateProject":
+		return fmt.Sprintf("create project %q", w.Project.Name)
+	case "CreateTag":
+		return fmt.Sprintf("create tag %q", w.Name)
+	case "RenameTag":
+		return fmt.Sprintf("rename tag %s to %q", w.ID, w.Name)
+	case "BatchModify":
+		return fmt.Sprintf("%s %d tasks", w.Batch.Action, len(w.IDs))
+	default:
+		return fmt.Sprintf("%s %s", w.Method, w.ID)
+	}
+}
+
+// Replay performs the write again on svc. Writes whose effect is already
+// visible (a completed task, a deleted task or tag, an existing task, project
+// or tag of the same name) are skipped, since the original call may still
+// have reached OmniFocus; a Rejected create is performed without looking.
+func (w PendingWrite) Replay(ctx context.Context, svc OmniFocusService) error {
+	switch w.Method {
+	case "CreateTask":
+		if w.Task == nil {
+			return fmt.Errorf("create task: missing input")
+		}
+		projectID := w.Task.ProjectID
+		if projectID == "" && w.Task.ProjectName != "" {
+			id, err := svc.ResolveProjectName(ctx, w.Task.ProjectName)
+			if err != nil {
+				return err
+			}
+			projectID = id
+		}
+		if !w.Rejected {
+			tasks, err := svc.GetTaskHierarchy(ctx, projectID)
+			if err != nil {
+				return err
+			}
+			for _, task := range domain.FlattenTasks(tasks) {
+				if task.Name == w.Task.Name && !task.Completed {
+					return nil
+				}
+			}
+		}
+		_, err := svc.CreateTask(ctx, *w.Task)
+		return err
+	case "ModifyTask":
+		if w.Modification == nil {
+			return fmt.Errorf("modify task: missing modification")
+		}
+		_, err := svc.ModifyTask(ctx, w.ID, *w.Modification)
+		return err
+	case "ReorderTask":
+		// Moving next to the same sibling again changes nothing
+		if w.Position == nil {
+			return fmt.Errorf("reorder task: missing position")
+		}
+		_, err := svc.ReorderTask(ctx, w.ID, *w.Position)
+		return err
+	case "CompleteTask":
+		if task, err := svc.GetTaskByID(ctx, w.ID); err != nil || task == nil || task.Completed {
+			return err
+		}
+		_, err := svc.CompleteTask(ctx, w.ID)
+		return err
+	case "UncompleteTask":
+		if task, err := svc.GetTaskByID(ctx, w.ID); err != nil || task == nil || !task.Completed {
+			return err
+		}
+		_, err := svc.UncompleteTask(ctx, w.ID)
+		return err
+	case "DeleteTask":
+		if task, err := svc.GetTaskByID(ctx, w.ID); err != nil || task == nil {
+			// Already gone
+			return nil
+		}
+		_, err := svc.DeleteTask(ctx, w.ID)
+		return err
+	case "DropTask":
+		if task, err := svc.GetTaskByID(ctx, w.ID); err != nil || task == nil {
+			// Already gone; dropping a dropped task again changes nothing
+			return nil
+		}
+		_, err := svc.DropTask(ctx, w.ID)
+		return err
+	case "DropProject":
+		if project, err := svc.GetProjectByID(ctx, w.ID); err != nil || project == nil || project.Status == "dropped" {
+			return err
+		}
+		_, err := svc.DropProject(ctx, w.ID)
+		return err
+	case "BatchModify":
+		if w.Batch == nil {
+			return fmt.Errorf("batch modify: missing operation")
+		}
+		for _, id := range w.IDs {
+			single := PendingWrite{ID: id}
+			switch w.Batch.Action {
+			case domain.BatchComplete:
+				single.Method = "CompleteTask"
+			case domain.BatchDelete:
+				single.Method = "DeleteTask"
+			case domain.BatchDrop:
+				single.Method = "DropTask"
+			default:
+				single.Method = "ModifyTask"
+				single.Modification = &w.Batch.Modification
+			}
+			if err := single.Replay(ctx, svc); err != nil {
+				return fmt.Errorf("task %s: %w", id, err)
+			}
+		}
+		return nil
+	case "CreateProject":
+		if w.Project == nil {
+			return fmt.Errorf("create project: missing input")
+		}
+		if !w.Rejected {
+			projects, err := svc.GetProjects(ctx, "all")
+			if err != nil {
+				return err
+			}
+			for _, project := range projects {
+				if project.Name == w.Project.Name {
+					return nil
+				}
+			}
+		}
+		_, err := svc.CreateProject(ctx, *w.Project)
+		return err
+	case "CreateTag":
+		if !w.Rejected {
+			tags, err := svc.GetTags(ctx)
+			if err != nil {
+				return err
+			}
+			for _, tag := range tags {
+				if strings.EqualFold(tag.Name, w.Name) {
+					return nil
+				}
+			}
+		}
+		_, err := svc.CreateTag(ctx, w.Name, w.ParentID)
+		return err
+	case "RenameTag":
+		_, err := svc.RenameTag(ctx, w.ID, w.Name)
+		return err
+	case "DeleteTag":
+		if tag, err := svc.GetTagByID(ctx, w.ID); err != nil || tag == nil {
+			// Already gone
+			return nil
+		}
+		_, err := svc.DeleteTag(ctx, w.ID)
+		return err
+	default:
+		return fmt.Errorf("unknown write %q", w.Method)
+	}
+}
+
+// WriteJournal saves writes to a file for a later Replay
+func WriteJournal(path string, writes []PendingWrite) error {
+	data, err := json.MarshalIndent(writes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode pending writes: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write pending writes: %w", err)
+	}
+	return nil
+}
+
+// ReadJournal loads writes saved by WriteJournal
+func ReadJournal(path string) ([]PendingWrite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pending writes: %w", err)
+	}
+	var writes []PendingWrite
+	if err := json.Unmarshal(data, &writes); err != nil {
+		return nil, fmt.Errorf("failed to parse pending writes: %w", err)
+	}
+	return writes, nil
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// blockingService holds CompleteTask calls until release is closed
+type blockingService struct {
+	*MockOmniFocusService
+	started   chan string
+	release   chan struct{}
+	completed []string
+}
+
+func (b *blockingService) CompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	if b.started != nil {
+		b.started <- id
+		<-b.release
+	}
+	b.completed = append(b.completed, id)
+	return b.MockOmniFocusService.CompleteTask(ctx, id)
+}
+
+func TestPendingOmniFocusService_TracksWritesInFlight(t *testing.T) {
+	inner := &blockingService{
+		MockOmniFocusService: &MockOmniFocusService{},
+		started:              make(chan string),
+		release:              make(chan struct{}),
+	}
+	svc := NewPendingOmniFocusService(inner)
+	svc.SetDevice("studio-mac")
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = svc.CompleteTask(context.Background(), "task1")
+		close(done)
+	}()
+	<-inner.started
+
+	writes := svc.PendingWrites()
+	if len(writes) != 1 || writes[0].Method != "CompleteTask" || writes[0].ID != "task1" {
+		t.Fatalf("PendingWrites() = %+v, want CompleteTask task1", writes)
+	}
+	if writes[0].Device != "studio-mac" || writes[0].Describe() != "CompleteTask task1 (from studio-mac)" {
+		t.Errorf("PendingWrites()[0] = %+v, want it labelled with the device", writes[0])
+	}
+
+	idle := make(chan struct{})
+	go func() {
+		svc.WaitIdle()
+		close(idle)
+	}()
+	select {
+	case <-idle:
+		t.Fatal("WaitIdle() returned while a write was in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(inner.release)
+	<-done
+	select {
+	case <-idle:
+	case <-time.After(time.Second):
+		t.Fatal("WaitIdle() did not return after the write finished")
+	}
+	if writes := svc.PendingWrites(); len(writes) != 0 {
+		t.Errorf("PendingWrites() = %+v, want none", writes)
+	}
+}
+
+// failingService fails CompleteTask with the error for the ID once it is
+// released, and blocks on IDs without one until the test ends
+type failingService struct {
+	*MockOmniFocusService
+	started chan string
+	release chan struct{}
+	errs    map[string]error
+}
+
+func (f *failingService) CompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	f.started <- id
+	<-f.release
+	if err, ok := f.errs[id]; ok {
+		return nil, err
+	}
+	select {}
+}
+
+func TestPendingOmniFocusService_DetachHandsOverFailedAndRunningWrites(t *testing.T) {
+	inner := &failingService{
+		MockOmniFocusService: &MockOmniFocusService{},
+		started:              make(chan string),
+		release:              make(chan struct{}),
+		errs: map[string]error{
+			"rejected": errors.New("Task not found: rejected"),
+			"timedout": bridge.ErrExecutionTimeout,
+		},
+	}
+	svc := NewPendingOmniFocusService(inner)
+	for _, id := range []string{"rejected", "timedout", "running"} {
+		go func() { _, _ = svc.CompleteTask(context.Background(), id) }()
+		<-inner.started
+	}
+
+	// Detach returns at once with every write still running
+	if writes := svc.Detach(); len(writes) != 3 {
+		t.Fatalf("Detach() = %+v, want the three running writes", writes)
+	}
+
+	// Writes failing after Detach are kept, marked Rejected when OmniFocus
+	// answered with an error
+	close(inner.release)
+	deadline := time.Now().Add(time.Second)
+	for len(svc.PendingWrites()) > 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	writes := svc.Detach()
+	rejected := map[string]bool{}
+	for _, w := range writes {
+		rejected[w.ID] = w.Rejected
+	}
+	if len(writes) != 3 || !rejected["rejected"] || rejected["timedout"] {
+		t.Errorf("Detach() = %+v, want rejected (Rejected), timedout and running", writes)
+	}
+	if _, ok := rejected["running"]; !ok || writes[2].ID != "running" {
+		t.Errorf("Detach() = %+v, want the running write last", writes)
+	}
+}
+
+func TestPendingOmniFocusService_ForwardsInvalidate(t *testing.T) {
+	mock := &MockOmniFocusService{InboxTasks: []domain.Task{{ID: "t1"}}}
+	cached := NewCachedOmniFocusService(mock, time.Minute)
+	svc := NewPendingOmniFocusService(cached)
+
+	if _, err := svc.GetInboxTasks(context.Background()); err != nil {
+		t.Fatalf("GetInboxTasks() error = %v", err)
+	}
+	mock.InboxTasks = []domain.Task{{ID: "t1"}, {ID: "t2"}}
+	svc.Invalidate()
+
+	tasks, err := svc.GetInboxTasks(context.Background())
+	if err != nil {
+		t.Fatalf("GetInboxTasks() error = %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("GetInboxTasks() after Invalidate() = %d tasks, want 2", len(tasks))
+	}
+}
+
+func TestPendingWrite_ReplaySkipsWritesAlreadyApplied(t *testing.T) {
+	tests := []struct {
+		name string
+		task *domain.Task
+		want int
+	}{
+		{name: "open task is completed", task: &domain.Task{ID: "task1"}, want: 1},
+		{name: "completed task is skipped", task: &domain.Task{ID: "task1", Completed: true}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &blockingService{MockOmniFocusService: &MockOmniFocusService{Task: tt.task}}
+
+			if err := (PendingWrite{Method: "CompleteTask", ID: "task1"}).Replay(context.Background(), svc); err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			if len(svc.completed) != tt.want {
+				t.Errorf("CompleteTask() called %d times, want %d", len(svc.completed), tt.want)
+			}
+		})
+	}
+}
+
+func TestPendingWrite_ReplayCreateTaskSkipsExisting(t *testing.T) {
+	mock := &MockOmniFocusService{InboxTasks: []domain.Task{{ID: "t1", Name: "Buy milk"}}}
+
+	if err := (PendingWrite{Method: "CreateTask", Task: &domain.TaskInput{Name: "Buy milk"}}).Replay(context.Background(), mock); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if err := (PendingWrite{Method: "CreateTask", Task: &domain.TaskInput{Name: "Call Bob"}}).Replay(context.Background(), mock); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	if len(mock.CreatedInputs) != 1 || mock.CreatedInputs[0].Name != "Call Bob" {
+		t.Errorf("CreateTask() inputs = %+v, want only Call Bob", mock.CreatedInputs)
+	}
+}
+
+func TestPendingWrite_ReplayRejectedCreateTaskDoesNotLook(t *testing.T) {
+	mock := &MockOmniFocusService{InboxTasks: []domain.Task{{ID: "t1", Name: "Buy milk"}}}
+
+	w := PendingWrite{Method: "CreateTask", Task: &domain.TaskInput{Name: "Buy milk"}, Rejected: true}
+	if err := w.Replay(context.Background(), mock); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	if len(mock.CreatedInputs) != 1 {
+		t.Errorf("CreateTask() inputs = %+v, want a second Buy milk", mock.CreatedInputs)
+	}
+}
+
+func TestJournal_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pending.json")
+	flagged := true
+	writes := []PendingWrite{
+		{Method: "ModifyTask", ID: "task1", Modification: &domain.TaskModification{Flagged: &flagged}},
+		{Method: "BatchModify", IDs: []string{"a", "b"}, Batch: &domain.BatchOperation{Action: domain.BatchComplete}, Device: "studio-mac"},
+	}
+
+	if err := WriteJournal(path, writes); err != nil {
+		t.Fatalf("WriteJournal() error = %v", err)
+	}
+	got, err := ReadJournal(path)
+	if err != nil {
+		t.Fatalf("ReadJournal() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("ReadJournal() = %d writes, want 2", len(got))
+	}
+	if got[0].Modification == nil || got[0].Modification.Flagged == nil || !*got[0].Modification.Flagged {
+		t.Errorf("ReadJournal()[0] = %+v, want flagged modification", got[0])
+	}
+	if got[1].Batch == nil || got[1].Batch.Action != domain.BatchComplete || len(got[1].IDs) != 2 || got[1].Device != "studio-mac" {
+		t.Errorf("ReadJournal()[1] = %+v, want batch complete of 2 tasks from studio-mac", got[1])
+	}
+}
+
+func TestPendingWrite_ReplayReorderTask(t *testing.T) {
+	mock := &MockOmniFocusService{}
+	pos := domain.TaskPosition{SiblingID: "task2", After: true}
+
+	if err := (PendingWrite{Method: "ReorderTask", ID: "task1", Position: &pos}).Replay(context.Background(), mock); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if mock.ReorderID != "task1" || mock.ReorderPosition == nil || *mock.ReorderPosition != pos {
+		t.Errorf("ReorderTask() got %s %+v, want task1 %+v", mock.ReorderID, mock.ReorderPosition, pos)
+	}
+}
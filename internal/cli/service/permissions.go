@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Scope limits which calls a ScopedOmniFocusService lets through
+type Scope string
+
+// Scopes that can be granted to an API token
+const (
+	ScopeReadOnly   Scope = "read-only"   // Read tasks, projects, tags and perspectives
+	ScopeCreateOnly Scope = "create-only" // Create tasks, projects and tags; nothing else
+	ScopeFull       Scope = "full"        // Every call
+)
+
+// ParseScope returns the scope with the given name
+func ParseScope(name string) (Scope, error) {
+	switch scope := Scope(strings.ToLower(strings.TrimSpace(name))); scope {
+	case ScopeReadOnly, ScopeCreateOnly, ScopeFull:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("unknown scope %q (must be %s, %s or %s)", name, ScopeReadOnly, ScopeCreateOnly, ScopeFull)
+	}
+}
+
+// access is the kind of call a scope is checked against
+type access int
+
+const (
+	accessRead access = iota
+	accessCreate
+	accessChange // Modifying, completing or deleting existing items
+)
+
+// allows reports whether the scope permits calls of the given kind
+func (s Scope) allows(a access) bool {
+	switch s {
+	case ScopeFull:
+		return true
+	case ScopeReadOnly:
+		return a == accessRead
+	case ScopeCreateOnly:
+		return a == accessCreate
+	default:
+		return false
+	}
+}
+
+// PermissionError is returned for calls the scope does not permit
+type PermissionError struct {
+	Scope  Scope
+	Method string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("permission denied: %s is not allowed with %s access", e.Method, e.Scope)
+}
+
+// ScopedOmniFocusService decorates an OmniFocusService and rejects the calls
+// its scope does not permit, without reaching the wrapped service. The wrapped
+// service is a named field rather than embedded, so a method added to the
+// interface does not compile until it is given a permission check here.
+type ScopedOmniFocusService struct {
+	svc   OmniFocusService
+	scope Scope
+}
+
+// NewScopedOmniFocusService wraps the given service to permit only calls within scope
+func NewScopedOmniFocusService(svc OmniFocusService, scope Scope) *ScopedOmniFocusService {
+	return &ScopedOmniFocusService{svc: svc, scope: scope}
+}
+
+// Scope returns the scope the service enforces
+func (s *ScopedOmniFocusService) Scope() Scope {
+	return s.scope
+}
+
+// check returns a PermissionError if the scope does not permit the call
+func (s *ScopedOmniFocusService) check(a access, method string) error {
+	if !s.scope.allows(a) {
+		return &PermissionError{Scope: s.scope, Method: method}
+	}
+	return nil
+}
+
+// GetInboxTasks requires read access
+func (s *ScopedOmniFocusService) GetInboxTasks(ctx context.Context) ([]domain.Task, error) {
+	if err := s.check(accessRead, "GetInboxTasks"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetInboxTasks(ctx)
+}
+
+// GetAllTasks requires read access
+func (s *ScopedOmniFocusService) GetAllTasks(ctx context.Context, filters TaskFilters) ([]domain.Task, error) {
+	if err := s.check(accessRead, "GetAllTasks"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetAllTasks(ctx, filters)
+}
+
+// StreamAllTasks requires read access
+func (s *ScopedOmniFocusService) StreamAllTasks(ctx context.Context, filters TaskFilters, yield func(domain.Task) error) error {
+	if err := s.check(accessRead, "StreamAllTasks"); err != nil {
+		return err
+	}
+	return s.svc.StreamAllTasks(ctx, filters, yield)
+}
+
+// GetTasksByProject requires read access
+func (s *ScopedOmniFocusService) GetTasksByProject(ctx context.Context, projectID string) ([]domain.Task, error) {
+	if err := s.check(accessRead, "GetTasksByProject"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetTasksByProject(ctx, projectID)
+}
+
+// GetTasksByTag requires read access
+func (s *ScopedOmniFocusService) GetTasksByTag(ctx context.Context, tagID string) ([]domain.Task, error) {
+	if err := s.check(accessRead, "GetTasksByTag"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetTasksByTag(ctx, tagID)
+}
+
+// GetFlaggedTasks requires read access
+func (s *ScopedOmniFocusService) GetFlaggedTasks(ctx context.Context) ([]domain.Task, error) {
+	if err := s.check(accessRead, "GetFlaggedTasks"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetFlaggedTasks(ctx)
+}
+
+// SearchTasks requires read access
+func (s *ScopedOmniFocusService) SearchTasks(ctx context.Context, query string) ([]domain.Task, error) {
+	if err := s.check(accessRead, "SearchTasks"); err != nil {
+		return nil, err
+	}
+	return s.svc.SearchTasks(ctx, query)
+}
+
+// GetCompletedTasks requires read access
+func (s *ScopedOmniFocusService) GetCompletedTasks(ctx context.Context, since time.Time) ([]domain.Task, error) {
+	if err := s.check(accessRead, "GetCompletedTasks"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetCompletedTasks(ctx, since)
+}
+
+// GetTaskByID requires read access
+func (s *ScopedOmniFocusService) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
+	if err := s.check(accessRead, "GetTaskByID"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetTaskByID(ctx, id)
+}
+
+// GetTaskHierarchy requires read access
+func (s *ScopedOmniFocusService) GetTaskHierarchy(ctx context.Context, projectID string) ([]domain.Task, error) {
+	if err := s.check(accessRead, "GetTaskHierarchy"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetTaskHierarchy(ctx, projectID)
+}
+
+// CreateTask requires create access
+func (s *ScopedOmniFocusService) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	if err := s.check(accessCreate, "CreateTask"); err != nil {
+		return nil, err
+	}
+	return s.svc.CreateTask(ctx, input)
+}
+
+// ModifyTask requires full access
+func (s *ScopedOmniFocusService) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
+	if err := s.check(accessChange, "ModifyTask"); err != nil {
+		return nil, err
+	}
+	return s.svc.ModifyTask(ctx, id, mod)
+}
+
+// ReorderTask requires full access
+func (s *ScopedOmniFocusService) ReorderTask(ctx context.Context, id string, pos domain.TaskPosition) (*domain.OperationResult, error) {
+	if err := s.check(accessChange, "ReorderTask"); err != nil {
+		return nil, err
+	}
+	return s.svc.ReorderTask(ctx, id, pos)
+}
+
+// CompleteTask requires full access
+func (s *ScopedOmniFocusService) CompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	if err := s.check(accessChange, "CompleteTask"); err != nil {
+		return nil, err
+	}
+	return s.svc.CompleteTask(ctx, id)
+}
+
+// UncompleteTask requires full access
+func (s *ScopedOmniFocusService) UncompleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	if err := s.check(accessChange, "UncompleteTask"); err != nil {
+		return nil, err
+	}
+	return s.svc.UncompleteTask(ctx, id)
+}
+
+// DeleteTask requires full access
+func (s *ScopedOmniFocusService) DeleteTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	if err := s.check(accessChange, "DeleteTask"); err != nil {
+		return nil, err
+	}
+	return s.svc.DeleteTask(ctx, id)
+}
+
+// DropTask requires full access
+func (s *ScopedOmniFocusService) DropTask(ctx context.Context, id string) (*domain.OperationResult, error) {
+	if err := s.check(accessChange, "DropTask"); err != nil {
+		return nil, err
+	}
+	return s.svc.DropTask(ctx, id)
+}
+
+// BatchModify requires full access
+func (s *ScopedOmniFocusService) BatchModify(ctx context.Context, ids []string, op domain.BatchOperation) (*domain.BatchResult, error) {
+	if err := s.check(accessChange, "BatchModify"); err != nil {
+		return nil, err
+	}
+	return s.svc.BatchModify(ctx, ids, op)
+}
+
+// GetProjects requires read access
+func (s *ScopedOmniFocusService) GetProjects(ctx context.Context, status string) ([]domain.Project, error) {
+	if err := s.check(accessRead, "GetProjects"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetProjects(ctx, status)
+}
+
+// GetProjectByID requires read access
+func (s *ScopedOmniFocusService) GetProjectByID(ctx context.Context, id string) (*domain.Project, error) {
+	if err := s.check(accessRead, "GetProjectByID"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetProjectByID(ctx, id)
+}
+
+// GetProjectWithTasks requires read access
+func (s *ScopedOmniFocusService) GetProjectWithTasks(ctx context.Context, id string) (*domain.Project, error) {
+	if err := s.check(accessRead, "GetProjectWithTasks"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetProjectWithTasks(ctx, id)
+}
+
+// CreateProject requires create access
+func (s *ScopedOmniFocusService) CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error) {
+	if err := s.check(accessCreate, "CreateProject"); err != nil {
+		return nil, err
+	}
+	return s.svc.CreateProject(ctx, input)
+}
+
+// DropProject requires full access
+func (s *ScopedOmniFocusService) DropProject(ctx context.Context, id string) (*domain.OperationResult, error) {
+	if err := s.check(accessChange, "DropProject"); err != nil {
+		return nil, err
+	}
+	return s.svc.DropProject(ctx, id)
+}
+
+// GetFolders requires read access
+func (s *ScopedOmniFocusService) GetFolders(ctx context.Context) ([]domain.Folder, error) {
+	if err := s.check(accessRead, "GetFolders"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetFolders(ctx)
+}
+
+// GetAttachments requires read access
+func (s *ScopedOmniFocusService) GetAttachments(ctx context.Context, projectID string, opts AttachmentOptions) ([]domain.Attachment, error) {
+	if err := s.check(accessRead, "GetAttachments"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetAttachments(ctx, projectID, opts)
+}
+
+// GetTags requires read access
+func (s *ScopedOmniFocusService) GetTags(ctx context.Context) ([]domain.Tag, error) {
+	if err := s.check(accessRead, "GetTags"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetTags(ctx)
+}
+
+// GetTagByID requires read access
+func (s *ScopedOmniFocusService) GetTagByID(ctx context.Context, id string) (*domain.Tag, error) {
+	if err := s.check(accessRead, "GetTagByID"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetTagByID(ctx, id)
+}
+
+// GetTagCounts requires read access
+func (s *ScopedOmniFocusService) GetTagCounts(ctx context.Context) (map[string]int, error) {
+	if err := s.check(accessRead, "GetTagCounts"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetTagCounts(ctx)
+}
+
+// CreateTag requires create access
+func (s *ScopedOmniFocusService) CreateTag(ctx context.Context, name, parentID string) (*domain.Tag, error) {
+	if err := s.check(accessCreate, "CreateTag"); err != nil {
+		return nil, err
+	}
+	return s.svc.CreateTag(ctx, name, parentID)
+}
+
+// RenameTag requires full access
+func (s *ScopedOmniFocusService) RenameTag(ctx context.Context, id, name string) (*domain.Tag, error) {
+	if err := s.check(accessChange, "RenameTag"); err != nil {
+		return nil, err
+	}
+	return s.svc.RenameTag(ctx, id, name)
+}
+
+// DeleteTag requires full access
+func (s *ScopedOmniFocusService) DeleteTag(ctx context.Context, id string) (*domain.OperationResult, error) {
+	if err := s.check(accessChange, "DeleteTag"); err != nil {
+		return nil, err
+	}
+	return s.svc.DeleteTag(ctx, id)
+}
+
+// GetPerspectiveTasks requires read access
+func (s *ScopedOmniFocusService) GetPerspectiveTasks(ctx context.Context, name string) ([]domain.Task, error) {
+	if err := s.check(accessRead, "GetPerspectiveTasks"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetPerspectiveTasks(ctx, name)
+}
+
+// GetPerspectiveRules requires read access
+func (s *ScopedOmniFocusService) GetPerspectiveRules(ctx context.Context, name string) (*domain.PerspectiveRules, error) {
+	if err := s.check(accessRead, "GetPerspectiveRules"); err != nil {
+		return nil, err
+	}
+	return s.svc.GetPerspectiveRules(ctx, name)
+}
+
+// ResolveProjectName requires read or create access, since tasks are often
+// created in a project given by name
+func (s *ScopedOmniFocusService) ResolveProjectName(ctx context.Context, name string) (string, error) {
+	if !s.scope.allows(accessRead) && !s.scope.allows(accessCreate) {
+		return "", &PermissionError{Scope: s.scope, Method: "ResolveProjectName"}
+	}
+	return s.svc.ResolveProjectName(ctx, name)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Compile-time check that ScopedOmniFocusService implements OmniFocusService
+var _ OmniFocusService = (*ScopedOmniFocusService)(nil)
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Scope
+		wantErr bool
+	}{
+		{name: "read-only", want: ScopeReadOnly},
+		{name: "Create-Only", want: ScopeCreateOnly},
+		{name: " full ", want: ScopeFull},
+		{name: "admin", wantErr: true},
+		{name: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScope(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseScope(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseScope(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopedService(t *testing.T) {
+	calls := map[string]func(OmniFocusService) error{
+		"read": func(svc OmniFocusService) error {
+			_, err := svc.GetInboxTasks(context.Background())
+			return err
+		},
+		"create": func(svc OmniFocusService) error {
+			_, err := svc.CreateTask(context.Background(), domain.TaskInput{Name: "Buy milk"})
+			return err
+		},
+		"resolve": func(svc OmniFocusService) error {
+			_, err := svc.ResolveProjectName(context.Background(), "Home")
+			return err
+		},
+		"complete": func(svc OmniFocusService) error {
+			_, err := svc.CompleteTask(context.Background(), "task1")
+			return err
+		},
+		"delete": func(svc OmniFocusService) error {
+			_, err := svc.DeleteTask(context.Background(), "task1")
+			return err
+		},
+		"drop": func(svc OmniFocusService) error {
+			_, err := svc.DropTask(context.Background(), "task1")
+			return err
+		},
+		"delete tag": func(svc OmniFocusService) error {
+			_, err := svc.DeleteTag(context.Background(), "tag1")
+			return err
+		},
+	}
+
+	tests := []struct {
+		scope   Scope
+		allowed map[string]bool
+	}{
+		{scope: ScopeReadOnly, allowed: map[string]bool{"read": true, "resolve": true}},
+		{scope: ScopeCreateOnly, allowed: map[string]bool{"create": true, "resolve": true}},
+		{scope: ScopeFull, allowed: map[string]bool{"read": true, "create": true, "resolve": true, "complete": true, "delete": true, "drop": true, "delete tag": true}},
+	}
+
+	for _, tt := range tests {
+		for name, call := range calls {
+			t.Run(string(tt.scope)+"/"+name, func(t *testing.T) {
+				inner := &MockOmniFocusService{
+					CreatedTask:    &domain.Task{ID: "task1"},
+					CompleteResult: &domain.OperationResult{Success: true},
+					DeleteResult:   &domain.OperationResult{Success: true},
+				}
+				svc := NewScopedOmniFocusService(inner, tt.scope)
+
+				err := call(svc)
+
+				var permErr *PermissionError
+				denied := errors.As(err, &permErr)
+				if denied == tt.allowed[name] {
+					t.Errorf("%s with %s scope: error = %v, want allowed %v", name, tt.scope, err, tt.allowed[name])
+				}
+			})
+		}
+	}
+}
+
+func TestScopedService_DeniedCallDoesNotReachService(t *testing.T) {
+	inner := &MockOmniFocusService{}
+	svc := NewScopedOmniFocusService(inner, ScopeCreateOnly)
+
+	_, err := svc.ModifyTask(context.Background(), "task1", domain.TaskModification{})
+
+	if err == nil || err.Error() != "permission denied: ModifyTask is not allowed with create-only access" {
+		t.Errorf("ModifyTask() error = %v, want permission denied", err)
+	}
+	if len(inner.Modifications) != 0 {
+		t.Errorf("ModifyTask() reached the wrapped service: %+v", inner.Modifications)
+	}
+}
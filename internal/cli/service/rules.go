@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/rules"
+)
+
+// RulesOmniFocusService decorates an OmniFocusService and applies the
+// user-defined rules to every task it creates. OmniFocus automation only
+// applies the first tag when a task is created, so tags added by rules that
+// did not make it onto the new task are added with a follow-up modification.
+type RulesOmniFocusService struct {
+	OmniFocusService
+
+	engine *rules.Engine
+}
+
+// NewRulesOmniFocusService wraps the given service so that created tasks pass through the rules engine
+func NewRulesOmniFocusService(svc OmniFocusService, engine *rules.Engine) *RulesOmniFocusService {
+	return &RulesOmniFocusService{
+		OmniFocusService: svc,
+		engine:           engine,
+	}
+}
+
+// CreateTask applies matching rules to the input before creating the task
+func (r *RulesOmniFocusService) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	ruled, matched := r.engine.ApplyToInput(input)
+
+	task, err := r.OmniFocusService.CreateTask(ctx, ruled)
+	if err != nil || len(matched) == 0 {
+		return task, err
+	}
+
+	var missing []string
+	for _, tag := range ruled.TagNames {
+		if !containsTagFold(input.TagNames, tag) && !containsTagFold(task.Tags, tag) {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) == 0 {
+		return task, nil
+	}
+
+	modified, err := r.OmniFocusService.ModifyTask(ctx, task.ID, domain.TaskModification{AddTags: missing})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply rule tags: %w", err)
+	}
+	return modified, nil
+}
+
+// containsTagFold reports whether tags contains name, ignoring case
+func containsTagFold(tags []string, name string) bool {
+	for _, tag := range tags {
+		if strings.EqualFold(tag, name) {
+			return true
+		}
+	}
+	return false
+}
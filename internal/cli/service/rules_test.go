@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/rules"
+)
+
+// Compile-time check that RulesOmniFocusService implements OmniFocusService
+var _ OmniFocusService = (*RulesOmniFocusService)(nil)
+
+func newTestRulesService(t *testing.T, inner OmniFocusService) *RulesOmniFocusService {
+	t.Helper()
+
+	engine, err := rules.New([]config.RuleConfig{{
+		Name:    "Phone calls",
+		Match:   config.RuleMatchConfig{Name: "call"},
+		Actions: config.RuleActionsConfig{AddTags: []string{"phone"}, Flag: true},
+	}})
+	if err != nil {
+		t.Fatalf("rules.New() error = %v", err)
+	}
+	return NewRulesOmniFocusService(inner, engine)
+}
+
+func TestRulesService_CreateTask_AppliesRules(t *testing.T) {
+	inner := &MockOmniFocusService{
+		CreatedTask:  &domain.Task{ID: "task1", Name: "Call mom", Tags: []string{"family"}},
+		ModifiedTask: &domain.Task{ID: "task1", Name: "Call mom", Tags: []string{"family", "phone"}},
+	}
+	svc := newTestRulesService(t, inner)
+
+	task, err := svc.CreateTask(context.Background(), domain.TaskInput{Name: "Call mom", TagNames: []string{"family"}})
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	if inner.CreateInput == nil || inner.CreateInput.Flagged == nil || !*inner.CreateInput.Flagged {
+		t.Errorf("CreateTask() input = %+v, want flagged by rule", inner.CreateInput)
+	}
+	mod, ok := inner.Modifications["task1"]
+	if !ok {
+		t.Fatal("expected ModifyTask() to add the rule tag")
+	}
+	if strings.Join(mod.AddTags, ",") != "phone" {
+		t.Errorf("ModifyTask() AddTags = %v, want [phone]", mod.AddTags)
+	}
+	if len(task.Tags) != 2 {
+		t.Errorf("CreateTask() tags = %v, want tags from the modified task", task.Tags)
+	}
+}
+
+func TestRulesService_CreateTask_NoMatch(t *testing.T) {
+	inner := &MockOmniFocusService{CreatedTask: &domain.Task{ID: "task1", Name: "Buy milk"}}
+	svc := newTestRulesService(t, inner)
+
+	if _, err := svc.CreateTask(context.Background(), domain.TaskInput{Name: "Buy milk"}); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	if inner.CreateInput.Flagged != nil || len(inner.CreateInput.TagNames) != 0 {
+		t.Errorf("CreateTask() input = %+v, want input unchanged", inner.CreateInput)
+	}
+	if len(inner.Modifications) != 0 {
+		t.Errorf("ModifyTask() calls = %v, want none", inner.Modifications)
+	}
+}
+
+func TestRulesService_CreateTask_TagFollowUpError(t *testing.T) {
+	inner := &MockOmniFocusService{
+		CreatedTask:   &domain.Task{ID: "task1", Name: "Call mom"},
+		ModifyTaskErr: errors.New("boom"),
+	}
+	svc := newTestRulesService(t, inner)
+
+	_, err := svc.CreateTask(context.Background(), domain.TaskInput{Name: "Call mom"})
+	if err == nil || !strings.Contains(err.Error(), "failed to apply rule tags") {
+		t.Errorf("CreateTask() error = %v, want rule tag error", err)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Step is one script of an operation that takes several, such as moving a
+// task and then tagging it
+type Step struct {
+	Label string // What the step does, e.g. "retagging \"Pay rent\""
+	Run   func(ctx context.Context) error
+}
+
+// StepProgress reports the step about to run
+type StepProgress struct {
+	Index int // 1-based
+	Total int
+	Label string
+}
+
+// String formats the progress as shown to the user, e.g. "2/4: retagging…"
+func (p StepProgress) String() string {
+	return fmt.Sprintf("%d/%d: %s…", p.Index, p.Total, p.Label)
+}
+
+// PartialFailureError is returned when a step fails after earlier steps of
+// the same operation already changed OmniFocus
+type PartialFailureError struct {
+	Done   []string // Labels of the steps that succeeded, in order
+	Failed string   // Label of the step that failed
+	Total  int
+	Err    error
+}
+
+func (e *PartialFailureError) Error() string {
+	return fmt.Sprintf("%s failed after %d of %d steps (done: %s): %v",
+		e.Failed, len(e.Done), e.Total, strings.Join(e.Done, ", "), e.Err)
+}
+
+// Unwrap returns the failure of the step
+func (e *PartialFailureError) Unwrap() error {
+	return e.Err
+}
+
+// RunSteps runs steps in order, calling progress before each one, and stops
+// at the first failure since later steps build on earlier ones. A failure of
+// the first step is returned as is; a later one as a *PartialFailureError
+// naming the steps already done.
+func RunSteps(ctx context.Context, steps []Step, progress func(StepProgress)) error {
+	done := make([]string, 0, len(steps))
+	for i, step := range steps {
+		if progress != nil {
+			progress(StepProgress{Index: i + 1, Total: len(steps), Label: step.Label})
+		}
+		if err := step.Run(ctx); err != nil {
+			if len(done) == 0 {
+				return fmt.Errorf("%s: %w", step.Label, err)
+			}
+			return &PartialFailureError{Done: done, Failed: step.Label, Total: len(steps), Err: err}
+		}
+		done = append(done, step.Label)
+	}
+	return nil
+}
+
+// MoveTaskSteps returns the steps moving a task to a project and then adding
+// tags to it, one tag per step since OmniFocus automation applies only the
+// first tag of a modification. An empty projectID only adds the tags.
+func MoveTaskSteps(svc OmniFocusService, task domain.Task, projectID string, tags []string) []Step {
+	var steps []Step
+	if projectID != "" {
+		steps = append(steps, Step{
+			Label: fmt.Sprintf("moving %q", task.Name),
+			Run: func(ctx context.Context) error {
+				_, err := svc.ModifyTask(ctx, task.ID, domain.TaskModification{ProjectID: &projectID})
+				return err
+			},
+		})
+	}
+	for _, tag := range tags {
+		steps = append(steps, Step{
+			Label: fmt.Sprintf("tagging %q #%s", task.Name, tag),
+			Run: func(ctx context.Context) error {
+				_, err := svc.ModifyTask(ctx, task.ID, domain.TaskModification{AddTags: []string{tag}})
+				return err
+			},
+		})
+	}
+	return steps
+}
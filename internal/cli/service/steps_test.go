@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestRunSteps_ReportsProgress(t *testing.T) {
+	var ran []string
+	step := func(label string) Step {
+		return Step{Label: label, Run: func(context.Context) error { ran = append(ran, label); return nil }}
+	}
+	var progress []string
+
+	err := RunSteps(context.Background(), []Step{step("moving"), step("retagging")}, func(p StepProgress) {
+		progress = append(progress, p.String())
+	})
+
+	if err != nil {
+		t.Fatalf("RunSteps() error = %v", err)
+	}
+	if strings.Join(progress, "|") != "1/2: moving…|2/2: retagging…" {
+		t.Errorf("progress = %v, want [1/2: moving… 2/2: retagging…]", progress)
+	}
+	if strings.Join(ran, ",") != "moving,retagging" {
+		t.Errorf("ran = %v, want both steps in order", ran)
+	}
+}
+
+func TestRunSteps_FirstStepFails(t *testing.T) {
+	boom := errors.New("boom")
+	ranSecond := false
+
+	err := RunSteps(context.Background(), []Step{
+		{Label: "moving", Run: func(context.Context) error { return boom }},
+		{Label: "retagging", Run: func(context.Context) error { ranSecond = true; return nil }},
+	}, nil)
+
+	var partial *PartialFailureError
+	if errors.As(err, &partial) {
+		t.Errorf("RunSteps() = %v, want a plain error when nothing was done", err)
+	}
+	if !errors.Is(err, boom) || !strings.Contains(err.Error(), "moving") {
+		t.Errorf("RunSteps() = %v, want boom naming the step", err)
+	}
+	if ranSecond {
+		t.Error("RunSteps() ran a step after a failure")
+	}
+}
+
+func TestRunSteps_PartialFailure(t *testing.T) {
+	boom := errors.New("boom")
+
+	err := RunSteps(context.Background(), []Step{
+		{Label: "moving", Run: func(context.Context) error { return nil }},
+		{Label: "retagging", Run: func(context.Context) error { return boom }},
+		{Label: "flagging", Run: func(context.Context) error { return nil }},
+	}, nil)
+
+	var partial *PartialFailureError
+	if !errors.As(err, &partial) {
+		t.Fatalf("RunSteps() = %v, want *PartialFailureError", err)
+	}
+	if partial.Failed != "retagging" || len(partial.Done) != 1 || partial.Done[0] != "moving" || partial.Total != 3 {
+		t.Errorf("PartialFailureError = %+v, want retagging failed after moving of 3", partial)
+	}
+	if !errors.Is(err, boom) {
+		t.Error("PartialFailureError should unwrap to the step failure")
+	}
+	if want := "retagging failed after 1 of 3 steps (done: moving): boom"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestMoveTaskSteps(t *testing.T) {
+	mock := &MockOmniFocusService{}
+	task := domain.Task{ID: "t1", Name: "Pay rent"}
+
+	steps := MoveTaskSteps(mock, task, "p1", []string{"home", "money"})
+
+	if len(steps) != 3 {
+		t.Fatalf("MoveTaskSteps() returned %d steps, want 3", len(steps))
+	}
+	wantLabels := []string{`moving "Pay rent"`, `tagging "Pay rent" #home`, `tagging "Pay rent" #money`}
+	for i, step := range steps {
+		if step.Label != wantLabels[i] {
+			t.Errorf("step %d label = %q, want %q", i, step.Label, wantLabels[i])
+		}
+	}
+
+	if err := steps[0].Run(context.Background()); err != nil {
+		t.Fatalf("move step error = %v", err)
+	}
+	if mod := mock.Modifications["t1"]; mod.ProjectID == nil || *mod.ProjectID != "p1" {
+		t.Errorf("move step modification = %+v, want project p1", mod)
+	}
+	if err := steps[2].Run(context.Background()); err != nil {
+		t.Fatalf("tag step error = %v", err)
+	}
+	if mod := mock.Modifications["t1"]; len(mod.AddTags) != 1 || mod.AddTags[0] != "money" {
+		t.Errorf("tag step modification = %+v, want one tag money", mod)
+	}
+}
+
+func TestMoveTaskSteps_TagsOnly(t *testing.T) {
+	steps := MoveTaskSteps(&MockOmniFocusService{}, domain.Task{ID: "t1", Name: "Pay rent"}, "", []string{"home"})
+
+	if len(steps) != 1 || !strings.HasPrefix(steps[0].Label, "tagging") {
+		t.Errorf("MoveTaskSteps() = %v, want only a tagging step", steps)
+	}
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pwojciechowski/lazyfocus/internal/shortcuts"
+	"github.com/spf13/cobra"
+)
+
+// runExternal runs a command to completion; tests replace it
+var runExternal = func(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil && len(out) > 0 {
+		return fmt.Errorf("%w: %s", err, out)
+	}
+	return err
+}
+
+// NewShortcutsCommand creates the shortcuts command
+func NewShortcutsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shortcuts",
+		Short: "Add Shortcuts.app shortcuts that run lazyfocus",
+		Long: `Manage Shortcuts.app shortcuts that run lazyfocus, so Siri, the menu bar and
+the Shortcuts widgets can add, list and complete OmniFocus tasks.`,
+		Annotations: map[string]string{
+			"skipServiceSetup": "true",
+		},
+	}
+
+	cmd.AddCommand(newShortcutsInstallCommand())
+
+	return cmd
+}
+
+// newShortcutsInstallCommand creates the shortcuts install subcommand
+func newShortcutsInstallCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Generate the lazyfocus shortcuts and import them into Shortcuts.app",
+		Long: `Generate these shortcuts, each calling this lazyfocus binary with
+--shortcut-output and showing its result:
+
+  Add to OmniFocus       asks what to add, then runs lazyfocus add
+  Today's Tasks          runs lazyfocus tasks --all --due today
+  Complete in OmniFocus  asks which task you finished, then runs lazyfocus done --yes
+
+Each shortcut is signed with the macOS shortcuts tool and opened, and
+Shortcuts.app asks whether to add it. With --dir the signed files are written
+there and not opened, e.g. to share them.`,
+		Example: `  lazyfocus shortcuts install
+  lazyfocus shortcuts install --dir ~/Desktop`,
+		Args: cobra.NoArgs,
+		Annotations: map[string]string{
+			"skipServiceSetup": "true",
+		},
+		RunE: runShortcutsInstall,
+	}
+
+	cmd.Flags().String("dir", "", "Write the signed shortcuts to this directory instead of importing them")
+	cmd.Flags().String("binary", "", "Path of the lazyfocus binary the shortcuts run (default: this binary)")
+
+	return cmd
+}
+
+func runShortcutsInstall(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	binary, _ := cmd.Flags().GetString("binary")
+
+	if _, err := lookPath("shortcuts"); err != nil {
+		return handleError(cmd, fmt.Errorf("the shortcuts tool was not found; it comes with macOS 12 or later"))
+	}
+
+	if binary == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to find the lazyfocus binary: %w", err))
+		}
+		binary = exe
+	}
+	binary, err := filepath.Abs(binary)
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to resolve %s: %w", binary, err))
+	}
+
+	importing := dir == ""
+	if importing {
+		dir, err = os.MkdirTemp("", "lazyfocus-shortcuts-")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to create directory for shortcuts: %w", err))
+		}
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		return handleError(cmd, fmt.Errorf("failed to create %s: %w", dir, err))
+	}
+
+	unsignedDir, err := os.MkdirTemp("", "lazyfocus-shortcuts-unsigned-")
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to create directory for shortcuts: %w", err))
+	}
+	defer os.RemoveAll(unsignedDir)
+
+	for _, shortcut := range shortcuts.Builtin {
+		unsigned := filepath.Join(unsignedDir, shortcut.FileName())
+		if err := os.WriteFile(unsigned, shortcut.Plist(binary), 0o644); err != nil {
+			return handleError(cmd, fmt.Errorf("failed to write %s: %w", unsigned, err))
+		}
+
+		signed := filepath.Join(dir, shortcut.FileName())
+		if err := runExternal("shortcuts", "sign", "--mode", "anyone", "--input", unsigned, "--output", signed); err != nil {
+			return handleError(cmd, fmt.Errorf("failed to sign %q: %w", shortcut.Name, err))
+		}
+
+		if !importing {
+			if !GetQuietFlag() {
+				cmd.Printf("Wrote %s\n", signed)
+			}
+			continue
+		}
+		if err := runExternal("open", signed); err != nil {
+			return handleError(cmd, fmt.Errorf("failed to open %q in Shortcuts: %w", shortcut.Name, err))
+		}
+		if !GetQuietFlag() {
+			cmd.Printf("Opened %q in Shortcuts; confirm to add it\n", shortcut.Name)
+		}
+	}
+
+	return nil
+}
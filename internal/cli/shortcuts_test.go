@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func executeShortcutsCommand(args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewShortcutsCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs(append([]string{"shortcuts"}, args...))
+
+	err := rootCmd.ExecuteContext(context.Background())
+	return buf.String(), err
+}
+
+// stubShortcutsTools finds the shortcuts tool and records the commands run
+// instead of running them
+func stubShortcutsTools(t *testing.T) *[][]string {
+	t.Helper()
+	var calls [][]string
+	originalLookPath, originalRun := lookPath, runExternal
+	lookPath = func(string) (string, error) { return "/usr/bin/shortcuts", nil }
+	runExternal = func(name string, args ...string) error {
+		calls = append(calls, append([]string{name}, args...))
+		return nil
+	}
+	t.Cleanup(func() { lookPath, runExternal = originalLookPath, originalRun })
+	return &calls
+}
+
+func TestShortcutsInstall_SignsAndOpens(t *testing.T) {
+	calls := stubShortcutsTools(t)
+
+	output, err := executeShortcutsCommand("install", "--binary", "/usr/local/bin/lazyfocus")
+	if err != nil {
+		t.Fatalf("shortcuts install error = %v", err)
+	}
+
+	if len(*calls) != 6 {
+		t.Fatalf("ran %d commands, want 6 (sign and open for 3 shortcuts): %v", len(*calls), *calls)
+	}
+	sign, open := (*calls)[0], (*calls)[1]
+	if sign[0] != "shortcuts" || sign[1] != "sign" || !strings.HasSuffix(sign[len(sign)-1], "Add to OmniFocus.shortcut") {
+		t.Errorf("first command = %v, want shortcuts sign of Add to OmniFocus", sign)
+	}
+	if open[0] != "open" || open[1] != sign[len(sign)-1] {
+		t.Errorf("second command = %v, want open %s", open, sign[len(sign)-1])
+	}
+	if !strings.Contains(output, `Opened "Add to OmniFocus" in Shortcuts`) {
+		t.Errorf("output = %q, want opened message", output)
+	}
+}
+
+func TestShortcutsInstall_DirWritesWithoutOpening(t *testing.T) {
+	calls := stubShortcutsTools(t)
+	dir := t.TempDir()
+
+	output, err := executeShortcutsCommand("install", "--dir", dir, "--binary", "/usr/local/bin/lazyfocus")
+	if err != nil {
+		t.Fatalf("shortcuts install error = %v", err)
+	}
+
+	for _, call := range *calls {
+		if call[0] == "open" {
+			t.Errorf("ran %v, want no open with --dir", call)
+		}
+	}
+	want := filepath.Join(dir, "Today's Tasks.shortcut")
+	if !strings.Contains(output, "Wrote "+want) {
+		t.Errorf("output = %q, want %q", output, "Wrote "+want)
+	}
+}
+
+func TestShortcutsInstall_SignsGeneratedPlist(t *testing.T) {
+	stubShortcutsTools(t)
+	var unsigned string
+	runExternal = func(name string, args ...string) error {
+		if name == "shortcuts" && unsigned == "" {
+			data, err := os.ReadFile(args[4])
+			if err != nil {
+				return err
+			}
+			unsigned = string(data)
+		}
+		return nil
+	}
+
+	if _, err := executeShortcutsCommand("install", "--dir", t.TempDir(), "--binary", "/usr/local/bin/lazyfocus"); err != nil {
+		t.Fatalf("shortcuts install error = %v", err)
+	}
+
+	if !strings.Contains(unsigned, `'/usr/local/bin/lazyfocus' 'add' "$1" '--shortcut-output'`) {
+		t.Errorf("signed file does not run lazyfocus add:\n%s", unsigned)
+	}
+}
+
+func TestShortcutsInstall_NoShortcutsTool(t *testing.T) {
+	calls := stubShortcutsTools(t)
+	lookPath = func(string) (string, error) { return "", errors.New("not found") }
+
+	_, err := executeShortcutsCommand("install")
+	if err == nil || !strings.Contains(err.Error(), "macOS 12") {
+		t.Errorf("shortcuts install error = %v, want shortcuts tool not found", err)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("ran %v, want nothing", *calls)
+	}
+}
+
+func TestShortcutsInstall_SignFailure(t *testing.T) {
+	stubShortcutsTools(t)
+	runExternal = func(string, ...string) error { return errors.New("exit status 1: invalid shortcut") }
+
+	_, err := executeShortcutsCommand("install", "--dir", t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), `failed to sign "Add to OmniFocus"`) {
+		t.Errorf("shortcuts install error = %v, want sign failure", err)
+	}
+}
@@ -31,11 +31,11 @@ func NewShowCommand() *cobra.Command {
 		Long: `Show detailed information for a specific item by its ID.
 
 The command will attempt to auto-detect the type of item (task, project, or tag)
-unless you specify the type explicitly with --type flag.
-
-Examples:
-  lazyfocus show abc123              # Auto-detect type
+unless you specify the type explicitly with --type flag. A number such as 3
+shows that task of the last tasks listing.`,
+		Example: `  lazyfocus show abc123              # Auto-detect type
   lazyfocus show abc123 --type task  # Show as task
+  lazyfocus show 3                   # Task 3 of the last lazyfocus tasks
   lazyfocus show abc123 --json       # Output as JSON`,
 		Args: cobra.ExactArgs(1),
 		RunE: runShow,
@@ -56,6 +56,13 @@ func runShow(cmd *cobra.Command, args []string) error {
 	}
 	formatter := getFormatter()
 
+	// Numbers from the last tasks listing only stand for tasks
+	if itemType == "" || itemType == "task" {
+		if id, err = resolveTaskID(id); err != nil {
+			return handleError(cmd, err)
+		}
+	}
+
 	switch itemType {
 	case "task":
 		return showTask(cmd, svc, formatter, id)
@@ -71,7 +78,7 @@ func runShow(cmd *cobra.Command, args []string) error {
 }
 
 func showTask(cmd *cobra.Command, svc service.OmniFocusService, formatter output.Formatter, id string) error {
-	task, err := svc.GetTaskByID(id)
+	task, err := svc.GetTaskByID(cmd.Context(), id)
 	if err != nil {
 		return handleError(cmd, err)
 	}
@@ -84,7 +91,7 @@ func showTask(cmd *cobra.Command, svc service.OmniFocusService, formatter output
 }
 
 func showProject(cmd *cobra.Command, svc service.OmniFocusService, formatter output.Formatter, id string) error {
-	project, err := svc.GetProjectByID(id)
+	project, err := svc.GetProjectByID(cmd.Context(), id)
 	if err != nil {
 		return handleError(cmd, err)
 	}
@@ -97,7 +104,7 @@ func showProject(cmd *cobra.Command, svc service.OmniFocusService, formatter out
 }
 
 func showTag(cmd *cobra.Command, svc service.OmniFocusService, formatter output.Formatter, id string) error {
-	tag, err := svc.GetTagByID(id)
+	tag, err := svc.GetTagByID(cmd.Context(), id)
 	if err != nil {
 		return handleError(cmd, err)
 	}
@@ -110,20 +117,22 @@ func showTag(cmd *cobra.Command, svc service.OmniFocusService, formatter output.
 }
 
 func autoDetectAndShow(cmd *cobra.Command, svc service.OmniFocusService, formatter output.Formatter, id string) error {
+	ctx := cmd.Context()
+
 	// Try task first
-	task, err := svc.GetTaskByID(id)
+	task, err := svc.GetTaskByID(ctx, id)
 	if err == nil && task != nil {
 		return outputItem(cmd, formatter, *task)
 	}
 
 	// Try project
-	project, err := svc.GetProjectByID(id)
+	project, err := svc.GetProjectByID(ctx, id)
 	if err == nil && project != nil {
 		return outputItem(cmd, formatter, *project)
 	}
 
 	// Try tag
-	tag, err := svc.GetTagByID(id)
+	tag, err := svc.GetTagByID(ctx, id)
 	if err == nil && tag != nil {
 		return outputItem(cmd, formatter, *tag)
 	}
@@ -138,18 +147,15 @@ func outputItem(cmd *cobra.Command, formatter output.Formatter, item interface{}
 		return nil
 	}
 
-	var outputStr string
+	ctx, w := cmd.Context(), cmd.OutOrStdout()
 	switch v := item.(type) {
 	case domain.Task:
-		outputStr = formatter.FormatTask(v)
+		return formatter.FormatTask(ctx, w, v)
 	case domain.Project:
-		outputStr = formatter.FormatProject(v)
+		return formatter.FormatProject(ctx, w, v)
 	case domain.Tag:
-		outputStr = formatter.FormatTag(v)
+		return formatter.FormatTag(ctx, w, v)
 	default:
 		return fmt.Errorf("unsupported item type: %T", item)
 	}
-
-	cmd.Print(outputStr)
-	return nil
 }
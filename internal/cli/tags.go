@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
 	"github.com/spf13/cobra"
 )
@@ -9,21 +11,78 @@ import (
 func NewTagsCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "tags",
-		Short: "List tags from OmniFocus",
+		Short: "List and manage tags in OmniFocus",
 		Long: `List tags from OmniFocus with optional hierarchy and task counts.
 
 By default, shows tags with hierarchy. Use --flat to show tags in a flat list.
-Use --with-counts to include task counts for each tag.`,
+Use --with-counts to include task counts for each tag.
+
+Use the add, rename and delete subcommands to manage tags.`,
+		Example: `  lazyfocus tags
+  lazyfocus tags --flat --with-counts`,
 		RunE: runTags,
 	}
 
 	cmd.Flags().Bool("flat", false, "Show tags in flat list (no hierarchy)")
 	cmd.Flags().Bool("with-counts", false, "Show task count per tag")
 
+	cmd.AddCommand(newTagsAddCommand())
+	cmd.AddCommand(newTagsRenameCommand())
+	cmd.AddCommand(newTagsDeleteCommand())
+
+	return cmd
+}
+
+// newTagsAddCommand creates the tags add subcommand
+func newTagsAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Create a tag",
+		Long:  `Create a tag, at the top level or nested under --parent.`,
+		Example: `  lazyfocus tags add Errands
+  lazyfocus tags add Calls --parent abc123`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTagsAdd,
+	}
+
+	cmd.Flags().String("parent", "", "ID of the parent tag")
+
+	return cmd
+}
+
+// newTagsRenameCommand creates the tags rename subcommand
+func newTagsRenameCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "rename <tag-id> <name>",
+		Short:   "Rename a tag",
+		Long:    `Rename the tag with the given ID.`,
+		Example: `  lazyfocus tags rename abc123 "Phone calls"`,
+		Args:    cobra.ExactArgs(2),
+		RunE:    runTagsRename,
+	}
+}
+
+// newTagsDeleteCommand creates the tags delete subcommand
+func newTagsDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <tag-id>",
+		Short: "Delete a tag",
+		Long: `Delete a tag. Tasks keep their other tags.
+
+Requires --force unless --json or --quiet is set.`,
+		Example: `  lazyfocus tags delete abc123 --force`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runTagsDelete,
+	}
+
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation")
+
 	return cmd
 }
 
 func runTags(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	// Get flag values
 	flatFlag, _ := cmd.Flags().GetBool("flat")
 	withCountsFlag, _ := cmd.Flags().GetBool("with-counts")
@@ -35,14 +94,14 @@ func runTags(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get tags from service
-	tags, getErr := svc.GetTags()
+	tags, getErr := svc.GetTags(ctx)
 	if getErr != nil {
 		return handleError(cmd, getErr)
 	}
 
 	// Get tag counts if requested
 	if withCountsFlag {
-		_, countErr := svc.GetTagCounts()
+		_, countErr := svc.GetTagCounts(ctx)
 		if countErr != nil {
 			return handleError(cmd, countErr)
 		}
@@ -61,8 +120,72 @@ func runTags(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter()
-	outputStr := formatter.FormatTags(tags, formatOptions)
-	cmd.Print(outputStr)
+	return formatter.FormatTags(ctx, cmd.OutOrStdout(), tags, formatOptions)
+}
+
+func runTagsAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	parentID, _ := cmd.Flags().GetString("parent")
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	tag, err := svc.CreateTag(ctx, args[0], parentID)
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to create tag: %w", err))
+	}
+
+	if !GetQuietFlag() {
+		if err := getFormatter().FormatCreatedTag(ctx, cmd.OutOrStdout(), *tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runTagsRename(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	tag, err := svc.RenameTag(ctx, args[0], args[1])
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to rename tag: %w", err))
+	}
 
+	if !GetQuietFlag() {
+		if err := getFormatter().FormatRenamedTag(ctx, cmd.OutOrStdout(), *tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runTagsDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	forceFlag, _ := cmd.Flags().GetBool("force")
+	if !forceFlag && !GetJSONFlag() && !GetQuietFlag() {
+		return fmt.Errorf("confirmation required: use --force to delete without confirmation")
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	result, err := svc.DeleteTag(ctx, args[0])
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to delete tag: %w", err))
+	}
+
+	if !GetQuietFlag() {
+		if err := getFormatter().FormatDeletedTag(ctx, cmd.OutOrStdout(), *result); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -295,6 +295,72 @@ func TestTagsCommand_WithCountsError(t *testing.T) {
 	}
 }
 
+func TestTagsAddCommand(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		CreatedTag: &domain.Tag{ID: "tag9", Name: "Errands"},
+	}
+
+	output, _, err := executeTagsCommand(mockService, []string{"add", "Errands"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if mockService.TagNameInput != "Errands" {
+		t.Errorf("CreateTag() name = %q, want %q", mockService.TagNameInput, "Errands")
+	}
+	if !strings.Contains(output, "Created tag: tag9") {
+		t.Errorf("Expected created tag output, got: %s", output)
+	}
+}
+
+func TestTagsRenameCommand_JSON(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		RenamedTag: &domain.Tag{ID: "tag1", Name: "Deep Work"},
+	}
+
+	output, _, err := executeTagsCommand(mockService, []string{"rename", "tag1", "Deep Work", "--json"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if mockService.TagNameInput != "Deep Work" {
+		t.Errorf("RenameTag() name = %q, want %q", mockService.TagNameInput, "Deep Work")
+	}
+	if !strings.Contains(output, `"success": true`) || !strings.Contains(output, `"Deep Work"`) {
+		t.Errorf("Expected JSON output with renamed tag, got: %s", output)
+	}
+}
+
+func TestTagsDeleteCommand_RequiresForce(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		DeleteTagResult: &domain.OperationResult{Success: true, ID: "tag1"},
+	}
+
+	_, _, err := executeTagsCommand(mockService, []string{"delete", "tag1"})
+	if err == nil || !strings.Contains(err.Error(), "confirmation required") {
+		t.Fatalf("Expected confirmation error, got: %v", err)
+	}
+
+	output, _, err := executeTagsCommand(mockService, []string{"delete", "tag1", "--force"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, "Deleted tag: tag1") {
+		t.Errorf("Expected deleted tag output, got: %s", output)
+	}
+}
+
+func TestTagsDeleteCommand_Error(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		DeleteTagErr: errors.New("Tag not found: tag1"),
+	}
+
+	_, _, err := executeTagsCommand(mockService, []string{"delete", "tag1", "--force"})
+	if err == nil || !strings.Contains(err.Error(), "failed to delete tag") {
+		t.Errorf("Expected delete error, got: %v", err)
+	}
+}
+
 // Helper function to execute tags command and capture output
 func executeTagsCommand(mockService service.OmniFocusService, args []string) (string, int, error) {
 	// Create a new root command for each test to avoid flag pollution
@@ -1,12 +1,21 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +26,26 @@ func NewTasksCommand() *cobra.Command {
 		Short: "List tasks from OmniFocus",
 		Long: `List tasks from OmniFocus with various filtering options.
 
-By default, shows inbox tasks. Use flags to filter by project, tag, due date, etc.`,
+By default, shows inbox tasks. Use flags to filter by project, tag, due date, etc.
+
+--since lists the tasks completed since a date or for a span back from now,
+such as 7d, 2w or 1mo (implies --completed).
+
+--filter applies a filter saved in the TUI with :save-filter (or imported with
+` + "`perspective import`" + `). Without --inbox, --project, --tag or --flagged it searches
+all tasks.
+
+--watch keeps listing the tasks, clearing the screen and running the query
+again every --interval, or when Enter is pressed, until q or Ctrl-C; handy
+for a tmux pane showing today's tasks.`,
+		Example: `  lazyfocus tasks
+  lazyfocus tasks --all --due today
+  lazyfocus tasks --all --due 2025-06-01..2025-06-15
+  lazyfocus tasks --all --deferred "next month"
+  lazyfocus tasks --tag urgent --flagged
+  lazyfocus tasks --completed --since 7d
+  lazyfocus tasks --filter work-today --json
+  lazyfocus tasks --all --due today --watch --interval 1m`,
 		RunE: runTasks,
 	}
 
@@ -26,20 +54,86 @@ By default, shows inbox tasks. Use flags to filter by project, tag, due date, et
 	cmd.Flags().String("project", "", "Filter by project ID")
 	cmd.Flags().String("tag", "", "Filter by tag ID")
 	cmd.Flags().Bool("flagged", false, "Show flagged tasks only")
-	cmd.Flags().String("due", "", "Show tasks due on/before date (supports 'today', 'tomorrow', or YYYY-MM-DD)")
+	cmd.Flags().String("due", "", "Show tasks due on/before a date, or within a range (e.g. 'friday', 'next month', '2025-06-01..2025-06-15', 'overdue')")
+	cmd.Flags().String("deferred", "", "Show tasks deferred until on/before a date, or within a range (same forms as --due)")
 	cmd.Flags().Bool("completed", false, "Include completed tasks")
+	cmd.Flags().String("since", "", "List tasks completed since a date or span back from now (e.g. '7d', '2w', 'last monday')")
+	cmd.Flags().String("filter", "", "Apply a saved filter by name")
+	cmd.Flags().Bool("watch", false, "Run the query again every --interval or on Enter, redrawing the list")
+	cmd.Flags().Duration("interval", defaultWatchInterval, "How often --watch runs the query")
 
 	return cmd
 }
 
 func runTasks(cmd *cobra.Command, args []string) error {
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		return watchCommand(cmd, interval, listTasks)
+	}
+	return listTasks(cmd)
+}
+
+// listTasks runs the query given by the flags and prints the tasks
+func listTasks(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
 	// Get flag values
 	allFlag, _ := cmd.Flags().GetBool("all")
 	projectFlag, _ := cmd.Flags().GetString("project")
 	tagFlag, _ := cmd.Flags().GetString("tag")
 	flaggedFlag, _ := cmd.Flags().GetBool("flagged")
 	dueFlag, _ := cmd.Flags().GetString("due")
+	deferredFlag, _ := cmd.Flags().GetString("deferred")
 	completedFlag, _ := cmd.Flags().GetBool("completed")
+	inboxFlag, _ := cmd.Flags().GetBool("inbox")
+	filterFlag, _ := cmd.Flags().GetString("filter")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+
+	var since time.Time
+	if sinceFlag != "" {
+		if inboxFlag || projectFlag != "" || tagFlag != "" || flaggedFlag {
+			return handleError(cmd, invalidInput("--since cannot be combined with --inbox, --project, --tag or --flagged"))
+		}
+		var err error
+		since, err = parseSince(sinceFlag, time.Now())
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		completedFlag = true
+	}
+
+	var savedFilter *filter.State
+	if filterFlag != "" {
+		saved, err := filter.LoadSaved(config.SavedFiltersPath())
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		state, ok := saved.Find(filterFlag)
+		if !ok {
+			return handleError(cmd, bridge.NewNotFoundError("saved filter", filterFlag))
+		}
+		savedFilter = &state
+		// A saved filter searches all tasks unless a source is given
+		allFlag = allFlag || !(inboxFlag || projectFlag != "" || tagFlag != "" || flaggedFlag)
+	}
+
+	filters := service.TaskFilters{
+		Completed: completedFlag,
+	}
+	if dueFlag != "" {
+		start, end, err := parseDateRange("due", dueFlag)
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		filters.DueStart, filters.DueEnd = start, end
+	}
+	if deferredFlag != "" {
+		start, end, err := parseDateRange("deferred", deferredFlag)
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		filters.DeferStart, filters.DeferEnd = start, end
+	}
 
 	// Get service
 	svc, err := getServiceFromCmd(cmd)
@@ -47,36 +141,54 @@ func runTasks(cmd *cobra.Command, args []string) error {
 		return handleError(cmd, err)
 	}
 
+	// Formats writing tasks one at a time get all tasks as they are decoded;
+	// saved filters rank their matches and need the whole list
+	listsAll := allFlag && sinceFlag == "" && !flaggedFlag && projectFlag == "" && tagFlag == ""
+	if formatter, ok := getFormatter().(output.StreamingFormatter); ok && listsAll && savedFilter == nil {
+		return streamTasks(cmd, svc, formatter, filters)
+	}
+
 	// Determine which service method to call based on flags
 	var tasks []domain.Task
 
 	switch {
+	case sinceFlag != "":
+		tasks, err = svc.GetCompletedTasks(ctx, since)
+		// OmniFocus is asked for whole days; drop those completed earlier on the first one
+		tasks = completedSince(tasks, since)
 	case flaggedFlag:
-		tasks, err = svc.GetFlaggedTasks()
+		tasks, err = svc.GetFlaggedTasks(ctx)
 	case projectFlag != "":
-		tasks, err = svc.GetTasksByProject(projectFlag)
+		tasks, err = svc.GetTasksByProject(ctx, projectFlag)
 	case tagFlag != "":
-		tasks, err = svc.GetTasksByTag(tagFlag)
+		tasks, err = svc.GetTasksByTag(ctx, tagFlag)
 	case allFlag:
-		filters := service.TaskFilters{
-			Completed: completedFlag,
-		}
-		tasks, err = svc.GetAllTasks(filters)
+		tasks, err = svc.GetAllTasks(ctx, filters)
 	default:
 		// Default to inbox (inbox flag is redundant with default behavior)
-		tasks, err = svc.GetInboxTasks()
+		tasks, err = svc.GetInboxTasks(ctx)
+	}
+
+	// A truncated result is still usable; warn on stderr and carry on
+	var truncated *service.TruncatedError
+	if errors.As(err, &truncated) {
+		if !GetQuietFlag() {
+			cmd.PrintErrf("Warning: %s\n", truncated)
+		}
+		err = nil
 	}
 
 	if err != nil {
 		return handleError(cmd, err)
 	}
 
-	// Apply due date filter if specified
-	if dueFlag != "" {
-		tasks, err = filterTasksByDueDate(tasks, dueFlag)
-		if err != nil {
-			return handleError(cmd, err)
-		}
+	if savedFilter != nil {
+		tasks = filter.NewMatcher(*savedFilter).FilterTasks(tasks)
+	}
+
+	// Apply due and defer date filters if specified
+	if dueFlag != "" || deferredFlag != "" {
+		tasks = filterTasksByDates(tasks, filters)
 	}
 
 	// Format and output results
@@ -91,10 +203,42 @@ func runTasks(cmd *cobra.Command, args []string) error {
 		ShowTags:      true,
 	}
 
+	// Number the listed tasks so later commands accept the numbers as IDs
+	if format := GetOutputFlag(); format == OutputHuman || format == OutputTable {
+		numbers, err := saveTaskIndex(tasks)
+		if err != nil {
+			cmd.PrintErrf("Warning: %v; tasks are not numbered\n", err)
+		}
+		formatOptions.Numbers = numbers
+	}
+
 	formatter := getFormatter()
-	outputStr := formatter.FormatTasks(tasks, formatOptions)
-	cmd.Print(outputStr)
+	return formatter.FormatTasks(ctx, cmd.OutOrStdout(), tasks, formatOptions)
+}
 
+// streamTasks writes all tasks matching filters as the service decodes them
+// from the script output, so the decoded list is never built in full
+func streamTasks(cmd *cobra.Command, svc service.OmniFocusService, formatter output.StreamingFormatter, filters service.TaskFilters) error {
+	ctx := cmd.Context()
+	writer := formatter.TaskWriter(ctx, cmd.OutOrStdout())
+	err := svc.StreamAllTasks(ctx, filters, func(task domain.Task) error {
+		if !filters.MatchesDates(task) || GetQuietFlag() {
+			return nil
+		}
+		return writer.WriteTask(task)
+	})
+
+	// A truncated result was still written; warn on stderr
+	var truncated *service.TruncatedError
+	if errors.As(err, &truncated) {
+		if !GetQuietFlag() {
+			cmd.PrintErrf("Warning: %s\n", truncated)
+		}
+		return nil
+	}
+	if err != nil {
+		return handleError(cmd, err)
+	}
 	return nil
 }
 
@@ -108,12 +252,37 @@ func getServiceFromCmd(cmd *cobra.Command) (service.OmniFocusService, error) {
 	return svc, nil
 }
 
-// getFormatter returns the appropriate formatter based on the --json flag
+// getFormatter returns the appropriate formatter based on the --json and --output flags
 func getFormatter() output.Formatter {
-	if GetJSONFlag() {
+	switch GetOutputFlag() {
+	case OutputJSON:
 		return output.NewJSONFormatter()
+	case OutputJSONL:
+		return output.NewJSONLinesFormatter()
+	case OutputCSV:
+		return output.NewCSVFormatter(GetColumnsFlag())
+	case OutputTSV:
+		return output.NewTSVFormatter(GetColumnsFlag())
+	case OutputTable:
+		return output.NewTableFormatter(lipgloss.DefaultRenderer(), terminalWidth())
+	case OutputShortcut:
+		return output.NewShortcutFormatter()
+	default:
+		return output.NewHumanFormatter()
+	}
+}
+
+// terminalWidth returns the width of the terminal on stdout, or 0 when
+// output goes elsewhere, so piped tables are not truncated
+func terminalWidth() int {
+	if !term.IsTerminal(os.Stdout.Fd()) {
+		return 0
 	}
-	return output.NewHumanFormatter()
+	width, _, err := term.GetSize(os.Stdout.Fd())
+	if err != nil {
+		return 0
+	}
+	return width
 }
 
 // handleError handles errors and formats them appropriately
@@ -123,50 +292,74 @@ func handleError(cmd *cobra.Command, err error) error {
 		return err
 	}
 
-	formatter := getFormatter()
-	cmd.Print(formatter.FormatError(err))
+	printError(cmd, err)
 
-	return err
+	return printed(err)
 }
 
-// filterTasksByDueDate filters tasks by due date
-// Tasks with due dates on or before the specified date are included.
-// Timezone handling: dates from OmniFocus come as UTC ISO strings and are
-// compared correctly with local timezone dates using Go's time.Time comparison.
-func filterTasksByDueDate(tasks []domain.Task, dueStr string) ([]domain.Task, error) {
-	dueDate, err := parseDueDate(dueStr)
+// printError writes err to stderr in the output format. It is written even
+// when the command was canceled, since the error says why it stopped.
+func printError(cmd *cobra.Command, err error) {
+	_ = getFormatter().FormatError(context.Background(), cmd.ErrOrStderr(), err)
+}
+
+// parseDateRange parses the range given to a date flag into filter bounds,
+// leaving a bound nil when that side of the range is open
+func parseDateRange(flag, value string) (start, end *time.Time, err error) {
+	r, err := dateparse.ParseRange(value)
 	if err != nil {
-		return nil, fmt.Errorf("invalid due date format: %w", err)
+		return nil, nil, invalidInput("invalid %s date: %w", flag, err)
+	}
+	if !r.Start.IsZero() {
+		start = &r.Start
 	}
+	if !r.End.IsZero() {
+		end = &r.End
+	}
+	return start, end, nil
+}
 
+// filterTasksByDates keeps the tasks whose due and defer dates fall within
+// the date bounds of filters. Dates from OmniFocus come as UTC and compare
+// correctly with the local bounds.
+func filterTasksByDates(tasks []domain.Task, filters service.TaskFilters) []domain.Task {
 	var filtered []domain.Task
 	for _, task := range tasks {
-		if task.DueDate != nil && !task.DueDate.After(dueDate) {
+		if filters.MatchesDates(task) {
 			filtered = append(filtered, task)
 		}
 	}
+	return filtered
+}
 
-	return filtered, nil
+// parseSince parses the --since value: a span back from now such as "7d",
+// "2w" or "1mo", or a date, counted from its start
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if span, err := domain.ParseBump(value); err == nil {
+		if span.Amount > 0 {
+			span.Amount = -span.Amount
+		}
+		return span.Apply(now), nil
+	}
+	since, err := dateparse.ParseWithReference(value, now)
+	if err != nil {
+		return time.Time{}, invalidInput("invalid --since %q: use a span such as 7d or 2w, or a date", value)
+	}
+	// Dates count from the start of the day, not the default due time
+	since = time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, since.Location())
+	if since.After(now) {
+		return time.Time{}, invalidInput("invalid --since %q: the date is in the future", value)
+	}
+	return since, nil
 }
 
-// parseDueDate parses a due date string (today, tomorrow, or YYYY-MM-DD)
-// Returns a time at 23:59:59 in the local timezone to include all tasks due on that day
-func parseDueDate(dueStr string) (time.Time, error) {
-	now := time.Now()
-	loc := now.Location()
-
-	switch dueStr {
-	case "today":
-		return time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, loc), nil
-	case "tomorrow":
-		tomorrow := now.AddDate(0, 0, 1)
-		return time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 23, 59, 59, 0, loc), nil
-	default:
-		// Try to parse as YYYY-MM-DD in local timezone
-		parsed, err := time.ParseInLocation("2006-01-02", dueStr, loc)
-		if err != nil {
-			return time.Time{}, fmt.Errorf("expected 'today', 'tomorrow', or YYYY-MM-DD format: %w", err)
+// completedSince returns the tasks completed at or after since
+func completedSince(tasks []domain.Task, since time.Time) []domain.Task {
+	var result []domain.Task
+	for _, task := range tasks {
+		if task.CompletedDate == nil || !task.CompletedDate.Before(since) {
+			result = append(result, task)
 		}
-		return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 23, 59, 59, 0, loc), nil
 	}
+	return result
 }
@@ -3,102 +3,78 @@ package cli
 import (
 	"testing"
 	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
 )
 
-func TestParseDueDate_Today(t *testing.T) {
-	result, err := parseDueDate("today")
+func TestParseDateRange_SingleDay(t *testing.T) {
+	start, end, err := parseDateRange("due", "today")
 	if err != nil {
-		t.Fatalf("parseDueDate(today) returned error: %v", err)
+		t.Fatalf("parseDateRange(today) returned error: %v", err)
 	}
 
 	now := time.Now()
-	expected := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
+	expected := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.Local)
 
-	if !result.Equal(expected) {
-		t.Errorf("parseDueDate(today) = %v, want %v", result, expected)
+	if start != nil {
+		t.Errorf("parseDateRange(today) start = %v, want nil (includes overdue tasks)", start)
 	}
-
-	// Verify it's in local timezone
-	if result.Location() != now.Location() {
-		t.Errorf("parseDueDate(today).Location() = %v, want %v", result.Location(), now.Location())
+	if end == nil || !end.Equal(expected) {
+		t.Errorf("parseDateRange(today) end = %v, want %v", end, expected)
 	}
 }
 
-func TestParseDueDate_Tomorrow(t *testing.T) {
-	result, err := parseDueDate("tomorrow")
+func TestParseDateRange_YYYYMMDD(t *testing.T) {
+	_, end, err := parseDateRange("due", "2024-03-15")
 	if err != nil {
-		t.Fatalf("parseDueDate(tomorrow) returned error: %v", err)
+		t.Fatalf("parseDateRange(2024-03-15) returned error: %v", err)
 	}
 
-	now := time.Now()
-	tomorrow := now.AddDate(0, 0, 1)
-	expected := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 23, 59, 59, 0, now.Location())
-
-	if !result.Equal(expected) {
-		t.Errorf("parseDueDate(tomorrow) = %v, want %v", result, expected)
-	}
-
-	// Verify it's in local timezone
-	if result.Location() != now.Location() {
-		t.Errorf("parseDueDate(tomorrow).Location() = %v, want %v", result.Location(), now.Location())
+	expected := time.Date(2024, 3, 16, 0, 0, 0, 0, time.Local)
+	if end == nil || !end.Equal(expected) {
+		t.Errorf("parseDateRange(2024-03-15) end = %v, want %v", end, expected)
 	}
 }
 
-func TestParseDueDate_YYYYMMDD(t *testing.T) {
-	result, err := parseDueDate("2024-03-15")
+func TestParseDateRange_Range(t *testing.T) {
+	start, end, err := parseDateRange("due", "2025-06-01..2025-06-15")
 	if err != nil {
-		t.Fatalf("parseDueDate(2024-03-15) returned error: %v", err)
+		t.Fatalf("parseDateRange(2025-06-01..2025-06-15) returned error: %v", err)
 	}
 
-	loc := time.Now().Location()
-	expected := time.Date(2024, 3, 15, 23, 59, 59, 0, loc)
-
-	if !result.Equal(expected) {
-		t.Errorf("parseDueDate(2024-03-15) = %v, want %v", result, expected)
+	wantStart := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)
+	wantEnd := time.Date(2025, 6, 16, 0, 0, 0, 0, time.Local)
+	if start == nil || !start.Equal(wantStart) {
+		t.Errorf("start = %v, want %v", start, wantStart)
 	}
-
-	// Verify it's in local timezone
-	if result.Location() != loc {
-		t.Errorf("parseDueDate(2024-03-15).Location() = %v, want %v", result.Location(), loc)
+	if end == nil || !end.Equal(wantEnd) {
+		t.Errorf("end = %v, want %v", end, wantEnd)
 	}
 }
 
-func TestParseDueDate_InvalidFormat(t *testing.T) {
+func TestParseDateRange_InvalidFormat(t *testing.T) {
 	testCases := []string{
 		"invalid",
 		"2024-13-01", // Invalid month
 		"2024-01-32", // Invalid day
 		"24-01-01",   // Wrong year format
+		"2025-06-15..2025-06-01",
+		"..",
 		"",
 	}
 
 	for _, tc := range testCases {
-		_, err := parseDueDate(tc)
+		_, _, err := parseDateRange("due", tc)
 		if err == nil {
-			t.Errorf("parseDueDate(%q) should return error but didn't", tc)
+			t.Errorf("parseDateRange(%q) should return error but didn't", tc)
 		}
 	}
 }
 
-func TestParseDueDate_TimezoneConsistency(t *testing.T) {
-	// Test that dates parsed in different ways are in the same timezone
-	today, _ := parseDueDate("today")
-	tomorrow, _ := parseDueDate("tomorrow")
-	explicit, _ := parseDueDate("2024-03-15")
-
-	if today.Location() != tomorrow.Location() {
-		t.Error("today and tomorrow should be in same timezone")
-	}
-
-	if today.Location() != explicit.Location() {
-		t.Error("today and explicit date should be in same timezone")
-	}
-}
-
-// TestFilterTasksByDueDate_WithUTCDates tests that filtering works correctly
+// TestFilterTasksByDates_WithUTCDates tests that filtering works correctly
 // when tasks have due dates in UTC (as returned from JavaScript)
-func TestFilterTasksByDueDate_WithUTCDates(t *testing.T) {
-	// Create test tasks with dates in different timezones
+func TestFilterTasksByDates_WithUTCDates(t *testing.T) {
 	loc, _ := time.LoadLocation("Europe/Warsaw") // CET/CEST (UTC+1/+2)
 
 	// Task due at 10:00 UTC on Jan 28
@@ -110,40 +86,42 @@ func TestFilterTasksByDueDate_WithUTCDates(t *testing.T) {
 	// Task due at 23:30 UTC on Jan 28 (00:30 CET Jan 29)
 	utcDate3 := time.Date(2024, 1, 28, 23, 30, 0, 0, time.UTC)
 
-	tasks := []Task{
+	tasks := []domain.Task{
 		{Name: "Task 1", DueDate: &utcDate1},
 		{Name: "Task 2", DueDate: &utcDate2},
 		{Name: "Task 3", DueDate: &utcDate3},
+		{Name: "No due date"},
 	}
 
-	// Filter by end of Jan 28 in CET
-	// This should include tasks 1 and 2, but not 3
-	dueDate := time.Date(2024, 1, 28, 23, 59, 59, 0, loc)
-
-	var filtered []Task
-	for _, task := range tasks {
-		if task.DueDate != nil && !task.DueDate.After(dueDate) {
-			filtered = append(filtered, task)
-		}
-	}
+	// Filter by the end of Jan 28 in CET
+	dueEnd := time.Date(2024, 1, 29, 0, 0, 0, 0, loc)
+	filtered := filterTasksByDates(tasks, service.TaskFilters{DueEnd: &dueEnd})
 
-	// Verify results
 	if len(filtered) != 2 {
-		t.Errorf("Expected 2 tasks, got %d", len(filtered))
+		t.Fatalf("Expected 2 tasks, got %d", len(filtered))
 	}
-
-	if len(filtered) >= 2 {
-		if filtered[0].Name != "Task 1" {
-			t.Errorf("Expected first task to be 'Task 1', got %s", filtered[0].Name)
-		}
-		if filtered[1].Name != "Task 2" {
-			t.Errorf("Expected second task to be 'Task 2', got %s", filtered[1].Name)
-		}
+	if filtered[0].Name != "Task 1" {
+		t.Errorf("Expected first task to be 'Task 1', got %s", filtered[0].Name)
+	}
+	if filtered[1].Name != "Task 2" {
+		t.Errorf("Expected second task to be 'Task 2', got %s", filtered[1].Name)
 	}
 }
 
-// Task is a minimal task structure for testing
-type Task struct {
-	Name    string
-	DueDate *time.Time
+func TestFilterTasksByDates_DeferRange(t *testing.T) {
+	early := time.Date(2025, 6, 2, 9, 0, 0, 0, time.Local)
+	late := time.Date(2025, 7, 2, 9, 0, 0, 0, time.Local)
+	tasks := []domain.Task{
+		{Name: "Early", DeferDate: &early},
+		{Name: "Late", DeferDate: &late},
+		{Name: "Not deferred"},
+	}
+
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2025, 7, 1, 0, 0, 0, 0, time.Local)
+	filtered := filterTasksByDates(tasks, service.TaskFilters{DeferStart: &start, DeferEnd: &end})
+
+	if len(filtered) != 1 || filtered[0].Name != "Early" {
+		t.Errorf("filterTasksByDates() = %v, want only 'Early'", filtered)
+	}
 }
@@ -3,13 +3,16 @@ package cli
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
 	"github.com/spf13/cobra"
 )
 
@@ -332,6 +335,74 @@ func TestTasksCommand_DueDateSpecific(t *testing.T) {
 	}
 }
 
+func TestTasksCommand_DueDateRange(t *testing.T) {
+	inRange := time.Date(2025, 6, 10, 17, 0, 0, 0, time.Local)
+	after := time.Date(2025, 6, 20, 17, 0, 0, 0, time.Local)
+	mockService := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "task1", Name: "June task", DueDate: &inRange},
+			{ID: "task2", Name: "Later task", DueDate: &after},
+			{ID: "task3", Name: "Undated task"},
+		},
+	}
+
+	output, exitCode, err := executeTasksCommand(mockService, []string{"--all", "--due", "2025-06-01..2025-06-15"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got: %d", exitCode)
+	}
+
+	if !strings.Contains(output, "June task") {
+		t.Errorf("Expected output to contain 'June task', got: %s", output)
+	}
+	if strings.Contains(output, "Later task") || strings.Contains(output, "Undated task") {
+		t.Errorf("Expected output to contain only tasks due in range, got: %s", output)
+	}
+}
+
+func TestTasksCommand_Deferred(t *testing.T) {
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().AddDate(0, 2, 0)
+	mockService := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "task1", Name: "Deferred soon", DeferDate: &soon},
+			{ID: "task2", Name: "Deferred later", DeferDate: &later},
+		},
+	}
+
+	output, _, err := executeTasksCommand(mockService, []string{"--all", "--deferred", "week"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, "Deferred soon") || strings.Contains(output, "Deferred later") {
+		t.Errorf("Expected output to contain only the task deferred this week, got: %s", output)
+	}
+}
+
+func TestTasksCommand_InvalidDueRange(t *testing.T) {
+	mockService := &service.MockOmniFocusService{}
+
+	_, exitCode, err := executeTasksCommand(mockService, []string{"--due", "someday"})
+
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if exitCode == 0 {
+		t.Errorf("Expected non-zero exit code, got: %d", exitCode)
+	}
+
+	if !strings.Contains(err.Error(), "invalid due date") {
+		t.Errorf("Expected error about invalid due date, got: %v", err)
+	}
+}
+
 func TestTasksCommand_EmptyResults(t *testing.T) {
 	// Test empty task list
 	mockService := &service.MockOmniFocusService{
@@ -353,7 +424,280 @@ func TestTasksCommand_EmptyResults(t *testing.T) {
 	}
 }
 
+func TestTasksCommand_Since(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Hour)
+	old := now.AddDate(0, 0, -3).Add(-time.Hour)
+	mockService := &service.MockOmniFocusService{
+		CompletedTasks: []domain.Task{
+			{ID: "t1", Name: "Filed taxes", Completed: true, CompletedDate: &recent},
+			{ID: "t2", Name: "Paid rent", Completed: true, CompletedDate: &old},
+		},
+	}
+
+	output, _, err := executeTasksCommand(mockService, []string{"--completed", "--since", "3d"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	wantSince := now.AddDate(0, 0, -3)
+	if diff := mockService.CompletedSince.Sub(wantSince); diff < -time.Minute || diff > time.Minute {
+		t.Errorf("CompletedSince = %v, want about %v", mockService.CompletedSince, wantSince)
+	}
+	if !strings.Contains(output, "Filed taxes") {
+		t.Errorf("Expected the recently completed task, got: %s", output)
+	}
+	if strings.Contains(output, "Paid rent") {
+		t.Errorf("Expected tasks completed before --since to be left out, got: %s", output)
+	}
+}
+
+func TestTasksCommand_SinceConflictsWithSource(t *testing.T) {
+	_, _, err := executeTasksCommand(&service.MockOmniFocusService{}, []string{"--since", "7d", "--flagged"})
+	if err == nil || !strings.Contains(err.Error(), "--since cannot be combined") {
+		t.Errorf("Expected a conflict error, got: %v", err)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 12, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"7d", time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC)},
+		{"-2w", time.Date(2026, 2, 26, 15, 0, 0, 0, time.UTC)},
+		{"12h", time.Date(2026, 3, 12, 3, 0, 0, 0, time.UTC)},
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.input, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, %v, want %v", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, input := range []string{"soon", "2026-04-01"} {
+		if _, err := parseSince(input, now); err == nil {
+			t.Errorf("parseSince(%q) error = nil, want an error", input)
+		}
+	}
+}
+
 // Helper function to execute tasks command and capture output
+func TestTasksCommand_AllTruncated_WarnsAndShowsPartialResults(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "task1", Name: "First task"},
+		},
+		AllTasksErr: &service.TruncatedError{Returned: 1, Total: 50000},
+	}
+
+	output, exitCode, err := executeTasksCommand(mockService, []string{"--all"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got: %d", exitCode)
+	}
+
+	if !strings.Contains(output, "showing 1 of 50000 tasks") {
+		t.Errorf("Expected truncation warning, got: %s", output)
+	}
+
+	if !strings.Contains(output, "First task") {
+		t.Errorf("Expected output to contain 'First task', got: %s", output)
+	}
+}
+
+func TestTasksCommand_CSVOutput(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "Buy milk, eggs", ProjectName: "Errands"},
+		},
+	}
+
+	output, exitCode, err := executeTasksCommand(mockService, []string{"--output", "csv", "--columns", "id,name,project"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if exitCode != 0 {
+		t.Errorf("Expected exit code 0, got: %d", exitCode)
+	}
+
+	want := "id,name,project\ntask1,\"Buy milk, eggs\",Errands\n"
+	if output != want {
+		t.Errorf("Expected output %q, got: %q", want, output)
+	}
+}
+
+func TestTasksCommand_TSVOutput(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "Buy milk"},
+		},
+	}
+
+	output, _, err := executeTasksCommand(mockService, []string{"--output", "tsv", "--columns", "id,name"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if output != "id\tname\ntask1\tBuy milk\n" {
+		t.Errorf("Expected tab-separated output, got: %q", output)
+	}
+}
+
+func TestTasksCommand_TableOutput(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "Buy milk", ProjectName: "Errands"},
+		},
+	}
+
+	output, _, err := executeTasksCommand(mockService, []string{"--output", "table"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Output is not a terminal, so the table is uncolored and untruncated
+	want := "#     Name      Project\n1  ☐  Buy milk  Errands\n"
+	if output != want {
+		t.Errorf("Expected output %q, got: %q", want, output)
+	}
+}
+
+func TestTasksCommand_InvalidOutputFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"unknown format", []string{"--output", "xml"}},
+		{"unknown column", []string{"--output", "csv", "--columns", "id,bogus"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := executeTasksCommand(&service.MockOmniFocusService{}, tt.args)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestTasksCommand_SavedFilter(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	saved := filter.Saved{"work-today": {TagID: "Work", FlaggedOnly: true}}
+	if err := saved.Save(filepath.Join(home, ".lazyfocus-filters.json")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	mockService := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "task1", Name: "Write report", Tags: []string{"Work"}, Flagged: true},
+			{ID: "task2", Name: "Email team", Tags: []string{"Work"}},
+			{ID: "task3", Name: "Buy milk", Flagged: true},
+		},
+	}
+
+	output, _, err := executeTasksCommand(mockService, []string{"--filter", "Work-Today"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, "Write report") {
+		t.Errorf("Expected output to contain 'Write report', got: %s", output)
+	}
+	for _, unwanted := range []string{"Email team", "Buy milk"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Expected output not to contain %q, got: %s", unwanted, output)
+		}
+	}
+}
+
+func TestTasksCommand_SavedFilterNotFound(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, _, err := executeTasksCommand(&service.MockOmniFocusService{}, []string{"--filter", "missing"})
+
+	if err == nil || !strings.Contains(err.Error(), "saved filter not found: missing") {
+		t.Errorf("Expected saved filter not found error, got: %v", err)
+	}
+}
+
+func TestTasksCommand_ShortcutOutput(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{
+			{ID: "task1", Name: "Buy groceries", Flagged: true},
+			{ID: "task2", Name: "Call dentist"},
+		},
+	}
+
+	output, _, err := executeTasksCommand(mockService, []string{"--shortcut-output"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := "Buy groceries, flagged\nCall dentist\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
+func TestTasksCommand_JSONLinesStreamsOneTaskPerLine(t *testing.T) {
+	due := time.Now().Add(-time.Hour)
+	mockService := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "t1", Name: "Overdue", DueDate: &due},
+			{ID: "t2", Name: "Someday"},
+			{ID: "t3", Name: "Also overdue", DueDate: &due},
+		},
+		AllTasksErr: &service.TruncatedError{Returned: 3, Total: 9},
+	}
+
+	output, _, err := executeTasksCommand(mockService, []string{"--all", "--due", "today", "--output", "jsonl"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if warning := lines[len(lines)-1]; !strings.Contains(warning, "showing 3 of 9 tasks") {
+		t.Errorf("last line = %q, want the truncation warning", warning)
+	}
+	var ids []string
+	for _, line := range lines[:len(lines)-1] {
+		var task domain.Task
+		if err := json.Unmarshal([]byte(line), &task); err != nil {
+			t.Fatalf("line %q is not a task: %v", line, err)
+		}
+		ids = append(ids, task.ID)
+	}
+	if strings.Join(ids, ",") != "t1,t3" {
+		t.Errorf("tasks = %v, want t1,t3", ids)
+	}
+}
+
+func TestTasksCommand_JSONLinesError(t *testing.T) {
+	mockService := &service.MockOmniFocusService{InboxTasksErr: errors.New("boom")}
+
+	output, _, err := executeTasksCommand(mockService, []string{"--output", "jsonl"})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	want := `{"code":1,"error":"boom","kind":"error"}` + "\n"
+	if output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
+
 func executeTasksCommand(mockService service.OmniFocusService, args []string) (string, int, error) {
 	// Create a new root command for each test to avoid flag pollution
 	rootCmd := newTestRootCommand()
@@ -415,3 +759,41 @@ func TestTasksCommand_ErrorWhenServiceNotInContext(t *testing.T) {
 		t.Errorf("Expected ErrServiceNotFound, got: %v", err)
 	}
 }
+
+func TestTasksCommand_Watch(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "task1", Name: "Buy milk"}},
+	}
+
+	run := func(input string, args ...string) (string, error) {
+		rootCmd := newTestRootCommand()
+		rootCmd.AddCommand(NewTasksCommand())
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetIn(strings.NewReader(input))
+		rootCmd.SetArgs(append([]string{"tasks", "--watch"}, args...))
+		err := rootCmd.ExecuteContext(ContextWithService(context.Background(), mockService))
+		return buf.String(), err
+	}
+
+	// Enter refreshes once, then q quits; the hour-long interval never passes
+	output, err := run("\nq\n", "--interval", "1h")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := strings.Count(output, "Buy milk"); got != 2 {
+		t.Errorf("Expected the list printed twice, got %d times:\n%s", got, output)
+	}
+	if got := strings.Count(output, "Every 1h0m0s, updated "); got != 2 {
+		t.Errorf("Expected a header before each list, got %d:\n%s", got, output)
+	}
+
+	// Errors from the first run end the watch
+	if _, err := run("", "--due", "someday-maybe"); err == nil {
+		t.Error("Expected an invalid --due to end the watch with an error")
+	}
+	if _, err := run("", "--interval", "0s"); err == nil {
+		t.Error("Expected a zero --interval to be rejected")
+	}
+}
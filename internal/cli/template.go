@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/output"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/gitinfo"
+	"github.com/pwojciechowski/lazyfocus/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+// NewTemplateCommand creates the template command
+func NewTemplateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Create projects from templates",
+		Long: `Create projects from the templates defined under "templates" in the config file.
+
+Templates may declare variables, referenced as {{.Name}} in the project name,
+note, dates and tasks. Values are passed with --var, read from a git
+repository with --from-git, or prompted for.`,
+	}
+
+	cmd.AddCommand(newTemplateListCommand())
+	cmd.AddCommand(newTemplateApplyCommand())
+
+	return cmd
+}
+
+// newTemplateListCommand creates the template list subcommand
+func newTemplateListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List configured templates",
+		Long:    `List the templates defined in the config file with their variables.`,
+		Example: `  lazyfocus template list`,
+		Args:    cobra.NoArgs,
+		RunE:    runTemplateList,
+	}
+}
+
+// newTemplateApplyCommand creates the template apply subcommand
+func newTemplateApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply <name>",
+		Short: "Create a project from a template",
+		Long: `Create a project and its tasks from a template.
+
+Variables not given with --var are prompted for; press Enter to accept the
+default shown in brackets. With --no-input, --json or --quiet nothing is
+prompted and variables without a value or default are an error.
+
+With --from-git, declared variables not set with --var are filled from the
+git repository (--repo, default the current directory):
+  Version          Latest tag
+  NextVersion      Latest tag with the patch number bumped
+  ChangedPackages  Comma-separated directories with Go changes since the tag
+  CommitCount      Commits since the tag
+  Branch           Current branch`,
+		Example: `  lazyfocus template apply onboarding
+  lazyfocus template apply onboarding --var ClientName=Acme --var DueOffsetDays=7
+  lazyfocus template apply onboarding --var ClientName=Acme --dry-run
+  lazyfocus template apply release --from-git`,
+		Args: cobra.ExactArgs(1),
+		RunE: runTemplateApply,
+	}
+
+	cmd.Flags().StringArray("var", nil, "Set a template variable (NAME=VALUE, repeatable)")
+	cmd.Flags().Bool("no-input", false, "Do not prompt for missing variables")
+	cmd.Flags().Bool("dry-run", false, "Show the project that would be created without creating it")
+	cmd.Flags().Bool("from-git", false, "Fill variables from the git repository")
+	cmd.Flags().String("repo", ".", "Git repository used by --from-git")
+
+	return cmd
+}
+
+// templateSummary is the JSON shape of a template in `template list`
+type templateSummary struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Variables   []string `json:"variables"`
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.FromContext(cmd.Context())
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	summaries := make([]templateSummary, 0, len(cfg.Templates))
+	for _, tmplCfg := range cfg.Templates {
+		tmpl, err := templates.New(tmplCfg)
+		if err != nil {
+			return handleError(cmd, err)
+		}
+		summary := templateSummary{Name: tmpl.Name, Description: tmpl.Description, Variables: []string{}}
+		for _, v := range tmpl.Variables {
+			summary.Variables = append(summary.Variables, v.Name)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if GetQuietFlag() {
+		return nil
+	}
+
+	if GetJSONFlag() {
+		data, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return handleError(cmd, fmt.Errorf("failed to encode templates: %w", err))
+		}
+		cmd.Println(string(data))
+		return nil
+	}
+
+	if len(summaries) == 0 {
+		cmd.Println("No templates configured")
+		return nil
+	}
+	for _, summary := range summaries {
+		line := summary.Name
+		if summary.Description != "" {
+			line += " - " + summary.Description
+		}
+		if len(summary.Variables) > 0 {
+			line += fmt.Sprintf(" (variables: %s)", strings.Join(summary.Variables, ", "))
+		}
+		cmd.Println(line)
+	}
+	return nil
+}
+
+func runTemplateApply(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	varFlags, _ := cmd.Flags().GetStringArray("var")
+	noInput, _ := cmd.Flags().GetBool("no-input")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	fromGit, _ := cmd.Flags().GetBool("from-git")
+	repo, _ := cmd.Flags().GetString("repo")
+
+	cfg, err := config.FromContext(ctx)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	tmpl, err := templates.Find(cfg.Templates, args[0])
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	values, err := parseTemplateVars(varFlags)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	if fromGit {
+		if err := fillGitVars(repo, tmpl, values); err != nil {
+			return handleError(cmd, err)
+		}
+	}
+	if !noInput && !GetJSONFlag() && !GetQuietFlag() {
+		promptTemplateVars(cmd.InOrStdin(), cmd.ErrOrStderr(), tmpl, values)
+	}
+
+	plan, err := tmpl.Render(values, time.Now())
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	if dryRun {
+		if !GetQuietFlag() {
+			project := domain.Project{Name: plan.Project.Name, Status: "active", Note: plan.Project.Note}
+			for _, input := range plan.Tasks {
+				project.Tasks = append(project.Tasks, taskFromInput(input))
+			}
+			return printTemplateProject(cmd, project)
+		}
+		return nil
+	}
+
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	project, err := svc.CreateProject(ctx, plan.Project)
+	if err != nil {
+		return handleError(cmd, fmt.Errorf("failed to create project: %w", err))
+	}
+
+	var lastError error
+	reporter := newProgressReporter(cmd, len(plan.Tasks))
+	reporter.Start("Creating tasks", len(plan.Tasks))
+
+	for _, input := range plan.Tasks {
+		input.ProjectID = project.ID
+		task, err := svc.CreateTask(ctx, input)
+		reporter.Increment()
+		if err != nil {
+			lastError = err
+			if !GetQuietFlag() {
+				reporter.Clear()
+				printError(cmd, fmt.Errorf("failed to create task %q: %w", input.Name, err))
+			}
+			continue
+		}
+		project.Tasks = append(project.Tasks, *task)
+	}
+	reporter.Finish()
+
+	if !GetQuietFlag() {
+		if err := printTemplateProject(cmd, *project); err != nil {
+			return err
+		}
+	}
+
+	// If every task failed, return the last error
+	if len(project.Tasks) == 0 && lastError != nil {
+		return printed(lastError)
+	}
+
+	return nil
+}
+
+// parseTemplateVars parses NAME=VALUE pairs from --var
+func parseTemplateVars(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, invalidInput("invalid --var %q: expected NAME=VALUE", pair)
+		}
+		values[strings.TrimSpace(name)] = value
+	}
+	return values, nil
+}
+
+// fillGitVars sets the declared variables not already given from the git repository at dir
+func fillGitVars(dir string, tmpl *templates.Template, values map[string]string) error {
+	info, err := gitinfo.Read(gitinfo.Command(dir))
+	if err != nil {
+		return fmt.Errorf("failed to read git repository: %w", err)
+	}
+	for name, value := range info.Vars() {
+		if _, ok := values[name]; ok || value == "" || !tmpl.HasVariable(name) {
+			continue
+		}
+		values[name] = value
+	}
+	return nil
+}
+
+// promptTemplateVars asks for each variable not already set, stopping at end of input
+func promptTemplateVars(in io.Reader, out io.Writer, tmpl *templates.Template, values map[string]string) {
+	reader := bufio.NewReader(in)
+	for _, v := range tmpl.Variables {
+		if _, ok := values[v.Name]; ok {
+			continue
+		}
+
+		prompt := v.Prompt
+		if prompt == "" {
+			prompt = v.Name
+		}
+		if !v.Required() {
+			prompt += fmt.Sprintf(" [%s]", v.Default)
+		}
+		fmt.Fprintf(out, "%s: ", prompt)
+
+		line, err := reader.ReadString('\n')
+		if answer := strings.TrimSpace(line); answer != "" {
+			values[v.Name] = answer
+		}
+		if err != nil {
+			fmt.Fprintln(out)
+			return
+		}
+	}
+}
+
+// printTemplateProject prints a project together with its tasks
+func printTemplateProject(cmd *cobra.Command, project domain.Project) error {
+	formatter := getFormatter()
+	return formatter.FormatProjects(cmd.Context(), cmd.OutOrStdout(), []domain.Project{project}, output.ProjectFormatOptions{ShowTasks: true, ShowNotes: true})
+}
+
+// taskFromInput builds the task a create input would produce, for previews
+func taskFromInput(input domain.TaskInput) domain.Task {
+	task := domain.Task{
+		Name:      input.Name,
+		Note:      input.Note,
+		Tags:      input.TagNames,
+		DueDate:   input.DueDate,
+		DeferDate: input.DeferDate,
+	}
+	if input.Flagged != nil {
+		task.Flagged = *input.Flagged
+	}
+	return task
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+var testTemplates = []config.TemplateConfig{{
+	Name:        "onboarding",
+	Description: "Client onboarding",
+	Project:     "Onboard {{.ClientName}}",
+	Variables: []config.TemplateVariableConfig{
+		{Name: "ClientName", Prompt: "Client name"},
+		{Name: "DueOffsetDays", Default: "14"},
+	},
+	Tasks: []config.TemplateTaskConfig{
+		{Name: "Kickoff call with {{.ClientName}}", Due: "in {{.DueOffsetDays}} days"},
+		{Name: "Send contract"},
+	},
+}, {
+	Name:    "release",
+	Project: "Release {{.NextVersion}}",
+	Variables: []config.TemplateVariableConfig{
+		{Name: "NextVersion"},
+		{Name: "ChangedPackages", Default: "."},
+	},
+	Tasks: []config.TemplateTaskConfig{
+		{Name: "Check changelog for {{.Item}}", Each: "ChangedPackages"},
+		{Name: "Tag {{.NextVersion}}"},
+	},
+}}
+
+func newTemplateMockService() *service.MockOmniFocusService {
+	return &service.MockOmniFocusService{
+		CreatedProject: &domain.Project{ID: "proj1", Name: "Onboard Acme", Status: "active"},
+		CreatedTask:    &domain.Task{ID: "task1", Name: "Kickoff call with Acme"},
+	}
+}
+
+func TestTemplateApplyCommand_WithVars(t *testing.T) {
+	mockService := newTemplateMockService()
+
+	output, err := executeTemplateCommand(mockService, "", []string{"apply", "onboarding", "--var", "ClientName=Acme", "--no-input"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.CreatedInputs) != 2 {
+		t.Fatalf("CreateTask() called %d times, want 2", len(mockService.CreatedInputs))
+	}
+	first := mockService.CreatedInputs[0]
+	if first.Name != "Kickoff call with Acme" || first.ProjectID != "proj1" || first.DueDate == nil {
+		t.Errorf("CreateTask() input = %+v, want rendered task in proj1 with due date", first)
+	}
+	if !strings.Contains(output, "Onboard Acme") {
+		t.Errorf("Expected output to contain project name, got: %s", output)
+	}
+}
+
+func TestTemplateApplyCommand_PromptsForMissingVars(t *testing.T) {
+	mockService := newTemplateMockService()
+
+	output, err := executeTemplateCommand(mockService, "Acme\n\n", []string{"apply", "onboarding"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(output, "Client name: ") || !strings.Contains(output, "DueOffsetDays [14]: ") {
+		t.Errorf("Expected prompts for both variables, got: %s", output)
+	}
+	if len(mockService.CreatedInputs) == 0 || mockService.CreatedInputs[0].Name != "Kickoff call with Acme" {
+		t.Errorf("CreateTask() inputs = %+v, want prompted value used", mockService.CreatedInputs)
+	}
+}
+
+func TestTemplateApplyCommand_MissingVarWithoutInput(t *testing.T) {
+	_, err := executeTemplateCommand(newTemplateMockService(), "", []string{"apply", "onboarding", "--no-input"})
+
+	if err == nil || !strings.Contains(err.Error(), "missing value for variable ClientName") {
+		t.Errorf("Expected missing variable error, got: %v", err)
+	}
+}
+
+func TestTemplateApplyCommand_DryRun(t *testing.T) {
+	mockService := newTemplateMockService()
+
+	output, err := executeTemplateCommand(mockService, "", []string{"apply", "onboarding", "--var", "ClientName=Acme", "--dry-run", "--no-input"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(mockService.CreatedInputs) != 0 {
+		t.Errorf("CreateTask() called %d times, want none on dry run", len(mockService.CreatedInputs))
+	}
+	if !strings.Contains(output, "Onboard Acme") || !strings.Contains(output, "Send contract") {
+		t.Errorf("Expected preview of project and tasks, got: %s", output)
+	}
+}
+
+func TestTemplateApplyCommand_InvalidVar(t *testing.T) {
+	_, err := executeTemplateCommand(newTemplateMockService(), "", []string{"apply", "onboarding", "--var", "ClientName"})
+
+	if err == nil || !strings.Contains(err.Error(), "expected NAME=VALUE") {
+		t.Errorf("Expected invalid --var error, got: %v", err)
+	}
+}
+
+func TestTemplateApplyCommand_FromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name string) {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	write("main.go")
+	git("add", "-A")
+	git("commit", "-q", "-m", "initial")
+	git("tag", "v1.2.0")
+	write("internal/cli/add.go")
+	git("add", "-A")
+	git("commit", "-q", "-m", "change")
+
+	output, err := executeTemplateCommand(newTemplateMockService(), "", []string{"apply", "release", "--from-git", "--repo", repo, "--dry-run", "--no-input"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{"Release v1.2.1", "Check changelog for internal/cli", "Tag v1.2.1"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+}
+
+func TestTemplateApplyCommand_FromGitNotARepository(t *testing.T) {
+	_, err := executeTemplateCommand(newTemplateMockService(), "", []string{"apply", "release", "--from-git", "--repo", t.TempDir(), "--no-input"})
+
+	if err == nil || !strings.Contains(err.Error(), "failed to read git repository") {
+		t.Errorf("Expected git error, got: %v", err)
+	}
+}
+
+func TestTemplateListCommand(t *testing.T) {
+	output, err := executeTemplateCommand(&service.MockOmniFocusService{}, "", []string{"list"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := "onboarding - Client onboarding (variables: ClientName, DueOffsetDays)"
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got: %s", want, output)
+	}
+}
+
+// Helper function to execute template command with the test templates configured
+func executeTemplateCommand(mockService service.OmniFocusService, stdin string, args []string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewTemplateCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetIn(strings.NewReader(stdin))
+	rootCmd.SetArgs(append([]string{"template"}, args...))
+
+	ctx := config.ContextWithConfig(context.Background(), &config.Config{Templates: testTemplates})
+	ctx = ContextWithService(ctx, mockService)
+	err := rootCmd.ExecuteContext(ctx)
+
+	return buf.String(), err
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// setupTheme makes the TUI and table output use the theme and terminal
+// background chosen in cfg
+func setupTheme(cfg config.TUIConfig) error {
+	if err := tui.SetBackground(lipgloss.DefaultRenderer(), cfg.Background); err != nil {
+		return fmt.Errorf("invalid tui.background: %w", err)
+	}
+
+	theme, err := resolveTheme(cfg)
+	if err != nil {
+		return err
+	}
+	tui.SetDefaultTheme(theme)
+	return nil
+}
+
+// resolveTheme returns the theme called cfg.Theme, either one of cfg.Themes
+// or a built-in one, with any tui.colors changed from their defaults on top
+func resolveTheme(cfg config.TUIConfig) (tui.Theme, error) {
+	name := strings.ToLower(cfg.Theme)
+	var theme tui.Theme
+	if custom, ok := cfg.Themes[name]; ok {
+		base, err := tui.LookupTheme(custom.Base)
+		if err != nil {
+			return tui.Theme{}, fmt.Errorf("invalid base of theme %q: %w", cfg.Theme, err)
+		}
+		theme = base.
+			Override(themeColors(custom.Colors), themeColors(custom.Colors)).
+			Override(themeColors(custom.Light), themeColors(custom.Dark))
+		theme.Name = name
+	} else {
+		builtIn, err := tui.LookupTheme(cfg.Theme)
+		if err != nil {
+			return tui.Theme{}, err
+		}
+		theme = builtIn
+	}
+
+	// tui.colors predate themes and still win when set
+	var colors tui.ThemeColors
+	if cfg.Colors.Primary != config.DefaultColors.Primary {
+		colors.Primary = cfg.Colors.Primary
+	}
+	if cfg.Colors.Flagged != config.DefaultColors.Flagged {
+		colors.Flagged = cfg.Colors.Flagged
+	}
+	if cfg.Colors.Due != config.DefaultColors.Due {
+		colors.Warning = cfg.Colors.Due
+	}
+	if cfg.Colors.Overdue != config.DefaultColors.Overdue {
+		colors.Error = cfg.Colors.Overdue
+	}
+	return theme.Override(colors, colors), nil
+}
+
+// themeColors converts theme colors from the config file
+func themeColors(c config.ThemeColorsConfig) tui.ThemeColors {
+	return tui.ThemeColors{
+		Primary:   c.Primary,
+		Secondary: c.Secondary,
+		Success:   c.Success,
+		Warning:   c.Warning,
+		Error:     c.Error,
+		Flagged:   c.Flagged,
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func TestResolveTheme(t *testing.T) {
+	solarized, _ := tui.LookupTheme(tui.ThemeSolarized)
+
+	tests := []struct {
+		name        string
+		cfg         config.TUIConfig
+		wantPrimary string // Dark primary color
+		wantError   string // Dark error color
+	}{
+		{
+			name:        "built-in",
+			cfg:         config.TUIConfig{Theme: "solarized", Colors: config.DefaultColors},
+			wantPrimary: solarized.Colors.Primary.Dark,
+			wantError:   solarized.Colors.Error.Dark,
+		},
+		{
+			name: "custom theme",
+			cfg: config.TUIConfig{
+				Theme: "Ocean",
+				Themes: map[string]config.ThemeConfig{"ocean": {
+					Base:   "solarized",
+					Colors: config.ThemeColorsConfig{Primary: "#0077BE"},
+					Dark:   config.ThemeColorsConfig{Primary: "#66B2FF"},
+				}},
+			},
+			wantPrimary: "#66B2FF",
+			wantError:   solarized.Colors.Error.Dark,
+		},
+		{
+			name: "tui.colors",
+			cfg: config.TUIConfig{
+				Theme:  "solarized",
+				Colors: config.ColorConfig{Primary: config.DefaultColors.Primary, Overdue: "#FF0000"},
+			},
+			wantPrimary: solarized.Colors.Primary.Dark,
+			wantError:   "#FF0000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := resolveTheme(tt.cfg)
+			if err != nil {
+				t.Fatalf("resolveTheme() error = %v", err)
+			}
+			if got := theme.Colors.Primary.Dark; got != tt.wantPrimary {
+				t.Errorf("Primary.Dark = %q, want %q", got, tt.wantPrimary)
+			}
+			if got := theme.Colors.Error.Dark; got != tt.wantError {
+				t.Errorf("Error.Dark = %q, want %q", got, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestSetupTheme_Errors(t *testing.T) {
+	t.Cleanup(func() { _ = setupTheme(config.TUIConfig{}) })
+
+	if err := setupTheme(config.TUIConfig{Theme: "neon"}); err == nil {
+		t.Error("setupTheme() error = nil, want error for unknown theme")
+	}
+	bad := config.TUIConfig{Theme: "mine", Themes: map[string]config.ThemeConfig{"mine": {Base: "neon"}}}
+	if err := setupTheme(bad); err == nil {
+		t.Error("setupTheme() error = nil, want error for unknown base theme")
+	}
+	if err := setupTheme(config.TUIConfig{Background: "sepia"}); err == nil {
+		t.Error("setupTheme() error = nil, want error for invalid background")
+	}
+}
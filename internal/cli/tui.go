@@ -1,23 +1,34 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/exec"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pwojciechowski/lazyfocus/internal/app"
 	"github.com/pwojciechowski/lazyfocus/internal/bridge"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/health"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
+	"github.com/pwojciechowski/lazyfocus/internal/notetemplates"
+	"github.com/pwojciechowski/lazyfocus/internal/rules"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/session"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/views/forecast"
 	"github.com/spf13/cobra"
 )
 
 // NewTUICommand creates the tui command
 func NewTUICommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "tui",
-		Short: "Launch the interactive TUI",
-		Long:  `Launch the interactive terminal user interface for managing OmniFocus tasks.`,
-		RunE:  runTUI,
+		Use:     "tui",
+		Short:   "Launch the interactive TUI",
+		Long:    `Launch the interactive terminal user interface for managing OmniFocus tasks.`,
+		Example: `  lazyfocus tui`,
+		RunE:    runTUI,
 		Annotations: map[string]string{
 			"skipServiceSetup": "true",
 		},
@@ -27,19 +38,165 @@ func NewTUICommand() *cobra.Command {
 }
 
 func runTUI(cmd *cobra.Command, args []string) error {
-	// Create executor and service
-	executor := bridge.NewOSAScriptExecutor()
-	svc := service.NewOmniFocusService(executor, 30*time.Second)
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if err := setupCalendar(cfg.Calendar); err != nil {
+		return err
+	}
+	forecastLabels, err := forecast.NewLabels(cfg.TUI.Forecast.Labels, cfg.TUI.Forecast.Order, cfg.TUI.Forecast.TaskCount)
+	if err != nil {
+		return fmt.Errorf("invalid tui.forecast: %w", err)
+	}
+	confirmPolicy, err := app.NewConfirmPolicy(cfg.TUI.Confirm.Delete, cfg.TUI.Confirm.Complete, cfg.TUI.Confirm.ChordTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid tui.confirm: %w", err)
+	}
+
+	// --timeout wins over the timeout in the config file
+	timeout := cfg.Timeout
+	if cmd.Flags().Changed("timeout") {
+		timeout = GetTimeoutFlag()
+	}
+
+	// Create executor and service, filling in note templates of created tasks
+	var base service.OmniFocusService = service.NewOmniFocusService(newExecutor(cfg), timeout)
+	if len(cfg.NoteTemplates) > 0 {
+		templates, err := notetemplates.New(cfg.NoteTemplates)
+		if err != nil {
+			return fmt.Errorf("invalid note templates: %w", err)
+		}
+		base = service.NewNoteTemplateOmniFocusService(base, templates, notetemplates.SourceTUI)
+	}
+	var cached service.OmniFocusService = service.NewCachedOmniFocusService(base, service.DefaultCacheTTL)
+
+	// Check WIP limits against cached task lists, warning with a toast; rules
+	// wrap the limits so the tags they add are checked too
+	limitList, err := limits.New(cfg.Limits)
+	if err != nil {
+		return fmt.Errorf("invalid limits: %w", err)
+	}
+	var p *tea.Program
+	if len(limitList) > 0 {
+		cached = service.NewLimitsOmniFocusService(cached, limitList, func(usage limits.Usage) {
+			p.Send(app.LimitWarningMsg{Usage: usage})
+		})
+	}
+	if len(cfg.Rules) > 0 {
+		engine, err := rules.New(cfg.Rules)
+		if err != nil {
+			return fmt.Errorf("invalid rules: %w", err)
+		}
+		cached = service.NewRulesOmniFocusService(cached, engine)
+	}
+
+	// Track writes in flight so quitting can wait for them or hand them off
+	svc := service.NewPendingOmniFocusService(cached)
+	device := deviceID(cfg)
+	svc.SetDevice(device)
 
-	// Create app model
+	// Create app model, reopening where the last session left off
 	model := app.NewApp(svc)
+	sessionPath := config.SessionStatePath()
+	state, err := session.Load(sessionPath)
+	if err != nil {
+		cmd.PrintErrf("Warning: %v; starting a new session\n", err)
+	}
+	model = model.RestoreSession(state)
+
+	// Explain a missing Automation permission once the first script fails
+	model = model.SetPermissionCheck(func() error {
+		return bridge.CheckAutomationPermission(context.Background())
+	})
+
+	// Describe anything that does not work in a banner at startup
+	model = model.SetHealthCheck(func() health.Snapshot {
+		return health.NewChecker().Run(context.Background())
+	})
+
+	// Show the current view in the terminal title
+	model = model.SetWindowTitle(cfg.TUI.WindowTitle)
+	model = model.SetShowPath(cfg.TUI.ShowPath)
+
+	// Name and order Forecast groups as configured
+	model = model.SetForecastLabels(forecastLabels)
+
+	// Confirm dangerous actions with a modal or a double press, as configured
+	model = model.SetConfirmPolicy(confirmPolicy)
+
+	// Show task counts against their WIP limits
+	model = model.SetLimits(limitList)
 
-	// Create and run Bubble Tea program with alt screen
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	// Rank :next suggestions as lazyfocus next does
+	model = model.SetNextOptions(nextWeights(cfg.Next.Weights), cfg.Next.Context)
 
-	if _, err := p.Run(); err != nil {
+	// Name this machine in conflicts, as in the pending writes journal
+	model = model.SetDevice(device)
+
+	// Create and run Bubble Tea program with alt screen and mouse support
+	p = tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithContext(cmd.Context()))
+
+	final, err := p.Run()
+	if cfg.TUI.WindowTitle {
+		fmt.Fprint(os.Stdout, app.WindowTitleReset)
+	}
+	if err != nil {
 		return fmt.Errorf("error running TUI: %w", err)
 	}
 
+	m, ok := final.(app.Model)
+	if !ok {
+		return nil
+	}
+	if err := m.Session().Save(sessionPath); err != nil {
+		cmd.PrintErrf("Warning: %v\n", err)
+	}
+	if len(m.BackgroundWrites()) > 0 {
+		return finishInBackground(cmd, svc, timeout)
+	}
+
+	return nil
+}
+
+// finishInBackground hands the writes pending at quit to a detached flush
+// without waiting for them. The flush gives the ones still running a little
+// longer than a script may take to land before it replays them, skipping any
+// whose effect is then visible.
+func finishInBackground(cmd *cobra.Command, svc *service.PendingOmniFocusService, timeout time.Duration) error {
+	writes := svc.Detach()
+	if len(writes) == 0 {
+		return nil
+	}
+	return startBackgroundFlush(cmd, writes, timeout+5*time.Second)
+}
+
+// startBackgroundFlush saves writes pending at quit to a journal and starts a
+// detached `lazyfocus flush` to finish them after wait
+func startBackgroundFlush(cmd *cobra.Command, writes []service.PendingWrite, wait time.Duration) error {
+	f, err := os.CreateTemp("", health.JournalPattern)
+	if err != nil {
+		return fmt.Errorf("failed to create journal: %w", err)
+	}
+	path := f.Name()
+	f.Close()
+
+	if err := service.WriteJournal(path, writes); err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to start background flush (run lazyfocus flush %s): %w", path, err)
+	}
+	flush := exec.Command(exe, "flush", "--quiet", "--wait", wait.String(), path)
+	flush.SysProcAttr = detachedProcAttr()
+	if err := flush.Start(); err != nil {
+		return fmt.Errorf("failed to start background flush (run lazyfocus flush %s): %w", path, err)
+	}
+	// The flush outlives this process; don't wait for it
+	_ = flush.Process.Release()
+
+	cmd.Printf("Finishing %d pending operations in the background (journal: %s)\n", len(writes), path)
 	return nil
 }
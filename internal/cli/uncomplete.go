@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewUncompleteCommand creates the uncomplete command
+func NewUncompleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "uncomplete <task-id...>",
+		Aliases: []string{"reopen"},
+		Short:   "Reopen completed tasks in OmniFocus",
+		Long: `Mark one or more completed tasks as incomplete again in OmniFocus.
+
+Accepts one or more task IDs, or the numbers the last tasks listing printed,
+as arguments. Find recently completed tasks with
+` + "`lazyfocus tasks --completed --since 7d`" + `. The command will attempt to
+reopen all specified tasks, continuing even if some fail.`,
+		Example: `  lazyfocus tasks --completed --since 7d
+  lazyfocus uncomplete 2        # Task 2 of the last lazyfocus tasks
+  lazyfocus reopen abc123 def456 --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runUncomplete,
+	}
+
+	return cmd
+}
+
+func runUncomplete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	svc, err := getServiceFromCmd(cmd)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+	args, err = resolveTaskIDs(args)
+	if err != nil {
+		return handleError(cmd, err)
+	}
+
+	var lastError error
+	successCount := 0
+	formatter := getFormatter()
+
+	reporter := newProgressReporter(cmd, len(args))
+	reporter.Start("Reopening tasks", len(args))
+	defer reporter.Finish()
+
+	for _, taskID := range args {
+		result, err := svc.UncompleteTask(ctx, taskID)
+		reporter.Increment()
+		if err != nil {
+			lastError = err
+			if !GetQuietFlag() {
+				reporter.Clear()
+				printError(cmd, fmt.Errorf("failed to reopen %s: %w", taskID, err))
+			}
+			continue
+		}
+
+		successCount++
+		if !GetQuietFlag() {
+			reporter.Clear()
+			if err := formatter.FormatUncompletedTask(ctx, cmd.OutOrStdout(), *result); err != nil {
+				return err
+			}
+		}
+	}
+
+	// If all tasks failed, return the last error
+	if successCount == 0 && lastError != nil {
+		return printed(lastError)
+	}
+
+	return nil
+}
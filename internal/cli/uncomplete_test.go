@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestUncompleteCommand(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		UncompleteResult: &domain.OperationResult{Success: true, ID: "task123", Message: "Task reopened"},
+	}
+
+	output, err := executeUncompleteCommand(mockService, "uncomplete", "task123")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, "Reopened: task123") {
+		t.Errorf("Expected output to report the reopened task, got: %s", output)
+	}
+}
+
+func TestUncompleteCommand_ReopenAlias(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		UncompleteResult: &domain.OperationResult{Success: true, ID: "task123", Message: "Task reopened"},
+	}
+
+	output, err := executeUncompleteCommand(mockService, "reopen", "task123", "--json")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(output, `"id": "task123"`) {
+		t.Errorf("Expected JSON output with the task ID, got: %s", output)
+	}
+}
+
+func TestUncompleteCommand_Error(t *testing.T) {
+	mockService := &service.MockOmniFocusService{
+		UncompleteTaskErr: errors.New("Task is not completed: task123"),
+	}
+
+	output, err := executeUncompleteCommand(mockService, "uncomplete", "task123")
+	if err == nil {
+		t.Fatal("Expected an error when every task fails")
+	}
+	if !strings.Contains(output, "failed to reopen task123") {
+		t.Errorf("Expected the failure to be reported, got: %s", output)
+	}
+}
+
+func executeUncompleteCommand(mockService service.OmniFocusService, args ...string) (string, error) {
+	rootCmd := newTestRootCommand()
+	rootCmd.AddCommand(NewUncompleteCommand())
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(args)
+
+	err := rootCmd.ExecuteContext(ContextWithService(context.Background(), mockService))
+	return buf.String(), err
+}
@@ -16,10 +16,11 @@ var (
 // NewVersionCommand creates the version command
 func NewVersionCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "version",
-		Short: "Print version information",
-		Long:  `Print version information for lazyfocus.`,
-		Args:  cobra.NoArgs,
+		Use:     "version",
+		Short:   "Print version information",
+		Long:    `Print version information for lazyfocus.`,
+		Example: `  lazyfocus version`,
+		Args:    cobra.NoArgs,
 		Annotations: map[string]string{
 			"skipServiceSetup": "true",
 		},
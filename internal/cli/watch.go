@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultWatchInterval is how often --watch runs a query by default
+const defaultWatchInterval = 30 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\x1b[H\x1b[2J"
+
+// watchCommand calls run every interval, or when Enter is read from stdin,
+// until q is entered, the context ends or the process is interrupted. An
+// error from the first run is returned, since the flags will not get better;
+// later errors are shown and the next run tries again.
+func watchCommand(cmd *cobra.Command, interval time.Duration, run func(*cobra.Command) error) error {
+	if interval <= 0 {
+		return handleError(cmd, invalidInput("--interval must be positive"))
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Lines typed while watching; closed at end of input
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(cmd.InOrStdin())
+		for scanner.Scan() {
+			select {
+			case lines <- strings.TrimSpace(scanner.Text()):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for first := true; ; first = false {
+		if terminalWidth() > 0 {
+			cmd.Print(clearScreen)
+		}
+		if format := GetOutputFlag(); !GetQuietFlag() && (format == OutputHuman || format == OutputTable) {
+			cmd.Printf("Every %s, updated %s (Enter: refresh, q: quit)\n\n", interval, time.Now().Format("15:04:05"))
+		}
+		if err := run(cmd); err != nil && first {
+			return err
+		}
+
+		var refresh bool
+		if refresh, lines = waitForRefresh(ctx, ticker, interval, lines); !refresh {
+			return nil
+		}
+	}
+}
+
+// waitForRefresh waits for the ticker or an entered line and reports whether
+// to run again, false once q is entered or ctx ends. Enter restarts the
+// interval. The lines channel is returned as nil once input has ended.
+func waitForRefresh(ctx context.Context, ticker *time.Ticker, interval time.Duration, lines chan string) (bool, chan string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, lines
+		case <-ticker.C:
+			return true, lines
+		case line, ok := <-lines:
+			switch {
+			case !ok:
+				// Without input, only the interval refreshes
+				lines = nil
+			case strings.EqualFold(line, "q"):
+				return false, lines
+			default:
+				ticker.Reset(interval)
+				return true, lines
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+// maxBundleFileBytes limits the size of a single file read from a bundle
+const maxBundleFileBytes = 10 << 20
+
+// BundleFile is a file packaged in a configuration bundle
+type BundleFile struct {
+	Name string // Name inside the bundle, e.g. "lazyfocus.yaml"
+	Path string // Location on this machine
+}
+
+// BundleFiles returns the files a configuration bundle carries: the config
+// file, which holds the theme, templates, note templates, rules, schedule and
+// API tokens, and the saved TUI filters. Session state is left out, since it
+// only makes sense on the machine that wrote it; ExportBundle leaves out the
+// tokens unless asked to keep them.
+func BundleFiles() []BundleFile {
+	return []BundleFile{
+		{Name: "lazyfocus.yaml", Path: FilePath()},
+		{Name: "filters.json", Path: SavedFiltersPath()},
+	}
+}
+
+// ExportBundle writes the files that exist to w as a gzipped tar archive and
+// returns the names it wrote. Bundles are shared, so the API tokens of the
+// config file are left out unless includeTokens is set.
+func ExportBundle(w io.Writer, files []BundleFile, includeTokens bool) ([]string, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	var written []string
+	for _, file := range files {
+		data, err := os.ReadFile(file.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+		if filepath.Ext(file.Name) == ".yaml" && !includeTokens {
+			if data, err = stripAPITokens(data); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+			}
+		}
+		header := &tar.Header{
+			Name:    file.Name,
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("failed to write bundle: %w", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to write bundle: %w", err)
+		}
+		written = append(written, file.Name)
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return written, nil
+}
+
+// stripAPITokens removes api.tokens from a config file, and the api section
+// when nothing else is left in it. Files without tokens are returned as is.
+func stripAPITokens(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil
+	}
+
+	root := doc.Content[0]
+	api := mappingValue(root, "api")
+	if api == nil || api.Kind != yaml.MappingNode || !removeMappingKey(api, "tokens") {
+		return data, nil
+	}
+	if len(api.Content) == 0 {
+		removeMappingKey(root, "api")
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mappingValue returns the value of key in a YAML mapping, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// removeMappingKey removes key and its value from a YAML mapping, reporting
+// whether it was there
+func removeMappingKey(mapping *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ImportBundle reads a bundle written by ExportBundle and writes its files to
+// their paths on this machine, returning the names it wrote. Nothing is
+// written if the bundle is invalid, or if a file already exists and overwrite
+// is false.
+func ImportBundle(r io.Reader, files []BundleFile, overwrite bool) ([]string, error) {
+	paths := make(map[string]string, len(files))
+	for _, file := range files {
+		paths[file.Name] = file.Path
+	}
+
+	contents, err := readBundle(r, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if !overwrite {
+		for _, name := range names {
+			if _, err := os.Stat(paths[name]); err == nil {
+				return nil, fmt.Errorf("%s already exists (use --force to replace it)", paths[name])
+			}
+		}
+	}
+
+	for _, name := range names {
+		path := paths[name]
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, contents[name], 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return names, nil
+}
+
+// readBundle reads and validates every file in the bundle
+func readBundle(r io.Reader, paths map[string]string) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	contents := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle: %w", err)
+		}
+		if _, ok := paths[header.Name]; !ok || header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("invalid bundle: unexpected entry %q", header.Name)
+		}
+		if header.Size > maxBundleFileBytes {
+			return nil, fmt.Errorf("invalid bundle: %s is too large", header.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle: %w", err)
+		}
+		if err := validateBundleFile(header.Name, data); err != nil {
+			return nil, fmt.Errorf("invalid bundle: %s: %w", header.Name, err)
+		}
+		contents[header.Name] = data
+	}
+	if len(contents) == 0 {
+		return nil, errors.New("invalid bundle: no configuration files")
+	}
+	return contents, nil
+}
+
+// validateBundleFile checks that a bundled file parses
+func validateBundleFile(name string, data []byte) error {
+	switch filepath.Ext(name) {
+	case ".yaml":
+		v := viper.New()
+		v.SetConfigType("yaml")
+		if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+			return err
+		}
+		var cfg Config
+		return v.Unmarshal(&cfg)
+	case ".json":
+		if !json.Valid(data) {
+			return errors.New("not valid JSON")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testBundleFiles(dir string) []BundleFile {
+	return []BundleFile{
+		{Name: "lazyfocus.yaml", Path: filepath.Join(dir, "config", ".lazyfocus.yaml")},
+		{Name: "filters.json", Path: filepath.Join(dir, "config", ".lazyfocus-filters.json")},
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBundle_RoundTrip(t *testing.T) {
+	src := testBundleFiles(t.TempDir())
+	writeTestFile(t, src[0].Path, "tui:\n  theme: solarized\n")
+	writeTestFile(t, src[1].Path, `{"work":{"flaggedOnly":true}}`)
+
+	var buf bytes.Buffer
+	written, err := ExportBundle(&buf, src, false)
+	if err != nil {
+		t.Fatalf("ExportBundle() error = %v", err)
+	}
+	if strings.Join(written, ",") != "lazyfocus.yaml,filters.json" {
+		t.Errorf("ExportBundle() = %v, want both files", written)
+	}
+
+	dst := testBundleFiles(t.TempDir())
+	imported, err := ImportBundle(&buf, dst, false)
+	if err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+	if len(imported) != 2 {
+		t.Errorf("ImportBundle() = %v, want 2 files", imported)
+	}
+	for i := range src {
+		want, _ := os.ReadFile(src[i].Path)
+		got, err := os.ReadFile(dst[i].Path)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("imported %s = %q (%v), want %q", dst[i].Name, got, err, want)
+		}
+	}
+}
+
+func TestExportBundle_SkipsMissingFiles(t *testing.T) {
+	files := testBundleFiles(t.TempDir())
+	writeTestFile(t, files[1].Path, `{}`)
+
+	written, err := ExportBundle(&bytes.Buffer{}, files, false)
+	if err != nil {
+		t.Fatalf("ExportBundle() error = %v", err)
+	}
+	if len(written) != 1 || written[0] != "filters.json" {
+		t.Errorf("ExportBundle() = %v, want filters.json only", written)
+	}
+}
+
+func TestExportBundle_LeavesOutAPITokens(t *testing.T) {
+	src := testBundleFiles(t.TempDir())
+	writeTestFile(t, src[0].Path, `timeout: 10s
+api:
+  tokens:
+    - name: phone
+      token: s3cret-bearer
+      scope: full
+`)
+
+	var buf bytes.Buffer
+	if _, err := ExportBundle(&buf, src, false); err != nil {
+		t.Fatalf("ExportBundle() error = %v", err)
+	}
+	dst := testBundleFiles(t.TempDir())
+	if _, err := ImportBundle(bytes.NewReader(buf.Bytes()), dst, false); err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+	got, _ := os.ReadFile(dst[0].Path)
+	if strings.Contains(string(got), "s3cret-bearer") || strings.Contains(string(got), "api:") {
+		t.Errorf("exported config = %q, want no API tokens", got)
+	}
+	if !strings.Contains(string(got), "timeout: 10s") {
+		t.Errorf("exported config = %q, want the other settings kept", got)
+	}
+
+	buf.Reset()
+	if _, err := ExportBundle(&buf, src, true); err != nil {
+		t.Fatalf("ExportBundle(includeTokens) error = %v", err)
+	}
+	dst = testBundleFiles(t.TempDir())
+	if _, err := ImportBundle(&buf, dst, false); err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+	if got, _ := os.ReadFile(dst[0].Path); !strings.Contains(string(got), "s3cret-bearer") {
+		t.Errorf("exported config = %q, want the tokens kept with includeTokens", got)
+	}
+}
+
+func TestImportBundle_ExistingFiles(t *testing.T) {
+	src := testBundleFiles(t.TempDir())
+	writeTestFile(t, src[0].Path, "timeout: 10s\n")
+	var buf bytes.Buffer
+	if _, err := ExportBundle(&buf, src, false); err != nil {
+		t.Fatal(err)
+	}
+	bundle := buf.Bytes()
+
+	dst := testBundleFiles(t.TempDir())
+	writeTestFile(t, dst[0].Path, "timeout: 5s\n")
+
+	if _, err := ImportBundle(bytes.NewReader(bundle), dst, false); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("ImportBundle() error = %v, want already exists", err)
+	}
+	if got, _ := os.ReadFile(dst[0].Path); string(got) != "timeout: 5s\n" {
+		t.Errorf("existing file = %q, want it left alone", got)
+	}
+
+	if _, err := ImportBundle(bytes.NewReader(bundle), dst, true); err != nil {
+		t.Fatalf("ImportBundle(overwrite) error = %v", err)
+	}
+	if got, _ := os.ReadFile(dst[0].Path); string(got) != "timeout: 10s\n" {
+		t.Errorf("overwritten file = %q, want bundled config", got)
+	}
+}
+
+// tarGz builds a bundle with the given entries
+func tarGz(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	return buf.Bytes()
+}
+
+func TestImportBundle_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		bundle []byte
+		want   string
+	}{
+		{name: "not gzip", bundle: []byte("plain text"), want: "invalid bundle"},
+		{name: "unknown entry", bundle: tarGz(t, map[string]string{"../.bashrc": "rm -rf ~"}), want: `unexpected entry "../.bashrc"`},
+		{name: "bad yaml", bundle: tarGz(t, map[string]string{"lazyfocus.yaml": "tui: [unclosed"}), want: "lazyfocus.yaml"},
+		{name: "bad json", bundle: tarGz(t, map[string]string{"filters.json": "{"}), want: "not valid JSON"},
+		{name: "empty", bundle: tarGz(t, nil), want: "no configuration files"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := testBundleFiles(t.TempDir())
+			_, err := ImportBundle(bytes.NewReader(tt.bundle), dst, true)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ImportBundle() error = %v, want %q", err, tt.want)
+			}
+			for _, file := range dst {
+				if _, err := os.Stat(file.Path); err == nil {
+					t.Errorf("ImportBundle() wrote %s from an invalid bundle", file.Name)
+				}
+			}
+		})
+	}
+}
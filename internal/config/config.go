@@ -22,15 +22,89 @@ var ErrConfigNotFound = errors.New("config not found in context")
 
 // Config holds the application configuration
 type Config struct {
-	Output   OutputConfig   `mapstructure:"output"`
-	Timeout  time.Duration  `mapstructure:"timeout"`
-	Defaults DefaultsConfig `mapstructure:"defaults"`
-	TUI      TUIConfig      `mapstructure:"tui"`
+	Output       OutputConfig     `mapstructure:"output"`
+	Timeout      time.Duration    `mapstructure:"timeout"`
+	MaxPayloadMB int              `mapstructure:"max_payload_mb"` // Max script output size before paginating
+	Retry        RetryConfig      `mapstructure:"retry"`          // Retries of transient OmniFocus failures
+	Defaults     DefaultsConfig   `mapstructure:"defaults"`
+	TUI          TUIConfig        `mapstructure:"tui"`
+	Rules        []RuleConfig     `mapstructure:"rules"`
+	Limits       []LimitConfig    `mapstructure:"limits"`   // WIP limits on open tasks per tag or project
+	Schedule     []JobConfig      `mapstructure:"schedule"` // Actions run by `lazyfocus serve`
+	Templates    []TemplateConfig `mapstructure:"templates"`
+
+	NoteTemplates []NoteTemplateConfig `mapstructure:"note_templates"` // Default notes for new tasks
+
+	API APIConfig `mapstructure:"api"` // HTTP API served by `lazyfocus serve --listen`
+
+	Calendar CalendarConfig `mapstructure:"calendar"` // Days relative dates skip
+
+	Next NextConfig `mapstructure:"next"` // How `lazyfocus next` ranks tasks
+
+	Backup BackupConfig `mapstructure:"backup"` // Where `lazyfocus backup` finds and copies backups
+
+	Digest DigestConfig `mapstructure:"digest"` // Where `lazyfocus digest` emails the weekly report
+
+	DeviceID string `mapstructure:"device_id"` // Names this machine in the debug log, pending writes and conflicts (default: host name)
+}
+
+// NextConfig holds how `lazyfocus next` and the TUI's next panel score tasks
+type NextConfig struct {
+	Weights NextWeightsConfig `mapstructure:"weights"`
+	Context []string          `mapstructure:"context"` // Tags that fit where you usually work, e.g. "office"
+}
+
+// NextWeightsConfig holds the weight of each factor of a task's score; 0
+// ignores the factor
+type NextWeightsConfig struct {
+	Due      float64 `mapstructure:"due"`      // Overdue or due within a week
+	Flagged  float64 `mapstructure:"flagged"`  // Flagged
+	Estimate float64 `mapstructure:"estimate"` // Estimate fits the time available
+	Context  float64 `mapstructure:"context"`  // Tagged with a context
+	Stale    float64 `mapstructure:"stale"`    // Unchanged for two weeks or more
+	Effort   float64 `mapstructure:"effort"`   // Effort suits the energy available
+}
+
+// BackupConfig holds where `lazyfocus backup` looks for the backups OmniFocus
+// writes and where it copies them
+type BackupConfig struct {
+	Dir         string `mapstructure:"dir"`         // OmniFocus backups folder (default: found in ~/Library/Containers)
+	Destination string `mapstructure:"destination"` // Folder each new backup is copied to; empty keeps it in place
+}
+
+// DigestConfig holds where `lazyfocus digest` sends the weekly report
+type DigestConfig struct {
+	To   string     `mapstructure:"to"` // Recipient when --email is not given
+	SMTP SMTPConfig `mapstructure:"smtp"`
+}
+
+// SMTPConfig holds the mail server the digest is sent through. The password
+// is read from the macOS keychain, never from the config file.
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`     // 587 uses STARTTLS; 465 uses TLS from the start
+	Username string `mapstructure:"username"` // Login, and the keychain item's account
+	From     string `mapstructure:"from"`     // Sender address (default: username)
+	Keychain string `mapstructure:"keychain"` // Service name of the keychain item holding the password
+}
+
+// CalendarConfig holds the days relative dates such as "tomorrow" skip
+type CalendarConfig struct {
+	SkipWeekends bool     `mapstructure:"skip_weekends"` // Move relative dates off Saturday and Sunday
+	Holidays     []string `mapstructure:"holidays"`      // Days to skip, as YYYY-MM-DD
+	HolidaysICS  string   `mapstructure:"holidays_ics"`  // iCalendar file whose events are holidays
+}
+
+// RetryConfig holds how OmniFocus scripts that fail transiently are retried
+type RetryConfig struct {
+	Attempts    int           `mapstructure:"attempts"`     // Tries per script including the first; 1 disables retries
+	InitialWait time.Duration `mapstructure:"initial_wait"` // Wait before the first retry, doubled for each one after
+	MaxWait     time.Duration `mapstructure:"max_wait"`     // Longest wait between retries
 }
 
 // OutputConfig holds output-related configuration
 type OutputConfig struct {
-	Format string `mapstructure:"format"` // "human" or "json"
+	Format string `mapstructure:"format"` // "human", "json", "jsonl", "csv", "tsv" or "table"
 }
 
 // DefaultsConfig holds default values for commands
@@ -38,10 +112,144 @@ type DefaultsConfig struct {
 	Project string `mapstructure:"project"` // Default project name
 }
 
+// RuleConfig holds a user-defined rule applied to new tasks and by `rules apply`
+type RuleConfig struct {
+	Name    string            `mapstructure:"name"`
+	Match   RuleMatchConfig   `mapstructure:"match"`
+	Actions RuleActionsConfig `mapstructure:"actions"`
+}
+
+// RuleMatchConfig holds rule conditions; every condition that is set must match
+type RuleMatchConfig struct {
+	Name    string `mapstructure:"name"`    // Case-insensitive regular expression for the task name
+	Project string `mapstructure:"project"` // Project name (case-insensitive)
+	Tag     string `mapstructure:"tag"`     // Tag name the task already has (case-insensitive)
+}
+
+// RuleActionsConfig holds the changes a matching rule makes
+type RuleActionsConfig struct {
+	AddTags []string `mapstructure:"add_tags"` // Tag names to add
+	Defer   string   `mapstructure:"defer"`    // Defer date (e.g. "next weekday"), only if unset
+	Due     string   `mapstructure:"due"`      // Due date (e.g. "friday"), only if unset
+	Flag    bool     `mapstructure:"flag"`     // Mark the task flagged
+}
+
+// LimitConfig holds a work-in-progress limit: the most open tasks allowed
+// with a tag or in a project
+type LimitConfig struct {
+	Tag     string `mapstructure:"tag"`     // Tag name (case-insensitive)
+	Project string `mapstructure:"project"` // Project name (case-insensitive) or ID
+	Max     int    `mapstructure:"max"`
+	Block   bool   `mapstructure:"block"` // Refuse changes past the limit instead of warning
+}
+
+// JobConfig holds an action run on a cron-like schedule by `lazyfocus serve`
+type JobConfig struct {
+	Name   string `mapstructure:"name"`
+	Cron   string `mapstructure:"cron"`   // Five-field cron expression or @hourly, @daily, @weekly, @monthly
+	Action string `mapstructure:"action"` // "rules", "report" or "digest"
+	Output string `mapstructure:"output"` // File the report action writes to
+	Email  string `mapstructure:"email"`  // Recipient of the digest action (default: digest.to)
+}
+
+// APIConfig holds the settings of the HTTP API
+type APIConfig struct {
+	Tokens []APITokenConfig `mapstructure:"tokens"`
+}
+
+// APITokenConfig holds a bearer token accepted by the HTTP API and what it may do
+type APITokenConfig struct {
+	Name  string `mapstructure:"name"`
+	Token string `mapstructure:"token"` // Secret sent as "Authorization: Bearer <token>"
+	Scope string `mapstructure:"scope"` // "read-only", "create-only" or "full"
+}
+
+// TemplateConfig holds a project template instantiated by `template apply`.
+// Text fields may reference declared variables as {{.Name}}.
+type TemplateConfig struct {
+	Name        string                   `mapstructure:"name"`
+	Description string                   `mapstructure:"description"`
+	Project     string                   `mapstructure:"project"` // Name of the project to create
+	Note        string                   `mapstructure:"note"`
+	Due         string                   `mapstructure:"due"`   // Project due date (e.g. "in {{.DueOffsetDays}} days")
+	Defer       string                   `mapstructure:"defer"` // Project defer date
+	Variables   []TemplateVariableConfig `mapstructure:"variables"`
+	Tasks       []TemplateTaskConfig     `mapstructure:"tasks"`
+}
+
+// TemplateVariableConfig declares a value supplied when a template is applied
+type TemplateVariableConfig struct {
+	Name    string `mapstructure:"name"`    // Referenced as {{.Name}}
+	Prompt  string `mapstructure:"prompt"`  // Question shown when prompting interactively
+	Default string `mapstructure:"default"` // Used when no value is given; empty means required
+}
+
+// TemplateTaskConfig holds a task created in the template's project
+type TemplateTaskConfig struct {
+	Name  string   `mapstructure:"name"`
+	Note  string   `mapstructure:"note"`
+	Tags  []string `mapstructure:"tags"`
+	Due   string   `mapstructure:"due"`
+	Defer string   `mapstructure:"defer"`
+	Flag  bool     `mapstructure:"flag"`
+	Each  string   `mapstructure:"each"` // Repeat the task for every comma-separated value of this variable
+}
+
+// NoteTemplateConfig holds the default note of new tasks in a project or with
+// a tag. The note may reference {{.Date}}, {{.Time}}, {{.Name}}, {{.Project}}
+// and {{.Source}}.
+type NoteTemplateConfig struct {
+	Project string `mapstructure:"project"` // Project name (case-insensitive)
+	Tag     string `mapstructure:"tag"`     // Tag name (case-insensitive)
+	Note    string `mapstructure:"note"`
+}
+
 // TUIConfig holds TUI-related configuration
 type TUIConfig struct {
-	Theme  string      `mapstructure:"theme"` // "default" or custom
-	Colors ColorConfig `mapstructure:"colors"`
+	Theme       string                 `mapstructure:"theme"`      // Built-in theme or one of Themes
+	Background  string                 `mapstructure:"background"` // "auto", "light" or "dark"
+	Colors      ColorConfig            `mapstructure:"colors"`
+	Themes      map[string]ThemeConfig `mapstructure:"themes"`       // User-defined themes by name
+	WindowTitle bool                   `mapstructure:"window_title"` // Show the current view in the terminal title
+	ShowPath    bool                   `mapstructure:"show_path"`    // Show the folder and project of each task in lists and details
+	Forecast    ForecastConfig         `mapstructure:"forecast"`
+	Confirm     ConfirmConfig          `mapstructure:"confirm"`
+}
+
+// ConfirmConfig picks how dangerous TUI actions are confirmed: "modal" asks
+// in a dialog, "chord" takes a second press of the action's key, like vim's dd
+type ConfirmConfig struct {
+	Delete       string        `mapstructure:"delete"`        // Deleting tasks
+	Complete     string        `mapstructure:"complete"`      // Completing marked tasks
+	ChordTimeout time.Duration `mapstructure:"chord_timeout"` // Longest wait for the second press
+}
+
+// ForecastConfig renames and reorders the forecast's groups. Groups are
+// named overdue, today, tomorrow, this-week, later, no-due and pinned.
+type ForecastConfig struct {
+	Labels    map[string]string `mapstructure:"labels"`     // Header name by group
+	Order     []string          `mapstructure:"order"`      // Groups listed first; the rest keep the default order
+	TaskCount string            `mapstructure:"task_count"` // Template for "N tasks" in the header, given .Count and plural
+}
+
+// ThemeConfig defines a TUI theme as a built-in theme with some colors
+// replaced
+type ThemeConfig struct {
+	Base   string            `mapstructure:"base"`   // Built-in theme to start from (default "default")
+	Colors ThemeColorsConfig `mapstructure:"colors"` // Colors on any background
+	Light  ThemeColorsConfig `mapstructure:"light"`  // Colors on light terminals only
+	Dark   ThemeColorsConfig `mapstructure:"dark"`   // Colors on dark terminals only
+}
+
+// ThemeColorsConfig holds the colors of a theme, as "#RRGGBB" hex colors or
+// ANSI color numbers. Empty colors keep the base theme's.
+type ThemeColorsConfig struct {
+	Primary   string `mapstructure:"primary"`   // Accent, headers and the selected row
+	Secondary string `mapstructure:"secondary"` // Dimmed text and borders
+	Success   string `mapstructure:"success"`
+	Warning   string `mapstructure:"warning"` // Due today
+	Error     string `mapstructure:"error"`   // Overdue and errors
+	Flagged   string `mapstructure:"flagged"`
 }
 
 // ColorConfig holds color configuration for TUI
@@ -52,6 +260,55 @@ type ColorConfig struct {
 	Overdue string `mapstructure:"overdue"` // Color for overdue items
 }
 
+// DefaultColors are the tui.colors defaults, which leave the theme's colors
+// unchanged
+var DefaultColors = ColorConfig{
+	Primary: "#5B9BD5",
+	Flagged: "#ED7D31",
+	Due:     "#70AD47",
+	Overdue: "#FF6B6B",
+}
+
+// EnvVar documents an environment variable read by lazyfocus
+type EnvVar struct {
+	Name        string
+	Description string
+
+	key string // Config key the variable overrides, if any
+}
+
+// envBindings are the environment variables that override config keys
+var envBindings = []EnvVar{
+	{Name: "LAZYFOCUS_OUTPUT_FORMAT", Description: "Default output format (human, json, jsonl, csv, tsv or table)", key: "output.format"},
+	{Name: "LAZYFOCUS_TIMEOUT", Description: "OmniFocus script timeout, e.g. 45s", key: "timeout"},
+	{Name: "LAZYFOCUS_MAX_PAYLOAD_MB", Description: "Largest script output read before paginating", key: "max_payload_mb"},
+	{Name: "LAZYFOCUS_RETRY_ATTEMPTS", Description: "Tries per OmniFocus read when it fails transiently; 1 disables retries", key: "retry.attempts"},
+	{Name: "LAZYFOCUS_DEVICE_ID", Description: "Name of this machine in the debug log, pending writes and conflicts (default: host name)", key: "device_id"},
+	{Name: "LAZYFOCUS_BACKUP_DESTINATION", Description: "Folder `lazyfocus backup` copies each new backup to", key: "backup.destination"},
+	{Name: "LAZYFOCUS_DEFAULTS_PROJECT", Description: "Project for new tasks when none is given", key: "defaults.project"},
+	{Name: "LAZYFOCUS_TUI_THEME", Description: "TUI theme: default, solarized, dracula, high-contrast or one under tui.themes", key: "tui.theme"},
+	{Name: "LAZYFOCUS_TUI_BACKGROUND", Description: "Terminal background the TUI colors suit: auto, light or dark", key: "tui.background"},
+	{Name: "LAZYFOCUS_TUI_COLORS_PRIMARY", Description: "TUI accent color", key: "tui.colors.primary"},
+	{Name: "LAZYFOCUS_TUI_COLORS_FLAGGED", Description: "TUI color of flagged items", key: "tui.colors.flagged"},
+	{Name: "LAZYFOCUS_TUI_COLORS_DUE", Description: "TUI color of due items", key: "tui.colors.due"},
+	{Name: "LAZYFOCUS_TUI_COLORS_OVERDUE", Description: "TUI color of overdue items", key: "tui.colors.overdue"},
+	{Name: "LAZYFOCUS_TUI_WINDOW_TITLE", Description: "Set to false to leave the terminal title alone in the TUI", key: "tui.window_title"},
+	{Name: "LAZYFOCUS_TUI_SHOW_PATH", Description: "Set to true to show each task's folder and project in the TUI", key: "tui.show_path"},
+}
+
+// EnvVars returns the environment variables lazyfocus reads, for help output
+// and man pages
+func EnvVars() []EnvVar {
+	vars := append([]EnvVar{}, envBindings...)
+	return append(vars,
+		EnvVar{Name: "LAZYFOCUS_DEBUG", Description: "Set to 1 to log OmniFocus script calls, like --debug"},
+		EnvVar{Name: "LAZYFOCUS_FIXTURES", Description: "Folder of output recorded by lazyfocus dev record-fixtures to answer with instead of OmniFocus"},
+		EnvVar{Name: "NO_COLOR", Description: "Set to anything to disable colors, like --no-color"},
+		EnvVar{Name: "HOME", Description: "Location of .lazyfocus.yaml and .lazyfocus-filters.json"},
+		EnvVar{Name: "XDG_STATE_HOME", Description: "Location of the TUI session state, task numbers and debug log (default ~/.local/state)"},
+	)
+}
+
 // Load loads configuration from file and environment
 func Load() (*Config, error) {
 	v := viper.New()
@@ -78,14 +335,9 @@ func Load() (*Config, error) {
 
 	// Bind environment variables to config keys explicitly
 	// This is needed for nested keys to work properly
-	_ = v.BindEnv("output.format", "LAZYFOCUS_OUTPUT_FORMAT")
-	_ = v.BindEnv("timeout", "LAZYFOCUS_TIMEOUT")
-	_ = v.BindEnv("defaults.project", "LAZYFOCUS_DEFAULTS_PROJECT")
-	_ = v.BindEnv("tui.theme", "LAZYFOCUS_TUI_THEME")
-	_ = v.BindEnv("tui.colors.primary", "LAZYFOCUS_TUI_COLORS_PRIMARY")
-	_ = v.BindEnv("tui.colors.flagged", "LAZYFOCUS_TUI_COLORS_FLAGGED")
-	_ = v.BindEnv("tui.colors.due", "LAZYFOCUS_TUI_COLORS_DUE")
-	_ = v.BindEnv("tui.colors.overdue", "LAZYFOCUS_TUI_COLORS_OVERDUE")
+	for _, binding := range envBindings {
+		_ = v.BindEnv(binding.key, binding.Name)
+	}
 
 	// Read config file (ignore if not found)
 	if err := v.ReadInConfig(); err != nil {
@@ -102,6 +354,11 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
+// MaxPayloadBytes returns the configured maximum script output size in bytes
+func (c *Config) MaxPayloadBytes() int64 {
+	return int64(c.MaxPayloadMB) << 20
+}
+
 // FilePath returns the path to the config file
 func FilePath() string {
 	home, err := os.UserHomeDir()
@@ -111,15 +368,71 @@ func FilePath() string {
 	return filepath.Join(home, ".lazyfocus.yaml")
 }
 
+// SavedFiltersPath returns the path to the saved TUI filters file
+func SavedFiltersPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".lazyfocus-filters.json"
+	}
+	return filepath.Join(home, ".lazyfocus-filters.json")
+}
+
+// SessionStatePath returns the path to the file the TUI saves its session
+// state to, under $XDG_STATE_HOME (default ~/.local/state)
+func SessionStatePath() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "lazyfocus", "session.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".lazyfocus-session.json"
+	}
+	return filepath.Join(home, ".local", "state", "lazyfocus", "session.json")
+}
+
+// TaskIndexPath returns the path to the file mapping the short task numbers
+// of the last tasks listing to task IDs, under $XDG_STATE_HOME (default
+// ~/.local/state)
+func TaskIndexPath() string {
+	return filepath.Join(filepath.Dir(SessionStatePath()), "task-index.json")
+}
+
+// DebugLogPath returns the path to the debug log written with --debug, under
+// $XDG_STATE_HOME (default ~/.local/state)
+func DebugLogPath() string {
+	return filepath.Join(filepath.Dir(SessionStatePath()), "debug.log")
+}
+
 func setDefaults(v *viper.Viper) {
 	v.SetDefault("output.format", "human")
 	v.SetDefault("timeout", "30s")
+	v.SetDefault("max_payload_mb", 32)
+	v.SetDefault("retry.attempts", 3)
+	v.SetDefault("retry.initial_wait", "100ms")
+	v.SetDefault("retry.max_wait", "2s")
 	v.SetDefault("defaults.project", "")
+	v.SetDefault("device_id", "")
+	v.SetDefault("backup.dir", "")
+	v.SetDefault("backup.destination", "")
+	v.SetDefault("digest.smtp.port", 587)
+	v.SetDefault("digest.smtp.keychain", "lazyfocus-smtp")
 	v.SetDefault("tui.theme", "default")
-	v.SetDefault("tui.colors.primary", "#5B9BD5")
-	v.SetDefault("tui.colors.flagged", "#ED7D31")
-	v.SetDefault("tui.colors.due", "#70AD47")
-	v.SetDefault("tui.colors.overdue", "#FF6B6B")
+	v.SetDefault("tui.background", "auto")
+	v.SetDefault("tui.colors.primary", DefaultColors.Primary)
+	v.SetDefault("tui.colors.flagged", DefaultColors.Flagged)
+	v.SetDefault("tui.colors.due", DefaultColors.Due)
+	v.SetDefault("tui.colors.overdue", DefaultColors.Overdue)
+	v.SetDefault("tui.window_title", true)
+	v.SetDefault("tui.show_path", false)
+	v.SetDefault("tui.confirm.delete", "modal")
+	v.SetDefault("tui.confirm.complete", "modal")
+	v.SetDefault("tui.confirm.chord_timeout", time.Second)
+	v.SetDefault("next.weights.due", 3.0) // Same as next.DefaultWeights
+	v.SetDefault("next.weights.flagged", 2.0)
+	v.SetDefault("next.weights.estimate", 1.0)
+	v.SetDefault("next.weights.context", 1.5)
+	v.SetDefault("next.weights.stale", 1.0)
+	v.SetDefault("next.weights.effort", 1.0)
 }
 
 // FromContext extracts the Config from the context.
@@ -33,6 +33,15 @@ func TestLoad_NoConfigFile_ReturnsDefaults(t *testing.T) {
 		t.Errorf("Expected default timeout 30s, got %v", cfg.Timeout)
 	}
 
+	if cfg.MaxPayloadBytes() != 32<<20 {
+		t.Errorf("Expected default max payload 32MB, got %d bytes", cfg.MaxPayloadBytes())
+	}
+
+	wantRetry := RetryConfig{Attempts: 3, InitialWait: 100 * time.Millisecond, MaxWait: 2 * time.Second}
+	if cfg.Retry != wantRetry {
+		t.Errorf("Expected default retry %+v, got %+v", wantRetry, cfg.Retry)
+	}
+
 	if cfg.Defaults.Project != "" {
 		t.Errorf("Expected default project to be empty, got %q", cfg.Defaults.Project)
 	}
@@ -44,6 +53,28 @@ func TestLoad_NoConfigFile_ReturnsDefaults(t *testing.T) {
 	if cfg.TUI.Colors.Primary != "#5B9BD5" {
 		t.Errorf("Expected default primary color '#5B9BD5', got %q", cfg.TUI.Colors.Primary)
 	}
+
+	if cfg.TUI.Background != "auto" {
+		t.Errorf("Expected default background 'auto', got %q", cfg.TUI.Background)
+	}
+
+	if !cfg.TUI.WindowTitle {
+		t.Error("Expected window title enabled by default")
+	}
+
+	if cfg.TUI.ShowPath {
+		t.Error("Expected task paths hidden by default")
+	}
+
+	wantConfirm := ConfirmConfig{Delete: "modal", Complete: "modal", ChordTimeout: time.Second}
+	if cfg.TUI.Confirm != wantConfirm {
+		t.Errorf("Expected default confirm %+v, got %+v", wantConfirm, cfg.TUI.Confirm)
+	}
+
+	wantWeights := NextWeightsConfig{Due: 3, Flagged: 2, Estimate: 1, Context: 1.5, Stale: 1, Effort: 1}
+	if cfg.Next.Weights != wantWeights {
+		t.Errorf("Expected default next weights %+v, got %+v", wantWeights, cfg.Next.Weights)
+	}
 }
 
 func TestLoad_WithConfigFile_OverridesDefaults(t *testing.T) {
@@ -103,6 +134,214 @@ tui:
 	}
 }
 
+func TestLoad_Rules(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	oldEnvVars := clearLazyFocusEnvVars()
+	defer restoreEnvVars(oldEnvVars)
+
+	configContent := `rules:
+  - name: Phone calls
+    match:
+      name: "call|phone"
+    actions:
+      add_tags: [phone]
+  - name: Work defers
+    match:
+      project: Work
+    actions:
+      defer: next weekday
+      flag: true
+`
+	configPath := filepath.Join(tmpDir, ".lazyfocus.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if len(cfg.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(cfg.Rules))
+	}
+	if cfg.Rules[0].Match.Name != "call|phone" || len(cfg.Rules[0].Actions.AddTags) != 1 {
+		t.Errorf("Unexpected first rule: %+v", cfg.Rules[0])
+	}
+	if cfg.Rules[1].Match.Project != "Work" || cfg.Rules[1].Actions.Defer != "next weekday" || !cfg.Rules[1].Actions.Flag {
+		t.Errorf("Unexpected second rule: %+v", cfg.Rules[1])
+	}
+}
+
+func TestLoad_Schedule(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	oldEnvVars := clearLazyFocusEnvVars()
+	defer restoreEnvVars(oldEnvVars)
+
+	configContent := `schedule:
+  - name: Morning report
+    cron: "0 7 * * 1-5"
+    action: report
+    output: /tmp/report.txt
+  - cron: "@hourly"
+    action: rules
+`
+	configPath := filepath.Join(tmpDir, ".lazyfocus.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if len(cfg.Schedule) != 2 {
+		t.Fatalf("Expected 2 scheduled jobs, got %d", len(cfg.Schedule))
+	}
+	want := JobConfig{Name: "Morning report", Cron: "0 7 * * 1-5", Action: "report", Output: "/tmp/report.txt"}
+	if cfg.Schedule[0] != want {
+		t.Errorf("Schedule[0] = %+v, want %+v", cfg.Schedule[0], want)
+	}
+	if cfg.Schedule[1].Cron != "@hourly" || cfg.Schedule[1].Action != "rules" {
+		t.Errorf("Unexpected second job: %+v", cfg.Schedule[1])
+	}
+}
+
+func TestLoad_Templates(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	oldEnvVars := clearLazyFocusEnvVars()
+	defer restoreEnvVars(oldEnvVars)
+
+	configContent := `templates:
+  - name: onboarding
+    project: "Onboard {{.ClientName}}"
+    variables:
+      - name: ClientName
+        prompt: Client name
+      - name: DueOffsetDays
+        default: "14"
+    tasks:
+      - name: Kickoff call
+        tags: [phone]
+        due: "in {{.DueOffsetDays}} days"
+`
+	configPath := filepath.Join(tmpDir, ".lazyfocus.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if len(cfg.Templates) != 1 {
+		t.Fatalf("Expected 1 template, got %d", len(cfg.Templates))
+	}
+	tmpl := cfg.Templates[0]
+	if tmpl.Project != "Onboard {{.ClientName}}" || len(tmpl.Variables) != 2 || tmpl.Variables[1].Default != "14" {
+		t.Errorf("Unexpected template: %+v", tmpl)
+	}
+	if len(tmpl.Tasks) != 1 || tmpl.Tasks[0].Due != "in {{.DueOffsetDays}} days" || tmpl.Tasks[0].Tags[0] != "phone" {
+		t.Errorf("Unexpected template tasks: %+v", tmpl.Tasks)
+	}
+}
+
+func TestLoad_ForecastLabels(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	oldEnvVars := clearLazyFocusEnvVars()
+	defer restoreEnvVars(oldEnvVars)
+
+	configContent := `tui:
+  forecast:
+    labels:
+      overdue: Late
+      this-week: Next 7 days
+    order: [today, overdue]
+    task_count: '{{.Count}} {{plural .Count "task" "tasks"}}'
+`
+	configPath := filepath.Join(tmpDir, ".lazyfocus.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	forecast := cfg.TUI.Forecast
+	if forecast.Labels["overdue"] != "Late" || forecast.Labels["this-week"] != "Next 7 days" {
+		t.Errorf("Unexpected labels: %+v", forecast.Labels)
+	}
+	if len(forecast.Order) != 2 || forecast.Order[0] != "today" {
+		t.Errorf("Unexpected order: %v", forecast.Order)
+	}
+	if forecast.TaskCount != `{{.Count}} {{plural .Count "task" "tasks"}}` {
+		t.Errorf("Unexpected task count: %q", forecast.TaskCount)
+	}
+}
+
+func TestLoad_Themes(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", oldHome)
+
+	oldEnvVars := clearLazyFocusEnvVars()
+	defer restoreEnvVars(oldEnvVars)
+
+	configContent := `tui:
+  theme: Ocean
+  background: light
+  themes:
+    Ocean:
+      base: solarized
+      colors:
+        primary: "#0077BE"
+      dark:
+        secondary: "#4A6670"
+`
+	configPath := filepath.Join(tmpDir, ".lazyfocus.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.TUI.Background != "light" {
+		t.Errorf("Expected background 'light', got %q", cfg.TUI.Background)
+	}
+	// Viper lowercases map keys
+	theme, ok := cfg.TUI.Themes["ocean"]
+	if !ok {
+		t.Fatalf("Expected theme 'ocean', got %+v", cfg.TUI.Themes)
+	}
+	if theme.Base != "solarized" || theme.Colors.Primary != "#0077BE" || theme.Dark.Secondary != "#4A6670" {
+		t.Errorf("Unexpected theme: %+v", theme)
+	}
+}
+
 func TestLoad_EnvironmentVariables_OverrideConfigFile(t *testing.T) {
 	// Create temp directory and config file
 	tmpDir := t.TempDir()
@@ -0,0 +1,150 @@
+// Package digest builds the weekly report of OmniFocus activity as Markdown
+// and sends it by email.
+package digest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
+)
+
+// Days is the number of days a digest looks back and ahead
+const Days = 7
+
+// Report is the content of a weekly digest
+type Report struct {
+	Start     time.Time
+	End       time.Time
+	Completed []domain.Task // Completed during the week, oldest first
+	Overdue   []domain.Task // Due before End
+	DueSoon   []domain.Task // Due in the week after End
+	Flagged   []domain.Task // Flagged and not listed as overdue or due soon
+	Forecasts []stats.ProjectForecast
+}
+
+// NewReport builds the digest of the week ending at now from the tasks
+// completed since the week began, the remaining tasks and the active projects
+func NewReport(completed, remaining []domain.Task, projects []domain.Project, now time.Time) Report {
+	r := Report{
+		Start:     now.AddDate(0, 0, -Days),
+		End:       now,
+		Forecasts: stats.ForecastProjects(projects, now),
+	}
+
+	for _, task := range completed {
+		if task.CompletedDate == nil || !task.CompletedDate.Before(r.Start) {
+			r.Completed = append(r.Completed, task)
+		}
+	}
+	sort.SliceStable(r.Completed, func(i, j int) bool {
+		a, b := r.Completed[i].CompletedDate, r.Completed[j].CompletedDate
+		return a != nil && (b == nil || a.Before(*b))
+	})
+
+	soon := now.AddDate(0, 0, Days)
+	for _, task := range remaining {
+		switch {
+		case task.Completed:
+		case task.DueDate != nil && task.DueDate.Before(now):
+			r.Overdue = append(r.Overdue, task)
+		case task.DueDate != nil && task.DueDate.Before(soon):
+			r.DueSoon = append(r.DueSoon, task)
+		case task.Flagged:
+			r.Flagged = append(r.Flagged, task)
+		}
+	}
+	byDue := func(tasks []domain.Task) {
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return tasks[i].DueDate.Before(*tasks[j].DueDate)
+		})
+	}
+	byDue(r.Overdue)
+	byDue(r.DueSoon)
+
+	return r
+}
+
+// Subject returns the email subject of the digest
+func (r Report) Subject() string {
+	return fmt.Sprintf("LazyFocus weekly digest: %s", r.span())
+}
+
+// span returns the dates the digest covers, e.g. "Jan 2 – Jan 9, 2026"
+func (r Report) span() string {
+	return fmt.Sprintf("%s – %s", r.Start.Format("Jan 2"), r.End.Format("Jan 2, 2006"))
+}
+
+// Markdown renders the digest as a Markdown document
+func (r Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Weekly digest: %s\n", r.span())
+	fmt.Fprintf(&b, "\n%d completed, %d overdue, %d due in the next %d days.\n",
+		len(r.Completed), len(r.Overdue), len(r.DueSoon), Days)
+
+	writeTasks(&b, "Completed", r.Completed, func(t domain.Task) string {
+		if t.CompletedDate == nil {
+			return ""
+		}
+		return t.CompletedDate.Format("Mon Jan 2")
+	})
+	writeTasks(&b, "Overdue", r.Overdue, dueText)
+	writeTasks(&b, "Due soon", r.DueSoon, dueText)
+	writeTasks(&b, "Flagged", r.Flagged, dueText)
+
+	b.WriteString("\n## Projects\n\n")
+	if len(r.Forecasts) == 0 {
+		b.WriteString("_None_\n")
+		return b.String()
+	}
+	b.WriteString("| Project | Remaining | Done per week | Estimated finish |\n")
+	b.WriteString("| --- | ---: | ---: | --- |\n")
+	for _, f := range r.Forecasts {
+		estimate := "no recent progress"
+		if f.HasEstimate() {
+			estimate = f.Estimate.Format("Jan 2, 2006")
+		}
+		fmt.Fprintf(&b, "| %s | %d | %.1f | %s |\n", escape(f.ProjectName), f.Remaining, f.RatePerWeek, estimate)
+	}
+	return b.String()
+}
+
+// writeTasks writes a section listing tasks with their project and detail
+func writeTasks(b *strings.Builder, title string, tasks []domain.Task, detail func(domain.Task) string) {
+	fmt.Fprintf(b, "\n## %s (%d)\n\n", title, len(tasks))
+	if len(tasks) == 0 {
+		b.WriteString("_None_\n")
+		return
+	}
+	for _, task := range tasks {
+		line := "- " + escape(task.Name)
+		if task.ProjectName != "" {
+			line += " — " + escape(task.ProjectName)
+		}
+		if d := detail(task); d != "" {
+			line += " (" + d + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+}
+
+// dueText describes a task's due date, empty when it has none
+func dueText(t domain.Task) string {
+	if t.DueDate == nil {
+		return ""
+	}
+	return "due " + t.DueDate.Format("Mon Jan 2")
+}
+
+// markdownEscaper escapes characters that Markdown would read as formatting
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "|", `\|`, "#", `\#`, "<", `\<`,
+)
+
+// escape makes text safe to put in a Markdown list item or table cell
+func escape(text string) string {
+	return markdownEscaper.Replace(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,140 @@
+package digest
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestNewReport(t *testing.T) {
+	now := time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)
+	day := func(d int) *time.Time {
+		t := now.AddDate(0, 0, d)
+		return &t
+	}
+
+	completed := []domain.Task{
+		{ID: "c1", Name: "Ship release", CompletedDate: day(-1)},
+		{ID: "c2", Name: "Old", CompletedDate: day(-10)},
+		{ID: "c3", Name: "Plan sprint", CompletedDate: day(-3)},
+	}
+	remaining := []domain.Task{
+		{ID: "o2", Name: "Taxes", DueDate: day(-1)},
+		{ID: "o1", Name: "Invoice", DueDate: day(-4), Flagged: true},
+		{ID: "s1", Name: "Dentist", DueDate: day(2)},
+		{ID: "l1", Name: "Later", DueDate: day(20)},
+		{ID: "f1", Name: "Read book", Flagged: true},
+	}
+
+	r := NewReport(completed, remaining, []domain.Project{{ID: "p1", Name: "Website"}}, now)
+
+	if got := ids(r.Completed); got != "c3,c1" {
+		t.Errorf("Completed = %s, want c3,c1 (oldest first, last week only)", got)
+	}
+	if got := ids(r.Overdue); got != "o1,o2" {
+		t.Errorf("Overdue = %s, want o1,o2", got)
+	}
+	if got := ids(r.DueSoon); got != "s1" {
+		t.Errorf("DueSoon = %s, want s1", got)
+	}
+	if got := ids(r.Flagged); got != "f1" {
+		t.Errorf("Flagged = %s, want f1 (overdue flagged tasks are listed once)", got)
+	}
+	if len(r.Forecasts) != 1 {
+		t.Errorf("Forecasts = %+v, want one project", r.Forecasts)
+	}
+}
+
+func TestReport_Markdown(t *testing.T) {
+	now := time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)
+	due := now.AddDate(0, 0, -1)
+	r := Report{
+		Start:   now.AddDate(0, 0, -Days),
+		End:     now,
+		Overdue: []domain.Task{{Name: "Fix *all* [bugs]", ProjectName: "Work | Q1", DueDate: &due}},
+	}
+
+	md := r.Markdown()
+	for _, want := range []string{
+		"# Weekly digest: Mar 6 – Mar 13, 2026",
+		"0 completed, 1 overdue, 0 due in the next 7 days.",
+		"## Completed (0)\n\n_None_",
+		`- Fix \*all\* \[bugs\] — Work \| Q1 (due Thu Mar 12)`,
+		"## Projects\n\n_None_",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown() missing %q in:\n%s", want, md)
+		}
+	}
+	if got := r.Subject(); got != "LazyFocus weekly digest: Mar 6 – Mar 13, 2026" {
+		t.Errorf("Subject() = %q", got)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	date := time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)
+	msg, err := Message("me@example.com", "you@example.com", "Digest – week 11", "# Done\nCafé", date)
+	if err != nil {
+		t.Fatalf("Message() error = %v", err)
+	}
+
+	text := string(msg)
+	for _, want := range []string{
+		"From: me@example.com\r\n",
+		"To: you@example.com\r\n",
+		"Subject: =?utf-8?q?Digest_=E2=80=93_week_11?=\r\n",
+		"Date: Fri, 13 Mar 2026 09:00:00 +0000\r\n",
+		"Content-Type: text/plain; charset=utf-8",
+		"\r\n\r\n# Done\r\nCaf=C3=A9",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Message() missing %q in:\n%s", want, text)
+		}
+	}
+
+	if _, err := Message("me@example.com", "you@example.com\r\nBcc: x@example.com", "s", "b", date); err == nil {
+		t.Error("Message() should reject addresses with line breaks")
+	}
+}
+
+func TestSend_StopsWhenCanceledWhileServerIsSilent(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+	// The server accepts the connection but never greets
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			<-time.After(5 * time.Second)
+		}
+	}()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = Send(ctx, SMTP{Host: "127.0.0.1", Port: port}, "me@example.com", []string{"you@example.com"}, []byte("hi"))
+	if err == nil || !strings.Contains(err.Error(), "127.0.0.1:"+strconv.Itoa(port)) {
+		t.Errorf("Send() error = %v, want a connection error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Send() took %v after ctx ended", elapsed)
+	}
+}
+
+func ids(tasks []domain.Task) string {
+	var out []string
+	for _, task := range tasks {
+		out = append(out, task.ID)
+	}
+	return strings.Join(out, ",")
+}
@@ -0,0 +1,139 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTP holds how to reach and log in to a mail server
+type SMTP struct {
+	Host     string
+	Port     int // 465 uses TLS from the start; other ports upgrade with STARTTLS
+	Username string
+	Password string
+}
+
+// Message returns an email with a UTF-8 plain text body, ready to send
+func Message(from, to, subject, body string, date time.Time) ([]byte, error) {
+	for _, header := range []string{from, to} {
+		if strings.ContainsAny(header, "\r\n") {
+			return nil, errors.New("email addresses must not contain line breaks")
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8; format=flowed\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// sendTimeout bounds connecting to the mail server and the whole exchange
+// with it, so an unresponsive server cannot hold up later jobs
+const sendTimeout = 30 * time.Second
+
+// Send delivers msg from from to the recipients through the server. The
+// password is only sent over TLS. Sending stops when ctx is canceled or
+// takes longer than sendTimeout.
+func Send(ctx context.Context, server SMTP, from string, to []string, msg []byte) error {
+	addr := net.JoinHostPort(server.Host, strconv.Itoa(server.Port))
+	var auth smtp.Auth
+	if server.Username != "" {
+		auth = smtp.PlainAuth("", server.Username, server.Password, server.Host)
+	}
+
+	dialer := net.Dialer{Timeout: sendTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(sendTimeout)); err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	tlsConfig := &tls.Config{ServerName: server.Host}
+	if server.Port == 465 {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	client, err := smtp.NewClient(conn, server.Host)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	if server.Port != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("failed to connect to %s: %w", addr, err)
+			}
+		}
+	}
+	if err := sendWith(client, auth, from, to, msg); err != nil {
+		return fmt.Errorf("failed to send email through %s: %w", addr, err)
+	}
+	if err := client.Quit(); err != nil {
+		return fmt.Errorf("failed to send email through %s: %w", addr, err)
+	}
+	return nil
+}
+
+// sendWith sends msg on an open connection
+func sendWith(client *smtp.Client, auth smtp.Auth, from string, to []string, msg []byte) error {
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// KeychainPassword reads the password of the generic password item service
+// for account from the macOS login keychain
+func KeychainPassword(service, account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the password of %s for %s from the keychain: %w", service, account, err)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
@@ -0,0 +1,224 @@
+// Package docgen generates man pages from the cobra command tree, so every
+// command is documented from the same metadata that drives its --help.
+package docgen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Entry is a name with a description, such as an exit code or environment variable
+type Entry struct {
+	Name        string
+	Description string
+}
+
+// Metadata holds what every page documents in addition to its command
+type Metadata struct {
+	Version     string    // Shown in the page footer
+	Date        time.Time // Shown in the page footer
+	ExitCodes   []Entry
+	Environment []Entry
+}
+
+// PageName returns the man page name of a command, e.g. "lazyfocus-tags-add"
+func PageName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+// Documented reports whether a command gets a man page and help sections
+func Documented(cmd *cobra.Command) bool {
+	return cmd.IsAvailableCommand() || !cmd.HasParent()
+}
+
+// GenerateMan writes a section 1 man page for root and each documented
+// command below it to dir, returning the file names written
+func GenerateMan(root *cobra.Command, dir string, meta Metadata) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	var written []string
+	var walk func(cmd *cobra.Command) error
+	walk = func(cmd *cobra.Command) error {
+		if !Documented(cmd) {
+			return nil
+		}
+		var buf bytes.Buffer
+		if err := WriteMan(&buf, cmd, meta); err != nil {
+			return err
+		}
+		name := PageName(cmd) + ".1"
+		if err := os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		written = append(written, name)
+		for _, child := range cmd.Commands() {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return nil, err
+	}
+	return written, nil
+}
+
+// WriteMan writes the man page of a single command in roff format
+func WriteMan(w io.Writer, cmd *cobra.Command, meta Metadata) error {
+	var b strings.Builder
+	name := PageName(cmd)
+
+	fmt.Fprintf(&b, ".TH %q \"1\" %q %q \"LazyFocus Manual\"\n",
+		strings.ToUpper(name), meta.Date.Format("January 2006"), strings.TrimSpace(cmd.Root().Name()+" "+meta.Version))
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", name, escape(cmd.Short))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, ".B %s\n", escape(cmd.CommandPath()))
+	if synopsis := strings.TrimSpace(strings.TrimPrefix(cmd.UseLine(), cmd.CommandPath())); synopsis != "" {
+		b.WriteString(escape(synopsis) + "\n")
+	}
+
+	b.WriteString(".SH DESCRIPTION\n")
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	writeParagraphs(&b, description)
+
+	writeFlags(&b, "OPTIONS", cmd.NonInheritedFlags())
+	writeFlags(&b, "GLOBAL OPTIONS", cmd.InheritedFlags())
+
+	if cmd.Example != "" {
+		b.WriteString(".SH EXAMPLES\n.PP\n.RS\n.nf\n")
+		for _, line := range strings.Split(cmd.Example, "\n") {
+			b.WriteString(escapeLine(strings.TrimPrefix(line, "  ")) + "\n")
+		}
+		b.WriteString(".fi\n.RE\n")
+	}
+
+	writeEntries(&b, "EXIT STATUS", meta.ExitCodes)
+	writeEntries(&b, "ENVIRONMENT", meta.Environment)
+
+	if seeAlso := related(cmd); len(seeAlso) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		b.WriteString(strings.Join(seeAlso, ",\n") + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeParagraphs writes text, keeping blank lines as paragraph breaks and
+// indented lines as preformatted blocks
+func writeParagraphs(b *strings.Builder, text string) {
+	preformatted := false
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		indented := strings.HasPrefix(line, "  ")
+		switch {
+		case indented && !preformatted:
+			if !strings.HasSuffix(b.String(), ".PP\n") {
+				b.WriteString(".PP\n")
+			}
+			b.WriteString(".RS\n.nf\n")
+			preformatted = true
+		case !indented && preformatted:
+			b.WriteString(".fi\n.RE\n")
+			preformatted = false
+		}
+		if strings.TrimSpace(line) == "" {
+			if !preformatted {
+				b.WriteString(".PP\n")
+			}
+			continue
+		}
+		if preformatted {
+			line = strings.TrimPrefix(line, "  ")
+		}
+		b.WriteString(escapeLine(line) + "\n")
+	}
+	if preformatted {
+		b.WriteString(".fi\n.RE\n")
+	}
+}
+
+// writeFlags writes a section listing flags, if there are any
+func writeFlags(b *strings.Builder, title string, flags *pflag.FlagSet) {
+	var lines []string
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		varname, usage := pflag.UnquoteUsage(flag)
+		term := `\fB\-\-` + escape(flag.Name) + `\fR`
+		if flag.Shorthand != "" {
+			term = `\fB\-` + escape(flag.Shorthand) + `\fR, ` + term
+		}
+		if varname != "" {
+			term += ` \fI` + escape(varname) + `\fR`
+		}
+		if flag.DefValue != "" && flag.DefValue != "false" && flag.DefValue != "[]" && flag.DefValue != "0" && flag.DefValue != "0s" {
+			usage += fmt.Sprintf(" (default %s)", flag.DefValue)
+		}
+		lines = append(lines, ".TP\n"+term+"\n"+escapeLine(usage)+"\n")
+	})
+	if len(lines) == 0 {
+		return
+	}
+	b.WriteString(".SH " + title + "\n")
+	b.WriteString(strings.Join(lines, ""))
+}
+
+// writeEntries writes a section of tagged paragraphs, if there are any
+func writeEntries(b *strings.Builder, title string, entries []Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	b.WriteString(".SH " + title + "\n")
+	for _, entry := range entries {
+		fmt.Fprintf(b, ".TP\n\\fB%s\\fR\n%s\n", escape(entry.Name), escapeLine(entry.Description))
+	}
+}
+
+// related returns man page references to the parent and children of a command
+func related(cmd *cobra.Command) []string {
+	var pages []string
+	if cmd.HasParent() {
+		pages = append(pages, `\fB`+escape(PageName(cmd.Parent()))+`\fR(1)`)
+	}
+	var children []string
+	for _, child := range cmd.Commands() {
+		if Documented(child) {
+			children = append(children, `\fB`+escape(PageName(child))+`\fR(1)`)
+		}
+	}
+	sort.Strings(children)
+	return append(pages, children...)
+}
+
+// escape escapes roff special characters in running text
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+}
+
+// escapeLine escapes a line of text so roff does not read it as a request
+func escapeLine(s string) string {
+	s = escape(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
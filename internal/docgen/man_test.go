@@ -0,0 +1,89 @@
+package docgen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func testTree() *cobra.Command {
+	root := &cobra.Command{Use: "lazyfocus", Short: "CLI interface for OmniFocus", Long: "LazyFocus is a CLI."}
+	root.PersistentFlags().Bool("json", false, "Output in JSON format")
+
+	tags := &cobra.Command{Use: "tags", Short: "List tags", Run: func(*cobra.Command, []string) {}}
+	add := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Create a tag",
+		Long:  "Create a tag.\n\n.dotted lines and back\\slashes are escaped:\n  lazyfocus tags add -x",
+		Example: `  lazyfocus tags add Errands
+  lazyfocus tags add Calls --parent abc123`,
+		Run: func(*cobra.Command, []string) {},
+	}
+	add.Flags().StringP("parent", "p", "", "ID of the `parent` tag")
+	hidden := &cobra.Command{Use: "flush", Short: "Hidden", Hidden: true, Run: func(*cobra.Command, []string) {}}
+
+	tags.AddCommand(add)
+	root.AddCommand(tags, hidden)
+	return root
+}
+
+var testMeta = Metadata{
+	Version:     "1.2.0",
+	Date:        time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+	ExitCodes:   []Entry{{Name: "0", Description: "Success"}},
+	Environment: []Entry{{Name: "LAZYFOCUS_TIMEOUT", Description: "Script timeout"}},
+}
+
+func TestWriteMan(t *testing.T) {
+	add, _, _ := testTree().Find([]string{"tags", "add"})
+	var buf bytes.Buffer
+
+	if err := WriteMan(&buf, add, testMeta); err != nil {
+		t.Fatalf("WriteMan() error = %v", err)
+	}
+
+	page := buf.String()
+	for _, want := range []string{
+		`.TH "LAZYFOCUS-TAGS-ADD" "1" "October 2026" "lazyfocus 1.2.0" "LazyFocus Manual"`,
+		"lazyfocus-tags-add \\- Create a tag",
+		".B lazyfocus tags add\n<name> [flags]",
+		"\\&.dotted lines and back\\eslashes are escaped:\n.PP\n.RS\n.nf\n",
+		".nf\nlazyfocus tags add \\-x\n.fi",
+		".SH OPTIONS\n.TP\n\\fB\\-p\\fR, \\fB\\-\\-parent\\fR \\fIparent\\fR\nID of the parent tag",
+		".SH GLOBAL OPTIONS\n.TP\n\\fB\\-\\-json\\fR",
+		".SH EXAMPLES\n.PP\n.RS\n.nf\nlazyfocus tags add Errands\n",
+		".SH EXIT STATUS\n.TP\n\\fB0\\fR\nSuccess",
+		".SH ENVIRONMENT\n.TP\n\\fBLAZYFOCUS_TIMEOUT\\fR",
+		".SH SEE ALSO\n\\fBlazyfocus\\-tags\\fR(1)",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("WriteMan() missing %q in:\n%s", want, page)
+		}
+	}
+}
+
+func TestGenerateMan(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "man")
+
+	written, err := GenerateMan(testTree(), dir, testMeta)
+	if err != nil {
+		t.Fatalf("GenerateMan() error = %v", err)
+	}
+
+	want := []string{"lazyfocus-tags-add.1", "lazyfocus-tags.1", "lazyfocus.1"}
+	sort.Strings(written)
+	if strings.Join(written, ",") != strings.Join(want, ",") {
+		t.Errorf("GenerateMan() = %v, want %v", written, want)
+	}
+	for _, name := range want {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("GenerateMan() did not write %s: %v", name, err)
+		}
+	}
+}
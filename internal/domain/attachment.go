@@ -0,0 +1,19 @@
+package domain
+
+// Reasons an attachment's contents were left out
+const (
+	AttachmentTooLarge   = "too-large"   // Bigger than the per-file size limit
+	AttachmentOverBudget = "over-budget" // Past the size limit of one fetch; fetch again from its index
+	AttachmentNotFile    = "not-file"    // A folder or package rather than a file
+	AttachmentBefore     = "before"      // Before the requested offset
+)
+
+// Attachment is a file attached to a task
+type Attachment struct {
+	TaskID   string `json:"taskId"`
+	TaskName string `json:"taskName"`
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Data     []byte `json:"data,omitempty"`    // File contents, base64 in JSON; empty when Skipped
+	Skipped  string `json:"skipped,omitempty"` // Why Data was left out, one of the Attachment* reasons
+}
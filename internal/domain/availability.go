@@ -0,0 +1,85 @@
+package domain
+
+import "time"
+
+// Availability describes whether a task can be worked on, following the
+// OmniFocus "Available" perspective
+type Availability int
+
+// Availability values, from actionable to finished
+const (
+	Available     Availability = iota // Can be worked on now
+	Blocked                           // Waiting on subtasks or an earlier task in a sequential project
+	DeferredUntil                     // Defer date, its own or inherited, is in the future
+	Done                              // Completed
+	Dropped                           // Dropped in OmniFocus
+)
+
+// String returns the lowercase name of the availability
+func (a Availability) String() string {
+	switch a {
+	case Available:
+		return "available"
+	case Blocked:
+		return "blocked"
+	case DeferredUntil:
+		return "deferred"
+	case Done:
+		return "completed"
+	case Dropped:
+		return "dropped"
+	default:
+		return "unknown"
+	}
+}
+
+// AvailabilityAt returns the availability of the task at the given time. A
+// task with incomplete subtasks is blocked: its subtasks are the next actions.
+func (t Task) AvailabilityAt(now time.Time) Availability {
+	if t.Completed {
+		return Done
+	}
+	if t.Dropped {
+		return Dropped
+	}
+	if deferDate := t.EffectiveDefer(); deferDate != nil && deferDate.After(now) {
+		return DeferredUntil
+	}
+	if t.Blocked || t.hasIncompleteChildren() {
+		return Blocked
+	}
+	return Available
+}
+
+// hasIncompleteChildren reports whether any direct subtask is still open
+func (t Task) hasIncompleteChildren() bool {
+	for _, child := range t.Children {
+		if !child.Completed {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAvailableAt reports whether the task can be worked on at the given time
+func (t Task) IsAvailableAt(now time.Time) bool {
+	return t.AvailabilityAt(now) == Available
+}
+
+// NextAction returns the first task available at the given time in outline
+// order, looking into subtasks: the next action of a sequential project. It
+// returns nil when no task is available.
+func NextAction(tasks []Task, now time.Time) *Task {
+	for i := range tasks {
+		if tasks[i].Completed {
+			continue
+		}
+		if tasks[i].IsAvailableAt(now) {
+			return &tasks[i]
+		}
+		if next := NextAction(tasks[i].Children, now); next != nil {
+			return next
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTask_AvailabilityAt(t *testing.T) {
+	now := time.Date(2024, 1, 19, 10, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		task Task
+		want Availability
+	}{
+		{"no dates", Task{Name: "Task"}, Available},
+		{"deferred in the past", Task{DeferDate: &past}, Available},
+		{"deferred until later", Task{DeferDate: &future}, DeferredUntil},
+		{"blocked by sequence", Task{Blocked: true}, Blocked},
+		{"incomplete subtask", Task{Children: []Task{{Name: "Step"}}}, Blocked},
+		{"completed subtasks", Task{Children: []Task{{Name: "Step", Completed: true}}}, Available},
+		{"completed", Task{Completed: true, DeferDate: &future}, Done},
+		{"dropped", Task{Dropped: true}, Dropped},
+		{"deferral wins over blocked", Task{Blocked: true, DeferDate: &future}, DeferredUntil},
+		{"project deferred until later", Task{EffectiveDeferDate: &future}, DeferredUntil},
+		{"own deferral ended, project's has not", Task{DeferDate: &past, EffectiveDeferDate: &future}, DeferredUntil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.AvailabilityAt(now); got != tt.want {
+				t.Errorf("AvailabilityAt() = %v, want %v", got, tt.want)
+			}
+			if got := tt.task.IsAvailableAt(now); got != (tt.want == Available) {
+				t.Errorf("IsAvailableAt() = %v, want %v", got, tt.want == Available)
+			}
+		})
+	}
+}
+
+func TestAvailability_String(t *testing.T) {
+	tests := map[Availability]string{
+		Available:        "available",
+		Blocked:          "blocked",
+		DeferredUntil:    "deferred",
+		Done:             "completed",
+		Dropped:          "dropped",
+		Availability(42): "unknown",
+	}
+
+	for availability, want := range tests {
+		if got := availability.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestNextAction(t *testing.T) {
+	now := time.Date(2024, 1, 19, 10, 0, 0, 0, time.UTC)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		tasks []Task
+		want  string
+	}{
+		{"first remaining task", []Task{{ID: "a", Completed: true}, {ID: "b"}, {ID: "c", Blocked: true}}, "b"},
+		{"into a group", []Task{{ID: "g", Children: []Task{{ID: "g1", Completed: true}, {ID: "g2"}}}, {ID: "c", Blocked: true}}, "g2"},
+		{"deferred first task", []Task{{ID: "a", DeferDate: &future}, {ID: "b", Blocked: true}}, ""},
+		{"empty", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextAction(tt.tasks, now)
+			switch {
+			case tt.want == "" && got != nil:
+				t.Errorf("NextAction() = %q, want none", got.ID)
+			case tt.want != "" && (got == nil || got.ID != tt.want):
+				t.Errorf("NextAction() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+import "errors"
+
+// BatchAction identifies the operation applied to every task in a batch
+type BatchAction string
+
+// Supported batch actions
+const (
+	BatchComplete BatchAction = "complete"
+	BatchDelete   BatchAction = "delete"
+	BatchDrop     BatchAction = "drop"
+	BatchModify   BatchAction = "modify"
+)
+
+// BatchOperation describes a single operation applied to several tasks at once
+type BatchOperation struct {
+	Action       BatchAction      // What to do with each task
+	Modification TaskModification // Changes to apply (only used by BatchModify)
+}
+
+// Validate checks that the operation is well-formed
+func (op BatchOperation) Validate() error {
+	switch op.Action {
+	case BatchComplete, BatchDelete, BatchDrop:
+		return nil
+	case BatchModify:
+		if op.Modification.IsEmpty() {
+			return errors.New("batch modify requires at least one modification")
+		}
+		return nil
+	default:
+		return errors.New("unknown batch action: " + string(op.Action))
+	}
+}
+
+// BatchResult collects the per-task outcomes of a batch operation
+type BatchResult struct {
+	Results []OperationResult // One entry per task, in request order
+}
+
+// Succeeded returns the number of tasks the operation succeeded for
+func (r BatchResult) Succeeded() int {
+	count := 0
+	for _, res := range r.Results {
+		if res.Success {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed returns the number of tasks the operation failed for
+func (r BatchResult) Failed() int {
+	return len(r.Results) - r.Succeeded()
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "testing"
+
+func TestBatchOperation_Validate(t *testing.T) {
+	flagged := true
+
+	tests := []struct {
+		name    string
+		op      BatchOperation
+		wantErr bool
+	}{
+		{name: "complete is valid", op: BatchOperation{Action: BatchComplete}},
+		{name: "delete is valid", op: BatchOperation{Action: BatchDelete}},
+		{name: "drop is valid", op: BatchOperation{Action: BatchDrop}},
+		{
+			name: "modify with changes is valid",
+			op:   BatchOperation{Action: BatchModify, Modification: TaskModification{Flagged: &flagged}},
+		},
+		{name: "modify without changes is invalid", op: BatchOperation{Action: BatchModify}, wantErr: true},
+		{name: "unknown action is invalid", op: BatchOperation{Action: "archive"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.op.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBatchResult_Counts(t *testing.T) {
+	result := BatchResult{
+		Results: []OperationResult{
+			{Success: true, ID: "task1"},
+			{Success: false, ID: "task2", Message: "not found"},
+			{Success: true, ID: "task3"},
+		},
+	}
+
+	if got := result.Succeeded(); got != 2 {
+		t.Errorf("Succeeded() = %v, want %v", got, 2)
+	}
+	if got := result.Failed(); got != 1 {
+		t.Errorf("Failed() = %v, want %v", got, 1)
+	}
+}
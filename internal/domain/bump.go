@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bump is a relative shift of a task's date, such as "+1d" or "-2w"
+type Bump struct {
+	Amount int    // Negative moves the date earlier
+	Unit   string // "h", "d", "w", "mo" or "y"
+}
+
+// ErrNothingToBump is returned when a task has neither a due nor a defer date
+var ErrNothingToBump = errors.New("task has no due or defer date to bump")
+
+var bumpPattern = regexp.MustCompile(`^([+-]?\d+)(h|d|w|mo|y)$`)
+
+// ParseBump parses a shift such as "1d", "+2w", "-3d", "1mo" or "12h"
+func ParseBump(s string) (Bump, error) {
+	text := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	match := bumpPattern.FindStringSubmatch(text)
+	if match == nil {
+		return Bump{}, fmt.Errorf("invalid bump %q: use a number and a unit, e.g. 1d, -2w, 1mo, 12h or 1y", s)
+	}
+	amount, err := strconv.Atoi(match[1])
+	if err != nil || amount == 0 {
+		return Bump{}, fmt.Errorf("invalid bump %q: the amount must not be zero", s)
+	}
+	return Bump{Amount: amount, Unit: match[2]}, nil
+}
+
+// Apply returns t shifted by the bump. Days and longer shift the local date,
+// so they keep the local time of day across daylight saving changes.
+func (b Bump) Apply(t time.Time) time.Time {
+	t = t.In(time.Local)
+	switch b.Unit {
+	case "h":
+		return t.Add(time.Duration(b.Amount) * time.Hour)
+	case "w":
+		return t.AddDate(0, 0, 7*b.Amount)
+	case "mo":
+		return t.AddDate(0, b.Amount, 0)
+	case "y":
+		return t.AddDate(b.Amount, 0, 0)
+	default:
+		return t.AddDate(0, 0, b.Amount)
+	}
+}
+
+// String formats the bump with its sign, e.g. "+1d"
+func (b Bump) String() string {
+	return fmt.Sprintf("%+d%s", b.Amount, b.Unit)
+}
+
+// Modification returns the change shifting the task's due date by the bump,
+// or its defer date when it has no due date. A bump of days or longer into
+// the future is moved on by rollForward, when given, to land on a workday.
+func (b Bump) Modification(task Task, rollForward func(time.Time) time.Time) (TaskModification, error) {
+	switch {
+	case task.DueDate != nil:
+		due := b.applyOnWorkday(*task.DueDate, rollForward)
+		return TaskModification{DueDate: &due}, nil
+	case task.DeferDate != nil:
+		deferDate := b.applyOnWorkday(*task.DeferDate, rollForward)
+		return TaskModification{DeferDate: &deferDate}, nil
+	}
+	return TaskModification{}, ErrNothingToBump
+}
+
+// applyOnWorkday applies the bump, then rolls a forward shift of whole days
+// or more on to a workday
+func (b Bump) applyOnWorkday(t time.Time, rollForward func(time.Time) time.Time) time.Time {
+	t = b.Apply(t)
+	if rollForward != nil && b.Amount > 0 && b.Unit != "h" {
+		t = rollForward(t)
+	}
+	return t
+}
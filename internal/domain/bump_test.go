@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseBump(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Bump
+	}{
+		{"1d", Bump{Amount: 1, Unit: "d"}},
+		{"+2w", Bump{Amount: 2, Unit: "w"}},
+		{"-3d", Bump{Amount: -3, Unit: "d"}},
+		{"1MO", Bump{Amount: 1, Unit: "mo"}},
+		{"12h", Bump{Amount: 12, Unit: "h"}},
+		{" 1 y ", Bump{Amount: 1, Unit: "y"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseBump(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseBump(%q) = %+v, %v, want %+v", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseBump_Invalid(t *testing.T) {
+	for _, input := range []string{"", "0d", "1", "d", "1m", "1.5d", "tomorrow"} {
+		if _, err := ParseBump(input); err == nil {
+			t.Errorf("ParseBump(%q) error = nil, want an error", input)
+		}
+	}
+}
+
+func TestBump_Apply(t *testing.T) {
+	base := time.Date(2026, 1, 31, 17, 0, 0, 0, time.UTC)
+	tests := []struct {
+		bump Bump
+		want time.Time
+	}{
+		{Bump{Amount: 1, Unit: "d"}, time.Date(2026, 2, 1, 17, 0, 0, 0, time.UTC)},
+		{Bump{Amount: -1, Unit: "w"}, time.Date(2026, 1, 24, 17, 0, 0, 0, time.UTC)},
+		{Bump{Amount: 3, Unit: "h"}, time.Date(2026, 1, 31, 20, 0, 0, 0, time.UTC)},
+		{Bump{Amount: 1, Unit: "y"}, time.Date(2027, 1, 31, 17, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := tt.bump.Apply(base); !got.Equal(tt.want) {
+			t.Errorf("%s.Apply() = %v, want %v", tt.bump, got, tt.want)
+		}
+	}
+}
+
+func TestBump_ApplyKeepsLocalTimeAcrossDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	local := time.Local
+	time.Local = newYork
+	t.Cleanup(func() { time.Local = local })
+
+	// 17:00 EST the day before clocks go forward, as decoded from JSON in UTC
+	base := time.Date(2026, 3, 7, 22, 0, 0, 0, time.UTC)
+	for _, bump := range []Bump{{Amount: 1, Unit: "d"}, {Amount: 1, Unit: "w"}, {Amount: 1, Unit: "mo"}} {
+		got := bump.Apply(base)
+		if got.Hour() != 17 || got.Location() != newYork {
+			t.Errorf("%s.Apply() = %v, want 17:00 local time", bump, got)
+		}
+	}
+}
+
+func TestBump_ModificationRollsForwardToWorkday(t *testing.T) {
+	friday := time.Date(2026, 3, 6, 17, 0, 0, 0, time.Local)
+	skipWeekend := func(t time.Time) time.Time {
+		for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t
+	}
+
+	mod, err := Bump{Amount: 1, Unit: "d"}.Modification(Task{DueDate: &friday}, skipWeekend)
+	if err != nil || mod.DueDate == nil || !mod.DueDate.Equal(friday.AddDate(0, 0, 3)) {
+		t.Errorf("Modification() = %+v, %v, want the due date rolled on to Monday", mod, err)
+	}
+
+	monday := friday.AddDate(0, 0, 3)
+	mod, err = Bump{Amount: -1, Unit: "d"}.Modification(Task{DueDate: &monday}, skipWeekend)
+	if err != nil || mod.DueDate == nil || !mod.DueDate.Equal(friday.AddDate(0, 0, 2)) {
+		t.Errorf("Modification() = %+v, %v, want an earlier date left where it lands", mod, err)
+	}
+}
+
+func TestBump_Modification(t *testing.T) {
+	due := time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC)
+	deferDate := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	bump := Bump{Amount: 2, Unit: "d"}
+
+	mod, err := bump.Modification(Task{DueDate: &due, DeferDate: &deferDate}, nil)
+	if err != nil || mod.DueDate == nil || !mod.DueDate.Equal(due.AddDate(0, 0, 2)) || mod.DeferDate != nil {
+		t.Errorf("Modification() with a due date = %+v, %v, want the due date moved", mod, err)
+	}
+
+	mod, err = bump.Modification(Task{DeferDate: &deferDate}, nil)
+	if err != nil || mod.DeferDate == nil || !mod.DeferDate.Equal(deferDate.AddDate(0, 0, 2)) {
+		t.Errorf("Modification() with a defer date = %+v, %v, want the defer date moved", mod, err)
+	}
+
+	if _, err := bump.Modification(Task{}, nil); !errors.Is(err, ErrNothingToBump) {
+		t.Errorf("Modification() without dates error = %v, want ErrNothingToBump", err)
+	}
+}
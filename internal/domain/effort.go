@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Effort is how much energy a task takes, kept on an "effort: …" line of its
+// note so OmniFocus and its sync carry it unchanged
+type Effort string
+
+const (
+	EffortLow    Effort = "low"
+	EffortMedium Effort = "medium"
+	EffortHigh   Effort = "high"
+)
+
+// EffortPrefix starts the note line holding a task's effort
+const EffortPrefix = "effort: "
+
+// ParseEffort parses an effort such as "low", "med" or "h". "none" returns
+// "", for a task without an effort.
+func ParseEffort(s string) (Effort, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low", "l", "easy":
+		return EffortLow, nil
+	case "medium", "med", "m":
+		return EffortMedium, nil
+	case "high", "h", "hard":
+		return EffortHigh, nil
+	case "none", "":
+		return "", nil
+	}
+	return "", fmt.Errorf("invalid effort %q: use low, medium, high or none", s)
+}
+
+// Effort returns the effort recorded in the task's note, or "" when it has none
+func (t Task) Effort() Effort {
+	return NoteEffort(t.Note)
+}
+
+// NoteEffort returns the effort on the "effort: …" line of note, or "" when
+// there is no such line or it does not hold an effort
+func NoteEffort(note string) Effort {
+	for _, line := range strings.Split(note, "\n") {
+		if value, ok := cutEffortLine(line); ok {
+			effort, err := ParseEffort(value)
+			if err == nil {
+				return effort
+			}
+		}
+	}
+	return ""
+}
+
+// SetNoteEffort returns note with its "effort: …" line replaced by one for
+// effort, added after a blank line when there was none. An empty effort
+// removes the line.
+func SetNoteEffort(note string, effort Effort) string {
+	note = StripNoteEffort(note)
+	if effort == "" {
+		return note
+	}
+	line := EffortPrefix + string(effort)
+	if note == "" {
+		return line
+	}
+	return note + "\n\n" + line
+}
+
+// StripNoteEffort returns note without its "effort: …" line, as shown where
+// the effort is edited on its own
+func StripNoteEffort(note string) string {
+	lines := strings.Split(note, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if _, ok := cutEffortLine(line); !ok {
+			kept = append(kept, line)
+		}
+	}
+	return strings.TrimRight(strings.Join(kept, "\n"), " \n")
+}
+
+// cutEffortLine returns the value of an "effort: …" line, ignoring case
+func cutEffortLine(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if len(line) < len(EffortPrefix) || !strings.EqualFold(line[:len(EffortPrefix)], EffortPrefix) {
+		return "", false
+	}
+	return line[len(EffortPrefix):], true
+}
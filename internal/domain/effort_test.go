@@ -0,0 +1,77 @@
+package domain
+
+import "testing"
+
+func TestParseEffort(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Effort
+	}{
+		{"low", EffortLow},
+		{"L", EffortLow},
+		{"easy", EffortLow},
+		{"med", EffortMedium},
+		{"Medium", EffortMedium},
+		{"h", EffortHigh},
+		{"hard", EffortHigh},
+		{"none", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEffort(tt.input)
+			if err != nil {
+				t.Fatalf("ParseEffort(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEffort(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseEffort("tiny"); err == nil {
+		t.Error("ParseEffort(\"tiny\") error = nil, want an error")
+	}
+}
+
+func TestNoteEffort(t *testing.T) {
+	tests := []struct {
+		name string
+		note string
+		want Effort
+	}{
+		{"no note", "", ""},
+		{"no effort line", "Buy the blue one", ""},
+		{"effort line", "Buy the blue one\n\neffort: low", EffortLow},
+		{"any case", "Effort: High", EffortHigh},
+		{"not an effort", "effort: unknown", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Task{Note: tt.note}).Effort(); got != tt.want {
+				t.Errorf("Effort() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetNoteEffort(t *testing.T) {
+	tests := []struct {
+		name   string
+		note   string
+		effort Effort
+		want   string
+	}{
+		{"empty note", "", EffortLow, "effort: low"},
+		{"adds after note", "Buy milk\n", EffortHigh, "Buy milk\n\neffort: high"},
+		{"replaces line", "Buy milk\n\neffort: low", EffortMedium, "Buy milk\n\neffort: medium"},
+		{"removes line", "Buy milk\n\neffort: low", "", "Buy milk"},
+		{"keeps lines after", "effort: low\nresolution: done", EffortHigh, "resolution: done\n\neffort: high"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetNoteEffort(tt.note, tt.effort); got != tt.want {
+				t.Errorf("SetNoteEffort(%q, %q) = %q, want %q", tt.note, tt.effort, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseEstimate parses an estimated duration such as "30m", "1h", "1h30m" or
+// "90" (minutes) into whole minutes
+func ParseEstimate(s string) (int, error) {
+	text := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if minutes, err := strconv.Atoi(text); err == nil && minutes > 0 {
+		return minutes, nil
+	}
+
+	d, err := time.ParseDuration(text)
+	if err != nil || d < time.Minute || d%time.Minute != 0 {
+		return 0, fmt.Errorf("invalid estimate %q: use minutes or a duration such as 30m, 1h or 1h30m", s)
+	}
+	return int(d / time.Minute), nil
+}
+
+// FormatEstimate formats an estimated duration in minutes, e.g. "45m", "2h"
+// or "1h30m"
+func FormatEstimate(minutes int) string {
+	hours, rest := minutes/60, minutes%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", rest)
+	case rest == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, rest)
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "testing"
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"30m", 30},
+		{"45", 45},
+		{"1h", 60},
+		{"1h30m", 90},
+		{"1h 30m", 90},
+		{"2H", 120},
+	}
+	for _, tt := range tests {
+		got, err := ParseEstimate(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseEstimate(%q) = %d, %v, want %d", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseEstimate_Invalid(t *testing.T) {
+	for _, input := range []string{"", "0", "-5", "30s", "1m30s", "soon"} {
+		if _, err := ParseEstimate(input); err == nil {
+			t.Errorf("ParseEstimate(%q) error = nil, want an error", input)
+		}
+	}
+}
+
+func TestFormatEstimate(t *testing.T) {
+	tests := []struct {
+		minutes int
+		want    string
+	}{
+		{15, "15m"},
+		{60, "1h"},
+		{90, "1h30m"},
+		{125, "2h5m"},
+	}
+	for _, tt := range tests {
+		if got := FormatEstimate(tt.minutes); got != tt.want {
+			t.Errorf("FormatEstimate(%d) = %q, want %q", tt.minutes, got, tt.want)
+		}
+		if back, err := ParseEstimate(FormatEstimate(tt.minutes)); err != nil || back != tt.minutes {
+			t.Errorf("ParseEstimate(FormatEstimate(%d)) = %d, %v", tt.minutes, back, err)
+		}
+	}
+}
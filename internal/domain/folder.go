@@ -0,0 +1,36 @@
+package domain
+
+import "strings"
+
+// Folder represents a folder of projects in OmniFocus
+type Folder struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	ParentID   string   `json:"parentId,omitempty"`
+	ProjectIDs []string `json:"projectIds,omitempty"` // Projects directly in the folder, in OmniFocus order
+	Children   []Folder `json:"children,omitempty"`
+}
+
+// AllProjectIDs returns the IDs of the projects in the folder and in its
+// subfolders
+func (f Folder) AllProjectIDs() []string {
+	ids := append([]string(nil), f.ProjectIDs...)
+	for _, child := range f.Children {
+		ids = append(ids, child.AllProjectIDs()...)
+	}
+	return ids
+}
+
+// FindFolder returns the first folder in the tree named name, ignoring case,
+// or nil when there is none
+func FindFolder(folders []Folder, name string) *Folder {
+	for i := range folders {
+		if strings.EqualFold(folders[i].Name, name) {
+			return &folders[i]
+		}
+		if found := FindFolder(folders[i].Children, name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testFolders() []Folder {
+	return []Folder{
+		{ID: "f1", Name: "Work", ProjectIDs: []string{"p1"}, Children: []Folder{
+			{ID: "f2", Name: "Clients", ParentID: "f1", ProjectIDs: []string{"p2", "p3"}},
+		}},
+		{ID: "f3", Name: "Home", ProjectIDs: []string{"p4"}},
+	}
+}
+
+func TestFolder_AllProjectIDs(t *testing.T) {
+	got := testFolders()[0].AllProjectIDs()
+	want := []string{"p1", "p2", "p3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AllProjectIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestFindFolder(t *testing.T) {
+	folders := testFolders()
+	if got := FindFolder(folders, "clients"); got == nil || got.ID != "f2" {
+		t.Errorf("FindFolder(clients) = %v, want f2", got)
+	}
+	if got := FindFolder(folders, "Garden"); got != nil {
+		t.Errorf("FindFolder(Garden) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "strings"
+
+// wordsPerMinute is the reading speed ReadingMinutes assumes
+const wordsPerMinute = 200
+
+// NoteWords returns the number of words in a note
+func NoteWords(note string) int {
+	return len(strings.Fields(note))
+}
+
+// ReadingMinutes returns the minutes it takes to read a note, rounded up, or
+// 0 for an empty note
+func ReadingMinutes(note string) int {
+	words := NoteWords(note)
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
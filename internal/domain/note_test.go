@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadingMinutes(t *testing.T) {
+	tests := []struct {
+		note        string
+		words, mins int
+	}{
+		{note: "", words: 0, mins: 0},
+		{note: "  \n ", words: 0, mins: 0},
+		{note: "Call Bob\nabout the\tinvoice", words: 5, mins: 1},
+		{note: strings.Repeat("word ", 200), words: 200, mins: 1},
+		{note: strings.Repeat("word ", 201), words: 201, mins: 2},
+	}
+	for _, tt := range tests {
+		if got := NoteWords(tt.note); got != tt.words {
+			t.Errorf("NoteWords(%q) = %d, want %d", tt.note, got, tt.words)
+		}
+		if got := ReadingMinutes(tt.note); got != tt.mins {
+			t.Errorf("ReadingMinutes(%q) = %d, want %d", tt.note, got, tt.mins)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+// PerspectiveRule is one archived filter rule of a custom perspective, keyed
+// by its OmniFocus rule type (e.g. "actionAvailability" or "actionHasAnyOfTags")
+type PerspectiveRule map[string]interface{}
+
+// PerspectiveRules is the filter definition of a custom perspective
+type PerspectiveRules struct {
+	Name        string            `json:"name"`
+	Aggregation string            `json:"aggregation"` // How top-level rules combine: "all", "any" or "none"
+	Rules       []PerspectiveRule `json:"rules"`
+}
@@ -4,10 +4,39 @@ package domain
 
 // Project represents a project in OmniFocus
 type Project struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Status    string `json:"status"` // "active", "on-hold", "completed", "dropped"
-	Note      string `json:"note,omitempty"`
-	TaskCount int    `json:"taskCount,omitempty"` // number of tasks in project
-	Tasks     []Task `json:"tasks,omitempty"`     // optional, for detailed view
+	ID                       string `json:"id"`
+	Name                     string `json:"name"`
+	Status                   string `json:"status"`                             // "active", "on-hold", "completed", "dropped"
+	Type                     string `json:"type,omitempty"`                     // ProjectParallel, ProjectSequential or ProjectSingleActions
+	Sequential               bool   `json:"sequential,omitempty"`               // OmniFocus's sequential setting
+	ContainsSingletonActions bool   `json:"containsSingletonActions,omitempty"` // A single-action list; takes precedence over Sequential
+	Note                     string `json:"note,omitempty"`
+	TaskCount                int    `json:"taskCount,omitempty"`         // number of tasks in project
+	CompletedRecently        int    `json:"completedRecently,omitempty"` // tasks completed in the forecast window
+	Tasks                    []Task `json:"tasks,omitempty"`             // optional, for detailed view
+}
+
+// Project types, deciding which of a project's tasks are available
+const (
+	ProjectParallel      = "parallel"       // All tasks are available at once
+	ProjectSequential    = "sequential"     // Only the first remaining task is available
+	ProjectSingleActions = "single-actions" // A list of unrelated tasks
+)
+
+// IsSequential reports whether only the first remaining task of the project
+// is available
+func (p Project) IsSequential() bool {
+	return p.Type == ProjectSequential
+}
+
+// ProjectType returns the type of a project with the given OmniFocus settings
+func ProjectType(sequential, containsSingletonActions bool) string {
+	switch {
+	case containsSingletonActions:
+		return ProjectSingleActions
+	case sequential:
+		return ProjectSequential
+	default:
+		return ProjectParallel
+	}
 }
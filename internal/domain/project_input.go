@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ProjectInput represents the data needed to create a new project
+type ProjectInput struct {
+	Name      string     // Required: project name
+	Note      string     // Optional: project note
+	DueDate   *time.Time // Optional: due date
+	DeferDate *time.Time // Optional: defer/start date
+}
+
+// Validate returns error if required fields are missing
+func (p ProjectInput) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("project name is required")
+	}
+	return nil
+}
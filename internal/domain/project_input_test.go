@@ -0,0 +1,24 @@
+package domain
+
+import "testing"
+
+func TestProjectInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   ProjectInput
+		wantErr bool
+	}{
+		{"valid project", ProjectInput{Name: "Release 1.2"}, false},
+		{"empty name returns error", ProjectInput{Name: ""}, true},
+		{"whitespace name returns error", ProjectInput{Name: "   "}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
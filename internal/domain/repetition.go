@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RepetitionMethod is how OmniFocus schedules the next occurrence of a
+// repeating task
+type RepetitionMethod string
+
+const (
+	RepeatFixed                RepetitionMethod = "fixed"                  // From the previous dates, whenever it is completed
+	RepeatDueAfterCompletion   RepetitionMethod = "due-after-completion"   // Due the interval after completion
+	RepeatStartAfterCompletion RepetitionMethod = "start-after-completion" // Deferred until the interval after completion
+)
+
+// RepetitionRule is how a task repeats, as OmniFocus stores it
+type RepetitionRule struct {
+	Recurrence string           `json:"recurrence"` // iCalendar RRULE, e.g. "FREQ=WEEKLY;INTERVAL=2"
+	Method     RepetitionMethod `json:"method"`
+}
+
+// Frequencies of simple repeats
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+// repeatUnits maps each frequency to the RRULE FREQ value and the unit used
+// in "every N <unit>s"
+var repeatUnits = []struct {
+	frequency string
+	freq      string
+	unit      string
+}{
+	{FrequencyDaily, "DAILY", "day"},
+	{FrequencyWeekly, "WEEKLY", "week"},
+	{FrequencyMonthly, "MONTHLY", "month"},
+	{FrequencyYearly, "YEARLY", "year"},
+}
+
+// Repeat is a simple repeat: every Interval days, weeks, months or years
+type Repeat struct {
+	Frequency string // FrequencyDaily, FrequencyWeekly, FrequencyMonthly or FrequencyYearly
+	Interval  int    // Number of frequency units between occurrences, at least 1
+	Method    RepetitionMethod
+}
+
+// Rule returns the repetition rule OmniFocus stores for the repeat
+func (r Repeat) Rule() RepetitionRule {
+	recurrence := ""
+	for _, u := range repeatUnits {
+		if u.frequency == r.Frequency {
+			recurrence = "FREQ=" + u.freq
+		}
+	}
+	if r.Interval > 1 {
+		recurrence += ";INTERVAL=" + strconv.Itoa(r.Interval)
+	}
+	return RepetitionRule{Recurrence: recurrence, Method: r.Method}
+}
+
+// String describes the repeat in the form ParseRepeat accepts, e.g.
+// "every 2 weeks, after completion"
+func (r Repeat) String() string {
+	text := r.Frequency
+	if r.Interval > 1 {
+		for _, u := range repeatUnits {
+			if u.frequency == r.Frequency {
+				text = fmt.Sprintf("every %d %ss", r.Interval, u.unit)
+			}
+		}
+	}
+	switch r.Method {
+	case RepeatDueAfterCompletion:
+		text += ", after completion"
+	case RepeatStartAfterCompletion:
+		text += ", defer after completion"
+	}
+	return text
+}
+
+// Simple returns the rule as a simple repeat, or false when it uses RRULE
+// parts beyond a frequency and interval, such as specific weekdays
+func (r RepetitionRule) Simple() (Repeat, bool) {
+	repeat := Repeat{Interval: 1, Method: r.Method}
+	if repeat.Method == "" {
+		repeat.Method = RepeatFixed
+	}
+	for _, part := range strings.Split(r.Recurrence, ";") {
+		key, value, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			for _, u := range repeatUnits {
+				if u.freq == strings.ToUpper(value) {
+					repeat.Frequency = u.frequency
+				}
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Repeat{}, false
+			}
+			repeat.Interval = n
+		default:
+			return Repeat{}, false
+		}
+	}
+	return repeat, repeat.Frequency != ""
+}
+
+// String describes the rule, as a simple repeat when it is one and as its
+// RRULE otherwise
+func (r RepetitionRule) String() string {
+	if repeat, ok := r.Simple(); ok {
+		return repeat.String()
+	}
+	return Repeat{Frequency: r.Recurrence, Method: r.Method}.String()
+}
+
+// ParseRepeat parses a simple repeat such as "weekly", "every 2 months" or
+// "daily, after completion". Repeats are fixed unless followed by "after
+// completion" (due again after completion) or "defer after completion"
+// (deferred until after completion). "none" returns nil, for a task that
+// does not repeat.
+func ParseRepeat(s string) (*Repeat, error) {
+	text := strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(s, ",", " ")), " "))
+	if text == "none" || text == "never" {
+		return nil, nil
+	}
+
+	repeat := Repeat{Interval: 1, Method: RepeatFixed}
+	for _, suffix := range []struct {
+		text   string
+		method RepetitionMethod
+	}{
+		{" defer after completion", RepeatStartAfterCompletion},
+		{" start after completion", RepeatStartAfterCompletion},
+		{" due after completion", RepeatDueAfterCompletion},
+		{" after completion", RepeatDueAfterCompletion},
+		{" fixed", RepeatFixed},
+	} {
+		if rest, ok := strings.CutSuffix(text, suffix.text); ok {
+			text, repeat.Method = rest, suffix.method
+			break
+		}
+	}
+
+	for _, u := range repeatUnits {
+		if text == u.frequency || text == "every "+u.unit {
+			repeat.Frequency = u.frequency
+			return &repeat, nil
+		}
+	}
+
+	if words := strings.Fields(text); len(words) == 3 && words[0] == "every" {
+		n, err := strconv.Atoi(words[1])
+		for _, u := range repeatUnits {
+			if err == nil && n > 0 && (words[2] == u.unit || words[2] == u.unit+"s") {
+				repeat.Frequency = u.frequency
+				repeat.Interval = n
+				return &repeat, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("invalid repeat %q: use daily, weekly, monthly, yearly or \"every N days|weeks|months|years\", optionally followed by \"after completion\" or \"defer after completion\", or none", s)
+}
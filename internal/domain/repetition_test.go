@@ -0,0 +1,81 @@
+package domain
+
+import "testing"
+
+func TestParseRepeat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  *Repeat
+	}{
+		{"weekly", &Repeat{Frequency: FrequencyWeekly, Interval: 1, Method: RepeatFixed}},
+		{"Daily", &Repeat{Frequency: FrequencyDaily, Interval: 1, Method: RepeatFixed}},
+		{"every month", &Repeat{Frequency: FrequencyMonthly, Interval: 1, Method: RepeatFixed}},
+		{"every 2 weeks", &Repeat{Frequency: FrequencyWeekly, Interval: 2, Method: RepeatFixed}},
+		{"monthly, after completion", &Repeat{Frequency: FrequencyMonthly, Interval: 1, Method: RepeatDueAfterCompletion}},
+		{"every 3 days defer after completion", &Repeat{Frequency: FrequencyDaily, Interval: 3, Method: RepeatStartAfterCompletion}},
+		{"yearly fixed", &Repeat{Frequency: FrequencyYearly, Interval: 1, Method: RepeatFixed}},
+		{"none", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRepeat(tt.input)
+			if err != nil {
+				t.Fatalf("ParseRepeat(%q) error = %v", tt.input, err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ParseRepeat(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRepeat_Invalid(t *testing.T) {
+	for _, input := range []string{"", "fortnightly", "every 0 days", "every two weeks", "every 2 weeks on monday"} {
+		if _, err := ParseRepeat(input); err == nil {
+			t.Errorf("ParseRepeat(%q) error = nil, want an error", input)
+		}
+	}
+}
+
+func TestRepeat_StringRoundTrips(t *testing.T) {
+	for _, repeat := range []Repeat{
+		{Frequency: FrequencyWeekly, Interval: 1, Method: RepeatFixed},
+		{Frequency: FrequencyMonthly, Interval: 2, Method: RepeatDueAfterCompletion},
+		{Frequency: FrequencyDaily, Interval: 3, Method: RepeatStartAfterCompletion},
+	} {
+		got, err := ParseRepeat(repeat.String())
+		if err != nil || got == nil || *got != repeat {
+			t.Errorf("ParseRepeat(%q) = %+v, %v, want %+v", repeat.String(), got, err, repeat)
+		}
+	}
+}
+
+func TestRepetitionRule_Simple(t *testing.T) {
+	repeat := Repeat{Frequency: FrequencyWeekly, Interval: 2, Method: RepeatDueAfterCompletion}
+	rule := repeat.Rule()
+	if rule.Recurrence != "FREQ=WEEKLY;INTERVAL=2" {
+		t.Errorf("Rule().Recurrence = %q, want FREQ=WEEKLY;INTERVAL=2", rule.Recurrence)
+	}
+	if got, ok := rule.Simple(); !ok || got != repeat {
+		t.Errorf("Simple() = %+v, %v, want %+v, true", got, ok, repeat)
+	}
+}
+
+func TestRepetitionRule_String(t *testing.T) {
+	tests := []struct {
+		rule RepetitionRule
+		want string
+	}{
+		{RepetitionRule{Recurrence: "FREQ=DAILY", Method: RepeatFixed}, "daily"},
+		{RepetitionRule{Recurrence: "FREQ=MONTHLY;INTERVAL=3", Method: RepeatDueAfterCompletion}, "every 3 months, after completion"},
+		{RepetitionRule{Recurrence: "FREQ=WEEKLY;BYDAY=MO,WE", Method: RepeatFixed}, "FREQ=WEEKLY;BYDAY=MO,WE"},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.rule, got, tt.want)
+		}
+		if _, ok := tt.rule.Simple(); ok != (tt.want != tt.rule.Recurrence) {
+			t.Errorf("%+v.Simple() ok = %v", tt.rule, ok)
+		}
+	}
+}
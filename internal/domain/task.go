@@ -1,18 +1,121 @@
 package domain
 
-import "time"
+import (
+	"net/url"
+	"strings"
+	"time"
+)
 
 // Task represents a task in OmniFocus
 type Task struct {
-	ID            string     `json:"id"`
-	Name          string     `json:"name"`
-	Note          string     `json:"note,omitempty"`
-	ProjectID     string     `json:"projectId,omitempty"`
-	ProjectName   string     `json:"projectName,omitempty"`
-	Tags          []string   `json:"tags,omitempty"`
-	DueDate       *time.Time `json:"dueDate,omitempty"`
-	DeferDate     *time.Time `json:"deferDate,omitempty"`
-	Flagged       bool       `json:"flagged"`
-	Completed     bool       `json:"completed"`
-	CompletedDate *time.Time `json:"completedDate,omitempty"`
+	ID                 string          `json:"id"`
+	Name               string          `json:"name"`
+	Note               string          `json:"note,omitempty"`
+	ProjectID          string          `json:"projectId,omitempty"`
+	ProjectName        string          `json:"projectName,omitempty"`
+	FolderPath         []string        `json:"folderPath,omitempty"` // Folders containing the project, outermost first
+	Tags               []string        `json:"tags,omitempty"`
+	DueDate            *time.Time      `json:"dueDate,omitempty"`
+	DeferDate          *time.Time      `json:"deferDate,omitempty"`
+	EffectiveDueDate   *time.Time      `json:"effectiveDueDate,omitempty"`   // DueDate, or the one inherited from the project or parent
+	EffectiveDeferDate *time.Time      `json:"effectiveDeferDate,omitempty"` // DeferDate, or the one inherited from the project or parent
+	Flagged            bool            `json:"flagged"`
+	Repetition         *RepetitionRule `json:"repetitionRule,omitempty"`   // nil when the task does not repeat
+	EstimatedMinutes   int             `json:"estimatedMinutes,omitempty"` // Estimated duration, 0 when not estimated
+	Blocked            bool            `json:"blocked,omitempty"`          // Waiting on an earlier task in a sequential project or group
+	Sequential         bool            `json:"sequential,omitempty"`       // Subtasks become available one at a time, in order
+	Dropped            bool            `json:"dropped,omitempty"`          // Dropped in OmniFocus; never available
+	ModifiedDate       *time.Time      `json:"modifiedDate,omitempty"`     // Last changed in OmniFocus
+	Completed          bool            `json:"completed"`
+	CompletedDate      *time.Time      `json:"completedDate,omitempty"`
+	ParentID           string          `json:"parentId,omitempty"`
+	Children           []Task          `json:"children,omitempty"`
+}
+
+// FlattenTasks returns the tasks and all their subtasks in outline order,
+// with Children cleared on the returned copies. Copies of tasks waiting on
+// subtasks are marked Blocked so they keep their availability.
+func FlattenTasks(tasks []Task) []Task {
+	var result []Task
+	for _, task := range tasks {
+		if task.hasIncompleteChildren() {
+			task.Blocked = true
+		}
+		children := task.Children
+		task.Children = nil
+		result = append(result, task)
+		result = append(result, FlattenTasks(children)...)
+	}
+	return result
+}
+
+// InheritedMarker follows a date a task inherits from its project or parent
+// task rather than has itself
+const InheritedMarker = "↑"
+
+// EffectiveDue returns when the task is due: its effective due date, which
+// may be inherited from its project or parent task, or else its own
+func (t Task) EffectiveDue() *time.Time {
+	if t.EffectiveDueDate != nil {
+		return t.EffectiveDueDate
+	}
+	return t.DueDate
+}
+
+// EffectiveDefer returns when the task becomes available: its effective
+// defer date, which may be inherited from its project or parent task, or
+// else its own
+func (t Task) EffectiveDefer() *time.Time {
+	if t.EffectiveDeferDate != nil {
+		return t.EffectiveDeferDate
+	}
+	return t.DeferDate
+}
+
+// DueInherited reports whether the task's effective due date comes from its
+// project or parent task rather than the task itself
+func (t Task) DueInherited() bool {
+	return inherited(t.DueDate, t.EffectiveDueDate)
+}
+
+// DeferInherited reports whether the task's effective defer date comes from
+// its project or parent task rather than the task itself
+func (t Task) DeferInherited() bool {
+	return inherited(t.DeferDate, t.EffectiveDeferDate)
+}
+
+// inherited reports whether an effective date differs from the task's own
+func inherited(own, effective *time.Time) bool {
+	return effective != nil && (own == nil || !own.Equal(*effective))
+}
+
+// PathSeparator separates the folders and project of a task's path
+const PathSeparator = " ▸ "
+
+// Path returns the folders and project containing the task, e.g.
+// "Work ▸ Clients ▸ Acme", or "" for inbox tasks
+func (t Task) Path() string {
+	if t.ProjectName == "" {
+		return ""
+	}
+	return strings.Join(append(append([]string(nil), t.FolderPath...), t.ProjectName), PathSeparator)
+}
+
+// TaskURL returns the omnifocus:// URL that opens a task in OmniFocus
+func TaskURL(id string) string {
+	return "omnifocus:///task/" + url.PathEscape(id)
+}
+
+// ResolutionPrefix starts the closing note added when a task is completed with a note
+const ResolutionPrefix = "resolution: "
+
+// AppendResolution returns note with a closing "resolution: …" line added
+// after a blank line
+func AppendResolution(note, resolution string) string {
+	line := ResolutionPrefix + strings.TrimSpace(resolution)
+	note = strings.TrimRight(note, " \n")
+	if note == "" {
+		return line
+	}
+	return note + "\n\n" + line
 }
@@ -12,6 +12,7 @@ type TaskInput struct {
 	Note        string     // Optional: task note
 	ProjectID   string     // Optional: resolved project ID
 	ProjectName string     // Optional: original @project name from input
+	ParentID    string     // Optional: ID of the task to create this one under
 	TagNames    []string   // Optional: tag names to apply
 	DueDate     *time.Time // Optional: due date
 	DeferDate   *time.Time // Optional: defer/start date
@@ -35,3 +36,18 @@ func (t TaskInput) HasProject() bool {
 func (t TaskInput) HasTags() bool {
 	return len(t.TagNames) > 0
 }
+
+// TaskInputFromTask builds the input needed to recreate a task from a snapshot
+func TaskInputFromTask(task Task) TaskInput {
+	flagged := task.Flagged
+	return TaskInput{
+		Name:        task.Name,
+		Note:        task.Note,
+		ProjectID:   task.ProjectID,
+		ProjectName: task.ProjectName,
+		TagNames:    task.Tags,
+		DueDate:     task.DueDate,
+		DeferDate:   task.DeferDate,
+		Flagged:     &flagged,
+	}
+}
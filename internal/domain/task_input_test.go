@@ -192,3 +192,35 @@ func TestTaskInput_HasTags(t *testing.T) {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestTaskInputFromTask(t *testing.T) {
+	due := time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)
+	task := Task{
+		ID:          "task1",
+		Name:        "Buy milk",
+		Note:        "Whole milk",
+		ProjectID:   "proj1",
+		ProjectName: "Errands",
+		Tags:        []string{"shopping"},
+		DueDate:     &due,
+		Flagged:     true,
+	}
+
+	input := TaskInputFromTask(task)
+
+	if input.Name != task.Name || input.Note != task.Note {
+		t.Errorf("TaskInputFromTask() name/note = %q/%q, want %q/%q", input.Name, input.Note, task.Name, task.Note)
+	}
+	if input.ProjectID != "proj1" || input.ProjectName != "Errands" {
+		t.Errorf("TaskInputFromTask() project = %q/%q, want proj1/Errands", input.ProjectID, input.ProjectName)
+	}
+	if len(input.TagNames) != 1 || input.TagNames[0] != "shopping" {
+		t.Errorf("TaskInputFromTask() TagNames = %v, want [shopping]", input.TagNames)
+	}
+	if input.DueDate == nil || !input.DueDate.Equal(due) {
+		t.Errorf("TaskInputFromTask() DueDate = %v, want %v", input.DueDate, due)
+	}
+	if input.Flagged == nil || !*input.Flagged {
+		t.Errorf("TaskInputFromTask() Flagged = %v, want true", input.Flagged)
+	}
+}
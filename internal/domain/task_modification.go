@@ -5,16 +5,20 @@ import "time"
 // TaskModification represents changes to apply to an existing task
 // Nil pointer fields are not modified; non-nil fields are set to the value
 type TaskModification struct {
-	Name       *string    // New name (nil = don't change)
-	Note       *string    // New note (nil = don't change)
-	ProjectID  *string    // New project ID (nil = don't change, empty string = remove from project)
-	AddTags    []string   // Tags to add
-	RemoveTags []string   // Tags to remove
-	DueDate    *time.Time // New due date (nil = don't change)
-	DeferDate  *time.Time // New defer date (nil = don't change)
-	Flagged    *bool      // New flagged status (nil = don't change)
-	ClearDue   bool       // If true, clear the due date
-	ClearDefer bool       // If true, clear the defer date
+	Name             *string    // New name (nil = don't change)
+	Note             *string    // New note (nil = don't change)
+	ProjectID        *string    // New project ID (nil = don't change, empty string = remove from project)
+	AddTags          []string   // Tags to add
+	RemoveTags       []string   // Tags to remove
+	DueDate          *time.Time // New due date (nil = don't change)
+	DeferDate        *time.Time // New defer date (nil = don't change)
+	Flagged          *bool      // New flagged status (nil = don't change)
+	Repeat           *Repeat    // New repeat (nil = don't change)
+	EstimatedMinutes *int       // New estimated duration in minutes (nil = don't change)
+	ClearDue         bool       // If true, clear the due date
+	ClearDefer       bool       // If true, clear the defer date
+	ClearRepeat      bool       // If true, stop the task repeating
+	ClearEstimate    bool       // If true, clear the estimated duration
 }
 
 // IsEmpty returns true if no modifications are specified
@@ -27,11 +31,94 @@ func (m TaskModification) IsEmpty() bool {
 		m.DueDate == nil &&
 		m.DeferDate == nil &&
 		m.Flagged == nil &&
+		m.Repeat == nil &&
+		m.EstimatedMinutes == nil &&
 		!m.ClearDue &&
-		!m.ClearDefer
+		!m.ClearDefer &&
+		!m.ClearRepeat &&
+		!m.ClearEstimate
 }
 
 // HasTagChanges returns true if tags are being added or removed
 func (m TaskModification) HasTagChanges() bool {
 	return len(m.AddTags) > 0 || len(m.RemoveTags) > 0
 }
+
+// Inverse returns the modification that restores the given task to its state
+// before this modification was applied
+func (m TaskModification) Inverse(before Task) TaskModification {
+	var inverse TaskModification
+
+	if m.Name != nil {
+		name := before.Name
+		inverse.Name = &name
+	}
+
+	if m.Note != nil {
+		note := before.Note
+		inverse.Note = &note
+	}
+
+	if m.ProjectID != nil {
+		projectID := before.ProjectID
+		inverse.ProjectID = &projectID
+	}
+
+	hadTag := make(map[string]bool, len(before.Tags))
+	for _, tag := range before.Tags {
+		hadTag[tag] = true
+	}
+	for _, tag := range m.AddTags {
+		if !hadTag[tag] {
+			inverse.RemoveTags = append(inverse.RemoveTags, tag)
+		}
+	}
+	for _, tag := range m.RemoveTags {
+		if hadTag[tag] {
+			inverse.AddTags = append(inverse.AddTags, tag)
+		}
+	}
+
+	if m.DueDate != nil || m.ClearDue {
+		if before.DueDate == nil {
+			inverse.ClearDue = true
+		} else {
+			due := *before.DueDate
+			inverse.DueDate = &due
+		}
+	}
+
+	if m.DeferDate != nil || m.ClearDefer {
+		if before.DeferDate == nil {
+			inverse.ClearDefer = true
+		} else {
+			deferDate := *before.DeferDate
+			inverse.DeferDate = &deferDate
+		}
+	}
+
+	if m.Flagged != nil {
+		flagged := before.Flagged
+		inverse.Flagged = &flagged
+	}
+
+	// A repeat that is not a simple one cannot be set again, so it is left as is
+	if m.Repeat != nil || m.ClearRepeat {
+		if before.Repetition == nil {
+			inverse.ClearRepeat = true
+		} else if repeat, ok := before.Repetition.Simple(); ok {
+			inverse.Repeat = &repeat
+		}
+	}
+
+	if m.EstimatedMinutes != nil || m.ClearEstimate {
+		if before.EstimatedMinutes == 0 {
+			inverse.ClearEstimate = true
+		} else {
+			minutes := before.EstimatedMinutes
+			inverse.EstimatedMinutes = &minutes
+		}
+	}
+
+	return inverse
+}
@@ -81,6 +81,34 @@ func TestTaskModification_IsEmpty(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "has repeat",
+			mod: TaskModification{
+				Repeat: &Repeat{Frequency: FrequencyWeekly, Interval: 1, Method: RepeatFixed},
+			},
+			want: false,
+		},
+		{
+			name: "has clear repeat flag",
+			mod: TaskModification{
+				ClearRepeat: true,
+			},
+			want: false,
+		},
+		{
+			name: "has estimate",
+			mod: TaskModification{
+				EstimatedMinutes: testutil.IntPtr(30),
+			},
+			want: false,
+		},
+		{
+			name: "has clear estimate flag",
+			mod: TaskModification{
+				ClearEstimate: true,
+			},
+			want: false,
+		},
 		{
 			name: "has clear due flag",
 			mod: TaskModification{
@@ -199,3 +227,115 @@ func TestTaskModification_HasTagChanges(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskModification_Inverse(t *testing.T) {
+	due := time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)
+	newDue := due.AddDate(0, 0, 7)
+	before := Task{
+		ID:        "task1",
+		Name:      "Old name",
+		Note:      "Old note",
+		ProjectID: "proj1",
+		Tags:      []string{"home", "errands"},
+		DueDate:   &due,
+		Flagged:   false,
+	}
+
+	t.Run("restores changed fields", func(t *testing.T) {
+		mod := TaskModification{
+			Name:      testutil.StringPtr("New name"),
+			Note:      testutil.StringPtr("New note"),
+			ProjectID: testutil.StringPtr(""),
+			DueDate:   &newDue,
+			DeferDate: &newDue,
+			Flagged:   testutil.BoolPtr(true),
+		}
+
+		inverse := mod.Inverse(before)
+
+		if inverse.Name == nil || *inverse.Name != "Old name" {
+			t.Errorf("Inverse().Name = %v, want %q", inverse.Name, "Old name")
+		}
+		if inverse.Note == nil || *inverse.Note != "Old note" {
+			t.Errorf("Inverse().Note = %v, want %q", inverse.Note, "Old note")
+		}
+		if inverse.ProjectID == nil || *inverse.ProjectID != "proj1" {
+			t.Errorf("Inverse().ProjectID = %v, want %q", inverse.ProjectID, "proj1")
+		}
+		if inverse.DueDate == nil || !inverse.DueDate.Equal(due) {
+			t.Errorf("Inverse().DueDate = %v, want %v", inverse.DueDate, due)
+		}
+		if !inverse.ClearDefer {
+			t.Error("Inverse().ClearDefer = false, want true (task had no defer date)")
+		}
+		if inverse.Flagged == nil || *inverse.Flagged {
+			t.Errorf("Inverse().Flagged = %v, want false", inverse.Flagged)
+		}
+	})
+
+	t.Run("leaves untouched fields alone", func(t *testing.T) {
+		mod := TaskModification{Flagged: testutil.BoolPtr(true)}
+
+		inverse := mod.Inverse(before)
+
+		if inverse.Name != nil || inverse.Note != nil || inverse.ProjectID != nil ||
+			inverse.DueDate != nil || inverse.ClearDue || inverse.HasTagChanges() {
+			t.Errorf("Inverse() = %+v, want only Flagged set", inverse)
+		}
+	})
+
+	t.Run("reverses tag changes that took effect", func(t *testing.T) {
+		mod := TaskModification{
+			AddTags:    []string{"home", "work"},
+			RemoveTags: []string{"errands", "urgent"},
+		}
+
+		inverse := mod.Inverse(before)
+
+		if len(inverse.RemoveTags) != 1 || inverse.RemoveTags[0] != "work" {
+			t.Errorf("Inverse().RemoveTags = %v, want [work]", inverse.RemoveTags)
+		}
+		if len(inverse.AddTags) != 1 || inverse.AddTags[0] != "errands" {
+			t.Errorf("Inverse().AddTags = %v, want [errands]", inverse.AddTags)
+		}
+	})
+
+	t.Run("restores cleared due date", func(t *testing.T) {
+		inverse := TaskModification{ClearDue: true}.Inverse(before)
+
+		if inverse.DueDate == nil || !inverse.DueDate.Equal(due) {
+			t.Errorf("Inverse().DueDate = %v, want %v", inverse.DueDate, due)
+		}
+	})
+
+	t.Run("restores repeat", func(t *testing.T) {
+		repeat := Repeat{Frequency: FrequencyMonthly, Interval: 2, Method: RepeatDueAfterCompletion}
+		repeating := before
+		repeating.Repetition = &RepetitionRule{Recurrence: "FREQ=MONTHLY;INTERVAL=2", Method: RepeatDueAfterCompletion}
+
+		inverse := TaskModification{ClearRepeat: true}.Inverse(repeating)
+		if inverse.Repeat == nil || *inverse.Repeat != repeat {
+			t.Errorf("Inverse().Repeat = %+v, want %+v", inverse.Repeat, repeat)
+		}
+
+		inverse = TaskModification{Repeat: &repeat}.Inverse(before)
+		if !inverse.ClearRepeat {
+			t.Error("Inverse().ClearRepeat = false, want true (task did not repeat)")
+		}
+	})
+
+	t.Run("restores estimate", func(t *testing.T) {
+		estimated := before
+		estimated.EstimatedMinutes = 45
+
+		inverse := TaskModification{ClearEstimate: true}.Inverse(estimated)
+		if inverse.EstimatedMinutes == nil || *inverse.EstimatedMinutes != 45 {
+			t.Errorf("Inverse().EstimatedMinutes = %v, want 45", inverse.EstimatedMinutes)
+		}
+
+		inverse = TaskModification{EstimatedMinutes: testutil.IntPtr(30)}.Inverse(before)
+		if !inverse.ClearEstimate {
+			t.Error("Inverse().ClearEstimate = false, want true (task had no estimate)")
+		}
+	})
+}
@@ -0,0 +1,9 @@
+package domain
+
+// TaskPosition places a task directly before or after one of its siblings.
+// Moving a task to a position it already has changes nothing, so the same
+// move can safely be repeated.
+type TaskPosition struct {
+	SiblingID string `json:"siblingId"`       // Task to move next to
+	After     bool   `json:"after,omitempty"` // Place after the sibling instead of before it
+}
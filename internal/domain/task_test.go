@@ -2,6 +2,7 @@ package domain
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 )
@@ -108,6 +109,12 @@ func TestTask_OmitEmptyFields(t *testing.T) {
 	if contains(jsonString, "completedDate") {
 		t.Error("Expected 'completedDate' field to be omitted when nil")
 	}
+	if contains(jsonString, "parentId") {
+		t.Error("Expected 'parentId' field to be omitted when empty")
+	}
+	if contains(jsonString, "children") {
+		t.Error("Expected 'children' field to be omitted when empty")
+	}
 
 	// Check that required fields are present
 	if !contains(jsonString, "id") {
@@ -171,3 +178,103 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFlattenTasks(t *testing.T) {
+	tasks := []Task{
+		{ID: "a", Name: "Plan trip", Children: []Task{
+			{ID: "a1", Name: "Book flights", ParentID: "a", Children: []Task{
+				{ID: "a1x", Name: "Compare prices", ParentID: "a1"},
+			}},
+			{ID: "a2", Name: "Book hotel", ParentID: "a"},
+		}},
+		{ID: "b", Name: "Pay rent"},
+	}
+
+	flat := FlattenTasks(tasks)
+
+	var ids []string
+	for _, task := range flat {
+		ids = append(ids, task.ID)
+		if task.Children != nil {
+			t.Errorf("FlattenTasks() task %s has children, want none", task.ID)
+		}
+	}
+	if got, want := strings.Join(ids, ","), "a,a1,a1x,a2,b"; got != want {
+		t.Errorf("FlattenTasks() order = %s, want %s", got, want)
+	}
+	if len(tasks[0].Children) != 2 {
+		t.Error("FlattenTasks() modified the input tree")
+	}
+	if !flat[0].Blocked || !flat[1].Blocked || flat[2].Blocked || flat[4].Blocked {
+		t.Error("FlattenTasks() should mark only tasks with incomplete subtasks as blocked")
+	}
+}
+
+func TestAppendResolution(t *testing.T) {
+	tests := []struct {
+		note, resolution, want string
+	}{
+		{note: "", resolution: "Done", want: "resolution: Done"},
+		{note: "Call back\n", resolution: " Fixed in 2.3 ", want: "Call back\n\nresolution: Fixed in 2.3"},
+	}
+	for _, tt := range tests {
+		if got := AppendResolution(tt.note, tt.resolution); got != tt.want {
+			t.Errorf("AppendResolution(%q, %q) = %q, want %q", tt.note, tt.resolution, got, tt.want)
+		}
+	}
+}
+
+func TestTask_Path(t *testing.T) {
+	tests := []struct {
+		task Task
+		want string
+	}{
+		{Task{Name: "Inbox task"}, ""},
+		{Task{ProjectName: "Errands"}, "Errands"},
+		{Task{ProjectName: "Acme", FolderPath: []string{"Work", "Clients"}}, "Work ▸ Clients ▸ Acme"},
+	}
+	for _, tt := range tests {
+		if got := tt.task.Path(); got != tt.want {
+			t.Errorf("Path() of %+v = %q, want %q", tt.task, got, tt.want)
+		}
+	}
+}
+
+func TestTaskURL(t *testing.T) {
+	if got := TaskURL("abc123"); got != "omnifocus:///task/abc123" {
+		t.Errorf("TaskURL() = %q, want omnifocus:///task/abc123", got)
+	}
+	if got := TaskURL("a/b c"); got != "omnifocus:///task/a%2Fb%20c" {
+		t.Errorf("TaskURL() = %q, want escaped ID", got)
+	}
+}
+
+func TestTask_EffectiveDue(t *testing.T) {
+	own := time.Date(2024, 1, 20, 17, 0, 0, 0, time.UTC)
+	project := time.Date(2024, 1, 25, 17, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		task          Task
+		want          *time.Time
+		wantInherited bool
+	}{
+		{"no dates", Task{}, nil, false},
+		{"own date only", Task{DueDate: &own}, &own, false},
+		{"own date matches effective", Task{DueDate: &own, EffectiveDueDate: &own}, &own, false},
+		{"inherited from project", Task{EffectiveDueDate: &project}, &project, true},
+		{"project due earlier than own", Task{DueDate: &project, EffectiveDueDate: &own}, &own, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.task.EffectiveDue()
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("EffectiveDue() = %v, want %v", got, tt.want)
+			}
+			if inherited := tt.task.DueInherited(); inherited != tt.wantInherited {
+				t.Errorf("DueInherited() = %v, want %v", inherited, tt.wantInherited)
+			}
+		})
+	}
+}
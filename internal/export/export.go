@@ -0,0 +1,177 @@
+// Package export dumps the OmniFocus database to JSON, TaskPaper or a compact
+// summary for AI assistants.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Export formats
+const (
+	FormatJSON      = "json"
+	FormatTaskPaper = "taskpaper"
+	FormatLLM       = "llm"
+)
+
+// taskPaperDate is the date layout of TaskPaper tag values
+const taskPaperDate = "2006-01-02 15:04"
+
+// Source is the part of the OmniFocus service an export reads from
+type Source interface {
+	GetProjects(ctx context.Context, status string) ([]domain.Project, error)
+	GetTaskHierarchy(ctx context.Context, projectID string) ([]domain.Task, error)
+	GetTags(ctx context.Context) ([]domain.Tag, error)
+}
+
+// Progress reports how many projects have been read
+type Progress interface {
+	Start(label string, total int)
+	Increment()
+	Finish()
+}
+
+// Database is a full dump of projects, inbox tasks and tags
+type Database struct {
+	ExportedAt time.Time        `json:"exportedAt"`
+	Inbox      []domain.Task    `json:"inbox"`
+	Projects   []domain.Project `json:"projects"`
+	Tags       []domain.Tag     `json:"tags"`
+}
+
+// Collect reads every project with its task tree, the inbox and the tags
+func Collect(ctx context.Context, src Source, progress Progress, now time.Time) (*Database, error) {
+	db := &Database{ExportedAt: now}
+
+	projects, err := src.GetProjects(ctx, "all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	progress.Start("Exporting projects", len(projects))
+	for _, project := range projects {
+		tasks, err := src.GetTaskHierarchy(ctx, project.ID)
+		if err != nil {
+			progress.Finish()
+			return nil, fmt.Errorf("failed to get tasks of project %s: %w", project.Name, err)
+		}
+		project.Tasks = tasks
+		db.Projects = append(db.Projects, project)
+		progress.Increment()
+	}
+	progress.Finish()
+
+	if db.Inbox, err = src.GetTaskHierarchy(ctx, ""); err != nil {
+		return nil, fmt.Errorf("failed to get inbox tasks: %w", err)
+	}
+	if db.Tags, err = src.GetTags(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return db, nil
+}
+
+// TaskCount returns the number of tasks and subtasks in the dump
+func (db *Database) TaskCount() int {
+	count := len(domain.FlattenTasks(db.Inbox))
+	for _, project := range db.Projects {
+		count += len(domain.FlattenTasks(project.Tasks))
+	}
+	return count
+}
+
+// Write encodes the dump in the given format
+func Write(w io.Writer, db *Database, format string) error {
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		return WriteJSON(w, db)
+	case FormatTaskPaper:
+		return WriteTaskPaper(w, db)
+	case FormatLLM:
+		return WriteLLM(w, db, 0)
+	default:
+		return fmt.Errorf("unknown export format %q: use %s, %s or %s", format, FormatJSON, FormatTaskPaper, FormatLLM)
+	}
+}
+
+// WriteJSON encodes the dump as indented JSON
+func WriteJSON(w io.Writer, db *Database) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(db); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// WriteTaskPaper encodes the dump as a TaskPaper outline: the inbox and each
+// project are top-level items, tasks are "- " items indented by tabs, and
+// dates, flags, tags and completion become @tag(value) attributes
+func WriteTaskPaper(w io.Writer, db *Database) error {
+	var b strings.Builder
+
+	if len(db.Inbox) > 0 {
+		b.WriteString("Inbox:\n")
+		writeTaskPaperTasks(&b, db.Inbox, 1)
+	}
+	for _, project := range db.Projects {
+		b.WriteString(project.Name + ":")
+		if project.Status != "" && project.Status != "active" {
+			fmt.Fprintf(&b, " @status(%s)", project.Status)
+		}
+		b.WriteString("\n")
+		writeTaskPaperNote(&b, project.Note, 1)
+		writeTaskPaperTasks(&b, project.Tasks, 1)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// writeTaskPaperTasks writes tasks and their subtasks at the given depth
+func writeTaskPaperTasks(b *strings.Builder, tasks []domain.Task, depth int) {
+	indent := strings.Repeat("\t", depth)
+	for _, task := range tasks {
+		b.WriteString(indent + "- " + task.Name)
+		if task.Flagged {
+			b.WriteString(" @flagged")
+		}
+		if task.DeferDate != nil {
+			fmt.Fprintf(b, " @defer(%s)", task.DeferDate.Local().Format(taskPaperDate))
+		}
+		if task.DueDate != nil {
+			fmt.Fprintf(b, " @due(%s)", task.DueDate.Local().Format(taskPaperDate))
+		}
+		if len(task.Tags) > 0 {
+			fmt.Fprintf(b, " @tags(%s)", strings.Join(task.Tags, ", "))
+		}
+		if task.Completed {
+			if task.CompletedDate != nil {
+				fmt.Fprintf(b, " @done(%s)", task.CompletedDate.Local().Format(taskPaperDate))
+			} else {
+				b.WriteString(" @done")
+			}
+		}
+		b.WriteString("\n")
+		writeTaskPaperNote(b, task.Note, depth+1)
+		writeTaskPaperTasks(b, task.Children, depth+1)
+	}
+}
+
+// writeTaskPaperNote writes each non-empty line of a note at the given depth
+func writeTaskPaperNote(b *strings.Builder, note string, depth int) {
+	indent := strings.Repeat("\t", depth)
+	for _, line := range strings.Split(note, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			b.WriteString(indent + line + "\n")
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// fakeSource serves projects, task trees by project ID ("" for the inbox) and tags
+type fakeSource struct {
+	projects []domain.Project
+	tasks    map[string][]domain.Task
+	tags     []domain.Tag
+	err      error
+}
+
+func (f *fakeSource) GetProjects(context.Context, string) ([]domain.Project, error) {
+	return f.projects, nil
+}
+func (f *fakeSource) GetTaskHierarchy(ctx context.Context, id string) ([]domain.Task, error) {
+	if f.err != nil && id != "" {
+		return nil, f.err
+	}
+	return f.tasks[id], nil
+}
+func (f *fakeSource) GetTags(ctx context.Context) ([]domain.Tag, error) { return f.tags, nil }
+
+// countingProgress records progress calls
+type countingProgress struct {
+	total, done int
+	finished    bool
+}
+
+func (p *countingProgress) Start(_ string, total int) { p.total = total }
+func (p *countingProgress) Increment()                { p.done++ }
+func (p *countingProgress) Finish()                   { p.finished = true }
+
+func newFakeSource() *fakeSource {
+	due := time.Date(2026, 3, 2, 17, 0, 0, 0, time.Local)
+	return &fakeSource{
+		projects: []domain.Project{
+			{ID: "p1", Name: "Home", Status: "active", Note: "Around the house"},
+			{ID: "p2", Name: "Old", Status: "dropped"},
+		},
+		tasks: map[string][]domain.Task{
+			"": {{ID: "i1", Name: "Call bank", Flagged: true}},
+			"p1": {{ID: "t1", Name: "Paint fence", DueDate: &due, Tags: []string{"Errands", "Weekend"},
+				Children: []domain.Task{{ID: "t2", Name: "Buy paint", Completed: true, ParentID: "t1"}}}},
+		},
+		tags: []domain.Tag{{ID: "tag1", Name: "Errands"}},
+	}
+}
+
+func TestCollect(t *testing.T) {
+	progress := &countingProgress{}
+	db, err := Collect(context.Background(), newFakeSource(), progress, time.Now())
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if len(db.Projects) != 2 || len(db.Projects[0].Tasks) != 1 || len(db.Inbox) != 1 || len(db.Tags) != 1 {
+		t.Errorf("Collect() = %+v, want 2 projects, 1 inbox task and 1 tag", db)
+	}
+	if got := db.TaskCount(); got != 3 {
+		t.Errorf("TaskCount() = %d, want 3", got)
+	}
+	if progress.total != 2 || progress.done != 2 || !progress.finished {
+		t.Errorf("progress = %+v, want 2 of 2 finished", progress)
+	}
+}
+
+func TestCollect_Error(t *testing.T) {
+	src := newFakeSource()
+	src.err = errors.New("timeout")
+
+	_, err := Collect(context.Background(), src, &countingProgress{}, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "failed to get tasks of project Home") {
+		t.Errorf("Collect() error = %v, want project error", err)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	db, _ := Collect(context.Background(), newFakeSource(), &countingProgress{}, time.Now())
+
+	var buf bytes.Buffer
+	if err := Write(&buf, db, FormatJSON); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var decoded Database
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Write() produced invalid JSON: %v", err)
+	}
+	if len(decoded.Projects) != 2 || decoded.Projects[0].Tasks[0].Children[0].Name != "Buy paint" {
+		t.Errorf("decoded = %+v, want projects with nested subtasks", decoded)
+	}
+}
+
+func TestWriteTaskPaper(t *testing.T) {
+	db, _ := Collect(context.Background(), newFakeSource(), &countingProgress{}, time.Now())
+
+	var buf bytes.Buffer
+	if err := Write(&buf, db, "TaskPaper"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := "Inbox:\n" +
+		"\t- Call bank @flagged\n" +
+		"Home:\n" +
+		"\tAround the house\n" +
+		"\t- Paint fence @due(2026-03-02 17:00) @tags(Errands, Weekend)\n" +
+		"\t\t- Buy paint @done\n" +
+		"Old: @status(dropped)\n"
+	if buf.String() != want {
+		t.Errorf("Write() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	err := Write(&bytes.Buffer{}, &Database{}, "xml")
+	if err == nil || !strings.Contains(err.Error(), `unknown export format "xml"`) {
+		t.Errorf("Write() error = %v, want unknown format", err)
+	}
+}
+
+func TestWriteLLM(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	db, _ := Collect(context.Background(), newFakeSource(), &countingProgress{}, now)
+	db.Inbox[0].Note = "Ask about\nthe mortgage " + strings.Repeat("rate ", 30)
+	db.Inbox = append(db.Inbox, db.Inbox[0])
+
+	var buf bytes.Buffer
+	if err := Write(&buf, db, FormatLLM); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := "# OmniFocus open tasks on 2026-03-01\n" +
+		"Each line: id name | due | flagged | tags | note\n" +
+		"\n## Inbox\n" +
+		"- i1 Call bank | flagged | note: Ask about the mortgage rate rate rate rate rate rate rate rate rate rate rate…\n" +
+		"\n## Home [p1]\n" +
+		"- t1 Paint fence | due 2026-03-02 | tags Errands, Weekend\n"
+	if buf.String() != want {
+		t.Errorf("Write() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteLLM_Budget(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	day := func(d int) *time.Time {
+		t := now.AddDate(0, 0, d)
+		return &t
+	}
+	db := &Database{
+		ExportedAt: now,
+		Inbox: []domain.Task{
+			{ID: "later", Name: "Someday maybe"},
+			{ID: "overdue", Name: "Pay invoice", DueDate: day(-2)},
+			{ID: "flagged", Name: "Call plumber", Flagged: true},
+			{ID: "deferred", Name: "Renew passport", DeferDate: day(30)},
+		},
+	}
+
+	// Room for the header, the heading, the two most urgent tasks and the
+	// line counting the rest
+	budget := estimateTokens("# OmniFocus open tasks on 2026-03-01\nEach line: id name | due | flagged | tags | note\n") +
+		estimateTokens("\n(000 less urgent tasks left out to fit the token budget)\n") +
+		estimateTokens("\n## Inbox\n") +
+		estimateTokens(llmLine(db.Inbox[1], now)+"\n") +
+		estimateTokens(llmLine(db.Inbox[2], now)+"\n")
+
+	var buf bytes.Buffer
+	if err := WriteLLM(&buf, db, budget); err != nil {
+		t.Fatalf("WriteLLM() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "- overdue Pay invoice | due 2026-02-27 (overdue)\n- flagged Call plumber | flagged\n") {
+		t.Errorf("WriteLLM() should keep the most urgent tasks in outline order, got:\n%s", out)
+	}
+	if strings.Contains(out, "later") || strings.Contains(out, "deferred") {
+		t.Errorf("WriteLLM() should leave out the least urgent tasks, got:\n%s", out)
+	}
+	if !strings.Contains(out, "(2 less urgent tasks left out to fit the token budget)") {
+		t.Errorf("WriteLLM() should count the tasks left out, got:\n%s", out)
+	}
+	if got := estimateTokens(out); got > budget {
+		t.Errorf("WriteLLM() used about %d tokens, over the budget of %d", got, budget)
+	}
+}
@@ -0,0 +1,216 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// llmDate is the date layout of the LLM summary; times are left out
+const llmDate = "2006-01-02"
+
+// llmNoteLength is the number of characters of a note kept in the LLM summary
+const llmNoteLength = 80
+
+// llmTask is an open task with where it is listed and how urgent it is
+type llmTask struct {
+	task    domain.Task
+	section int // Index into the sections: 0 is the inbox, then each project
+	order   int // Outline order across the whole dump
+	rank    int // Lower is more urgent
+	line    string
+}
+
+// WriteLLM writes a compact plain-text summary of the open tasks, meant to
+// be pasted into an AI assistant's context: one line per task with its ID,
+// name, due date, flag, tags and the start of its note, under a heading per
+// project. Completed and dropped projects and completed tasks are left out.
+// A positive budget is an approximate token limit; the most urgent tasks are
+// kept and the rest are counted in a closing line.
+func WriteLLM(w io.Writer, db *Database, budget int) error {
+	now := db.ExportedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	headings := []string{"## Inbox"}
+	var tasks []llmTask
+	seen := make(map[string]bool)
+	add := func(section int, list []domain.Task) {
+		for _, task := range domain.FlattenTasks(list) {
+			if task.Completed || (task.ID != "" && seen[task.ID]) {
+				continue
+			}
+			seen[task.ID] = true
+			tasks = append(tasks, llmTask{
+				task:    task,
+				section: section,
+				order:   len(tasks),
+				rank:    llmRank(task, now),
+				line:    llmLine(task, now),
+			})
+		}
+	}
+
+	add(0, db.Inbox)
+	for _, project := range db.Projects {
+		if project.Status == "completed" || project.Status == "dropped" {
+			continue
+		}
+		add(len(headings), project.Tasks)
+		headings = append(headings, llmHeading(project))
+	}
+
+	header := fmt.Sprintf("# OmniFocus open tasks on %s\nEach line: id name | due | flagged | tags | note\n", now.Local().Format(llmDate))
+	kept := tasks
+	if budget > 0 {
+		kept = selectLLMTasks(tasks, headings, budget-estimateTokens(header))
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	section := -1
+	for _, t := range kept {
+		if t.section != section {
+			section = t.section
+			b.WriteString("\n" + headings[section] + "\n")
+		}
+		b.WriteString(t.line + "\n")
+	}
+	if omitted := len(tasks) - len(kept); omitted > 0 {
+		fmt.Fprintf(&b, "\n(%d less urgent tasks left out to fit the token budget)\n", omitted)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// selectLLMTasks returns the most urgent tasks whose lines, and the headings
+// they need, fit in budget tokens, in outline order
+func selectLLMTasks(tasks []llmTask, headings []string, budget int) []llmTask {
+	byUrgency := make([]llmTask, len(tasks))
+	copy(byUrgency, tasks)
+	sort.SliceStable(byUrgency, func(i, j int) bool {
+		a, b := byUrgency[i], byUrgency[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if a.task.DueDate != nil && b.task.DueDate != nil && !a.task.DueDate.Equal(*b.task.DueDate) {
+			return a.task.DueDate.Before(*b.task.DueDate)
+		}
+		return a.task.DueDate != nil && b.task.DueDate == nil
+	})
+
+	// Leave room for the line counting what was left out
+	remaining := budget - estimateTokens("\n(000 less urgent tasks left out to fit the token budget)\n")
+	used := make(map[int]bool)
+	var kept []llmTask
+	for _, t := range byUrgency {
+		cost := estimateTokens(t.line + "\n")
+		if !used[t.section] {
+			cost += estimateTokens("\n" + headings[t.section] + "\n")
+		}
+		if cost > remaining {
+			break
+		}
+		remaining -= cost
+		used[t.section] = true
+		kept = append(kept, t)
+	}
+
+	sort.Slice(kept, func(i, j int) bool { return kept[i].order < kept[j].order })
+	return kept
+}
+
+// llmRank orders tasks by urgency: overdue, due within a day, flagged, due
+// within a week, available, blocked, then deferred
+func llmRank(task domain.Task, now time.Time) int {
+	due := func(d time.Duration) bool {
+		return task.DueDate != nil && task.DueDate.Before(now.Add(d))
+	}
+	switch {
+	case due(0):
+		return 0
+	case due(24 * time.Hour):
+		return 1
+	case task.Flagged:
+		return 2
+	case due(7 * 24 * time.Hour):
+		return 3
+	}
+	switch task.AvailabilityAt(now) {
+	case domain.Available:
+		return 4
+	case domain.Blocked:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// llmLine formats a task as one line, leaving out empty fields
+func llmLine(task domain.Task, now time.Time) string {
+	parts := []string{fmt.Sprintf("- %s %s", task.ID, oneLine(task.Name))}
+	if task.DueDate != nil {
+		due := "due " + task.DueDate.Local().Format(llmDate)
+		if task.DueDate.Before(now) {
+			due += " (overdue)"
+		}
+		parts = append(parts, due)
+	}
+	if task.DeferDate != nil && task.DeferDate.After(now) {
+		parts = append(parts, "deferred until "+task.DeferDate.Local().Format(llmDate))
+	}
+	if task.Flagged {
+		parts = append(parts, "flagged")
+	}
+	if len(task.Tags) > 0 {
+		parts = append(parts, "tags "+strings.Join(task.Tags, ", "))
+	}
+	if note := truncateNote(task.Note); note != "" {
+		parts = append(parts, "note: "+note)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// llmHeading formats a project heading with its ID and, unless active, its
+// status
+func llmHeading(project domain.Project) string {
+	heading := fmt.Sprintf("## %s [%s]", oneLine(project.Name), project.ID)
+	if project.Status != "" && project.Status != "active" {
+		heading += " " + project.Status
+	}
+	return heading
+}
+
+// truncateNote collapses a note to one line of at most llmNoteLength
+// characters, cut at a word boundary when there is one
+func truncateNote(note string) string {
+	note = oneLine(note)
+	if utf8.RuneCountInString(note) <= llmNoteLength {
+		return note
+	}
+	cut := string([]rune(note)[:llmNoteLength-1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
+
+// oneLine collapses runs of whitespace, including line breaks, to one space
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// estimateTokens approximates the number of tokens of text at four
+// characters per token
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
@@ -0,0 +1,169 @@
+// Package fixtures anonymizes script output recorded from a real OmniFocus
+// database, for the fixtures `lazyfocus dev record-fixtures` writes.
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Kinds of named item, which name their anonymized names, e.g. "Project 3"
+const (
+	kindTask    = "Task"
+	kindProject = "Project"
+	kindTag     = "Tag"
+	kindFolder  = "Folder"
+	kindItem    = "Item"
+)
+
+// noteWords replace the words of notes, in turn
+var noteWords = strings.Fields("lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor")
+
+// Anonymizer replaces the names and notes in script output. The same name
+// gets the same replacement in every output it anonymizes, so a task's
+// project name still matches the project's. IDs, dates and flags are kept.
+type Anonymizer struct {
+	replacements map[string]string // Replacements by kind and real name
+	counts       map[string]int    // Names replaced so far, by kind
+}
+
+// NewAnonymizer creates an Anonymizer
+func NewAnonymizer() *Anonymizer {
+	return &Anonymizer{
+		replacements: make(map[string]string),
+		counts:       make(map[string]int),
+	}
+}
+
+// Anonymize returns the JSON script output with its names and notes
+// replaced, indented for reading in diffs
+func (a *Anonymizer) Anonymize(output []byte) ([]byte, error) {
+	var value any
+	if err := json.Unmarshal(output, &value); err != nil {
+		return nil, fmt.Errorf("failed to parse script output: %w", err)
+	}
+	data, err := json.MarshalIndent(a.value(value, kindItem), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// value anonymizes a JSON value found inside an item of the given kind
+func (a *Anonymizer) value(value any, kind string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		// An item's own name first, then the rest in order, so numbering
+		// follows the output and does not change between recordings
+		keys := slices.Sorted(maps.Keys(v))
+		if i := slices.Index(keys, "name"); i > 0 {
+			keys = append([]string{"name"}, slices.Delete(keys, i, i+1)...)
+		}
+		for _, key := range keys {
+			v[key] = a.field(key, v[key], kind)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = a.value(item, kind)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// field anonymizes the value of key in an object of the given kind
+func (a *Anonymizer) field(key string, value any, kind string) any {
+	switch key {
+	case "name":
+		return a.name(value, kind)
+	case "taskName":
+		return a.name(value, kindTask)
+	case "projectName":
+		return a.name(value, kindProject)
+	case "note":
+		if note, ok := value.(string); ok {
+			return anonymizeNote(note)
+		}
+		return value
+	case "folderPath":
+		return a.names(value, kindFolder)
+	case "tags":
+		// Tasks list tag names; tag listings list tag objects
+		if names, ok := value.([]any); ok && len(names) > 0 {
+			if _, isName := names[0].(string); isName {
+				return a.names(value, kindTag)
+			}
+		}
+		return a.value(value, kindTag)
+	case "children":
+		return a.value(value, kind)
+	default:
+		return a.value(value, kindOf(key, kind))
+	}
+}
+
+// kindOf returns the kind of the items under key, or kind when key does not
+// say
+func kindOf(key, kind string) string {
+	switch key {
+	case "task", "tasks":
+		return kindTask
+	case "project", "projects":
+		return kindProject
+	case "tag":
+		return kindTag
+	case "folder", "folders":
+		return kindFolder
+	default:
+		return kind
+	}
+}
+
+// name returns the replacement of a name, the same for the same name and kind
+func (a *Anonymizer) name(value any, kind string) any {
+	name, ok := value.(string)
+	if !ok || name == "" {
+		return value
+	}
+	key := kind + "\x00" + name
+	if replacement, ok := a.replacements[key]; ok {
+		return replacement
+	}
+	a.counts[kind]++
+	replacement := fmt.Sprintf("%s %d", kind, a.counts[kind])
+	a.replacements[key] = replacement
+	return replacement
+}
+
+// names replaces each name in a list of names
+func (a *Anonymizer) names(value any, kind string) any {
+	list, ok := value.([]any)
+	if !ok {
+		return value
+	}
+	for i, name := range list {
+		list[i] = a.name(name, kind)
+	}
+	return list
+}
+
+// anonymizeNote replaces each word of note with filler, keeping its lines and
+// how many words each has so notes render as they would
+func anonymizeNote(note string) string {
+	lines := strings.Split(note, "\n")
+	next := 0
+	for i, line := range lines {
+		words := strings.Fields(line)
+		for j := range words {
+			words[j] = noteWords[next%len(noteWords)]
+			next++
+		}
+		lines[i] = strings.Join(words, " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,63 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAnonymizer_ReplacesNamesConsistently(t *testing.T) {
+	a := NewAnonymizer()
+
+	projects, err := a.Anonymize([]byte(`{"projects": [{"id": "p1", "name": "Renovate kitchen", "note": "Call Anna\nBudget 5k"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks, err := a.Anonymize([]byte(`{"tasks": [{"id": "t1", "name": "Buy tiles", "projectName": "Renovate kitchen",
+		"folderPath": ["Home"], "tags": ["errands"], "dueDate": "2024-01-15T17:00:00Z",
+		"children": [{"id": "t2", "name": "Measure floor"}]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, private := range []string{"Renovate", "Anna", "tiles", "Home", "errands", "Measure"} {
+		if strings.Contains(string(projects)+string(tasks), private) {
+			t.Errorf("fixtures still contain %q:\n%s\n%s", private, projects, tasks)
+		}
+	}
+
+	var got struct {
+		Projects []struct{ ID, Name, Note string }
+		Tasks    []struct {
+			ID, Name, ProjectName, DueDate string
+			FolderPath, Tags               []string
+			Children                       []struct{ Name string }
+		}
+	}
+	if err := json.Unmarshal(projects, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(tasks, &got); err != nil {
+		t.Fatal(err)
+	}
+	project, task := got.Projects[0], got.Tasks[0]
+	if project.ID != "p1" || project.Name != "Project 1" || project.Note != "lorem ipsum\ndolor sit" {
+		t.Errorf("project = %+v, want its ID kept and name and note replaced line by line", project)
+	}
+	if task.Name != "Task 1" || task.ProjectName != "Project 1" || task.Children[0].Name != "Task 2" {
+		t.Errorf("task = %+v, want task names and the same project name", task)
+	}
+	if task.FolderPath[0] != "Folder 1" || task.Tags[0] != "Tag 1" || task.DueDate != "2024-01-15T17:00:00Z" {
+		t.Errorf("task = %+v, want folders and tags replaced and dates kept", task)
+	}
+}
+
+func TestAnonymizer_TagObjects(t *testing.T) {
+	output, err := NewAnonymizer().Anonymize([]byte(`{"tags": [{"id": "g1", "name": "Work", "children": [{"id": "g2", "name": "Calls"}]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(output), `"Tag 1"`) || !strings.Contains(string(output), `"Tag 2"`) || strings.Contains(string(output), "Calls") {
+		t.Errorf("Anonymize() = %s, want tag names replaced", output)
+	}
+}
@@ -0,0 +1,157 @@
+// Package gitinfo reads release information from a git repository.
+package gitinfo
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Template variables filled from the repository by Info.Vars
+const (
+	VarVersion         = "Version"
+	VarNextVersion     = "NextVersion"
+	VarChangedPackages = "ChangedPackages"
+	VarCommitCount     = "CommitCount"
+	VarBranch          = "Branch"
+)
+
+var semverPattern = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)$`)
+
+// Runner runs git with the given arguments and returns its trimmed output
+type Runner func(args ...string) (string, error)
+
+// Info describes the state of a repository since its latest release tag
+type Info struct {
+	Version         string   // Latest tag, empty when the repository has none
+	NextVersion     string   // Version with the patch number bumped
+	ChangedPackages []string // Directories with changes since Version
+	CommitCount     int      // Commits since Version
+	Branch          string
+}
+
+// Command returns a Runner that executes git in dir
+func Command(dir string) Runner {
+	return func(args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+				return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+			}
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+}
+
+// Read collects release information using run
+func Read(run Runner) (*Info, error) {
+	if _, err := run("rev-parse", "--is-inside-work-tree"); err != nil {
+		return nil, fmt.Errorf("not a git repository: %w", err)
+	}
+
+	info := &Info{}
+
+	branch, err := run("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read branch: %w", err)
+	}
+	info.Branch = branch
+
+	// A repository without tags has no previous release; everything is new
+	if tag, err := run("describe", "--tags", "--abbrev=0"); err == nil {
+		info.Version = tag
+	}
+	info.NextVersion = NextPatch(info.Version)
+
+	revRange := "HEAD"
+	if info.Version != "" {
+		revRange = info.Version + "..HEAD"
+	}
+
+	count, err := run("rev-list", "--count", revRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count commits: %w", err)
+	}
+	if info.CommitCount, err = strconv.Atoi(count); err != nil {
+		return nil, fmt.Errorf("failed to count commits: unexpected output %q", count)
+	}
+
+	var files string
+	if info.Version != "" {
+		files, err = run("diff", "--name-only", revRange)
+	} else {
+		files, err = run("ls-files")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+	info.ChangedPackages = packagesOf(files)
+
+	return info, nil
+}
+
+// Vars returns the repository information as template variable values
+func (i *Info) Vars() map[string]string {
+	return map[string]string{
+		VarVersion:         i.Version,
+		VarNextVersion:     i.NextVersion,
+		VarChangedPackages: strings.Join(i.ChangedPackages, ", "),
+		VarCommitCount:     strconv.Itoa(i.CommitCount),
+		VarBranch:          i.Branch,
+	}
+}
+
+// NextPatch bumps the patch number of a semantic version tag, keeping a
+// leading "v". Versions that are not semantic yield "v0.1.0" when empty and
+// are returned unchanged otherwise.
+func NextPatch(version string) string {
+	if version == "" {
+		return "v0.1.0"
+	}
+	m := semverPattern.FindStringSubmatch(version)
+	if m == nil {
+		return version
+	}
+	patch, _ := strconv.Atoi(m[4])
+	return fmt.Sprintf("%s%s.%s.%d", m[1], m[2], m[3], patch+1)
+}
+
+// packagesOf returns the sorted, unique directories of the Go files in a
+// newline-separated file list, falling back to all directories when no Go
+// files changed
+func packagesOf(files string) []string {
+	goDirs := make(map[string]bool)
+	allDirs := make(map[string]bool)
+	for _, file := range strings.Split(files, "\n") {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+		dir := path.Dir(file)
+		allDirs[dir] = true
+		if strings.HasSuffix(file, ".go") {
+			goDirs[dir] = true
+		}
+	}
+
+	dirs := goDirs
+	if len(dirs) == 0 {
+		dirs = allDirs
+	}
+
+	result := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		result = append(result, dir)
+	}
+	sort.Strings(result)
+	return result
+}
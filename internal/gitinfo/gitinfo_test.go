@@ -0,0 +1,92 @@
+package gitinfo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeGit returns canned output keyed by the joined git arguments
+func fakeGit(outputs map[string]string) Runner {
+	return func(args ...string) (string, error) {
+		out, ok := outputs[strings.Join(args, " ")]
+		if !ok {
+			return "", errors.New("exit status 128")
+		}
+		return out, nil
+	}
+}
+
+func TestRead_SinceLatestTag(t *testing.T) {
+	run := fakeGit(map[string]string{
+		"rev-parse --is-inside-work-tree": "true",
+		"rev-parse --abbrev-ref HEAD":     "main",
+		"describe --tags --abbrev=0":      "v1.4.2",
+		"rev-list --count v1.4.2..HEAD":   "7",
+		"diff --name-only v1.4.2..HEAD":   "internal/cli/add.go\ninternal/cli/add_test.go\ninternal/tui/keys.go\nREADME.md",
+	})
+
+	info, err := Read(run)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if info.Version != "v1.4.2" || info.NextVersion != "v1.4.3" {
+		t.Errorf("Read() versions = %s, %s, want v1.4.2, v1.4.3", info.Version, info.NextVersion)
+	}
+	if info.CommitCount != 7 || info.Branch != "main" {
+		t.Errorf("Read() = %+v, want 7 commits on main", info)
+	}
+	if got := strings.Join(info.ChangedPackages, ","); got != "internal/cli,internal/tui" {
+		t.Errorf("Read() ChangedPackages = %s, want internal/cli,internal/tui", got)
+	}
+	if vars := info.Vars(); vars[VarChangedPackages] != "internal/cli, internal/tui" || vars[VarCommitCount] != "7" {
+		t.Errorf("Vars() = %v", vars)
+	}
+}
+
+func TestRead_WithoutTags(t *testing.T) {
+	run := fakeGit(map[string]string{
+		"rev-parse --is-inside-work-tree": "true",
+		"rev-parse --abbrev-ref HEAD":     "main",
+		"rev-list --count HEAD":           "2",
+		"ls-files":                        "docs/index.md\nREADME.md",
+	})
+
+	info, err := Read(run)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if info.Version != "" || info.NextVersion != "v0.1.0" {
+		t.Errorf("Read() versions = %q, %q, want empty and v0.1.0", info.Version, info.NextVersion)
+	}
+	if got := strings.Join(info.ChangedPackages, ","); got != ".,docs" {
+		t.Errorf("Read() ChangedPackages = %s, want all directories when no Go files changed", got)
+	}
+}
+
+func TestRead_NotARepository(t *testing.T) {
+	_, err := Read(fakeGit(nil))
+	if err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Errorf("Read() error = %v, want not a git repository", err)
+	}
+}
+
+func TestNextPatch(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"v1.2.3", "v1.2.4"},
+		{"0.9.9", "0.9.10"},
+		{"", "v0.1.0"},
+		{"release-2024", "release-2024"},
+	}
+
+	for _, tt := range tests {
+		if got := NextPatch(tt.version); got != tt.want {
+			t.Errorf("NextPatch(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Package health checks whether lazyfocus can work with OmniFocus: the checks
+// behind `lazyfocus doctor` and the banner the TUI shows when something is
+// wrong at startup.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+)
+
+// JournalPattern matches the journals of writes the TUI hands to a
+// background flush when it quits with operations pending
+const JournalPattern = "lazyfocus-pending-*.json"
+
+// journalGrace is how old a journal must be before it counts as left
+// behind; younger ones may still be being flushed
+const journalGrace = time.Minute
+
+// Check is the result of one health check, and its JSON shape
+type Check struct {
+	Name     string `json:"name"`
+	OK       bool   `json:"ok"`
+	Detail   string `json:"detail"`
+	Fix      string `json:"fix,omitempty"`
+	Degraded string `json:"-"` // What does not work while the check fails, for the TUI banner
+}
+
+// Checker runs the health checks; tests replace its functions
+type Checker struct {
+	LookPath        func(file string) (string, error)
+	CheckAutomation func(ctx context.Context) error
+	Journals        func() ([]string, error) // Journals of pending writes left behind
+}
+
+// NewChecker returns a Checker asking the system
+func NewChecker() Checker {
+	return Checker{
+		LookPath:        exec.LookPath,
+		CheckAutomation: bridge.CheckAutomationPermission,
+		Journals:        leftJournals,
+	}
+}
+
+// Snapshot is the outcome of a health check run
+type Snapshot struct {
+	Checks []Check
+	At     time.Time
+}
+
+// Run runs the checks in order, skipping those that cannot run after an
+// earlier one failed, and then looks for pending writes left behind
+func (c Checker) Run(ctx context.Context) Snapshot {
+	s := Snapshot{Checks: c.omniFocusChecks(ctx), At: time.Now()}
+	if c.Journals != nil {
+		s.Checks = append(s.Checks, journalChecks(c.Journals)...)
+	}
+	return s
+}
+
+// omniFocusChecks checks osascript, OmniFocus and the Automation permission
+func (c Checker) omniFocusChecks(ctx context.Context) []Check {
+	path, err := c.LookPath("osascript")
+	if err != nil {
+		return []Check{{
+			Name:     "osascript",
+			Detail:   "not found",
+			Fix:      "lazyfocus runs OmniFocus scripts with osascript, which comes with macOS. Run it on a Mac.",
+			Degraded: "osascript not found — OmniFocus cannot be reached from here",
+		}}
+	}
+	checks := []Check{{Name: "osascript", OK: true, Detail: path}}
+
+	err = c.CheckAutomation(ctx)
+	switch {
+	case err == nil:
+		return append(checks,
+			Check{Name: "OmniFocus", OK: true, Detail: "running"},
+			Check{Name: "Automation permission", OK: true, Detail: "granted"})
+	case errors.Is(err, bridge.ErrOmniFocusNotRunning):
+		return append(checks, Check{
+			Name:     "OmniFocus",
+			Detail:   "not running",
+			Fix:      "Start OmniFocus and run lazyfocus doctor again to check the Automation permission.",
+			Degraded: "OmniFocus is not running — lists stay empty until it starts",
+		})
+	case bridge.IsPermissionDenied(err):
+		return append(checks,
+			Check{Name: "OmniFocus", OK: true, Detail: "running"},
+			Check{
+				Name:     "Automation permission",
+				Detail:   "denied",
+				Fix:      bridge.AutomationPermissionFix,
+				Degraded: "Automation not allowed — tasks cannot be read or changed",
+			})
+	default:
+		return append(checks, Check{
+			Name:     "OmniFocus",
+			Detail:   err.Error(),
+			Degraded: "OmniFocus is not answering — " + err.Error(),
+		})
+	}
+}
+
+// journalChecks reports each journal of pending writes left behind, whose
+// changes never reached OmniFocus
+func journalChecks(journals func() ([]string, error)) []Check {
+	paths, err := journals()
+	if err != nil {
+		return []Check{{Name: "Pending writes", Detail: err.Error()}}
+	}
+	var checks []Check
+	for _, path := range paths {
+		detail := "unsaved changes in " + path
+		if writes, err := service.ReadJournal(path); err == nil {
+			detail = fmt.Sprintf("%d unsaved %s in %s", len(writes), changesWord(len(writes)), path)
+		}
+		checks = append(checks, Check{
+			Name:     "Pending writes",
+			Detail:   detail,
+			Fix:      fmt.Sprintf("An earlier session quit before these changes reached OmniFocus. Run lazyfocus flush %s to apply them.", path),
+			Degraded: "changes from an earlier session were not saved",
+		})
+	}
+	return checks
+}
+
+// changesWord returns "change" or "changes" to follow count
+func changesWord(count int) string {
+	if count == 1 {
+		return "change"
+	}
+	return "changes"
+}
+
+// leftJournals returns the journals in the temporary directory old enough
+// that their background flush has ended without removing them
+func leftJournals() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(os.TempDir(), JournalPattern))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look for pending writes: %w", err)
+	}
+	var left []string
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > journalGrace {
+			left = append(left, path)
+		}
+	}
+	return left, nil
+}
+
+// Failed returns the checks that did not pass
+func (s Snapshot) Failed() []Check {
+	var failed []Check
+	for _, check := range s.Checks {
+		if !check.OK {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// Banner describes what does not work, e.g. "OmniFocus is not running —
+// lists stay empty until it starts (checked 09:12; run lazyfocus doctor)",
+// or is "" when every check passed
+func (s Snapshot) Banner() string {
+	var degraded []string
+	for _, check := range s.Failed() {
+		text := check.Degraded
+		if text == "" {
+			text = check.Name + ": " + check.Detail
+		}
+		if !slices.Contains(degraded, text) {
+			degraded = append(degraded, text)
+		}
+	}
+	if len(degraded) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s (checked %s; run lazyfocus doctor)", strings.Join(degraded, "; "), s.At.Format("15:04"))
+}
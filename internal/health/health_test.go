@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/bridge"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+)
+
+// checker returns a Checker finding osascript unless pathErr is set, with
+// automationErr from the permission check and the given journals
+func checker(pathErr, automationErr error, journals ...string) Checker {
+	return Checker{
+		LookPath:        func(string) (string, error) { return "/usr/bin/osascript", pathErr },
+		CheckAutomation: func(context.Context) error { return automationErr },
+		Journals:        func() ([]string, error) { return journals, nil },
+	}
+}
+
+func TestRun(t *testing.T) {
+	denied := fmt.Errorf("%w: %s", bridge.ErrAutomationNotPermitted, "(-1743)")
+	tests := []struct {
+		name    string
+		checker Checker
+		want    []string // Names of the checks, failed ones marked with !
+	}{
+		{"all pass", checker(nil, nil), []string{"osascript", "OmniFocus", "Automation permission"}},
+		{"no osascript", checker(exec.ErrNotFound, errors.New("should not run")), []string{"!osascript"}},
+		{"not running", checker(nil, bridge.ErrOmniFocusNotRunning), []string{"osascript", "!OmniFocus"}},
+		{"denied", checker(nil, denied), []string{"osascript", "OmniFocus", "!Automation permission"}},
+		{"other error", checker(nil, errors.New("timeout")), []string{"osascript", "!OmniFocus"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, check := range tt.checker.Run(context.Background()).Checks {
+				name := check.Name
+				if !check.OK {
+					name = "!" + name
+				}
+				got = append(got, name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("checks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_ReportsJournalsLeftBehind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazyfocus-pending-1.json")
+	writes := []service.PendingWrite{{Method: "CompleteTask", ID: "a"}, {Method: "FlagTask", ID: "b"}}
+	if err := service.WriteJournal(path, writes); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := checker(nil, nil, path).Run(context.Background())
+	failed := snapshot.Failed()
+	if len(failed) != 1 {
+		t.Fatalf("failed checks = %+v, want the pending writes", failed)
+	}
+	if want := "2 unsaved changes in " + path; failed[0].Detail != want {
+		t.Errorf("detail = %q, want %q", failed[0].Detail, want)
+	}
+	if !strings.Contains(failed[0].Fix, "lazyfocus flush "+path) {
+		t.Errorf("fix = %q, want how to flush the journal", failed[0].Fix)
+	}
+}
+
+func TestSnapshot_Banner(t *testing.T) {
+	at := time.Date(2024, 1, 15, 9, 12, 0, 0, time.Local)
+
+	if banner := (Snapshot{Checks: checker(nil, nil).Run(context.Background()).Checks, At: at}).Banner(); banner != "" {
+		t.Errorf("healthy banner = %q, want none", banner)
+	}
+
+	snapshot := Snapshot{At: at, Checks: []Check{
+		{Name: "osascript", OK: true},
+		{Name: "OmniFocus", Detail: "not running", Degraded: "OmniFocus is not running"},
+		{Name: "Pending writes", Detail: "1 unsaved change", Degraded: "changes were not saved"},
+		{Name: "Pending writes", Detail: "3 unsaved changes", Degraded: "changes were not saved"},
+		{Name: "Pending writes", Detail: "glob failed"},
+	}}
+	want := "OmniFocus is not running; changes were not saved; Pending writes: glob failed (checked 09:12; run lazyfocus doctor)"
+	if banner := snapshot.Banner(); banner != want {
+		t.Errorf("Banner() = %q, want %q", banner, want)
+	}
+}
@@ -0,0 +1,454 @@
+// Package importer reads TaskPaper outlines and Markdown checklists and
+// creates their projects and tasks in OmniFocus.
+package importer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/export"
+)
+
+// Import formats
+const (
+	FormatTaskPaper = export.FormatTaskPaper
+	FormatMarkdown  = "markdown"
+)
+
+// inboxName is the project name that stands for the inbox
+const inboxName = "Inbox"
+
+// tabWidth is the number of columns a tab indents by
+const tabWidth = 4
+
+var (
+	// attributePattern matches TaskPaper @tag and @tag(value) attributes
+	attributePattern = regexp.MustCompile(`(?:^|\s)@([\w-]+)(?:\(([^)]*)\))?`)
+	// checkboxPattern matches Markdown checklist items: "- [ ] name" or "* [x] name"
+	checkboxPattern = regexp.MustCompile(`^[-*+]\s+\[([ xX])\]\s*(.*)$`)
+	// headingPattern matches Markdown headings
+	headingPattern = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*$`)
+)
+
+// Target is the part of the OmniFocus service an import writes to
+type Target interface {
+	CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error)
+	CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error)
+	ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error)
+}
+
+// Progress reports how many tasks have been created
+type Progress interface {
+	Start(label string, total int)
+	Increment()
+	Finish()
+}
+
+// Result counts what an import created and left out
+type Result struct {
+	Projects   int `json:"projects"`
+	Tasks      int `json:"tasks"`
+	Skipped    int `json:"skipped"`    // Completed or dropped projects and tasks
+	Merged     int `json:"merged"`     // Existing tasks updated from matching incoming ones
+	Duplicates int `json:"duplicates"` // Incoming tasks left out for matching existing ones
+}
+
+// DetectFormat guesses the format of the named file from its extension,
+// falling back to looking for checklist items in its content
+func DetectFormat(name string, content []byte) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown":
+		return FormatMarkdown
+	case ".taskpaper":
+		return FormatTaskPaper
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if checkboxPattern.MatchString(strings.TrimSpace(line)) {
+			return FormatMarkdown
+		}
+	}
+	return FormatTaskPaper
+}
+
+// Parse reads an outline in the given format. Tasks outside any project go
+// to the inbox, as do tasks under a project named "Inbox".
+func Parse(r io.Reader, format string, now time.Time) (*export.Database, error) {
+	switch strings.ToLower(format) {
+	case FormatTaskPaper:
+		return parseOutline(r, parseTaskPaperLine, now)
+	case FormatMarkdown, "md":
+		return parseOutline(r, parseMarkdownLine, now)
+	default:
+		return nil, fmt.Errorf("unknown import format %q: use %s or %s", format, FormatTaskPaper, FormatMarkdown)
+	}
+}
+
+// lineKind is what an outline line holds
+type lineKind int
+
+const (
+	lineNote lineKind = iota
+	lineProject
+	lineTask
+)
+
+// line is one parsed outline line
+type line struct {
+	kind lineKind
+	text string // Project or task name with attributes, or note text
+	done bool   // Markdown checkbox is ticked
+}
+
+// parseTaskPaperLine classifies a trimmed TaskPaper line
+func parseTaskPaperLine(text string) line {
+	if name, ok := strings.CutPrefix(text, "- "); ok {
+		return line{kind: lineTask, text: name}
+	}
+	if name, _ := splitAttributes(text); strings.HasSuffix(name, ":") {
+		return line{kind: lineProject, text: text}
+	}
+	return line{kind: lineNote, text: text}
+}
+
+// parseMarkdownLine classifies a trimmed Markdown line
+func parseMarkdownLine(text string) line {
+	if m := checkboxPattern.FindStringSubmatch(text); m != nil {
+		return line{kind: lineTask, text: m[2], done: m[1] != " "}
+	}
+	if m := headingPattern.FindStringSubmatch(text); m != nil {
+		return line{kind: lineProject, text: m[1]}
+	}
+	return line{kind: lineNote, text: text}
+}
+
+// openTask is a task whose subtasks may still follow
+type openTask struct {
+	indent int
+	task   *domain.Task
+}
+
+// parseOutline builds the projects and tasks of an outline, nesting tasks by
+// indentation. Other lines become notes of the item above them.
+func parseOutline(r io.Reader, classify func(string) line, now time.Time) (*export.Database, error) {
+	db := &export.Database{}
+
+	inbox := &domain.Project{Name: inboxName} // Holds inbox tasks until the end
+	project := inbox
+	var stack []openTask
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		raw := scanner.Text()
+		text := strings.TrimSpace(raw)
+		if text == "" {
+			continue
+		}
+		indent := indentWidth(raw)
+
+		l := classify(text)
+		if l.kind == lineProject && indent > 0 {
+			// Indented projects are groups of tasks
+			name, _ := splitAttributes(l.text)
+			l = line{kind: lineTask, text: strings.Replace(l.text, name, strings.TrimSuffix(name, ":"), 1)}
+		}
+
+		switch l.kind {
+		case lineProject:
+			name, attrs := splitAttributes(l.text)
+			name = strings.TrimSuffix(name, ":")
+			if strings.EqualFold(name, inboxName) {
+				project = inbox
+			} else {
+				db.Projects = append(db.Projects, domain.Project{Name: name, Status: projectStatus(attrs)})
+				project = &db.Projects[len(db.Projects)-1]
+			}
+			stack = nil
+
+		case lineTask:
+			task, err := parseTask(l, now)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			var added *domain.Task
+			if len(stack) == 0 {
+				project.Tasks = append(project.Tasks, task)
+				added = &project.Tasks[len(project.Tasks)-1]
+			} else {
+				parent := stack[len(stack)-1].task
+				parent.Children = append(parent.Children, task)
+				added = &parent.Children[len(parent.Children)-1]
+			}
+			stack = append(stack, openTask{indent: indent, task: added})
+
+		case lineNote:
+			// Notes belong to the innermost task indented less than them
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) > 0 {
+				appendNote(&stack[len(stack)-1].task.Note, l.text)
+			} else if project != inbox {
+				appendNote(&project.Note, l.text)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read import: %w", err)
+	}
+
+	db.Inbox = inbox.Tasks
+	return db, nil
+}
+
+// parseTask builds a task from a task line and its attributes
+func parseTask(l line, now time.Time) (domain.Task, error) {
+	name, attrs := splitAttributes(l.text)
+	if name == "" {
+		return domain.Task{}, fmt.Errorf("task name is required")
+	}
+
+	task := domain.Task{Name: name, Completed: l.done}
+	for _, attr := range attrs {
+		switch attr.name {
+		case "flagged":
+			task.Flagged = true
+		case "done":
+			task.Completed = true
+		case "due", "defer", "start":
+			if attr.value == "" {
+				return task, fmt.Errorf("@%s needs a date", attr.name)
+			}
+			date, err := parseDate(attr.value, now)
+			if err != nil {
+				return task, fmt.Errorf("invalid @%s: %w", attr.name, err)
+			}
+			if attr.name == "due" {
+				task.DueDate = &date
+			} else {
+				task.DeferDate = &date
+			}
+		case "tags":
+			for _, tag := range strings.Split(attr.value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					task.Tags = append(task.Tags, tag)
+				}
+			}
+		default:
+			// Any other TaskPaper tag is an OmniFocus tag
+			task.Tags = append(task.Tags, attr.name)
+		}
+	}
+	return task, nil
+}
+
+// attribute is a parsed @name(value)
+type attribute struct {
+	name  string
+	value string
+}
+
+// splitAttributes separates a line's name from its @attributes
+func splitAttributes(text string) (string, []attribute) {
+	var attrs []attribute
+	for _, m := range attributePattern.FindAllStringSubmatch(text, -1) {
+		attrs = append(attrs, attribute{name: strings.ToLower(m[1]), value: strings.TrimSpace(m[2])})
+	}
+	name := attributePattern.ReplaceAllString(text, "")
+	return strings.TrimSpace(name), attrs
+}
+
+// projectStatus returns the status set by a project's @status or @done
+func projectStatus(attrs []attribute) string {
+	for _, attr := range attrs {
+		switch attr.name {
+		case "status":
+			return attr.value
+		case "done":
+			return "completed"
+		}
+	}
+	return "active"
+}
+
+// parseDate accepts the dates export writes as well as natural language dates
+func parseDate(value string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04:05Z07:00"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return dateparse.ParseWithReference(value, now)
+}
+
+// indentWidth returns the number of columns a line is indented by
+func indentWidth(raw string) int {
+	width := 0
+	for _, r := range raw {
+		switch r {
+		case '\t':
+			width += tabWidth
+		case ' ':
+			width++
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// appendNote adds a line to a note
+func appendNote(note *string, text string) {
+	if *note != "" {
+		*note += "\n"
+	}
+	*note += text
+}
+
+// Apply creates the projects and tasks of a parsed import. Completed tasks
+// and projects that are not active are left out, and tasks matching one of
+// dups.Existing are resolved by dups.Resolve. It stops at the first failure,
+// returning what was created so far.
+func Apply(ctx context.Context, db *export.Database, target Target, progress Progress, dups Duplicates) (Result, error) {
+	var result Result
+	a := &applier{target: target, progress: progress, result: &result, existing: dups.index(), resolve: dups.Resolve}
+
+	total := countOpen(db.Inbox)
+	for _, project := range db.Projects {
+		if project.Status == "" || project.Status == "active" {
+			total += countOpen(project.Tasks)
+		}
+	}
+	progress.Start("Importing tasks", total)
+	defer progress.Finish()
+
+	if err := a.createTasks(ctx, db.Inbox, "", ""); err != nil {
+		return result, err
+	}
+
+	for _, project := range db.Projects {
+		if project.Status != "" && project.Status != "active" {
+			result.Skipped += 1 + len(domain.FlattenTasks(project.Tasks))
+			continue
+		}
+
+		created, err := target.CreateProject(ctx, domain.ProjectInput{Name: project.Name, Note: project.Note})
+		if err != nil {
+			return result, fmt.Errorf("failed to create project %s: %w", project.Name, err)
+		}
+		result.Projects++
+
+		if err := a.createTasks(ctx, project.Tasks, created.ID, ""); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// applier creates the tasks of an import
+type applier struct {
+	target   Target
+	progress Progress
+	result   *Result
+	existing map[string]domain.Task // Existing tasks by match key
+	resolve  Resolver
+}
+
+// createTasks creates tasks and their subtasks in a project or under a parent task
+func (a *applier) createTasks(ctx context.Context, tasks []domain.Task, projectID, parentID string) error {
+	for _, task := range tasks {
+		if task.Completed {
+			a.result.Skipped += len(domain.FlattenTasks([]domain.Task{task}))
+			continue
+		}
+
+		resolved, err := a.resolveDuplicate(ctx, task)
+		if err != nil {
+			return err
+		}
+		if resolved {
+			continue
+		}
+
+		input := domain.TaskInput{
+			Name:      task.Name,
+			Note:      task.Note,
+			ProjectID: projectID,
+			ParentID:  parentID,
+			TagNames:  task.Tags,
+			DueDate:   task.DueDate,
+			DeferDate: task.DeferDate,
+		}
+		if task.Flagged {
+			flagged := true
+			input.Flagged = &flagged
+		}
+
+		created, err := a.target.CreateTask(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to create task %s: %w", task.Name, err)
+		}
+		a.result.Tasks++
+		a.progress.Increment()
+
+		if err := a.createTasks(ctx, task.Children, projectID, created.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveDuplicate asks what to do with task when it matches an existing
+// task, and reports whether that left nothing to create. Subtasks of a
+// skipped or merged task are imported under the existing task.
+func (a *applier) resolveDuplicate(ctx context.Context, task domain.Task) (bool, error) {
+	existing, ok := a.existing[matchKey(task.Name)]
+	if !ok {
+		return false, nil
+	}
+	collision := Collision{Incoming: task, Existing: existing}
+	resolution, err := a.resolve(collision)
+	if err != nil {
+		return false, err
+	}
+
+	switch resolution.Action {
+	case ActionSkip:
+		a.result.Duplicates++
+	case ActionMerge:
+		if mod := Modification(existing, resolution.Merged); !mod.IsEmpty() {
+			if _, err := a.target.ModifyTask(ctx, existing.ID, mod); err != nil {
+				return false, fmt.Errorf("failed to merge task %s: %w", task.Name, err)
+			}
+		}
+		a.result.Merged++
+	default:
+		return false, nil
+	}
+	a.progress.Increment()
+
+	return true, a.createTasks(ctx, task.Children, existing.ProjectID, existing.ID)
+}
+
+// countOpen returns the number of tasks Apply would create
+func countOpen(tasks []domain.Task) int {
+	count := 0
+	for _, task := range tasks {
+		if !task.Completed {
+			count += 1 + countOpen(task.Children)
+		}
+	}
+	return count
+}
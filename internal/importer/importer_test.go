@@ -0,0 +1,267 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/export"
+)
+
+var testNow = time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+
+// recordingTarget records created projects and tasks, giving each an ID
+// from its name, and modified tasks
+type recordingTarget struct {
+	projects []domain.ProjectInput
+	tasks    []domain.TaskInput
+	modified map[string]domain.TaskModification
+	failOn   string
+}
+
+func (r *recordingTarget) CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error) {
+	r.projects = append(r.projects, input)
+	return &domain.Project{ID: "p" + input.Name, Name: input.Name}, nil
+}
+
+func (r *recordingTarget) CreateTask(ctx context.Context, input domain.TaskInput) (*domain.Task, error) {
+	if input.Name == r.failOn {
+		return nil, errors.New("OmniFocus is not running")
+	}
+	r.tasks = append(r.tasks, input)
+	return &domain.Task{ID: "t" + input.Name, Name: input.Name}, nil
+}
+
+func (r *recordingTarget) ModifyTask(ctx context.Context, id string, mod domain.TaskModification) (*domain.Task, error) {
+	if r.modified == nil {
+		r.modified = make(map[string]domain.TaskModification)
+	}
+	r.modified[id] = mod
+	return &domain.Task{ID: id}, nil
+}
+
+type nopProgress struct{}
+
+func (nopProgress) Start(string, int) {}
+func (nopProgress) Increment()        {}
+func (nopProgress) Finish()           {}
+
+func TestParse_TaskPaper(t *testing.T) {
+	input := "Call bank\n" +
+		"- Buy milk @flagged\n" +
+		"Home:\n" +
+		"\tWeekend chores\n" +
+		"\t- Paint fence @due(2026-10-20 17:00) @tags(Errands, Outside)\n" +
+		"\t\tUse the green paint\n" +
+		"\t\t- Buy paint @defer(2026-10-18)\n" +
+		"\t- Mow lawn @done(2026-10-01 10:00)\n" +
+		"Old: @status(dropped)\n" +
+		"\t- Forgotten\n"
+
+	db, err := Parse(strings.NewReader(input), FormatTaskPaper, testNow)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(db.Inbox) != 1 || db.Inbox[0].Name != "Buy milk" || !db.Inbox[0].Flagged {
+		t.Errorf("Parse() inbox = %+v, want flagged Buy milk", db.Inbox)
+	}
+	if len(db.Projects) != 2 {
+		t.Fatalf("Parse() = %d projects, want 2", len(db.Projects))
+	}
+
+	home := db.Projects[0]
+	if home.Name != "Home" || home.Status != "active" || home.Note != "Weekend chores" {
+		t.Errorf("Parse() project = %+v, want active Home with note", home)
+	}
+	if len(home.Tasks) != 2 {
+		t.Fatalf("Parse() Home tasks = %d, want 2", len(home.Tasks))
+	}
+	fence := home.Tasks[0]
+	if fence.Name != "Paint fence" || fence.Note != "Use the green paint" {
+		t.Errorf("Parse() task = %+v, want Paint fence with note", fence)
+	}
+	if fence.DueDate == nil || fence.DueDate.Format("2006-01-02 15:04") != "2026-10-20 17:00" {
+		t.Errorf("Parse() due = %v, want 2026-10-20 17:00", fence.DueDate)
+	}
+	if strings.Join(fence.Tags, ",") != "Errands,Outside" {
+		t.Errorf("Parse() tags = %v, want [Errands Outside]", fence.Tags)
+	}
+	if len(fence.Children) != 1 || fence.Children[0].Name != "Buy paint" || fence.Children[0].DeferDate == nil {
+		t.Errorf("Parse() subtasks = %+v, want deferred Buy paint", fence.Children)
+	}
+	if !home.Tasks[1].Completed {
+		t.Errorf("Parse() Mow lawn completed = false, want true")
+	}
+	if db.Projects[1].Status != "dropped" {
+		t.Errorf("Parse() Old status = %q, want dropped", db.Projects[1].Status)
+	}
+}
+
+func TestParse_Markdown(t *testing.T) {
+	input := "- [ ] Call bank\n" +
+		"# Release 1.2\n" +
+		"Ship the next version.\n" +
+		"- [ ] Update changelog\n" +
+		"  - [x] Collect PR titles\n" +
+		"  - [ ] Write summary @due(tomorrow)\n" +
+		"    Keep it short\n" +
+		"- [X] Bump version\n"
+
+	db, err := Parse(strings.NewReader(input), FormatMarkdown, testNow)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(db.Inbox) != 1 || db.Inbox[0].Name != "Call bank" {
+		t.Errorf("Parse() inbox = %+v, want Call bank", db.Inbox)
+	}
+	if len(db.Projects) != 1 || db.Projects[0].Name != "Release 1.2" || db.Projects[0].Note != "Ship the next version." {
+		t.Fatalf("Parse() projects = %+v, want Release 1.2 with note", db.Projects)
+	}
+
+	tasks := db.Projects[0].Tasks
+	if len(tasks) != 2 || !tasks[1].Completed {
+		t.Fatalf("Parse() tasks = %+v, want 2 with Bump version done", tasks)
+	}
+	children := tasks[0].Children
+	if len(children) != 2 || !children[0].Completed || children[1].Completed {
+		t.Fatalf("Parse() subtasks = %+v, want first done and second open", children)
+	}
+	if children[1].DueDate == nil || children[1].DueDate.Day() != 17 || children[1].Note != "Keep it short" {
+		t.Errorf("Parse() subtask = %+v, want due tomorrow with note", children[1])
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		format string
+		want   string
+	}{
+		{name: "unknown format", input: "", format: "opml", want: "unknown import format"},
+		{name: "bad date", input: "- Pay rent @due(someday)", format: FormatTaskPaper, want: "line 1: invalid @due"},
+		{name: "missing date", input: "- Pay rent @due", format: FormatTaskPaper, want: "@due needs a date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input), tt.format, testNow)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_ExportRoundTrip(t *testing.T) {
+	due := time.Date(2026, 10, 20, 17, 0, 0, 0, time.Local)
+	original := &export.Database{
+		Inbox: []domain.Task{{Name: "Call bank", Flagged: true}},
+		Projects: []domain.Project{{
+			Name:   "Home",
+			Status: "active",
+			Tasks: []domain.Task{{
+				Name:     "Paint fence",
+				DueDate:  &due,
+				Tags:     []string{"Errands"},
+				Note:     "Green",
+				Children: []domain.Task{{Name: "Buy paint"}},
+			}},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := export.WriteTaskPaper(&buf, original); err != nil {
+		t.Fatalf("WriteTaskPaper() error = %v", err)
+	}
+
+	db, err := Parse(&buf, FormatTaskPaper, testNow)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var again bytes.Buffer
+	if err := export.WriteTaskPaper(&again, db); err != nil {
+		t.Fatalf("WriteTaskPaper() error = %v", err)
+	}
+	var want bytes.Buffer
+	_ = export.WriteTaskPaper(&want, original)
+	if again.String() != want.String() {
+		t.Errorf("round trip =\n%s\nwant\n%s", again.String(), want.String())
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{name: "markdown extension", file: "todo.md", want: FormatMarkdown},
+		{name: "taskpaper extension", file: "todo.taskpaper", content: "- [ ] x", want: FormatTaskPaper},
+		{name: "checklist content", file: "", content: "# Home\n- [ ] Paint", want: FormatMarkdown},
+		{name: "outline content", file: "todo.txt", content: "Home:\n\t- Paint", want: FormatTaskPaper},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectFormat(tt.file, []byte(tt.content)); got != tt.want {
+				t.Errorf("DetectFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	db, err := Parse(strings.NewReader("- Call bank\nHome:\n\t- Paint fence @flagged\n\t\t- Buy paint\n\t- Mow lawn @done\nOld: @done\n\t- Forgotten\n"), FormatTaskPaper, testNow)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	target := &recordingTarget{}
+
+	result, err := Apply(context.Background(), db, target, nopProgress{}, Duplicates{})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	want := Result{Projects: 1, Tasks: 3, Skipped: 3}
+	if result != want {
+		t.Errorf("Apply() = %+v, want %+v", result, want)
+	}
+	if len(target.projects) != 1 || target.projects[0].Name != "Home" {
+		t.Errorf("CreateProject() inputs = %+v, want Home only", target.projects)
+	}
+	if len(target.tasks) != 3 {
+		t.Fatalf("CreateTask() inputs = %+v, want 3", target.tasks)
+	}
+	if target.tasks[0].ProjectID != "" {
+		t.Errorf("CreateTask(Call bank) project = %q, want inbox", target.tasks[0].ProjectID)
+	}
+	fence := target.tasks[1]
+	if fence.ProjectID != "pHome" || fence.Flagged == nil || !*fence.Flagged {
+		t.Errorf("CreateTask(Paint fence) = %+v, want flagged in pHome", fence)
+	}
+	if paint := target.tasks[2]; paint.ParentID != "tPaint fence" || paint.ProjectID != "pHome" {
+		t.Errorf("CreateTask(Buy paint) = %+v, want under tPaint fence", paint)
+	}
+}
+
+func TestApply_StopsOnFailure(t *testing.T) {
+	db, _ := Parse(strings.NewReader("Home:\n\t- First\n\t- Second\n\t- Third\n"), FormatTaskPaper, testNow)
+	target := &recordingTarget{failOn: "Second"}
+
+	result, err := Apply(context.Background(), db, target, nopProgress{}, Duplicates{})
+
+	if err == nil || !strings.Contains(err.Error(), "failed to create task Second") {
+		t.Errorf("Apply() error = %v, want failure on Second", err)
+	}
+	if result.Tasks != 1 {
+		t.Errorf("Apply() created %d tasks, want 1 before the failure", result.Tasks)
+	}
+}
@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Field is a task field that can be taken from the incoming or the existing
+// task when merging
+type Field int
+
+// Fields of a task a merge picks between
+const (
+	FieldName Field = iota
+	FieldNote
+	FieldDue
+	FieldDefer
+	FieldFlagged
+	FieldTags
+)
+
+// Fields lists the fields of a merge in the order they are shown
+var Fields = []Field{FieldName, FieldNote, FieldDue, FieldDefer, FieldFlagged, FieldTags}
+
+// String returns the name of the field
+func (f Field) String() string {
+	switch f {
+	case FieldName:
+		return "Name"
+	case FieldNote:
+		return "Note"
+	case FieldDue:
+		return "Due"
+	case FieldDefer:
+		return "Defer"
+	case FieldFlagged:
+		return "Flagged"
+	case FieldTags:
+		return "Tags"
+	default:
+		return ""
+	}
+}
+
+// Action is what an import does with an incoming task matching an existing one
+type Action int
+
+// Actions for duplicates
+const (
+	ActionCreate Action = iota // Create the incoming task anyway
+	ActionSkip                 // Keep the existing task as it is
+	ActionMerge                // Update the existing task with fields of the incoming one
+)
+
+// Collision is an incoming task that closely matches an existing one
+type Collision struct {
+	Incoming domain.Task
+	Existing domain.Task
+}
+
+// Resolution is what to do with a collision; with ActionMerge, Merged is the
+// existing task as it should be after the import
+type Resolution struct {
+	Action Action
+	Merged domain.Task
+}
+
+// Resolver decides what to do with each collision; an error stops the import
+type Resolver func(Collision) (Resolution, error)
+
+// Duplicates is how Apply looks for incoming tasks already in OmniFocus.
+// Without Resolve every task is created.
+type Duplicates struct {
+	Existing []domain.Task // Tasks already in OmniFocus, with subtasks nested or flat
+	Resolve  Resolver
+}
+
+// matchKey returns the name tasks closely matching name share: its words,
+// lowercased, without punctuation
+func matchKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// index returns the incomplete existing tasks by match key, the first task
+// listed winning
+func (d Duplicates) index() map[string]domain.Task {
+	if d.Resolve == nil {
+		return nil
+	}
+	index := make(map[string]domain.Task)
+	for _, task := range domain.FlattenTasks(d.Existing) {
+		key := matchKey(task.Name)
+		if _, seen := index[key]; !task.Completed && key != "" && !seen {
+			index[key] = task
+		}
+	}
+	return index
+}
+
+// IsEmpty reports whether task has no value for field
+func IsEmpty(task domain.Task, field Field) bool {
+	switch field {
+	case FieldName:
+		return task.Name == ""
+	case FieldNote:
+		return task.Note == ""
+	case FieldDue:
+		return task.DueDate == nil
+	case FieldDefer:
+		return task.DeferDate == nil
+	case FieldFlagged:
+		return !task.Flagged
+	case FieldTags:
+		return len(task.Tags) == 0
+	default:
+		return true
+	}
+}
+
+// DefaultPicks returns the fields a merge takes from the incoming task by
+// default: those the existing task has no value for
+func DefaultPicks(c Collision) map[Field]bool {
+	picks := make(map[Field]bool)
+	for _, field := range Fields {
+		if IsEmpty(c.Existing, field) && !IsEmpty(c.Incoming, field) {
+			picks[field] = true
+		}
+	}
+	return picks
+}
+
+// Merge returns the existing task with the fields in fromIncoming taken from
+// the incoming task
+func Merge(c Collision, fromIncoming map[Field]bool) domain.Task {
+	merged := c.Existing
+	for _, field := range Fields {
+		if !fromIncoming[field] {
+			continue
+		}
+		switch field {
+		case FieldName:
+			merged.Name = c.Incoming.Name
+		case FieldNote:
+			merged.Note = c.Incoming.Note
+		case FieldDue:
+			merged.DueDate = c.Incoming.DueDate
+		case FieldDefer:
+			merged.DeferDate = c.Incoming.DeferDate
+		case FieldFlagged:
+			merged.Flagged = c.Incoming.Flagged
+		case FieldTags:
+			merged.Tags = slices.Clone(c.Incoming.Tags)
+		}
+	}
+	return merged
+}
+
+// Modification returns the change that turns existing into merged
+func Modification(existing, merged domain.Task) domain.TaskModification {
+	var mod domain.TaskModification
+	if merged.Name != existing.Name {
+		mod.Name = &merged.Name
+	}
+	if merged.Note != existing.Note {
+		mod.Note = &merged.Note
+	}
+	if !sameTime(merged.DueDate, existing.DueDate) {
+		if merged.DueDate == nil {
+			mod.ClearDue = true
+		} else {
+			mod.DueDate = merged.DueDate
+		}
+	}
+	if !sameTime(merged.DeferDate, existing.DeferDate) {
+		if merged.DeferDate == nil {
+			mod.ClearDefer = true
+		} else {
+			mod.DeferDate = merged.DeferDate
+		}
+	}
+	if merged.Flagged != existing.Flagged {
+		mod.Flagged = &merged.Flagged
+	}
+	for _, tag := range merged.Tags {
+		if !slices.Contains(existing.Tags, tag) {
+			mod.AddTags = append(mod.AddTags, tag)
+		}
+	}
+	for _, tag := range existing.Tags {
+		if !slices.Contains(merged.Tags, tag) {
+			mod.RemoveTags = append(mod.RemoveTags, tag)
+		}
+	}
+	return mod
+}
+
+// sameTime reports whether two optional times are both unset or equal
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
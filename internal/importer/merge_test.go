@@ -0,0 +1,136 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestMatchKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Call bank", "call bank", true},
+		{"Call  bank!", "Call bank", true},
+		{"Re: invoice #42", "re invoice 42", true},
+		{"Call bank", "Call banker", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchKey(tt.a) == matchKey(tt.b); got != tt.want {
+			t.Errorf("matchKey(%q) == matchKey(%q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	due := time.Date(2026, 10, 20, 17, 0, 0, 0, time.UTC)
+	c := Collision{
+		Incoming: domain.Task{Name: "Paint fence", Note: "Green", DueDate: &due, Flagged: true, Tags: []string{"Outside"}},
+		Existing: domain.Task{ID: "t1", Name: "paint fence", Note: "Blue", Tags: []string{"Home"}},
+	}
+
+	picks := DefaultPicks(c)
+	if !picks[FieldDue] || !picks[FieldFlagged] || picks[FieldName] || picks[FieldNote] || picks[FieldTags] {
+		t.Errorf("DefaultPicks() = %v, want due and flagged, which the existing task lacks", picks)
+	}
+
+	picks[FieldTags] = true
+	merged := Merge(c, picks)
+	if merged.ID != "t1" || merged.Name != "paint fence" || merged.Note != "Blue" {
+		t.Errorf("Merge() = %+v, want the existing task's ID, name and note", merged)
+	}
+	if merged.DueDate == nil || !merged.Flagged || !slices.Equal(merged.Tags, []string{"Outside"}) {
+		t.Errorf("Merge() = %+v, want the incoming due date, flag and tags", merged)
+	}
+
+	mod := Modification(c.Existing, merged)
+	if mod.Name != nil || mod.Note != nil || mod.DueDate == nil || mod.Flagged == nil || !*mod.Flagged {
+		t.Errorf("Modification() = %+v, want due and flagged changed only", mod)
+	}
+	if !slices.Equal(mod.AddTags, []string{"Outside"}) || !slices.Equal(mod.RemoveTags, []string{"Home"}) {
+		t.Errorf("Modification() tags = +%v -%v, want +Outside -Home", mod.AddTags, mod.RemoveTags)
+	}
+	if !Modification(c.Existing, c.Existing).IsEmpty() {
+		t.Error("Modification() of an unchanged task should be empty")
+	}
+}
+
+func TestModification_ClearsDates(t *testing.T) {
+	due := time.Date(2026, 10, 20, 17, 0, 0, 0, time.UTC)
+	existing := domain.Task{ID: "t1", Name: "Pay rent", DueDate: &due, DeferDate: &due}
+
+	mod := Modification(existing, domain.Task{ID: "t1", Name: "Pay rent"})
+
+	if !mod.ClearDue || !mod.ClearDefer {
+		t.Errorf("Modification() = %+v, want both dates cleared", mod)
+	}
+}
+
+func TestApply_ResolvesDuplicates(t *testing.T) {
+	db, err := Parse(strings.NewReader("- Call bank @flagged\n- Buy milk\nHome:\n\t- Paint fence\n\t\t- Buy paint\n\t- Mow lawn\n"), FormatTaskPaper, testNow)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	existing := []domain.Task{
+		{ID: "bank", Name: "Call bank"},
+		{ID: "milk", Name: "buy milk.", Completed: true},
+		{ID: "fence", Name: "Paint fence", ProjectID: "pOld"},
+	}
+	var asked []string
+	resolve := func(c Collision) (Resolution, error) {
+		asked = append(asked, c.Existing.ID)
+		if c.Existing.ID == "bank" {
+			return Resolution{Action: ActionMerge, Merged: Merge(c, DefaultPicks(c))}, nil
+		}
+		return Resolution{Action: ActionSkip}, nil
+	}
+	target := &recordingTarget{}
+
+	result, err := Apply(context.Background(), db, target, nopProgress{}, Duplicates{Existing: existing, Resolve: resolve})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if got := strings.Join(asked, ","); got != "bank,fence" {
+		t.Errorf("asked about %s, want bank,fence (completed tasks do not match)", got)
+	}
+	want := Result{Projects: 1, Tasks: 3, Merged: 1, Duplicates: 1}
+	if result != want {
+		t.Errorf("Apply() = %+v, want %+v", result, want)
+	}
+	if mod, ok := target.modified["bank"]; !ok || mod.Flagged == nil || !*mod.Flagged {
+		t.Errorf("ModifyTask(bank) = %+v, want it flagged", target.modified)
+	}
+	var paint domain.TaskInput
+	for _, input := range target.tasks {
+		if input.Name == "Buy paint" {
+			paint = input
+		}
+	}
+	if paint.ParentID != "fence" || paint.ProjectID != "pOld" {
+		t.Errorf("CreateTask(Buy paint) = %+v, want it under the existing fence task", paint)
+	}
+}
+
+func TestApply_ResolverErrorStops(t *testing.T) {
+	db, _ := Parse(strings.NewReader("- Call bank\n- Buy milk\n"), FormatTaskPaper, testNow)
+	cancelled := errors.New("import cancelled")
+	resolve := func(Collision) (Resolution, error) { return Resolution{}, cancelled }
+	target := &recordingTarget{}
+
+	_, err := Apply(context.Background(), db, target, nopProgress{}, Duplicates{Existing: []domain.Task{{ID: "bank", Name: "Call bank"}}, Resolve: resolve})
+
+	if !errors.Is(err, cancelled) {
+		t.Errorf("Apply() error = %v, want the resolver's", err)
+	}
+	if len(target.tasks) != 0 {
+		t.Errorf("CreateTask() inputs = %+v, want none after cancelling", target.tasks)
+	}
+}
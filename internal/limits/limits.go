@@ -0,0 +1,143 @@
+// Package limits enforces work-in-progress limits on the number of open tasks
+// with a tag or in a project.
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// WarnIcon marks counts past their limit
+const WarnIcon = "⚠"
+
+// ErrLimitReached is returned when a change would take a blocking limit past its maximum
+var ErrLimitReached = errors.New("WIP limit reached")
+
+// Limit caps the number of open tasks with a tag or in a project
+type Limit struct {
+	Tag     string
+	Project string // Project name or ID
+	Max     int
+	Block   bool // Refuse changes past the limit instead of warning
+}
+
+// New validates the configured limits, rejecting ones without exactly one
+// of tag or project or without a positive maximum
+func New(cfgs []config.LimitConfig) ([]Limit, error) {
+	limits := make([]Limit, 0, len(cfgs))
+	for i, cfg := range cfgs {
+		limit := Limit{
+			Tag:     strings.TrimSpace(cfg.Tag),
+			Project: strings.TrimSpace(cfg.Project),
+			Max:     cfg.Max,
+			Block:   cfg.Block,
+		}
+		if (limit.Tag == "") == (limit.Project == "") {
+			return nil, fmt.Errorf("limit %d: set exactly one of tag or project", i+1)
+		}
+		if limit.Max <= 0 {
+			return nil, fmt.Errorf("limit %d (%s): max must be greater than zero", i+1, limit)
+		}
+		limits = append(limits, limit)
+	}
+	return limits, nil
+}
+
+// String describes what the limit counts, e.g. `tag "doing"`
+func (l Limit) String() string {
+	if l.Tag != "" {
+		return fmt.Sprintf("tag %q", l.Tag)
+	}
+	return fmt.Sprintf("project %q", l.Project)
+}
+
+// Matches reports whether the task counts towards the limit. Tags and project
+// names match ignoring case; projects also match by ID.
+func (l Limit) Matches(task domain.Task) bool {
+	if l.Tag != "" {
+		for _, tag := range task.Tags {
+			if strings.EqualFold(tag, l.Tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return task.ProjectID == l.Project || (task.ProjectName != "" && strings.EqualFold(task.ProjectName, l.Project))
+}
+
+// ForTag returns the limit on the named tag
+func ForTag(limits []Limit, name string) (Limit, bool) {
+	for _, limit := range limits {
+		if limit.Tag != "" && strings.EqualFold(limit.Tag, name) {
+			return limit, true
+		}
+	}
+	return Limit{}, false
+}
+
+// ForProject returns the limit on the project, named or given by ID
+func ForProject(limits []Limit, project domain.Project) (Limit, bool) {
+	for _, limit := range limits {
+		if limit.Project != "" && (limit.Project == project.ID || strings.EqualFold(limit.Project, project.Name)) {
+			return limit, true
+		}
+	}
+	return Limit{}, false
+}
+
+// Badge formats a count against the limit, e.g. "(3/5)", marked with
+// WarnIcon once the limit is exceeded
+func (l Limit) Badge(count int) string {
+	if count > l.Max {
+		return fmt.Sprintf("%s (%d/%d)", WarnIcon, count, l.Max)
+	}
+	return fmt.Sprintf("(%d/%d)", count, l.Max)
+}
+
+// Usage is the number of open tasks counting towards a limit
+type Usage struct {
+	Limit Limit
+	Count int
+}
+
+// Exceeded reports whether the count is over the limit
+func (u Usage) Exceeded() bool {
+	return u.Count > u.Limit.Max
+}
+
+// String describes the usage, e.g. `6 open tasks with tag "doing" (limit 5)`
+func (u Usage) String() string {
+	return fmt.Sprintf("%d open tasks with %s (limit %d)", u.Count, u.Limit, u.Limit.Max)
+}
+
+// Check counts the open tasks towards each limit, in configuration order
+func Check(limits []Limit, tasks []domain.Task) []Usage {
+	usages := make([]Usage, len(limits))
+	for i, limit := range limits {
+		usages[i].Limit = limit
+		for _, task := range tasks {
+			if !task.Completed && limit.Matches(task) {
+				usages[i].Count++
+			}
+		}
+	}
+	return usages
+}
+
+// Exceeded returns the limits a change takes past their maximum: over the
+// limit after the change and with more tasks than before it. Limits that
+// were already over and are not made worse are left out.
+func Exceeded(limits []Limit, before, after []domain.Task) []Usage {
+	was := Check(limits, before)
+	var over []Usage
+	for i, usage := range Check(limits, after) {
+		if usage.Exceeded() && usage.Count > was[i].Count {
+			over = append(over, usage)
+		}
+	}
+	return over
+}
@@ -0,0 +1,110 @@
+package limits
+
+import (
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestNew(t *testing.T) {
+	limitList, err := New([]config.LimitConfig{
+		{Tag: " doing ", Max: 5},
+		{Project: "Work", Max: 10, Block: true},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	want := []Limit{{Tag: "doing", Max: 5}, {Project: "Work", Max: 10, Block: true}}
+	if len(limitList) != len(want) || limitList[0] != want[0] || limitList[1] != want[1] {
+		t.Errorf("New() = %+v, want %+v", limitList, want)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.LimitConfig
+	}{
+		{"neither tag nor project", config.LimitConfig{Max: 5}},
+		{"both tag and project", config.LimitConfig{Tag: "doing", Project: "Work", Max: 5}},
+		{"no max", config.LimitConfig{Tag: "doing"}},
+		{"negative max", config.LimitConfig{Tag: "doing", Max: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New([]config.LimitConfig{tt.cfg}); err == nil {
+				t.Error("New() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestLimit_Matches(t *testing.T) {
+	task := domain.Task{ProjectID: "p1", ProjectName: "Work", Tags: []string{"Doing", "phone"}}
+	tests := []struct {
+		limit Limit
+		want  bool
+	}{
+		{Limit{Tag: "doing"}, true},
+		{Limit{Tag: "waiting"}, false},
+		{Limit{Project: "work"}, true},
+		{Limit{Project: "p1"}, true},
+		{Limit{Project: "Home"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.limit.Matches(task); got != tt.want {
+			t.Errorf("%s.Matches() = %v, want %v", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	limitList := []Limit{{Tag: "doing", Max: 1}, {Project: "Work", Max: 5}}
+	tasks := []domain.Task{
+		{ID: "t1", ProjectName: "Work", Tags: []string{"doing"}},
+		{ID: "t2", Tags: []string{"doing"}},
+		{ID: "t3", ProjectName: "Work", Tags: []string{"doing"}, Completed: true},
+	}
+
+	usages := Check(limitList, tasks)
+	if usages[0].Count != 2 || !usages[0].Exceeded() {
+		t.Errorf("tag usage = %+v, want 2 open tasks, exceeded", usages[0])
+	}
+	if usages[1].Count != 1 || usages[1].Exceeded() {
+		t.Errorf("project usage = %+v, want 1 open task, not exceeded", usages[1])
+	}
+	if got, want := usages[0].String(), `2 open tasks with tag "doing" (limit 1)`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestExceeded(t *testing.T) {
+	limitList := []Limit{{Tag: "doing", Max: 1}, {Tag: "waiting", Max: 1}}
+	before := []domain.Task{
+		{ID: "t1", Tags: []string{"doing"}},
+		{ID: "t2", Tags: []string{"waiting"}},
+		{ID: "t3", Tags: []string{"waiting"}},
+	}
+	after := []domain.Task{
+		{ID: "t1", Tags: []string{"doing"}},
+		{ID: "t2", Tags: []string{"waiting", "doing"}},
+		{ID: "t3", Tags: []string{"waiting"}},
+	}
+
+	// waiting was already over its limit and is unchanged, so only doing is reported
+	over := Exceeded(limitList, before, after)
+	if len(over) != 1 || over[0].Limit.Tag != "doing" || over[0].Count != 2 {
+		t.Errorf("Exceeded() = %+v, want only doing with 2 tasks", over)
+	}
+}
+
+func TestLimit_Badge(t *testing.T) {
+	limit := Limit{Tag: "doing", Max: 5}
+	if got := limit.Badge(5); got != "(5/5)" {
+		t.Errorf("Badge(5) = %q, want (5/5)", got)
+	}
+	if got := limit.Badge(6); got != WarnIcon+" (6/5)" {
+		t.Errorf("Badge(6) = %q, want a warning", got)
+	}
+}
@@ -0,0 +1,116 @@
+// Package log provides the debug log. It is off by default; once enabled with
+// --debug, LAZYFOCUS_DEBUG=1 or the TUI's :debug command, structured records
+// (script names, parameters, durations and truncated output) are appended to
+// a log file.
+package log
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxValueLength is the length values are truncated to by Truncate
+const maxValueLength = 500
+
+var (
+	mu      sync.Mutex
+	logger  = slog.New(slog.DiscardHandler)
+	handler slog.Handler // Handler writing to file, while enabled
+	file    io.Closer
+	path    string
+	device  string // Device ID added to every record
+)
+
+// Logger returns the debug logger, which discards records while disabled
+func Logger() *slog.Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	return logger
+}
+
+// Enabled reports whether debug logging is on
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return file != nil
+}
+
+// Path returns the file debug records are written to, or "" while disabled
+func Path() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return path
+}
+
+// Enable starts appending debug records to the file at p, creating it and
+// its directory if needed
+func Enable(p string) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open debug log: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil {
+		_ = file.Close()
+	}
+	file = f
+	path = p
+	handler = slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger = newLogger()
+	return nil
+}
+
+// SetDevice labels every following record with id, so a log gathered from
+// several machines sharing one database shows which of them made each call
+func SetDevice(id string) {
+	mu.Lock()
+	defer mu.Unlock()
+	device = id
+	if handler != nil {
+		logger = newLogger()
+	}
+}
+
+// newLogger returns a logger writing to handler, with the device if set.
+// The caller holds mu.
+func newLogger() *slog.Logger {
+	l := slog.New(handler)
+	if device != "" {
+		l = l.With("device", device)
+	}
+	return l
+}
+
+// Disable stops debug logging and closes the log file
+func Disable() error {
+	mu.Lock()
+	defer mu.Unlock()
+	logger = slog.New(slog.DiscardHandler)
+	handler = nil
+	path = ""
+	if file == nil {
+		return nil
+	}
+	err := file.Close()
+	file = nil
+	return err
+}
+
+// Truncate shortens s to a length suitable for a log record
+func Truncate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= maxValueLength {
+		return s
+	}
+	return fmt.Sprintf("%s… (%d bytes)", s[:maxValueLength], len(s))
+}
@@ -0,0 +1,87 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnableDisable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "debug.log")
+	t.Cleanup(func() { _ = Disable() })
+
+	Logger().Debug("before enabling")
+	if Enabled() {
+		t.Fatal("Enabled() = true before Enable()")
+	}
+
+	if err := Enable(path); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if !Enabled() || Path() != path {
+		t.Errorf("Enabled() = %v, Path() = %q, want true and %q", Enabled(), Path(), path)
+	}
+	Logger().Debug("running script", "script", "get_tags")
+
+	if err := Disable(); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	Logger().Debug("after disabling")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	log := string(data)
+	if !strings.Contains(log, "level=DEBUG") || !strings.Contains(log, "script=get_tags") {
+		t.Errorf("log = %q, want the debug record", log)
+	}
+	for _, unwanted := range []string{"before enabling", "after disabling"} {
+		if strings.Contains(log, unwanted) {
+			t.Errorf("log = %q, should not contain %q", log, unwanted)
+		}
+	}
+}
+
+func TestSetDevice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+	t.Cleanup(func() {
+		SetDevice("")
+		_ = Disable()
+	})
+
+	if err := Enable(path); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	Logger().Debug("lazyfocus started")
+	SetDevice("studio-mac")
+	Logger().Debug("running script", "script", "get_tags")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("log has %d records, want 2: %q", len(lines), data)
+	}
+	if strings.Contains(lines[0], "device=") {
+		t.Errorf("record before SetDevice() = %q, want no device", lines[0])
+	}
+	if !strings.Contains(lines[1], "device=studio-mac") {
+		t.Errorf("record after SetDevice() = %q, want device=studio-mac", lines[1])
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := Truncate("  short\n"); got != "short" {
+		t.Errorf("Truncate() = %q, want %q", got, "short")
+	}
+
+	long := strings.Repeat("x", maxValueLength+10)
+	got := Truncate(long)
+	if !strings.HasPrefix(got, strings.Repeat("x", maxValueLength)+"…") || !strings.HasSuffix(got, "(510 bytes)") {
+		t.Errorf("Truncate() = %q, want %d characters and the full length", got, maxValueLength)
+	}
+}
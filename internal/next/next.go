@@ -0,0 +1,240 @@
+// Package next suggests the task to work on next, scoring tasks on several
+// factors and keeping the reasons for each score.
+package next
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Factors a task is scored on
+const (
+	FactorDue      = "due"
+	FactorFlagged  = "flagged"
+	FactorEstimate = "estimate"
+	FactorContext  = "context"
+	FactorStale    = "stale"
+	FactorEffort   = "effort"
+)
+
+// StaleAfterDays is how long a task goes unchanged before it counts as stale
+const StaleAfterDays = 14
+
+// quickMinutes is the longest estimate that counts as a quick task when the
+// available time is unknown
+const quickMinutes = 15
+
+// Weights scale each factor of a task's score; a weight of 0 ignores the factor
+type Weights struct {
+	Due      float64 `json:"due"`      // Overdue or due soon
+	Flagged  float64 `json:"flagged"`  // Flagged
+	Estimate float64 `json:"estimate"` // Estimate fits the time available
+	Context  float64 `json:"context"`  // Tagged with a current context
+	Stale    float64 `json:"stale"`    // Unchanged for a long time
+	Effort   float64 `json:"effort"`   // Effort suits the energy available
+}
+
+// DefaultWeights favor deadlines, then flags and contexts
+var DefaultWeights = Weights{Due: 3, Flagged: 2, Estimate: 1, Context: 1.5, Stale: 1, Effort: 1}
+
+// Options describe the situation tasks are ranked for
+type Options struct {
+	Weights   Weights
+	Available int           // Minutes available now, 0 when unknown
+	Context   []string      // Tag names that fit the current context, e.g. "home"
+	Energy    domain.Effort // Energy available now, "" when unknown
+	Now       time.Time
+}
+
+// Reason is one factor's contribution to a suggestion's score
+type Reason struct {
+	Factor string  `json:"factor"`
+	Score  float64 `json:"score"` // Weighted contribution; negative counts against the task
+	Text   string  `json:"text"`  // e.g. "due tomorrow"
+}
+
+// Suggestion is a task with its score and the reasons for it
+type Suggestion struct {
+	Task    domain.Task `json:"task"`
+	Score   float64     `json:"score"`
+	Reasons []Reason    `json:"reasons"`
+}
+
+// Explain describes the reasons for the suggestion, e.g. "due tomorrow ·
+// flagged", or that nothing set it apart
+func (s Suggestion) Explain() string {
+	if len(s.Reasons) == 0 {
+		return "nothing stands out"
+	}
+	texts := make([]string, len(s.Reasons))
+	for i, r := range s.Reasons {
+		texts[i] = r.Text
+	}
+	return strings.Join(texts, " · ")
+}
+
+// Suggest ranks the available tasks and their subtasks, best first. Tasks
+// that are completed, blocked or deferred past opts.Now are left out; equal
+// scores keep the order of tasks.
+func Suggest(tasks []domain.Task, opts Options) []Suggestion {
+	var suggestions []Suggestion
+	for _, task := range domain.FlattenTasks(tasks) {
+		if !available(task, opts.Now) {
+			continue
+		}
+		suggestions = append(suggestions, score(task, opts))
+	}
+	slices.SortStableFunc(suggestions, func(a, b Suggestion) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	return suggestions
+}
+
+// available reports whether task can be worked on now
+func available(task domain.Task, now time.Time) bool {
+	return !task.Completed && !task.Blocked && (task.DeferDate == nil || !task.DeferDate.After(now))
+}
+
+// score scores task on every weighted factor
+func score(task domain.Task, opts Options) Suggestion {
+	s := Suggestion{Task: task}
+	add := func(factor string, weight, value float64, text string) {
+		if weight == 0 || value == 0 {
+			return
+		}
+		s.Score += weight * value
+		s.Reasons = append(s.Reasons, Reason{Factor: factor, Score: weight * value, Text: text})
+	}
+
+	value, text := dueFactor(task, opts.Now)
+	add(FactorDue, opts.Weights.Due, value, text)
+	if task.Flagged {
+		add(FactorFlagged, opts.Weights.Flagged, 1, "flagged")
+	}
+	value, text = estimateFactor(task, opts.Available)
+	add(FactorEstimate, opts.Weights.Estimate, value, text)
+	if tag, ok := contextTag(task, opts.Context); ok {
+		add(FactorContext, opts.Weights.Context, 1, "tagged "+tag)
+	}
+	value, text = staleFactor(task, opts.Now)
+	add(FactorStale, opts.Weights.Stale, value, text)
+	value, text = effortFactor(task, opts.Energy)
+	add(FactorEffort, opts.Weights.Effort, value, text)
+	return s
+}
+
+// dueFactor rates how pressing the due date is, from 1 when overdue down to
+// nothing a week out
+func dueFactor(task domain.Task, now time.Time) (float64, string) {
+	if task.DueDate == nil {
+		return 0, ""
+	}
+	days := daysBetween(now, *task.DueDate)
+	switch {
+	case days < 0:
+		return 1, fmt.Sprintf("overdue by %s", plural(-days, "day"))
+	case days == 0:
+		return 0.9, "due today"
+	case days == 1:
+		return 0.75, "due tomorrow"
+	case days <= 7:
+		return 0.7 - 0.07*float64(days), fmt.Sprintf("due in %d days", days)
+	}
+	return 0, ""
+}
+
+// estimateFactor rates how well the estimate fits the time available: tasks
+// that fit count for, and tasks that do not count against. Without a known
+// time, quick tasks count for.
+func estimateFactor(task domain.Task, available int) (float64, string) {
+	estimate := task.EstimatedMinutes
+	switch {
+	case estimate == 0:
+		return 0, ""
+	case available == 0 && estimate <= quickMinutes:
+		return 0.5, "quick: " + domain.FormatEstimate(estimate)
+	case available == 0:
+		return 0, ""
+	case estimate <= available:
+		return 1, fmt.Sprintf("fits in %s (takes %s)", domain.FormatEstimate(available), domain.FormatEstimate(estimate))
+	}
+	return -1, fmt.Sprintf("takes %s, more than %s", domain.FormatEstimate(estimate), domain.FormatEstimate(available))
+}
+
+// effortLevels orders efforts from least to most demanding
+var effortLevels = map[domain.Effort]int{domain.EffortLow: 1, domain.EffortMedium: 2, domain.EffortHigh: 3}
+
+// effortFactor rates how well the effort of task suits the energy available:
+// a matching effort counts for, and a higher one counts against. Without a
+// known energy, low effort tasks count for.
+func effortFactor(task domain.Task, energy domain.Effort) (float64, string) {
+	effort := task.Effort()
+	switch {
+	case effort == "":
+		return 0, ""
+	case energy == "" && effort == domain.EffortLow:
+		return 0.5, "low effort"
+	case energy == "":
+		return 0, ""
+	case effort == energy:
+		return 1, fmt.Sprintf("%s effort suits your energy", effort)
+	case effortLevels[effort] > effortLevels[energy]:
+		return -1, fmt.Sprintf("%s effort, more than your %s energy", effort, energy)
+	}
+	return 0, ""
+}
+
+// contextTag returns the first tag of task that is one of the contexts,
+// ignoring case
+func contextTag(task domain.Task, contexts []string) (string, bool) {
+	for _, tag := range task.Tags {
+		for _, context := range contexts {
+			if strings.EqualFold(tag, context) {
+				return tag, true
+			}
+		}
+	}
+	return "", false
+}
+
+// staleFactor rates how long task has gone unchanged, growing from
+// StaleAfterDays to its full value at 60 days
+func staleFactor(task domain.Task, now time.Time) (float64, string) {
+	if task.ModifiedDate == nil {
+		return 0, ""
+	}
+	days := daysBetween(*task.ModifiedDate, now)
+	if days < StaleAfterDays {
+		return 0, ""
+	}
+	return min(1, float64(days)/60), fmt.Sprintf("untouched for %d days", days)
+}
+
+// daysBetween counts the calendar days from the day of from to the day of
+// to, in local time
+func daysBetween(from, to time.Time) int {
+	from, to = from.Local(), to.Local()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.Local)
+	// Round so days shortened or lengthened by daylight saving still count as one
+	return int(math.Round(end.Sub(start).Hours() / 24))
+}
+
+// plural formats n with unit, adding "s" unless n is 1
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,167 @@
+package next
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+var now = time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+
+func day(offset int) *time.Time {
+	t := now.AddDate(0, 0, offset)
+	return &t
+}
+
+func TestSuggest_RanksByWeightedFactors(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "plain", Name: "Tidy desk"},
+		{ID: "flagged", Name: "Call plumber", Flagged: true},
+		{ID: "overdue", Name: "Pay rent", DueDate: day(-2)},
+		{ID: "context", Name: "Water plants", Tags: []string{"Home"}},
+	}
+
+	got := Suggest(tasks, Options{Weights: DefaultWeights, Context: []string{"home"}, Now: now})
+
+	want := []string{"overdue", "flagged", "context", "plain"}
+	if len(got) != len(want) {
+		t.Fatalf("Suggest() returned %d suggestions, want %d", len(got), len(want))
+	}
+	for i, id := range want {
+		if got[i].Task.ID != id {
+			t.Errorf("suggestion %d = %s, want %s", i, got[i].Task.ID, id)
+		}
+	}
+	if got[0].Explain() != "overdue by 2 days" {
+		t.Errorf("Explain() = %q, want overdue by 2 days", got[0].Explain())
+	}
+	if got[2].Explain() != "tagged Home" {
+		t.Errorf("Explain() = %q, want tagged Home", got[2].Explain())
+	}
+	if got[3].Explain() != "nothing stands out" {
+		t.Errorf("Explain() = %q, want nothing stands out", got[3].Explain())
+	}
+}
+
+func TestSuggest_SkipsUnavailableTasks(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "done", Completed: true},
+		{ID: "blocked", Blocked: true},
+		{ID: "deferred", DeferDate: day(3)},
+		{ID: "parent", Children: []domain.Task{{ID: "child"}}},
+	}
+
+	got := Suggest(tasks, Options{Weights: DefaultWeights, Now: now})
+
+	if len(got) != 1 || got[0].Task.ID != "child" {
+		t.Errorf("Suggest() = %v, want only the available subtask", got)
+	}
+}
+
+func TestSuggest_WeightsCanIgnoreFactors(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "flagged", Flagged: true},
+		{ID: "due", DueDate: day(0)},
+	}
+
+	got := Suggest(tasks, Options{Weights: Weights{Flagged: 1}, Now: now})
+
+	if got[0].Task.ID != "flagged" || len(got[1].Reasons) != 0 {
+		t.Errorf("Suggest() = %v, want the due date ignored", got)
+	}
+}
+
+func TestDueFactor(t *testing.T) {
+	tests := []struct {
+		due      *time.Time
+		wantText string
+	}{
+		{nil, ""},
+		{day(-1), "overdue by 1 day"},
+		{day(0), "due today"},
+		{day(1), "due tomorrow"},
+		{day(5), "due in 5 days"},
+		{day(10), ""},
+	}
+
+	previous := 2.0
+	for _, tt := range tests[1:] {
+		value, text := dueFactor(domain.Task{DueDate: tt.due}, now)
+		if text != tt.wantText {
+			t.Errorf("dueFactor(%v) text = %q, want %q", tt.due, text, tt.wantText)
+		}
+		if value >= previous {
+			t.Errorf("dueFactor(%v) = %v, want less than %v for a later date", tt.due, value, previous)
+		}
+		previous = value
+	}
+	if value, _ := dueFactor(domain.Task{}, now); value != 0 {
+		t.Errorf("dueFactor(no due date) = %v, want 0", value)
+	}
+}
+
+func TestEstimateFactor(t *testing.T) {
+	tests := []struct {
+		name      string
+		estimate  int
+		available int
+		wantValue float64
+		wantText  string
+	}{
+		{"no estimate", 0, 30, 0, ""},
+		{"fits", 20, 30, 1, "fits in 30m (takes 20m)"},
+		{"too long", 90, 30, -1, "takes 1h30m, more than 30m"},
+		{"quick without available time", 10, 0, 0.5, "quick: 10m"},
+		{"long without available time", 60, 0, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, text := estimateFactor(domain.Task{EstimatedMinutes: tt.estimate}, tt.available)
+			if value != tt.wantValue || text != tt.wantText {
+				t.Errorf("estimateFactor() = %v, %q, want %v, %q", value, text, tt.wantValue, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestEffortFactor(t *testing.T) {
+	tests := []struct {
+		name      string
+		effort    domain.Effort
+		energy    domain.Effort
+		wantValue float64
+		wantText  string
+	}{
+		{"no effort", "", domain.EffortLow, 0, ""},
+		{"matches", domain.EffortHigh, domain.EffortHigh, 1, "high effort suits your energy"},
+		{"too demanding", domain.EffortHigh, domain.EffortLow, -1, "high effort, more than your low energy"},
+		{"less demanding", domain.EffortLow, domain.EffortHigh, 0, ""},
+		{"low without known energy", domain.EffortLow, "", 0.5, "low effort"},
+		{"high without known energy", domain.EffortHigh, "", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := domain.Task{Note: domain.SetNoteEffort("", tt.effort)}
+			value, text := effortFactor(task, tt.energy)
+			if value != tt.wantValue || text != tt.wantText {
+				t.Errorf("effortFactor() = %v, %q, want %v, %q", value, text, tt.wantValue, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestStaleFactor(t *testing.T) {
+	if value, _ := staleFactor(domain.Task{ModifiedDate: day(-3)}, now); value != 0 {
+		t.Errorf("staleFactor(3 days) = %v, want 0", value)
+	}
+	value, text := staleFactor(domain.Task{ModifiedDate: day(-30)}, now)
+	if value != 0.5 || text != "untouched for 30 days" {
+		t.Errorf("staleFactor(30 days) = %v, %q, want 0.5, untouched for 30 days", value, text)
+	}
+	if value, _ := staleFactor(domain.Task{ModifiedDate: day(-120)}, now); value != 1 {
+		t.Errorf("staleFactor(120 days) = %v, want 1", value)
+	}
+}
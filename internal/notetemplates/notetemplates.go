@@ -0,0 +1,129 @@
+// Package notetemplates fills in configured default notes for new tasks.
+package notetemplates
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Sources of created tasks, available to notes as {{.Source}}
+const (
+	SourceCLI = "cli"
+	SourceTUI = "tui"
+)
+
+// Data is the value note templates are executed with
+type Data struct {
+	Date    string // Creation date (2006-01-02)
+	Time    string // Creation time (15:04)
+	Name    string // Task name
+	Project string // Project name, empty for inbox tasks
+	Source  string // Where the task was created: "cli" or "tui"
+}
+
+// noteTemplate is a compiled note template and the condition selecting it
+type noteTemplate struct {
+	project string
+	tag     string
+	note    *template.Template
+}
+
+// Set holds note templates in configuration order
+type Set struct {
+	templates []noteTemplate
+	now       func() time.Time
+}
+
+// New compiles the configured note templates, rejecting templates without a
+// condition or with an invalid note
+func New(cfgs []config.NoteTemplateConfig) (*Set, error) {
+	set := &Set{now: time.Now}
+
+	for i, cfg := range cfgs {
+		name := fmt.Sprintf("note template %d", i+1)
+
+		t := noteTemplate{
+			project: strings.TrimSpace(cfg.Project),
+			tag:     strings.TrimSpace(cfg.Tag),
+		}
+		if t.project == "" && t.tag == "" {
+			return nil, fmt.Errorf("%s: a project or tag is required", name)
+		}
+		if strings.TrimSpace(cfg.Note) == "" {
+			return nil, fmt.Errorf("%s: note is required", name)
+		}
+
+		note, err := template.New(name).Option("missingkey=error").Parse(cfg.Note)
+		if err == nil {
+			err = note.Execute(&strings.Builder{}, Data{})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid note: %w", name, err)
+		}
+		t.note = note
+
+		set.templates = append(set.templates, t)
+	}
+
+	return set, nil
+}
+
+// Len returns the number of note templates
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.templates)
+}
+
+// Apply sets the note of a task about to be created from the first template
+// matching its project or tags. Inputs that already have a note are returned
+// unchanged.
+func (s *Set) Apply(input domain.TaskInput, source string) (domain.TaskInput, error) {
+	if s == nil || strings.TrimSpace(input.Note) != "" {
+		return input, nil
+	}
+
+	for _, t := range s.templates {
+		if !t.matches(input) {
+			continue
+		}
+
+		now := s.now()
+		data := Data{
+			Date:    now.Format("2006-01-02"),
+			Time:    now.Format("15:04"),
+			Name:    input.Name,
+			Project: input.ProjectName,
+			Source:  source,
+		}
+		var b strings.Builder
+		if err := t.note.Execute(&b, data); err != nil {
+			return input, fmt.Errorf("failed to render %s: %w", t.note.Name(), err)
+		}
+		input.Note = strings.TrimRight(b.String(), "\n")
+		return input, nil
+	}
+
+	return input, nil
+}
+
+// matches reports whether the task is in the template's project or has its tag
+func (t noteTemplate) matches(input domain.TaskInput) bool {
+	if t.project != "" && (strings.EqualFold(input.ProjectName, t.project) || strings.EqualFold(input.ProjectID, t.project)) {
+		return true
+	}
+	if t.tag != "" {
+		for _, tag := range input.TagNames {
+			if strings.EqualFold(tag, t.tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package notetemplates
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+var testNow = time.Date(2024, 1, 19, 10, 30, 0, 0, time.Local)
+
+func newTestSet(t *testing.T, cfgs ...config.NoteTemplateConfig) *Set {
+	t.Helper()
+
+	set, err := New(cfgs)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	set.now = func() time.Time { return testNow }
+	return set
+}
+
+var (
+	bugTemplate = config.NoteTemplateConfig{
+		Tag:  "bug",
+		Note: "Steps to reproduce:\n\nExpected:\n\nActual:\n\nReported {{.Date}} via {{.Source}}\n",
+	}
+	meetingTemplate = config.NoteTemplateConfig{
+		Project: "Meetings",
+		Note:    "Agenda for {{.Name}} ({{.Project}}, {{.Date}} {{.Time}})",
+	}
+)
+
+func TestNew_RejectsInvalidTemplates(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.NoteTemplateConfig
+		wantErr string
+	}{
+		{"no condition", config.NoteTemplateConfig{Note: "x"}, "project or tag is required"},
+		{"no note", config.NoteTemplateConfig{Tag: "bug"}, "note is required"},
+		{"bad syntax", config.NoteTemplateConfig{Tag: "bug", Note: "{{.Date"}, "invalid note"},
+		{"unknown variable", config.NoteTemplateConfig{Tag: "bug", Note: "{{.Author}}"}, "invalid note"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New([]config.NoteTemplateConfig{tt.cfg})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	set := newTestSet(t, bugTemplate, meetingTemplate)
+
+	tests := []struct {
+		name  string
+		input domain.TaskInput
+		want  string
+	}{
+		{
+			name:  "matches tag",
+			input: domain.TaskInput{Name: "Crash on save", TagNames: []string{"Bug"}},
+			want:  "Steps to reproduce:\n\nExpected:\n\nActual:\n\nReported 2024-01-19 via cli",
+		},
+		{
+			name:  "matches project",
+			input: domain.TaskInput{Name: "Planning", ProjectName: "meetings"},
+			want:  "Agenda for Planning (meetings, 2024-01-19 10:30)",
+		},
+		{
+			name:  "keeps existing note",
+			input: domain.TaskInput{Name: "Crash", Note: "Seen twice", TagNames: []string{"bug"}},
+			want:  "Seen twice",
+		},
+		{
+			name:  "no match",
+			input: domain.TaskInput{Name: "Buy milk", TagNames: []string{"errands"}},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := set.Apply(tt.input, SourceCLI)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got.Note != tt.want {
+				t.Errorf("Apply() note = %q, want %q", got.Note, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply_FirstMatchWins(t *testing.T) {
+	set := newTestSet(t, meetingTemplate, bugTemplate)
+
+	got, err := set.Apply(domain.TaskInput{Name: "Demo bug", ProjectName: "Meetings", TagNames: []string{"bug"}}, SourceTUI)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if !strings.HasPrefix(got.Note, "Agenda for Demo bug") {
+		t.Errorf("Apply() note = %q, want the meetings template", got.Note)
+	}
+}
+
+func TestApply_NilSet(t *testing.T) {
+	var set *Set
+	input := domain.TaskInput{Name: "Task", TagNames: []string{"bug"}}
+
+	got, err := set.Apply(input, SourceCLI)
+	if err != nil || got.Note != "" {
+		t.Errorf("Apply() = %+v, %v, want input unchanged", got, err)
+	}
+	if set.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", set.Len())
+	}
+}
@@ -0,0 +1,216 @@
+// Package rules applies user-defined automatic tagging and scheduling rules to tasks.
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Rule is a compiled rule: a set of conditions and the changes made when they all match
+type Rule struct {
+	Name string
+
+	namePattern *regexp.Regexp
+	project     string
+	tag         string
+
+	addTags  []string
+	deferStr string
+	dueStr   string
+	flag     bool
+}
+
+// Engine evaluates rules in configuration order
+type Engine struct {
+	rules []Rule
+	now   func() time.Time
+}
+
+// New compiles the configured rules, rejecting rules that are incomplete or invalid
+func New(cfgs []config.RuleConfig) (*Engine, error) {
+	engine := &Engine{now: time.Now}
+
+	for i, cfg := range cfgs {
+		name := cfg.Name
+		if name == "" {
+			name = fmt.Sprintf("rule %d", i+1)
+		}
+
+		rule := Rule{
+			Name:     name,
+			project:  strings.TrimSpace(cfg.Match.Project),
+			tag:      strings.TrimSpace(cfg.Match.Tag),
+			addTags:  cfg.Actions.AddTags,
+			deferStr: strings.TrimSpace(cfg.Actions.Defer),
+			dueStr:   strings.TrimSpace(cfg.Actions.Due),
+			flag:     cfg.Actions.Flag,
+		}
+
+		if cfg.Match.Name != "" {
+			pattern, err := regexp.Compile("(?i)" + cfg.Match.Name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid name pattern: %w", name, err)
+			}
+			rule.namePattern = pattern
+		}
+
+		if rule.namePattern == nil && rule.project == "" && rule.tag == "" {
+			return nil, fmt.Errorf("%s: at least one match condition is required", name)
+		}
+		if len(rule.addTags) == 0 && rule.deferStr == "" && rule.dueStr == "" && !rule.flag {
+			return nil, fmt.Errorf("%s: at least one action is required", name)
+		}
+
+		for _, date := range []string{rule.deferStr, rule.dueStr} {
+			if date == "" {
+				continue
+			}
+			if _, err := dateparse.Parse(date); err != nil {
+				return nil, fmt.Errorf("%s: invalid date: %w", name, err)
+			}
+		}
+
+		engine.rules = append(engine.rules, rule)
+	}
+
+	return engine, nil
+}
+
+// Len returns the number of rules
+func (e *Engine) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.rules)
+}
+
+// ApplyToInput applies matching rules to a task about to be created and returns
+// the updated input with the names of the rules that matched. Dates already set
+// on the input are kept.
+func (e *Engine) ApplyToInput(input domain.TaskInput) (domain.TaskInput, []string) {
+	if e == nil {
+		return input, nil
+	}
+
+	now := e.now()
+	input.TagNames = append([]string(nil), input.TagNames...)
+	var matched []string
+	for _, rule := range e.rules {
+		if !rule.matches(input.Name, []string{input.ProjectName, input.ProjectID}, input.TagNames) {
+			continue
+		}
+		matched = append(matched, rule.Name)
+
+		input.TagNames = appendMissing(input.TagNames, rule.addTags)
+		if input.DeferDate == nil {
+			input.DeferDate = rule.date(rule.deferStr, now)
+		}
+		if input.DueDate == nil {
+			input.DueDate = rule.date(rule.dueStr, now)
+		}
+		if rule.flag {
+			flagged := true
+			input.Flagged = &flagged
+		}
+	}
+
+	return input, matched
+}
+
+// ModificationFor returns the changes matching rules would make to an existing
+// task and the names of the rules that matched. The modification is empty when
+// the task already satisfies every matching rule.
+func (e *Engine) ModificationFor(task domain.Task) (domain.TaskModification, []string) {
+	var mod domain.TaskModification
+	if e == nil {
+		return mod, nil
+	}
+
+	now := e.now()
+	task.Tags = append([]string(nil), task.Tags...)
+	var matched []string
+	for _, rule := range e.rules {
+		if !rule.matches(task.Name, []string{task.ProjectName, task.ProjectID}, task.Tags) {
+			continue
+		}
+		matched = append(matched, rule.Name)
+
+		for _, tag := range rule.addTags {
+			if !containsFold(task.Tags, tag) {
+				task.Tags = append(task.Tags, tag)
+				mod.AddTags = append(mod.AddTags, tag)
+			}
+		}
+		if task.DeferDate == nil {
+			if date := rule.date(rule.deferStr, now); date != nil {
+				task.DeferDate = date
+				mod.DeferDate = date
+			}
+		}
+		if task.DueDate == nil {
+			if date := rule.date(rule.dueStr, now); date != nil {
+				task.DueDate = date
+				mod.DueDate = date
+			}
+		}
+		if rule.flag && !task.Flagged {
+			task.Flagged = true
+			flagged := true
+			mod.Flagged = &flagged
+		}
+	}
+
+	return mod, matched
+}
+
+// matches reports whether every condition of the rule holds
+func (r Rule) matches(name string, projects, tags []string) bool {
+	if r.namePattern != nil && !r.namePattern.MatchString(name) {
+		return false
+	}
+	if r.project != "" && !containsFold(projects, r.project) {
+		return false
+	}
+	if r.tag != "" && !containsFold(tags, r.tag) {
+		return false
+	}
+	return true
+}
+
+// date resolves a date action relative to now, or nil when the action is unset
+func (r Rule) date(value string, now time.Time) *time.Time {
+	if value == "" {
+		return nil
+	}
+	date, err := dateparse.ParseWithReference(value, now)
+	if err != nil {
+		return nil // Validated in New
+	}
+	return &date
+}
+
+// appendMissing appends the values not already present (case-insensitive)
+func appendMissing(list, values []string) []string {
+	for _, value := range values {
+		if !containsFold(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// containsFold reports whether list contains value, ignoring case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
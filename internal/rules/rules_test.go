@@ -0,0 +1,184 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Friday, January 19, 2024
+var testNow = time.Date(2024, 1, 19, 10, 0, 0, 0, time.Local)
+
+func newTestEngine(t *testing.T, cfgs ...config.RuleConfig) *Engine {
+	t.Helper()
+
+	engine, err := New(cfgs)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	engine.now = func() time.Time { return testNow }
+	return engine
+}
+
+var (
+	phoneRule = config.RuleConfig{
+		Name:    "Phone calls",
+		Match:   config.RuleMatchConfig{Name: "call|phone"},
+		Actions: config.RuleActionsConfig{AddTags: []string{"phone"}},
+	}
+	workRule = config.RuleConfig{
+		Name:    "Work defers",
+		Match:   config.RuleMatchConfig{Project: "work"},
+		Actions: config.RuleActionsConfig{Defer: "next weekday", Flag: true},
+	}
+)
+
+func TestNew_RejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.RuleConfig
+		wantErr string
+	}{
+		{"no condition", config.RuleConfig{Actions: config.RuleActionsConfig{Flag: true}}, "match condition"},
+		{"no action", config.RuleConfig{Match: config.RuleMatchConfig{Tag: "x"}}, "action"},
+		{"bad pattern", config.RuleConfig{Match: config.RuleMatchConfig{Name: "("}, Actions: config.RuleActionsConfig{Flag: true}}, "invalid name pattern"},
+		{"bad date", config.RuleConfig{Match: config.RuleMatchConfig{Tag: "x"}, Actions: config.RuleActionsConfig{Due: "someday"}}, "invalid date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New([]config.RuleConfig{tt.cfg})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "rule 1") {
+				t.Errorf("New() error = %v, want unnamed rule referenced by position", err)
+			}
+		})
+	}
+}
+
+func TestApplyToInput_MatchesNameCaseInsensitively(t *testing.T) {
+	engine := newTestEngine(t, phoneRule)
+
+	input, matched := engine.ApplyToInput(domain.TaskInput{Name: "CALL the bank", TagNames: []string{"errands"}})
+
+	if len(matched) != 1 || matched[0] != "Phone calls" {
+		t.Errorf("ApplyToInput() matched = %v, want [Phone calls]", matched)
+	}
+	if strings.Join(input.TagNames, ",") != "errands,phone" {
+		t.Errorf("ApplyToInput() tags = %v, want [errands phone]", input.TagNames)
+	}
+}
+
+func TestApplyToInput_ProjectRuleSetsDeferAndFlag(t *testing.T) {
+	engine := newTestEngine(t, workRule)
+
+	input, matched := engine.ApplyToInput(domain.TaskInput{Name: "Review PR", ProjectName: "Work"})
+
+	if len(matched) != 1 {
+		t.Fatalf("ApplyToInput() matched = %v, want 1 rule", matched)
+	}
+	want := time.Date(2024, 1, 22, 17, 0, 0, 0, time.Local) // Monday after Friday
+	if input.DeferDate == nil || !input.DeferDate.Equal(want) {
+		t.Errorf("ApplyToInput() defer = %v, want %v", input.DeferDate, want)
+	}
+	if input.Flagged == nil || !*input.Flagged {
+		t.Error("ApplyToInput() should flag the task")
+	}
+}
+
+func TestApplyToInput_KeepsExplicitDates(t *testing.T) {
+	engine := newTestEngine(t, workRule)
+	explicit := time.Date(2024, 2, 1, 9, 0, 0, 0, time.Local)
+
+	input, _ := engine.ApplyToInput(domain.TaskInput{Name: "Plan", ProjectName: "Work", DeferDate: &explicit})
+
+	if !input.DeferDate.Equal(explicit) {
+		t.Errorf("ApplyToInput() defer = %v, want explicit %v", input.DeferDate, explicit)
+	}
+}
+
+func TestApplyToInput_NoMatch(t *testing.T) {
+	engine := newTestEngine(t, phoneRule, workRule)
+	original := domain.TaskInput{Name: "Buy milk", ProjectName: "Home"}
+
+	input, matched := engine.ApplyToInput(original)
+
+	if len(matched) != 0 {
+		t.Errorf("ApplyToInput() matched = %v, want none", matched)
+	}
+	if len(input.TagNames) != 0 || input.DeferDate != nil || input.Flagged != nil {
+		t.Errorf("ApplyToInput() = %+v, want input unchanged", input)
+	}
+}
+
+func TestApplyToInput_RulesChainOnAddedTags(t *testing.T) {
+	urgentPhone := config.RuleConfig{
+		Match:   config.RuleMatchConfig{Tag: "phone"},
+		Actions: config.RuleActionsConfig{Due: "tomorrow"},
+	}
+	engine := newTestEngine(t, phoneRule, urgentPhone)
+
+	input, matched := engine.ApplyToInput(domain.TaskInput{Name: "Phone the plumber"})
+
+	if len(matched) != 2 {
+		t.Errorf("ApplyToInput() matched = %v, want 2 rules", matched)
+	}
+	if input.DueDate == nil {
+		t.Error("ApplyToInput() should set due date from the chained tag rule")
+	}
+}
+
+func TestModificationFor_OnlyIncludesMissingChanges(t *testing.T) {
+	engine := newTestEngine(t, phoneRule, workRule)
+	deferDate := testNow
+
+	mod, matched := engine.ModificationFor(domain.Task{
+		Name:        "Call client",
+		ProjectName: "Work",
+		Tags:        []string{"Phone"},
+		DeferDate:   &deferDate,
+	})
+
+	if len(matched) != 2 {
+		t.Errorf("ModificationFor() matched = %v, want 2 rules", matched)
+	}
+	if len(mod.AddTags) != 0 {
+		t.Errorf("ModificationFor() AddTags = %v, want none (tag already present)", mod.AddTags)
+	}
+	if mod.DeferDate != nil {
+		t.Error("ModificationFor() should keep the existing defer date")
+	}
+	if mod.Flagged == nil || !*mod.Flagged {
+		t.Error("ModificationFor() should flag the task")
+	}
+}
+
+func TestModificationFor_SatisfiedTaskIsEmpty(t *testing.T) {
+	engine := newTestEngine(t, phoneRule)
+
+	mod, matched := engine.ModificationFor(domain.Task{Name: "Call mom", Tags: []string{"phone"}})
+
+	if len(matched) != 1 {
+		t.Errorf("ModificationFor() matched = %v, want 1 rule", matched)
+	}
+	if !mod.IsEmpty() {
+		t.Errorf("ModificationFor() = %+v, want empty", mod)
+	}
+}
+
+func TestNilEngine(t *testing.T) {
+	var engine *Engine
+
+	if engine.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", engine.Len())
+	}
+	input, matched := engine.ApplyToInput(domain.TaskInput{Name: "Call"})
+	if input.Name != "Call" || matched != nil {
+		t.Errorf("ApplyToInput() = %+v, %v, want input unchanged", input, matched)
+	}
+}
@@ -0,0 +1,191 @@
+// Package scheduler runs actions on cron-like schedules.
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchYears bounds how far ahead Next looks for a matching time
+const searchYears = 5
+
+// descriptors maps the supported @ shorthands to their cron expressions
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// Schedule is a parsed cron expression. Each field is a bitmask of allowed values.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool
+	anyDow bool
+	spec   string
+}
+
+// field describes the range of one cron field
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7}, // 0 and 7 are both Sunday
+}
+
+// Parse parses a five-field cron expression (minute hour day-of-month month
+// day-of-week) or one of @hourly, @daily, @midnight, @weekly and @monthly.
+// Fields accept *, single values, ranges (1-5), lists (1,3) and steps (*/15).
+func Parse(spec string) (Schedule, error) {
+	expr := strings.TrimSpace(spec)
+	if d, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return Schedule{}, fmt.Errorf("invalid cron expression %q: expected %d fields, got %d", spec, len(fields), len(parts))
+	}
+
+	masks := make([]uint64, len(fields))
+	for i, part := range parts {
+		mask, err := parseField(part, fields[i])
+		if err != nil {
+			return Schedule{}, fmt.Errorf("invalid cron expression %q: %w", spec, err)
+		}
+		masks[i] = mask
+	}
+
+	// Fold day-of-week 7 onto 0 so both mean Sunday
+	dow := masks[4]
+	if dow&(1<<7) != 0 {
+		dow = dow&^(1<<7) | 1
+	}
+
+	return Schedule{
+		minute: masks[0],
+		hour:   masks[1],
+		dom:    masks[2],
+		month:  masks[3],
+		dow:    dow,
+		anyDom: parts[2] == "*",
+		anyDow: parts[4] == "*",
+		spec:   strings.TrimSpace(spec),
+	}, nil
+}
+
+// String returns the expression the schedule was parsed from
+func (s Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first matching minute strictly after t. It returns false when
+// no time matches within the next few years (e.g. "0 0 30 2 *").
+func (s Schedule) Next(t time.Time) (time.Time, bool) {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(searchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// dayMatches applies cron's day rule: when both day fields are restricted,
+// either may match; otherwise the restricted one must match
+func (s Schedule) dayMatches(t time.Time) bool {
+	domOK := has(s.dom, t.Day())
+	dowOK := has(s.dow, int(t.Weekday()))
+	switch {
+	case s.anyDom && s.anyDow:
+		return true
+	case s.anyDom:
+		return dowOK
+	case s.anyDow:
+		return domOK
+	default:
+		return domOK || dowOK
+	}
+}
+
+// parseField parses one comma-separated cron field into a bitmask
+func parseField(value string, f field) (uint64, error) {
+	var mask uint64
+	for _, item := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, item)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rangePart != "*" {
+			var err error
+			if lo, hi, err = parseRange(rangePart); err != nil {
+				return 0, fmt.Errorf("invalid %s field %q", f.name, item)
+			}
+			if hasStep && !strings.Contains(rangePart, "-") {
+				hi = f.max // "5/15" means from 5 to the end in steps of 15
+			}
+		}
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("%s field %q out of range %d-%d", f.name, item, f.min, f.max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+// parseRange parses "n" or "a-b"
+func parseRange(value string) (int, int, error) {
+	lo, hi, isRange := strings.Cut(value, "-")
+	start, err := strconv.Atoi(lo)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !isRange {
+		return start, start, nil
+	}
+	end, err := strconv.Atoi(hi)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// has reports whether bit v is set in mask
+func has(mask uint64, v int) bool {
+	return mask&(1<<uint(v)) != 0
+}
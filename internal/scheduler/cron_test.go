@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Friday, January 19, 2024 10:30:15
+var testNow = time.Date(2024, 1, 19, 10, 30, 15, 0, time.UTC)
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{"* * * *", "expected 5 fields"},
+		{"60 * * * *", "out of range"},
+		{"* 24 * * *", "out of range"},
+		{"* * 0 * *", "out of range"},
+		{"* * * 13 *", "out of range"},
+		{"* * * * 8", "out of range"},
+		{"*/0 * * * *", "invalid step"},
+		{"a * * * *", "invalid minute field"},
+		{"5-1 * * * *", "out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse(%q) error = %v, want error containing %q", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 19, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 19, 10, 45, 0, 0, time.UTC)},
+		{"0 7 * * *", time.Date(2024, 1, 20, 7, 0, 0, 0, time.UTC)},
+		{"0 7 * * 1-5", time.Date(2024, 1, 22, 7, 0, 0, 0, time.UTC)}, // Monday
+		{"30 10 * * *", time.Date(2024, 1, 20, 10, 30, 0, 0, time.UTC)},
+		{"0 9 1,15 * *", time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)},  // 7 is Sunday
+		{"0 0 13 * 5", time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC)}, // Either day field matches
+		{"5/20 * * * *", time.Date(2024, 1, 19, 10, 45, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 19, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.spec, err)
+			}
+			got, ok := schedule.Next(testNow)
+			if !ok || !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, %v, want %v", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextNeverMatches(t *testing.T) {
+	schedule, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if got, ok := schedule.Next(testNow); ok {
+		t.Errorf("Next() = %v, want no match", got)
+	}
+}
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoUpcomingRuns is returned by Run when no job has a future run time
+var ErrNoUpcomingRuns = errors.New("no scheduled job has an upcoming run")
+
+// Job is an action run whenever its schedule matches
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+}
+
+// Result reports the outcome of one job run
+type Result struct {
+	Job string
+	At  time.Time
+	Err error
+}
+
+// Scheduler runs jobs at the times their schedules match. Jobs due at the same
+// minute run one after another in the order they were added.
+type Scheduler struct {
+	jobs     []Job
+	onResult func(Result)
+
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+}
+
+// New creates a scheduler for the given jobs. onResult, if not nil, is called after every run.
+func New(jobs []Job, onResult func(Result)) *Scheduler {
+	return &Scheduler{
+		jobs:     jobs,
+		onResult: onResult,
+		now:      time.Now,
+		after:    time.After,
+	}
+}
+
+// NextRun returns the next time the named job will run
+func (s *Scheduler) NextRun(name string) (time.Time, bool) {
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return job.Schedule.Next(s.now())
+		}
+	}
+	return time.Time{}, false
+}
+
+// Run blocks, running jobs as they come due, until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		now := s.now()
+
+		var next time.Time
+		var due []Job
+		for _, job := range s.jobs {
+			at, ok := job.Schedule.Next(now)
+			switch {
+			case !ok:
+				continue
+			case next.IsZero() || at.Before(next):
+				next, due = at, []Job{job}
+			case at.Equal(next):
+				due = append(due, job)
+			}
+		}
+		if next.IsZero() {
+			return ErrNoUpcomingRuns
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.after(next.Sub(now)):
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		for _, job := range due {
+			err := job.Run(ctx)
+			if s.onResult != nil {
+				s.onResult(Result{Job: job.Name, At: next, Err: err})
+			}
+		}
+
+		// Never evaluate the same minute twice, even if the jobs finished early
+		if until := next.Sub(s.now()); until > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-s.after(until):
+			}
+		}
+	}
+}
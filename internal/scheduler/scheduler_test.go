@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock advances instantly when the scheduler waits
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) after(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func newTestScheduler(jobs []Job, onResult func(Result)) (*Scheduler, *fakeClock) {
+	clock := &fakeClock{now: testNow}
+	s := New(jobs, onResult)
+	s.now = func() time.Time { return clock.now }
+	s.after = clock.after
+	return s, clock
+}
+
+func mustParse(t *testing.T, spec string) Schedule {
+	t.Helper()
+	schedule, err := Parse(spec)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", spec, err)
+	}
+	return schedule
+}
+
+func TestScheduler_RunsJobsInTimeOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var results []Result
+	noop := func(context.Context) error { return nil }
+	failing := func(context.Context) error { return errors.New("boom") }
+	jobs := []Job{
+		{Name: "quarter", Schedule: mustParse(t, "*/15 * * * *"), Run: noop},
+		{Name: "hourly", Schedule: mustParse(t, "@hourly"), Run: failing},
+	}
+	s, _ := newTestScheduler(jobs, func(r Result) {
+		results = append(results, r)
+		if len(results) == 4 {
+			cancel()
+		}
+	})
+
+	if err := s.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []struct {
+		job    string
+		minute int
+	}{{"quarter", 45}, {"quarter", 0}, {"hourly", 0}, {"quarter", 15}}
+	for i, w := range want {
+		if results[i].Job != w.job || results[i].At.Minute() != w.minute {
+			t.Errorf("result %d = %s at %v, want %s at minute %d", i, results[i].Job, results[i].At, w.job, w.minute)
+		}
+	}
+	if results[2].Err == nil {
+		t.Error("expected the failing job's error to be reported")
+	}
+}
+
+func TestScheduler_NoUpcomingRuns(t *testing.T) {
+	jobs := []Job{{Name: "never", Schedule: mustParse(t, "0 0 30 2 *"), Run: func(context.Context) error { return nil }}}
+	s, _ := newTestScheduler(jobs, nil)
+
+	if err := s.Run(context.Background()); !errors.Is(err, ErrNoUpcomingRuns) {
+		t.Errorf("Run() error = %v, want ErrNoUpcomingRuns", err)
+	}
+}
+
+func TestScheduler_NextRun(t *testing.T) {
+	s, _ := newTestScheduler([]Job{{Name: "daily", Schedule: mustParse(t, "0 7 * * *")}}, nil)
+
+	got, ok := s.NextRun("daily")
+	if want := time.Date(2024, 1, 20, 7, 0, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("NextRun() = %v, %v, want %v", got, ok, want)
+	}
+	if _, ok := s.NextRun("missing"); ok {
+		t.Error("NextRun() should report unknown jobs")
+	}
+}
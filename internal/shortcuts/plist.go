@@ -0,0 +1,51 @@
+package shortcuts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dict is a property list dictionary, kept in order so output is stable
+type dict []entry
+
+// entry is one key of a dict
+type entry struct {
+	Key   string
+	Value any
+}
+
+// plistEscaper escapes text for XML
+var plistEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// writeValue writes v as XML property list markup, indented by depth tabs.
+// It supports strings, ints, bools, []any and dict.
+func writeValue(b *strings.Builder, v any, depth int) {
+	indent := strings.Repeat("\t", depth)
+	switch v := v.(type) {
+	case string:
+		fmt.Fprintf(b, "%s<string>%s</string>\n", indent, plistEscaper.Replace(v))
+	case int:
+		fmt.Fprintf(b, "%s<integer>%d</integer>\n", indent, v)
+	case bool:
+		fmt.Fprintf(b, "%s<%t/>\n", indent, v)
+	case []any:
+		if len(v) == 0 {
+			fmt.Fprintf(b, "%s<array/>\n", indent)
+			return
+		}
+		fmt.Fprintf(b, "%s<array>\n", indent)
+		for _, item := range v {
+			writeValue(b, item, depth+1)
+		}
+		fmt.Fprintf(b, "%s</array>\n", indent)
+	case dict:
+		fmt.Fprintf(b, "%s<dict>\n", indent)
+		for _, e := range v {
+			fmt.Fprintf(b, "%s\t<key>%s</key>\n", indent, plistEscaper.Replace(e.Key))
+			writeValue(b, e.Value, depth+1)
+		}
+		fmt.Fprintf(b, "%s</dict>\n", indent)
+	default:
+		panic(fmt.Sprintf("shortcuts: unsupported plist value %T", v))
+	}
+}
@@ -0,0 +1,144 @@
+// Package shortcuts builds Shortcuts.app shortcuts that run lazyfocus.
+package shortcuts
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+)
+
+// Shortcut is a shortcut that optionally asks for text, runs lazyfocus with
+// it and shows the result
+type Shortcut struct {
+	Name   string
+	Prompt string   // Question asked first; empty to run without input
+	Args   []string // lazyfocus arguments; InputArg is replaced by the answer
+}
+
+// InputArg stands for the answer to the shortcut's prompt in its arguments
+const InputArg = "{input}"
+
+// Builtin lists the shortcuts lazyfocus shortcuts install adds
+var Builtin = []Shortcut{
+	{
+		Name:   "Add to OmniFocus",
+		Prompt: "What do you want to add?",
+		Args:   []string{"add", InputArg, "--shortcut-output"},
+	},
+	{
+		Name: "Today's Tasks",
+		Args: []string{"tasks", "--all", "--due", "today", "--shortcut-output"},
+	},
+	{
+		Name:   "Complete in OmniFocus",
+		Prompt: "Which task did you finish?",
+		Args:   []string{"done", InputArg, "--yes", "--shortcut-output"},
+	},
+}
+
+// Script returns the shell command the shortcut runs, calling binary with the
+// answer to the prompt as $1
+func (s Shortcut) Script(binary string) string {
+	words := []string{shellQuote(binary)}
+	for _, arg := range s.Args {
+		if arg == InputArg {
+			words = append(words, `"$1"`)
+		} else {
+			words = append(words, shellQuote(arg))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Plist returns the unsigned shortcut file, an XML property list in the
+// format Shortcuts.app imports once signed with `shortcuts sign`
+func (s Shortcut) Plist(binary string) []byte {
+	askUUID := s.uuid("ask")
+	scriptUUID := s.uuid("script")
+
+	var actions []any
+	scriptParams := dict{
+		{"Script", s.Script(binary)},
+		{"Shell", "/bin/zsh"},
+		{"InputMode", "as arguments"},
+		{"UUID", scriptUUID},
+	}
+	if s.Prompt != "" {
+		actions = append(actions, action("is.workflow.actions.ask", dict{
+			{"WFAskActionPrompt", s.Prompt},
+			{"UUID", askUUID},
+		}))
+		scriptParams = append(scriptParams, entry{"Input", dict{
+			{"Value", outputRef(askUUID, "Provided Input")},
+			{"WFSerializationType", "WFTextTokenAttachment"},
+		}})
+	}
+	actions = append(actions,
+		action("is.workflow.actions.runshellscript", scriptParams),
+		action("is.workflow.actions.showresult", dict{
+			{"Text", dict{
+				{"Value", dict{
+					{"attachmentsByRange", dict{{"{0, 1}", outputRef(scriptUUID, "Shell Script Result")}}},
+					{"string", "￼"},
+				}},
+				{"WFSerializationType", "WFTextTokenString"},
+			}},
+		}),
+	)
+
+	workflow := dict{
+		{"WFWorkflowActions", actions},
+		{"WFWorkflowClientVersion", "1146.14"},
+		{"WFWorkflowMinimumClientVersion", 900},
+		{"WFWorkflowMinimumClientVersionString", "900"},
+		{"WFWorkflowIcon", dict{
+			{"WFWorkflowIconStartColor", 4282601983},
+			{"WFWorkflowIconGlyphNumber", 59511},
+		}},
+		{"WFWorkflowImportQuestions", []any{}},
+		{"WFWorkflowInputContentItemClasses", []any{}},
+		{"WFWorkflowTypes", []any{}},
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n")
+	writeValue(&b, workflow, 0)
+	b.WriteString("</plist>\n")
+	return []byte(b.String())
+}
+
+// FileName returns the file name Shortcuts.app names the shortcut after
+func (s Shortcut) FileName() string {
+	return strings.NewReplacer("/", "-", ":", "-").Replace(s.Name) + ".shortcut"
+}
+
+// uuid returns a UUID for one of the shortcut's actions, the same on every
+// build so generated files only change when the shortcut does
+func (s Shortcut) uuid(part string) string {
+	sum := sha1.Sum([]byte(s.Name + "/" + part))
+	return strings.ToUpper(fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16]))
+}
+
+// action builds a shortcut action
+func action(identifier string, params dict) dict {
+	return dict{
+		{"WFWorkflowActionIdentifier", identifier},
+		{"WFWorkflowActionParameters", params},
+	}
+}
+
+// outputRef refers to the output of an earlier action
+func outputRef(uuid, name string) dict {
+	return dict{
+		{"OutputName", name},
+		{"OutputUUID", uuid},
+		{"Type", "ActionOutput"},
+	}
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
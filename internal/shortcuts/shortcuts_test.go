@@ -0,0 +1,81 @@
+package shortcuts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScript_QuotesArgumentsAndPassesInput(t *testing.T) {
+	s := Shortcut{Name: "Add", Prompt: "What?", Args: []string{"add", InputArg, "--shortcut-output"}}
+
+	got := s.Script("/Users/me/it's/lazyfocus")
+	want := `'/Users/me/it'\''s/lazyfocus' 'add' "$1" '--shortcut-output'`
+	if got != want {
+		t.Errorf("Script() = %q, want %q", got, want)
+	}
+}
+
+func TestPlist_AskAndRunScript(t *testing.T) {
+	s := Builtin[0]
+
+	plist := string(s.Plist("/usr/local/bin/lazyfocus"))
+
+	for _, want := range []string{
+		`<plist version="1.0">`,
+		"<string>is.workflow.actions.ask</string>",
+		"<string>What do you want to add?</string>",
+		"<string>is.workflow.actions.runshellscript</string>",
+		`<string>'/usr/local/bin/lazyfocus' 'add' "$1" '--shortcut-output'</string>`,
+		"<string>Provided Input</string>",
+		"<string>is.workflow.actions.showresult</string>",
+		"<string>Shell Script Result</string>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("Plist() missing %q", want)
+		}
+	}
+}
+
+func TestPlist_WithoutPromptSkipsAsk(t *testing.T) {
+	s := Shortcut{Name: "Today", Args: []string{"tasks"}}
+
+	plist := string(s.Plist("lazyfocus"))
+
+	if strings.Contains(plist, "is.workflow.actions.ask") || strings.Contains(plist, "Provided Input") {
+		t.Errorf("Plist() asks for input without a prompt:\n%s", plist)
+	}
+}
+
+func TestPlist_EscapesXML(t *testing.T) {
+	s := Shortcut{Name: "Tasks", Prompt: "Work & <home>?", Args: []string{"tasks"}}
+
+	plist := string(s.Plist("lazyfocus"))
+
+	if !strings.Contains(plist, "<string>Work &amp; &lt;home&gt;?</string>") {
+		t.Errorf("Plist() did not escape the prompt:\n%s", plist)
+	}
+}
+
+func TestPlist_Stable(t *testing.T) {
+	for _, s := range Builtin {
+		if first, second := string(s.Plist("lazyfocus")), string(s.Plist("lazyfocus")); first != second {
+			t.Errorf("Plist() for %q differs between calls", s.Name)
+		}
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Today's Tasks", "Today's Tasks.shortcut"},
+		{"Work/Home: Next", "Work-Home- Next.shortcut"},
+	}
+
+	for _, tt := range tests {
+		if got := (Shortcut{Name: tt.name}).FileName(); got != tt.want {
+			t.Errorf("FileName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
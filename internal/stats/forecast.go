@@ -0,0 +1,56 @@
+// Package stats derives metrics such as completion forecasts from OmniFocus data.
+package stats
+
+import (
+	"math"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// ForecastWindowDays is the number of trailing days used to measure a project's completion rate
+const ForecastWindowDays = 28
+
+// ProjectForecast holds the projected completion date for a project
+type ProjectForecast struct {
+	ProjectID   string     `json:"projectId"`
+	ProjectName string     `json:"projectName"`
+	Remaining   int        `json:"remaining"`
+	RatePerWeek float64    `json:"ratePerWeek"`
+	Estimate    *time.Time `json:"estimate,omitempty"` // nil when there is no recent progress
+}
+
+// HasEstimate reports whether a projected completion date could be computed
+func (f ProjectForecast) HasEstimate() bool {
+	return f.Estimate != nil
+}
+
+// ForecastProject estimates when a project will be finished at its recent completion rate
+func ForecastProject(project domain.Project, now time.Time) ProjectForecast {
+	forecast := ProjectForecast{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Remaining:   project.TaskCount,
+		RatePerWeek: float64(project.CompletedRecently) * 7 / ForecastWindowDays,
+	}
+
+	if project.TaskCount <= 0 || project.CompletedRecently <= 0 {
+		return forecast
+	}
+
+	ratePerDay := float64(project.CompletedRecently) / ForecastWindowDays
+	days := int(math.Ceil(float64(project.TaskCount) / ratePerDay))
+	estimate := now.AddDate(0, 0, days)
+	forecast.Estimate = &estimate
+
+	return forecast
+}
+
+// ForecastProjects computes forecasts for the given projects, preserving their order
+func ForecastProjects(projects []domain.Project, now time.Time) []ProjectForecast {
+	forecasts := make([]ProjectForecast, 0, len(projects))
+	for _, project := range projects {
+		forecasts = append(forecasts, ForecastProject(project, now))
+	}
+	return forecasts
+}
@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestForecastProject(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		project      domain.Project
+		wantEstimate *time.Time
+		wantRate     float64
+	}{
+		{
+			name:         "no recent completions",
+			project:      domain.Project{ID: "p1", Name: "Stalled", TaskCount: 5},
+			wantEstimate: nil,
+			wantRate:     0,
+		},
+		{
+			name:         "nothing remaining",
+			project:      domain.Project{ID: "p2", Name: "Done", CompletedRecently: 4},
+			wantEstimate: nil,
+			wantRate:     1,
+		},
+		{
+			name:         "one task per week",
+			project:      domain.Project{ID: "p3", Name: "Steady", TaskCount: 2, CompletedRecently: 4},
+			wantEstimate: timePtr(now.AddDate(0, 0, 14)),
+			wantRate:     1,
+		},
+		{
+			name:         "rounds partial days up",
+			project:      domain.Project{ID: "p4", Name: "Busy", TaskCount: 10, CompletedRecently: 3},
+			wantEstimate: timePtr(now.AddDate(0, 0, 94)),
+			wantRate:     0.75,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ForecastProject(tt.project, now)
+
+			if got.ProjectID != tt.project.ID {
+				t.Errorf("ProjectID = %q, want %q", got.ProjectID, tt.project.ID)
+			}
+			if got.Remaining != tt.project.TaskCount {
+				t.Errorf("Remaining = %d, want %d", got.Remaining, tt.project.TaskCount)
+			}
+			if got.RatePerWeek != tt.wantRate {
+				t.Errorf("RatePerWeek = %v, want %v", got.RatePerWeek, tt.wantRate)
+			}
+			if tt.wantEstimate == nil {
+				if got.HasEstimate() {
+					t.Errorf("Estimate = %v, want nil", got.Estimate)
+				}
+				return
+			}
+			if !got.HasEstimate() || !got.Estimate.Equal(*tt.wantEstimate) {
+				t.Errorf("Estimate = %v, want %v", got.Estimate, *tt.wantEstimate)
+			}
+		})
+	}
+}
+
+func TestForecastProjects_PreservesOrder(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	projects := []domain.Project{
+		{ID: "b", Name: "Second"},
+		{ID: "a", Name: "First"},
+	}
+
+	got := ForecastProjects(projects, now)
+
+	if len(got) != 2 {
+		t.Fatalf("ForecastProjects() returned %d forecasts, want 2", len(got))
+	}
+	if got[0].ProjectID != "b" || got[1].ProjectID != "a" {
+		t.Errorf("ForecastProjects() order = [%s %s], want [b a]", got[0].ProjectID, got[1].ProjectID)
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,94 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Heatmap dimensions
+const (
+	HeatmapWeeks  = 12 // Number of weeks shown
+	HeatmapLevels = 5  // Intensity buckets, 0 (none) through 4 (busiest)
+)
+
+// HeatmapDay holds the number of tasks completed on a single day
+type HeatmapDay struct {
+	Date  time.Time `json:"date"`
+	Count int       `json:"count"`
+}
+
+// Heatmap holds daily completion counts grouped into Monday-first weeks.
+// The last week is cut off at today, so it may hold fewer than seven days.
+type Heatmap struct {
+	Weeks [][]HeatmapDay
+	Max   int
+	Total int
+}
+
+// HeatmapStart returns the Monday that begins a heatmap of the given number of weeks ending today
+func HeatmapStart(now time.Time, weeks int) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	offset := (int(today.Weekday()) + 6) % 7 // days since Monday
+	return today.AddDate(0, 0, -offset-(weeks-1)*7)
+}
+
+// BuildHeatmap counts completed tasks per day for the given number of weeks ending today
+func BuildHeatmap(tasks []domain.Task, now time.Time, weeks int) Heatmap {
+	start := HeatmapStart(now, weeks)
+
+	counts := make(map[string]int)
+	for _, task := range tasks {
+		if task.CompletedDate == nil {
+			continue
+		}
+		counts[task.CompletedDate.In(now.Location()).Format("2006-01-02")]++
+	}
+
+	var heatmap Heatmap
+	today := now.Format("2006-01-02")
+	for w := 0; w < weeks; w++ {
+		week := make([]HeatmapDay, 0, 7)
+		for d := 0; d < 7; d++ {
+			date := start.AddDate(0, 0, w*7+d)
+			key := date.Format("2006-01-02")
+			if key > today {
+				break
+			}
+			count := counts[key]
+			week = append(week, HeatmapDay{Date: date, Count: count})
+			heatmap.Total += count
+			if count > heatmap.Max {
+				heatmap.Max = count
+			}
+		}
+		heatmap.Weeks = append(heatmap.Weeks, week)
+	}
+
+	return heatmap
+}
+
+// Days returns every day in the heatmap in chronological order
+func (h Heatmap) Days() []HeatmapDay {
+	var days []HeatmapDay
+	for _, week := range h.Weeks {
+		days = append(days, week...)
+	}
+	return days
+}
+
+// Level returns the intensity bucket (0 to HeatmapLevels-1) for a day's count
+func (h Heatmap) Level(count int) int {
+	if count <= 0 || h.Max <= 0 {
+		return 0
+	}
+	top := HeatmapLevels - 1
+	level := (count*top + h.Max - 1) / h.Max
+	if level < 1 {
+		level = 1
+	}
+	if level > top {
+		level = top
+	}
+	return level
+}
@@ -0,0 +1,91 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestHeatmapStart(t *testing.T) {
+	// Wednesday, January 17, 2024
+	now := time.Date(2024, 1, 17, 15, 30, 0, 0, time.UTC)
+
+	got := HeatmapStart(now, 2)
+	want := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	if !got.Equal(want) {
+		t.Errorf("HeatmapStart() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildHeatmap(t *testing.T) {
+	// Wednesday, January 17, 2024
+	now := time.Date(2024, 1, 17, 15, 30, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+	tasks := []domain.Task{
+		{ID: "t1", CompletedDate: at(8, 9)},
+		{ID: "t2", CompletedDate: at(8, 17)},
+		{ID: "t3", CompletedDate: at(17, 10)},
+		{ID: "t4", CompletedDate: at(1, 10)}, // before the heatmap starts
+		{ID: "t5"},                           // no completion date
+	}
+
+	heatmap := BuildHeatmap(tasks, now, 2)
+
+	if len(heatmap.Weeks) != 2 {
+		t.Fatalf("BuildHeatmap() returned %d weeks, want 2", len(heatmap.Weeks))
+	}
+	if len(heatmap.Weeks[0]) != 7 {
+		t.Errorf("first week has %d days, want 7", len(heatmap.Weeks[0]))
+	}
+	if len(heatmap.Weeks[1]) != 3 {
+		t.Errorf("current week has %d days, want 3 (Mon-Wed)", len(heatmap.Weeks[1]))
+	}
+	if heatmap.Weeks[0][0].Count != 2 {
+		t.Errorf("Jan 8 count = %d, want 2", heatmap.Weeks[0][0].Count)
+	}
+	if heatmap.Weeks[1][2].Count != 1 {
+		t.Errorf("Jan 17 count = %d, want 1", heatmap.Weeks[1][2].Count)
+	}
+	if heatmap.Total != 3 {
+		t.Errorf("Total = %d, want 3", heatmap.Total)
+	}
+	if heatmap.Max != 2 {
+		t.Errorf("Max = %d, want 2", heatmap.Max)
+	}
+	if len(heatmap.Days()) != 10 {
+		t.Errorf("Days() returned %d days, want 10", len(heatmap.Days()))
+	}
+}
+
+func TestHeatmap_Level(t *testing.T) {
+	heatmap := Heatmap{Max: 10}
+
+	tests := []struct {
+		count int
+		want  int
+	}{
+		{0, 0},
+		{1, 1},
+		{3, 2},
+		{5, 2},
+		{6, 3},
+		{8, 4},
+		{10, 4},
+		{15, 4},
+	}
+
+	for _, tt := range tests {
+		if got := heatmap.Level(tt.count); got != tt.want {
+			t.Errorf("Level(%d) = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+
+	if got := (Heatmap{}).Level(3); got != 0 {
+		t.Errorf("Level() with no completions = %d, want 0", got)
+	}
+}
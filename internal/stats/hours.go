@@ -0,0 +1,113 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// HoursInDay is the number of hourly buckets in a time-of-day profile
+const HoursInDay = 24
+
+// MinBestHourSamples is the number of completions needed before a best-time hint is given
+const MinBestHourSamples = 5
+
+// NoProjectName groups completed tasks that do not belong to a project
+const NoProjectName = "Inbox"
+
+// HourlyProfile counts completed tasks per hour of day for one project, tag or overall
+type HourlyProfile struct {
+	Name   string          `json:"name"`
+	Counts [HoursInDay]int `json:"counts"`
+	Total  int             `json:"total"`
+}
+
+// Max returns the highest hourly count in the profile
+func (p HourlyProfile) Max() int {
+	top := 0
+	for _, count := range p.Counts {
+		if count > top {
+			top = count
+		}
+	}
+	return top
+}
+
+// BestHour returns the hour with the most completions; ok is false when there
+// are too few completions for the hint to be meaningful. Ties go to the earlier hour.
+func (p HourlyProfile) BestHour() (hour int, ok bool) {
+	if p.Total < MinBestHourSamples {
+		return 0, false
+	}
+	for h, count := range p.Counts {
+		if count > p.Counts[hour] {
+			hour = h
+		}
+	}
+	return hour, true
+}
+
+func (p *HourlyProfile) add(t time.Time) {
+	p.Counts[t.Hour()]++
+	p.Total++
+}
+
+// CompletionHours builds a single profile covering every completed task
+func CompletionHours(tasks []domain.Task, loc *time.Location) HourlyProfile {
+	profile := HourlyProfile{Name: "All"}
+	for _, task := range tasks {
+		if task.CompletedDate != nil {
+			profile.add(task.CompletedDate.In(loc))
+		}
+	}
+	return profile
+}
+
+// CompletionHoursByProject builds one profile per project, busiest first
+func CompletionHoursByProject(tasks []domain.Task, loc *time.Location) []HourlyProfile {
+	return groupHours(tasks, loc, func(task domain.Task) []string {
+		if task.ProjectName == "" {
+			return []string{NoProjectName}
+		}
+		return []string{task.ProjectName}
+	})
+}
+
+// CompletionHoursByTag builds one profile per tag, busiest first; a task counts once for each of its tags
+func CompletionHoursByTag(tasks []domain.Task, loc *time.Location) []HourlyProfile {
+	return groupHours(tasks, loc, func(task domain.Task) []string {
+		return task.Tags
+	})
+}
+
+// groupHours aggregates completion hours under the keys returned for each task
+func groupHours(tasks []domain.Task, loc *time.Location, keys func(domain.Task) []string) []HourlyProfile {
+	byName := make(map[string]*HourlyProfile)
+	for _, task := range tasks {
+		if task.CompletedDate == nil {
+			continue
+		}
+		completed := task.CompletedDate.In(loc)
+		for _, name := range keys(task) {
+			profile, ok := byName[name]
+			if !ok {
+				profile = &HourlyProfile{Name: name}
+				byName[name] = profile
+			}
+			profile.add(completed)
+		}
+	}
+
+	profiles := make([]HourlyProfile, 0, len(byName))
+	for _, profile := range byName {
+		profiles = append(profiles, *profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].Total != profiles[j].Total {
+			return profiles[i].Total > profiles[j].Total
+		}
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles
+}
@@ -0,0 +1,116 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func completedAt(hour int) *time.Time {
+	t := time.Date(2024, 1, 15, hour, 30, 0, 0, time.UTC)
+	return &t
+}
+
+func TestCompletionHours(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "t1", CompletedDate: completedAt(9)},
+		{ID: "t2", CompletedDate: completedAt(9)},
+		{ID: "t3", CompletedDate: completedAt(14)},
+		{ID: "t4"}, // no completion date
+	}
+
+	profile := CompletionHours(tasks, time.UTC)
+
+	if profile.Total != 3 {
+		t.Errorf("Total = %d, want 3", profile.Total)
+	}
+	if profile.Counts[9] != 2 || profile.Counts[14] != 1 {
+		t.Errorf("Counts[9], Counts[14] = %d, %d, want 2, 1", profile.Counts[9], profile.Counts[14])
+	}
+	if profile.Max() != 2 {
+		t.Errorf("Max() = %d, want 2", profile.Max())
+	}
+}
+
+func TestCompletionHours_UsesLocation(t *testing.T) {
+	tasks := []domain.Task{{ID: "t1", CompletedDate: completedAt(9)}}
+	loc := time.FixedZone("UTC+2", 2*60*60)
+
+	profile := CompletionHours(tasks, loc)
+
+	if profile.Counts[11] != 1 {
+		t.Errorf("Counts[11] = %d, want 1 (09:30 UTC is 11:30 in UTC+2)", profile.Counts[11])
+	}
+}
+
+func TestCompletionHoursByProject(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "t1", ProjectName: "Work", CompletedDate: completedAt(9)},
+		{ID: "t2", ProjectName: "Work", CompletedDate: completedAt(10)},
+		{ID: "t3", ProjectName: "Home", CompletedDate: completedAt(20)},
+		{ID: "t4", CompletedDate: completedAt(8)},
+	}
+
+	profiles := CompletionHoursByProject(tasks, time.UTC)
+
+	if len(profiles) != 3 {
+		t.Fatalf("CompletionHoursByProject() returned %d profiles, want 3", len(profiles))
+	}
+	wantOrder := []string{"Work", "Home", NoProjectName}
+	for i, want := range wantOrder {
+		if profiles[i].Name != want {
+			t.Errorf("profiles[%d].Name = %q, want %q", i, profiles[i].Name, want)
+		}
+	}
+	if profiles[0].Total != 2 {
+		t.Errorf("Work Total = %d, want 2", profiles[0].Total)
+	}
+}
+
+func TestCompletionHoursByTag_CountsEachTag(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "t1", Tags: []string{"email", "quick"}, CompletedDate: completedAt(8)},
+		{ID: "t2", Tags: []string{"email"}, CompletedDate: completedAt(8)},
+		{ID: "t3", CompletedDate: completedAt(8)}, // untagged
+	}
+
+	profiles := CompletionHoursByTag(tasks, time.UTC)
+
+	if len(profiles) != 2 {
+		t.Fatalf("CompletionHoursByTag() returned %d profiles, want 2", len(profiles))
+	}
+	if profiles[0].Name != "email" || profiles[0].Total != 2 {
+		t.Errorf("profiles[0] = %s/%d, want email/2", profiles[0].Name, profiles[0].Total)
+	}
+	if profiles[1].Name != "quick" || profiles[1].Total != 1 {
+		t.Errorf("profiles[1] = %s/%d, want quick/1", profiles[1].Name, profiles[1].Total)
+	}
+}
+
+func TestHourlyProfile_BestHour(t *testing.T) {
+	tests := []struct {
+		name     string
+		hours    []int
+		wantHour int
+		wantOK   bool
+	}{
+		{"too few samples", []int{9, 9, 9}, 0, false},
+		{"clear peak", []int{9, 14, 14, 14, 20}, 14, true},
+		{"tie goes to earlier hour", []int{7, 7, 16, 16, 22}, 7, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var profile HourlyProfile
+			for _, h := range tt.hours {
+				profile.add(*completedAt(h))
+			}
+
+			hour, ok := profile.BestHour()
+			if ok != tt.wantOK || (ok && hour != tt.wantHour) {
+				t.Errorf("BestHour() = %d, %v, want %d, %v", hour, ok, tt.wantHour, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// SparklineDays is the number of days the daily completion sparkline covers
+const SparklineDays = 14
+
+// NamedCount is the number of open tasks in a project or with a tag
+type NamedCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Summary holds the headline numbers of the stats dashboard
+type Summary struct {
+	CompletedToday    int          `json:"completed_today"`
+	CompletedThisWeek int          `json:"completed_this_week"` // Since Monday
+	Overdue           int          `json:"overdue"`
+	Daily             []int        `json:"daily"`    // Completions per day for SparklineDays days, ending today
+	Projects          []NamedCount `json:"projects"` // Open tasks per project, most first
+	Tags              []NamedCount `json:"tags"`     // Open tasks per tag, most first
+}
+
+// Summarize counts completions from the completed tasks and overdue tasks,
+// projects and tags from the remaining ones
+func Summarize(completed, remaining []domain.Task, now time.Time) Summary {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monday := HeatmapStart(now, 1)
+	first := today.AddDate(0, 0, -(SparklineDays - 1))
+
+	s := Summary{Daily: make([]int, SparklineDays)}
+	for _, task := range completed {
+		if task.CompletedDate == nil {
+			continue
+		}
+		done := task.CompletedDate.In(now.Location())
+		if !done.Before(today) {
+			s.CompletedToday++
+		}
+		if !done.Before(monday) {
+			s.CompletedThisWeek++
+		}
+		if day := time.Date(done.Year(), done.Month(), done.Day(), 0, 0, 0, 0, now.Location()); !day.Before(first) && !day.After(today) {
+			// Count calendar days rather than 24-hour spans across daylight saving changes
+			s.Daily[int(day.Sub(first).Hours()+12)/24]++
+		}
+	}
+
+	projects := make(map[string]int)
+	tags := make(map[string]int)
+	for _, task := range remaining {
+		if task.Completed {
+			continue
+		}
+		if task.DueDate != nil && task.DueDate.Before(now) {
+			s.Overdue++
+		}
+		name := task.ProjectName
+		if name == "" {
+			name = NoProjectName
+		}
+		projects[name]++
+		for _, tag := range task.Tags {
+			tags[tag]++
+		}
+	}
+	s.Projects = sortedCounts(projects)
+	s.Tags = sortedCounts(tags)
+	return s
+}
+
+// sortedCounts lists counts by name, most first, ties by name
+func sortedCounts(counts map[string]int) []NamedCount {
+	list := make([]NamedCount, 0, len(counts))
+	for name, count := range counts {
+		list = append(list, NamedCount{Name: name, Count: count})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
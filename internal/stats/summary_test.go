@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestSummarize(t *testing.T) {
+	// Wednesday, January 17, 2024
+	now := time.Date(2024, 1, 17, 15, 0, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour int) *time.Time {
+		t := time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	completed := []domain.Task{
+		{ID: "c1", CompletedDate: at(1, 17, 9)},  // Today
+		{ID: "c2", CompletedDate: at(1, 15, 10)}, // Monday
+		{ID: "c3", CompletedDate: at(1, 14, 10)}, // Last Sunday
+		{ID: "c4", CompletedDate: at(1, 4, 10)},  // First day of the sparkline
+		{ID: "c5", CompletedDate: at(1, 3, 10)},  // Before the sparkline
+		{ID: "c6"},
+	}
+	remaining := []domain.Task{
+		{ID: "r1", ProjectName: "Work", Tags: []string{"doing"}, DueDate: at(1, 16, 17)},
+		{ID: "r2", ProjectName: "Work", Tags: []string{"doing", "phone"}},
+		{ID: "r3", Tags: []string{"phone"}, DueDate: at(1, 18, 17)},
+		{ID: "r4", ProjectName: "Home", Completed: true},
+	}
+
+	s := Summarize(completed, remaining, now)
+
+	if s.CompletedToday != 1 || s.CompletedThisWeek != 2 || s.Overdue != 1 {
+		t.Errorf("today, week, overdue = %d, %d, %d, want 1, 2, 1", s.CompletedToday, s.CompletedThisWeek, s.Overdue)
+	}
+
+	wantDaily := make([]int, SparklineDays)
+	wantDaily[0] = 1  // Jan 4
+	wantDaily[10] = 1 // Jan 14
+	wantDaily[11] = 1 // Jan 15
+	wantDaily[13] = 1 // Jan 17
+	if !reflect.DeepEqual(s.Daily, wantDaily) {
+		t.Errorf("Daily = %v, want %v", s.Daily, wantDaily)
+	}
+
+	wantProjects := []NamedCount{{Name: "Work", Count: 2}, {Name: NoProjectName, Count: 1}}
+	if !reflect.DeepEqual(s.Projects, wantProjects) {
+		t.Errorf("Projects = %+v, want %+v", s.Projects, wantProjects)
+	}
+	wantTags := []NamedCount{{Name: "doing", Count: 2}, {Name: "phone", Count: 2}}
+	if !reflect.DeepEqual(s.Tags, wantTags) {
+		t.Errorf("Tags = %+v, want %+v", s.Tags, wantTags)
+	}
+}
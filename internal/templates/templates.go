@@ -0,0 +1,288 @@
+// Package templates instantiates configured project templates with variables.
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ItemVariable holds the current value in tasks repeated with "each"
+const ItemVariable = "Item"
+
+// Variable is a value supplied when a template is applied
+type Variable struct {
+	Name    string
+	Prompt  string
+	Default string
+}
+
+// Required reports whether the variable has no default
+func (v Variable) Required() bool {
+	return v.Default == ""
+}
+
+// Template is a compiled project template
+type Template struct {
+	Name        string
+	Description string
+	Variables   []Variable
+
+	project *template.Template
+	note    *template.Template
+	due     *template.Template
+	deferTo *template.Template
+	tasks   []taskTemplate
+}
+
+// taskTemplate holds the compiled fields of one template task
+type taskTemplate struct {
+	name    *template.Template
+	note    *template.Template
+	due     *template.Template
+	deferTo *template.Template
+	tags    []string
+	flag    bool
+	each    string
+}
+
+// Plan is the project and tasks a rendered template creates
+type Plan struct {
+	Project domain.ProjectInput
+	Tasks   []domain.TaskInput
+}
+
+// New compiles a template, rejecting invalid variables and references to undeclared ones
+func New(cfg config.TemplateConfig) (*Template, error) {
+	if strings.TrimSpace(cfg.Name) == "" {
+		return nil, fmt.Errorf("template name is required")
+	}
+	if strings.TrimSpace(cfg.Project) == "" {
+		return nil, fmt.Errorf("template %s: project name is required", cfg.Name)
+	}
+
+	t := &Template{Name: cfg.Name, Description: cfg.Description}
+	seen := make(map[string]bool)
+	for _, v := range cfg.Variables {
+		if !variableNamePattern.MatchString(v.Name) {
+			return nil, fmt.Errorf("template %s: invalid variable name %q", cfg.Name, v.Name)
+		}
+		if seen[v.Name] {
+			return nil, fmt.Errorf("template %s: duplicate variable %q", cfg.Name, v.Name)
+		}
+		seen[v.Name] = true
+		t.Variables = append(t.Variables, Variable{Name: v.Name, Prompt: v.Prompt, Default: v.Default})
+	}
+
+	c := compiler{template: cfg.Name, sample: t.sampleValues()}
+	t.project = c.compile("project", cfg.Project)
+	t.note = c.compile("note", cfg.Note)
+	t.due = c.compile("due", cfg.Due)
+	t.deferTo = c.compile("defer", cfg.Defer)
+	for i, task := range cfg.Tasks {
+		field := fmt.Sprintf("task %d", i+1)
+		tc := c
+		if task.Each != "" {
+			if !seen[task.Each] {
+				return nil, fmt.Errorf("template %s: %s repeats over undeclared variable %q", cfg.Name, field, task.Each)
+			}
+			tc = compiler{template: cfg.Name, sample: t.sampleValues()}
+			tc.sample[ItemVariable] = "x"
+		}
+		t.tasks = append(t.tasks, taskTemplate{
+			name:    tc.compile(field+" name", task.Name),
+			note:    tc.compile(field+" note", task.Note),
+			due:     tc.compile(field+" due", task.Due),
+			deferTo: tc.compile(field+" defer", task.Defer),
+			tags:    task.Tags,
+			flag:    task.Flag,
+			each:    task.Each,
+		})
+		if c.err == nil {
+			c.err = tc.err
+		}
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return t, nil
+}
+
+// Find compiles the template with the given name (case-insensitive)
+func Find(cfgs []config.TemplateConfig, name string) (*Template, error) {
+	for _, cfg := range cfgs {
+		if strings.EqualFold(cfg.Name, name) {
+			return New(cfg)
+		}
+	}
+	return nil, fmt.Errorf("template not found: %s", name)
+}
+
+// HasVariable reports whether the template declares the named variable
+func (t *Template) HasVariable(name string) bool {
+	for _, v := range t.Variables {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Render fills in the variables and resolves dates relative to now. Variables
+// without a value fall back to their default; required variables must be set.
+func (t *Template) Render(values map[string]string, now time.Time) (Plan, error) {
+	data := make(map[string]string, len(t.Variables))
+	for _, v := range t.Variables {
+		value, ok := values[v.Name]
+		if !ok || value == "" {
+			value = v.Default
+		}
+		if value == "" {
+			return Plan{}, fmt.Errorf("missing value for variable %s", v.Name)
+		}
+		data[v.Name] = value
+	}
+	for name := range values {
+		if _, ok := data[name]; !ok {
+			return Plan{}, fmt.Errorf("template %s has no variable %s", t.Name, name)
+		}
+	}
+
+	r := renderer{data: data, now: now}
+	plan := Plan{
+		Project: domain.ProjectInput{
+			Name:      r.text(t.project),
+			Note:      r.text(t.note),
+			DueDate:   r.date(t.due),
+			DeferDate: r.date(t.deferTo),
+		},
+	}
+	for i, task := range t.tasks {
+		if task.each == "" {
+			plan.Tasks = append(plan.Tasks, r.task(i, task))
+			continue
+		}
+		for _, item := range splitList(data[task.each]) {
+			itemData := make(map[string]string, len(data)+1)
+			for name, value := range data {
+				itemData[name] = value
+			}
+			itemData[ItemVariable] = item
+			ir := renderer{data: itemData, now: now}
+			plan.Tasks = append(plan.Tasks, ir.task(i, task))
+			if r.err == nil {
+				r.err = ir.err
+			}
+		}
+	}
+	if r.err != nil {
+		return Plan{}, r.err
+	}
+
+	return plan, nil
+}
+
+// splitList splits a comma-separated variable value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// sampleValues returns a placeholder for every variable, used to check references at compile time
+func (t *Template) sampleValues() map[string]string {
+	values := make(map[string]string, len(t.Variables))
+	for _, v := range t.Variables {
+		values[v.Name] = "x"
+	}
+	return values
+}
+
+// compiler parses template fields, keeping the first error
+type compiler struct {
+	template string
+	sample   map[string]string
+	err      error
+}
+
+// compile parses text, or returns nil for an empty field
+func (c *compiler) compile(field, text string) *template.Template {
+	if c.err != nil || text == "" {
+		return nil
+	}
+	tmpl, err := template.New(field).Option("missingkey=error").Parse(text)
+	if err == nil {
+		err = tmpl.Execute(&strings.Builder{}, c.sample)
+	}
+	if err != nil {
+		c.err = fmt.Errorf("template %s: invalid %s: %w", c.template, field, err)
+		return nil
+	}
+	return tmpl
+}
+
+// renderer executes template fields, keeping the first error
+type renderer struct {
+	data map[string]string
+	now  time.Time
+	err  error
+}
+
+// text renders a field, or returns "" for an empty field
+func (r *renderer) text(tmpl *template.Template) string {
+	if r.err != nil || tmpl == nil {
+		return ""
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, r.data); err != nil {
+		r.err = fmt.Errorf("failed to render %s: %w", tmpl.Name(), err)
+		return ""
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// task renders the i-th template task
+func (r *renderer) task(i int, task taskTemplate) domain.TaskInput {
+	input := domain.TaskInput{
+		Name:      r.text(task.name),
+		Note:      r.text(task.note),
+		TagNames:  task.tags,
+		DueDate:   r.date(task.due),
+		DeferDate: r.date(task.deferTo),
+	}
+	if task.flag {
+		flagged := true
+		input.Flagged = &flagged
+	}
+	if r.err == nil && strings.TrimSpace(input.Name) == "" {
+		r.err = fmt.Errorf("task %d has an empty name", i+1)
+	}
+	return input
+}
+
+// date renders a field and parses it as a date, or returns nil for an empty field
+func (r *renderer) date(tmpl *template.Template) *time.Time {
+	value := r.text(tmpl)
+	if value == "" {
+		return nil
+	}
+	date, err := dateparse.ParseWithReference(value, r.now)
+	if err != nil {
+		r.err = fmt.Errorf("invalid %s date: %w", tmpl.Name(), err)
+		return nil
+	}
+	return &date
+}
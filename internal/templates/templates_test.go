@@ -0,0 +1,148 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/config"
+)
+
+// Friday, January 19, 2024
+var testNow = time.Date(2024, 1, 19, 10, 0, 0, 0, time.Local)
+
+var onboarding = config.TemplateConfig{
+	Name:    "onboarding",
+	Project: "Onboard {{.ClientName}}",
+	Due:     "in {{.DueOffsetDays}} days",
+	Variables: []config.TemplateVariableConfig{
+		{Name: "ClientName", Prompt: "Client name"},
+		{Name: "DueOffsetDays", Default: "14"},
+	},
+	Tasks: []config.TemplateTaskConfig{
+		{Name: "Kickoff call with {{.ClientName}}", Tags: []string{"phone"}, Flag: true},
+		{Name: "Send contract", Due: "in 3 days"},
+	},
+}
+
+func TestNew_RejectsInvalidTemplates(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.TemplateConfig
+		wantErr string
+	}{
+		{"no name", config.TemplateConfig{Project: "P"}, "template name is required"},
+		{"no project", config.TemplateConfig{Name: "t"}, "project name is required"},
+		{"bad variable", config.TemplateConfig{Name: "t", Project: "P", Variables: []config.TemplateVariableConfig{{Name: "a-b"}}}, "invalid variable name"},
+		{"duplicate variable", config.TemplateConfig{Name: "t", Project: "P", Variables: []config.TemplateVariableConfig{{Name: "A"}, {Name: "A"}}}, "duplicate variable"},
+		{"bad syntax", config.TemplateConfig{Name: "t", Project: "{{.A"}, "invalid project"},
+		{"undeclared variable", config.TemplateConfig{Name: "t", Project: "P", Tasks: []config.TemplateTaskConfig{{Name: "{{.Missing}}"}}}, "invalid task 1 name"},
+		{"undeclared each", config.TemplateConfig{Name: "t", Project: "P", Tasks: []config.TemplateTaskConfig{{Name: "{{.Item}}", Each: "Missing"}}}, "undeclared variable \"Missing\""},
+		{"item outside each", config.TemplateConfig{Name: "t", Project: "P", Tasks: []config.TemplateTaskConfig{{Name: "{{.Item}}"}}}, "invalid task 1 name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRender_FillsVariablesAndDates(t *testing.T) {
+	tmpl, err := New(onboarding)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	plan, err := tmpl.Render(map[string]string{"ClientName": "Acme"}, testNow)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if plan.Project.Name != "Onboard Acme" {
+		t.Errorf("Render() project = %q, want %q", plan.Project.Name, "Onboard Acme")
+	}
+	wantDue := time.Date(2024, 2, 2, 17, 0, 0, 0, time.Local) // Default offset of 14 days
+	if plan.Project.DueDate == nil || !plan.Project.DueDate.Equal(wantDue) {
+		t.Errorf("Render() project due = %v, want %v", plan.Project.DueDate, wantDue)
+	}
+	if len(plan.Tasks) != 2 {
+		t.Fatalf("Render() tasks = %d, want 2", len(plan.Tasks))
+	}
+	first := plan.Tasks[0]
+	if first.Name != "Kickoff call with Acme" || len(first.TagNames) != 1 || first.Flagged == nil || !*first.Flagged {
+		t.Errorf("Render() first task = %+v, want rendered name, tag and flag", first)
+	}
+	if plan.Tasks[1].DueDate == nil {
+		t.Error("Render() second task should have a due date")
+	}
+}
+
+func TestRender_RepeatsTasksForEachItem(t *testing.T) {
+	tmpl, err := New(config.TemplateConfig{
+		Name:      "release",
+		Project:   "Release {{.Version}}",
+		Variables: []config.TemplateVariableConfig{{Name: "Version"}, {Name: "Packages"}},
+		Tasks: []config.TemplateTaskConfig{
+			{Name: "Review changes in {{.Item}} for {{.Version}}", Each: "Packages"},
+			{Name: "Tag {{.Version}}"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	plan, err := tmpl.Render(map[string]string{"Version": "v1.2.0", "Packages": "internal/cli, internal/tui,"}, testNow)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var names []string
+	for _, task := range plan.Tasks {
+		names = append(names, task.Name)
+	}
+	want := "Review changes in internal/cli for v1.2.0|Review changes in internal/tui for v1.2.0|Tag v1.2.0"
+	if got := strings.Join(names, "|"); got != want {
+		t.Errorf("Render() tasks = %s, want %s", got, want)
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	tmpl, err := New(onboarding)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		wantErr string
+	}{
+		{"missing required", nil, "missing value for variable ClientName"},
+		{"unknown variable", map[string]string{"ClientName": "Acme", "Other": "x"}, "has no variable Other"},
+		{"bad date", map[string]string{"ClientName": "Acme", "DueOffsetDays": "soon"}, "invalid due date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tmpl.Render(tt.values, testNow)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Render() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	cfgs := []config.TemplateConfig{onboarding}
+
+	if tmpl, err := Find(cfgs, "Onboarding"); err != nil || tmpl.Name != "onboarding" {
+		t.Errorf("Find() = %v, %v, want case-insensitive match", tmpl, err)
+	}
+	if _, err := Find(cfgs, "release"); err == nil || !strings.Contains(err.Error(), "template not found") {
+		t.Errorf("Find() error = %v, want not found", err)
+	}
+}
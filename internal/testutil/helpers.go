@@ -10,3 +10,8 @@ func StringPtr(s string) *string {
 func BoolPtr(b bool) *bool {
 	return &b
 }
+
+// IntPtr returns a pointer to the given int
+func IntPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+)
+
+// SelectedMark marks the selected row when there are no colors to highlight it
+const SelectedMark = "▸"
+
+// ColorEnabled reports whether output written to f gets colors. Colors are
+// off with --no-color (noColor), when NO_COLOR is set to anything, and when
+// f is not a terminal, so scripts reading the output get plain text.
+func ColorEnabled(f *os.File, noColor bool) bool {
+	return !noColor && os.Getenv("NO_COLOR") == "" && term.IsTerminal(f.Fd())
+}
+
+// NewRenderer returns a renderer for w. With color it uses every color the
+// terminal supports; without, it renders text with no escape codes at all.
+func NewRenderer(w io.Writer, color bool) *lipgloss.Renderer {
+	r := lipgloss.NewRenderer(w)
+	if !color {
+		r.SetColorProfile(termenv.Ascii)
+	}
+	return r
+}
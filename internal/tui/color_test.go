@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestColorEnabled(t *testing.T) {
+	// Test output is not a terminal, so colors stay off whatever the flag says
+	t.Setenv("NO_COLOR", "")
+	if ColorEnabled(os.Stdout, false) {
+		t.Error("ColorEnabled() = true for output that is not a terminal")
+	}
+
+	if ColorEnabled(os.Stdout, true) {
+		t.Error("ColorEnabled() = true with --no-color")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled(os.Stdout, false) {
+		t.Error("ColorEnabled() = true with NO_COLOR set")
+	}
+}
+
+func TestNewRenderer_WithoutColorRendersPlainText(t *testing.T) {
+	r := NewRenderer(io.Discard, false)
+
+	got := r.NewStyle().Bold(true).Foreground(DefaultStyles().Colors.Error).Render("overdue")
+
+	if got != "overdue" {
+		t.Errorf("Render() = %q, want plain text", got)
+	}
+}
+
+func TestNewStyles_MarksSelectionWithoutColor(t *testing.T) {
+	plain := NewRenderer(io.Discard, false)
+	colored := NewRenderer(io.Discard, true)
+	colored.SetColorProfile(termenv.TrueColor)
+
+	if got := NewStyles(plain).Task.Selected.Render("Buy milk"); !strings.HasPrefix(got, SelectedMark+" Buy milk") {
+		t.Errorf("selected row without color = %q, want it marked with %q", got, SelectedMark)
+	}
+	if got := NewStyles(colored).Task.Selected.Render("Buy milk"); strings.Contains(got, SelectedMark) {
+		t.Errorf("selected row with color = %q, want it highlighted rather than marked", got)
+	}
+	if got := NewStyles(plain).UI.ActiveTab.Render("Inbox"); got != SelectedMark+" Inbox" {
+		t.Errorf("active tab without color = %q, want %q", got, SelectedMark+" Inbox")
+	}
+}
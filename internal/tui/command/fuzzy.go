@@ -0,0 +1,61 @@
+package command
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Scores awarded by FuzzyScore
+const (
+	fuzzyMatch       = 1  // Each matched character
+	fuzzyConsecutive = 5  // Character directly after the previous match
+	fuzzyWordStart   = 8  // Character at the start of a word
+	fuzzyPrefix      = 15 // Query matches the start of the target
+	fuzzyExact       = 100
+)
+
+// FuzzyScore reports whether every character of query appears in target in
+// order, ignoring case, and how well: consecutive characters, word starts
+// and prefixes score higher, gaps lower. An empty query matches everything
+// with a score of 0.
+func FuzzyScore(query, target string) (int, bool) {
+	q := []rune(strings.ToLower(strings.TrimSpace(query)))
+	if len(q) == 0 {
+		return 0, true
+	}
+	t := []rune(strings.ToLower(target))
+
+	if string(q) == string(t) {
+		return fuzzyExact, true
+	}
+
+	score := 0
+	qi := 0
+	last := -1
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+
+		score += fuzzyMatch
+		switch {
+		case last >= 0 && ti == last+1:
+			score += fuzzyConsecutive
+		case ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]):
+			score += fuzzyWordStart
+		}
+		if last >= 0 {
+			score -= ti - last - 1
+		}
+		last = ti
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+
+	if strings.HasPrefix(string(t), string(q)) {
+		score += fuzzyPrefix
+	}
+	return score, true
+}
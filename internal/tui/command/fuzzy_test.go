@@ -0,0 +1,59 @@
+package command
+
+import "testing"
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query  string
+		target string
+		wantOK bool
+	}{
+		{query: "", target: "complete", wantOK: true},
+		{query: "cmp", target: "complete", wantOK: true},
+		{query: "CMPL", target: "complete", wantOK: true},
+		{query: "xyz", target: "complete", wantOK: false},
+		{query: "etelpmoc", target: "complete", wantOK: false},
+		{query: "wh", target: "Work Home", wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.target, func(t *testing.T) {
+			if _, ok := FuzzyScore(tt.query, tt.target); ok != tt.wantOK {
+				t.Errorf("FuzzyScore(%q, %q) ok = %v, want %v", tt.query, tt.target, ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFuzzyScore_Ranking(t *testing.T) {
+	better := []struct {
+		query       string
+		best, worse string
+	}{
+		{query: "add", best: "add", worse: "available"},
+		{query: "fl", best: "flagged", worse: "filter"},
+		{query: "wh", best: "Work Home", worse: "wash"},
+		{query: "ref", best: "refresh", worse: "reset filters"},
+	}
+
+	for _, tt := range better {
+		b, _ := FuzzyScore(tt.query, tt.best)
+		w, _ := FuzzyScore(tt.query, tt.worse)
+		if b <= w {
+			t.Errorf("FuzzyScore(%q): %q = %d, %q = %d, want the first higher", tt.query, tt.best, b, tt.worse, w)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	def, ok := Lookup(":done")
+	if !ok || def.Name != "complete" || def.Keys != "c" {
+		t.Errorf("Lookup(:done) = %+v, %v, want complete bound to c", def, ok)
+	}
+	if _, ok := Lookup("nope"); ok {
+		t.Error("Lookup(nope) ok = true, want false")
+	}
+	if move, _ := Lookup("move"); !move.RequiresArgs() {
+		t.Error("move.RequiresArgs() = false, want true")
+	}
+}
@@ -18,21 +18,37 @@ type Def struct {
 	Aliases     []string
 	Description string
 	ArgsHint    string // e.g., "<task name>", "[project name]"
+	Keys        string // Key that does the same outside the palette, e.g. "c"
 }
 
 // Available commands
 var commands = []Def{
-	{Name: "quit", Aliases: []string{"q", "exit"}, Description: "Quit application"},
+	{Name: "quit", Aliases: []string{"q", "exit"}, Description: "Quit application", Keys: "q"},
 	{Name: "refresh", Aliases: []string{"w", "sync"}, Description: "Refresh current view"},
-	{Name: "add", Aliases: []string{"a"}, Description: "Add new task", ArgsHint: "<task name>"},
-	{Name: "complete", Aliases: []string{"done", "c"}, Description: "Complete selected task"},
-	{Name: "delete", Aliases: []string{"del", "rm"}, Description: "Delete selected task"},
+	{Name: "add", Aliases: []string{"a"}, Description: "Add new task", ArgsHint: "<task name>", Keys: "a"},
+	{Name: "complete", Aliases: []string{"done", "c"}, Description: "Complete selected task, with an optional closing note", ArgsHint: "[note]", Keys: "c"},
+	{Name: "delete", Aliases: []string{"del", "rm"}, Description: "Delete selected task", Keys: "d"},
+	{Name: "drop", Aliases: []string{}, Description: "Drop selected or marked tasks, or the selected project", Keys: "x"},
+	{Name: "open", Aliases: []string{"o"}, Description: "Open selected task in OmniFocus", Keys: "o"},
+	{Name: "reconcile", Aliases: []string{"rc"}, Description: "Retry the change of a conflicted task, or keep OmniFocus's state with discard", ArgsHint: "[discard]", Keys: "R"},
+	{Name: "move", Aliases: []string{"mv"}, Description: "Move selected or marked tasks to project, and tag them with #tag", ArgsHint: "<project name> [#tag...]"},
 	{Name: "project", Aliases: []string{"p"}, Description: "Filter by project", ArgsHint: "<project name>"},
 	{Name: "tag", Aliases: []string{"t"}, Description: "Filter by tag", ArgsHint: "<tag name>"},
 	{Name: "due", Aliases: []string{}, Description: "Filter by due date", ArgsHint: "<today|tomorrow|week>"},
 	{Name: "flagged", Aliases: []string{}, Description: "Show only flagged tasks"},
+	{Name: "available", Aliases: []string{"avail"}, Description: "Hide deferred and blocked tasks"},
+	{Name: "time", Aliases: []string{}, Description: "Show tasks that fit in a time slot, by estimated duration", ArgsHint: "<30m|1h|off>"},
+	{Name: "low-energy", Aliases: []string{"low", "le"}, Description: "Show only tasks marked low effort"},
+	{Name: "next", Aliases: []string{"n"}, Description: "Suggest the tasks to work on next, optionally for the time and energy available", ArgsHint: "[30m|1h] [low|medium|high]"},
+	{Name: "search-all", Aliases: []string{"sa"}, Description: "Search task names and notes across the whole database", ArgsHint: "<text>", Keys: "g /"},
+	{Name: "sort", Aliases: []string{"so"}, Description: "Sort the current view, or cycle to the next order", ArgsHint: "[added|due|defer|name|project|flagged]", Keys: "s"},
+	{Name: "filter", Aliases: []string{"f"}, Description: "Apply a saved filter, or pick one", ArgsHint: "[name]", Keys: "F"},
+	{Name: "save-filter", Aliases: []string{"sf"}, Description: "Save the current filter under a name", ArgsHint: "<name>"},
+	{Name: "replay", Aliases: []string{"@"}, Description: "Replay a recorded macro", ArgsHint: "<register> [count]", Keys: "@"},
 	{Name: "clear", Aliases: []string{"reset"}, Description: "Clear all filters"},
-	{Name: "help", Aliases: []string{"?"}, Description: "Show available commands"},
+	{Name: "path", Aliases: []string{}, Description: "Show or hide the folder and project of each task"},
+	{Name: "debug", Aliases: []string{}, Description: "Toggle logging of OmniFocus script calls"},
+	{Name: "help", Aliases: []string{"?"}, Description: "Show available commands", Keys: "?"},
 }
 
 // Parser parses command strings
@@ -140,3 +156,24 @@ func (p *Parser) GetCompletions(prefix string) []string {
 func GetCommands() []Def {
 	return commands
 }
+
+// Lookup returns the definition of a command by name or alias
+func Lookup(name string) (Def, bool) {
+	name = strings.ToLower(strings.TrimPrefix(name, ":"))
+	for _, def := range commands {
+		if def.Name == name {
+			return def, true
+		}
+		for _, alias := range def.Aliases {
+			if alias == name {
+				return def, true
+			}
+		}
+	}
+	return Def{}, false
+}
+
+// RequiresArgs reports whether the command cannot run without arguments
+func (d Def) RequiresArgs() bool {
+	return strings.HasPrefix(d.ArgsHint, "<")
+}
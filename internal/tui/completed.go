@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// CompletedDays is how many days of completed tasks views show, today included
+const CompletedDays = 7
+
+// CompletedSince returns the start of the first day views show completed tasks from
+func CompletedSince(now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return today.AddDate(0, 0, -(CompletedDays - 1))
+}
+
+// WithCompleted returns tasks followed by the completed tasks keep accepts,
+// most recently completed first. Completed tasks already among tasks or
+// their subtasks are not listed again.
+func WithCompleted(tasks, completed []domain.Task, keep func(domain.Task) bool) []domain.Task {
+	listed := make(map[string]bool)
+	for _, task := range domain.FlattenTasks(tasks) {
+		listed[task.ID] = true
+	}
+
+	var extra []domain.Task
+	for _, task := range completed {
+		if !listed[task.ID] && keep(task) {
+			extra = append(extra, task)
+		}
+	}
+	sort.SliceStable(extra, func(i, j int) bool {
+		a, b := extra[i].CompletedDate, extra[j].CompletedDate
+		return a != nil && (b == nil || a.After(*b))
+	})
+
+	result := make([]domain.Task, 0, len(tasks)+len(extra))
+	result = append(result, tasks...)
+	return append(result, extra...)
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestCompletedSince(t *testing.T) {
+	now := time.Date(2026, 3, 12, 15, 30, 0, 0, time.UTC)
+	want := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+	if got := CompletedSince(now); !got.Equal(want) {
+		t.Errorf("CompletedSince() = %v, want %v", got, want)
+	}
+}
+
+func TestWithCompleted(t *testing.T) {
+	monday := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	tasks := []domain.Task{
+		{ID: "open", Children: []domain.Task{{ID: "done-child", Completed: true}}},
+	}
+	completed := []domain.Task{
+		{ID: "done-child", Completed: true, CompletedDate: &monday},
+		{ID: "monday", Completed: true, CompletedDate: &monday},
+		{ID: "tuesday", Completed: true, CompletedDate: &tuesday},
+		{ID: "elsewhere", ProjectID: "p1", Completed: true, CompletedDate: &tuesday},
+	}
+
+	got := WithCompleted(tasks, completed, func(task domain.Task) bool {
+		return task.ProjectID == ""
+	})
+
+	var ids []string
+	for _, task := range got {
+		ids = append(ids, task.ID)
+	}
+	want := []string{"open", "tuesday", "monday"}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Errorf("WithCompleted() = %v, want %v", ids, want)
+	}
+}
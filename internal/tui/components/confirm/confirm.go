@@ -2,6 +2,9 @@
 package confirm
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,11 +19,24 @@ type ConfirmedMsg struct {
 // CancelledMsg is sent when the user cancels the action
 type CancelledMsg struct{}
 
+// Choice is one of several answers offered by ShowWithChoices
+type Choice struct {
+	Key   string // Key that picks the choice
+	Label string // Text shown in the hint line
+}
+
+// ChoiceMsg is sent when the user picks one of the offered choices
+type ChoiceMsg struct {
+	Key     string      // Key of the picked choice
+	Context interface{} // Optional context passed through from ShowWithChoices()
+}
+
 // Model represents the confirmation modal state
 type Model struct {
 	title   string
 	message string
 	context interface{}
+	choices []Choice // Offered answers; nil for a yes/no confirmation
 	visible bool
 	styles  *tui.Styles
 	width   int
@@ -41,6 +57,7 @@ func (m Model) Show(title, message string) Model {
 	m.message = message
 	m.visible = true
 	m.context = nil
+	m.choices = nil
 	return m
 }
 
@@ -50,6 +67,18 @@ func (m Model) ShowWithContext(title, message string, context interface{}) Model
 	m.message = message
 	m.visible = true
 	m.context = context
+	m.choices = nil
+	return m
+}
+
+// ShowWithChoices makes the modal visible offering several answers instead of
+// yes/no. Picking one sends ChoiceMsg; Esc cancels.
+func (m Model) ShowWithChoices(title, message string, choices []Choice, context interface{}) Model {
+	m.title = title
+	m.message = message
+	m.visible = true
+	m.context = context
+	m.choices = choices
 	return m
 }
 
@@ -64,6 +93,11 @@ func (m Model) IsVisible() bool {
 	return m.visible
 }
 
+// Context returns the context the modal was shown with
+func (m Model) Context() interface{} {
+	return m.context
+}
+
 // SetSize updates the dimensions for the modal
 func (m Model) SetSize(width, height int) Model {
 	m.width = width
@@ -84,6 +118,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.choices != nil {
+			return m.updateChoices(msg)
+		}
 		switch {
 		case key.Matches(msg, confirmKey):
 			m.visible = false
@@ -104,6 +141,26 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateChoices handles keys while choices are offered
+func (m Model) updateChoices(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if msg.Type == tea.KeyEsc {
+		m.visible = false
+		return m, func() tea.Msg {
+			return CancelledMsg{}
+		}
+	}
+
+	for _, choice := range m.choices {
+		if msg.String() == choice.Key {
+			m.visible = false
+			return m, func() tea.Msg {
+				return ChoiceMsg{Key: choice.Key, Context: m.context}
+			}
+		}
+	}
+	return m, nil
+}
+
 // View renders the modal
 func (m Model) View() string {
 	if !m.visible {
@@ -138,7 +195,7 @@ func (m Model) View() string {
 		Foreground(m.styles.Colors.Secondary).
 		Width(modalWidth - 4).
 		Align(lipgloss.Center)
-	content += hintStyle.Render("[y/Enter] Confirm  [n/Esc] Cancel")
+	content += hintStyle.Render(m.hint())
 
 	// Wrap in overlay style
 	return m.styles.UI.Overlay.
@@ -146,6 +203,20 @@ func (m Model) View() string {
 		Render(content)
 }
 
+// hint describes the keys the modal accepts
+func (m Model) hint() string {
+	if m.choices == nil {
+		return "[y/Enter] Confirm  [n/Esc] Cancel"
+	}
+
+	parts := make([]string, 0, len(m.choices)+1)
+	for _, choice := range m.choices {
+		parts = append(parts, fmt.Sprintf("[%s] %s", choice.Key, choice.Label))
+	}
+	parts = append(parts, "[Esc] Cancel")
+	return strings.Join(parts, "  ")
+}
+
 // Key bindings for the confirmation modal
 var (
 	confirmKey = key.NewBinding(
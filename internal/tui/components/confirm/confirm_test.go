@@ -224,3 +224,61 @@ func TestSetSize(t *testing.T) {
 		t.Errorf("height = %d, want 50", m.height)
 	}
 }
+
+func TestUpdate_Choice(t *testing.T) {
+	styles := tui.DefaultStyles()
+	choices := []Choice{{Key: "w", Label: "Wait"}, {Key: "d", Label: "Discard"}}
+	m := New(styles).ShowWithChoices("Quit", "2 operations pending", choices, "quit")
+
+	// Keys that are not choices are ignored, including y
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if !m.IsVisible() || cmd != nil {
+		t.Fatalf("Update(y) visible = %v, cmd = %v, want modal kept open", m.IsVisible(), cmd)
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if m.IsVisible() {
+		t.Error("modal should be hidden after a choice")
+	}
+	if cmd == nil {
+		t.Fatal("cmd should not be nil")
+	}
+	choice, ok := cmd().(ChoiceMsg)
+	if !ok {
+		t.Fatalf("expected ChoiceMsg, got %T", cmd())
+	}
+	if choice.Key != "d" || choice.Context != "quit" {
+		t.Errorf("ChoiceMsg = %+v, want key d with context quit", choice)
+	}
+}
+
+func TestUpdate_ChoiceCancel(t *testing.T) {
+	styles := tui.DefaultStyles()
+	m := New(styles).ShowWithChoices("Quit", "Pending", []Choice{{Key: "w", Label: "Wait"}}, nil)
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+
+	if m.IsVisible() {
+		t.Error("modal should be hidden after cancel")
+	}
+	if cmd == nil {
+		t.Fatal("cmd should not be nil")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Errorf("expected CancelledMsg, got %T", cmd())
+	}
+}
+
+func TestView_Choices(t *testing.T) {
+	styles := tui.DefaultStyles()
+	choices := []Choice{{Key: "w", Label: "Wait"}, {Key: "b", Label: "Background"}}
+	m := New(styles).ShowWithChoices("Quit", "Pending", choices, nil).SetSize(80, 24)
+
+	view := m.View()
+
+	for _, want := range []string{"[w] Wait", "[b] Background", "[Esc] Cancel"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got %q", want, view)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+// Package datepicker provides an overlay to pick a due or defer date from a
+// calendar grid or a quick option.
+package datepicker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// Field is the date being picked
+type Field int
+
+// Fields a date can be picked for
+const (
+	FieldDue Field = iota
+	FieldDefer
+)
+
+// String returns the lowercase name of the field
+func (f Field) String() string {
+	if f == FieldDefer {
+		return "defer"
+	}
+	return "due"
+}
+
+// PickedMsg is sent when a date is picked; a nil date clears the field
+type PickedMsg struct {
+	Field Field
+	Date  *time.Time
+}
+
+// CancelledMsg is sent when the picker is closed without picking a date
+type CancelledMsg struct{}
+
+// quickOption is a date picked with one key
+type quickOption struct {
+	key   string
+	label string
+	expr  string // Parsed with dateparse; empty for options handled in pickQuick
+}
+
+// quickOptions are listed under the calendar in this order
+var quickOptions = []quickOption{
+	{key: "t", label: "Today", expr: "today"},
+	{key: "m", label: "Tomorrow", expr: "tomorrow"},
+	{key: "w", label: "Next week", expr: "next monday"},
+	{key: "+", label: "+1 week"},
+	{key: "x", label: "Clear"},
+}
+
+// Model represents the date picker state
+type Model struct {
+	field   Field
+	title   string
+	current *time.Time // Date the task has now, if any
+	cursor  time.Time  // Day under the cursor, at midnight
+	now     time.Time
+	visible bool
+	err     string
+	styles  *tui.Styles
+	width   int
+	height  int
+}
+
+// New creates a new date picker
+func New(styles *tui.Styles) Model {
+	return Model{styles: styles}
+}
+
+// Show opens the picker for field under title, with the cursor on the
+// current date, or on today when there is none
+func (m Model) Show(title string, field Field, current *time.Time, now time.Time) Model {
+	m.visible = true
+	m.title = title
+	m.field = field
+	m.current = current
+	m.now = now
+	m.err = ""
+	m.cursor = startOfDay(now)
+	if current != nil {
+		m.cursor = startOfDay(current.In(now.Location()))
+	}
+	return m
+}
+
+// Hide closes the picker
+func (m Model) Hide() Model {
+	m.visible = false
+	return m
+}
+
+// IsVisible returns true if the picker is visible
+func (m Model) IsVisible() bool {
+	return m.visible
+}
+
+// Field returns the field the picker is open for
+func (m Model) Field() Field {
+	return m.field
+}
+
+// Cursor returns the day under the cursor
+func (m Model) Cursor() time.Time {
+	return m.cursor
+}
+
+// SetSize updates the dimensions for the picker
+func (m Model) SetSize(width, height int) Model {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// Init initializes the component
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		return m.SetSize(msg.Width, msg.Height), nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, escapeKey):
+			m = m.Hide()
+			return m, func() tea.Msg { return CancelledMsg{} }
+		case key.Matches(msg, enterKey):
+			// The cursor day gets the default time of typed dates
+			date, err := dateparse.ParseWithReference(m.cursor.Format("2006-01-02"), m.now)
+			return m.pick(&date, err)
+		case key.Matches(msg, leftKey):
+			m.cursor = m.cursor.AddDate(0, 0, -1)
+		case key.Matches(msg, rightKey):
+			m.cursor = m.cursor.AddDate(0, 0, 1)
+		case key.Matches(msg, upKey):
+			m.cursor = m.cursor.AddDate(0, 0, -7)
+		case key.Matches(msg, downKey):
+			m.cursor = m.cursor.AddDate(0, 0, 7)
+		case key.Matches(msg, prevMonthKey):
+			m.cursor = addMonths(m.cursor, -1)
+		case key.Matches(msg, nextMonthKey):
+			m.cursor = addMonths(m.cursor, 1)
+		default:
+			return m.pickQuick(msg.String())
+		}
+	}
+	return m, nil
+}
+
+// pickQuick picks the date of the quick option bound to k, if any
+func (m Model) pickQuick(k string) (Model, tea.Cmd) {
+	switch k {
+	case "x":
+		return m.pick(nil, nil)
+	case "+":
+		// A week after the task's date keeps its local time of day and, like
+		// "in 1 week", moves on to a workday
+		if m.current != nil {
+			date := dateparse.RollForward(m.current.In(time.Local).AddDate(0, 0, 7))
+			return m.pick(&date, nil)
+		}
+		date, err := dateparse.ParseWithReference("in 1 week", m.now)
+		return m.pick(&date, err)
+	}
+	for _, option := range quickOptions {
+		if option.key == k && option.expr != "" {
+			date, err := dateparse.ParseWithReference(option.expr, m.now)
+			return m.pick(&date, err)
+		}
+	}
+	return m, nil
+}
+
+// pick closes the picker with date, or shows err
+func (m Model) pick(date *time.Time, err error) (Model, tea.Cmd) {
+	if err != nil {
+		m.err = err.Error()
+		return m, nil
+	}
+	m = m.Hide()
+	field := m.field
+	return m, func() tea.Msg { return PickedMsg{Field: field, Date: date} }
+}
+
+// View renders the picker
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	width := 44
+	inner := width - 4
+	descStyle := lipgloss.NewStyle().Foreground(m.styles.Colors.Secondary)
+
+	var b strings.Builder
+	b.WriteString(m.styles.UI.Header.Width(inner).Align(lipgloss.Center).Render(m.title))
+	b.WriteString("\n")
+	current := "none"
+	if m.current != nil {
+		current = m.current.Format("Mon Jan 2, 2006")
+	}
+	b.WriteString(descStyle.Width(inner).Align(lipgloss.Center).Render(fmt.Sprintf("Current %s date: %s", m.field, current)))
+	b.WriteString("\n\n")
+
+	b.WriteString(lipgloss.NewStyle().Width(inner).Align(lipgloss.Center).Render(m.grid()))
+	b.WriteString("\n\n")
+
+	var options []string
+	for _, option := range quickOptions {
+		options = append(options, lipgloss.NewStyle().Bold(true).Render(option.key)+" "+option.label)
+	}
+	b.WriteString(lipgloss.NewStyle().Width(inner).Align(lipgloss.Center).Render(strings.Join(options, "  ")))
+	b.WriteString("\n")
+
+	if m.err != "" {
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(m.styles.Colors.Error).Width(inner).Render(m.err))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(descStyle.Width(inner).Align(lipgloss.Center).Render("←↓↑→ day/week • [/] month • Enter pick • Esc cancel"))
+
+	return m.styles.UI.Overlay.Width(width).Render(b.String())
+}
+
+// grid renders the month of the cursor, weeks starting on Monday
+func (m Model) grid() string {
+	first := time.Date(m.cursor.Year(), m.cursor.Month(), 1, 0, 0, 0, 0, m.cursor.Location())
+	today := startOfDay(m.now)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(first.Format("January 2006")))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(m.styles.Colors.Secondary).Render("Mo Tu We Th Fr Sa Su"))
+
+	// Monday is column 0
+	offset := (int(first.Weekday()) + 6) % 7
+	day := first.AddDate(0, 0, -offset)
+	for day.Month() == first.Month() || day.Before(first) {
+		b.WriteString("\n")
+		cells := make([]string, 7)
+		for i := range cells {
+			if day.Month() == first.Month() {
+				cells[i] = m.cell(day, today)
+			} else {
+				cells[i] = "  "
+			}
+			day = day.AddDate(0, 0, 1)
+		}
+		b.WriteString(strings.Join(cells, " "))
+	}
+	return b.String()
+}
+
+// cell renders one day of the grid: the cursor reversed, today in the
+// primary color and the task's current date underlined
+func (m Model) cell(day, today time.Time) string {
+	style := lipgloss.NewStyle()
+	if day.Equal(today) {
+		style = style.Foreground(m.styles.Colors.Primary).Bold(true)
+	}
+	if m.current != nil && day.Equal(startOfDay(m.current.In(day.Location()))) {
+		style = style.Underline(true)
+	}
+	if day.Equal(m.cursor) {
+		style = style.Reverse(true)
+	}
+	return style.Render(fmt.Sprintf("%2d", day.Day()))
+}
+
+// startOfDay returns midnight of t's day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// addMonths moves t by n months, keeping to the last day of shorter months
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+var (
+	escapeKey    = key.NewBinding(key.WithKeys("esc"))
+	enterKey     = key.NewBinding(key.WithKeys("enter"))
+	leftKey      = key.NewBinding(key.WithKeys("left", "h"))
+	rightKey     = key.NewBinding(key.WithKeys("right", "l"))
+	upKey        = key.NewBinding(key.WithKeys("up", "k"))
+	downKey      = key.NewBinding(key.WithKeys("down", "j"))
+	prevMonthKey = key.NewBinding(key.WithKeys("[", "pgup"))
+	nextMonthKey = key.NewBinding(key.WithKeys("]", "pgdown"))
+)
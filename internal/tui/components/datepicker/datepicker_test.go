@@ -0,0 +1,137 @@
+package datepicker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/dateparse"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// testNow is a Friday morning
+var testNow = time.Date(2026, 10, 16, 10, 0, 0, 0, time.Local)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// picked returns the PickedMsg cmd sends, failing when it sends none
+func picked(t *testing.T, cmd tea.Cmd) PickedMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	msg, ok := cmd().(PickedMsg)
+	if !ok {
+		t.Fatalf("expected PickedMsg, got %T", cmd())
+	}
+	return msg
+}
+
+func TestGrid_MovesCursorAndPicks(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show("Due", FieldDue, nil, testNow)
+	if !strings.Contains(m.View(), "October 2026") {
+		t.Errorf("View() should show the month of today, got:\n%s", m.View())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.Update(runeKey('j'))
+	m, _ = m.Update(runeKey(']'))
+	if got := m.Cursor(); !got.Equal(time.Date(2026, 11, 24, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("Cursor() = %v, want Nov 24", got)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg := picked(t, cmd)
+	want := time.Date(2026, 11, 24, 17, 0, 0, 0, time.Local)
+	if msg.Field != FieldDue || msg.Date == nil || !msg.Date.Equal(want) {
+		t.Errorf("PickedMsg = %+v, want due %v", msg, want)
+	}
+	if m.IsVisible() {
+		t.Error("picking should close the picker")
+	}
+}
+
+func TestQuickOptions(t *testing.T) {
+	current := time.Date(2026, 10, 20, 9, 30, 0, 0, time.Local)
+	tests := []struct {
+		key     rune
+		current *time.Time
+		want    *time.Time
+	}{
+		{'t', nil, ptr(time.Date(2026, 10, 16, 17, 0, 0, 0, time.Local))},
+		{'m', nil, ptr(time.Date(2026, 10, 17, 17, 0, 0, 0, time.Local))},
+		{'w', nil, ptr(time.Date(2026, 10, 19, 17, 0, 0, 0, time.Local))},
+		{'+', &current, ptr(time.Date(2026, 10, 27, 9, 30, 0, 0, time.Local))},
+		{'x', &current, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.key), func(t *testing.T) {
+			m := New(tui.DefaultStyles()).Show("Defer", FieldDefer, tt.current, testNow)
+			_, cmd := m.Update(runeKey(tt.key))
+			msg := picked(t, cmd)
+			if msg.Field != FieldDefer {
+				t.Errorf("Field = %v, want defer", msg.Field)
+			}
+			switch {
+			case tt.want == nil && msg.Date != nil:
+				t.Errorf("Date = %v, want nil to clear", msg.Date)
+			case tt.want != nil && (msg.Date == nil || !msg.Date.Equal(*tt.want)):
+				t.Errorf("Date = %v, want %v", msg.Date, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuickOptions_WeekLaterSkipsCalendarDays(t *testing.T) {
+	calendar, err := dateparse.NewCalendar(true, []string{"2026-10-26"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dateparse.SetCalendar(calendar)
+	t.Cleanup(func() { dateparse.SetCalendar(dateparse.Calendar{}) })
+
+	// A week after Saturday Oct 17 skips the weekend and the holiday on Monday
+	current := time.Date(2026, 10, 17, 9, 30, 0, 0, time.Local)
+	m := New(tui.DefaultStyles()).Show("Due", FieldDue, &current, testNow)
+	_, cmd := m.Update(runeKey('+'))
+
+	msg := picked(t, cmd)
+	want := time.Date(2026, 10, 27, 9, 30, 0, 0, time.Local)
+	if msg.Date == nil || !msg.Date.Equal(want) {
+		t.Errorf("Date = %v, want %v", msg.Date, want)
+	}
+}
+
+func TestShow_StartsOnCurrentDate(t *testing.T) {
+	current := time.Date(2027, 1, 31, 17, 0, 0, 0, time.Local)
+	m := New(tui.DefaultStyles()).Show("Due", FieldDue, &current, testNow)
+
+	if !strings.Contains(m.View(), "January 2027") || !strings.Contains(m.View(), "Sun Jan 31, 2027") {
+		t.Errorf("View() should show the current date's month, got:\n%s", m.View())
+	}
+
+	// February is shorter: the cursor keeps to its last day
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if got := m.Cursor(); !got.Equal(time.Date(2027, 2, 28, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Cursor() = %v, want Feb 28", got)
+	}
+}
+
+func TestEscape_Cancels(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show("Due", FieldDue, nil, testNow)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsVisible() || cmd == nil {
+		t.Fatal("Esc should close the picker with a command")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Errorf("expected CancelledMsg, got %T", cmd())
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
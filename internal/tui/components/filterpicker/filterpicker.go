@@ -0,0 +1,165 @@
+// Package filterpicker provides an overlay listing saved filters to apply or delete.
+package filterpicker
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
+)
+
+// AppliedMsg is sent when a saved filter is picked
+type AppliedMsg struct {
+	Name  string
+	State filter.State
+}
+
+// DeletedMsg is sent when a saved filter is deleted from the picker
+type DeletedMsg struct {
+	Name string
+}
+
+// CancelledMsg is sent when the picker is closed without picking a filter
+type CancelledMsg struct{}
+
+// Model represents the saved filter picker state
+type Model struct {
+	saved   filter.Saved
+	names   []string
+	cursor  int
+	visible bool
+	styles  *tui.Styles
+	width   int
+	height  int
+}
+
+// New creates a new saved filter picker
+func New(styles *tui.Styles) Model {
+	return Model{styles: styles}
+}
+
+// Show opens the picker with the given saved filters
+func (m Model) Show(saved filter.Saved) Model {
+	m.saved = saved
+	m.names = saved.Names()
+	m.cursor = 0
+	m.visible = true
+	return m
+}
+
+// Hide closes the picker
+func (m Model) Hide() Model {
+	m.visible = false
+	return m
+}
+
+// IsVisible returns true if the picker is visible
+func (m Model) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the dimensions for the picker
+func (m Model) SetSize(width, height int) Model {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// Init initializes the component
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, escapeKey):
+		m = m.Hide()
+		return m, func() tea.Msg { return CancelledMsg{} }
+	case key.Matches(keyMsg, upKey):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, downKey):
+		if m.cursor < len(m.names)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, enterKey):
+		if m.cursor >= len(m.names) {
+			return m, nil
+		}
+		name := m.names[m.cursor]
+		state := m.saved[name]
+		m = m.Hide()
+		return m, func() tea.Msg { return AppliedMsg{Name: name, State: state} }
+	case key.Matches(keyMsg, deleteKey):
+		if m.cursor >= len(m.names) {
+			return m, nil
+		}
+		name := m.names[m.cursor]
+		m.names = append(m.names[:m.cursor:m.cursor], m.names[m.cursor+1:]...)
+		if m.cursor >= len(m.names) && m.cursor > 0 {
+			m.cursor--
+		}
+		return m, func() tea.Msg { return DeletedMsg{Name: name} }
+	}
+	return m, nil
+}
+
+// View renders the picker
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	width := min(60, m.width-4)
+	if width < 30 {
+		width = 30
+	}
+	inner := width - 4
+
+	var b strings.Builder
+	b.WriteString(m.styles.UI.Header.Width(inner).Align(lipgloss.Center).Render("Saved Filters"))
+	b.WriteString("\n\n")
+
+	descStyle := lipgloss.NewStyle().Foreground(m.styles.Colors.Secondary)
+	if len(m.names) == 0 {
+		b.WriteString(descStyle.Render("No saved filters — use :save-filter <name>"))
+		b.WriteString("\n")
+	}
+	for i, name := range m.names {
+		desc := strings.Join(m.saved[name].Describe(), ", ")
+		if i == m.cursor {
+			line := lipgloss.NewStyle().Bold(true).Render("▸ "+name) + "  " + desc
+			b.WriteString(m.styles.Task.Selected.Width(inner).Render(line))
+		} else {
+			b.WriteString("  " + name + "  " + descStyle.Render(desc))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(descStyle.Width(inner).Align(lipgloss.Center).Render("↑/↓ select • Enter apply • d delete • Esc close"))
+
+	return m.styles.UI.Overlay.Width(width).Render(b.String())
+}
+
+var (
+	escapeKey = key.NewBinding(key.WithKeys("esc", "F"))
+	enterKey  = key.NewBinding(key.WithKeys("enter"))
+	upKey     = key.NewBinding(key.WithKeys("up", "k"))
+	downKey   = key.NewBinding(key.WithKeys("down", "j"))
+	deleteKey = key.NewBinding(key.WithKeys("d", "x"))
+)
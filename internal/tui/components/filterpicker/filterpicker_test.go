@@ -0,0 +1,98 @@
+package filterpicker
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func testSaved() filter.Saved {
+	return filter.Saved{
+		"errands":    {TagID: "Errands"},
+		"work-today": {FlaggedOnly: true},
+	}
+}
+
+func TestShow(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(80, 24)
+	if m.IsVisible() {
+		t.Error("new picker should not be visible")
+	}
+
+	m = m.Show(testSaved())
+
+	if !m.IsVisible() {
+		t.Error("picker should be visible after Show()")
+	}
+	view := m.View()
+	for _, want := range []string{"Saved Filters", "errands", "work-today", "flagged"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+}
+
+func TestUpdate_EnterApplies(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show(testSaved())
+
+	m, _ = m.Update(runeKey('j'))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.IsVisible() {
+		t.Error("picker should close after Enter")
+	}
+	msg, ok := cmd().(AppliedMsg)
+	if !ok {
+		t.Fatalf("Enter produced %T, want AppliedMsg", cmd())
+	}
+	if msg.Name != "work-today" || !msg.State.FlaggedOnly {
+		t.Errorf("AppliedMsg = %+v, want work-today", msg)
+	}
+}
+
+func TestUpdate_DeleteRemovesRow(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(80, 24).Show(testSaved())
+
+	m, cmd := m.Update(runeKey('d'))
+
+	if msg, ok := cmd().(DeletedMsg); !ok || msg.Name != "errands" {
+		t.Errorf("d produced %+v, want DeletedMsg for errands", cmd())
+	}
+	if !m.IsVisible() {
+		t.Error("picker should stay open after deleting")
+	}
+	if strings.Contains(m.View(), "errands") {
+		t.Error("deleted filter should no longer be listed")
+	}
+}
+
+func TestUpdate_EscapeCancels(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show(testSaved())
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.IsVisible() {
+		t.Error("picker should close on Esc")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Errorf("Esc produced %T, want CancelledMsg", cmd())
+	}
+}
+
+func TestView_Empty(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(80, 24).Show(nil)
+
+	if !strings.Contains(m.View(), "No saved filters") {
+		t.Error("View() should explain how to save a filter when none exist")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Enter with no saved filters should do nothing")
+	}
+}
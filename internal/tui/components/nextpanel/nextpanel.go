@@ -0,0 +1,152 @@
+// Package nextpanel provides an overlay suggesting the tasks to work on next,
+// with the reasons each was ranked where it is.
+package nextpanel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// SelectedMsg is sent when a suggested task is picked
+type SelectedMsg struct {
+	Task domain.Task
+}
+
+// CancelledMsg is sent when the panel is closed without picking a task
+type CancelledMsg struct{}
+
+// Model represents the next panel state
+type Model struct {
+	suggestions []next.Suggestion
+	cursor      int
+	visible     bool
+	styles      *tui.Styles
+	width       int
+	height      int
+}
+
+// New creates a new next panel
+func New(styles *tui.Styles) Model {
+	return Model{styles: styles}
+}
+
+// Show opens the panel with the given suggestions, best first
+func (m Model) Show(suggestions []next.Suggestion) Model {
+	m.suggestions = suggestions
+	m.cursor = 0
+	m.visible = true
+	return m
+}
+
+// Hide closes the panel
+func (m Model) Hide() Model {
+	m.visible = false
+	return m
+}
+
+// IsVisible returns true if the panel is visible
+func (m Model) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the dimensions for the panel
+func (m Model) SetSize(width, height int) Model {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// Init initializes the component
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, escapeKey):
+		m = m.Hide()
+		return m, func() tea.Msg { return CancelledMsg{} }
+	case key.Matches(keyMsg, upKey):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, downKey):
+		if m.cursor < len(m.suggestions)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, enterKey):
+		if m.cursor >= len(m.suggestions) {
+			return m, nil
+		}
+		task := m.suggestions[m.cursor].Task
+		m = m.Hide()
+		return m, func() tea.Msg { return SelectedMsg{Task: task} }
+	}
+	return m, nil
+}
+
+// View renders the panel
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	width := min(70, m.width-4)
+	if width < 30 {
+		width = 30
+	}
+	inner := width - 4
+
+	var b strings.Builder
+	b.WriteString(m.styles.UI.Header.Width(inner).Align(lipgloss.Center).Render("What's Next"))
+	b.WriteString("\n\n")
+
+	descStyle := lipgloss.NewStyle().Foreground(m.styles.Colors.Secondary)
+	if len(m.suggestions) == 0 {
+		b.WriteString(descStyle.Render("No available tasks"))
+		b.WriteString("\n")
+	}
+	for i, s := range m.suggestions {
+		name := ansi.Truncate(s.Task.Name, inner-10, "…")
+		score := fmt.Sprintf("%.1f", s.Score)
+		gap := strings.Repeat(" ", max(1, inner-2-lipgloss.Width(name)-len(score)))
+		why := "  " + ansi.Truncate(s.Explain(), inner-4, "…")
+		if i == m.cursor {
+			line := lipgloss.NewStyle().Bold(true).Render("▸ "+name) + gap + score + "\n" + why
+			b.WriteString(m.styles.Task.Selected.Width(inner).Render(line))
+		} else {
+			b.WriteString("  " + name + gap + descStyle.Render(score) + "\n" + descStyle.Render(why))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(descStyle.Width(inner).Align(lipgloss.Center).Render("↑/↓ select • Enter open • Esc close"))
+
+	return m.styles.UI.Overlay.Width(width).Render(b.String())
+}
+
+var (
+	escapeKey = key.NewBinding(key.WithKeys("esc", "q"))
+	enterKey  = key.NewBinding(key.WithKeys("enter"))
+	upKey     = key.NewBinding(key.WithKeys("up", "k"))
+	downKey   = key.NewBinding(key.WithKeys("down", "j"))
+)
@@ -0,0 +1,80 @@
+package nextpanel
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/next"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func testSuggestions() []next.Suggestion {
+	return []next.Suggestion{
+		{Task: domain.Task{ID: "t1", Name: "Pay rent"}, Score: 3, Reasons: []next.Reason{{Text: "overdue by 1 day"}}},
+		{Task: domain.Task{ID: "t2", Name: "Call bank"}, Score: 2, Reasons: []next.Reason{{Text: "flagged"}}},
+	}
+}
+
+func TestShow(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(80, 24)
+	if m.IsVisible() {
+		t.Error("new panel should not be visible")
+	}
+
+	m = m.Show(testSuggestions())
+
+	if !m.IsVisible() {
+		t.Error("panel should be visible after Show()")
+	}
+	view := m.View()
+	for _, want := range []string{"What's Next", "Pay rent", "overdue by 1 day", "Call bank", "flagged", "3.0"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+}
+
+func TestShow_Empty(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(80, 24).Show(nil)
+
+	if !strings.Contains(m.View(), "No available tasks") {
+		t.Errorf("View() = %q, want it to say there are no tasks", m.View())
+	}
+}
+
+func TestUpdate_EnterSelects(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show(testSuggestions())
+
+	m, _ = m.Update(runeKey('j'))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.IsVisible() {
+		t.Error("panel should close after Enter")
+	}
+	msg, ok := cmd().(SelectedMsg)
+	if !ok {
+		t.Fatalf("Enter produced %T, want SelectedMsg", cmd())
+	}
+	if msg.Task.ID != "t2" {
+		t.Errorf("SelectedMsg.Task.ID = %q, want %q", msg.Task.ID, "t2")
+	}
+}
+
+func TestUpdate_EscapeCancels(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show(testSuggestions())
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.IsVisible() {
+		t.Error("panel should close on Esc")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Errorf("Esc produced %T, want CancelledMsg", cmd())
+	}
+}
@@ -0,0 +1,429 @@
+// Package palette provides a command palette that fuzzy-searches commands,
+// projects and tags.
+package palette
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+)
+
+// ExecutedMsg is sent when a command is executed from the palette
+type ExecutedMsg struct {
+	Command *command.Command
+}
+
+// CancelledMsg is sent when the palette is closed without running anything
+type CancelledMsg struct{}
+
+// ErrorMsg is sent when a typed command cannot be parsed
+type ErrorMsg struct {
+	Error string
+}
+
+// ItemsLoadedMsg carries the projects and tags offered next to commands
+type ItemsLoadedMsg struct {
+	Projects []domain.Project
+	Tags     []domain.Tag
+}
+
+// Kind is the type of a palette entry
+type Kind int
+
+// Kinds of palette entries
+const (
+	KindCommand Kind = iota
+	KindProject
+	KindTag
+)
+
+// maxRecent is the number of executed commands remembered
+const maxRecent = 10
+
+// maxVisible is the number of entries shown at once
+const maxVisible = 10
+
+// Item is one palette entry
+type Item struct {
+	Kind        Kind
+	Title       string   // Shown and matched, e.g. "complete" or a project name
+	Aliases     []string // Also matched, e.g. command aliases
+	Description string
+	Keys        string // Key binding shown next to the entry
+	Line        string // Command line run on Enter, e.g. `project "Home"`
+	Def         command.Def
+}
+
+// match is an item with its score for the current query
+type match struct {
+	item  Item
+	score int
+}
+
+// Model represents the command palette state
+type Model struct {
+	input    textinput.Model
+	parser   *command.Parser
+	commands []Item
+	extra    []Item   // Projects and tags
+	recent   []string // Executed command lines, most recent first
+	matches  []Item
+	cursor   int
+	visible  bool
+	styles   *tui.Styles
+	width    int
+	height   int
+}
+
+// New creates a new command palette
+func New(styles *tui.Styles) Model {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	ti.Placeholder = "Type a command, project or tag"
+	ti.CharLimit = 200
+
+	var commands []Item
+	for _, def := range command.GetCommands() {
+		commands = append(commands, Item{
+			Kind:        KindCommand,
+			Title:       def.Name,
+			Aliases:     def.Aliases,
+			Description: def.Description,
+			Keys:        def.Keys,
+			Line:        def.Name,
+			Def:         def,
+		})
+	}
+
+	return Model{
+		input:    ti,
+		parser:   command.NewParser(),
+		commands: commands,
+		styles:   styles,
+	}
+}
+
+// Show opens the palette with an empty query
+func (m Model) Show() Model {
+	m.visible = true
+	m.input.Focus()
+	m.input.SetValue("")
+	m.filter()
+	return m
+}
+
+// ShowWith opens the palette with the query pre-filled, e.g. "complete " to
+// prompt for a command's arguments
+func (m Model) ShowWith(query string) Model {
+	m = m.Show()
+	m.input.SetValue(query)
+	m.input.CursorEnd()
+	m.filter()
+	return m
+}
+
+// Hide closes the palette
+func (m Model) Hide() Model {
+	m.visible = false
+	m.input.Blur()
+	return m
+}
+
+// IsVisible returns true if the palette is visible
+func (m Model) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the dimensions for the palette
+func (m Model) SetSize(width, height int) Model {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetItems replaces the projects and tags offered by the palette
+func (m Model) SetItems(projects []domain.Project, tags []domain.Tag) Model {
+	m.extra = nil
+	for _, project := range projects {
+		m.extra = append(m.extra, Item{
+			Kind:        KindProject,
+			Title:       project.Name,
+			Description: "Filter by project",
+			Line:        "project " + strconv.Quote(project.Name),
+		})
+	}
+	for _, tag := range tags {
+		m.extra = append(m.extra, Item{
+			Kind:        KindTag,
+			Title:       tag.Name,
+			Description: "Filter by tag",
+			Line:        "tag " + strconv.Quote(tag.Name),
+		})
+	}
+	m.filter()
+	return m
+}
+
+// Matches returns the entries matching the current query, best first
+func (m Model) Matches() []Item {
+	return m.matches
+}
+
+// Init initializes the component
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case ItemsLoadedMsg:
+		return m.SetItems(msg.Projects, msg.Tags), nil
+	case tea.WindowSizeMsg:
+		return m.SetSize(msg.Width, msg.Height), nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, escapeKey):
+			m = m.Hide()
+			return m, func() tea.Msg { return CancelledMsg{} }
+		case key.Matches(msg, enterKey):
+			return m.execute()
+		case key.Matches(msg, upKey):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case key.Matches(msg, downKey):
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case key.Matches(msg, tabKey):
+			return m.complete(), nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.filter()
+	return m, cmd
+}
+
+// filter ranks the entries against the query. With an empty query recently
+// run commands come first.
+func (m *Model) filter() {
+	query := m.input.Value()
+	m.cursor = 0
+
+	var candidates []Item
+	seen := make(map[string]bool)
+	if strings.TrimSpace(query) == "" {
+		for _, item := range m.recentItems() {
+			candidates = append(candidates, item)
+			seen[item.Line] = true
+		}
+	}
+	for _, item := range append(append([]Item{}, m.commands...), m.extra...) {
+		if !seen[item.Line] {
+			candidates = append(candidates, item)
+		}
+	}
+
+	var found []match
+	for _, item := range candidates {
+		score, ok := command.FuzzyScore(query, item.Title)
+		for _, alias := range item.Aliases {
+			if s, aliasOK := command.FuzzyScore(query, alias); aliasOK && (!ok || s > score) {
+				score, ok = s, true
+			}
+		}
+		if ok {
+			found = append(found, match{item: item, score: score})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].score > found[j].score
+	})
+
+	m.matches = m.matches[:0:0]
+	for _, f := range found {
+		m.matches = append(m.matches, f.item)
+	}
+}
+
+// recentItems returns entries for recently run command lines
+func (m Model) recentItems() []Item {
+	var items []Item
+	for _, line := range m.recent {
+		item := Item{Kind: KindCommand, Title: line, Description: "Recent", Line: line}
+		if cmd, err := m.parser.Parse(line); err == nil {
+			if def, ok := command.Lookup(cmd.Name); ok {
+				item.Keys = def.Keys
+				item.Def = def
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// complete fills the input with the selected command so arguments can follow
+func (m Model) complete() Model {
+	if m.cursor >= len(m.matches) {
+		return m
+	}
+	item := m.matches[m.cursor]
+	line := item.Line
+	if item.Kind == KindCommand && item.Def.ArgsHint != "" && !strings.Contains(line, " ") {
+		line += " "
+	}
+	m.input.SetValue(line)
+	m.input.CursorEnd()
+	m.filter()
+	return m
+}
+
+// execute runs the typed command when it has arguments, or the selected entry
+func (m Model) execute() (Model, tea.Cmd) {
+	query := strings.TrimSpace(m.input.Value())
+
+	line := query
+	if cmd, err := m.parser.Parse(query); query == "" || err != nil || len(cmd.Args) == 0 {
+		if m.cursor < len(m.matches) {
+			item := m.matches[m.cursor]
+			if item.Kind == KindCommand && item.Def.RequiresArgs() && !strings.Contains(item.Line, " ") {
+				// Keep the palette open for the arguments
+				return m.complete(), nil
+			}
+			line = item.Line
+		}
+	}
+
+	m = m.Hide()
+	if line == "" {
+		return m, func() tea.Msg { return CancelledMsg{} }
+	}
+
+	cmd, err := m.parser.Parse(line)
+	if err != nil {
+		errStr := err.Error()
+		return m, func() tea.Msg { return ErrorMsg{Error: errStr} }
+	}
+	m.remember(line)
+	return m, func() tea.Msg { return ExecutedMsg{Command: cmd} }
+}
+
+// remember records a command line as recently run
+func (m *Model) remember(line string) {
+	recent := []string{line}
+	for _, r := range m.recent {
+		if r != line && len(recent) < maxRecent {
+			recent = append(recent, r)
+		}
+	}
+	m.recent = recent
+}
+
+// View renders the palette
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	width := min(70, m.width-4)
+	if width < 30 {
+		width = 30
+	}
+	inner := width - 4
+
+	var b strings.Builder
+	inputStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Colors.Primary).
+		Padding(0, 1).
+		Width(inner)
+	b.WriteString(inputStyle.Render(m.input.View()))
+	b.WriteString("\n")
+
+	// Scroll so the cursor stays visible
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(m.matches))
+
+	descStyle := lipgloss.NewStyle().Foreground(m.styles.Colors.Secondary)
+	for i := start; i < end; i++ {
+		b.WriteString(m.renderItem(m.matches[i], i == m.cursor, inner, descStyle))
+		b.WriteString("\n")
+	}
+	if len(m.matches) == 0 {
+		b.WriteString(descStyle.Render("No matches — Enter runs the typed command"))
+		b.WriteString("\n")
+	} else if len(m.matches) > end {
+		b.WriteString(descStyle.Render(fmt.Sprintf("… %d more", len(m.matches)-end)))
+		b.WriteString("\n")
+	}
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(m.styles.Colors.Secondary).
+		Width(inner).
+		Align(lipgloss.Center)
+	b.WriteString(hintStyle.Render("↑/↓ select • Tab complete • Enter run • Esc cancel"))
+
+	return m.styles.UI.Overlay.
+		Width(width).
+		Render(b.String())
+}
+
+// renderItem renders one entry: title, args hint, description and key binding
+func (m Model) renderItem(item Item, selected bool, width int, descStyle lipgloss.Style) string {
+	title := item.Title
+	switch item.Kind {
+	case KindProject:
+		title = "📁 " + title
+	case KindTag:
+		title = "🏷  " + title
+	default:
+		if item.Def.ArgsHint != "" && !strings.Contains(item.Line, " ") {
+			title += " " + item.Def.ArgsHint
+		}
+	}
+
+	right := item.Description
+	if item.Keys != "" {
+		right += "  [" + item.Keys + "]"
+	}
+
+	gap := width - lipgloss.Width(title) - lipgloss.Width(right) - 2
+	if gap < 1 {
+		gap = 1
+	}
+
+	if selected {
+		line := " " + title + strings.Repeat(" ", gap) + right + " "
+		return m.styles.Task.Selected.Width(width).PaddingLeft(0).Render(line)
+	}
+	return " " + title + strings.Repeat(" ", gap) + descStyle.Render(right)
+}
+
+var (
+	escapeKey = key.NewBinding(key.WithKeys("esc"))
+	enterKey  = key.NewBinding(key.WithKeys("enter"))
+	upKey     = key.NewBinding(key.WithKeys("up", "ctrl+p", "ctrl+k"))
+	downKey   = key.NewBinding(key.WithKeys("down", "ctrl+n", "ctrl+j"))
+	tabKey    = key.NewBinding(key.WithKeys("tab"))
+)
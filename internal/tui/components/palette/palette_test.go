@@ -0,0 +1,189 @@
+package palette
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func typeText(m Model, text string) Model {
+	for _, r := range text {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func newTestPalette() Model {
+	m := New(tui.DefaultStyles()).SetSize(100, 40).Show()
+	return m.SetItems(
+		[]domain.Project{{ID: "p1", Name: "Home Renovation"}},
+		[]domain.Tag{{ID: "t1", Name: "Errands"}},
+	)
+}
+
+// executed runs cmd and returns the executed command line, failing on other messages
+func executed(t *testing.T, cmd tea.Cmd) ExecutedMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("cmd should not be nil")
+	}
+	msg, ok := cmd().(ExecutedMsg)
+	if !ok {
+		t.Fatalf("expected ExecutedMsg, got %T", cmd())
+	}
+	return msg
+}
+
+func TestPalette_FuzzyMatchesCommandsProjectsAndTags(t *testing.T) {
+	m := newTestPalette()
+
+	tests := []struct {
+		query string
+		want  string
+		kind  Kind
+	}{
+		{query: "cmpl", want: "complete", kind: KindCommand},
+		{query: "hren", want: "Home Renovation", kind: KindProject},
+		{query: "errnd", want: "Errands", kind: KindTag},
+		{query: "rm", want: "delete", kind: KindCommand}, // Alias
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			matches := typeText(m, tt.query).Matches()
+			if len(matches) == 0 || matches[0].Title != tt.want || matches[0].Kind != tt.kind {
+				t.Errorf("Matches() for %q = %+v, want %s first", tt.query, matches, tt.want)
+			}
+		})
+	}
+}
+
+func TestPalette_EnterRunsSelectedEntry(t *testing.T) {
+	m := typeText(newTestPalette(), "hren")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.IsVisible() {
+		t.Error("palette should close after running an entry")
+	}
+	msg := executed(t, cmd)
+	if msg.Command.Name != "project" || strings.Join(msg.Command.Args, " ") != "Home Renovation" {
+		t.Errorf("ExecutedMsg = %+v, want project Home Renovation", msg.Command)
+	}
+}
+
+func TestPalette_EnterRunsTypedCommandWithArgs(t *testing.T) {
+	m := typeText(newTestPalette(), "move Someday")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	msg := executed(t, cmd)
+	if msg.Command.Name != "move" || strings.Join(msg.Command.Args, " ") != "Someday" {
+		t.Errorf("ExecutedMsg = %+v, want move Someday", msg.Command)
+	}
+}
+
+func TestPalette_CommandNeedingArgsStaysOpen(t *testing.T) {
+	m := typeText(newTestPalette(), "mv")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if cmd != nil || !m.IsVisible() {
+		t.Fatal("palette should stay open for the arguments of move")
+	}
+	if got := m.input.Value(); got != "move " {
+		t.Errorf("input = %q, want %q", got, "move ")
+	}
+}
+
+func TestPalette_ArrowsMoveSelection(t *testing.T) {
+	m := typeText(newTestPalette(), "h")
+	if matches := m.Matches(); len(matches) < 2 || matches[1].Kind != KindProject {
+		t.Fatalf("Matches() = %+v, want the project second", matches)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if msg := executed(t, cmd); msg.Command.Name != "project" {
+		t.Errorf("ExecutedMsg = %+v, want the selected project", msg.Command)
+	}
+}
+
+func TestPalette_RecentCommandsFirst(t *testing.T) {
+	m := typeText(newTestPalette(), "errnd")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	m = m.Show()
+
+	matches := m.Matches()
+	if len(matches) == 0 || matches[0].Line != `tag "Errands"` || matches[0].Description != "Recent" {
+		t.Errorf("Matches() first = %+v, want recent tag Errands", matches[0])
+	}
+	for _, item := range matches[1:] {
+		if item.Line == `tag "Errands"` {
+			t.Error("recent entry should not also be listed again")
+		}
+	}
+}
+
+func TestPalette_UnknownCommand(t *testing.T) {
+	m := typeText(newTestPalette(), "zzz")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if cmd == nil {
+		t.Fatal("cmd should not be nil")
+	}
+	if msg, ok := cmd().(ErrorMsg); !ok || !strings.Contains(msg.Error, "unknown command") {
+		t.Errorf("expected unknown command ErrorMsg, got %#v", cmd())
+	}
+}
+
+func TestPalette_Escape(t *testing.T) {
+	m := newTestPalette()
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.IsVisible() {
+		t.Error("palette should close on Escape")
+	}
+	if cmd == nil {
+		t.Fatal("cmd should not be nil")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Errorf("expected CancelledMsg, got %T", cmd())
+	}
+}
+
+func TestPalette_ViewShowsDescriptionsAndKeys(t *testing.T) {
+	view := typeText(newTestPalette(), "complete").View()
+
+	for _, want := range []string{"complete", "Complete selected task", "[c]"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() should contain %q, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestPalette_ShowWithPrefillsQuery(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(100, 40).ShowWith("complete ")
+
+	m = typeText(m, "shipped")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	msg := executed(t, cmd)
+	if msg.Command.Name != "complete" || strings.Join(msg.Command.Args, " ") != "shipped" {
+		t.Errorf("ExecutedMsg = %+v, want complete shipped", msg.Command)
+	}
+
+	// Without a note the command still runs, with no arguments
+	m = New(tui.DefaultStyles()).SetSize(100, 40).ShowWith("complete ")
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg := executed(t, cmd); msg.Command.Name != "complete" || len(msg.Command.Args) != 0 {
+		t.Errorf("ExecutedMsg = %+v, want complete without a note", msg.Command)
+	}
+}
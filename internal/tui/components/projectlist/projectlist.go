@@ -3,13 +3,17 @@ package projectlist
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
+	"github.com/pwojciechowski/lazyfocus/internal/stats"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 )
 
@@ -20,29 +24,49 @@ const (
 	CheckIcon      = "✓"
 	PauseIcon      = "⏸"
 	DropIcon       = "✗"
+	EstimateIcon   = "≈"
+	ExpandedIcon   = "▼"
+	CollapsedIcon  = "▶"
 )
 
+// toggleKey expands or collapses the folder under the cursor
+var toggleKey = key.NewBinding(key.WithKeys("tab"))
+
+// row is a line of the list: a folder, or a project indented under its folder
+type row struct {
+	folder  *domain.Folder
+	project domain.Project
+	depth   int
+}
+
 // Model represents the project list component state
 type Model struct {
-	projects []domain.Project
-	cursor   int
-	width    int
-	height   int
-	styles   *tui.Styles
-	keys     tui.KeyMap
-	loading  bool
-	empty    bool
+	projects  []domain.Project
+	folders   []domain.Folder
+	collapsed map[string]bool // Folder IDs whose contents are hidden
+	rows      []row
+	cursor    int
+	width     int
+	height    int
+	styles    *tui.Styles
+	keys      tui.KeyMap
+	loading   bool
+	empty     bool
+	now       func() time.Time
+	limits    []limits.Limit
 }
 
 // New creates a new project list component
 func New(styles *tui.Styles, keys tui.KeyMap) Model {
 	return Model{
-		projects: []domain.Project{},
-		cursor:   0,
-		styles:   styles,
-		keys:     keys,
-		loading:  false,
-		empty:    true,
+		projects:  []domain.Project{},
+		collapsed: make(map[string]bool),
+		cursor:    0,
+		styles:    styles,
+		keys:      keys,
+		loading:   false,
+		empty:     true,
+		now:       time.Now,
 	}
 }
 
@@ -56,6 +80,8 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -65,13 +91,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
-	if len(m.projects) == 0 {
+	if len(m.rows) == 0 {
 		return m, nil
 	}
 
 	if key.Matches(msg, m.keys.Down) {
 		m.cursor++
-		if m.cursor >= len(m.projects) {
+		if m.cursor >= len(m.rows) {
 			m.cursor = 0
 		}
 		return m, nil
@@ -80,14 +106,54 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 	if key.Matches(msg, m.keys.Up) {
 		m.cursor--
 		if m.cursor < 0 {
-			m.cursor = len(m.projects) - 1
+			m.cursor = len(m.rows) - 1
 		}
 		return m, nil
 	}
 
+	if key.Matches(msg, toggleKey) {
+		return m.ToggleFolder(), nil
+	}
+
+	return m, nil
+}
+
+// handleMouse selects the clicked project and pages through the list with
+// the scroll wheel. Coordinates are relative to the component's first line.
+func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if len(m.rows) == 0 || m.loading {
+		return m, nil
+	}
+
+	if direction := tui.WheelDirection(msg); direction != 0 {
+		m.cursor = tui.PageCursor(m.cursor, len(m.rows), m.height, direction)
+		return m, nil
+	}
+
+	if tui.IsClick(msg) {
+		if row, ok := m.RowAt(msg.Y); ok {
+			m.cursor = row
+		}
+	}
 	return m, nil
 }
 
+// RowAt returns the index of the row rendered on line y, accounting for
+// lines that wrap
+func (m Model) RowAt(y int) (int, bool) {
+	if y < 0 || m.loading {
+		return 0, false
+	}
+	line := 0
+	for i, r := range m.rows {
+		line += lipgloss.Height(m.formatRow(r, i == m.cursor))
+		if y < line {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
 // View renders the component
 func (m Model) View() string {
 	if m.loading {
@@ -118,8 +184,8 @@ func (m Model) renderEmpty() string {
 func (m Model) renderProjects() string {
 	var b strings.Builder
 
-	for i, project := range m.projects {
-		line := m.formatProjectLine(project, i == m.cursor)
+	for i, r := range m.rows {
+		line := m.formatRow(r, i == m.cursor)
 		b.WriteString(line)
 		b.WriteString("\n")
 	}
@@ -127,7 +193,62 @@ func (m Model) renderProjects() string {
 	return b.String()
 }
 
+// formatEstimate returns the projected completion date for active projects with recent progress
+func (m Model) formatEstimate(project domain.Project) string {
+	if project.Status != "active" {
+		return ""
+	}
+
+	now := m.now()
+	forecast := stats.ForecastProject(project, now)
+	if !forecast.HasEstimate() {
+		return ""
+	}
+
+	if forecast.Estimate.Year() == now.Year() {
+		return EstimateIcon + " " + forecast.Estimate.Format("Jan 2")
+	}
+	return EstimateIcon + " " + forecast.Estimate.Format("Jan 2, 2006")
+}
+
+// formatRow renders a folder or project row
+func (m Model) formatRow(r row, selected bool) string {
+	if r.folder != nil {
+		return m.formatFolderLine(*r.folder, r.depth, selected)
+	}
+	return m.formatProjectRow(r.project, r.depth, selected)
+}
+
+// formatFolderLine renders a folder with its expand/collapse icon and the
+// number of remaining tasks in its projects
+func (m Model) formatFolderLine(folder domain.Folder, depth int, selected bool) string {
+	icon := ExpandedIcon + " " + FolderOpenIcon
+	if m.collapsed[folder.ID] {
+		icon = CollapsedIcon + " " + FolderIcon
+	}
+	indent := strings.Repeat("  ", depth)
+	leftSide := indent + icon + " " + folder.Name
+	rightSide := fmt.Sprintf("(%d)", m.folderTaskCount(folder))
+
+	contentWidth := m.width
+	if contentWidth == 0 {
+		contentWidth = 80
+	}
+	spacing := max(1, contentWidth-runewidth.StringWidth(leftSide)-runewidth.StringWidth(rightSide)-2)
+	line := leftSide + strings.Repeat(" ", spacing) + rightSide
+
+	if selected {
+		return m.styles.Task.Selected.Render(line)
+	}
+	return m.styles.Project.Active.Bold(true).Render(line)
+}
+
 func (m Model) formatProjectLine(project domain.Project, selected bool) string {
+	return m.formatProjectRow(project, 0, selected)
+}
+
+// formatProjectRow renders a project indented to depth under its folders
+func (m Model) formatProjectRow(project domain.Project, depth int, selected bool) string {
 	// Status icon based on project status
 	statusIcon := FolderIcon
 	switch project.Status {
@@ -140,10 +261,19 @@ func (m Model) formatProjectLine(project domain.Project, selected bool) string {
 	}
 
 	// Build left side
-	leftSide := fmt.Sprintf("%s %s", statusIcon, project.Name)
+	indent := strings.Repeat("  ", depth)
+	leftSide := fmt.Sprintf("%s%s %s", indent, statusIcon, project.Name)
 
-	// Build right side (task count)
+	// Build right side (projected completion date and task count, against
+	// its WIP limit if it has one)
 	rightSide := fmt.Sprintf("(%d)", project.TaskCount)
+	limit, limited := limits.ForProject(m.limits, project)
+	if limited {
+		rightSide = limit.Badge(project.TaskCount)
+	}
+	if estimate := m.formatEstimate(project); estimate != "" {
+		rightSide = estimate + " " + rightSide
+	}
 
 	// Calculate spacing
 	contentWidth := m.width
@@ -151,7 +281,7 @@ func (m Model) formatProjectLine(project domain.Project, selected bool) string {
 		contentWidth = 80
 	}
 
-	leftLen := runewidth.StringWidth(statusIcon) + 1 + runewidth.StringWidth(project.Name)
+	leftLen := len(indent) + runewidth.StringWidth(statusIcon) + 1 + runewidth.StringWidth(project.Name)
 	rightLen := runewidth.StringWidth(rightSide)
 	spacing := contentWidth - leftLen - rightLen - 2
 	if spacing < 0 {
@@ -164,6 +294,9 @@ func (m Model) formatProjectLine(project domain.Project, selected bool) string {
 	if selected {
 		return m.styles.Task.Selected.Render(line)
 	}
+	if limited && project.TaskCount > limit.Max {
+		return m.styles.UI.OverLimit.Render(line)
+	}
 
 	switch project.Status {
 	case "done", "completed":
@@ -177,18 +310,118 @@ func (m Model) formatProjectLine(project domain.Project, selected bool) string {
 	}
 }
 
-// SetProjects updates the project list
+// SetProjects updates the project list, keeping the selected project or its
+// nearest neighbor selected
 func (m Model) SetProjects(projects []domain.Project) Model {
 	m.projects = projects
 	m.empty = len(projects) == 0
 	m.loading = false
-	if m.cursor >= len(m.projects) {
-		if len(m.projects) > 0 {
-			m.cursor = len(m.projects) - 1
-		} else {
-			m.cursor = 0
+	return m.rebuildRows()
+}
+
+// SetFolders sets the folder tree projects are listed under; projects in no
+// folder follow the folders. Without folders the list is flat.
+func (m Model) SetFolders(folders []domain.Folder) Model {
+	m.folders = folders
+	return m.rebuildRows()
+}
+
+// ToggleFolder expands or collapses the folder under the cursor
+func (m Model) ToggleFolder() Model {
+	folder := m.SelectedFolder()
+	if folder == nil {
+		return m
+	}
+	collapsed := make(map[string]bool, len(m.collapsed)+1)
+	for id := range m.collapsed {
+		collapsed[id] = true
+	}
+	if collapsed[folder.ID] {
+		delete(collapsed, folder.ID)
+	} else {
+		collapsed[folder.ID] = true
+	}
+	m.collapsed = collapsed
+	return m.rebuildRows()
+}
+
+// rebuildRows lays out the folder tree and the projects in it, hiding the
+// contents of collapsed folders and folders with none of the projects, and
+// keeps the selected row or its nearest neighbor selected
+func (m Model) rebuildRows() Model {
+	byID := make(map[string]domain.Project, len(m.projects))
+	for _, project := range m.projects {
+		byID[project.ID] = project
+	}
+	inFolder := make(map[string]bool)
+
+	var rows []row
+	var walk func(folders []domain.Folder, depth int)
+	walk = func(folders []domain.Folder, depth int) {
+		for i := range folders {
+			folder := &folders[i]
+			if !hasProjects(*folder, byID) {
+				continue
+			}
+			for _, id := range folder.AllProjectIDs() {
+				inFolder[id] = true
+			}
+			rows = append(rows, row{folder: folder, depth: depth})
+			if m.collapsed[folder.ID] {
+				continue
+			}
+			walk(folder.Children, depth+1)
+			for _, id := range folder.ProjectIDs {
+				if project, ok := byID[id]; ok {
+					rows = append(rows, row{project: project, depth: depth + 1})
+				}
+			}
+		}
+	}
+	walk(m.folders, 0)
+	for _, project := range m.projects {
+		if !inFolder[project.ID] {
+			rows = append(rows, row{project: project})
 		}
 	}
+
+	if i, ok := tui.KeepSelection(rowIDs(m.rows), m.cursor, rowIDs(rows)); ok {
+		m.cursor = i
+	}
+	m.rows = rows
+	if m.cursor >= len(m.rows) {
+		m.cursor = max(0, len(m.rows)-1)
+	}
+	return m
+}
+
+// hasProjects reports whether folder or its subfolders hold any of the
+// listed projects
+func hasProjects(folder domain.Folder, listed map[string]domain.Project) bool {
+	for _, id := range folder.AllProjectIDs() {
+		if _, ok := listed[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// folderTaskCount returns the number of remaining tasks in the listed
+// projects of folder and its subfolders
+func (m Model) folderTaskCount(folder domain.Folder) int {
+	ids := folder.AllProjectIDs()
+	count := 0
+	for _, project := range m.projects {
+		if slices.Contains(ids, project.ID) {
+			count += project.TaskCount
+		}
+	}
+	return count
+}
+
+// SetLimits sets the WIP limits shown next to project task counts
+func (m Model) SetLimits(limitList []limits.Limit) Model {
+	m.limits = limitList
 	return m
 }
 
@@ -198,12 +431,23 @@ func (m Model) SetLoading(loading bool) Model {
 	return m
 }
 
-// SelectedProject returns the currently selected project
+// SelectedProject returns the currently selected project, or nil when a
+// folder is selected
 func (m Model) SelectedProject() *domain.Project {
-	if len(m.projects) == 0 || m.cursor >= len(m.projects) {
+	if m.cursor >= len(m.rows) || m.rows[m.cursor].folder != nil {
+		return nil
+	}
+	project := m.rows[m.cursor].project
+	return &project
+}
+
+// SelectedFolder returns the currently selected folder, or nil when a
+// project is selected
+func (m Model) SelectedFolder() *domain.Folder {
+	if m.cursor >= len(m.rows) {
 		return nil
 	}
-	return &m.projects[m.cursor]
+	return m.rows[m.cursor].folder
 }
 
 // SelectedIndex returns the current cursor position
@@ -215,3 +459,16 @@ func (m Model) SelectedIndex() int {
 func (m Model) Projects() []domain.Project {
 	return m.projects
 }
+
+// rowIDs returns an ID for each row in order, telling folders from projects
+func rowIDs(rows []row) []string {
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		if r.folder != nil {
+			ids[i] = "folder:" + r.folder.ID
+		} else {
+			ids[i] = r.project.ID
+		}
+	}
+	return ids
+}
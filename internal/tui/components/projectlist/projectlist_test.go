@@ -3,9 +3,11 @@ package projectlist
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 )
 
@@ -192,6 +194,24 @@ func TestViewWithProjects(t *testing.T) {
 	}
 }
 
+func TestViewWithProjects_Limits(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetLimits([]limits.Limit{{Project: "work project", Max: 4}, {Project: "p2", Max: 3}})
+	m = m.SetProjects([]domain.Project{
+		{ID: "p1", Name: "Work Project", Status: "active", TaskCount: 5},
+		{ID: "p2", Name: "Personal", Status: "active", TaskCount: 3},
+	})
+	m.width = 80
+
+	view := m.View()
+	if !strings.Contains(view, limits.WarnIcon+" (5/4)") {
+		t.Errorf("should flag the project over its limit by name, got:\n%s", view)
+	}
+	if !strings.Contains(view, "(3/3)") || strings.Contains(view, limits.WarnIcon+" (3/3)") {
+		t.Errorf("should show the project at its limit by ID without a warning, got:\n%s", view)
+	}
+}
+
 func TestFormatProjectLine_StatusIcons(t *testing.T) {
 	styles := tui.DefaultStyles()
 	keys := tui.DefaultKeyMap()
@@ -235,6 +255,56 @@ func TestFormatProjectLine_StatusIcons(t *testing.T) {
 	}
 }
 
+func TestFormatProjectLine_Estimate(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		project domain.Project
+		want    string
+	}{
+		{
+			name:    "active with recent progress",
+			project: domain.Project{ID: "p1", Name: "Website", Status: "active", TaskCount: 2, CompletedRecently: 4},
+			want:    "≈ Jan 29",
+		},
+		{
+			name:    "estimate in a later year",
+			project: domain.Project{ID: "p2", Name: "Garage", Status: "active", TaskCount: 100, CompletedRecently: 1},
+			want:    "2031",
+		},
+		{
+			name:    "no recent progress",
+			project: domain.Project{ID: "p3", Name: "Stalled", Status: "active", TaskCount: 2},
+			want:    "",
+		},
+		{
+			name:    "on hold project",
+			project: domain.Project{ID: "p4", Name: "Paused", Status: "on-hold", TaskCount: 2, CompletedRecently: 4},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+			m.now = func() time.Time { return now }
+
+			line := m.formatProjectLine(tt.project, false)
+
+			if tt.want == "" {
+				if strings.Contains(line, EstimateIcon) {
+					t.Errorf("formatProjectLine() = %q, want no estimate", line)
+				}
+				return
+			}
+			if !strings.Contains(line, tt.want) {
+				t.Errorf("formatProjectLine() = %q, want it to contain %q", line, tt.want)
+			}
+		})
+	}
+}
+
 func TestWindowSizeMsg(t *testing.T) {
 	styles := tui.DefaultStyles()
 	keys := tui.DefaultKeyMap()
@@ -266,3 +336,84 @@ func TestProjects_ReturnsAllProjects(t *testing.T) {
 		t.Errorf("expected 2 projects, got %d", len(allProjects))
 	}
 }
+
+func TestSetProjectsKeepsSelection(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetProjects([]domain.Project{{ID: "p1"}, {ID: "p2"}, {ID: "p3"}})
+	m.cursor = 1
+
+	m = m.SetProjects([]domain.Project{{ID: "p0"}, {ID: "p1"}, {ID: "p2"}, {ID: "p3"}})
+	if got := m.SelectedProject(); got == nil || got.ID != "p2" {
+		t.Errorf("SelectedProject() = %v, want p2", got)
+	}
+
+	m = m.SetProjects([]domain.Project{{ID: "p0"}, {ID: "p1"}, {ID: "p3"}})
+	if got := m.SelectedProject(); got == nil || got.ID != "p3" {
+		t.Errorf("SelectedProject() = %v, want neighbor p3 after p2 disappeared", got)
+	}
+}
+
+func folderTree() ([]domain.Folder, []domain.Project) {
+	folders := []domain.Folder{
+		{ID: "f1", Name: "Work", ProjectIDs: []string{"p1"}, Children: []domain.Folder{
+			{ID: "f2", Name: "Clients", ParentID: "f1", ProjectIDs: []string{"p2"}},
+		}},
+		{ID: "f3", Name: "Archive", ProjectIDs: []string{"p9"}},
+	}
+	projects := []domain.Project{
+		{ID: "p1", Name: "Website", Status: "active", TaskCount: 2},
+		{ID: "p2", Name: "Acme", Status: "active", TaskCount: 5},
+		{ID: "p3", Name: "Errands", Status: "active", TaskCount: 1},
+	}
+	return folders, projects
+}
+
+func rowNames(m Model) []string {
+	var names []string
+	for _, r := range m.rows {
+		if r.folder != nil {
+			names = append(names, strings.Repeat(" ", r.depth)+"/"+r.folder.Name)
+		} else {
+			names = append(names, strings.Repeat(" ", r.depth)+r.project.Name)
+		}
+	}
+	return names
+}
+
+func TestSetFolders_ListsTree(t *testing.T) {
+	folders, projects := folderTree()
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap()).SetFolders(folders).SetProjects(projects)
+
+	want := []string{"/Work", " /Clients", "  Acme", " Website", "Errands"}
+	if got := rowNames(m); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("rows = %q, want %q (Archive has no listed projects)", got, want)
+	}
+	if got := m.folderTaskCount(folders[0]); got != 7 {
+		t.Errorf("folderTaskCount(Work) = %d, want 7", got)
+	}
+	if view := m.View(); !strings.Contains(view, "Work") || !strings.Contains(view, "(7)") {
+		t.Errorf("View() should show Work with its task count, got:\n%s", view)
+	}
+}
+
+func TestToggleFolder(t *testing.T) {
+	folders, projects := folderTree()
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap()).SetFolders(folders).SetProjects(projects)
+
+	if m.SelectedProject() != nil || m.SelectedFolder() == nil {
+		t.Fatal("the Work folder should be selected first")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	want := []string{"/Work", "Errands"}
+	if got := rowNames(m); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("rows after collapsing = %q, want %q", got, want)
+	}
+	if !strings.Contains(m.View(), CollapsedIcon) {
+		t.Error("a collapsed folder should show the collapsed icon")
+	}
+
+	m = m.ToggleFolder()
+	if len(m.rows) != 5 {
+		t.Errorf("rows after expanding = %q, want all 5", rowNames(m))
+	}
+}
@@ -0,0 +1,259 @@
+// Package projectpicker provides an overlay that fuzzy-searches projects to
+// move a task to.
+package projectpicker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/command"
+)
+
+// InboxName is the entry that moves a task out of its project
+const InboxName = "Inbox"
+
+// maxVisible is the number of projects shown at once
+const maxVisible = 10
+
+// PickedMsg is sent when a project is picked; an empty project ID is the inbox
+type PickedMsg struct {
+	Project domain.Project
+}
+
+// CancelledMsg is sent when the picker is closed without picking a project
+type CancelledMsg struct{}
+
+// ProjectsLoadedMsg carries the projects to pick from
+type ProjectsLoadedMsg struct {
+	Projects []domain.Project
+	Err      error
+}
+
+// Model represents the project picker state
+type Model struct {
+	input    textinput.Model
+	projects []domain.Project // Inbox first, then open projects
+	matches  []domain.Project
+	title    string
+	current  string // ID of the task's project, marked in the list
+	cursor   int
+	loading  bool
+	err      error
+	visible  bool
+	styles   *tui.Styles
+	width    int
+	height   int
+}
+
+// New creates a new project picker
+func New(styles *tui.Styles) Model {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "Type to filter projects"
+	ti.CharLimit = 100
+
+	return Model{input: ti, styles: styles}
+}
+
+// Show opens the picker under title, loading until SetProjects is called.
+// The project with currentID is marked as the task's project.
+func (m Model) Show(title, currentID string) Model {
+	m.visible = true
+	m.title = title
+	m.loading = true
+	m.err = nil
+	m.current = currentID
+	m.projects = nil
+	m.input.SetValue("")
+	m.input.Focus()
+	m.filter()
+	return m
+}
+
+// Hide closes the picker
+func (m Model) Hide() Model {
+	m.visible = false
+	m.input.Blur()
+	return m
+}
+
+// IsVisible returns true if the picker is visible
+func (m Model) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the dimensions for the picker
+func (m Model) SetSize(width, height int) Model {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetProjects sets the projects to pick from, leaving out completed and
+// dropped ones
+func (m Model) SetProjects(projects []domain.Project) Model {
+	m.loading = false
+	m.projects = []domain.Project{{Name: InboxName}}
+	for _, project := range projects {
+		if project.Status != "completed" && project.Status != "dropped" {
+			m.projects = append(m.projects, project)
+		}
+	}
+	m.filter()
+	return m
+}
+
+// Matches returns the projects matching the current query, best first
+func (m Model) Matches() []domain.Project {
+	return m.matches
+}
+
+// Init initializes the component
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case ProjectsLoadedMsg:
+		if msg.Err != nil {
+			m.loading = false
+			m.err = msg.Err
+			return m, nil
+		}
+		return m.SetProjects(msg.Projects), nil
+	case tea.WindowSizeMsg:
+		return m.SetSize(msg.Width, msg.Height), nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, escapeKey):
+			m = m.Hide()
+			return m, func() tea.Msg { return CancelledMsg{} }
+		case key.Matches(msg, enterKey):
+			if m.cursor >= len(m.matches) {
+				return m, nil
+			}
+			project := m.matches[m.cursor]
+			m = m.Hide()
+			return m, func() tea.Msg { return PickedMsg{Project: project} }
+		case key.Matches(msg, upKey):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case key.Matches(msg, downKey):
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.filter()
+	return m, cmd
+}
+
+// filter ranks the projects against the query, keeping their order on ties
+func (m *Model) filter() {
+	type match struct {
+		project domain.Project
+		score   int
+	}
+
+	var found []match
+	for _, project := range m.projects {
+		if score, ok := command.FuzzyScore(m.input.Value(), project.Name); ok {
+			found = append(found, match{project: project, score: score})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].score > found[j].score
+	})
+
+	m.matches = m.matches[:0:0]
+	for _, f := range found {
+		m.matches = append(m.matches, f.project)
+	}
+	m.cursor = 0
+}
+
+// View renders the picker
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	width := min(60, m.width-4)
+	if width < 30 {
+		width = 30
+	}
+	inner := width - 4
+
+	var b strings.Builder
+	b.WriteString(m.styles.UI.Header.Width(inner).Align(lipgloss.Center).Render(m.title))
+	b.WriteString("\n\n")
+	inputStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Colors.Primary).
+		Padding(0, 1).
+		Width(inner - 2)
+	m.input.Width = inner - 8
+	b.WriteString(inputStyle.Render(m.input.View()))
+	b.WriteString("\n\n")
+
+	descStyle := lipgloss.NewStyle().Foreground(m.styles.Colors.Secondary)
+	switch {
+	case m.loading:
+		b.WriteString(descStyle.Render("Loading projects..."))
+		b.WriteString("\n")
+	case m.err != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(m.styles.Colors.Error).Render("Error: " + m.err.Error()))
+		b.WriteString("\n")
+	case len(m.matches) == 0:
+		b.WriteString(descStyle.Render("No matching projects"))
+		b.WriteString("\n")
+	}
+
+	// Keep the cursor in the visible window
+	start := max(0, m.cursor-maxVisible+1)
+	end := min(len(m.matches), start+maxVisible)
+	for i := start; i < end; i++ {
+		project := m.matches[i]
+		desc := ""
+		if project.ID == m.current {
+			desc = "current"
+		}
+		if i == m.cursor {
+			line := lipgloss.NewStyle().Bold(true).Render("▸ "+project.Name) + "  " + desc
+			b.WriteString(m.styles.Task.Selected.Width(inner).Render(line))
+		} else {
+			b.WriteString("  " + project.Name + "  " + descStyle.Render(desc))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(descStyle.Width(inner).Align(lipgloss.Center).Render("↑/↓ select • Enter pick • Esc cancel"))
+
+	return m.styles.UI.Overlay.Width(width).Render(b.String())
+}
+
+var (
+	escapeKey = key.NewBinding(key.WithKeys("esc"))
+	enterKey  = key.NewBinding(key.WithKeys("enter"))
+	upKey     = key.NewBinding(key.WithKeys("up", "ctrl+p", "ctrl+k"))
+	downKey   = key.NewBinding(key.WithKeys("down", "ctrl+n", "ctrl+j"))
+)
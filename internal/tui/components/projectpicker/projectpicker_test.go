@@ -0,0 +1,95 @@
+package projectpicker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func testProjects() []domain.Project {
+	return []domain.Project{
+		{ID: "p1", Name: "Home Renovation", Status: "active"},
+		{ID: "p2", Name: "Work", Status: "on-hold"},
+		{ID: "p3", Name: "Old Website", Status: "completed"},
+	}
+}
+
+func names(projects []domain.Project) string {
+	var out []string
+	for _, p := range projects {
+		out = append(out, p.Name)
+	}
+	return strings.Join(out, ", ")
+}
+
+func typeText(m Model, text string) Model {
+	for _, r := range text {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestShow_LoadsAndListsOpenProjects(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(100, 40).Show("Move to Project", "p1")
+	if !strings.Contains(m.View(), "Loading projects") {
+		t.Errorf("View() should show loading until projects arrive, got:\n%s", m.View())
+	}
+
+	m, _ = m.Update(ProjectsLoadedMsg{Projects: testProjects()})
+	if got := names(m.Matches()); got != "Inbox, Home Renovation, Work" {
+		t.Errorf("Matches() = %s, want the inbox and open projects", got)
+	}
+	if !strings.Contains(m.View(), "Home Renovation  current") {
+		t.Errorf("View() should mark the task's project, got:\n%s", m.View())
+	}
+}
+
+func TestFilter_FuzzyMatches(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(100, 40).Show("Move to Project", "").SetProjects(testProjects())
+
+	m = typeText(m, "hr")
+	if got := names(m.Matches()); got != "Home Renovation" {
+		t.Errorf("Matches() = %s, want Home Renovation", got)
+	}
+}
+
+func TestEnter_PicksProject(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(100, 40).Show("Move to Project", "").SetProjects(testProjects())
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsVisible() || cmd == nil {
+		t.Fatal("Enter should close the picker with a pick")
+	}
+	if msg, ok := cmd().(PickedMsg); !ok || msg.Project.ID != "p1" {
+		t.Errorf("Enter sent %#v, want PickedMsg for p1", cmd())
+	}
+}
+
+func TestEscape_Cancels(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(100, 40).Show("Move to Project", "").SetProjects(testProjects())
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsVisible() {
+		t.Error("Esc should close the picker")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Error("Esc should send CancelledMsg")
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(100, 40).Show("Move to Project", "")
+
+	m, _ = m.Update(ProjectsLoadedMsg{Err: errors.New("OmniFocus is not running")})
+	if !strings.Contains(m.View(), "OmniFocus is not running") {
+		t.Errorf("View() should show the load error, got:\n%s", m.View())
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Enter should pick nothing without projects")
+	}
+}
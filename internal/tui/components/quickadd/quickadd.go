@@ -2,7 +2,9 @@
 package quickadd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,6 +14,9 @@ import (
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 )
 
+// previewDateFormat is the layout used for dates in the live preview
+const previewDateFormat = "Mon Jan 2"
+
 // Model represents the quick add overlay component state
 type Model struct {
 	textInput textinput.Model
@@ -21,6 +26,8 @@ type Model struct {
 	styles    *tui.Styles
 	err       error
 	service   service.OmniFocusService
+	similar   string // Hint naming an existing task like the one being typed
+	seq       int    // Counts input changes, so stale lookups are ignored
 }
 
 // New creates a new quick add overlay component
@@ -50,6 +57,18 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	}
 
 	switch msg := msg.(type) {
+	case similarTickMsg:
+		if msg.seq != m.seq {
+			return m, nil
+		}
+		return m, findSimilar(m.service, m.textInput.Value(), msg.seq)
+
+	case similarFoundMsg:
+		if msg.seq == m.seq {
+			m.similar = msg.hint
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.Type {
 		case tea.KeyEsc:
@@ -62,9 +81,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 		default:
 			// Pass through to text input
+			before := m.textInput.Value()
 			var cmd tea.Cmd
 			m.textInput, cmd = m.textInput.Update(msg)
-			return m, cmd
+			if m.textInput.Value() == before {
+				return m, cmd
+			}
+			return m.inputChanged(cmd)
 		}
 	}
 
@@ -82,7 +105,7 @@ func (m Model) View() string {
 
 	// Calculate modal dimensions
 	modalWidth := min(70, m.width-4)
-	modalHeight := 8
+	modalHeight := 10
 
 	// Build content
 	var content string
@@ -104,6 +127,19 @@ func (m Model) View() string {
 	input := inputStyle.Render(m.textInput.View())
 	content += input + "\n"
 
+	// Live preview of the parsed fields
+	previewStyle := lipgloss.NewStyle().
+		Foreground(m.styles.Colors.Secondary).
+		Width(modalWidth - 4)
+	content += previewStyle.Render(m.Preview()) + "\n"
+
+	// Hint about a possible duplicate, kept subtle
+	similarStyle := lipgloss.NewStyle().
+		Foreground(m.styles.Colors.Secondary).
+		Italic(true).
+		Width(modalWidth - 4)
+	content += similarStyle.Render(m.similar) + "\n"
+
 	// Error display (fixed height to prevent layout shift)
 	errorStyle := lipgloss.NewStyle().
 		Foreground(m.styles.Colors.Error).
@@ -139,10 +175,31 @@ func (m Model) Show() Model {
 	return m
 }
 
+// ShowWithText makes the component visible with the input pre-filled and the cursor at the end
+func (m Model) ShowWithText(text string) Model {
+	m = m.Show()
+	m.textInput.SetValue(text)
+	m.textInput.CursorEnd()
+	return m
+}
+
+// inputChanged clears the similar-task hint for an emptied input, or looks
+// up a new one once typing pauses
+func (m Model) inputChanged(cmd tea.Cmd) (Model, tea.Cmd) {
+	m.seq++
+	if strings.TrimSpace(m.textInput.Value()) == "" {
+		m.similar = ""
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, scheduleSimilar(m.seq))
+}
+
 // Hide makes the component invisible and clears the input
 func (m Model) Hide() Model {
 	m.visible = false
 	m.err = nil
+	m.similar = ""
+	m.seq++
 	m.textInput.SetValue("")
 	m.textInput.Blur()
 	return m
@@ -160,6 +217,37 @@ func (m Model) SetSize(width, height int) Model {
 	return m
 }
 
+// Preview describes the fields parsed from the current input, or is empty when
+// the input does not parse yet
+func (m Model) Preview() string {
+	if strings.TrimSpace(m.textInput.Value()) == "" {
+		return ""
+	}
+
+	taskInput, err := taskparse.Parse(m.textInput.Value())
+	if err != nil {
+		return ""
+	}
+
+	parts := []string{taskInput.Name}
+	if taskInput.ProjectName != "" {
+		parts = append(parts, "@"+taskInput.ProjectName)
+	}
+	for _, tag := range taskInput.TagNames {
+		parts = append(parts, "#"+tag)
+	}
+	if taskInput.DueDate != nil {
+		parts = append(parts, "due "+taskInput.DueDate.Format(previewDateFormat))
+	}
+	if taskInput.DeferDate != nil {
+		parts = append(parts, "defer "+taskInput.DeferDate.Format(previewDateFormat))
+	}
+	if taskInput.Flagged != nil && *taskInput.Flagged {
+		parts = append(parts, "flagged")
+	}
+	return "→ " + strings.Join(parts, " · ")
+}
+
 // submitTask parses the input and creates a task
 func (m Model) submitTask() (Model, tea.Cmd) {
 	input := m.textInput.Value()
@@ -175,7 +263,7 @@ func (m Model) submitTask() (Model, tea.Cmd) {
 
 	// Resolve project name to ID if specified
 	if taskInput.ProjectName != "" {
-		projectID, err := m.service.ResolveProjectName(taskInput.ProjectName)
+		projectID, err := m.service.ResolveProjectName(context.Background(), taskInput.ProjectName)
 		if err != nil {
 			m.err = err
 			return m, func() tea.Msg {
@@ -186,7 +274,7 @@ func (m Model) submitTask() (Model, tea.Cmd) {
 	}
 
 	// Create the task
-	task, err := m.service.CreateTask(taskInput)
+	task, err := m.service.CreateTask(context.Background(), taskInput)
 	if err != nil {
 		m.err = err
 		return m, func() tea.Msg {
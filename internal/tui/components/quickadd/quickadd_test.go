@@ -605,3 +605,51 @@ func TestSubmitTaskProjectResolutionError(t *testing.T) {
 		t.Error("Expected error in ErrorMsg")
 	}
 }
+
+// TestPreview verifies the live preview shows the fields parsed from the input
+func TestPreview(t *testing.T) {
+	styles := tui.DefaultStyles()
+	model := New(styles, &service.MockOmniFocusService{}).Show().SetSize(80, 40)
+
+	if got := model.Preview(); got != "" {
+		t.Errorf("Preview() = %q, want empty for empty input", got)
+	}
+
+	model.textInput.SetValue("Pay rent @Home #finance due:2024-01-19 defer:2024-01-18 !")
+	preview := model.Preview()
+	for _, want := range []string{"Pay rent", "@Home", "#finance", "due Fri Jan 19", "defer Thu Jan 18", "flagged"} {
+		if !strings.Contains(preview, want) {
+			t.Errorf("Preview() = %q, want it to contain %q", preview, want)
+		}
+	}
+	if !strings.Contains(model.View(), "#finance") {
+		t.Error("Expected view to contain the preview")
+	}
+
+	model.textInput.SetValue("Pay rent due:someday")
+	if got := model.Preview(); got != "" {
+		t.Errorf("Preview() = %q, want empty while input does not parse", got)
+	}
+}
+
+// TestShowWithText verifies the input is pre-filled and submitted as typed
+func TestShowWithText(t *testing.T) {
+	styles := tui.DefaultStyles()
+	mockSvc := &service.MockOmniFocusService{
+		CreatedTask: &domain.Task{ID: "test-id", Name: "buy milk"},
+	}
+
+	model := New(styles, mockSvc).ShowWithText("buy milk due:tomorrow")
+
+	if !model.IsVisible() {
+		t.Error("Expected quick add to be visible after ShowWithText()")
+	}
+	if got := model.textInput.Value(); got != "buy milk due:tomorrow" {
+		t.Errorf("textInput.Value() = %q, want %q", got, "buy milk due:tomorrow")
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if mockSvc.CreateInput == nil || mockSvc.CreateInput.Name != "buy milk" || mockSvc.CreateInput.DueDate == nil {
+		t.Errorf("CreateTask() input = %+v, want parsed name and due date", mockSvc.CreateInput)
+	}
+}
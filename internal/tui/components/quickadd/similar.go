@@ -0,0 +1,130 @@
+package quickadd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/taskparse"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// similarDelay is how long typing must pause before looking for similar tasks
+const similarDelay = 300 * time.Millisecond
+
+// similarThreshold is the share of words a name must have in common with an
+// existing task for that task to be hinted as a possible duplicate
+const similarThreshold = 0.6
+
+// similarTickMsg fires when typing may have paused; it is stale unless seq
+// is still the input's latest change
+type similarTickMsg struct{ seq int }
+
+// similarFoundMsg carries the hint found for the input as it was at seq
+type similarFoundMsg struct {
+	seq  int
+	hint string
+}
+
+// scheduleSimilar waits for typing to pause before the input at seq is looked up
+func scheduleSimilar(seq int) tea.Cmd {
+	return tea.Tick(similarDelay, func(time.Time) tea.Msg {
+		return similarTickMsg{seq: seq}
+	})
+}
+
+// findSimilar looks up remaining tasks resembling the task being typed and
+// returns the hint for the closest one, or an empty hint when none is close
+func findSimilar(svc service.OmniFocusService, input string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		name := input
+		if taskInput, err := taskparse.Parse(input); err == nil {
+			name = taskInput.Name
+		}
+
+		tasks, err := svc.GetAllTasks(context.Background(), service.TaskFilters{})
+		var truncated *service.TruncatedError
+		if err != nil && !errors.As(err, &truncated) {
+			// The hint is a nicety; without tasks there is nothing to hint
+			return similarFoundMsg{seq: seq}
+		}
+
+		task, ok := similarTask(name, tasks)
+		if !ok {
+			return similarFoundMsg{seq: seq}
+		}
+		return similarFoundMsg{seq: seq, hint: similarHint(task)}
+	}
+}
+
+// similarHint describes a likely duplicate, e.g. "similar: Pay bill (due Fri Jan 19)"
+func similarHint(task domain.Task) string {
+	hint := "similar: " + task.Name
+	if task.DueDate != nil {
+		hint += " (due " + task.DueDate.Local().Format(previewDateFormat) + ")"
+	}
+	return hint
+}
+
+// similarTask returns the remaining task whose name shares the most words
+// with name, if it shares enough to be a likely duplicate. The last word of
+// name may still be being typed, so it also matches words it begins.
+func similarTask(name string, tasks []domain.Task) (domain.Task, bool) {
+	typed := nameWords(name)
+	if len(typed) == 0 {
+		return domain.Task{}, false
+	}
+
+	var best domain.Task
+	bestScore := 0.0
+	for _, task := range domain.FlattenTasks(tasks) {
+		if task.Completed {
+			continue
+		}
+		if score := similarity(typed, nameWords(task.Name)); score > bestScore {
+			best, bestScore = task, score
+		}
+	}
+	return best, bestScore >= similarThreshold
+}
+
+// similarity is the share of distinct words typed and existing have in
+// common, the last typed word matching any existing word it begins
+func similarity(typed, existing []string) float64 {
+	if len(existing) == 0 {
+		return 0
+	}
+	common := 0
+	matched := make([]bool, len(existing))
+	for i, word := range typed {
+		last := i == len(typed)-1
+		for j, other := range existing {
+			if !matched[j] && (word == other || (last && strings.HasPrefix(other, word))) {
+				matched[j] = true
+				common++
+				break
+			}
+		}
+	}
+	return float64(common) / float64(len(typed)+len(existing)-common)
+}
+
+// nameWords returns the distinct lowercased words of a task name, ignoring
+// punctuation
+func nameWords(name string) []string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
@@ -0,0 +1,105 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func TestSimilarTask(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "t1", Name: "Pay electricity bill"},
+		{ID: "t2", Name: "Call the plumber", Children: []domain.Task{{ID: "t3", Name: "Find plumber's number"}}},
+		{ID: "t4", Name: "Buy milk", Completed: true},
+	}
+
+	tests := []struct {
+		name   string
+		typed  string
+		wantID string
+	}{
+		{name: "same words", typed: "pay the electricity bill!", wantID: "t1"},
+		{name: "last word being typed", typed: "Pay electricity bi", wantID: "t1"},
+		{name: "subtask", typed: "find the plumber's number", wantID: "t3"},
+		{name: "too few words in common", typed: "Pay rent"},
+		{name: "completed tasks are ignored", typed: "Buy milk"},
+		{name: "empty", typed: "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, ok := similarTask(tt.typed, tasks)
+			if tt.wantID == "" {
+				if ok {
+					t.Errorf("similarTask(%q) = %s, want none", tt.typed, task.ID)
+				}
+				return
+			}
+			if !ok || task.ID != tt.wantID {
+				t.Errorf("similarTask(%q) = %s, %v, want %s", tt.typed, task.ID, ok, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestSimilarHint(t *testing.T) {
+	due := time.Date(2024, 1, 19, 17, 0, 0, 0, time.Local)
+	got := similarHint(domain.Task{Name: "Pay electricity bill", DueDate: &due})
+	if want := "similar: Pay electricity bill (due Fri Jan 19)"; got != want {
+		t.Errorf("similarHint() = %q, want %q", got, want)
+	}
+}
+
+// TestSimilarLookupIsDebounced verifies only the lookup for the latest input sets the hint
+func TestSimilarLookupIsDebounced(t *testing.T) {
+	mockSvc := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{{ID: "t1", Name: "Pay electricity bill"}},
+	}
+	model := New(tui.DefaultStyles(), mockSvc).Show()
+
+	for _, ch := range "Pay electricity" {
+		var cmd tea.Cmd
+		model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{ch}})
+		if cmd == nil {
+			t.Fatalf("typing %q returned no command, want a scheduled lookup", ch)
+		}
+	}
+
+	// A tick from an earlier keystroke is stale
+	if _, cmd := model.Update(similarTickMsg{seq: model.seq - 1}); cmd != nil {
+		t.Error("stale tick started a lookup")
+	}
+
+	_, cmd := model.Update(similarTickMsg{seq: model.seq})
+	if cmd == nil {
+		t.Fatal("latest tick started no lookup")
+	}
+	found, ok := cmd().(similarFoundMsg)
+	if !ok || found.hint != "similar: Pay electricity bill" {
+		t.Fatalf("lookup = %#v, want the electricity bill hint", found)
+	}
+
+	model, _ = model.Update(similarFoundMsg{seq: model.seq - 1, hint: "similar: stale"})
+	if model.similar != "" {
+		t.Errorf("stale result set hint %q", model.similar)
+	}
+	model, _ = model.Update(found)
+	if model.similar != found.hint {
+		t.Errorf("similar = %q, want %q", model.similar, found.hint)
+	}
+
+	// Clearing the input clears the hint, as does hiding
+	model.textInput.SetValue("x")
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if model.similar != "" {
+		t.Errorf("similar = %q after clearing the input, want empty", model.similar)
+	}
+	model.similar = found.hint
+	if model = model.Hide(); model.similar != "" {
+		t.Errorf("similar = %q after Hide(), want empty", model.similar)
+	}
+}
@@ -1,4 +1,5 @@
 // Package searchinput provides a search input component for the TUI.
+// Search text starting with filter.RegexPrefix is a regular expression.
 package searchinput
 
 import (
@@ -7,11 +8,14 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
 )
 
-// SearchChangedMsg is sent when search text changes
+// SearchChangedMsg is sent when search text changes, or whether notes are
+// searched
 type SearchChangedMsg struct {
-	Text string
+	Text      string
+	NamesOnly bool
 }
 
 // SearchClearedMsg is sent when search is cleared
@@ -19,15 +23,17 @@ type SearchClearedMsg struct{}
 
 // SearchConfirmedMsg is sent when search is confirmed (Enter)
 type SearchConfirmedMsg struct {
-	Text string
+	Text      string
+	NamesOnly bool
 }
 
 // Model represents the search input state
 type Model struct {
-	input   textinput.Model
-	visible bool
-	styles  *tui.Styles
-	width   int
+	input     textinput.Model
+	visible   bool
+	namesOnly bool // Search task names but not notes; kept between searches
+	styles    *tui.Styles
+	width     int
 }
 
 // New creates a new search input
@@ -69,6 +75,16 @@ func (m Model) Value() string {
 	return m.input.Value()
 }
 
+// NamesOnly returns true if only task names are searched, not notes
+func (m Model) NamesOnly() bool {
+	return m.namesOnly
+}
+
+// state returns the filter the current input searches with
+func (m Model) state() filter.State {
+	return filter.State{SearchText: m.input.Value(), NamesOnly: m.namesOnly}
+}
+
 // SetWidth sets the width for the input
 func (m Model) SetWidth(width int) Model {
 	m.width = width
@@ -99,8 +115,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		case key.Matches(msg, enterKey):
 			m.visible = false
 			m.input.Blur()
-			text := m.input.Value()
-			return m, func() tea.Msg { return SearchConfirmedMsg{Text: text} }
+			text, namesOnly := m.input.Value(), m.namesOnly
+			return m, func() tea.Msg { return SearchConfirmedMsg{Text: text, NamesOnly: namesOnly} }
+
+		case key.Matches(msg, notesKey):
+			m.namesOnly = !m.namesOnly
+			text, namesOnly := m.input.Value(), m.namesOnly
+			return m, func() tea.Msg { return SearchChangedMsg{Text: text, NamesOnly: namesOnly} }
 		}
 	}
 
@@ -110,10 +131,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	m.input, cmd = m.input.Update(msg)
 
 	// Emit change event if text changed
-	newValue := m.input.Value()
+	newValue, namesOnly := m.input.Value(), m.namesOnly
 	if newValue != prevValue {
 		return m, tea.Batch(cmd, func() tea.Msg {
-			return SearchChangedMsg{Text: newValue}
+			return SearchChangedMsg{Text: newValue, NamesOnly: namesOnly}
 		})
 	}
 
@@ -126,17 +147,36 @@ func (m Model) View() string {
 		return ""
 	}
 
-	// Render at bottom of screen
+	// Render at bottom of screen, with where the text is looked for on the right
 	inputStyle := lipgloss.NewStyle().
-		Background(m.styles.Colors.Primary).
-		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(m.styles.Colors.Selection).
+		Foreground(m.styles.Colors.OnSelection).
 		Padding(0, 1).
 		Width(m.width)
 
-	return inputStyle.Render(m.input.View())
+	status := "names + notes (^N)"
+	if m.namesOnly {
+		status = "names only (^N)"
+	}
+	state := m.state()
+	if _, err := state.SearchRegexp(); err != nil {
+		status = "invalid regex • " + status
+	} else if state.IsRegexp() {
+		status = "regex • " + status
+	}
+
+	// Narrow the input to make room for the status, unless that leaves too
+	// little to type in
+	input := m.input
+	if width := input.Width - lipgloss.Width(status) - 2; width >= 10 {
+		input.Width = width
+		return inputStyle.Render(input.View() + " " + status)
+	}
+	return inputStyle.Render(input.View())
 }
 
 var (
 	escapeKey = key.NewBinding(key.WithKeys("esc", "escape"))
 	enterKey  = key.NewBinding(key.WithKeys("enter"))
+	notesKey  = key.NewBinding(key.WithKeys("ctrl+n"))
 )
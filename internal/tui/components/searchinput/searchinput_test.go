@@ -1,6 +1,7 @@
 package searchinput
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -114,3 +115,53 @@ func TestView_NotVisible(t *testing.T) {
 		t.Error("view should be empty when not visible")
 	}
 }
+
+func TestUpdate_CtrlN_TogglesNotes(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show().SetWidth(80)
+	m.input.SetValue("invoice")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+
+	if !m.NamesOnly() {
+		t.Error("NamesOnly() = false after ctrl+n, want true")
+	}
+	if cmd == nil {
+		t.Fatal("expected SearchChangedMsg command")
+	}
+	changed, ok := cmd().(SearchChangedMsg)
+	if !ok || changed.Text != "invoice" || !changed.NamesOnly {
+		t.Errorf("msg = %+v, want the text searched in names only", changed)
+	}
+
+	// The choice is kept for the next search
+	m = m.Hide().Show()
+	if !m.NamesOnly() {
+		t.Error("NamesOnly() should be kept between searches")
+	}
+}
+
+func TestView_ShowsSearchMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		namesOnly bool
+		want      string
+	}{
+		{name: "text", value: "call", want: "names + notes"},
+		{name: "names only", value: "call", namesOnly: true, want: "names only"},
+		{name: "regex", value: "re:^call", want: "regex • names + notes"},
+		{name: "invalid regex", value: "re:(call", want: "invalid regex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tui.DefaultStyles()).Show().SetWidth(80)
+			m.input.SetValue(tt.value)
+			m.namesOnly = tt.namesOnly
+
+			if view := m.View(); !strings.Contains(view, tt.want) {
+				t.Errorf("View() = %q, want it to contain %q", view, tt.want)
+			}
+		})
+	}
+}
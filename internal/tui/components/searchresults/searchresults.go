@@ -0,0 +1,191 @@
+// Package searchresults provides an overlay listing the tasks a search across
+// the whole database found, from which a task or its project can be opened.
+package searchresults
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// SelectedMsg is sent when a task is picked to open
+type SelectedMsg struct {
+	Task domain.Task
+}
+
+// ProjectMsg is sent when jumping to the project of a task
+type ProjectMsg struct {
+	Task domain.Task
+}
+
+// CancelledMsg is sent when the results are closed without picking a task
+type CancelledMsg struct{}
+
+// Model represents the search results state
+type Model struct {
+	query   string
+	tasks   []domain.Task
+	cursor  int
+	offset  int // First row shown
+	visible bool
+	styles  *tui.Styles
+	width   int
+	height  int
+}
+
+// New creates a new search results overlay
+func New(styles *tui.Styles) Model {
+	return Model{styles: styles}
+}
+
+// Show opens the overlay with the tasks found for query
+func (m Model) Show(query string, tasks []domain.Task) Model {
+	m.query = query
+	m.tasks = tasks
+	m.cursor = 0
+	m.offset = 0
+	m.visible = true
+	return m
+}
+
+// Hide closes the overlay
+func (m Model) Hide() Model {
+	m.visible = false
+	return m
+}
+
+// IsVisible returns true if the overlay is visible
+func (m Model) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the dimensions for the overlay
+func (m Model) SetSize(width, height int) Model {
+	m.width = width
+	m.height = height
+	return m.scroll()
+}
+
+// Init initializes the component
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, escapeKey):
+		m = m.Hide()
+		return m, func() tea.Msg { return CancelledMsg{} }
+	case key.Matches(keyMsg, upKey):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, downKey):
+		if m.cursor < len(m.tasks)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, enterKey):
+		if m.cursor >= len(m.tasks) {
+			return m, nil
+		}
+		task := m.tasks[m.cursor]
+		m = m.Hide()
+		return m, func() tea.Msg { return SelectedMsg{Task: task} }
+	case key.Matches(keyMsg, projectKey):
+		if m.cursor >= len(m.tasks) || m.tasks[m.cursor].ProjectID == "" {
+			return m, nil
+		}
+		task := m.tasks[m.cursor]
+		m = m.Hide()
+		return m, func() tea.Msg { return ProjectMsg{Task: task} }
+	}
+	return m.scroll(), nil
+}
+
+// rows returns how many results fit in the overlay
+func (m Model) rows() int {
+	// Border, padding, title, blank lines and hint take 9 lines
+	return max(3, m.height-9)
+}
+
+// scroll keeps the cursor within the rows shown
+func (m Model) scroll() Model {
+	rows := m.rows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+	return m
+}
+
+// View renders the overlay
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	width := min(80, m.width-4)
+	if width < 30 {
+		width = 30
+	}
+	inner := width - 4
+
+	var b strings.Builder
+	title := fmt.Sprintf("Search: %s (%d)", m.query, len(m.tasks))
+	b.WriteString(m.styles.UI.Header.Width(inner).Align(lipgloss.Center).Render(ansi.Truncate(title, inner-2, "…")))
+	b.WriteString("\n\n")
+
+	descStyle := lipgloss.NewStyle().Foreground(m.styles.Colors.Secondary)
+	if len(m.tasks) == 0 {
+		b.WriteString(descStyle.Render("No matching tasks"))
+		b.WriteString("\n")
+	}
+	end := min(len(m.tasks), m.offset+m.rows())
+	for i := m.offset; i < end; i++ {
+		task := m.tasks[i]
+		project := task.ProjectName
+		if task.ProjectID == "" {
+			project = "Inbox"
+		}
+		project = ansi.Truncate(project, inner/3, "…")
+		name := ansi.Truncate(task.Name, inner-4-lipgloss.Width(project), "…")
+		gap := strings.Repeat(" ", max(1, inner-2-lipgloss.Width(name)-lipgloss.Width(project)))
+		if i == m.cursor {
+			b.WriteString(m.styles.Task.Selected.Width(inner).Render("▸ " + name + gap + project))
+		} else {
+			b.WriteString("  " + name + gap + descStyle.Render(project))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(descStyle.Width(inner).Align(lipgloss.Center).Render("↑/↓ select • Enter open • p project • Esc close"))
+
+	return m.styles.UI.Overlay.Width(width).Render(b.String())
+}
+
+var (
+	escapeKey  = key.NewBinding(key.WithKeys("esc", "q"))
+	enterKey   = key.NewBinding(key.WithKeys("enter"))
+	projectKey = key.NewBinding(key.WithKeys("p"))
+	upKey      = key.NewBinding(key.WithKeys("up", "k"))
+	downKey    = key.NewBinding(key.WithKeys("down", "j"))
+)
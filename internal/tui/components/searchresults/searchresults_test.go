@@ -0,0 +1,118 @@
+package searchresults
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func testTasks() []domain.Task {
+	return []domain.Task{
+		{ID: "t1", Name: "Renew passport"},
+		{ID: "t2", Name: "Book passport photos", ProjectID: "p1", ProjectName: "Travel"},
+	}
+}
+
+func TestShow(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(80, 24)
+	if m.IsVisible() {
+		t.Error("new overlay should not be visible")
+	}
+
+	m = m.Show("passport", testTasks())
+
+	if !m.IsVisible() {
+		t.Error("overlay should be visible after Show()")
+	}
+	view := m.View()
+	for _, want := range []string{"Search: passport (2)", "Renew passport", "Inbox", "Book passport photos", "Travel"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+}
+
+func TestShow_Empty(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetSize(80, 24).Show("nothing", nil)
+
+	if !strings.Contains(m.View(), "No matching tasks") {
+		t.Errorf("View() = %q, want it to say nothing matched", m.View())
+	}
+}
+
+func TestUpdate_EnterSelects(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show("passport", testTasks())
+
+	m, _ = m.Update(runeKey('j'))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.IsVisible() {
+		t.Error("overlay should close after Enter")
+	}
+	msg, ok := cmd().(SelectedMsg)
+	if !ok || msg.Task.ID != "t2" {
+		t.Errorf("Enter produced %+v, want SelectedMsg for t2", cmd())
+	}
+}
+
+func TestUpdate_ProjectJumps(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show("passport", testTasks())
+
+	// The inbox task has no project to jump to
+	m, cmd := m.Update(runeKey('p'))
+	if cmd != nil || !m.IsVisible() {
+		t.Error("p on an inbox task should do nothing")
+	}
+
+	m, _ = m.Update(runeKey('j'))
+	m, cmd = m.Update(runeKey('p'))
+
+	if m.IsVisible() {
+		t.Error("overlay should close after p")
+	}
+	msg, ok := cmd().(ProjectMsg)
+	if !ok || msg.Task.ProjectID != "p1" {
+		t.Errorf("p produced %+v, want ProjectMsg for p1", cmd())
+	}
+}
+
+func TestUpdate_Scrolls(t *testing.T) {
+	var tasks []domain.Task
+	for i := range 30 {
+		tasks = append(tasks, domain.Task{ID: fmt.Sprint(i), Name: fmt.Sprintf("Task %02d", i)})
+	}
+	m := New(tui.DefaultStyles()).SetSize(80, 15).Show("task", tasks)
+
+	for range 20 {
+		m, _ = m.Update(runeKey('j'))
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "Task 20") {
+		t.Error("View() should show the row under the cursor")
+	}
+	if strings.Contains(view, "Task 00") {
+		t.Error("View() should scroll past the first rows")
+	}
+}
+
+func TestUpdate_EscapeCancels(t *testing.T) {
+	m := New(tui.DefaultStyles()).Show("passport", testTasks())
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.IsVisible() {
+		t.Error("overlay should close on Esc")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Errorf("Esc produced %T, want CancelledMsg", cmd())
+	}
+}
@@ -0,0 +1,195 @@
+// Package statusbar provides the bar along the bottom of the screen showing
+// the views as tabs, with a spinner on those still loading, active filters,
+// item counts and the last refresh time.
+package statusbar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// separator goes between the segments on the right of the bar
+const separator = " │ "
+
+// Model represents the status bar
+type Model struct {
+	styles    *tui.Styles
+	tabs      []string
+	active    int
+	loading   map[int]bool // Indexes of tabs whose data is loading
+	spinner   spinner.Model
+	filters   []string
+	count     string
+	recording string
+	refreshed time.Time
+	width     int
+}
+
+// New creates a status bar with the given tab labels, the first one active
+func New(styles *tui.Styles, tabs []string) Model {
+	return Model{
+		styles:  styles,
+		tabs:    tabs,
+		loading: make(map[int]bool),
+		spinner: spinner.New(spinner.WithSpinner(spinner.MiniDot), spinner.WithStyle(styles.UI.Status)),
+	}
+}
+
+// Init starts the spinner shown on loading tabs
+func (m Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Update advances the spinner while any tab is loading
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	tick, ok := msg.(spinner.TickMsg)
+	if !ok || !m.Loading() {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(tick)
+	return m, cmd
+}
+
+// SetLoading shows or hides the spinner on the tab at index
+func (m Model) SetLoading(index int, loading bool) Model {
+	updated := make(map[int]bool, len(m.loading)+1)
+	for i := range m.loading {
+		updated[i] = true
+	}
+	if loading {
+		updated[index] = true
+	} else {
+		delete(updated, index)
+	}
+	m.loading = updated
+	return m
+}
+
+// TabLoading reports whether the tab at index is loading
+func (m Model) TabLoading(index int) bool {
+	return m.loading[index]
+}
+
+// Loading reports whether any tab is loading
+func (m Model) Loading() bool {
+	return len(m.loading) > 0
+}
+
+// SetWidth sets the width the bar fills
+func (m Model) SetWidth(width int) Model {
+	m.width = width
+	return m
+}
+
+// SetActive highlights the tab at index
+func (m Model) SetActive(index int) Model {
+	m.active = index
+	return m
+}
+
+// SetFilters sets the active filter conditions, e.g. "project: Home"
+func (m Model) SetFilters(filters []string) Model {
+	m.filters = filters
+	return m
+}
+
+// SetCount sets the item count shown, e.g. "12 tasks"
+func (m Model) SetCount(count string) Model {
+	m.count = count
+	return m
+}
+
+// SetRecording sets the macro register being recorded, or "" when idle
+func (m Model) SetRecording(register string) Model {
+	m.recording = register
+	return m
+}
+
+// SetRefreshed sets when the shown data was last loaded
+func (m Model) SetRefreshed(t time.Time) Model {
+	m.refreshed = t
+	return m
+}
+
+// Refreshed returns when the shown data was last loaded
+func (m Model) Refreshed() time.Time {
+	return m.refreshed
+}
+
+// TabAt returns the index of the tab rendered at column x
+func (m Model) TabAt(x int) (int, bool) {
+	start := 0
+	for i, cell := range m.tabCells() {
+		end := start + lipgloss.Width(cell)
+		if x >= start && x < end {
+			return i, true
+		}
+		start = end + 1
+	}
+	return 0, false
+}
+
+// View renders the bar as a single line: tabs on the left and status on the
+// right, which is cut short when the bar is too narrow for both
+func (m Model) View() string {
+	tabs := strings.Join(m.tabCells(), " ")
+	status := m.renderStatus()
+	if status == "" {
+		return tabs
+	}
+	if m.width <= 0 {
+		return tabs + " " + status
+	}
+
+	room := m.width - lipgloss.Width(tabs) - 1
+	if room < 1 {
+		return ansi.Truncate(tabs, m.width, "")
+	}
+	status = ansi.Truncate(status, room, "…")
+	gap := m.width - lipgloss.Width(tabs) - lipgloss.Width(status)
+	return tabs + strings.Repeat(" ", gap) + status
+}
+
+// tabCells renders the label of each tab, followed by a spinner while its
+// data is loading
+func (m Model) tabCells() []string {
+	cells := make([]string, 0, len(m.tabs))
+	for i, label := range m.tabs {
+		style := m.styles.UI.Tab
+		if i == m.active {
+			style = m.styles.UI.ActiveTab
+		}
+		cell := style.Render(label)
+		if m.loading[i] {
+			cell += m.spinner.View()
+		}
+		cells = append(cells, cell)
+	}
+	return cells
+}
+
+// renderStatus renders the filter, count, recording and refresh segments
+func (m Model) renderStatus() string {
+	var segments []string
+	if len(m.filters) > 0 {
+		segments = append(segments, m.styles.UI.StatusFilter.Render("filter: "+strings.Join(m.filters, ", ")))
+	}
+	if m.count != "" {
+		segments = append(segments, m.styles.UI.Status.Render(m.count))
+	}
+	if m.recording != "" {
+		segments = append(segments, m.styles.UI.StatusFilter.Render("recording @"+m.recording))
+	}
+	if !m.refreshed.IsZero() {
+		segments = append(segments, m.styles.UI.Status.Render(fmt.Sprintf("refreshed %s", m.refreshed.Format("15:04"))))
+	}
+	return strings.Join(segments, m.styles.UI.Status.Render(separator))
+}
@@ -0,0 +1,107 @@
+package statusbar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func newTestBar() Model {
+	return New(tui.DefaultStyles(), []string{"1 Inbox", "2 Projects"})
+}
+
+func TestView_ShowsTabsAndStatus(t *testing.T) {
+	refreshed := time.Date(2026, 3, 4, 9, 30, 0, 0, time.Local)
+	m := newTestBar().
+		SetWidth(100).
+		SetFilters([]string{"flagged only"}).
+		SetCount("3 tasks").
+		SetRecording("a").
+		SetRefreshed(refreshed)
+
+	view := m.View()
+	for _, want := range []string{"1 Inbox", "2 Projects", "filter: flagged only", "3 tasks", "recording @a", "refreshed 09:30"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() = %q, want it to contain %q", view, want)
+		}
+	}
+	if got := lipgloss.Width(view); got != 100 {
+		t.Errorf("View() width = %d, want 100", got)
+	}
+}
+
+func TestView_TruncatesStatusWhenNarrow(t *testing.T) {
+	m := newTestBar().SetWidth(30).SetCount("120 tasks").SetFilters([]string{"project: A long project name"})
+
+	view := m.View()
+	if got := lipgloss.Width(view); got != 30 {
+		t.Errorf("View() width = %d, want 30", got)
+	}
+	if !strings.Contains(view, "1 Inbox") {
+		t.Errorf("View() = %q, tabs should stay visible", view)
+	}
+}
+
+func TestView_NoStatusRendersOnlyTabs(t *testing.T) {
+	m := newTestBar().SetWidth(80)
+	if view := m.View(); view != strings.Join(m.tabCells(), " ") {
+		t.Errorf("View() = %q, want only the tabs", view)
+	}
+}
+
+func TestTabAt(t *testing.T) {
+	m := newTestBar().SetActive(1)
+	first := lipgloss.Width(m.tabCells()[0])
+
+	tests := []struct {
+		x      int
+		want   int
+		wantOK bool
+	}{
+		{x: 0, want: 0, wantOK: true},
+		{x: first - 1, want: 0, wantOK: true},
+		{x: first, wantOK: false},
+		{x: first + 1, want: 1, wantOK: true},
+		{x: 200, wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := m.TabAt(tt.x)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("TabAt(%d) = %d, %v, want %d, %v", tt.x, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSetLoading(t *testing.T) {
+	m := newTestBar().SetLoading(1, true)
+
+	if !m.TabLoading(1) || m.TabLoading(0) {
+		t.Errorf("TabLoading(0), TabLoading(1) = %v, %v, want false, true", m.TabLoading(0), m.TabLoading(1))
+	}
+	if !m.Loading() {
+		t.Error("Loading() = false, want true")
+	}
+	if cells := m.tabCells(); lipgloss.Width(cells[1]) <= lipgloss.Width("2 Projects") {
+		t.Errorf("tabCells()[1] = %q, want a spinner after the label", cells[1])
+	}
+
+	m = m.SetLoading(1, false)
+	if m.Loading() {
+		t.Error("Loading() = true after clearing the only loading tab, want false")
+	}
+}
+
+func TestUpdate_SpinsOnlyWhileLoading(t *testing.T) {
+	m := newTestBar()
+	tick := m.Init()()
+
+	if _, cmd := m.Update(tick); cmd != nil {
+		t.Error("Update() returned a command with no tab loading, want the spinner stopped")
+	}
+	if _, cmd := m.SetLoading(0, true).Update(tick); cmd == nil {
+		t.Error("Update() returned no command while a tab is loading, want the next tick")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 )
 
@@ -33,6 +34,7 @@ type Model struct {
 	keys    tui.KeyMap
 	loading bool
 	empty   bool
+	limits  []limits.Limit
 }
 
 // New creates a new tag list component
@@ -57,6 +59,8 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -89,6 +93,42 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleMouse selects the clicked tag and pages through the list with
+// the scroll wheel. Coordinates are relative to the component's first line.
+func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if len(m.tags) == 0 || m.loading {
+		return m, nil
+	}
+
+	if direction := tui.WheelDirection(msg); direction != 0 {
+		m.cursor = tui.PageCursor(m.cursor, len(m.tags), m.height, direction)
+		return m, nil
+	}
+
+	if tui.IsClick(msg) {
+		if row, ok := m.RowAt(msg.Y); ok {
+			m.cursor = row
+		}
+	}
+	return m, nil
+}
+
+// RowAt returns the index of the tag rendered on line y, accounting for
+// lines that wrap
+func (m Model) RowAt(y int) (int, bool) {
+	if y < 0 || m.loading {
+		return 0, false
+	}
+	line := 0
+	for i, tagWithCount := range m.tags {
+		line += lipgloss.Height(m.formatTagLine(tagWithCount, i == m.cursor))
+		if y < line {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
 // View renders the component
 func (m Model) View() string {
 	if m.loading {
@@ -135,8 +175,12 @@ func (m Model) formatTagLine(twc TagWithCount, selected bool) string {
 	// Build left side with tag icon and name
 	leftSide := fmt.Sprintf("%s%s %s", indent, TagIcon, twc.Tag.Name)
 
-	// Build right side (task count)
+	// Build right side (task count, against its WIP limit if it has one)
 	rightSide := fmt.Sprintf("(%d)", twc.Count)
+	limit, limited := limits.ForTag(m.limits, twc.Tag.Name)
+	if limited {
+		rightSide = limit.Badge(twc.Count)
+	}
 
 	// Calculate spacing
 	contentWidth := m.width
@@ -157,13 +201,21 @@ func (m Model) formatTagLine(twc TagWithCount, selected bool) string {
 	if selected {
 		return m.styles.Task.Selected.Render(line)
 	}
+	if limited && twc.Count > limit.Max {
+		return m.styles.UI.OverLimit.Render(line)
+	}
 
 	return m.styles.Tag.Badge.Render(line)
 }
 
-// SetTags updates the tag list with counts
+// SetTags updates the tag list with counts, keeping the selected tag or its
+// nearest neighbor selected
 func (m Model) SetTags(tags []domain.Tag, counts map[string]int) Model {
-	m.tags = m.flattenTags(tags, counts, 0)
+	flat := m.flattenTags(tags, counts, 0)
+	if i, ok := tui.KeepSelection(tagIDs(m.tags), m.cursor, tagIDs(flat)); ok {
+		m.cursor = i
+	}
+	m.tags = flat
 	m.empty = len(m.tags) == 0
 	m.loading = false
 	if m.cursor >= len(m.tags) {
@@ -195,6 +247,12 @@ func (m Model) flattenTags(tags []domain.Tag, counts map[string]int, depth int)
 	return result
 }
 
+// SetLimits sets the WIP limits shown next to tag counts
+func (m Model) SetLimits(limitList []limits.Limit) Model {
+	m.limits = limitList
+	return m
+}
+
 // SetLoading sets the loading state
 func (m Model) SetLoading(loading bool) Model {
 	m.loading = loading
@@ -218,3 +276,12 @@ func (m Model) SelectedIndex() int {
 func (m Model) Tags() []TagWithCount {
 	return m.tags
 }
+
+// tagIDs returns the IDs of tags in order
+func tagIDs(tags []TagWithCount) []string {
+	ids := make([]string, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.Tag.ID
+	}
+	return ids
+}
@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 )
 
@@ -197,6 +198,27 @@ func TestViewTags(t *testing.T) {
 	}
 }
 
+func TestViewTags_Limits(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetLimits([]limits.Limit{{Tag: "Doing", Max: 5}, {Tag: "waiting", Max: 3}})
+	m = m.SetTags([]domain.Tag{
+		{ID: "t1", Name: "doing"},
+		{ID: "t2", Name: "waiting"},
+		{ID: "t3", Name: "phone"},
+	}, map[string]int{"t1": 6, "t2": 2, "t3": 4})
+
+	view := m.View()
+	if !strings.Contains(view, limits.WarnIcon+" (6/5)") {
+		t.Errorf("view should flag the tag over its limit, got:\n%s", view)
+	}
+	if !strings.Contains(view, "(2/3)") || strings.Contains(view, limits.WarnIcon+" (2/3)") {
+		t.Errorf("view should show the count against the limit without a warning, got:\n%s", view)
+	}
+	if !strings.Contains(view, "(4)") {
+		t.Errorf("view should show a plain count for tags without a limit, got:\n%s", view)
+	}
+}
+
 func TestHierarchicalTags(t *testing.T) {
 	styles := tui.DefaultStyles()
 	keys := tui.DefaultKeyMap()
@@ -251,3 +273,14 @@ func TestCursorClamp(t *testing.T) {
 		t.Errorf("cursor = %d, want 2 (clamped to last item)", m.cursor)
 	}
 }
+
+func TestSetTagsKeepsSelection(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTags([]domain.Tag{{ID: "t1"}, {ID: "t2"}}, nil)
+	m.cursor = 1
+
+	m = m.SetTags([]domain.Tag{{ID: "t0"}, {ID: "t1"}, {ID: "t2"}}, nil)
+	if got := m.SelectedTag(); got == nil || got.ID != "t2" {
+		t.Errorf("SelectedTag() = %v, want t2", got)
+	}
+}
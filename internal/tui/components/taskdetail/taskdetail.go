@@ -3,13 +3,17 @@ package taskdetail
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	glamourstyles "github.com/charmbracelet/glamour/styles"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 )
@@ -32,6 +36,10 @@ type FlagRequestedMsg struct {
 	Flagged bool
 }
 
+// OpenURLRequestedMsg signals the user wants to open a link from the note or
+// the task itself in OmniFocus.
+type OpenURLRequestedMsg struct{ URL string }
+
 // Model represents the task detail view state
 type Model struct {
 	task     *domain.Task
@@ -39,9 +47,11 @@ type Model struct {
 	styles   *tui.Styles
 	keys     tui.KeyMap
 	viewport viewport.Model
+	links    []string // URLs found in the note
+	link     int      // Index of the link opened by o
 	width    int
 	height   int
-	ready    bool
+	showPath bool // Show the folders containing the project
 }
 
 // New creates a new task detail view
@@ -57,8 +67,10 @@ func New(styles *tui.Styles, keys tui.KeyMap) Model {
 func (m Model) Show(task *domain.Task) Model {
 	m.task = task
 	m.visible = true
-	m.ready = false
-	return m
+	m.links = FindLinks(task.Note)
+	m.link = 0
+	m.viewport = viewport.Model{}
+	return m.layout()
 }
 
 // Hide closes the task detail view
@@ -82,16 +94,33 @@ func (m Model) Task() *domain.Task {
 func (m Model) SetSize(width, height int) Model {
 	m.width = width
 	m.height = height
+	return m.layout()
+}
 
-	// Update viewport size
-	modalWidth := min(70, width-4)
-	modalHeight := min(20, height-4)
+// SetShowPath sets whether the project is shown with the folders containing it
+func (m Model) SetShowPath(show bool) Model {
+	m.showPath = show
+	return m.layout()
+}
+
+// modalWidth returns the width of the overlay
+func (m Model) modalWidth() int {
+	return max(min(70, m.width-4), 30)
+}
 
-	if m.visible && m.ready {
-		m.viewport.Width = modalWidth - 4
-		m.viewport.Height = modalHeight - 6 // Account for header and footer
+// layout sizes the viewport and fills it with the task's details, keeping
+// the scroll position
+func (m Model) layout() Model {
+	if !m.visible || m.task == nil {
+		return m
 	}
 
+	width := m.modalWidth() - 4
+	height := max(min(20, m.height-4)-6, 3) // Account for header and footer
+	offset := m.viewport.YOffset
+	m.viewport = viewport.New(width, height)
+	m.viewport.SetContent(m.buildContent(width))
+	m.viewport.SetYOffset(offset)
 	return m
 }
 
@@ -151,6 +180,24 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 			return FlagRequestedMsg{TaskID: m.task.ID, Flagged: !m.task.Flagged}
 		}
 
+	// Open the selected link
+	case key.Matches(msg, openLinkKey) && len(m.links) > 0:
+		url := m.links[m.link]
+		return m, func() tea.Msg { return OpenURLRequestedMsg{URL: url} }
+
+	// Open the task in OmniFocus; o does so only when the note has no links
+	case key.Matches(msg, m.keys.Open, openTaskKey):
+		url := domain.TaskURL(m.task.ID)
+		return m, func() tea.Msg { return OpenURLRequestedMsg{URL: url} }
+
+	// Select the next link
+	case key.Matches(msg, nextLinkKey):
+		if len(m.links) > 0 {
+			m.link = (m.link + 1) % len(m.links)
+			m.viewport.SetContent(m.buildContent(m.viewport.Width))
+		}
+		return m, nil
+
 	// Scroll down
 	case key.Matches(msg, m.keys.Down):
 		m.viewport.ScrollDown(1)
@@ -171,23 +218,7 @@ func (m Model) View() string {
 		return ""
 	}
 
-	modalWidth := min(70, m.width-4)
-	if modalWidth < 30 {
-		modalWidth = 30
-	}
-
-	// Build content
-	content := m.buildContent(modalWidth - 4)
-
-	// Initialize viewport if not ready
-	if !m.ready {
-		modalHeight := min(20, m.height-4)
-		m.viewport = viewport.New(modalWidth-4, modalHeight-6)
-		m.viewport.SetContent(content)
-		m.ready = true
-	} else {
-		m.viewport.SetContent(content)
-	}
+	modalWidth := m.modalWidth()
 
 	// Header
 	header := m.renderHeader(modalWidth - 4)
@@ -238,8 +269,12 @@ func (m Model) buildContent(width int) string {
 
 	// Project
 	if m.task.ProjectName != "" {
+		project := m.task.ProjectName
+		if m.showPath {
+			project = m.task.Path()
+		}
 		b.WriteString(labelStyle.Render("Project:"))
-		b.WriteString(valueStyle.Render(m.task.ProjectName))
+		b.WriteString(valueStyle.Render(project))
 		b.WriteString("\n")
 	}
 
@@ -250,17 +285,37 @@ func (m Model) buildContent(width int) string {
 		b.WriteString("\n")
 	}
 
-	// Due Date
-	if m.task.DueDate != nil {
+	// Due Date, its own or inherited from the project or parent
+	if due := m.task.EffectiveDue(); due != nil {
 		b.WriteString(labelStyle.Render("Due:"))
-		b.WriteString(m.formatDueDate(*m.task.DueDate, valueStyle))
+		b.WriteString(m.formatDueDate(*due, valueStyle))
+		if m.task.DueInherited() {
+			b.WriteString(m.styles.UI.Help.Render(" " + inheritedLabel))
+		}
 		b.WriteString("\n")
 	}
 
 	// Defer Date
-	if m.task.DeferDate != nil {
+	if deferDate := m.task.EffectiveDefer(); deferDate != nil {
 		b.WriteString(labelStyle.Render("Defer:"))
-		b.WriteString(valueStyle.Render(formatDateTime(*m.task.DeferDate)))
+		b.WriteString(valueStyle.Render(formatDateTime(*deferDate)))
+		if m.task.DeferInherited() {
+			b.WriteString(m.styles.UI.Help.Render(" " + inheritedLabel))
+		}
+		b.WriteString("\n")
+	}
+
+	// Repeat
+	if m.task.Repetition != nil {
+		b.WriteString(labelStyle.Render("Repeats:"))
+		b.WriteString(valueStyle.Render(m.task.Repetition.String()))
+		b.WriteString("\n")
+	}
+
+	// Estimate
+	if m.task.EstimatedMinutes != 0 {
+		b.WriteString(labelStyle.Render("Estimate:"))
+		b.WriteString(valueStyle.Render(domain.FormatEstimate(m.task.EstimatedMinutes)))
 		b.WriteString("\n")
 	}
 
@@ -275,16 +330,102 @@ func (m Model) buildContent(width int) string {
 	if m.task.Note != "" {
 		b.WriteString("\n")
 		b.WriteString(labelStyle.Render("Note:"))
+		b.WriteString(m.styles.UI.Help.Render(NoteSummary(m.task.Note)))
 		b.WriteString("\n")
-		noteStyle := lipgloss.NewStyle().
-			Width(width).
-			Foreground(m.styles.Colors.Secondary)
-		b.WriteString(noteStyle.Render(m.task.Note))
+		b.WriteString(m.renderNote(width))
+	}
+
+	// Links, with the one o opens highlighted
+	if len(m.links) > 0 {
+		b.WriteString("\n\n")
+		b.WriteString(labelStyle.Render("Links:"))
+		b.WriteString(m.styles.UI.Help.Render("[o]pen  [tab] next"))
+		linkStyle := lipgloss.NewStyle().Foreground(m.styles.Colors.Secondary)
+		selectedStyle := lipgloss.NewStyle().Foreground(m.styles.Colors.Primary).Underline(true)
+		for i, link := range m.links {
+			b.WriteString("\n")
+			style := linkStyle
+			if i == m.link {
+				style = selectedStyle
+			}
+			b.WriteString(fmt.Sprintf("%2d. ", i+1) + style.Render(ansi.Truncate(link, width-4, "…")))
+		}
 	}
 
 	return b.String()
 }
 
+// renderNote renders the note as Markdown wrapped to width, falling back to
+// plain text when it cannot be rendered
+func (m Model) renderNote(width int) string {
+	style := glamourstyles.LightStyle
+	if lipgloss.HasDarkBackground() {
+		style = glamourstyles.DarkStyle
+	}
+	// Leave room for the document margin glamour adds on both sides
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(style),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+		glamour.WithWordWrap(width-4),
+	)
+	if err == nil {
+		if out, err := r.Render(m.task.Note); err == nil {
+			return trimLines(strings.Trim(out, "\n"))
+		}
+	}
+	return lipgloss.NewStyle().
+		Width(width).
+		Foreground(m.styles.Colors.Secondary).
+		Render(m.task.Note)
+}
+
+// trailingPadding matches the styled spaces glamour pads lines with
+var trailingPadding = regexp.MustCompile(`(?:\x1b\[[0-9;]*m| )+$`)
+
+// trimLines removes the padding after the text of each rendered line, so
+// lines wider than the overlay do not wrap
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		trimmed := trailingPadding.ReplaceAllString(line, "")
+		if strings.Contains(trimmed, "\x1b[") {
+			trimmed += "\x1b[0m"
+		}
+		lines[i] = trimmed
+	}
+	return strings.Join(lines, "\n")
+}
+
+// urlPattern matches http and https URLs in free text
+var urlPattern = regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+`)
+
+// NoteSummary describes the length of a note, e.g. "120 words · 1 min read"
+func NoteSummary(note string) string {
+	words := domain.NoteWords(note)
+	unit := "words"
+	if words == 1 {
+		unit = "word"
+	}
+	return fmt.Sprintf("%d %s · %d min read", words, unit, domain.ReadingMinutes(note))
+}
+
+// FindLinks returns the distinct URLs in text, in order of appearance
+func FindLinks(text string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, link := range urlPattern.FindAllString(text, -1) {
+		link = strings.TrimRight(link, ".,;:!?*_")
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// inheritedLabel follows a date the task inherits from its project or parent
+const inheritedLabel = domain.InheritedMarker + " inherited"
+
 func (m Model) formatDueDate(t time.Time, style lipgloss.Style) string {
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
@@ -309,7 +450,7 @@ func (m Model) renderFooter(width int) string {
 		Width(width).
 		Align(lipgloss.Center)
 
-	hints := "[e]dit  [c]omplete  [d]elete  [f]lag  [Esc] close"
+	hints := "[e]dit  [c]omplete  [d]elete  [f]lag  [O]mniFocus  [Esc] close"
 	return hintStyle.Render(hints)
 }
 
@@ -322,4 +463,9 @@ func formatDateTime(t time.Time) string {
 	return t.Format("Jan 2, 2006 at 3:04 PM")
 }
 
-var escapeKey = key.NewBinding(key.WithKeys("esc", "escape"))
+var (
+	escapeKey   = key.NewBinding(key.WithKeys("esc", "escape"))
+	openLinkKey = key.NewBinding(key.WithKeys("o"))
+	nextLinkKey = key.NewBinding(key.WithKeys("tab"))
+	openTaskKey = key.NewBinding(key.WithKeys("O"))
+)
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 )
@@ -191,6 +193,19 @@ func TestView_NotVisible(t *testing.T) {
 	}
 }
 
+func TestView_ShowPath_ShowsFolders(t *testing.T) {
+	task := &domain.Task{ID: "task1", Name: "Send invoice", ProjectName: "Acme", FolderPath: []string{"Work", "Clients"}}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap()).Show(task).SetSize(80, 24)
+	if strings.Contains(m.View(), "Clients") {
+		t.Error("view should show only the project name by default")
+	}
+
+	m = m.SetShowPath(true)
+	if !strings.Contains(m.View(), "Work ▸ Clients ▸ Acme") {
+		t.Errorf("view should show the project's path, got:\n%s", m.View())
+	}
+}
+
 func TestView_Visible_ShowsTaskInfo(t *testing.T) {
 	styles := tui.DefaultStyles()
 	keys := tui.DefaultKeyMap()
@@ -222,7 +237,7 @@ func TestView_Visible_ShowsTaskInfo(t *testing.T) {
 	if !strings.Contains(view, "urgent") {
 		t.Error("view should contain tag")
 	}
-	if !strings.Contains(view, "This is a test note") {
+	if !strings.Contains(ansi.Strip(view), "This is a test note") {
 		t.Error("view should contain note")
 	}
 	if !strings.Contains(view, "🚩") {
@@ -230,6 +245,36 @@ func TestView_Visible_ShowsTaskInfo(t *testing.T) {
 	}
 }
 
+func TestView_ShowsRepeat(t *testing.T) {
+	styles := tui.DefaultStyles()
+	keys := tui.DefaultKeyMap()
+
+	task := &domain.Task{
+		ID:         "task1",
+		Name:       "Water plants",
+		Repetition: &domain.RepetitionRule{Recurrence: "FREQ=DAILY;INTERVAL=3", Method: domain.RepeatStartAfterCompletion},
+	}
+
+	view := ansi.Strip(New(styles, keys).Show(task).SetSize(80, 24).View())
+
+	if !strings.Contains(view, "Repeats:") || !strings.Contains(view, "every 3 days, defer after completion") {
+		t.Errorf("view should show the repeat, got:\n%s", view)
+	}
+}
+
+func TestView_ShowsEstimate(t *testing.T) {
+	styles := tui.DefaultStyles()
+	keys := tui.DefaultKeyMap()
+
+	task := &domain.Task{ID: "task1", Name: "Review PR", EstimatedMinutes: 45}
+
+	view := ansi.Strip(New(styles, keys).Show(task).SetSize(80, 24).View())
+
+	if !strings.Contains(view, "Estimate:") || !strings.Contains(view, "45m") {
+		t.Errorf("view should show the estimate, got:\n%s", view)
+	}
+}
+
 func TestSetSize(t *testing.T) {
 	styles := tui.DefaultStyles()
 	keys := tui.DefaultKeyMap()
@@ -244,3 +289,100 @@ func TestSetSize(t *testing.T) {
 		t.Errorf("height = %d, want 50", m.height)
 	}
 }
+
+func TestView_RendersNoteAsMarkdown(t *testing.T) {
+	task := &domain.Task{ID: "task1", Name: "Test Task", Note: "# Steps\n\n- **Call** the bank\n- Pay"}
+
+	view := ansi.Strip(New(tui.DefaultStyles(), tui.DefaultKeyMap()).SetSize(80, 40).Show(task).View())
+
+	for _, want := range []string{"Steps", "Call", "the bank", "Pay"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "**Call**") {
+		t.Error("Markdown emphasis should be rendered, not shown literally")
+	}
+}
+
+func TestUpdate_ScrollsLongNote(t *testing.T) {
+	task := &domain.Task{ID: "task1", Name: "Test Task", Note: strings.Repeat("line\n\n", 40)}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap()).SetSize(80, 24).Show(task)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if m.viewport.YOffset != 1 {
+		t.Errorf("YOffset = %d, want 1 after scrolling down", m.viewport.YOffset)
+	}
+
+	// Resizing keeps the scroll position
+	m = m.SetSize(90, 24)
+	if m.viewport.YOffset != 1 {
+		t.Errorf("YOffset = %d after resize, want 1", m.viewport.YOffset)
+	}
+}
+
+func TestUpdate_OpensLinks(t *testing.T) {
+	task := &domain.Task{ID: "task1", Name: "Test Task", Note: "See https://example.com/a and [docs](https://example.com/b)."}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap()).SetSize(80, 24).Show(task)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if msg, ok := cmd().(OpenURLRequestedMsg); !ok || msg.URL != "https://example.com/a" {
+		t.Errorf("o = %v, want the first link", msg)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if msg, ok := cmd().(OpenURLRequestedMsg); !ok || msg.URL != "https://example.com/b" {
+		t.Errorf("o after tab = %v, want the second link", msg)
+	}
+
+	// O always opens the task in OmniFocus
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	if msg, ok := cmd().(OpenURLRequestedMsg); !ok || msg.URL != "omnifocus:///task/task1" {
+		t.Errorf("O = %v, want the task's OmniFocus link", msg)
+	}
+
+	// Without links, o opens the task in OmniFocus too
+	m = m.Show(&domain.Task{ID: "task2", Name: "No links"})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if msg, ok := cmd().(OpenURLRequestedMsg); !ok || msg.URL != "omnifocus:///task/task2" {
+		t.Errorf("o without links = %v, want the task's OmniFocus link", msg)
+	}
+}
+
+func TestFindLinks(t *testing.T) {
+	got := FindLinks("Go to https://a.example/x, then (http://b.example/y) and https://a.example/x again.")
+	want := []string{"https://a.example/x", "http://b.example/y"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("FindLinks() = %v, want %v", got, want)
+	}
+}
+
+func TestView_NoteFitsOverlay(t *testing.T) {
+	task := &domain.Task{ID: "task1", Name: "Test Task", Note: "# Heading\n\n" + strings.Repeat("word ", 60)}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap()).SetSize(80, 40).Show(task)
+
+	for _, line := range strings.Split(m.View(), "\n") {
+		if w := lipgloss.Width(line); w > m.modalWidth()+2 {
+			t.Errorf("line is %d wide, want at most %d: %q", w, m.modalWidth()+2, ansi.Strip(line))
+		}
+	}
+}
+
+func TestNoteSummary(t *testing.T) {
+	if got := NoteSummary("Call"); got != "1 word · 1 min read" {
+		t.Errorf("NoteSummary() = %q, want 1 word · 1 min read", got)
+	}
+	if got := NoteSummary(strings.Repeat("word ", 450)); got != "450 words · 3 min read" {
+		t.Errorf("NoteSummary() = %q, want 450 words · 3 min read", got)
+	}
+}
+
+func TestView_ShowsNoteSummary(t *testing.T) {
+	task := &domain.Task{ID: "task1", Name: "Test Task", Note: "Bring the signed contract"}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap()).SetSize(100, 40).Show(task)
+
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "4 words · 1 min read") {
+		t.Errorf("View() should show the note length, got:\n%s", view)
+	}
+}
@@ -3,6 +3,7 @@ package taskedit
 
 import (
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -21,6 +22,9 @@ const (
 	FieldTags
 	FieldDueDate
 	FieldDeferDate
+	FieldRepeat
+	FieldEstimate
+	FieldEffort
 	FieldFlagged
 	NumFields
 )
@@ -34,6 +38,18 @@ type SaveMsg struct {
 // CancelMsg is sent when the user cancels editing
 type CancelMsg struct{}
 
+// PickProjectMsg is sent when the user asks to pick the project from a list
+type PickProjectMsg struct {
+	ProjectID string // The task's project, marked in the list
+}
+
+// PickDateMsg is sent when the user asks to pick the due or defer date from
+// a calendar
+type PickDateMsg struct {
+	Field int        // FieldDueDate or FieldDeferDate
+	Date  *time.Time // The field's date, nil when empty or invalid
+}
+
 // Model represents the edit task overlay state
 type Model struct {
 	task       *domain.Task
@@ -42,6 +58,7 @@ type Model struct {
 	inputs     []textinput.Model
 	focusIndex int
 	flagged    bool
+	picked     *domain.Project // Project picked for the project field
 	width      int
 	height     int
 	err        string
@@ -63,7 +80,7 @@ func New(styles *tui.Styles) Model {
 
 	// Project field
 	inputs[FieldProject] = textinput.New()
-	inputs[FieldProject].Placeholder = "Project name"
+	inputs[FieldProject].Placeholder = "Project name (Ctrl+P to pick)"
 	inputs[FieldProject].CharLimit = 100
 
 	// Tags field
@@ -73,15 +90,30 @@ func New(styles *tui.Styles) Model {
 
 	// Due date field
 	inputs[FieldDueDate] = textinput.New()
-	inputs[FieldDueDate].Placeholder = "Due date (e.g., tomorrow, next monday)"
+	inputs[FieldDueDate].Placeholder = "Due date (e.g., tomorrow; Ctrl+P to pick)"
 	inputs[FieldDueDate].CharLimit = 50
 
 	// Defer date field
 	inputs[FieldDeferDate] = textinput.New()
-	inputs[FieldDeferDate].Placeholder = "Defer date"
+	inputs[FieldDeferDate].Placeholder = "Defer date (Ctrl+P to pick)"
 	inputs[FieldDeferDate].CharLimit = 50
 
-	// Flagged is a toggle, not a text input - index 6
+	// Repeat field
+	inputs[FieldRepeat] = textinput.New()
+	inputs[FieldRepeat].Placeholder = "Repeat (e.g., weekly, monthly after completion)"
+	inputs[FieldRepeat].CharLimit = 100
+
+	// Estimate field
+	inputs[FieldEstimate] = textinput.New()
+	inputs[FieldEstimate].Placeholder = "Estimated duration (e.g., 30m, 1h30m)"
+	inputs[FieldEstimate].CharLimit = 20
+
+	// Effort field, kept on an "effort: …" line of the note
+	inputs[FieldEffort] = textinput.New()
+	inputs[FieldEffort].Placeholder = "Effort (low, medium, high)"
+	inputs[FieldEffort].CharLimit = 10
+
+	// Flagged is a toggle, not a text input, and stays the last field
 	inputs[FieldFlagged] = textinput.New()
 	inputs[FieldFlagged].Placeholder = "[Press Enter to toggle]"
 
@@ -99,10 +131,11 @@ func (m Model) Show(task *domain.Task) Model {
 	m.visible = true
 	m.focusIndex = 0
 	m.err = ""
+	m.picked = nil
 
 	// Populate fields with current values
 	m.inputs[FieldName].SetValue(task.Name)
-	m.inputs[FieldNote].SetValue(task.Note)
+	m.inputs[FieldNote].SetValue(domain.StripNoteEffort(task.Note))
 	m.inputs[FieldProject].SetValue(task.ProjectName)
 
 	// Tags as comma-separated
@@ -126,6 +159,15 @@ func (m Model) Show(task *domain.Task) Model {
 		m.inputs[FieldDeferDate].SetValue("")
 	}
 
+	// Repeat
+	m.inputs[FieldRepeat].SetValue(repeatText(task))
+
+	// Estimate
+	m.inputs[FieldEstimate].SetValue(estimateText(task))
+
+	// Effort
+	m.inputs[FieldEffort].SetValue(string(task.Effort()))
+
 	m.flagged = task.Flagged
 
 	// Focus first input
@@ -141,6 +183,38 @@ func (m Model) Hide() Model {
 	return m
 }
 
+// Task returns the task being edited, or nil when hidden
+func (m Model) Task() *domain.Task {
+	return m.task
+}
+
+// SetProject fills the project field with a picked project, whose ID is saved
+// unless the field is changed again; an empty ID is the inbox
+func (m Model) SetProject(project domain.Project) Model {
+	m.picked = &project
+	name := project.Name
+	if project.ID == "" {
+		name = ""
+	}
+	m.inputs[FieldProject].SetValue(name)
+	m.inputs[FieldProject].CursorEnd()
+	return m
+}
+
+// SetDate fills the due or defer date field with a picked date; nil clears it
+func (m Model) SetDate(field int, date *time.Time) Model {
+	if field != FieldDueDate && field != FieldDeferDate {
+		return m
+	}
+	value := ""
+	if date != nil {
+		value = date.Format("2006-01-02")
+	}
+	m.inputs[field].SetValue(value)
+	m.inputs[field].CursorEnd()
+	return m
+}
+
 // IsVisible returns true if the overlay is visible
 func (m Model) IsVisible() bool {
 	return m.visible
@@ -200,6 +274,17 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		case key.Matches(msg, shiftTabKey):
 			m = m.prevField()
 			return m, nil
+
+		case key.Matches(msg, pickKey) && m.focusIndex == FieldProject:
+			projectID := m.task.ProjectID
+			return m, func() tea.Msg { return PickProjectMsg{ProjectID: projectID} }
+
+		case key.Matches(msg, pickKey) && (m.focusIndex == FieldDueDate || m.focusIndex == FieldDeferDate):
+			pick := PickDateMsg{Field: m.focusIndex}
+			if date, err := dateparse.Parse(strings.TrimSpace(m.inputs[m.focusIndex].Value())); err == nil {
+				pick.Date = &date
+			}
+			return m, func() tea.Msg { return pick }
 		}
 
 	case tea.WindowSizeMsg:
@@ -279,6 +364,27 @@ func (m Model) validate() string {
 		}
 	}
 
+	// Validate repeat if changed; a repeat lazyfocus cannot set is shown as is
+	repeatStr := strings.TrimSpace(m.inputs[FieldRepeat].Value())
+	if repeatStr != "" && repeatStr != repeatText(m.task) {
+		if _, err := domain.ParseRepeat(repeatStr); err != nil {
+			return "Invalid repeat (e.g., weekly, every 2 months after completion, none)"
+		}
+	}
+
+	// Validate estimate if provided
+	estimateStr := strings.TrimSpace(m.inputs[FieldEstimate].Value())
+	if estimateStr != "" {
+		if _, err := domain.ParseEstimate(estimateStr); err != nil {
+			return "Invalid estimate (e.g., 30m, 1h, 1h30m)"
+		}
+	}
+
+	// Validate effort if provided
+	if _, err := domain.ParseEffort(m.inputs[FieldEffort].Value()); err != nil {
+		return "Invalid effort (low, medium, high or none)"
+	}
+
 	return ""
 }
 
@@ -291,6 +397,8 @@ func (m Model) buildModification() domain.TaskModification {
 	m.buildTagsModification(&mod)
 	m.buildDueDateModification(&mod)
 	m.buildDeferDateModification(&mod)
+	m.buildRepeatModification(&mod)
+	m.buildEstimateModification(&mod)
 	m.buildFlaggedModification(&mod)
 
 	return mod
@@ -304,17 +412,33 @@ func (m Model) buildNameModification(mod *domain.TaskModification) {
 	}
 }
 
-// buildNoteModification adds note modification if changed
+// buildNoteModification adds note modification if the note or effort changed
 func (m Model) buildNoteModification(mod *domain.TaskModification) {
 	newNote := strings.TrimSpace(m.inputs[FieldNote].Value())
+	if newNote == domain.StripNoteEffort(m.task.Note) && m.effort() == m.task.Effort() {
+		return
+	}
+	newNote = domain.SetNoteEffort(newNote, m.effort())
 	if newNote != m.task.Note {
 		mod.Note = &newNote
 	}
 }
 
+// effort returns the effort in the effort field, "" when empty or invalid
+func (m Model) effort() domain.Effort {
+	effort, _ := domain.ParseEffort(m.inputs[FieldEffort].Value())
+	return effort
+}
+
 // buildProjectModification adds project modification if changed
 func (m Model) buildProjectModification(mod *domain.TaskModification) {
 	newProject := strings.TrimSpace(m.inputs[FieldProject].Value())
+	if m.picked != nil && (newProject == m.picked.Name || m.picked.ID == "" && newProject == "") {
+		if projectID := m.picked.ID; projectID != m.task.ProjectID {
+			mod.ProjectID = &projectID
+		}
+		return
+	}
 	if newProject != m.task.ProjectName {
 		if newProject == "" {
 			// Clear project
@@ -385,6 +509,49 @@ func (m Model) buildDeferDateModification(mod *domain.TaskModification) {
 	}
 }
 
+// buildRepeatModification adds repeat modification if changed
+func (m Model) buildRepeatModification(mod *domain.TaskModification) {
+	repeatStr := strings.TrimSpace(m.inputs[FieldRepeat].Value())
+	if repeatStr == repeatText(m.task) {
+		return
+	}
+	repeat, err := domain.ParseRepeat(repeatStr)
+	switch {
+	case repeatStr == "" || (err == nil && repeat == nil):
+		mod.ClearRepeat = m.task.Repetition != nil
+	case err == nil:
+		mod.Repeat = repeat
+	}
+}
+
+// repeatText returns how the repeat field shows the task's repeat
+func repeatText(task *domain.Task) string {
+	if task.Repetition == nil {
+		return ""
+	}
+	return task.Repetition.String()
+}
+
+// buildEstimateModification adds estimated duration modification if changed
+func (m Model) buildEstimateModification(mod *domain.TaskModification) {
+	estimateStr := strings.TrimSpace(m.inputs[FieldEstimate].Value())
+	if estimateStr == "" {
+		mod.ClearEstimate = m.task.EstimatedMinutes != 0
+		return
+	}
+	if minutes, err := domain.ParseEstimate(estimateStr); err == nil && minutes != m.task.EstimatedMinutes {
+		mod.EstimatedMinutes = &minutes
+	}
+}
+
+// estimateText returns how the estimate field shows the task's estimated duration
+func estimateText(task *domain.Task) string {
+	if task.EstimatedMinutes == 0 {
+		return ""
+	}
+	return domain.FormatEstimate(task.EstimatedMinutes)
+}
+
 // buildFlaggedModification adds flagged modification if changed
 func (m Model) buildFlaggedModification(mod *domain.TaskModification) {
 	if m.flagged != m.task.Flagged {
@@ -424,7 +591,7 @@ func (m Model) View() string {
 	}
 
 	// Fields
-	labels := []string{"Name:", "Note:", "Project:", "Tags:", "Due:", "Defer:", "Flagged:"}
+	labels := []string{"Name:", "Note:", "Project:", "Tags:", "Due:", "Defer:", "Repeat:", "Estimate:", "Effort:", "Flagged:"}
 
 	labelStyle := lipgloss.NewStyle().
 		Foreground(m.styles.Colors.Secondary).
@@ -446,8 +613,8 @@ func (m Model) View() string {
 			var style lipgloss.Style
 			if i == m.focusIndex {
 				style = lipgloss.NewStyle().
-					Background(m.styles.Colors.Primary).
-					Foreground(lipgloss.Color("#FFFFFF")).
+					Background(m.styles.Colors.Selection).
+					Foreground(m.styles.Colors.OnSelection).
 					Width(inputWidth)
 			} else {
 				style = lipgloss.NewStyle().Width(inputWidth)
@@ -481,4 +648,5 @@ var (
 	submitKey   = key.NewBinding(key.WithKeys("enter"))
 	tabKey      = key.NewBinding(key.WithKeys("tab"))
 	shiftTabKey = key.NewBinding(key.WithKeys("shift+tab"))
+	pickKey     = key.NewBinding(key.WithKeys("ctrl+p"))
 )
@@ -71,6 +71,21 @@ func TestHide(t *testing.T) {
 	}
 }
 
+func TestTask_ReturnsEditedTaskUntilHidden(t *testing.T) {
+	styles := tui.DefaultStyles()
+	m := New(styles)
+
+	task := &domain.Task{ID: "task1", Name: "Test"}
+	m = m.Show(task)
+
+	if got := m.Task(); got == nil || got.ID != "task1" {
+		t.Errorf("Task() = %v, want task1", got)
+	}
+	if got := m.Hide().Task(); got != nil {
+		t.Errorf("Task() after Hide() = %v, want nil", got)
+	}
+}
+
 func TestUpdate_Escape_Cancels(t *testing.T) {
 	styles := tui.DefaultStyles()
 	m := New(styles)
@@ -491,6 +506,62 @@ func TestProjectField_ClearsProject(t *testing.T) {
 	}
 }
 
+func TestProjectField_PicksProject(t *testing.T) {
+	task := &domain.Task{ID: "task1", Name: "Test", ProjectID: "p1", ProjectName: "Work"}
+	m := New(tui.DefaultStyles()).Show(task).SetSize(80, 24)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if cmd == nil {
+		t.Fatal("Ctrl+P on the project field should ask for the picker")
+	}
+	if msg, ok := cmd().(PickProjectMsg); !ok || msg.ProjectID != "p1" {
+		t.Errorf("Ctrl+P sent %#v, want PickProjectMsg for the task's project", cmd())
+	}
+
+	m = m.SetProject(domain.Project{ID: "p2", Name: "Home Renovation"})
+	if got := m.inputs[FieldProject].Value(); got != "Home Renovation" {
+		t.Errorf("project field = %q, want the picked name", got)
+	}
+	if mod := m.buildModification(); mod.ProjectID == nil || *mod.ProjectID != "p2" {
+		t.Errorf("ProjectID = %v, want the picked project's ID", mod.ProjectID)
+	}
+
+	m = m.SetProject(domain.Project{Name: "Inbox"})
+	if mod := m.buildModification(); m.inputs[FieldProject].Value() != "" || mod.ProjectID == nil || *mod.ProjectID != "" {
+		t.Errorf("picking the inbox should clear the project, got %v", mod.ProjectID)
+	}
+}
+
+func TestDateFields_PickDate(t *testing.T) {
+	due := time.Date(2026, 10, 20, 17, 0, 0, 0, time.Local)
+	task := &domain.Task{ID: "task1", Name: "Test", DueDate: &due}
+	m := New(tui.DefaultStyles()).Show(task).SetSize(80, 24)
+
+	for i := 0; i < FieldDueDate; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if cmd == nil {
+		t.Fatal("Ctrl+P on the due field should ask for the date picker")
+	}
+	if msg, ok := cmd().(PickDateMsg); !ok || msg.Field != FieldDueDate || msg.Date == nil || !msg.Date.Equal(due) {
+		t.Errorf("Ctrl+P sent %#v, want PickDateMsg for the due date", cmd())
+	}
+
+	picked := time.Date(2026, 10, 23, 17, 0, 0, 0, time.Local)
+	m = m.SetDate(FieldDueDate, &picked)
+	if mod := m.buildModification(); mod.DueDate == nil || !mod.DueDate.Equal(picked) {
+		t.Errorf("DueDate = %v, want the picked date", mod.DueDate)
+	}
+
+	m = m.SetDate(FieldDueDate, nil)
+	if mod := m.buildModification(); !mod.ClearDue {
+		t.Error("picking no date should clear the due date")
+	}
+}
+
 // Tag Modification with Special Characters
 func TestTags_WithSpaces(t *testing.T) {
 	styles := tui.DefaultStyles()
@@ -552,7 +623,7 @@ func TestTabNavigation_ForwardCycle(t *testing.T) {
 	}
 
 	// Tab through all fields
-	fields := []int{FieldName, FieldNote, FieldProject, FieldTags, FieldDueDate, FieldDeferDate, FieldFlagged}
+	fields := []int{FieldName, FieldNote, FieldProject, FieldTags, FieldDueDate, FieldDeferDate, FieldRepeat, FieldEstimate, FieldEffort, FieldFlagged}
 	for i, expected := range fields[1:] {
 		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
 		if m.focusIndex != expected {
@@ -581,8 +652,8 @@ func TestTabNavigation_BackwardCycle(t *testing.T) {
 
 	// Continue backward
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
-	if m.focusIndex != FieldDeferDate {
-		t.Errorf("after 2nd shift+tab: focus = %d, want %d", m.focusIndex, FieldDeferDate)
+	if m.focusIndex != FieldEffort {
+		t.Errorf("after 2nd shift+tab: focus = %d, want %d", m.focusIndex, FieldEffort)
 	}
 }
 
@@ -803,6 +874,138 @@ func TestDateClearing_DueDateAndDeferDate(t *testing.T) {
 	}
 }
 
+func TestRepeatField_SetsRepeat(t *testing.T) {
+	styles := tui.DefaultStyles()
+	task := &domain.Task{ID: "task1", Name: "Test"}
+	m := New(styles)
+	m = m.Show(task).SetSize(80, 24)
+
+	m.inputs[FieldRepeat].SetValue("every 2 weeks after completion")
+
+	if err := m.validate(); err != "" {
+		t.Fatalf("validate() = %q, want no error", err)
+	}
+	mod := m.buildModification()
+
+	want := domain.Repeat{Frequency: domain.FrequencyWeekly, Interval: 2, Method: domain.RepeatDueAfterCompletion}
+	if mod.Repeat == nil || *mod.Repeat != want {
+		t.Errorf("Repeat = %+v, want %+v", mod.Repeat, want)
+	}
+}
+
+func TestRepeatField_ClearsRepeat(t *testing.T) {
+	styles := tui.DefaultStyles()
+	task := &domain.Task{
+		ID:         "task1",
+		Name:       "Test",
+		Repetition: &domain.RepetitionRule{Recurrence: "FREQ=DAILY", Method: domain.RepeatFixed},
+	}
+	m := New(styles)
+	m = m.Show(task).SetSize(80, 24)
+
+	if got := m.inputs[FieldRepeat].Value(); got != "daily" {
+		t.Errorf("Repeat field = %q, want %q", got, "daily")
+	}
+
+	m.inputs[FieldRepeat].SetValue("")
+	mod := m.buildModification()
+
+	if !mod.ClearRepeat || mod.Repeat != nil {
+		t.Errorf("ClearRepeat = %v, Repeat = %+v, want cleared", mod.ClearRepeat, mod.Repeat)
+	}
+}
+
+func TestRepeatField_KeepsComplexRepeat(t *testing.T) {
+	styles := tui.DefaultStyles()
+	task := &domain.Task{
+		ID:         "task1",
+		Name:       "Test",
+		Repetition: &domain.RepetitionRule{Recurrence: "FREQ=WEEKLY;BYDAY=MO,WE", Method: domain.RepeatFixed},
+	}
+	m := New(styles)
+	m = m.Show(task).SetSize(80, 24)
+
+	if err := m.validate(); err != "" {
+		t.Errorf("validate() = %q, want no error for an unchanged repeat", err)
+	}
+	if mod := m.buildModification(); mod.Repeat != nil || mod.ClearRepeat {
+		t.Errorf("buildModification() changed the repeat: %+v", mod)
+	}
+}
+
+func TestEstimateField(t *testing.T) {
+	styles := tui.DefaultStyles()
+	task := &domain.Task{ID: "task1", Name: "Test", EstimatedMinutes: 90}
+	m := New(styles)
+	m = m.Show(task).SetSize(80, 24)
+
+	if got := m.inputs[FieldEstimate].Value(); got != "1h30m" {
+		t.Errorf("Estimate field = %q, want %q", got, "1h30m")
+	}
+	if mod := m.buildModification(); mod.EstimatedMinutes != nil || mod.ClearEstimate {
+		t.Errorf("unchanged estimate built %+v", mod)
+	}
+
+	m.inputs[FieldEstimate].SetValue("20m")
+	if mod := m.buildModification(); mod.EstimatedMinutes == nil || *mod.EstimatedMinutes != 20 {
+		t.Errorf("EstimatedMinutes = %v, want 20", mod.EstimatedMinutes)
+	}
+
+	m.inputs[FieldEstimate].SetValue("")
+	if mod := m.buildModification(); !mod.ClearEstimate {
+		t.Error("ClearEstimate = false, want true")
+	}
+
+	m.inputs[FieldEstimate].SetValue("a while")
+	if err := m.validate(); !strings.Contains(err, "Invalid estimate") {
+		t.Errorf("validate() = %q, want an invalid estimate error", err)
+	}
+}
+
+func TestEffortField(t *testing.T) {
+	styles := tui.DefaultStyles()
+	task := &domain.Task{ID: "task1", Name: "Test", Note: "Bring the ladder\n\neffort: high"}
+	m := New(styles)
+	m = m.Show(task).SetSize(80, 24)
+
+	if got := m.inputs[FieldNote].Value(); got != "Bring the ladder" {
+		t.Errorf("Note field = %q, want the note without its effort line", got)
+	}
+	if got := m.inputs[FieldEffort].Value(); got != "high" {
+		t.Errorf("Effort field = %q, want %q", got, "high")
+	}
+	if mod := m.buildModification(); mod.Note != nil {
+		t.Errorf("unchanged effort built note %q", *mod.Note)
+	}
+
+	m.inputs[FieldEffort].SetValue("low")
+	if mod := m.buildModification(); mod.Note == nil || *mod.Note != "Bring the ladder\n\neffort: low" {
+		t.Errorf("Note = %v, want the effort line changed to low", mod.Note)
+	}
+
+	m.inputs[FieldEffort].SetValue("")
+	if mod := m.buildModification(); mod.Note == nil || *mod.Note != "Bring the ladder" {
+		t.Errorf("Note = %v, want the effort line removed", mod.Note)
+	}
+
+	m.inputs[FieldEffort].SetValue("tiny")
+	if err := m.validate(); !strings.Contains(err, "Invalid effort") {
+		t.Errorf("validate() = %q, want an invalid effort error", err)
+	}
+}
+
+func TestRepeatField_Invalid(t *testing.T) {
+	styles := tui.DefaultStyles()
+	m := New(styles)
+	m = m.Show(&domain.Task{ID: "task1", Name: "Test"}).SetSize(80, 24)
+
+	m.inputs[FieldRepeat].SetValue("fortnightly")
+
+	if err := m.validate(); !strings.Contains(err, "Invalid repeat") {
+		t.Errorf("validate() = %q, want an invalid repeat error", err)
+	}
+}
+
 func TestBuildModification_NoteChange(t *testing.T) {
 	styles := tui.DefaultStyles()
 	task := &domain.Task{ID: "task1", Name: "Test", Note: "Original note"}
@@ -0,0 +1,84 @@
+package tasklist
+
+import (
+	"reflect"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// ChangeFade is how long rows changed by a reload stay highlighted, and
+// removed rows stay struck through before they disappear
+const ChangeFade = 2 * time.Second
+
+// changesFadedMsg redraws the list once the change highlights have faded
+type changesFadedMsg struct{}
+
+// removedRow is a task dropped by a reload, shown struck through below the
+// row it followed until the changes fade
+type removedRow struct {
+	task  domain.Task
+	after string // ID of the visible row it follows, or "" when it led the list
+	depth int
+}
+
+// UpdateTasks replaces the tasks with a reload of the same list. Rows added
+// or modified since the previous load are highlighted and removed rows are
+// struck through until the returned command redraws the list without them.
+// The first load, and a load while the list shows its loading state, are
+// set as with SetTasks.
+func (m Model) UpdateTasks(tasks []domain.Task) (Model, tea.Cmd) {
+	if !m.loaded || m.loading {
+		return m.SetTasks(tasks), nil
+	}
+
+	previous := make(map[string]domain.Task)
+	for _, task := range domain.FlattenTasks(m.tree) {
+		previous[task.ID] = task
+	}
+	oldRows, oldDepth := m.tasks, m.depth
+
+	m = m.SetTasks(tasks)
+
+	present := make(map[string]bool)
+	changed := make(map[string]bool)
+	for _, task := range domain.FlattenTasks(tasks) {
+		present[task.ID] = true
+		if old, ok := previous[task.ID]; !ok || !sameTask(old, task) {
+			changed[task.ID] = true
+		}
+	}
+
+	var removed []removedRow
+	after := ""
+	for _, row := range oldRows {
+		if present[row.ID] {
+			after = row.ID
+			continue
+		}
+		removed = append(removed, removedRow{task: row, after: after, depth: oldDepth[row.ID]})
+	}
+
+	m.changed = changed
+	m.removed = removed
+	if len(changed) == 0 && len(removed) == 0 {
+		return m, nil
+	}
+	m.changedAt = m.now()
+	return m, tea.Tick(ChangeFade, func(time.Time) tea.Msg {
+		return changesFadedMsg{}
+	})
+}
+
+// fading reports whether the changes of the last reload are still shown
+func (m Model) fading() bool {
+	return (len(m.changed) > 0 || len(m.removed) > 0) && m.now().Sub(m.changedAt) < ChangeFade
+}
+
+// sameTask reports whether a and b show the same task fields, ignoring
+// their subtasks, which are compared as rows of their own
+func sameTask(a, b domain.Task) bool {
+	a.Children, b.Children = nil, nil
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,97 @@
+package tasklist
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// newChangesTestList returns a list loaded with tasks 1 to 3, and a clock
+// the test can move forward
+func newChangesTestList() (Model, *time.Time) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m.now = func() time.Time { return now }
+	m, _ = m.UpdateTasks([]domain.Task{{ID: "1", Name: "Keep"}, {ID: "2", Name: "Drop"}, {ID: "3", Name: "Edit"}})
+	return m, &now
+}
+
+func TestUpdateTasks_FirstLoadHasNoChanges(t *testing.T) {
+	m, _ := newChangesTestList()
+
+	if m.fading() {
+		t.Error("fading() = true after the first load, want false")
+	}
+}
+
+func TestUpdateTasks_MarksAddedModifiedAndRemoved(t *testing.T) {
+	m, _ := newChangesTestList()
+
+	m, cmd := m.UpdateTasks([]domain.Task{{ID: "1", Name: "Keep"}, {ID: "3", Name: "Edited"}, {ID: "4", Name: "New"}})
+
+	if cmd == nil {
+		t.Fatal("UpdateTasks() returned no command, want one to fade the changes")
+	}
+	for id, want := range map[string]bool{"1": false, "3": true, "4": true} {
+		if m.changed[id] != want {
+			t.Errorf("changed[%s] = %v, want %v", id, m.changed[id], want)
+		}
+	}
+	if len(m.removed) != 1 || m.removed[0].task.ID != "2" || m.removed[0].after != "1" {
+		t.Errorf("removed = %+v, want task 2 after task 1", m.removed)
+	}
+
+	lines := strings.Split(strings.TrimRight(m.View(), "\n"), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[1], "Drop") {
+		t.Errorf("View() = %q, want the removed task shown below task 1", m.View())
+	}
+}
+
+func TestUpdateTasks_UnchangedReturnsNoCommand(t *testing.T) {
+	m, _ := newChangesTestList()
+
+	m, cmd := m.UpdateTasks([]domain.Task{{ID: "1", Name: "Keep"}, {ID: "2", Name: "Drop"}, {ID: "3", Name: "Edit"}})
+
+	if cmd != nil {
+		t.Error("UpdateTasks() returned a command for an unchanged reload, want nil")
+	}
+	if m.fading() {
+		t.Error("fading() = true for an unchanged reload, want false")
+	}
+}
+
+func TestUpdateTasks_RemovedRowsFade(t *testing.T) {
+	m, now := newChangesTestList()
+	m, _ = m.UpdateTasks([]domain.Task{{ID: "1", Name: "Keep"}, {ID: "3", Name: "Edit"}})
+
+	*now = now.Add(ChangeFade)
+
+	if strings.Contains(m.View(), "Drop") {
+		t.Errorf("View() = %q, want the removed task gone once changes fade", m.View())
+	}
+}
+
+func TestRowAt_SkipsRemovedRows(t *testing.T) {
+	m, _ := newChangesTestList()
+	m, _ = m.UpdateTasks([]domain.Task{{ID: "1", Name: "Keep"}, {ID: "3", Name: "Edit"}})
+
+	if _, ok := m.rowAt(1); ok {
+		t.Error("rowAt(1) on the removed task reported a row, want none")
+	}
+	if row, ok := m.rowAt(2); !ok || row != 1 {
+		t.Errorf("rowAt(2) = %d, %v, want 1, true", row, ok)
+	}
+}
+
+func TestUpdateTasks_WhileLoadingSetsTasks(t *testing.T) {
+	m, _ := newChangesTestList()
+
+	m, cmd := m.SetLoading(true).UpdateTasks([]domain.Task{{ID: "9", Name: "Other project"}})
+
+	if cmd != nil || len(m.removed) > 0 {
+		t.Errorf("UpdateTasks() while loading = %v, %+v, want a plain load", cmd, m.removed)
+	}
+}
@@ -3,6 +3,8 @@ package tasklist
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,29 +22,62 @@ const (
 	CheckboxChecked = "☑"
 	FlagIcon        = "🚩"
 	CalendarIcon    = "📅"
+	MarkIcon        = "●"
+	PinIcon         = "📌"
+	ConflictIcon    = "⚠"
+	ExpandedIcon    = "▼"
+	CollapsedIcon   = "▶"
+	NextActionIcon  = "➜"
 )
 
+// toggleKey expands or collapses the subtasks of the task under the cursor
+var toggleKey = key.NewBinding(key.WithKeys("tab"))
+
 // Model represents the task list component state
 type Model struct {
-	tasks   []domain.Task
-	cursor  int
-	width   int
-	height  int
-	styles  *tui.Styles
-	keys    tui.KeyMap
-	loading bool
-	empty   bool
+	tree       []domain.Task // Top-level tasks with subtasks nested under Children
+	tasks      []domain.Task // Visible rows in outline order
+	depth      map[string]int
+	collapsed  map[string]bool // Task IDs whose subtasks are hidden
+	nested     bool            // Whether any task has subtasks
+	cursor     int
+	offset     int // First row in the viewport
+	width      int
+	height     int
+	styles     *tui.Styles
+	keys       tui.KeyMap
+	loading    bool
+	empty      bool
+	loaded     bool            // Whether tasks have been set, so reloads can be diffed
+	marked     map[string]bool // Task IDs marked for bulk actions
+	pinned     map[string]bool // Task IDs listed first among their siblings
+	conflicts  map[string]bool // Task IDs whose change OmniFocus did not take
+	changed    map[string]bool // Task IDs added or modified by the last reload
+	removed    []removedRow    // Tasks dropped by the last reload
+	changedAt  time.Time
+	highlight  *regexp.Regexp // Search matches to highlight in task names
+	sort       tui.SortMode   // Order of siblings, before pinned tasks are moved first
+	sequential bool           // Tasks belong to a sequential project
+	nextOnly   bool           // Show only the next action, or available tasks outside sequential projects
+	nextID     string         // Next action of a sequential project
+	showPath   bool           // Show each task's folder and project path after its name
+	now        func() time.Time
 }
 
 // New creates a new task list component
 func New(styles *tui.Styles, keys tui.KeyMap) Model {
 	return Model{
-		tasks:   []domain.Task{},
-		cursor:  0,
-		styles:  styles,
-		keys:    keys,
-		loading: false,
-		empty:   true,
+		tasks:     []domain.Task{},
+		depth:     make(map[string]int),
+		collapsed: make(map[string]bool),
+		cursor:    0,
+		styles:    styles,
+		keys:      keys,
+		loading:   false,
+		empty:     true,
+		marked:    make(map[string]bool),
+		pinned:    make(map[string]bool),
+		now:       time.Now,
 	}
 }
 
@@ -55,11 +90,15 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		return m.handleKeyPress(msg)
+		m, cmd := m.handleKeyPress(msg)
+		return m.scroll(), cmd
+	case tea.MouseMsg:
+		m, cmd := m.handleMouse(msg)
+		return m.scroll(), cmd
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		return m, nil
+		return m.scroll(), nil
 	}
 
 	return m, nil
@@ -89,9 +128,112 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Move a page at a time, stopping at the first and last task
+	if key.Matches(msg, m.keys.PageDown) {
+		m.cursor = tui.PageCursor(m.cursor, len(m.tasks), m.pageSize(), 1)
+		return m, nil
+	}
+	if key.Matches(msg, m.keys.PageUp) {
+		m.cursor = tui.PageCursor(m.cursor, len(m.tasks), m.pageSize(), -1)
+		return m, nil
+	}
+
+	// Toggle mark on the current task and advance to the next one
+	if key.Matches(msg, m.keys.Select) {
+		m = m.ToggleMark()
+		if m.cursor < len(m.tasks)-1 {
+			m.cursor++
+		}
+		return m, nil
+	}
+
+	// Expand or collapse the subtasks of the current task
+	if key.Matches(msg, toggleKey) {
+		m = m.ToggleCollapse()
+		return m, nil
+	}
+
+	// Escape clears all marks
+	if msg.Type == tea.KeyEsc && len(m.marked) > 0 {
+		m = m.ClearMarks()
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// handleMouse selects the clicked task, expands or collapses subtasks when
+// their icon is clicked, asks to complete a task whose checkbox is clicked and
+// pages through the list with the scroll wheel. Coordinates are relative to
+// the component's first line.
+func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if len(m.tasks) == 0 || m.loading {
+		return m, nil
+	}
+
+	if direction := tui.WheelDirection(msg); direction != 0 {
+		m.cursor = tui.PageCursor(m.cursor, len(m.tasks), m.height, direction)
+		return m, nil
+	}
+
+	if !tui.IsClick(msg) {
+		return m, nil
+	}
+	row, ok := m.rowAt(msg.Y)
+	if !ok {
+		return m, nil
+	}
+	m.cursor = row
+	task := m.tasks[row]
+	if m.onOutlineIcon(task, msg.X) {
+		m = m.ToggleCollapse()
+	}
+	if m.onCheckbox(task, msg.X) && !task.Completed {
+		return m, func() tea.Msg { return tui.CompleteClickedMsg{Task: task} }
+	}
 	return m, nil
 }
 
+// rowAt returns the index of the task rendered on line y, accounting for
+// task lines that wrap, removed tasks still shown and scroll indicators
+func (m Model) rowAt(y int) (int, bool) {
+	if y < 0 {
+		return 0, false
+	}
+	line := 0
+	for _, l := range m.visibleLines() {
+		line += lipgloss.Height(l.text)
+		if y < line {
+			return l.row, l.row >= 0
+		}
+	}
+	return 0, false
+}
+
+// onOutlineIcon reports whether column x is on the expand/collapse icon of task
+func (m Model) onOutlineIcon(task domain.Task, x int) bool {
+	if !m.nested || len(task.Children) == 0 {
+		return false
+	}
+	start := m.styles.Task.Normal.GetPaddingLeft() + 2*m.depth[task.ID]
+	if len(m.marked) > 0 {
+		start += 2
+	}
+	return x >= start && x < start+2
+}
+
+// onCheckbox reports whether column x is on the status checkbox of task
+func (m Model) onCheckbox(task domain.Task, x int) bool {
+	start := m.styles.Task.Normal.GetPaddingLeft()
+	if len(m.marked) > 0 {
+		start += 2
+	}
+	if m.nested {
+		start += 2*m.depth[task.ID] + 2
+	}
+	return x >= start && x < start+2
+}
+
 // View renders the component
 func (m Model) View() string {
 	if m.loading {
@@ -127,34 +269,121 @@ func (m Model) renderEmpty() string {
 	return padding + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, "No tasks")
 }
 
-// renderTasks renders the task list
+// renderTasks renders the rows in the viewport
 func (m Model) renderTasks() string {
 	var b strings.Builder
 
-	for i, task := range m.tasks {
-		line := m.formatTaskLine(task, i == m.cursor)
-		b.WriteString(line)
+	for _, l := range m.visibleLines() {
+		b.WriteString(l.text)
 		b.WriteString("\n")
 	}
 
 	return b.String()
 }
 
+// renderedLine is a rendered task with the index of its row, or -1 for a
+// task removed by the last reload or a scroll indicator
+type renderedLine struct {
+	text string
+	row  int
+}
+
 // formatTaskLine formats a single task line
 func (m Model) formatTaskLine(task domain.Task, selected bool) string {
+	line, nameAt := m.layoutTask(task, m.depth[task.ID])
+
+	// Apply styles
+	var style lipgloss.Style
+	switch {
+	case selected:
+		now := m.now()
+		style = m.styles.Task.SelectedStyle(task, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()))
+	case m.marked[task.ID]:
+		style = m.styles.Task.Marked
+	case m.changed[task.ID] && m.fading():
+		style = m.styles.Task.Changed
+	case task.Completed:
+		style = m.styles.Task.Completed
+	case task.ID == m.nextID:
+		style = m.styles.Task.NextAction
+	case m.sequential && task.AvailabilityAt(m.now()) == domain.Blocked:
+		style = m.styles.Task.Blocked
+	default:
+		style = m.styles.Task.Normal
+	}
+
+	line = tui.Highlight(line, nameAt, nameAt+len(task.Name), m.highlight, style, m.styles.Task.Match)
+	return style.Render(line)
+}
+
+// taskText lays out the unstyled line of a task indented to depth
+func (m Model) taskText(task domain.Task, depth int) string {
+	line, _ := m.layoutTask(task, depth)
+	return line
+}
+
+// layoutTask lays out the unstyled line of a task indented to depth, and
+// returns where the task's name starts in it
+func (m Model) layoutTask(task domain.Task, depth int) (string, int) {
 	// Status icon
 	statusIcon := CheckboxEmpty
 	if task.Completed {
 		statusIcon = CheckboxChecked
 	}
 
-	// Build the left side (status icon + task name)
-	leftSide := fmt.Sprintf("%s %s", statusIcon, task.Name)
+	// Prefix with a mark column while any task is marked so rows stay aligned
+	markPrefix := ""
+	if len(m.marked) > 0 {
+		markPrefix = "  "
+		if m.marked[task.ID] {
+			markPrefix = MarkIcon + " "
+		}
+	}
+
+	// Indent subtasks and show an expand/collapse icon on tasks that have them
+	outline := ""
+	if m.nested {
+		outline = strings.Repeat("  ", depth) + "  "
+		if len(task.Children) > 0 {
+			icon := ExpandedIcon
+			if m.collapsed[task.ID] {
+				icon = CollapsedIcon
+			}
+			outline = strings.Repeat("  ", depth) + icon + " "
+		}
+	}
+	markPrefix += outline
+
+	// Show pinned tasks with a pin and conflicted tasks with a warning before the name
+	name := task.Name
+	if m.pinned[task.ID] {
+		name = PinIcon + " " + name
+	}
+	if m.conflicts[task.ID] {
+		name = ConflictIcon + " " + name
+	}
+	if task.ID == m.nextID {
+		name = NextActionIcon + " " + name
+	}
+
+	// Build the left side (mark + outline + status icon + task name)
+	leftSide := fmt.Sprintf("%s%s %s", markPrefix, statusIcon, name)
+	nameAt := len(leftSide) - len(task.Name)
 
-	// Build the right side (due date or flag)
+	// Follow the name with where the task lives, to tell apart tasks named alike
+	path := ""
+	if m.showPath && task.Path() != "" {
+		path = "  " + task.Path()
+		leftSide += path
+	}
+
+	// Build the right side (due date or flag); an inherited due date is marked
 	var rightSide string
-	if task.DueDate != nil {
-		rightSide = fmt.Sprintf("%s %s", CalendarIcon, formatDate(*task.DueDate))
+	if due := task.EffectiveDue(); due != nil {
+		rightSide = fmt.Sprintf("%s %s", CalendarIcon, formatDate(*due))
+		if task.DueInherited() {
+			rightSide += " " + domain.InheritedMarker
+		}
 	} else if task.Flagged {
 		rightSide = FlagIcon
 	}
@@ -166,7 +395,7 @@ func (m Model) formatTaskLine(task domain.Task, selected bool) string {
 	}
 
 	// Calculate display width using runewidth (handles emoji/Unicode correctly)
-	leftLen := runewidth.StringWidth(statusIcon) + 1 + runewidth.StringWidth(task.Name)
+	leftLen := runewidth.StringWidth(markPrefix) + runewidth.StringWidth(statusIcon) + 1 + runewidth.StringWidth(name) + runewidth.StringWidth(path)
 	rightLen := runewidth.StringWidth(rightSide)
 
 	spacing := contentWidth - leftLen - rightLen - 2
@@ -174,21 +403,10 @@ func (m Model) formatTaskLine(task domain.Task, selected bool) string {
 		spacing = 1
 	}
 
-	line := leftSide
-	if rightSide != "" {
-		line = leftSide + strings.Repeat(" ", spacing) + rightSide
-	}
-
-	// Apply styles
-	if selected {
-		return m.styles.Task.Selected.Render(line)
+	if rightSide == "" {
+		return leftSide, nameAt
 	}
-
-	if task.Completed {
-		return m.styles.Task.Completed.Render(line)
-	}
-
-	return m.styles.Task.Normal.Render(line)
+	return leftSide + strings.Repeat(" ", spacing) + rightSide, nameAt
 }
 
 // formatDate formats a time.Time into a human-readable string
@@ -227,11 +445,31 @@ func isSameDay(t1, t2 time.Time) bool {
 	return y1 == y2 && m1 == m2 && d1 == d2
 }
 
-// SetTasks updates the task list
+// SetTasks updates the task list. Subtasks nested under Children are shown
+// indented below their parent. The selected task stays selected, or its
+// nearest neighbor when it is gone.
 func (m Model) SetTasks(tasks []domain.Task) Model {
-	m.tasks = tasks
+	oldIDs := m.rowIDs()
+	m.tree = tasks
+	m = m.rebuildRows()
+	if i, ok := tui.KeepSelection(oldIDs, m.cursor, m.rowIDs()); ok {
+		m.cursor = i
+	}
 	m.empty = len(tasks) == 0
 	m.loading = false
+	m.loaded = true
+	m.changed, m.removed = nil, nil
+
+	// Drop marks for tasks that are no longer in the list
+	if len(m.marked) > 0 {
+		present := make(map[string]bool, len(m.marked))
+		for _, task := range domain.FlattenTasks(tasks) {
+			if m.marked[task.ID] {
+				present[task.ID] = true
+			}
+		}
+		m.marked = present
+	}
 
 	// Clamp cursor to valid range
 	if m.cursor >= len(m.tasks) {
@@ -242,9 +480,247 @@ func (m Model) SetTasks(tasks []domain.Task) Model {
 		}
 	}
 
+	return m.scroll()
+}
+
+// rowIDs returns the IDs of the visible rows in order
+func (m Model) rowIDs() []string {
+	ids := make([]string, len(m.tasks))
+	for i, task := range m.tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+// rebuildRows recomputes the visible rows from the task tree, skipping the
+// subtasks of collapsed tasks and listing siblings in sort order with pinned
+// tasks first. With next actions only, just those tasks and their parents
+// are listed.
+func (m Model) rebuildRows() Model {
+	m.tasks = []domain.Task{}
+	m.depth = make(map[string]int)
+	m.nested = false
+
+	// The next action follows the project's order, whatever the sort
+	m.nextID = ""
+	if m.sequential {
+		if next := domain.NextAction(m.tree, m.now()); next != nil {
+			m.nextID = next.ID
+		}
+	}
+
+	var walk func(tasks []domain.Task, depth int)
+	walk = func(tasks []domain.Task, depth int) {
+		for _, task := range tui.PinnedFirst(tui.SortTasks(tasks, m.sort), m.pinned) {
+			if m.nextOnly && !m.leadsToNextAction(task) {
+				continue
+			}
+			m.tasks = append(m.tasks, task)
+			m.depth[task.ID] = depth
+			if len(task.Children) > 0 {
+				m.nested = true
+				if !m.collapsed[task.ID] {
+					walk(task.Children, depth+1)
+				}
+			}
+		}
+	}
+	walk(m.tree, 0)
+
+	return m
+}
+
+// ToggleCollapse expands or collapses the subtasks of the task under the cursor
+func (m Model) ToggleCollapse() Model {
+	task := m.SelectedTask()
+	if task == nil || len(task.Children) == 0 {
+		return m
+	}
+
+	collapsed := make(map[string]bool, len(m.collapsed)+1)
+	for id := range m.collapsed {
+		collapsed[id] = true
+	}
+	if collapsed[task.ID] {
+		delete(collapsed, task.ID)
+	} else {
+		collapsed[task.ID] = true
+	}
+	m.collapsed = collapsed
+
+	return m.rebuildRows()
+}
+
+// SetPinned sets the tasks listed first among their siblings, keeping the
+// cursor on the selected task
+func (m Model) SetPinned(pinned map[string]bool) Model {
+	selected := m.SelectedTask()
+	m.pinned = pinned
+	m = m.rebuildRows()
+	if selected != nil {
+		m, _ = m.SelectTask(selected.ID)
+	}
+	return m
+}
+
+// SetSort sets the order tasks are listed in, keeping the cursor on the
+// selected task
+func (m Model) SetSort(mode tui.SortMode) Model {
+	selected := m.SelectedTask()
+	m.sort = mode
+	m = m.rebuildRows()
+	if selected != nil {
+		m, _ = m.SelectTask(selected.ID)
+	}
+	return m
+}
+
+// Sort returns the order tasks are listed in
+func (m Model) Sort() tui.SortMode {
+	return m.sort
+}
+
+// SetHighlight sets the pattern whose matches in task names are highlighted,
+// or nil for none
+func (m Model) SetHighlight(re *regexp.Regexp) Model {
+	m.highlight = re
+	return m
+}
+
+// SetConflicts sets the tasks shown with a conflict marker
+func (m Model) SetConflicts(conflicts map[string]bool) Model {
+	m.conflicts = conflicts
 	return m
 }
 
+// SetShowPath sets whether rows show the folders and project containing each task
+func (m Model) SetShowPath(show bool) Model {
+	m.showPath = show
+	return m
+}
+
+// SetSequential sets whether the tasks belong to a sequential project, whose
+// next action is marked and whose blocked tasks are dimmed
+func (m Model) SetSequential(sequential bool) Model {
+	m.sequential = sequential
+	return m.rebuildRows().clampCursor()
+}
+
+// ToggleNextOnly shows only the next action of a sequential project, or the
+// available tasks of any other list, or every task again
+func (m Model) ToggleNextOnly() Model {
+	selected := m.SelectedTask()
+	m.nextOnly = !m.nextOnly
+	m = m.rebuildRows()
+	if selected != nil {
+		m, _ = m.SelectTask(selected.ID)
+	}
+	return m.clampCursor()
+}
+
+// NextOnly reports whether only next actions are shown
+func (m Model) NextOnly() bool {
+	return m.nextOnly
+}
+
+// leadsToNextAction reports whether task is a next action, or has one among
+// its subtasks
+func (m Model) leadsToNextAction(task domain.Task) bool {
+	if m.sequential && task.ID == m.nextID {
+		return true
+	}
+	if !m.sequential && task.IsAvailableAt(m.now()) {
+		return true
+	}
+	for _, child := range task.Children {
+		if m.leadsToNextAction(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// clampCursor keeps the cursor on a row and the rows around it in view
+func (m Model) clampCursor() Model {
+	m.cursor = max(min(m.cursor, len(m.tasks)-1), 0)
+	return m.scroll()
+}
+
+// MoveSelected swaps the selected task with its previous (direction -1) or
+// next (direction 1) sibling, and returns the position that puts it there.
+// Tasks only move in added order, where the rows follow OmniFocus's order.
+func (m Model) MoveSelected(direction int) (Model, domain.TaskPosition, bool) {
+	task := m.SelectedTask()
+	if task == nil || !m.sort.IsDefault() {
+		return m, domain.TaskPosition{}, false
+	}
+
+	tree, pos, ok := moveInTree(m.tree, task.ID, direction)
+	if !ok {
+		return m, domain.TaskPosition{}, false
+	}
+	m.tree = tree
+	m = m.rebuildRows()
+	m, _ = m.SelectTask(task.ID)
+	return m, pos, true
+}
+
+// moveInTree returns a copy of tasks with the task id swapped with the
+// sibling in direction, leaving tasks untouched
+func moveInTree(tasks []domain.Task, id string, direction int) ([]domain.Task, domain.TaskPosition, bool) {
+	for i, task := range tasks {
+		if task.ID == id {
+			j := i + direction
+			if j < 0 || j >= len(tasks) {
+				return tasks, domain.TaskPosition{}, false
+			}
+			moved := slices.Clone(tasks)
+			moved[i], moved[j] = moved[j], moved[i]
+			return moved, domain.TaskPosition{SiblingID: tasks[j].ID, After: direction > 0}, true
+		}
+		if children, pos, ok := moveInTree(task.Children, id, direction); ok {
+			moved := slices.Clone(tasks)
+			moved[i].Children = children
+			return moved, pos, true
+		}
+	}
+	return tasks, domain.TaskPosition{}, false
+}
+
+// PatchTask applies patch to a task in place, so a change shows before the
+// list is reloaded
+func (m Model) PatchTask(id string, patch func(*domain.Task)) Model {
+	tree, ok := PatchTree(m.tree, id, patch)
+	if !ok {
+		return m
+	}
+	selected := m.SelectedTask()
+	m.tree = tree
+	m = m.rebuildRows()
+	if selected != nil {
+		m, _ = m.SelectTask(selected.ID)
+	}
+	return m
+}
+
+// PatchTree returns a copy of tasks with patch applied to task id, leaving
+// tasks untouched; ok is false when no task has the ID
+func PatchTree(tasks []domain.Task, id string, patch func(*domain.Task)) ([]domain.Task, bool) {
+	for i, task := range tasks {
+		if task.ID == id {
+			updated := slices.Clone(tasks)
+			patch(&updated[i])
+			return updated, true
+		}
+		if children, ok := PatchTree(task.Children, id, patch); ok {
+			updated := slices.Clone(tasks)
+			updated[i].Children = children
+			return updated, true
+		}
+	}
+	return tasks, false
+}
+
 // SetLoading sets the loading state
 func (m Model) SetLoading(loading bool) Model {
 	m.loading = loading
@@ -260,7 +736,62 @@ func (m Model) SelectedTask() *domain.Task {
 	return &m.tasks[m.cursor]
 }
 
+// SelectTask moves the cursor to the visible task with the given ID, and
+// reports whether it was found
+func (m Model) SelectTask(id string) (Model, bool) {
+	for i, task := range m.tasks {
+		if task.ID == id {
+			m.cursor = i
+			return m.scroll(), true
+		}
+	}
+	return m, false
+}
+
 // SelectedIndex returns the current cursor position
 func (m Model) SelectedIndex() int {
 	return m.cursor
 }
+
+// ToggleMark toggles the bulk-action mark on the task under the cursor
+func (m Model) ToggleMark() Model {
+	task := m.SelectedTask()
+	if task == nil {
+		return m
+	}
+
+	marked := make(map[string]bool, len(m.marked)+1)
+	for id := range m.marked {
+		marked[id] = true
+	}
+	if marked[task.ID] {
+		delete(marked, task.ID)
+	} else {
+		marked[task.ID] = true
+	}
+	m.marked = marked
+
+	return m
+}
+
+// ClearMarks removes all bulk-action marks
+func (m Model) ClearMarks() Model {
+	m.marked = make(map[string]bool)
+	return m
+}
+
+// MarkedTasks returns the marked tasks in outline order, including subtasks
+// hidden under a collapsed parent
+func (m Model) MarkedTasks() []domain.Task {
+	if len(m.marked) == 0 {
+		return nil
+	}
+
+	var tasks []domain.Task
+	for _, task := range domain.FlattenTasks(m.tree) {
+		if m.marked[task.ID] {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
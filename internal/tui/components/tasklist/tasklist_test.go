@@ -1,11 +1,17 @@
 package tasklist
 
 import (
+	"fmt"
+	"io"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 )
@@ -65,6 +71,22 @@ func TestSetTasks(t *testing.T) {
 	}
 }
 
+func TestSetTasksKeepsSelectionByID(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{{ID: "1"}, {ID: "2"}, {ID: "3"}})
+	m, _ = m.SelectTask("2")
+
+	m = m.SetTasks([]domain.Task{{ID: "0"}, {ID: "1"}, {ID: "2"}, {ID: "3"}})
+	if got := m.SelectedTask(); got == nil || got.ID != "2" {
+		t.Errorf("SelectedTask() = %v, want task 2 after it moved down", got)
+	}
+
+	m = m.SetTasks([]domain.Task{{ID: "0"}, {ID: "1"}, {ID: "3"}})
+	if got := m.SelectedTask(); got == nil || got.ID != "3" {
+		t.Errorf("SelectedTask() = %v, want its neighbor task 3 after task 2 disappeared", got)
+	}
+}
+
 func TestSetTasksEmpty(t *testing.T) {
 	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
 
@@ -582,3 +604,484 @@ func TestFormatTaskLineNormal(t *testing.T) {
 		t.Error("expected line to contain empty checkbox")
 	}
 }
+
+func TestToggleMarkWithSpace(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{
+		{ID: "1", Name: "Task 1"},
+		{ID: "2", Name: "Task 2"},
+		{ID: "3", Name: "Task 3"},
+	})
+
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	// Mark first task; cursor advances
+	m, _ = m.Update(space)
+	if m.cursor != 1 {
+		t.Errorf("expected cursor at 1 after marking, got %d", m.cursor)
+	}
+
+	// Skip second, mark third
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(space)
+
+	marked := m.MarkedTasks()
+	if len(marked) != 2 {
+		t.Fatalf("expected 2 marked tasks, got %d", len(marked))
+	}
+	if marked[0].ID != "1" || marked[1].ID != "3" {
+		t.Errorf("expected marked tasks 1 and 3, got %s and %s", marked[0].ID, marked[1].ID)
+	}
+
+	// Marking the same task again unmarks it
+	m = m.ToggleMark()
+	if len(m.MarkedTasks()) != 1 {
+		t.Errorf("expected 1 marked task after toggling off, got %d", len(m.MarkedTasks()))
+	}
+}
+
+func TestMarkIndicatorAndClear(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{
+		{ID: "1", Name: "Task 1"},
+		{ID: "2", Name: "Task 2"},
+	})
+
+	m = m.ToggleMark()
+	if !strings.Contains(m.View(), MarkIcon) {
+		t.Error("expected view to contain mark indicator")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.MarkedTasks()) != 0 {
+		t.Errorf("expected marks cleared on esc, got %d", len(m.MarkedTasks()))
+	}
+	if strings.Contains(m.View(), MarkIcon) {
+		t.Error("expected no mark indicator after clearing")
+	}
+}
+
+func TestSetTasksPrunesMarks(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{
+		{ID: "1", Name: "Task 1"},
+		{ID: "2", Name: "Task 2"},
+	})
+	m = m.ToggleMark()
+
+	m = m.SetTasks([]domain.Task{{ID: "2", Name: "Task 2"}})
+	if len(m.MarkedTasks()) != 0 {
+		t.Errorf("expected marks for removed tasks to be pruned, got %d", len(m.MarkedTasks()))
+	}
+}
+
+func nestedTestTasks() []domain.Task {
+	return []domain.Task{
+		{ID: "1", Name: "Plan trip", Children: []domain.Task{
+			{ID: "1a", Name: "Book flights", ParentID: "1"},
+			{ID: "1b", Name: "Book hotel", ParentID: "1"},
+		}},
+		{ID: "2", Name: "Pay rent"},
+	}
+}
+
+func TestSetTasksNested(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks(nestedTestTasks())
+
+	if len(m.tasks) != 4 {
+		t.Fatalf("expected 4 visible rows, got %d", len(m.tasks))
+	}
+
+	view := m.View()
+	if !strings.Contains(view, ExpandedIcon+" "+CheckboxEmpty+" Plan trip") {
+		t.Errorf("expected expanded icon on parent task, got:\n%s", view)
+	}
+	if !strings.Contains(view, "    "+CheckboxEmpty+" Book flights") {
+		t.Errorf("expected subtask to be indented, got:\n%s", view)
+	}
+}
+
+func TestToggleCollapseWithTab(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks(nestedTestTasks())
+
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	m, _ = m.Update(tab)
+
+	if len(m.tasks) != 2 {
+		t.Errorf("expected subtasks hidden after collapse, got %d rows", len(m.tasks))
+	}
+	if !strings.Contains(m.View(), CollapsedIcon) {
+		t.Error("expected collapsed icon after collapse")
+	}
+
+	// Collapse state survives a reload
+	m = m.SetTasks(nestedTestTasks())
+	if len(m.tasks) != 2 {
+		t.Errorf("expected parent to stay collapsed after reload, got %d rows", len(m.tasks))
+	}
+
+	m, _ = m.Update(tab)
+	if len(m.tasks) != 4 {
+		t.Errorf("expected subtasks shown after expand, got %d rows", len(m.tasks))
+	}
+
+	// Tab on a task without subtasks does nothing
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tab)
+	if len(m.tasks) != 4 || m.cursor != 1 {
+		t.Errorf("expected no change on leaf task, got %d rows and cursor %d", len(m.tasks), m.cursor)
+	}
+}
+
+func TestMarkedTasksIncludesCollapsedSubtasks(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks(nestedTestTasks())
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = m.ToggleMark()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = m.ToggleCollapse()
+
+	marked := m.MarkedTasks()
+	if len(marked) != 1 || marked[0].ID != "1a" {
+		t.Errorf("expected hidden subtask 1a to stay marked, got %+v", marked)
+	}
+}
+
+func TestSelectTask(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{{ID: "t1", Name: "First"}, {ID: "t2", Name: "Second"}})
+
+	m, found := m.SelectTask("t2")
+	if !found || m.SelectedIndex() != 1 {
+		t.Errorf("SelectTask(t2) = index %d, found %v, want 1, true", m.SelectedIndex(), found)
+	}
+
+	m, found = m.SelectTask("missing")
+	if found || m.SelectedIndex() != 1 {
+		t.Errorf("SelectTask(missing) = index %d, found %v, want cursor unchanged", m.SelectedIndex(), found)
+	}
+}
+
+func click(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+}
+
+func TestMouse_ClickSelectsRow(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{{ID: "1", Name: "One"}, {ID: "2", Name: "Two"}, {ID: "3", Name: "Three"}})
+
+	m, _ = m.Update(click(10, 2))
+	if got := m.SelectedIndex(); got != 2 {
+		t.Errorf("SelectedIndex() = %d, want 2", got)
+	}
+
+	// Clicks below the last row leave the selection alone
+	m, _ = m.Update(click(10, 7))
+	if got := m.SelectedIndex(); got != 2 {
+		t.Errorf("SelectedIndex() after click below rows = %d, want 2", got)
+	}
+}
+
+func TestMouse_ClickOnOutlineIconTogglesSubtasks(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{
+		{ID: "p", Name: "Parent", Children: []domain.Task{{ID: "c", Name: "Child"}}},
+		{ID: "o", Name: "Other"},
+	})
+
+	// Clicking the task name selects without collapsing
+	m, _ = m.Update(click(10, 0))
+	if len(m.tasks) != 3 {
+		t.Fatalf("clicking the name should not collapse, got %d rows", len(m.tasks))
+	}
+
+	// The icon follows the one-column left padding
+	m, _ = m.Update(click(1, 0))
+	if len(m.tasks) != 2 {
+		t.Errorf("clicking the icon should collapse the parent, got %d rows", len(m.tasks))
+	}
+	m, _ = m.Update(click(1, 0))
+	if len(m.tasks) != 3 {
+		t.Errorf("clicking the icon again should expand the parent, got %d rows", len(m.tasks))
+	}
+}
+
+func TestMouse_ClickOnCheckboxRequestsCompletion(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{{ID: "1", Name: "One"}, {ID: "2", Name: "Two", Completed: true}})
+
+	// The checkbox follows the one-column left padding
+	m, cmd := m.Update(click(1, 0))
+	if cmd == nil {
+		t.Fatal("clicking the checkbox should request completion")
+	}
+	if msg, ok := cmd().(tui.CompleteClickedMsg); !ok || msg.Task.ID != "1" {
+		t.Errorf("cmd() = %v, want CompleteClickedMsg for task 1", msg)
+	}
+
+	if _, cmd = m.Update(click(10, 0)); cmd != nil {
+		t.Error("clicking the name should not request completion")
+	}
+	if _, cmd = m.Update(click(1, 1)); cmd != nil {
+		t.Error("clicking the checkbox of a completed task should do nothing")
+	}
+}
+
+func TestMouse_WheelPages(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	tasks := make([]domain.Task, 30)
+	for i := range tasks {
+		tasks[i] = domain.Task{ID: fmt.Sprint(i), Name: fmt.Sprintf("Task %d", i)}
+	}
+	m = m.SetTasks(tasks)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+
+	m, _ = m.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	if got := m.SelectedIndex(); got != 10 {
+		t.Errorf("SelectedIndex() after wheel down = %d, want 10", got)
+	}
+	m, _ = m.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelUp})
+	if got := m.SelectedIndex(); got != 0 {
+		t.Errorf("SelectedIndex() after wheel up = %d, want 0", got)
+	}
+}
+
+func TestSetPinned_ListsPinnedTasksFirst(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{
+		{ID: "1", Name: "One"},
+		{ID: "2", Name: "Two", Children: []domain.Task{{ID: "2a", Name: "Two A"}, {ID: "2b", Name: "Two B"}}},
+		{ID: "3", Name: "Three"},
+	})
+	m, _ = m.SelectTask("3")
+
+	m = m.SetPinned(map[string]bool{"3": true, "2b": true})
+
+	var ids []string
+	for _, task := range m.tasks {
+		ids = append(ids, task.ID)
+	}
+	if want := "3 1 2 2b 2a"; strings.Join(ids, " ") != want {
+		t.Errorf("rows = %v, want %s", ids, want)
+	}
+	if task := m.SelectedTask(); task == nil || task.ID != "3" {
+		t.Errorf("SelectedTask() = %v, want the task selected before pinning", task)
+	}
+	if view := m.View(); !strings.Contains(view, PinIcon+" Three") {
+		t.Errorf("View() should show the pin icon before pinned tasks, got:\n%s", view)
+	}
+}
+
+func TestSetSort_OrdersSiblings(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{
+		{ID: "1", Name: "Write"},
+		{ID: "2", Name: "Call", Children: []domain.Task{{ID: "2a", Name: "Zip"}, {ID: "2b", Name: "Ask"}}},
+		{ID: "3", Name: "Book"},
+	})
+	m, _ = m.SelectTask("1")
+	m = m.SetPinned(map[string]bool{"1": true})
+
+	m = m.SetSort(tui.SortName)
+
+	var ids []string
+	for _, task := range m.tasks {
+		ids = append(ids, task.ID)
+	}
+	if want := "1 3 2 2b 2a"; strings.Join(ids, " ") != want {
+		t.Errorf("rows = %v, want %s", ids, want)
+	}
+	if task := m.SelectedTask(); task == nil || task.ID != "1" {
+		t.Errorf("SelectedTask() = %v, want the task selected before sorting", task)
+	}
+	if _, _, ok := m.MoveSelected(1); ok {
+		t.Error("MoveSelected() should do nothing while sorted")
+	}
+
+	m = m.SetSort(tui.SortAdded)
+	if m.tasks[1].ID != "2" {
+		t.Errorf("rows after SetSort(added) start %s, %s, want service order", m.tasks[0].ID, m.tasks[1].ID)
+	}
+}
+
+func TestMoveSelected(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks(nestedTestTasks())
+	m, _ = m.SelectTask("1b")
+
+	m, pos, ok := m.MoveSelected(-1)
+	if !ok || pos != (domain.TaskPosition{SiblingID: "1a"}) {
+		t.Fatalf("MoveSelected(-1) = %+v, %v, want before 1a", pos, ok)
+	}
+	if m.tasks[1].ID != "1b" || m.tasks[2].ID != "1a" || m.SelectedTask().ID != "1b" {
+		t.Errorf("expected 1b above 1a and still selected, got %s, %s", m.tasks[1].ID, m.tasks[2].ID)
+	}
+
+	// A subtask does not move past its first sibling
+	if _, _, ok := m.MoveSelected(-1); ok {
+		t.Error("MoveSelected(-1) on the first sibling should do nothing")
+	}
+
+	m, _ = m.SelectTask("1")
+	m, pos, ok = m.MoveSelected(1)
+	if !ok || pos != (domain.TaskPosition{SiblingID: "2", After: true}) {
+		t.Fatalf("MoveSelected(1) = %+v, %v, want after 2", pos, ok)
+	}
+	if m.tasks[0].ID != "2" || m.tasks[1].ID != "1" || m.SelectedTask().ID != "1" {
+		t.Errorf("expected the parent and its subtasks below 2, got %s, %s", m.tasks[0].ID, m.tasks[1].ID)
+	}
+}
+
+func TestPatchTask(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	tasks := []domain.Task{
+		{ID: "p", Name: "Parent", Children: []domain.Task{{ID: "c", Name: "Child"}}},
+		{ID: "o", Name: "Other"},
+	}
+	m = m.SetTasks(tasks)
+	m, _ = m.SelectTask("o")
+
+	m = m.PatchTask("c", func(task *domain.Task) { task.Completed = true })
+	if !m.tasks[1].Completed {
+		t.Error("PatchTask(c) should mark the subtask completed")
+	}
+	if !strings.Contains(m.View(), CheckboxChecked+" Child") {
+		t.Errorf("View() should show the subtask checked, got:\n%s", m.View())
+	}
+	if tasks[0].Children[0].Completed {
+		t.Error("PatchTask() modified the tasks passed to SetTasks")
+	}
+	if task := m.SelectedTask(); task == nil || task.ID != "o" {
+		t.Errorf("SelectedTask() = %v, want o", task)
+	}
+
+	m = m.PatchTask("c", func(task *domain.Task) { task.Completed = false })
+	if m.tasks[1].Completed {
+		t.Error("PatchTask(c) should mark the subtask not completed")
+	}
+}
+
+func TestSetConflicts_MarksConflictedTasks(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{{ID: "1", Name: "One"}, {ID: "2", Name: "Two"}})
+
+	m = m.SetConflicts(map[string]bool{"2": true})
+
+	view := m.View()
+	if !strings.Contains(view, ConflictIcon+" Two") {
+		t.Errorf("View() should show the conflict icon before conflicted tasks, got:\n%s", view)
+	}
+	if strings.Contains(view, ConflictIcon+" One") {
+		t.Errorf("View() should not mark tasks without a conflict, got:\n%s", view)
+	}
+}
+
+func TestSetShowPath_ShowsFolderAndProject(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{
+		{ID: "1", Name: "Send invoice", ProjectName: "Acme", FolderPath: []string{"Work", "Clients"}},
+		{ID: "2", Name: "Inbox task"},
+	})
+	if strings.Contains(m.View(), "Acme") {
+		t.Error("View() should not show paths by default")
+	}
+
+	m = m.SetShowPath(true)
+
+	view := m.View()
+	if !strings.Contains(view, "Send invoice  Work ▸ Clients ▸ Acme") {
+		t.Errorf("View() should show the path after the name, got:\n%s", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "Inbox task") && strings.TrimSpace(line) != "☐ Inbox task" {
+			t.Errorf("View() should show no path for inbox tasks, got %q", line)
+		}
+	}
+}
+
+func TestSetHighlight_HighlightsNameMatches(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	styles := tui.NewStyles(r)
+	m := New(styles, tui.DefaultKeyMap())
+	m = m.SetTasks([]domain.Task{{ID: "1", Name: "Call mom"}, {ID: "2", Name: "Buy milk"}})
+	plain := m.View()
+
+	m = m.SetHighlight(regexp.MustCompile("(?i)mom"))
+
+	view := m.View()
+	if ansi.Strip(view) != ansi.Strip(plain) {
+		t.Errorf("highlighting should not change the text, got:\n%s", ansi.Strip(view))
+	}
+	if view == plain {
+		t.Error("View() should highlight the match in the selected task")
+	}
+	if !strings.Contains(view, styles.Task.Normal.Render("☐ Buy milk")) {
+		t.Error("View() should render tasks without a match as before")
+	}
+}
+
+func TestSetSequential_MarksNextAction(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "1", Name: "Quote", Completed: true},
+		{ID: "2", Name: "Order", Children: []domain.Task{{ID: "2a", Name: "Measure"}, {ID: "2b", Name: "Pick tiles", Blocked: true}}},
+		{ID: "3", Name: "Install", Blocked: true},
+	}
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	m := New(tui.NewStyles(r), tui.DefaultKeyMap())
+	m = m.SetTasks(tasks)
+	if strings.Contains(m.View(), NextActionIcon) {
+		t.Error("View() should not mark a next action outside sequential projects")
+	}
+
+	m = m.SetSequential(true)
+	view := m.View()
+	if !strings.Contains(view, NextActionIcon+" Measure") || strings.Count(view, NextActionIcon) != 1 {
+		t.Errorf("View() should mark only the first available task, got:\n%s", view)
+	}
+	install := m.tasks[4]
+	if m.formatTaskLine(install, false) != m.styles.Task.Blocked.Render(m.taskText(install, 0)) {
+		t.Error("blocked tasks should be dimmed")
+	}
+}
+
+func TestToggleNextOnly(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "1", Name: "Quote", Completed: true},
+		{ID: "2", Name: "Order", Children: []domain.Task{{ID: "2a", Name: "Measure"}, {ID: "2b", Name: "Pick tiles", Blocked: true}}},
+		{ID: "3", Name: "Install", Blocked: true},
+	}
+	rows := func(m Model) string {
+		var ids []string
+		for _, task := range m.tasks {
+			ids = append(ids, task.ID)
+		}
+		return strings.Join(ids, " ")
+	}
+
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks(tasks).SetSequential(true)
+	m, _ = m.SelectTask("3")
+
+	m = m.ToggleNextOnly()
+	if got := rows(m); got != "2 2a" {
+		t.Errorf("rows = %s, want the next action and its parent", got)
+	}
+	if task := m.SelectedTask(); task == nil {
+		t.Error("the cursor should stay on a row")
+	}
+
+	m = m.SetSequential(false)
+	if got := rows(m); got != "2 2a" {
+		t.Errorf("rows of a parallel project = %s, want the available tasks", got)
+	}
+
+	m = m.ToggleNextOnly()
+	if got := rows(m); got != "1 2 2a 2b 3" || m.NextOnly() {
+		t.Errorf("rows = %s, want every task again", got)
+	}
+}
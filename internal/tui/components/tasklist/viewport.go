@@ -0,0 +1,148 @@
+package tasklist
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Scroll indicators shown above and below the rows when the list overflows
+const (
+	MoreAboveIcon = "↑"
+	MoreBelowIcon = "↓"
+)
+
+// window returns the rows [start, end) shown, with the cursor among them,
+// and whether the list overflows its height so a scroll indicator line is
+// kept above and below the rows. Only rows near the viewport are rendered,
+// so the cost follows the height rather than the number of tasks. Without a
+// height every row is shown.
+func (m Model) window() (start, end int, overflow bool) {
+	n := len(m.tasks)
+	if m.height <= 0 || n == 0 {
+		return 0, n, false
+	}
+
+	removed := m.removedAfter()
+	heights := make(map[int]int)
+	height := func(i int) int {
+		h, ok := heights[i]
+		if !ok {
+			for _, l := range m.rowLines(i, removed) {
+				h += lipgloss.Height(l.text)
+			}
+			heights[i] = h
+		}
+		return h
+	}
+
+	// Show every row when they fit
+	used := 0
+	for end = 0; end < n && used <= m.height; end++ {
+		used += height(end)
+	}
+	if end == n && used <= m.height {
+		return 0, n, false
+	}
+
+	budget := max(1, m.height-2)
+	cursor := min(max(m.cursor, 0), n-1)
+	start = min(max(m.offset, 0), cursor)
+
+	// Scroll down until the rows from start to the cursor fit
+	used = height(cursor)
+	first := cursor
+	for first > start && used+height(first-1) <= budget {
+		first--
+		used += height(first)
+	}
+	start = first
+
+	// Fill the viewport below start, then above it once the end is reached
+	used = 0
+	for end = start; end < n && used+height(end) <= budget; end++ {
+		used += height(end)
+	}
+	end = max(end, cursor+1)
+	if end == n {
+		for start > 0 && used+height(start-1) <= budget {
+			start--
+			used += height(start)
+		}
+	}
+	return start, end, true
+}
+
+// scroll moves the viewport so the cursor is shown
+func (m Model) scroll() Model {
+	m.offset, _, _ = m.window()
+	return m
+}
+
+// pageSize returns how many rows page up and page down move by
+func (m Model) pageSize() int {
+	return max(1, m.height-2)
+}
+
+// visibleLines renders the rows in the viewport, with tasks removed by the
+// last reload struck through below the row they followed while the changes
+// are shown, and a scroll indicator line above and below when the list
+// overflows
+func (m Model) visibleLines() []renderedLine {
+	start, end, overflow := m.window()
+	removed := m.removedAfter()
+
+	lines := make([]renderedLine, 0, end-start+2)
+	if overflow {
+		lines = append(lines, m.scrollIndicator(MoreAboveIcon, start))
+	}
+	for i := start; i < end; i++ {
+		lines = append(lines, m.rowLines(i, removed)...)
+	}
+	if overflow {
+		lines = append(lines, m.scrollIndicator(MoreBelowIcon, len(m.tasks)-end))
+	}
+	return lines
+}
+
+// scrollIndicator renders the line telling how many rows are hidden in one
+// direction, blank when none are so rows keep their place
+func (m Model) scrollIndicator(icon string, hidden int) renderedLine {
+	if hidden == 0 {
+		return renderedLine{row: -1}
+	}
+	return renderedLine{text: m.styles.UI.Help.Render(fmt.Sprintf(" %s %d more", icon, hidden)), row: -1}
+}
+
+// rowLines renders row i followed by the removed rows after it; the first
+// row also brings the removed rows that led the list
+func (m Model) rowLines(i int, removed map[string][]removedRow) []renderedLine {
+	var lines []renderedLine
+	if i == 0 {
+		lines = m.appendRemoved(lines, removed[""])
+	}
+	task := m.tasks[i]
+	lines = append(lines, renderedLine{text: m.formatTaskLine(task, i == m.cursor), row: i})
+	return m.appendRemoved(lines, removed[task.ID])
+}
+
+// appendRemoved appends removed rows struck through
+func (m Model) appendRemoved(lines []renderedLine, rows []removedRow) []renderedLine {
+	for _, r := range rows {
+		lines = append(lines, renderedLine{text: m.styles.Task.Removed.Render(m.taskText(r.task, r.depth)), row: -1})
+	}
+	return lines
+}
+
+// removedAfter groups the removed rows still shown by the ID of the row they
+// follow
+func (m Model) removedAfter() map[string][]removedRow {
+	if !m.fading() {
+		return nil
+	}
+	removed := make(map[string][]removedRow)
+	for _, r := range m.removed {
+		removed[r.after] = append(removed[r.after], r)
+	}
+	return removed
+}
@@ -0,0 +1,155 @@
+package tasklist
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// newLongList returns a list of n tasks named "Task 0" on, height lines tall
+func newLongList(n, height int) Model {
+	tasks := make([]domain.Task, n)
+	for i := range tasks {
+		tasks[i] = domain.Task{ID: fmt.Sprint(i), Name: fmt.Sprintf("Task %d", i)}
+	}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap())
+	m = m.SetTasks(tasks)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: height})
+	return m
+}
+
+// viewLines returns the lines of the list's view without styling
+func viewLines(m Model) []string {
+	return strings.Split(strings.TrimSuffix(ansi.Strip(m.View()), "\n"), "\n")
+}
+
+func TestView_RendersOnlyTheViewport(t *testing.T) {
+	m := newLongList(1000, 10)
+
+	lines := viewLines(m)
+	if len(lines) != 10 {
+		t.Fatalf("View() has %d lines, want 10", len(lines))
+	}
+	if strings.TrimSpace(lines[0]) != "" {
+		t.Errorf("first line = %q, want a blank indicator at the top", lines[0])
+	}
+	if !strings.Contains(lines[1], "Task 0") || !strings.Contains(lines[8], "Task 7") {
+		t.Errorf("rows = %q .. %q, want Task 0 .. Task 7", lines[1], lines[8])
+	}
+	if !strings.Contains(lines[9], MoreBelowIcon+" 992 more") {
+		t.Errorf("last line = %q, want the rows below counted", lines[9])
+	}
+}
+
+func TestView_ShortListHasNoIndicators(t *testing.T) {
+	m := newLongList(5, 10)
+
+	lines := viewLines(m)
+	if len(lines) != 5 || !strings.Contains(lines[0], "Task 0") {
+		t.Errorf("View() = %q, want the five tasks alone", lines)
+	}
+}
+
+func TestScroll_FollowsCursor(t *testing.T) {
+	m := newLongList(100, 10)
+
+	for range 9 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	lines := viewLines(m)
+	if !strings.Contains(lines[0], MoreAboveIcon+" 2 more") || !strings.Contains(lines[8], "Task 9") {
+		t.Errorf("View() = %q, want Task 9 at the bottom with 2 rows above", lines)
+	}
+
+	// Moving back up keeps the viewport until the cursor reaches its top
+	for range 2 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	}
+	if lines := viewLines(m); !strings.Contains(lines[1], "Task 2") {
+		t.Errorf("first row = %q, want Task 2 still first", lines[1])
+	}
+
+	m, _ = m.SelectTask("99")
+	lines = viewLines(m)
+	if !strings.Contains(lines[8], "Task 99") || strings.TrimSpace(lines[9]) != "" {
+		t.Errorf("View() = %q, want Task 99 last with nothing below", lines)
+	}
+}
+
+func TestPageKeys(t *testing.T) {
+	m := newLongList(100, 10)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if got := m.SelectedIndex(); got != 8 {
+		t.Errorf("SelectedIndex() after page down = %d, want 8", got)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	if got := m.SelectedIndex(); got != 16 {
+		t.Errorf("SelectedIndex() after ctrl+f = %d, want 16", got)
+	}
+	if lines := viewLines(m); !strings.Contains(lines[8], "Task 16") {
+		t.Errorf("View() = %q, want the selected task shown", lines)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	if got := m.SelectedIndex(); got != 0 {
+		t.Errorf("SelectedIndex() after paging up = %d, want 0", got)
+	}
+}
+
+func TestRowAt_ScrolledList(t *testing.T) {
+	m := newLongList(100, 10)
+	m, _ = m.SelectTask("50")
+
+	start, _, _ := m.window()
+	if row, ok := m.rowAt(1); !ok || row != start {
+		t.Errorf("rowAt(1) = %d, %v, want the first row shown (%d)", row, ok, start)
+	}
+	if _, ok := m.rowAt(0); ok {
+		t.Error("rowAt(0) should hit the scroll indicator, not a task")
+	}
+}
+
+func TestView_CostFollowsViewport(t *testing.T) {
+	// Each row of a sequential list reads the clock as it is formatted, so
+	// counting the reads counts the rows rendered
+	formatted := func(n int) int {
+		m := newLongList(n, 40).SetSequential(true)
+		m, _ = m.SelectTask(fmt.Sprint(n / 2))
+		reads := 0
+		m.now = func() time.Time {
+			reads++
+			return time.Now()
+		}
+		_ = m.View()
+		return reads
+	}
+
+	small, large := formatted(1_000), formatted(100_000)
+	if small == 0 {
+		t.Fatal("View() formatted no rows")
+	}
+	// Rendering every row would format a hundred times more
+	if large != small {
+		t.Errorf("View() of 100000 tasks formatted %d rows, want the %d of 1000", large, small)
+	}
+}
+
+func BenchmarkView(b *testing.B) {
+	for _, n := range []int{100, 1_000, 10_000, 100_000} {
+		m := newLongList(n, 40)
+		m, _ = m.SelectTask(fmt.Sprint(n / 2))
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for b.Loop() {
+				_ = m.View()
+			}
+		})
+	}
+}
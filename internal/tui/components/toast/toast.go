@@ -0,0 +1,153 @@
+// Package toast provides transient notifications that dismiss themselves.
+package toast
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// Display limits
+const (
+	DefaultDuration = 3 * time.Second // How long info and success toasts stay visible
+	ErrorDuration   = 5 * time.Second // How long error and warning toasts stay visible
+	MaxToasts       = 3               // Older toasts are dropped beyond this count
+	MaxWidth        = 40              // Maximum rendered width of a toast, including its border
+)
+
+// Level identifies the severity of a toast
+type Level int
+
+const (
+	Info Level = iota
+	Success
+	Error
+	Warning
+)
+
+// DismissMsg is sent when the toast with the given ID should disappear
+type DismissMsg struct {
+	ID int
+}
+
+// toast is a single queued notification
+type toast struct {
+	id    int
+	level Level
+	text  string
+}
+
+// Model represents the stack of visible toasts
+type Model struct {
+	toasts []toast
+	nextID int
+	styles *tui.Styles
+	width  int
+}
+
+// New creates an empty toast stack
+func New(styles *tui.Styles) Model {
+	return Model{styles: styles}
+}
+
+// Push shows a new toast and returns the command that dismisses it later
+func (m Model) Push(level Level, text string) (Model, tea.Cmd) {
+	m.nextID++
+	id := m.nextID
+
+	toasts := append([]toast{}, m.toasts...)
+	toasts = append(toasts, toast{id: id, level: level, text: text})
+	if len(toasts) > MaxToasts {
+		toasts = toasts[len(toasts)-MaxToasts:]
+	}
+	m.toasts = toasts
+
+	duration := DefaultDuration
+	if level == Error || level == Warning {
+		duration = ErrorDuration
+	}
+	return m, tea.Tick(duration, func(time.Time) tea.Msg {
+		return DismissMsg{ID: id}
+	})
+}
+
+// Update handles dismissal messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(DismissMsg); ok {
+		m = m.dismiss(msg.ID)
+	}
+	return m, nil
+}
+
+// dismiss removes the toast with the given ID, if it is still shown
+func (m Model) dismiss(id int) Model {
+	toasts := make([]toast, 0, len(m.toasts))
+	for _, t := range m.toasts {
+		if t.id != id {
+			toasts = append(toasts, t)
+		}
+	}
+	m.toasts = toasts
+	return m
+}
+
+// SetWidth updates the available screen width
+func (m Model) SetWidth(width int) Model {
+	m.width = width
+	return m
+}
+
+// IsVisible returns true if at least one toast is shown
+func (m Model) IsVisible() bool {
+	return len(m.toasts) > 0
+}
+
+// Messages returns the text of the visible toasts, oldest first
+func (m Model) Messages() []string {
+	messages := make([]string, len(m.toasts))
+	for i, t := range m.toasts {
+		messages[i] = t.text
+	}
+	return messages
+}
+
+// View renders the toasts stacked vertically, newest at the bottom
+func (m Model) View() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+
+	width := MaxWidth
+	if m.width > 0 && m.width/2 < width {
+		width = m.width / 2
+	}
+
+	rendered := make([]string, len(m.toasts))
+	for i, t := range m.toasts {
+		style := m.style(t.level)
+		textWidth := width - style.GetHorizontalFrameSize()
+		if textWidth < 1 {
+			textWidth = 1
+		}
+		rendered[i] = style.Render(ansi.Truncate(t.text, textWidth, "…"))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Right, rendered...)
+}
+
+// style returns the style for a toast level
+func (m Model) style(level Level) lipgloss.Style {
+	switch level {
+	case Success:
+		return m.styles.Toast.Success
+	case Error:
+		return m.styles.Toast.Error
+	case Warning:
+		return m.styles.Toast.Warning
+	default:
+		return m.styles.Toast.Info
+	}
+}
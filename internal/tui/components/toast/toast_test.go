@@ -0,0 +1,79 @@
+package toast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func TestNew(t *testing.T) {
+	m := New(tui.DefaultStyles())
+
+	if m.IsVisible() {
+		t.Error("new toast stack should not be visible")
+	}
+	if m.View() != "" {
+		t.Errorf("View() = %q, want empty", m.View())
+	}
+}
+
+func TestPush_ShowsToastAndSchedulesDismissal(t *testing.T) {
+	m := New(tui.DefaultStyles())
+
+	m, cmd := m.Push(Success, "Completed \"Write report\"")
+
+	if !m.IsVisible() {
+		t.Error("toast should be visible after Push()")
+	}
+	if cmd == nil {
+		t.Fatal("Push() should return a dismissal command")
+	}
+	if !strings.Contains(m.View(), "Write report") {
+		t.Errorf("View() missing toast text\nGot: %s", m.View())
+	}
+}
+
+func TestUpdate_DismissMsgRemovesOnlyMatchingToast(t *testing.T) {
+	m := New(tui.DefaultStyles())
+	m, _ = m.Push(Info, "first")
+	m, _ = m.Push(Error, "second")
+
+	m, _ = m.Update(DismissMsg{ID: 1})
+
+	got := m.Messages()
+	if len(got) != 1 || got[0] != "second" {
+		t.Errorf("Messages() = %v, want [second]", got)
+	}
+
+	m, _ = m.Update(DismissMsg{ID: 2})
+	if m.IsVisible() {
+		t.Error("toast stack should be empty after dismissing every toast")
+	}
+}
+
+func TestPush_DropsOldestBeyondLimit(t *testing.T) {
+	m := New(tui.DefaultStyles())
+	for _, text := range []string{"a", "b", "c", "d"} {
+		m, _ = m.Push(Info, text)
+	}
+
+	got := m.Messages()
+	if len(got) != MaxToasts || got[0] != "b" {
+		t.Errorf("Messages() = %v, want the %d newest", got, MaxToasts)
+	}
+}
+
+func TestView_TruncatesLongText(t *testing.T) {
+	m := New(tui.DefaultStyles()).SetWidth(40)
+	m, _ = m.Push(Info, strings.Repeat("x", 100))
+
+	for _, line := range strings.Split(m.View(), "\n") {
+		if w := len([]rune(line)); w > 20 {
+			t.Errorf("toast line width = %d, want at most 20 (half the screen)", w)
+		}
+	}
+	if !strings.Contains(m.View(), "…") {
+		t.Error("truncated toast should end with an ellipsis")
+	}
+}
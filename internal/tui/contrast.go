@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Contrast ratios, as defined by WCAG, that selection colors are kept to
+const (
+	minTextContrast  = 4.5 // Text on a selected row
+	minMutedContrast = 3.0 // Struck-through text of a selected completed task
+	minRowContrast   = 3.0 // A selected row against the terminal background
+)
+
+// rgb is a color with channels from 0 to 1
+type rgb struct {
+	r, g, b float64
+}
+
+var (
+	black = rgb{0, 0, 0}
+	white = rgb{1, 1, 1}
+)
+
+// parseColor reads a "#RRGGBB" hex color or an ANSI color number
+func parseColor(s string) (rgb, bool) {
+	if s == "" {
+		return rgb{}, false
+	}
+	color := termenv.TrueColor.Color(s)
+	if color == nil {
+		return rgb{}, false
+	}
+	c := termenv.ConvertToRGB(color)
+	return rgb{c.R, c.G, c.B}, true
+}
+
+// hex returns c as a "#RRGGBB" hex color
+func (c rgb) hex() string {
+	channel := func(v float64) int {
+		return int(math.Round(min(max(v, 0), 1) * 255))
+	}
+	return fmt.Sprintf("#%02X%02X%02X", channel(c.r), channel(c.g), channel(c.b))
+}
+
+// rounded returns c as precise as a hex color holds it
+func (c rgb) rounded() rgb {
+	r, _ := parseColor(c.hex())
+	return r
+}
+
+// luminance returns the relative luminance of c
+func (c rgb) luminance() float64 {
+	linear := func(v float64) float64 {
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(c.r) + 0.7152*linear(c.g) + 0.0722*linear(c.b)
+}
+
+// contrastRatio returns the contrast ratio of a and b, from 1 to 21
+func contrastRatio(a, b rgb) float64 {
+	la, lb := a.luminance(), b.luminance()
+	return (max(la, lb) + 0.05) / (min(la, lb) + 0.05)
+}
+
+// mix returns the color t of the way from a to b
+func mix(a, b rgb, t float64) rgb {
+	return rgb{a.r + (b.r-a.r)*t, a.g + (b.g-a.g)*t, a.b + (b.b-a.b)*t}
+}
+
+// ensureContrast returns c, moved toward black or white just enough to reach
+// ratio against every one of backgrounds
+func ensureContrast(c rgb, ratio float64, backgrounds ...rgb) rgb {
+	lowest := func(c rgb) float64 {
+		lowest := math.Inf(1)
+		for _, bg := range backgrounds {
+			lowest = min(lowest, contrastRatio(c, bg))
+		}
+		return lowest
+	}
+	if c = c.rounded(); lowest(c) >= ratio {
+		return c
+	}
+	target := white
+	if lowest(black) > lowest(white) {
+		target = black
+	}
+	for t := 0.05; t < 1; t += 0.05 {
+		if moved := mix(c, target, t).rounded(); lowest(moved) >= ratio {
+			return moved
+		}
+	}
+	return target
+}
+
+// selectionColors are the colors of selected rows for a light or a dark
+// terminal
+type selectionColors struct {
+	background, foreground               string
+	overdueBackground, overdueForeground string
+	completedForeground                  string
+}
+
+// newSelectionColors derives selection colors from a theme's colors for a
+// light or a dark terminal. The background starts from primary and stays
+// apart from the terminal's, approximated by surface and by white or black;
+// text on it stays readable. Colors that can't be read are kept as they are.
+func newSelectionColors(primary, onPrimary, errorColor, surface string, dark bool) selectionColors {
+	kept := selectionColors{primary, onPrimary, errorColor, onPrimary, onPrimary}
+	bg, ok := parseColor(primary)
+	if !ok {
+		return kept
+	}
+	terminal := white
+	if dark {
+		terminal = black
+	}
+	backgrounds := []rgb{terminal}
+	if s, ok := parseColor(surface); ok {
+		backgrounds = append(backgrounds, s)
+	}
+	fg, ok := parseColor(onPrimary)
+	if !ok {
+		fg = terminal
+	}
+
+	bg = ensureContrast(bg, minRowContrast, backgrounds...)
+	fg = ensureContrast(fg, minTextContrast, bg)
+	colors := selectionColors{
+		background:          bg.hex(),
+		foreground:          fg.hex(),
+		overdueBackground:   errorColor,
+		overdueForeground:   fg.hex(),
+		completedForeground: ensureContrast(mix(fg, bg, 0.35), minMutedContrast, bg).hex(),
+	}
+	if e, ok := parseColor(errorColor); ok {
+		e = ensureContrast(e, minRowContrast, backgrounds...)
+		colors.overdueBackground = e.hex()
+		colors.overdueForeground = ensureContrast(fg, minTextContrast, e).hex()
+	}
+	return colors
+}
+
+// selectionPalette holds the colors of selected rows for light and dark
+// terminals
+type selectionPalette struct {
+	background, foreground               lipgloss.AdaptiveColor
+	overdueBackground, overdueForeground lipgloss.AdaptiveColor
+	completedForeground                  lipgloss.AdaptiveColor
+}
+
+// newSelectionPalette derives the selection colors of a theme's colors
+func newSelectionPalette(colors ColorStyles) selectionPalette {
+	light := newSelectionColors(colors.Primary.Light, colors.OnPrimary.Light, colors.Error.Light, colors.Surface.Light, false)
+	dark := newSelectionColors(colors.Primary.Dark, colors.OnPrimary.Dark, colors.Error.Dark, colors.Surface.Dark, true)
+	return selectionPalette{
+		background:          lipgloss.AdaptiveColor{Light: light.background, Dark: dark.background},
+		foreground:          lipgloss.AdaptiveColor{Light: light.foreground, Dark: dark.foreground},
+		overdueBackground:   lipgloss.AdaptiveColor{Light: light.overdueBackground, Dark: dark.overdueBackground},
+		overdueForeground:   lipgloss.AdaptiveColor{Light: light.overdueForeground, Dark: dark.overdueForeground},
+		completedForeground: lipgloss.AdaptiveColor{Light: light.completedForeground, Dark: dark.completedForeground},
+	}
+}
+
+// SelectedStyle returns the style of task's row while it is selected:
+// struck through when completed, on the error color when due, or inheriting
+// a due date, before today
+func (s TaskStyles) SelectedStyle(task domain.Task, today time.Time) lipgloss.Style {
+	due := task.EffectiveDue()
+	switch {
+	case task.Completed:
+		return s.SelectedCompleted
+	case due != nil && due.Before(today):
+		return s.SelectedOverdue
+	default:
+		return s.Selected
+	}
+}
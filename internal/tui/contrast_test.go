@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestContrastRatio(t *testing.T) {
+	if got := contrastRatio(black, white); got < 20.9 || got > 21.1 {
+		t.Errorf("contrastRatio(black, white) = %.2f, want 21", got)
+	}
+	if got := contrastRatio(white, white); got != 1 {
+		t.Errorf("contrastRatio(white, white) = %.2f, want 1", got)
+	}
+}
+
+// mustParse parses a color the test expects to be valid
+func mustParse(t *testing.T, s string) rgb {
+	t.Helper()
+	c, ok := parseColor(s)
+	if !ok {
+		t.Fatalf("parseColor(%q) failed", s)
+	}
+	return c
+}
+
+func TestSelectionContrast_Themes(t *testing.T) {
+	for _, name := range ThemeNames() {
+		theme, err := LookupTheme(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// A near-white primary would vanish on a light terminal
+		washedOut := theme.Override(ThemeColors{Primary: "#F4F4F4", Error: "#FFEEEE"}, ThemeColors{Primary: "#101010", Error: "#200000"})
+
+		for _, theme := range []Theme{theme, washedOut} {
+			p := newSelectionPalette(theme.Colors)
+			for _, v := range []struct {
+				variant  string
+				terminal rgb
+				pick     func(lipgloss.AdaptiveColor) string
+			}{
+				{"light", white, func(c lipgloss.AdaptiveColor) string { return c.Light }},
+				{"dark", black, func(c lipgloss.AdaptiveColor) string { return c.Dark }},
+			} {
+				bg := mustParse(t, v.pick(p.background))
+				overdue := mustParse(t, v.pick(p.overdueBackground))
+				checks := []struct {
+					what string
+					a, b rgb
+					min  float64
+				}{
+					{"selection on terminal", bg, v.terminal, minRowContrast},
+					{"text on selection", mustParse(t, v.pick(p.foreground)), bg, minTextContrast},
+					{"completed text on selection", mustParse(t, v.pick(p.completedForeground)), bg, minMutedContrast},
+					{"overdue selection on terminal", overdue, v.terminal, minRowContrast},
+					{"text on overdue selection", mustParse(t, v.pick(p.overdueForeground)), overdue, minTextContrast},
+				}
+				for _, c := range checks {
+					if got := contrastRatio(c.a, c.b); got < c.min {
+						t.Errorf("%s (%s): %s contrast = %.2f, want at least %.1f", name, v.variant, c.what, got, c.min)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSelectionColors_KeepsUnreadableColors(t *testing.T) {
+	got := newSelectionColors("", "15", "9", "", true)
+	if got.background != "" || got.foreground != "15" {
+		t.Errorf("newSelectionColors() = %+v, want the colors kept", got)
+	}
+}
+
+func TestSelectedStyle(t *testing.T) {
+	styles := DefaultStyles().Task
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+	later := today.Add(9 * time.Hour)
+
+	tests := []struct {
+		name string
+		task domain.Task
+		want lipgloss.Style
+	}{
+		{"plain", domain.Task{Name: "a"}, styles.Selected},
+		{"due today", domain.Task{Name: "a", DueDate: &later}, styles.Selected},
+		{"overdue", domain.Task{Name: "a", DueDate: &yesterday}, styles.SelectedOverdue},
+		{"completed", domain.Task{Name: "a", DueDate: &yesterday, Completed: true}, styles.SelectedCompleted},
+	}
+	for _, tt := range tests {
+		got := styles.SelectedStyle(tt.task, today)
+		if got.GetBackground() != tt.want.GetBackground() || got.GetStrikethrough() != tt.want.GetStrikethrough() {
+			t.Errorf("SelectedStyle(%s) picked the wrong style", tt.name)
+		}
+	}
+	if styles.SelectedOverdue.GetBackground() == styles.Selected.GetBackground() {
+		t.Error("SelectedOverdue should have its own background")
+	}
+	if !styles.SelectedCompleted.GetStrikethrough() {
+		t.Error("SelectedCompleted should be struck through")
+	}
+}
@@ -0,0 +1,45 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// Index holds the lowercased names and notes of loaded tasks, so a search
+// does not lowercase every note again on each keystroke
+type Index struct {
+	entries map[string]indexEntry
+}
+
+// indexEntry is the searchable text of one task
+type indexEntry struct {
+	name, note           string // As indexed, to detect tasks changed since
+	lowerName, lowerNote string
+}
+
+// NewIndex indexes tasks and all their subtasks
+func NewIndex(tasks []domain.Task) *Index {
+	flat := domain.FlattenTasks(tasks)
+	idx := &Index{entries: make(map[string]indexEntry, len(flat))}
+	for _, task := range flat {
+		idx.entries[task.ID] = indexEntry{
+			name:      task.Name,
+			note:      task.Note,
+			lowerName: strings.ToLower(task.Name),
+			lowerNote: strings.ToLower(task.Note),
+		}
+	}
+	return idx
+}
+
+// text returns the lowercased name and note of task, from the index when it
+// holds the task's current text
+func (idx *Index) text(task domain.Task) (name, note string) {
+	if idx != nil {
+		if e, ok := idx.entries[task.ID]; ok && e.name == task.Name && e.note == task.Note {
+			return e.lowerName, e.lowerNote
+		}
+	}
+	return strings.ToLower(task.Name), strings.ToLower(task.Note)
+}
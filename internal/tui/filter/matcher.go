@@ -2,6 +2,8 @@
 package filter
 
 import (
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,42 +12,100 @@ import (
 
 // Matcher filters tasks based on filter state
 type Matcher struct {
-	state State
+	state   State
+	index   *Index
+	pattern *regexp.Regexp // Search pattern when the search text is a regular expression
+	invalid bool           // Whether the search pattern does not compile, so nothing matches
 }
 
 // NewMatcher creates a new Matcher with the given state
 func NewMatcher(state State) *Matcher {
-	return &Matcher{state: state}
+	m := &Matcher{state: state}
+	if state.IsRegexp() {
+		pattern, err := state.SearchRegexp()
+		m.pattern, m.invalid = pattern, err != nil
+	}
+	return m
+}
+
+// WithIndex makes the matcher search the text held by idx
+func (m *Matcher) WithIndex(idx *Index) *Matcher {
+	m.index = idx
+	return m
 }
 
-// FilterTasks returns tasks that match the current filter state
+// FilterTasks returns tasks that match the current filter state. With search
+// text, tasks whose name matches come first, followed by tasks matching only
+// in their note, most mentions first.
 func (m *Matcher) FilterTasks(tasks []domain.Task) []domain.Task {
 	if !m.state.IsActive() {
 		return tasks
 	}
 
 	result := make([]domain.Task, 0, len(tasks))
+	ranks := make(map[string]searchRank)
 	for _, task := range tasks {
-		if m.matches(task) {
+		if rank, ok := m.match(task); ok {
 			result = append(result, task)
+			ranks[task.ID] = rank
 		}
 	}
+
+	if m.state.SearchText != "" {
+		sort.SliceStable(result, func(i, j int) bool {
+			return ranks[result[i].ID].before(ranks[result[j].ID])
+		})
+	}
 	return result
 }
 
-// matches checks if a single task matches the filter state
-func (m *Matcher) matches(task domain.Task) bool {
+// searchRank is where the search text was found in a task
+type searchRank struct {
+	inName   bool
+	mentions int // Times the note mentions the search text
+}
+
+// before reports whether a task ranked r is listed before one ranked other
+func (r searchRank) before(other searchRank) bool {
+	if r.inName != other.inName {
+		return r.inName
+	}
+	return !r.inName && r.mentions > other.mentions
+}
+
+// match checks if a single task matches the filter state and ranks it for
+// the search text
+func (m *Matcher) match(task domain.Task) (searchRank, bool) {
+	if !m.matchesFilters(task) {
+		return searchRank{}, false
+	}
+
 	// Search text filter (case-insensitive)
-	if m.state.SearchText != "" {
+	if m.state.SearchText == "" {
+		return searchRank{}, true
+	}
+	var rank searchRank
+	switch {
+	case m.invalid:
+		return searchRank{}, false
+	case m.pattern != nil:
+		rank.inName = m.pattern.MatchString(task.Name)
+		if !m.state.NamesOnly {
+			rank.mentions = len(m.pattern.FindAllStringIndex(task.Note, -1))
+		}
+	default:
 		searchLower := strings.ToLower(m.state.SearchText)
-		nameLower := strings.ToLower(task.Name)
-		noteLower := strings.ToLower(task.Note)
-
-		if !strings.Contains(nameLower, searchLower) && !strings.Contains(noteLower, searchLower) {
-			return false
+		nameLower, noteLower := m.index.text(task)
+		rank.inName = strings.Contains(nameLower, searchLower)
+		if !m.state.NamesOnly {
+			rank.mentions = strings.Count(noteLower, searchLower)
 		}
 	}
+	return rank, rank.inName || rank.mentions > 0
+}
 
+// matchesFilters checks the filters other than the search text
+func (m *Matcher) matchesFilters(task domain.Task) bool {
 	// Project filter
 	if m.state.ProjectID != "" && task.ProjectID != m.state.ProjectID {
 		return false
@@ -70,6 +130,21 @@ func (m *Matcher) matches(task domain.Task) bool {
 		return false
 	}
 
+	// Availability filter
+	if m.state.AvailableOnly && !task.IsAvailableAt(time.Now()) {
+		return false
+	}
+
+	// Time filter; tasks without an estimate do not fit any time slot
+	if m.state.MaxMinutes > 0 && (task.EstimatedMinutes == 0 || task.EstimatedMinutes > m.state.MaxMinutes) {
+		return false
+	}
+
+	// Low energy filter; tasks without an effort are not known to be easy
+	if m.state.LowEnergy && task.Effort() != domain.EffortLow {
+		return false
+	}
+
 	// Due date filter
 	if m.state.DueFilter != DueNone {
 		if !m.matchesDueFilter(task) {
@@ -80,32 +155,34 @@ func (m *Matcher) matches(task domain.Task) bool {
 	return true
 }
 
-// matchesDueFilter checks if task due date matches the due filter
+// matchesDueFilter checks if task due date, its own or inherited, matches
+// the due filter
 func (m *Matcher) matchesDueFilter(task domain.Task) bool {
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	tomorrow := today.AddDate(0, 0, 1)
 	weekEnd := today.AddDate(0, 0, 7)
+	due := task.EffectiveDue()
 
 	switch m.state.DueFilter {
 	case DueOverdue:
-		return task.DueDate != nil && task.DueDate.Before(today)
+		return due != nil && due.Before(today)
 	case DueToday:
-		if task.DueDate == nil {
+		if due == nil {
 			return false
 		}
-		return !task.DueDate.Before(today) && task.DueDate.Before(tomorrow)
+		return !due.Before(today) && due.Before(tomorrow)
 	case DueTomorrow:
-		if task.DueDate == nil {
+		if due == nil {
 			return false
 		}
 		dayAfterTomorrow := tomorrow.AddDate(0, 0, 1)
-		return !task.DueDate.Before(tomorrow) && task.DueDate.Before(dayAfterTomorrow)
+		return !due.Before(tomorrow) && due.Before(dayAfterTomorrow)
 	case DueWeek:
-		if task.DueDate == nil {
+		if due == nil {
 			return false
 		}
-		return !task.DueDate.Before(today) && task.DueDate.Before(weekEnd)
+		return !due.Before(today) && due.Before(weekEnd)
 	default:
 		return true
 	}
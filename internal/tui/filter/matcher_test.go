@@ -1,6 +1,7 @@
 package filter
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -25,6 +26,90 @@ func TestMatcher_FilterTasks_SearchText(t *testing.T) {
 	}
 }
 
+func TestMatcher_FilterTasks_SearchRanksNameMatchesFirst(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "note1", Name: "Plan week", Note: "invoice"},
+		{ID: "name", Name: "Send invoice"},
+		{ID: "note3", Name: "Accounting", Note: "Invoice A, invoice B and invoice C"},
+		{ID: "miss", Name: "Walk dog"},
+	}
+
+	result := NewMatcher(State{SearchText: "Invoice"}).FilterTasks(tasks)
+
+	var ids []string
+	for _, task := range result {
+		ids = append(ids, task.ID)
+	}
+	if got, want := strings.Join(ids, ","), "name,note3,note1"; got != want {
+		t.Errorf("FilterTasks() order = %s, want %s", got, want)
+	}
+}
+
+func TestMatcher_FilterTasks_SearchUsesIndex(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "1", Name: "Parent", Children: []domain.Task{{ID: "2", Name: "Child", Note: "Dentist at 9"}}},
+	}
+	idx := NewIndex(tasks)
+	flat := domain.FlattenTasks(tasks)
+
+	result := NewMatcher(State{SearchText: "dentist"}).WithIndex(idx).FilterTasks(flat)
+	if len(result) != 1 || result[0].ID != "2" {
+		t.Errorf("FilterTasks() = %v, want the subtask", result)
+	}
+
+	// A task edited since indexing is searched by its current text
+	flat[1].Note = "Doctor at 9"
+	if result := NewMatcher(State{SearchText: "dentist"}).WithIndex(idx).FilterTasks(flat); len(result) != 0 {
+		t.Errorf("FilterTasks() = %v, want no match for the old note", result)
+	}
+}
+
+func TestMatcher_FilterTasks_SearchRegexp(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "name", Name: "Call Mom"},
+		{ID: "note", Name: "Plan week", Note: "Before Friday:\ncall dad\ncall bank"},
+		{ID: "recall", Name: "Recall product"},
+	}
+
+	result := NewMatcher(State{SearchText: "re:^call"}).FilterTasks(tasks)
+
+	var ids []string
+	for _, task := range result {
+		ids = append(ids, task.ID)
+	}
+	if got, want := strings.Join(ids, ","), "name"; got != want {
+		t.Errorf("FilterTasks() = %s, want %s", got, want)
+	}
+
+	// (?m) makes ^ match at the start of every note line
+	result = NewMatcher(State{SearchText: "re:(?m)^call"}).FilterTasks(tasks)
+	if len(result) != 2 || result[1].ID != "note" {
+		t.Errorf("FilterTasks() = %v, want the name match then the note match", result)
+	}
+}
+
+func TestMatcher_FilterTasks_InvalidRegexpMatchesNothing(t *testing.T) {
+	tasks := []domain.Task{{ID: "1", Name: "Call (mom"}}
+
+	if result := NewMatcher(State{SearchText: "re:(mom"}).FilterTasks(tasks); len(result) != 0 {
+		t.Errorf("FilterTasks() = %v, want no tasks for an invalid pattern", result)
+	}
+}
+
+func TestMatcher_FilterTasks_NamesOnly(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "name", Name: "Send invoice"},
+		{ID: "note", Name: "Accounting", Note: "invoice"},
+	}
+
+	for _, search := range []string{"invoice", "re:invoice"} {
+		result := NewMatcher(State{SearchText: search, NamesOnly: true}).FilterTasks(tasks)
+		if len(result) != 1 || result[0].ID != "name" {
+			t.Errorf("FilterTasks(%q) = %v, want only the name match", search, result)
+		}
+	}
+}
+
 func TestMatcher_FilterTasks_Project(t *testing.T) {
 	tasks := []domain.Task{
 		{ID: "1", Name: "Task 1", ProjectID: "proj1"},
@@ -70,6 +155,55 @@ func TestMatcher_FilterTasks_Flagged(t *testing.T) {
 	}
 }
 
+func TestMatcher_FilterTasks_AvailableOnly(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-24 * time.Hour)
+	tasks := []domain.Task{
+		{ID: "1", Name: "Available"},
+		{ID: "2", Name: "Deferred", DeferDate: &future},
+		{ID: "3", Name: "Blocked", Blocked: true},
+		{ID: "4", Name: "Started", DeferDate: &past},
+	}
+
+	matcher := NewMatcher(State{AvailableOnly: true})
+	result := matcher.FilterTasks(tasks)
+
+	if len(result) != 2 || result[0].ID != "1" || result[1].ID != "4" {
+		t.Errorf("got %+v, want tasks 1 and 4", result)
+	}
+}
+
+func TestMatcher_FilterTasks_MaxMinutes(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "1", Name: "Quick", EstimatedMinutes: 15},
+		{ID: "2", Name: "Exact fit", EstimatedMinutes: 30},
+		{ID: "3", Name: "Long", EstimatedMinutes: 90},
+		{ID: "4", Name: "Not estimated"},
+	}
+
+	matcher := NewMatcher(State{MaxMinutes: 30})
+	result := matcher.FilterTasks(tasks)
+
+	if len(result) != 2 || result[0].ID != "1" || result[1].ID != "2" {
+		t.Errorf("got %+v, want tasks 1 and 2", result)
+	}
+}
+
+func TestMatcher_FilterTasks_LowEnergy(t *testing.T) {
+	tasks := []domain.Task{
+		{ID: "1", Name: "Easy", Note: "effort: low"},
+		{ID: "2", Name: "Hard", Note: "effort: high"},
+		{ID: "3", Name: "Unknown"},
+	}
+
+	matcher := NewMatcher(State{LowEnergy: true})
+	result := matcher.FilterTasks(tasks)
+
+	if len(result) != 1 || result[0].ID != "1" {
+		t.Errorf("got %+v, want task 1", result)
+	}
+}
+
 func TestMatcher_FilterTasks_DueToday(t *testing.T) {
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, now.Location())
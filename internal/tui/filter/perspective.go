@@ -0,0 +1,132 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// FromPerspective converts the rules of a simple custom perspective into the
+// closest filter state. Only availability, flag, due, single-tag and
+// single-project rules combined with "all" are supported; other rules are
+// left out and described in the returned list. tagName resolves tag IDs to
+// the names tasks are matched on.
+func FromPerspective(p domain.PerspectiveRules, tagName func(id string) (string, error)) (State, []string, error) {
+	var state State
+	var skipped []string
+
+	switch p.Aggregation {
+	case "", "all":
+	case "any":
+		if len(p.Rules) > 1 {
+			return state, nil, fmt.Errorf("perspective %s matches any of its rules, which filters cannot express", p.Name)
+		}
+	default:
+		return state, nil, fmt.Errorf("perspective %s combines its rules with %q, which filters cannot express", p.Name, p.Aggregation)
+	}
+
+	for _, rule := range p.Rules {
+		reason, err := state.applyRule(rule, tagName)
+		if err != nil {
+			return state, nil, err
+		}
+		if reason != "" {
+			skipped = append(skipped, reason)
+		}
+	}
+
+	if !state.IsActive() {
+		return state, skipped, fmt.Errorf("perspective %s has no rules filters can express", p.Name)
+	}
+	return state, skipped, nil
+}
+
+// applyRule narrows the state by one perspective rule, returning why the
+// rule was skipped when it has no equivalent
+func (s *State) applyRule(rule domain.PerspectiveRule, tagName func(id string) (string, error)) (string, error) {
+	switch {
+	case rule["actionAvailability"] != nil:
+		switch rule["actionAvailability"] {
+		case "available", "firstAvailable":
+			s.AvailableOnly = true
+		case "remaining":
+			// Views only show remaining tasks already
+		default:
+			return fmt.Sprintf("availability %v", rule["actionAvailability"]), nil
+		}
+
+	case rule["actionStatus"] != nil:
+		switch rule["actionStatus"] {
+		case "flagged":
+			s.FlaggedOnly = true
+		case "due":
+			s.DueFilter = DueToday
+		default:
+			return fmt.Sprintf("status %v", rule["actionStatus"]), nil
+		}
+
+	case rule["actionDateField"] != nil:
+		if rule["actionDateField"] != "due" {
+			return fmt.Sprintf("%v date", rule["actionDateField"]), nil
+		}
+		if rule["actionDateIsToday"] != true {
+			return "due date range", nil
+		}
+		s.DueFilter = DueToday
+
+	case rule["actionHasAnyOfTags"] != nil || rule["actionHasAllOfTags"] != nil:
+		ids := ruleIDs(rule, "actionHasAnyOfTags", "actionHasAllOfTags")
+		if len(ids) != 1 || s.TagID != "" {
+			return "more than one tag", nil
+		}
+		name, err := tagName(ids[0])
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve tag %s: %w", ids[0], err)
+		}
+		s.TagID = name
+
+	case rule["actionWithinFocus"] != nil:
+		ids := ruleIDs(rule, "actionWithinFocus")
+		if len(ids) != 1 || s.ProjectID != "" {
+			return "more than one project or folder", nil
+		}
+		s.ProjectID = ids[0]
+
+	default:
+		return "rule " + ruleType(rule), nil
+	}
+
+	return "", nil
+}
+
+// ruleIDs returns the IDs listed under the first of keys present in rule
+func ruleIDs(rule domain.PerspectiveRule, keys ...string) []string {
+	for _, key := range keys {
+		values, ok := rule[key].([]interface{})
+		if !ok {
+			continue
+		}
+		ids := make([]string, 0, len(values))
+		for _, v := range values {
+			if id, ok := v.(string); ok {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	return nil
+}
+
+// ruleType returns the rule's type key, used to describe unsupported rules
+func ruleType(rule domain.PerspectiveRule) string {
+	keys := make([]string, 0, len(rule))
+	for key := range rule {
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return "(empty)"
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
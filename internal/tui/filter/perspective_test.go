@@ -0,0 +1,106 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func testTagName(id string) (string, error) {
+	if id == "tag1" {
+		return "Errands", nil
+	}
+	return "", errors.New("tag not found: " + id)
+}
+
+func TestFromPerspective(t *testing.T) {
+	p := domain.PerspectiveRules{
+		Name:        "Errands",
+		Aggregation: "all",
+		Rules: []domain.PerspectiveRule{
+			{"actionAvailability": "available"},
+			{"actionStatus": "flagged"},
+			{"actionHasAnyOfTags": []interface{}{"tag1"}},
+			{"actionWithinFocus": []interface{}{"proj1"}},
+			{"actionDateField": "due", "actionDateIsToday": true},
+		},
+	}
+
+	state, skipped, err := FromPerspective(p, testTagName)
+	if err != nil {
+		t.Fatalf("FromPerspective() error = %v", err)
+	}
+
+	want := State{ProjectID: "proj1", TagID: "Errands", DueFilter: DueToday, FlaggedOnly: true, AvailableOnly: true}
+	if state != want {
+		t.Errorf("FromPerspective() = %+v, want %+v", state, want)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("FromPerspective() skipped = %v, want none", skipped)
+	}
+}
+
+func TestFromPerspective_SkipsUnsupportedRules(t *testing.T) {
+	p := domain.PerspectiveRules{
+		Name: "Mixed",
+		Rules: []domain.PerspectiveRule{
+			{"actionStatus": "flagged"},
+			{"actionHasAnyOfTags": []interface{}{"tag1", "tag2"}},
+			{"actionIsLeaf": true},
+		},
+	}
+
+	state, skipped, err := FromPerspective(p, testTagName)
+	if err != nil {
+		t.Fatalf("FromPerspective() error = %v", err)
+	}
+
+	if state != (State{FlaggedOnly: true}) {
+		t.Errorf("FromPerspective() = %+v, want flagged only", state)
+	}
+	if len(skipped) != 2 || skipped[0] != "more than one tag" || skipped[1] != "rule actionIsLeaf" {
+		t.Errorf("FromPerspective() skipped = %v, want tag and actionIsLeaf rules", skipped)
+	}
+}
+
+func TestFromPerspective_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		p    domain.PerspectiveRules
+		want string
+	}{
+		{
+			name: "any of several rules",
+			p: domain.PerspectiveRules{Name: "P", Aggregation: "any", Rules: []domain.PerspectiveRule{
+				{"actionStatus": "flagged"}, {"actionAvailability": "available"},
+			}},
+			want: "matches any of its rules",
+		},
+		{
+			name: "none aggregation",
+			p:    domain.PerspectiveRules{Name: "P", Aggregation: "none", Rules: []domain.PerspectiveRule{{"actionStatus": "flagged"}}},
+			want: `combines its rules with "none"`,
+		},
+		{
+			name: "nothing convertible",
+			p:    domain.PerspectiveRules{Name: "P", Rules: []domain.PerspectiveRule{{"actionIsLeaf": true}}},
+			want: "no rules filters can express",
+		},
+		{
+			name: "unknown tag",
+			p:    domain.PerspectiveRules{Name: "P", Rules: []domain.PerspectiveRule{{"actionHasAnyOfTags": []interface{}{"tag9"}}}},
+			want: "failed to resolve tag tag9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := FromPerspective(tt.p, testTagName)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("FromPerspective() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
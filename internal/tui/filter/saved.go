@@ -0,0 +1,65 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Saved maps names to filter states kept between sessions
+type Saved map[string]State
+
+// LoadSaved reads saved filters from path. A missing file yields no filters.
+func LoadSaved(path string) (Saved, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Saved{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read saved filters: %w", err)
+	}
+
+	saved := Saved{}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse saved filters %s: %w", path, err)
+	}
+	return saved, nil
+}
+
+// Save writes the saved filters to path
+func (s Saved) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode saved filters: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write saved filters: %w", err)
+	}
+	return nil
+}
+
+// Names returns the saved filter names in alphabetical order
+func (s Saved) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Find returns the saved filter with the given name, ignoring case
+func (s Saved) Find(name string) (State, bool) {
+	if state, ok := s[name]; ok {
+		return state, true
+	}
+	for savedName, state := range s {
+		if strings.EqualFold(savedName, name) {
+			return state, true
+		}
+	}
+	return State{}, false
+}
@@ -0,0 +1,67 @@
+package filter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaved_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.json")
+	saved := Saved{
+		"errands": {TagID: "Errands", DueFilter: DueWeek, AvailableOnly: true},
+		"work":    {ProjectID: "proj1"},
+	}
+
+	if err := saved.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"due": "week"`) {
+		t.Errorf("Save() wrote %s, want due filter by name", data)
+	}
+
+	loaded, err := LoadSaved(path)
+	if err != nil {
+		t.Fatalf("LoadSaved() error = %v", err)
+	}
+	if loaded["errands"] != saved["errands"] || loaded["work"] != saved["work"] {
+		t.Errorf("LoadSaved() = %+v, want %+v", loaded, saved)
+	}
+	if names := loaded.Names(); len(names) != 2 || names[0] != "errands" {
+		t.Errorf("Names() = %v, want [errands work]", names)
+	}
+}
+
+func TestLoadSaved_MissingFile(t *testing.T) {
+	saved, err := LoadSaved(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadSaved() error = %v", err)
+	}
+	if len(saved) != 0 {
+		t.Errorf("LoadSaved() = %v, want empty", saved)
+	}
+}
+
+func TestLoadSaved_UnknownDueFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.json")
+	if err := os.WriteFile(path, []byte(`{"x": {"due": "someday"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadSaved(path); err == nil || !strings.Contains(err.Error(), "unknown due filter") {
+		t.Errorf("LoadSaved() error = %v, want unknown due filter", err)
+	}
+}
+
+func TestSaved_Find(t *testing.T) {
+	saved := Saved{"Errands": {FlaggedOnly: true}}
+
+	if state, ok := saved.Find("errands"); !ok || !state.FlaggedOnly {
+		t.Errorf("Find(errands) = %+v, %v, want case-insensitive match", state, ok)
+	}
+	if _, ok := saved.Find("work"); ok {
+		t.Error("Find(work) found a filter, want none")
+	}
+}
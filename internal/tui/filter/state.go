@@ -1,5 +1,13 @@
 package filter
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
 // DueFilter defines due date filtering options
 type DueFilter int
 
@@ -12,13 +20,73 @@ const (
 	DueOverdue
 )
 
+// dueFilterNames are the names of due filters in commands and saved filters
+var dueFilterNames = map[DueFilter]string{
+	DueNone:     "",
+	DueToday:    "today",
+	DueTomorrow: "tomorrow",
+	DueWeek:     "week",
+	DueOverdue:  "overdue",
+}
+
+// String returns the name of the due filter
+func (d DueFilter) String() string {
+	return dueFilterNames[d]
+}
+
+// MarshalText encodes the due filter by name
+func (d DueFilter) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a due filter name
+func (d *DueFilter) UnmarshalText(text []byte) error {
+	name := strings.ToLower(string(text))
+	for filter, filterName := range dueFilterNames {
+		if filterName == name {
+			*d = filter
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown due filter: %s", text)
+}
+
 // State represents the current filter state
 type State struct {
-	SearchText  string
-	ProjectID   string
-	TagID       string
-	DueFilter   DueFilter
-	FlaggedOnly bool
+	SearchText    string    `json:"search,omitempty"`
+	ProjectID     string    `json:"project,omitempty"`
+	TagID         string    `json:"tag,omitempty"`
+	DueFilter     DueFilter `json:"due,omitempty"`
+	FlaggedOnly   bool      `json:"flagged,omitempty"`
+	AvailableOnly bool      `json:"available,omitempty"`  // Hide deferred, blocked and completed tasks
+	MaxMinutes    int       `json:"maxMinutes,omitempty"` // Show tasks estimated to take at most this long, 0 for any
+	LowEnergy     bool      `json:"lowEnergy,omitempty"`  // Show tasks marked low effort
+	NamesOnly     bool      `json:"namesOnly,omitempty"`  // Search task names but not notes
+}
+
+// RegexPrefix starts search text that is a regular expression, as in "re:^call"
+const RegexPrefix = "re:"
+
+// IsRegexp reports whether the search text is a regular expression
+func (s State) IsRegexp() bool {
+	return strings.HasPrefix(s.SearchText, RegexPrefix)
+}
+
+// SearchRegexp returns a case-insensitive pattern finding the search text,
+// or nil without search text. Text after RegexPrefix is compiled as a
+// regular expression; other text is found literally.
+func (s State) SearchRegexp() (*regexp.Regexp, error) {
+	if s.SearchText == "" {
+		return nil, nil
+	}
+	if !s.IsRegexp() {
+		return regexp.MustCompile("(?i)" + regexp.QuoteMeta(s.SearchText)), nil
+	}
+	re, err := regexp.Compile("(?i)" + strings.TrimPrefix(s.SearchText, RegexPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid search pattern: %w", err)
+	}
+	return re, nil
 }
 
 // IsActive returns true if any filter is applied
@@ -27,7 +95,42 @@ func (s State) IsActive() bool {
 		s.ProjectID != "" ||
 		s.TagID != "" ||
 		s.DueFilter != DueNone ||
-		s.FlaggedOnly
+		s.FlaggedOnly ||
+		s.AvailableOnly ||
+		s.MaxMinutes > 0 ||
+		s.LowEnergy
+}
+
+// Describe lists the conditions of the filter, one per line
+func (s State) Describe() []string {
+	var lines []string
+	if s.ProjectID != "" {
+		lines = append(lines, "project: "+s.ProjectID)
+	}
+	if s.TagID != "" {
+		lines = append(lines, "tag: "+s.TagID)
+	}
+	if s.DueFilter != DueNone {
+		lines = append(lines, "due: "+s.DueFilter.String())
+	}
+	if s.FlaggedOnly {
+		lines = append(lines, "flagged only")
+	}
+	if s.AvailableOnly {
+		lines = append(lines, "available only")
+	}
+	if s.MaxMinutes > 0 {
+		lines = append(lines, "time: "+domain.FormatEstimate(s.MaxMinutes)+" or less")
+	}
+	if s.LowEnergy {
+		lines = append(lines, "low effort only")
+	}
+	if s.SearchText != "" && s.NamesOnly {
+		lines = append(lines, fmt.Sprintf("search names: %q", s.SearchText))
+	} else if s.SearchText != "" {
+		lines = append(lines, fmt.Sprintf("search: %q", s.SearchText))
+	}
+	return lines
 }
 
 // Clear returns a State with all filters cleared
@@ -41,6 +144,13 @@ func (s State) WithSearchText(text string) State {
 	return s
 }
 
+// WithNamesOnly returns a State whose search text is looked for in task
+// names only, or also in notes
+func (s State) WithNamesOnly(namesOnly bool) State {
+	s.NamesOnly = namesOnly
+	return s
+}
+
 // WithProject returns a State with the project filter set
 func (s State) WithProject(projectID string) State {
 	s.ProjectID = projectID
@@ -64,3 +174,22 @@ func (s State) WithFlaggedOnly(flagged bool) State {
 	s.FlaggedOnly = flagged
 	return s
 }
+
+// WithMaxMinutes returns a State showing only tasks estimated to take at
+// most minutes, or any task for 0
+func (s State) WithMaxMinutes(minutes int) State {
+	s.MaxMinutes = minutes
+	return s
+}
+
+// WithLowEnergy returns a State showing only tasks marked low effort
+func (s State) WithLowEnergy(low bool) State {
+	s.LowEnergy = low
+	return s
+}
+
+// WithAvailableOnly returns a State with the availability filter set
+func (s State) WithAvailableOnly(available bool) State {
+	s.AvailableOnly = available
+	return s
+}
@@ -1,6 +1,9 @@
 package filter
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestState_IsActive(t *testing.T) {
 	tests := []struct {
@@ -14,6 +17,9 @@ func TestState_IsActive(t *testing.T) {
 		{"with tag", State{TagID: "tag1"}, true},
 		{"with due filter", State{DueFilter: DueToday}, true},
 		{"with flagged only", State{FlaggedOnly: true}, true},
+		{"with available only", State{AvailableOnly: true}, true},
+		{"with max minutes", State{MaxMinutes: 30}, true},
+		{"with low energy", State{LowEnergy: true}, true},
 	}
 
 	for _, tt := range tests {
@@ -47,7 +53,8 @@ func TestState_BuilderMethods(t *testing.T) {
 		WithProject("proj1").
 		WithTag("tag1").
 		WithDueFilter(DueWeek).
-		WithFlaggedOnly(true)
+		WithFlaggedOnly(true).
+		WithAvailableOnly(true)
 
 	if state.SearchText != "search" {
 		t.Errorf("SearchText = %q, want %q", state.SearchText, "search")
@@ -64,4 +71,61 @@ func TestState_BuilderMethods(t *testing.T) {
 	if !state.FlaggedOnly {
 		t.Error("FlaggedOnly = false, want true")
 	}
+	if !state.AvailableOnly {
+		t.Error("AvailableOnly = false, want true")
+	}
+}
+
+func TestState_Describe(t *testing.T) {
+	state := State{TagID: "Work", DueFilter: DueToday, FlaggedOnly: true, SearchText: "report"}
+
+	got := strings.Join(state.Describe(), ", ")
+
+	want := `tag: Work, due: today, flagged only, search: "report"`
+	if got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+	if lines := (State{}).Describe(); len(lines) != 0 {
+		t.Errorf("Describe() of empty state = %v, want none", lines)
+	}
+}
+
+func TestState_SearchRegexp(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  string
+		text    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "literal", search: "a.b", text: "A.B test", want: true},
+		{name: "literal is not a pattern", search: "a.b", text: "axb", want: false},
+		{name: "regex", search: "re:^call (mom|dad)$", text: "Call Mom", want: true},
+		{name: "regex no match", search: "re:^call", text: "Recall", want: false},
+		{name: "invalid regex", search: "re:(call", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := State{SearchText: tt.search}.SearchRegexp()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SearchRegexp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && re.MatchString(tt.text) != tt.want {
+				t.Errorf("SearchRegexp() matches %q = %v, want %v", tt.text, !tt.want, tt.want)
+			}
+		})
+	}
+
+	if re, err := (State{}).SearchRegexp(); re != nil || err != nil {
+		t.Errorf("SearchRegexp() without search text = %v, %v; want nil, nil", re, err)
+	}
+}
+
+func TestState_DescribeNamesOnly(t *testing.T) {
+	state := State{SearchText: "re:^call", NamesOnly: true}
+
+	if got, want := strings.Join(state.Describe(), ", "), `search names: "re:^call"`; got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
 }
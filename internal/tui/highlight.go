@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Highlight styles the text re finds within line[start:end] with match, for
+// a line about to be rendered with base. Each styled match ends by resetting
+// the terminal, so the text around the matches is styled with base as well.
+// Without a match line is returned as is.
+func Highlight(line string, start, end int, re *regexp.Regexp, base, match lipgloss.Style) string {
+	if re == nil || start < 0 || end > len(line) || start >= end {
+		return line
+	}
+
+	var found [][]int
+	for _, loc := range re.FindAllStringIndex(line[start:end], -1) {
+		if loc[0] < loc[1] { // Patterns such as "a*" also match nothing
+			found = append(found, []int{start + loc[0], start + loc[1]})
+		}
+	}
+	if len(found) == 0 {
+		return line
+	}
+
+	// Width, padding and the selection mark are left to the caller rendering
+	// the whole line
+	inline := base.Inline(true).UnsetWidth().UnsetMaxWidth().UnsetString()
+	match = match.Inherit(inline)
+	render := func(b *strings.Builder, style lipgloss.Style, s string) {
+		if s != "" {
+			b.WriteString(style.Render(s))
+		}
+	}
+
+	var b strings.Builder
+	prev := 0
+	for _, loc := range found {
+		render(&b, inline, line[prev:loc[0]])
+		render(&b, match, line[loc[0]:loc[1]])
+		prev = loc[1]
+	}
+	render(&b, inline, line[prev:])
+	return b.String()
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+func TestHighlight(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	base := r.NewStyle().Width(40).PaddingLeft(1).Bold(true)
+	match := r.NewStyle().Underline(true)
+	line := "☐ Call mom about call"
+	start := strings.Index(line, "Call")
+	re := regexp.MustCompile("(?i)call")
+
+	got := Highlight(line, start, len(line), re, base, match)
+
+	if plain := ansi.Strip(got); plain != line {
+		t.Errorf("Highlight() text = %q, want %q", plain, line)
+	}
+	styled := match.Inherit(base.Inline(true).UnsetWidth())
+	if !strings.Contains(got, styled.Render("Call")) || !strings.Contains(got, styled.Render("call")) {
+		t.Errorf("Highlight() = %q, want both matches styled", got)
+	}
+	if rendered := base.Render(got); lipgloss.Width(rendered) != 40 {
+		t.Errorf("rendered width = %d, want 40", lipgloss.Width(rendered))
+	}
+}
+
+func TestHighlight_NoMatch(t *testing.T) {
+	base := lipgloss.NewStyle()
+	line := "☐ Buy milk"
+
+	for _, re := range []*regexp.Regexp{nil, regexp.MustCompile("bread"), regexp.MustCompile("x*")} {
+		if got := Highlight(line, 0, len(line), re, base, base); got != line {
+			t.Errorf("Highlight(%v) = %q, want the line unchanged", re, got)
+		}
+	}
+}
+
+func TestHighlight_OnlyWithinRange(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	line := "☐ Today     📅 Today"
+	end := strings.Index(line, " ")
+
+	if got := Highlight(line, 0, end, regexp.MustCompile("Today"), r.NewStyle(), r.NewStyle().Underline(true)); got != line {
+		t.Errorf("Highlight() = %q, want no match outside the range", got)
+	}
+}
+
+func TestHighlight_KeepsSelectionMarkOnce(t *testing.T) {
+	plain := NewRenderer(io.Discard, false)
+	styles := NewStyles(plain)
+	line := "☐ Call mom"
+
+	got := styles.Task.Selected.Render(Highlight(line, 4, len(line), regexp.MustCompile("mom"), styles.Task.Selected, styles.Task.Match))
+
+	if strings.Count(got, SelectedMark) != 1 {
+		t.Errorf("selected row = %q, want one %q", got, SelectedMark)
+	}
+}
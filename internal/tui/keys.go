@@ -11,19 +11,39 @@ type KeyMap struct {
 	Left  key.Binding
 	Right key.Binding
 
-	// View Switching (1-5)
+	// Paging through long lists
+	PageUp   key.Binding
+	PageDown key.Binding
+
+	// View Switching (1-6)
 	View1 key.Binding
 	View2 key.Binding
 	View3 key.Binding
 	View4 key.Binding
 	View5 key.Binding
+	View6 key.Binding
 
 	// Actions
-	QuickAdd key.Binding
-	Complete key.Binding
-	Edit     key.Binding
-	Delete   key.Binding
-	Flag     key.Binding
+	QuickAdd  key.Binding
+	Complete  key.Binding
+	Resolve   key.Binding // Complete with a closing note
+	Edit      key.Binding
+	Delete    key.Binding
+	Drop      key.Binding // Drop the task, or the project in the projects list
+	Flag      key.Binding
+	Move      key.Binding // Move to another project
+	Due       key.Binding // Pick a new due date
+	Defer     key.Binding // Pick a new defer date
+	Bump      key.Binding // Move the due or defer date a day later
+	Unbump    key.Binding // Move the due or defer date a day earlier
+	Select    key.Binding
+	Undo      key.Binding
+	Filters   key.Binding
+	Pin       key.Binding
+	Sort      key.Binding // Cycle the sort order of the current view
+	Completed key.Binding // Show or hide recently completed tasks
+	Open      key.Binding // Open in OmniFocus
+	Reconcile key.Binding // Retry the change of a conflicted task
 
 	// Global
 	Quit key.Binding
@@ -51,6 +71,15 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("l/→", "move right"),
 		),
 
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+b"),
+			key.WithHelp("pgup/ctrl+b", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+f"),
+			key.WithHelp("pgdown/ctrl+f", "page down"),
+		),
+
 		// View Switching
 		View1: key.NewBinding(
 			key.WithKeys("1"),
@@ -72,6 +101,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("5"),
 			key.WithHelp("5", "review view"),
 		),
+		View6: key.NewBinding(
+			key.WithKeys("6"),
+			key.WithHelp("6", "stats view"),
+		),
 
 		// Actions
 		QuickAdd: key.NewBinding(
@@ -82,6 +115,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("c"),
 			key.WithHelp("c", "complete task"),
 		),
+		Resolve: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "complete with closing note"),
+		),
 		Edit: key.NewBinding(
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit task"),
@@ -90,10 +127,66 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("d"),
 			key.WithHelp("d", "delete task"),
 		),
+		Drop: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "drop task or project"),
+		),
 		Flag: key.NewBinding(
 			key.WithKeys("f"),
 			key.WithHelp("f", "toggle flag"),
 		),
+		Move: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "move task to project"),
+		),
+		Due: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "pick due date"),
+		),
+		Defer: key.NewBinding(
+			key.WithKeys("ctrl+d"),
+			key.WithHelp("ctrl+d", "pick defer date"),
+		),
+		Bump: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "bump date a day later"),
+		),
+		Unbump: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "bump date a day earlier"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark task for bulk action"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo last action"),
+		),
+		Filters: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "saved filters"),
+		),
+		Pin: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "pin/unpin task"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "cycle sort order"),
+		),
+		Completed: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "show/hide completed tasks"),
+		),
+		Open: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open task in OmniFocus"),
+		),
+		Reconcile: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "retry conflicted change"),
+		),
 
 		// Global
 		Quit: key.NewBinding(
@@ -81,6 +81,13 @@ func TestDefaultKeyMap(t *testing.T) {
 			wantHelp:    "5",
 			wantEnabled: true,
 		},
+		{
+			name:        "View6 binding",
+			binding:     km.View6,
+			wantKeys:    []string{"6"},
+			wantHelp:    "6",
+			wantEnabled: true,
+		},
 		// Actions
 		{
 			name:        "QuickAdd binding",
@@ -110,6 +117,13 @@ func TestDefaultKeyMap(t *testing.T) {
 			wantHelp:    "d",
 			wantEnabled: true,
 		},
+		{
+			name:        "Drop binding",
+			binding:     km.Drop,
+			wantKeys:    []string{"x"},
+			wantHelp:    "x",
+			wantEnabled: true,
+		},
 		{
 			name:        "Flag binding",
 			binding:     km.Flag,
@@ -117,6 +131,62 @@ func TestDefaultKeyMap(t *testing.T) {
 			wantHelp:    "f",
 			wantEnabled: true,
 		},
+		{
+			name:        "Move binding",
+			binding:     km.Move,
+			wantKeys:    []string{"m"},
+			wantHelp:    "m",
+			wantEnabled: true,
+		},
+		{
+			name:        "Due binding",
+			binding:     km.Due,
+			wantKeys:    []string{"D"},
+			wantHelp:    "D",
+			wantEnabled: true,
+		},
+		{
+			name:        "Defer binding",
+			binding:     km.Defer,
+			wantKeys:    []string{"ctrl+d"},
+			wantHelp:    "ctrl+d",
+			wantEnabled: true,
+		},
+		{
+			name:        "Bump binding",
+			binding:     km.Bump,
+			wantKeys:    []string{"+", "="},
+			wantHelp:    "+",
+			wantEnabled: true,
+		},
+		{
+			name:        "Unbump binding",
+			binding:     km.Unbump,
+			wantKeys:    []string{"-"},
+			wantHelp:    "-",
+			wantEnabled: true,
+		},
+		{
+			name:        "Completed binding",
+			binding:     km.Completed,
+			wantKeys:    []string{"H"},
+			wantHelp:    "H",
+			wantEnabled: true,
+		},
+		{
+			name:        "Undo binding",
+			binding:     km.Undo,
+			wantKeys:    []string{"u"},
+			wantHelp:    "u",
+			wantEnabled: true,
+		},
+		{
+			name:        "Filters binding",
+			binding:     km.Filters,
+			wantKeys:    []string{"F"},
+			wantHelp:    "F",
+			wantEnabled: true,
+		},
 		// Global
 		{
 			name:        "Quit binding",
@@ -192,13 +262,18 @@ func TestKeyBindingMatches(t *testing.T) {
 		{"View3 with 3", km.View3, "3", true},
 		{"View4 with 4", km.View4, "4", true},
 		{"View5 with 5", km.View5, "5", true},
+		{"View6 with 6", km.View6, "6", true},
 		{"View1 with wrong key", km.View1, "6", false},
 		// Actions
 		{"QuickAdd with a", km.QuickAdd, "a", true},
 		{"Complete with c", km.Complete, "c", true},
 		{"Edit with e", km.Edit, "e", true},
 		{"Delete with d", km.Delete, "d", true},
+		{"Drop with x", km.Drop, "x", true},
 		{"Flag with f", km.Flag, "f", true},
+		{"Undo with u", km.Undo, "u", true},
+		{"Filters with F", km.Filters, "F", true},
+		{"Filters with f", km.Filters, "f", false},
 		{"QuickAdd with wrong key", km.QuickAdd, "b", false},
 		// Global
 		{"Quit with q", km.Quit, "q", true},
@@ -239,11 +314,15 @@ func TestKeyMapStructHasAllFields(t *testing.T) {
 	_ = km.View3
 	_ = km.View4
 	_ = km.View5
+	_ = km.View6
 	_ = km.QuickAdd
 	_ = km.Complete
 	_ = km.Edit
 	_ = km.Delete
+	_ = km.Drop
 	_ = km.Flag
+	_ = km.Undo
+	_ = km.Filters
 	_ = km.Quit
 	_ = km.Help
 
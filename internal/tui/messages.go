@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"errors"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 )
@@ -12,18 +14,26 @@ const (
 	ViewTags     = 3
 	ViewForecast = 4
 	ViewReview   = 5
+	ViewStats    = 6
 )
 
 // Data Loading Messages
 
 // TasksLoadedMsg is sent when tasks are loaded asynchronously
 type TasksLoadedMsg struct {
-	Tasks []domain.Task
+	Tasks   []domain.Task
+	Warning string // Non-fatal notice, e.g. when results were truncated
 }
 
 // ProjectsLoadedMsg is sent when projects are loaded asynchronously
 type ProjectsLoadedMsg struct {
 	Projects []domain.Project
+	Folders  []domain.Folder // Folder tree the projects are listed under
+}
+
+// CompletedTasksLoadedMsg is sent when completed-task history is loaded asynchronously
+type CompletedTasksLoadedMsg struct {
+	Tasks []domain.Task
 }
 
 // TagsLoadedMsg is sent when tags are loaded asynchronously
@@ -44,15 +54,60 @@ type TaskCompletedMsg struct {
 	TaskName string
 }
 
+// TaskUncompletedMsg is sent when a completed task is reopened
+type TaskUncompletedMsg struct {
+	TaskID   string
+	TaskName string
+}
+
+// CompleteClickedMsg is sent when the checkbox of an incomplete task is clicked
+type CompleteClickedMsg struct {
+	Task domain.Task
+}
+
 // TaskDeletedMsg is sent when a task is deleted
 type TaskDeletedMsg struct {
 	TaskID   string
 	TaskName string
+	Snapshot *domain.Task // Task as it was before deletion, used to recreate it on undo
+}
+
+// TaskDroppedMsg is sent when a task is dropped
+type TaskDroppedMsg struct {
+	TaskID   string
+	TaskName string
+}
+
+// ProjectDroppedMsg is sent when a project is dropped
+type ProjectDroppedMsg struct {
+	ProjectID   string
+	ProjectName string
 }
 
 // TaskModifiedMsg is sent when a task is modified
 type TaskModifiedMsg struct {
-	Task domain.Task
+	Task         domain.Task
+	Previous     *domain.Task            // Task as it was before the change, used to revert it on undo
+	Modification domain.TaskModification // Change that was applied
+}
+
+// BatchCompletedMsg is sent when a bulk operation has been applied
+type BatchCompletedMsg struct {
+	Result    domain.BatchResult
+	Operation domain.BatchOperation
+	Tasks     []domain.Task // Tasks as they were before the operation
+}
+
+// TaskReorderedMsg is sent when saving a task's new position has finished
+type TaskReorderedMsg struct {
+	TaskID string
+	Err    error // Set when OmniFocus could not save the new order
+}
+
+// TagSavedMsg is sent when a tag is created or renamed
+type TagSavedMsg struct {
+	Tag     domain.Tag
+	Created bool
 }
 
 // UI Messages
@@ -62,6 +117,16 @@ type ErrorMsg struct {
 	Err error
 }
 
+// Attempts returns how many times the failed operation was tried, which is
+// more than 1 when the bridge retried a transient failure
+func (m ErrorMsg) Attempts() int {
+	var retried interface{ RetryAttempts() int }
+	if errors.As(m.Err, &retried) {
+		return retried.RetryAttempts()
+	}
+	return 1
+}
+
 // ViewChangedMsg is sent when the user switches to a different view
 type ViewChangedMsg struct {
 	View int
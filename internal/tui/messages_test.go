@@ -2,6 +2,7 @@ package tui
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -130,6 +131,23 @@ func TestErrorMsg(t *testing.T) {
 	if msg.Err.Error() != "something went wrong" {
 		t.Errorf("expected error message 'something went wrong', got '%s'", msg.Err.Error())
 	}
+	if got := msg.Attempts(); got != 1 {
+		t.Errorf("Attempts() = %d, want 1", got)
+	}
+}
+
+// retriedError reports the attempts made, like bridge.RetryError
+type retriedError struct{ attempts int }
+
+func (e retriedError) Error() string      { return "timed out" }
+func (e retriedError) RetryAttempts() int { return e.attempts }
+
+func TestErrorMsg_AttemptsOfRetriedError(t *testing.T) {
+	msg := ErrorMsg{Err: fmt.Errorf("failed to get inbox tasks: %w", retriedError{attempts: 3})}
+
+	if got := msg.Attempts(); got != 3 {
+		t.Errorf("Attempts() = %d, want 3", got)
+	}
 }
 
 func TestViewChangedMsg(t *testing.T) {
@@ -143,6 +161,7 @@ func TestViewChangedMsg(t *testing.T) {
 		{"tags view", ViewTags, 3},
 		{"forecast view", ViewForecast, 4},
 		{"review view", ViewReview, 5},
+		{"stats view", ViewStats, 6},
 	}
 
 	for _, tt := range tests {
@@ -172,6 +191,7 @@ func TestViewConstants(t *testing.T) {
 		{"ViewTags", ViewTags, 3},
 		{"ViewForecast", ViewForecast, 4},
 		{"ViewReview", ViewReview, 5},
+		{"ViewStats", ViewStats, 6},
 	}
 
 	for _, tt := range tests {
@@ -184,7 +204,7 @@ func TestViewConstants(t *testing.T) {
 }
 
 func TestViewConstantsAreUnique(t *testing.T) {
-	views := []int{ViewInbox, ViewProjects, ViewTags, ViewForecast, ViewReview}
+	views := []int{ViewInbox, ViewProjects, ViewTags, ViewForecast, ViewReview, ViewStats}
 	seen := make(map[int]bool)
 
 	for _, view := range views {
@@ -194,7 +214,7 @@ func TestViewConstantsAreUnique(t *testing.T) {
 		seen[view] = true
 	}
 
-	if len(seen) != 5 {
-		t.Errorf("expected 5 unique view constants, got %d", len(seen))
+	if len(seen) != 6 {
+		t.Errorf("expected 6 unique view constants, got %d", len(seen))
 	}
 }
@@ -0,0 +1,47 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// IsClick reports whether msg is a left button press
+func IsClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+// WheelDirection returns -1 for wheel up, 1 for wheel down and 0 for any
+// other mouse event
+func WheelDirection(msg tea.MouseMsg) int {
+	if msg.Action != tea.MouseActionPress {
+		return 0
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return -1
+	case tea.MouseButtonWheelDown:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ShiftMouse moves msg up by rows lines, so a component that renders below
+// rows lines of other content sees coordinates relative to its own top line
+func ShiftMouse(msg tea.MouseMsg, rows int) tea.MouseMsg {
+	msg.Y -= rows
+	return msg
+}
+
+// PageCursor moves cursor by one page of the given height in direction,
+// clamped to a list of n rows
+func PageCursor(cursor, n, height, direction int) int {
+	if height < 1 {
+		height = 1
+	}
+	cursor += direction * height
+	if cursor >= n {
+		cursor = n - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	return cursor
+}
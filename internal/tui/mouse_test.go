@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIsClick(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  tea.MouseMsg
+		want bool
+	}{
+		{"left press", tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}, true},
+		{"left release", tea.MouseMsg{Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft}, false},
+		{"right press", tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonRight}, false},
+		{"motion", tea.MouseMsg{Action: tea.MouseActionMotion}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsClick(tt.msg); got != tt.want {
+				t.Errorf("IsClick() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWheelDirection(t *testing.T) {
+	up := tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelUp}
+	down := tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown}
+	click := tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+
+	if got := WheelDirection(up); got != -1 {
+		t.Errorf("WheelDirection(up) = %d, want -1", got)
+	}
+	if got := WheelDirection(down); got != 1 {
+		t.Errorf("WheelDirection(down) = %d, want 1", got)
+	}
+	if got := WheelDirection(click); got != 0 {
+		t.Errorf("WheelDirection(click) = %d, want 0", got)
+	}
+}
+
+func TestShiftMouse(t *testing.T) {
+	msg := ShiftMouse(tea.MouseMsg{X: 4, Y: 5}, 2)
+	if msg.X != 4 || msg.Y != 3 {
+		t.Errorf("ShiftMouse() = (%d, %d), want (4, 3)", msg.X, msg.Y)
+	}
+}
+
+func TestPageCursor(t *testing.T) {
+	tests := []struct {
+		name                         string
+		cursor, n, height, direction int
+		want                         int
+	}{
+		{"page down", 0, 50, 10, 1, 10},
+		{"page up", 25, 50, 10, -1, 15},
+		{"clamps to last", 45, 50, 10, 1, 49},
+		{"clamps to first", 3, 50, 10, -1, 0},
+		{"zero height moves one row", 3, 50, 0, 1, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PageCursor(tt.cursor, tt.n, tt.height, tt.direction); got != tt.want {
+				t.Errorf("PageCursor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -84,6 +84,17 @@ func (c *Compositor) Compose(base, overlay string, dim bool) string {
 	return c.layerContentWithCharacterPrecision(processedBase, centeredOverlay)
 }
 
+// ComposeTopRight layers an overlay in the top-right corner of a base view
+// without dimming it, for unobtrusive content such as notifications.
+func (c *Compositor) ComposeTopRight(base, overlay string) string {
+	if overlay == "" || c.width <= 0 || c.height <= 0 {
+		return base
+	}
+
+	placed := lipgloss.Place(c.width, c.height, lipgloss.Right, lipgloss.Top, overlay)
+	return c.layerContentWithCharacterPrecision(base, placed)
+}
+
 // applyDim applies the backdrop style to make content appear dimmed.
 func (c *Compositor) applyDim(content string) string {
 	return c.backdropStyle.Render(content)
@@ -178,6 +178,34 @@ func TestComposeWithDimming(t *testing.T) {
 	}
 }
 
+// TestComposeTopRightPlacesOverlayInCorner verifies corner placement keeps the rest of the base
+func TestComposeTopRightPlacesOverlayInCorner(t *testing.T) {
+	c := newTestCompositor()
+	c.SetSize(20, 3)
+
+	result := c.ComposeTopRight("Header\nBody\nFooter", "[ok]")
+	lines := strings.Split(result, "\n")
+
+	if len(lines) != 3 {
+		t.Fatalf("ComposeTopRight() returned %d lines, want 3", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "Header") || !strings.HasSuffix(lines[0], "[ok]") {
+		t.Errorf("first line = %q, want base on the left and overlay on the right", lines[0])
+	}
+	if strings.TrimSpace(lines[1]) != "Body" || strings.TrimSpace(lines[2]) != "Footer" {
+		t.Errorf("base lines changed: %q", lines[1:])
+	}
+}
+
+// TestComposeTopRightWithoutSize returns the base unchanged
+func TestComposeTopRightWithoutSize(t *testing.T) {
+	c := newTestCompositor()
+
+	if got := c.ComposeTopRight("base", "toast"); got != "base" {
+		t.Errorf("ComposeTopRight() = %q, want %q", got, "base")
+	}
+}
+
 // TestSetSizeUpdatesCompositor verifies that SetSize updates the compositor dimensions
 func TestSetSizeUpdatesCompositor(t *testing.T) {
 	c := newTestCompositor()
@@ -0,0 +1,23 @@
+package tui
+
+import "github.com/pwojciechowski/lazyfocus/internal/domain"
+
+// PinnedFirst returns tasks with the pinned ones moved to the front, keeping
+// the order within pinned and unpinned tasks
+func PinnedFirst(tasks []domain.Task, pinned map[string]bool) []domain.Task {
+	if len(pinned) == 0 {
+		return tasks
+	}
+	sorted := make([]domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if pinned[task.ID] {
+			sorted = append(sorted, task)
+		}
+	}
+	for _, task := range tasks {
+		if !pinned[task.ID] {
+			sorted = append(sorted, task)
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,25 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func TestPinnedFirst(t *testing.T) {
+	tasks := []domain.Task{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+
+	got := PinnedFirst(tasks, map[string]bool{"c": true, "b": true})
+
+	var ids []string
+	for _, task := range got {
+		ids = append(ids, task.ID)
+	}
+	if want := []string{"b", "c", "a", "d"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("PinnedFirst() = %v, want %v", ids, want)
+	}
+	if tasks[1].ID != "b" || tasks[2].ID != "c" {
+		t.Error("PinnedFirst() should not reorder its input")
+	}
+}
@@ -0,0 +1,31 @@
+package tui
+
+// KeepSelection returns the index in ids of the row to select after a list is
+// reloaded: the row previously selected at cursor in oldIDs if it is still
+// listed, otherwise its nearest neighbor that is, preferring the rows that
+// followed it. Empty IDs are skipped. It reports false when no row of oldIDs
+// is still listed.
+func KeepSelection(oldIDs []string, cursor int, ids []string) (int, bool) {
+	if cursor < 0 || cursor >= len(oldIDs) {
+		return 0, false
+	}
+
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if _, seen := index[id]; id != "" && !seen {
+			index[id] = i
+		}
+	}
+
+	for d := 0; d < len(oldIDs); d++ {
+		for _, i := range []int{cursor + d, cursor - d} {
+			if i < 0 || i >= len(oldIDs) || oldIDs[i] == "" {
+				continue
+			}
+			if j, ok := index[oldIDs[i]]; ok {
+				return j, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,37 @@
+package tui
+
+import "testing"
+
+func TestKeepSelection(t *testing.T) {
+	old := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		name   string
+		cursor int
+		ids    []string
+		want   int
+		wantOK bool
+	}{
+		{name: "moved", cursor: 2, ids: []string{"c", "a", "b", "d"}, want: 0, wantOK: true},
+		{name: "removed selects next", cursor: 1, ids: []string{"a", "c", "d"}, want: 1, wantOK: true},
+		{name: "removed last selects previous", cursor: 3, ids: []string{"a", "b", "c"}, want: 2, wantOK: true},
+		{name: "neighbors removed", cursor: 1, ids: []string{"x", "d"}, want: 1, wantOK: true},
+		{name: "none left", cursor: 1, ids: []string{"x", "y"}, wantOK: false},
+		{name: "cursor out of range", cursor: 4, ids: old, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := KeepSelection(old, tt.cursor, tt.ids)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("KeepSelection() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestKeepSelection_SkipsEmptyIDs(t *testing.T) {
+	got, ok := KeepSelection([]string{"", "a"}, 0, []string{"", "b", "a"})
+	if !ok || got != 2 {
+		t.Errorf("KeepSelection() = %d, %v, want 2, true", got, ok)
+	}
+}
@@ -0,0 +1,57 @@
+// Package session saves the TUI state on quit and restores it on the next
+// start, so the TUI reopens where it was left.
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/views/forecast"
+)
+
+// State is the TUI state kept between runs
+type State struct {
+	View              string                  `json:"view,omitempty"`              // "inbox", "projects", "tags", "forecast", "review" or "stats"
+	SelectedTask      string                  `json:"selectedTask,omitempty"`      // ID of the task under the cursor
+	ForecastCollapsed []forecast.DueGroup     `json:"forecastCollapsed,omitempty"` // Collapsed forecast groups
+	Filter            filter.State            `json:"filter"`
+	Pinned            []string                `json:"pinned,omitempty"` // IDs of tasks pinned to the top of their view
+	Sort              map[string]tui.SortMode `json:"sort,omitempty"`   // Sort mode by view name, for views not in added order
+}
+
+// Load reads the session state from path. A missing file yields an empty state.
+func Load(path string) (State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("failed to read session state: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("failed to parse session state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// Save writes the session state to path, creating its directory if needed
+func (s State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create session state directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write session state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/views/forecast"
+)
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "lazyfocus", "session.json")
+	want := State{
+		View:              "forecast",
+		SelectedTask:      "task1",
+		ForecastCollapsed: []forecast.DueGroup{forecast.GroupOverdue, forecast.GroupNoDue},
+		Filter:            filter.State{FlaggedOnly: true, DueFilter: filter.DueWeek},
+		Pinned:            []string{"task2", "task3"},
+	}
+
+	if err := want.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"no-due"`) || !strings.Contains(string(data), `"due": "week"`) {
+		t.Errorf("Save() wrote %s, want groups and due filter by name", data)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	got, err := Load(filepath.Join(t.TempDir(), "session.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, State{}) {
+		t.Errorf("Load() = %+v, want empty state", got)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(path, []byte(`{"forecastCollapsed": ["someday"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "failed to parse session state") {
+		t.Errorf("Load() error = %v, want parse error", err)
+	}
+}
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+// SortMode is the order a view lists its tasks in
+type SortMode string
+
+// Sort modes; SortAdded keeps the order OmniFocus returns tasks in
+const (
+	SortAdded   SortMode = "added"
+	SortDue     SortMode = "due"
+	SortDefer   SortMode = "defer"
+	SortName    SortMode = "name"
+	SortProject SortMode = "project"
+	SortFlagged SortMode = "flagged"
+)
+
+// SortModes lists the sort modes in the order the sort key cycles through them
+var SortModes = []SortMode{SortAdded, SortDue, SortDefer, SortName, SortProject, SortFlagged}
+
+// ParseSortMode returns the sort mode called name, ignoring case
+func ParseSortMode(name string) (SortMode, error) {
+	mode := SortMode(strings.ToLower(strings.TrimSpace(name)))
+	if !slices.Contains(SortModes, mode) {
+		names := make([]string, len(SortModes))
+		for i, m := range SortModes {
+			names[i] = string(m)
+		}
+		return "", fmt.Errorf("unknown sort %q: use one of %s", name, strings.Join(names, ", "))
+	}
+	return mode, nil
+}
+
+// Next returns the sort mode after m; an empty mode counts as SortAdded
+func (m SortMode) Next() SortMode {
+	i := max(0, slices.Index(SortModes, m))
+	return SortModes[(i+1)%len(SortModes)]
+}
+
+// IsDefault reports whether m keeps the order of OmniFocus
+func (m SortMode) IsDefault() bool {
+	return m == "" || m == SortAdded
+}
+
+// Label returns how m is described in headers and toasts, e.g. "due date"
+func (m SortMode) Label() string {
+	switch m {
+	case SortDue:
+		return "due date"
+	case SortDefer:
+		return "defer date"
+	case SortName:
+		return "name"
+	case SortProject:
+		return "project"
+	case SortFlagged:
+		return "flagged first"
+	default:
+		return "added order"
+	}
+}
+
+// SortTasks returns tasks ordered by mode, keeping the order of tasks that
+// compare equal. Tasks without the date or project sorted on come last.
+// Subtasks are sorted among their siblings; tasks is left untouched.
+func SortTasks(tasks []domain.Task, mode SortMode) []domain.Task {
+	if mode.IsDefault() || len(tasks) == 0 {
+		return tasks
+	}
+	var compare func(a, b domain.Task) int
+	switch mode {
+	case SortDue:
+		compare = func(a, b domain.Task) int { return compareDates(a.DueDate, b.DueDate) }
+	case SortDefer:
+		compare = func(a, b domain.Task) int { return compareDates(a.DeferDate, b.DeferDate) }
+	case SortName:
+		compare = func(a, b domain.Task) int { return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)) }
+	case SortProject:
+		compare = func(a, b domain.Task) int {
+			if (a.ProjectName == "") != (b.ProjectName == "") {
+				return boolOrder(a.ProjectName == "")
+			}
+			return strings.Compare(strings.ToLower(a.ProjectName), strings.ToLower(b.ProjectName))
+		}
+	case SortFlagged:
+		compare = func(a, b domain.Task) int {
+			if a.Flagged == b.Flagged {
+				return 0
+			}
+			return boolOrder(!a.Flagged)
+		}
+	default:
+		return tasks
+	}
+
+	sorted := slices.Clone(tasks)
+	for i := range sorted {
+		sorted[i].Children = SortTasks(sorted[i].Children, mode)
+	}
+	slices.SortStableFunc(sorted, compare)
+	return sorted
+}
+
+// compareDates orders earlier dates first and missing dates last
+func compareDates(a, b *time.Time) int {
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return 0
+		}
+		return boolOrder(a == nil)
+	}
+	return a.Compare(*b)
+}
+
+// boolOrder returns 1 when last is true, putting that task after the other
+func boolOrder(last bool) int {
+	if last {
+		return 1
+	}
+	return -1
+}
+
+// SortHint returns the note views add after their header while sorted by
+// mode, or "" in added order
+func SortHint(styles *Styles, mode SortMode) string {
+	if mode.IsDefault() {
+		return ""
+	}
+	return styles.UI.Help.Render("  sorted by " + mode.Label())
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+)
+
+func sortedIDs(tasks []domain.Task) []string {
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestSortTasks(t *testing.T) {
+	day := func(d int) *time.Time {
+		date := time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC)
+		return &date
+	}
+	tasks := []domain.Task{
+		{ID: "a", Name: "write report", ProjectName: "Work", DeferDate: day(3)},
+		{ID: "b", Name: "Buy milk", DueDate: day(5), Flagged: true},
+		{ID: "c", Name: "call bank", ProjectName: "Finance", DueDate: day(2), DeferDate: day(1)},
+		{ID: "d", Name: "Archive", ProjectName: "work", Flagged: true},
+	}
+
+	tests := []struct {
+		mode SortMode
+		want []string
+	}{
+		{SortAdded, []string{"a", "b", "c", "d"}},
+		{SortDue, []string{"c", "b", "a", "d"}},
+		{SortDefer, []string{"c", "a", "b", "d"}},
+		{SortName, []string{"d", "b", "c", "a"}},
+		{SortProject, []string{"c", "a", "d", "b"}},
+		{SortFlagged, []string{"b", "d", "a", "c"}},
+	}
+	for _, tt := range tests {
+		if got := sortedIDs(SortTasks(tasks, tt.mode)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SortTasks(%s) = %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+	if got := sortedIDs(tasks); !reflect.DeepEqual(got, []string{"a", "b", "c", "d"}) {
+		t.Errorf("SortTasks() reordered its input to %v", got)
+	}
+}
+
+func TestSortTasks_SortsSubtasks(t *testing.T) {
+	tasks := []domain.Task{{ID: "p", Name: "Parent", Children: []domain.Task{{ID: "z", Name: "Zebra"}, {ID: "y", Name: "Yak"}}}}
+
+	got := SortTasks(tasks, SortName)
+
+	if ids := sortedIDs(got[0].Children); !reflect.DeepEqual(ids, []string{"y", "z"}) {
+		t.Errorf("SortTasks() children = %v, want [y z]", ids)
+	}
+	if tasks[0].Children[0].ID != "z" {
+		t.Error("SortTasks() should not reorder the input's children")
+	}
+}
+
+func TestSortMode_Next(t *testing.T) {
+	mode := SortMode("")
+	var seen []SortMode
+	for range SortModes {
+		mode = mode.Next()
+		seen = append(seen, mode)
+	}
+	want := append(SortModes[1:], SortAdded)
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("Next() cycle = %v, want %v", seen, want)
+	}
+}
+
+func TestParseSortMode(t *testing.T) {
+	if mode, err := ParseSortMode(" Due "); err != nil || mode != SortDue {
+		t.Errorf("ParseSortMode(Due) = %q, %v, want due", mode, err)
+	}
+	if _, err := ParseSortMode("priority"); err == nil {
+		t.Error("ParseSortMode(priority) should fail")
+	}
+}
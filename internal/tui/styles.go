@@ -2,6 +2,7 @@ package tui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // ColorStyles defines the color palette for the TUI
@@ -12,14 +13,30 @@ type ColorStyles struct {
 	Warning   lipgloss.AdaptiveColor
 	Error     lipgloss.AdaptiveColor
 	Flagged   lipgloss.AdaptiveColor
+	OnPrimary lipgloss.AdaptiveColor // Text on a Primary background
+	Surface   lipgloss.AdaptiveColor // Overlay background
+	Subtle    lipgloss.AdaptiveColor // Badge background
+
+	// Selected rows, derived from Primary by NewThemeStyles so they stand out
+	// from the terminal background with readable text whatever the theme
+	Selection   lipgloss.AdaptiveColor
+	OnSelection lipgloss.AdaptiveColor
 }
 
 // TaskStyles defines styles for task display
 type TaskStyles struct {
-	Normal    lipgloss.Style
-	Selected  lipgloss.Style
-	Flagged   lipgloss.Style
-	Completed lipgloss.Style
+	Normal            lipgloss.Style
+	Selected          lipgloss.Style
+	SelectedOverdue   lipgloss.Style // Selected task due before today
+	SelectedCompleted lipgloss.Style // Selected completed task
+	Flagged           lipgloss.Style
+	Completed         lipgloss.Style
+	Marked            lipgloss.Style
+	Changed           lipgloss.Style // Rows added or modified by the last reload
+	Removed           lipgloss.Style // Rows dropped by the last reload, until they fade
+	Match             lipgloss.Style // Search matches in task names
+	NextAction        lipgloss.Style // The next action of a sequential project
+	Blocked           lipgloss.Style // Tasks waiting on an earlier task
 }
 
 // UIStyles defines styles for UI elements
@@ -30,6 +47,12 @@ type UIStyles struct {
 	Overlay         lipgloss.Style
 	OverlayBackdrop lipgloss.Style
 	Input           lipgloss.Style
+	Tab             lipgloss.Style
+	ActiveTab       lipgloss.Style
+	Status          lipgloss.Style // Status bar text
+	StatusFilter    lipgloss.Style // Status bar notices, such as active filters
+	OverLimit       lipgloss.Style // Tags and projects past their WIP limit
+	Banner          lipgloss.Style // Line above the status bar describing what does not work
 }
 
 // DueDateStyles defines styles for due date display
@@ -56,6 +79,20 @@ type ForecastStyles struct {
 	GroupHeader lipgloss.Style
 }
 
+// HeatmapStyles defines the color intensity buckets for completion heatmaps,
+// from no activity (index 0) to the busiest days
+type HeatmapStyles struct {
+	Levels []lipgloss.Style
+}
+
+// ToastStyles defines styles for transient notifications by severity
+type ToastStyles struct {
+	Info    lipgloss.Style
+	Success lipgloss.Style
+	Error   lipgloss.Style
+	Warning lipgloss.Style
+}
+
 // SearchStyles defines styles for search highlighting
 type SearchStyles struct {
 	Highlight lipgloss.Style
@@ -74,65 +111,98 @@ type Styles struct {
 	Task     TaskStyles
 	Project  ProjectStyles
 	Forecast ForecastStyles
+	Heatmap  HeatmapStyles
+	Toast    ToastStyles
 	Search   SearchStyles
 	Tag      TagStyles
 	UI       UIStyles
 	DueDate  DueDateStyles
 }
 
-// DefaultStyles returns the default style configuration
+// DefaultStyles returns the style configuration of the default theme,
+// rendered by the default lipgloss renderer
 func DefaultStyles() *Styles {
-	// Define color palette
-	colors := ColorStyles{
-		Primary: lipgloss.AdaptiveColor{
-			Light: "#5B9BD5", // Blue
-			Dark:  "#7FB3D5",
-		},
-		Secondary: lipgloss.AdaptiveColor{
-			Light: "#808080", // Gray
-			Dark:  "#A0A0A0",
-		},
-		Success: lipgloss.AdaptiveColor{
-			Light: "#70AD47", // Green
-			Dark:  "#90CD67",
-		},
-		Warning: lipgloss.AdaptiveColor{
-			Light: "#FFC000", // Orange/Yellow
-			Dark:  "#FFD666",
-		},
-		Error: lipgloss.AdaptiveColor{
-			Light: "#C00000", // Red
-			Dark:  "#FF6B6B",
-		},
-		Flagged: lipgloss.AdaptiveColor{
-			Light: "#ED7D31", // Orange/Red
-			Dark:  "#FF9F66",
-		},
-	}
+	return NewStyles(lipgloss.DefaultRenderer())
+}
+
+// NewStyles returns the style configuration of the default theme rendered by
+// r. When r renders no colors, the selected row is marked with SelectedMark
+// instead.
+func NewStyles(r *lipgloss.Renderer) *Styles {
+	return NewThemeStyles(r, defaultTheme)
+}
+
+// NewThemeStyles returns the style configuration of theme rendered by r
+func NewThemeStyles(r *lipgloss.Renderer, theme Theme) *Styles {
+	colors := theme.Colors
+	selection := newSelectionPalette(colors)
+	colors.Selection = selection.background
+	colors.OnSelection = selection.foreground
 
 	// Task styles
 	taskStyles := TaskStyles{
-		Normal: lipgloss.NewStyle().
+		Normal: r.NewStyle().
 			Width(80).
 			PaddingLeft(1),
-		Selected: lipgloss.NewStyle().
+		Selected: r.NewStyle().
+			Width(80).
+			PaddingLeft(1).
+			Background(colors.Selection).
+			Foreground(colors.OnSelection).
+			Bold(true),
+		SelectedOverdue: r.NewStyle().
 			Width(80).
 			PaddingLeft(1).
-			Background(colors.Primary).
-			Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"}).
+			Background(selection.overdueBackground).
+			Foreground(selection.overdueForeground).
 			Bold(true),
-		Flagged: lipgloss.NewStyle().
+		SelectedCompleted: r.NewStyle().
+			Width(80).
+			PaddingLeft(1).
+			Background(colors.Selection).
+			Foreground(selection.completedForeground).
+			Strikethrough(true),
+		Flagged: r.NewStyle().
 			Foreground(colors.Flagged).
 			Bold(true),
-		Completed: lipgloss.NewStyle().
+		Completed: r.NewStyle().
 			Foreground(colors.Secondary).
 			Faint(true).
 			Strikethrough(true),
+		Marked: r.NewStyle().
+			Width(80).
+			PaddingLeft(1).
+			Foreground(colors.Primary).
+			Bold(true),
+		Changed: r.NewStyle().
+			Width(80).
+			PaddingLeft(1).
+			Foreground(colors.Success).
+			Bold(true),
+		Removed: r.NewStyle().
+			Width(80).
+			PaddingLeft(1).
+			Foreground(colors.Error).
+			Faint(true).
+			Strikethrough(true),
+		Match: r.NewStyle().
+			Reverse(true).
+			Bold(true),
+		NextAction: r.NewStyle().
+			Width(80).
+			PaddingLeft(1).
+			Foreground(colors.Primary).
+			Bold(true),
+		Blocked: r.NewStyle().
+			Width(80).
+			PaddingLeft(1).
+			Foreground(colors.Secondary).
+			Faint(true),
 	}
 
 	// UI styles
 	uiStyles := UIStyles{
-		Header: lipgloss.NewStyle().
+		Header: r.NewStyle().
 			Bold(true).
 			Foreground(colors.Primary).
 			BorderStyle(lipgloss.NormalBorder()).
@@ -140,7 +210,7 @@ func DefaultStyles() *Styles {
 			BorderForeground(colors.Secondary).
 			PaddingLeft(1).
 			PaddingRight(1),
-		Footer: lipgloss.NewStyle().
+		Footer: r.NewStyle().
 			Height(1).
 			Foreground(colors.Secondary).
 			BorderStyle(lipgloss.NormalBorder()).
@@ -148,46 +218,64 @@ func DefaultStyles() *Styles {
 			BorderForeground(colors.Secondary).
 			PaddingLeft(1).
 			PaddingRight(1),
-		Help: lipgloss.NewStyle().
+		Help: r.NewStyle().
 			Foreground(colors.Secondary).
 			Faint(true),
-		Overlay: lipgloss.NewStyle().
-			Background(lipgloss.AdaptiveColor{Light: "#F0F0F0", Dark: "#2A2A2A"}).
+		Overlay: r.NewStyle().
+			Background(colors.Surface).
 			BorderStyle(lipgloss.RoundedBorder()).
 			BorderForeground(colors.Primary).
 			Padding(1, 2),
-		OverlayBackdrop: lipgloss.NewStyle().
+		OverlayBackdrop: r.NewStyle().
 			Faint(true),
-		Input: lipgloss.NewStyle().
+		Input: r.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
 			BorderForeground(colors.Primary).
 			Padding(0, 1),
+		Tab: r.NewStyle().
+			Foreground(colors.Secondary).
+			Padding(0, 1),
+		ActiveTab: r.NewStyle().
+			Background(colors.Selection).
+			Foreground(colors.OnSelection).
+			Bold(true).
+			Padding(0, 1),
+		Status: r.NewStyle().
+			Foreground(colors.Secondary),
+		StatusFilter: r.NewStyle().
+			Foreground(colors.Warning).
+			Bold(true),
+		OverLimit: r.NewStyle().
+			Foreground(colors.Warning).
+			Bold(true),
+		Banner: r.NewStyle().
+			Foreground(colors.Warning),
 	}
 
 	// Due date styles
 	dueDateStyles := DueDateStyles{
-		Today: lipgloss.NewStyle().
+		Today: r.NewStyle().
 			Foreground(colors.Warning).
 			Bold(true),
-		Overdue: lipgloss.NewStyle().
+		Overdue: r.NewStyle().
 			Foreground(colors.Error).
 			Bold(true),
-		Normal: lipgloss.NewStyle().
+		Normal: r.NewStyle().
 			Foreground(colors.Secondary),
 	}
 
 	// Project styles
 	projectStyles := ProjectStyles{
-		Active: lipgloss.NewStyle().
+		Active: r.NewStyle().
 			Foreground(colors.Primary),
-		OnHold: lipgloss.NewStyle().
+		OnHold: r.NewStyle().
 			Foreground(colors.Secondary).
 			Faint(true),
-		Completed: lipgloss.NewStyle().
+		Completed: r.NewStyle().
 			Foreground(colors.Secondary).
 			Faint(true).
 			Strikethrough(true),
-		Dropped: lipgloss.NewStyle().
+		Dropped: r.NewStyle().
 			Foreground(colors.Error).
 			Faint(true).
 			Strikethrough(true),
@@ -195,29 +283,46 @@ func DefaultStyles() *Styles {
 
 	// Forecast styles
 	forecastStyles := ForecastStyles{
-		Overdue: lipgloss.NewStyle().
+		Overdue: r.NewStyle().
 			Foreground(colors.Error).
 			Bold(true),
-		Today: lipgloss.NewStyle().
+		Today: r.NewStyle().
 			Foreground(colors.Warning).
 			Bold(true),
-		Tomorrow: lipgloss.NewStyle().
+		Tomorrow: r.NewStyle().
 			Foreground(colors.Primary),
-		Later: lipgloss.NewStyle().
+		Later: r.NewStyle().
 			Foreground(colors.Secondary).
 			Faint(true),
-		GroupHeader: lipgloss.NewStyle().
+		GroupHeader: r.NewStyle().
 			Bold(true).
 			Underline(true).
 			Foreground(colors.Primary),
 	}
 
+	// Heatmap styles
+	heatmapStyles := HeatmapStyles{}
+	for _, level := range theme.Heatmap {
+		heatmapStyles.Levels = append(heatmapStyles.Levels, r.NewStyle().Foreground(level))
+	}
+
+	// Toast styles
+	toastBase := r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1)
+	toastStyles := ToastStyles{
+		Info:    toastBase.BorderForeground(colors.Primary).Foreground(colors.Primary),
+		Success: toastBase.BorderForeground(colors.Success).Foreground(colors.Success),
+		Error:   toastBase.BorderForeground(colors.Error).Foreground(colors.Error),
+		Warning: toastBase.BorderForeground(colors.Warning).Foreground(colors.Warning),
+	}
+
 	// Search styles
 	searchStyles := SearchStyles{
-		Highlight: lipgloss.NewStyle().
+		Highlight: r.NewStyle().
 			Background(colors.Warning).
 			Foreground(lipgloss.AdaptiveColor{Light: "#000000", Dark: "#000000"}),
-		Input: lipgloss.NewStyle().
+		Input: r.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
 			BorderForeground(colors.Primary).
 			Padding(0, 1),
@@ -225,26 +330,35 @@ func DefaultStyles() *Styles {
 
 	// Tag styles
 	tagStyles := TagStyles{
-		Badge: lipgloss.NewStyle().
+		Badge: r.NewStyle().
 			Foreground(colors.Secondary).
-			Background(lipgloss.AdaptiveColor{Light: "#E8E8E8", Dark: "#3A3A3A"}).
+			Background(colors.Subtle).
 			Padding(0, 1).
 			BorderStyle(lipgloss.RoundedBorder()).
 			BorderForeground(colors.Secondary),
-		Selected: lipgloss.NewStyle().
-			Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"}).
-			Background(colors.Primary).
+		Selected: r.NewStyle().
+			Foreground(colors.OnSelection).
+			Background(colors.Selection).
 			Padding(0, 1).
 			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(colors.Primary).
+			BorderForeground(colors.Selection).
 			Bold(true),
 	}
 
+	if r.ColorProfile() == termenv.Ascii {
+		taskStyles.Selected = taskStyles.Selected.PaddingLeft(0).SetString(SelectedMark)
+		taskStyles.SelectedOverdue = taskStyles.SelectedOverdue.PaddingLeft(0).SetString(SelectedMark)
+		taskStyles.SelectedCompleted = taskStyles.SelectedCompleted.PaddingLeft(0).SetString(SelectedMark)
+		uiStyles.ActiveTab = uiStyles.ActiveTab.Padding(0).SetString(SelectedMark)
+	}
+
 	return &Styles{
 		Colors:   colors,
 		Task:     taskStyles,
 		Project:  projectStyles,
 		Forecast: forecastStyles,
+		Heatmap:  heatmapStyles,
+		Toast:    toastStyles,
 		Search:   searchStyles,
 		Tag:      tagStyles,
 		UI:       uiStyles,
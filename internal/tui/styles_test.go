@@ -110,6 +110,12 @@ func TestTaskStyles(t *testing.T) {
 			t.Error("TaskCompleted should have strikethrough")
 		}
 	})
+
+	t.Run("TaskRemoved is struck through", func(t *testing.T) {
+		if !styles.Task.Removed.GetStrikethrough() {
+			t.Error("TaskRemoved should have strikethrough")
+		}
+	})
 }
 
 func TestUIStyles(t *testing.T) {
@@ -324,6 +330,36 @@ func TestForecastStyles(t *testing.T) {
 	})
 }
 
+func TestHeatmapStyles(t *testing.T) {
+	styles := DefaultStyles()
+
+	if len(styles.Heatmap.Levels) != 5 {
+		t.Fatalf("Heatmap.Levels has %d entries, want 5", len(styles.Heatmap.Levels))
+	}
+	for i, level := range styles.Heatmap.Levels {
+		if level.GetForeground() == nil {
+			t.Errorf("Heatmap.Levels[%d] foreground not set", i)
+		}
+	}
+}
+
+func TestToastStyles(t *testing.T) {
+	styles := DefaultStyles()
+
+	for name, style := range map[string]lipgloss.Style{
+		"Info":    styles.Toast.Info,
+		"Success": styles.Toast.Success,
+		"Error":   styles.Toast.Error,
+	} {
+		if style.GetForeground() == nil {
+			t.Errorf("Toast.%s foreground not set", name)
+		}
+		if !style.GetBorderTop() {
+			t.Errorf("Toast.%s should have a border", name)
+		}
+	}
+}
+
 func TestSearchStyles(t *testing.T) {
 	styles := DefaultStyles()
 
@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Names of the built-in themes
+const (
+	ThemeDefault      = "default"
+	ThemeSolarized    = "solarized"
+	ThemeDracula      = "dracula"
+	ThemeHighContrast = "high-contrast"
+)
+
+// Theme is a color scheme for the TUI. Every color has a value for light and
+// one for dark terminals; lipgloss picks between them from the background
+// termenv detects, unless SetBackground fixed it.
+type Theme struct {
+	Name    string
+	Colors  ColorStyles
+	Heatmap []lipgloss.AdaptiveColor // Completion heatmap, from no activity to the busiest days
+}
+
+// ThemeColors replace some colors of a theme; each is a "#RRGGBB" hex color
+// or an ANSI color number, and empty keeps the theme's color
+type ThemeColors struct {
+	Primary   string
+	Secondary string
+	Success   string
+	Warning   string
+	Error     string
+	Flagged   string
+}
+
+// themes are the built-in themes by name
+var themes = map[string]Theme{
+	ThemeDefault: {
+		Name: ThemeDefault,
+		Colors: ColorStyles{
+			Primary:   lipgloss.AdaptiveColor{Light: "#5B9BD5", Dark: "#7FB3D5"}, // Blue
+			Secondary: lipgloss.AdaptiveColor{Light: "#808080", Dark: "#A0A0A0"}, // Gray
+			Success:   lipgloss.AdaptiveColor{Light: "#70AD47", Dark: "#90CD67"}, // Green
+			Warning:   lipgloss.AdaptiveColor{Light: "#FFC000", Dark: "#FFD666"}, // Orange/Yellow
+			Error:     lipgloss.AdaptiveColor{Light: "#C00000", Dark: "#FF6B6B"}, // Red
+			Flagged:   lipgloss.AdaptiveColor{Light: "#ED7D31", Dark: "#FF9F66"}, // Orange/Red
+			OnPrimary: lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+			Surface:   lipgloss.AdaptiveColor{Light: "#F0F0F0", Dark: "#2A2A2A"},
+			Subtle:    lipgloss.AdaptiveColor{Light: "#E8E8E8", Dark: "#3A3A3A"},
+		},
+		Heatmap: []lipgloss.AdaptiveColor{
+			{Light: "#EBEDF0", Dark: "#2D333B"},
+			{Light: "#9BE9A8", Dark: "#0E4429"},
+			{Light: "#40C463", Dark: "#006D32"},
+			{Light: "#30A14E", Dark: "#26A641"},
+			{Light: "#216E39", Dark: "#39D353"},
+		},
+	},
+	ThemeSolarized: {
+		Name: ThemeSolarized,
+		Colors: ColorStyles{
+			Primary:   lipgloss.AdaptiveColor{Light: "#268BD2", Dark: "#268BD2"}, // Blue
+			Secondary: lipgloss.AdaptiveColor{Light: "#93A1A1", Dark: "#586E75"}, // base1, base01
+			Success:   lipgloss.AdaptiveColor{Light: "#859900", Dark: "#859900"}, // Green
+			Warning:   lipgloss.AdaptiveColor{Light: "#B58900", Dark: "#B58900"}, // Yellow
+			Error:     lipgloss.AdaptiveColor{Light: "#DC322F", Dark: "#DC322F"}, // Red
+			Flagged:   lipgloss.AdaptiveColor{Light: "#CB4B16", Dark: "#CB4B16"}, // Orange
+			OnPrimary: lipgloss.AdaptiveColor{Light: "#FDF6E3", Dark: "#002B36"}, // base3, base03
+			Surface:   lipgloss.AdaptiveColor{Light: "#EEE8D5", Dark: "#073642"}, // base2, base02
+			Subtle:    lipgloss.AdaptiveColor{Light: "#EEE8D5", Dark: "#073642"},
+		},
+		Heatmap: []lipgloss.AdaptiveColor{
+			{Light: "#EEE8D5", Dark: "#073642"},
+			{Light: "#D3DA99", Dark: "#3B4A10"},
+			{Light: "#B5C14D", Dark: "#5B6E0A"},
+			{Light: "#98A81A", Dark: "#758A05"},
+			{Light: "#6B7A00", Dark: "#859900"},
+		},
+	},
+	ThemeDracula: {
+		Name: ThemeDracula,
+		Colors: ColorStyles{
+			Primary:   lipgloss.AdaptiveColor{Light: "#644AC9", Dark: "#BD93F9"}, // Purple
+			Secondary: lipgloss.AdaptiveColor{Light: "#6C664B", Dark: "#6272A4"}, // Comment
+			Success:   lipgloss.AdaptiveColor{Light: "#14710A", Dark: "#50FA7B"}, // Green
+			Warning:   lipgloss.AdaptiveColor{Light: "#846E15", Dark: "#F1FA8C"}, // Yellow
+			Error:     lipgloss.AdaptiveColor{Light: "#CB3A2A", Dark: "#FF5555"}, // Red
+			Flagged:   lipgloss.AdaptiveColor{Light: "#A34D14", Dark: "#FFB86C"}, // Orange
+			OnPrimary: lipgloss.AdaptiveColor{Light: "#FFFBEB", Dark: "#282A36"}, // Background
+			Surface:   lipgloss.AdaptiveColor{Light: "#CFCFDE", Dark: "#44475A"}, // Current line
+			Subtle:    lipgloss.AdaptiveColor{Light: "#CFCFDE", Dark: "#44475A"},
+		},
+		Heatmap: []lipgloss.AdaptiveColor{
+			{Light: "#CFCFDE", Dark: "#44475A"},
+			{Light: "#B9A8EE", Dark: "#5E4F8C"},
+			{Light: "#9378DE", Dark: "#7E65BE"},
+			{Light: "#7A5ED3", Dark: "#9E7CDD"},
+			{Light: "#644AC9", Dark: "#BD93F9"},
+		},
+	},
+	ThemeHighContrast: {
+		Name: ThemeHighContrast,
+		Colors: ColorStyles{
+			Primary:   lipgloss.AdaptiveColor{Light: "#0000CC", Dark: "#00FFFF"},
+			Secondary: lipgloss.AdaptiveColor{Light: "#333333", Dark: "#DDDDDD"},
+			Success:   lipgloss.AdaptiveColor{Light: "#006600", Dark: "#00FF00"},
+			Warning:   lipgloss.AdaptiveColor{Light: "#7A4F00", Dark: "#FFFF00"},
+			Error:     lipgloss.AdaptiveColor{Light: "#CC0000", Dark: "#FF5555"},
+			Flagged:   lipgloss.AdaptiveColor{Light: "#A33D00", Dark: "#FFA500"},
+			OnPrimary: lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+			Surface:   lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+			Subtle:    lipgloss.AdaptiveColor{Light: "#DDDDDD", Dark: "#333333"},
+		},
+		Heatmap: []lipgloss.AdaptiveColor{
+			{Light: "#EEEEEE", Dark: "#222222"},
+			{Light: "#99CC99", Dark: "#005500"},
+			{Light: "#33AA33", Dark: "#008800"},
+			{Light: "#117711", Dark: "#00CC00"},
+			{Light: "#004400", Dark: "#00FF00"},
+		},
+	},
+}
+
+// defaultTheme is the theme DefaultStyles uses
+var defaultTheme = themes[ThemeDefault]
+
+// ThemeNames returns the names of the built-in themes, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// LookupTheme returns the built-in theme called name, ignoring case. An
+// empty name is the default theme.
+func LookupTheme(name string) (Theme, error) {
+	if name == "" {
+		name = ThemeDefault
+	}
+	theme, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q: use one of %s or a theme defined under tui.themes", name, strings.Join(ThemeNames(), ", "))
+	}
+	return theme, nil
+}
+
+// Override returns the theme with colors replaced by light on light
+// terminals and by dark on dark ones
+func (t Theme) Override(light, dark ThemeColors) Theme {
+	c := &t.Colors
+	for _, o := range []struct {
+		color       *lipgloss.AdaptiveColor
+		light, dark string
+	}{
+		{&c.Primary, light.Primary, dark.Primary},
+		{&c.Secondary, light.Secondary, dark.Secondary},
+		{&c.Success, light.Success, dark.Success},
+		{&c.Warning, light.Warning, dark.Warning},
+		{&c.Error, light.Error, dark.Error},
+		{&c.Flagged, light.Flagged, dark.Flagged},
+	} {
+		if o.light != "" {
+			o.color.Light = o.light
+		}
+		if o.dark != "" {
+			o.color.Dark = o.dark
+		}
+	}
+	return t
+}
+
+// SetDefaultTheme makes DefaultStyles use theme, as chosen in the config file
+func SetDefaultTheme(theme Theme) {
+	defaultTheme = theme
+}
+
+// Background modes of SetBackground
+const (
+	BackgroundAuto  = "auto"
+	BackgroundLight = "light"
+	BackgroundDark  = "dark"
+)
+
+// SetBackground fixes whether r renders theme colors for a light or a dark
+// terminal. BackgroundAuto, or an empty mode, leaves r to ask the terminal
+// through termenv, which falls back to dark when the terminal does not say.
+func SetBackground(r *lipgloss.Renderer, mode string) error {
+	switch strings.ToLower(mode) {
+	case BackgroundAuto, "":
+	case BackgroundLight:
+		r.SetHasDarkBackground(false)
+	case BackgroundDark:
+		r.SetHasDarkBackground(true)
+	default:
+		return fmt.Errorf("invalid background %q: use auto, light or dark", mode)
+	}
+	return nil
+}
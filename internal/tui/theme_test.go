@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestLookupTheme(t *testing.T) {
+	for _, name := range ThemeNames() {
+		theme, err := LookupTheme(name)
+		if err != nil {
+			t.Fatalf("LookupTheme(%q) error = %v", name, err)
+		}
+		if theme.Name != name {
+			t.Errorf("LookupTheme(%q).Name = %q", name, theme.Name)
+		}
+		if len(theme.Heatmap) != 5 {
+			t.Errorf("LookupTheme(%q) has %d heatmap levels, want 5", name, len(theme.Heatmap))
+		}
+	}
+
+	if theme, err := LookupTheme(""); err != nil || theme.Name != ThemeDefault {
+		t.Errorf("LookupTheme(\"\") = %q, %v, want the default theme", theme.Name, err)
+	}
+	if theme, err := LookupTheme("Dracula"); err != nil || theme.Name != ThemeDracula {
+		t.Errorf("LookupTheme(\"Dracula\") = %q, %v, want dracula", theme.Name, err)
+	}
+	if _, err := LookupTheme("neon"); err == nil {
+		t.Error("LookupTheme(\"neon\") error = nil, want an error")
+	}
+}
+
+func TestThemeOverride(t *testing.T) {
+	base, _ := LookupTheme(ThemeDefault)
+
+	theme := base.Override(ThemeColors{Primary: "#111111"}, ThemeColors{Primary: "#222222", Error: "9"})
+
+	if want := (lipgloss.AdaptiveColor{Light: "#111111", Dark: "#222222"}); theme.Colors.Primary != want {
+		t.Errorf("Primary = %+v, want %+v", theme.Colors.Primary, want)
+	}
+	if want := (lipgloss.AdaptiveColor{Light: base.Colors.Error.Light, Dark: "9"}); theme.Colors.Error != want {
+		t.Errorf("Error = %+v, want %+v", theme.Colors.Error, want)
+	}
+	if theme.Colors.Success != base.Colors.Success {
+		t.Errorf("Success = %+v, want it unchanged", theme.Colors.Success)
+	}
+	if base.Colors.Primary.Light == "#111111" {
+		t.Error("Override() changed the base theme")
+	}
+}
+
+func TestSetDefaultTheme(t *testing.T) {
+	t.Cleanup(func() { SetDefaultTheme(themes[ThemeDefault]) })
+
+	dracula, _ := LookupTheme(ThemeDracula)
+	SetDefaultTheme(dracula)
+
+	if got := DefaultStyles().Colors.Primary; got != dracula.Colors.Primary {
+		t.Errorf("DefaultStyles().Colors.Primary = %+v, want dracula's %+v", got, dracula.Colors.Primary)
+	}
+}
+
+func TestSetBackground(t *testing.T) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.TrueColor)
+	theme, _ := LookupTheme(ThemeDefault)
+	styles := NewThemeStyles(r, theme)
+
+	if err := SetBackground(r, BackgroundLight); err != nil {
+		t.Fatalf("SetBackground(light) error = %v", err)
+	}
+	light := styles.Task.Selected.Render("x")
+	if err := SetBackground(r, BackgroundDark); err != nil {
+		t.Fatalf("SetBackground(dark) error = %v", err)
+	}
+	dark := styles.Task.Selected.Render("x")
+	if light == dark {
+		t.Error("selected row renders the same on light and dark backgrounds")
+	}
+
+	if err := SetBackground(r, BackgroundAuto); err != nil {
+		t.Errorf("SetBackground(auto) error = %v", err)
+	}
+	if !r.HasDarkBackground() {
+		t.Error("SetBackground(auto) should leave the detected background alone")
+	}
+	if err := SetBackground(r, "sepia"); err == nil {
+		t.Error("SetBackground(sepia) error = nil, want an error")
+	}
+}
@@ -2,7 +2,11 @@
 package forecast
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,6 +16,7 @@ import (
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/tasklist"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
 )
 
@@ -26,8 +31,46 @@ const (
 	GroupThisWeek
 	GroupLater
 	GroupNoDue
+	GroupPinned // Pinned tasks, listed before all other groups
 )
 
+// dueGroupNames are the names of due groups in saved session state
+var dueGroupNames = map[DueGroup]string{
+	GroupOverdue:  "overdue",
+	GroupToday:    "today",
+	GroupTomorrow: "tomorrow",
+	GroupThisWeek: "this-week",
+	GroupLater:    "later",
+	GroupNoDue:    "no-due",
+	GroupPinned:   "pinned",
+}
+
+// MarshalText encodes the due group by name
+func (g DueGroup) MarshalText() ([]byte, error) {
+	name, ok := dueGroupNames[g]
+	if !ok {
+		return nil, fmt.Errorf("unknown due group: %d", g)
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes a due group name
+func (g *DueGroup) UnmarshalText(text []byte) error {
+	for group, name := range dueGroupNames {
+		if name == string(text) {
+			*g = group
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown due group: %s", text)
+}
+
+// StripDays is the number of days in the calendar strip, starting today
+const StripDays = 7
+
+// noDay means no calendar strip day is selected and all groups are shown
+const noDay = -1
+
 // GroupedTask wraps a task with its group info
 type GroupedTask struct {
 	Task     domain.Task
@@ -43,12 +86,25 @@ type Model struct {
 	styles    *tui.Styles
 	keys      tui.KeyMap
 	filter    filter.State
+	highlight *regexp.Regexp // Search matches to highlight in task names
+	sort      tui.SortMode   // Order of tasks within each group
+	labels    Labels         // Group names, group order and the task count text
 	width     int
 	height    int
 	err       error
 	loaded    bool
 	collapsed map[DueGroup]bool // Track collapsed groups
+	marked    map[string]bool   // Task IDs marked for bulk actions
+	pinned    map[string]bool   // Task IDs listed in the Pinned group
+	conflicts map[string]bool   // Task IDs whose change OmniFocus did not take
+	showPath  bool              // Show each task's folder and project path after its name
 	allTasks  []domain.Task     // Store all tasks for filtering
+	index     *filter.Index     // Lowercased task text for searching
+	warning   string            // Non-fatal load warning (e.g. truncated results)
+	day       int               // Selected calendar strip day as an offset from today, or noDay
+	now       func() time.Time
+
+	selectID string // Task to select once tasks are loaded
 }
 
 // New creates a new forecast view
@@ -60,7 +116,10 @@ func New(styles *tui.Styles, keys tui.KeyMap, svc service.OmniFocusService) Mode
 		styles:    styles,
 		keys:      keys,
 		collapsed: make(map[DueGroup]bool),
+		marked:    make(map[string]bool),
 		loaded:    false,
+		day:       noDay,
+		now:       time.Now,
 	}
 }
 
@@ -71,7 +130,11 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) loadTasks() tea.Cmd {
 	return func() tea.Msg {
-		tasks, err := m.service.GetAllTasks(service.TaskFilters{})
+		tasks, err := m.service.GetAllTasks(context.Background(), service.TaskFilters{})
+		var truncated *service.TruncatedError
+		if errors.As(err, &truncated) {
+			return tui.TasksLoadedMsg{Tasks: tasks, Warning: truncated.Error()}
+		}
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
@@ -85,13 +148,22 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case tui.TasksLoadedMsg:
 		// Store all tasks and apply filter
 		m.allTasks = msg.Tasks
-		filteredTasks := m.applyFilter(msg.Tasks)
-		m.items = m.groupTasks(filteredTasks)
+		m.index = filter.NewIndex(msg.Tasks)
+		m.warning = msg.Warning
+		m.pruneMarks()
+		oldKeys := itemKeys(m.items)
+		m.items = m.buildItems(m.applyFilter(msg.Tasks))
+		// Keep the selected row on reload, otherwise start at the first task
+		if i, ok := tui.KeepSelection(oldKeys, m.cursor, itemKeys(m.items)); m.loaded && ok {
+			m.cursor = i
+		} else {
+			m.resetCursor()
+		}
 		m.loaded = true
 		m.err = nil
-		// Move cursor to first task (skip header)
-		if len(m.items) > 0 && m.items[0].IsHeader && len(m.items) > 1 {
-			m.cursor = 1
+		if m.selectID != "" {
+			m = m.selectTaskID(m.selectID)
+			m.selectID = ""
 		}
 		return m, nil
 
@@ -106,12 +178,28 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
+
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	}
 
 	return m, nil
 }
 
 func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
+	// Move between calendar strip days; left of today goes back to all groups
+	if key.Matches(msg, m.keys.Right) && m.day < StripDays-1 {
+		return m.selectDay(m.day + 1), nil
+	}
+	if key.Matches(msg, m.keys.Left) && m.day > noDay {
+		return m.selectDay(m.day - 1), nil
+	}
+
+	// Escape leaves the selected day when there are no marks to clear
+	if msg.Type == tea.KeyEsc && len(m.marked) == 0 && m.day != noDay {
+		return m.selectDay(noDay), nil
+	}
+
 	if len(m.items) == 0 {
 		return m, nil
 	}
@@ -126,19 +214,117 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Toggle bulk-action mark on the current task and advance
+	if key.Matches(msg, m.keys.Select) {
+		if task := m.SelectedTask(); task != nil {
+			if m.marked[task.ID] {
+				delete(m.marked, task.ID)
+			} else {
+				m.marked[task.ID] = true
+			}
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+		}
+		return m, nil
+	}
+
+	// Escape clears all marks
+	if msg.Type == tea.KeyEsc && len(m.marked) > 0 {
+		return m.ClearMarks(), nil
+	}
+
 	// Toggle group collapse on Enter when on header
 	if key.Matches(msg, enterKey) {
 		if m.cursor < len(m.items) && m.items[m.cursor].IsHeader {
-			group := m.items[m.cursor].Group
-			m.collapsed[group] = !m.collapsed[group]
-			m.items = m.rebuildItems()
+			return m.toggleGroup(m.items[m.cursor].Group), nil
+		}
+	}
+
+	return m, nil
+}
+
+// toggleGroup collapses or expands a due group
+func (m Model) toggleGroup(group DueGroup) Model {
+	m.collapsed[group] = !m.collapsed[group]
+	m.items = m.rebuildItems()
+	return m
+}
+
+// handleMouse selects the clicked task, toggles clicked group headers,
+// selects clicked calendar strip days and pages with the scroll wheel
+func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if m.err != nil || !m.loaded {
+		return m, nil
+	}
+
+	stripLine := lipgloss.Height(m.renderHeader())
+	if direction := tui.WheelDirection(msg); direction != 0 {
+		if len(m.items) > 0 {
+			m.cursor = tui.PageCursor(m.cursor, len(m.items), m.height-stripLine-1, direction)
+		}
+		return m, nil
+	}
+	if !tui.IsClick(msg) {
+		return m, nil
+	}
+
+	if msg.Y == stripLine {
+		day, ok := m.stripDayAt(msg.X)
+		if !ok {
 			return m, nil
 		}
+		if day == m.day {
+			day = noDay
+		}
+		return m.selectDay(day), nil
 	}
 
+	row, ok := m.itemAt(msg.Y - stripLine - 1)
+	if !ok {
+		return m, nil
+	}
+	m.cursor = row
+	item := m.items[row]
+	if item.IsHeader {
+		return m.toggleGroup(item.Group), nil
+	}
+	// The checkbox follows the one-column mark and a space
+	start := m.styles.Task.Normal.GetPaddingLeft() + 2
+	if msg.X >= start && msg.X < start+2 && !item.Task.Completed {
+		return m, func() tea.Msg { return tui.CompleteClickedMsg{Task: item.Task} }
+	}
 	return m, nil
 }
 
+// itemAt returns the index of the item rendered on content line y
+func (m Model) itemAt(y int) (int, bool) {
+	if y < 0 {
+		return 0, false
+	}
+	line := 0
+	for i, item := range m.items {
+		line += lipgloss.Height(m.renderItem(item, i == m.cursor))
+		if y < line {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// stripDayAt returns the calendar strip day rendered at column x
+func (m Model) stripDayAt(x int) (int, bool) {
+	start := 0
+	for day, cell := range m.stripCells() {
+		end := start + lipgloss.Width(cell)
+		if x >= start && x < end {
+			return day, true
+		}
+		start = end + 1
+	}
+	return 0, false
+}
+
 // nextSelectableIndex finds the next selectable item (skips headers optionally)
 func (m Model) nextSelectableIndex(current, direction int) int {
 	next := current + direction
@@ -151,8 +337,107 @@ func (m Model) nextSelectableIndex(current, direction int) int {
 	return next
 }
 
+// selectDay shows the tasks due on the given strip day, or all groups for noDay
+func (m Model) selectDay(day int) Model {
+	m.day = day
+	m.items = m.buildItems(m.applyFilter(m.allTasks))
+	m.resetCursor()
+	return m
+}
+
+// resetCursor moves the cursor to the first task, skipping a leading header
+func (m *Model) resetCursor() {
+	if len(m.items) > 0 && m.items[0].IsHeader && len(m.items) > 1 {
+		m.cursor = 1
+	} else {
+		m.cursor = 0
+	}
+}
+
+// itemKeys identifies each item across reloads: tasks by ID and headers by
+// their group
+func itemKeys(items []GroupedTask) []string {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		if item.IsHeader {
+			keys[i] = fmt.Sprintf("group:%d", item.Group)
+		} else {
+			keys[i] = item.Task.ID
+		}
+	}
+	return keys
+}
+
+// buildItems lists the tasks due on the selected day, or groups all tasks when no day is selected
+func (m Model) buildItems(tasks []domain.Task) []GroupedTask {
+	tasks = tui.SortTasks(tasks, m.sort)
+	if m.day == noDay {
+		return m.groupTasks(tasks)
+	}
+
+	start := m.dayStart(m.day)
+	end := start.AddDate(0, 0, 1)
+	group := GroupThisWeek
+	switch m.day {
+	case 0:
+		group = GroupToday
+	case 1:
+		group = GroupTomorrow
+	}
+
+	items := []GroupedTask{}
+	for _, task := range tui.PinnedFirst(tasks, m.pinned) {
+		if dueBetween(task, start, end) {
+			items = append(items, GroupedTask{Task: task, Group: group})
+		}
+	}
+	return items
+}
+
+// dayStart returns midnight of the day offset days from today
+func (m Model) dayStart(offset int) time.Time {
+	now := m.now()
+	return time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, now.Location())
+}
+
+// dueBetween reports whether an incomplete task is due in [start, end),
+// going by its own or inherited due date
+func dueBetween(task domain.Task, start, end time.Time) bool {
+	due := task.EffectiveDue()
+	return !task.Completed && due != nil && !due.Before(start) && due.Before(end)
+}
+
+// DayCounts returns the number of incomplete, filtered tasks due on each calendar strip day
+func (m Model) DayCounts() [StripDays]int {
+	var counts [StripDays]int
+	tasks := m.applyFilter(m.allTasks)
+	for day := range counts {
+		start := m.dayStart(day)
+		end := start.AddDate(0, 0, 1)
+		for _, task := range tasks {
+			if dueBetween(task, start, end) {
+				counts[day]++
+			}
+		}
+	}
+	return counts
+}
+
+// DueCount returns the number of incomplete, filtered tasks overdue or due today
+func (m Model) DueCount() int {
+	now := m.now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	count := 0
+	for _, task := range m.applyFilter(m.allTasks) {
+		if due := task.EffectiveDue(); !task.Completed && due != nil && due.Before(tomorrow) {
+			count++
+		}
+	}
+	return count
+}
+
 func (m Model) groupTasks(tasks []domain.Task) []GroupedTask {
-	now := time.Now()
+	now := m.now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	tomorrow := today.AddDate(0, 0, 1)
 	weekEnd := today.AddDate(0, 0, 7)
@@ -164,6 +449,7 @@ func (m Model) groupTasks(tasks []domain.Task) []GroupedTask {
 		GroupThisWeek: {},
 		GroupLater:    {},
 		GroupNoDue:    {},
+		GroupPinned:   {},
 	}
 
 	for _, task := range tasks {
@@ -172,18 +458,24 @@ func (m Model) groupTasks(tasks []domain.Task) []GroupedTask {
 		}
 
 		group := m.categorizeTask(task, today, tomorrow, weekEnd)
+		if m.pinned[task.ID] {
+			group = GroupPinned
+		}
 		groups[group] = append(groups[group], task)
 	}
 
 	return m.buildGroupedItems(groups)
 }
 
+// categorizeTask groups a task by its due date, its own or inherited from
+// its project or parent
 func (m Model) categorizeTask(task domain.Task, today, tomorrow, weekEnd time.Time) DueGroup {
-	if task.DueDate == nil {
+	effective := task.EffectiveDue()
+	if effective == nil {
 		return GroupNoDue
 	}
 
-	due := *task.DueDate
+	due := *effective
 	if due.Before(today) {
 		return GroupOverdue
 	}
@@ -203,9 +495,7 @@ func (m Model) categorizeTask(task domain.Task, today, tomorrow, weekEnd time.Ti
 func (m Model) buildGroupedItems(groups map[DueGroup][]domain.Task) []GroupedTask {
 	var items []GroupedTask
 
-	groupOrder := []DueGroup{GroupOverdue, GroupToday, GroupTomorrow, GroupThisWeek, GroupLater, GroupNoDue}
-
-	for _, group := range groupOrder {
+	for _, group := range m.labels.groupOrder() {
 		tasks := groups[group]
 		if len(tasks) == 0 {
 			continue
@@ -252,7 +542,7 @@ func (m Model) View() string {
 	header := m.renderHeader()
 	content := m.renderContent()
 
-	return header + "\n" + content
+	return header + "\n" + m.renderStrip() + "\n" + content
 }
 
 func (m Model) renderHeader() string {
@@ -262,14 +552,50 @@ func (m Model) renderHeader() string {
 			taskCount++
 		}
 	}
-	headerText := fmt.Sprintf("FORECAST (%d tasks)", taskCount)
-	return m.styles.UI.Header.Render(headerText)
+	headerText := fmt.Sprintf("FORECAST (%s)", m.labels.taskCount(taskCount))
+	if m.day != noDay {
+		headerText = fmt.Sprintf("FORECAST · %s (%s)", m.dayStart(m.day).Format("Mon, Jan 2"), m.labels.taskCount(taskCount))
+	}
+	header := m.styles.UI.Header.Render(headerText) + tui.SortHint(m.styles, m.sort)
+	if m.warning != "" {
+		header += "\n" + lipgloss.NewStyle().Foreground(m.styles.Colors.Warning).Render("⚠ "+m.warning)
+	}
+	return header
+}
+
+// renderStrip renders the 7-day calendar strip with per-day task counts
+func (m Model) renderStrip() string {
+	return strings.Join(m.stripCells(), " ")
+}
+
+// stripCells renders the label of each calendar strip day
+func (m Model) stripCells() []string {
+	counts := m.DayCounts()
+	cells := make([]string, 0, StripDays)
+	for day, count := range counts {
+		label := fmt.Sprintf("%s (%d)", m.dayStart(day).Format("Mon 2"), count)
+
+		style := m.styles.Forecast.Later
+		switch {
+		case day == m.day:
+			style = lipgloss.NewStyle().Background(m.styles.Colors.Selection).Foreground(m.styles.Colors.OnSelection).Bold(true)
+		case day == 0:
+			style = m.styles.Forecast.Today
+		case count > 0:
+			style = m.styles.Forecast.Tomorrow
+		}
+		cells = append(cells, style.Render(label))
+	}
+	return cells
 }
 
 func (m Model) renderContent() string {
 	if !m.loaded {
 		return "Loading..."
 	}
+	if len(m.items) == 0 && m.day != noDay {
+		return "No tasks due " + m.dayStart(m.day).Format("Monday, January 2")
+	}
 	if len(m.items) == 0 {
 		return "No tasks"
 	}
@@ -277,20 +603,23 @@ func (m Model) renderContent() string {
 	var b strings.Builder
 
 	for i, item := range m.items {
-		selected := i == m.cursor
-		if item.IsHeader {
-			b.WriteString(m.renderGroupHeader(item.Group, selected))
-		} else {
-			b.WriteString(m.renderTask(item.Task, item.Group, selected))
-		}
+		b.WriteString(m.renderItem(item, i == m.cursor))
 		b.WriteString("\n")
 	}
 
 	return b.String()
 }
 
+// renderItem renders a group header or task line
+func (m Model) renderItem(item GroupedTask, selected bool) string {
+	if item.IsHeader {
+		return m.renderGroupHeader(item.Group, selected)
+	}
+	return m.renderTask(item.Task, item.Group, selected)
+}
+
 func (m Model) renderGroupHeader(group DueGroup, selected bool) string {
-	name := groupName(group)
+	name := m.labels.groupName(group)
 	icon := "▼" // Expanded state - down arrow means "can collapse"
 	if m.collapsed[group] {
 		icon = "▶" // Collapsed state - right arrow means "can expand"
@@ -312,7 +641,7 @@ func (m Model) renderGroupHeader(group DueGroup, selected bool) string {
 	}
 
 	if selected {
-		style = style.Background(m.styles.Colors.Primary).Foreground(lipgloss.Color("#FFFFFF"))
+		style = style.Background(m.styles.Colors.Selection).Foreground(m.styles.Colors.OnSelection)
 	}
 
 	return style.Bold(true).Render(header)
@@ -329,12 +658,35 @@ func (m Model) renderTask(task domain.Task, _ DueGroup, selected bool) string {
 		flagIcon = " 🚩"
 	}
 
-	line := fmt.Sprintf("  %s %s%s", statusIcon, task.Name, flagIcon)
+	markIcon := " "
+	if m.marked[task.ID] {
+		markIcon = "●"
+	}
+
+	name := task.Name
+	if m.pinned[task.ID] {
+		name = tasklist.PinIcon + " " + name
+	}
+	if m.conflicts[task.ID] {
+		name = tasklist.ConflictIcon + " " + name
+	}
+
+	path := ""
+	if m.showPath && task.Path() != "" {
+		path = "  " + task.Path()
+	}
+
+	line := fmt.Sprintf("%s %s %s%s%s", markIcon, statusIcon, name, path, flagIcon)
+	nameAt := len(line) - len(flagIcon) - len(path) - len(task.Name)
 
+	style := m.styles.Task.Normal
 	if selected {
-		return m.styles.Task.Selected.Render(line)
+		style = m.styles.Task.SelectedStyle(task, m.dayStart(0))
+	} else if m.marked[task.ID] {
+		style = m.styles.Task.Marked
 	}
-	return m.styles.Task.Normal.Render(line)
+	line = tui.Highlight(line, nameAt, nameAt+len(task.Name), m.highlight, style, m.styles.Task.Match)
+	return style.Render(line)
 }
 
 func (m Model) renderError() string {
@@ -352,6 +704,156 @@ func (m Model) SelectedTask() *domain.Task {
 	return &m.items[m.cursor].Task
 }
 
+// MarkedTasks returns the tasks marked for bulk actions
+func (m Model) MarkedTasks() []domain.Task {
+	if len(m.marked) == 0 {
+		return nil
+	}
+
+	var tasks []domain.Task
+	for _, task := range m.allTasks {
+		if m.marked[task.ID] {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// ClearMarks removes all bulk-action marks
+func (m Model) ClearMarks() Model {
+	m.marked = make(map[string]bool)
+	return m
+}
+
+// pruneMarks drops marks for tasks that are no longer loaded
+func (m *Model) pruneMarks() {
+	present := make(map[string]bool, len(m.marked))
+	for _, task := range m.allTasks {
+		if m.marked[task.ID] {
+			present[task.ID] = true
+		}
+	}
+	m.marked = present
+}
+
+// SelectedDay returns the selected calendar strip day as an offset from today, and false when all groups are shown
+func (m Model) SelectedDay() (int, bool) {
+	return m.day, m.day != noDay
+}
+
+// SelectTask moves the cursor to the task with the given ID, waiting for the
+// tasks to load if needed
+func (m Model) SelectTask(id string) Model {
+	if !m.loaded {
+		m.selectID = id
+		return m
+	}
+	return m.selectTaskID(id)
+}
+
+// selectTaskID moves the cursor to the listed task with the given ID
+func (m Model) selectTaskID(id string) Model {
+	for i, item := range m.items {
+		if !item.IsHeader && item.Task.ID == id {
+			m.cursor = i
+			break
+		}
+	}
+	return m
+}
+
+// CollapsedGroups returns the collapsed groups in display order
+func (m Model) CollapsedGroups() []DueGroup {
+	var groups []DueGroup
+	for group := GroupOverdue; group <= GroupPinned; group++ {
+		if m.collapsed[group] {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// SetCollapsedGroups collapses exactly the given groups
+func (m Model) SetCollapsedGroups(groups []DueGroup) Model {
+	m.collapsed = make(map[DueGroup]bool, len(groups))
+	for _, group := range groups {
+		m.collapsed[group] = true
+	}
+	m.items = m.buildItems(m.applyFilter(m.allTasks))
+	return m
+}
+
+// SetPinned sets the tasks listed in the Pinned group, keeping the cursor on
+// the selected task
+func (m Model) SetPinned(pinned map[string]bool) Model {
+	selected := m.SelectedTask()
+	m.pinned = pinned
+	m.items = m.buildItems(m.applyFilter(m.allTasks))
+	if selected != nil {
+		m = m.selectTaskID(selected.ID)
+	}
+	if m.cursor >= len(m.items) {
+		m.resetCursor()
+	}
+	return m
+}
+
+// SetSort sets the order of tasks within each group, keeping the cursor on
+// the selected task
+func (m Model) SetSort(mode tui.SortMode) Model {
+	selected := m.SelectedTask()
+	m.sort = mode
+	m.items = m.buildItems(m.applyFilter(m.allTasks))
+	if selected != nil {
+		m = m.selectTaskID(selected.ID)
+	}
+	if m.cursor >= len(m.items) {
+		m.resetCursor()
+	}
+	return m
+}
+
+// SetLabels sets the group names, group order and task count text shown
+func (m Model) SetLabels(labels Labels) Model {
+	m.labels = labels
+	m.items = m.buildItems(m.applyFilter(m.allTasks))
+	if m.cursor >= len(m.items) {
+		m.resetCursor()
+	}
+	return m
+}
+
+// SetConflicts sets the tasks shown with a conflict marker
+func (m Model) SetConflicts(conflicts map[string]bool) Model {
+	m.conflicts = conflicts
+	return m
+}
+
+// SetShowPath sets whether task rows show the folders and project containing each task
+func (m Model) SetShowPath(show bool) Model {
+	m.showPath = show
+	return m
+}
+
+// PatchTask applies patch to a task in place; completed tasks leave the
+// forecast until a patch marks them not completed again
+func (m Model) PatchTask(id string, patch func(*domain.Task)) Model {
+	i := slices.IndexFunc(m.allTasks, func(task domain.Task) bool { return task.ID == id })
+	if i < 0 {
+		return m
+	}
+	oldKeys := itemKeys(m.items)
+	m.allTasks = slices.Clone(m.allTasks)
+	patch(&m.allTasks[i])
+	m.items = m.buildItems(m.applyFilter(m.allTasks))
+	if i, ok := tui.KeepSelection(oldKeys, m.cursor, itemKeys(m.items)); ok {
+		m.cursor = i
+	} else {
+		m.resetCursor()
+	}
+	return m
+}
+
 // Refresh reloads tasks
 func (m Model) Refresh() tea.Cmd {
 	return m.loadTasks()
@@ -360,14 +862,12 @@ func (m Model) Refresh() tea.Cmd {
 // SetFilter sets the filter state and applies it to tasks
 func (m Model) SetFilter(f filter.State) Model {
 	m.filter = f
+	m.highlight, _ = f.SearchRegexp() // An invalid pattern matches no task to highlight
 	// Re-apply filter to existing tasks
-	filteredTasks := m.applyFilter(m.allTasks)
-	m.items = m.groupTasks(filteredTasks)
+	m.items = m.buildItems(m.applyFilter(m.allTasks))
 	// Reset cursor to first valid position
-	if len(m.items) > 0 && m.items[0].IsHeader && len(m.items) > 1 {
-		m.cursor = 1
-	} else if len(m.items) > 0 {
-		m.cursor = 0
+	if len(m.items) > 0 {
+		m.resetCursor()
 	}
 	return m
 }
@@ -377,10 +877,11 @@ func (m Model) applyFilter(tasks []domain.Task) []domain.Task {
 	if !m.filter.IsActive() {
 		return tasks
 	}
-	matcher := filter.NewMatcher(m.filter)
+	matcher := filter.NewMatcher(m.filter).WithIndex(m.index)
 	return matcher.FilterTasks(tasks)
 }
 
+// groupName returns the default header name of a group
 func groupName(g DueGroup) string {
 	switch g {
 	case GroupOverdue:
@@ -395,6 +896,8 @@ func groupName(g DueGroup) string {
 		return "Later"
 	case GroupNoDue:
 		return "No Due Date"
+	case GroupPinned:
+		return "Pinned"
 	default:
 		return "Unknown"
 	}
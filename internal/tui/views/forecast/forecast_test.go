@@ -1,11 +1,15 @@
 package forecast
 
 import (
+	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
@@ -15,32 +19,112 @@ import (
 // MockService for testing
 type MockService struct {
 	tasks []domain.Task
+	err   error
 }
 
-func (m *MockService) GetAllTasks(_ service.TaskFilters) ([]domain.Task, error) {
-	return m.tasks, nil
+func (m *MockService) GetAllTasks(ctx context.Context, _ service.TaskFilters) ([]domain.Task, error) {
+	return m.tasks, m.err
+}
+func (m *MockService) StreamAllTasks(ctx context.Context, _ service.TaskFilters, _ func(domain.Task) error) error {
+	return nil
 }
 
 // Stub other methods
-func (m *MockService) GetInboxTasks() ([]domain.Task, error)               { return nil, nil }
-func (m *MockService) GetTasksByProject(_ string) ([]domain.Task, error)   { return nil, nil }
-func (m *MockService) GetTasksByTag(_ string) ([]domain.Task, error)       { return nil, nil }
-func (m *MockService) GetFlaggedTasks() ([]domain.Task, error)             { return nil, nil }
-func (m *MockService) GetTaskByID(_ string) (*domain.Task, error)          { return nil, nil }
-func (m *MockService) CreateTask(_ domain.TaskInput) (*domain.Task, error) { return nil, nil }
-func (m *MockService) ModifyTask(_ string, _ domain.TaskModification) (*domain.Task, error) {
+func (m *MockService) GetInboxTasks(ctx context.Context) ([]domain.Task, error) { return nil, nil }
+func (m *MockService) GetTasksByProject(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetTasksByTag(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetFlaggedTasks(ctx context.Context) ([]domain.Task, error) { return nil, nil }
+func (m *MockService) GetTaskByID(ctx context.Context, _ string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) CreateTask(ctx context.Context, _ domain.TaskInput) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) ModifyTask(ctx context.Context, _ string, _ domain.TaskModification) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) CompleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+func (m *MockService) DeleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjects(ctx context.Context, _ string) ([]domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjectByID(ctx context.Context, _ string) (*domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjectWithTasks(ctx context.Context, _ string) (*domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetAttachments(ctx context.Context, _ string, _ service.AttachmentOptions) ([]domain.Attachment, error) {
+	return nil, nil
+}
+func (m *MockService) GetFolders(ctx context.Context) ([]domain.Folder, error)       { return nil, nil }
+func (m *MockService) GetTags(ctx context.Context) ([]domain.Tag, error)             { return nil, nil }
+func (m *MockService) GetTagByID(ctx context.Context, _ string) (*domain.Tag, error) { return nil, nil }
+func (m *MockService) GetTagCounts(ctx context.Context) (map[string]int, error)      { return nil, nil }
+func (m *MockService) SearchTasks(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetPerspectiveTasks(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) ResolveProjectName(ctx context.Context, _ string) (string, error) {
+	return "", nil
+}
+func (m *MockService) BatchModify(ctx context.Context, _ []string, _ domain.BatchOperation) (*domain.BatchResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetCompletedTasks(ctx context.Context, _ time.Time) ([]domain.Task, error) {
+	return nil, nil
+}
+
+func (m *MockService) UncompleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) CreateProject(ctx context.Context, _ domain.ProjectInput) (*domain.Project, error) {
+	return nil, nil
+}
+
+func (m *MockService) DropTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) DropProject(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetTaskHierarchy(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+
+func (m *MockService) CreateTag(ctx context.Context, _, _ string) (*domain.Tag, error) {
+	return nil, nil
+}
+
+func (m *MockService) RenameTag(ctx context.Context, _, _ string) (*domain.Tag, error) {
+	return nil, nil
+}
+
+func (m *MockService) ReorderTask(ctx context.Context, _ string, _ domain.TaskPosition) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) DeleteTag(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetPerspectiveRules(ctx context.Context, _ string) (*domain.PerspectiveRules, error) {
 	return nil, nil
 }
-func (m *MockService) CompleteTask(_ string) (*domain.OperationResult, error) { return nil, nil }
-func (m *MockService) DeleteTask(_ string) (*domain.OperationResult, error)   { return nil, nil }
-func (m *MockService) GetProjects(_ string) ([]domain.Project, error)         { return nil, nil }
-func (m *MockService) GetProjectByID(_ string) (*domain.Project, error)       { return nil, nil }
-func (m *MockService) GetProjectWithTasks(_ string) (*domain.Project, error)  { return nil, nil }
-func (m *MockService) GetTags() ([]domain.Tag, error)                         { return nil, nil }
-func (m *MockService) GetTagByID(_ string) (*domain.Tag, error)               { return nil, nil }
-func (m *MockService) GetTagCounts() (map[string]int, error)                  { return nil, nil }
-func (m *MockService) GetPerspectiveTasks(_ string) ([]domain.Task, error)    { return nil, nil }
-func (m *MockService) ResolveProjectName(_ string) (string, error)            { return "", nil }
 
 func TestNew(t *testing.T) {
 	styles := tui.DefaultStyles()
@@ -257,6 +341,30 @@ func TestUpdate_TasksLoadedMsg(t *testing.T) {
 	}
 }
 
+func TestUpdate_TasksLoadedMsgKeepsSelection(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), &MockService{})
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, now.Location())
+	tasks := []domain.Task{
+		{ID: "1", Name: "First", DueDate: &today},
+		{ID: "2", Name: "Second", DueDate: &today},
+		{ID: "3", Name: "Third", DueDate: &today},
+	}
+	m, _ = m.Update(tui.TasksLoadedMsg{Tasks: tasks})
+	m = m.SelectTask("2")
+
+	m, _ = m.Update(tui.TasksLoadedMsg{Tasks: tasks})
+	if got := m.SelectedTask(); got == nil || got.ID != "2" {
+		t.Errorf("SelectedTask() = %v, want task 2 kept after reload", got)
+	}
+
+	m, _ = m.Update(tui.TasksLoadedMsg{Tasks: []domain.Task{tasks[0], tasks[2]}})
+	if got := m.SelectedTask(); got == nil || got.ID != "3" {
+		t.Errorf("SelectedTask() = %v, want neighbor task 3 after task 2 disappeared", got)
+	}
+}
+
 // TestUpdate_WindowSizeMsg verifies dimensions are updated
 func TestUpdate_WindowSizeMsg(t *testing.T) {
 	styles := tui.DefaultStyles()
@@ -508,6 +616,28 @@ func TestCategorizeTask(t *testing.T) {
 	}
 }
 
+func TestCategorizeTask_InheritedDueDate(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), &MockService{})
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tomorrow := today.AddDate(0, 0, 1)
+	weekEnd := today.AddDate(0, 0, 7)
+
+	// Due when its project is, with no date of its own
+	task := domain.Task{ID: "t1", Name: "Inherits", EffectiveDueDate: timePtr(today.Add(12 * time.Hour))}
+	if group := m.categorizeTask(task, today, tomorrow, weekEnd); group != GroupToday {
+		t.Errorf("categorizeTask() = %v, want Today", groupName(group))
+	}
+
+	// Its project is due before the task's own date
+	task.DueDate = timePtr(today.AddDate(0, 0, 10))
+	task.EffectiveDueDate = timePtr(today.AddDate(0, 0, -1))
+	if group := m.categorizeTask(task, today, tomorrow, weekEnd); group != GroupOverdue {
+		t.Errorf("categorizeTask() = %v, want Overdue", groupName(group))
+	}
+}
+
 // timePtr returns a pointer to a time value
 func timePtr(t time.Time) *time.Time {
 	return &t
@@ -772,6 +902,21 @@ func TestRenderTask_IncompleteTask(t *testing.T) {
 	}
 }
 
+// TestRenderTask_ShowPath verifies the folder and project path follows the name when enabled
+func TestRenderTask_ShowPath(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), &MockService{})
+	task := domain.Task{ID: "1", Name: "Send invoice", ProjectName: "Acme", FolderPath: []string{"Work"}, Flagged: true}
+
+	if contains(m.renderTask(task, GroupToday, false), "Acme") {
+		t.Error("path should be hidden by default")
+	}
+
+	m = m.SetShowPath(true)
+	if rendered := m.renderTask(task, GroupToday, false); !contains(rendered, "Send invoice  Work ▸ Acme 🚩") {
+		t.Errorf("rendered task should show its path before the flag, got %q", rendered)
+	}
+}
+
 // TestNextSelectableIndex_Wrapping verifies cursor wraps around
 func TestNextSelectableIndex_Wrapping(t *testing.T) {
 	styles := tui.DefaultStyles()
@@ -918,3 +1063,308 @@ func TestSetFilter_EmptyFilter(t *testing.T) {
 		t.Errorf("expected 2 tasks with empty filter, got %d", taskCount)
 	}
 }
+
+func TestLoadTasks_TruncatedShowsWarning(t *testing.T) {
+	styles := tui.DefaultStyles()
+	keys := tui.DefaultKeyMap()
+	today := time.Now()
+	svc := &MockService{
+		tasks: []domain.Task{{ID: "1", Name: "Task 1", DueDate: &today}},
+		err:   &service.TruncatedError{Returned: 1, Total: 20000},
+	}
+
+	m := New(styles, keys, svc)
+	msg := m.loadTasks()()
+
+	loaded, ok := msg.(tui.TasksLoadedMsg)
+	if !ok {
+		t.Fatalf("loadTasks() returned %T, want tui.TasksLoadedMsg", msg)
+	}
+	if loaded.Warning == "" {
+		t.Error("expected truncation warning on TasksLoadedMsg")
+	}
+
+	m, _ = m.Update(loaded)
+	if !strings.Contains(m.View(), "showing 1 of 20000 tasks") {
+		t.Error("expected truncation warning in view")
+	}
+}
+
+func TestMarkTasks(t *testing.T) {
+	styles := tui.DefaultStyles()
+	keys := tui.DefaultKeyMap()
+	today := time.Now()
+	svc := &MockService{
+		tasks: []domain.Task{
+			{ID: "1", Name: "Task 1", DueDate: &today},
+			{ID: "2", Name: "Task 2", DueDate: &today},
+		},
+	}
+
+	m := New(styles, keys, svc)
+	m, _ = m.Update(tui.TasksLoadedMsg{Tasks: svc.tasks})
+
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	m, _ = m.Update(space)
+
+	marked := m.MarkedTasks()
+	if len(marked) != 1 || marked[0].ID != "1" {
+		t.Fatalf("expected task 1 marked, got %v", marked)
+	}
+	if !strings.Contains(m.View(), "●") {
+		t.Error("expected mark indicator in view")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.MarkedTasks()) != 0 {
+		t.Error("expected marks cleared on esc")
+	}
+}
+
+func newStripModel(t *testing.T) Model {
+	t.Helper()
+
+	// Friday, January 19, 2024
+	now := time.Date(2024, 1, 19, 9, 0, 0, 0, time.Local)
+	at := func(days int) *time.Time {
+		due := time.Date(2024, 1, 19+days, 17, 0, 0, 0, time.Local)
+		return &due
+	}
+	tasks := []domain.Task{
+		{ID: "overdue", Name: "Overdue", DueDate: at(-1)},
+		{ID: "today", Name: "Today", DueDate: at(0)},
+		{ID: "sat1", Name: "Saturday 1", DueDate: at(1)},
+		{ID: "sat2", Name: "Saturday 2", DueDate: at(1)},
+		{ID: "done", Name: "Done", DueDate: at(1), Completed: true},
+		{ID: "thu", Name: "Thursday", DueDate: at(6)},
+		{ID: "later", Name: "Later", DueDate: at(7)},
+	}
+
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), &MockService{})
+	m.now = func() time.Time { return now }
+	m, _ = m.Update(tui.TasksLoadedMsg{Tasks: tasks})
+	return m
+}
+
+func TestDayCounts(t *testing.T) {
+	m := newStripModel(t)
+
+	want := [StripDays]int{1, 2, 0, 0, 0, 0, 1}
+	if got := m.DayCounts(); got != want {
+		t.Errorf("DayCounts() = %v, want %v", got, want)
+	}
+}
+
+func TestDueCount(t *testing.T) {
+	m := newStripModel(t)
+
+	if got := m.DueCount(); got != 2 {
+		t.Errorf("DueCount() = %d, want 2 (overdue and today)", got)
+	}
+}
+
+func TestDayNavigation_FiltersToSelectedDay(t *testing.T) {
+	m := newStripModel(t)
+	right := tea.KeyMsg{Type: tea.KeyRight}
+	left := tea.KeyMsg{Type: tea.KeyLeft}
+
+	if _, ok := m.SelectedDay(); ok {
+		t.Fatal("no day should be selected initially")
+	}
+
+	m, _ = m.Update(right)
+	m, _ = m.Update(right)
+	if day, ok := m.SelectedDay(); !ok || day != 1 {
+		t.Fatalf("SelectedDay() = %d, %v, want 1, true", day, ok)
+	}
+	if len(m.items) != 2 || m.items[0].Task.ID != "sat1" || m.items[1].Task.ID != "sat2" {
+		t.Errorf("items = %+v, want the two open Saturday tasks", m.items)
+	}
+	if task := m.SelectedTask(); task == nil || task.ID != "sat1" {
+		t.Errorf("SelectedTask() = %v, want sat1", task)
+	}
+	if !strings.Contains(m.renderHeader(), "Sat, Jan 20") {
+		t.Errorf("header should name the selected day, got: %s", m.renderHeader())
+	}
+
+	m, _ = m.Update(left)
+	m, _ = m.Update(left)
+	if _, ok := m.SelectedDay(); ok {
+		t.Error("moving left of today should show all groups again")
+	}
+}
+
+func TestDayNavigation_StopsAtLastDay(t *testing.T) {
+	m := newStripModel(t)
+
+	for i := 0; i < StripDays+3; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	}
+
+	if day, _ := m.SelectedDay(); day != StripDays-1 {
+		t.Errorf("SelectedDay() = %d, want %d", day, StripDays-1)
+	}
+}
+
+func TestDayNavigation_EscapeShowsAllGroups(t *testing.T) {
+	m := newStripModel(t)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if _, ok := m.SelectedDay(); ok {
+		t.Error("Esc should clear the selected day")
+	}
+	if len(m.items) == 0 || !m.items[0].IsHeader {
+		t.Error("Esc should restore the grouped list")
+	}
+}
+
+func TestView_ShowsCalendarStrip(t *testing.T) {
+	m := newStripModel(t)
+
+	view := m.View()
+
+	for _, want := range []string{"Fri 19 (1)", "Sat 20 (2)", "Thu 25 (1)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got: %s", want, view)
+		}
+	}
+}
+
+func TestView_EmptySelectedDay(t *testing.T) {
+	m := newStripModel(t)
+
+	for i := 0; i < 3; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	}
+
+	if view := m.View(); !strings.Contains(view, "No tasks due Sunday, January 21") {
+		t.Errorf("view should describe the empty day, got: %s", view)
+	}
+}
+
+func click(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+}
+
+func TestMouse_ClickSelectsTaskAndTogglesGroups(t *testing.T) {
+	m := newStripModel(t)
+	content := lipgloss.Height(m.renderHeader()) + 1 // Header and calendar strip
+
+	m, _ = m.Update(click(5, content+1))
+	if task := m.SelectedTask(); task == nil || task.ID != "overdue" {
+		t.Fatalf("SelectedTask() = %v, want overdue", task)
+	}
+
+	group := m.items[0].Group
+	m, _ = m.Update(click(5, content))
+	if !m.collapsed[group] {
+		t.Error("clicking a group header should collapse it")
+	}
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want the clicked header", m.cursor)
+	}
+	m, _ = m.Update(click(5, content))
+	if m.collapsed[group] {
+		t.Error("clicking a collapsed group header should expand it")
+	}
+}
+
+func TestPatchTask_HidesCompletedTaskUntilRolledBack(t *testing.T) {
+	m := newStripModel(t)
+	count := len(m.items)
+
+	m = m.PatchTask("overdue", func(task *domain.Task) { task.Completed = true })
+	if len(m.items) >= count {
+		t.Fatalf("items = %d, want fewer than %d once the task is completed", len(m.items), count)
+	}
+	for _, item := range m.items {
+		if !item.IsHeader && item.Task.ID == "overdue" {
+			t.Error("a completed task should leave the forecast")
+		}
+	}
+
+	m = m.PatchTask("overdue", func(task *domain.Task) { task.Completed = false })
+	if len(m.items) != count {
+		t.Errorf("items = %d after rolling back, want %d", len(m.items), count)
+	}
+}
+
+func TestMouse_ClickOnCheckboxRequestsCompletion(t *testing.T) {
+	m := newStripModel(t)
+	content := lipgloss.Height(m.renderHeader()) + 1 // Header and calendar strip
+
+	_, cmd := m.Update(click(3, content+1))
+	if cmd == nil {
+		t.Fatal("clicking the checkbox should request completion")
+	}
+	if msg, ok := cmd().(tui.CompleteClickedMsg); !ok || msg.Task.ID != "overdue" {
+		t.Errorf("cmd() = %v, want CompleteClickedMsg for overdue", msg)
+	}
+}
+
+func TestMouse_ClickOnStripSelectsDay(t *testing.T) {
+	m := newStripModel(t)
+	strip := lipgloss.Height(m.renderHeader())
+	secondDay := lipgloss.Width(m.stripCells()[0]) + 1
+
+	m, _ = m.Update(click(secondDay, strip))
+	if day, ok := m.SelectedDay(); !ok || day != 1 {
+		t.Fatalf("SelectedDay() = %d, %v, want 1, true", day, ok)
+	}
+
+	// Clicking the selected day again shows all groups
+	m, _ = m.Update(click(secondDay, strip))
+	if _, ok := m.SelectedDay(); ok {
+		t.Error("clicking the selected day should clear the selection")
+	}
+}
+
+func TestSetPinned_ShowsPinnedGroupFirst(t *testing.T) {
+	m := newStripModel(t)
+	m = m.SetPinned(map[string]bool{"thu": true})
+
+	if !m.items[0].IsHeader || m.items[0].Group != GroupPinned {
+		t.Fatalf("first item = %+v, want the Pinned header", m.items[0])
+	}
+	if m.items[1].Task.ID != "thu" {
+		t.Errorf("second item = %s, want the pinned task", m.items[1].Task.ID)
+	}
+	for _, item := range m.items[2:] {
+		if item.Task.ID == "thu" {
+			t.Error("a pinned task should only be listed in the Pinned group")
+		}
+	}
+	if !strings.Contains(m.View(), "Pinned") {
+		t.Error("View() should render the Pinned group header")
+	}
+}
+
+func TestSetSort_OrdersTasksWithinGroups(t *testing.T) {
+	m := newStripModel(t)
+	m = m.SetPinned(map[string]bool{"today": true})
+	m, _ = m.Update(tui.TasksLoadedMsg{Tasks: append(slices.Clone(m.allTasks),
+		domain.Task{ID: "zoo", Name: "Zoo tickets"},
+		domain.Task{ID: "bike", Name: "Bike repair"},
+	)})
+	m = m.selectTaskID("zoo")
+
+	m = m.SetSort(tui.SortName)
+
+	var ids []string
+	for _, item := range m.items {
+		if !item.IsHeader {
+			ids = append(ids, item.Task.ID)
+		}
+	}
+	if want := "today overdue sat1 sat2 thu later bike zoo"; strings.Join(ids, " ") != want {
+		t.Errorf("tasks = %v, want %s", ids, want)
+	}
+	if task := m.SelectedTask(); task == nil || task.ID != "zoo" {
+		t.Errorf("SelectedTask() = %v, want the task selected before sorting", task)
+	}
+	if !strings.Contains(m.View(), "sorted by name") {
+		t.Error("View() should show the sort in the header")
+	}
+}
@@ -0,0 +1,115 @@
+package forecast
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// defaultGroupOrder is the order groups are listed in unless Labels.Order
+// changes it
+var defaultGroupOrder = []DueGroup{GroupPinned, GroupOverdue, GroupToday, GroupTomorrow, GroupThisWeek, GroupLater, GroupNoDue}
+
+// Labels are the words the forecast shows, so teams can use their own terms
+// (e.g. "Late" for overdue tasks). The zero value shows the defaults.
+type Labels struct {
+	Groups    map[DueGroup]string // Group header names; missing groups keep their default name
+	Order     []DueGroup          // Groups listed first; the rest follow in the default order
+	TaskCount *template.Template  // Renders the header's task count from CountData; nil shows "N tasks"
+}
+
+// CountData is what the task count template is rendered with
+type CountData struct {
+	Count int
+}
+
+// countFuncs are the functions task count templates can call
+var countFuncs = template.FuncMap{
+	// plural picks one for a count of 1 and other for any other count
+	"plural": func(n int, one, other string) string {
+		if n == 1 {
+			return one
+		}
+		return other
+	},
+}
+
+// NewLabels builds forecast labels from the config file: group names keyed
+// by their session names ("overdue", "this-week", …), an order of those
+// names and a task count template such as
+// `{{.Count}} {{plural .Count "task" "tasks"}}`
+func NewLabels(groups map[string]string, order []string, taskCount string) (Labels, error) {
+	var labels Labels
+	for name, label := range groups {
+		var group DueGroup
+		if err := group.UnmarshalText([]byte(strings.ToLower(name))); err != nil {
+			return Labels{}, fmt.Errorf("invalid group label: %w", err)
+		}
+		if labels.Groups == nil {
+			labels.Groups = make(map[DueGroup]string, len(groups))
+		}
+		labels.Groups[group] = label
+	}
+
+	for _, name := range order {
+		var group DueGroup
+		if err := group.UnmarshalText([]byte(strings.ToLower(name))); err != nil {
+			return Labels{}, fmt.Errorf("invalid group order: %w", err)
+		}
+		if slices.Contains(labels.Order, group) {
+			return Labels{}, fmt.Errorf("invalid group order: %s listed twice", name)
+		}
+		labels.Order = append(labels.Order, group)
+	}
+
+	if taskCount != "" {
+		tmpl, err := template.New("task_count").Funcs(countFuncs).Option("missingkey=error").Parse(taskCount)
+		if err != nil {
+			return Labels{}, fmt.Errorf("invalid task count template: %w", err)
+		}
+		if _, err := renderCount(tmpl, 2); err != nil {
+			return Labels{}, fmt.Errorf("invalid task count template: %w", err)
+		}
+		labels.TaskCount = tmpl
+	}
+	return labels, nil
+}
+
+// groupName returns the header name of group
+func (l Labels) groupName(g DueGroup) string {
+	if name, ok := l.Groups[g]; ok && name != "" {
+		return name
+	}
+	return groupName(g)
+}
+
+// groupOrder returns every group in the order they are listed
+func (l Labels) groupOrder() []DueGroup {
+	order := slices.Clone(l.Order)
+	for _, group := range defaultGroupOrder {
+		if !slices.Contains(order, group) {
+			order = append(order, group)
+		}
+	}
+	return order
+}
+
+// taskCount returns the header's text for count tasks
+func (l Labels) taskCount(count int) string {
+	if l.TaskCount != nil {
+		if text, err := renderCount(l.TaskCount, count); err == nil {
+			return text
+		}
+	}
+	return fmt.Sprintf("%d tasks", count)
+}
+
+// renderCount renders a task count template
+func renderCount(tmpl *template.Template, count int) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, CountData{Count: count}); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
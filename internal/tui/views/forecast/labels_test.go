@@ -0,0 +1,82 @@
+package forecast
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewLabels(t *testing.T) {
+	labels, err := NewLabels(
+		map[string]string{"overdue": "Late", "No-Due": "Someday"},
+		[]string{"today", "overdue"},
+		`{{.Count}} {{plural .Count "task" "tasks"}}`,
+	)
+	if err != nil {
+		t.Fatalf("NewLabels() error = %v", err)
+	}
+
+	if got := labels.groupName(GroupOverdue); got != "Late" {
+		t.Errorf("groupName(overdue) = %q, want Late", got)
+	}
+	if got := labels.groupName(GroupNoDue); got != "Someday" {
+		t.Errorf("groupName(no-due) = %q, want Someday", got)
+	}
+	if got := labels.groupName(GroupToday); got != "Today" {
+		t.Errorf("groupName(today) = %q, want the default", got)
+	}
+
+	want := []DueGroup{GroupToday, GroupOverdue, GroupPinned, GroupTomorrow, GroupThisWeek, GroupLater, GroupNoDue}
+	if got := labels.groupOrder(); !slices.Equal(got, want) {
+		t.Errorf("groupOrder() = %v, want %v", got, want)
+	}
+
+	for count, want := range map[int]string{0: "0 tasks", 1: "1 task", 5: "5 tasks"} {
+		if got := labels.taskCount(count); got != want {
+			t.Errorf("taskCount(%d) = %q, want %q", count, got, want)
+		}
+	}
+}
+
+func TestNewLabels_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		groups    map[string]string
+		order     []string
+		taskCount string
+		wantErr   string
+	}{
+		{"unknown group", map[string]string{"someday": "Maybe"}, nil, "", "invalid group label"},
+		{"unknown order", nil, []string{"today", "next-week"}, "", "invalid group order"},
+		{"repeated order", nil, []string{"today", "today"}, "", "listed twice"},
+		{"bad template", nil, nil, "{{.Count", "invalid task count template"},
+		{"unknown field", nil, nil, "{{.Total}} tasks", "invalid task count template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLabels(tt.groups, tt.order, tt.taskCount)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewLabels() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetLabels_RenamesAndReordersGroups(t *testing.T) {
+	labels, err := NewLabels(map[string]string{"overdue": "Late"}, []string{"later"}, `{{.Count}} Aufgaben`)
+	if err != nil {
+		t.Fatalf("NewLabels() error = %v", err)
+	}
+	m := newStripModel(t).SetLabels(labels)
+
+	if !m.items[0].IsHeader || m.items[0].Group != GroupLater {
+		t.Errorf("first item = %+v, want the Later header", m.items[0])
+	}
+	view := m.View()
+	if !strings.Contains(view, "▼ Late") || strings.Contains(view, "▼ Overdue") {
+		t.Errorf("View() should name the overdue group Late, got:\n%s", view)
+	}
+	if !strings.Contains(view, "FORECAST (6 Aufgaben)") {
+		t.Errorf("View() should render the task count template, got:\n%s", view)
+	}
+}
@@ -2,10 +2,13 @@
 package inbox
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
@@ -25,7 +28,11 @@ type Model struct {
 	err       error
 	loaded    bool
 	taskCount int
-	allTasks  []domain.Task // Store all tasks for filtering
+	allTasks  []domain.Task // Store all tasks (with subtasks) for filtering
+	index     *filter.Index // Lowercased task text for searching
+
+	selectID      string // Task to select once tasks are loaded
+	showCompleted bool   // List recently completed inbox tasks
 }
 
 // New creates a new inbox view
@@ -48,10 +55,19 @@ func (m Model) Init() tea.Cmd {
 // loadTasks loads tasks from the OmniFocus service
 func (m Model) loadTasks() tea.Cmd {
 	return func() tea.Msg {
-		tasks, err := m.service.GetInboxTasks()
+		tasks, err := m.service.GetTaskHierarchy(context.Background(), "")
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
+		if m.showCompleted {
+			completed, err := m.service.GetCompletedTasks(context.Background(), tui.CompletedSince(time.Now()))
+			if err != nil {
+				return tui.ErrorMsg{Err: err}
+			}
+			tasks = tui.WithCompleted(tasks, completed, func(task domain.Task) bool {
+				return task.ProjectID == ""
+			})
+		}
 		return tui.TasksLoadedMsg{Tasks: tasks}
 	}
 }
@@ -62,12 +78,27 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case tui.TasksLoadedMsg:
 		// Store all tasks and apply filter
 		m.allTasks = msg.Tasks
+		m.index = filter.NewIndex(msg.Tasks)
 		filteredTasks := m.applyFilter(msg.Tasks)
-		m.taskList = m.taskList.SetTasks(filteredTasks)
-		m.taskCount = len(filteredTasks)
+		var cmd tea.Cmd
+		m.taskList, cmd = m.taskList.UpdateTasks(filteredTasks)
+		m.taskCount = len(domain.FlattenTasks(filteredTasks))
 		m.loaded = true
 		m.err = nil
-		return m, nil
+		if m.selectID != "" {
+			m.taskList, _ = m.taskList.SelectTask(m.selectID)
+			m.selectID = ""
+		}
+		return m, cmd
+
+	case tea.MouseMsg:
+		// Task rows start below the header
+		if m.err != nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.taskList, cmd = m.taskList.Update(tui.ShiftMouse(msg, lipgloss.Height(m.renderHeader())))
+		return m, cmd
 
 	case tui.ErrorMsg:
 		// Store error for display
@@ -127,7 +158,7 @@ func (m Model) renderHeader() string {
 	// Apply header style
 	styled := m.styles.UI.Header.Render(headerText)
 
-	return styled
+	return styled + tui.SortHint(m.styles, m.taskList.Sort())
 }
 
 // renderError renders the error view
@@ -158,6 +189,66 @@ func (m Model) SelectedTask() *domain.Task {
 	return m.taskList.SelectedTask()
 }
 
+// MarkedTasks returns the tasks marked for bulk actions
+func (m Model) MarkedTasks() []domain.Task {
+	return m.taskList.MarkedTasks()
+}
+
+// ClearMarks removes all bulk-action marks
+func (m Model) ClearMarks() Model {
+	m.taskList = m.taskList.ClearMarks()
+	return m
+}
+
+// SelectTask moves the cursor to the task with the given ID, waiting for the
+// tasks to load if needed
+func (m Model) SelectTask(id string) Model {
+	if !m.loaded {
+		m.selectID = id
+		return m
+	}
+	m.taskList, _ = m.taskList.SelectTask(id)
+	return m
+}
+
+// SetPinned sets the tasks listed first
+func (m Model) SetPinned(pinned map[string]bool) Model {
+	m.taskList = m.taskList.SetPinned(pinned)
+	return m
+}
+
+// SetSort sets the order tasks are listed in
+func (m Model) SetSort(mode tui.SortMode) Model {
+	m.taskList = m.taskList.SetSort(mode)
+	return m
+}
+
+// SetConflicts sets the tasks shown with a conflict marker
+func (m Model) SetConflicts(conflicts map[string]bool) Model {
+	m.taskList = m.taskList.SetConflicts(conflicts)
+	return m
+}
+
+// SetShowPath sets whether task rows show the folders and project containing each task
+func (m Model) SetShowPath(show bool) Model {
+	m.taskList = m.taskList.SetShowPath(show)
+	return m
+}
+
+// PatchTask applies patch to a task in place
+func (m Model) PatchTask(id string, patch func(*domain.Task)) Model {
+	// Keep the unfiltered tasks in step so a later filter change shows the patch
+	m.allTasks, _ = tasklist.PatchTree(m.allTasks, id, patch)
+	m.taskList = m.taskList.PatchTask(id, patch)
+	return m
+}
+
+// SetShowCompleted sets whether recently completed tasks are listed, from the next load
+func (m Model) SetShowCompleted(show bool) Model {
+	m.showCompleted = show
+	return m
+}
+
 // Refresh reloads tasks from the service
 func (m Model) Refresh() tea.Cmd {
 	return m.loadTasks()
@@ -166,18 +257,21 @@ func (m Model) Refresh() tea.Cmd {
 // SetFilter sets the filter state and applies it to tasks
 func (m Model) SetFilter(f filter.State) Model {
 	m.filter = f
+	highlight, _ := f.SearchRegexp() // An invalid pattern matches no task to highlight
+	m.taskList = m.taskList.SetHighlight(highlight)
 	// Re-apply filter to existing tasks
 	filteredTasks := m.applyFilter(m.allTasks)
 	m.taskList = m.taskList.SetTasks(filteredTasks)
-	m.taskCount = len(filteredTasks)
+	m.taskCount = len(domain.FlattenTasks(filteredTasks))
 	return m
 }
 
-// applyFilter filters tasks based on current filter state
+// applyFilter filters tasks based on current filter state. Matching subtasks
+// are listed flat, without their parents.
 func (m Model) applyFilter(tasks []domain.Task) []domain.Task {
 	if !m.filter.IsActive() {
 		return tasks
 	}
-	matcher := filter.NewMatcher(m.filter)
-	return matcher.FilterTasks(tasks)
+	matcher := filter.NewMatcher(m.filter).WithIndex(m.index)
+	return matcher.FilterTasks(domain.FlattenTasks(tasks))
 }
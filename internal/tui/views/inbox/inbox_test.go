@@ -10,6 +10,7 @@ import (
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/filter"
 )
 
 // TestInitialState verifies the model is initialized with correct defaults
@@ -269,6 +270,46 @@ func TestTaskCount_ReturnsCorrectCount(t *testing.T) {
 	}
 }
 
+// TestTaskCount_IncludesSubtasks verifies subtasks count and filters match them
+func TestTaskCount_IncludesSubtasks(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), &service.MockOmniFocusService{})
+
+	tasks := []domain.Task{
+		{ID: "1", Name: "Plan trip", Children: []domain.Task{
+			{ID: "1a", Name: "Book flights", ParentID: "1"},
+		}},
+		{ID: "2", Name: "Pay rent"},
+	}
+	m, _ = m.Update(tui.TasksLoadedMsg{Tasks: tasks})
+
+	if m.TaskCount() != 3 {
+		t.Errorf("expected 3 tasks including subtask, got %d", m.TaskCount())
+	}
+
+	m = m.SetFilter(filter.State{SearchText: "flights"})
+	if m.TaskCount() != 1 {
+		t.Errorf("expected filter to match the subtask, got %d tasks", m.TaskCount())
+	}
+}
+
+// TestPatchTask_SurvivesFilterChange verifies a patched subtask keeps the
+// patch when the filter is applied again
+func TestPatchTask_SurvivesFilterChange(t *testing.T) {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), &service.MockOmniFocusService{})
+	m, _ = m.Update(tui.TasksLoadedMsg{Tasks: []domain.Task{
+		{ID: "1", Name: "Plan trip", Children: []domain.Task{
+			{ID: "1a", Name: "Book flights", ParentID: "1"},
+		}},
+	}})
+
+	m = m.PatchTask("1a", func(task *domain.Task) { task.Name = "Book trains" })
+	m = m.SetFilter(filter.State{SearchText: "trains"})
+
+	if m.TaskCount() != 1 {
+		t.Errorf("expected the filter to match the patched subtask, got %d tasks", m.TaskCount())
+	}
+}
+
 // TestSelectedTask_DelegatesToTaskList verifies SelectedTask method
 func TestSelectedTask_DelegatesToTaskList(t *testing.T) {
 	styles := tui.DefaultStyles()
@@ -482,3 +523,28 @@ func TestRenderError_ZeroWidth(t *testing.T) {
 		t.Error("expected view to contain separator")
 	}
 }
+
+func TestRefresh_ShowCompleted(t *testing.T) {
+	done := time.Now().Add(-time.Hour)
+	svc := &service.MockOmniFocusService{
+		InboxTasks: []domain.Task{{ID: "1", Name: "Open task"}},
+		CompletedTasks: []domain.Task{
+			{ID: "2", Name: "Done in the inbox", Completed: true, CompletedDate: &done},
+			{ID: "3", Name: "Done in a project", ProjectID: "p1", Completed: true, CompletedDate: &done},
+		},
+	}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+
+	if msg := m.Refresh()().(tui.TasksLoadedMsg); len(msg.Tasks) != 1 {
+		t.Errorf("without completed tasks shown got %d tasks, want 1", len(msg.Tasks))
+	}
+
+	m = m.SetShowCompleted(true)
+	msg := m.Refresh()().(tui.TasksLoadedMsg)
+	if len(msg.Tasks) != 2 || msg.Tasks[1].ID != "2" {
+		t.Errorf("with completed tasks shown got %+v, want the open task and the completed inbox task", msg.Tasks)
+	}
+	if want := tui.CompletedSince(time.Now()); !svc.CompletedSince.Equal(want) {
+		t.Errorf("CompletedSince = %v, want %v", svc.CompletedSince, want)
+	}
+}
@@ -2,13 +2,17 @@
 package projects
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/projectlist"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/tasklist"
@@ -32,10 +36,12 @@ type Model struct {
 	keys           tui.KeyMap
 	mode           ViewMode
 	currentProject *domain.Project
+	selectID       string // Task to select once the project's tasks load
 	width          int
 	height         int
 	err            error
 	loaded         bool
+	showCompleted  bool // List recently completed tasks of the project
 }
 
 // New creates a new projects view
@@ -58,20 +64,31 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) loadProjects() tea.Cmd {
 	return func() tea.Msg {
-		projects, err := m.service.GetProjects("")
+		projects, err := m.service.GetProjects(context.Background(), "")
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
-		return tui.ProjectsLoadedMsg{Projects: projects}
+		// Without folders the projects are still listed, just not nested
+		folders, _ := m.service.GetFolders(context.Background())
+		return tui.ProjectsLoadedMsg{Projects: projects, Folders: folders}
 	}
 }
 
 func (m Model) loadProjectTasks(projectID string) tea.Cmd {
 	return func() tea.Msg {
-		tasks, err := m.service.GetTasksByProject(projectID)
+		tasks, err := m.service.GetTaskHierarchy(context.Background(), projectID)
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
+		if m.showCompleted {
+			completed, err := m.service.GetCompletedTasks(context.Background(), tui.CompletedSince(time.Now()))
+			if err != nil {
+				return tui.ErrorMsg{Err: err}
+			}
+			tasks = tui.WithCompleted(tasks, completed, func(task domain.Task) bool {
+				return task.ProjectID == projectID
+			})
+		}
 		return tui.TasksLoadedMsg{Tasks: tasks}
 	}
 }
@@ -80,14 +97,30 @@ func (m Model) loadProjectTasks(projectID string) tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tui.ProjectsLoadedMsg:
-		m.projectList = m.projectList.SetProjects(msg.Projects)
+		m.projectList = m.projectList.SetFolders(msg.Folders).SetProjects(msg.Projects)
 		m.loaded = true
 		m.err = nil
 		return m, nil
 
 	case tui.TasksLoadedMsg:
-		m.taskList = m.taskList.SetTasks(msg.Tasks)
-		return m, nil
+		var cmd tea.Cmd
+		m.taskList, cmd = m.taskList.UpdateTasks(msg.Tasks)
+		if m.selectID != "" {
+			m.taskList, _ = m.taskList.SelectTask(m.selectID)
+			m.selectID = ""
+		}
+		return m, cmd
+
+	case tui.TaskReorderedMsg:
+		// Reload the project so the list shows the order OmniFocus kept
+		if msg.Err == nil || m.currentProject == nil {
+			return m, nil
+		}
+		err := fmt.Errorf("failed to reorder task: %w", msg.Err)
+		return m, tea.Batch(
+			m.loadProjectTasks(m.currentProject.ID),
+			func() tea.Msg { return tui.ErrorMsg{Err: err} },
+		)
 
 	case tui.ErrorMsg:
 		m.err = msg.Err
@@ -116,6 +149,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	default:
 		return m.delegateToCurrentList(msg)
 	}
@@ -124,14 +160,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 	// Handle drill-down with Enter
 	if key.Matches(msg, enterKey) {
-		if m.mode == ModeProjectList {
-			project := m.projectList.SelectedProject()
-			if project != nil {
-				m.mode = ModeProjectTasks
-				m.currentProject = project
-				return m, m.loadProjectTasks(project.ID)
-			}
-		}
+		return m.openSelectedProject()
+	}
+
+	// Escape clears bulk-action marks before navigating back
+	if key.Matches(msg, escapeKey) && len(m.taskList.MarkedTasks()) > 0 {
+		m.taskList = m.taskList.ClearMarks()
 		return m, nil
 	}
 
@@ -145,10 +179,99 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Reorder tasks within the project
+	if m.mode == ModeProjectTasks {
+		if key.Matches(msg, moveUpKey) {
+			return m.moveSelectedTask(-1)
+		}
+		if key.Matches(msg, moveDownKey) {
+			return m.moveSelectedTask(1)
+		}
+		if key.Matches(msg, nextOnlyKey) {
+			m.taskList = m.taskList.ToggleNextOnly()
+			return m, nil
+		}
+	}
+
 	// Delegate to current list
 	return m.delegateToCurrentList(msg)
 }
 
+// moveSelectedTask moves the selected task past its previous (direction -1)
+// or next (direction 1) sibling right away and saves the order in OmniFocus
+func (m Model) moveSelectedTask(direction int) (Model, tea.Cmd) {
+	task := m.taskList.SelectedTask()
+	if task == nil {
+		return m, nil
+	}
+	id := task.ID
+
+	var pos domain.TaskPosition
+	var ok bool
+	m.taskList, pos, ok = m.taskList.MoveSelected(direction)
+	if !ok {
+		return m, nil
+	}
+
+	svc := m.service
+	return m, func() tea.Msg {
+		_, err := svc.ReorderTask(context.Background(), id, pos)
+		return tui.TaskReorderedMsg{TaskID: id, Err: err}
+	}
+}
+
+// openSelectedProject drills down into the tasks of the selected project, or
+// expands or collapses the selected folder
+func (m Model) openSelectedProject() (Model, tea.Cmd) {
+	if m.mode != ModeProjectList {
+		return m, nil
+	}
+	if m.projectList.SelectedFolder() != nil {
+		m.projectList = m.projectList.ToggleFolder()
+		return m, nil
+	}
+	project := m.projectList.SelectedProject()
+	if project == nil {
+		return m, nil
+	}
+	m.mode = ModeProjectTasks
+	m.currentProject = project
+	m.taskList = m.taskList.SetSequential(project.IsSequential()).SetLoading(true)
+	return m, m.loadProjectTasks(project.ID)
+}
+
+// OpenProject drills down into the tasks of the project with the given ID,
+// selecting the task with taskID once they load
+func (m Model) OpenProject(projectID, projectName, taskID string) (Model, tea.Cmd) {
+	project := &domain.Project{ID: projectID, Name: projectName}
+	for _, p := range m.projectList.Projects() {
+		if p.ID == projectID {
+			project = &p
+			break
+		}
+	}
+	m.mode = ModeProjectTasks
+	m.currentProject = project
+	m.selectID = taskID
+	m.taskList = m.taskList.SetSequential(project.IsSequential()).SetLoading(true)
+	return m, m.loadProjectTasks(projectID)
+}
+
+// handleMouse routes mouse events to the list below the header; clicking the
+// selected project opens it
+func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if m.err != nil {
+		return m, nil
+	}
+	msg = tui.ShiftMouse(msg, lipgloss.Height(m.renderHeader()))
+	if m.mode == ModeProjectList && tui.IsClick(msg) {
+		if row, ok := m.projectList.RowAt(msg.Y); ok && row == m.projectList.SelectedIndex() {
+			return m.openSelectedProject()
+		}
+	}
+	return m.delegateToCurrentList(msg)
+}
+
 func (m Model) delegateToCurrentList(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 	if m.mode == ModeProjectList {
@@ -192,8 +315,14 @@ func (m Model) renderHeader() string {
 
 	// Add back hint when in drill-down mode
 	if m.mode == ModeProjectTasks {
+		if m.currentProject != nil && m.currentProject.Type != "" {
+			styled += m.styles.UI.Help.Render("  " + m.currentProject.Type)
+		}
 		hint := m.styles.UI.Help.Render("  [h/Esc] back")
-		styled += hint
+		if m.taskList.NextOnly() {
+			hint += m.styles.UI.StatusFilter.Render("  next actions only [A]")
+		}
+		styled += hint + tui.SortHint(m.styles, m.taskList.Sort())
 	}
 
 	return styled
@@ -220,6 +349,70 @@ func (m Model) SelectedTask() *domain.Task {
 	return nil
 }
 
+// SelectedProject returns the selected project (when in project list mode)
+func (m Model) SelectedProject() *domain.Project {
+	if m.mode == ModeProjectList {
+		return m.projectList.SelectedProject()
+	}
+	return nil
+}
+
+// MarkedTasks returns the tasks marked for bulk actions (when in task mode)
+func (m Model) MarkedTasks() []domain.Task {
+	if m.mode == ModeProjectTasks {
+		return m.taskList.MarkedTasks()
+	}
+	return nil
+}
+
+// ClearMarks removes all bulk-action marks
+func (m Model) ClearMarks() Model {
+	m.taskList = m.taskList.ClearMarks()
+	return m
+}
+
+// SetPinned sets the tasks listed first
+func (m Model) SetPinned(pinned map[string]bool) Model {
+	m.taskList = m.taskList.SetPinned(pinned)
+	return m
+}
+
+// SetSort sets the order project tasks are listed in
+func (m Model) SetSort(mode tui.SortMode) Model {
+	m.taskList = m.taskList.SetSort(mode)
+	return m
+}
+
+// SetLimits sets the WIP limits shown next to project task counts
+func (m Model) SetLimits(limitList []limits.Limit) Model {
+	m.projectList = m.projectList.SetLimits(limitList)
+	return m
+}
+
+// SetShowCompleted sets whether recently completed tasks are listed, from the next load
+func (m Model) SetShowCompleted(show bool) Model {
+	m.showCompleted = show
+	return m
+}
+
+// SetConflicts sets the tasks shown with a conflict marker
+func (m Model) SetConflicts(conflicts map[string]bool) Model {
+	m.taskList = m.taskList.SetConflicts(conflicts)
+	return m
+}
+
+// SetShowPath sets whether task rows show the folders and project containing each task
+func (m Model) SetShowPath(show bool) Model {
+	m.taskList = m.taskList.SetShowPath(show)
+	return m
+}
+
+// PatchTask applies patch to a task in place
+func (m Model) PatchTask(id string, patch func(*domain.Task)) Model {
+	m.taskList = m.taskList.PatchTask(id, patch)
+	return m
+}
+
 // Refresh reloads projects
 func (m Model) Refresh() tea.Cmd {
 	if m.mode == ModeProjectTasks && m.currentProject != nil {
@@ -237,4 +430,11 @@ var (
 	enterKey  = key.NewBinding(key.WithKeys("enter"))
 	backKey   = key.NewBinding(key.WithKeys("h", "left"))
 	escapeKey = key.NewBinding(key.WithKeys("esc", "escape"))
+
+	// Move the selected task up or down among its siblings
+	moveUpKey   = key.NewBinding(key.WithKeys("K", "ctrl+k"))
+	moveDownKey = key.NewBinding(key.WithKeys("J", "ctrl+j"))
+
+	// Show only the next action, or the available tasks of a parallel project
+	nextOnlyKey = key.NewBinding(key.WithKeys("A"))
 )
@@ -1,49 +1,141 @@
 package projects
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
+	"github.com/pwojciechowski/lazyfocus/internal/tui/components/tasklist"
 )
 
 // MockService for testing
 type MockService struct {
-	projects []domain.Project
-	tasks    []domain.Task
+	projects   []domain.Project
+	folders    []domain.Folder
+	tasks      []domain.Task
+	reorderErr error
+	reordered  []string // "<id> before|after <sibling>" for each ReorderTask call
 }
 
-func (m *MockService) GetProjects(_ string) ([]domain.Project, error) {
+func (m *MockService) GetProjects(ctx context.Context, _ string) ([]domain.Project, error) {
 	return m.projects, nil
 }
 
-func (m *MockService) GetTasksByProject(_ string) ([]domain.Task, error) {
+func (m *MockService) GetTasksByProject(ctx context.Context, _ string) ([]domain.Task, error) {
 	return m.tasks, nil
 }
 
 // Implement other interface methods as stubs...
-func (m *MockService) GetInboxTasks() ([]domain.Task, error)                    { return nil, nil }
-func (m *MockService) GetAllTasks(_ service.TaskFilters) ([]domain.Task, error) { return nil, nil }
-func (m *MockService) GetTasksByTag(_ string) ([]domain.Task, error)            { return nil, nil }
-func (m *MockService) GetFlaggedTasks() ([]domain.Task, error)                  { return nil, nil }
-func (m *MockService) GetTaskByID(_ string) (*domain.Task, error)               { return nil, nil }
-func (m *MockService) CreateTask(_ domain.TaskInput) (*domain.Task, error)      { return nil, nil }
-func (m *MockService) ModifyTask(_ string, _ domain.TaskModification) (*domain.Task, error) {
+func (m *MockService) GetInboxTasks(ctx context.Context) ([]domain.Task, error) { return nil, nil }
+func (m *MockService) GetAllTasks(ctx context.Context, _ service.TaskFilters) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetTasksByTag(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetFlaggedTasks(ctx context.Context) ([]domain.Task, error) { return nil, nil }
+func (m *MockService) GetTaskByID(ctx context.Context, _ string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) CreateTask(ctx context.Context, _ domain.TaskInput) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) ModifyTask(ctx context.Context, _ string, _ domain.TaskModification) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) StreamAllTasks(ctx context.Context, _ service.TaskFilters, _ func(domain.Task) error) error {
+	return nil
+}
+func (m *MockService) CompleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+func (m *MockService) DeleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjectByID(ctx context.Context, _ string) (*domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjectWithTasks(ctx context.Context, _ string) (*domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetAttachments(ctx context.Context, _ string, _ service.AttachmentOptions) ([]domain.Attachment, error) {
+	return nil, nil
+}
+func (m *MockService) GetFolders(ctx context.Context) ([]domain.Folder, error)       { return m.folders, nil }
+func (m *MockService) GetTags(ctx context.Context) ([]domain.Tag, error)             { return nil, nil }
+func (m *MockService) GetTagByID(ctx context.Context, _ string) (*domain.Tag, error) { return nil, nil }
+func (m *MockService) GetTagCounts(ctx context.Context) (map[string]int, error)      { return nil, nil }
+func (m *MockService) SearchTasks(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetPerspectiveTasks(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) ResolveProjectName(ctx context.Context, _ string) (string, error) {
+	return "", nil
+}
+func (m *MockService) BatchModify(ctx context.Context, _ []string, _ domain.BatchOperation) (*domain.BatchResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetCompletedTasks(ctx context.Context, _ time.Time) ([]domain.Task, error) {
+	return nil, nil
+}
+
+func (m *MockService) UncompleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) CreateProject(ctx context.Context, _ domain.ProjectInput) (*domain.Project, error) {
+	return nil, nil
+}
+
+func (m *MockService) DropTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) DropProject(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetTaskHierarchy(ctx context.Context, _ string) ([]domain.Task, error) {
+	return m.tasks, nil
+}
+
+func (m *MockService) CreateTag(ctx context.Context, _, _ string) (*domain.Tag, error) {
+	return nil, nil
+}
+
+func (m *MockService) RenameTag(ctx context.Context, _, _ string) (*domain.Tag, error) {
+	return nil, nil
+}
+
+func (m *MockService) ReorderTask(ctx context.Context, id string, pos domain.TaskPosition) (*domain.OperationResult, error) {
+	where := "before"
+	if pos.After {
+		where = "after"
+	}
+	m.reordered = append(m.reordered, id+" "+where+" "+pos.SiblingID)
+	if m.reorderErr != nil {
+		return nil, m.reorderErr
+	}
+	return &domain.OperationResult{Success: true, ID: id}, nil
+}
+
+func (m *MockService) DeleteTag(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetPerspectiveRules(ctx context.Context, _ string) (*domain.PerspectiveRules, error) {
 	return nil, nil
 }
-func (m *MockService) CompleteTask(_ string) (*domain.OperationResult, error) { return nil, nil }
-func (m *MockService) DeleteTask(_ string) (*domain.OperationResult, error)   { return nil, nil }
-func (m *MockService) GetProjectByID(_ string) (*domain.Project, error)       { return nil, nil }
-func (m *MockService) GetProjectWithTasks(_ string) (*domain.Project, error)  { return nil, nil }
-func (m *MockService) GetTags() ([]domain.Tag, error)                         { return nil, nil }
-func (m *MockService) GetTagByID(_ string) (*domain.Tag, error)               { return nil, nil }
-func (m *MockService) GetTagCounts() (map[string]int, error)                  { return nil, nil }
-func (m *MockService) GetPerspectiveTasks(_ string) ([]domain.Task, error)    { return nil, nil }
-func (m *MockService) ResolveProjectName(_ string) (string, error)            { return "", nil }
 
 func TestNew(t *testing.T) {
 	styles := tui.DefaultStyles()
@@ -103,6 +195,68 @@ func TestEnterKey_DrillsDown(t *testing.T) {
 	}
 }
 
+func TestSequentialProject_NextActionsOnly(t *testing.T) {
+	svc := &MockService{
+		projects: []domain.Project{{ID: "p1", Name: "Kitchen", Type: domain.ProjectSequential}},
+		tasks:    []domain.Task{{ID: "t1", Name: "Measure"}, {ID: "t2", Name: "Order tiles", Blocked: true}},
+	}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+	m, _ = m.Update(tui.ProjectsLoadedMsg{Projects: svc.projects})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(cmd())
+
+	view := m.View()
+	if !strings.Contains(view, tasklist.NextActionIcon+" Measure") || !strings.Contains(view, "sequential") {
+		t.Fatalf("View() should mark the next action of a sequential project, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	view = m.View()
+	if strings.Contains(view, "Order tiles") || !strings.Contains(view, "next actions only") {
+		t.Errorf("A should show only the next action, got:\n%s", view)
+	}
+}
+
+func TestEnterKey_TogglesFolder(t *testing.T) {
+	svc := &MockService{
+		projects: []domain.Project{{ID: "p1", Name: "Website", TaskCount: 4}},
+		folders:  []domain.Folder{{ID: "f1", Name: "Work", ProjectIDs: []string{"p1"}}},
+	}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+	m, _ = m.Update(m.Init()())
+
+	if !strings.Contains(m.View(), "Website") {
+		t.Fatalf("View() should list the project under its folder, got:\n%s", m.View())
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Mode() != ModeProjectList || cmd != nil {
+		t.Error("Enter on a folder should not open a project")
+	}
+	if strings.Contains(m.View(), "Website") {
+		t.Errorf("Enter should collapse the folder, got:\n%s", m.View())
+	}
+}
+
+func TestOpenProject_SelectsTask(t *testing.T) {
+	svc := &MockService{
+		projects: []domain.Project{{ID: "p1", Name: "Project 1", Status: "active"}, {ID: "p2", Name: "Project 2"}},
+		tasks:    []domain.Task{{ID: "t1", Name: "Task 1"}, {ID: "t2", Name: "Task 2"}},
+	}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+	m, _ = m.Update(tui.ProjectsLoadedMsg{Projects: svc.projects})
+
+	m, cmd := m.OpenProject("p1", "Project 1", "t2")
+	m, _ = m.Update(cmd())
+
+	if m.Mode() != ModeProjectTasks || m.currentProject == nil || m.currentProject.Status != "active" {
+		t.Errorf("OpenProject() mode = %v, project = %+v, want the loaded p1", m.Mode(), m.currentProject)
+	}
+	if task := m.SelectedTask(); task == nil || task.ID != "t2" {
+		t.Errorf("SelectedTask() = %+v, want t2", task)
+	}
+}
+
 func TestBackKey_ReturnsToList(t *testing.T) {
 	styles := tui.DefaultStyles()
 	keys := tui.DefaultKeyMap()
@@ -766,6 +920,73 @@ func TestRefresh_ProjectListMode(t *testing.T) {
 	}
 }
 
+func TestMoveTask_SavesNewPosition(t *testing.T) {
+	svc := &MockService{
+		projects: []domain.Project{{ID: "p1", Name: "Project 1"}},
+		tasks:    []domain.Task{{ID: "t1", Name: "Task 1"}, {ID: "t2", Name: "Task 2"}},
+	}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+	m, _ = m.Update(tui.ProjectsLoadedMsg{Projects: svc.projects})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(tui.TasksLoadedMsg{Tasks: svc.tasks})
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")})
+	if cmd == nil {
+		t.Fatal("J should save the new position")
+	}
+	if task := m.taskList.SelectedTask(); task == nil || task.ID != "t1" || m.taskList.SelectedIndex() != 1 {
+		t.Error("the moved task should stay selected in its new place")
+	}
+	if msg, ok := cmd().(tui.TaskReorderedMsg); !ok || msg.TaskID != "t1" || msg.Err != nil {
+		t.Errorf("cmd() = %v, want TaskReorderedMsg for t1", msg)
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	if cmd == nil {
+		t.Fatal("ctrl+k should save the new position")
+	}
+	cmd()
+
+	want := []string{"t1 after t2", "t1 before t2"}
+	if strings.Join(svc.reordered, ", ") != strings.Join(want, ", ") {
+		t.Errorf("reordered = %v, want %v", svc.reordered, want)
+	}
+
+	// The last task has nowhere further down to go
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")}); cmd != nil {
+		t.Error("K on the first task should do nothing")
+	}
+}
+
+func TestMoveTask_FailureReloadsTasks(t *testing.T) {
+	svc := &MockService{
+		projects: []domain.Project{{ID: "p1", Name: "Project 1"}},
+		tasks:    []domain.Task{{ID: "t1", Name: "Task 1"}, {ID: "t2", Name: "Task 2"}},
+	}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+	m, _ = m.Update(tui.ProjectsLoadedMsg{Projects: svc.projects})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	_, cmd := m.Update(tui.TaskReorderedMsg{TaskID: "t1", Err: fmt.Errorf("boom")})
+	if cmd == nil {
+		t.Fatal("a failed reorder should reload the project")
+	}
+	msgs, ok := cmd().(tea.BatchMsg)
+	if !ok || len(msgs) != 2 {
+		t.Fatalf("cmd() = %T, want a batch of reload and error", cmd())
+	}
+	if _, ok := msgs[0]().(tui.TasksLoadedMsg); !ok {
+		t.Error("first command should reload the project tasks")
+	}
+	if _, ok := msgs[1]().(tui.ErrorMsg); !ok {
+		t.Error("second command should report the error")
+	}
+
+	if _, cmd = m.Update(tui.TaskReorderedMsg{TaskID: "t1"}); cmd != nil {
+		t.Error("a saved reorder needs no follow-up")
+	}
+}
+
 func TestRefresh_TaskListMode(t *testing.T) {
 	styles := tui.DefaultStyles()
 	keys := tui.DefaultKeyMap()
@@ -883,3 +1104,28 @@ func TestWindowSizeMsg_NegativeHeight(t *testing.T) {
 		t.Errorf("height = %d, want 1", m.height)
 	}
 }
+
+func TestMouse_ClickSelectsThenOpensProject(t *testing.T) {
+	svc := &MockService{
+		projects: []domain.Project{{ID: "p1", Name: "Project 1"}, {ID: "p2", Name: "Project 2"}},
+	}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+	m, _ = m.Update(tui.ProjectsLoadedMsg{Projects: svc.projects})
+
+	click := tea.MouseMsg{X: 5, Y: lipgloss.Height(m.renderHeader()) + 1, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+	m, cmd := m.Update(click)
+	if m.Mode() != ModeProjectList || cmd != nil {
+		t.Fatal("the first click should only select the project")
+	}
+	if project := m.projectList.SelectedProject(); project == nil || project.ID != "p2" {
+		t.Fatalf("SelectedProject() = %v, want p2", project)
+	}
+
+	m, cmd = m.Update(click)
+	if m.Mode() != ModeProjectTasks || m.currentProject == nil || m.currentProject.ID != "p2" {
+		t.Error("clicking the selected project should open it")
+	}
+	if cmd == nil {
+		t.Error("opening a project should load its tasks")
+	}
+}
@@ -2,10 +2,12 @@
 package review
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
@@ -26,6 +28,7 @@ type Model struct {
 	loaded    bool
 	taskCount int
 	allTasks  []domain.Task // Store all tasks for filtering
+	index     *filter.Index // Lowercased task text for searching
 }
 
 // New creates a new review view
@@ -47,7 +50,7 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) loadFlaggedTasks() tea.Cmd {
 	return func() tea.Msg {
-		tasks, err := m.service.GetFlaggedTasks()
+		tasks, err := m.service.GetFlaggedTasks(context.Background())
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
@@ -61,12 +64,23 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case tui.TasksLoadedMsg:
 		// Store all tasks and apply filter
 		m.allTasks = msg.Tasks
+		m.index = filter.NewIndex(msg.Tasks)
 		filteredTasks := m.applyFilter(msg.Tasks)
-		m.taskList = m.taskList.SetTasks(filteredTasks)
+		var cmd tea.Cmd
+		m.taskList, cmd = m.taskList.UpdateTasks(filteredTasks)
 		m.taskCount = len(filteredTasks)
 		m.loaded = true
 		m.err = nil
-		return m, nil
+		return m, cmd
+
+	case tea.MouseMsg:
+		// Task rows start below the header
+		if m.err != nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.taskList, cmd = m.taskList.Update(tui.ShiftMouse(msg, lipgloss.Height(m.renderHeader())))
+		return m, cmd
 
 	case tui.ErrorMsg:
 		m.err = msg.Err
@@ -111,7 +125,7 @@ func (m Model) View() string {
 
 func (m Model) renderHeader() string {
 	headerText := fmt.Sprintf("REVIEW - Flagged Tasks (%d)", m.taskCount)
-	styled := m.styles.UI.Header.Render(headerText)
+	styled := m.styles.UI.Header.Render(headerText) + tui.SortHint(m.styles, m.taskList.Sort())
 
 	// Add subtext
 	subtext := m.styles.UI.Help.Render("Review flagged tasks: [c]omplete, [d]elete, [f]unflag")
@@ -137,11 +151,54 @@ func (m Model) SelectedTask() *domain.Task {
 	return m.taskList.SelectedTask()
 }
 
+// MarkedTasks returns the tasks marked for bulk actions
+func (m Model) MarkedTasks() []domain.Task {
+	return m.taskList.MarkedTasks()
+}
+
+// ClearMarks removes all bulk-action marks
+func (m Model) ClearMarks() Model {
+	m.taskList = m.taskList.ClearMarks()
+	return m
+}
+
 // TaskCount returns the number of flagged tasks
 func (m Model) TaskCount() int {
 	return m.taskCount
 }
 
+// SetPinned sets the tasks listed first
+func (m Model) SetPinned(pinned map[string]bool) Model {
+	m.taskList = m.taskList.SetPinned(pinned)
+	return m
+}
+
+// SetSort sets the order tasks are listed in
+func (m Model) SetSort(mode tui.SortMode) Model {
+	m.taskList = m.taskList.SetSort(mode)
+	return m
+}
+
+// SetConflicts sets the tasks shown with a conflict marker
+func (m Model) SetConflicts(conflicts map[string]bool) Model {
+	m.taskList = m.taskList.SetConflicts(conflicts)
+	return m
+}
+
+// SetShowPath sets whether task rows show the folders and project containing each task
+func (m Model) SetShowPath(show bool) Model {
+	m.taskList = m.taskList.SetShowPath(show)
+	return m
+}
+
+// PatchTask applies patch to a task in place
+func (m Model) PatchTask(id string, patch func(*domain.Task)) Model {
+	// Keep the unfiltered tasks in step so a later filter change shows the patch
+	m.allTasks, _ = tasklist.PatchTree(m.allTasks, id, patch)
+	m.taskList = m.taskList.PatchTask(id, patch)
+	return m
+}
+
 // Refresh reloads flagged tasks
 func (m Model) Refresh() tea.Cmd {
 	return m.loadFlaggedTasks()
@@ -150,6 +207,8 @@ func (m Model) Refresh() tea.Cmd {
 // SetFilter sets the filter state and applies it to tasks
 func (m Model) SetFilter(f filter.State) Model {
 	m.filter = f
+	highlight, _ := f.SearchRegexp() // An invalid pattern matches no task to highlight
+	m.taskList = m.taskList.SetHighlight(highlight)
 	// Re-apply filter to existing tasks
 	filteredTasks := m.applyFilter(m.allTasks)
 	m.taskList = m.taskList.SetTasks(filteredTasks)
@@ -162,6 +221,6 @@ func (m Model) applyFilter(tasks []domain.Task) []domain.Task {
 	if !m.filter.IsActive() {
 		return tasks
 	}
-	matcher := filter.NewMatcher(m.filter)
+	matcher := filter.NewMatcher(m.filter).WithIndex(m.index)
 	return matcher.FilterTasks(tasks)
 }
@@ -1,9 +1,11 @@
 package review
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
@@ -18,7 +20,7 @@ type MockService struct {
 	returnErr error
 }
 
-func (m *MockService) GetFlaggedTasks() ([]domain.Task, error) {
+func (m *MockService) GetFlaggedTasks(ctx context.Context) ([]domain.Task, error) {
 	if m.returnErr != nil {
 		return nil, m.returnErr
 	}
@@ -26,29 +28,106 @@ func (m *MockService) GetFlaggedTasks() ([]domain.Task, error) {
 }
 
 // Stub other methods
-func (m *MockService) GetInboxTasks() ([]domain.Task, error) { return nil, nil }
-func (m *MockService) GetAllTasks(_ service.TaskFilters) ([]domain.Task, error) {
+func (m *MockService) GetInboxTasks(ctx context.Context) ([]domain.Task, error) { return nil, nil }
+func (m *MockService) GetAllTasks(ctx context.Context, _ service.TaskFilters) ([]domain.Task, error) {
 	return nil, nil
 }
-func (m *MockService) GetTasksByProject(_ string) ([]domain.Task, error) { return nil, nil }
-func (m *MockService) GetTasksByTag(_ string) ([]domain.Task, error)     { return nil, nil }
-func (m *MockService) GetTaskByID(_ string) (*domain.Task, error)        { return nil, nil }
-func (m *MockService) CreateTask(_ domain.TaskInput) (*domain.Task, error) {
+func (m *MockService) StreamAllTasks(ctx context.Context, _ service.TaskFilters, _ func(domain.Task) error) error {
+	return nil
+}
+func (m *MockService) GetTasksByProject(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetTasksByTag(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetTaskByID(ctx context.Context, _ string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) CreateTask(ctx context.Context, _ domain.TaskInput) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) ModifyTask(ctx context.Context, _ string, _ domain.TaskModification) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) CompleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+func (m *MockService) DeleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjects(ctx context.Context, _ string) ([]domain.Project, error) {
 	return nil, nil
 }
-func (m *MockService) ModifyTask(_ string, _ domain.TaskModification) (*domain.Task, error) {
+func (m *MockService) GetProjectByID(ctx context.Context, _ string) (*domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjectWithTasks(ctx context.Context, _ string) (*domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetAttachments(ctx context.Context, _ string, _ service.AttachmentOptions) ([]domain.Attachment, error) {
+	return nil, nil
+}
+func (m *MockService) GetFolders(ctx context.Context) ([]domain.Folder, error)       { return nil, nil }
+func (m *MockService) GetTags(ctx context.Context) ([]domain.Tag, error)             { return nil, nil }
+func (m *MockService) GetTagByID(ctx context.Context, _ string) (*domain.Tag, error) { return nil, nil }
+func (m *MockService) GetTagCounts(ctx context.Context) (map[string]int, error)      { return nil, nil }
+func (m *MockService) SearchTasks(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetPerspectiveTasks(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) ResolveProjectName(ctx context.Context, _ string) (string, error) {
+	return "", nil
+}
+func (m *MockService) BatchModify(ctx context.Context, _ []string, _ domain.BatchOperation) (*domain.BatchResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetCompletedTasks(ctx context.Context, _ time.Time) ([]domain.Task, error) {
+	return nil, nil
+}
+
+func (m *MockService) UncompleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) CreateProject(ctx context.Context, _ domain.ProjectInput) (*domain.Project, error) {
+	return nil, nil
+}
+
+func (m *MockService) DropTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) DropProject(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetTaskHierarchy(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+
+func (m *MockService) CreateTag(ctx context.Context, _, _ string) (*domain.Tag, error) {
+	return nil, nil
+}
+
+func (m *MockService) RenameTag(ctx context.Context, _, _ string) (*domain.Tag, error) {
+	return nil, nil
+}
+
+func (m *MockService) ReorderTask(ctx context.Context, _ string, _ domain.TaskPosition) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) DeleteTag(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetPerspectiveRules(ctx context.Context, _ string) (*domain.PerspectiveRules, error) {
 	return nil, nil
 }
-func (m *MockService) CompleteTask(_ string) (*domain.OperationResult, error) { return nil, nil }
-func (m *MockService) DeleteTask(_ string) (*domain.OperationResult, error)   { return nil, nil }
-func (m *MockService) GetProjects(_ string) ([]domain.Project, error)         { return nil, nil }
-func (m *MockService) GetProjectByID(_ string) (*domain.Project, error)       { return nil, nil }
-func (m *MockService) GetProjectWithTasks(_ string) (*domain.Project, error)  { return nil, nil }
-func (m *MockService) GetTags() ([]domain.Tag, error)                         { return nil, nil }
-func (m *MockService) GetTagByID(_ string) (*domain.Tag, error)               { return nil, nil }
-func (m *MockService) GetTagCounts() (map[string]int, error)                  { return nil, nil }
-func (m *MockService) GetPerspectiveTasks(_ string) ([]domain.Task, error)    { return nil, nil }
-func (m *MockService) ResolveProjectName(_ string) (string, error)            { return "", nil }
 
 // Helper to create a test model with default configuration
 func newTestReviewModel() Model {
@@ -0,0 +1,371 @@
+// Package stats provides the statistics view for the TUI.
+package stats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	metrics "github.com/pwojciechowski/lazyfocus/internal/stats"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+// HeatmapCell is the glyph used to draw a single day in the heatmap
+const HeatmapCell = "■"
+
+// sparklineGlyphs draws hourly counts from none (space) to the busiest hour
+var sparklineGlyphs = []rune(" ▁▂▃▄▅▆▇█")
+
+// Time-of-day panel layout
+const (
+	hourlyRows      = 3  // Busiest projects and tags listed in the chart panel
+	hourlyNameWidth = 14 // Width of the row label column
+)
+
+// Open task panel layout
+const (
+	countRows     = 5  // Projects and tags listed with their open task counts
+	countBarWidth = 12 // Width of the bar of the largest count
+)
+
+// openTasksLoadedMsg carries the remaining tasks counted by the summary
+type openTasksLoadedMsg struct {
+	Tasks []domain.Task
+}
+
+// weekdayLabels labels heatmap rows, Monday first; blank rows keep the grid compact
+var weekdayLabels = [7]string{"Mon", "   ", "Wed", "   ", "Fri", "   ", "Sun"}
+
+// Model represents the stats view state
+type Model struct {
+	service      service.OmniFocusService
+	styles       *tui.Styles
+	keys         tui.KeyMap
+	width        int
+	height       int
+	err          error
+	loaded       bool
+	heatmap      metrics.Heatmap
+	hours        metrics.HourlyProfile
+	projectHours []metrics.HourlyProfile
+	tagHours     []metrics.HourlyProfile
+	completed    []domain.Task // Completed tasks the summary counts once open tasks load
+	summary      metrics.Summary
+	summarized   bool
+	now          func() time.Time
+}
+
+// New creates a new stats view
+func New(styles *tui.Styles, keys tui.KeyMap, svc service.OmniFocusService) Model {
+	return Model{
+		service: svc,
+		styles:  styles,
+		keys:    keys,
+		loaded:  false,
+		now:     time.Now,
+	}
+}
+
+// Init initializes the stats view
+func (m Model) Init() tea.Cmd {
+	return m.loadCompletedTasks()
+}
+
+func (m Model) loadCompletedTasks() tea.Cmd {
+	since := metrics.HeatmapStart(m.now(), metrics.HeatmapWeeks)
+	return func() tea.Msg {
+		tasks, err := m.service.GetCompletedTasks(context.Background(), since)
+		if err != nil {
+			return tui.ErrorMsg{Err: err}
+		}
+		return tui.CompletedTasksLoadedMsg{Tasks: tasks}
+	}
+}
+
+func (m Model) loadOpenTasks() tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := m.service.GetAllTasks(context.Background(), service.TaskFilters{})
+		if err != nil {
+			return tui.ErrorMsg{Err: err}
+		}
+		return openTasksLoadedMsg{Tasks: tasks}
+	}
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tui.CompletedTasksLoadedMsg:
+		now := m.now()
+		m.heatmap = metrics.BuildHeatmap(msg.Tasks, now, metrics.HeatmapWeeks)
+		m.hours = metrics.CompletionHours(msg.Tasks, now.Location())
+		m.projectHours = metrics.CompletionHoursByProject(msg.Tasks, now.Location())
+		m.tagHours = metrics.CompletionHoursByTag(msg.Tasks, now.Location())
+		m.completed = msg.Tasks
+		m.loaded = true
+		m.err = nil
+		return m, m.loadOpenTasks()
+
+	case openTasksLoadedMsg:
+		m.summary = metrics.Summarize(m.completed, msg.Tasks, m.now())
+		m.summarized = true
+		return m, nil
+
+	case tui.ErrorMsg:
+		m.err = msg.Err
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// View renders the stats view
+func (m Model) View() string {
+	if m.err != nil {
+		return m.renderError()
+	}
+
+	header := m.styles.UI.Header.Render("STATS")
+	if !m.loaded {
+		return header + "\n" + m.styles.UI.Help.Render("Loading...")
+	}
+
+	return header + "\n" + m.renderSummary() + "\n\n" + m.renderHeatmap() + "\n\n" + m.renderHours()
+}
+
+// renderSummary renders the headline counts, a sparkline of daily
+// completions and the open tasks per project and tag
+func (m Model) renderSummary() string {
+	if !m.summarized {
+		return m.styles.UI.Help.Render("Counting open tasks...")
+	}
+	s := m.summary
+
+	var b strings.Builder
+	label := m.styles.UI.Help.Render
+	b.WriteString(fmt.Sprintf("%s %d   %s %d   %s %d",
+		label("Completed today"), s.CompletedToday,
+		label("This week"), s.CompletedThisWeek,
+		label("Overdue"), s.Overdue))
+	b.WriteString("\n")
+	b.WriteString(label(fmt.Sprintf("Last %d days", metrics.SparklineDays)) + " " + m.sparkline(s.Daily))
+	b.WriteString("\n\n")
+
+	projects := m.renderCounts("Open tasks by project", s.Projects)
+	tags := m.renderCounts("Open tasks by tag", s.Tags)
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, projects, "    ", tags))
+	return b.String()
+}
+
+// renderCounts renders a titled list of the largest counts with bars
+func (m Model) renderCounts(title string, counts []metrics.NamedCount) string {
+	var b strings.Builder
+	b.WriteString(m.styles.Forecast.GroupHeader.Render(title))
+	if len(counts) == 0 {
+		b.WriteString("\n" + m.styles.UI.Help.Render("None"))
+		return b.String()
+	}
+	peak := counts[0].Count
+	for i, c := range counts {
+		if i == countRows {
+			b.WriteString("\n" + m.styles.UI.Help.Render(fmt.Sprintf("+%d more", len(counts)-countRows)))
+			break
+		}
+		bar := strings.Repeat("█", max(1, c.Count*countBarWidth/peak))
+		b.WriteString(fmt.Sprintf("\n%s %3d %s", fitLabel(c.Name), c.Count, bar))
+	}
+	return b.String()
+}
+
+// renderHeatmap renders a contribution-style grid with one column per week
+func (m Model) renderHeatmap() string {
+	var b strings.Builder
+
+	title := fmt.Sprintf("Completed tasks — last %d weeks (%d total)", metrics.HeatmapWeeks, m.heatmap.Total)
+	b.WriteString(m.styles.Forecast.GroupHeader.Render(title))
+	b.WriteString("\n\n")
+
+	b.WriteString("    " + m.renderMonthLabels() + "\n")
+	for day := 0; day < 7; day++ {
+		b.WriteString(weekdayLabels[day] + " ")
+		for _, week := range m.heatmap.Weeks {
+			if day < len(week) {
+				b.WriteString(m.renderCell(week[day].Count))
+			} else {
+				b.WriteString(" ")
+			}
+			b.WriteString(" ")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n    ")
+	b.WriteString(m.styles.UI.Help.Render("Less "))
+	for level := range m.styles.Heatmap.Levels {
+		b.WriteString(m.styles.Heatmap.Levels[level].Render(HeatmapCell) + " ")
+	}
+	b.WriteString(m.styles.UI.Help.Render("More"))
+
+	return b.String()
+}
+
+// renderMonthLabels labels the first week of each month above the grid
+func (m Model) renderMonthLabels() string {
+	labels := make([]rune, len(m.heatmap.Weeks)*2+2)
+	for i := range labels {
+		labels[i] = ' '
+	}
+
+	lastMonth := time.Month(0)
+	for i, week := range m.heatmap.Weeks {
+		if len(week) == 0 || week[0].Date.Month() == lastMonth {
+			continue
+		}
+		lastMonth = week[0].Date.Month()
+		name := week[0].Date.Format("Jan")
+		if i*2+len(name) > len(labels) {
+			continue
+		}
+		copy(labels[i*2:], []rune(name))
+	}
+
+	return strings.TrimRight(string(labels), " ")
+}
+
+// renderCell renders a single day using the theme's intensity bucket for its count
+func (m Model) renderCell(count int) string {
+	levels := m.styles.Heatmap.Levels
+	if len(levels) == 0 {
+		return HeatmapCell
+	}
+	level := m.heatmap.Level(count) * (len(levels) - 1) / (metrics.HeatmapLevels - 1)
+	return levels[level].Render(HeatmapCell)
+}
+
+// renderHours renders a chart panel of completions by hour of day, overall and
+// for the busiest projects and tags, each with a best-time hint
+func (m Model) renderHours() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Forecast.GroupHeader.Render("Time of day"))
+	b.WriteString("\n\n")
+	b.WriteString(strings.Repeat(" ", hourlyNameWidth+1))
+	b.WriteString(m.styles.UI.Help.Render("0     6     12    18"))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderHourlyRow(m.hours))
+	for _, group := range []struct {
+		title    string
+		profiles []metrics.HourlyProfile
+	}{{"Projects", m.projectHours}, {"Tags", m.tagHours}} {
+		if len(group.profiles) == 0 {
+			continue
+		}
+		b.WriteString("\n" + m.styles.UI.Help.Render(group.title) + "\n")
+		for i, profile := range group.profiles {
+			if i == hourlyRows {
+				break
+			}
+			b.WriteString(m.renderHourlyRow(profile))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// fitLabel shortens or pads name to hourlyNameWidth terminal cells, so rows
+// line up even when names hold wide characters such as CJK or emoji
+func fitLabel(name string) string {
+	name = ansi.Truncate(name, hourlyNameWidth, "…")
+	return name + strings.Repeat(" ", max(0, hourlyNameWidth-lipgloss.Width(name)))
+}
+
+// renderHourlyRow renders one labelled sparkline with its best-time hint
+func (m Model) renderHourlyRow(profile metrics.HourlyProfile) string {
+	label := fitLabel(profile.Name)
+
+	hint := "not enough data"
+	if hour, ok := profile.BestHour(); ok {
+		hint = fmt.Sprintf("best %02d:00–%02d:00", hour, (hour+1)%metrics.HoursInDay)
+	}
+
+	return label + " " + m.renderSparkline(profile) + "  " + m.styles.UI.Help.Render(hint) + "\n"
+}
+
+// renderSparkline draws one glyph per hour scaled to the profile's busiest hour
+func (m Model) renderSparkline(profile metrics.HourlyProfile) string {
+	return m.sparkline(profile.Counts[:])
+}
+
+// sparkline draws one glyph per count scaled to the largest count
+func (m Model) sparkline(counts []int) string {
+	top := len(sparklineGlyphs) - 1
+	peak := 0
+	for _, count := range counts {
+		peak = max(peak, count)
+	}
+
+	glyphs := make([]rune, len(counts))
+	for i, count := range counts {
+		level := 0
+		if peak > 0 {
+			level = (count*top + peak - 1) / peak
+		}
+		glyphs[i] = sparklineGlyphs[level]
+	}
+
+	line := string(glyphs)
+	if levels := m.styles.Heatmap.Levels; len(levels) > 0 {
+		return levels[len(levels)-1].Render(line)
+	}
+	return line
+}
+
+func (m Model) renderError() string {
+	header := m.styles.UI.Header.Render("STATS")
+	separatorWidth := m.width
+	if separatorWidth == 0 {
+		separatorWidth = 40
+	}
+	separator := strings.Repeat("─", separatorWidth)
+	errorText := fmt.Sprintf("Error: %v", m.err)
+	errorStyle := m.styles.UI.Help.Foreground(m.styles.Colors.Error)
+	errorStyled := errorStyle.Render(errorText)
+	return header + "\n" + separator + "\n" + errorStyled
+}
+
+// Heatmap returns the completion heatmap currently displayed
+func (m Model) Heatmap() metrics.Heatmap {
+	return m.heatmap
+}
+
+// Summary returns the headline counts currently displayed
+func (m Model) Summary() metrics.Summary {
+	return m.summary
+}
+
+// Hours returns the overall completion-by-hour profile currently displayed
+func (m Model) Hours() metrics.HourlyProfile {
+	return m.hours
+}
+
+// SelectedTask returns nil because the stats view has no task selection
+func (m Model) SelectedTask() *domain.Task {
+	return nil
+}
+
+// Refresh reloads completed-task history
+func (m Model) Refresh() tea.Cmd {
+	return m.loadCompletedTasks()
+}
@@ -0,0 +1,166 @@
+package stats
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
+	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/tui"
+)
+
+func newTestModel(svc service.OmniFocusService, now time.Time) Model {
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+	m.now = func() time.Time { return now }
+	return m
+}
+
+func TestInit_LoadsCompletedTasksSinceHeatmapStart(t *testing.T) {
+	// Wednesday, January 17, 2024
+	now := time.Date(2024, 1, 17, 15, 0, 0, 0, time.Local)
+	completed := now.Add(-time.Hour)
+	svc := &service.MockOmniFocusService{
+		CompletedTasks: []domain.Task{{ID: "t1", Completed: true, CompletedDate: &completed}},
+	}
+	m := newTestModel(svc, now)
+
+	msg := m.Init()()
+
+	loaded, ok := msg.(tui.CompletedTasksLoadedMsg)
+	if !ok {
+		t.Fatalf("Init() returned %T, want tui.CompletedTasksLoadedMsg", msg)
+	}
+	if len(loaded.Tasks) != 1 {
+		t.Errorf("CompletedTasksLoadedMsg has %d tasks, want 1", len(loaded.Tasks))
+	}
+
+	wantSince := time.Date(2023, 10, 30, 0, 0, 0, 0, time.Local)
+	if !svc.CompletedSince.Equal(wantSince) {
+		t.Errorf("GetCompletedTasks() since = %v, want %v", svc.CompletedSince, wantSince)
+	}
+}
+
+func TestUpdate_CompletedTasksLoaded_BuildsHeatmap(t *testing.T) {
+	now := time.Date(2024, 1, 17, 15, 0, 0, 0, time.Local)
+	day := time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local)
+	m := newTestModel(&service.MockOmniFocusService{}, now)
+
+	m, _ = m.Update(tui.CompletedTasksLoadedMsg{Tasks: []domain.Task{
+		{ID: "t1", CompletedDate: &day},
+		{ID: "t2", CompletedDate: &day},
+	}})
+
+	heatmap := m.Heatmap()
+	if len(heatmap.Weeks) != 12 {
+		t.Errorf("heatmap has %d weeks, want 12", len(heatmap.Weeks))
+	}
+	if heatmap.Total != 2 {
+		t.Errorf("heatmap total = %d, want 2", heatmap.Total)
+	}
+
+	view := m.View()
+	for _, want := range []string{"STATS", "last 12 weeks (2 total)", "Mon", "Less", "More", HeatmapCell} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q\nGot: %s", want, view)
+		}
+	}
+}
+
+func TestView_TimeOfDayPanel(t *testing.T) {
+	now := time.Date(2024, 1, 17, 15, 0, 0, 0, time.Local)
+	var tasks []domain.Task
+	for i := 0; i < 6; i++ {
+		done := time.Date(2024, 1, 15, 9, i, 0, 0, time.Local)
+		tasks = append(tasks, domain.Task{ID: "t", ProjectName: "Work", Tags: []string{"email"}, CompletedDate: &done})
+	}
+	late := time.Date(2024, 1, 16, 22, 0, 0, 0, time.Local)
+	tasks = append(tasks, domain.Task{ID: "t7", ProjectName: "Home", CompletedDate: &late})
+	m := newTestModel(&service.MockOmniFocusService{}, now)
+
+	m, _ = m.Update(tui.CompletedTasksLoadedMsg{Tasks: tasks})
+
+	if got := m.Hours().Total; got != 7 {
+		t.Errorf("Hours().Total = %d, want 7", got)
+	}
+	view := m.View()
+	for _, want := range []string{"Time of day", "Projects", "Work", "Home", "Tags", "email", "best 09:00–10:00", "not enough data"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q\nGot: %s", want, view)
+		}
+	}
+}
+
+func TestFitLabel_PadsAndTruncatesByDisplayWidth(t *testing.T) {
+	for _, name := range []string{"Work", "日本語のプロジェクト", "🚀 Launch prep for Q3", "A very long project name"} {
+		label := fitLabel(name)
+		if got := lipgloss.Width(label); got != hourlyNameWidth {
+			t.Errorf("fitLabel(%q) = %q, %d cells wide, want %d", name, label, got, hourlyNameWidth)
+		}
+	}
+	if got := fitLabel("日本語のプロジェクト"); got != "日本語のプロ… " {
+		t.Errorf("fitLabel() = %q, want the wide name shortened with an ellipsis", got)
+	}
+}
+
+func TestUpdate_CompletedTasksLoaded_LoadsOpenTasksForSummary(t *testing.T) {
+	now := time.Date(2024, 1, 17, 15, 0, 0, 0, time.Local)
+	today := time.Date(2024, 1, 17, 9, 0, 0, 0, time.Local)
+	overdue := time.Date(2024, 1, 10, 17, 0, 0, 0, time.Local)
+	svc := &service.MockOmniFocusService{
+		AllTasks: []domain.Task{
+			{ID: "r1", ProjectName: "Work", Tags: []string{"doing"}, DueDate: &overdue},
+			{ID: "r2", ProjectName: "Work"},
+		},
+	}
+	m := newTestModel(svc, now)
+
+	m, cmd := m.Update(tui.CompletedTasksLoadedMsg{Tasks: []domain.Task{{ID: "c1", CompletedDate: &today}}})
+	if cmd == nil {
+		t.Fatal("CompletedTasksLoadedMsg should load the open tasks")
+	}
+	if !strings.Contains(m.View(), "Counting open tasks...") {
+		t.Errorf("View() before the open tasks load should say so\nGot: %s", m.View())
+	}
+	m, _ = m.Update(cmd())
+
+	if s := m.Summary(); s.CompletedToday != 1 || s.Overdue != 1 || len(s.Projects) != 1 {
+		t.Errorf("Summary() = %+v, want 1 completed today, 1 overdue and 1 project", s)
+	}
+	view := m.View()
+	for _, want := range []string{"Completed today", "Overdue", "Last 14 days", "Open tasks by project", "Work", "Open tasks by tag", "doing"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q\nGot: %s", want, view)
+		}
+	}
+}
+
+func TestView_BeforeLoad(t *testing.T) {
+	m := newTestModel(&service.MockOmniFocusService{}, time.Now())
+
+	if !strings.Contains(m.View(), "Loading...") {
+		t.Errorf("View() before load = %q, want loading message", m.View())
+	}
+}
+
+func TestUpdate_ErrorMsg(t *testing.T) {
+	m := newTestModel(&service.MockOmniFocusService{}, time.Now())
+
+	m, _ = m.Update(tui.ErrorMsg{Err: errors.New("OmniFocus is not running")})
+
+	if !strings.Contains(m.View(), "OmniFocus is not running") {
+		t.Errorf("View() = %q, want error message", m.View())
+	}
+}
+
+func TestSelectedTask_IsNil(t *testing.T) {
+	m := newTestModel(&service.MockOmniFocusService{}, time.Now())
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	if m.SelectedTask() != nil {
+		t.Error("SelectedTask() should be nil in stats view")
+	}
+}
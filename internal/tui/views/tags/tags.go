@@ -2,13 +2,18 @@
 package tags
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
 	"github.com/pwojciechowski/lazyfocus/internal/domain"
+	"github.com/pwojciechowski/lazyfocus/internal/limits"
 	"github.com/pwojciechowski/lazyfocus/internal/tui"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/taglist"
 	"github.com/pwojciechowski/lazyfocus/internal/tui/components/tasklist"
@@ -42,10 +47,20 @@ type Model struct {
 	height     int
 	err        error
 	loaded     bool
+
+	showCompleted bool // List recently completed tasks with the tag
+
+	// Inline tag name input; renameID is empty when creating a tag
+	input    textinput.Model
+	editing  bool
+	renameID string
 }
 
 // New creates a new tags view
 func New(styles *tui.Styles, keys tui.KeyMap, svc service.OmniFocusService) Model {
+	input := textinput.New()
+	input.CharLimit = 100
+
 	return Model{
 		tagList:  taglist.New(styles, keys),
 		taskList: tasklist.New(styles, keys),
@@ -54,6 +69,7 @@ func New(styles *tui.Styles, keys tui.KeyMap, svc service.OmniFocusService) Mode
 		keys:     keys,
 		mode:     ModeTagList,
 		loaded:   false,
+		input:    input,
 	}
 }
 
@@ -64,11 +80,11 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) loadTagsAndCounts() tea.Cmd {
 	return func() tea.Msg {
-		tags, err := m.service.GetTags()
+		tags, err := m.service.GetTags(context.Background())
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
-		counts, err := m.service.GetTagCounts()
+		counts, err := m.service.GetTagCounts(context.Background())
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
@@ -76,12 +92,26 @@ func (m Model) loadTagsAndCounts() tea.Cmd {
 	}
 }
 
-func (m Model) loadTagTasks(tagID string) tea.Cmd {
+func (m Model) loadTagTasks(tag domain.Tag) tea.Cmd {
 	return func() tea.Msg {
-		tasks, err := m.service.GetTasksByTag(tagID)
+		tasks, err := m.service.GetTasksByTag(context.Background(), tag.ID)
 		if err != nil {
 			return tui.ErrorMsg{Err: err}
 		}
+		if m.showCompleted {
+			completed, err := m.service.GetCompletedTasks(context.Background(), tui.CompletedSince(time.Now()))
+			if err != nil {
+				return tui.ErrorMsg{Err: err}
+			}
+			tasks = tui.WithCompleted(tasks, completed, func(task domain.Task) bool {
+				for _, name := range task.Tags {
+					if strings.EqualFold(name, tag.Name) {
+						return true
+					}
+				}
+				return false
+			})
+		}
 		return tui.TasksLoadedMsg{Tasks: tasks}
 	}
 }
@@ -100,8 +130,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m, m.loadTagsAndCounts()
 
 	case tui.TasksLoadedMsg:
-		m.taskList = m.taskList.SetTasks(msg.Tasks)
-		return m, nil
+		var cmd tea.Cmd
+		m.taskList, cmd = m.taskList.UpdateTasks(msg.Tasks)
+		return m, cmd
 
 	case tui.ErrorMsg:
 		m.err = msg.Err
@@ -130,22 +161,40 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	default:
 		return m.delegateToCurrentList(msg)
 	}
 }
 
 func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
-	// Handle drill-down with Enter
-	if key.Matches(msg, enterKey) {
-		if m.mode == ModeTagList {
-			tag := m.tagList.SelectedTag()
-			if tag != nil {
-				m.mode = ModeTagTasks
-				m.currentTag = tag
-				return m, m.loadTagTasks(tag.ID)
+	if m.editing {
+		return m.handleEditKey(msg)
+	}
+
+	// Create or rename tags inline in the tag list
+	if m.mode == ModeTagList {
+		if key.Matches(msg, newTagKey) {
+			return m.startEdit("", ""), textinput.Blink
+		}
+		if key.Matches(msg, renameTagKey) {
+			if tag := m.tagList.SelectedTag(); tag != nil {
+				return m.startEdit(tag.ID, tag.Name), textinput.Blink
 			}
+			return m, nil
 		}
+	}
+
+	// Handle drill-down with Enter
+	if key.Matches(msg, enterKey) {
+		return m.openSelectedTag()
+	}
+
+	// Escape clears bulk-action marks before navigating back
+	if key.Matches(msg, escapeKey) && len(m.taskList.MarkedTasks()) > 0 {
+		m.taskList = m.taskList.ClearMarks()
 		return m, nil
 	}
 
@@ -162,6 +211,96 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m.delegateToCurrentList(msg)
 }
 
+// openSelectedTag drills down into the tasks of the selected tag
+func (m Model) openSelectedTag() (Model, tea.Cmd) {
+	if m.mode != ModeTagList {
+		return m, nil
+	}
+	tag := m.tagList.SelectedTag()
+	if tag == nil {
+		return m, nil
+	}
+	m.mode = ModeTagTasks
+	m.currentTag = tag
+	m.taskList = m.taskList.SetLoading(true)
+	return m, m.loadTagTasks(*tag)
+}
+
+// handleMouse routes mouse events to the list below the header; clicking the
+// selected tag opens it. The mouse is ignored while a tag name is edited.
+func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if m.err != nil || m.editing {
+		return m, nil
+	}
+	msg = tui.ShiftMouse(msg, lipgloss.Height(m.renderHeader()))
+	if m.mode == ModeTagList && tui.IsClick(msg) {
+		if row, ok := m.tagList.RowAt(msg.Y); ok && row == m.tagList.SelectedIndex() {
+			return m.openSelectedTag()
+		}
+	}
+	return m.delegateToCurrentList(msg)
+}
+
+// startEdit shows the name input, renaming the tag with renameID or creating a new one
+func (m Model) startEdit(renameID, name string) Model {
+	m.editing = true
+	m.renameID = renameID
+	if renameID == "" {
+		m.input.Prompt = "New tag: "
+	} else {
+		m.input.Prompt = "Rename tag: "
+	}
+	m.input.SetValue(name)
+	m.input.CursorEnd()
+	m.input.Focus()
+	return m
+}
+
+// stopEdit hides the name input
+func (m Model) stopEdit() Model {
+	m.editing = false
+	m.renameID = ""
+	m.input.Blur()
+	return m
+}
+
+func (m Model) handleEditKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, escapeKey):
+		return m.stopEdit(), nil
+	case key.Matches(msg, enterKey):
+		name := strings.TrimSpace(m.input.Value())
+		renameID := m.renameID
+		m = m.stopEdit()
+		if name == "" {
+			return m, nil
+		}
+		return m, m.saveTag(renameID, name)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// saveTag creates a top-level tag, or renames the tag with renameID
+func (m Model) saveTag(renameID, name string) tea.Cmd {
+	return func() tea.Msg {
+		if renameID == "" {
+			tag, err := m.service.CreateTag(context.Background(), name, "")
+			if err != nil {
+				return tui.ErrorMsg{Err: err}
+			}
+			return tui.TagSavedMsg{Tag: *tag, Created: true}
+		}
+		tag, err := m.service.RenameTag(context.Background(), renameID, name)
+		if err != nil {
+			return tui.ErrorMsg{Err: err}
+		}
+		return tui.TagSavedMsg{Tag: *tag}
+	}
+}
+
 func (m Model) delegateToCurrentList(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 	if m.mode == ModeTagList {
@@ -187,6 +326,10 @@ func (m Model) View() string {
 		content = m.taskList.View()
 	}
 
+	if m.editing {
+		return header + "\n" + m.input.View() + "\n" + content
+	}
+
 	return header + "\n" + content
 }
 
@@ -205,7 +348,9 @@ func (m Model) renderHeader() string {
 
 	if m.mode == ModeTagTasks {
 		hint := m.styles.UI.Help.Render("  [h/Esc] back")
-		styled += hint
+		styled += hint + tui.SortHint(m.styles, m.taskList.Sort())
+	} else if m.editing {
+		styled += m.styles.UI.Help.Render("  [Enter] save  [Esc] cancel")
 	}
 
 	return styled
@@ -232,14 +377,75 @@ func (m Model) SelectedTask() *domain.Task {
 	return nil
 }
 
+// MarkedTasks returns the tasks marked for bulk actions (when in task mode)
+func (m Model) MarkedTasks() []domain.Task {
+	if m.mode == ModeTagTasks {
+		return m.taskList.MarkedTasks()
+	}
+	return nil
+}
+
+// ClearMarks removes all bulk-action marks
+func (m Model) ClearMarks() Model {
+	m.taskList = m.taskList.ClearMarks()
+	return m
+}
+
+// SetPinned sets the tasks listed first
+func (m Model) SetPinned(pinned map[string]bool) Model {
+	m.taskList = m.taskList.SetPinned(pinned)
+	return m
+}
+
+// SetSort sets the order the tasks of a tag are listed in
+func (m Model) SetSort(mode tui.SortMode) Model {
+	m.taskList = m.taskList.SetSort(mode)
+	return m
+}
+
+// SetLimits sets the WIP limits shown next to tag task counts
+func (m Model) SetLimits(limitList []limits.Limit) Model {
+	m.tagList = m.tagList.SetLimits(limitList)
+	return m
+}
+
+// SetShowCompleted sets whether recently completed tasks are listed, from the next load
+func (m Model) SetShowCompleted(show bool) Model {
+	m.showCompleted = show
+	return m
+}
+
+// SetConflicts sets the tasks shown with a conflict marker
+func (m Model) SetConflicts(conflicts map[string]bool) Model {
+	m.taskList = m.taskList.SetConflicts(conflicts)
+	return m
+}
+
+// SetShowPath sets whether task rows show the folders and project containing each task
+func (m Model) SetShowPath(show bool) Model {
+	m.taskList = m.taskList.SetShowPath(show)
+	return m
+}
+
+// PatchTask applies patch to a task in place
+func (m Model) PatchTask(id string, patch func(*domain.Task)) Model {
+	m.taskList = m.taskList.PatchTask(id, patch)
+	return m
+}
+
 // Refresh reloads tags
 func (m Model) Refresh() tea.Cmd {
 	if m.mode == ModeTagTasks && m.currentTag != nil {
-		return m.loadTagTasks(m.currentTag.ID)
+		return m.loadTagTasks(*m.currentTag)
 	}
 	return m.loadTagsAndCounts()
 }
 
+// Editing reports whether a tag name is being typed, so keys should not be taken as shortcuts
+func (m Model) Editing() bool {
+	return m.editing
+}
+
 // Mode returns the current view mode
 func (m Model) Mode() ViewMode {
 	return m.mode
@@ -249,4 +455,7 @@ var (
 	enterKey  = key.NewBinding(key.WithKeys("enter"))
 	backKey   = key.NewBinding(key.WithKeys("h", "left"))
 	escapeKey = key.NewBinding(key.WithKeys("esc", "escape"))
+
+	newTagKey    = key.NewBinding(key.WithKeys("n"))
+	renameTagKey = key.NewBinding(key.WithKeys("r"))
 )
@@ -1,8 +1,10 @@
 package tags
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pwojciechowski/lazyfocus/internal/cli/service"
@@ -15,38 +17,123 @@ type MockService struct {
 	tags   []domain.Tag
 	counts map[string]int
 	tasks  []domain.Task
+
+	createdName string
+	renamedID   string
+	renamedName string
 }
 
-func (m *MockService) GetTags() ([]domain.Tag, error) {
+func (m *MockService) GetAttachments(ctx context.Context, _ string, _ service.AttachmentOptions) ([]domain.Attachment, error) {
+	return nil, nil
+}
+func (m *MockService) GetFolders(ctx context.Context) ([]domain.Folder, error) { return nil, nil }
+func (m *MockService) GetTags(ctx context.Context) ([]domain.Tag, error) {
 	return m.tags, nil
 }
 
-func (m *MockService) GetTagCounts() (map[string]int, error) {
+func (m *MockService) GetTagCounts(ctx context.Context) (map[string]int, error) {
 	return m.counts, nil
 }
 
-func (m *MockService) GetTasksByTag(_ string) ([]domain.Task, error) {
+func (m *MockService) GetTasksByTag(ctx context.Context, _ string) ([]domain.Task, error) {
 	return m.tasks, nil
 }
 
 // Stub other interface methods
-func (m *MockService) GetInboxTasks() ([]domain.Task, error)                    { return nil, nil }
-func (m *MockService) GetAllTasks(_ service.TaskFilters) ([]domain.Task, error) { return nil, nil }
-func (m *MockService) GetTasksByProject(_ string) ([]domain.Task, error)        { return nil, nil }
-func (m *MockService) GetFlaggedTasks() ([]domain.Task, error)                  { return nil, nil }
-func (m *MockService) GetTaskByID(_ string) (*domain.Task, error)               { return nil, nil }
-func (m *MockService) CreateTask(_ domain.TaskInput) (*domain.Task, error)      { return nil, nil }
-func (m *MockService) ModifyTask(_ string, _ domain.TaskModification) (*domain.Task, error) {
+func (m *MockService) GetInboxTasks(ctx context.Context) ([]domain.Task, error) { return nil, nil }
+func (m *MockService) GetAllTasks(ctx context.Context, _ service.TaskFilters) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetTasksByProject(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetFlaggedTasks(ctx context.Context) ([]domain.Task, error) { return nil, nil }
+func (m *MockService) GetTaskByID(ctx context.Context, _ string) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) CreateTask(ctx context.Context, _ domain.TaskInput) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) ModifyTask(ctx context.Context, _ string, _ domain.TaskModification) (*domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) StreamAllTasks(ctx context.Context, _ service.TaskFilters, _ func(domain.Task) error) error {
+	return nil
+}
+func (m *MockService) CompleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+func (m *MockService) DeleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjects(ctx context.Context, _ string) ([]domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjectByID(ctx context.Context, _ string) (*domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetProjectWithTasks(ctx context.Context, _ string) (*domain.Project, error) {
+	return nil, nil
+}
+func (m *MockService) GetTagByID(ctx context.Context, _ string) (*domain.Tag, error) { return nil, nil }
+func (m *MockService) SearchTasks(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) GetPerspectiveTasks(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+func (m *MockService) ResolveProjectName(ctx context.Context, _ string) (string, error) {
+	return "", nil
+}
+func (m *MockService) BatchModify(ctx context.Context, _ []string, _ domain.BatchOperation) (*domain.BatchResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetCompletedTasks(ctx context.Context, _ time.Time) ([]domain.Task, error) {
+	return nil, nil
+}
+
+func (m *MockService) UncompleteTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) CreateProject(ctx context.Context, _ domain.ProjectInput) (*domain.Project, error) {
+	return nil, nil
+}
+
+func (m *MockService) DropTask(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) DropProject(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetTaskHierarchy(ctx context.Context, _ string) ([]domain.Task, error) {
+	return nil, nil
+}
+
+func (m *MockService) CreateTag(ctx context.Context, name, _ string) (*domain.Tag, error) {
+	m.createdName = name
+	return &domain.Tag{ID: "new", Name: name}, nil
+}
+
+func (m *MockService) RenameTag(ctx context.Context, id, name string) (*domain.Tag, error) {
+	m.renamedID, m.renamedName = id, name
+	return &domain.Tag{ID: id, Name: name}, nil
+}
+
+func (m *MockService) ReorderTask(ctx context.Context, _ string, _ domain.TaskPosition) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) DeleteTag(ctx context.Context, _ string) (*domain.OperationResult, error) {
+	return nil, nil
+}
+
+func (m *MockService) GetPerspectiveRules(ctx context.Context, _ string) (*domain.PerspectiveRules, error) {
 	return nil, nil
 }
-func (m *MockService) CompleteTask(_ string) (*domain.OperationResult, error) { return nil, nil }
-func (m *MockService) DeleteTask(_ string) (*domain.OperationResult, error)   { return nil, nil }
-func (m *MockService) GetProjects(_ string) ([]domain.Project, error)         { return nil, nil }
-func (m *MockService) GetProjectByID(_ string) (*domain.Project, error)       { return nil, nil }
-func (m *MockService) GetProjectWithTasks(_ string) (*domain.Project, error)  { return nil, nil }
-func (m *MockService) GetTagByID(_ string) (*domain.Tag, error)               { return nil, nil }
-func (m *MockService) GetPerspectiveTasks(_ string) ([]domain.Task, error)    { return nil, nil }
-func (m *MockService) ResolveProjectName(_ string) (string, error)            { return "", nil }
 
 func TestNew(t *testing.T) {
 	styles := tui.DefaultStyles()
@@ -728,3 +815,67 @@ func containsAny(s string, subs ...string) bool {
 	}
 	return false
 }
+
+func typeText(m Model, text string) Model {
+	for _, r := range text {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestNewTagKey_CreatesTag(t *testing.T) {
+	svc := &MockService{}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !m.Editing() {
+		t.Fatal("n should start editing a new tag")
+	}
+	m = typeText(m, "Errands")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.Editing() {
+		t.Error("Enter should stop editing")
+	}
+	if cmd == nil {
+		t.Fatal("Enter should return a save command")
+	}
+	msg, ok := cmd().(tui.TagSavedMsg)
+	if !ok || !msg.Created || svc.createdName != "Errands" {
+		t.Errorf("save = %+v (created %q), want created tag Errands", msg, svc.createdName)
+	}
+}
+
+func TestRenameTagKey_RenamesSelectedTag(t *testing.T) {
+	svc := &MockService{tags: []domain.Tag{{ID: "t1", Name: "Work"}}}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+	m, _ = m.Update(LoadedWithCountsMsg{Tags: svc.tags, Counts: map[string]int{}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if got := m.input.Value(); got != "Work" {
+		t.Errorf("rename input = %q, want %q", got, "Work")
+	}
+	m = typeText(m, "shop")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter should return a save command")
+	}
+	cmd()
+
+	if svc.renamedID != "t1" || svc.renamedName != "Workshop" {
+		t.Errorf("RenameTag(%q, %q), want (t1, Workshop)", svc.renamedID, svc.renamedName)
+	}
+}
+
+func TestTagInput_EscapeCancels(t *testing.T) {
+	svc := &MockService{}
+	m := New(tui.DefaultStyles(), tui.DefaultKeyMap(), svc)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = typeText(m, "Errands")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+
+	if m.Editing() || cmd != nil || svc.createdName != "" {
+		t.Error("Esc should cancel without creating a tag")
+	}
+}